@@ -0,0 +1,124 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/sreday/cfp.ninja/pkg/cfp"
+)
+
+var eventApplyCmd = &cobra.Command{
+	Use:   "apply",
+	Short: "Create or update an event from a YAML or JSON file",
+	Long: `Creates the event in the file if its slug is not taken, or updates it if
+you organize it. Only the fields present in the file are changed, and
+cfp_questions replaces the event's questions rather than adding to them.
+
+Updates print the changes first and are only made with --yes, so the same
+file can be kept in git and applied repeatedly.`,
+	Example: `  # Show what would change
+  cfp event apply -f event.yaml
+
+  # Apply the changes
+  cfp event apply -f event.yaml --yes`,
+	Args: cobra.NoArgs,
+	RunE: runEventApply,
+}
+
+var (
+	applyFile string
+	applyYes  bool
+)
+
+func init() {
+	eventApplyCmd.Flags().StringVarP(&applyFile, "file", "f", "", "Event file (YAML or JSON, in the cfp create format)")
+	eventApplyCmd.Flags().BoolVarP(&applyYes, "yes", "y", false, "Update an existing event without stopping at the diff")
+	_ = eventApplyCmd.MarkFlagRequired("file")
+	eventsCmd.AddCommand(eventApplyCmd)
+}
+
+func runEventApply(cmd *cobra.Command, args []string) error {
+	data, err := os.ReadFile(applyFile)
+	if err != nil {
+		return fmt.Errorf("failed to read file: %w", err)
+	}
+	content := string(data)
+	if err := cfp.ValidateEventTemplate(content); err != nil {
+		return fmt.Errorf("invalid event: %w", err)
+	}
+	event, err := cfp.ParseEventTemplate(content)
+	if err != nil {
+		return fmt.Errorf("invalid event: %w", err)
+	}
+	fields, err := cfp.EventFileFields(content)
+	if err != nil {
+		return fmt.Errorf("invalid event: %w", err)
+	}
+	desired := cfp.EventFieldValues(event, fields)
+
+	client, err := getClient()
+	if err != nil {
+		return err
+	}
+
+	// Events the user organizes are on their dashboard, drafts included
+	dashboard, err := client.GetDashboard(cfp.MyEventsOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to load your events: %w", err)
+	}
+	var eventID uint
+	for _, e := range dashboard.Events {
+		if e.Slug == event.Slug {
+			eventID = e.ID
+		}
+	}
+
+	if eventID == 0 {
+		fmt.Printf("Creating event %s\n", event.Slug)
+		result, err := client.ApplyEvent(event.Slug, desired)
+		if err != nil {
+			return applyError(event.Slug, err)
+		}
+		fmt.Printf("Created %s (ID %d): %s/e/%s\n", result.Name, result.ID, client.BaseURL, result.Slug)
+		return nil
+	}
+
+	current, err := client.GetEventFields(eventID)
+	if err != nil {
+		return fmt.Errorf("failed to load event %s: %w", event.Slug, err)
+	}
+	changes := cfp.DiffEvent(current, desired)
+	if len(changes) == 0 {
+		fmt.Printf("Event %s is up to date.\n", event.Slug)
+		return nil
+	}
+
+	fmt.Printf("Changes to event %s:\n", event.Slug)
+	update := make(map[string]interface{}, len(changes))
+	for _, c := range changes {
+		fmt.Printf("  %s\n", cfp.FormatEventChange(c))
+		update[c.Field] = desired[c.Field]
+	}
+	if !applyYes {
+		return fmt.Errorf("not applied; run again with --yes to update %s", event.Slug)
+	}
+
+	if _, err := client.ApplyEvent(event.Slug, update); err != nil {
+		return applyError(event.Slug, err)
+	}
+	fmt.Printf("Updated %d field(s) of %s.\n", len(changes), event.Slug)
+	return nil
+}
+
+// applyError describes a failed apply, listing the server's validation
+// messages per field
+func applyError(slug string, err error) error {
+	switch {
+	case cfp.IsForbidden(err):
+		return fmt.Errorf("slug %q belongs to an event you don't organize", slug)
+	case cfp.IsPaymentRequired(err):
+		return fmt.Errorf("failed to apply %s: %w\n\nSet cfp_status: draft, then complete the listing payment in the dashboard", slug, err)
+	}
+	return fmt.Errorf("failed to apply %s: %w%s", slug, err, fieldErrorDetails(err))
+}
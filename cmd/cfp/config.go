@@ -15,9 +15,14 @@ var configCmd = &cobra.Command{
 
 Configuration is stored in ~/.config/cfp/config.yaml (or $XDG_CONFIG_HOME/cfp/config.yaml).
 
+Settings are kept per profile, so you can switch between servers without
+logging in again. Use --profile to pick a profile for a single command,
+or 'cfp config use-context' to change the default.
+
 Available configuration keys:
   server         CFP.ninja server URL (default: https://cfp.ninja)
-  auth_provider  OAuth provider for login (github or google, default: github)`,
+  auth_provider  OAuth provider for login (github or google, default: github)
+  output         Default output format (table, json or yaml, default: table)`,
 	Example: `  # List all config values
   cfp config list
 
@@ -28,7 +33,11 @@ Available configuration keys:
   cfp config set server http://localhost:8080
 
   # Set OAuth provider to Google
-  cfp config set auth_provider google`,
+  cfp config set auth_provider google
+
+  # Log in to a second server and make it the default
+  cfp login --profile staging --server https://cfp.staging.example.com
+  cfp config use-context staging`,
 }
 
 var configGetCmd = &cobra.Command{
@@ -71,7 +80,28 @@ var configPathCmd = &cobra.Command{
 	RunE:  runConfigPath,
 }
 
+var configUseContextCmd = &cobra.Command{
+	Use:   "use-context <profile>",
+	Short: "Switch the default profile",
+	Long:  `Make the named profile the default for future commands.`,
+	Example: `  cfp config use-context staging
+  cfp config use-context default`,
+	Args:              cobra.ExactArgs(1),
+	RunE:              runConfigUseContext,
+	ValidArgsFunction: completeProfiles,
+}
+
+var configGetContextsCmd = &cobra.Command{
+	Use:   "get-contexts",
+	Short: "List profiles",
+	Long:  `List all profiles, marking the active one with an asterisk.`,
+	Args:  cobra.NoArgs,
+	RunE:  runConfigGetContexts,
+}
+
 func init() {
+	configCmd.AddCommand(configUseContextCmd)
+	configCmd.AddCommand(configGetContextsCmd)
 	configCmd.AddCommand(configGetCmd)
 	configCmd.AddCommand(configSetCmd)
 	configCmd.AddCommand(configListCmd)
@@ -130,6 +160,7 @@ func runConfigList(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
 
+	fmt.Printf("profile = %s\n", cfg.Profile)
 	for _, key := range cfp.ValidConfigKeys() {
 		value, _ := cfg.GetConfigValue(string(key))
 		fmt.Printf("%s = %s\n", key, value)
@@ -138,6 +169,32 @@ func runConfigList(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func runConfigUseContext(cmd *cobra.Command, args []string) error {
+	if err := cfp.UseProfile(args[0]); err != nil {
+		return err
+	}
+
+	fmt.Printf("Switched to profile %q\n", args[0])
+	return nil
+}
+
+func runConfigGetContexts(cmd *cobra.Command, args []string) error {
+	names, active, err := cfp.ListProfiles()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	for _, name := range names {
+		marker := " "
+		if name == active {
+			marker = "*"
+		}
+		fmt.Printf("%s %s\n", marker, name)
+	}
+
+	return nil
+}
+
 func runConfigPath(cmd *cobra.Command, args []string) error {
 	path, err := cfp.GetConfigPath()
 	if err != nil {
@@ -173,6 +230,27 @@ func completeConfigKeys(cmd *cobra.Command, args []string, toComplete string) ([
 	return completions, cobra.ShellCompDirectiveNoFileComp
 }
 
+// completeProfiles provides tab completion for profile names
+func completeProfiles(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) != 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	names, _, err := cfp.ListProfiles()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	var completions []string
+	for _, name := range names {
+		if strings.HasPrefix(name, toComplete) {
+			completions = append(completions, name)
+		}
+	}
+
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
 // completeConfigKeysAndValues provides tab completion for config set
 func completeConfigKeysAndValues(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 	switch len(args) {
@@ -191,6 +269,14 @@ func completeConfigKeysAndValues(cmd *cobra.Command, args []string, toComplete s
 				}
 			}
 			return completions, cobra.ShellCompDirectiveNoFileComp
+		case cfp.ConfigKeyOutput:
+			var completions []string
+			for _, v := range []string{"table", "json", "yaml"} {
+				if strings.HasPrefix(v, toComplete) {
+					completions = append(completions, v)
+				}
+			}
+			return completions, cobra.ShellCompDirectiveNoFileComp
 		case cfp.ConfigKeyServer:
 			// Suggest common values
 			suggestions := []string{"https://cfp.ninja", "http://localhost:8080"}
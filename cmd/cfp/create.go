@@ -26,6 +26,9 @@ Your default editor is determined by $EDITOR, $VISUAL, or falls back to vim.`,
   # Use an existing file as a starting template (opens in editor)
   cfp create --template event.yaml
 
+  # Prefill the template from the event's website (opens in editor)
+  cfp create --from-url https://gophercon.example.com
+
   # Validate without creating
   cfp create --dry-run`,
 	RunE: runCreate,
@@ -34,12 +37,14 @@ Your default editor is determined by $EDITOR, $VISUAL, or falls back to vim.`,
 var (
 	createFile     string
 	createTemplate string
+	createFromURL  string
 	createDryRun   bool
 )
 
 func init() {
 	createCmd.Flags().StringVarP(&createFile, "file", "f", "", "Read event from YAML file (no editor)")
 	createCmd.Flags().StringVarP(&createTemplate, "template", "t", "", "Use existing file as starting template (opens in editor)")
+	createCmd.Flags().StringVar(&createFromURL, "from-url", "", "Prefill the template from the event's website (opens in editor)")
 	createCmd.Flags().BoolVar(&createDryRun, "dry-run", false, "Validate template without creating")
 }
 
@@ -53,6 +58,9 @@ func runCreate(cmd *cobra.Command, args []string) error {
 
 	// Validation function for the editor loop
 	validateEvent := func(c string) error {
+		if err := cfp.ValidateEventTemplate(c); err != nil {
+			return err
+		}
 		e, err := cfp.ParseEventTemplate(c)
 		if err != nil {
 			return err
@@ -82,9 +90,16 @@ func runCreate(cmd *cobra.Command, args []string) error {
 				return fmt.Errorf("failed to read template file: %w", err)
 			}
 			template = string(data)
+		} else if createFromURL != "" {
+			// Prefill from the event's website
+			draft, err := client.ScrapeEventPreview(createFromURL)
+			if err != nil {
+				return fmt.Errorf("failed to read event from %s: %w", createFromURL, err)
+			}
+			template = cfp.GenerateEventTemplate(draft)
 		} else {
 			// Generate blank template
-			template = cfp.GenerateEventTemplate()
+			template = cfp.GenerateEventTemplate(nil)
 		}
 
 		// Open in editor with validation loop
@@ -139,7 +154,13 @@ func runCreate(cmd *cobra.Command, args []string) error {
 	// Create the event
 	result, err := client.CreateEvent(event)
 	if err != nil {
-		return fmt.Errorf("failed to create event: %w", err)
+		switch {
+		case cfp.IsPaymentRequired(err):
+			return fmt.Errorf("failed to create event: %w\n\nCreate the event with cfp_status: draft, then complete the listing payment at:\n  %s/dashboard/events", err, client.BaseURL)
+		case cfp.IsConflict(err):
+			return fmt.Errorf("failed to create event: slug %q is already taken; choose a different slug", event.Slug)
+		}
+		return fmt.Errorf("failed to create event: %w%s", err, fieldErrorDetails(err))
 	}
 
 	fmt.Printf("\nSuccess! Event created.\n")
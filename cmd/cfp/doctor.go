@@ -0,0 +1,215 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/sreday/cfp.ninja/pkg/cfp"
+)
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Diagnose common CLI problems",
+	Long: `Runs a series of checks on your CLI setup: config file, server
+reachability, login token, clock skew and editor.
+
+Each check prints PASS, WARN or FAIL. The command exits non-zero if any
+check fails. Use -o json to attach the report to a bug report; the token
+itself is never included.`,
+	Example: `  cfp doctor
+  cfp doctor -o json`,
+	Args: cobra.NoArgs,
+	RunE: runDoctor,
+}
+
+const (
+	checkPass = "pass"
+	checkWarn = "warn"
+	checkFail = "fail"
+
+	// Clock skew thresholds: JWT iat/nbf/exp checks start failing at large skews
+	clockSkewWarn = 30 * time.Second
+	clockSkewFail = 5 * time.Minute
+)
+
+// doctorCheck is the result of a single diagnostic check
+type doctorCheck struct {
+	Name   string `json:"name" yaml:"name"`
+	Status string `json:"status" yaml:"status"`
+	Detail string `json:"detail" yaml:"detail"`
+}
+
+// doctorReport is the full diagnostic report
+type doctorReport struct {
+	Version string        `json:"version" yaml:"version"`
+	Profile string        `json:"profile" yaml:"profile"`
+	Server  string        `json:"server" yaml:"server"`
+	Token   string        `json:"token" yaml:"token"`
+	Checks  []doctorCheck `json:"checks" yaml:"checks"`
+}
+
+func (r *doctorReport) add(name, status, format string, args ...interface{}) {
+	r.Checks = append(r.Checks, doctorCheck{Name: name, Status: status, Detail: fmt.Sprintf(format, args...)})
+}
+
+func runDoctor(cmd *cobra.Command, args []string) error {
+	formatter, err := getFormatter()
+	if err != nil {
+		return err
+	}
+
+	report := &doctorReport{Version: Version, Token: "none"}
+
+	cfg := checkConfigFile(report)
+	if serverURL != "" {
+		cfg.Server = serverURL
+	}
+	report.Profile = cfg.Profile
+	report.Server = cfg.Server
+
+	client := newClient(cfg)
+	if checkServer(report, client) {
+		checkToken(report, client)
+	}
+	checkEditor(report)
+
+	if err := printDoctorReport(formatter, report); err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, c := range report.Checks {
+		if c.Status == checkFail {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d check(s) failed", failed)
+	}
+	return nil
+}
+
+// checkConfigFile verifies the config file is readable and well-formed
+func checkConfigFile(report *doctorReport) *cfp.Config {
+	path, err := cfp.GetConfigPath()
+	if err != nil {
+		report.add("config", checkFail, "cannot locate config file: %v", err)
+		return &cfp.Config{Server: cfp.DefaultServer}
+	}
+
+	cfg, err := cfp.LoadConfig()
+	if err != nil {
+		report.add("config", checkFail, "%v", err)
+		return &cfp.Config{Server: cfp.DefaultServer}
+	}
+
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		report.add("config", checkWarn, "%s not found, using defaults", path)
+	} else {
+		report.add("config", checkPass, "%s (profile %s)", path, cfg.Profile)
+	}
+	return cfg
+}
+
+// checkServer verifies the server is reachable and the local clock agrees with it
+func checkServer(report *doctorReport, client *cfp.Client) bool {
+	sc, err := client.GetServerConfig()
+	if err != nil {
+		report.add("server", checkFail, "%s unreachable: %v", client.BaseURL, err)
+		return false
+	}
+
+	providers := "none"
+	if len(sc.AuthProviders) > 0 {
+		providers = strings.Join(sc.AuthProviders, ", ")
+	}
+	report.add("server", checkPass, "%s reachable (login via %s)", client.BaseURL, providers)
+
+	if sc.ServerTime.IsZero() {
+		report.add("clock", checkWarn, "server did not report its time")
+		return true
+	}
+	skew := time.Since(sc.ServerTime)
+	// The Date header has one-second resolution, so ignore sub-second differences
+	skewAbs := skew.Abs().Truncate(time.Second)
+	switch {
+	case skewAbs >= clockSkewFail:
+		report.add("clock", checkFail, "local clock is off by %s; logins will be rejected", cfp.FormatDuration(skew))
+	case skewAbs >= clockSkewWarn:
+		report.add("clock", checkWarn, "local clock is off by %s", cfp.FormatDuration(skew))
+	default:
+		report.add("clock", checkPass, "in sync with server")
+	}
+	return true
+}
+
+// checkToken verifies a token is stored, decodes its expiry and validates it with the server
+func checkToken(report *doctorReport, client *cfp.Client) {
+	if client.Token == "" {
+		report.add("token", checkWarn, "not logged in; run 'cfp login' to submit proposals")
+		return
+	}
+	report.Token = "[redacted]"
+
+	info, err := cfp.DecodeToken(client.Token)
+	if err != nil {
+		report.add("token", checkFail, "stored token is malformed: %v; run 'cfp login'", err)
+		return
+	}
+	if info.IsExpired() {
+		report.add("token", checkFail, "expired %s ago; run 'cfp login'", cfp.FormatDuration(info.ExpiresIn()))
+		return
+	}
+
+	user, err := client.GetMe()
+	if err != nil {
+		if cfp.IsUnauthorized(err) {
+			report.add("token", checkFail, "rejected by server; run 'cfp login'")
+			return
+		}
+		report.add("token", checkFail, "could not be verified: %v", err)
+		return
+	}
+
+	expiry := "no expiry"
+	if !info.ExpiresAt.IsZero() {
+		expiry = "expires in " + cfp.FormatDuration(info.ExpiresIn())
+	}
+	report.add("token", checkPass, "valid for %s (%s)", user.Email, expiry)
+}
+
+// checkEditor verifies the editor used by submit and create can be found
+func checkEditor(report *doctorReport) {
+	editor := cfp.GetEditor()
+	fields := strings.Fields(editor)
+	if len(fields) == 0 {
+		report.add("editor", checkWarn, "no editor configured; use --file with submit/create")
+		return
+	}
+	if _, err := exec.LookPath(fields[0]); err != nil {
+		report.add("editor", checkWarn, "%q not found; set $EDITOR or use --file with submit/create", editor)
+		return
+	}
+	report.add("editor", checkPass, "%s", editor)
+}
+
+func printDoctorReport(formatter *cfp.Formatter, report *doctorReport) error {
+	switch formatter.Format {
+	case cfp.FormatJSON:
+		return formatter.PrintJSON(report)
+	case cfp.FormatYAML:
+		return formatter.PrintYAML(report)
+	default:
+		w := tabwriter.NewWriter(formatter.Writer, 0, 0, 2, ' ', 0)
+		for _, c := range report.Checks {
+			fmt.Fprintf(w, "[%s]\t%s\t%s\n", strings.ToUpper(c.Status), c.Name, c.Detail)
+		}
+		return w.Flush()
+	}
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/sreday/cfp.ninja/pkg/cfp"
+)
+
+// eventError describes a failed event lookup, with a hint for unknown slugs
+func eventError(slug string, err error) error {
+	if cfp.IsNotFound(err) {
+		return fmt.Errorf("event %q not found. Run 'cfp events' to browse events", slug)
+	}
+	return fmt.Errorf("failed to get event: %w", err)
+}
+
+// fieldErrorDetails formats the per-field validation messages of an API error,
+// one per line, or returns "" when the server did not report any
+func fieldErrorDetails(err error) string {
+	var apiErr *cfp.APIError
+	if !errors.As(err, &apiErr) || len(apiErr.Fields) == 0 {
+		return ""
+	}
+
+	fields := make([]string, 0, len(apiErr.Fields))
+	for f := range apiErr.Fields {
+		fields = append(fields, f)
+	}
+	sort.Strings(fields)
+
+	var b strings.Builder
+	for _, f := range fields {
+		fmt.Fprintf(&b, "\n  %s: %s", f, apiErr.Fields[f])
+	}
+	return b.String()
+}
@@ -2,28 +2,54 @@ package main
 
 import (
 	"fmt"
+	"os"
 	"strings"
+	"time"
 
+	"github.com/pkg/browser"
 	"github.com/spf13/cobra"
 	"github.com/sreday/cfp.ninja/pkg/cfp"
 )
 
 var eventsCmd = &cobra.Command{
-	Use:   "events [slug]",
-	Short: "List or show events",
+	Use:     "events [slug]",
+	Aliases: []string{"event"},
+	Short:   "List or show events",
 	Long: `Without arguments, lists events with open CFPs.
-With a slug argument, shows detailed information about that event.`,
+With a slug argument, shows detailed information about that event.
+
+The last successful listing for each set of filters is cached, and shown
+(with a staleness warning) when the server cannot be reached.`,
 	Example: `  # List all events with open CFPs
   cfp events
 
   # List events filtered by tag
   cfp events --tag go
 
+  # Show the second page, or fetch every page at once
+  cfp events --page 2
+  cfp events --all
+
   # Show details for a specific event
   cfp events gophercon-2026
 
   # Output as JSON for scripting
-  cfp events -o json`,
+  cfp events -o json
+
+  # Add all open CFP deadlines to your calendar
+  cfp events --all --ical deadlines.ics
+
+  # CFPs closing in the next two weeks, one tab-separated line each
+  cfp events --deadlines --within 14
+
+  # Open an event page in the browser
+  cfp events --open gophercon-2026
+
+  # List the events you organize, with proposal counts
+  cfp events --mine
+
+  # Only those whose name or slug contains "sreday"
+  cfp events --mine -q sreday`,
 	Args:              cobra.MaximumNArgs(1),
 	RunE:              runEvents,
 	ValidArgsFunction: completeEventSlugs,
@@ -36,14 +62,23 @@ var (
 	eventsLocation  string
 	eventsFrom      string
 	eventsTo        string
-	eventsStatus string
-	eventsSort   string
+	eventsStatus    string
+	eventsSort      string
 	eventsOrder     string
 	eventsLimit     int
+	eventsPage      int
+	eventsPerPage   int
+	eventsAll       bool
+	eventsRefresh   bool
+	eventsICal      string
+	eventsOpen      string
+	eventsMine      bool
+	eventsDeadlines bool
+	eventsWithin    int
 )
 
 func init() {
-	eventsCmd.Flags().StringVarP(&eventsQuery, "query", "q", "", "Search events by name or description")
+	eventsCmd.Flags().StringVarP(&eventsQuery, "query", "q", "", "Search events by name or description (with --mine, by name or slug)")
 	eventsCmd.Flags().StringVarP(&eventsTag, "tag", "t", "", "Filter by tag")
 	eventsCmd.Flags().StringVar(&eventsCountry, "country", "", "Filter by country code (e.g., US, GB)")
 	eventsCmd.Flags().StringVar(&eventsLocation, "location", "", "Filter by location text")
@@ -52,16 +87,35 @@ func init() {
 	eventsCmd.Flags().StringVar(&eventsStatus, "status", "open", "Filter by CFP status: open, closed, all")
 	eventsCmd.Flags().StringVar(&eventsSort, "sort", "", "Sort by: start_date, name, cfp_close_at (default: context-aware)")
 	eventsCmd.Flags().StringVar(&eventsOrder, "order", "", "Sort order: asc, desc (default: context-aware)")
-	eventsCmd.Flags().IntVar(&eventsLimit, "limit", 0, "Max results to show (0 = all)")
+	eventsCmd.Flags().IntVar(&eventsLimit, "limit", 0, "Max results to show with --all (0 = no limit)")
+	eventsCmd.Flags().IntVar(&eventsPage, "page", 1, "Page of results to show")
+	eventsCmd.Flags().IntVar(&eventsPerPage, "per-page", 20, "Results per page (max 100)")
+	eventsCmd.Flags().BoolVar(&eventsAll, "all", false, "Fetch all pages of results")
+	eventsCmd.Flags().BoolVar(&eventsRefresh, "refresh", false, "Always fetch from the server, never fall back to cached results")
+	eventsCmd.Flags().StringVar(&eventsICal, "ical", "", "Write the listed events' CFP deadlines to an .ics calendar file")
+	eventsCmd.Flags().StringVar(&eventsOpen, "open", "", "Open the page of the event with this slug in your browser")
+	eventsCmd.Flags().BoolVar(&eventsMine, "mine", false, "List the events you organize (or, if none, the events you submitted to)")
+	eventsCmd.Flags().BoolVar(&eventsDeadlines, "deadlines", false, "List open CFP deadlines as YYYY-MM-DD<TAB>slug<TAB>name lines, soonest first")
+	eventsCmd.Flags().IntVar(&eventsWithin, "within", 0, "With --deadlines, only CFPs closing in the next N days (0 = no limit)")
+
+	eventsCmd.RegisterFlagCompletionFunc("open", completeEventSlugs)
 }
 
 func runEvents(cmd *cobra.Command, args []string) error {
+	if eventsMine {
+		return listMyEvents()
+	}
+
 	// Events are public - no login required
 	client, err := getPublicClient()
 	if err != nil {
 		return err
 	}
 
+	if eventsOpen != "" {
+		return openEventPage(client, eventsOpen)
+	}
+
 	formatter, err := getFormatter()
 	if err != nil {
 		return err
@@ -72,6 +126,13 @@ func runEvents(cmd *cobra.Command, args []string) error {
 		return showEvent(client, formatter, args[0])
 	}
 
+	if eventsDeadlines {
+		return listDeadlines(client, formatter)
+	}
+	if eventsWithin != 0 {
+		return fmt.Errorf("--within requires --deadlines")
+	}
+
 	// Otherwise, list events
 	return listEvents(client, formatter)
 }
@@ -86,8 +147,8 @@ func listEvents(client *cfp.Client, formatter *cfp.Formatter) error {
 		To:       eventsTo,
 		Sort:     eventsSort,
 		Order:    eventsOrder,
-		PerPage:  100, // fetch max page size for efficiency
-		Page:     1,
+		PerPage:  eventsPerPage,
+		Page:     eventsPage,
 	}
 
 	// Map --status flag to CFPFilter
@@ -100,41 +161,209 @@ func listEvents(client *cfp.Client, formatter *cfp.Formatter) error {
 		opts.CFPFilter = "open"
 	}
 
-	// Auto-paginate to fetch all results
-	var allEvents []cfp.Event
-	for {
-		resp, err := client.ListEvents(opts)
-		if err != nil {
+	if eventsAll {
+		opts.PerPage = 100 // fetch max page size for efficiency
+	}
+	if formatter.Format == cfp.FormatTable && eventsICal == "" {
+		// The table only shows a few columns; skip descriptions and CFP questions
+		opts.Fields = cfp.EventTableFields
+	}
+
+	cacheKey := cfp.EventsCacheKey(client.BaseURL, opts, eventsAll)
+	events, pagination, err := fetchEvents(client, opts)
+	if err != nil {
+		// Fall back to the last successful listing when the server is unreachable
+		cached, cacheErr := cfp.LoadEventsCache(cacheKey)
+		if eventsRefresh || !cfp.IsUnreachable(err) || cacheErr != nil || cached == nil {
 			return fmt.Errorf("failed to list events: %w", err)
 		}
+		fmt.Fprintf(os.Stderr, "Warning: could not reach %s; showing results cached %s, may be stale.\n",
+			client.BaseURL, cfp.FormatAge(cached.Age()))
+		events, pagination = cached.Events, cached.Pagination
+	} else {
+		_ = cfp.SaveEventsCache(cacheKey, &cfp.EventsCache{
+			Server:     client.BaseURL,
+			FetchedAt:  time.Now(),
+			Events:     events,
+			Pagination: pagination,
+		})
+	}
+
+	if eventsICal != "" {
+		return writeICalFile(client, events, eventsICal)
+	}
+
+	if err := formatter.PrintEvents(events); err != nil {
+		return err
+	}
+	if !eventsAll {
+		formatter.PrintPaginationSummary(pagination, len(events))
+	}
+	return nil
+}
+
+// listDeadlines prints the deadlines of every open CFP matching the filters,
+// dated in the local time zone
+func listDeadlines(client *cfp.Client, formatter *cfp.Formatter) error {
+	if eventsWithin < 0 {
+		return fmt.Errorf("--within must be a number of days")
+	}
+	opts := cfp.ListEventsOptions{
+		Query:     eventsQuery,
+		Tag:       eventsTag,
+		Country:   eventsCountry,
+		Location:  eventsLocation,
+		From:      eventsFrom,
+		To:        eventsTo,
+		CFPFilter: "open",
+		Sort:      "cfp_close_at",
+		Order:     "asc",
+		PerPage:   100,
+		Fields:    cfp.DeadlineFields,
+	}
+
+	var events []cfp.Event
+	err := client.ListAllEvents(opts, func(page []cfp.Event, p cfp.Pagination) error {
+		events = append(events, page...)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list events: %w", err)
+	}
+
+	deadlines := cfp.Deadlines(events, time.Now(), eventsWithin, time.Local)
+	switch formatter.Format {
+	case cfp.FormatJSON:
+		if deadlines == nil {
+			deadlines = []cfp.Deadline{}
+		}
+		return formatter.PrintJSON(deadlines)
+	case cfp.FormatYAML:
+		return formatter.PrintYAML(deadlines)
+	}
+	return cfp.WriteDeadlines(formatter.Writer, deadlines)
+}
 
-		allEvents = append(allEvents, resp.GetEvents()...)
+// listMyEvents lists the events the user organizes from the dashboard
+// endpoint, falling back to the events they submitted to when they organize
+// none
+func listMyEvents() error {
+	client, err := getClient()
+	if err != nil {
+		return err
+	}
+	formatter, err := getFormatter()
+	if err != nil {
+		return err
+	}
+
+	// -q is passed through to the server, which searches the user's events
+	opts := cfp.MyEventsOptions{Query: eventsQuery}
+	dashboard, err := client.GetDashboard(opts)
+	if err != nil {
+		return fmt.Errorf("failed to list your events: %w", err)
+	}
+	if len(dashboard.Events) > 0 {
+		return formatter.PrintDashboard(dashboard)
+	}
 
-		if opts.Page >= resp.Pagination.TotalPages {
-			break
+	resp, err := client.GetMyEvents(opts)
+	if err != nil {
+		return fmt.Errorf("failed to list your events: %w", err)
+	}
+	return formatter.PrintSubmittedEvents(resp.Submitted)
+}
+
+// fetchEvents fetches a single page of events, or every page (up to the client's
+// safety cap) when --all is set
+func fetchEvents(client *cfp.Client, opts cfp.ListEventsOptions) ([]cfp.Event, cfp.Pagination, error) {
+	if !eventsAll {
+		resp, err := client.ListEvents(opts)
+		if err != nil {
+			return nil, cfp.Pagination{}, err
 		}
+		return resp.GetEvents(), resp.Pagination, nil
+	}
+
+	var allEvents []cfp.Event
+	var last cfp.Pagination
+	err := client.ListAllEvents(opts, func(events []cfp.Event, p cfp.Pagination) error {
+		allEvents = append(allEvents, events...)
+		last = p
 		if eventsLimit > 0 && len(allEvents) >= eventsLimit {
-			break
+			return cfp.ErrStopPagination
 		}
-		opts.Page++
+		return nil
+	})
+	if err != nil {
+		return nil, cfp.Pagination{}, err
 	}
 
 	if eventsLimit > 0 && len(allEvents) > eventsLimit {
 		allEvents = allEvents[:eventsLimit]
 	}
 
-	return formatter.PrintEvents(allEvents)
+	return allEvents, last, nil
 }
 
 func showEvent(client *cfp.Client, formatter *cfp.Formatter, slug string) error {
 	event, err := client.GetEvent(slug)
 	if err != nil {
-		return fmt.Errorf("failed to get event: %w", err)
+		return eventError(slug, err)
+	}
+
+	if eventsICal != "" {
+		return writeICalFile(client, []cfp.Event{*event}, eventsICal)
 	}
 
 	return formatter.PrintEvent(event)
 }
 
+// writeICalFile writes the events' CFP deadlines to an .ics file ("-" for stdout)
+func writeICalFile(client *cfp.Client, events []cfp.Event, path string) error {
+	if path == "-" {
+		return cfp.WriteICS(os.Stdout, events, client.BaseURL)
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create calendar file: %w", err)
+	}
+	if err := cfp.WriteICS(f, events, client.BaseURL); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write calendar file: %w", err)
+	}
+
+	deadlines := 0
+	for _, e := range events {
+		if !e.CFPCloseAt.IsZero() {
+			deadlines++
+		}
+	}
+	fmt.Printf("Wrote %d CFP deadline(s) to %s\n", deadlines, path)
+	return nil
+}
+
+// openEventPage opens an event's public page in the default browser
+func openEventPage(client *cfp.Client, slug string) error {
+	// Resolve the event first so typos fail here rather than in the browser
+	event, err := client.GetEvent(slug)
+	if err != nil {
+		return eventError(slug, err)
+	}
+
+	pageURL := cfp.EventURL(client.BaseURL, event.Slug)
+	if err := browser.OpenURL(pageURL); err != nil {
+		fmt.Printf("Could not open browser automatically. Visit:\n  %s\n", pageURL)
+		return nil
+	}
+	fmt.Printf("Opened %s\n", pageURL)
+	return nil
+}
+
 // completeEventSlugs provides tab completion for event slugs
 func completeEventSlugs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 	// Only complete the first argument
@@ -152,6 +381,7 @@ func completeEventSlugs(cmd *cobra.Command, args []string, toComplete string) ([
 	resp, err := client.ListEvents(cfp.ListEventsOptions{
 		CFPFilter: "open",
 		PerPage:   50,
+		Fields:    []string{"slug", "name"},
 	})
 	if err != nil {
 		return nil, cobra.ShellCompDirectiveNoFileComp
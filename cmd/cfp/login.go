@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/pkg/browser"
@@ -17,7 +18,8 @@ A temporary local server receives the callback.
 
 By default uses GitHub OAuth. Use --provider google for Google OAuth.
 By default, connects to https://cfp.ninja. Use --server to connect
-to a different CFP.ninja instance.`,
+to a different CFP.ninja instance, and --profile to store the login
+in a named profile alongside your other servers.`,
 	Example: `  # Login with GitHub (default)
   cfp login
 
@@ -25,7 +27,10 @@ to a different CFP.ninja instance.`,
   cfp login --provider google
 
   # Login to a custom server
-  cfp login --server https://cfp.myconference.com`,
+  cfp login --server https://cfp.myconference.com
+
+  # Login to a self-hosted instance as a separate profile
+  cfp login --profile staging --server https://cfp.staging.example.com`,
 	RunE: runLogin,
 }
 
@@ -48,9 +53,10 @@ func runLogin(cmd *cobra.Command, args []string) error {
 	server := loginServer
 	if serverURL != "" {
 		server = serverURL
-	} else if server == cfp.DefaultServer && cfg.Server != "" {
+	} else if !cmd.Flags().Changed("server") && cfg.Server != "" {
 		server = cfg.Server
 	}
+	server = cfp.NormalizeServer(server)
 
 	// Determine provider: flag > config > default
 	provider := loginProvider
@@ -63,6 +69,17 @@ func runLogin(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("invalid provider: %s (must be 'github' or 'google')", provider)
 	}
 
+	// Fail early when the server doesn't offer the provider. Servers that are
+	// unreachable or predate the feature map get to answer the browser instead.
+	if sc, err := newClient(&cfp.Config{Server: server}).GetServerConfig(); err == nil && sc.Features != nil {
+		if !sc.Features.HasProvider(provider) {
+			if len(sc.Features.Providers) == 0 {
+				return fmt.Errorf("%s has no login providers configured", server)
+			}
+			return fmt.Errorf("%s doesn't support %s login (available: %s)", server, provider, strings.Join(sc.Features.Providers, ", "))
+		}
+	}
+
 	// Start local OAuth callback server
 	oauth, err := cfp.StartOAuthServer()
 	if err != nil {
@@ -102,7 +119,7 @@ func runLogin(cmd *cobra.Command, args []string) error {
 	}
 
 	// Verify the token works by fetching user info
-	client := cfp.NewClientWithConfig(cfg)
+	client := newClient(cfg)
 	user, err := client.GetMe()
 	if err != nil {
 		// Token might be invalid, but was received
@@ -10,7 +10,7 @@ import (
 var logoutCmd = &cobra.Command{
 	Use:   "logout",
 	Short: "Clear stored credentials",
-	Long:  `Removes the stored authentication token from your config file.`,
+	Long:  `Removes the stored authentication token from your config file and clears cached data.`,
 	RunE:  runLogout,
 }
 
@@ -18,6 +18,9 @@ func runLogout(cmd *cobra.Command, args []string) error {
 	if err := cfp.ClearConfig(); err != nil {
 		return fmt.Errorf("failed to clear config: %w", err)
 	}
+	if err := cfp.ClearCache(); err != nil {
+		return fmt.Errorf("failed to clear cache: %w", err)
+	}
 
 	fmt.Println("Logged out successfully.")
 	return nil
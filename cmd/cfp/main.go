@@ -1,8 +1,11 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"os/signal"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/sreday/cfp.ninja/pkg/cfp"
@@ -13,8 +16,13 @@ var (
 	Version = "dev"
 
 	// Global flags
-	outputFormat string
-	serverURL    string
+	outputFormat   string
+	serverURL      string
+	requestTimeout time.Duration
+	maxRetries     int
+	profileName    string
+	absoluteDates  bool
+	noColor        bool
 )
 
 var rootCmd = &cobra.Command{
@@ -35,36 +43,55 @@ Enable shell completion:
 }
 
 func init() {
+	cobra.OnInitialize(func() {
+		cfp.SelectProfile(profileName)
+	})
+	rootCmd.PersistentPostRun = func(cmd *cobra.Command, args []string) {
+		maybeCheckForUpdate(cmd)
+	}
+
 	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", "table", "Output format: table, json, yaml")
 	rootCmd.PersistentFlags().StringVar(&serverURL, "server", "", "CFP.ninja server URL (overrides config)")
+	rootCmd.PersistentFlags().DurationVar(&requestTimeout, "timeout", cfp.DefaultTimeout, "Timeout for each API request")
+	rootCmd.PersistentFlags().StringVar(&profileName, "profile", os.Getenv("CFP_PROFILE"), "Config profile to use (overrides current context)")
+	rootCmd.PersistentFlags().IntVar(&maxRetries, "retries", cfp.DefaultMaxRetries, "Number of retries for transient API failures")
+	rootCmd.PersistentFlags().BoolVar(&absoluteDates, "absolute-dates", false, "Show dates in tables as Jan 2, 2006 instead of relative (in 12d, 3d ago)")
+	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "Disable colored output (also set by the NO_COLOR environment variable)")
 
 	rootCmd.AddCommand(loginCmd)
 	rootCmd.AddCommand(logoutCmd)
 	rootCmd.AddCommand(whoamiCmd)
 	rootCmd.AddCommand(eventsCmd)
+	rootCmd.AddCommand(openCmd)
 	rootCmd.AddCommand(createCmd)
 	rootCmd.AddCommand(submitCmd)
 	rootCmd.AddCommand(proposalsCmd)
 	rootCmd.AddCommand(configCmd)
+	rootCmd.AddCommand(cacheCmd)
+	rootCmd.AddCommand(doctorCmd)
 	rootCmd.AddCommand(completionCmd)
 	rootCmd.AddCommand(versionCmd)
 }
 
-var versionCmd = &cobra.Command{
-	Use:   "version",
-	Short: "Print version information",
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Printf("cfp version %s\n", Version)
-	},
-}
-
-// getFormatter creates a formatter based on the global output flag
+// getFormatter creates a formatter based on the global output flag,
+// falling back to the active profile's default output format
 func getFormatter() (*cfp.Formatter, error) {
-	format, err := cfp.ParseOutputFormat(outputFormat)
+	output := outputFormat
+	if !rootCmd.PersistentFlags().Changed("output") {
+		if cfg, err := cfp.LoadConfig(); err == nil && cfg.Output != "" {
+			output = cfg.Output
+		}
+	}
+
+	format, err := cfp.ParseOutputFormat(output)
 	if err != nil {
 		return nil, err
 	}
-	return cfp.NewFormatter(format), nil
+	formatter := cfp.NewFormatter(format)
+	formatter.AbsoluteDates = absoluteDates
+	formatter.Color = !noColor && cfp.ColorEnabled(os.Stdout)
+	formatter.Width = cfp.TerminalWidth(os.Stdout)
+	return formatter, nil
 }
 
 // getClient creates an API client, optionally using the server flag override
@@ -81,8 +108,17 @@ func getClient() (*cfp.Client, error) {
 	if !cfg.IsLoggedIn() {
 		return nil, fmt.Errorf("not logged in. Run 'cfp login' first")
 	}
+	warnImpersonation(cfg.Token)
 
-	return cfp.NewClientWithConfig(cfg), nil
+	return newClient(cfg), nil
+}
+
+// warnImpersonation prints a banner when the stored token was issued to an
+// admin impersonating a user, so their commands aren't mistaken for the user's
+func warnImpersonation(token string) {
+	if info, err := cfp.DecodeToken(token); err == nil && info.IsImpersonation() {
+		fmt.Fprintln(os.Stderr, info.ImpersonationWarning())
+	}
 }
 
 // getPublicClient creates an unauthenticated API client for public endpoints
@@ -98,11 +134,31 @@ func getPublicClient() (*cfp.Client, error) {
 
 	// Clear token for public access
 	cfg.Token = ""
-	return cfp.NewClientWithConfig(cfg), nil
+	return newClient(cfg), nil
+}
+
+// newClient creates an API client honoring the global timeout and retry flags.
+// Requests are bound to the root command context so Ctrl-C aborts them.
+func newClient(cfg *cfp.Config) *cfp.Client {
+	client := cfp.NewClientWithConfig(cfg)
+	client.Version = Version
+	if requestTimeout > 0 {
+		client.HTTPClient.Timeout = requestTimeout
+	}
+	if maxRetries >= 0 {
+		client.MaxRetries = maxRetries
+	}
+	if ctx := rootCmd.Context(); ctx != nil {
+		client = client.WithContext(ctx)
+	}
+	return client
 }
 
 func main() {
-	if err := rootCmd.Execute(); err != nil {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	err := rootCmd.ExecuteContext(ctx)
+	stop()
+	if err != nil {
 		os.Exit(1)
 	}
 }
@@ -0,0 +1,24 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var openCmd = &cobra.Command{
+	Use:               "open <slug>",
+	Short:             "Open an event page in your browser",
+	Long:              `Opens the public CFP.ninja page of an event in your default browser.`,
+	Example:           `  cfp open gophercon-2026`,
+	Args:              cobra.ExactArgs(1),
+	RunE:              runOpen,
+	ValidArgsFunction: completeEventSlugs,
+}
+
+func runOpen(cmd *cobra.Command, args []string) error {
+	client, err := getPublicClient()
+	if err != nil {
+		return err
+	}
+
+	return openEventPage(client, args[0])
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/sreday/cfp.ninja/pkg/cfp"
+)
+
+var eventOrganizersCmd = &cobra.Command{
+	Use:   "organizers <slug>",
+	Short: "List the organizers of an event you organize",
+	Example: `  # List the organizers
+  cfp event organizers gophercon-2026
+
+  # Add the program committee
+  cfp event organizers add gophercon-2026 ada@example.com grace@example.com`,
+	Args: cobra.ExactArgs(1),
+	RunE: runEventOrganizers,
+}
+
+var eventOrganizersAddCmd = &cobra.Command{
+	Use:   "add <slug> <email>...",
+	Short: "Add registered users as organizers of an event",
+	Long: `Adds each email's account as an organizer of the event, in one request,
+and prints what became of each email. Emails without an account, and those
+past the server's organizer limit, are not added, and make the command fail.`,
+	Example: `  cfp event organizers add gophercon-2026 ada@example.com grace@example.com`,
+	Args:    cobra.MinimumNArgs(2),
+	RunE:    runEventOrganizersAdd,
+}
+
+func init() {
+	eventOrganizersCmd.AddCommand(eventOrganizersAddCmd)
+	eventsCmd.AddCommand(eventOrganizersCmd)
+}
+
+// managedEventID returns the ID of the event with the slug among the events
+// the user organizes, drafts included
+func managedEventID(client *cfp.Client, slug string) (uint, error) {
+	dashboard, err := client.GetDashboard(cfp.MyEventsOptions{})
+	if err != nil {
+		return 0, fmt.Errorf("failed to load your events: %w", err)
+	}
+	for _, e := range dashboard.Events {
+		if e.Slug == slug {
+			return e.ID, nil
+		}
+	}
+	return 0, fmt.Errorf("you don't organize an event %q. Run 'cfp events --mine' to list yours", slug)
+}
+
+func runEventOrganizers(cmd *cobra.Command, args []string) error {
+	client, err := getClient()
+	if err != nil {
+		return err
+	}
+	formatter, err := getFormatter()
+	if err != nil {
+		return err
+	}
+
+	eventID, err := managedEventID(client, args[0])
+	if err != nil {
+		return err
+	}
+	organizers, err := client.ListOrganizers(eventID)
+	if err != nil {
+		return fmt.Errorf("failed to list organizers: %w", err)
+	}
+	return formatter.PrintOrganizers(organizers)
+}
+
+func runEventOrganizersAdd(cmd *cobra.Command, args []string) error {
+	client, err := getClient()
+	if err != nil {
+		return err
+	}
+	formatter, err := getFormatter()
+	if err != nil {
+		return err
+	}
+
+	slug, emails := args[0], args[1:]
+	eventID, err := managedEventID(client, slug)
+	if err != nil {
+		return err
+	}
+	results, err := client.AddOrganizers(eventID, emails)
+	if err != nil {
+		return fmt.Errorf("failed to add organizers: %w%s", err, fieldErrorDetails(err))
+	}
+	if err := formatter.PrintOrganizerResults(results); err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, r := range results {
+		if r.Result == "not_found" || r.Result == "limit_reached" {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d organizers not added", failed, len(results))
+	}
+	return nil
+}
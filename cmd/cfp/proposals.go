@@ -1,8 +1,11 @@
 package main
 
 import (
+	"errors"
 	"fmt"
+	"os"
 	"strconv"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/sreday/cfp.ninja/pkg/cfp"
@@ -20,19 +23,28 @@ With an ID argument, shows detailed information about that proposal.`,
   cfp proposals 123
 
   # Output as JSON for scripting
-  cfp proposals -o json`,
+  cfp proposals -o json
+
+  # Keep polling and get a desktop notification when a status changes
+  cfp proposals --watch --notify`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runProposals,
 }
 
 var (
-	proposalsEvent  string
-	proposalsStatus string
+	proposalsEvent    string
+	proposalsStatus   string
+	proposalsWatch    bool
+	proposalsInterval time.Duration
+	proposalsNotify   bool
 )
 
 func init() {
 	proposalsCmd.Flags().StringVar(&proposalsEvent, "event", "", "Filter by event slug")
-	proposalsCmd.Flags().StringVar(&proposalsStatus, "status", "", "Filter by status: submitted, accepted, rejected, tentative")
+	proposalsCmd.Flags().StringVar(&proposalsStatus, "status", "", "Filter by status: submitted, accepted, rejected, tentative, cancelled")
+	proposalsCmd.Flags().BoolVarP(&proposalsWatch, "watch", "w", false, "Keep polling and mark proposals whose status changed (Ctrl-C to stop)")
+	proposalsCmd.Flags().DurationVar(&proposalsInterval, "interval", time.Minute, "Polling interval for --watch (at least 30s)")
+	proposalsCmd.Flags().BoolVar(&proposalsNotify, "notify", false, "With --watch, show a desktop notification when a status changes")
 }
 
 func runProposals(cmd *cobra.Command, args []string) error {
@@ -55,47 +67,121 @@ func runProposals(cmd *cobra.Command, args []string) error {
 		return showProposal(client, formatter, uint(id))
 	}
 
+	if proposalsWatch {
+		if formatter.Format != cfp.FormatTable {
+			return fmt.Errorf("--watch only supports table output")
+		}
+		return watchProposals(client, formatter)
+	}
+	if proposalsNotify {
+		return fmt.Errorf("--notify requires --watch")
+	}
+
 	// Otherwise, list proposals
 	return listProposals(client, formatter)
 }
 
 func listProposals(client *cfp.Client, formatter *cfp.Formatter) error {
-	resp, err := client.GetMyEvents()
+	resp, err := client.GetMyEvents(cfp.MyEventsOptions{})
 	if err != nil {
 		return fmt.Errorf("failed to get proposals: %w", err)
 	}
 
+	return formatter.PrintSubmittedEvents(filterSubmittedEvents(resp.Submitted))
+}
+
+// filterSubmittedEvents applies the --event and --status filters
+func filterSubmittedEvents(events []cfp.SubmittedEvent) []cfp.SubmittedEvent {
 	// Filter by event if specified
 	if proposalsEvent != "" {
 		var filtered []cfp.SubmittedEvent
-		for _, e := range resp.Submitted {
+		for _, e := range events {
 			// Match by name since we might not have the slug
 			if e.Name == proposalsEvent || containsIgnoreCase(e.Name, proposalsEvent) {
 				filtered = append(filtered, e)
 			}
 		}
-		resp.Submitted = filtered
+		events = filtered
 	}
 
 	// Filter by status if specified
 	if proposalsStatus != "" {
-		for i := range resp.Submitted {
+		for i := range events {
 			var filtered []cfp.MyProposal
-			for _, p := range resp.Submitted[i].MyProposals {
+			for _, p := range events[i].MyProposals {
 				if p.Status == proposalsStatus {
 					filtered = append(filtered, p)
 				}
 			}
-			resp.Submitted[i].MyProposals = filtered
+			events[i].MyProposals = filtered
 		}
 	}
 
-	return formatter.PrintSubmittedEvents(resp.Submitted)
+	return events
+}
+
+// watchProposals polls the user's proposals until interrupted, reprinting
+// them with the ones whose status changed since the previous poll marked.
+// Failed polls are reported and retried, waiting as long as the server asks.
+func watchProposals(client *cfp.Client, formatter *cfp.Formatter) error {
+	if proposalsInterval < cfp.MinWatchInterval {
+		return fmt.Errorf("--interval must be at least %s", cfp.MinWatchInterval)
+	}
+	ctx := client.Context()
+
+	var prev []cfp.SubmittedEvent
+	polled := false
+	for {
+		var retryAfter time.Duration
+		resp, err := client.GetMyEvents(cfp.MyEventsOptions{})
+		switch {
+		case ctx.Err() != nil:
+			return nil
+		case cfp.IsUnauthorized(err):
+			return fmt.Errorf("failed to get proposals: %w", err)
+		case err != nil:
+			var apiErr *cfp.APIError
+			if errors.As(err, &apiErr) {
+				retryAfter = apiErr.RetryAfter
+			}
+			fmt.Fprintf(os.Stderr, "Failed to get proposals, will retry: %v\n", err)
+		default:
+			events := filterSubmittedEvents(resp.Submitted)
+			var changes []cfp.ProposalStatusChange
+			if polled {
+				changes = cfp.DiffProposalStatuses(prev, events)
+			}
+			prev, polled = events, true
+
+			fmt.Printf("\n%s\n", time.Now().Format("15:04:05"))
+			formatter.PrintWatchedProposals(events, changes)
+			if proposalsNotify {
+				for _, c := range changes {
+					if err := cfp.Notify("CFP.ninja", c.String()); err != nil {
+						fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+						proposalsNotify = false
+						break
+					}
+				}
+			}
+		}
+
+		timer := time.NewTimer(cfp.WatchDelay(proposalsInterval, retryAfter))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil
+		case <-timer.C:
+		}
+	}
 }
 
 func showProposal(client *cfp.Client, formatter *cfp.Formatter, id uint) error {
 	proposal, err := client.GetProposal(id)
 	if err != nil {
+		if cfp.IsNotFound(err) {
+			return fmt.Errorf("proposal #%d not found", id)
+		}
 		return fmt.Errorf("failed to get proposal: %w", err)
 	}
 
@@ -56,7 +56,7 @@ func runSubmit(cmd *cobra.Command, args []string) error {
 	// Fetch event details
 	event, err := client.GetEvent(slug)
 	if err != nil {
-		return fmt.Errorf("failed to get event: %w", err)
+		return eventError(slug, err)
 	}
 
 	// Check if CFP is open
@@ -68,6 +68,9 @@ func runSubmit(cmd *cobra.Command, args []string) error {
 
 	// Validation function for the editor loop
 	validateProposal := func(c string) error {
+		if err := cfp.ValidateProposalTemplate(c, event.CFPQuestions); err != nil {
+			return err
+		}
 		p, err := cfp.ParseTemplate(c)
 		if err != nil {
 			return err
@@ -75,6 +78,9 @@ func runSubmit(cmd *cobra.Command, args []string) error {
 		if err := cfp.ValidateCustomAnswers(p, event.CFPQuestions); err != nil {
 			return err
 		}
+		if event.RequireCoCAcceptance && !p.CoCAccepted {
+			return fmt.Errorf("this event requires accepting its code of conduct (%s): set coc_accepted: true", event.CoCURL)
+		}
 		proposal = p
 		return nil
 	}
@@ -134,6 +140,11 @@ func runSubmit(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
+	if event.CFPRequiresPayment {
+		fmt.Printf("\nNote: %s charges a submission fee%s, paid at %s/dashboard/proposals after submitting.\n",
+			event.Name, submissionFee(client), client.BaseURL)
+	}
+
 	// Confirm submission
 	fmt.Print("\nSubmit this proposal? [Y/n] ")
 	reader := bufio.NewReader(os.Stdin)
@@ -148,7 +159,10 @@ func runSubmit(cmd *cobra.Command, args []string) error {
 	// Submit the proposal
 	result, err := client.SubmitProposal(event.ID, proposal)
 	if err != nil {
-		return fmt.Errorf("failed to submit proposal: %w", err)
+		if cfp.IsPaymentRequired(err) {
+			return fmt.Errorf("failed to submit proposal: %w\n\nThis event charges a submission fee. Complete payment at:\n  %s/dashboard/proposals", err, client.BaseURL)
+		}
+		return fmt.Errorf("failed to submit proposal: %w%s", err, fieldErrorDetails(err))
 	}
 
 	fmt.Printf("\nSuccess! Proposal #%d submitted to %s.\n", result.ID, event.Name)
@@ -156,3 +170,15 @@ func runSubmit(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// submissionFee describes the server's default submission fee, e.g. " (usually
+// 1.00 USD)", or returns "" when the server doesn't say. Events may set their
+// own fee, which is only shown at checkout.
+func submissionFee(client *cfp.Client) string {
+	sc, err := client.GetServerConfig()
+	if err != nil || sc.Features == nil || !sc.Features.PaymentsEnabled || sc.Features.SubmissionListingFee == 0 {
+		return ""
+	}
+	f := sc.Features
+	return fmt.Sprintf(" (usually %d.%02d %s)", f.SubmissionListingFee/100, f.SubmissionListingFee%100, strings.ToUpper(f.SubmissionListingFeeCurrency))
+}
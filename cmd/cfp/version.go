@@ -0,0 +1,116 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/sreday/cfp.ninja/pkg/cfp"
+)
+
+const (
+	// updateCheckInterval is how often commands check the server for a newer CLI
+	updateCheckInterval = 24 * time.Hour
+	// updateCheckTimeout keeps the background check from slowing commands down
+	updateCheckTimeout = 2 * time.Second
+)
+
+var versionCheck bool
+
+var versionCmd = &cobra.Command{
+	Use:   "version",
+	Short: "Print version information",
+	Long: `Print the CLI version. With --check, also query the server for its
+version and report whether a newer CLI is available.`,
+	Example: `  cfp version
+  cfp version --check`,
+	Args: cobra.NoArgs,
+	RunE: runVersion,
+}
+
+func init() {
+	versionCmd.Flags().BoolVar(&versionCheck, "check", false, "Check the server for a newer CLI version")
+}
+
+func runVersion(cmd *cobra.Command, args []string) error {
+	fmt.Printf("cfp version %s\n", Version)
+	if !versionCheck {
+		return nil
+	}
+
+	client, err := getPublicClient()
+	if err != nil {
+		return err
+	}
+
+	sv, err := client.GetServerVersion()
+	if err != nil {
+		// Offline is not an error for a version check
+		fmt.Printf("server version unknown (could not reach %s)\n", client.BaseURL)
+		return nil
+	}
+	_ = cfp.RecordUpdateCheck(time.Now())
+
+	fmt.Printf("server version %s (%s)\n", sv.Version, client.BaseURL)
+	if sv.MinCLIVersion != "" {
+		fmt.Printf("minimum supported CLI version %s\n", sv.MinCLIVersion)
+	}
+	if !printUpdateNotice(sv) {
+		if _, ok := cfp.CompareVersions(Version, sv.Version); ok {
+			fmt.Println("Your CLI is up to date.")
+		}
+	}
+	return nil
+}
+
+// maybeCheckForUpdate checks the server for a newer CLI at most once per
+// updateCheckInterval. It never fails the command and stays silent when offline.
+func maybeCheckForUpdate(cmd *cobra.Command) {
+	// Skip when the user asked explicitly, for shell completion and for dev builds
+	name := cmd.Name()
+	if name == "version" || strings.HasPrefix(name, "__") || name == "completion" ||
+		(cmd.HasParent() && cmd.Parent().Name() == "completion") {
+		return
+	}
+	if _, ok := cfp.CompareVersions(Version, Version); !ok {
+		return
+	}
+
+	last, err := cfp.LastUpdateCheck()
+	if err != nil || time.Since(last) < updateCheckInterval {
+		return
+	}
+
+	client, err := getPublicClient()
+	if err != nil {
+		return
+	}
+	client.HTTPClient.Timeout = updateCheckTimeout
+	client.MaxRetries = 0
+
+	sv, err := client.GetServerVersion()
+	if err != nil {
+		return
+	}
+	_ = cfp.RecordUpdateCheck(time.Now())
+
+	printUpdateNotice(sv)
+}
+
+// printUpdateNotice writes an update notice to stderr if one is warranted,
+// reporting whether anything was printed
+func printUpdateNotice(sv *cfp.ServerVersion) bool {
+	if cmp, ok := cfp.CompareVersions(Version, sv.MinCLIVersion); ok && cmp < 0 {
+		fmt.Fprintf(os.Stderr, "Warning: cfp %s is no longer supported by this server (minimum %s). "+
+			"Please upgrade: go install github.com/sreday/cfp.ninja/cmd/cfp@latest\n", Version, sv.MinCLIVersion)
+		return true
+	}
+	if cmp, ok := cfp.CompareVersions(Version, sv.Version); ok && cmp < 0 {
+		fmt.Fprintf(os.Stderr, "A newer cfp CLI is available (%s, you have %s). "+
+			"Upgrade with: go install github.com/sreday/cfp.ninja/cmd/cfp@latest\n", sv.Version, Version)
+		return true
+	}
+	return false
+}
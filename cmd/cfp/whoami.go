@@ -2,6 +2,8 @@ package main
 
 import (
 	"fmt"
+	"os"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/sreday/cfp.ninja/pkg/cfp"
@@ -10,8 +12,23 @@ import (
 var whoamiCmd = &cobra.Command{
 	Use:   "whoami",
 	Short: "Display current user information",
-	Long:  `Shows the currently authenticated user's name, email, and ID.`,
-	RunE:  runWhoami,
+	Long: `Shows the currently authenticated user's name, email, and ID, along with
+the active profile and server, the credential type and when it expires.`,
+	RunE: runWhoami,
+}
+
+// tokenExpiryWarning is how close to expiry whoami starts warning about the token
+const tokenExpiryWarning = 24 * time.Hour
+
+// whoamiInfo is the structured whoami output: the user plus credential details
+type whoamiInfo struct {
+	*cfp.UserInfo  `yaml:",inline"`
+	Profile        string     `json:"profile" yaml:"profile"`
+	Server         string     `json:"server" yaml:"server"`
+	Credential     string     `json:"credential" yaml:"credential"`
+	IssuedAt       *time.Time `json:"issued_at,omitempty" yaml:"issued_at,omitempty"`
+	ExpiresAt      *time.Time `json:"expires_at,omitempty" yaml:"expires_at,omitempty"`
+	ImpersonatedBy string     `json:"impersonated_by,omitempty" yaml:"impersonated_by,omitempty"`
 }
 
 func runWhoami(cmd *cobra.Command, args []string) error {
@@ -24,9 +41,12 @@ func runWhoami(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("not logged in. Run 'cfp login' first")
 	}
 
-	client := cfp.NewClientWithConfig(cfg)
+	client := newClient(cfg)
 	user, err := client.GetMe()
 	if err != nil {
+		if cfp.IsUnauthorized(err) {
+			return fmt.Errorf("your session has expired or was revoked. Run 'cfp login' to sign in again")
+		}
 		return fmt.Errorf("failed to get user info: %w", err)
 	}
 
@@ -35,5 +55,59 @@ func runWhoami(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	return formatter.PrintUser(user)
+	info := whoamiInfo{
+		UserInfo:   user,
+		Profile:    cfg.Profile,
+		Server:     client.BaseURL,
+		Credential: cfp.CredentialType(cfg.Token),
+	}
+	// Decoded locally for display only; the server already accepted the token above
+	if claims, err := cfp.DecodeToken(cfg.Token); err == nil {
+		if !claims.IssuedAt.IsZero() {
+			info.IssuedAt = &claims.IssuedAt
+		}
+		if !claims.ExpiresAt.IsZero() {
+			info.ExpiresAt = &claims.ExpiresAt
+		}
+		info.ImpersonatedBy = claims.ImpersonatorEmail
+	}
+	warnImpersonation(cfg.Token)
+
+	if info.ExpiresAt != nil && time.Until(*info.ExpiresAt) < tokenExpiryWarning {
+		fmt.Fprintf(os.Stderr, "Warning: your token expires in %s. Run 'cfp login' to renew it.\n",
+			cfp.FormatDuration(time.Until(*info.ExpiresAt)))
+	}
+
+	switch formatter.Format {
+	case cfp.FormatJSON:
+		return formatter.PrintJSON(info)
+	case cfp.FormatYAML:
+		return formatter.PrintYAML(info)
+	}
+
+	if err := formatter.PrintUser(user); err != nil {
+		return err
+	}
+	fmt.Printf("Profile: %s\n", info.Profile)
+	fmt.Printf("Server:  %s\n", info.Server)
+	fmt.Printf("Auth:    %s\n", credentialLabel(info.Credential))
+	if info.ImpersonatedBy != "" {
+		fmt.Printf("Admin:   %s (impersonating)\n", info.ImpersonatedBy)
+	}
+	if info.IssuedAt != nil {
+		fmt.Printf("Issued:  %s\n", info.IssuedAt.Local().Format("Jan 2, 2006 15:04"))
+	}
+	if info.ExpiresAt != nil {
+		fmt.Printf("Expires: %s (in %s)\n", info.ExpiresAt.Local().Format("Jan 2, 2006 15:04"),
+			cfp.FormatDuration(time.Until(*info.ExpiresAt)))
+	}
+	return nil
+}
+
+// credentialLabel returns a display name for a credential type
+func credentialLabel(credential string) string {
+	if credential == cfp.CredentialAPIKey {
+		return "API key"
+	}
+	return "JWT (browser login)"
 }
@@ -5,15 +5,16 @@ import (
 	"embed"
 	"io/fs"
 	"log/slog"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
-	"path"
-	"strings"
 	"syscall"
 	"time"
 
 	"github.com/sreday/cfp.ninja/pkg/api"
+	"github.com/sreday/cfp.ninja/pkg/assets"
+	"github.com/sreday/cfp.ninja/pkg/logging"
 	"github.com/sreday/cfp.ninja/pkg/server"
 	"github.com/sreday/cfp.ninja/pkg/tasks"
 )
@@ -21,6 +22,9 @@ import (
 //go:embed static/*
 var staticFiles embed.FS
 
+// Version is set at build time
+var Version = "dev"
+
 func main() {
 	// Setup static file server
 	staticFS, err := fs.Sub(staticFiles, "static")
@@ -28,42 +32,23 @@ func main() {
 		slog.Error("failed to get static files", "error", err)
 		os.Exit(1)
 	}
-	fileServer := http.FileServer(http.FS(staticFS))
-
-	// Create a handler that serves static files with SPA routing and cache headers
-	staticHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		// Try to serve the file
-		p := strings.TrimPrefix(r.URL.Path, "/")
-		if p == "" {
-			p = "index.html"
-		}
 
-		// Check if file exists; if not, serve index.html for SPA routing
-		isSPAFallback := false
-		if _, err := fs.Stat(staticFS, p); err != nil {
-			r.URL.Path = "/"
-			isSPAFallback = true
-		}
-
-		// Set Cache-Control based on file type
-		ext := strings.ToLower(path.Ext(p))
-		switch {
-		case isSPAFallback || ext == ".html":
-			w.Header().Set("Cache-Control", "no-cache, no-store, must-revalidate")
-		case ext == ".js" || ext == ".css":
-			w.Header().Set("Cache-Control", "public, max-age=0, must-revalidate")
-		case ext == ".png" || ext == ".jpg" || ext == ".jpeg" || ext == ".gif" || ext == ".svg" || ext == ".ico" || ext == ".woff" || ext == ".woff2" || ext == ".ttf":
-			w.Header().Set("Cache-Control", "public, max-age=3600")
-		}
-
-		fileServer.ServeHTTP(w, r)
-	})
+	// Serve static files with SPA routing, fingerprinted asset names and
+	// precompressed siblings from `make static-compress`
+	staticHandler, err := assets.New(staticFS)
+	if err != nil {
+		slog.Error("failed to index static files", "error", err)
+		os.Exit(1)
+	}
 
 	cfg, handler, err := server.SetupServer(staticHandler)
 	if err != nil {
 		slog.Error("failed to setup server", "error", err)
 		os.Exit(1)
 	}
+	cfg.Version = Version
+	logger := logging.Module(cfg.Logger, logging.Server)
+	taskLogger := logging.Module(cfg.Logger, logging.Tasks)
 
 	// Context for background tasks, cancelled on shutdown
 	syncCtx, syncCancel := context.WithCancel(context.Background())
@@ -73,19 +58,46 @@ func main() {
 	api.StartUserCacheCleanup(syncCtx)
 
 	if len(cfg.AutoOrganiserIDs) > 0 {
-		go tasks.StartEventSync(syncCtx, cfg.DB, cfg.Logger, cfg.SyncInterval, cfg.AutoOrganiserIDs)
+		go tasks.StartEventSync(syncCtx, cfg.DB, taskLogger, cfg.SyncInterval, cfg.SyncLogSample, cfg.AutoOrganiserIDs, cfg.Geocoder)
 	} else {
-		cfg.Logger.Info("event sync disabled (AUTO_ORGANISERS_IDS not set)")
+		logger.Info("event sync disabled (AUTO_ORGANISERS_IDS not set)")
 	}
 
+	// Delete login history past its retention period
+	go tasks.StartLoginCleanup(syncCtx, cfg.DB, taskLogger, cfg.LoginRetention)
+
+	// Purge the retained proposals of deleted events once recovery has lapsed
+	go tasks.StartDeletedProposalPurge(syncCtx, cfg.DB, taskLogger)
+
+	// Archive long-past events and scrub their speakers' personal data
+	go tasks.StartEventArchival(syncCtx, cfg.DB, taskLogger, cfg.EmailSender, cfg.EmailFrom, cfg.BaseURL, tasks.ArchiveConfig{
+		ArchiveAfter: cfg.ArchiveAfter,
+		ScrubAfter:   cfg.ScrubAfter,
+		ScrubNotice:  cfg.ScrubNotice,
+		DryRun:       cfg.ArchiveDryRun,
+	})
+
+	// Email notify-me registrations when CFPs open
+	go tasks.StartCFPOpenNotifier(syncCtx, cfg.DB, taskLogger, cfg.EmailSender, cfg.EmailFrom, cfg.BaseURL)
+
 	// Start weekly digest emails (only if Resend is configured)
 	if cfg.ResendAPIKey != "" {
-		go tasks.StartWeeklyDigest(syncCtx, cfg.DB, cfg.Logger, cfg.EmailSender, cfg.EmailFrom, cfg.BaseURL)
+		go tasks.StartWeeklyDigest(syncCtx, cfg.DB, taskLogger, cfg.EmailSender, cfg.EmailFrom, cfg.BaseURL, tasks.DigestSchedule{
+			Day:            cfg.DigestDay,
+			Hour:           cfg.DigestHour,
+			SendsPerSecond: cfg.DigestSendsPerSecond,
+		})
 	}
 
+	// Requests derive their context from requestsCtx, so cancelling it stops
+	// their database queries
+	requestsCtx, cancelRequests := context.WithCancel(context.Background())
+	defer cancelRequests()
+
 	srv := &http.Server{
 		Addr:              ":" + cfg.Port,
 		Handler:           handler,
+		BaseContext:       func(net.Listener) context.Context { return requestsCtx },
 		ReadHeaderTimeout: 5 * time.Second,
 		ReadTimeout:       15 * time.Second,
 		WriteTimeout:      30 * time.Second,
@@ -97,15 +109,15 @@ func main() {
 	signal.Notify(done, os.Interrupt, syscall.SIGTERM)
 
 	go func() {
-		cfg.Logger.Info("starting server", "port", cfg.Port)
+		logger.Info("starting server", "port", cfg.Port)
 		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			slog.Error("server failed", "error", err)
+			logger.Error("server failed", "error", err)
 			os.Exit(1)
 		}
 	}()
 
 	<-done
-	cfg.Logger.Info("shutting down server")
+	logger.Info("shutting down server")
 
 	syncCancel()
 
@@ -117,9 +129,16 @@ func main() {
 	defer shutdownCancel()
 
 	if err := srv.Shutdown(shutdownCtx); err != nil {
-		slog.Error("server shutdown failed", "error", err)
+		// Abort the queries of requests still running, rather than leave
+		// them to the database after exit. Closing the pool waits for the
+		// cancellations to land.
+		cancelRequests()
+		if sqlDB, err := cfg.DB.DB(); err == nil {
+			sqlDB.Close()
+		}
+		logger.Error("server shutdown failed", "error", err)
 		os.Exit(1)
 	}
 
-	cfg.Logger.Info("server stopped")
+	logger.Info("server stopped")
 }
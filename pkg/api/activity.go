@@ -0,0 +1,190 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sreday/cfp.ninja/pkg/config"
+	"github.com/sreday/cfp.ninja/pkg/models"
+)
+
+// recordActivity adds an entry to the event's activity log. The change it
+// describes already happened, so a failure is logged rather than returned.
+func recordActivity(cfg *config.Config, r *http.Request, activity models.EventActivity) {
+	activity.CreatedAt = cfg.Now()
+	if err := cfg.DBCtx(r).Create(&activity).Error; err != nil {
+		cfg.Logger.Error("failed to record event activity",
+			"error", err,
+			"event_id", activity.EventID,
+			"action", activity.Action,
+		)
+	}
+}
+
+// organizerName is how an added or removed organizer is named in the
+// activity log
+func organizerName(u *models.User) string {
+	if u.Name != "" {
+		return u.Name
+	}
+	return u.Email
+}
+
+// changedEventFields returns the fields whose value differs between the
+// event's JSON encoding before an update and the event after it, sorted by
+// name. The encoding is taken before updating because an update writes
+// through the event's pointer fields.
+func changedEventFields(before []byte, after *models.Event, fields []string) []string {
+	curJSON, err := json.Marshal(after)
+	if err != nil {
+		return nil
+	}
+	var old, cur map[string]json.RawMessage
+	if json.Unmarshal(before, &old) != nil || json.Unmarshal(curJSON, &cur) != nil {
+		return nil
+	}
+
+	var changed []string
+	for _, field := range fields {
+		o, ok1 := old[field]
+		c, ok2 := cur[field]
+		if (ok1 || ok2) && !bytes.Equal(o, c) {
+			changed = append(changed, field)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
+
+// activityActor is who performed an activity, or null once their account is
+// deleted
+type activityActor struct {
+	ID   uint   `json:"id"`
+	Name string `json:"name"`
+}
+
+// activityItem is an activity log entry as returned by the API
+type activityItem struct {
+	ID         uint           `json:"id"`
+	Action     string         `json:"action"`
+	Summary    string         `json:"summary"`
+	Actor      *activityActor `json:"actor"`
+	ProposalID *uint          `json:"proposal_id,omitempty"`
+	CreatedAt  time.Time      `json:"created_at"`
+}
+
+// parseActivityTime parses a since or until filter as RFC 3339 or a date
+func parseActivityTime(field, value string, errs *validationErrors) time.Time {
+	if value == "" {
+		return time.Time{}
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t
+	}
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return t
+	}
+	errs.add(field, "Must be an RFC 3339 time or a YYYY-MM-DD date")
+	return time.Time{}
+}
+
+// GetEventActivityHandler lists what the event's organizers did, newest
+// first, with a readable summary of each entry. It can be filtered by a
+// comma-separated list of actions and a since/until time range, where until
+// is exclusive.
+// GET /api/v0/events/{id}/activity
+func GetEventActivityHandler(cfg *config.Config) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user := GetUserFromContext(r.Context())
+		if user == nil {
+			encodeAPIError(w, r, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		id, err := strconv.ParseUint(r.PathValue("id"), 10, 32)
+		if err != nil {
+			encodeAPIError(w, r, "Invalid event ID", http.StatusBadRequest)
+			return
+		}
+
+		event, err := getEventWithOrganizers(cfg, r, uint(id))
+		if err != nil {
+			encodeAPIError(w, r, "Event not found", http.StatusNotFound)
+			return
+		}
+
+		if !event.IsOrganizer(user.ID) {
+			encodeAPIError(w, r, "Forbidden", http.StatusForbidden)
+			return
+		}
+
+		query := r.URL.Query()
+		var errs validationErrors
+		var filter models.ActivityFilter
+		if actions := query.Get("action"); actions != "" {
+			for _, a := range strings.Split(actions, ",") {
+				a = strings.TrimSpace(a)
+				if !slices.Contains(models.ActivityActions, a) {
+					errs.add("action", "Unknown action "+strconv.Quote(a)+"; must be one of "+strings.Join(models.ActivityActions, ", "))
+					break
+				}
+				filter.Actions = append(filter.Actions, a)
+			}
+		}
+		filter.Since = parseActivityTime("since", query.Get("since"), &errs)
+		filter.Until = parseActivityTime("until", query.Get("until"), &errs)
+		if len(errs) > 0 {
+			encodeValidationErrors(w, r, errs)
+			return
+		}
+
+		page, _ := strconv.Atoi(query.Get("page"))
+		if page < 1 {
+			page = 1
+		}
+		perPage, _ := strconv.Atoi(query.Get("per_page"))
+		if perPage < 1 {
+			perPage = DefaultPageSize
+		}
+		if perPage > MaxPageSize {
+			perPage = MaxPageSize
+		}
+
+		activity, total, err := models.ListEventActivity(cfg.DBCtx(r), event.ID, filter, (page-1)*perPage, perPage)
+		if err != nil {
+			cfg.Logger.Error("failed to load event activity", "error", err, "event_id", event.ID)
+			encodeAPIError(w, r, "Failed to load activity", http.StatusInternalServerError)
+			return
+		}
+
+		items := make([]activityItem, len(activity))
+		for i, a := range activity {
+			items[i] = activityItem{
+				ID:         a.ID,
+				Action:     a.Action,
+				Summary:    a.Summary(),
+				ProposalID: a.ProposalID,
+				CreatedAt:  a.CreatedAt,
+			}
+			if a.Actor != nil {
+				items[i].Actor = &activityActor{ID: a.Actor.ID, Name: a.ActorName()}
+			}
+		}
+
+		encodeResponse(w, r, map[string]interface{}{
+			"data": items,
+			"pagination": map[string]interface{}{
+				"page":        page,
+				"per_page":    perPage,
+				"total":       total,
+				"total_pages": int((total + int64(perPage) - 1) / int64(perPage)),
+			},
+		})
+	}
+}
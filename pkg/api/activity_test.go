@@ -0,0 +1,51 @@
+package api
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/sreday/cfp.ninja/pkg/models"
+)
+
+func TestChangedEventFields(t *testing.T) {
+	closeAt := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	maxAccepted := 10
+	event := models.Event{Name: "SREday", Location: "London", CFPCloseAt: closeAt, MaxAccepted: &maxAccepted}
+	before, err := json.Marshal(event)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Writing through the pointer must not hide the change
+	event.Name = "SREday London"
+	event.CFPCloseAt = closeAt.AddDate(0, 0, 7)
+	*event.MaxAccepted = 12
+
+	got := changedEventFields(before, &event, []string{"name", "location", "max_accepted", "cfp_close_at", "unknown"})
+	want := []string{"cfp_close_at", "max_accepted", "name"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("changedEventFields() = %q, want %q", got, want)
+	}
+}
+
+func TestParseActivityTime(t *testing.T) {
+	var errs validationErrors
+	if got := parseActivityTime("since", "2026-03-01", &errs); !got.Equal(time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("date = %v", got)
+	}
+	if got := parseActivityTime("since", "2026-03-01T10:00:00Z", &errs); !got.Equal(time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("RFC 3339 = %v", got)
+	}
+	if got := parseActivityTime("since", "", &errs); !got.IsZero() {
+		t.Errorf("empty = %v", got)
+	}
+	if len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	parseActivityTime("until", "last week", &errs)
+	if len(errs) != 1 || errs[0].Field != "until" {
+		t.Errorf("errs = %v, want one until error", errs)
+	}
+}
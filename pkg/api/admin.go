@@ -0,0 +1,291 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"gorm.io/gorm"
+
+	"github.com/sreday/cfp.ninja/pkg/config"
+	"github.com/sreday/cfp.ninja/pkg/models"
+)
+
+// AdminHandler authenticates the request and only lets platform administrators
+// (ADMIN_EMAILS) through. Impersonated sessions never reach admin routes, even
+// when the impersonated user is an admin.
+func AdminHandler(cfg *config.Config, next http.HandlerFunc) http.HandlerFunc {
+	return AuthHandler(cfg, func(w http.ResponseWriter, r *http.Request) {
+		user := GetUserFromContext(r.Context())
+		if user == nil || !cfg.IsAdmin(user.Email) || GetImpersonationFromContext(r.Context()) != nil {
+			encodeAPIError(w, r, "Forbidden", http.StatusForbidden)
+			return
+		}
+		next(w, r)
+	})
+}
+
+// SetUserTrustedHandler flags a user as trusted, exempting them from proposal
+// submission abuse limits (admin only)
+func SetUserTrustedHandler(cfg *config.Config) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		admin := GetUserFromContext(r.Context())
+
+		id, err := strconv.ParseUint(r.PathValue("id"), 10, 32)
+		if err != nil {
+			encodeAPIError(w, r, "Invalid user ID", http.StatusBadRequest)
+			return
+		}
+
+		var req struct {
+			Trusted *bool `json:"trusted"`
+		}
+		if !decodeJSONBody(w, r, MaxSmallBodySize, &req) {
+			return
+		}
+		if req.Trusted == nil {
+			var errs validationErrors
+			errs.add("trusted", "trusted is required")
+			encodeValidationErrors(w, r, errs)
+			return
+		}
+
+		if err := models.SetUserTrusted(cfg.DBCtx(r), uint(id), *req.Trusted); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				encodeAPIError(w, r, "User not found", http.StatusNotFound)
+				return
+			}
+			cfg.Logger.Error("failed to update user trust", "user_id", id, "error", err)
+			encodeAPIError(w, r, "Failed to update user", http.StatusInternalServerError)
+			return
+		}
+
+		// Evict user from cache so the new flag applies to their next request
+		userCache.Lock()
+		delete(userCache.entries, uint(id))
+		userCache.Unlock()
+
+		var user models.User
+		if err := cfg.DBCtx(r).First(&user, id).Error; err != nil {
+			cfg.Logger.Error("failed to reload user after trust update", "user_id", id, "error", err)
+			encodeAPIError(w, r, "Failed to reload user", http.StatusInternalServerError)
+			return
+		}
+
+		cfg.Logger.Info("user trust updated", "user_id", user.ID, "trusted", user.IsTrusted, "admin_id", admin.ID)
+
+		encodeResponse(w, r, map[string]interface{}{
+			"id":         user.ID,
+			"email":      user.Email,
+			"name":       user.Name,
+			"is_trusted": user.IsTrusted,
+		})
+	}
+}
+
+// creatorTransferResponse is an event handed to a co-organizer by a user deletion
+type creatorTransferResponse struct {
+	EventID      uint `json:"event_id"`
+	NewCreatorID uint `json:"new_creator_id"`
+}
+
+// DeleteUserHandler deletes a user account for good (admin only). Each event
+// they created goes to its co-organizer with the oldest account, or is
+// orphaned when it has none: only admins can then take the creator's actions
+// on it, such as deleting it or managing its organizers.
+// DELETE /api/v0/admin/users/{id}
+func DeleteUserHandler(cfg *config.Config) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		admin := GetUserFromContext(r.Context())
+
+		id, err := strconv.ParseUint(r.PathValue("id"), 10, 32)
+		if err != nil {
+			encodeAPIError(w, r, "Invalid user ID", http.StatusBadRequest)
+			return
+		}
+		if uint(id) == admin.ID {
+			encodeAPIError(w, r, "You can't delete your own account", http.StatusBadRequest)
+			return
+		}
+
+		transfers, orphaned, err := models.DeleteUser(cfg.DBCtx(r), uint(id))
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				encodeAPIError(w, r, "User not found", http.StatusNotFound)
+				return
+			}
+			cfg.Logger.Error("failed to delete user", "user_id", id, "error", err)
+			encodeAPIError(w, r, "Failed to delete user", http.StatusInternalServerError)
+			return
+		}
+
+		// Evict user from cache so their tokens stop working at once
+		userCache.Lock()
+		delete(userCache.entries, uint(id))
+		userCache.Unlock()
+
+		cfg.Logger.Info("user deleted",
+			"user_id", id,
+			"admin_id", admin.ID,
+			"transferred_events", len(transfers),
+			"orphaned_events", len(orphaned),
+		)
+
+		transferred := make([]creatorTransferResponse, 0, len(transfers))
+		for _, t := range transfers {
+			recordActivity(cfg, r, models.EventActivity{
+				EventID: t.EventID,
+				ActorID: &admin.ID,
+				Action:  models.ActivityCreatorChanged,
+				Subject: organizerName(&t.NewCreator),
+			})
+			transferred = append(transferred, creatorTransferResponse{EventID: t.EventID, NewCreatorID: t.NewCreator.ID})
+		}
+		if orphaned == nil {
+			orphaned = []uint{}
+		}
+
+		encodeResponse(w, r, map[string]interface{}{
+			"message":            "User deleted",
+			"transferred_events": transferred,
+			"orphaned_events":    orphaned,
+		})
+	}
+}
+
+// ListDeletedProposalsHandler returns the retained copies of a deleted event's
+// proposals, for recovery until they are purged (admin only)
+func ListDeletedProposalsHandler(cfg *config.Config) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.ParseUint(r.PathValue("id"), 10, 32)
+		if err != nil {
+			encodeAPIError(w, r, "Invalid event ID", http.StatusBadRequest)
+			return
+		}
+
+		copies, err := models.GetDeletedProposals(cfg.DBCtx(r), uint(id))
+		if err != nil {
+			cfg.Logger.Error("failed to load deleted proposals", "event_id", id, "error", err)
+			encodeAPIError(w, r, "Failed to load deleted proposals", http.StatusInternalServerError)
+			return
+		}
+		encodeResponse(w, r, copies)
+	}
+}
+
+// MaxImpersonationLogs caps the audit entries returned at once
+const MaxImpersonationLogs = 200
+
+// ImpersonateUserHandler issues a short-lived token to see the app as another
+// user, for support (admin only). The session is read-only unless the body
+// asks for {"write": true}. With {"type": "browser"} the token replaces the
+// admin's session cookie instead of being returned.
+// POST /api/v0/admin/impersonate/{userID}
+func ImpersonateUserHandler(cfg *config.Config) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		admin := GetUserFromContext(r.Context())
+
+		id, err := strconv.ParseUint(r.PathValue("userID"), 10, 32)
+		if err != nil {
+			encodeAPIError(w, r, "Invalid user ID", http.StatusBadRequest)
+			return
+		}
+
+		var req struct {
+			Write bool   `json:"write"`
+			Type  string `json:"type"`
+		}
+		if err := decodeJSON(w, r, MaxSmallBodySize, &req); err != nil && !errors.Is(err, errEmptyBody) {
+			encodeBodyError(w, r, err)
+			return
+		}
+		if req.Type == "" {
+			req.Type = TokenTypeCLI
+		}
+		if req.Type != TokenTypeCLI && req.Type != TokenTypeBrowser {
+			var errs validationErrors
+			errs.add("type", "type must be 'cli' or 'browser'")
+			encodeValidationErrors(w, r, errs)
+			return
+		}
+		if uint(id) == admin.ID {
+			encodeAPIError(w, r, "You can't impersonate yourself", http.StatusBadRequest)
+			return
+		}
+
+		var user models.User
+		if err := cfg.DBCtx(r).First(&user, id).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				encodeAPIError(w, r, "User not found", http.StatusNotFound)
+				return
+			}
+			cfg.Logger.Error("failed to load user to impersonate", "user_id", id, "error", err)
+			encodeAPIError(w, r, "Failed to load user", http.StatusInternalServerError)
+			return
+		}
+		if !user.IsActive {
+			encodeAPIError(w, r, "User is inactive", http.StatusBadRequest)
+			return
+		}
+
+		token, expiresAt, err := GenerateImpersonationJWT(cfg, &user, admin, req.Type, req.Write)
+		if err != nil {
+			cfg.Logger.Error("failed to generate impersonation token", "user_id", user.ID, "admin_id", admin.ID, "error", err)
+			encodeAPIError(w, r, "Failed to generate token", http.StatusInternalServerError)
+			return
+		}
+		recordImpersonation(cfg, &Impersonation{Admin: admin, Write: req.Write}, &user, r, http.StatusOK)
+
+		resp := map[string]interface{}{
+			"user": map[string]interface{}{
+				"id":    user.ID,
+				"email": user.Email,
+				"name":  user.Name,
+			},
+			"write":      req.Write,
+			"expires_at": expiresAt,
+		}
+		if req.Type == TokenTypeBrowser {
+			setSessionCookie(cfg, w, token, ImpersonationTokenTTL)
+		} else {
+			resp["token"] = token
+		}
+		encodeResponse(w, r, resp)
+	}
+}
+
+// ListImpersonationLogsHandler returns the impersonation audit log, newest
+// first, optionally for one admin_id or user_id (admin only)
+func ListImpersonationLogsHandler(cfg *config.Config) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var filter models.ImpersonationLogFilter
+		var errs validationErrors
+		for _, p := range []struct {
+			name string
+			dst  *uint
+		}{{"admin_id", &filter.AdminID}, {"user_id", &filter.UserID}} {
+			raw := r.URL.Query().Get(p.name)
+			if raw == "" {
+				continue
+			}
+			v, err := strconv.ParseUint(raw, 10, 32)
+			if err != nil {
+				errs.add(p.name, p.name+" must be a user ID")
+				continue
+			}
+			*p.dst = uint(v)
+		}
+		if len(errs) > 0 {
+			encodeValidationErrors(w, r, errs)
+			return
+		}
+
+		logs, err := models.GetImpersonationLogs(cfg.DBCtx(r), filter, MaxImpersonationLogs)
+		if err != nil {
+			cfg.Logger.Error("failed to load impersonation logs", "error", err)
+			encodeAPIError(w, r, "Failed to load impersonation logs", http.StatusInternalServerError)
+			return
+		}
+		encodeResponse(w, r, logs)
+	}
+}
@@ -0,0 +1,211 @@
+package api
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/mail"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+
+	"github.com/sreday/cfp.ninja/pkg/config"
+	"github.com/sreday/cfp.ninja/pkg/models"
+)
+
+// apiKeyPrefix starts every API key, so keys are recognizable in logs and
+// secret scanners and are never mistaken for a JWT
+const apiKeyPrefix = "cfpk_"
+
+// MaxAPIKeyNameLen caps the name an admin gives a key
+const MaxAPIKeyNameLen = 200
+
+// apiKeyDisplayLen is how much of a key is kept in clear to tell keys apart
+const apiKeyDisplayLen = len(apiKeyPrefix) + 6
+
+// APIKeyContextKey holds the *models.APIKey of a public API request
+const APIKeyContextKey contextKey = "apiKey"
+
+// GetAPIKeyFromContext returns the API key of a request that passed
+// APIKeyHandler
+func GetAPIKeyFromContext(ctx context.Context) *models.APIKey {
+	if key, ok := ctx.Value(APIKeyContextKey).(*models.APIKey); ok {
+		return key
+	}
+	return nil
+}
+
+// generateAPIKey returns a new random API key
+func generateAPIKey() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return apiKeyPrefix + hex.EncodeToString(b), nil
+}
+
+// hashAPIKey returns the hash under which a key is stored. Keys are random, so
+// an unsalted hash is enough.
+func hashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// apiKeyFromRequest returns the key sent as "Authorization: Bearer cfpk_...",
+// or "" if the request has none
+func apiKeyFromRequest(r *http.Request) string {
+	key, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || !strings.HasPrefix(key, apiKeyPrefix) {
+		return ""
+	}
+	return key
+}
+
+// APIKeyHandler only lets requests with a valid, unrevoked API key through.
+// Each key is rate limited by limiter, and every request it makes is counted.
+func APIKeyHandler(cfg *config.Config, limiter *RateLimiter, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		raw := apiKeyFromRequest(r)
+		if raw == "" {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="cfp.ninja public API"`)
+			encodeAPIError(w, r, "An API key is required: send Authorization: Bearer <key>", http.StatusUnauthorized)
+			return
+		}
+
+		key, err := models.GetActiveAPIKeyByHash(cfg.DBCtx(r), hashAPIKey(raw))
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			encodeAPIError(w, r, "Invalid or revoked API key", http.StatusUnauthorized)
+			return
+		} else if err != nil {
+			cfg.Logger.Error("failed to look up API key", "error", err)
+			encodeAPIError(w, r, "Failed to check API key", http.StatusInternalServerError)
+			return
+		}
+
+		if !limiter.Allow("key:" + strconv.FormatUint(uint64(key.ID), 10)) {
+			encodeAPIErrorCode(w, r, ErrCodeRateLimited, "Rate limit exceeded for this API key", http.StatusTooManyRequests)
+			return
+		}
+
+		if err := models.RecordAPIKeyUse(cfg.DBCtx(r), key.ID, cfg.Now()); err != nil {
+			cfg.Logger.Warn("failed to record API key use", "api_key_id", key.ID, "error", err)
+		}
+
+		next(w, r.WithContext(context.WithValue(r.Context(), APIKeyContextKey, key)))
+	}
+}
+
+// createdAPIKey is an API key as returned once, when it is created
+type createdAPIKey struct {
+	models.APIKey
+	Key string `json:"key"`
+}
+
+// CreateAPIKeyHandler issues an API key for the public API (admin only). The
+// key itself is only ever shown in this response.
+// POST /api/v0/admin/api-keys
+func CreateAPIKeyHandler(cfg *config.Config) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		admin := GetUserFromContext(r.Context())
+
+		var req struct {
+			Name         string `json:"name"`
+			ContactEmail string `json:"contact_email"`
+		}
+		if !decodeJSONBody(w, r, MaxSmallBodySize, &req) {
+			return
+		}
+		req.Name = strings.TrimSpace(req.Name)
+		req.ContactEmail = strings.TrimSpace(req.ContactEmail)
+
+		var errs validationErrors
+		if req.Name == "" {
+			errs.add("name", "Name is required")
+		} else if len(req.Name) > MaxAPIKeyNameLen {
+			errs.add("name", "Name must be at most 200 characters")
+		}
+		if req.ContactEmail != "" {
+			if _, err := mail.ParseAddress(req.ContactEmail); err != nil || len(req.ContactEmail) > MaxSpeakerEmailLen {
+				errs.add("contact_email", "Invalid contact email")
+			}
+		}
+		if len(errs) > 0 {
+			encodeValidationErrors(w, r, errs)
+			return
+		}
+
+		raw, err := generateAPIKey()
+		if err != nil {
+			cfg.Logger.Error("failed to generate API key", "error", err)
+			encodeAPIError(w, r, "Failed to create API key", http.StatusInternalServerError)
+			return
+		}
+
+		key := models.APIKey{
+			Name:         req.Name,
+			ContactEmail: req.ContactEmail,
+			Prefix:       raw[:apiKeyDisplayLen],
+			KeyHash:      hashAPIKey(raw),
+			CreatedByID:  &admin.ID,
+			CreatedAt:    cfg.Now(),
+		}
+		if err := cfg.DBCtx(r).Create(&key).Error; err != nil {
+			cfg.Logger.Error("failed to create API key", "error", err)
+			encodeAPIError(w, r, "Failed to create API key", http.StatusInternalServerError)
+			return
+		}
+
+		cfg.Logger.Info("API key created", "api_key_id", key.ID, "name", key.Name, "admin_id", admin.ID)
+
+		encodeCreated(w, r, fmt.Sprintf("/api/v0/admin/api-keys/%d", key.ID), createdAPIKey{APIKey: key, Key: raw})
+	}
+}
+
+// ListAPIKeysHandler lists API keys with their usage, most used first
+// (admin only)
+// GET /api/v0/admin/api-keys
+func ListAPIKeysHandler(cfg *config.Config) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var keys []models.APIKey
+		if err := cfg.DBCtx(r).Order("request_count DESC, id DESC").Find(&keys).Error; err != nil {
+			cfg.Logger.Error("failed to list API keys", "error", err)
+			encodeAPIError(w, r, "Failed to load API keys", http.StatusInternalServerError)
+			return
+		}
+		encodeResponse(w, r, keys)
+	}
+}
+
+// RevokeAPIKeyHandler revokes an API key (admin only). The key's usage stays
+// listed.
+// DELETE /api/v0/admin/api-keys/{id}
+func RevokeAPIKeyHandler(cfg *config.Config) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		admin := GetUserFromContext(r.Context())
+
+		id, err := strconv.ParseUint(r.PathValue("id"), 10, 32)
+		if err != nil {
+			encodeAPIError(w, r, "Invalid API key ID", http.StatusBadRequest)
+			return
+		}
+
+		if err := models.RevokeAPIKey(cfg.DBCtx(r), uint(id), cfg.Now()); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				encodeAPIError(w, r, "API key not found", http.StatusNotFound)
+				return
+			}
+			cfg.Logger.Error("failed to revoke API key", "api_key_id", id, "error", err)
+			encodeAPIError(w, r, "Failed to revoke API key", http.StatusInternalServerError)
+			return
+		}
+
+		cfg.Logger.Info("API key revoked", "api_key_id", id, "admin_id", admin.ID)
+
+		encodeResponse(w, r, map[string]string{"message": "API key revoked"})
+	}
+}
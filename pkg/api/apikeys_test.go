@@ -0,0 +1,68 @@
+package api
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sreday/cfp.ninja/pkg/config"
+	"github.com/sreday/cfp.ninja/pkg/models"
+)
+
+func TestGenerateAPIKey(t *testing.T) {
+	a, err := generateAPIKey()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	b, _ := generateAPIKey()
+	if !strings.HasPrefix(a, apiKeyPrefix) || a == b {
+		t.Errorf("expected distinct prefixed keys, got %q and %q", a, b)
+	}
+	if hashAPIKey(a) == hashAPIKey(b) || hashAPIKey(a) != hashAPIKey(a) {
+		t.Error("expected the hash to depend only on the key")
+	}
+}
+
+func TestAPIKeyFromRequest(t *testing.T) {
+	tests := []struct {
+		header string
+		want   string
+	}{
+		{"Bearer cfpk_abc", "cfpk_abc"},
+		{"Bearer eyJhbGciOiJIUzI1NiJ9.x.y", ""}, // a login token
+		{"cfpk_abc", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest("GET", "/api/v0/public/events", nil)
+		if tt.header != "" {
+			r.Header.Set("Authorization", tt.header)
+		}
+		if got := apiKeyFromRequest(r); got != tt.want {
+			t.Errorf("apiKeyFromRequest(%q) = %q, want %q", tt.header, got, tt.want)
+		}
+	}
+}
+
+func TestNewPublicEvent(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	cfg := &config.Config{BaseURL: "https://cfp.ninja", Clock: func() time.Time { return now }}
+	event := &models.Event{
+		Slug:       "gophercon-2026",
+		Tags:       "go, cloud,,",
+		CFPStatus:  models.CFPStatusOpen,
+		CFPCloseAt: now.Add(time.Hour),
+	}
+
+	e := newPublicEvent(cfg, event)
+	if e.URL != "https://cfp.ninja/e/gophercon-2026" || e.CFPURL != e.URL+"/submit" {
+		t.Errorf("unexpected URLs: %q, %q", e.URL, e.CFPURL)
+	}
+	if len(e.Tags) != 2 || e.Tags[0] != "go" || e.Tags[1] != "cloud" {
+		t.Errorf("unexpected tags: %q", e.Tags)
+	}
+	if !e.CFPOpen || e.CFPOpenAt != nil || e.StartDate != nil || e.CFPCloseAt == nil {
+		t.Errorf("unexpected CFP fields: %+v", e)
+	}
+}
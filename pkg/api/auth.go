@@ -5,6 +5,7 @@ import (
 	"errors"
 	"math"
 	"net/http"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -74,11 +75,55 @@ func StartUserCacheCleanup(ctx context.Context) {
 	}()
 }
 
+// Token types, carried as the JWT audience. Browser sessions live in the
+// session cookie and CLI tokens are sent as bearer tokens; each is only
+// accepted the way it is meant to be presented.
+const (
+	TokenTypeBrowser = "browser"
+	TokenTypeCLI     = "cli"
+)
+
+// errTokenRevoked is returned for tokens issued before the user signed out
+// everywhere
+var errTokenRevoked = errors.New("token revoked")
+
+// tokenLifetime returns how long a token of the given type stays valid
+func tokenLifetime(cfg *config.Config, tokenType string) time.Duration {
+	if tokenType == TokenTypeBrowser {
+		if cfg.BrowserSessionTTL > 0 {
+			return cfg.BrowserSessionTTL
+		}
+		return config.DefaultBrowserSessionTTL
+	}
+	if cfg.CLITokenTTL > 0 {
+		return cfg.CLITokenTTL
+	}
+	return config.DefaultCLITokenTTL
+}
+
 // Context key for authenticated user
 type contextKey string
 
 const UserContextKey contextKey = "authenticatedUser"
 
+// impersonationContextKey holds the *Impersonation of an impersonated request
+const impersonationContextKey contextKey = "impersonation"
+
+// Impersonation is the real identity behind a request an admin makes as
+// another user, for support
+type Impersonation struct {
+	Admin *models.User
+	// Write allows changes; impersonated sessions are read-only by default
+	Write bool
+}
+
+// GetImpersonationFromContext returns the impersonation behind the request,
+// or nil when the user is acting as themselves
+func GetImpersonationFromContext(ctx context.Context) *Impersonation {
+	imp, _ := ctx.Value(impersonationContextKey).(*Impersonation)
+	return imp
+}
+
 // GetUserFromContext retrieves the authenticated user from the request context
 func GetUserFromContext(ctx context.Context) *models.User {
 	user, ok := ctx.Value(UserContextKey).(*models.User)
@@ -100,9 +145,9 @@ func getJWTFromCookie(r *http.Request) string {
 // resolveInsecureUser returns the user to use in insecure mode.
 // If InsecureUserEmail is set, the user is looked up from the database.
 // Otherwise a dummy user with a high ID is returned.
-func resolveInsecureUser(cfg *config.Config) (*models.User, error) {
+func resolveInsecureUser(cfg *config.Config, r *http.Request) (*models.User, error) {
 	if cfg.InsecureUserEmail != "" {
-		return models.GetUserByEmail(cfg.DB, cfg.InsecureUserEmail)
+		return models.GetUserByEmail(cfg.DBCtx(r), cfg.InsecureUserEmail)
 	}
 	user := &models.User{
 		Email: "insecure@system",
@@ -134,7 +179,7 @@ func AuthHandler(cfg *config.Config, next http.HandlerFunc) http.HandlerFunc {
 
 		// Skip auth in insecure mode
 		if cfg.Insecure {
-			user, err := resolveInsecureUser(cfg)
+			user, err := resolveInsecureUser(cfg, r)
 			if err != nil {
 				cfg.Logger.Error("insecure user not found", "email", cfg.InsecureUserEmail, "error", err.Error())
 				encodeError(w, "Insecure user not found", http.StatusInternalServerError)
@@ -147,6 +192,7 @@ func AuthHandler(cfg *config.Config, next http.HandlerFunc) http.HandlerFunc {
 
 		// Extract token from Authorization header or session cookie
 		var token string
+		tokenType := TokenTypeCLI
 		authHeader := r.Header.Get("Authorization")
 		if authHeader != "" {
 			// Expect "Bearer <token>"
@@ -159,6 +205,7 @@ func AuthHandler(cfg *config.Config, next http.HandlerFunc) http.HandlerFunc {
 		} else {
 			// Fall back to session cookie (browser sessions)
 			token = getJWTFromCookie(r)
+			tokenType = TokenTypeBrowser
 		}
 
 		if token == "" {
@@ -168,7 +215,7 @@ func AuthHandler(cfg *config.Config, next http.HandlerFunc) http.HandlerFunc {
 		}
 
 		// JWT authentication
-		user, err := validateJWT(cfg, token)
+		user, imp, err := validateSession(r.Context(), cfg, token, tokenType)
 		if err != nil {
 			cfg.Logger.Warn("JWT authentication failed", "error", err.Error())
 			encodeError(w, "Invalid or expired token", http.StatusUnauthorized)
@@ -176,9 +223,50 @@ func AuthHandler(cfg *config.Config, next http.HandlerFunc) http.HandlerFunc {
 		}
 
 		// Add user to context and call next handler
-		ctx := context.WithValue(r.Context(), UserContextKey, user)
+		serveAs(cfg, w, r, user, imp, next)
+	}
+}
+
+// serveAs calls next as the authenticated user. Impersonated requests are
+// refused when they would change something in a read-only session, and are
+// written to the impersonation audit log either way.
+func serveAs(cfg *config.Config, w http.ResponseWriter, r *http.Request, user *models.User, imp *Impersonation, next http.HandlerFunc) {
+	ctx := context.WithValue(r.Context(), UserContextKey, user)
+	if imp == nil {
 		next(w, r.WithContext(ctx))
+		return
 	}
+	ctx = context.WithValue(ctx, impersonationContextKey, imp)
+
+	rw := &responseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+	if !imp.Write && !isReadOnlyMethod(r.Method) {
+		encodeAPIErrorCode(rw, r, ErrCodeImpersonationReadOnly, "This impersonation session is read-only", http.StatusForbidden)
+	} else {
+		next(rw, r.WithContext(ctx))
+	}
+	recordImpersonation(cfg, imp, user, r, rw.statusCode)
+}
+
+// isReadOnlyMethod reports whether requests with the method never change anything
+func isReadOnlyMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
+
+// recordImpersonation writes a request made as user to the impersonation
+// audit log, with the admin behind it
+func recordImpersonation(cfg *config.Config, imp *Impersonation, user *models.User, r *http.Request, status int) {
+	entry := models.ImpersonationLog{
+		AdminID: imp.Admin.ID,
+		UserID:  user.ID,
+		Method:  r.Method,
+		Path:    r.URL.Path,
+		Status:  status,
+		Write:   imp.Write,
+	}
+	if err := cfg.DBCtx(r).Create(&entry).Error; err != nil {
+		cfg.Logger.Error("failed to record impersonated request", "error", err, "admin_id", imp.Admin.ID, "user_id", user.ID, "path", r.URL.Path)
+	}
+	cfg.Logger.Info("impersonated request", "admin_id", imp.Admin.ID, "user_id", user.ID, "method", r.Method, "path", r.URL.Path, "status", status)
 }
 
 // AuthCorsHandler combines CORS and Auth middleware
@@ -191,7 +279,7 @@ func OptionalAuthHandler(cfg *config.Config, next http.HandlerFunc) http.Handler
 	return func(w http.ResponseWriter, r *http.Request) {
 		// In insecure mode, resolve user the same way as AuthHandler
 		if cfg.Insecure {
-			user, err := resolveInsecureUser(cfg)
+			user, err := resolveInsecureUser(cfg, r)
 			if err != nil {
 				next(w, r)
 				return
@@ -203,6 +291,7 @@ func OptionalAuthHandler(cfg *config.Config, next http.HandlerFunc) http.Handler
 
 		// Extract token from Authorization header or session cookie
 		var token string
+		tokenType := TokenTypeCLI
 		authHeader := r.Header.Get("Authorization")
 		if authHeader != "" {
 			parts := strings.SplitN(authHeader, " ", 2)
@@ -213,6 +302,7 @@ func OptionalAuthHandler(cfg *config.Config, next http.HandlerFunc) http.Handler
 			token = parts[1]
 		} else {
 			token = getJWTFromCookie(r)
+			tokenType = TokenTypeBrowser
 		}
 
 		if token == "" {
@@ -220,10 +310,9 @@ func OptionalAuthHandler(cfg *config.Config, next http.HandlerFunc) http.Handler
 			return
 		}
 
-		user, err := validateJWT(cfg, token)
+		user, imp, err := validateSession(r.Context(), cfg, token, tokenType)
 		if err == nil && user != nil {
-			ctx := context.WithValue(r.Context(), UserContextKey, user)
-			next(w, r.WithContext(ctx))
+			serveAs(cfg, w, r, user, imp, next)
 			return
 		}
 
@@ -231,11 +320,44 @@ func OptionalAuthHandler(cfg *config.Config, next http.HandlerFunc) http.Handler
 	}
 }
 
-// validateJWT validates a JWT token and returns the user.
+// validateJWT validates a JWT token presented as tokenType and returns the user.
 // Returns jwt.ErrSignatureInvalid for invalid tokens or inactive users.
+// Returns jwt.ErrTokenInvalidAudience for tokens of another type.
+// Returns errTokenRevoked for tokens issued before the user signed out everywhere.
 // Returns gorm.ErrRecordNotFound if the user no longer exists.
 // Returns other errors for database failures (should be treated as 500).
-func validateJWT(cfg *config.Config, tokenString string) (*models.User, error) {
+func validateJWT(ctx context.Context, cfg *config.Config, tokenString, tokenType string) (*models.User, error) {
+	user, _, err := parseJWT(ctx, cfg, tokenString, tokenType)
+	return user, err
+}
+
+// validateSession validates a JWT like validateJWT and also returns the
+// impersonation it carries, if any. Impersonation tokens are only honored
+// while the admin who issued them is still an active admin.
+func validateSession(ctx context.Context, cfg *config.Config, tokenString, tokenType string) (*models.User, *Impersonation, error) {
+	user, claims, err := parseJWT(ctx, cfg, tokenString, tokenType)
+	if err != nil {
+		return nil, nil, err
+	}
+	adminID, ok := claims["impersonator_id"].(float64)
+	if !ok {
+		return user, nil, nil
+	}
+
+	admin, err := lookupUser(ctx, cfg, uint(adminID))
+	if err != nil {
+		return nil, nil, err
+	}
+	if !admin.IsActive || !cfg.IsAdmin(admin.Email) {
+		return nil, nil, jwt.ErrSignatureInvalid
+	}
+	write, _ := claims["impersonation_write"].(bool)
+	return user, &Impersonation{Admin: admin, Write: write}, nil
+}
+
+// parseJWT checks a token's signature, audience and generation and returns
+// its user and claims
+func parseJWT(ctx context.Context, cfg *config.Config, tokenString, tokenType string) (*models.User, jwt.MapClaims, error) {
 	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, jwt.ErrSignatureInvalid
@@ -244,32 +366,60 @@ func validateJWT(cfg *config.Config, tokenString string) (*models.User, error) {
 	})
 
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	claims, ok := token.Claims.(jwt.MapClaims)
 	if !ok || !token.Valid {
-		return nil, jwt.ErrSignatureInvalid
+		return nil, nil, jwt.ErrSignatureInvalid
+	}
+
+	// Tokens issued before token types existed have no audience; they are
+	// accepted either way until they expire
+	audience, err := claims.GetAudience()
+	if err != nil {
+		return nil, nil, jwt.ErrSignatureInvalid
+	}
+	if len(audience) > 0 && !slices.Contains(audience, tokenType) {
+		return nil, nil, jwt.ErrTokenInvalidAudience
 	}
 
 	// Get user ID from claims
 	userIDFloat, ok := claims["user_id"].(float64)
 	if !ok {
-		return nil, jwt.ErrSignatureInvalid
+		return nil, nil, jwt.ErrSignatureInvalid
 	}
-	userID := uint(userIDFloat)
 
-	// Check short-TTL cache first to avoid DB query on every request
+	// Tokens issued before token generations existed have none, like
+	// generation 0
+	generation, _ := claims["gen"].(float64)
+
+	user, err := lookupUser(ctx, cfg, uint(userIDFloat))
+	if err != nil {
+		return nil, nil, err
+	}
+	if !user.IsActive {
+		return nil, nil, jwt.ErrSignatureInvalid
+	}
+	// A sign-out everywhere reaches other instances within the cache TTL
+	if int(generation) != user.TokenGeneration {
+		return nil, nil, errTokenRevoked
+	}
+	return user, claims, nil
+}
+
+// lookupUser returns a user by ID, from the short-TTL cache when possible to
+// avoid a DB query on every request. A user deleted after their token was
+// issued is reported as jwt.ErrSignatureInvalid; database errors are returned
+// as they are.
+func lookupUser(ctx context.Context, cfg *config.Config, userID uint) (*models.User, error) {
 	if cached, ok := getCachedUser(userID); ok {
-		if !cached.IsActive {
-			return nil, jwt.ErrSignatureInvalid
-		}
 		return cached, nil
 	}
 
 	// Look up user - distinguish between "not found" and database errors
 	var user models.User
-	if err := cfg.DB.First(&user, userID).Error; err != nil {
+	if err := cfg.DB.WithContext(ctx).First(&user, userID).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			// User was deleted after token was issued - treat as invalid token
 			return nil, jwt.ErrSignatureInvalid
@@ -278,23 +428,22 @@ func validateJWT(cfg *config.Config, tokenString string) (*models.User, error) {
 		return nil, err
 	}
 
-	if !user.IsActive {
-		return nil, jwt.ErrSignatureInvalid
-	}
-
 	setCachedUser(&user)
 	return &user, nil
 }
 
-// GenerateJWT generates a JWT token for a user.
-// Tokens expire after 7 days; users must re-authenticate after expiry.
-func GenerateJWT(cfg *config.Config, user *models.User) (string, error) {
+// GenerateJWT generates a JWT token of the given type (TokenTypeBrowser or
+// TokenTypeCLI) for a user. Tokens expire after the type's configured
+// lifetime; users must re-authenticate after expiry.
+func GenerateJWT(cfg *config.Config, user *models.User, tokenType string) (string, error) {
 	now := time.Now()
 	claims := jwt.MapClaims{
 		"user_id": user.ID,
 		"email":   user.Email,
 		"name":    user.Name,
-		"exp":     now.Add(7 * 24 * time.Hour).Unix(),
+		"aud":     tokenType,
+		"gen":     user.TokenGeneration,
+		"exp":     now.Add(tokenLifetime(cfg, tokenType)).Unix(),
 		"iat":     now.Unix(),
 		"nbf":     now.Unix(),
 	}
@@ -302,3 +451,31 @@ func GenerateJWT(cfg *config.Config, user *models.User) (string, error) {
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	return token.SignedString([]byte(cfg.JWTSecret))
 }
+
+// ImpersonationTokenTTL is how long a token issued to impersonate a user lasts
+const ImpersonationTokenTTL = 15 * time.Minute
+
+// GenerateImpersonationJWT generates a short-lived token of the given type that
+// authenticates as user on behalf of admin. The admin's identity travels in the
+// impersonator claims so every request can be audited with both; without write
+// the token is read-only.
+func GenerateImpersonationJWT(cfg *config.Config, user, admin *models.User, tokenType string, write bool) (string, time.Time, error) {
+	now := time.Now()
+	expiresAt := now.Add(ImpersonationTokenTTL)
+	claims := jwt.MapClaims{
+		"user_id":             user.ID,
+		"email":               user.Email,
+		"name":                user.Name,
+		"aud":                 tokenType,
+		"gen":                 user.TokenGeneration,
+		"impersonator_id":     admin.ID,
+		"impersonator_email":  admin.Email,
+		"impersonation_write": write,
+		"exp":                 expiresAt.Unix(),
+		"iat":                 now.Unix(),
+		"nbf":                 now.Unix(),
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(cfg.JWTSecret))
+	return token, expiresAt, err
+}
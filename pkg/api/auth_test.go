@@ -2,6 +2,7 @@ package api
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -21,7 +22,7 @@ func TestGenerateJWT(t *testing.T) {
 	}
 	user.ID = 123
 
-	token, err := GenerateJWT(cfg, user)
+	token, err := GenerateJWT(cfg, user, TokenTypeCLI)
 	if err != nil {
 		t.Fatalf("GenerateJWT failed: %v", err)
 	}
@@ -67,7 +68,7 @@ func TestGenerateJWT_EmptySecret(t *testing.T) {
 	user.ID = 1
 
 	// Should still generate a token (empty secret is valid HMAC key, though not secure)
-	token, err := GenerateJWT(cfg, user)
+	token, err := GenerateJWT(cfg, user, TokenTypeCLI)
 	if err != nil {
 		t.Fatalf("GenerateJWT failed with empty secret: %v", err)
 	}
@@ -76,6 +77,83 @@ func TestGenerateJWT_EmptySecret(t *testing.T) {
 	}
 }
 
+func TestGenerateJWT_TokenTypes(t *testing.T) {
+	cfg := &config.Config{JWTSecret: "test-secret", BrowserSessionTTL: 2 * time.Hour}
+	user := &models.User{Email: "test@example.com", Name: "Test"}
+	user.ID = 1
+
+	tests := []struct {
+		tokenType string
+		lifetime  time.Duration
+	}{
+		{TokenTypeBrowser, 2 * time.Hour},
+		{TokenTypeCLI, config.DefaultCLITokenTTL},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tokenType, func(t *testing.T) {
+			token, err := GenerateJWT(cfg, user, tt.tokenType)
+			if err != nil {
+				t.Fatalf("GenerateJWT failed: %v", err)
+			}
+			parsed, err := jwt.Parse(token, func(token *jwt.Token) (interface{}, error) {
+				return []byte(cfg.JWTSecret), nil
+			})
+			if err != nil {
+				t.Fatalf("failed to parse generated token: %v", err)
+			}
+			claims := parsed.Claims.(jwt.MapClaims)
+			if claims["aud"] != tt.tokenType {
+				t.Errorf("expected audience %q, got %v", tt.tokenType, claims["aud"])
+			}
+			lifetime := time.Duration(claims["exp"].(float64)-claims["iat"].(float64)) * time.Second
+			if lifetime != tt.lifetime {
+				t.Errorf("expected lifetime %v, got %v", tt.lifetime, lifetime)
+			}
+		})
+	}
+}
+
+func TestValidateJWT_WrongTokenType(t *testing.T) {
+	cfg := &config.Config{JWTSecret: "test-secret"}
+	user := &models.User{Email: "test@example.com", Name: "Test"}
+	user.ID = 1
+
+	// Browser sessions are rejected as bearer tokens, CLI tokens as cookies
+	browser, _ := GenerateJWT(cfg, user, TokenTypeBrowser)
+	if _, err := validateJWT(context.Background(), cfg, browser, TokenTypeCLI); !errors.Is(err, jwt.ErrTokenInvalidAudience) {
+		t.Errorf("expected ErrTokenInvalidAudience for a browser token, got %v", err)
+	}
+	cli, _ := GenerateJWT(cfg, user, TokenTypeCLI)
+	if _, err := validateJWT(context.Background(), cfg, cli, TokenTypeBrowser); !errors.Is(err, jwt.ErrTokenInvalidAudience) {
+		t.Errorf("expected ErrTokenInvalidAudience for a CLI token, got %v", err)
+	}
+}
+
+func TestValidateJWT_RevokedGeneration(t *testing.T) {
+	cfg := &config.Config{JWTSecret: "test-secret"}
+	user := &models.User{Email: "revoked@example.com", IsActive: true}
+	user.ID = 987654
+
+	old, _ := GenerateJWT(cfg, user, TokenTypeCLI)
+	user.TokenGeneration = 1
+	current, _ := GenerateJWT(cfg, user, TokenTypeCLI)
+
+	// The cached user avoids a database lookup
+	setCachedUser(user)
+	t.Cleanup(func() {
+		userCache.Lock()
+		delete(userCache.entries, user.ID)
+		userCache.Unlock()
+	})
+
+	if _, err := validateJWT(context.Background(), cfg, old, TokenTypeCLI); !errors.Is(err, errTokenRevoked) {
+		t.Errorf("expected errTokenRevoked for a token of an earlier generation, got %v", err)
+	}
+	if _, err := validateJWT(context.Background(), cfg, current, TokenTypeCLI); err != nil {
+		t.Errorf("expected the current generation to be accepted, got %v", err)
+	}
+}
+
 func TestValidateJWT_InvalidSigningMethod(t *testing.T) {
 	cfg := &config.Config{
 		JWTSecret: "test-secret",
@@ -89,7 +167,7 @@ func TestValidateJWT_InvalidSigningMethod(t *testing.T) {
 
 	tokenString, _ := token.SignedString(jwt.UnsafeAllowNoneSignatureType)
 
-	_, err := validateJWT(cfg, tokenString)
+	_, err := validateJWT(context.Background(), cfg, tokenString, TokenTypeCLI)
 	if err == nil {
 		t.Error("expected error for invalid signing method")
 	}
@@ -112,7 +190,7 @@ func TestValidateJWT_MalformedToken(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			_, err := validateJWT(cfg, tc.token)
+			_, err := validateJWT(context.Background(), cfg, tc.token, TokenTypeCLI)
 			if err == nil {
 				t.Errorf("expected error for %s", tc.name)
 			}
@@ -126,11 +204,11 @@ func TestValidateJWT_WrongSecret(t *testing.T) {
 	user := &models.User{Email: "test@example.com", Name: "Test"}
 	user.ID = 1
 
-	token, _ := GenerateJWT(cfg1, user)
+	token, _ := GenerateJWT(cfg1, user, TokenTypeCLI)
 
 	// Try to validate with different secret
 	cfg2 := &config.Config{JWTSecret: "secret2"}
-	_, err := validateJWT(cfg2, token)
+	_, err := validateJWT(context.Background(), cfg2, token, TokenTypeCLI)
 	if err == nil {
 		t.Error("expected error when validating with wrong secret")
 	}
@@ -146,7 +224,7 @@ func TestValidateJWT_MissingUserID(t *testing.T) {
 	})
 	tokenString, _ := token.SignedString([]byte(cfg.JWTSecret))
 
-	_, err := validateJWT(cfg, tokenString)
+	_, err := validateJWT(context.Background(), cfg, tokenString, TokenTypeCLI)
 	if err == nil {
 		t.Error("expected error for missing user_id claim")
 	}
@@ -163,7 +241,7 @@ func TestValidateJWT_ExpiredToken(t *testing.T) {
 	})
 	tokenString, _ := token.SignedString([]byte(cfg.JWTSecret))
 
-	_, err := validateJWT(cfg, tokenString)
+	_, err := validateJWT(context.Background(), cfg, tokenString, TokenTypeCLI)
 	if err == nil {
 		t.Error("expected error for expired token")
 	}
@@ -177,3 +255,55 @@ func TestGetUserFromContext_NoUser(t *testing.T) {
 		t.Error("expected nil user from context without user")
 	}
 }
+
+func TestValidateSession_Impersonation(t *testing.T) {
+	cfg := &config.Config{JWTSecret: "test-secret", AdminEmails: []string{"support@example.com"}}
+	user := &models.User{Email: "organizer@example.com", IsActive: true}
+	user.ID = 987661
+	admin := &models.User{Email: "support@example.com", IsActive: true}
+	admin.ID = 987662
+
+	// The cached users avoid a database lookup
+	setCachedUser(user)
+	setCachedUser(admin)
+	t.Cleanup(func() {
+		userCache.Lock()
+		delete(userCache.entries, user.ID)
+		delete(userCache.entries, admin.ID)
+		userCache.Unlock()
+	})
+
+	plain, _ := GenerateJWT(cfg, user, TokenTypeCLI)
+	if got, imp, err := validateSession(context.Background(), cfg, plain, TokenTypeCLI); err != nil || got.ID != user.ID || imp != nil {
+		t.Errorf("expected a plain session, got %v %+v %v", got, imp, err)
+	}
+
+	token, expiresAt, err := GenerateImpersonationJWT(cfg, user, admin, TokenTypeCLI, false)
+	if err != nil {
+		t.Fatalf("GenerateImpersonationJWT failed: %v", err)
+	}
+	if d := time.Until(expiresAt); d <= 14*time.Minute || d > ImpersonationTokenTTL {
+		t.Errorf("expected the token to expire in 15 minutes, got %v", d)
+	}
+	got, imp, err := validateSession(context.Background(), cfg, token, TokenTypeCLI)
+	if err != nil || got.ID != user.ID {
+		t.Fatalf("expected the impersonated user, got %v %v", got, err)
+	}
+	if imp == nil || imp.Admin.ID != admin.ID || imp.Write {
+		t.Errorf("expected a read-only impersonation by the admin, got %+v", imp)
+	}
+
+	// The token dies with the admin's role
+	cfg.AdminEmails = nil
+	if _, _, err := validateSession(context.Background(), cfg, token, TokenTypeCLI); !errors.Is(err, jwt.ErrSignatureInvalid) {
+		t.Errorf("expected ErrSignatureInvalid once the issuer is no longer an admin, got %v", err)
+	}
+}
+
+func TestIsReadOnlyMethod(t *testing.T) {
+	for method, want := range map[string]bool{"GET": true, "HEAD": true, "OPTIONS": true, "POST": false, "PUT": false, "PATCH": false, "DELETE": false} {
+		if got := isReadOnlyMethod(method); got != want {
+			t.Errorf("%s: expected %v, got %v", method, want, got)
+		}
+	}
+}
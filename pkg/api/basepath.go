@@ -0,0 +1,44 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+type basePathKey struct{}
+
+// BasePath serves next under prefix, such as "/cfp", for deployments behind
+// another site (BASE_PATH). next sees request paths with the prefix removed
+// and requests outside it get a 404. The prefix is kept in the request
+// context for the paths handlers send back, see withBasePath.
+func BasePath(prefix string, next http.Handler) http.Handler {
+	if prefix == "" {
+		return next
+	}
+	strip := http.StripPrefix(prefix, next)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == prefix {
+			// The frontend's relative references only resolve under prefix/
+			target := prefix + "/"
+			if r.URL.RawQuery != "" {
+				target += "?" + r.URL.RawQuery
+			}
+			http.Redirect(w, r, target, http.StatusMovedPermanently)
+			return
+		}
+		if !strings.HasPrefix(r.URL.Path, prefix+"/") {
+			http.NotFound(w, r)
+			return
+		}
+		ctx := context.WithValue(r.Context(), basePathKey{}, prefix)
+		strip.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// withBasePath prefixes an absolute path of the app with the path it is
+// served under, for Location headers and redirects
+func withBasePath(r *http.Request, p string) string {
+	prefix, _ := r.Context().Value(basePathKey{}).(string)
+	return prefix + p
+}
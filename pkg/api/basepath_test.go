@@ -0,0 +1,45 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestBasePath(t *testing.T) {
+	handler := BasePath("/cfp", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		encodeCreated(w, r, "/api/v0/events/42?path="+r.URL.Path, map[string]interface{}{"id": 42})
+	}))
+
+	tests := []struct {
+		path     string
+		status   int
+		location string
+	}{
+		{"/cfp/api/v0/events", http.StatusCreated, "/cfp/api/v0/events/42?path=/api/v0/events"},
+		{"/cfp/", http.StatusCreated, "/cfp/api/v0/events/42?path=/"},
+		{"/cfp", http.StatusMovedPermanently, "/cfp/"},
+		{"/cfp?ref=mail", http.StatusMovedPermanently, "/cfp/?ref=mail"},
+		{"/api/v0/events", http.StatusNotFound, ""},
+		{"/cfpx/api/v0/events", http.StatusNotFound, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, tt.path, nil))
+			if rec.Code != tt.status {
+				t.Fatalf("expected status %d, got %d", tt.status, rec.Code)
+			}
+			if got := rec.Header().Get("Location"); got != tt.location {
+				t.Errorf("expected Location %q, got %q", tt.location, got)
+			}
+		})
+	}
+}
+
+func TestWithBasePath_Root(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/api/v0/events", nil)
+	if got := withBasePath(r, "/api/v0/events/42"); got != "/api/v0/events/42" {
+		t.Errorf("expected the path unchanged, got %q", got)
+	}
+}
@@ -0,0 +1,120 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// Request body limits per kind of route. Imports use MaxImportFileSize.
+const (
+	// Status changes, ratings and other single-field commands
+	MaxSmallBodySize = 4 << 10
+	// Speaker emails carry a subject and a free-text body
+	MaxEmailBodySize = 64 << 10
+	// Events and proposals, including custom answers
+	MaxBodySize = 1 << 20
+)
+
+// Limits on the shape of JSON bodies, checked before they are decoded so a
+// small but hostile payload (e.g. deeply nested custom_answers) is rejected
+// without building it in memory.
+const (
+	MaxJSONDepth    = 16
+	MaxJSONArrayLen = 1000
+)
+
+var (
+	errBodyTooLarge   = errors.New("request body too large")
+	errEmptyBody      = errors.New("request body is empty")
+	errJSONTooDeep    = fmt.Errorf("JSON is nested more than %d levels deep", MaxJSONDepth)
+	errJSONArrayLimit = fmt.Errorf("JSON array has more than %d elements", MaxJSONArrayLen)
+)
+
+// decodeJSON reads at most limit bytes of the request body, checks it against
+// MaxJSONDepth and MaxJSONArrayLen, and unmarshals it into v. It returns
+// errEmptyBody when there is no body, so handlers with optional bodies can
+// ignore it.
+func decodeJSON(w http.ResponseWriter, r *http.Request, limit int64, v interface{}) error {
+	r.Body = http.MaxBytesReader(w, r.Body, limit)
+	defer r.Body.Close()
+
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return errBodyTooLarge
+		}
+		return err
+	}
+	if len(bytes.TrimSpace(data)) == 0 {
+		return errEmptyBody
+	}
+	if err := checkJSONLimits(data); err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// encodeBodyError writes the response for an error returned by decodeJSON
+func encodeBodyError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, errBodyTooLarge):
+		encodeAPIErrorCode(w, r, ErrCodeTooLarge, "Request body too large", http.StatusRequestEntityTooLarge)
+	case errors.Is(err, errJSONTooDeep), errors.Is(err, errJSONArrayLimit):
+		encodeAPIErrorCode(w, r, ErrCodeInvalidBody, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+	default:
+		encodeAPIErrorCode(w, r, ErrCodeInvalidBody, "Invalid request body", http.StatusBadRequest)
+	}
+}
+
+// decodeJSONBody decodes a required JSON body into v. On failure it writes the
+// error response and returns false.
+func decodeJSONBody(w http.ResponseWriter, r *http.Request, limit int64, v interface{}) bool {
+	if err := decodeJSON(w, r, limit, v); err != nil {
+		encodeBodyError(w, r, err)
+		return false
+	}
+	return true
+}
+
+// checkJSONLimits walks the tokens of data and fails once nesting exceeds
+// MaxJSONDepth or an array exceeds MaxJSONArrayLen. Syntax errors are
+// returned as they are found.
+func checkJSONLimits(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	// One entry per open container: the element count for arrays, -1 for objects
+	var open []int
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if n := len(open); n > 0 && open[n-1] >= 0 && tok != json.Delim(']') {
+			open[n-1]++
+			if open[n-1] > MaxJSONArrayLen {
+				return errJSONArrayLimit
+			}
+		}
+		switch tok {
+		case json.Delim('['):
+			open = append(open, 0)
+		case json.Delim('{'):
+			open = append(open, -1)
+		case json.Delim(']'), json.Delim('}'):
+			open = open[:len(open)-1]
+		}
+		if len(open) > MaxJSONDepth {
+			return errJSONTooDeep
+		}
+	}
+}
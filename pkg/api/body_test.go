@@ -0,0 +1,137 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCheckJSONLimits(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		wantErr error
+	}{
+		{"flat object", `{"status":"accepted"}`, nil},
+		{"nested at limit", strings.Repeat(`{"a":`, MaxJSONDepth) + `1` + strings.Repeat(`}`, MaxJSONDepth), nil},
+		{"objects too deep", strings.Repeat(`{"a":`, MaxJSONDepth+1) + `1` + strings.Repeat(`}`, MaxJSONDepth+1), errJSONTooDeep},
+		{"arrays too deep", strings.Repeat(`[`, 10000) + strings.Repeat(`]`, 10000), errJSONTooDeep},
+		{"unterminated nesting", strings.Repeat(`[{"a":`, 1000), errJSONTooDeep},
+		{"array at limit", `[` + strings.Repeat(`0,`, MaxJSONArrayLen-1) + `0]`, nil},
+		{"array too long", `{"tags":[` + strings.Repeat(`"x",`, MaxJSONArrayLen) + `"x"]}`, errJSONArrayLimit},
+		{"array of objects too long", `[` + strings.Repeat(`{},`, MaxJSONArrayLen) + `{}]`, errJSONArrayLimit},
+		{"long object is fine", `{` + strings.Repeat(`"k":1,`, MaxJSONArrayLen) + `"k":1}`, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := checkJSONLimits([]byte(tt.body)); !errors.Is(err, tt.wantErr) {
+				t.Errorf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestDecodeJSONBody(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		limit      int64
+		wantStatus int
+		wantCode   string
+	}{
+		{"valid", `{"status":"accepted"}`, MaxSmallBodySize, http.StatusOK, ""},
+		{"empty", ``, MaxSmallBodySize, http.StatusBadRequest, ErrCodeInvalidBody},
+		{"truncated", `{"status":"acc`, MaxSmallBodySize, http.StatusBadRequest, ErrCodeInvalidBody},
+		{"trailing garbage", `{"status":"accepted"} {"status":"rejected"}`, MaxSmallBodySize, http.StatusBadRequest, ErrCodeInvalidBody},
+		{"wrong type", `{"status":42}`, MaxSmallBodySize, http.StatusBadRequest, ErrCodeInvalidBody},
+		{"too large", `{"status":"` + strings.Repeat("x", MaxSmallBodySize) + `"}`, MaxSmallBodySize, http.StatusRequestEntityTooLarge, ErrCodeTooLarge},
+		{"too deep", `{"status":"accepted","x":` + strings.Repeat(`[`, 100) + strings.Repeat(`]`, 100) + `}`, MaxSmallBodySize, http.StatusBadRequest, ErrCodeInvalidBody},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPut, "/api/v0/proposals/1/status", strings.NewReader(tt.body))
+
+			var req struct {
+				Status string `json:"status"`
+			}
+			ok := decodeJSONBody(rec, r, tt.limit, &req)
+			if ok != (tt.wantStatus == http.StatusOK) {
+				t.Fatalf("expected ok=%v, got %v", tt.wantStatus == http.StatusOK, ok)
+			}
+			if ok {
+				if req.Status != "accepted" {
+					t.Errorf("expected status accepted, got %q", req.Status)
+				}
+				return
+			}
+			if rec.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d", tt.wantStatus, rec.Code)
+			}
+			var body map[string]string
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatalf("failed to parse body: %v", err)
+			}
+			if body["code"] != tt.wantCode {
+				t.Errorf("expected code %q, got %q", tt.wantCode, body["code"])
+			}
+		})
+	}
+}
+
+func TestDecodeJSON_EmptyBody(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/api/v0/proposals/1/copy", strings.NewReader("  \n"))
+	var req map[string]interface{}
+	if err := decodeJSON(httptest.NewRecorder(), r, MaxBodySize, &req); !errors.Is(err, errEmptyBody) {
+		t.Errorf("expected errEmptyBody, got %v", err)
+	}
+}
+
+// FuzzCheckJSONLimits checks that anything passing the limits is within them
+// and that the walk never panics on hostile input.
+func FuzzCheckJSONLimits(f *testing.F) {
+	f.Add(`{"custom_answers":{"q":"a"}}`)
+	f.Add(strings.Repeat(`[`, 50))
+	f.Add(`[1,[2,[3,{"a":[4]}]]]`)
+	f.Add(`{"a":}`)
+	f.Add(`]]]}}}`)
+
+	f.Fuzz(func(t *testing.T, body string) {
+		if checkJSONLimits([]byte(body)) != nil {
+			return
+		}
+		var v interface{}
+		if json.Unmarshal([]byte(body), &v) != nil {
+			return
+		}
+		if d := jsonDepth(v); d > MaxJSONDepth {
+			t.Errorf("accepted JSON nested %d levels deep", d)
+		}
+	})
+}
+
+func jsonDepth(v interface{}) int {
+	max := 0
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for _, e := range v {
+			if d := jsonDepth(e); d > max {
+				max = d
+			}
+		}
+		return max + 1
+	case []interface{}:
+		for _, e := range v {
+			if d := jsonDepth(e); d > max {
+				max = d
+			}
+		}
+		return max + 1
+	}
+	return 0
+}
@@ -18,15 +18,44 @@ var gzipPool = sync.Pool{
 type gzipResponseWriter struct {
 	http.ResponseWriter
 	gw *gzip.Writer
+	// started is set once the response headers are decided; passthrough when
+	// the handler encoded the body itself, e.g. a precompressed static file
+	started     bool
+	passthrough bool
+}
+
+// start decides whether to compress, before the headers are written
+func (w *gzipResponseWriter) start() {
+	if w.started {
+		return
+	}
+	w.started = true
+	if w.Header().Get("Content-Encoding") != "" {
+		w.passthrough = true
+		return
+	}
+	w.Header().Set("Content-Encoding", "gzip")
+	w.Header().Del("Content-Length") // length will change
+	w.Header().Add("Vary", "Accept-Encoding")
+}
+
+func (w *gzipResponseWriter) WriteHeader(code int) {
+	w.start()
+	w.ResponseWriter.WriteHeader(code)
 }
 
 func (w *gzipResponseWriter) Write(b []byte) (int, error) {
+	w.start()
+	if w.passthrough {
+		return w.ResponseWriter.Write(b)
+	}
 	return w.gw.Write(b)
 }
 
 // GzipHandler wraps an http.Handler with gzip compression for clients that
 // accept it. Only compresses responses that are likely to benefit (JSON, HTML,
-// CSS, JS, CSV, SVG, plain text).
+// CSS, JS, CSV, SVG, plain text). Responses the handler already set a
+// Content-Encoding on are passed through unchanged.
 func GzipHandler(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if !strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") {
@@ -37,13 +66,13 @@ func GzipHandler(next http.Handler) http.Handler {
 		gz := gzipPool.Get().(*gzip.Writer)
 		defer gzipPool.Put(gz)
 		gz.Reset(w)
-		defer gz.Close()
-
-		w.Header().Set("Content-Encoding", "gzip")
-		w.Header().Del("Content-Length") // length will change
-		w.Header().Add("Vary", "Accept-Encoding")
 
 		grw := &gzipResponseWriter{ResponseWriter: w, gw: gz}
 		next.ServeHTTP(grw, r)
+
+		grw.start()
+		if !grw.passthrough {
+			gz.Close()
+		}
 	})
 }
@@ -0,0 +1,69 @@
+package api
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGzipHandler(t *testing.T) {
+	const body = `{"message":"hello"}`
+
+	t.Run("compresses for clients that accept gzip", func(t *testing.T) {
+		handler := GzipHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Length", "19")
+			w.Write([]byte(body))
+		}))
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Accept-Encoding", "gzip")
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+
+		if rr.Header().Get("Content-Encoding") != "gzip" {
+			t.Fatalf("expected gzip encoding, got %q", rr.Header().Get("Content-Encoding"))
+		}
+		if rr.Header().Get("Content-Length") != "" {
+			t.Error("expected the uncompressed Content-Length to be dropped")
+		}
+		zr, err := gzip.NewReader(rr.Body)
+		if err != nil {
+			t.Fatalf("invalid gzip body: %v", err)
+		}
+		got, _ := io.ReadAll(zr)
+		if string(got) != body {
+			t.Errorf("expected %q, got %q", body, got)
+		}
+	})
+
+	t.Run("passes through already encoded responses", func(t *testing.T) {
+		handler := GzipHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Encoding", "br")
+			w.Write([]byte("brotli bytes"))
+		}))
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Accept-Encoding", "gzip, br")
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+
+		if rr.Header().Get("Content-Encoding") != "br" {
+			t.Errorf("expected br encoding, got %q", rr.Header().Get("Content-Encoding"))
+		}
+		if rr.Body.String() != "brotli bytes" {
+			t.Errorf("expected the body unchanged, got %q", rr.Body.String())
+		}
+	})
+
+	t.Run("leaves clients without gzip alone", func(t *testing.T) {
+		handler := GzipHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(body))
+		}))
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		if rr.Header().Get("Content-Encoding") != "" || rr.Body.String() != body {
+			t.Errorf("expected an uncompressed body, got %q encoded as %q", rr.Body.String(), rr.Header().Get("Content-Encoding"))
+		}
+	})
+}
@@ -15,6 +15,7 @@ type AppConfig struct {
 	EventListingFeeCurrency      string   `json:"event_listing_fee_currency,omitempty"`
 	SubmissionListingFee         int      `json:"submission_listing_fee,omitempty"`
 	SubmissionListingFeeCurrency string   `json:"submission_listing_fee_currency,omitempty"`
+	ListingTermsVersion          string   `json:"listing_terms_version,omitempty"`
 	PaymentsEnabled              bool     `json:"payments_enabled"`
 	MaxProposalsPerEvent         int      `json:"max_proposals_per_event"`
 	MaxOrganizersPerEvent        int      `json:"max_organizers_per_event"`
@@ -23,6 +24,25 @@ type AppConfig struct {
 	LegalAddress                 string   `json:"legal_address"`
 	LegalEmail                   string   `json:"legal_email"`
 	LegalCompanyNo               string   `json:"legal_company_no"`
+
+	Features AppFeatures `json:"features"`
+}
+
+// AppFeatures says which optional parts of the platform this server runs, so
+// clients can hide what isn't available. Every key is always present; fees
+// are 0 when payments are disabled.
+type AppFeatures struct {
+	PaymentsEnabled              bool     `json:"payments_enabled"`
+	Providers                    []string `json:"providers"`
+	EventListingFee              int      `json:"event_listing_fee"`
+	EventListingFeeCurrency      string   `json:"event_listing_fee_currency"`
+	SubmissionListingFee         int      `json:"submission_listing_fee"`
+	SubmissionListingFeeCurrency string   `json:"submission_listing_fee_currency"`
+	MaxProposalsPerEvent         int      `json:"max_proposals_per_event"`
+	MaxOrganizersPerEvent        int      `json:"max_organizers_per_event"`
+	MaxSpeakers                  int      `json:"max_speakers"`
+	EventSyncEnabled             bool     `json:"event_sync_enabled"`
+	DigestEnabled                bool     `json:"digest_enabled"`
 }
 
 // ConfigHandler returns the public application configuration
@@ -53,12 +73,28 @@ func ConfigHandler(cfg *config.Config) http.HandlerFunc {
 			LegalEmail:            cfg.LegalEmail,
 			LegalCompanyNo:        cfg.LegalCompanyNo,
 		}
+		resp.Features = AppFeatures{
+			PaymentsEnabled:       paymentsEnabled,
+			Providers:             providers,
+			MaxProposalsPerEvent:  cfg.MaxProposalsPerEvent,
+			MaxOrganizersPerEvent: cfg.MaxOrganizersPerEvent,
+			MaxSpeakers:           maxSpeakers,
+			// main only starts these jobs when they are configured
+			EventSyncEnabled: len(cfg.AutoOrganiserIDs) > 0,
+			DigestEnabled:    cfg.ResendAPIKey != "",
+		}
 		if paymentsEnabled {
 			resp.StripePublishableKey = cfg.StripePublishableKey
 			resp.EventListingFee = cfg.EventListingFee
 			resp.EventListingFeeCurrency = cfg.EventListingFeeCurrency
 			resp.SubmissionListingFee = cfg.SubmissionListingFee
 			resp.SubmissionListingFeeCurrency = cfg.SubmissionListingFeeCurrency
+			resp.ListingTermsVersion = cfg.ListingTermsVersion
+
+			resp.Features.EventListingFee = cfg.EventListingFee
+			resp.Features.EventListingFeeCurrency = cfg.EventListingFeeCurrency
+			resp.Features.SubmissionListingFee = cfg.SubmissionListingFee
+			resp.Features.SubmissionListingFeeCurrency = cfg.SubmissionListingFeeCurrency
 		}
 
 		// Extract bare email address from EmailFrom (format: "Name <addr>")
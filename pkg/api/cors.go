@@ -2,40 +2,113 @@ package api
 
 import (
 	"net/http"
+	"strings"
 
 	"github.com/sreday/cfp.ninja/pkg/config"
 )
 
+// corsMaxAge is how long browsers may cache a preflight response, in seconds
+const corsMaxAge = "600"
+
+// exposedHeaders are the response headers cross-origin scripts may read
+var exposedHeaders = []string{
+	"Location",
+	headerAcceptedCount, headerMaxAccepted, headerRemainingSlots, headerCapacityWarning,
+}
+
 // CorsHandler wraps a handler with CORS headers for cross-origin requests.
 //
 // Security considerations:
 //   - When specific origins are configured, only those origins receive CORS headers
+//   - Anonymous GET requests (no Authorization header or session cookie) are
+//     also allowed from the public origins, "*" by default, so conference
+//     sites can read public data without being configured
 //   - The Vary header is set when using specific origins to prevent cache poisoning
-//   - Credentials are not explicitly allowed (Access-Control-Allow-Credentials not set)
+//   - Credentials are only allowed when CORS_ALLOW_CREDENTIALS is set, and
+//     only for origins in ALLOWED_ORIGINS, never for "*"
 //   - OPTIONS preflight requests return immediately with headers but no body
 //
 // The ALLOWED_ORIGINS environment variable controls which origins are permitted.
 // Use "*" for development or public APIs; use specific origins in production.
 func CorsHandler(cfg *config.Config, h http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		origin := r.Header.Get("Origin")
-		allowedOrigin := getAllowedOrigin(origin, cfg.AllowedOrigins)
-
-		w.Header().Set("Access-Control-Allow-Origin", allowedOrigin)
-		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization")
-
-		if allowedOrigin != "*" {
-			w.Header().Set("Vary", "Origin")
-		}
+		setCorsHeaders(cfg, w, r)
 
 		if r.Method == "OPTIONS" {
+			w.WriteHeader(http.StatusNoContent)
 			return // Preflight only
 		}
 		h(w, r)
 	}
 }
 
+// CorsPreflight wraps the API to answer CORS preflight requests for every
+// /api/v0/ route, so routes don't each need an OPTIONS handler. Other
+// requests, including OPTIONS requests that aren't preflights, pass through.
+func CorsPreflight(cfg *config.Config, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" &&
+			strings.HasPrefix(r.URL.Path, "/api/v0/") {
+			setCorsHeaders(cfg, w, r)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// setCorsHeaders sets the CORS response headers for r
+func setCorsHeaders(cfg *config.Config, w http.ResponseWriter, r *http.Request) {
+	origin := r.Header.Get("Origin")
+	allowedOrigin := getAllowedOrigin(origin, cfg.AllowedOrigins)
+	public := false
+	if allowedOrigin == "" && len(cfg.CORSPublicOrigins) > 0 && isAnonymousRead(r) {
+		allowedOrigin = getAllowedOrigin(origin, cfg.CORSPublicOrigins)
+		public = true
+	}
+
+	methods, headers := cfg.CORSAllowedMethods, cfg.CORSAllowedHeaders
+	if len(methods) == 0 {
+		methods = config.DefaultCORSAllowedMethods
+	}
+	if len(headers) == 0 {
+		headers = config.DefaultCORSAllowedHeaders
+	}
+
+	w.Header().Set("Access-Control-Allow-Origin", allowedOrigin)
+	w.Header().Set("Access-Control-Allow-Methods", strings.Join(methods, ", "))
+	w.Header().Set("Access-Control-Allow-Headers", strings.Join(headers, ", "))
+	w.Header().Set("Access-Control-Expose-Headers", strings.Join(exposedHeaders, ", "))
+	if r.Method == http.MethodOptions {
+		w.Header().Set("Access-Control-Max-Age", corsMaxAge)
+	}
+	if cfg.CORSAllowCredentials && !public && allowedOrigin != "" && allowedOrigin != "*" {
+		w.Header().Set("Access-Control-Allow-Credentials", "true")
+	}
+
+	if allowedOrigin != "*" {
+		w.Header().Set("Vary", "Origin")
+	}
+}
+
+// isAnonymousRead reports whether r is a GET or HEAD request without
+// credentials, or the preflight of one. Such requests only ever see public
+// data, so they may come from the public CORS origins.
+func isAnonymousRead(r *http.Request) bool {
+	method := r.Method
+	if method == http.MethodOptions {
+		method = r.Header.Get("Access-Control-Request-Method")
+		for _, h := range strings.Split(r.Header.Get("Access-Control-Request-Headers"), ",") {
+			if strings.EqualFold(strings.TrimSpace(h), "Authorization") {
+				return false
+			}
+		}
+	} else if r.Header.Get("Authorization") != "" || getJWTFromCookie(r) != "" {
+		return false
+	}
+	return method == http.MethodGet || method == http.MethodHead
+}
+
 // getAllowedOrigin determines what to return in Access-Control-Allow-Origin.
 //
 // Logic:
@@ -48,7 +121,7 @@ func getAllowedOrigin(origin string, allowedOrigins []string) string {
 		if allowed == "*" {
 			return "*"
 		}
-		if allowed == origin {
+		if origin != "" && originMatches(allowed, origin) {
 			return origin
 		}
 	}
@@ -58,3 +131,20 @@ func getAllowedOrigin(origin string, allowedOrigins []string) string {
 	}
 	return "*"
 }
+
+// originMatches reports whether origin matches an allowed origin, which may
+// have a wildcard subdomain: "https://*.example.com" matches
+// "https://cfp.example.com" and "https://a.b.example.com", but not
+// "https://example.com" or "http://cfp.example.com"
+func originMatches(allowed, origin string) bool {
+	prefix, suffix, wildcard := strings.Cut(allowed, "*")
+	if !wildcard {
+		return allowed == origin
+	}
+	if !strings.HasPrefix(suffix, ".") || len(origin) <= len(prefix)+len(suffix) {
+		return false
+	}
+	sub := origin[len(prefix) : len(origin)-len(suffix)]
+	return strings.HasPrefix(origin, prefix) && strings.HasSuffix(origin, suffix) &&
+		!strings.ContainsAny(sub, "/:@")
+}
@@ -166,3 +166,134 @@ func TestCorsHandler_BlockedOrigin(t *testing.T) {
 		t.Errorf("expected empty Allow-Origin for blocked origin, got: %s", rr.Header().Get("Access-Control-Allow-Origin"))
 	}
 }
+
+func TestGetAllowedOrigin_WildcardSubdomain(t *testing.T) {
+	allowed := []string{"https://*.example.com"}
+	for origin, want := range map[string]string{
+		"https://cfp.example.com":       "https://cfp.example.com",
+		"https://a.b.example.com":       "https://a.b.example.com",
+		"https://example.com":           "",
+		"http://cfp.example.com":        "",
+		"https://cfp.example.com.evil":  "",
+		"https://evil.com/.example.com": "",
+	} {
+		if got := getAllowedOrigin(origin, allowed); got != want {
+			t.Errorf("getAllowedOrigin(%q) = %q, want %q", origin, got, want)
+		}
+	}
+}
+
+func TestCorsHandler_PublicOrigins(t *testing.T) {
+	cfg := &config.Config{
+		AllowedOrigins:       []string{"https://cfp.example.com"},
+		CORSPublicOrigins:    []string{"*"},
+		CORSAllowCredentials: true,
+	}
+	handler := CorsHandler(cfg, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	tests := []struct {
+		name        string
+		method      string
+		origin      string
+		auth        bool
+		wantOrigin  string
+		credentials bool
+	}{
+		{"anonymous GET from any site", http.MethodGet, "https://conference.org", false, "*", false},
+		{"authenticated GET from an unknown site", http.MethodGet, "https://conference.org", true, "", false},
+		{"write from an unknown site", http.MethodPost, "https://conference.org", false, "", false},
+		{"authenticated GET from an allowed origin", http.MethodGet, "https://cfp.example.com", true, "https://cfp.example.com", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/api/v0/events", nil)
+			req.Header.Set("Origin", tt.origin)
+			if tt.auth {
+				req.Header.Set("Authorization", "Bearer token")
+			}
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			if got := rr.Header().Get("Access-Control-Allow-Origin"); got != tt.wantOrigin {
+				t.Errorf("expected Allow-Origin %q, got %q", tt.wantOrigin, got)
+			}
+			if got := rr.Header().Get("Access-Control-Allow-Credentials") == "true"; got != tt.credentials {
+				t.Errorf("expected Allow-Credentials %v, got %v", tt.credentials, got)
+			}
+		})
+	}
+}
+
+func TestCorsPreflight(t *testing.T) {
+	cfg := &config.Config{
+		AllowedOrigins:     []string{"https://cfp.example.com"},
+		CORSPublicOrigins:  []string{"*"},
+		CORSAllowedMethods: []string{"GET", "PUT"},
+		CORSAllowedHeaders: []string{"Authorization", "Content-Type"},
+	}
+	nextCalled := false
+	handler := CorsPreflight(cfg, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		nextCalled = true
+	}))
+
+	preflight := func(path, origin, method, headers string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodOptions, path, nil)
+		req.Header.Set("Origin", origin)
+		req.Header.Set("Access-Control-Request-Method", method)
+		if headers != "" {
+			req.Header.Set("Access-Control-Request-Headers", headers)
+		}
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+
+	rr := preflight("/api/v0/proposals/1", "https://cfp.example.com", "PUT", "authorization, content-type")
+	if rr.Code != http.StatusNoContent {
+		t.Errorf("expected 204, got %d", rr.Code)
+	}
+	if got := rr.Header().Get("Access-Control-Allow-Origin"); got != "https://cfp.example.com" {
+		t.Errorf("expected the allowed origin echoed, got %q", got)
+	}
+	if got := rr.Header().Get("Access-Control-Allow-Methods"); got != "GET, PUT" {
+		t.Errorf("expected the configured methods, got %q", got)
+	}
+	if got := rr.Header().Get("Access-Control-Allow-Headers"); got != "Authorization, Content-Type" {
+		t.Errorf("expected the configured headers, got %q", got)
+	}
+	if rr.Header().Get("Access-Control-Max-Age") == "" {
+		t.Error("expected preflights to be cacheable")
+	}
+
+	// Disallowed origins get no Allow-Origin, unless preflighting an anonymous read
+	if got := preflight("/api/v0/proposals/1", "https://evil.com", "PUT", "authorization").Header().Get("Access-Control-Allow-Origin"); got != "" {
+		t.Errorf("expected no Allow-Origin for a disallowed origin, got %q", got)
+	}
+	if got := preflight("/api/v0/events", "https://evil.com", "GET", "authorization").Header().Get("Access-Control-Allow-Origin"); got != "" {
+		t.Errorf("expected no Allow-Origin for an authenticated read, got %q", got)
+	}
+	if got := preflight("/api/v0/events", "https://conference.org", "GET", "accept").Header().Get("Access-Control-Allow-Origin"); got != "*" {
+		t.Errorf("expected * for an anonymous read, got %q", got)
+	}
+	if nextCalled {
+		t.Error("preflights should not reach the API")
+	}
+
+	// Other requests pass through
+	for _, req := range []*http.Request{
+		httptest.NewRequest(http.MethodOptions, "/api/v0/events", nil),
+		httptest.NewRequest(http.MethodGet, "/api/v0/events", nil),
+		httptest.NewRequest(http.MethodOptions, "/about", nil),
+	} {
+		nextCalled = false
+		if req.URL.Path == "/about" {
+			req.Header.Set("Access-Control-Request-Method", "GET")
+		}
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+		if !nextCalled {
+			t.Errorf("expected %s %s to pass through", req.Method, req.URL.Path)
+		}
+	}
+}
@@ -0,0 +1,311 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/sreday/cfp.ninja/pkg/config"
+	"github.com/sreday/cfp.ninja/pkg/models"
+)
+
+// Listing payment states of a dashboard event
+const (
+	PaymentStatusPaid        = "paid"
+	PaymentStatusUnpaid      = "unpaid"
+	PaymentStatusNotRequired = "not_required"
+)
+
+// dashboardOrganizer is an organizer of a dashboard event, with the
+// timestamps of their account
+type dashboardOrganizer struct {
+	ID        uint      `json:"id"`
+	Name      string    `json:"name"`
+	Email     string    `json:"email"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// dashboardEvent is an event the user manages, with what the organizer
+// dashboard shows about it
+type dashboardEvent struct {
+	ID             uint                 `json:"id"`
+	Name           string               `json:"name"`
+	Slug           string               `json:"slug"`
+	StartDate      time.Time            `json:"start_date"`
+	EndDate        time.Time            `json:"end_date"`
+	CFPStatus      models.CFPStatus     `json:"cfp_status"`
+	CFPOpen        bool                 `json:"cfp_open"`
+	CFPCloseAt     *time.Time           `json:"cfp_close_at"`
+	IsCreator      bool                 `json:"is_creator"`
+	PaymentStatus  string               `json:"payment_status"`
+	ProposalCounts map[string]int64     `json:"proposal_counts"`
+	ProposalTotal  int64                `json:"proposal_total"`
+	UnratedCount   int64                `json:"unrated_count"`
+	Organizers     []dashboardOrganizer `json:"organizers"`
+	CreatedAt      time.Time            `json:"created_at"`
+	UpdatedAt      time.Time            `json:"updated_at"`
+}
+
+// dashboardDeadline is the soonest CFP deadline across the dashboard events
+type dashboardDeadline struct {
+	EventID    uint      `json:"event_id"`
+	Name       string    `json:"name"`
+	CFPCloseAt time.Time `json:"cfp_close_at"`
+}
+
+// dashboardProposalStatuses are the statuses counted for every event, so
+// each appears in proposal_counts even when zero
+var dashboardProposalStatuses = []models.ProposalStatus{
+	models.ProposalStatusSubmitted,
+	models.ProposalStatusTentative,
+	models.ProposalStatusAccepted,
+	models.ProposalStatusRejected,
+	models.ProposalStatusCancelled,
+}
+
+// managedEventsFilter narrows the events a user manages by ?q=, matched
+// against name and slug, and ?cfp_status=, and pages them with ?page= and
+// ?per_page=. Without either page parameter every event is returned.
+type managedEventsFilter struct {
+	Query     string
+	CFPStatus models.CFPStatus
+	Page      int
+	PerPage   int // 0 when not paginated
+}
+
+// parseManagedEventsFilter reads a managedEventsFilter from the query string
+func parseManagedEventsFilter(r *http.Request) (managedEventsFilter, validationErrors) {
+	query := r.URL.Query()
+	var errs validationErrors
+	f := managedEventsFilter{Query: query.Get("q"), CFPStatus: models.CFPStatus(query.Get("cfp_status"))}
+	validStatuses := map[models.CFPStatus]bool{
+		models.CFPStatusDraft:     true,
+		models.CFPStatusOpen:      true,
+		models.CFPStatusClosed:    true,
+		models.CFPStatusReviewing: true,
+		models.CFPStatusComplete:  true,
+	}
+	if f.CFPStatus != "" && !validStatuses[f.CFPStatus] {
+		errs.add("cfp_status", "Must be draft, open, closed, reviewing or complete")
+	}
+	if v := query.Get("page"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			errs.add("page", "Must be a positive number")
+		}
+		f.Page, f.PerPage = n, DefaultPageSize
+	}
+	if v := query.Get("per_page"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > MaxPageSize {
+			errs.add("per_page", "Must be a number from 1 to "+strconv.Itoa(MaxPageSize))
+		}
+		f.PerPage = n
+		if f.Page == 0 {
+			f.Page = 1
+		}
+	}
+	return f, errs
+}
+
+// query returns the user's managed events matching the filter, unordered and
+// not paged
+func (f managedEventsFilter) query(db *gorm.DB, userID uint) *gorm.DB {
+	q := db.Model(&models.Event{}).Where(managedEventsSQL, map[string]interface{}{"user": userID})
+	if f.Query != "" {
+		pattern := "%" + escapeLikePattern(f.Query) + "%"
+		q = q.Where("(name ILIKE ? OR slug ILIKE ?)", pattern, pattern)
+	}
+	if f.CFPStatus != "" {
+		q = q.Where("cfp_status = ?", f.CFPStatus)
+	}
+	return q
+}
+
+// find loads the page of matching events in the given order, and the number
+// of matching events when paginated
+func (f managedEventsFilter) find(db *gorm.DB, userID uint, order string) ([]models.Event, int64, error) {
+	q := f.query(db, userID)
+	var total int64
+	if f.PerPage > 0 {
+		if err := q.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+			return nil, 0, err
+		}
+		q = q.Offset((f.Page - 1) * f.PerPage).Limit(f.PerPage)
+	}
+	var events []models.Event
+	if err := q.Order(order).Find(&events).Error; err != nil {
+		return nil, 0, err
+	}
+	return events, total, nil
+}
+
+// pagination describes the page of a paginated listing of total events
+func (f managedEventsFilter) pagination(total int64) map[string]interface{} {
+	return map[string]interface{}{
+		"page":        f.Page,
+		"per_page":    f.PerPage,
+		"total":       total,
+		"total_pages": int((total + int64(f.PerPage) - 1) / int64(f.PerPage)),
+	}
+}
+
+// GetMyDashboardHandler returns the events the user manages with proposal
+// counts by status, unrated proposals awaiting a decision, the listing payment
+// status and organizers, so the dashboard needs one request. Each is loaded
+// for all events with one batched query.
+// GET /api/v0/me/dashboard
+func GetMyDashboardHandler(cfg *config.Config) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user := GetUserFromContext(r.Context())
+		if user == nil {
+			encodeAPIError(w, r, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		filter, errs := parseManagedEventsFilter(r)
+		if len(errs) > 0 {
+			encodeValidationErrors(w, r, errs)
+			return
+		}
+		events, total, err := filter.find(cfg.DBCtx(r), user.ID, "start_date DESC, id DESC")
+		if err != nil {
+			cfg.Logger.Error("failed to fetch dashboard events", "error", err, "user_id", user.ID)
+			encodeAPIError(w, r, "Failed to load dashboard", http.StatusInternalServerError)
+			return
+		}
+
+		now := cfg.Now()
+		ids := make([]uint, len(events))
+		for i, e := range events {
+			ids[i] = e.ID
+		}
+
+		type countRow struct {
+			EventID uint
+			Status  models.ProposalStatus
+			Count   int64
+			Unrated int64
+		}
+		var counts []countRow
+		type organizerRow struct {
+			EventID   uint
+			ID        uint
+			Name      string
+			Email     string
+			CreatedAt time.Time
+			UpdatedAt time.Time
+		}
+		var organizers []organizerRow
+		if len(ids) > 0 {
+			if err := cfg.DBCtx(r).Model(&models.Proposal{}).
+				Select("event_id, status, COUNT(*) AS count, COUNT(*) FILTER (WHERE rating IS NULL) AS unrated").
+				Where("event_id IN ?", ids).
+				Group("event_id, status").
+				Scan(&counts).Error; err != nil {
+				cfg.Logger.Error("failed to count dashboard proposals", "error", err, "user_id", user.ID)
+				encodeAPIError(w, r, "Failed to load dashboard", http.StatusInternalServerError)
+				return
+			}
+			if err := cfg.DBCtx(r).Table("event_organizers").
+				Select("event_organizers.event_id, users.id, users.name, users.email, users.created_at, users.updated_at").
+				Joins("JOIN users ON users.id = event_organizers.user_id AND users.deleted_at IS NULL").
+				Where("event_organizers.event_id IN ?", ids).
+				Order("users.name, users.id").
+				Scan(&organizers).Error; err != nil {
+				cfg.Logger.Error("failed to load dashboard organizers", "error", err, "user_id", user.ID)
+				encodeAPIError(w, r, "Failed to load dashboard", http.StatusInternalServerError)
+				return
+			}
+		}
+
+		byID := make(map[uint]*dashboardEvent, len(events))
+		resp := make([]dashboardEvent, len(events))
+		var next *dashboardDeadline
+		for i, e := range events {
+			d := dashboardEvent{
+				ID:             e.ID,
+				Name:           e.Name,
+				Slug:           e.Slug,
+				StartDate:      e.StartDate,
+				EndDate:        e.EndDate,
+				CFPStatus:      e.CFPStatus,
+				CFPOpen:        e.IsCFPOpenAt(now, 0),
+				IsCreator:      e.CreatedByID != nil && *e.CreatedByID == user.ID,
+				PaymentStatus:  PaymentStatusNotRequired,
+				ProposalCounts: make(map[string]int64, len(dashboardProposalStatuses)),
+				Organizers:     []dashboardOrganizer{},
+				CreatedAt:      e.CreatedAt,
+				UpdatedAt:      e.UpdatedAt,
+			}
+			if !e.CFPCloseAt.IsZero() {
+				closeAt := e.CFPCloseAt
+				d.CFPCloseAt = &closeAt
+				if d.CFPOpen && (next == nil || closeAt.Before(next.CFPCloseAt)) {
+					next = &dashboardDeadline{EventID: e.ID, Name: e.Name, CFPCloseAt: closeAt}
+				}
+			}
+			if e.IsPaid {
+				d.PaymentStatus = PaymentStatusPaid
+			} else if cfg.EventListingFee > 0 {
+				d.PaymentStatus = PaymentStatusUnpaid
+			}
+			for _, s := range dashboardProposalStatuses {
+				d.ProposalCounts[string(s)] = 0
+			}
+			resp[i] = d
+			byID[e.ID] = &resp[i]
+		}
+
+		for _, c := range counts {
+			d := byID[c.EventID]
+			d.ProposalCounts[string(c.Status)] += c.Count
+			d.ProposalTotal += c.Count
+			// Only proposals still awaiting a decision need a rating
+			if c.Status == models.ProposalStatusSubmitted || c.Status == models.ProposalStatusTentative {
+				d.UnratedCount += c.Unrated
+			}
+		}
+		for _, o := range organizers {
+			d := byID[o.EventID]
+			d.Organizers = append(d.Organizers, dashboardOrganizer{ID: o.ID, Name: o.Name, Email: o.Email, CreatedAt: o.CreatedAt, UpdatedAt: o.UpdatedAt})
+		}
+
+		body := map[string]interface{}{
+			"events":            resp,
+			"next_cfp_deadline": next,
+		}
+		if filter.PerPage > 0 {
+			// The soonest deadline may be on another page
+			next, err = nextManagedDeadline(cfg.DBCtx(r), filter, user.ID, now)
+			if err != nil {
+				cfg.Logger.Error("failed to find next dashboard deadline", "error", err, "user_id", user.ID)
+				encodeAPIError(w, r, "Failed to load dashboard", http.StatusInternalServerError)
+				return
+			}
+			body["next_cfp_deadline"] = next
+			body["pagination"] = filter.pagination(total)
+		}
+		encodeResponse(w, r, body)
+	}
+}
+
+// nextManagedDeadline finds the soonest deadline of the open CFPs among all
+// events matching the filter, or nil if none has one
+func nextManagedDeadline(db *gorm.DB, filter managedEventsFilter, userID uint, now time.Time) (*dashboardDeadline, error) {
+	var events []models.Event
+	if err := filter.query(db, userID).
+		Where(cfpOpenSQL, cfpOpenVars(now)).
+		Where("cfp_close_at IS NOT NULL AND cfp_close_at != ?", time.Time{}).
+		Order("cfp_close_at, id").Limit(1).
+		Find(&events).Error; err != nil {
+		return nil, err
+	}
+	if len(events) == 0 {
+		return nil, nil
+	}
+	return &dashboardDeadline{EventID: events[0].ID, Name: events[0].Name, CFPCloseAt: events[0].CFPCloseAt}, nil
+}
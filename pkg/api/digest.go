@@ -0,0 +1,43 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/sreday/cfp.ninja/pkg/config"
+	"github.com/sreday/cfp.ninja/pkg/email"
+	"github.com/sreday/cfp.ninja/pkg/tasks"
+)
+
+// GetMyDigestPreviewHandler renders the weekly digest the user would get for
+// the past 7 days as HTML, without sending it. It works for every user, not
+// only organisers, so anyone can see the CFPs the digest would suggest.
+// GET /api/v0/me/digest/preview
+func GetMyDigestPreviewHandler(cfg *config.Config) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user := GetUserFromContext(r.Context())
+		if user == nil {
+			encodeAPIError(w, r, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		digest, err := tasks.BuildDigest(cfg.DBCtx(r), cfg.BaseURL, user, cfg.Now())
+		if err != nil {
+			cfg.Logger.Error("failed to build digest preview", "user_id", user.ID, "error", err)
+			encodeAPIError(w, r, "Failed to build digest", http.StatusInternalServerError)
+			return
+		}
+		ncfg := &email.NotifyConfig{BaseURL: cfg.BaseURL, Logger: cfg.Logger}
+		html, _, err := email.RenderWeeklyDigest(ncfg, user, digest)
+		if err != nil {
+			cfg.Logger.Error("failed to render digest preview", "user_id", user.ID, "error", err)
+			encodeAPIError(w, r, "Failed to build digest", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Header().Set("Content-Security-Policy", "default-src 'none'; style-src 'unsafe-inline'")
+		w.Header().Set("Cache-Control", "no-store")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(html))
+	}
+}
@@ -0,0 +1,53 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/sreday/cfp.ninja/pkg/config"
+	"github.com/sreday/cfp.ninja/pkg/models"
+)
+
+// GetProposalNotificationsHandler lists the emails sent about a proposal,
+// newest first, including failed sends, so organizers can check whether a
+// speaker was notified
+// GET /api/v0/proposals/{id}/notifications
+func GetProposalNotificationsHandler(cfg *config.Config) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user := GetUserFromContext(r.Context())
+		if user == nil {
+			encodeAPIError(w, r, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		id, err := strconv.ParseUint(r.PathValue("id"), 10, 32)
+		if err != nil {
+			encodeAPIError(w, r, "Invalid proposal ID", http.StatusBadRequest)
+			return
+		}
+
+		proposal, event, err := getProposalWithEvent(cfg, r, uint(id))
+		if proposal == nil {
+			encodeAPIError(w, r, "Proposal not found", http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			encodeAPIError(w, r, "Event not found", http.StatusNotFound)
+			return
+		}
+
+		if !event.IsOrganizer(user.ID) {
+			encodeAPIError(w, r, "Forbidden", http.StatusForbidden)
+			return
+		}
+
+		logs, err := models.GetProposalEmailLogs(cfg.DBCtx(r), proposal.ID)
+		if err != nil {
+			cfg.Logger.Error("failed to load email log", "proposal_id", proposal.ID, "error", err)
+			encodeAPIError(w, r, "Failed to load notifications", http.StatusInternalServerError)
+			return
+		}
+
+		encodeResponse(w, r, logs)
+	}
+}
@@ -0,0 +1,163 @@
+package api
+
+import (
+	"encoding/json"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// Machine-readable error codes sent alongside the error message, so clients
+// can branch on the kind of failure without matching on English text.
+// Codes are part of the API contract: add new ones, never rename them.
+const (
+	ErrCodeValidation       = "validation"
+	ErrCodeInvalidBody      = "invalid_body"
+	ErrCodeUnauthorized     = "unauthorized"
+	ErrCodePaymentRequired  = "payment_required"
+	ErrCodeForbidden        = "forbidden"
+	ErrCodeNotFound         = "not_found"
+	ErrCodeMethodNotAllowed = "method_not_allowed"
+	ErrCodeConflict         = "conflict"
+	ErrCodeSlugConflict     = "slug_conflict"
+	ErrCodeCFPClosed        = "cfp_closed"
+	ErrCodeProposalLimit    = "proposal_limit"
+	ErrCodeTooLarge         = "too_large"
+	ErrCodeRateLimited      = "rate_limited"
+	ErrCodeInternal         = "internal"
+	ErrCodeUnavailable      = "unavailable"
+	// An action must be repeated with confirm: true, e.g. a large bulk email
+	ErrCodeConfirmationRequired = "confirmation_required"
+	// The event creator must accept the current listing terms before checkout
+	ErrCodeTermsNotAccepted = "terms_not_accepted"
+	// Someone else is editing the resource; retry with force=true to override
+	ErrCodeLocked = "locked"
+	// The request was made in a read-only impersonation session
+	ErrCodeImpersonationReadOnly = "impersonation_read_only"
+)
+
+// errorCodeForStatus returns the generic error code for an HTTP status
+func errorCodeForStatus(statusCode int) string {
+	switch statusCode {
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return ErrCodeValidation
+	case http.StatusUnauthorized:
+		return ErrCodeUnauthorized
+	case http.StatusPaymentRequired:
+		return ErrCodePaymentRequired
+	case http.StatusForbidden:
+		return ErrCodeForbidden
+	case http.StatusNotFound:
+		return ErrCodeNotFound
+	case http.StatusMethodNotAllowed:
+		return ErrCodeMethodNotAllowed
+	case http.StatusConflict:
+		return ErrCodeConflict
+	case http.StatusRequestEntityTooLarge:
+		return ErrCodeTooLarge
+	case http.StatusLocked:
+		return ErrCodeLocked
+	case http.StatusTooManyRequests:
+		return ErrCodeRateLimited
+	case http.StatusServiceUnavailable:
+		return ErrCodeUnavailable
+	}
+	return ErrCodeInternal
+}
+
+// errorBody is the structured error returned to v2 clients
+type errorBody struct {
+	Code    string            `json:"code"`
+	Message string            `json:"message"`
+	Fields  map[string]string `json:"fields,omitempty"`
+	// Details carries error-specific context, e.g. what a confirmation covers
+	Details interface{} `json:"details,omitempty"`
+}
+
+// wantsErrorEnvelope reports whether the client asked for v2 errors, either with
+// ?v=2 or an Accept header carrying version=2 (e.g. "application/json; version=2")
+func wantsErrorEnvelope(r *http.Request) bool {
+	if r.URL.Query().Get("v") == "2" {
+		return true
+	}
+	for _, accept := range strings.Split(r.Header.Get("Accept"), ",") {
+		if _, params, err := mime.ParseMediaType(strings.TrimSpace(accept)); err == nil && params["version"] == "2" {
+			return true
+		}
+	}
+	return false
+}
+
+// encodeError sends a JSON error response: {"error": message, "code": code}.
+// Handlers with the request at hand should prefer encodeAPIError, which also
+// serves v2 clients.
+func encodeError(w http.ResponseWriter, message string, statusCode int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(map[string]string{"error": message, "code": errorCodeForStatus(statusCode)})
+}
+
+// encodeAPIError sends an error response with the generic code for the status
+func encodeAPIError(w http.ResponseWriter, r *http.Request, message string, statusCode int) {
+	writeError(w, r, statusCode, errorBody{Code: errorCodeForStatus(statusCode), Message: message})
+}
+
+// encodeAPIErrorCode sends an error response with a specific error code
+func encodeAPIErrorCode(w http.ResponseWriter, r *http.Request, code, message string, statusCode int) {
+	writeError(w, r, statusCode, errorBody{Code: code, Message: message})
+}
+
+// encodeAPIErrorDetails sends an error response with a specific error code and
+// error-specific details
+func encodeAPIErrorDetails(w http.ResponseWriter, r *http.Request, code, message string, details interface{}, statusCode int) {
+	writeError(w, r, statusCode, errorBody{Code: code, Message: message, Details: details})
+}
+
+// writeError writes an error in the shape the client asked for. v2 clients get
+// {"error": {"code", "message", "fields"}}; everyone else keeps the flat
+// {"error": message, "code": code} shape existing clients parse.
+func writeError(w http.ResponseWriter, r *http.Request, statusCode int, body errorBody) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Add("Vary", "Accept")
+	w.WriteHeader(statusCode)
+
+	if wantsErrorEnvelope(r) {
+		json.NewEncoder(w).Encode(map[string]errorBody{"error": body})
+		return
+	}
+	json.NewEncoder(w).Encode(struct {
+		Error   string            `json:"error"`
+		Code    string            `json:"code"`
+		Fields  map[string]string `json:"fields,omitempty"`
+		Details interface{}       `json:"details,omitempty"`
+	}{body.Message, body.Code, body.Fields, body.Details})
+}
+
+// fieldError is a validation failure of a single request field
+type fieldError struct {
+	Field   string
+	Message string
+}
+
+// validationErrors accumulates field errors, so a request with several problems
+// is rejected in one response rather than one round trip per problem
+type validationErrors []fieldError
+
+func (v *validationErrors) add(field, message string) {
+	*v = append(*v, fieldError{Field: field, Message: message})
+}
+
+// encodeValidationErrors sends all accumulated field errors in one 400 response.
+// The message is the first violation, so clients that only read the message see
+// the same error as before errors were accumulated.
+func encodeValidationErrors(w http.ResponseWriter, r *http.Request, errs validationErrors) {
+	fields := make(map[string]string, len(errs))
+	for _, e := range errs {
+		if prev, ok := fields[e.Field]; ok {
+			fields[e.Field] = prev + "; " + e.Message
+		} else {
+			fields[e.Field] = e.Message
+		}
+	}
+	writeError(w, r, http.StatusBadRequest, errorBody{Code: ErrCodeValidation, Message: errs[0].Message, Fields: fields})
+}
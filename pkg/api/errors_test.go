@@ -0,0 +1,126 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWantsErrorEnvelope(t *testing.T) {
+	tests := []struct {
+		name   string
+		url    string
+		accept string
+		want   bool
+	}{
+		{"default", "/api/v0/events", "", false},
+		{"plain json", "/api/v0/events", "application/json", false},
+		{"query flag", "/api/v0/events?v=2", "", true},
+		{"accept version", "/api/v0/events", "application/json; version=2", true},
+		{"accept list", "/api/v0/events", "text/html, application/json;version=2", true},
+		{"other version", "/api/v0/events?v=1", "application/json; version=1", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			if tt.accept != "" {
+				r.Header.Set("Accept", tt.accept)
+			}
+			if got := wantsErrorEnvelope(r); got != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestEncodeAPIErrorCode_Shapes(t *testing.T) {
+	// Legacy clients get the flat shape
+	rec := httptest.NewRecorder()
+	encodeAPIErrorCode(rec, httptest.NewRequest(http.MethodPost, "/api/v0/events", nil), ErrCodeSlugConflict, "Slug already exists", http.StatusConflict)
+
+	var flat map[string]string
+	if err := json.Unmarshal(rec.Body.Bytes(), &flat); err != nil {
+		t.Fatalf("failed to parse body: %v", err)
+	}
+	if flat["error"] != "Slug already exists" || flat["code"] != ErrCodeSlugConflict {
+		t.Errorf("unexpected flat error: %v", flat)
+	}
+
+	// v2 clients get the envelope
+	rec = httptest.NewRecorder()
+	encodeAPIErrorCode(rec, httptest.NewRequest(http.MethodPost, "/api/v0/events?v=2", nil), ErrCodeSlugConflict, "Slug already exists", http.StatusConflict)
+
+	if rec.Code != http.StatusConflict {
+		t.Errorf("expected status 409, got %d", rec.Code)
+	}
+	var envelope struct {
+		Error errorBody `json:"error"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &envelope); err != nil {
+		t.Fatalf("failed to parse body: %v", err)
+	}
+	if envelope.Error.Code != ErrCodeSlugConflict || envelope.Error.Message != "Slug already exists" {
+		t.Errorf("unexpected envelope: %+v", envelope.Error)
+	}
+}
+
+func TestEncodeValidationErrors(t *testing.T) {
+	var errs validationErrors
+	errs.add("name", "Name is required")
+	errs.add("website", "Website must be a valid HTTP or HTTPS URL")
+	errs.add("name", "Name must be at most 200 characters")
+
+	rec := httptest.NewRecorder()
+	encodeValidationErrors(rec, httptest.NewRequest(http.MethodPost, "/api/v0/events?v=2", nil), errs)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", rec.Code)
+	}
+	var envelope struct {
+		Error errorBody `json:"error"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &envelope); err != nil {
+		t.Fatalf("failed to parse body: %v", err)
+	}
+	if envelope.Error.Code != ErrCodeValidation {
+		t.Errorf("expected code %q, got %q", ErrCodeValidation, envelope.Error.Code)
+	}
+	if envelope.Error.Message != "Name is required" {
+		t.Errorf("expected first violation as message, got %q", envelope.Error.Message)
+	}
+	if len(envelope.Error.Fields) != 2 {
+		t.Fatalf("expected 2 fields, got %v", envelope.Error.Fields)
+	}
+	if envelope.Error.Fields["name"] != "Name is required; Name must be at most 200 characters" {
+		t.Errorf("expected joined messages for name, got %q", envelope.Error.Fields["name"])
+	}
+}
+
+func TestEncodeAPIErrorDetails(t *testing.T) {
+	details := map[string]int{"proposal_total": 3}
+	for _, url := range []string{"/api/v0/events/1", "/api/v0/events/1?v=2"} {
+		rec := httptest.NewRecorder()
+		encodeAPIErrorDetails(rec, httptest.NewRequest(http.MethodDelete, url, nil), ErrCodeConfirmationRequired, "Confirm", details, http.StatusBadRequest)
+
+		var body struct {
+			Code    string `json:"code"`
+			Details struct {
+				ProposalTotal int `json:"proposal_total"`
+			} `json:"details"`
+			Error json.RawMessage `json:"error"`
+		}
+		if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+			t.Fatalf("failed to parse body: %v", err)
+		}
+		if url == "/api/v0/events/1?v=2" {
+			if err := json.Unmarshal(body.Error, &body); err != nil {
+				t.Fatalf("failed to parse envelope: %v", err)
+			}
+		}
+		if body.Code != ErrCodeConfirmationRequired || body.Details.ProposalTotal != 3 {
+			t.Errorf("%s: expected the details alongside the code, got %s", url, rec.Body.String())
+		}
+	}
+}
@@ -1,22 +1,30 @@
 package api
 
 import (
+	"bytes"
+	"encoding/csv"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/mail"
 	"net/url"
 	"regexp"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/jackc/pgx/v5/pgconn"
 	"gorm.io/datatypes"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 	"github.com/sreday/cfp.ninja/pkg/config"
+	"github.com/sreday/cfp.ninja/pkg/countries"
+	"github.com/sreday/cfp.ninja/pkg/email"
 	"github.com/sreday/cfp.ninja/pkg/models"
 )
 
@@ -41,39 +49,345 @@ const (
 	MaxEventTagsLen        = 1000
 )
 
+// Limits for an event's information sections
+const (
+	MaxEventSections        = 10
+	MaxEventSectionTitleLen = 200
+	MaxEventSectionBodyLen  = 5000
+)
+
+// Limits for an event's own proposal status email copy
+const (
+	MaxStatusEmailSubjectLen = 200
+	MaxStatusEmailBodyLen    = 5000
+)
+
+// validateCoCSettings checks an event's code of conduct link, which is
+// required when speakers must accept the code of conduct to submit
+func validateCoCSettings(cocURL string, required bool, errs *validationErrors) {
+	if cocURL != "" {
+		if len(cocURL) > MaxEventWebsiteLen {
+			errs.add("coc_url", "Code of conduct URL must be at most 2000 characters")
+		} else if u, err := url.Parse(cocURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			errs.add("coc_url", "Code of conduct URL must be a valid HTTP or HTTPS URL")
+		}
+	} else if required {
+		errs.add("coc_url", "A code of conduct URL is required for speakers to accept it")
+	}
+}
+
+// MaxAbstractWordLimit is the highest abstract word limit an event can set;
+// longer abstracts would hit MaxProposalAbstractLen first
+const MaxAbstractWordLimit = 2000
+
+// validateAbstractWordLimits checks an event's abstract_min_words and
+// abstract_max_words, either of which may be nil for no limit
+func validateAbstractWordLimits(minWords, maxWords *int, errs *validationErrors) {
+	inRange := func(field string, v *int) bool {
+		if v != nil && (*v < 1 || *v > MaxAbstractWordLimit) {
+			errs.add(field, fmt.Sprintf("Must be between 1 and %d words", MaxAbstractWordLimit))
+			return false
+		}
+		return true
+	}
+	minOK := inRange("abstract_min_words", minWords)
+	maxOK := inRange("abstract_max_words", maxWords)
+	if minOK && maxOK && minWords != nil && maxWords != nil && *maxWords < *minWords {
+		errs.add("abstract_max_words", "Maximum words must be at least the minimum")
+	}
+}
+
+// wordLimitUpdate returns the abstract word limit set by updates[field], or
+// current when the field is not being updated. null clears the limit.
+func wordLimitUpdate(updates map[string]interface{}, field string, current *int, errs *validationErrors) *int {
+	v, ok := updates[field]
+	if !ok {
+		return current
+	}
+	if v == nil {
+		return nil
+	}
+	f, ok := v.(float64)
+	if !ok || f != float64(int(f)) {
+		errs.add(field, "Must be a whole number of words")
+		return current
+	}
+	n := int(f)
+	updates[field] = n
+	return &n
+}
+
+// isSlugConflict reports whether err is a violation of the unique index on
+// events.slug, which is how a taken slug is detected on create and update
+func isSlugConflict(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == models.EventSlugIndex
+}
+
+// validateEventSections checks the JSON of an event's sections, adding a
+// field error per problem, and returns it re-encoded with titles and bodies
+// trimmed. A null or empty value clears the sections.
+func validateEventSections(data []byte, errs *validationErrors) datatypes.JSON {
+	var sections []models.EventSection
+	if err := json.Unmarshal(data, &sections); err != nil {
+		errs.add("sections", "Sections must be a list of {title, body} objects")
+		return nil
+	}
+	if len(sections) > MaxEventSections {
+		errs.add("sections", fmt.Sprintf("At most %d sections are allowed", MaxEventSections))
+		return nil
+	}
+	for i := range sections {
+		field := "sections[" + strconv.Itoa(i) + "]."
+		sections[i].Title = strings.TrimSpace(sections[i].Title)
+		sections[i].Body = strings.TrimSpace(sections[i].Body)
+		if sections[i].Title == "" {
+			errs.add(field+"title", "Section title is required")
+		} else if len(sections[i].Title) > MaxEventSectionTitleLen {
+			errs.add(field+"title", "Section title must be at most 200 characters")
+		}
+		if sections[i].Body == "" {
+			errs.add(field+"body", "Section body is required")
+		} else if len(sections[i].Body) > MaxEventSectionBodyLen {
+			errs.add(field+"body", "Section body must be at most 5000 characters")
+		}
+	}
+	if len(sections) == 0 {
+		return nil
+	}
+	normalized, err := json.Marshal(sections)
+	if err != nil {
+		errs.add("sections", "Invalid sections data")
+		return nil
+	}
+	return normalized
+}
+
+// validateQuestionWindows checks that the CFP questions parse, so a bad
+// open_at or close_at can't break submissions later, and that each window
+// closes after it opens
+func validateQuestionWindows(data []byte, errs *validationErrors) {
+	var questions []models.CustomQuestion
+	if err := json.Unmarshal(data, &questions); err != nil {
+		errs.add("cfp_questions", "CFP questions must be a list of questions, with open_at and close_at as RFC 3339 timestamps")
+		return
+	}
+	for _, q := range questions {
+		if q.OpenAt != nil && q.CloseAt != nil && !q.CloseAt.After(*q.OpenAt) {
+			errs.add("cfp_questions."+q.ID+".close_at", "close_at must be after open_at")
+		}
+	}
+}
+
+// validateStatusEmails checks and normalizes an event's own status email
+// copy, a map from accepted, rejected or tentative to a {subject, body}
+// object. Each is rendered with sample data so copy that cannot be sent is
+// rejected now. Returns nil (and clears the copy) when there is none.
+func validateStatusEmails(data []byte, errs *validationErrors) datatypes.JSON {
+	var emails map[models.ProposalStatus]models.StatusEmail
+	if err := json.Unmarshal(data, &emails); err != nil {
+		errs.add("status_emails", "Status emails must map a status to a {subject, body} object")
+		return nil
+	}
+	allowed := "use only {{" + strings.Join(email.StatusEmailVariables, "}}, {{") + "}}"
+	for status, se := range emails {
+		switch status {
+		case models.ProposalStatusAccepted, models.ProposalStatusRejected, models.ProposalStatusTentative:
+		default:
+			errs.add("status_emails", fmt.Sprintf("Unknown status %q; must be one of: accepted, rejected, tentative", status))
+			continue
+		}
+		field := "status_emails." + string(status) + "."
+		se.Subject = strings.TrimSpace(se.Subject)
+		se.Body = strings.TrimSpace(se.Body)
+		valid := true
+		if se.Subject == "" {
+			errs.add(field+"subject", "Subject is required")
+			valid = false
+		} else if len(se.Subject) > MaxStatusEmailSubjectLen {
+			errs.add(field+"subject", fmt.Sprintf("Subject must be at most %d characters", MaxStatusEmailSubjectLen))
+			valid = false
+		} else if strings.ContainsAny(se.Subject, "\r\n") {
+			errs.add(field+"subject", "Subject must be a single line")
+			valid = false
+		} else if unknown := email.UnknownStatusEmailVariables(se.Subject); len(unknown) > 0 {
+			errs.add(field+"subject", fmt.Sprintf("Unknown variable {{%s}}; %s", unknown[0], allowed))
+			valid = false
+		}
+		if se.Body == "" {
+			errs.add(field+"body", "Body is required")
+			valid = false
+		} else if len(se.Body) > MaxStatusEmailBodyLen {
+			errs.add(field+"body", fmt.Sprintf("Body must be at most %d characters", MaxStatusEmailBodyLen))
+			valid = false
+		} else if unknown := email.UnknownStatusEmailVariables(se.Body); len(unknown) > 0 {
+			errs.add(field+"body", fmt.Sprintf("Unknown variable {{%s}}; %s", unknown[0], allowed))
+			valid = false
+		}
+		if valid {
+			if err := email.CheckStatusEmail(se); err != nil {
+				errs.add(field+"body", "Email could not be rendered: "+err.Error())
+			}
+		}
+		emails[status] = se
+	}
+	if len(emails) == 0 {
+		return nil
+	}
+	normalized, err := json.Marshal(emails)
+	if err != nil {
+		errs.add("status_emails", "Invalid status emails data")
+		return nil
+	}
+	return normalized
+}
+
 // slugRegex validates event URL slugs.
 // Valid examples: "sreday-2026", "gophercon-us", "kubecon-eu-2025"
 // Invalid examples: "SREDay" (uppercase), "my--event" (double hyphen), "-event" (leading hyphen)
 var slugRegex = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
 
-// GetCountriesHandler returns unique countries from all events
+// countryCount is an entry of the countries listing
+type countryCount struct {
+	Code  string `json:"code"`
+	Name  string `json:"name"`
+	Count int64  `json:"count"`
+}
+
+// attendanceModeMessage is the validation message for unknown attendance modes
+const attendanceModeMessage = "Attendance mode must be in_person, online or hybrid"
+
+// descriptionFormatMessage is the validation message for unknown description formats
+const descriptionFormatMessage = "Description format must be plaintext or markdown"
+
+// cfpOpenSQL matches events whose CFP accepts submissions at @now. Unset
+// (NULL or zero) dates leave that side of the window unbounded, the same rule
+// as models.Event.IsCFPOpenAt. Bind it with cfpOpenVars.
+const cfpOpenSQL = "cfp_status = @open AND " +
+	"(cfp_open_at IS NULL OR cfp_open_at <= @now) AND " +
+	"(cfp_close_at IS NULL OR cfp_close_at = @zero OR cfp_close_at >= @now)"
+
+func cfpOpenVars(now time.Time) map[string]interface{} {
+	return map[string]interface{}{"open": models.CFPStatusOpen, "now": now, "zero": time.Time{}}
+}
+
+// findOpenFirst fetches a page of the events query ordered open CFPs first,
+// then by start date, latest first. Ordering every row by whether its CFP is
+// open can't use an index, so the open events are paged through on their
+// own, served in order by the partial idx_events_open_cfp, and the page is
+// filled up from the rest.
+func findOpenFirst(query *gorm.DB, now time.Time, offset, limit int) ([]models.Event, error) {
+	base := query.Session(&gorm.Session{})
+	open := func() *gorm.DB {
+		return base.Where(models.OpenCFPPredicate).Where(cfpOpenSQL, cfpOpenVars(now))
+	}
+
+	// Count would count a single selected field's non-null values instead
+	var openCount int64
+	if err := open().Select("count(*)").Count(&openCount).Error; err != nil {
+		return nil, err
+	}
+
+	var events []models.Event
+	if int64(offset) < openCount {
+		if err := open().Order("start_date DESC, id DESC").Offset(offset).Limit(limit).Find(&events).Error; err != nil {
+			return nil, err
+		}
+	}
+	if rest := limit - len(events); rest > 0 {
+		var closed []models.Event
+		if err := base.Where("NOT ("+cfpOpenSQL+")", cfpOpenVars(now)).
+			Order("start_date DESC, id DESC").
+			Offset(max(offset-int(openCount), 0)).Limit(rest).
+			Find(&closed).Error; err != nil {
+			return nil, err
+		}
+		events = append(events, closed...)
+	}
+	return events, nil
+}
+
+// unknownCountryMessage is the validation message for unrecognized countries
+const unknownCountryMessage = "Country must be an ISO 3166-1 alpha-2 code (e.g. GB, US) or a country name"
+
+// GetCountriesHandler returns the countries of all events with their event counts,
+// sorted by name. With ?all=true it lists every ISO 3166-1 country instead.
+// Responses are cached like GetStatsHandler's.
 func GetCountriesHandler(cfg *config.Config) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodGet {
-			encodeError(w, "Method not allowed", http.StatusMethodNotAllowed)
+			encodeAPIError(w, r, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		all := r.URL.Query().Get("all") == "true"
+		key := "countries"
+		if all {
+			key = "countries:all"
+		}
+		now := cfg.Now()
+		cached, generation, ok := getPublicCache(key, now)
+		if ok && !wantsFreshResponse(cfg, r) {
+			encodeResponse(w, r, cached)
 			return
 		}
 
-		var countries []string
-		if err := cfg.DB.Model(&models.Event{}).
-			Distinct("country").
-			Where("country IS NOT NULL AND country != ''").
-			Order("country ASC").
-			Pluck("country", &countries).Error; err != nil {
+		var rows []struct {
+			Code  string
+			Count int64
+		}
+		if err := cfg.DBCtx(r).Model(&models.Event{}).
+			Select("country_code AS code, COUNT(*) AS count").
+			Where("country_code IS NOT NULL AND country_code != '' AND NOT unlisted AND NOT archived").
+			Group("country_code").
+			Scan(&rows).Error; err != nil {
 			cfg.Logger.Error("failed to query countries", "error", err)
-			encodeError(w, "Failed to load countries", http.StatusInternalServerError)
+			encodeAPIError(w, r, "Failed to load countries", http.StatusInternalServerError)
 			return
 		}
 
-		encodeResponse(w, r, countries)
+		counts := make(map[string]int64, len(rows))
+		for _, row := range rows {
+			counts[row.Code] = row.Count
+		}
+
+		result := []countryCount{}
+		if all {
+			for _, c := range countries.All() {
+				result = append(result, countryCount{Code: c.Code, Name: c.Name, Count: counts[c.Code]})
+			}
+		} else {
+			for code, count := range counts {
+				result = append(result, countryCount{Code: code, Name: countries.Name(code), Count: count})
+			}
+			sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+		}
+
+		setPublicCache(key, result, generation, now)
+		encodeResponse(w, r, result)
 	}
 }
 
-// GetStatsHandler returns platform statistics
+// statsTagsSQL aggregates the distinct trimmed tags of all listed events into
+// one sorted, comma-separated string, so the stats need a single query
+const statsTagsSQL = `(SELECT string_agg(DISTINCT TRIM(tag), ',' ORDER BY TRIM(tag))
+	FROM events AS tagged, unnest(string_to_array(tagged.tags, ',')) AS tag
+	WHERE tagged.deleted_at IS NULL AND NOT tagged.unlisted AND NOT tagged.archived AND TRIM(tag) != '')`
+
+// GetStatsHandler returns platform statistics. They are computed with one
+// query and cached for publicCacheTTL, or until an event is written; admins
+// can skip the cache with ?fresh=true.
 func GetStatsHandler(cfg *config.Config) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodGet {
-			encodeError(w, "Method not allowed", http.StatusMethodNotAllowed)
+			encodeAPIError(w, r, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		now := cfg.Now()
+		cached, generation, ok := getPublicCache("stats", now)
+		if ok && !wantsFreshResponse(cfg, r) {
+			encodeResponse(w, r, cached)
 			return
 		}
 
@@ -84,57 +398,44 @@ func GetStatsHandler(cfg *config.Config) http.HandlerFunc {
 			CfpClosed       int64
 			UniqueLocations int64
 			UniqueCountries int64
+			UniqueTags      *string
 		}
 		var stats statsRow
-		if err := cfg.DB.Model(&models.Event{}).Select(`
+		if err := cfg.DBCtx(r).Model(&models.Event{}).Select(`
 			COUNT(*) AS total_events,
 			COUNT(CASE WHEN cfp_status = ? THEN 1 END) AS cfp_open,
 			COUNT(CASE WHEN cfp_status IN (?,?,?) THEN 1 END) AS cfp_closed,
 			COUNT(DISTINCT location) AS unique_locations,
-			COUNT(DISTINCT country) AS unique_countries`,
+			COUNT(DISTINCT NULLIF(country_code, '')) AS unique_countries,
+			`+statsTagsSQL+` AS unique_tags`,
 			models.CFPStatusOpen,
 			models.CFPStatusClosed, models.CFPStatusReviewing, models.CFPStatusComplete,
-		).Scan(&stats).Error; err != nil {
+		).Where("NOT unlisted AND NOT archived").Scan(&stats).Error; err != nil {
 			cfg.Logger.Error("failed to query stats", "error", err)
-			encodeError(w, "Failed to load stats", http.StatusInternalServerError)
-			return
-		}
-
-		// Get unique tags
-		var allTags []string
-		if err := cfg.DB.Model(&models.Event{}).Distinct("tags").Pluck("tags", &allTags).Error; err != nil {
-			cfg.Logger.Error("failed to query tags", "error", err)
-			encodeError(w, "Failed to load stats", http.StatusInternalServerError)
+			encodeAPIError(w, r, "Failed to load stats", http.StatusInternalServerError)
 			return
 		}
 
-		tagSet := make(map[string]bool)
-		for _, tagString := range allTags {
-			for _, tag := range strings.Split(tagString, ",") {
-				tag = strings.TrimSpace(tag)
-				if tag != "" {
-					tagSet[tag] = true
-				}
-			}
-		}
-
-		uniqueTags := make([]string, 0, len(tagSet))
-		for tag := range tagSet {
-			uniqueTags = append(uniqueTags, tag)
+		uniqueTags := []string{}
+		if stats.UniqueTags != nil {
+			uniqueTags = strings.Split(*stats.UniqueTags, ",")
 		}
 
-		encodeResponse(w, r, map[string]interface{}{
+		result := map[string]interface{}{
 			"total_events":     stats.TotalEvents,
 			"cfp_open":         stats.CfpOpen,
 			"cfp_closed":       stats.CfpClosed,
 			"unique_locations": stats.UniqueLocations,
 			"unique_countries": stats.UniqueCountries,
 			"unique_tags":      uniqueTags,
-		})
+		}
+		setPublicCache("stats", result, generation, now)
+		encodeResponse(w, r, result)
 	}
 }
 
-// GetProposalStatsHandler returns daily proposal submission counts for the last N days.
+// GetProposalStatsHandler returns daily proposal submission counts for the
+// last N days, and how many of them came from each source.
 func GetProposalStatsHandler(cfg *config.Config) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		days := 7
@@ -151,14 +452,14 @@ func GetProposalStatsHandler(cfg *config.Config) http.HandlerFunc {
 
 		var rows []dayStat
 		cutoff := time.Now().AddDate(0, 0, -days)
-		if err := cfg.DB.Model(&models.Proposal{}).
+		if err := cfg.DBCtx(r).Model(&models.Proposal{}).
 			Select("TO_CHAR(created_at, 'YYYY-MM-DD') as date, COUNT(*) as count").
 			Where("created_at >= ?", cutoff).
 			Group("TO_CHAR(created_at, 'YYYY-MM-DD')").
 			Order("date").
 			Scan(&rows).Error; err != nil {
 			cfg.Logger.Error("failed to query proposal stats", "error", err)
-			encodeError(w, "Failed to load proposal stats", http.StatusInternalServerError)
+			encodeAPIError(w, r, "Failed to load proposal stats", http.StatusInternalServerError)
 			return
 		}
 
@@ -167,9 +468,34 @@ func GetProposalStatsHandler(cfg *config.Config) http.HandlerFunc {
 			total += r.Count
 		}
 
+		// Proposals from before sources were recorded count as unknown
+		var sourceRows []struct {
+			Source string
+			Count  int64
+		}
+		if err := cfg.DBCtx(r).Model(&models.Proposal{}).
+			Select("COALESCE(NULLIF(source, ''), 'unknown') AS source, COUNT(*) AS count").
+			Where("created_at >= ?", cutoff).
+			Group("COALESCE(NULLIF(source, ''), 'unknown')").
+			Scan(&sourceRows).Error; err != nil {
+			cfg.Logger.Error("failed to query proposal sources", "error", err)
+			encodeAPIError(w, r, "Failed to load proposal stats", http.StatusInternalServerError)
+			return
+		}
+		bySource := map[string]int64{
+			models.ProposalSourceWeb:    0,
+			models.ProposalSourceCLI:    0,
+			models.ProposalSourceAPI:    0,
+			models.ProposalSourceImport: 0,
+		}
+		for _, row := range sourceRows {
+			bySource[row.Source] = row.Count
+		}
+
 		encodeResponse(w, r, map[string]interface{}{
-			"stats": rows,
-			"total": total,
+			"stats":     rows,
+			"total":     total,
+			"by_source": bySource,
 		})
 	}
 }
@@ -182,6 +508,109 @@ func sanitizeEventForPublic(event *models.Event) {
 	// Keep CFPRequiresPayment visible so speakers know payment is needed
 	event.CFPSubmissionFee = 0
 	event.CFPSubmissionFeeCurrency = ""
+	event.ListingTermsVersion = ""
+	event.ListingTermsAcceptedAt = nil
+	event.StatusEmails = nil
+}
+
+// canPreviewDraft reports whether the request's user, if any, organizes the
+// event and may therefore see it while it is still a draft
+func canPreviewDraft(cfg *config.Config, r *http.Request, event *models.Event) (bool, error) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		return false, nil
+	}
+	if event.CreatedByID != nil && *event.CreatedByID == user.ID {
+		return true, nil
+	}
+	var organizers []models.User
+	if err := cfg.DBCtx(r).Model(event).Association("Organizers").Find(&organizers); err != nil {
+		return false, err
+	}
+	for _, o := range organizers {
+		if o.ID == user.ID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// actsAsCreator reports whether the user may take the event creator's actions:
+// they created it, or its creator's account was deleted and they are an admin
+func actsAsCreator(cfg *config.Config, event *models.Event, user *models.User) bool {
+	if event.CreatedByID == nil {
+		return cfg.IsAdmin(user.Email)
+	}
+	return *event.CreatedByID == user.ID
+}
+
+// loadEventForCreator loads the event named by the {id} path value and checks
+// that the current user may act as its creator, sending an error response if
+// not. action completes the 403 message "Only the event creator can ...".
+func loadEventForCreator(cfg *config.Config, w http.ResponseWriter, r *http.Request, action string) (*models.Event, bool) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		encodeAPIError(w, r, "Unauthorized", http.StatusUnauthorized)
+		return nil, false
+	}
+
+	eventID, err := strconv.ParseUint(r.PathValue("id"), 10, 32)
+	if err != nil {
+		encodeAPIError(w, r, "Invalid event ID", http.StatusBadRequest)
+		return nil, false
+	}
+
+	var event models.Event
+	if err := cfg.DBCtx(r).First(&event, eventID).Error; err != nil {
+		encodeAPIError(w, r, "Event not found", http.StatusNotFound)
+		return nil, false
+	}
+
+	if !actsAsCreator(cfg, &event, user) {
+		encodeAPIError(w, r, "Only the event creator can "+action, http.StatusForbidden)
+		return nil, false
+	}
+	return &event, true
+}
+
+// encodeEventPage sends a public event, or a draft event marked with
+// draft: true to one of its organizers or the holder of a valid
+// ?preview_token=. Drafts are 404 for everyone else.
+func encodeEventPage(cfg *config.Config, w http.ResponseWriter, r *http.Request, event *models.Event, expand map[string]bool) {
+	if event.CFPStatus == models.CFPStatusDraft {
+		ok, err := canPreviewDraft(cfg, r, event)
+		if err == nil && !ok {
+			ok, err = validPreviewToken(cfg, r, r.URL.Query().Get("preview_token"), event)
+			// Preview links show the event page only, never organizer data
+			expand = nil
+		}
+		if err != nil {
+			cfg.Logger.Error("failed to check draft event access", "error", err, "event_id", event.ID)
+			encodeAPIError(w, r, "Failed to load event", http.StatusInternalServerError)
+			return
+		}
+		if !ok {
+			encodeAPIError(w, r, "Event not found", http.StatusNotFound)
+			return
+		}
+		event.Draft = true
+		// Previews depend on who is asking, so must not be cached or shared
+		w.Header().Set("Cache-Control", "private, no-store")
+	}
+
+	// Tell speakers which questions take answers now
+	if err := event.FlagActiveQuestions(cfg.Now(), cfg.CFPGracePeriod); err != nil {
+		cfg.Logger.Warn("event has invalid cfp_questions JSON", "event_id", event.ID, "error", err)
+	}
+
+	// Unlisted events are shared by link only and archived events are stale,
+	// so keep them out of search engines
+	if event.Unlisted || event.Archived {
+		w.Header().Set("X-Robots-Tag", "noindex")
+	}
+
+	sanitizeEventForPublic(event)
+	encodePublicEvent(cfg, w, r, event, expand)
 }
 
 // escapeLikePattern escapes LIKE/ILIKE special characters in user input
@@ -197,14 +626,36 @@ func escapeLikePattern(s string) string {
 func ListEventsHandler(cfg *config.Config) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodGet {
-			encodeError(w, "Method not allowed", http.StatusMethodNotAllowed)
+			encodeAPIError(w, r, "Method not allowed", http.StatusMethodNotAllowed)
 			return
 		}
 
-		query := cfg.DB.Model(&models.Event{})
+		var errs validationErrors
+
+		// Optional field selection, e.g. ?fields=id,name,slug
+		fields, err := parseEventFields(r.URL.Query().Get("fields"))
+		if err != nil {
+			errs.add("fields", err.Error())
+		}
+
+		// Optional near-me search, e.g. ?near=52.52,13.405&radius_km=500
+		near := parseNearQuery(r.URL.Query(), &errs)
+
+		// Optional aggregates, e.g. ?include=stats
+		include, err := parseEventInclude(r.URL.Query().Get("include"))
+		if err != nil {
+			errs.add("include", err.Error())
+		}
+
+		if len(errs) > 0 {
+			encodeValidationErrors(w, r, errs)
+			return
+		}
 
-		// Never show draft events in public listings
-		query = query.Where("cfp_status != ?", models.CFPStatusDraft)
+		query := cfg.DBCtx(r).Model(&models.Event{})
+
+		// Never show draft, unlisted, archived or events held for moderation in public listings
+		query = query.Where("cfp_status != ? AND moderation_status = ? AND NOT unlisted AND NOT archived", models.CFPStatusDraft, models.ModerationApproved)
 
 		// Search
 		if q := r.URL.Query().Get("q"); q != "" {
@@ -212,14 +663,18 @@ func ListEventsHandler(cfg *config.Config) http.HandlerFunc {
 			query = query.Where("name ILIKE ? OR description ILIKE ?", "%"+escaped+"%", "%"+escaped+"%")
 		}
 
-		// Filter by tag
-		if tag := r.URL.Query().Get("tag"); tag != "" {
-			query = query.Where("tags ILIKE ?", "%"+escapeLikePattern(tag)+"%")
+		// Filter by tag. Tags are stored normalized, so match the normalized tag exactly
+		if tag := models.NormalizeTags(r.URL.Query().Get("tag")); tag != "" {
+			query = query.Where("? = ANY(string_to_array(tags, ','))", tag)
 		}
 
-		// Filter by country
+		// Filter by country, matching either the ISO code or the name as entered
 		if country := r.URL.Query().Get("country"); country != "" {
-			query = query.Where("country ILIKE ?", escapeLikePattern(country))
+			if code, ok := countries.Lookup(country); ok {
+				query = query.Where("(country_code = ? OR country ILIKE ?)", code, escapeLikePattern(country))
+			} else {
+				query = query.Where("country ILIKE ?", escapeLikePattern(country))
+			}
 		}
 
 		// Filter by location
@@ -244,20 +699,27 @@ func ListEventsHandler(cfg *config.Config) http.HandlerFunc {
 			}
 		}
 
-		// Filter by event type (online/in-person)
-		if t := r.URL.Query().Get("type"); t == "online" {
-			query = query.Where("is_online = ?", true)
-		} else if t == "in-person" {
-			query = query.Where("is_online = ?", false)
+		// Filter by event type; hybrid events match both online and in-person
+		switch r.URL.Query().Get("type") {
+		case "online":
+			query = query.Where("attendance_mode IN ?", []models.AttendanceMode{models.AttendanceOnline, models.AttendanceHybrid})
+		case "in_person", "in-person":
+			query = query.Where("attendance_mode IN ?", []models.AttendanceMode{models.AttendanceInPerson, models.AttendanceHybrid})
+		case "hybrid":
+			query = query.Where("attendance_mode = ?", models.AttendanceHybrid)
+		}
+
+		// Filter by distance; events without coordinates are excluded
+		if near != nil {
+			query = near.filter(query)
 		}
 
 		// Filter by CFP status (open/closed)
 		if status := r.URL.Query().Get("status"); status != "" {
-			now := time.Now()
 			if status == "open" {
-				query = query.Where("cfp_status = ? AND cfp_open_at <= ? AND cfp_close_at >= ?", models.CFPStatusOpen, now, now)
+				query = query.Where(cfpOpenSQL, cfpOpenVars(cfg.Now()))
 			} else if status == "closed" {
-				query = query.Where("cfp_status != ? OR cfp_open_at > ? OR cfp_close_at < ?", models.CFPStatusOpen, now, now)
+				query = query.Where("NOT ("+cfpOpenSQL+")", cfpOpenVars(cfg.Now()))
 			}
 		}
 
@@ -265,12 +727,13 @@ func ListEventsHandler(cfg *config.Config) http.HandlerFunc {
 		var total int64
 		if err := query.Count(&total).Error; err != nil {
 			cfg.Logger.Error("failed to count events", "error", err)
-			encodeError(w, "Failed to load events", http.StatusInternalServerError)
+			encodeAPIError(w, r, "Failed to load events", http.StatusInternalServerError)
 			return
 		}
 
 		// Sorting
 		sortField := r.URL.Query().Get("sort")
+		openFirst := false
 		sortOrder := r.URL.Query().Get("order")
 
 		// Validate sort field
@@ -289,16 +752,25 @@ func ListEventsHandler(cfg *config.Config) http.HandlerFunc {
 					Desc:   sortOrder == "desc",
 				})
 			}
+			// Tie-break on id so rows sharing a sort value keep a stable order across pages
+			query = query.Order(clause.OrderByColumn{
+				Column: clause.Column{Name: "id"},
+				Desc:   sortOrder == "desc",
+			})
+		} else if near != nil {
+			// Nearest first
+			query = near.order(query)
 		} else {
 			// Context-aware default sort based on status filter
 			statusParam := r.URL.Query().Get("status")
 			switch statusParam {
 			case "open":
-				query = query.Order("start_date ASC")
+				query = query.Order("start_date ASC, id ASC")
 			case "closed":
-				query = query.Order("start_date DESC")
+				query = query.Order("start_date DESC, id DESC")
 			default:
-				query = query.Order("CASE WHEN cfp_status = 'open' AND cfp_close_at >= NOW() AND cfp_open_at <= NOW() THEN 0 ELSE 1 END, start_date DESC")
+				// Open CFPs first, fetched by findOpenFirst
+				openFirst = true
 			}
 		}
 
@@ -318,81 +790,150 @@ func ListEventsHandler(cfg *config.Config) http.HandlerFunc {
 
 		offset := (page - 1) * perPage
 
+		if fields != nil {
+			// Only fetch the requested columns, those the stats need, and
+			// those a derived summary comes from
+			var extra []string
+			if include["stats"] {
+				extra = append(extra, eventStatsColumns...)
+			}
+			if slices.Contains(fields, "summary") {
+				extra = append(extra, "description", "description_format")
+			}
+			columns := append([]string{}, fields...)
+			for _, c := range extra {
+				if !slices.Contains(columns, c) {
+					columns = append(columns, c)
+				}
+			}
+			query = query.Select(columns)
+		}
+
 		var events []models.Event
-		if err := query.Offset(offset).Limit(perPage).Find(&events).Error; err != nil {
+		if openFirst {
+			events, err = findOpenFirst(query, cfg.Now(), offset, perPage)
+		} else {
+			err = query.Offset(offset).Limit(perPage).Find(&events).Error
+		}
+		if err != nil {
 			cfg.Logger.Error("failed to query events", "error", err)
-			encodeError(w, "Failed to load events", http.StatusInternalServerError)
+			encodeAPIError(w, r, "Failed to load events", http.StatusInternalServerError)
 			return
 		}
 
 		for i := range events {
 			sanitizeEventForPublic(&events[i])
+			// Listings carry the summary; the full description is only in
+			// the event itself, unless asked for with ?fields=
+			if fields == nil {
+				events[i].Description, events[i].DescriptionHTML = "", ""
+			}
+		}
+
+		if include["stats"] {
+			if err := loadEventStats(cfg.DBCtx(r), events, cfg.Now()); err != nil {
+				cfg.Logger.Error("failed to load event stats", "error", err)
+				encodeAPIError(w, r, "Failed to load events", http.StatusInternalServerError)
+				return
+			}
 		}
 
 		totalPages := int((total + int64(perPage) - 1) / int64(perPage))
 
-		encodeResponse(w, r, map[string]interface{}{
-			"data": events,
-			"pagination": map[string]interface{}{
-				"page":        page,
-				"per_page":    perPage,
-				"total":       total,
-				"total_pages": totalPages,
-			},
-		})
+		pagination := map[string]interface{}{
+			"page":        page,
+			"per_page":    perPage,
+			"total":       total,
+			"total_pages": totalPages,
+		}
+
+		if fields != nil {
+			rows, err := selectEventFields(events, fields)
+			if err != nil {
+				cfg.Logger.Error("failed to select event fields", "error", err)
+				encodeAPIError(w, r, "Failed to load events", http.StatusInternalServerError)
+				return
+			}
+			addEventStats(events, rows)
+			resp := map[string]interface{}{"data": rows, "pagination": pagination}
+			encodeResponse(w, r, withCSV(resp, func(cw *csv.Writer) { writeEventFieldsCSV(cw, fields, rows) }))
+			return
+		}
+
+		resp := map[string]interface{}{"data": events, "pagination": pagination}
+		encodeResponse(w, r, withCSV(resp, func(cw *csv.Writer) { writeEventsCSV(cw, events) }))
 	}
 }
 
-// GetEventBySlugHandler returns an event by its slug
+// GetEventBySlugHandler returns an event by its slug. Draft events are only
+// returned to their organizers.
 func GetEventBySlugHandler(cfg *config.Config) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		slug := r.PathValue("slug")
 
 		if slug == "" {
-			encodeError(w, "Missing slug", http.StatusBadRequest)
+			encodeAPIError(w, r, "Missing slug", http.StatusBadRequest)
+			return
+		}
+
+		expand, ok := parseExpandParam(w, r, eventExpansions)
+		if !ok {
 			return
 		}
 
 		var event models.Event
-		if err := cfg.DB.Where("slug = ? AND cfp_status != ?", slug, models.CFPStatusDraft).First(&event).Error; err != nil {
+		if err := cfg.DBCtx(r).Where("slug = ? AND moderation_status = ?", slug, models.ModerationApproved).First(&event).Error; err != nil {
 			if errors.Is(err, gorm.ErrRecordNotFound) {
-				encodeError(w, "Event not found", http.StatusNotFound)
+				// The slug may belong to an event merged into another
+				if target, err := models.GetEventSlugRedirect(cfg.DBCtx(r), slug); err == nil && target.IsListed() {
+					location := "/api/v0/e/" + url.PathEscape(target.Slug)
+					if r.URL.RawQuery != "" {
+						location += "?" + r.URL.RawQuery
+					}
+					http.Redirect(w, r, withBasePath(r, location), http.StatusMovedPermanently)
+					return
+				}
+				encodeAPIError(w, r, "Event not found", http.StatusNotFound)
 			} else {
 				cfg.Logger.Error("failed to query event by slug", "error", err, "slug", slug)
-				encodeError(w, "Failed to load event", http.StatusInternalServerError)
+				encodeAPIError(w, r, "Failed to load event", http.StatusInternalServerError)
 			}
 			return
 		}
 
-		sanitizeEventForPublic(&event)
-		encodeResponse(w, r, event)
+		encodeEventPage(cfg, w, r, &event, expand)
 	}
 }
 
 // GetEventByIDHandler returns an event by ID (public endpoint).
-// Draft events are hidden and internal payment fields are stripped.
+// Draft events are only returned to their organizers, unapproved events are
+// hidden, and internal payment fields are stripped.
 func GetEventByIDHandler(cfg *config.Config) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		idStr := r.PathValue("id")
 		id, err := strconv.ParseUint(idStr, 10, 32)
 		if err != nil {
-			encodeError(w, "Invalid event ID", http.StatusBadRequest)
+			encodeAPIError(w, r, "Invalid event ID", http.StatusBadRequest)
+			return
+		}
+
+		expand, ok := parseExpandParam(w, r, eventExpansions)
+		if !ok {
 			return
 		}
 
 		var event models.Event
-		if err := cfg.DB.Where("cfp_status != ?", models.CFPStatusDraft).First(&event, id).Error; err != nil {
+		if err := cfg.DBCtx(r).Where("moderation_status = ?", models.ModerationApproved).First(&event, id).Error; err != nil {
 			if errors.Is(err, gorm.ErrRecordNotFound) {
-				encodeError(w, "Event not found", http.StatusNotFound)
+				encodeAPIError(w, r, "Event not found", http.StatusNotFound)
 			} else {
 				cfg.Logger.Error("failed to query event by ID", "error", err, "id", id)
-				encodeError(w, "Failed to load event", http.StatusInternalServerError)
+				encodeAPIError(w, r, "Failed to load event", http.StatusInternalServerError)
 			}
 			return
 		}
 
-		sanitizeEventForPublic(&event)
-		encodeResponse(w, r, event)
+		encodeEventPage(cfg, w, r, &event, expand)
 	}
 }
 
@@ -402,144 +943,170 @@ func GetEventForOrganizerHandler(cfg *config.Config) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		user := GetUserFromContext(r.Context())
 		if user == nil {
-			encodeError(w, "Unauthorized", http.StatusUnauthorized)
+			encodeAPIError(w, r, "Unauthorized", http.StatusUnauthorized)
 			return
 		}
 
 		id, err := strconv.ParseUint(r.PathValue("id"), 10, 32)
 		if err != nil {
-			encodeError(w, "Invalid event ID", http.StatusBadRequest)
+			encodeAPIError(w, r, "Invalid event ID", http.StatusBadRequest)
 			return
 		}
 
-		var event models.Event
-		if err := cfg.DB.Preload("Organizers").First(&event, id).Error; err != nil {
+		event, err := getEventWithOrganizers(cfg, r, uint(id))
+		if err != nil {
 			if errors.Is(err, gorm.ErrRecordNotFound) {
-				encodeError(w, "Event not found", http.StatusNotFound)
+				encodeAPIError(w, r, "Event not found", http.StatusNotFound)
 			} else {
 				cfg.Logger.Error("failed to query event for organizer", "error", err, "id", id)
-				encodeError(w, "Failed to load event", http.StatusInternalServerError)
+				encodeAPIError(w, r, "Failed to load event", http.StatusInternalServerError)
 			}
 			return
 		}
 
-		if !event.IsOrganizer(user.ID) {
-			encodeError(w, "Forbidden", http.StatusForbidden)
+		if !event.IsOrganizer(user.ID) && !actsAsCreator(cfg, event, user) {
+			encodeAPIError(w, r, "Forbidden", http.StatusForbidden)
+			return
+		}
+
+		count, err := models.CountCFPInterests(cfg.DBCtx(r), event.ID)
+		if err != nil {
+			cfg.Logger.Error("failed to count cfp interests", "error", err, "event_id", event.ID)
+			encodeAPIError(w, r, "Failed to load event", http.StatusInternalServerError)
 			return
 		}
+		event.NotifyMeCount = &count
 
 		encodeResponse(w, r, event)
 	}
 }
 
+// validateSeries checks an event's series name, which follows the slug
+// rules, and returns it lowercased. Empty means the event is in no series.
+func validateSeries(series string, errs *validationErrors) string {
+	series = strings.ToLower(strings.TrimSpace(series))
+	if series == "" {
+		return ""
+	}
+	if !slugRegex.MatchString(series) {
+		errs.add("series", "Series must be lowercase alphanumeric with hyphens only")
+	} else if len(series) > MaxEventSlugLen {
+		errs.add("series", "Series must be at most 200 characters")
+	}
+	return series
+}
+
 // CreateEventHandler creates a new event
 func CreateEventHandler(cfg *config.Config) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodPost {
-			encodeError(w, "Method not allowed", http.StatusMethodNotAllowed)
-			return
-		}
-
 		user := GetUserFromContext(r.Context())
 		if user == nil {
-			encodeError(w, "Unauthorized", http.StatusUnauthorized)
+			encodeAPIError(w, r, "Unauthorized", http.StatusUnauthorized)
 			return
 		}
 
-		r.Body = http.MaxBytesReader(w, r.Body, 1<<20) // 1MB limit
-		defer r.Body.Close()
-
-		var event models.Event
-		if err := json.NewDecoder(r.Body).Decode(&event); err != nil {
-			encodeError(w, "Invalid request body", http.StatusBadRequest)
-			return
+		// homepage is a honeypot: the create form hides it, so only bots fill it in
+		var req struct {
+			models.Event
+			Homepage string `json:"homepage"`
 		}
-
-		// Validate slug
-		if event.Slug == "" {
-			encodeError(w, "Slug is required", http.StatusBadRequest)
+		if !decodeJSONBody(w, r, MaxBodySize, &req) {
 			return
 		}
+		event := req.Event
 
-		event.Slug = strings.ToLower(event.Slug)
-		if !slugRegex.MatchString(event.Slug) {
-			encodeError(w, "Slug must be lowercase alphanumeric with hyphens only", http.StatusBadRequest)
-			return
-		}
+		var errs validationErrors
 
-		// Check slug uniqueness
-		var existing models.Event
-		if cfg.DB.Where("slug = ?", event.Slug).First(&existing).Error == nil {
-			encodeError(w, "Slug already exists", http.StatusConflict)
-			return
+		// Validate slug
+		event.Slug = strings.ToLower(event.Slug)
+		switch {
+		case event.Slug == "":
+			errs.add("slug", "Slug is required")
+		case !slugRegex.MatchString(event.Slug):
+			errs.add("slug", "Slug must be lowercase alphanumeric with hyphens only")
+		case len(event.Slug) > MaxEventSlugLen:
+			errs.add("slug", "Slug must be at most 200 characters")
 		}
+		event.Series = validateSeries(event.Series, &errs)
 
-		// Validate required fields
+		// Validate required fields and field lengths
 		if event.Name == "" {
-			encodeError(w, "Name is required", http.StatusBadRequest)
-			return
+			errs.add("name", "Name is required")
+		} else if len(event.Name) > MaxEventNameLen {
+			errs.add("name", "Name must be at most 200 characters")
 		}
-
-		// Validate field lengths
-		if len(event.Name) > MaxEventNameLen {
-			encodeError(w, "Name must be at most 200 characters", http.StatusBadRequest)
-			return
+		if len(event.Description) > MaxEventDescriptionLen {
+			errs.add("description", "Description must be at most 10000 characters")
 		}
-		if len(event.Slug) > MaxEventSlugLen {
-			encodeError(w, "Slug must be at most 200 characters", http.StatusBadRequest)
-			return
+		event.Summary = strings.TrimSpace(event.Summary)
+		if utf8.RuneCountInString(event.Summary) > models.MaxSummaryLen {
+			errs.add("summary", "Summary must be at most 300 characters")
 		}
-		if len(event.Description) > MaxEventDescriptionLen {
-			encodeError(w, "Description must be at most 10000 characters", http.StatusBadRequest)
-			return
+		if event.DescriptionFormat == "" {
+			event.DescriptionFormat = models.DescriptionPlaintext
+		} else if !event.DescriptionFormat.Valid() {
+			errs.add("description_format", descriptionFormatMessage)
 		}
 		if len(event.Location) > MaxEventLocationLen {
-			encodeError(w, "Location must be at most 500 characters", http.StatusBadRequest)
-			return
+			errs.add("location", "Location must be at most 500 characters")
 		}
+		event.CountryCode = ""
 		if len(event.Country) > MaxEventCountryLen {
-			encodeError(w, "Country must be at most 100 characters", http.StatusBadRequest)
-			return
+			errs.add("country", "Country must be at most 100 characters")
+		} else if event.Country != "" {
+			if code, ok := countries.Lookup(event.Country); ok {
+				event.CountryCode = code
+			} else {
+				errs.add("country", unknownCountryMessage)
+			}
 		}
 		if len(event.Website) > MaxEventWebsiteLen {
-			encodeError(w, "Website must be at most 2000 characters", http.StatusBadRequest)
-			return
-		}
-		if event.Website != "" {
+			errs.add("website", "Website must be at most 2000 characters")
+		} else if event.Website != "" {
 			u, err := url.Parse(event.Website)
 			if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
-				encodeError(w, "Website must be a valid HTTP or HTTPS URL", http.StatusBadRequest)
-				return
+				errs.add("website", "Website must be a valid HTTP or HTTPS URL")
 			}
 		}
+		validateLogoURL(event.LogoURL, &errs)
 		if len(event.Tags) > MaxEventTagsLen {
-			encodeError(w, "Tags must be at most 1000 characters", http.StatusBadRequest)
-			return
+			errs.add("tags", "Tags must be at most 1000 characters")
+		} else if len(models.SplitTags(event.Tags)) > models.MaxEventTags {
+			errs.add("tags", fmt.Sprintf("At most %d tags are allowed", models.MaxEventTags))
+		}
+		event.Tags = models.NormalizeTags(event.Tags)
+		if len(event.Sections) > 0 {
+			event.Sections = validateEventSections(event.Sections, &errs)
+		}
+		if len(event.StatusEmails) > 0 {
+			event.StatusEmails = validateStatusEmails(event.StatusEmails, &errs)
+		}
+		if len(event.CFPQuestions) > 0 {
+			validateQuestionWindows(event.CFPQuestions, &errs)
 		}
+		validateCoCSettings(event.CoCURL, event.RequireCoCAcceptance, &errs)
+		validateAbstractWordLimits(event.AbstractMinWords, event.AbstractMaxWords, &errs)
+
+		// attendance_mode wins over the legacy is_online flag, which is kept in sync
+		if event.AttendanceMode == "" {
+			event.AttendanceMode = models.AttendanceModeFromOnline(event.IsOnline)
+		} else if !event.AttendanceMode.Valid() {
+			errs.add("attendance_mode", attendanceModeMessage)
+		}
+		event.IsOnline = event.AttendanceMode.IsOnline()
 
 		// Validate date ordering
 		if !event.StartDate.IsZero() && !event.EndDate.IsZero() && event.EndDate.Before(event.StartDate) {
-			encodeError(w, "End date must be after start date", http.StatusBadRequest)
-			return
+			errs.add("end_date", "End date must be after start date")
 		}
 		if !event.CFPOpenAt.IsZero() && !event.CFPCloseAt.IsZero() && event.CFPCloseAt.Before(event.CFPOpenAt) {
-			encodeError(w, "CFP close date must be after CFP open date", http.StatusBadRequest)
-			return
+			errs.add("cfp_close_at", "CFP close date must be after CFP open date")
 		}
 
-		// Set defaults
-		event.CreatedByID = &user.ID
+		// Validate cfp_status against allowed values
 		if event.CFPStatus == "" {
 			event.CFPStatus = models.CFPStatusDraft
 		}
-
-		// Zero out server-controlled fields to prevent mass assignment
-		event.IsPaid = false
-		event.StripePaymentID = ""
-		event.CFPSubmissionFee = 0
-		event.CFPSubmissionFeeCurrency = ""
-
-		// Validate cfp_status against allowed values
 		validStatuses := map[models.CFPStatus]bool{
 			models.CFPStatusDraft:     true,
 			models.CFPStatusOpen:      true,
@@ -548,30 +1115,146 @@ func CreateEventHandler(cfg *config.Config) http.HandlerFunc {
 			models.CFPStatusComplete:  true,
 		}
 		if !validStatuses[event.CFPStatus] {
-			encodeError(w, "Invalid CFP status", http.StatusBadRequest)
+			errs.add("cfp_status", "Invalid CFP status")
+		}
+
+		if len(errs) > 0 {
+			encodeValidationErrors(w, r, errs)
 			return
 		}
 
+		event.CreatedByID = &user.ID
+
+		// Zero out server-controlled fields to prevent mass assignment
+		event.IsPaid = false
+		event.StripePaymentID = ""
+		event.CFPSubmissionFee = 0
+		event.CFPSubmissionFeeCurrency = ""
+		event.ListingTermsVersion = ""
+		event.ListingTermsAcceptedAt = nil
+		event.Latitude = nil
+		event.Longitude = nil
+		event.Archived = false
+		event.ArchivedAt = nil
+		event.ScrubbedAt = nil
+
+		spamResult := moderateNewEvent(cfg, r, user, &event, strings.TrimSpace(req.Homepage) != "")
+
 		// Payment gate: block creating with open status if listing fee is required
 		if event.CFPStatus == models.CFPStatusOpen && cfg.EventListingFee > 0 {
-			encodeError(w, "Event listing must be paid before opening CFP", http.StatusPaymentRequired)
+			encodeAPIError(w, r, "Event listing must be paid before opening CFP", http.StatusPaymentRequired)
 			return
 		}
 
-		if err := cfg.DB.Create(&event).Error; err != nil {
-			var pgErr *pgconn.PgError
-			if errors.As(err, &pgErr) && pgErr.Code == "23505" {
-				encodeError(w, "Slug already exists", http.StatusConflict)
+		if err := cfg.DBCtx(r).Create(&event).Error; err != nil {
+			if isSlugConflict(err) {
+				encodeAPIErrorCode(w, r, ErrCodeSlugConflict, "Slug already exists", http.StatusConflict)
 				return
 			}
 			cfg.Logger.Error("failed to create event", "error", err)
-			encodeError(w, "Failed to create event", http.StatusInternalServerError)
+			encodeAPIError(w, r, "Failed to create event", http.StatusInternalServerError)
 			return
 		}
+		invalidatePublicCache()
 
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusCreated)
-		encodeResponse(w, r, event)
+		geocodeEventAsync(cfg, event)
+		if event.ModerationStatus == models.ModerationPendingReview {
+			notifyEventHeld(cfg, event, *user, spamResult)
+		}
+
+		encodeCreated(w, r, fmt.Sprintf("/api/v0/events/%d", event.ID), event)
+	}
+}
+
+// validateLogoURL checks an event's logo or banner image URL, which may be empty
+func validateLogoURL(logoURL string, errs *validationErrors) {
+	if logoURL == "" {
+		return
+	}
+	if len(logoURL) > MaxEventWebsiteLen {
+		errs.add("logo_url", "Logo URL must be at most 2000 characters")
+	} else if !isHTTPURL(logoURL) {
+		errs.add("logo_url", "Logo URL must be a valid HTTP or HTTPS URL")
+	}
+}
+
+// validateHTTPURL checks the optional absolute HTTP(S) URL in field, such as
+// a proposal's slides
+func validateHTTPURL(field, raw string, errs *validationErrors) {
+	if raw == "" {
+		return
+	}
+	if len(raw) > MaxEventWebsiteLen {
+		errs.add(field, "Must be at most 2000 characters")
+	} else if !isHTTPURL(raw) {
+		errs.add(field, "Must be a valid HTTP or HTTPS URL")
+	}
+}
+
+// isHTTPURL reports whether raw is an absolute HTTP or HTTPS URL
+func isHTTPURL(raw string) bool {
+	u, err := url.Parse(raw)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// ApplyEventHandler creates the event with the slug in the path, or updates
+// it if it exists, so event definitions can be kept in files and applied
+// repeatedly. Only the event's organizers can update it, and only the fields
+// sent change; cfp_questions, when sent, replaces the whole list.
+// PUT /api/v0/e/{slug}
+func ApplyEventHandler(cfg *config.Config) http.HandlerFunc {
+	create := CreateEventHandler(cfg)
+	update := UpdateEventHandler(cfg)
+	return func(w http.ResponseWriter, r *http.Request) {
+		user := GetUserFromContext(r.Context())
+		if user == nil {
+			encodeAPIError(w, r, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		slug := strings.ToLower(r.PathValue("slug"))
+		var fields map[string]json.RawMessage
+		if !decodeJSONBody(w, r, MaxBodySize, &fields) {
+			return
+		}
+		if raw, ok := fields["slug"]; ok {
+			var bodySlug string
+			if json.Unmarshal(raw, &bodySlug) != nil || strings.ToLower(bodySlug) != slug {
+				var errs validationErrors
+				errs.add("slug", "Slug must match the one in the URL")
+				encodeValidationErrors(w, r, errs)
+				return
+			}
+		}
+
+		var event models.Event
+		err := cfg.DBCtx(r).Select("id").Where("slug = ?", slug).First(&event).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			cfg.Logger.Error("failed to look up event by slug", "error", err, "slug", slug)
+			encodeAPIError(w, r, "Failed to load event", http.StatusInternalServerError)
+			return
+		}
+
+		// Hand the request on to the create or update handler, which do
+		// the validation and authorization
+		if err == nil {
+			delete(fields, "slug")
+			r.SetPathValue("id", strconv.FormatUint(uint64(event.ID), 10))
+		} else {
+			fields["slug"], _ = json.Marshal(slug)
+		}
+		data, err := json.Marshal(fields)
+		if err != nil {
+			encodeAPIError(w, r, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(data))
+		r.ContentLength = int64(len(data))
+		if event.ID != 0 {
+			update(w, r)
+		} else {
+			create(w, r)
+		}
 	}
 }
 
@@ -580,56 +1263,62 @@ func UpdateEventHandler(cfg *config.Config) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		user := GetUserFromContext(r.Context())
 		if user == nil {
-			encodeError(w, "Unauthorized", http.StatusUnauthorized)
+			encodeAPIError(w, r, "Unauthorized", http.StatusUnauthorized)
 			return
 		}
 
 		idStr := r.PathValue("id")
 		id, err := strconv.ParseUint(idStr, 10, 32)
 		if err != nil {
-			encodeError(w, "Invalid event ID", http.StatusBadRequest)
+			encodeAPIError(w, r, "Invalid event ID", http.StatusBadRequest)
 			return
 		}
 
-		var event models.Event
-		if err := cfg.DB.Preload("Organizers").First(&event, id).Error; err != nil {
-			encodeError(w, "Event not found", http.StatusNotFound)
+		event, err := getEventWithOrganizers(cfg, r, uint(id))
+		if err != nil {
+			encodeAPIError(w, r, "Event not found", http.StatusNotFound)
 			return
 		}
 
 		// Check authorization
-		if !event.IsOrganizer(user.ID) {
-			encodeError(w, "Forbidden", http.StatusForbidden)
+		if !event.IsOrganizer(user.ID) && !actsAsCreator(cfg, event, user) {
+			encodeAPIError(w, r, "Forbidden", http.StatusForbidden)
 			return
 		}
 
-		r.Body = http.MaxBytesReader(w, r.Body, 1<<20)
-		defer r.Body.Close()
-
 		var updates map[string]interface{}
-		if err := json.NewDecoder(r.Body).Decode(&updates); err != nil {
-			encodeError(w, "Invalid request body", http.StatusBadRequest)
+		if !decodeJSONBody(w, r, MaxBodySize, &updates) {
 			return
 		}
 
 		// Only allow known safe fields to be updated (allowlist approach)
 		allowedFields := map[string]bool{
-			"name": true, "slug": true, "description": true, "location": true,
-			"country": true, "start_date": true, "end_date": true, "website": true,
-			"terms_url": true, "tags": true, "is_online": true, "contact_email": true,
+			"name": true, "slug": true, "description": true, "summary": true, "description_format": true, "location": true,
+			"country": true, "start_date": true, "end_date": true, "website": true, "logo_url": true,
+			"terms_url": true, "coc_url": true, "require_coc_acceptance": true, "tags": true, "is_online": true, "attendance_mode": true, "contact_email": true,
 			"travel_covered": true, "hotel_covered": true, "honorarium_provided": true,
 			"cfp_description": true, "cfp_open_at": true, "cfp_close_at": true,
-			"max_accepted": true, "cfp_questions": true,
-			"cfp_requires_payment": true, "cfp_status": true,
+			"max_accepted": true, "waitlist_auto_promote": true, "allow_overbook": true, "cfp_questions": true, "sections": true, "status_emails": true,
+			"abstract_min_words": true, "abstract_max_words": true, "show_submission_count": true, "hide_speaker_emails_from_reviewers": true,
+			"unlisted": true, "series": true, "flag_series_duplicates": true, "cfp_requires_payment": true, "cfp_status": true,
 		}
 		filtered := make(map[string]interface{})
+		var requested []string
 		for k, v := range updates {
 			if allowedFields[k] {
 				filtered[k] = v
+				requested = append(requested, k)
 			}
 		}
 		updates = filtered
 
+		// Reviewers must not be able to unmask the emails hidden from them
+		if _, ok := updates["hide_speaker_emails_from_reviewers"]; ok &&
+			(event.CreatedByID == nil || *event.CreatedByID != user.ID) && !cfg.IsAdmin(user.Email) {
+			encodeAPIError(w, r, "Only the event creator can change who sees speaker emails", http.StatusForbidden)
+			return
+		}
+
 		// When cfp_requires_payment is toggled, auto-populate or clear fee fields from server config
 		if reqPayment, ok := updates["cfp_requires_payment"]; ok {
 			enabled, _ := reqPayment.(bool)
@@ -642,8 +1331,11 @@ func UpdateEventHandler(cfg *config.Config) http.HandlerFunc {
 			}
 		}
 
+		var errs validationErrors
+
 		// Validate cfp_status enum if being updated
-		if status, ok := updates["cfp_status"].(string); ok {
+		status, statusUpdated := updates["cfp_status"].(string)
+		if statusUpdated {
 			validStatuses := map[models.CFPStatus]bool{
 				models.CFPStatusDraft:     true,
 				models.CFPStatusOpen:      true,
@@ -652,89 +1344,147 @@ func UpdateEventHandler(cfg *config.Config) http.HandlerFunc {
 				models.CFPStatusComplete:  true,
 			}
 			if !validStatuses[models.CFPStatus(status)] {
-				encodeError(w, "Invalid CFP status", http.StatusBadRequest)
-				return
-			}
-
-			// Payment gate: block opening CFP if listing fee is required and unpaid
-			if status == string(models.CFPStatusOpen) && cfg.EventListingFee > 0 && !event.IsPaid {
-				encodeError(w, "Event listing must be paid before opening CFP", http.StatusPaymentRequired)
-				return
+				errs.add("cfp_status", "Invalid CFP status")
 			}
 		}
 
 		// Validate field lengths on update
 		if name, ok := updates["name"].(string); ok && len(name) > MaxEventNameLen {
-			encodeError(w, "Name must be at most 200 characters", http.StatusBadRequest)
-			return
+			errs.add("name", "Name must be at most 200 characters")
 		}
 		if desc, ok := updates["description"].(string); ok && len(desc) > MaxEventDescriptionLen {
-			encodeError(w, "Description must be at most 10000 characters", http.StatusBadRequest)
-			return
+			errs.add("description", "Description must be at most 10000 characters")
+		}
+		if summary, ok := updates["summary"].(string); ok {
+			summary = strings.TrimSpace(summary)
+			// Forms send back the summary they were shown; keep a derived
+			// one derived so it follows the description
+			if event.IsSummaryDerived() && summary == event.Summary {
+				summary = ""
+			}
+			updates["summary"] = summary
+			if utf8.RuneCountInString(summary) > models.MaxSummaryLen {
+				errs.add("summary", "Summary must be at most 300 characters")
+			}
+		}
+		if v, ok := updates["description_format"]; ok {
+			if format, _ := v.(string); !models.DescriptionFormat(format).Valid() {
+				errs.add("description_format", descriptionFormatMessage)
+			}
 		}
 		if loc, ok := updates["location"].(string); ok && len(loc) > MaxEventLocationLen {
-			encodeError(w, "Location must be at most 500 characters", http.StatusBadRequest)
-			return
+			errs.add("location", "Location must be at most 500 characters")
+		}
+		if country, ok := updates["country"].(string); ok {
+			if len(country) > MaxEventCountryLen {
+				errs.add("country", "Country must be at most 100 characters")
+			} else if country == "" {
+				updates["country_code"] = ""
+			} else if code, ok := countries.Lookup(country); ok {
+				updates["country_code"] = code
+			} else {
+				errs.add("country", unknownCountryMessage)
+			}
 		}
-		if country, ok := updates["country"].(string); ok && len(country) > MaxEventCountryLen {
-			encodeError(w, "Country must be at most 100 characters", http.StatusBadRequest)
-			return
+		if website, ok := updates["website"].(string); ok && website != "" {
+			if len(website) > MaxEventWebsiteLen {
+				errs.add("website", "Website must be at most 2000 characters")
+			} else if u, err := url.Parse(website); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				errs.add("website", "Website must be a valid HTTP or HTTPS URL")
+			}
 		}
-		if website, ok := updates["website"].(string); ok && len(website) > MaxEventWebsiteLen {
-			encodeError(w, "Website must be at most 2000 characters", http.StatusBadRequest)
-			return
+		if logoURL, ok := updates["logo_url"].(string); ok {
+			validateLogoURL(logoURL, &errs)
 		}
-		if website, ok := updates["website"].(string); ok && website != "" {
-			u, err := url.Parse(website)
-			if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
-				encodeError(w, "Website must be a valid HTTP or HTTPS URL", http.StatusBadRequest)
-				return
+		if tags, ok := updates["tags"].(string); ok {
+			if len(tags) > MaxEventTagsLen {
+				errs.add("tags", "Tags must be at most 1000 characters")
+			} else if len(models.SplitTags(tags)) > models.MaxEventTags {
+				errs.add("tags", fmt.Sprintf("At most %d tags are allowed", models.MaxEventTags))
 			}
+			updates["tags"] = models.NormalizeTags(tags)
 		}
-		if tags, ok := updates["tags"].(string); ok && len(tags) > MaxEventTagsLen {
-			encodeError(w, "Tags must be at most 1000 characters", http.StatusBadRequest)
-			return
+		if val, ok := updates["sections"]; ok {
+			data, _ := json.Marshal(val)
+			updates["sections"] = validateEventSections(data, &errs)
+		}
+		if val, ok := updates["status_emails"]; ok {
+			data, _ := json.Marshal(val)
+			updates["status_emails"] = validateStatusEmails(data, &errs)
+		}
+		if val, ok := updates["cfp_questions"]; ok && val != nil {
+			data, _ := json.Marshal(val)
+			validateQuestionWindows(data, &errs)
+		}
+
+		// Keep attendance_mode and the legacy is_online flag in sync. A legacy client
+		// re-sending is_online unchanged leaves a hybrid event hybrid.
+		if v, ok := updates["attendance_mode"]; ok {
+			mode, _ := v.(string)
+			if !models.AttendanceMode(mode).Valid() {
+				errs.add("attendance_mode", attendanceModeMessage)
+			} else {
+				updates["is_online"] = models.AttendanceMode(mode).IsOnline()
+			}
+		} else if isOnline, ok := updates["is_online"].(bool); ok && isOnline != event.AttendanceMode.IsOnline() {
+			updates["attendance_mode"] = string(models.AttendanceModeFromOnline(isOnline))
 		}
 
 		// Validate terms_url if being updated
 		if termsURL, ok := updates["terms_url"].(string); ok && termsURL != "" {
 			if len(termsURL) > MaxEventWebsiteLen {
-				encodeError(w, "Terms URL must be at most 2000 characters", http.StatusBadRequest)
-				return
+				errs.add("terms_url", "Terms URL must be at most 2000 characters")
+			} else if u, err := url.Parse(termsURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				errs.add("terms_url", "Terms URL must be a valid HTTP or HTTPS URL")
 			}
-			u, err := url.Parse(termsURL)
-			if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
-				encodeError(w, "Terms URL must be a valid HTTP or HTTPS URL", http.StatusBadRequest)
-				return
+		}
+
+		// Validate the code of conduct settings against the event's current ones
+		_, cocURLUpdated := updates["coc_url"]
+		_, cocRequiredUpdated := updates["require_coc_acceptance"]
+		if cocURLUpdated || cocRequiredUpdated {
+			cocURL, cocRequired := event.CoCURL, event.RequireCoCAcceptance
+			if cocURLUpdated {
+				cocURL, _ = updates["coc_url"].(string)
+				updates["coc_url"] = cocURL
+			}
+			if cocRequiredUpdated {
+				cocRequired, _ = updates["require_coc_acceptance"].(bool)
+				updates["require_coc_acceptance"] = cocRequired
 			}
+			validateCoCSettings(cocURL, cocRequired, &errs)
+		}
+
+		// Validate the abstract word limits against the event's current ones
+		_, minUpdated := updates["abstract_min_words"]
+		_, maxUpdated := updates["abstract_max_words"]
+		if minUpdated || maxUpdated {
+			minWords := wordLimitUpdate(updates, "abstract_min_words", event.AbstractMinWords, &errs)
+			maxWords := wordLimitUpdate(updates, "abstract_max_words", event.AbstractMaxWords, &errs)
+			validateAbstractWordLimits(minWords, maxWords, &errs)
 		}
 
 		// Validate contact_email if being updated
 		if contactEmail, ok := updates["contact_email"].(string); ok && contactEmail != "" {
 			if _, err := mail.ParseAddress(contactEmail); err != nil {
-				encodeError(w, "Contact email must be a valid email address", http.StatusBadRequest)
-				return
+				errs.add("contact_email", "Contact email must be a valid email address")
 			}
 		}
 
 		// Validate slug if being updated
-		if slug, ok := updates["slug"].(string); ok {
+		slug, slugUpdated := updates["slug"].(string)
+		if slugUpdated {
 			slug = strings.ToLower(slug)
 			if !slugRegex.MatchString(slug) {
-				encodeError(w, "Slug must be lowercase alphanumeric with hyphens only", http.StatusBadRequest)
-				return
-			}
-			if len(slug) > MaxEventSlugLen {
-				encodeError(w, "Slug must be at most 200 characters", http.StatusBadRequest)
-				return
-			}
-			var existing models.Event
-			if cfg.DB.Where("slug = ? AND id != ?", slug, id).First(&existing).Error == nil {
-				encodeError(w, "Slug already exists", http.StatusConflict)
-				return
+				errs.add("slug", "Slug must be lowercase alphanumeric with hyphens only")
+			} else if len(slug) > MaxEventSlugLen {
+				errs.add("slug", "Slug must be at most 200 characters")
 			}
 			updates["slug"] = slug
 		}
+		if series, ok := updates["series"].(string); ok {
+			updates["series"] = validateSeries(series, &errs)
+		}
 
 		// Validate date ordering on update
 		{
@@ -763,115 +1513,232 @@ func UpdateEventHandler(cfg *config.Config) http.HandlerFunc {
 				}
 			}
 			if !startDate.IsZero() && !endDate.IsZero() && endDate.Before(startDate) {
-				encodeError(w, "End date must be after start date", http.StatusBadRequest)
-				return
+				errs.add("end_date", "End date must be after start date")
 			}
 			if !cfpOpen.IsZero() && !cfpClose.IsZero() && cfpClose.Before(cfpOpen) {
-				encodeError(w, "CFP close date must be after CFP open date", http.StatusBadRequest)
-				return
+				errs.add("cfp_close_at", "CFP close date must be after CFP open date")
 			}
 		}
 
+		if len(errs) > 0 {
+			encodeValidationErrors(w, r, errs)
+			return
+		}
+
+		// Payment gate: block opening CFP if listing fee is required and unpaid
+		if statusUpdated && status == string(models.CFPStatusOpen) && cfg.EventListingFee > 0 && !event.IsPaid {
+			encodeAPIError(w, r, "Event listing must be paid before opening CFP", http.StatusPaymentRequired)
+			return
+		}
+
 		// Re-marshal JSONB fields so GORM/pgx stores them correctly.
 		if val, ok := updates["cfp_questions"]; ok && val != nil {
 			jsonBytes, err := json.Marshal(val)
 			if err != nil {
-				encodeError(w, "Invalid cfp_questions data", http.StatusBadRequest)
+				encodeAPIError(w, r, "Invalid cfp_questions data", http.StatusBadRequest)
 				return
 			}
 			updates["cfp_questions"] = datatypes.JSON(jsonBytes)
 		}
 
-		if err := cfg.DB.Model(&event).Updates(updates).Error; err != nil {
+		// Coordinates are stale once the place changes; they are re-geocoded below
+		relocated := placeChanged(event, updates)
+		if relocated {
+			updates["latitude"] = nil
+			updates["longitude"] = nil
+		}
+
+		before, _ := json.Marshal(event)
+		if err := cfg.DBCtx(r).Model(event).Updates(updates).Error; err != nil {
+			if isSlugConflict(err) {
+				encodeAPIErrorCode(w, r, ErrCodeSlugConflict, "Slug already exists", http.StatusConflict)
+				return
+			}
 			cfg.Logger.Error("failed to update event", "error", err)
-			encodeError(w, "Failed to update event", http.StatusInternalServerError)
+			encodeAPIError(w, r, "Failed to update event", http.StatusInternalServerError)
 			return
 		}
+		invalidatePublicCache()
 
 		// Reload event
-		if err := cfg.DB.First(&event, id).Error; err != nil {
+		if err := cfg.DBCtx(r).First(event, id).Error; err != nil {
 			cfg.Logger.Error("failed to reload event after update", "error", err)
-			encodeError(w, "Failed to reload event", http.StatusInternalServerError)
+			encodeAPIError(w, r, "Failed to reload event", http.StatusInternalServerError)
 			return
 		}
 
+		changed := changedEventFields(before, event, requested)
+		if i := slices.Index(changed, "cfp_status"); i >= 0 {
+			changed = slices.Delete(changed, i, i+1)
+			recordActivity(cfg, r, models.EventActivity{
+				EventID: event.ID,
+				ActorID: &user.ID,
+				Action:  models.ActivityCFPStatus,
+				Detail:  string(event.CFPStatus),
+			})
+		}
+		if len(changed) > 0 {
+			recordActivity(cfg, r, models.EventActivity{
+				EventID: event.ID,
+				ActorID: &user.ID,
+				Action:  models.ActivityEventUpdated,
+				Detail:  strings.Join(changed, ","),
+			})
+		}
+
+		if relocated {
+			geocodeEventAsync(cfg, *event)
+		}
+		if _, ok := updates["cfp_status"]; ok || updates["cfp_open_at"] != nil {
+			notifyCFPOpened(cfg, *event)
+		}
+
 		encodeResponse(w, r, event)
 	}
 }
 
-// DeleteEventHandler deletes an event and its proposals
+// deleteEventConfirmation is sent with the refusal to delete an event that has
+// proposals, so the client can show what would be lost and where to export it
+type deleteEventConfirmation struct {
+	Slug           string           `json:"slug"`
+	ProposalTotal  int64            `json:"proposal_total"`
+	ProposalCounts map[string]int64 `json:"proposal_counts"`
+	ExportURL      string           `json:"export_url"`
+}
+
+// deleteEventConfirmationFor counts the event's proposals by status
+func deleteEventConfirmationFor(cfg *config.Config, r *http.Request, event *models.Event) (*deleteEventConfirmation, error) {
+	var counts []struct {
+		Status models.ProposalStatus
+		Count  int64
+	}
+	if err := cfg.DBCtx(r).Model(&models.Proposal{}).
+		Select("status, COUNT(*) AS count").
+		Where("event_id = ?", event.ID).
+		Group("status").
+		Scan(&counts).Error; err != nil {
+		return nil, err
+	}
+
+	c := &deleteEventConfirmation{
+		Slug:           event.Slug,
+		ProposalCounts: make(map[string]int64, len(counts)),
+		ExportURL:      fmt.Sprintf("/api/v0/events/%d/proposals/export", event.ID),
+	}
+	for _, row := range counts {
+		c.ProposalCounts[string(row.Status)] = row.Count
+		c.ProposalTotal += row.Count
+	}
+	return c, nil
+}
+
+// DeleteEventHandler deletes an event and its proposals. An event with
+// proposals is only deleted when the body confirms it with the event's slug;
+// the proposals are kept in the retention table for admins to recover.
 func DeleteEventHandler(cfg *config.Config) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		user := GetUserFromContext(r.Context())
 		if user == nil {
-			encodeError(w, "Unauthorized", http.StatusUnauthorized)
+			encodeAPIError(w, r, "Unauthorized", http.StatusUnauthorized)
 			return
 		}
 
 		idStr := r.PathValue("id")
 		id, err := strconv.ParseUint(idStr, 10, 32)
 		if err != nil {
-			encodeError(w, "Invalid event ID", http.StatusBadRequest)
+			encodeAPIError(w, r, "Invalid event ID", http.StatusBadRequest)
+			return
+		}
+
+		// Deleting an event with proposals must be confirmed with its slug
+		var req struct {
+			Confirm string `json:"confirm"`
+		}
+		if err := decodeJSON(w, r, MaxSmallBodySize, &req); err != nil && !errors.Is(err, errEmptyBody) {
+			encodeBodyError(w, r, err)
 			return
 		}
 
 		var event models.Event
-		if err := cfg.DB.First(&event, id).Error; err != nil {
-			encodeError(w, "Event not found", http.StatusNotFound)
+		if err := cfg.DBCtx(r).First(&event, id).Error; err != nil {
+			encodeAPIError(w, r, "Event not found", http.StatusNotFound)
 			return
 		}
 
-		// Only the creator can delete an event
-		if event.CreatedByID == nil || *event.CreatedByID != user.ID {
-			encodeError(w, "Only the event creator can delete the event", http.StatusForbidden)
+		// Only the creator can delete an event, or an admin once it is orphaned
+		if !actsAsCreator(cfg, &event, user) {
+			encodeAPIError(w, r, "Only the event creator can delete the event", http.StatusForbidden)
 			return
 		}
 
 		// Block deletion if there are accepted or confirmed proposals
 		var acceptedCount int64
-		if err := cfg.DB.Model(&models.Proposal{}).
+		if err := cfg.DBCtx(r).Model(&models.Proposal{}).
 			Where("event_id = ? AND status IN ?", event.ID, []string{
 				string(models.ProposalStatusAccepted),
 				string(models.ProposalStatusTentative),
 			}).Count(&acceptedCount).Error; err != nil {
 			cfg.Logger.Error("failed to check accepted proposals", "error", err)
-			encodeError(w, "Failed to delete event", http.StatusInternalServerError)
+			encodeAPIError(w, r, "Failed to delete event", http.StatusInternalServerError)
 			return
 		}
 		if acceptedCount > 0 {
-			encodeError(w, "Cannot delete event with accepted or tentative proposals. Reject or cancel them first.", http.StatusConflict)
+			encodeAPIError(w, r, "Cannot delete event with accepted or tentative proposals. Reject or cancel them first.", http.StatusConflict)
+			return
+		}
+
+		confirmation, err := deleteEventConfirmationFor(cfg, r, &event)
+		if err != nil {
+			cfg.Logger.Error("failed to count event proposals", "error", err, "event_id", event.ID)
+			encodeAPIError(w, r, "Failed to delete event", http.StatusInternalServerError)
+			return
+		}
+		if confirmation.ProposalTotal > 0 && req.Confirm != event.Slug {
+			encodeAPIErrorDetails(w, r, ErrCodeConfirmationRequired,
+				fmt.Sprintf("This event has %d proposals. Export them from %s first, then delete again with confirm set to the event slug", confirmation.ProposalTotal, confirmation.ExportURL),
+				confirmation, http.StatusBadRequest)
 			return
 		}
 
-		// Delete associated proposals and organizer links, then the event
-		tx := cfg.DB.Begin()
+		// Keep a copy of the proposals for recovery, delete them and the
+		// organizer links, then the event. The copies are the recovery
+		// path, so the proposals are deleted for good, not soft deleted.
+		tx := cfg.DBCtx(r).Begin()
 		if tx.Error != nil {
 			cfg.Logger.Error("failed to begin transaction", "error", tx.Error)
-			encodeError(w, "Failed to delete event", http.StatusInternalServerError)
+			encodeAPIError(w, r, "Failed to delete event", http.StatusInternalServerError)
 			return
 		}
 		defer tx.Rollback()
 
-		if err := tx.Where("event_id = ?", event.ID).Delete(&models.Proposal{}).Error; err != nil {
+		retained, err := models.RetainDeletedProposals(tx, &event, user.ID, cfg.Now())
+		if err != nil {
+			cfg.Logger.Error("failed to retain event proposals", "error", err, "event_id", event.ID)
+			encodeAPIError(w, r, "Failed to delete event", http.StatusInternalServerError)
+			return
+		}
+		if err := tx.Unscoped().Where("event_id = ?", event.ID).Delete(&models.Proposal{}).Error; err != nil {
 			cfg.Logger.Error("failed to delete event proposals", "error", err)
-			encodeError(w, "Failed to delete event", http.StatusInternalServerError)
+			encodeAPIError(w, r, "Failed to delete event", http.StatusInternalServerError)
 			return
 		}
 		if err := tx.Model(&event).Association("Organizers").Clear(); err != nil {
 			cfg.Logger.Error("failed to clear event organizers", "error", err)
-			encodeError(w, "Failed to delete event", http.StatusInternalServerError)
+			encodeAPIError(w, r, "Failed to delete event", http.StatusInternalServerError)
 			return
 		}
 		if err := tx.Delete(&event).Error; err != nil {
 			cfg.Logger.Error("failed to delete event", "error", err)
-			encodeError(w, "Failed to delete event", http.StatusInternalServerError)
+			encodeAPIError(w, r, "Failed to delete event", http.StatusInternalServerError)
 			return
 		}
 		if err := tx.Commit().Error; err != nil {
 			cfg.Logger.Error("failed to commit event deletion", "error", err, "event_id", id)
-			encodeError(w, "Failed to delete event", http.StatusInternalServerError)
+			encodeAPIError(w, r, "Failed to delete event", http.StatusInternalServerError)
 			return
 		}
+		invalidatePublicCache()
+		cfg.Logger.Info("event deleted", "event_id", event.ID, "user_id", user.ID, "retained_proposals", retained)
 
 		encodeResponse(w, r, map[string]string{"message": "Event deleted"})
 	}
@@ -882,36 +1749,32 @@ func UpdateCFPStatusHandler(cfg *config.Config) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		user := GetUserFromContext(r.Context())
 		if user == nil {
-			encodeError(w, "Unauthorized", http.StatusUnauthorized)
+			encodeAPIError(w, r, "Unauthorized", http.StatusUnauthorized)
 			return
 		}
 
 		id, err := strconv.ParseUint(r.PathValue("id"), 10, 32)
 		if err != nil {
-			encodeError(w, "Invalid event ID", http.StatusBadRequest)
+			encodeAPIError(w, r, "Invalid event ID", http.StatusBadRequest)
 			return
 		}
 
-		var event models.Event
-		if err := cfg.DB.Preload("Organizers").First(&event, id).Error; err != nil {
-			encodeError(w, "Event not found", http.StatusNotFound)
+		event, err := getEventWithOrganizers(cfg, r, uint(id))
+		if err != nil {
+			encodeAPIError(w, r, "Event not found", http.StatusNotFound)
 			return
 		}
 
 		if !event.IsOrganizer(user.ID) {
-			encodeError(w, "Forbidden", http.StatusForbidden)
+			encodeAPIError(w, r, "Forbidden", http.StatusForbidden)
 			return
 		}
 
-		r.Body = http.MaxBytesReader(w, r.Body, 1<<20) // 1MB limit
-		defer r.Body.Close()
-
 		var req struct {
-			Status models.CFPStatus `json:"status"`
+			Status     models.CFPStatus `json:"status"`
+			CFPCloseAt *time.Time       `json:"cfp_close_at"`
 		}
-
-		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-			encodeError(w, "Invalid request body", http.StatusBadRequest)
+		if !decodeJSONBody(w, r, MaxSmallBodySize, &req) {
 			return
 		}
 
@@ -925,22 +1788,51 @@ func UpdateCFPStatusHandler(cfg *config.Config) http.HandlerFunc {
 		}
 
 		if !validStatuses[req.Status] {
-			encodeError(w, "Invalid status", http.StatusBadRequest)
+			encodeAPIError(w, r, "Invalid status", http.StatusBadRequest)
 			return
 		}
 
 		// Payment gate: block opening CFP if listing fee is required and unpaid
 		if req.Status == models.CFPStatusOpen && cfg.EventListingFee > 0 && !event.IsPaid {
-			encodeError(w, "Event listing must be paid before opening CFP", http.StatusPaymentRequired)
+			encodeAPIError(w, r, "Event listing must be paid before opening CFP", http.StatusPaymentRequired)
+			return
+		}
+
+		// Reopening a CFP whose deadline has passed needs a new deadline in the
+		// same request, otherwise it would be "open" but reject submissions
+		now := cfg.Now()
+		updates := map[string]interface{}{"cfp_status": req.Status}
+		var errs validationErrors
+		if req.CFPCloseAt != nil {
+			if req.Status != models.CFPStatusOpen {
+				errs.add("cfp_close_at", "A new CFP close date can only be set when opening the CFP")
+			} else if !req.CFPCloseAt.After(now) {
+				errs.add("cfp_close_at", "CFP close date must be in the future")
+			} else if !event.CFPOpenAt.IsZero() && req.CFPCloseAt.Before(event.CFPOpenAt) {
+				errs.add("cfp_close_at", "CFP close date must be after CFP open date")
+			} else {
+				updates["cfp_close_at"] = *req.CFPCloseAt
+			}
+		} else if req.Status == models.CFPStatusOpen && !event.CFPCloseAt.IsZero() && !event.CFPCloseAt.After(now) {
+			errs.add("cfp_close_at", "The CFP close date has passed; include a new cfp_close_at in the future to reopen the CFP")
+		}
+		if len(errs) > 0 {
+			encodeValidationErrors(w, r, errs)
 			return
 		}
 
 		oldStatus := event.CFPStatus
-		if err := cfg.DB.Model(&event).Update("cfp_status", req.Status).Error; err != nil {
-			encodeError(w, "Failed to update status", http.StatusInternalServerError)
+		oldCloseAt := event.CFPCloseAt
+		wasAccepting := event.IsCFPOpenAt(now, 0)
+		if err := cfg.DBCtx(r).Model(event).Updates(updates).Error; err != nil {
+			encodeAPIError(w, r, "Failed to update status", http.StatusInternalServerError)
 			return
 		}
+		invalidatePublicCache()
 		event.CFPStatus = req.Status
+		if req.CFPCloseAt != nil {
+			event.CFPCloseAt = *req.CFPCloseAt
+		}
 
 		cfg.Logger.Info("CFP status changed",
 			"event_id", event.ID,
@@ -948,125 +1840,376 @@ func UpdateCFPStatusHandler(cfg *config.Config) http.HandlerFunc {
 			"new_status", string(req.Status),
 			"actor_id", user.ID,
 		)
+		if oldStatus != req.Status {
+			recordActivity(cfg, r, models.EventActivity{
+				EventID: event.ID,
+				ActorID: &user.ID,
+				Action:  models.ActivityCFPStatus,
+				Detail:  string(req.Status),
+			})
+		}
+		if req.CFPCloseAt != nil && !req.CFPCloseAt.Equal(oldCloseAt) {
+			recordActivity(cfg, r, models.EventActivity{
+				EventID: event.ID,
+				ActorID: &user.ID,
+				Action:  models.ActivityEventUpdated,
+				Detail:  "cfp_close_at",
+			})
+		}
+
+		// A CFP that stopped accepting submissions and accepts them again, or
+		// whose deadline moved later, was extended
+		reopened := req.Status == models.CFPStatusOpen && oldStatus != models.CFPStatusDraft && !wasAccepting
+		later := wasAccepting && req.CFPCloseAt != nil && !oldCloseAt.IsZero() && req.CFPCloseAt.After(oldCloseAt)
+		if reopened || later {
+			cfg.Logger.Info("CFP extended",
+				"event_id", event.ID,
+				"old_close_at", oldCloseAt,
+				"new_close_at", event.CFPCloseAt,
+				"reopened", reopened,
+				"actor_id", user.ID,
+			)
+			notifyCFPExtended(cfg, *event)
+		}
+		if req.Status == models.CFPStatusOpen {
+			notifyCFPOpened(cfg, *event)
+		}
 
 		encodeResponse(w, r, event)
 	}
 }
 
+// notifyCFPExtended emails everyone who has submitted to the event that its
+// CFP was extended (fire-and-forget)
+func notifyCFPExtended(cfg *config.Config, event models.Event) {
+	if cfg.EmailSender == nil {
+		return
+	}
+	SafeGo(cfg, func() {
+		var submitters []models.User
+		if err := cfg.DB.Where("id IN (?)", cfg.DB.Model(&models.Proposal{}).
+			Select("created_by_id").
+			Where("event_id = ?", event.ID)).
+			Find(&submitters).Error; err != nil {
+			cfg.Logger.Error("failed to load submitters for cfp extended notification", "event_id", event.ID, "error", err)
+			return
+		}
+		if len(submitters) == 0 {
+			return
+		}
+		ncfg := &email.NotifyConfig{
+			Sender:  cfg.EmailSender,
+			From:    cfg.EmailFrom,
+			BaseURL: cfg.BaseURL,
+			Logger:  cfg.Logger,
+		}
+		email.SendCFPExtendedNotification(ncfg, &event, submitters)
+	})
+}
+
 // GetEventProposalsHandler returns proposals for an event
 func GetEventProposalsHandler(cfg *config.Config) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		user := GetUserFromContext(r.Context())
 		if user == nil {
-			encodeError(w, "Unauthorized", http.StatusUnauthorized)
+			encodeAPIError(w, r, "Unauthorized", http.StatusUnauthorized)
 			return
 		}
 
 		id, err := strconv.ParseUint(r.PathValue("id"), 10, 32)
 		if err != nil {
-			encodeError(w, "Invalid event ID", http.StatusBadRequest)
+			encodeAPIError(w, r, "Invalid event ID", http.StatusBadRequest)
 			return
 		}
 
-		var event models.Event
-		if err := cfg.DB.Preload("Organizers").First(&event, id).Error; err != nil {
-			encodeError(w, "Event not found", http.StatusNotFound)
+		event, err := getEventWithOrganizers(cfg, r, uint(id))
+		if err != nil {
+			encodeAPIError(w, r, "Event not found", http.StatusNotFound)
 			return
 		}
 
 		isOrganizer := event.IsOrganizer(user.ID)
 
-		// Hide draft events from non-organizers to prevent information disclosure
-		if event.CFPStatus == models.CFPStatusDraft && !isOrganizer {
-			encodeError(w, "Event not found", http.StatusNotFound)
+		// Hide draft and held events from non-organizers to prevent information disclosure
+		if (event.CFPStatus == models.CFPStatusDraft || !event.IsListed()) && !isOrganizer {
+			encodeAPIError(w, r, "Event not found", http.StatusNotFound)
 			return
 		}
 
 		var proposals []models.Proposal
-		query := cfg.DB.Where("event_id = ?", id).Order("created_at DESC").Limit(MaxProposalsPerPage)
+		query := cfg.DBCtx(r).Where("event_id = ?", id).Order("created_at DESC, id DESC").Limit(MaxProposalsPerPage)
+
+		// Optional window on when speakers confirmed attendance, e.g.
+		// ?confirmed_before=2026-05-01 to chase the late ones
+		var errs validationErrors
+		if after, ok := parseTimeQuery(r.URL.Query(), "confirmed_after", &errs); ok {
+			query = query.Where("attendance_confirmed_at >= ?", after)
+		}
+		if before, ok := parseTimeQuery(r.URL.Query(), "confirmed_before", &errs); ok {
+			query = query.Where("attendance_confirmed_at < ?", before)
+		}
+		// ?missing_answers=true keeps the proposals without an answer to a
+		// required question, such as one added after they were submitted
+		if r.URL.Query().Get("missing_answers") == "true" {
+			questions, _ := event.GetCFPQuestions()
+			required := models.MissingAnswers(nil, questions, cfg.Now())
+			if len(required) == 0 {
+				query = query.Where("FALSE")
+			} else {
+				conds := make([]string, len(required))
+				args := make([]interface{}, len(required))
+				for i, id := range required {
+					conds[i] = "COALESCE(jsonb_typeof(custom_answers -> ?), 'null') = 'null'"
+					args[i] = id
+				}
+				query = query.Where(strings.Join(conds, " OR "), args...)
+			}
+		}
+		if len(errs) > 0 {
+			encodeValidationErrors(w, r, errs)
+			return
+		}
 
 		if isOrganizer {
-			// Organizers see all proposals
+			// Organizers see all proposals, with their own private review notes
 			if err := query.Find(&proposals).Error; err != nil {
 				cfg.Logger.Error("failed to query proposals", "error", err, "event_id", id)
-				encodeError(w, "Failed to load proposals", http.StatusInternalServerError)
+				encodeAPIError(w, r, "Failed to load proposals", http.StatusInternalServerError)
+				return
+			}
+			if err := models.LoadReviewerNotes(cfg.DBCtx(r), user.ID, proposals); err != nil {
+				cfg.Logger.Error("failed to load reviewer notes", "error", err, "event_id", id)
+				encodeAPIError(w, r, "Failed to load proposals", http.StatusInternalServerError)
+				return
+			}
+			if err := models.LoadSeriesSubmissions(cfg.DBCtx(r), event, proposals); err != nil {
+				cfg.Logger.Error("failed to load series submissions", "error", err, "event_id", id)
+				encodeAPIError(w, r, "Failed to load proposals", http.StatusInternalServerError)
 				return
 			}
+			// The listing is capped, so the accepted count comes from the database
+			var accepted int64
+			if err := cfg.DBCtx(r).Model(&models.Proposal{}).
+				Where("event_id = ? AND status = ?", id, models.ProposalStatusAccepted).
+				Count(&accepted).Error; err != nil {
+				cfg.Logger.Error("failed to count accepted proposals", "error", err, "event_id", id)
+				encodeAPIError(w, r, "Failed to load proposals", http.StatusInternalServerError)
+				return
+			}
+			setCapacityHeaders(w, event, accepted)
 		} else {
 			// Others see only their own proposals
 			if err := query.Where("created_by_id = ?", user.ID).Find(&proposals).Error; err != nil {
 				cfg.Logger.Error("failed to query user proposals", "error", err, "event_id", id)
-				encodeError(w, "Failed to load proposals", http.StatusInternalServerError)
+				encodeAPIError(w, r, "Failed to load proposals", http.StatusInternalServerError)
 				return
 			}
-			// Hide organizer notes from non-organizers
-			for i := range proposals {
-				proposals[i].OrganizerNotes = ""
-			}
 		}
+		newProposalView(cfg, event, user).shapeAll(proposals)
 
-		encodeResponse(w, r, proposals)
+		encodeResponse(w, r, withCSV(proposals, func(cw *csv.Writer) { writeProposalsCSV(cw, proposals) }))
 	}
 }
 
-// GetEventOrganizersHandler returns organizers for an event
-func GetEventOrganizersHandler(cfg *config.Config) http.HandlerFunc {
+// parseTimeQuery reads an RFC 3339 time or a date, meaning midnight UTC, from
+// the query parameter param. It reports whether the parameter was given.
+func parseTimeQuery(query url.Values, param string, errs *validationErrors) (time.Time, bool) {
+	v := query.Get(param)
+	if v == "" {
+		return time.Time{}, false
+	}
+	if t, err := time.Parse(time.RFC3339, v); err == nil {
+		return t, true
+	}
+	if t, err := time.Parse("2006-01-02", v); err == nil {
+		return t, true
+	}
+	errs.add(param, "Must be an RFC 3339 time or a YYYY-MM-DD date")
+	return time.Time{}, false
+}
+
+// ProposalSummary is a quick overview of an event's proposals for organizers
+type ProposalSummary struct {
+	EventID        uint             `json:"event_id"`
+	Total          int64            `json:"total"`
+	ByStatus       map[string]int64 `json:"by_status"`
+	ByFormat       map[string]int64 `json:"by_format"`
+	Unrated        int64            `json:"unrated"`
+	Confirmed      int64            `json:"confirmed"`
+	Last24h        int64            `json:"last_24h"`
+	Last7d         int64            `json:"last_7d"`
+	AverageRating  *float64         `json:"average_rating"`  // nil when nothing is rated
+	AcceptedCount  int64            `json:"accepted_count"`
+	MaxAccepted    *int             `json:"max_accepted"`    // nil = unlimited
+	RemainingSlots *int64           `json:"remaining_slots"` // nil = unlimited
+}
+
+// GetEventProposalsSummaryHandler returns proposal counts for an event (organizer only)
+func GetEventProposalsSummaryHandler(cfg *config.Config) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		user := GetUserFromContext(r.Context())
 		if user == nil {
-			encodeError(w, "Unauthorized", http.StatusUnauthorized)
+			encodeAPIError(w, r, "Unauthorized", http.StatusUnauthorized)
 			return
 		}
 
 		id, err := strconv.ParseUint(r.PathValue("id"), 10, 32)
 		if err != nil {
-			encodeError(w, "Invalid event ID", http.StatusBadRequest)
+			encodeAPIError(w, r, "Invalid event ID", http.StatusBadRequest)
 			return
 		}
 
-		var event models.Event
-		if err := cfg.DB.Preload("Organizers").First(&event, id).Error; err != nil {
-			encodeError(w, "Event not found", http.StatusNotFound)
+		event, err := getEventWithOrganizers(cfg, r, uint(id))
+		if err != nil {
+			encodeAPIError(w, r, "Event not found", http.StatusNotFound)
 			return
 		}
 
 		if !event.IsOrganizer(user.ID) {
-			encodeError(w, "Forbidden", http.StatusForbidden)
+			encodeAPIError(w, r, "Forbidden", http.StatusForbidden)
+			return
+		}
+
+		// Consolidate counts into a single query using conditional aggregation
+		type summaryRow struct {
+			Total         int64
+			Submitted     int64
+			Accepted      int64
+			Rejected      int64
+			Tentative     int64
+			Cancelled     int64
+			Talk          int64
+			Workshop      int64
+			Lightning     int64
+			Unrated       int64
+			Confirmed     int64
+			Last24h       int64 `gorm:"column:last_24h"`
+			Last7d        int64 `gorm:"column:last_7d"`
+			AverageRating *float64
+		}
+		now := cfg.Now()
+		var row summaryRow
+		if err := cfg.DBCtx(r).Model(&models.Proposal{}).Select(`
+			COUNT(*) AS total,
+			COUNT(CASE WHEN status = ? THEN 1 END) AS submitted,
+			COUNT(CASE WHEN status = ? THEN 1 END) AS accepted,
+			COUNT(CASE WHEN status = ? THEN 1 END) AS rejected,
+			COUNT(CASE WHEN status = ? THEN 1 END) AS tentative,
+			COUNT(CASE WHEN status = ? THEN 1 END) AS cancelled,
+			COUNT(CASE WHEN format = ? THEN 1 END) AS talk,
+			COUNT(CASE WHEN format = ? THEN 1 END) AS workshop,
+			COUNT(CASE WHEN format = ? THEN 1 END) AS lightning,
+			COUNT(CASE WHEN rating IS NULL THEN 1 END) AS unrated,
+			COUNT(CASE WHEN attendance_confirmed THEN 1 END) AS confirmed,
+			COUNT(CASE WHEN created_at >= ? THEN 1 END) AS last_24h,
+			COUNT(CASE WHEN created_at >= ? THEN 1 END) AS last_7d,
+			AVG(rating)::float8 AS average_rating`,
+			models.ProposalStatusSubmitted, models.ProposalStatusAccepted,
+			models.ProposalStatusRejected, models.ProposalStatusTentative,
+			models.ProposalStatusCancelled,
+			models.FormatTalk, models.FormatWorkshop, models.FormatLightning,
+			now.Add(-24*time.Hour), now.AddDate(0, 0, -7),
+		).Where("event_id = ?", event.ID).Scan(&row).Error; err != nil {
+			cfg.Logger.Error("failed to query proposal summary", "error", err, "event_id", event.ID)
+			encodeAPIError(w, r, "Failed to load proposal summary", http.StatusInternalServerError)
+			return
+		}
+
+		summary := ProposalSummary{
+			EventID: event.ID,
+			Total:   row.Total,
+			ByStatus: map[string]int64{
+				string(models.ProposalStatusSubmitted): row.Submitted,
+				string(models.ProposalStatusAccepted):  row.Accepted,
+				string(models.ProposalStatusRejected):  row.Rejected,
+				string(models.ProposalStatusTentative): row.Tentative,
+				string(models.ProposalStatusCancelled): row.Cancelled,
+			},
+			ByFormat: map[string]int64{
+				string(models.FormatTalk):      row.Talk,
+				string(models.FormatWorkshop):  row.Workshop,
+				string(models.FormatLightning): row.Lightning,
+			},
+			Unrated:        row.Unrated,
+			Confirmed:      row.Confirmed,
+			Last24h:        row.Last24h,
+			Last7d:         row.Last7d,
+			AverageRating:  row.AverageRating,
+			AcceptedCount:  row.Accepted,
+			MaxAccepted:    event.MaxAccepted,
+			RemainingSlots: remainingSlots(event, row.Accepted),
+		}
+
+		encodeResponse(w, r, summary)
+	}
+}
+
+// organizerResponse is an organizer of an event. The timestamps are those
+// of the organizer's account.
+type organizerResponse struct {
+	ID        uint      `json:"id"`
+	Email     string    `json:"email"`
+	Name      string    `json:"name"`
+	IsCreator bool      `json:"is_creator"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func newOrganizerResponse(u models.User, isCreator bool) organizerResponse {
+	return organizerResponse{
+		ID:        u.ID,
+		Email:     u.Email,
+		Name:      u.Name,
+		IsCreator: isCreator,
+		CreatedAt: u.CreatedAt,
+		UpdatedAt: u.UpdatedAt,
+	}
+}
+
+// GetEventOrganizersHandler returns organizers for an event
+func GetEventOrganizersHandler(cfg *config.Config) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user := GetUserFromContext(r.Context())
+		if user == nil {
+			encodeAPIError(w, r, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		id, err := strconv.ParseUint(r.PathValue("id"), 10, 32)
+		if err != nil {
+			encodeAPIError(w, r, "Invalid event ID", http.StatusBadRequest)
 			return
 		}
 
-		type OrganizerResponse struct {
-			ID        uint   `json:"id"`
-			Email     string `json:"email"`
-			Name      string `json:"name"`
-			IsCreator bool   `json:"is_creator"`
+		event, err := getEventWithOrganizers(cfg, r, uint(id))
+		if err != nil {
+			encodeAPIError(w, r, "Event not found", http.StatusNotFound)
+			return
+		}
+
+		// Admins manage the organizers of orphaned events
+		if !event.IsOrganizer(user.ID) && !actsAsCreator(cfg, event, user) {
+			encodeAPIError(w, r, "Forbidden", http.StatusForbidden)
+			return
 		}
 
-		var organizers []OrganizerResponse
+		organizers := []organizerResponse{}
 		var creatorID uint
 
 		// Include creator info if available
 		if event.CreatedByID != nil {
 			var creator models.User
-			if err := cfg.DB.First(&creator, *event.CreatedByID).Error; err == nil {
+			if err := cfg.DBCtx(r).First(&creator, *event.CreatedByID).Error; err != nil {
+				cfg.Logger.Warn("failed to load event creator", "error", err, "event_id", event.ID)
+			} else {
 				creatorID = creator.ID
-				organizers = append(organizers, OrganizerResponse{
-					ID:        creator.ID,
-					Email:     creator.Email,
-					Name:      creator.Name,
-					IsCreator: true,
-				})
+				organizers = append(organizers, newOrganizerResponse(creator, true))
 			}
 		}
 
 		for _, org := range event.Organizers {
 			if org.ID != creatorID {
-				organizers = append(organizers, OrganizerResponse{
-					ID:        org.ID,
-					Email:     org.Email,
-					Name:      org.Name,
-					IsCreator: false,
-				})
+				organizers = append(organizers, newOrganizerResponse(org, false))
 			}
 		}
 
@@ -1079,62 +2222,58 @@ func AddOrganizerHandler(cfg *config.Config) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		user := GetUserFromContext(r.Context())
 		if user == nil {
-			encodeError(w, "Unauthorized", http.StatusUnauthorized)
+			encodeAPIError(w, r, "Unauthorized", http.StatusUnauthorized)
 			return
 		}
 
 		id, err := strconv.ParseUint(r.PathValue("id"), 10, 32)
 		if err != nil {
-			encodeError(w, "Invalid event ID", http.StatusBadRequest)
+			encodeAPIError(w, r, "Invalid event ID", http.StatusBadRequest)
 			return
 		}
 
-		var event models.Event
-		if err := cfg.DB.Preload("Organizers").First(&event, id).Error; err != nil {
-			encodeError(w, "Event not found", http.StatusNotFound)
+		event, err := getEventWithOrganizers(cfg, r, uint(id))
+		if err != nil {
+			encodeAPIError(w, r, "Event not found", http.StatusNotFound)
 			return
 		}
 
-		if !event.IsOrganizer(user.ID) {
-			encodeError(w, "Forbidden", http.StatusForbidden)
+		// Admins manage the organizers of orphaned events
+		if !event.IsOrganizer(user.ID) && !actsAsCreator(cfg, event, user) {
+			encodeAPIError(w, r, "Forbidden", http.StatusForbidden)
 			return
 		}
 
-		r.Body = http.MaxBytesReader(w, r.Body, 1<<20) // 1MB limit
-		defer r.Body.Close()
-
 		var req struct {
 			Email string `json:"email"`
 		}
-
-		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-			encodeError(w, "Invalid request body", http.StatusBadRequest)
+		if !decodeJSONBody(w, r, MaxSmallBodySize, &req) {
 			return
 		}
 
-		if req.Email == "" {
-			encodeError(w, "Email is required", http.StatusBadRequest)
+		if strings.TrimSpace(req.Email) == "" {
+			encodeAPIError(w, r, "Email is required", http.StatusBadRequest)
 			return
 		}
 
-		// Find user by email
-		var newOrganizer models.User
-		if err := cfg.DB.Where("email = ?", req.Email).First(&newOrganizer).Error; err != nil {
-			encodeError(w, "User not found", http.StatusNotFound)
+		// Find user by email, whatever its case
+		newOrganizer, err := models.GetUserByEmail(cfg.DBCtx(r), req.Email)
+		if err != nil {
+			encodeAPIError(w, r, "User not found", http.StatusNotFound)
 			return
 		}
 
 		// Check if already an organizer
 		if event.IsOrganizer(newOrganizer.ID) {
-			encodeError(w, "User is already an organizer", http.StatusConflict)
+			encodeAPIError(w, r, "User is already an organizer", http.StatusConflict)
 			return
 		}
 
 		// Use a transaction with row lock to prevent TOCTOU race on organizer count
-		tx := cfg.DB.Begin()
+		tx := cfg.DBCtx(r).Begin()
 		if tx.Error != nil {
 			cfg.Logger.Error("failed to begin transaction", "error", tx.Error)
-			encodeError(w, "Failed to add organizer", http.StatusInternalServerError)
+			encodeAPIError(w, r, "Failed to add organizer", http.StatusInternalServerError)
 			return
 		}
 		defer tx.Rollback()
@@ -1143,29 +2282,25 @@ func AddOrganizerHandler(cfg *config.Config) http.HandlerFunc {
 		var lockedEvent models.Event
 		if err := tx.Preload("Organizers").Clauses(clause.Locking{Strength: "UPDATE"}).First(&lockedEvent, event.ID).Error; err != nil {
 			cfg.Logger.Error("failed to lock event for organizer add", "error", err)
-			encodeError(w, "Failed to add organizer", http.StatusInternalServerError)
+			encodeAPIError(w, r, "Failed to add organizer", http.StatusInternalServerError)
 			return
 		}
 
-		totalOrganizers := len(lockedEvent.Organizers)
-		if lockedEvent.CreatedByID != nil {
-			totalOrganizers++
-		}
-		if totalOrganizers >= cfg.MaxOrganizersPerEvent {
-			encodeError(w, fmt.Sprintf("Maximum %d organizers allowed", cfg.MaxOrganizersPerEvent), http.StatusBadRequest)
+		if organizerCount(&lockedEvent) >= cfg.MaxOrganizersPerEvent {
+			encodeAPIError(w, r, fmt.Sprintf("Maximum %d organizers allowed", cfg.MaxOrganizersPerEvent), http.StatusBadRequest)
 			return
 		}
 
 		// Add to organizers within the transaction
-		if err := tx.Model(&lockedEvent).Association("Organizers").Append(&newOrganizer); err != nil {
+		if err := tx.Model(&lockedEvent).Association("Organizers").Append(newOrganizer); err != nil {
 			cfg.Logger.Error("failed to add organizer", "error", err, "event_id", event.ID)
-			encodeError(w, "Failed to add organizer", http.StatusInternalServerError)
+			encodeAPIError(w, r, "Failed to add organizer", http.StatusInternalServerError)
 			return
 		}
 
 		if err := tx.Commit().Error; err != nil {
 			cfg.Logger.Error("failed to commit organizer add", "error", err)
-			encodeError(w, "Failed to add organizer", http.StatusInternalServerError)
+			encodeAPIError(w, r, "Failed to add organizer", http.StatusInternalServerError)
 			return
 		}
 
@@ -1175,10 +2310,273 @@ func AddOrganizerHandler(cfg *config.Config) http.HandlerFunc {
 			"added_email", newOrganizer.Email,
 			"actor_id", user.ID,
 		)
+		recordActivity(cfg, r, models.EventActivity{
+			EventID: event.ID,
+			ActorID: &user.ID,
+			Action:  models.ActivityOrganizerAdded,
+			Subject: organizerName(newOrganizer),
+		})
+
+		encodeCreated(w, r, fmt.Sprintf("/api/v0/events/%d/organizers/%d", event.ID, newOrganizer.ID), map[string]string{"message": "Organizer added"})
+	}
+}
+
+// organizerCount is the number of organizers of an event, its creator included
+func organizerCount(event *models.Event) int {
+	count := len(event.Organizers)
+	if event.CreatedByID != nil {
+		count++
+	}
+	return count
+}
+
+// MaxBulkOrganizerEmails caps the emails one bulk organizer request may list
+const MaxBulkOrganizerEmails = 50
+
+// What became of each email of a bulk organizer request
+const (
+	OrganizerAdded            = "added"
+	OrganizerAlreadyOrganizer = "already_organizer"
+	OrganizerNotFound         = "not_found"
+	OrganizerLimitReached     = "limit_reached"
+)
+
+// organizerAddResult is the outcome of adding one email in bulk
+type organizerAddResult struct {
+	Email  string `json:"email"`
+	Result string `json:"result"`
+	UserID uint   `json:"user_id,omitempty"`
+}
+
+// BulkAddOrganizersHandler adds several organizers to an event at once, by
+// email, and reports what became of each. All of them are checked against
+// MaxOrganizersPerEvent under one lock on the event, in the order given, so
+// emails past the limit are refused while the ones before it are added.
+func BulkAddOrganizersHandler(cfg *config.Config) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user := GetUserFromContext(r.Context())
+		if user == nil {
+			encodeAPIError(w, r, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		id, err := strconv.ParseUint(r.PathValue("id"), 10, 32)
+		if err != nil {
+			encodeAPIError(w, r, "Invalid event ID", http.StatusBadRequest)
+			return
+		}
+
+		event, err := getEventWithOrganizers(cfg, r, uint(id))
+		if err != nil {
+			encodeAPIError(w, r, "Event not found", http.StatusNotFound)
+			return
+		}
+
+		if !event.IsOrganizer(user.ID) {
+			encodeAPIError(w, r, "Forbidden", http.StatusForbidden)
+			return
+		}
+
+		var req struct {
+			Emails []string `json:"emails"`
+		}
+		if !decodeJSONBody(w, r, MaxBodySize, &req) {
+			return
+		}
+
+		var errs validationErrors
+		if len(req.Emails) == 0 {
+			errs.add("emails", "At least one email is required")
+		} else if len(req.Emails) > MaxBulkOrganizerEmails {
+			errs.add("emails", fmt.Sprintf("At most %d emails can be added at once", MaxBulkOrganizerEmails))
+		}
+		for i, email := range req.Emails {
+			if strings.TrimSpace(email) == "" {
+				errs.add(fmt.Sprintf("emails.%d", i), "Email is required")
+			}
+		}
+		if len(errs) > 0 {
+			encodeValidationErrors(w, r, errs)
+			return
+		}
+
+		// Lock the event row so concurrent additions can't exceed the limit
+		tx := cfg.DBCtx(r).Begin()
+		if tx.Error != nil {
+			cfg.Logger.Error("failed to begin transaction", "error", tx.Error)
+			encodeAPIError(w, r, "Failed to add organizers", http.StatusInternalServerError)
+			return
+		}
+		defer tx.Rollback()
+
+		var lockedEvent models.Event
+		if err := tx.Preload("Organizers").Clauses(clause.Locking{Strength: "UPDATE"}).First(&lockedEvent, event.ID).Error; err != nil {
+			cfg.Logger.Error("failed to lock event for organizer add", "error", err)
+			encodeAPIError(w, r, "Failed to add organizers", http.StatusInternalServerError)
+			return
+		}
+
+		total := organizerCount(&lockedEvent)
+		results := make([]organizerAddResult, 0, len(req.Emails))
+		var added []*models.User
+		addedIDs := make(map[uint]bool)
+		for _, email := range req.Emails {
+			result := organizerAddResult{Email: strings.TrimSpace(email)}
+			newOrganizer, err := models.GetUserByEmail(tx, email)
+			switch {
+			case err != nil:
+				result.Result = OrganizerNotFound
+			case lockedEvent.IsOrganizer(newOrganizer.ID) || addedIDs[newOrganizer.ID]:
+				result.Result = OrganizerAlreadyOrganizer
+				result.UserID = newOrganizer.ID
+			case total >= cfg.MaxOrganizersPerEvent:
+				result.Result = OrganizerLimitReached
+			default:
+				result.Result = OrganizerAdded
+				result.UserID = newOrganizer.ID
+				added = append(added, newOrganizer)
+				addedIDs[newOrganizer.ID] = true
+				total++
+			}
+			results = append(results, result)
+		}
+
+		if len(added) > 0 {
+			if err := tx.Model(&lockedEvent).Association("Organizers").Append(added); err != nil {
+				cfg.Logger.Error("failed to add organizers", "error", err, "event_id", event.ID)
+				encodeAPIError(w, r, "Failed to add organizers", http.StatusInternalServerError)
+				return
+			}
+		}
+
+		if err := tx.Commit().Error; err != nil {
+			cfg.Logger.Error("failed to commit organizer add", "error", err)
+			encodeAPIError(w, r, "Failed to add organizers", http.StatusInternalServerError)
+			return
+		}
+
+		for _, newOrganizer := range added {
+			cfg.Logger.Info("organizer added",
+				"event_id", event.ID,
+				"added_user_id", newOrganizer.ID,
+				"added_email", newOrganizer.Email,
+				"actor_id", user.ID,
+			)
+			recordActivity(cfg, r, models.EventActivity{
+				EventID: event.ID,
+				ActorID: &user.ID,
+				Action:  models.ActivityOrganizerAdded,
+				Subject: organizerName(newOrganizer),
+			})
+		}
+
+		encodeResponse(w, r, map[string]interface{}{"results": results})
+	}
+}
+
+// LeaveEventHandler lets a co-organizer remove themselves from an event. The
+// creator can't leave their own event. Nor can the last organizer of an event
+// whose creator's account was deleted, as nobody would be left to run it.
+func LeaveEventHandler(cfg *config.Config) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user := GetUserFromContext(r.Context())
+		if user == nil {
+			encodeAPIError(w, r, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		id, err := strconv.ParseUint(r.PathValue("id"), 10, 32)
+		if err != nil {
+			encodeAPIError(w, r, "Invalid event ID", http.StatusBadRequest)
+			return
+		}
+
+		event, err := getEventWithOrganizers(cfg, r, uint(id))
+		if err != nil {
+			encodeAPIError(w, r, "Event not found", http.StatusNotFound)
+			return
+		}
+
+		if !event.IsOrganizer(user.ID) {
+			encodeAPIError(w, r, "Forbidden", http.StatusForbidden)
+			return
+		}
+		if event.CreatedByID != nil && *event.CreatedByID == user.ID {
+			encodeAPIError(w, r, "The event creator can't leave their own event", http.StatusBadRequest)
+			return
+		}
+
+		var creator *models.User
+		if event.CreatedByID != nil {
+			var u models.User
+			if err := cfg.DBCtx(r).Where("id = ?", *event.CreatedByID).Limit(1).Find(&u).Error; err != nil {
+				cfg.Logger.Error("failed to load event creator", "error", err, "event_id", event.ID)
+				encodeAPIError(w, r, "Failed to leave event", http.StatusInternalServerError)
+				return
+			}
+			if u.ID != 0 {
+				creator = &u
+			}
+		}
+
+		// Lock the event row so two last organizers can't both leave
+		tx := cfg.DBCtx(r).Begin()
+		if tx.Error != nil {
+			cfg.Logger.Error("failed to begin transaction", "error", tx.Error)
+			encodeAPIError(w, r, "Failed to leave event", http.StatusInternalServerError)
+			return
+		}
+		defer tx.Rollback()
+
+		var lockedEvent models.Event
+		if err := tx.Preload("Organizers").Clauses(clause.Locking{Strength: "UPDATE"}).First(&lockedEvent, event.ID).Error; err != nil {
+			cfg.Logger.Error("failed to lock event for organizer leave", "error", err)
+			encodeAPIError(w, r, "Failed to leave event", http.StatusInternalServerError)
+			return
+		}
+		if creator == nil && len(lockedEvent.Organizers) <= 1 {
+			encodeAPIError(w, r, "You are the last organizer of this event and can't leave it", http.StatusBadRequest)
+			return
+		}
+
+		if err := tx.Model(&lockedEvent).Association("Organizers").Delete(user); err != nil {
+			cfg.Logger.Error("failed to remove organizer", "error", err, "event_id", event.ID)
+			encodeAPIError(w, r, "Failed to leave event", http.StatusInternalServerError)
+			return
+		}
+
+		if err := tx.Commit().Error; err != nil {
+			cfg.Logger.Error("failed to commit organizer leave", "error", err)
+			encodeAPIError(w, r, "Failed to leave event", http.StatusInternalServerError)
+			return
+		}
+
+		cfg.Logger.Info("organizer left",
+			"event_id", event.ID,
+			"actor_id", user.ID,
+		)
+		recordActivity(cfg, r, models.EventActivity{
+			EventID: event.ID,
+			ActorID: &user.ID,
+			Action:  models.ActivityOrganizerLeft,
+			Subject: organizerName(user),
+		})
+
+		// Tell the creator (fire-and-forget)
+		if cfg.EmailSender != nil && creator != nil {
+			e, leaver := *event, *user // copy for goroutine
+			SafeGo(cfg, func() {
+				ncfg := &email.NotifyConfig{
+					Sender:  cfg.EmailSender,
+					From:    cfg.EmailFrom,
+					BaseURL: cfg.BaseURL,
+					Logger:  cfg.Logger,
+				}
+				email.SendOrganizerLeftNotification(ncfg, &e, creator, &leaver)
+			})
+		}
 
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusCreated)
-		encodeResponse(w, r, map[string]string{"message": "Organizer added"})
+		encodeResponse(w, r, map[string]string{"message": "You left the event"})
 	}
 }
 
@@ -1187,49 +2585,49 @@ func RemoveOrganizerHandler(cfg *config.Config) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		user := GetUserFromContext(r.Context())
 		if user == nil {
-			encodeError(w, "Unauthorized", http.StatusUnauthorized)
+			encodeAPIError(w, r, "Unauthorized", http.StatusUnauthorized)
 			return
 		}
 
 		eventID, err := strconv.ParseUint(r.PathValue("id"), 10, 32)
 		if err != nil {
-			encodeError(w, "Invalid event ID", http.StatusBadRequest)
+			encodeAPIError(w, r, "Invalid event ID", http.StatusBadRequest)
 			return
 		}
 
 		userIDToRemove, err := strconv.ParseUint(r.PathValue("userId"), 10, 32)
 		if err != nil {
-			encodeError(w, "Invalid user ID", http.StatusBadRequest)
+			encodeAPIError(w, r, "Invalid user ID", http.StatusBadRequest)
 			return
 		}
 
-		var event models.Event
-		if err := cfg.DB.Preload("Organizers").First(&event, eventID).Error; err != nil {
-			encodeError(w, "Event not found", http.StatusNotFound)
+		event, err := getEventWithOrganizers(cfg, r, uint(eventID))
+		if err != nil {
+			encodeAPIError(w, r, "Event not found", http.StatusNotFound)
 			return
 		}
 
-		// Only creator can remove organizers
-		if event.CreatedByID == nil || *event.CreatedByID != user.ID {
-			encodeError(w, "Only the event creator can remove organizers", http.StatusForbidden)
+		// Only creator can remove organizers, or an admin once the event is orphaned
+		if !actsAsCreator(cfg, event, user) {
+			encodeAPIError(w, r, "Only the event creator can remove organizers", http.StatusForbidden)
 			return
 		}
 
 		// Can't remove the creator
 		if event.CreatedByID != nil && uint(userIDToRemove) == *event.CreatedByID {
-			encodeError(w, "Cannot remove the event creator", http.StatusBadRequest)
+			encodeAPIError(w, r, "Cannot remove the event creator", http.StatusBadRequest)
 			return
 		}
 
 		var organizerToRemove models.User
-		if err := cfg.DB.First(&organizerToRemove, userIDToRemove).Error; err != nil {
-			encodeError(w, "User not found", http.StatusNotFound)
+		if err := cfg.DBCtx(r).First(&organizerToRemove, userIDToRemove).Error; err != nil {
+			encodeAPIError(w, r, "User not found", http.StatusNotFound)
 			return
 		}
 
-		if err := cfg.DB.Model(&event).Association("Organizers").Delete(&organizerToRemove); err != nil {
+		if err := cfg.DBCtx(r).Model(event).Association("Organizers").Delete(&organizerToRemove); err != nil {
 			cfg.Logger.Error("failed to remove organizer", "error", err, "event_id", event.ID)
-			encodeError(w, "Failed to remove organizer", http.StatusInternalServerError)
+			encodeAPIError(w, r, "Failed to remove organizer", http.StatusInternalServerError)
 			return
 		}
 
@@ -1238,6 +2636,12 @@ func RemoveOrganizerHandler(cfg *config.Config) http.HandlerFunc {
 			"removed_user_id", organizerToRemove.ID,
 			"actor_id", user.ID,
 		)
+		recordActivity(cfg, r, models.EventActivity{
+			EventID: event.ID,
+			ActorID: &user.ID,
+			Action:  models.ActivityOrganizerRemoved,
+			Subject: organizerName(&organizerToRemove),
+		})
 
 		encodeResponse(w, r, map[string]string{"message": "Organizer removed"})
 	}
@@ -1,6 +1,11 @@
 package api
 
-import "testing"
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
 
 func TestEscapeLikePattern(t *testing.T) {
 	tests := []struct {
@@ -26,3 +31,150 @@ func TestEscapeLikePattern(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateEventSections(t *testing.T) {
+	var errs validationErrors
+	got := validateEventSections([]byte(`[{"title":" Travel ","body":"Fly into LHR."}]`), &errs)
+	if len(errs) != 0 || string(got) != `[{"title":"Travel","body":"Fly into LHR."}]` {
+		t.Fatalf("expected trimmed sections, got %s %+v", got, errs)
+	}
+
+	errs = nil
+	if got := validateEventSections([]byte(`null`), &errs); got != nil || len(errs) != 0 {
+		t.Errorf("expected null to clear sections, got %s %+v", got, errs)
+	}
+
+	errs = nil
+	validateEventSections([]byte(`[{"title":"","body":"x"},{"title":"CoC","body":"`+strings.Repeat("x", MaxEventSectionBodyLen+1)+`"}]`), &errs)
+	if len(errs) != 2 || errs[0].Field != "sections[0].title" || errs[1].Field != "sections[1].body" {
+		t.Errorf("expected title and body errors, got %+v", errs)
+	}
+
+	errs = nil
+	validateEventSections([]byte(`[`+strings.Repeat(`{"title":"t","body":"b"},`, MaxEventSections)+`{"title":"t","body":"b"}]`), &errs)
+	if len(errs) != 1 || errs[0].Field != "sections" {
+		t.Errorf("expected a section count error, got %+v", errs)
+	}
+
+	errs = nil
+	validateEventSections([]byte(`"travel info"`), &errs)
+	if len(errs) != 1 {
+		t.Errorf("expected a type error, got %+v", errs)
+	}
+}
+
+func TestValidateStatusEmails(t *testing.T) {
+	var errs validationErrors
+	got := validateStatusEmails([]byte(`{"accepted":{"subject":" {{talk_title}} is in ","body":"See you at {{event_name}}."}}`), &errs)
+	if len(errs) != 0 || string(got) != `{"accepted":{"subject":"{{talk_title}} is in","body":"See you at {{event_name}}."}}` {
+		t.Fatalf("expected trimmed copy, got %s %+v", got, errs)
+	}
+
+	errs = nil
+	if got := validateStatusEmails([]byte(`null`), &errs); got != nil || len(errs) != 0 {
+		t.Errorf("expected null to clear the copy, got %s %+v", got, errs)
+	}
+
+	errs = nil
+	validateStatusEmails([]byte(`{"rejected":{"subject":"Sorry\nBcc: x@example.com","body":"Room {{room}}"}}`), &errs)
+	if len(errs) != 2 || errs[0].Field != "status_emails.rejected.subject" || errs[1].Field != "status_emails.rejected.body" {
+		t.Errorf("expected subject and body errors, got %+v", errs)
+	}
+
+	errs = nil
+	validateStatusEmails([]byte(`{"tentative":{"subject":"","body":"`+strings.Repeat("x", MaxStatusEmailBodyLen+1)+`"}}`), &errs)
+	if len(errs) != 2 {
+		t.Errorf("expected required and length errors, got %+v", errs)
+	}
+
+	errs = nil
+	validateStatusEmails([]byte(`{"submitted":{"subject":"Thanks","body":"Got it"}}`), &errs)
+	if len(errs) != 1 || errs[0].Field != "status_emails" {
+		t.Errorf("expected an unknown status error, got %+v", errs)
+	}
+
+	errs = nil
+	validateStatusEmails([]byte(`"accepted"`), &errs)
+	if len(errs) != 1 {
+		t.Errorf("expected a type error, got %+v", errs)
+	}
+}
+
+func TestValidateAbstractWordLimits(t *testing.T) {
+	intPtr := func(n int) *int { return &n }
+
+	var errs validationErrors
+	validateAbstractWordLimits(intPtr(100), intPtr(300), &errs)
+	if len(errs) != 0 {
+		t.Errorf("expected valid limits, got %+v", errs)
+	}
+
+	errs = nil
+	validateAbstractWordLimits(nil, nil, &errs)
+	if len(errs) != 0 {
+		t.Errorf("expected no limits to be valid, got %+v", errs)
+	}
+
+	errs = nil
+	validateAbstractWordLimits(intPtr(0), intPtr(MaxAbstractWordLimit+1), &errs)
+	if len(errs) != 2 || errs[0].Field != "abstract_min_words" || errs[1].Field != "abstract_max_words" {
+		t.Errorf("expected range errors for both limits, got %+v", errs)
+	}
+
+	errs = nil
+	validateAbstractWordLimits(intPtr(300), intPtr(100), &errs)
+	if len(errs) != 1 || errs[0].Field != "abstract_max_words" {
+		t.Errorf("expected a max below min error, got %+v", errs)
+	}
+}
+
+func TestWordLimitUpdate(t *testing.T) {
+	current := 50
+
+	var errs validationErrors
+	updates := map[string]interface{}{"abstract_min_words": float64(100)}
+	if got := wordLimitUpdate(updates, "abstract_min_words", &current, &errs); got == nil || *got != 100 || updates["abstract_min_words"] != 100 {
+		t.Errorf("expected 100, got %v %+v", got, updates)
+	}
+	if got := wordLimitUpdate(updates, "abstract_max_words", &current, &errs); got != &current {
+		t.Errorf("expected the current limit when not updated, got %v", got)
+	}
+	if got := wordLimitUpdate(map[string]interface{}{"abstract_min_words": nil}, "abstract_min_words", &current, &errs); got != nil {
+		t.Errorf("expected null to clear the limit, got %v", *got)
+	}
+	if len(errs) != 0 {
+		t.Errorf("expected no errors, got %+v", errs)
+	}
+
+	for _, v := range []interface{}{10.5, "100", true} {
+		errs = nil
+		wordLimitUpdate(map[string]interface{}{"abstract_min_words": v}, "abstract_min_words", &current, &errs)
+		if len(errs) != 1 {
+			t.Errorf("%v: expected a type error, got %+v", v, errs)
+		}
+	}
+}
+
+func TestParseTimeQuery(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Time
+		ok    bool
+		err   bool
+	}{
+		{"", time.Time{}, false, false},
+		{"2026-05-01", time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC), true, false},
+		{"2026-05-01T12:30:00+02:00", time.Date(2026, 5, 1, 10, 30, 0, 0, time.UTC), true, false},
+		{"May 1st", time.Time{}, false, true},
+	}
+	for _, tt := range tests {
+		var errs validationErrors
+		got, ok := parseTimeQuery(url.Values{"confirmed_after": {tt.value}}, "confirmed_after", &errs)
+		if ok != tt.ok || !got.Equal(tt.want) {
+			t.Errorf("parseTimeQuery(%q) = %v, %v; want %v, %v", tt.value, got, ok, tt.want, tt.ok)
+		}
+		if (len(errs) > 0) != tt.err {
+			t.Errorf("parseTimeQuery(%q) errors = %v, want error %v", tt.value, errs, tt.err)
+		}
+	}
+}
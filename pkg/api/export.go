@@ -7,15 +7,27 @@ import (
 	"fmt"
 	"log/slog"
 	"net/http"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/sreday/cfp.ninja/pkg/config"
+	"github.com/sreday/cfp.ninja/pkg/logging"
 	"github.com/sreday/cfp.ninja/pkg/models"
 )
 
-// ExportProposalsHandler exports proposals for an event as CSV
+// Values of the status filter of the speakers export
+const (
+	speakerExportConfirmed   = "confirmed"   // accepted and attendance confirmed (the default)
+	speakerExportUnconfirmed = "unconfirmed" // accepted but not yet confirmed
+	speakerExportAccepted    = "accepted"    // accepted, confirmed or not
+)
+
+// ExportProposalsHandler exports proposals for an event as CSV. The in-person
+// and online formats have a row per proposal; the speakers format has a row
+// per accepted speaker, for badge printing, filtered by ?status=. The pretalx
+// format is JSON for importing into pretalx instead; see buildPretalxExport.
 func ExportProposalsHandler(cfg *config.Config) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		user := GetUserFromContext(r.Context())
@@ -31,7 +43,7 @@ func ExportProposalsHandler(cfg *config.Config) http.HandlerFunc {
 		}
 
 		var event models.Event
-		if err := cfg.DB.Preload("Organizers").First(&event, eventID).Error; err != nil {
+		if err := cfg.DBCtx(r).Preload("Organizers").First(&event, eventID).Error; err != nil {
 			encodeError(w, "Event not found", http.StatusNotFound)
 			return
 		}
@@ -42,8 +54,16 @@ func ExportProposalsHandler(cfg *config.Config) http.HandlerFunc {
 		}
 
 		format := r.URL.Query().Get("format")
-		if format != "in-person" && format != "online" {
-			encodeError(w, "format must be 'in-person' or 'online'", http.StatusBadRequest)
+		if format != "in-person" && format != "online" && format != "speakers" && format != "pretalx" {
+			encodeError(w, "format must be 'in-person', 'online', 'speakers' or 'pretalx'", http.StatusBadRequest)
+			return
+		}
+
+		// The speakers and pretalx exports identify speakers by email, so
+		// they can't be masked
+		view := newProposalView(cfg, &event, user)
+		if view.hideEmails && (format == "speakers" || format == "pretalx") {
+			encodeError(w, errSpeakerEmailsHidden, http.StatusForbidden)
 			return
 		}
 
@@ -51,12 +71,54 @@ func ExportProposalsHandler(cfg *config.Config) http.HandlerFunc {
 		ctx, cancel := context.WithTimeout(r.Context(), 2*time.Minute)
 		defer cancel()
 
+		query := cfg.DBCtx(r).WithContext(ctx).Where("event_id = ?", eventID)
+		if format == "speakers" {
+			// Badge printing only needs speakers who are coming, unless asked
+			// for those yet to confirm
+			status := r.URL.Query().Get("status")
+			switch status {
+			case "", speakerExportConfirmed:
+				query = query.Where("status = ? AND attendance_confirmed = ?", models.ProposalStatusAccepted, true)
+			case speakerExportUnconfirmed:
+				query = query.Where("status = ? AND attendance_confirmed = ?", models.ProposalStatusAccepted, false)
+			case speakerExportAccepted:
+				query = query.Where("status = ?", models.ProposalStatusAccepted)
+			default:
+				encodeError(w, "status must be 'confirmed', 'unconfirmed' or 'accepted'", http.StatusBadRequest)
+				return
+			}
+		}
+
 		var proposals []models.Proposal
-		if err := cfg.DB.WithContext(ctx).Where("event_id = ?", eventID).Limit(MaxExportRows).Find(&proposals).Error; err != nil {
+		if err := query.Order("id").Limit(MaxExportRows).Find(&proposals).Error; err != nil {
 			cfg.Logger.Error("failed to query proposals for export", "error", err, "event_id", eventID)
 			encodeError(w, "Failed to export proposals", http.StatusInternalServerError)
 			return
 		}
+		view.shapeAll(proposals)
+
+		if format == "pretalx" {
+			data, err := json.MarshalIndent(buildPretalxExport(&event, proposals), "", "  ")
+			if err != nil {
+				cfg.Logger.Error("failed to encode pretalx export", "error", err, "event_id", eventID)
+				encodeError(w, "Failed to export proposals", http.StatusInternalServerError)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fmt.Sprintf("proposals-%s-pretalx.json", event.Slug)))
+			w.Write(data)
+			return
+		}
+
+		var pictures map[string]string
+		if format == "speakers" {
+			pictures, err = speakerPictures(ctx, cfg, proposals)
+			if err != nil {
+				cfg.Logger.Error("failed to load speaker photos for export", "error", err, "event_id", eventID)
+				encodeError(w, "Failed to export proposals", http.StatusInternalServerError)
+				return
+			}
+		}
 
 		filename := fmt.Sprintf("proposals-%s-%s.csv", event.Slug, format)
 		w.Header().Set("Content-Type", "text/csv")
@@ -64,10 +126,13 @@ func ExportProposalsHandler(cfg *config.Config) http.HandlerFunc {
 
 		writer := csv.NewWriter(w)
 
-		if format == "in-person" {
+		switch format {
+		case "in-person":
 			writeInPersonCSV(writer, proposals)
-		} else {
+		case "online":
 			writeOnlineCSV(writer, proposals)
+		default:
+			writeSpeakersCSV(writer, proposals, pictures)
 		}
 		writer.Flush()
 		if err := writer.Error(); err != nil {
@@ -78,7 +143,7 @@ func ExportProposalsHandler(cfg *config.Config) http.HandlerFunc {
 
 func writeInPersonCSV(w *csv.Writer, proposals []models.Proposal) {
 	// SREday format
-	header := []string{"status", "confirmed", "name", "track", "email", "day", "organization", "photo", "linkedin", "linkedin2", "twitter", "twitter2", "title", "abstract", "description", "bio"}
+	header := []string{"status", "confirmed", "name", "track", "email", "day", "organization", "photo", "linkedin", "linkedin2", "twitter", "twitter2", "title", "abstract", "description", "bio", "coc_accepted_at", "confirmed_at"}
 	w.Write(header)
 
 	for _, p := range proposals {
@@ -121,6 +186,8 @@ func writeInPersonCSV(w *csv.Writer, proposals []models.Proposal) {
 			sanitizeCSVCell(p.Abstract),
 			sanitizeCSVCell(p.Abstract),     // description (same as abstract)
 			sanitizeCSVCell(bio),
+			formatCSVTimePtr(p.CoCAcceptedAt),
+			formatCSVTimePtr(p.AttendanceConfirmedAt),
 		}
 		w.Write(row)
 	}
@@ -128,7 +195,7 @@ func writeInPersonCSV(w *csv.Writer, proposals []models.Proposal) {
 
 func writeOnlineCSV(w *csv.Writer, proposals []models.Proposal) {
 	// Conf42 format
-	header := []string{"Featured", "Track", "Name1", "Email1", "JobTitle1", "Company1", "Name2", "Email2", "JobTitle2", "Company2", "Title", "Abstract", "LinkedIn1", "Twitter1", "LinkedIn2", "Twitter2", "Slides", "Picture", "YouTube", "Keywords", "Duration", "Status", "Confirmed"}
+	header := []string{"Featured", "Track", "Name1", "Email1", "JobTitle1", "Company1", "Name2", "Email2", "JobTitle2", "Company2", "Title", "Abstract", "LinkedIn1", "Twitter1", "LinkedIn2", "Twitter2", "Slides", "Picture", "YouTube", "Keywords", "Duration", "Status", "Confirmed", "CoCAcceptedAt", "ConfirmedAt"}
 	w.Write(header)
 
 	for _, p := range proposals {
@@ -176,11 +243,167 @@ func writeOnlineCSV(w *csv.Writer, proposals []models.Proposal) {
 			strconv.Itoa(p.Duration),
 			string(p.Status),
 			boolToYesNo(p.AttendanceConfirmed),
+			formatCSVTimePtr(p.CoCAcceptedAt),
+			formatCSVTimePtr(p.AttendanceConfirmedAt),
 		}
 		w.Write(row)
 	}
 }
 
+// speakerPictures returns the profile photos of the speakers on proposals who
+// have an account, keyed by lowercased email
+func speakerPictures(ctx context.Context, cfg *config.Config, proposals []models.Proposal) (map[string]string, error) {
+	var emails []string
+	for _, p := range proposals {
+		for _, s := range parseSpeakers(p.Speakers) {
+			if s.Email != "" {
+				emails = append(emails, strings.ToLower(s.Email))
+			}
+		}
+	}
+	pictures := make(map[string]string)
+	if len(emails) == 0 {
+		return pictures, nil
+	}
+	var users []models.User
+	if err := cfg.DB.WithContext(ctx).Select("email", "picture_url").
+		Where("LOWER(email) IN ? AND picture_url <> ''", emails).Find(&users).Error; err != nil {
+		return nil, err
+	}
+	for _, u := range users {
+		pictures[strings.ToLower(u.Email)] = u.PictureURL
+	}
+	return pictures, nil
+}
+
+// writeSpeakersCSV writes one row per speaker across proposals, matching
+// speakers by case-insensitive email. Details come from the speaker's first
+// proposal; the titles of all of them are joined.
+func writeSpeakersCSV(w *csv.Writer, proposals []models.Proposal, pictures map[string]string) {
+	w.Write([]string{"name", "email", "company", "job_title", "photo_url", "talks"})
+
+	type speakerRow struct {
+		speaker models.Speaker
+		titles  []string
+	}
+	var rows []*speakerRow
+	byEmail := make(map[string]*speakerRow)
+	for _, p := range proposals {
+		for _, s := range parseSpeakers(p.Speakers) {
+			key := strings.ToLower(strings.TrimSpace(s.Email))
+			if key == "" {
+				continue
+			}
+			if row, ok := byEmail[key]; ok {
+				if !slices.Contains(row.titles, p.Title) {
+					row.titles = append(row.titles, p.Title)
+				}
+				continue
+			}
+			byEmail[key] = &speakerRow{speaker: s, titles: []string{p.Title}}
+			rows = append(rows, byEmail[key])
+		}
+	}
+
+	for _, row := range rows {
+		s := row.speaker
+		// The speaker's own photo, or else their account picture
+		photo := s.PhotoURL
+		if photo == "" {
+			photo = pictures[strings.ToLower(strings.TrimSpace(s.Email))]
+		}
+		w.Write([]string{
+			sanitizeCSVCell(s.Name),
+			sanitizeCSVCell(s.Email),
+			sanitizeCSVCell(s.Company),
+			sanitizeCSVCell(s.JobTitle),
+			sanitizeCSVCell(photo),
+			sanitizeCSVCell(strings.Join(row.titles, "; ")),
+		})
+	}
+}
+
+// writeEventsCSV renders an event listing as CSV (GET /api/v0/events with Accept: text/csv)
+func writeEventsCSV(w *csv.Writer, events []models.Event) {
+	w.Write([]string{"id", "name", "slug", "location", "country", "start_date", "end_date", "is_online", "attendance_mode", "cfp_status", "cfp_open_at", "cfp_close_at", "website", "tags"})
+
+	for _, e := range events {
+		w.Write([]string{
+			strconv.FormatUint(uint64(e.ID), 10),
+			sanitizeCSVCell(e.Name),
+			e.Slug,
+			sanitizeCSVCell(e.Location),
+			e.Country,
+			formatCSVTime(e.StartDate),
+			formatCSVTime(e.EndDate),
+			boolToYesNo(e.IsOnline),
+			string(e.AttendanceMode),
+			string(e.CFPStatus),
+			formatCSVTime(e.CFPOpenAt),
+			formatCSVTime(e.CFPCloseAt),
+			sanitizeCSVCell(e.Website),
+			sanitizeCSVCell(e.Tags),
+		})
+	}
+}
+
+// writeProposalsCSV renders a proposal listing as CSV (GET /api/v0/events/{id}/proposals
+// with Accept: text/csv). Unlike the export formats, it mirrors the JSON fields,
+// so the notes columns are only filled in for organizers.
+func writeProposalsCSV(w *csv.Writer, proposals []models.Proposal) {
+	w.Write([]string{"id", "title", "format", "duration", "level", "tags", "status", "rating", "attendance_confirmed", "attendance_confirmed_at", "speakers", "emails", "organizer_notes", "reviewer_notes", "coc_accepted_at", "created_at"})
+
+	for _, p := range proposals {
+		speakers := parseSpeakers(p.Speakers)
+		names := make([]string, len(speakers))
+		emails := make([]string, len(speakers))
+		for i, s := range speakers {
+			names[i] = s.Name
+			emails[i] = s.Email
+		}
+
+		rating := ""
+		if p.Rating != nil {
+			rating = strconv.Itoa(*p.Rating)
+		}
+
+		w.Write([]string{
+			strconv.FormatUint(uint64(p.ID), 10),
+			sanitizeCSVCell(p.Title),
+			string(p.Format),
+			strconv.Itoa(p.Duration),
+			sanitizeCSVCell(p.Level),
+			sanitizeCSVCell(p.Tags),
+			string(p.Status),
+			rating,
+			boolToYesNo(p.AttendanceConfirmed),
+			formatCSVTimePtr(p.AttendanceConfirmedAt),
+			sanitizeCSVCell(strings.Join(names, ", ")),
+			sanitizeCSVCell(strings.Join(emails, ", ")),
+			sanitizeCSVCell(p.OrganizerNotes),
+			sanitizeCSVCell(p.ReviewerNotes),
+			formatCSVTimePtr(p.CoCAcceptedAt),
+			formatCSVTime(p.CreatedAt),
+		})
+	}
+}
+
+// formatCSVTime formats a timestamp as RFC 3339, leaving unset times empty
+func formatCSVTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// formatCSVTimePtr formats an optional timestamp like formatCSVTime
+func formatCSVTimePtr(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return formatCSVTime(*t)
+}
+
 func boolToYesNo(b bool) string {
 	if b {
 		return "yes"
@@ -208,7 +431,7 @@ func parseSpeakers(data []byte) []models.Speaker {
 	var speakers []models.Speaker
 	if data != nil {
 		if err := json.Unmarshal(data, &speakers); err != nil {
-			slog.Warn("failed to parse speaker JSON in CSV export", "error", err)
+			logging.Module(slog.Default(), logging.API).Warn("failed to parse speaker JSON in CSV export", "error", err)
 		}
 	}
 	return speakers
@@ -0,0 +1,228 @@
+package api
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/sreday/cfp.ninja/pkg/config"
+	"github.com/sreday/cfp.ninja/pkg/models"
+	"gorm.io/gorm"
+)
+
+// fullExportBatchSize is how many proposals or activity entries the full
+// export loads at a time, so large events are streamed rather than buffered
+const fullExportBatchSize = 200
+
+// fullExportOrganizer is an entry of organizers.json in the full export,
+// with the timestamps of the organizer's account
+type fullExportOrganizer struct {
+	ID        uint      `json:"id"`
+	Name      string    `json:"name"`
+	Email     string    `json:"email"`
+	Role      string    `json:"role"` // "creator" or "organizer"
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func newFullExportOrganizer(u models.User, role string) fullExportOrganizer {
+	return fullExportOrganizer{ID: u.ID, Name: u.Name, Email: u.Email, Role: role, CreatedAt: u.CreatedAt, UpdatedAt: u.UpdatedAt}
+}
+
+// ExportFullEventHandler streams everything about an event as a zip, for
+// organizers moving to another platform: event.json, proposals.json with
+// speakers, custom answers and the creator's own review notes,
+// organizers.json and activity.json, the event's audit log. Only the creator
+// may export, so the event's and proposals' Stripe payment IDs are kept in.
+// GET /api/v0/events/{id}/export/full
+func ExportFullEventHandler(cfg *config.Config) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		event, ok := loadEventForCreator(cfg, w, r, "export the full event")
+		if !ok {
+			return
+		}
+		user := GetUserFromContext(r.Context())
+
+		organizers, err := fullExportOrganizers(cfg.DBCtx(r), event)
+		if err != nil {
+			cfg.Logger.Error("failed to load organizers for full export", "error", err, "event_id", event.ID)
+			encodeAPIError(w, r, "Failed to export event", http.StatusInternalServerError)
+			return
+		}
+
+		// Add a timeout to prevent indefinite blocking on large exports
+		ctx, cancel := context.WithTimeout(r.Context(), 5*time.Minute)
+		defer cancel()
+
+		filename := fmt.Sprintf("%s-export-%s.zip", event.Slug, cfg.Now().UTC().Format("2006-01-02"))
+		w.Header().Set("Content-Type", "application/zip")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+
+		// Headers are sent with the first write, so failures from here on can
+		// only be logged, leaving a truncated zip the client can't open
+		zw := zip.NewWriter(w)
+		if err := writeFullExport(ctx, cfg, zw, event, user, organizers); err != nil {
+			cfg.Logger.Error("full export failed", "error", err, "event_id", event.ID)
+			return
+		}
+		if err := zw.Close(); err != nil {
+			cfg.Logger.Error("full export failed", "error", err, "event_id", event.ID)
+			return
+		}
+		cfg.Logger.Info("event exported", "event_id", event.ID, "user_id", user.ID)
+	}
+}
+
+// fullExportOrganizers lists the event's creator and co-organizers
+func fullExportOrganizers(db *gorm.DB, event *models.Event) ([]fullExportOrganizer, error) {
+	var users []models.User
+	if err := db.Model(event).Association("Organizers").Find(&users); err != nil {
+		return nil, err
+	}
+	organizers := make([]fullExportOrganizer, 0, len(users)+1)
+	hasCreator := false
+	for _, u := range users {
+		role := "organizer"
+		if event.CreatedByID != nil && *event.CreatedByID == u.ID {
+			role = "creator"
+			hasCreator = true
+		}
+		organizers = append(organizers, newFullExportOrganizer(u, role))
+	}
+	if !hasCreator && event.CreatedByID != nil {
+		var creator models.User
+		if err := db.First(&creator, *event.CreatedByID).Error; err != nil {
+			return nil, err
+		}
+		organizers = append([]fullExportOrganizer{newFullExportOrganizer(creator, "creator")}, organizers...)
+	}
+	return organizers, nil
+}
+
+// writeFullExport writes the files of the full export to zw
+func writeFullExport(ctx context.Context, cfg *config.Config, zw *zip.Writer, event *models.Event, user *models.User, organizers []fullExportOrganizer) error {
+	modified := cfg.Now()
+	db := cfg.DB.WithContext(ctx)
+
+	if err := writeZipJSON(zw, "event.json", modified, event); err != nil {
+		return err
+	}
+
+	f, err := createZipFile(zw, "proposals.json", modified)
+	if err != nil {
+		return err
+	}
+	proposals := newJSONArrayWriter(f)
+	view := newProposalView(cfg, event, user)
+	var batch []models.Proposal
+	err = db.Where("event_id = ?", event.ID).FindInBatches(&batch, fullExportBatchSize, func(tx *gorm.DB, _ int) error {
+		if err := models.LoadReviewerNotes(tx, user.ID, batch); err != nil {
+			return err
+		}
+		view.shapeAll(batch)
+		for i := range batch {
+			if err := proposals.add(&batch[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}).Error
+	if err != nil {
+		return err
+	}
+	if err := proposals.close(); err != nil {
+		return err
+	}
+
+	if err := writeZipJSON(zw, "organizers.json", modified, organizers); err != nil {
+		return err
+	}
+
+	f, err = createZipFile(zw, "activity.json", modified)
+	if err != nil {
+		return err
+	}
+	activity := newJSONArrayWriter(f)
+	var entries []models.EventActivity
+	err = db.Preload("Actor").Where("event_id = ?", event.ID).FindInBatches(&entries, fullExportBatchSize, func(_ *gorm.DB, _ int) error {
+		for _, a := range entries {
+			item := activityItem{
+				ID:         a.ID,
+				Action:     a.Action,
+				Summary:    a.Summary(),
+				ProposalID: a.ProposalID,
+				CreatedAt:  a.CreatedAt,
+			}
+			if a.Actor != nil {
+				item.Actor = &activityActor{ID: a.Actor.ID, Name: a.ActorName()}
+			}
+			if err := activity.add(item); err != nil {
+				return err
+			}
+		}
+		return nil
+	}).Error
+	if err != nil {
+		return err
+	}
+	return activity.close()
+}
+
+// createZipFile adds a compressed file to zw and returns its writer
+func createZipFile(zw *zip.Writer, name string, modified time.Time) (io.Writer, error) {
+	return zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: modified})
+}
+
+// writeZipJSON adds a file holding v as indented JSON to zw
+func writeZipJSON(zw *zip.Writer, name string, modified time.Time, v interface{}) error {
+	f, err := createZipFile(zw, name, modified)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(f)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// jsonArrayWriter writes a JSON array one element at a time, so it never
+// holds more than one element in memory
+type jsonArrayWriter struct {
+	w io.Writer
+	n int
+}
+
+func newJSONArrayWriter(w io.Writer) *jsonArrayWriter {
+	return &jsonArrayWriter{w: w}
+}
+
+// add appends v to the array
+func (a *jsonArrayWriter) add(v interface{}) error {
+	data, err := json.MarshalIndent(v, "  ", "  ")
+	if err != nil {
+		return err
+	}
+	sep := ",\n  "
+	if a.n == 0 {
+		sep = "[\n  "
+	}
+	a.n++
+	if _, err := io.WriteString(a.w, sep); err != nil {
+		return err
+	}
+	_, err = a.w.Write(data)
+	return err
+}
+
+// close ends the array
+func (a *jsonArrayWriter) close() error {
+	end := "\n]\n"
+	if a.n == 0 {
+		end = "[]\n"
+	}
+	_, err := io.WriteString(a.w, end)
+	return err
+}
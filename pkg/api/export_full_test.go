@@ -0,0 +1,40 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestJSONArrayWriter(t *testing.T) {
+	tests := []struct {
+		name  string
+		items []interface{}
+	}{
+		{"empty", nil},
+		{"one", []interface{}{map[string]int{"id": 1}}},
+		{"several", []interface{}{map[string]int{"id": 1}, "two", []int{3}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			a := newJSONArrayWriter(&buf)
+			for _, item := range tt.items {
+				if err := a.add(item); err != nil {
+					t.Fatalf("add: %v", err)
+				}
+			}
+			if err := a.close(); err != nil {
+				t.Fatalf("close: %v", err)
+			}
+
+			var got []interface{}
+			if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+				t.Fatalf("invalid JSON %q: %v", buf.String(), err)
+			}
+			if len(got) != len(tt.items) {
+				t.Errorf("expected %d items, got %d in %q", len(tt.items), len(got), buf.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,304 @@
+package api
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sreday/cfp.ninja/pkg/config"
+	"github.com/sreday/cfp.ninja/pkg/models"
+	"gorm.io/gorm"
+)
+
+// eventListFields maps the fields selectable with ?fields= on the event listing
+// to their JSON keys. Payment and ownership fields are deliberately absent.
+var eventListFields = map[string]string{
+	"id":                     "ID",
+	"created_at":             "CreatedAt",
+	"updated_at":             "UpdatedAt",
+	"name":                   "name",
+	"slug":                   "slug",
+	"description":            "description",
+	"summary":                "summary",
+	"location":               "location",
+	"country":                "country",
+	"country_code":           "country_code",
+	"latitude":               "latitude",
+	"longitude":              "longitude",
+	"start_date":             "start_date",
+	"end_date":               "end_date",
+	"website":                "website",
+	"logo_url":               "logo_url",
+	"terms_url":              "terms_url",
+	"coc_url":                "coc_url",
+	"require_coc_acceptance": "require_coc_acceptance",
+	"tags":                   "tags",
+	"is_online":              "is_online",
+	"attendance_mode":        "attendance_mode",
+	"travel_covered":         "travel_covered",
+	"hotel_covered":          "hotel_covered",
+	"honorarium_provided":    "honorarium_provided",
+	"cfp_description":        "cfp_description",
+	"cfp_open_at":            "cfp_open_at",
+	"cfp_close_at":           "cfp_close_at",
+	"cfp_status":             "cfp_status",
+	"max_accepted":           "max_accepted",
+	"cfp_questions":          "cfp_questions",
+	"abstract_min_words":     "abstract_min_words",
+	"abstract_max_words":     "abstract_max_words",
+	"show_submission_count":  "show_submission_count",
+	"sections":               "sections",
+	"cfp_requires_payment":   "cfp_requires_payment",
+}
+
+// parseEventFields parses a comma-separated ?fields= value. It returns nil when
+// no selection was requested. Field names are also the database column names.
+func parseEventFields(raw string) ([]string, error) {
+	if strings.TrimSpace(raw) == "" {
+		return nil, nil
+	}
+
+	var fields []string
+	seen := make(map[string]bool)
+	for _, f := range strings.Split(raw, ",") {
+		f = strings.ToLower(strings.TrimSpace(f))
+		if f == "" || seen[f] {
+			continue
+		}
+		if _, ok := eventListFields[f]; !ok {
+			allowed := make([]string, 0, len(eventListFields))
+			for name := range eventListFields {
+				allowed = append(allowed, name)
+			}
+			sort.Strings(allowed)
+			return nil, fmt.Errorf("unknown field %q (allowed: %s)", f, strings.Join(allowed, ", "))
+		}
+		seen[f] = true
+		fields = append(fields, f)
+	}
+	return fields, nil
+}
+
+// selectEventFields reduces events to the requested fields, keyed as in the full
+// JSON representation
+func selectEventFields(events []models.Event, fields []string) ([]map[string]interface{}, error) {
+	rows := make([]map[string]interface{}, len(events))
+	for i := range events {
+		b, err := json.Marshal(&events[i])
+		if err != nil {
+			return nil, err
+		}
+		var full map[string]interface{}
+		if err := json.Unmarshal(b, &full); err != nil {
+			return nil, err
+		}
+		row := make(map[string]interface{}, len(fields))
+		for _, f := range fields {
+			key := eventListFields[f]
+			row[key] = full[key]
+		}
+		rows[i] = row
+	}
+	return rows, nil
+}
+
+// eventIncludes lists the values accepted by ?include= on the event listing
+var eventIncludes = map[string]bool{
+	"stats": true,
+}
+
+// eventStatsColumns are the columns loadEventStats reads, selected along with
+// any ?fields= selection
+var eventStatsColumns = []string{"id", "cfp_status", "cfp_open_at", "cfp_close_at", "show_submission_count"}
+
+// parseEventInclude parses a comma-separated ?include= value
+func parseEventInclude(raw string) (map[string]bool, error) {
+	include := make(map[string]bool)
+	for _, v := range strings.Split(raw, ",") {
+		v = strings.ToLower(strings.TrimSpace(v))
+		if v == "" {
+			continue
+		}
+		if !eventIncludes[v] {
+			return nil, fmt.Errorf("unknown include %q (allowed: stats)", v)
+		}
+		include[v] = true
+	}
+	return include, nil
+}
+
+// loadEventStats sets the ?include=stats aggregates on a page of events. The
+// submission counts of every event that shows them come from one grouped query.
+func loadEventStats(db *gorm.DB, events []models.Event, now time.Time) error {
+	var counted []uint
+	for i := range events {
+		e := &events[i]
+		if !e.CFPCloseAt.IsZero() && e.IsCFPOpenAt(now, 0) {
+			days := int(e.CFPCloseAt.Sub(now).Hours() / 24)
+			e.DaysUntilCFPClose = &days
+		}
+		if e.ShowSubmissionCount {
+			counted = append(counted, e.ID)
+		}
+	}
+	if len(counted) == 0 {
+		return nil
+	}
+
+	var rows []struct {
+		EventID uint
+		Count   int64
+	}
+	if err := db.Model(&models.Proposal{}).Select("event_id, COUNT(*) AS count").
+		Where("event_id IN ?", counted).Group("event_id").Scan(&rows).Error; err != nil {
+		return err
+	}
+	counts := make(map[uint]int64, len(rows))
+	for _, row := range rows {
+		counts[row.EventID] = row.Count
+	}
+	for i := range events {
+		if events[i].ShowSubmissionCount {
+			count := counts[events[i].ID]
+			events[i].SubmissionCount = &count
+		}
+	}
+	return nil
+}
+
+// addEventStats copies the stats set by loadEventStats into rows reduced by
+// selectEventFields
+func addEventStats(events []models.Event, rows []map[string]interface{}) {
+	for i := range events {
+		if events[i].DaysUntilCFPClose != nil {
+			rows[i]["days_until_cfp_close"] = *events[i].DaysUntilCFPClose
+		}
+		if events[i].SubmissionCount != nil {
+			rows[i]["submission_count"] = *events[i].SubmissionCount
+		}
+	}
+}
+
+// writeEventFieldsCSV renders events reduced by selectEventFields as CSV,
+// with one column per requested field
+func writeEventFieldsCSV(w *csv.Writer, fields []string, rows []map[string]interface{}) {
+	w.Write(fields)
+
+	for _, row := range rows {
+		record := make([]string, len(fields))
+		for i, f := range fields {
+			switch v := row[eventListFields[f]].(type) {
+			case nil:
+			case string:
+				record[i] = sanitizeCSVCell(v)
+			case bool:
+				record[i] = boolToYesNo(v)
+			case float64:
+				record[i] = fmt.Sprint(v)
+			default:
+				b, _ := json.Marshal(v)
+				record[i] = sanitizeCSVCell(string(b))
+			}
+		}
+		w.Write(record)
+	}
+}
+
+// publicOrganizer is the organizer information safe to show on public event pages
+type publicOrganizer struct {
+	Name       string `json:"name"`
+	PictureURL string `json:"picture_url,omitempty"`
+}
+
+// eventWithExpansions is an event with the optional ?expand= sections
+type eventWithExpansions struct {
+	models.Event
+	OrganizersPublic *[]publicOrganizer `json:"organizers_public,omitempty"`
+}
+
+// eventExpansions lists the values accepted by ?expand= on single-event endpoints
+var eventExpansions = map[string]bool{
+	"organizers_public": true,
+}
+
+// proposalExpansions lists the values accepted by ?expand= on the proposal
+// detail. They only apply to organizers; speakers get the proposal as is.
+var proposalExpansions = map[string]bool{
+	"speaker_history": true,
+}
+
+// parseEventExpand parses a comma-separated ?expand= value
+func parseEventExpand(raw string) (map[string]bool, error) {
+	return parseExpand(raw, eventExpansions)
+}
+
+// parseExpand parses a comma-separated ?expand= value against the allowed
+// expansions
+func parseExpand(raw string, allowed map[string]bool) (map[string]bool, error) {
+	expand := make(map[string]bool)
+	for _, e := range strings.Split(raw, ",") {
+		e = strings.ToLower(strings.TrimSpace(e))
+		if e == "" {
+			continue
+		}
+		if !allowed[e] {
+			names := make([]string, 0, len(allowed))
+			for name := range allowed {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			return nil, fmt.Errorf("unknown expansion %q (allowed: %s)", e, strings.Join(names, ", "))
+		}
+		expand[e] = true
+	}
+	return expand, nil
+}
+
+// expandEvent loads the requested expansions for a public event response
+func expandEvent(cfg *config.Config, r *http.Request, event *models.Event, expand map[string]bool) (*eventWithExpansions, error) {
+	resp := &eventWithExpansions{Event: *event}
+	if expand["organizers_public"] {
+		var organizers []models.User
+		if err := cfg.DBCtx(r).Model(event).Association("Organizers").Find(&organizers); err != nil {
+			return nil, err
+		}
+		public := make([]publicOrganizer, 0, len(organizers))
+		for _, o := range organizers {
+			public = append(public, publicOrganizer{Name: o.Name, PictureURL: o.PictureURL})
+		}
+		resp.OrganizersPublic = &public
+	}
+	return resp, nil
+}
+
+// parseExpandParam parses ?expand= against the endpoint's allowed
+// expansions, sending a 400 and returning false when it names an unknown one
+func parseExpandParam(w http.ResponseWriter, r *http.Request, allowed map[string]bool) (map[string]bool, bool) {
+	expand, err := parseExpand(r.URL.Query().Get("expand"), allowed)
+	if err != nil {
+		var errs validationErrors
+		errs.add("expand", err.Error())
+		encodeValidationErrors(w, r, errs)
+		return nil, false
+	}
+	return expand, true
+}
+
+// encodePublicEvent sends a public event, with any requested expansions
+func encodePublicEvent(cfg *config.Config, w http.ResponseWriter, r *http.Request, event *models.Event, expand map[string]bool) {
+	if len(expand) == 0 {
+		encodeResponse(w, r, event)
+		return
+	}
+	resp, err := expandEvent(cfg, r, event, expand)
+	if err != nil {
+		cfg.Logger.Error("failed to expand event", "error", err, "event_id", event.ID)
+		encodeAPIError(w, r, "Failed to load event", http.StatusInternalServerError)
+		return
+	}
+	encodeResponse(w, r, resp)
+}
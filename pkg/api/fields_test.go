@@ -0,0 +1,190 @@
+package api
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sreday/cfp.ninja/pkg/models"
+)
+
+func TestParseEventFields(t *testing.T) {
+	tests := []struct {
+		raw     string
+		want    []string
+		wantErr bool
+	}{
+		{"", nil, false},
+		{"  ", nil, false},
+		{"id,name,slug", []string{"id", "name", "slug"}, false},
+		{" ID , Name ,,name", []string{"id", "name"}, false},
+		{"id,stripe_payment_id", nil, true},
+		{"created_by_id", nil, true},
+		{"organizers", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			got, err := parseEventFields(tt.raw)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error for %q", tt.raw)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestParseEventFields_ErrorListsAllowed(t *testing.T) {
+	_, err := parseEventFields("nmae")
+	if err == nil || !strings.Contains(err.Error(), `"nmae"`) || !strings.Contains(err.Error(), "cfp_close_at") {
+		t.Errorf("expected error naming the field and the allowed list, got %v", err)
+	}
+}
+
+func TestSelectEventFields(t *testing.T) {
+	events := []models.Event{{Name: "GopherCon", Slug: "gophercon", Description: "long text", CFPCloseAt: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)}}
+	events[0].ID = 42
+
+	rows, err := selectEventFields(events, []string{"id", "slug", "cfp_close_at"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	b, _ := json.Marshal(rows[0])
+	var got map[string]interface{}
+	json.Unmarshal(b, &got)
+	if len(got) != 3 {
+		t.Errorf("expected 3 keys, got %v", got)
+	}
+	if got["ID"] != float64(42) {
+		t.Errorf("expected ID 42, got %v", got["ID"])
+	}
+	if got["slug"] != "gophercon" {
+		t.Errorf("expected slug gophercon, got %v", got["slug"])
+	}
+	if got["cfp_close_at"] != "2026-03-01T00:00:00Z" {
+		t.Errorf("expected cfp_close_at 2026-03-01T00:00:00Z, got %v", got["cfp_close_at"])
+	}
+	if _, ok := got["description"]; ok {
+		t.Error("expected description to be omitted")
+	}
+}
+
+func TestWriteEventFieldsCSV(t *testing.T) {
+	events := []models.Event{{Name: "@risky", IsOnline: true}}
+	fields := []string{"name", "is_online", "max_accepted"}
+	rows, err := selectEventFields(events, fields)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var b strings.Builder
+	cw := csv.NewWriter(&b)
+	writeEventFieldsCSV(cw, fields, rows)
+	cw.Flush()
+
+	records, err := csv.NewReader(strings.NewReader(b.String())).ReadAll()
+	if err != nil {
+		t.Fatalf("invalid CSV: %v", err)
+	}
+	if strings.Join(records[0], ",") != "name,is_online,max_accepted" {
+		t.Errorf("unexpected header: %v", records[0])
+	}
+	if strings.Join(records[1], ",") != "'@risky,yes," {
+		t.Errorf("unexpected row: %v", records[1])
+	}
+}
+
+func TestParseEventExpand(t *testing.T) {
+	expand, err := parseEventExpand("organizers_public")
+	if err != nil || !expand["organizers_public"] {
+		t.Errorf("expected organizers_public expansion, got %v (err %v)", expand, err)
+	}
+
+	expand, err = parseEventExpand("")
+	if err != nil || len(expand) != 0 {
+		t.Errorf("expected no expansions, got %v (err %v)", expand, err)
+	}
+
+	if _, err := parseEventExpand("organizers"); err == nil {
+		t.Error("expected error for unknown expansion")
+	}
+}
+
+func TestParseExpand_ProposalExpansions(t *testing.T) {
+	expand, err := parseExpand("Speaker_History", proposalExpansions)
+	if err != nil || !expand["speaker_history"] {
+		t.Errorf("expected speaker_history expansion, got %v (err %v)", expand, err)
+	}
+
+	_, err = parseExpand("organizers_public", proposalExpansions)
+	if err == nil || !strings.Contains(err.Error(), "allowed: speaker_history") {
+		t.Errorf("expected event expansions to be refused on proposals, got %v", err)
+	}
+}
+
+func TestEventWithExpansions_JSON(t *testing.T) {
+	event := models.Event{Name: "GopherCon", Slug: "gophercon"}
+
+	b, _ := json.Marshal(eventWithExpansions{Event: event})
+	if strings.Contains(string(b), "organizers_public") {
+		t.Error("expected organizers_public to be omitted when not expanded")
+	}
+
+	empty := []publicOrganizer{}
+	b, _ = json.Marshal(eventWithExpansions{Event: event, OrganizersPublic: &empty})
+	var got map[string]interface{}
+	json.Unmarshal(b, &got)
+	if got["slug"] != "gophercon" {
+		t.Errorf("expected embedded event fields, got %v", got)
+	}
+	if list, ok := got["organizers_public"].([]interface{}); !ok || len(list) != 0 {
+		t.Errorf("expected empty organizers_public list, got %v", got["organizers_public"])
+	}
+}
+
+func TestParseEventInclude(t *testing.T) {
+	include, err := parseEventInclude(" Stats ")
+	if err != nil || !include["stats"] {
+		t.Errorf("expected stats, got %v (err %v)", include, err)
+	}
+
+	include, err = parseEventInclude("")
+	if err != nil || len(include) != 0 {
+		t.Errorf("expected nothing included, got %v (err %v)", include, err)
+	}
+
+	if _, err := parseEventInclude("stats,counts"); err == nil {
+		t.Error("expected error for unknown include")
+	}
+}
+
+func TestAddEventStats(t *testing.T) {
+	days, count := 3, int64(12)
+	events := []models.Event{
+		{DaysUntilCFPClose: &days, SubmissionCount: &count},
+		{},
+	}
+	rows := []map[string]interface{}{{"name": "a"}, {"name": "b"}}
+	addEventStats(events, rows)
+
+	if rows[0]["days_until_cfp_close"] != 3 || rows[0]["submission_count"] != int64(12) {
+		t.Errorf("expected stats on the first row, got %v", rows[0])
+	}
+	if _, ok := rows[1]["days_until_cfp_close"]; ok {
+		t.Errorf("expected no stats on the second row, got %v", rows[1])
+	}
+	if _, ok := rows[1]["submission_count"]; ok {
+		t.Errorf("expected no stats on the second row, got %v", rows[1])
+	}
+}
@@ -0,0 +1,136 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/sreday/cfp.ninja/pkg/config"
+	"github.com/sreday/cfp.ninja/pkg/geocode"
+	"github.com/sreday/cfp.ninja/pkg/models"
+)
+
+// Near-me search limits
+const (
+	DefaultNearRadiusKm = 100
+	MaxNearRadiusKm     = 5000
+)
+
+// haversineSQL is the great-circle distance in km from the event to a point.
+// Its parameters are the point's latitude, latitude again, and longitude.
+const haversineSQL = `2 * 6371 * ASIN(LEAST(1, SQRT(
+	POWER(SIN(RADIANS(latitude - ?) / 2), 2) +
+	COS(RADIANS(?)) * COS(RADIANS(latitude)) * POWER(SIN(RADIANS(longitude - ?) / 2), 2))))`
+
+// nearQuery is a parsed ?near=lat,lon&radius_km= filter
+type nearQuery struct {
+	center   geocode.Point
+	radiusKm float64
+}
+
+// parseNearQuery parses ?near= and ?radius_km=, recording problems in errs.
+// It returns nil when no near filter was requested or it is invalid.
+func parseNearQuery(q url.Values, errs *validationErrors) *nearQuery {
+	near := strings.TrimSpace(q.Get("near"))
+	radius := strings.TrimSpace(q.Get("radius_km"))
+	if near == "" {
+		if radius != "" {
+			errs.add("radius_km", "radius_km requires near")
+		}
+		return nil
+	}
+
+	n := &nearQuery{radiusKm: DefaultNearRadiusKm}
+	valid := true
+
+	lat, lon, found := strings.Cut(near, ",")
+	latVal, latErr := strconv.ParseFloat(strings.TrimSpace(lat), 64)
+	lonVal, lonErr := strconv.ParseFloat(strings.TrimSpace(lon), 64)
+	if !found || latErr != nil || lonErr != nil {
+		errs.add("near", "near must be latitude,longitude (e.g. 52.52,13.405)")
+		valid = false
+	} else if latVal < -90 || latVal > 90 || lonVal < -180 || lonVal > 180 {
+		errs.add("near", "near must be within latitude -90..90 and longitude -180..180")
+		valid = false
+	} else {
+		n.center = geocode.Point{Lat: latVal, Lon: lonVal}
+	}
+
+	if radius != "" {
+		r, err := strconv.ParseFloat(radius, 64)
+		if err != nil || r <= 0 || r > MaxNearRadiusKm {
+			errs.add("radius_km", "radius_km must be a number between 0 and 5000")
+			valid = false
+		} else {
+			n.radiusKm = r
+		}
+	}
+
+	if !valid {
+		return nil
+	}
+	return n
+}
+
+// filter restricts the query to events within the radius. A bounding box on the
+// indexed coordinates narrows the candidates before the exact distance check.
+// Events without coordinates never match.
+func (n *nearQuery) filter(query *gorm.DB) *gorm.DB {
+	box := geocode.Around(n.center, n.radiusKm)
+	query = query.Where("latitude BETWEEN ? AND ?", box.MinLat, box.MaxLat)
+	if box.CrossesAntimeridian() {
+		query = query.Where("(longitude >= ? OR longitude <= ?)", box.MinLon, box.MaxLon)
+	} else {
+		query = query.Where("longitude BETWEEN ? AND ?", box.MinLon, box.MaxLon)
+	}
+	return query.Where(haversineSQL+" <= ?", n.center.Lat, n.center.Lat, n.center.Lon, n.radiusKm)
+}
+
+// order sorts the query nearest first, tie-breaking on id
+func (n *nearQuery) order(query *gorm.DB) *gorm.DB {
+	return query.Order(clause.OrderBy{Expression: clause.Expr{
+		SQL:                haversineSQL + " ASC, id ASC",
+		Vars:               []interface{}{n.center.Lat, n.center.Lat, n.center.Lon},
+		WithoutParentheses: true,
+	}})
+}
+
+// placeChanged reports whether updates move the event, so its coordinates need
+// to be geocoded again
+func placeChanged(event *models.Event, updates map[string]interface{}) bool {
+	if v, ok := updates["location"].(string); ok && v != event.Location {
+		return true
+	}
+	if v, ok := updates["country_code"].(string); ok && v != event.CountryCode {
+		return true
+	}
+	if v, ok := updates["attendance_mode"].(string); ok && models.AttendanceMode(v).HasVenue() != event.AttendanceMode.HasVenue() {
+		return true
+	}
+	return false
+}
+
+// geocodeEventAsync resolves the event's coordinates in the background. Events
+// whose location cannot be geocoded are left without coordinates.
+func geocodeEventAsync(cfg *config.Config, event models.Event) {
+	if cfg.Geocoder == nil || !event.AttendanceMode.HasVenue() || strings.TrimSpace(event.Location) == "" {
+		return
+	}
+	SafeGo(cfg, func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		if err := models.GeocodeEvent(ctx, cfg.DB, cfg.Geocoder, &event); err != nil {
+			if errors.Is(err, geocode.ErrNotFound) {
+				cfg.Logger.Info("event location not found by geocoder", "event_id", event.ID, "location", event.Location)
+				return
+			}
+			cfg.Logger.Warn("failed to geocode event", "event_id", event.ID, "error", err)
+		}
+	})
+}
@@ -0,0 +1,89 @@
+package api
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/sreday/cfp.ninja/pkg/models"
+)
+
+func TestParseNearQuery(t *testing.T) {
+	tests := []struct {
+		query      string
+		wantNil    bool
+		wantErrors []string
+		wantLat    float64
+		wantLon    float64
+		wantRadius float64
+	}{
+		{query: "", wantNil: true},
+		{query: "near=52.52,13.405", wantLat: 52.52, wantLon: 13.405, wantRadius: DefaultNearRadiusKm},
+		{query: "near=52.52,%2013.405&radius_km=500", wantLat: 52.52, wantLon: 13.405, wantRadius: 500},
+		{query: "near=-33.87,151.21&radius_km=0.5", wantLat: -33.87, wantLon: 151.21, wantRadius: 0.5},
+		{query: "radius_km=500", wantNil: true, wantErrors: []string{"radius_km"}},
+		{query: "near=berlin", wantNil: true, wantErrors: []string{"near"}},
+		{query: "near=52.52", wantNil: true, wantErrors: []string{"near"}},
+		{query: "near=95,13", wantNil: true, wantErrors: []string{"near"}},
+		{query: "near=52,181", wantNil: true, wantErrors: []string{"near"}},
+		{query: "near=52,13&radius_km=0", wantNil: true, wantErrors: []string{"radius_km"}},
+		{query: "near=52,13&radius_km=5001", wantNil: true, wantErrors: []string{"radius_km"}},
+		{query: "near=x&radius_km=far", wantNil: true, wantErrors: []string{"near", "radius_km"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			q, err := url.ParseQuery(tt.query)
+			if err != nil {
+				t.Fatalf("bad test query: %v", err)
+			}
+			var errs validationErrors
+			n := parseNearQuery(q, &errs)
+
+			if len(errs) != len(tt.wantErrors) {
+				t.Fatalf("expected %d errors, got %v", len(tt.wantErrors), errs)
+			}
+			for i, field := range tt.wantErrors {
+				if errs[i].Field != field {
+					t.Errorf("expected error on %q, got %q", field, errs[i].Field)
+				}
+			}
+			if tt.wantNil {
+				if n != nil {
+					t.Errorf("expected no near filter, got %+v", n)
+				}
+				return
+			}
+			if n == nil {
+				t.Fatal("expected a near filter")
+			}
+			if n.center.Lat != tt.wantLat || n.center.Lon != tt.wantLon || n.radiusKm != tt.wantRadius {
+				t.Errorf("expected (%v,%v) r=%v, got (%v,%v) r=%v",
+					tt.wantLat, tt.wantLon, tt.wantRadius, n.center.Lat, n.center.Lon, n.radiusKm)
+			}
+		})
+	}
+}
+
+func TestPlaceChanged(t *testing.T) {
+	event := &models.Event{Location: "Berlin", CountryCode: "DE", AttendanceMode: models.AttendanceInPerson}
+
+	tests := []struct {
+		name    string
+		updates map[string]interface{}
+		want    bool
+	}{
+		{"unrelated field", map[string]interface{}{"name": "New name"}, false},
+		{"same location", map[string]interface{}{"location": "Berlin"}, false},
+		{"new location", map[string]interface{}{"location": "Munich"}, true},
+		{"new country", map[string]interface{}{"country_code": "AT"}, true},
+		{"now online", map[string]interface{}{"attendance_mode": "online"}, true},
+		{"now hybrid, same venue", map[string]interface{}{"attendance_mode": "hybrid"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := placeChanged(event, tt.updates); got != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
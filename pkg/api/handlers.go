@@ -6,25 +6,70 @@ import (
 	"net/http"
 
 	"github.com/sreday/cfp.ninja/pkg/config"
+	"github.com/sreday/cfp.ninja/pkg/logging"
 )
 
-// encodeResponse encodes a response as JSON
+// encodeResponse encodes a response in the format requested by the Accept header:
+// JSON (the default, also used for unknown types), YAML, or CSV for responses
+// wrapped with withCSV.
 func encodeResponse(w http.ResponseWriter, r *http.Request, data interface{}) {
-	w.Header().Set("Content-Type", "application/json")
-	enc := json.NewEncoder(w)
-	if r.URL.Query().Get("pretty") == "true" {
-		enc.SetIndent("", "  ")
-	}
-	if err := enc.Encode(data); err != nil {
-		slog.Warn("failed to encode response", "error", err)
+	encodeResponseStatus(w, r, http.StatusOK, data)
+}
+
+// encodeCreated writes a 201 response for a newly created resource, with the
+// resource's path in the Location header
+func encodeCreated(w http.ResponseWriter, r *http.Request, location string, data interface{}) {
+	w.Header().Set("Location", withBasePath(r, location))
+	encodeResponseStatus(w, r, http.StatusCreated, data)
+}
+
+// statusWriter sends its status code with the first write, so headers the
+// encoders set before writing the body are not lost
+type statusWriter struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+}
+
+func (sw *statusWriter) Write(b []byte) (int, error) {
+	if !sw.wroteHeader {
+		sw.wroteHeader = true
+		sw.ResponseWriter.WriteHeader(sw.status)
 	}
+	return sw.ResponseWriter.Write(b)
 }
 
-// encodeError sends a JSON error response
-func encodeError(w http.ResponseWriter, message string, statusCode int) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(statusCode)
-	json.NewEncoder(w).Encode(map[string]string{"error": message})
+// encodeResponseStatus is encodeResponse with a status code other than 200
+func encodeResponseStatus(w http.ResponseWriter, r *http.Request, status int, data interface{}) {
+	if status != http.StatusOK {
+		w = &statusWriter{ResponseWriter: w, status: status}
+	}
+
+	offers := []string{mediaJSON, mediaYAML}
+	table, hasCSV := data.(csvResponse)
+	if hasCSV {
+		data = table.data
+		offers = append(offers, mediaCSV)
+	}
+	w.Header().Add("Vary", "Accept")
+
+	var err error
+	switch negotiateMedia(r, offers...) {
+	case mediaYAML:
+		err = encodeYAML(w, data)
+	case mediaCSV:
+		err = encodeCSV(w, table.writeCSV)
+	default:
+		w.Header().Set("Content-Type", mediaJSON)
+		enc := json.NewEncoder(w)
+		if r.URL.Query().Get("pretty") == "true" {
+			enc.SetIndent("", "  ")
+		}
+		err = enc.Encode(data)
+	}
+	if err != nil {
+		logging.Module(slog.Default(), logging.API).Warn("failed to encode response", "error", err)
+	}
 }
 
 // safeGoSem limits the number of concurrent SafeGo goroutines to avoid
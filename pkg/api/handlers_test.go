@@ -1,8 +1,14 @@
 package api
 
 import (
+	"bytes"
+	"encoding/json"
+	"log"
 	"log/slog"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -75,3 +81,116 @@ func TestSafeGo_NilCallback(t *testing.T) {
 		t.Fatal("SafeGo did not complete within timeout")
 	}
 }
+
+func TestEncodeError_IncludesCode(t *testing.T) {
+	tests := []struct {
+		status int
+		code   string
+	}{
+		{http.StatusBadRequest, ErrCodeValidation},
+		{http.StatusPaymentRequired, ErrCodePaymentRequired},
+		{http.StatusNotFound, ErrCodeNotFound},
+		{http.StatusConflict, ErrCodeConflict},
+		{http.StatusTooManyRequests, ErrCodeRateLimited},
+		{http.StatusLocked, ErrCodeLocked},
+		{http.StatusInternalServerError, ErrCodeInternal},
+	}
+
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		encodeError(rec, "something went wrong", tt.status)
+
+		if rec.Code != tt.status {
+			t.Errorf("expected status %d, got %d", tt.status, rec.Code)
+		}
+		var body map[string]string
+		if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+			t.Fatalf("failed to parse body: %v", err)
+		}
+		if body["error"] != "something went wrong" {
+			t.Errorf("expected error message to be kept, got %q", body["error"])
+		}
+		if body["code"] != tt.code {
+			t.Errorf("status %d: expected code %q, got %q", tt.status, tt.code, body["code"])
+		}
+	}
+}
+
+// headerCountingRecorder fails the test when the status is written twice
+type headerCountingRecorder struct {
+	*httptest.ResponseRecorder
+	writes int
+}
+
+func (rec *headerCountingRecorder) WriteHeader(code int) {
+	rec.writes++
+	rec.ResponseRecorder.WriteHeader(code)
+}
+
+func TestEncodeCreated(t *testing.T) {
+	tests := []struct {
+		name        string
+		accept      string
+		contentType string
+	}{
+		{"default", "", "application/json"},
+		{"json", "application/json", "application/json"},
+		{"yaml", "application/yaml", "application/yaml"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := &headerCountingRecorder{ResponseRecorder: httptest.NewRecorder()}
+			r := httptest.NewRequest(http.MethodPost, "/api/v0/events", nil)
+			if tt.accept != "" {
+				r.Header.Set("Accept", tt.accept)
+			}
+
+			encodeCreated(rec, r, "/api/v0/events/42", map[string]interface{}{"id": 42})
+
+			if rec.Code != http.StatusCreated {
+				t.Errorf("expected status 201, got %d", rec.Code)
+			}
+			if rec.writes != 1 {
+				t.Errorf("expected one WriteHeader call, got %d", rec.writes)
+			}
+			if got := rec.Header().Get("Location"); got != "/api/v0/events/42" {
+				t.Errorf("expected Location /api/v0/events/42, got %q", got)
+			}
+			if got := rec.Header().Get("Content-Type"); got != tt.contentType {
+				t.Errorf("expected Content-Type %q, got %q", tt.contentType, got)
+			}
+			if !strings.Contains(rec.Body.String(), "42") {
+				t.Errorf("expected the body once, got %q", rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestEncodeCreated_NoSuperfluousWriteHeader(t *testing.T) {
+	var errLog bytes.Buffer
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		encodeCreated(w, r, "/api/v0/proposals/7", map[string]interface{}{"id": 7})
+	}))
+	srv.Config.ErrorLog = log.New(&errLog, "", 0)
+	srv.Start()
+	defer srv.Close()
+
+	resp, err := http.Post(srv.URL, "application/json", nil)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	var body map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("failed to parse body: %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated || resp.Header.Get("Location") != "/api/v0/proposals/7" {
+		t.Errorf("expected 201 with Location, got %d %q", resp.StatusCode, resp.Header.Get("Location"))
+	}
+	srv.Close()
+	if strings.Contains(errLog.String(), "superfluous") {
+		t.Errorf("unexpected server log: %s", errLog.String())
+	}
+}
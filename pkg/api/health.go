@@ -15,7 +15,7 @@ func HealthHandler(cfg *config.Config) http.HandlerFunc {
 			return
 		}
 
-		sqlDB, err := cfg.DB.DB()
+		sqlDB, err := cfg.DBCtx(r).DB()
 		if err != nil {
 			encodeError(w, "database unavailable", http.StatusServiceUnavailable)
 			return
@@ -0,0 +1,419 @@
+package api
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+
+	"github.com/sreday/cfp.ninja/pkg/config"
+	"github.com/sreday/cfp.ninja/pkg/models"
+)
+
+// Import limits
+const (
+	MaxImportFileSize = 5 << 20 // 5MB
+	MaxImportRows     = 2000
+	maxImportSpeakers = 3
+)
+
+// importColumnAliases maps normalized CSV headers to the proposal fields they
+// fill. Headers are lowercased and runs of other characters become "_", so
+// "Owner Email" matches owner_email. Speakers 2 and 3 use the same names with
+// a number: speaker2_name or "Speaker 2 Name".
+var importColumnAliases = map[string]string{
+	"title":             "title",
+	"session_title":     "title",
+	"abstract":          "abstract",
+	"description":       "abstract",
+	"format":            "format",
+	"session_format":    "format",
+	"duration":          "duration",
+	"level":             "level",
+	"tags":              "tags",
+	"speaker_notes":     "speaker_notes",
+	"notes":             "speaker_notes",
+	"speaker_name":      "speaker_name",
+	"owner":             "speaker_name",
+	"speaker_email":     "speaker_email",
+	"owner_email":       "speaker_email",
+	"email":             "speaker_email",
+	"speaker_company":   "speaker_company",
+	"company":           "speaker_company",
+	"speaker_job_title": "speaker_job_title",
+	"job_title":         "speaker_job_title",
+	"tagline":           "speaker_job_title",
+	"speaker_linkedin":  "speaker_linkedin",
+	"linkedin":          "speaker_linkedin",
+	"speaker_bio":       "speaker_bio",
+	"bio":               "speaker_bio",
+}
+
+// importFormatAliases maps format values, lowercased, to proposal formats.
+// An empty format imports as a talk.
+var importFormatAliases = map[string]models.ProposalFormat{
+	"":               models.FormatTalk,
+	"talk":           models.FormatTalk,
+	"session":        models.FormatTalk,
+	"workshop":       models.FormatWorkshop,
+	"lightning":      models.FormatLightning,
+	"lightning talk": models.FormatLightning,
+}
+
+var (
+	importHeaderJunk   = regexp.MustCompile(`[^a-z0-9]+`)
+	importSpeakerIndex = regexp.MustCompile(`^speaker_?([123])_(.+)$`)
+)
+
+// importColumn resolves a CSV header to a proposal field and, for speaker
+// fields, the speaker it belongs to (0-based). ok is false for columns the
+// import does not use.
+func importColumn(header string) (field string, speaker int, ok bool) {
+	h := strings.Trim(importHeaderJunk.ReplaceAllString(strings.ToLower(header), "_"), "_")
+	if m := importSpeakerIndex.FindStringSubmatch(h); m != nil {
+		speaker = int(m[1][0] - '1')
+		h = "speaker_" + m[2]
+		if field, ok = importColumnAliases[h]; !ok || !strings.HasPrefix(field, "speaker_") || field == "speaker_notes" {
+			return "", 0, false
+		}
+		return field, speaker, true
+	}
+	field, ok = importColumnAliases[h]
+	return field, 0, ok
+}
+
+// importRow is one CSV data row parsed into a proposal
+type importRow struct {
+	Line     int
+	Proposal models.Proposal
+	Errors   validationErrors
+}
+
+// parseImportCSV reads a proposal CSV and validates each row with the same
+// rules as a submission, except that no speaker has to match an account.
+// It returns the rows and the headers it ignored. Custom answers are not
+// imported, so required custom questions are not checked. An error is only
+// returned when the file itself cannot be used.
+func parseImportCSV(r io.Reader) ([]importRow, []string, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, nil, errors.New("CSV file is empty")
+	} else if err != nil {
+		return nil, nil, fmt.Errorf("invalid CSV: %w", err)
+	}
+	if len(header) > 0 {
+		header[0] = strings.TrimPrefix(header[0], "\ufeff") // Excel's byte order mark
+	}
+
+	type column struct {
+		field   string
+		speaker int
+	}
+	columns := make([]column, len(header))
+	var ignored []string
+	hasTitle := false
+	for i, h := range header {
+		field, speaker, ok := importColumn(h)
+		if !ok {
+			if strings.TrimSpace(h) != "" {
+				ignored = append(ignored, h)
+			}
+			continue
+		}
+		columns[i] = column{field: field, speaker: speaker}
+		hasTitle = hasTitle || field == "title"
+	}
+	if !hasTitle {
+		return nil, nil, errors.New("CSV must have a title column")
+	}
+
+	var rows []importRow
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid CSV: %w", err)
+		}
+		line, _ := reader.FieldPos(0)
+		if len(rows) >= MaxImportRows {
+			return nil, nil, fmt.Errorf("CSV has more than %d rows", MaxImportRows)
+		}
+
+		blank := true
+		for _, v := range record {
+			if strings.TrimSpace(v) != "" {
+				blank = false
+				break
+			}
+		}
+		if blank {
+			continue
+		}
+
+		row := importRow{Line: line}
+		p := &row.Proposal
+		var speakers [maxImportSpeakers]models.Speaker
+		var format, duration string
+		for i, v := range record {
+			if i >= len(columns) || columns[i].field == "" {
+				continue
+			}
+			v = strings.TrimSpace(v)
+			s := &speakers[columns[i].speaker]
+			switch columns[i].field {
+			case "title":
+				p.Title = v
+			case "abstract":
+				p.Abstract = v
+			case "format":
+				format = v
+			case "duration":
+				duration = v
+			case "level":
+				p.Level = v
+			case "tags":
+				p.Tags = v
+			case "speaker_notes":
+				p.SpeakerNotes = v
+			case "speaker_name":
+				s.Name = v
+			case "speaker_email":
+				s.Email = v
+			case "speaker_company":
+				s.Company = v
+			case "speaker_job_title":
+				s.JobTitle = v
+			case "speaker_linkedin":
+				s.LinkedIn = v
+			case "speaker_bio":
+				s.Bio = v
+			}
+		}
+
+		if p.Title == "" {
+			row.Errors.add("title", "Title is required")
+		} else if len(p.Title) > MaxProposalTitleLen {
+			row.Errors.add("title", "Title must be at most 300 characters")
+		}
+		if p.Abstract == "" {
+			row.Errors.add("abstract", "Abstract is required")
+		} else if len(p.Abstract) > MaxProposalAbstractLen {
+			row.Errors.add("abstract", "Abstract must be at most 10000 characters")
+		}
+		if f, ok := importFormatAliases[strings.ToLower(format)]; ok {
+			p.Format = f
+		} else {
+			row.Errors.add("format", "Format must be one of: talk, workshop, lightning")
+		}
+		if duration != "" {
+			if d, err := strconv.Atoi(duration); err != nil || d < 0 {
+				row.Errors.add("duration", "Duration must be a whole number of minutes")
+			} else {
+				p.Duration = d
+			}
+		}
+
+		var list []models.Speaker
+		for _, s := range speakers {
+			if s != (models.Speaker{}) {
+				list = append(list, s)
+			}
+		}
+		if len(list) > 0 {
+			list[0].Primary = true
+		}
+		validateSpeakers(list, "", &row.Errors)
+		if err := p.SetSpeakers(list); err != nil {
+			row.Errors.add("speakers", "Invalid speakers data")
+		}
+
+		rows = append(rows, row)
+	}
+	if len(rows) == 0 {
+		return nil, nil, errors.New("CSV has no proposals")
+	}
+	return rows, ignored, nil
+}
+
+// normalizeImportTitle is the form of a title compared when looking for
+// duplicates
+func normalizeImportTitle(title string) string {
+	return strings.ToLower(strings.Join(strings.Fields(title), " "))
+}
+
+// importRowResult is the outcome of one CSV row
+type importRowResult struct {
+	Line       int               `json:"line"`
+	Title      string            `json:"title"`
+	Result     string            `json:"result"` // "ok", "invalid" or "duplicate"
+	Errors     map[string]string `json:"errors,omitempty"`
+	ProposalID uint              `json:"proposal_id,omitempty"`
+}
+
+// importReport is the response of a proposal import
+type importReport struct {
+	Error          string            `json:"error,omitempty"`
+	Code           string            `json:"code,omitempty"`
+	DryRun         bool              `json:"dry_run"`
+	Imported       int               `json:"imported"`
+	Duplicates     int               `json:"duplicates"`
+	Invalid        int               `json:"invalid"`
+	IgnoredColumns []string          `json:"ignored_columns"`
+	Rows           []importRowResult `json:"rows"`
+}
+
+// ImportProposalsHandler imports proposals for an event from a CSV upload
+// (organizer only). Rows are validated like submissions; if any row is
+// invalid nothing is imported. Rows whose title matches an existing proposal
+// or an earlier row are reported as duplicates and skipped. With
+// ?dry_run=true the report is returned without writing anything.
+// POST /api/v0/events/{id}/proposals/import
+func ImportProposalsHandler(cfg *config.Config) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user := GetUserFromContext(r.Context())
+		if user == nil {
+			encodeAPIError(w, r, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		eventID, err := strconv.ParseUint(r.PathValue("id"), 10, 32)
+		if err != nil {
+			encodeAPIError(w, r, "Invalid event ID", http.StatusBadRequest)
+			return
+		}
+
+		var event models.Event
+		if err := cfg.DBCtx(r).Preload("Organizers").First(&event, eventID).Error; err != nil {
+			encodeAPIError(w, r, "Event not found", http.StatusNotFound)
+			return
+		}
+		if !event.IsOrganizer(user.ID) {
+			encodeAPIError(w, r, "Only organizers can import proposals", http.StatusForbidden)
+			return
+		}
+
+		dryRun := r.URL.Query().Get("dry_run") == "true"
+
+		// Leave room for the multipart framing around the file
+		r.Body = http.MaxBytesReader(w, r.Body, MaxImportFileSize+64<<10)
+		defer r.Body.Close()
+
+		file, _, err := r.FormFile("file")
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				encodeAPIError(w, r, "CSV file must be at most 5MB", http.StatusRequestEntityTooLarge)
+				return
+			}
+			encodeAPIErrorCode(w, r, ErrCodeInvalidBody, "Upload the CSV as multipart form field \"file\"", http.StatusBadRequest)
+			return
+		}
+		defer file.Close()
+
+		rows, ignored, err := parseImportCSV(io.LimitReader(file, MaxImportFileSize+1))
+		if err != nil {
+			var errs validationErrors
+			errs.add("file", err.Error())
+			encodeValidationErrors(w, r, errs)
+			return
+		}
+
+		var existing []string
+		if err := cfg.DBCtx(r).Model(&models.Proposal{}).Where("event_id = ?", event.ID).Pluck("title", &existing).Error; err != nil {
+			cfg.Logger.Error("failed to load proposal titles for import", "error", err, "event_id", event.ID)
+			encodeAPIError(w, r, "Failed to import proposals", http.StatusInternalServerError)
+			return
+		}
+		seen := make(map[string]bool, len(existing)+len(rows))
+		for _, t := range existing {
+			seen[normalizeImportTitle(t)] = true
+		}
+
+		report := importReport{DryRun: dryRun, IgnoredColumns: ignored, Rows: make([]importRowResult, len(rows))}
+		if report.IgnoredColumns == nil {
+			report.IgnoredColumns = []string{}
+		}
+		var toCreate []int
+		for i, row := range rows {
+			res := importRowResult{Line: row.Line, Title: row.Proposal.Title, Result: "ok"}
+			key := normalizeImportTitle(row.Proposal.Title)
+			switch {
+			case len(row.Errors) > 0:
+				res.Result = "invalid"
+				res.Errors = make(map[string]string, len(row.Errors))
+				for _, e := range row.Errors {
+					if prev, ok := res.Errors[e.Field]; ok {
+						res.Errors[e.Field] = prev + "; " + e.Message
+					} else {
+						res.Errors[e.Field] = e.Message
+					}
+				}
+				report.Invalid++
+			case seen[key]:
+				res.Result = "duplicate"
+				report.Duplicates++
+			default:
+				toCreate = append(toCreate, i)
+			}
+			if key != "" {
+				seen[key] = true
+			}
+			report.Rows[i] = res
+		}
+
+		if report.Invalid > 0 && !dryRun {
+			report.Error = fmt.Sprintf("%d rows have errors; nothing was imported", report.Invalid)
+			report.Code = ErrCodeValidation
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			encodeResponse(w, r, report)
+			return
+		}
+		if dryRun {
+			encodeResponse(w, r, report)
+			return
+		}
+
+		err = cfg.DBCtx(r).Transaction(func(tx *gorm.DB) error {
+			for _, i := range toCreate {
+				p := &rows[i].Proposal
+				p.EventID = event.ID
+				p.Status = models.ProposalStatusSubmitted
+				p.Imported = true
+				p.Source = models.ProposalSourceImport
+				if err := tx.Create(p).Error; err != nil {
+					return err
+				}
+				report.Rows[i].ProposalID = p.ID
+			}
+			return nil
+		})
+		if err != nil {
+			cfg.Logger.Error("failed to import proposals", "error", err, "event_id", event.ID)
+			encodeAPIError(w, r, "Failed to import proposals", http.StatusInternalServerError)
+			return
+		}
+		report.Imported = len(toCreate)
+
+		cfg.Logger.Info("proposals imported",
+			"event_id", event.ID,
+			"imported", report.Imported,
+			"duplicates", report.Duplicates,
+			"actor_id", user.ID,
+		)
+
+		encodeResponse(w, r, report)
+	}
+}
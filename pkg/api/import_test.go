@@ -0,0 +1,96 @@
+package api
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/sreday/cfp.ninja/pkg/models"
+)
+
+func TestImportColumn(t *testing.T) {
+	tests := []struct {
+		header  string
+		field   string
+		speaker int
+		ok      bool
+	}{
+		{"Title", "title", 0, true},
+		{"Description", "abstract", 0, true},
+		{"Session format", "format", 0, true},
+		{"Owner Email", "speaker_email", 0, true},
+		{"speaker_linkedin", "speaker_linkedin", 0, true},
+		{"speaker2_name", "speaker_name", 1, true},
+		{"Speaker 3 Email", "speaker_email", 2, true},
+		{"speaker2_notes", "", 0, false},
+		{"Track", "", 0, false},
+	}
+	for _, tt := range tests {
+		field, speaker, ok := importColumn(tt.header)
+		if field != tt.field || speaker != tt.speaker || ok != tt.ok {
+			t.Errorf("importColumn(%q) = %q, %d, %v; want %q, %d, %v", tt.header, field, speaker, ok, tt.field, tt.speaker, tt.ok)
+		}
+	}
+}
+
+func TestParseImportCSV(t *testing.T) {
+	csv := "\ufeffTitle,Description,Session format,Duration,Owner,Owner Email,Company,Tagline,LinkedIn,Speaker 2 Name,Speaker 2 Email,Speaker 2 Company,Speaker 2 Job Title,Speaker 2 LinkedIn,Track\n" +
+		"Scaling Go,How we scaled.,Workshop,90,Jane Doe,jane@example.com,Acme,SRE,https://linkedin.com/in/jane,John Roe,john@example.com,Acme,Dev,https://linkedin.com/in/john,Main\n" +
+		",,,,,,,,,,,,,,\n" +
+		"No Abstract,,keynote,soon,Jane Doe,not-an-email,Acme,SRE,https://linkedin.com/in/jane,,,,,,\n"
+
+	rows, ignored, err := parseImportCSV(strings.NewReader(csv))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(ignored) != 1 || ignored[0] != "Track" {
+		t.Errorf("expected Track to be ignored, got %v", ignored)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("expected 2 rows (blank row skipped), got %d", len(rows))
+	}
+
+	ok := rows[0]
+	if len(ok.Errors) != 0 {
+		t.Fatalf("expected first row to be valid, got %+v", ok.Errors)
+	}
+	if ok.Line != 2 || ok.Proposal.Format != models.FormatWorkshop || ok.Proposal.Duration != 90 {
+		t.Errorf("unexpected first row: line %d, %+v", ok.Line, ok.Proposal)
+	}
+	speakers, _ := ok.Proposal.GetSpeakers()
+	if len(speakers) != 2 || !speakers[0].Primary || speakers[1].Primary || speakers[1].Email != "john@example.com" {
+		t.Errorf("unexpected speakers: %+v", speakers)
+	}
+
+	bad := rows[1]
+	if bad.Line != 4 {
+		t.Errorf("expected line 4, got %d", bad.Line)
+	}
+	fields := make(map[string]bool)
+	for _, e := range bad.Errors {
+		fields[e.Field] = true
+	}
+	for _, want := range []string{"abstract", "format", "duration", "speakers[0].email"} {
+		if !fields[want] {
+			t.Errorf("expected error for %s, got %+v", want, bad.Errors)
+		}
+	}
+}
+
+func TestParseImportCSV_UnusableFiles(t *testing.T) {
+	for name, csv := range map[string]string{
+		"empty":      "",
+		"no title":   "Abstract,Speaker Name\nx,y\n",
+		"no rows":    "Title,Abstract\n",
+		"bad quotes": "Title,Abstract\n\"unterminated,x\n",
+	} {
+		if _, _, err := parseImportCSV(strings.NewReader(csv)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestNormalizeImportTitle(t *testing.T) {
+	if normalizeImportTitle("  Scaling   GO ") != normalizeImportTitle("scaling go") {
+		t.Error("expected titles differing only in case and spacing to match")
+	}
+}
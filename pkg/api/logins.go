@@ -0,0 +1,142 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/sreday/cfp.ninja/pkg/config"
+	"github.com/sreday/cfp.ninja/pkg/countries"
+	"github.com/sreday/cfp.ninja/pkg/email"
+	"github.com/sreday/cfp.ninja/pkg/models"
+)
+
+// loginCountryWindow is how recently a country must have been seen on an
+// account for a login from it not to trigger an alert
+const loginCountryWindow = 90 * 24 * time.Hour
+
+// MaxLoginEventsPage caps how many logins GET /api/v0/me/logins returns
+const MaxLoginEventsPage = 100
+
+// loginProviderNames are the display names of the OAuth providers
+var loginProviderNames = map[string]string{
+	"github": "GitHub",
+	"google": "Google",
+}
+
+// recordLogin stores a successful sign-in when a token is issued. The country
+// is looked up in the background, and the user is emailed when their account
+// has not been used from it recently. Failures are logged and never block the
+// sign-in.
+func recordLogin(cfg *config.Config, r *http.Request, user *models.User, provider string) {
+	trusted := make(map[string]bool, len(cfg.TrustedProxies))
+	for _, p := range cfg.TrustedProxies {
+		trusted[p] = true
+	}
+	userAgent := r.UserAgent()
+	if len(userAgent) > 512 {
+		userAgent = userAgent[:512]
+	}
+
+	login := models.LoginEvent{
+		UserID:    user.ID,
+		Provider:  provider,
+		IP:        clientIP(r, trusted),
+		UserAgent: userAgent,
+		CreatedAt: cfg.Now(),
+	}
+	if err := cfg.DBCtx(r).Create(&login).Error; err != nil {
+		cfg.Logger.Error("failed to record login", "user_id", user.ID, "provider", provider, "error", err)
+		return
+	}
+	cfg.Logger.Info("user logged in",
+		"user_id", user.ID,
+		"provider", provider,
+		"ip", login.IP,
+		"request_id", GetRequestID(r.Context()),
+	)
+
+	if cfg.GeoIP == nil {
+		return
+	}
+	u := *user
+	SafeGo(cfg, func() {
+		checkLoginCountry(cfg, &u, &login)
+	})
+}
+
+// checkLoginCountry resolves the country of a login and emails the user when
+// it was not seen on their account within loginCountryWindow. The first login
+// with a known country only sets the baseline.
+func checkLoginCountry(cfg *config.Config, user *models.User, login *models.LoginEvent) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	country, err := cfg.GeoIP.Country(ctx, login.IP)
+	if err != nil {
+		cfg.Logger.Warn("failed to look up login country", "user_id", user.ID, "error", err)
+		return
+	}
+	if country == "" {
+		return
+	}
+	login.Country = country
+	if err := cfg.DB.Model(login).Update("country", country).Error; err != nil {
+		cfg.Logger.Error("failed to store login country", "user_id", user.ID, "error", err)
+		return
+	}
+
+	seen, known, err := models.LoginCountrySeen(cfg.DB, user.ID, country, login.CreatedAt.Add(-loginCountryWindow), login.ID)
+	if err != nil {
+		cfg.Logger.Error("failed to check login countries", "user_id", user.ID, "error", err)
+		return
+	}
+	if seen || !known {
+		return
+	}
+
+	cfg.Logger.Warn("login from new country", "user_id", user.ID, "country", country, "ip", login.IP)
+	countryName := countries.Name(country)
+	if countryName == "" {
+		countryName = country
+	}
+	providerName := loginProviderNames[login.Provider]
+	if providerName == "" {
+		providerName = login.Provider
+	}
+	ncfg := &email.NotifyConfig{Sender: cfg.EmailSender, From: cfg.EmailFrom, BaseURL: cfg.BaseURL, Logger: cfg.Logger}
+	if err := email.SendNewLoginCountryNotification(ncfg, user, login, countryName, providerName); err != nil {
+		cfg.Logger.Error("failed to send new login country email", "user_id", user.ID, "error", err)
+	}
+}
+
+// GetMyLoginsHandler returns the authenticated user's recent sign-ins, newest
+// first. ?limit= sets how many (default 20, at most 100).
+// GET /api/v0/me/logins
+func GetMyLoginsHandler(cfg *config.Config) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user := GetUserFromContext(r.Context())
+		if user == nil {
+			encodeAPIError(w, r, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		if limit < 1 {
+			limit = DefaultPageSize
+		}
+		if limit > MaxLoginEventsPage {
+			limit = MaxLoginEventsPage
+		}
+
+		logins, err := models.GetLoginEvents(cfg.DBCtx(r), user.ID, limit)
+		if err != nil {
+			cfg.Logger.Error("failed to load logins", "user_id", user.ID, "error", err)
+			encodeAPIError(w, r, "Failed to load logins", http.StatusInternalServerError)
+			return
+		}
+
+		encodeResponse(w, r, logins)
+	}
+}
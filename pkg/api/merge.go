@@ -0,0 +1,222 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/sreday/cfp.ninja/pkg/config"
+	"github.com/sreday/cfp.ninja/pkg/models"
+)
+
+// errMergeConflict is returned by mergeEvents when the events cannot be merged
+// as they are; its message is shown to the admin
+type errMergeConflict struct{ msg string }
+
+func (e errMergeConflict) Error() string { return e.msg }
+
+// eventMergeResult is what a merge did
+type eventMergeResult struct {
+	SourceID           uint   `json:"source_id"`
+	SourceSlug         string `json:"source_slug"` // Now redirects to the target
+	TargetID           uint   `json:"target_id"`
+	TargetSlug         string `json:"target_slug"`
+	ProposalsMoved     int64  `json:"proposals_moved"`
+	EmailsMoved        int64  `json:"emails_moved"`
+	OrganizersAdded    []uint `json:"organizers_added"`
+	PaymentTransferred bool   `json:"payment_transferred"`
+}
+
+// checkMergePayments refuses merges that would mix up payments: two paid
+// listings, or submission fees paid under different terms than the target's
+func checkMergePayments(source, target *models.Event, paidProposals int64) error {
+	if source.IsPaid && target.IsPaid {
+		return errMergeConflict{"Both events have a paid listing; refund one of them before merging"}
+	}
+	if paidProposals > 0 && (source.CFPRequiresPayment != target.CFPRequiresPayment ||
+		source.CFPSubmissionFee != target.CFPSubmissionFee ||
+		source.CFPSubmissionFeeCurrency != target.CFPSubmissionFeeCurrency) {
+		return errMergeConflict{fmt.Sprintf("%d proposals paid a submission fee to the source event, which charges a different fee than the target", paidProposals)}
+	}
+	return nil
+}
+
+// mergeEvents moves everything attached to the source event to the target and
+// soft-deletes the source, in a single transaction
+func mergeEvents(db *gorm.DB, sourceID, targetID uint) (*eventMergeResult, error) {
+	result := &eventMergeResult{OrganizersAdded: []uint{}}
+	err := db.Transaction(func(tx *gorm.DB) error {
+		// Lock both events in ID order so that concurrent merges cannot deadlock
+		var locked []models.Event
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id IN ?", []uint{sourceID, targetID}).Order("id").
+			Find(&locked).Error; err != nil {
+			return err
+		}
+		if len(locked) != 2 {
+			return gorm.ErrRecordNotFound
+		}
+
+		var source, target models.Event
+		if err := tx.Preload("Organizers").First(&source, sourceID).Error; err != nil {
+			return err
+		}
+		if err := tx.Preload("Organizers").First(&target, targetID).Error; err != nil {
+			return err
+		}
+
+		var paidProposals int64
+		if err := tx.Model(&models.Proposal{}).
+			Where("event_id = ? AND is_paid = ?", source.ID, true).
+			Count(&paidProposals).Error; err != nil {
+			return err
+		}
+		if err := checkMergePayments(&source, &target, paidProposals); err != nil {
+			return err
+		}
+
+		// Deleted proposals move too, so the source is left with nothing
+		moved := tx.Unscoped().Model(&models.Proposal{}).Where("event_id = ?", source.ID).Update("event_id", target.ID)
+		if moved.Error != nil {
+			return moved.Error
+		}
+		result.ProposalsMoved = moved.RowsAffected
+
+		emails := tx.Model(&models.SpeakerEmailSend{}).Where("event_id = ?", source.ID).Update("event_id", target.ID)
+		if emails.Error != nil {
+			return emails.Error
+		}
+		result.EmailsMoved = emails.RowsAffected
+
+		// The source's creator and organizers keep access as organizers
+		var candidates []uint
+		if source.CreatedByID != nil {
+			candidates = append(candidates, *source.CreatedByID)
+		}
+		for _, org := range source.Organizers {
+			candidates = append(candidates, org.ID)
+		}
+		seen := make(map[uint]bool)
+		var addIDs []uint
+		for _, id := range candidates {
+			if seen[id] || target.IsOrganizer(id) {
+				continue
+			}
+			seen[id] = true
+			addIDs = append(addIDs, id)
+		}
+		if len(addIDs) > 0 {
+			var added []models.User
+			if err := tx.Where("id IN ?", addIDs).Find(&added).Error; err != nil {
+				return err
+			}
+			if err := tx.Model(&target).Association("Organizers").Append(&added); err != nil {
+				return err
+			}
+			for _, u := range added {
+				result.OrganizersAdded = append(result.OrganizersAdded, u.ID)
+			}
+		}
+		if err := tx.Model(&source).Association("Organizers").Clear(); err != nil {
+			return err
+		}
+
+		if source.IsPaid {
+			if err := tx.Model(&target).Updates(map[string]interface{}{
+				"is_paid":           true,
+				"stripe_payment_id": source.StripePaymentID,
+			}).Error; err != nil {
+				return err
+			}
+			result.PaymentTransferred = true
+		}
+
+		// Links to the source, including slugs it had itself inherited, now
+		// lead to the target
+		if err := tx.Model(&models.EventSlugHistory{}).Where("event_id = ?", source.ID).Update("event_id", target.ID).Error; err != nil {
+			return err
+		}
+		if err := tx.Create(&models.EventSlugHistory{Slug: source.Slug, EventID: target.ID}).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&source).Error; err != nil {
+			return err
+		}
+
+		result.SourceID, result.SourceSlug = source.ID, source.Slug
+		result.TargetID, result.TargetSlug = target.ID, target.Slug
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// MergeEventsHandler merges a duplicate event into another (admin only). The
+// source event's proposals, organizers, sent speaker emails and listing
+// payment move to the target, its slug redirects to the target, and it is
+// soft-deleted. Events whose payments cannot be combined are refused.
+// POST /api/v0/admin/events/merge
+func MergeEventsHandler(cfg *config.Config) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		admin := GetUserFromContext(r.Context())
+
+		var req struct {
+			SourceID uint `json:"source_id"`
+			TargetID uint `json:"target_id"`
+		}
+		if !decodeJSONBody(w, r, MaxSmallBodySize, &req) {
+			return
+		}
+
+		var errs validationErrors
+		if req.SourceID == 0 {
+			errs.add("source_id", "source_id is required")
+		}
+		if req.TargetID == 0 {
+			errs.add("target_id", "target_id is required")
+		} else if req.TargetID == req.SourceID {
+			errs.add("target_id", "An event cannot be merged into itself")
+		}
+		if len(errs) > 0 {
+			encodeValidationErrors(w, r, errs)
+			return
+		}
+
+		result, err := mergeEvents(cfg.DBCtx(r), req.SourceID, req.TargetID)
+		if err != nil {
+			var conflict errMergeConflict
+			switch {
+			case errors.Is(err, gorm.ErrRecordNotFound):
+				encodeAPIError(w, r, "Event not found", http.StatusNotFound)
+			case errors.As(err, &conflict):
+				cfg.Logger.Warn("event merge refused", "source_id", req.SourceID, "target_id", req.TargetID, "reason", conflict.msg, "admin_id", admin.ID)
+				encodeAPIErrorCode(w, r, ErrCodeConflict, conflict.msg, http.StatusConflict)
+			default:
+				cfg.Logger.Error("failed to merge events", "source_id", req.SourceID, "target_id", req.TargetID, "error", err)
+				encodeAPIError(w, r, "Failed to merge events", http.StatusInternalServerError)
+			}
+			return
+		}
+
+		cfg.Logger.Info("events merged",
+			"source_id", result.SourceID,
+			"source_slug", result.SourceSlug,
+			"target_id", result.TargetID,
+			"target_slug", result.TargetSlug,
+			"proposals_moved", result.ProposalsMoved,
+			"emails_moved", result.EmailsMoved,
+			"organizers_added", result.OrganizersAdded,
+			"payment_transferred", result.PaymentTransferred,
+			"admin_id", admin.ID,
+			"admin_email", admin.Email,
+			"request_id", GetRequestID(r.Context()),
+		)
+
+		encodeResponse(w, r, result)
+	}
+}
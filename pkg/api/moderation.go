@@ -0,0 +1,198 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"gorm.io/datatypes"
+
+	"github.com/sreday/cfp.ninja/pkg/config"
+	"github.com/sreday/cfp.ninja/pkg/email"
+	"github.com/sreday/cfp.ninja/pkg/models"
+	"github.com/sreday/cfp.ninja/pkg/spam"
+)
+
+// maxDuplicateCandidates caps how many existing event names are compared
+// against a new event's name
+const maxDuplicateCandidates = 200
+
+// moderateNewEvent scores a new event for spam, records the score on it, and
+// holds it in pending_review when the score reaches the configured threshold.
+// Admins and trusted users are scored but never held.
+func moderateNewEvent(cfg *config.Config, r *http.Request, user *models.User, event *models.Event, honeypotFilled bool) spam.Result {
+	in := spam.Input{
+		Name:           event.Name,
+		Description:    event.Description,
+		CreatorEmail:   user.Email,
+		AccountAge:     cfg.Now().Sub(user.CreatedAt),
+		HoneypotFilled: honeypotFilled,
+	}
+
+	// Compare against other creators' events sharing the name's longest word
+	longest := ""
+	for _, t := range spam.NameTokens(event.Name) {
+		if len(t) > len(longest) {
+			longest = t
+		}
+	}
+	if longest != "" {
+		if err := cfg.DBCtx(r).Model(&models.Event{}).
+			Where("LOWER(name) LIKE ? AND (created_by_id IS NULL OR created_by_id != ?)", "%"+longest+"%", user.ID).
+			Order("id DESC").Limit(maxDuplicateCandidates).
+			Pluck("name", &in.ExistingNames).Error; err != nil {
+			cfg.Logger.Warn("failed to load event names for spam check", "error", err)
+		}
+	}
+
+	result := spam.Score(in)
+	event.SpamScore = result.Score
+	if signals, err := json.Marshal(result.Signals); err == nil {
+		event.SpamSignals = datatypes.JSON(signals)
+	}
+
+	event.ModerationStatus = models.ModerationApproved
+	exempt := user.IsTrusted || cfg.IsAdmin(user.Email)
+	if cfg.SpamScoreThreshold > 0 && result.Score >= cfg.SpamScoreThreshold && !exempt {
+		event.ModerationStatus = models.ModerationPendingReview
+	}
+	return result
+}
+
+// notifyEventHeld logs a held event and emails the admins in the background
+func notifyEventHeld(cfg *config.Config, event models.Event, creator models.User, result spam.Result) {
+	reasons := make([]string, len(result.Signals))
+	for i, s := range result.Signals {
+		reasons[i] = fmt.Sprintf("%s (%d): %s", s.Name, s.Points, s.Detail)
+	}
+	cfg.Logger.Warn("event held for moderation",
+		"event_id", event.ID,
+		"slug", event.Slug,
+		"user_id", creator.ID,
+		"spam_score", result.Score,
+		"signals", reasons,
+	)
+
+	SafeGo(cfg, func() {
+		ncfg := &email.NotifyConfig{Sender: cfg.EmailSender, From: cfg.EmailFrom, BaseURL: cfg.BaseURL, Logger: cfg.Logger}
+		if err := email.SendEventHeldNotification(ncfg, &event, &creator, cfg.AdminEmails, result.Score, reasons); err != nil {
+			cfg.Logger.Error("failed to notify admins of held event", "event_id", event.ID, "error", err)
+		}
+	})
+}
+
+// moderationEvent is an event as shown to admins reviewing spam scores
+type moderationEvent struct {
+	ID               uint                    `json:"id"`
+	Name             string                  `json:"name"`
+	Slug             string                  `json:"slug"`
+	Description      string                  `json:"description"`
+	Website          string                  `json:"website"`
+	CreatedByID      *uint                   `json:"created_by_id"`
+	ModerationStatus models.ModerationStatus `json:"moderation_status"`
+	SpamScore        int                     `json:"spam_score"`
+	SpamSignals      []spam.Signal           `json:"spam_signals"`
+	CreatedAt        time.Time               `json:"created_at"`
+	UpdatedAt        time.Time               `json:"updated_at"`
+}
+
+func newModerationEvent(e models.Event) moderationEvent {
+	signals := []spam.Signal{}
+	if len(e.SpamSignals) > 0 {
+		_ = json.Unmarshal(e.SpamSignals, &signals)
+	}
+	return moderationEvent{
+		ID:               e.ID,
+		Name:             e.Name,
+		Slug:             e.Slug,
+		Description:      e.Description,
+		Website:          e.Website,
+		CreatedByID:      e.CreatedByID,
+		ModerationStatus: e.ModerationStatus,
+		SpamScore:        e.SpamScore,
+		SpamSignals:      signals,
+		CreatedAt:        e.CreatedAt,
+		UpdatedAt:        e.UpdatedAt,
+	}
+}
+
+// validModerationStatus reports whether s is a known moderation status
+func validModerationStatus(s models.ModerationStatus) bool {
+	return s == models.ModerationApproved || s == models.ModerationPendingReview || s == models.ModerationRejected
+}
+
+// ListModerationEventsHandler lists events by moderation status, pending_review
+// by default, with their spam scores (admin only)
+func ListModerationEventsHandler(cfg *config.Config) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		status := models.ModerationStatus(r.URL.Query().Get("status"))
+		if status == "" {
+			status = models.ModerationPendingReview
+		}
+		if !validModerationStatus(status) {
+			var errs validationErrors
+			errs.add("status", "status must be one of: approved, pending_review, rejected")
+			encodeValidationErrors(w, r, errs)
+			return
+		}
+
+		var events []models.Event
+		if err := cfg.DBCtx(r).Where("moderation_status = ?", status).
+			Order("spam_score DESC, id DESC").Limit(MaxProposalsPerPage).
+			Find(&events).Error; err != nil {
+			cfg.Logger.Error("failed to query events for moderation", "error", err)
+			encodeAPIError(w, r, "Failed to load events", http.StatusInternalServerError)
+			return
+		}
+
+		result := make([]moderationEvent, len(events))
+		for i, e := range events {
+			result[i] = newModerationEvent(e)
+		}
+		encodeResponse(w, r, result)
+	}
+}
+
+// UpdateEventModerationHandler approves or rejects an event (admin only)
+func UpdateEventModerationHandler(cfg *config.Config) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		admin := GetUserFromContext(r.Context())
+
+		id, err := strconv.ParseUint(r.PathValue("id"), 10, 32)
+		if err != nil {
+			encodeAPIError(w, r, "Invalid event ID", http.StatusBadRequest)
+			return
+		}
+
+		var req struct {
+			Status models.ModerationStatus `json:"status"`
+		}
+		if !decodeJSONBody(w, r, MaxSmallBodySize, &req) {
+			return
+		}
+		if !validModerationStatus(req.Status) {
+			var errs validationErrors
+			errs.add("status", "status must be one of: approved, pending_review, rejected")
+			encodeValidationErrors(w, r, errs)
+			return
+		}
+
+		var event models.Event
+		if err := cfg.DBCtx(r).First(&event, id).Error; err != nil {
+			encodeAPIError(w, r, "Event not found", http.StatusNotFound)
+			return
+		}
+
+		if err := cfg.DBCtx(r).Model(&event).Update("moderation_status", req.Status).Error; err != nil {
+			cfg.Logger.Error("failed to update event moderation", "event_id", event.ID, "error", err)
+			encodeAPIError(w, r, "Failed to update event", http.StatusInternalServerError)
+			return
+		}
+
+		cfg.Logger.Info("event moderation updated", "event_id", event.ID, "moderation_status", req.Status, "admin_id", admin.ID)
+
+		encodeResponse(w, r, newModerationEvent(event))
+	}
+}
@@ -0,0 +1,132 @@
+package api
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Response media types
+const (
+	mediaJSON = "application/json"
+	mediaYAML = "application/yaml"
+	mediaCSV  = "text/csv"
+)
+
+// mediaAliases maps alternative names clients send to the canonical media type
+var mediaAliases = map[string]string{
+	"application/x-yaml": mediaYAML,
+	"text/yaml":          mediaYAML,
+	"text/x-yaml":        mediaYAML,
+}
+
+// csvResponse pairs a response with its CSV rendering. Handlers opt in to
+// text/csv by passing withCSV(data, ...) to encodeResponse.
+type csvResponse struct {
+	data     interface{}
+	writeCSV func(w *csv.Writer)
+}
+
+// withCSV offers a CSV representation of data alongside JSON and YAML
+func withCSV(data interface{}, writeCSV func(w *csv.Writer)) interface{} {
+	return csvResponse{data: data, writeCSV: writeCSV}
+}
+
+// negotiateMedia picks the offer best matching the Accept header, honoring
+// q-values and wildcards. Ties go to the earlier offer; when nothing matches,
+// the first offer is used rather than failing with 406.
+func negotiateMedia(r *http.Request, offers ...string) string {
+	accept := r.Header.Get("Accept")
+	if accept == "" {
+		return offers[0]
+	}
+
+	best, bestQ := offers[0], 0.0
+	for _, entry := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(entry))
+		if err != nil {
+			continue
+		}
+		if alias, ok := mediaAliases[mediaType]; ok {
+			mediaType = alias
+		}
+		q := 1.0
+		if v, ok := params["q"]; ok {
+			if parsed, err := strconv.ParseFloat(v, 64); err == nil {
+				q = parsed
+			}
+		}
+		if q <= bestQ {
+			continue
+		}
+		for _, offer := range offers {
+			if mediaMatches(mediaType, offer) {
+				best, bestQ = offer, q
+				break
+			}
+		}
+	}
+	return best
+}
+
+// mediaMatches reports whether an Accept media range (e.g. "text/*") covers the offer
+func mediaMatches(mediaRange, offer string) bool {
+	if mediaRange == "*/*" || mediaRange == offer {
+		return true
+	}
+	if prefix, ok := strings.CutSuffix(mediaRange, "/*"); ok {
+		return strings.HasPrefix(offer, prefix+"/")
+	}
+	return false
+}
+
+// encodeYAML writes data as YAML. It goes through JSON first so YAML keys,
+// field order and omitted fields match the JSON representation exactly.
+func encodeYAML(w http.ResponseWriter, data interface{}) error {
+	b, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(b, &doc); err != nil {
+		return err
+	}
+	// JSON parses as flow-style YAML; reset to block style for readability
+	clearYAMLStyle(&doc)
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return err
+	}
+	if err := enc.Close(); err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", mediaYAML)
+	_, err = w.Write(buf.Bytes())
+	return err
+}
+
+func clearYAMLStyle(n *yaml.Node) {
+	n.Style = 0
+	for _, c := range n.Content {
+		clearYAMLStyle(c)
+	}
+}
+
+// encodeCSV writes a CSV response
+func encodeCSV(w http.ResponseWriter, writeCSV func(w *csv.Writer)) error {
+	w.Header().Set("Content-Type", mediaCSV+"; charset=utf-8")
+	cw := csv.NewWriter(w)
+	writeCSV(cw)
+	cw.Flush()
+	return cw.Error()
+}
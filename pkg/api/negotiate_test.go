@@ -0,0 +1,244 @@
+package api
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/sreday/cfp.ninja/pkg/models"
+)
+
+func TestNegotiateMedia(t *testing.T) {
+	offers := []string{mediaJSON, mediaYAML, mediaCSV}
+	tests := []struct {
+		accept string
+		want   string
+	}{
+		{"", mediaJSON},
+		{"*/*", mediaJSON},
+		{"application/json", mediaJSON},
+		{"application/json; version=2", mediaJSON},
+		{"application/yaml", mediaYAML},
+		{"application/x-yaml", mediaYAML},
+		{"text/yaml", mediaYAML},
+		{"text/csv", mediaCSV},
+		{"text/*", mediaCSV},
+		{"text/html", mediaJSON},
+		{"application/yaml;q=0.5, text/csv", mediaCSV},
+		{"application/json;q=0.1, application/yaml;q=0.9", mediaYAML},
+		{"text/csv;q=0.5, */*;q=0.1", mediaCSV},
+		{"not a media type", mediaJSON},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.accept, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/", nil)
+			if tt.accept != "" {
+				r.Header.Set("Accept", tt.accept)
+			}
+			if got := negotiateMedia(r, offers...); got != tt.want {
+				t.Errorf("expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestNegotiateMedia_CSVNotOffered(t *testing.T) {
+	r := httptest.NewRequest("GET", "/", nil)
+	r.Header.Set("Accept", "text/csv")
+	if got := negotiateMedia(r, mediaJSON, mediaYAML); got != mediaJSON {
+		t.Errorf("expected fallback to %s, got %s", mediaJSON, got)
+	}
+}
+
+func TestEncodeResponse_YAML(t *testing.T) {
+	rating := 4
+	proposal := models.Proposal{
+		Title:    "Go Performance",
+		Format:   models.FormatTalk,
+		Duration: 45,
+		Tags:     "true",
+		Rating:   &rating,
+	}
+
+	r := httptest.NewRequest("GET", "/", nil)
+	r.Header.Set("Accept", "application/yaml")
+	w := httptest.NewRecorder()
+	encodeResponse(w, r, proposal)
+
+	if ct := w.Header().Get("Content-Type"); ct != mediaYAML {
+		t.Errorf("expected Content-Type %s, got %s", mediaYAML, ct)
+	}
+
+	var got map[string]interface{}
+	if err := yaml.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatalf("response is not valid YAML: %v\n%s", err, w.Body.String())
+	}
+	if got["title"] != "Go Performance" {
+		t.Errorf("expected title %q, got %v", "Go Performance", got["title"])
+	}
+	if got["duration"] != 45 {
+		t.Errorf("expected duration 45, got %v", got["duration"])
+	}
+	// Strings that look like other YAML types must stay strings
+	if got["tags"] != "true" {
+		t.Errorf("expected tags %q, got %#v", "true", got["tags"])
+	}
+	// Keys and omitted fields follow the JSON representation
+	if _, ok := got["speaker_notes"]; ok {
+		t.Error("expected omitempty field speaker_notes to be omitted")
+	}
+	if strings.Contains(w.Body.String(), "{") {
+		t.Errorf("expected block-style YAML, got:\n%s", w.Body.String())
+	}
+}
+
+func TestEncodeResponse_JSONDefault(t *testing.T) {
+	for _, accept := range []string{"", "text/html", "text/csv"} {
+		r := httptest.NewRequest("GET", "/", nil)
+		if accept != "" {
+			r.Header.Set("Accept", accept)
+		}
+		w := httptest.NewRecorder()
+		encodeResponse(w, r, map[string]string{"status": "ok"})
+
+		if ct := w.Header().Get("Content-Type"); ct != mediaJSON {
+			t.Errorf("Accept %q: expected Content-Type %s, got %s", accept, mediaJSON, ct)
+		}
+		var got map[string]string
+		if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+			t.Errorf("Accept %q: response is not valid JSON: %v", accept, err)
+		}
+		if vary := w.Header().Get("Vary"); vary != "Accept" {
+			t.Errorf("expected Vary: Accept, got %q", vary)
+		}
+	}
+}
+
+func TestEncodeResponse_CSV(t *testing.T) {
+	events := []models.Event{{
+		Name:      "=cmd|' /C calc'!A0",
+		Slug:      "gophercon",
+		Country:   "US",
+		StartDate: time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC),
+		CFPStatus: models.CFPStatusOpen,
+	}}
+	resp := map[string]interface{}{"data": events}
+
+	r := httptest.NewRequest("GET", "/", nil)
+	r.Header.Set("Accept", "text/csv")
+	w := httptest.NewRecorder()
+	encodeResponse(w, r, withCSV(resp, func(cw *csv.Writer) { writeEventsCSV(cw, events) }))
+
+	if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, mediaCSV) {
+		t.Errorf("expected Content-Type %s, got %s", mediaCSV, ct)
+	}
+	records, err := csv.NewReader(w.Body).ReadAll()
+	if err != nil {
+		t.Fatalf("response is not valid CSV: %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("expected header and 1 row, got %d records", len(records))
+	}
+	if records[0][0] != "id" || records[0][2] != "slug" {
+		t.Errorf("unexpected header: %v", records[0])
+	}
+	row := records[1]
+	if !strings.HasPrefix(row[1], "'") {
+		t.Errorf("expected formula in name to be escaped, got %q", row[1])
+	}
+	if row[5] != "2026-06-01T00:00:00Z" {
+		t.Errorf("expected start_date 2026-06-01T00:00:00Z, got %q", row[5])
+	}
+	if row[6] != "" {
+		t.Errorf("expected empty end_date for unset time, got %q", row[6])
+	}
+
+	// The same response still defaults to JSON
+	r = httptest.NewRequest("GET", "/", nil)
+	w = httptest.NewRecorder()
+	encodeResponse(w, r, withCSV(resp, func(cw *csv.Writer) { writeEventsCSV(cw, events) }))
+	var got map[string]interface{}
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatalf("expected JSON by default: %v", err)
+	}
+	if _, ok := got["data"]; !ok {
+		t.Error("expected data key in JSON response")
+	}
+}
+
+func TestWriteProposalsCSV(t *testing.T) {
+	rating := 3
+	accepted := time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)
+	speakers, _ := json.Marshal([]models.Speaker{{Name: "Ada", Email: "ada@example.com"}, {Name: "Bob", Email: "bob@example.com"}})
+	proposals := []models.Proposal{{Title: "Channels", Duration: 30, Status: models.ProposalStatusAccepted, Rating: &rating, Speakers: speakers, OrganizerNotes: "Accept", ReviewerNotes: "=strong", CoCAcceptedAt: &accepted}}
+
+	var b strings.Builder
+	cw := csv.NewWriter(&b)
+	writeProposalsCSV(cw, proposals)
+	cw.Flush()
+
+	records, err := csv.NewReader(strings.NewReader(b.String())).ReadAll()
+	if err != nil {
+		t.Fatalf("invalid CSV: %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("expected 2 records, got %d", len(records))
+	}
+	header := records[0]
+	row := map[string]string{}
+	for i, h := range header {
+		row[h] = records[1][i]
+	}
+	if row["speakers"] != "Ada, Bob" {
+		t.Errorf("expected speakers %q, got %q", "Ada, Bob", row["speakers"])
+	}
+	if row["rating"] != "3" {
+		t.Errorf("expected rating 3, got %q", row["rating"])
+	}
+	if row["status"] != "accepted" {
+		t.Errorf("expected status accepted, got %q", row["status"])
+	}
+	if row["organizer_notes"] != "Accept" {
+		t.Errorf("expected organizer_notes Accept, got %q", row["organizer_notes"])
+	}
+	if row["reviewer_notes"] != "'=strong" {
+		t.Errorf("expected sanitized reviewer_notes, got %q", row["reviewer_notes"])
+	}
+	if row["coc_accepted_at"] != "2026-03-01T09:30:00Z" {
+		t.Errorf("expected coc_accepted_at, got %q", row["coc_accepted_at"])
+	}
+}
+
+func TestWriteSpeakersCSV(t *testing.T) {
+	first, _ := json.Marshal([]models.Speaker{
+		{Name: "Ada", Email: "Ada@Example.com", Company: "=HYPERLINK()", JobTitle: "SRE"},
+		{Name: "Bob", Email: "bob@example.com"},
+	})
+	second, _ := json.Marshal([]models.Speaker{{Name: "Ada L.", Email: "ada@example.com"}})
+	proposals := []models.Proposal{{Title: "Channels", Speakers: first}, {Title: "Generics", Speakers: second}}
+
+	var b strings.Builder
+	cw := csv.NewWriter(&b)
+	writeSpeakersCSV(cw, proposals, map[string]string{"ada@example.com": "https://example.com/ada.png"})
+	cw.Flush()
+
+	records, err := csv.NewReader(strings.NewReader(b.String())).ReadAll()
+	if err != nil {
+		t.Fatalf("invalid CSV: %v", err)
+	}
+	want := [][]string{
+		{"name", "email", "company", "job_title", "photo_url", "talks"},
+		{"Ada", "Ada@Example.com", "'=HYPERLINK()", "SRE", "https://example.com/ada.png", "Channels; Generics"},
+		{"Bob", "bob@example.com", "", "", "", "Channels"},
+	}
+	if !reflect.DeepEqual(records, want) {
+		t.Errorf("got %q, want %q", records, want)
+	}
+}
@@ -0,0 +1,182 @@
+package api
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/url"
+
+	"github.com/sreday/cfp.ninja/pkg/config"
+	"github.com/sreday/cfp.ninja/pkg/email"
+	"github.com/sreday/cfp.ninja/pkg/models"
+	"github.com/sreday/cfp.ninja/pkg/tasks"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// generateUnsubscribeToken returns a random token for a notify-me
+// registration's unsubscribe link. It is stored as is, since the email that
+// carries it is sent later.
+func generateUnsubscribeToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// notifyMeEvent loads the listed, non-draft event named by the slug path
+// value, writing a 404 when there is none
+func notifyMeEvent(cfg *config.Config, w http.ResponseWriter, r *http.Request) (*models.Event, bool) {
+	var event models.Event
+	err := cfg.DBCtx(r).Where("slug = ? AND moderation_status = ? AND cfp_status != ?",
+		r.PathValue("slug"), models.ModerationApproved, models.CFPStatusDraft).First(&event).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		encodeAPIError(w, r, "Event not found", http.StatusNotFound)
+		return nil, false
+	}
+	if err != nil {
+		cfg.Logger.Error("failed to query event for notify-me", "error", err, "slug", r.PathValue("slug"))
+		encodeAPIError(w, r, "Failed to load event", http.StatusInternalServerError)
+		return nil, false
+	}
+	return &event, true
+}
+
+// NotifyMeHandler registers the user to be emailed once when the event's CFP
+// opens. Registering again is a no-op that returns the existing registration.
+// POST /api/v0/e/{slug}/notify-me
+func NotifyMeHandler(cfg *config.Config) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user := GetUserFromContext(r.Context())
+		if user == nil {
+			encodeAPIError(w, r, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		event, ok := notifyMeEvent(cfg, w, r)
+		if !ok {
+			return
+		}
+		if event.IsCFPOpenAt(cfg.Now(), 0) {
+			encodeAPIError(w, r, "The CFP is already open", http.StatusConflict)
+			return
+		}
+
+		token, err := generateUnsubscribeToken()
+		if err != nil {
+			cfg.Logger.Error("failed to generate unsubscribe token", "error", err)
+			encodeAPIError(w, r, "Failed to register", http.StatusInternalServerError)
+			return
+		}
+		interest := models.CFPInterest{EventID: event.ID, UserID: user.ID, UnsubscribeToken: token}
+		result := cfg.DBCtx(r).Clauses(clause.OnConflict{DoNothing: true}).Create(&interest)
+		if result.Error != nil {
+			cfg.Logger.Error("failed to create cfp interest", "error", result.Error, "event_id", event.ID)
+			encodeAPIError(w, r, "Failed to register", http.StatusInternalServerError)
+			return
+		}
+
+		location := "/api/v0/e/" + url.PathEscape(event.Slug) + "/notify-me"
+		if result.RowsAffected == 0 {
+			if err := cfg.DBCtx(r).Where("event_id = ? AND user_id = ?", event.ID, user.ID).First(&interest).Error; err != nil {
+				cfg.Logger.Error("failed to load cfp interest", "error", err, "event_id", event.ID)
+				encodeAPIError(w, r, "Failed to register", http.StatusInternalServerError)
+				return
+			}
+			encodeResponse(w, r, interest)
+			return
+		}
+
+		cfg.Logger.Info("cfp interest registered",
+			"event_id", event.ID,
+			"actor_id", user.ID,
+		)
+		encodeCreated(w, r, location, interest)
+	}
+}
+
+// RemoveNotifyMeHandler removes the user's notify-me registration for the event
+// DELETE /api/v0/e/{slug}/notify-me
+func RemoveNotifyMeHandler(cfg *config.Config) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user := GetUserFromContext(r.Context())
+		if user == nil {
+			encodeAPIError(w, r, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		event, ok := notifyMeEvent(cfg, w, r)
+		if !ok {
+			return
+		}
+
+		result := cfg.DBCtx(r).Where("event_id = ? AND user_id = ?", event.ID, user.ID).Delete(&models.CFPInterest{})
+		if result.Error != nil {
+			cfg.Logger.Error("failed to delete cfp interest", "error", result.Error, "event_id", event.ID)
+			encodeAPIError(w, r, "Failed to unregister", http.StatusInternalServerError)
+			return
+		}
+		if result.RowsAffected == 0 {
+			encodeAPIError(w, r, "Not registered", http.StatusNotFound)
+			return
+		}
+
+		encodeResponse(w, r, map[string]string{"message": "Unregistered"})
+	}
+}
+
+// UnsubscribeNotifyMeHandler removes the notify-me registration with the
+// token from an email. The token comes in the query string, as mail clients
+// send it for one-click unsubscribe (RFC 8058), or in a JSON body. Unknown
+// tokens succeed too, so repeated clicks are harmless.
+// POST /api/v0/notify-me/unsubscribe
+func UnsubscribeNotifyMeHandler(cfg *config.Config) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		token := r.URL.Query().Get("token")
+		if token == "" {
+			var req struct {
+				Token string `json:"token"`
+			}
+			if !decodeJSONBody(w, r, MaxSmallBodySize, &req) {
+				return
+			}
+			token = req.Token
+		}
+		if token == "" || len(token) > 64 {
+			encodeAPIError(w, r, "Invalid token", http.StatusBadRequest)
+			return
+		}
+
+		result := cfg.DBCtx(r).Where("unsubscribe_token = ?", token).Delete(&models.CFPInterest{})
+		if result.Error != nil {
+			cfg.Logger.Error("failed to unsubscribe cfp interest", "error", result.Error)
+			encodeAPIError(w, r, "Failed to unsubscribe", http.StatusInternalServerError)
+			return
+		}
+		if result.RowsAffected > 0 {
+			cfg.Logger.Info("cfp interest unsubscribed")
+		}
+
+		encodeResponse(w, r, map[string]string{"message": "Unsubscribed"})
+	}
+}
+
+// notifyCFPOpened emails the event's notify-me registrations if its CFP now
+// accepts submissions (fire-and-forget). CFPs that open later, at
+// cfp_open_at, are picked up by tasks.StartCFPOpenNotifier.
+func notifyCFPOpened(cfg *config.Config, event models.Event) {
+	if cfg.EmailSender == nil {
+		return
+	}
+	SafeGo(cfg, func() {
+		ncfg := &email.NotifyConfig{
+			Sender:  cfg.EmailSender,
+			From:    cfg.EmailFrom,
+			BaseURL: cfg.BaseURL,
+			Logger:  cfg.Logger,
+		}
+		tasks.NotifyCFPOpened(cfg.DB, ncfg, &event, cfg.Now())
+	})
+}
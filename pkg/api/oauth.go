@@ -8,17 +8,21 @@ import (
 	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/github"
 	"golang.org/x/oauth2/google"
+	"gorm.io/gorm"
 	"github.com/sreday/cfp.ninja/pkg/config"
 	"github.com/sreday/cfp.ninja/pkg/models"
 )
@@ -50,30 +54,72 @@ type GitHubEmail struct {
 	Verified bool   `json:"verified"`
 }
 
-func getGoogleOAuthConfig(cfg *config.Config) *oauth2.Config {
+// errOAuthHostNotAllowed is returned when a login is requested through a host
+// that is not in OAUTH_REDIRECT_HOSTS
+var errOAuthHostNotAllowed = errors.New("logins are not allowed from this host")
+
+// oauthRedirectURL returns the OAuth redirect URL for the request. Without
+// OAUTH_REDIRECT_HOSTS it is the configured URL; otherwise the configured URL
+// with the request's host, which must be one of the allowed hosts.
+func oauthRedirectURL(cfg *config.Config, r *http.Request, configured string) (string, error) {
+	if len(cfg.OAuthRedirectHosts) == 0 {
+		return configured, nil
+	}
+	host := strings.ToLower(r.Host)
+	if !slices.Contains(cfg.OAuthRedirectHosts, host) {
+		return "", errOAuthHostNotAllowed
+	}
+	u, err := url.Parse(configured)
+	if err != nil || u.Host == "" {
+		return "", fmt.Errorf("invalid OAuth redirect URL %q", configured)
+	}
+	u.Host = host
+	return u.String(), nil
+}
+
+func getGoogleOAuthConfig(cfg *config.Config, r *http.Request) (*oauth2.Config, error) {
+	redirectURL, err := oauthRedirectURL(cfg, r, cfg.GoogleRedirectURL)
+	if err != nil {
+		return nil, err
+	}
 	return &oauth2.Config{
 		ClientID:     cfg.GoogleClientID,
 		ClientSecret: cfg.GoogleClientSecret,
-		RedirectURL:  cfg.GoogleRedirectURL,
+		RedirectURL:  redirectURL,
 		Scopes: []string{
 			"https://www.googleapis.com/auth/userinfo.email",
 			"https://www.googleapis.com/auth/userinfo.profile",
 		},
 		Endpoint: google.Endpoint,
-	}
+	}, nil
 }
 
-func getGitHubOAuthConfig(cfg *config.Config) *oauth2.Config {
+func getGitHubOAuthConfig(cfg *config.Config, r *http.Request) (*oauth2.Config, error) {
+	redirectURL, err := oauthRedirectURL(cfg, r, cfg.GitHubRedirectURL)
+	if err != nil {
+		return nil, err
+	}
 	return &oauth2.Config{
 		ClientID:     cfg.GitHubClientID,
 		ClientSecret: cfg.GitHubClientSecret,
-		RedirectURL:  cfg.GitHubRedirectURL,
+		RedirectURL:  redirectURL,
 		Scopes: []string{
 			"user:email",
 			"read:user",
 		},
 		Endpoint: github.Endpoint,
+	}, nil
+}
+
+// oauthConfigError responds to a failure to build the OAuth config
+func oauthConfigError(cfg *config.Config, w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, errOAuthHostNotAllowed) {
+		cfg.Logger.Warn("OAuth login from disallowed host", "host", r.Host)
+		encodeError(w, "Login is not available on this host", http.StatusBadRequest)
+		return
 	}
+	cfg.Logger.Error("failed to build OAuth config", "error", err)
+	encodeError(w, "Internal server error", http.StatusInternalServerError)
 }
 
 // generateRandomState creates a random state string for CSRF protection.
@@ -103,14 +149,34 @@ func signOAuthState(payload, secret string) string {
 	return hex.EncodeToString(mac.Sum(nil))
 }
 
+// oauthStateBinding ties an OAuth state to the browser that started the login:
+// a hash of its User-Agent and the nonce kept in its oauth_nonce cookie. A
+// state from a leaked auth URL then fails in any other browser.
+func oauthStateBinding(userAgent, nonce string) string {
+	ua := sha256.Sum256([]byte(userAgent))
+	return hex.EncodeToString(ua[:]) + "|" + nonce
+}
+
+// requestOAuthStateBinding returns the binding of the browser making the
+// callback request
+func requestOAuthStateBinding(r *http.Request) string {
+	var nonce string
+	if cookie, err := r.Cookie(oauthNonceCookieName); err == nil {
+		nonce = cookie.Value
+	}
+	return oauthStateBinding(r.UserAgent(), nonce)
+}
+
 // encodeOAuthState encodes CLI parameters into the OAuth state with an HMAC
 // signature to prevent tampering. The state format is:
 //   - Browser flow: "randomState.hmac"
 //   - CLI flow:     "randomState|cli|port.hmac"
 //
-// The HMAC is computed over the payload (everything before the last ".") using
-// the JWT secret, so the callback can verify integrity.
-func encodeOAuthState(cliMode bool, redirectPort string, jwtSecret string) (string, error) {
+// The HMAC is computed over the payload (everything before the last ".") and
+// the browser binding using the JWT secret, so the callback can verify
+// integrity and that it comes from the browser that started the login. The
+// binding itself is not part of the state.
+func encodeOAuthState(cliMode bool, redirectPort string, binding string, jwtSecret string) (string, error) {
 	state, err := generateRandomState()
 	if err != nil {
 		return "", err
@@ -119,13 +185,14 @@ func encodeOAuthState(cliMode bool, redirectPort string, jwtSecret string) (stri
 	if cliMode && redirectPort != "" {
 		payload = fmt.Sprintf("%s|cli|%s", state, redirectPort)
 	}
-	sig := signOAuthState(payload, jwtSecret)
+	sig := signOAuthState(payload+"\n"+binding, jwtSecret)
 	return payload + "." + sig, nil
 }
 
-// decodeOAuthState verifies the HMAC signature and decodes the OAuth state to
-// extract CLI parameters. Returns ok=false if the signature is invalid.
-func decodeOAuthState(state string, jwtSecret string) (isCLI bool, redirectPort string, ok bool) {
+// decodeOAuthState verifies the HMAC signature against the browser binding
+// and decodes the OAuth state to extract CLI parameters. Returns ok=false if
+// the signature is invalid.
+func decodeOAuthState(state string, binding string, jwtSecret string) (isCLI bool, redirectPort string, ok bool) {
 	idx := strings.LastIndex(state, ".")
 	if idx < 0 || idx == len(state)-1 {
 		return false, "", false
@@ -133,7 +200,7 @@ func decodeOAuthState(state string, jwtSecret string) (isCLI bool, redirectPort
 	payload := state[:idx]
 	sig := state[idx+1:]
 
-	expected := signOAuthState(payload, jwtSecret)
+	expected := signOAuthState(payload+"\n"+binding, jwtSecret)
 	if !hmac.Equal([]byte(sig), []byte(expected)) {
 		return false, "", false
 	}
@@ -146,31 +213,62 @@ func decodeOAuthState(state string, jwtSecret string) (isCLI bool, redirectPort
 }
 
 const oauthStateCookieName = "oauth_state"
+const oauthNonceCookieName = "oauth_nonce"
 const sessionCookieName = "cfpninja_session"
 
-// setSessionCookie sets an HttpOnly cookie containing the JWT for browser sessions.
-func setSessionCookie(w http.ResponseWriter, jwt string, insecure bool) {
+// oauthStateTTL is how long a login has to come back from the provider
+const oauthStateTTL = 10 * time.Minute
+
+// stateStore remembers consumed OAuth states until they expire, so a callback
+// cannot be replayed, for example by submitting the popup twice. It is per
+// process; the state cookies are cleared on first use as well.
+type stateStore struct {
+	mu       sync.Mutex
+	consumed map[string]time.Time // state -> expiry
+}
+
+var consumedOAuthStates = &stateStore{consumed: make(map[string]time.Time)}
+
+// consume marks the state as used, returning false if it already was
+func (s *stateStore) consume(state string, now time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for k, expiry := range s.consumed {
+		if now.After(expiry) {
+			delete(s.consumed, k)
+		}
+	}
+	if _, used := s.consumed[state]; used {
+		return false
+	}
+	s.consumed[state] = now.Add(oauthStateTTL)
+	return true
+}
+
+// setSessionCookie sets an HttpOnly cookie containing the JWT for browser
+// sessions. maxAge should match the token's lifetime.
+func setSessionCookie(cfg *config.Config, w http.ResponseWriter, jwt string, maxAge time.Duration) {
 	http.SetCookie(w, &http.Cookie{
 		Name:     sessionCookieName,
 		Value:    jwt,
-		Path:     "/",
-		MaxAge:   7 * 24 * 60 * 60, // 7 days (matches JWT expiry)
+		Path:     cfg.BasePath + "/",
+		MaxAge:   int(maxAge.Seconds()),
 		HttpOnly: true,
 		SameSite: http.SameSiteLaxMode,
-		Secure:   !insecure,
+		Secure:   !cfg.Insecure,
 	})
 }
 
 // clearSessionCookie removes the session cookie.
-func clearSessionCookie(w http.ResponseWriter, insecure bool) {
+func clearSessionCookie(cfg *config.Config, w http.ResponseWriter) {
 	http.SetCookie(w, &http.Cookie{
 		Name:     sessionCookieName,
 		Value:    "",
-		Path:     "/",
+		Path:     cfg.BasePath + "/",
 		MaxAge:   -1,
 		HttpOnly: true,
 		SameSite: http.SameSiteLaxMode,
-		Secure:   !insecure,
+		Secure:   !cfg.Insecure,
 	})
 }
 
@@ -181,70 +279,125 @@ func LogoutHandler(cfg *config.Config) http.HandlerFunc {
 			encodeError(w, "Method not allowed", http.StatusMethodNotAllowed)
 			return
 		}
-		clearSessionCookie(w, cfg.Insecure)
+		clearSessionCookie(cfg, w)
 		encodeResponse(w, r, map[string]string{"message": "Logged out"})
 	}
 }
 
-// setOAuthStateCookie stores the OAuth state in a short-lived HTTP-only cookie
-// for CSRF validation on callback.
-func setOAuthStateCookie(w http.ResponseWriter, state string, insecure bool) {
+// LogoutAllHandler signs the user out of every browser and CLI by revoking
+// all tokens issued to them so far, including the one making the request.
+// POST /api/v0/me/logout-all
+func LogoutAllHandler(cfg *config.Config) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user := GetUserFromContext(r.Context())
+		if user == nil {
+			encodeAPIError(w, r, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		if err := models.RevokeUserTokens(cfg.DBCtx(r), user.ID); err != nil {
+			cfg.Logger.Error("failed to revoke tokens", "user_id", user.ID, "error", err)
+			encodeAPIError(w, r, "Failed to sign out", http.StatusInternalServerError)
+			return
+		}
+
+		// Evict user from cache so revoked tokens stop working here at once
+		userCache.Lock()
+		delete(userCache.entries, user.ID)
+		userCache.Unlock()
+
+		cfg.Logger.Info("user signed out everywhere",
+			"user_id", user.ID,
+			"request_id", GetRequestID(r.Context()),
+		)
+
+		clearSessionCookie(cfg, w)
+		encodeResponse(w, r, map[string]string{"message": "Signed out everywhere"})
+	}
+}
+
+// setOAuthStateCookies stores the OAuth state and the nonce it is bound to in
+// short-lived HTTP-only cookies for CSRF validation on callback.
+func setOAuthStateCookies(cfg *config.Config, w http.ResponseWriter, state, nonce string) {
+	setOAuthCookie(cfg, w, oauthStateCookieName, state, int(oauthStateTTL.Seconds()))
+	setOAuthCookie(cfg, w, oauthNonceCookieName, nonce, int(oauthStateTTL.Seconds()))
+}
+
+// setOAuthCookie sets or, with a negative maxAge, clears a cookie used during
+// the OAuth flow
+func setOAuthCookie(cfg *config.Config, w http.ResponseWriter, name, value string, maxAge int) {
 	http.SetCookie(w, &http.Cookie{
-		Name:     oauthStateCookieName,
-		Value:    state,
-		Path:     "/api/v0/auth/",
-		MaxAge:   600, // 10 minutes
+		Name:     name,
+		Value:    value,
+		Path:     cfg.BasePath + "/api/v0/auth/",
+		MaxAge:   maxAge,
 		HttpOnly: true,
 		SameSite: http.SameSiteLaxMode,
-		Secure:   !insecure,
+		Secure:   !cfg.Insecure,
 	})
 }
 
-// validateOAuthStateCookie validates the OAuth state from the callback matches the cookie.
+// validateOAuthStateCookie validates the OAuth state from the callback: it must
+// match the cookie, be signed for this browser's User-Agent and nonce cookie,
+// and not have been used before.
 // Returns an error message if invalid, or empty string if valid.
-// Clears the cookie after validation.
-func validateOAuthStateCookie(w http.ResponseWriter, r *http.Request, callbackState string, insecure bool) string {
+// Clears the cookies after validation.
+func validateOAuthStateCookie(cfg *config.Config, w http.ResponseWriter, r *http.Request, callbackState string) string {
 	cookie, err := r.Cookie(oauthStateCookieName)
 	if err != nil || cookie.Value == "" {
 		return "Missing OAuth state cookie - please retry login"
 	}
+	nonce, err := r.Cookie(oauthNonceCookieName)
+	if err != nil || nonce.Value == "" {
+		return "Missing OAuth state cookie - please retry login"
+	}
 
-	// Clear the cookie (match flags used when setting)
-	http.SetCookie(w, &http.Cookie{
-		Name:     oauthStateCookieName,
-		Value:    "",
-		Path:     "/api/v0/auth/",
-		MaxAge:   -1,
-		HttpOnly: true,
-		SameSite: http.SameSiteLaxMode,
-		Secure:   !insecure,
-	})
+	// Clear the cookies (match flags used when setting)
+	setOAuthCookie(cfg, w, oauthStateCookieName, "", -1)
+	setOAuthCookie(cfg, w, oauthNonceCookieName, "", -1)
 
 	if subtle.ConstantTimeCompare([]byte(cookie.Value), []byte(callbackState)) != 1 {
 		return "OAuth state mismatch - possible CSRF attack"
 	}
+	if _, _, ok := decodeOAuthState(callbackState, requestOAuthStateBinding(r), cfg.JWTSecret); !ok {
+		return "OAuth state was issued to a different browser - please retry login"
+	}
+	if !consumedOAuthStates.consume(callbackState, time.Now()) {
+		return "OAuth state already used - please retry login"
+	}
 	return ""
 }
 
 // GoogleAuthHandler redirects to Google OAuth consent screen
 func GoogleAuthHandler(cfg *config.Config) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		oauthConfig := getGoogleOAuthConfig(cfg)
+		oauthConfig, err := getGoogleOAuthConfig(cfg, r)
+		if err != nil {
+			oauthConfigError(cfg, w, r, err)
+			return
+		}
 
 		// Check for CLI mode parameters
 		cliMode := r.URL.Query().Get("cli") == "true"
 		redirectPort := r.URL.Query().Get("redirect_port")
 
-		// Generate state with CLI info encoded and HMAC-signed
-		state, err := encodeOAuthState(cliMode, redirectPort, cfg.JWTSecret)
+		// Generate state with CLI info encoded and HMAC-signed, bound to this
+		// browser by its User-Agent and a nonce cookie
+		nonce, err := generateRandomState()
+		if err != nil {
+			cfg.Logger.Error("failed to generate OAuth nonce", "error", err)
+			encodeError(w, "Internal server error", http.StatusInternalServerError)
+			return
+		}
+		state, err := encodeOAuthState(cliMode, redirectPort, oauthStateBinding(r.UserAgent(), nonce), cfg.JWTSecret)
 		if err != nil {
 			cfg.Logger.Error("failed to generate OAuth state", "error", err)
 			encodeError(w, "Internal server error", http.StatusInternalServerError)
 			return
 		}
 
-		// Store state in cookie for CSRF validation on callback
-		setOAuthStateCookie(w, state, cfg.Insecure)
+		// Store state and nonce in cookies for CSRF validation on callback
+		setOAuthStateCookies(cfg, w, state, nonce)
 
 		authURL := oauthConfig.AuthCodeURL(state, oauth2.AccessTypeOffline)
 		http.Redirect(w, r, authURL, http.StatusTemporaryRedirect)
@@ -254,11 +407,15 @@ func GoogleAuthHandler(cfg *config.Config) http.HandlerFunc {
 // GoogleCallbackHandler handles the OAuth callback from Google
 func GoogleCallbackHandler(cfg *config.Config) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		oauthConfig := getGoogleOAuthConfig(cfg)
+		oauthConfig, err := getGoogleOAuthConfig(cfg, r)
+		if err != nil {
+			oauthConfigError(cfg, w, r, err)
+			return
+		}
 
 		// Validate OAuth state to prevent CSRF
 		state := r.URL.Query().Get("state")
-		if errMsg := validateOAuthStateCookie(w, r, state, cfg.Insecure); errMsg != "" {
+		if errMsg := validateOAuthStateCookie(cfg, w, r, state); errMsg != "" {
 			cfg.Logger.Warn("OAuth state validation failed", "error", errMsg)
 			encodeError(w, errMsg, http.StatusBadRequest)
 			return
@@ -309,36 +466,41 @@ func GoogleCallbackHandler(cfg *config.Config) http.HandlerFunc {
 		}
 
 		// Create or update user in database
-		user, err := models.CreateOrUpdateUserFromGoogle(cfg.DB, userInfo.ID, userInfo.Email, userInfo.Name, userInfo.Picture)
+		user, err := models.CreateOrUpdateUserFromGoogle(cfg.DBCtx(r), userInfo.ID, userInfo.Email, userInfo.Name, userInfo.Picture)
 		if err != nil {
 			cfg.Logger.Error("failed to create/update user", "error", err)
 			encodeError(w, "Failed to create user", http.StatusInternalServerError)
 			return
 		}
 
-		// Generate JWT
-		jwtToken, err := GenerateJWT(cfg, user)
-		if err != nil {
-			cfg.Logger.Error("failed to generate JWT", "error", err)
-			encodeError(w, "Failed to generate token", http.StatusInternalServerError)
-			return
-		}
-
 		// Check if this is a CLI OAuth flow (state already validated above)
-		isCLI, redirectPort, stateOK := decodeOAuthState(state, cfg.JWTSecret)
+		isCLI, redirectPort, stateOK := decodeOAuthState(state, requestOAuthStateBinding(r), cfg.JWTSecret)
 		if !stateOK {
 			encodeError(w, "OAuth state signature invalid", http.StatusBadRequest)
 			return
 		}
 
+		// Generate a CLI token or a browser session, which expire differently
+		tokenType := TokenTypeBrowser
+		var port int
 		if isCLI && redirectPort != "" {
 			// Validate redirect port is numeric and in valid range
-			port, err := strconv.Atoi(redirectPort)
+			port, err = strconv.Atoi(redirectPort)
 			if err != nil || port < 1024 || port > 65535 {
 				encodeError(w, "Invalid redirect port", http.StatusBadRequest)
 				return
 			}
+			tokenType = TokenTypeCLI
+		}
+		jwtToken, err := GenerateJWT(cfg, user, tokenType)
+		if err != nil {
+			cfg.Logger.Error("failed to generate JWT", "error", err)
+			encodeError(w, "Failed to generate token", http.StatusInternalServerError)
+			return
+		}
+		recordLogin(cfg, r, user, "google")
 
+		if tokenType == TokenTypeCLI {
 			// CLI mode: redirect to local callback server with token
 			redirectURL := fmt.Sprintf("http://localhost:%d/callback?token=%s",
 				port, url.QueryEscape(jwtToken))
@@ -347,7 +509,7 @@ func GoogleCallbackHandler(cfg *config.Config) http.HandlerFunc {
 		}
 
 		// Browser mode: set session cookie and return HTML that signals the opener
-		setSessionCookie(w, jwtToken, cfg.Insecure)
+		setSessionCookie(cfg, w, jwtToken, tokenLifetime(cfg, TokenTypeBrowser))
 
 		nonce, err := generateCSPNonce()
 		if err != nil {
@@ -383,22 +545,33 @@ if (window.opener) {
 // GitHubAuthHandler redirects to GitHub OAuth consent screen
 func GitHubAuthHandler(cfg *config.Config) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		oauthConfig := getGitHubOAuthConfig(cfg)
+		oauthConfig, err := getGitHubOAuthConfig(cfg, r)
+		if err != nil {
+			oauthConfigError(cfg, w, r, err)
+			return
+		}
 
 		// Check for CLI mode parameters
 		cliMode := r.URL.Query().Get("cli") == "true"
 		redirectPort := r.URL.Query().Get("redirect_port")
 
-		// Generate state with CLI info encoded and HMAC-signed
-		state, err := encodeOAuthState(cliMode, redirectPort, cfg.JWTSecret)
+		// Generate state with CLI info encoded and HMAC-signed, bound to this
+		// browser by its User-Agent and a nonce cookie
+		nonce, err := generateRandomState()
+		if err != nil {
+			cfg.Logger.Error("failed to generate OAuth nonce", "error", err)
+			encodeError(w, "Internal server error", http.StatusInternalServerError)
+			return
+		}
+		state, err := encodeOAuthState(cliMode, redirectPort, oauthStateBinding(r.UserAgent(), nonce), cfg.JWTSecret)
 		if err != nil {
 			cfg.Logger.Error("failed to generate OAuth state", "error", err)
 			encodeError(w, "Internal server error", http.StatusInternalServerError)
 			return
 		}
 
-		// Store state in cookie for CSRF validation on callback
-		setOAuthStateCookie(w, state, cfg.Insecure)
+		// Store state and nonce in cookies for CSRF validation on callback
+		setOAuthStateCookies(cfg, w, state, nonce)
 
 		authURL := oauthConfig.AuthCodeURL(state)
 		http.Redirect(w, r, authURL, http.StatusTemporaryRedirect)
@@ -408,11 +581,15 @@ func GitHubAuthHandler(cfg *config.Config) http.HandlerFunc {
 // GitHubCallbackHandler handles the OAuth callback from GitHub
 func GitHubCallbackHandler(cfg *config.Config) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		oauthConfig := getGitHubOAuthConfig(cfg)
+		oauthConfig, err := getGitHubOAuthConfig(cfg, r)
+		if err != nil {
+			oauthConfigError(cfg, w, r, err)
+			return
+		}
 
 		// Validate OAuth state to prevent CSRF
 		state := r.URL.Query().Get("state")
-		if errMsg := validateOAuthStateCookie(w, r, state, cfg.Insecure); errMsg != "" {
+		if errMsg := validateOAuthStateCookie(cfg, w, r, state); errMsg != "" {
 			cfg.Logger.Warn("OAuth state validation failed", "error", errMsg)
 			encodeError(w, errMsg, http.StatusBadRequest)
 			return
@@ -480,36 +657,41 @@ func GitHubCallbackHandler(cfg *config.Config) http.HandlerFunc {
 
 		// Create or update user in database
 		gitHubID := fmt.Sprintf("%d", userInfo.ID)
-		user, err := models.CreateOrUpdateUserFromGitHub(cfg.DB, gitHubID, email, name, userInfo.AvatarURL)
+		user, err := models.CreateOrUpdateUserFromGitHub(cfg.DBCtx(r), gitHubID, email, name, userInfo.AvatarURL)
 		if err != nil {
 			cfg.Logger.Error("failed to create/update user", "error", err)
 			encodeError(w, "Failed to create user", http.StatusInternalServerError)
 			return
 		}
 
-		// Generate JWT
-		jwtToken, err := GenerateJWT(cfg, user)
-		if err != nil {
-			cfg.Logger.Error("failed to generate JWT", "error", err)
-			encodeError(w, "Failed to generate token", http.StatusInternalServerError)
-			return
-		}
-
 		// Check if this is a CLI OAuth flow (state already validated above)
-		isCLI, redirectPort, stateOK := decodeOAuthState(state, cfg.JWTSecret)
+		isCLI, redirectPort, stateOK := decodeOAuthState(state, requestOAuthStateBinding(r), cfg.JWTSecret)
 		if !stateOK {
 			encodeError(w, "OAuth state signature invalid", http.StatusBadRequest)
 			return
 		}
 
+		// Generate a CLI token or a browser session, which expire differently
+		tokenType := TokenTypeBrowser
+		var port int
 		if isCLI && redirectPort != "" {
 			// Validate redirect port is numeric and in valid range
-			port, err := strconv.Atoi(redirectPort)
+			port, err = strconv.Atoi(redirectPort)
 			if err != nil || port < 1024 || port > 65535 {
 				encodeError(w, "Invalid redirect port", http.StatusBadRequest)
 				return
 			}
+			tokenType = TokenTypeCLI
+		}
+		jwtToken, err := GenerateJWT(cfg, user, tokenType)
+		if err != nil {
+			cfg.Logger.Error("failed to generate JWT", "error", err)
+			encodeError(w, "Failed to generate token", http.StatusInternalServerError)
+			return
+		}
+		recordLogin(cfg, r, user, "github")
 
+		if tokenType == TokenTypeCLI {
 			// CLI mode: redirect to local callback server with token
 			redirectURL := fmt.Sprintf("http://localhost:%d/callback?token=%s",
 				port, url.QueryEscape(jwtToken))
@@ -518,7 +700,7 @@ func GitHubCallbackHandler(cfg *config.Config) http.HandlerFunc {
 		}
 
 		// Browser mode: set session cookie and return HTML that signals the opener
-		setSessionCookie(w, jwtToken, cfg.Insecure)
+		setSessionCookie(cfg, w, jwtToken, tokenLifetime(cfg, TokenTypeBrowser))
 
 		nonce, err := generateCSPNonce()
 		if err != nil {
@@ -604,13 +786,23 @@ func GetMeHandler(cfg *config.Config) http.HandlerFunc {
 			return
 		}
 
-		encodeResponse(w, r, map[string]interface{}{
+		me := map[string]interface{}{
 			"id":                user.ID,
 			"email":             user.Email,
 			"name":              user.Name,
 			"picture_url":       user.PictureURL,
 			"terms_accepted_at": user.TermsAcceptedAt,
-		})
+		}
+		// Lets the UI warn that an admin is seeing the app as this user
+		if imp := GetImpersonationFromContext(r.Context()); imp != nil {
+			me["impersonated_by"] = map[string]interface{}{
+				"id":    imp.Admin.ID,
+				"email": imp.Admin.Email,
+				"name":  imp.Admin.Name,
+			}
+			me["impersonation_write"] = imp.Write
+		}
+		encodeResponse(w, r, me)
 	}
 }
 
@@ -628,7 +820,7 @@ func AcceptTermsHandler(cfg *config.Config) http.HandlerFunc {
 			return
 		}
 
-		if err := models.AcceptTerms(cfg.DB, user.ID); err != nil {
+		if err := models.AcceptTerms(cfg.DBCtx(r), user.ID); err != nil {
 			cfg.Logger.Error("failed to record terms acceptance", "user_id", user.ID, "error", err)
 			encodeError(w, "Failed to record terms acceptance", http.StatusInternalServerError)
 			return
@@ -643,7 +835,55 @@ func AcceptTermsHandler(cfg *config.Config) http.HandlerFunc {
 	}
 }
 
-// GetMyEventsHandler returns events the user manages or has submitted to
+// managedEventsSQL matches the events a user created or co-organizes, as one
+// parenthesized condition so further conditions can't change its grouping.
+// Bind the user's ID as @user, like cfpOpenSQL.
+const managedEventsSQL = "(created_by_id = @user OR id IN (SELECT event_id FROM event_organizers WHERE user_id = @user))"
+
+// managingEvent is an event the user manages, as listed by GetMyEventsHandler
+type managingEvent struct {
+	ID            uint      `json:"id"`
+	Name          string    `json:"name"`
+	Slug          string    `json:"slug"`
+	StartDate     time.Time `json:"start_date"`
+	EndDate       time.Time `json:"end_date"`
+	CFPStatus     string    `json:"cfp_status"`
+	ProposalCount int64     `json:"proposal_count"`
+	IsPaid        bool      `json:"is_paid"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// myProposal is one of the user's proposals to a submittedEvent
+type myProposal struct {
+	ID                    uint       `json:"id"`
+	Title                 string     `json:"title"`
+	Status                string     `json:"status"`
+	Rating                *int       `json:"rating,omitempty"`
+	AttendanceConfirmed   bool       `json:"attendance_confirmed"`
+	AttendanceConfirmedAt *time.Time `json:"attendance_confirmed_at,omitempty"`
+	IsPaid                bool       `json:"is_paid"`
+	EventRequiresPayment  bool       `json:"event_requires_payment"`
+	CreatedAt             time.Time  `json:"created_at"`
+	UpdatedAt             time.Time  `json:"updated_at"`
+}
+
+// submittedEvent is an event the user submitted proposals to
+type submittedEvent struct {
+	ID          uint         `json:"id"`
+	Name        string       `json:"name"`
+	Slug        string       `json:"slug"`
+	CFPStatus   string       `json:"cfp_status"`
+	MyProposals []myProposal `json:"my_proposals"`
+	CreatedAt   time.Time    `json:"created_at"`
+	UpdatedAt   time.Time    `json:"updated_at"`
+}
+
+// GetMyEventsHandler returns events the user manages or has submitted to.
+// The managed events can be searched and paged like the dashboard (see
+// managedEventsFilter); ?q= also narrows the submitted events to those whose
+// name or slug, or the user's proposal titles, match.
+// Any failed query is a 500, never a partial or empty list.
 func GetMyEventsHandler(cfg *config.Config) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodGet {
@@ -657,67 +897,49 @@ func GetMyEventsHandler(cfg *config.Config) http.HandlerFunc {
 			return
 		}
 
+		filter, errs := parseManagedEventsFilter(r)
+		if len(errs) > 0 {
+			encodeValidationErrors(w, r, errs)
+			return
+		}
+
 		// Get events user created or is organizing
-		var managingEvents []models.Event
-		if err := cfg.DB.Where("created_by_id = ?", user.ID).
-			Or("id IN (SELECT event_id FROM event_organizers WHERE user_id = ?)", user.ID).
-			Find(&managingEvents).Error; err != nil {
-			cfg.Logger.Error("failed to fetch managing events", "error", err)
+		managingEvents, managingTotal, err := filter.find(cfg.DBCtx(r), user.ID, "start_date DESC, id DESC")
+		if err != nil {
+			cfg.Logger.Error("failed to fetch managing events", "error", err, "user_id", user.ID)
 			encodeError(w, "Failed to fetch events", http.StatusInternalServerError)
 			return
 		}
 
+		// The user's proposals, narrowed by ?q= to matching titles or events
+		userProposals := func() *gorm.DB {
+			q := cfg.DBCtx(r).Model(&models.Proposal{}).Where("created_by_id = ?", user.ID)
+			if filter.Query != "" {
+				pattern := "%" + escapeLikePattern(filter.Query) + "%"
+				q = q.Where("(title ILIKE ? OR event_id IN (SELECT id FROM events WHERE name ILIKE ? OR slug ILIKE ?))", pattern, pattern, pattern)
+			}
+			return q
+		}
+
 		// Get events user has submitted proposals to
 		var submittedEventIDs []uint
-		if err := cfg.DB.Model(&models.Proposal{}).
-			Where("created_by_id = ?", user.ID).
+		if err := userProposals().
 			Distinct("event_id").
 			Pluck("event_id", &submittedEventIDs).Error; err != nil {
-			cfg.Logger.Error("failed to fetch submitted event IDs", "error", err)
+			cfg.Logger.Error("failed to fetch submitted event IDs", "error", err, "user_id", user.ID)
 			encodeError(w, "Failed to fetch events", http.StatusInternalServerError)
 			return
 		}
 
 		var submittedEvents []models.Event
 		if len(submittedEventIDs) > 0 {
-			if err := cfg.DB.Where("id IN ?", submittedEventIDs).Find(&submittedEvents).Error; err != nil {
-				cfg.Logger.Error("failed to fetch submitted events", "error", err)
+			if err := cfg.DBCtx(r).Where("id IN ?", submittedEventIDs).Find(&submittedEvents).Error; err != nil {
+				cfg.Logger.Error("failed to fetch submitted events", "error", err, "user_id", user.ID)
 				encodeError(w, "Failed to fetch events", http.StatusInternalServerError)
 				return
 			}
 		}
 
-		// Build response
-		type ManagingEvent struct {
-			ID            uint      `json:"id"`
-			Name          string    `json:"name"`
-			Slug          string    `json:"slug"`
-			StartDate     time.Time `json:"start_date"`
-			EndDate       time.Time `json:"end_date"`
-			CFPStatus     string    `json:"cfp_status"`
-			ProposalCount int64     `json:"proposal_count"`
-			IsPaid        bool      `json:"is_paid"`
-		}
-
-		type MyProposal struct {
-			ID                    uint      `json:"id"`
-			Title                 string    `json:"title"`
-			Status                string    `json:"status"`
-			Rating                *int      `json:"rating,omitempty"`
-			AttendanceConfirmed   bool      `json:"attendance_confirmed"`
-			IsPaid                bool      `json:"is_paid"`
-			EventRequiresPayment  bool      `json:"event_requires_payment"`
-			CreatedAt             time.Time `json:"created_at"`
-		}
-
-		type SubmittedEvent struct {
-			ID          uint         `json:"id"`
-			Name        string       `json:"name"`
-			Slug        string       `json:"slug"`
-			CFPStatus   string       `json:"cfp_status"`
-			MyProposals []MyProposal `json:"my_proposals"`
-		}
-
 		// Batch-fetch proposal counts for all managed events in a single query
 		proposalCounts := make(map[uint]int64)
 		if len(managingEvents) > 0 {
@@ -730,12 +952,12 @@ func GetMyEventsHandler(cfg *config.Config) http.HandlerFunc {
 				Count   int64
 			}
 			var counts []countRow
-			if err := cfg.DB.Model(&models.Proposal{}).
+			if err := cfg.DBCtx(r).Model(&models.Proposal{}).
 				Select("event_id, count(*) as count").
 				Where("event_id IN ?", managingIDs).
 				Group("event_id").
 				Find(&counts).Error; err != nil {
-				cfg.Logger.Error("failed to fetch proposal counts", "error", err)
+				cfg.Logger.Error("failed to fetch proposal counts", "error", err, "user_id", user.ID)
 				encodeError(w, "Failed to fetch events", http.StatusInternalServerError)
 				return
 			}
@@ -744,9 +966,9 @@ func GetMyEventsHandler(cfg *config.Config) http.HandlerFunc {
 			}
 		}
 
-		managing := make([]ManagingEvent, 0)
+		managing := make([]managingEvent, 0)
 		for _, e := range managingEvents {
-			managing = append(managing, ManagingEvent{
+			managing = append(managing, managingEvent{
 				ID:            e.ID,
 				Name:          e.Name,
 				Slug:          e.Slug,
@@ -755,6 +977,8 @@ func GetMyEventsHandler(cfg *config.Config) http.HandlerFunc {
 				CFPStatus:     string(e.CFPStatus),
 				ProposalCount: proposalCounts[e.ID],
 				IsPaid:        e.IsPaid,
+				CreatedAt:     e.CreatedAt,
+				UpdatedAt:     e.UpdatedAt,
 			})
 		}
 
@@ -765,9 +989,9 @@ func GetMyEventsHandler(cfg *config.Config) http.HandlerFunc {
 			for i, e := range submittedEvents {
 				submittedIDs[i] = e.ID
 			}
-			if err := cfg.DB.Where("event_id IN ? AND created_by_id = ?", submittedIDs, user.ID).Find(&allUserProposals).Error; err != nil {
-				cfg.Logger.Error("failed to load proposals for submitted events", "error", err)
-				encodeError(w, "Internal server error", http.StatusInternalServerError)
+			if err := userProposals().Where("event_id IN ?", submittedIDs).Find(&allUserProposals).Error; err != nil {
+				cfg.Logger.Error("failed to load proposals for submitted events", "error", err, "user_id", user.ID)
+				encodeError(w, "Failed to fetch events", http.StatusInternalServerError)
 				return
 			}
 		}
@@ -778,34 +1002,42 @@ func GetMyEventsHandler(cfg *config.Config) http.HandlerFunc {
 			proposalsByEvent[p.EventID] = append(proposalsByEvent[p.EventID], p)
 		}
 
-		submitted := make([]SubmittedEvent, 0)
+		submitted := make([]submittedEvent, 0)
 		for _, e := range submittedEvents {
-			myProposals := make([]MyProposal, 0)
+			myProposals := make([]myProposal, 0)
 			for _, p := range proposalsByEvent[e.ID] {
-				myProposals = append(myProposals, MyProposal{
+				myProposals = append(myProposals, myProposal{
 					ID:                    p.ID,
 					Title:                 p.Title,
 					Status:                string(p.Status),
 					Rating:                p.Rating,
 					AttendanceConfirmed:   p.AttendanceConfirmed,
+					AttendanceConfirmedAt: p.AttendanceConfirmedAt,
 					IsPaid:                p.IsPaid,
 					EventRequiresPayment:  e.CFPRequiresPayment,
 					CreatedAt:             p.CreatedAt,
+					UpdatedAt:             p.UpdatedAt,
 				})
 			}
 
-			submitted = append(submitted, SubmittedEvent{
+			submitted = append(submitted, submittedEvent{
 				ID:          e.ID,
 				Name:        e.Name,
 				Slug:        e.Slug,
 				CFPStatus:   string(e.CFPStatus),
 				MyProposals: myProposals,
+				CreatedAt:   e.CreatedAt,
+				UpdatedAt:   e.UpdatedAt,
 			})
 		}
 
-		encodeResponse(w, r, map[string]interface{}{
+		body := map[string]interface{}{
 			"managing":  managing,
 			"submitted": submitted,
-		})
+		}
+		if filter.PerPage > 0 {
+			body["pagination"] = filter.pagination(managingTotal)
+		}
+		encodeResponse(w, r, body)
 	}
 }
@@ -1,12 +1,22 @@
 package api
 
 import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/sreday/cfp.ninja/pkg/config"
 )
 
 const testJWTSecret = "test-secret-for-oauth-state-signing"
 
+var testBinding = oauthStateBinding("Mozilla/5.0 (test)", "test-nonce")
+
 func TestGenerateRandomState(t *testing.T) {
 	// Test that it generates non-empty strings
 	state1, err := generateRandomState()
@@ -45,7 +55,7 @@ func TestGenerateRandomState_Length(t *testing.T) {
 }
 
 func TestEncodeOAuthState_BrowserMode(t *testing.T) {
-	state, err := encodeOAuthState(false, "", testJWTSecret)
+	state, err := encodeOAuthState(false, "", testBinding, testJWTSecret)
 	if err != nil {
 		t.Fatalf("encodeOAuthState failed: %v", err)
 	}
@@ -62,7 +72,7 @@ func TestEncodeOAuthState_BrowserMode(t *testing.T) {
 }
 
 func TestEncodeOAuthState_CLIMode(t *testing.T) {
-	state, err := encodeOAuthState(true, "8080", testJWTSecret)
+	state, err := encodeOAuthState(true, "8080", testBinding, testJWTSecret)
 	if err != nil {
 		t.Fatalf("encodeOAuthState failed: %v", err)
 	}
@@ -93,7 +103,7 @@ func TestEncodeOAuthState_CLIMode(t *testing.T) {
 
 func TestEncodeOAuthState_CLIModeWithoutPort(t *testing.T) {
 	// CLI mode without port should behave like browser mode
-	state, err := encodeOAuthState(true, "", testJWTSecret)
+	state, err := encodeOAuthState(true, "", testBinding, testJWTSecret)
 	if err != nil {
 		t.Fatalf("encodeOAuthState failed: %v", err)
 	}
@@ -110,8 +120,8 @@ func TestEncodeOAuthState_CLIModeWithoutPort(t *testing.T) {
 }
 
 func TestDecodeOAuthState_BrowserMode(t *testing.T) {
-	state, _ := encodeOAuthState(false, "", testJWTSecret)
-	isCLI, port, ok := decodeOAuthState(state, testJWTSecret)
+	state, _ := encodeOAuthState(false, "", testBinding, testJWTSecret)
+	isCLI, port, ok := decodeOAuthState(state, testBinding, testJWTSecret)
 
 	if !ok {
 		t.Fatal("expected ok=true for valid signed state")
@@ -125,8 +135,8 @@ func TestDecodeOAuthState_BrowserMode(t *testing.T) {
 }
 
 func TestDecodeOAuthState_CLIMode(t *testing.T) {
-	state, _ := encodeOAuthState(true, "9999", testJWTSecret)
-	isCLI, port, ok := decodeOAuthState(state, testJWTSecret)
+	state, _ := encodeOAuthState(true, "9999", testBinding, testJWTSecret)
+	isCLI, port, ok := decodeOAuthState(state, testBinding, testJWTSecret)
 
 	if !ok {
 		t.Fatal("expected ok=true for valid signed state")
@@ -140,24 +150,24 @@ func TestDecodeOAuthState_CLIMode(t *testing.T) {
 }
 
 func TestDecodeOAuthState_InvalidSignature(t *testing.T) {
-	state, _ := encodeOAuthState(true, "8080", testJWTSecret)
+	state, _ := encodeOAuthState(true, "8080", testBinding, testJWTSecret)
 
 	// Tamper with the state by using a different secret
-	_, _, ok := decodeOAuthState(state, "wrong-secret")
+	_, _, ok := decodeOAuthState(state, testBinding, "wrong-secret")
 	if ok {
 		t.Error("expected ok=false when verifying with wrong secret")
 	}
 }
 
 func TestDecodeOAuthState_TamperedPayload(t *testing.T) {
-	state, _ := encodeOAuthState(true, "8080", testJWTSecret)
+	state, _ := encodeOAuthState(true, "8080", testBinding, testJWTSecret)
 
 	// Replace port in payload but keep old signature
 	dotIdx := strings.LastIndex(state, ".")
 	sig := state[dotIdx:]
 	tampered := strings.Replace(state[:dotIdx], "8080", "9999", 1) + sig
 
-	_, _, ok := decodeOAuthState(tampered, testJWTSecret)
+	_, _, ok := decodeOAuthState(tampered, testBinding, testJWTSecret)
 	if ok {
 		t.Error("expected ok=false for tampered payload")
 	}
@@ -175,7 +185,7 @@ func TestDecodeOAuthState_InvalidFormat(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			isCLI, port, ok := decodeOAuthState(tc.state, testJWTSecret)
+			isCLI, port, ok := decodeOAuthState(tc.state, testBinding, testJWTSecret)
 			if ok {
 				t.Error("expected ok=false for invalid format")
 			}
@@ -199,12 +209,12 @@ func TestDecodeOAuthState_RoundTrip(t *testing.T) {
 	}
 
 	for _, tc := range testCases {
-		state, err := encodeOAuthState(tc.cliMode, tc.port, testJWTSecret)
+		state, err := encodeOAuthState(tc.cliMode, tc.port, testBinding, testJWTSecret)
 		if err != nil {
 			t.Fatalf("encodeOAuthState failed: %v", err)
 		}
 
-		isCLI, port, ok := decodeOAuthState(state, testJWTSecret)
+		isCLI, port, ok := decodeOAuthState(state, testBinding, testJWTSecret)
 		if !ok {
 			t.Fatalf("decodeOAuthState returned ok=false for valid state")
 		}
@@ -223,3 +233,186 @@ func TestDecodeOAuthState_RoundTrip(t *testing.T) {
 		}
 	}
 }
+
+func TestDecodeOAuthState_OtherBrowser(t *testing.T) {
+	state, _ := encodeOAuthState(true, "8080", testBinding, testJWTSecret)
+
+	if _, _, ok := decodeOAuthState(state, oauthStateBinding("curl/8.0", "test-nonce"), testJWTSecret); ok {
+		t.Error("expected ok=false for a different User-Agent")
+	}
+	if _, _, ok := decodeOAuthState(state, oauthStateBinding("Mozilla/5.0 (test)", "other-nonce"), testJWTSecret); ok {
+		t.Error("expected ok=false for a different nonce")
+	}
+}
+
+// oauthCallbackRequest builds a callback request carrying the given state
+// cookies from a browser with the given User-Agent
+func oauthCallbackRequest(state, stateCookie, nonceCookie, userAgent string) *http.Request {
+	r := httptest.NewRequest(http.MethodGet, "/api/v0/auth/github/callback?state="+state, nil)
+	r.Header.Set("User-Agent", userAgent)
+	if stateCookie != "" {
+		r.AddCookie(&http.Cookie{Name: oauthStateCookieName, Value: stateCookie})
+	}
+	if nonceCookie != "" {
+		r.AddCookie(&http.Cookie{Name: oauthNonceCookieName, Value: nonceCookie})
+	}
+	return r
+}
+
+func TestValidateOAuthStateCookie(t *testing.T) {
+	const ua = "Mozilla/5.0 (test)"
+	newState := func() string {
+		state, err := encodeOAuthState(false, "", oauthStateBinding(ua, "nonce"), testJWTSecret)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return state
+	}
+
+	cfg := &config.Config{JWTSecret: testJWTSecret}
+	state := newState()
+	w := httptest.NewRecorder()
+	if msg := validateOAuthStateCookie(cfg, w, oauthCallbackRequest(state, state, "nonce", ua), state); msg != "" {
+		t.Fatalf("expected a valid state, got %q", msg)
+	}
+	if cleared := w.Result().Cookies(); len(cleared) != 2 || cleared[0].MaxAge >= 0 || cleared[1].MaxAge >= 0 {
+		t.Errorf("expected both cookies cleared, got %+v", cleared)
+	}
+
+	// The same callback submitted again is rejected, even with the cookies
+	if msg := validateOAuthStateCookie(cfg, httptest.NewRecorder(), oauthCallbackRequest(state, state, "nonce", ua), state); !strings.Contains(msg, "already used") {
+		t.Errorf("expected a replay to be rejected, got %q", msg)
+	}
+
+	tests := []struct {
+		name        string
+		stateCookie string
+		nonceCookie string
+		userAgent   string
+		want        string
+	}{
+		{"no state cookie", "", "nonce", ua, "Missing"},
+		{"no nonce cookie", "match", "", ua, "Missing"},
+		{"other state", "other", "nonce", ua, "mismatch"},
+		{"other browser", "match", "nonce", "curl/8.0", "different browser"},
+		{"other nonce", "match", "other-nonce", ua, "different browser"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := newState()
+			stateCookie := tt.stateCookie
+			if stateCookie == "match" {
+				stateCookie = state
+			}
+			r := oauthCallbackRequest(state, stateCookie, tt.nonceCookie, tt.userAgent)
+			if msg := validateOAuthStateCookie(cfg, httptest.NewRecorder(), r, state); !strings.Contains(msg, tt.want) {
+				t.Errorf("expected an error containing %q, got %q", tt.want, msg)
+			}
+		})
+	}
+}
+
+func TestStateStore_Consume(t *testing.T) {
+	store := &stateStore{consumed: make(map[string]time.Time)}
+	now := time.Now()
+
+	if !store.consume("a", now) {
+		t.Fatal("expected the first use to succeed")
+	}
+	if store.consume("a", now.Add(time.Minute)) {
+		t.Error("expected a second use to fail")
+	}
+
+	// Expired states are forgotten; their cookies are gone by then
+	if !store.consume("b", now.Add(oauthStateTTL+time.Second)) {
+		t.Error("expected a new state to succeed")
+	}
+	if _, ok := store.consumed["a"]; ok {
+		t.Error("expected the expired state to be pruned")
+	}
+}
+
+func TestOAuthRedirectURL(t *testing.T) {
+	const configured = "https://cfp.ninja/api/v0/auth/github/callback"
+	request := func(host string) *http.Request {
+		r := httptest.NewRequest(http.MethodGet, "/api/v0/auth/github", nil)
+		r.Host = host
+		return r
+	}
+
+	// Without an allowlist the configured URL is used whatever the host
+	got, err := oauthRedirectURL(&config.Config{}, request("staging.cfp.ninja"), configured)
+	if err != nil || got != configured {
+		t.Errorf("no allowlist: got %q, %v", got, err)
+	}
+
+	cfg := &config.Config{OAuthRedirectHosts: []string{"staging.cfp.ninja", "cfp.ninja", "localhost:8080"}}
+	for host, want := range map[string]string{
+		"cfp.ninja":         configured,
+		"Staging.CFP.ninja": "https://staging.cfp.ninja/api/v0/auth/github/callback",
+		"localhost:8080":    "https://localhost:8080/api/v0/auth/github/callback",
+	} {
+		got, err := oauthRedirectURL(cfg, request(host), configured)
+		if err != nil || got != want {
+			t.Errorf("host %s: got %q, %v, want %q", host, got, err, want)
+		}
+	}
+
+	for _, host := range []string{"evil.example.com", "cfp.ninja.evil.example.com", "localhost:9090"} {
+		if _, err := oauthRedirectURL(cfg, request(host), configured); err != errOAuthHostNotAllowed {
+			t.Errorf("host %s: expected errOAuthHostNotAllowed, got %v", host, err)
+		}
+	}
+}
+
+func TestGitHubAuthHandler_RedirectHost(t *testing.T) {
+	cfg := &config.Config{
+		GitHubClientID:     "client",
+		GitHubRedirectURL:  "https://cfp.ninja/api/v0/auth/github/callback",
+		OAuthRedirectHosts: []string{"cfp.ninja", "staging.cfp.ninja"},
+		JWTSecret:          testJWTSecret,
+		Logger:             slog.New(slog.NewTextHandler(io.Discard, nil)),
+	}
+	handler := GitHubAuthHandler(cfg)
+
+	r := httptest.NewRequest(http.MethodGet, "/api/v0/auth/github", nil)
+	r.Host = "staging.cfp.ninja"
+	w := httptest.NewRecorder()
+	handler(w, r)
+	if w.Code != http.StatusTemporaryRedirect {
+		t.Fatalf("expected a redirect, got %d", w.Code)
+	}
+	loc, err := url.Parse(w.Header().Get("Location"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := loc.Query().Get("redirect_uri"); got != "https://staging.cfp.ninja/api/v0/auth/github/callback" {
+		t.Errorf("redirect_uri = %q, want the staging host", got)
+	}
+	// Cookies belong to the requesting host only
+	for _, c := range w.Result().Cookies() {
+		if c.Domain != "" {
+			t.Errorf("cookie %s has domain %q, want host-only", c.Name, c.Domain)
+		}
+	}
+
+	r = httptest.NewRequest(http.MethodGet, "/api/v0/auth/github", nil)
+	r.Host = "evil.example.com"
+	w = httptest.NewRecorder()
+	handler(w, r)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("disallowed host: expected 400, got %d", w.Code)
+	}
+	if len(w.Result().Cookies()) != 0 {
+		t.Error("disallowed host: expected no cookies")
+	}
+
+	// The callback rejects the host before looking at the state
+	r = httptest.NewRequest(http.MethodGet, "/api/v0/auth/github/callback?state=x&code=y", nil)
+	r.Host = "evil.example.com"
+	w = httptest.NewRecorder()
+	GitHubCallbackHandler(cfg)(w, r)
+	if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "not available on this host") {
+		t.Errorf("disallowed callback host: got %d %s", w.Code, w.Body.String())
+	}
+}
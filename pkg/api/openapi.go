@@ -0,0 +1,64 @@
+package api
+
+import (
+	"bytes"
+	"embed"
+	"net/http"
+
+	"github.com/sreday/cfp.ninja/pkg/config"
+)
+
+// openapiFS holds the OpenAPI document for the v0 API and the Swagger UI page.
+// tests/integration/openapi_test.go checks the document against real responses.
+//
+//go:embed openapi/*
+var openapiFS embed.FS
+
+// OpenAPISpec returns the embedded OpenAPI document
+func OpenAPISpec() []byte {
+	data, err := openapiFS.ReadFile("openapi/openapi.json")
+	if err != nil {
+		panic("openapi: embedded spec missing: " + err.Error())
+	}
+	return data
+}
+
+// OpenAPIHandler serves the OpenAPI document, with the server URL under
+// BASE_PATH when it's set.
+// GET /api/v0/openapi.json
+func OpenAPIHandler(cfg *config.Config) http.HandlerFunc {
+	if cfg.BasePath == "" {
+		return serveOpenAPIFile("openapi/openapi.json", "application/json")
+	}
+	spec := bytes.Replace(OpenAPISpec(), []byte(`"url": "/"`), []byte(`"url": "`+cfg.BasePath+`"`), 1)
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "public, max-age=3600")
+		w.Write(spec)
+	}
+}
+
+// APIDocsHandler serves a Swagger UI page for the OpenAPI document.
+// GET /api/v0/docs
+func APIDocsHandler(cfg *config.Config) http.HandlerFunc {
+	return serveOpenAPIFile("openapi/docs.html", "text/html; charset=utf-8")
+}
+
+// APIDocsScriptHandler serves the script that initializes Swagger UI.
+// GET /api/v0/docs/docs.js
+func APIDocsScriptHandler(cfg *config.Config) http.HandlerFunc {
+	return serveOpenAPIFile("openapi/docs.js", "text/javascript; charset=utf-8")
+}
+
+func serveOpenAPIFile(name, contentType string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		data, err := openapiFS.ReadFile(name)
+		if err != nil {
+			encodeAPIError(w, r, "Not found", http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", contentType)
+		w.Header().Set("Cache-Control", "public, max-age=3600")
+		w.Write(data)
+	}
+}
@@ -0,0 +1,8 @@
+// Loaded as a separate file because the Content-Security-Policy forbids inline scripts
+window.addEventListener("load", function () {
+  window.ui = SwaggerUIBundle({
+    url: "openapi.json",
+    dom_id: "#swagger-ui",
+    deepLinking: true,
+  });
+});
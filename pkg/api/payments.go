@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/sreday/cfp.ninja/pkg/config"
+	"github.com/sreday/cfp.ninja/pkg/logging"
 	"github.com/sreday/cfp.ninja/pkg/models"
 	"github.com/stripe/stripe-go/v82"
 	"gorm.io/gorm"
@@ -19,6 +20,7 @@ import (
 // CreateEventCheckoutHandler creates a Stripe Checkout session for event listing payment.
 // POST /api/v0/events/{id}/checkout
 func CreateEventCheckoutHandler(cfg *config.Config) http.HandlerFunc {
+	logger := logging.Module(cfg.Logger, logging.Payments)
 	return func(w http.ResponseWriter, r *http.Request) {
 		user := GetUserFromContext(r.Context())
 		if user == nil {
@@ -33,7 +35,7 @@ func CreateEventCheckoutHandler(cfg *config.Config) http.HandlerFunc {
 		}
 
 		var event models.Event
-		if err := cfg.DB.Preload("Organizers").First(&event, eventID).Error; err != nil {
+		if err := cfg.DBCtx(r).Preload("Organizers").First(&event, eventID).Error; err != nil {
 			encodeError(w, "Event not found", http.StatusNotFound)
 			return
 		}
@@ -53,6 +55,15 @@ func CreateEventCheckoutHandler(cfg *config.Config) http.HandlerFunc {
 			return
 		}
 
+		// Acceptances of older terms still cover listings already paid for,
+		// but not new checkouts
+		if event.ListingTermsAcceptedAt == nil || event.ListingTermsVersion != cfg.ListingTermsVersion {
+			encodeAPIErrorCode(w, r, ErrCodeTermsNotAccepted,
+				fmt.Sprintf("The event creator must accept version %s of the listing terms before paying", cfg.ListingTermsVersion),
+				http.StatusConflict)
+			return
+		}
+
 		params := &stripe.CheckoutSessionParams{
 			Mode: stripe.String(string(stripe.CheckoutSessionModePayment)),
 			LineItems: []*stripe.CheckoutSessionLineItemParams{
@@ -75,10 +86,11 @@ func CreateEventCheckoutHandler(cfg *config.Config) http.HandlerFunc {
 
 		s, err := session.New(params)
 		if err != nil {
-			cfg.Logger.Error("failed to create Stripe checkout session", "error", err)
+			logger.Error("failed to create Stripe checkout session", "error", err)
 			encodeError(w, "Failed to create checkout session", http.StatusInternalServerError)
 			return
 		}
+		logger.Debug("created Stripe checkout session", "session_id", s.ID, "user_id", user.ID)
 
 		encodeResponse(w, r, map[string]string{
 			"checkout_url": s.URL,
@@ -87,9 +99,86 @@ func CreateEventCheckoutHandler(cfg *config.Config) http.HandlerFunc {
 	}
 }
 
+// AcceptListingTermsHandler records that the event's creator accepted the
+// current version of the platform terms, which the listing checkout requires.
+// The body must name the version being accepted, so a client showing outdated
+// terms cannot accept newer ones.
+// POST /api/v0/events/{id}/accept-terms
+func AcceptListingTermsHandler(cfg *config.Config) http.HandlerFunc {
+	logger := logging.Module(cfg.Logger, logging.Payments)
+	return func(w http.ResponseWriter, r *http.Request) {
+		user := GetUserFromContext(r.Context())
+		if user == nil {
+			encodeAPIError(w, r, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		eventID, err := strconv.ParseUint(r.PathValue("id"), 10, 32)
+		if err != nil {
+			encodeAPIError(w, r, "Invalid event ID", http.StatusBadRequest)
+			return
+		}
+
+		var req struct {
+			Version string `json:"version"`
+		}
+		if !decodeJSONBody(w, r, MaxSmallBodySize, &req) {
+			return
+		}
+
+		var event models.Event
+		if err := cfg.DBCtx(r).Preload("Organizers").First(&event, eventID).Error; err != nil {
+			encodeAPIError(w, r, "Event not found", http.StatusNotFound)
+			return
+		}
+
+		if !actsAsCreator(cfg, &event, user) {
+			encodeAPIError(w, r, "Only the event creator can accept the listing terms", http.StatusForbidden)
+			return
+		}
+
+		var errs validationErrors
+		if req.Version == "" {
+			errs.add("version", "version is required")
+		}
+		if len(errs) > 0 {
+			encodeValidationErrors(w, r, errs)
+			return
+		}
+		if req.Version != cfg.ListingTermsVersion {
+			encodeAPIErrorCode(w, r, ErrCodeConflict,
+				fmt.Sprintf("The listing terms have changed; review and accept version %s", cfg.ListingTermsVersion),
+				http.StatusConflict)
+			return
+		}
+
+		now := cfg.Now()
+		if err := cfg.DBCtx(r).Model(&event).Updates(map[string]interface{}{
+			"listing_terms_version":     req.Version,
+			"listing_terms_accepted_at": now,
+		}).Error; err != nil {
+			logger.Error("failed to record listing terms acceptance", "error", err, "event_id", event.ID)
+			encodeAPIError(w, r, "Failed to accept terms", http.StatusInternalServerError)
+			return
+		}
+		event.ListingTermsVersion = req.Version
+		event.ListingTermsAcceptedAt = &now
+
+		logger.Info("listing terms accepted",
+			"event_id", event.ID,
+			"terms_version", req.Version,
+			"user_id", user.ID,
+			"request_id", GetRequestID(r.Context()),
+		)
+
+		encodeResponse(w, r, event)
+	}
+}
+
 // CreateProposalCheckoutHandler creates a Stripe Checkout session for proposal submission payment.
 // POST /api/v0/events/{id}/proposals/{proposalId}/checkout
 func CreateProposalCheckoutHandler(cfg *config.Config) http.HandlerFunc {
+	logger := logging.Module(cfg.Logger, logging.Payments)
 	return func(w http.ResponseWriter, r *http.Request) {
 		user := GetUserFromContext(r.Context())
 		if user == nil {
@@ -110,7 +199,7 @@ func CreateProposalCheckoutHandler(cfg *config.Config) http.HandlerFunc {
 		}
 
 		var event models.Event
-		if err := cfg.DB.First(&event, eventID).Error; err != nil {
+		if err := cfg.DBCtx(r).First(&event, eventID).Error; err != nil {
 			encodeError(w, "Event not found", http.StatusNotFound)
 			return
 		}
@@ -121,7 +210,7 @@ func CreateProposalCheckoutHandler(cfg *config.Config) http.HandlerFunc {
 		}
 
 		var proposal models.Proposal
-		if err := cfg.DB.First(&proposal, proposalID).Error; err != nil {
+		if err := cfg.DBCtx(r).First(&proposal, proposalID).Error; err != nil {
 			encodeError(w, "Proposal not found", http.StatusNotFound)
 			return
 		}
@@ -173,10 +262,11 @@ func CreateProposalCheckoutHandler(cfg *config.Config) http.HandlerFunc {
 
 		s, err := session.New(params)
 		if err != nil {
-			cfg.Logger.Error("failed to create Stripe checkout session", "error", err)
+			logger.Error("failed to create Stripe checkout session", "error", err)
 			encodeError(w, "Failed to create checkout session", http.StatusInternalServerError)
 			return
 		}
+		logger.Debug("created Stripe checkout session", "session_id", s.ID, "user_id", user.ID)
 
 		encodeResponse(w, r, map[string]string{
 			"checkout_url": s.URL,
@@ -189,11 +279,12 @@ func CreateProposalCheckoutHandler(cfg *config.Config) http.HandlerFunc {
 // POST /api/v0/webhooks/stripe
 // No JWT auth - verified via Stripe signature.
 func StripeWebhookHandler(cfg *config.Config) http.HandlerFunc {
+	logger := logging.Module(cfg.Logger, logging.Payments)
 	return func(w http.ResponseWriter, r *http.Request) {
 		defer r.Body.Close()
 
 		if cfg.StripeWebhookSecret == "" {
-			cfg.Logger.Error("Stripe webhook secret not configured, rejecting webhook")
+			logger.Error("Stripe webhook secret not configured, rejecting webhook")
 			encodeError(w, "Webhook not configured", http.StatusServiceUnavailable)
 			return
 		}
@@ -210,10 +301,11 @@ func StripeWebhookHandler(cfg *config.Config) http.HandlerFunc {
 			IgnoreAPIVersionMismatch: true,
 		})
 		if err != nil {
-			cfg.Logger.Warn("Stripe webhook signature verification failed", "error", err)
+			logger.Warn("Stripe webhook signature verification failed", "error", err)
 			encodeError(w, "Invalid signature", http.StatusBadRequest)
 			return
 		}
+		logger.Debug("Stripe webhook received", "type", event.Type, "id", event.ID)
 
 		// Always return 200 to Stripe after signature verification, even if
 		// processing fails. Returning non-2xx causes Stripe to retry indefinitely
@@ -222,7 +314,7 @@ func StripeWebhookHandler(cfg *config.Config) http.HandlerFunc {
 		case "checkout.session.completed":
 			var sess stripe.CheckoutSession
 			if err := json.Unmarshal(event.Data.Raw, &sess); err != nil {
-				cfg.Logger.Error("failed to parse checkout session", "error", err)
+				logger.Error("failed to parse checkout session", "error", err)
 				break
 			}
 
@@ -233,12 +325,12 @@ func StripeWebhookHandler(cfg *config.Config) http.HandlerFunc {
 				eventIDStr := sess.Metadata["event_id"]
 				eventID, err := strconv.ParseUint(eventIDStr, 10, 32)
 				if err != nil {
-					cfg.Logger.Error("invalid event_id in webhook metadata", "event_id", eventIDStr)
+					logger.Error("invalid event_id in webhook metadata", "event_id", eventIDStr)
 					break
 				}
 				// Idempotent update: only update if not already paid
 				// Wrap payment mark + CFP auto-open in a transaction so both succeed or neither does
-				if txErr := cfg.DB.Transaction(func(tx *gorm.DB) error {
+				if txErr := cfg.DBCtx(r).Transaction(func(tx *gorm.DB) error {
 					result := tx.Model(&models.Event{}).
 						Where("id = ? AND is_paid = ?", eventID, false).
 						Updates(map[string]interface{}{
@@ -250,8 +342,8 @@ func StripeWebhookHandler(cfg *config.Config) http.HandlerFunc {
 					}
 					if result.RowsAffected > 0 {
 						// Auto-open CFP for draft events after payment, but only
-						// if CFP dates are configured. Without dates, IsCFPOpen()
-						// returns false and speakers can't submit despite "open" status.
+						// if CFP dates are configured. An open CFP without dates
+						// never closes, which the organizer should choose explicitly.
 						if err := tx.Model(&models.Event{}).
 							Where("id = ? AND cfp_status = ? AND cfp_open_at IS NOT NULL AND cfp_close_at IS NOT NULL AND cfp_open_at != ? AND cfp_close_at != ?",
 								eventID, models.CFPStatusDraft, time.Time{}, time.Time{}).
@@ -261,37 +353,37 @@ func StripeWebhookHandler(cfg *config.Config) http.HandlerFunc {
 					}
 					return nil
 				}); txErr != nil {
-					cfg.Logger.Error("failed to update event payment", "error", txErr, "event_id", eventID)
+					logger.Error("failed to update event payment", "error", txErr, "event_id", eventID)
 				} else {
-					cfg.Logger.Info("event listing payment completed", "event_id", eventID, "session_id", sess.ID)
+					logger.Info("event listing payment completed", "event_id", eventID, "session_id", sess.ID)
 				}
 
 			case "proposal_submission":
 				proposalIDStr := sess.Metadata["proposal_id"]
 				proposalID, err := strconv.ParseUint(proposalIDStr, 10, 32)
 				if err != nil {
-					cfg.Logger.Error("invalid proposal_id in webhook metadata", "proposal_id", proposalIDStr)
+					logger.Error("invalid proposal_id in webhook metadata", "proposal_id", proposalIDStr)
 					break
 				}
 				// Idempotent update: only update if not already paid
-				result := cfg.DB.Model(&models.Proposal{}).
+				result := cfg.DBCtx(r).Model(&models.Proposal{}).
 					Where("id = ? AND is_paid = ?", proposalID, false).
 					Updates(map[string]interface{}{
 						"is_paid":           true,
 						"stripe_payment_id": sess.ID,
 					})
 				if result.Error != nil {
-					cfg.Logger.Error("failed to update proposal payment", "error", result.Error, "proposal_id", proposalID)
+					logger.Error("failed to update proposal payment", "error", result.Error, "proposal_id", proposalID)
 				} else {
-					cfg.Logger.Info("proposal submission payment completed", "proposal_id", proposalID, "session_id", sess.ID)
+					logger.Info("proposal submission payment completed", "proposal_id", proposalID, "session_id", sess.ID)
 				}
 
 			default:
-				cfg.Logger.Warn("unknown payment type in webhook metadata", "type", paymentType)
+				logger.Warn("unknown payment type in webhook metadata", "type", paymentType)
 			}
 
 		default:
-			cfg.Logger.Info("unhandled Stripe event type", "type", event.Type)
+			logger.Info("unhandled Stripe event type", "type", event.Type)
 		}
 
 		w.Header().Set("Content-Type", "application/json")
@@ -0,0 +1,203 @@
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/sreday/cfp.ninja/pkg/models"
+)
+
+// pretalxCodeAlphabet is the alphabet of pretalx submission and speaker
+// codes, which leaves out characters that are easily confused
+const pretalxCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ3789"
+
+// pretalxCodeLen is the length of pretalx codes
+const pretalxCodeLen = 6
+
+// pretalxExport is the pretalx export of an event's proposals, in the shape
+// of a page of pretalx's submissions API so its importers can read it as is
+type pretalxExport struct {
+	Count    int                 `json:"count"`
+	Next     *string             `json:"next"`
+	Previous *string             `json:"previous"`
+	Results  []pretalxSubmission `json:"results"`
+}
+
+// pretalxSubmission is a proposal as a pretalx submission. cfp.ninja has no
+// tracks, so Track is always null.
+type pretalxSubmission struct {
+	Code           string            `json:"code"`
+	Title          string            `json:"title"`
+	SubmissionType map[string]string `json:"submission_type"`
+	Track          map[string]string `json:"track"`
+	State          string            `json:"state"`
+	Abstract       string            `json:"abstract"`
+	Description    string            `json:"description"`
+	Duration       int               `json:"duration"`
+	Speakers       []pretalxSpeaker  `json:"speakers"`
+	Answers        []pretalxAnswer   `json:"answers"`
+	Tags           []string          `json:"tags"`
+}
+
+// pretalxSpeaker is a proposal speaker as a pretalx speaker. The same email
+// always gets the same code, so a speaker on several proposals is one
+// pretalx speaker.
+type pretalxSpeaker struct {
+	Code      string `json:"code"`
+	Name      string `json:"name"`
+	Email     string `json:"email"`
+	Biography string `json:"biography"`
+}
+
+// pretalxAnswer is the answer to one of the event's custom questions
+type pretalxAnswer struct {
+	Question pretalxQuestion `json:"question"`
+	Answer   string          `json:"answer"`
+}
+
+// pretalxQuestion is the question an answer belongs to
+type pretalxQuestion struct {
+	ID       string            `json:"id"`
+	Question map[string]string `json:"question"`
+}
+
+// pretalxState maps a proposal's status to a pretalx submission state.
+// pretalx has no tentative state, so tentative proposals are still
+// submitted; accepted proposals whose speakers confirmed are confirmed.
+func pretalxState(p *models.Proposal) string {
+	switch p.Status {
+	case models.ProposalStatusAccepted:
+		if p.AttendanceConfirmed {
+			return "confirmed"
+		}
+		return "accepted"
+	case models.ProposalStatusRejected:
+		return "rejected"
+	case models.ProposalStatusCancelled:
+		return "canceled"
+	default:
+		return "submitted"
+	}
+}
+
+// pretalxCode encodes n as a pretalx code
+func pretalxCode(n uint64) string {
+	base := uint64(len(pretalxCodeAlphabet))
+	code := make([]byte, pretalxCodeLen)
+	for i := pretalxCodeLen - 1; i >= 0; i-- {
+		code[i] = pretalxCodeAlphabet[n%base]
+		n /= base
+	}
+	return string(code)
+}
+
+// pretalxSpeakerCode derives a speaker's code from their email, ignoring case
+func pretalxSpeakerCode(email string) string {
+	sum := sha256.Sum256([]byte(strings.ToLower(strings.TrimSpace(email))))
+	return pretalxCode(binary.BigEndian.Uint64(sum[:8]))
+}
+
+// pretalxAnswerText formats a custom answer the way pretalx stores answers:
+// choices joined by commas and checkboxes as True or False
+func pretalxAnswerText(v interface{}) string {
+	switch a := v.(type) {
+	case string:
+		return a
+	case bool:
+		if a {
+			return "True"
+		}
+		return "False"
+	case []interface{}:
+		parts := make([]string, len(a))
+		for i, p := range a {
+			parts[i] = pretalxAnswerText(p)
+		}
+		return strings.Join(parts, ", ")
+	case nil:
+		return ""
+	default:
+		return fmt.Sprint(a)
+	}
+}
+
+// buildPretalxExport converts proposals to pretalx submissions. Fields pretalx
+// does not know (level, rating, notes, speaker company, job title and
+// LinkedIn) are left out. Answers follow the order of the event's questions;
+// answers to questions the event no longer has come last, with the question
+// ID as its text.
+func buildPretalxExport(event *models.Event, proposals []models.Proposal) pretalxExport {
+	var questions []models.CustomQuestion
+	if len(event.CFPQuestions) > 0 {
+		_ = json.Unmarshal(event.CFPQuestions, &questions)
+	}
+
+	results := make([]pretalxSubmission, len(proposals))
+	for i := range proposals {
+		p := &proposals[i]
+		s := pretalxSubmission{
+			Code:           pretalxCode(uint64(p.ID)),
+			Title:          p.Title,
+			SubmissionType: map[string]string{"en": pretalxSubmissionType(p.Format)},
+			State:          pretalxState(p),
+			Abstract:       p.Abstract,
+			Duration:       p.Duration,
+			Speakers:       []pretalxSpeaker{},
+			Answers:        []pretalxAnswer{},
+			Tags:           []string{},
+		}
+		for _, sp := range parseSpeakers(p.Speakers) {
+			s.Speakers = append(s.Speakers, pretalxSpeaker{
+				Code:      pretalxSpeakerCode(sp.Email),
+				Name:      sp.Name,
+				Email:     sp.Email,
+				Biography: sp.Bio,
+			})
+		}
+		for _, tag := range strings.Split(p.Tags, ",") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				s.Tags = append(s.Tags, tag)
+			}
+		}
+
+		answers, _ := p.GetCustomAnswers()
+		for _, q := range questions {
+			if v, ok := answers[q.ID]; ok {
+				s.Answers = append(s.Answers, pretalxAnswer{
+					Question: pretalxQuestion{ID: q.ID, Question: map[string]string{"en": q.Text}},
+					Answer:   pretalxAnswerText(v),
+				})
+				delete(answers, q.ID)
+			}
+		}
+		orphans := make([]string, 0, len(answers))
+		for id := range answers {
+			orphans = append(orphans, id)
+		}
+		sort.Strings(orphans)
+		for _, id := range orphans {
+			s.Answers = append(s.Answers, pretalxAnswer{
+				Question: pretalxQuestion{ID: id, Question: map[string]string{"en": id}},
+				Answer:   pretalxAnswerText(answers[id]),
+			})
+		}
+		results[i] = s
+	}
+	return pretalxExport{Count: len(results), Results: results}
+}
+
+// pretalxSubmissionType is the pretalx submission type name of a proposal format
+func pretalxSubmissionType(f models.ProposalFormat) string {
+	switch f {
+	case models.FormatWorkshop:
+		return "Workshop"
+	case models.FormatLightning:
+		return "Lightning talk"
+	default:
+		return "Talk"
+	}
+}
@@ -0,0 +1,90 @@
+package api
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gorm.io/gorm"
+
+	"github.com/sreday/cfp.ninja/pkg/models"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+// pretalxFixture is an event with multi-speaker talks and custom answers,
+// including one to a question the event has since removed
+func pretalxFixture() (*models.Event, []models.Proposal) {
+	event := &models.Event{
+		Name: "SREday London 2026",
+		Slug: "sreday-london-2026",
+		CFPQuestions: []byte(`[
+			{"id": "travel", "text": "Do you need travel support?", "type": "select", "options": ["Yes", "No"]},
+			{"id": "topics", "text": "Which topics does it cover?", "type": "multiselect", "options": ["SRE", "Go", "Kubernetes"]},
+			{"id": "recording", "text": "May we record your talk?", "type": "checkbox"}
+		]`),
+	}
+	speakers := func(s ...models.Speaker) []byte {
+		data, _ := json.Marshal(s)
+		return data
+	}
+	jane := models.Speaker{Name: "Jane Doe", Email: "jane@example.com", Bio: "SRE at Acme.", Company: "Acme", Primary: true}
+	proposals := []models.Proposal{
+		{
+			Model: gorm.Model{ID: 1}, Title: "Building Reliable Systems", Abstract: "How we keep things up.",
+			Format: models.FormatTalk, Duration: 30, Tags: "sre, reliability", Level: "intermediate",
+			Status: models.ProposalStatusAccepted, AttendanceConfirmed: true,
+			Speakers:      speakers(jane, models.Speaker{Name: "John Smith", Email: "john@example.com", Bio: "DevOps lead."}),
+			CustomAnswers: []byte(`{"travel": "Yes", "topics": ["SRE", "Go"], "recording": true, "old_question": "kept"}`),
+		},
+		{
+			Model: gorm.Model{ID: 2}, Title: "Hands-on Chaos Engineering", Abstract: "Break things safely.",
+			Format: models.FormatWorkshop, Duration: 120, Status: models.ProposalStatusTentative,
+			Speakers: speakers(models.Speaker{Name: "Jane Doe", Email: "JANE@example.com", Bio: "SRE at Acme."}),
+		},
+		{
+			Model: gorm.Model{ID: 3}, Title: "Five Minutes of Go", Abstract: "Quick tips.",
+			Format: models.FormatLightning, Duration: 5, Status: models.ProposalStatusCancelled,
+			Speakers:      speakers(models.Speaker{Name: "Ann Lee", Email: "ann@example.com"}),
+			CustomAnswers: []byte(`{"recording": false}`),
+		},
+	}
+	return event, proposals
+}
+
+func TestBuildPretalxExport_Golden(t *testing.T) {
+	event, proposals := pretalxFixture()
+	got, err := json.MarshalIndent(buildPretalxExport(event, proposals), "", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join("testdata", "pretalx_export.golden.json")
+	if *updateGolden {
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read golden file (run with -update to create it): %v", err)
+	}
+	if string(got) != string(want) {
+		t.Errorf("%s differs from the export (run with -update if the change is intended):\n%s", path, got)
+	}
+}
+
+func TestPretalxSpeakerCode(t *testing.T) {
+	if pretalxSpeakerCode("Jane@Example.com ") != pretalxSpeakerCode("jane@example.com") {
+		t.Error("expected the same code for the same email in any case")
+	}
+	if got := pretalxCode(0); got != "AAAAAA" {
+		t.Errorf("pretalxCode(0) = %q", got)
+	}
+	if pretalxCode(1) == pretalxCode(2) {
+		t.Error("expected distinct codes")
+	}
+}
@@ -0,0 +1,228 @@
+package api
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sreday/cfp.ninja/pkg/config"
+	"github.com/sreday/cfp.ninja/pkg/models"
+)
+
+const (
+	defaultPreviewLinkDays  = 7
+	maxPreviewLinkDays      = 90
+	maxPreviewLinksPerEvent = 20
+)
+
+// previewLinkResponse is a preview link as shown to the event creator
+type previewLinkResponse struct {
+	ID        uint      `json:"id"`
+	Token     string    `json:"token"`
+	URL       string    `json:"url"`
+	ExpiresAt time.Time `json:"expires_at"`
+	CreatedAt time.Time `json:"created_at"`
+	Expired   bool      `json:"expired"`
+}
+
+// signPreviewToken produces an HMAC-SHA256 signature for a preview token
+// payload. The "preview|" prefix keeps these signatures distinct from OAuth
+// state signatures made with the same secret.
+func signPreviewToken(payload, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("preview|" + payload))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// encodePreviewToken builds the token for a preview link. The token format is
+// "linkID.eventID.expiresUnix.hmac".
+func encodePreviewToken(link *models.EventPreviewLink, secret string) string {
+	payload := fmt.Sprintf("%d.%d.%d", link.ID, link.EventID, link.ExpiresAt.Unix())
+	return payload + "." + signPreviewToken(payload, secret)
+}
+
+// decodePreviewToken verifies a preview token's signature and returns the
+// link and event IDs and expiry it carries. Returns ok=false if the token is
+// malformed or the signature is invalid.
+func decodePreviewToken(token, secret string) (linkID, eventID uint, expiresAt time.Time, ok bool) {
+	idx := strings.LastIndex(token, ".")
+	if idx < 0 || idx == len(token)-1 {
+		return 0, 0, time.Time{}, false
+	}
+	payload, sig := token[:idx], token[idx+1:]
+	if !hmac.Equal([]byte(sig), []byte(signPreviewToken(payload, secret))) {
+		return 0, 0, time.Time{}, false
+	}
+
+	parts := strings.Split(payload, ".")
+	if len(parts) != 3 {
+		return 0, 0, time.Time{}, false
+	}
+	link, err1 := strconv.ParseUint(parts[0], 10, 32)
+	event, err2 := strconv.ParseUint(parts[1], 10, 32)
+	exp, err3 := strconv.ParseInt(parts[2], 10, 64)
+	if err1 != nil || err2 != nil || err3 != nil {
+		return 0, 0, time.Time{}, false
+	}
+	return uint(link), uint(event), time.Unix(exp, 0), true
+}
+
+// validPreviewToken reports whether token grants a preview of the event: it
+// must be signed by us, issued for this event, unexpired, and not revoked.
+func validPreviewToken(cfg *config.Config, r *http.Request, token string, event *models.Event) (bool, error) {
+	if token == "" || cfg.JWTSecret == "" {
+		return false, nil
+	}
+	linkID, eventID, expiresAt, ok := decodePreviewToken(token, cfg.JWTSecret)
+	if !ok || eventID != event.ID || !cfg.Now().Before(expiresAt) {
+		return false, nil
+	}
+	var count int64
+	if err := cfg.DBCtx(r).Model(&models.EventPreviewLink{}).
+		Where("id = ? AND event_id = ?", linkID, eventID).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func newPreviewLinkResponse(cfg *config.Config, event *models.Event, link *models.EventPreviewLink) previewLinkResponse {
+	token := encodePreviewToken(link, cfg.JWTSecret)
+	return previewLinkResponse{
+		ID:        link.ID,
+		Token:     token,
+		URL:       cfg.BaseURL + "/e/" + url.PathEscape(event.Slug) + "?preview_token=" + url.QueryEscape(token),
+		ExpiresAt: link.ExpiresAt,
+		CreatedAt: link.CreatedAt,
+		Expired:   !cfg.Now().Before(link.ExpiresAt),
+	}
+}
+
+// CreatePreviewLinkHandler creates a signed link that shows the draft event
+// page to anyone holding it, until it expires or is revoked (creator only).
+// POST /api/v0/events/{id}/preview-links
+func CreatePreviewLinkHandler(cfg *config.Config) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		event, ok := loadEventForCreator(cfg, w, r, "manage preview links")
+		if !ok {
+			return
+		}
+		user := GetUserFromContext(r.Context())
+
+		// The body is optional; an empty one gets the default expiry
+		var req struct {
+			ExpiresInDays *int `json:"expires_in_days"`
+		}
+		if err := decodeJSON(w, r, MaxSmallBodySize, &req); err != nil && !errors.Is(err, errEmptyBody) {
+			encodeBodyError(w, r, err)
+			return
+		}
+		days := defaultPreviewLinkDays
+		if req.ExpiresInDays != nil {
+			days = *req.ExpiresInDays
+		}
+		if days < 1 || days > maxPreviewLinkDays {
+			var errs validationErrors
+			errs.add("expires_in_days", fmt.Sprintf("expires_in_days must be between 1 and %d", maxPreviewLinkDays))
+			encodeValidationErrors(w, r, errs)
+			return
+		}
+
+		var count int64
+		if err := cfg.DBCtx(r).Model(&models.EventPreviewLink{}).Where("event_id = ?", event.ID).Count(&count).Error; err != nil {
+			cfg.Logger.Error("failed to count preview links", "error", err, "event_id", event.ID)
+			encodeAPIError(w, r, "Failed to create preview link", http.StatusInternalServerError)
+			return
+		}
+		if count >= maxPreviewLinksPerEvent {
+			encodeAPIError(w, r, fmt.Sprintf("An event can have at most %d preview links; revoke one first", maxPreviewLinksPerEvent), http.StatusBadRequest)
+			return
+		}
+
+		link := models.EventPreviewLink{
+			EventID:     event.ID,
+			CreatedByID: user.ID,
+			ExpiresAt:   cfg.Now().Add(time.Duration(days) * 24 * time.Hour).Truncate(time.Second),
+		}
+		if err := cfg.DBCtx(r).Create(&link).Error; err != nil {
+			cfg.Logger.Error("failed to create preview link", "error", err, "event_id", event.ID)
+			encodeAPIError(w, r, "Failed to create preview link", http.StatusInternalServerError)
+			return
+		}
+
+		cfg.Logger.Info("preview link created",
+			"event_id", event.ID,
+			"link_id", link.ID,
+			"expires_at", link.ExpiresAt,
+			"actor_id", user.ID,
+		)
+
+		encodeCreated(w, r, fmt.Sprintf("/api/v0/events/%d/preview-links/%d", event.ID, link.ID), newPreviewLinkResponse(cfg, event, &link))
+	}
+}
+
+// ListPreviewLinksHandler lists an event's preview links, newest first
+// (creator only).
+// GET /api/v0/events/{id}/preview-links
+func ListPreviewLinksHandler(cfg *config.Config) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		event, ok := loadEventForCreator(cfg, w, r, "manage preview links")
+		if !ok {
+			return
+		}
+
+		var links []models.EventPreviewLink
+		if err := cfg.DBCtx(r).Where("event_id = ?", event.ID).Order("created_at DESC, id DESC").Find(&links).Error; err != nil {
+			cfg.Logger.Error("failed to list preview links", "error", err, "event_id", event.ID)
+			encodeAPIError(w, r, "Failed to load preview links", http.StatusInternalServerError)
+			return
+		}
+
+		result := make([]previewLinkResponse, len(links))
+		for i := range links {
+			result[i] = newPreviewLinkResponse(cfg, event, &links[i])
+		}
+		encodeResponse(w, r, result)
+	}
+}
+
+// RevokePreviewLinkHandler revokes a preview link; its token stops working
+// immediately (creator only).
+// DELETE /api/v0/events/{id}/preview-links/{linkId}
+func RevokePreviewLinkHandler(cfg *config.Config) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		event, ok := loadEventForCreator(cfg, w, r, "manage preview links")
+		if !ok {
+			return
+		}
+		user := GetUserFromContext(r.Context())
+
+		linkID, err := strconv.ParseUint(r.PathValue("linkId"), 10, 32)
+		if err != nil {
+			encodeAPIError(w, r, "Invalid preview link ID", http.StatusBadRequest)
+			return
+		}
+
+		result := cfg.DBCtx(r).Where("id = ? AND event_id = ?", linkID, event.ID).Delete(&models.EventPreviewLink{})
+		if result.Error != nil {
+			cfg.Logger.Error("failed to revoke preview link", "error", result.Error, "event_id", event.ID, "link_id", linkID)
+			encodeAPIError(w, r, "Failed to revoke preview link", http.StatusInternalServerError)
+			return
+		}
+		if result.RowsAffected == 0 {
+			encodeAPIError(w, r, "Preview link not found", http.StatusNotFound)
+			return
+		}
+
+		cfg.Logger.Info("preview link revoked", "event_id", event.ID, "link_id", linkID, "actor_id", user.ID)
+
+		encodeResponse(w, r, map[string]string{"message": "Preview link revoked"})
+	}
+}
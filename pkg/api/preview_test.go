@@ -0,0 +1,49 @@
+package api
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sreday/cfp.ninja/pkg/models"
+)
+
+func TestPreviewToken_RoundTrip(t *testing.T) {
+	expires := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	token := encodePreviewToken(&models.EventPreviewLink{ID: 7, EventID: 42, ExpiresAt: expires}, testJWTSecret)
+
+	linkID, eventID, expiresAt, ok := decodePreviewToken(token, testJWTSecret)
+	if !ok {
+		t.Fatal("expected ok=true for a freshly encoded token")
+	}
+	if linkID != 7 || eventID != 42 || !expiresAt.Equal(expires) {
+		t.Errorf("expected link 7, event 42, expiry %v; got %d, %d, %v", expires, linkID, eventID, expiresAt)
+	}
+}
+
+func TestPreviewToken_Rejected(t *testing.T) {
+	token := encodePreviewToken(&models.EventPreviewLink{ID: 7, EventID: 42, ExpiresAt: time.Now()}, testJWTSecret)
+	sig := token[strings.LastIndex(token, "."):]
+
+	testCases := []struct {
+		name   string
+		token  string
+		secret string
+	}{
+		{"wrong secret", token, "wrong-secret"},
+		{"other event", strings.Replace(token, ".42.", ".43.", 1), testJWTSecret},
+		{"extended expiry", token[:strings.Index(token, ".42.")+4] + "9999999999" + sig, testJWTSecret},
+		{"oauth state signature", "7.42.1." + signOAuthState("7.42.1", testJWTSecret), testJWTSecret},
+		{"empty", "", testJWTSecret},
+		{"no dot", "abcdef", testJWTSecret},
+		{"dot at end", "7.42.1.", testJWTSecret},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, _, _, ok := decodePreviewToken(tc.token, tc.secret); ok {
+				t.Errorf("expected ok=false for %q", tc.token)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package api
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/sreday/cfp.ninja/pkg/config"
+	"github.com/sreday/cfp.ninja/pkg/models"
+)
+
+// errSpeakerEmailsHidden is the 403 message for reviewers asking for
+// something that needs the speaker emails the event hides from them
+const errSpeakerEmailsHidden = "Speaker emails are hidden from reviewers of this event"
+
+// proposalView is what one user may see of an event's proposals. Every
+// handler returning proposals shapes them with it, rather than blanking
+// fields itself.
+//
+// There are no organizer roles yet, so the event creator (and platform
+// admins) manage the event and every co-organizer counts as a reviewer.
+type proposalView struct {
+	userID     uint
+	organizer  bool // sees organizer notes and missing required answers
+	hideEmails bool // reviewer of an event with HideSpeakerEmailsFromReviewers
+	questions  []models.CustomQuestion
+	now        time.Time
+}
+
+// newProposalView returns the view of the event's proposals for user. The
+// event's organizers must be loaded.
+func newProposalView(cfg *config.Config, event *models.Event, user *models.User) proposalView {
+	v := proposalView{userID: user.ID, organizer: event.IsOrganizer(user.ID), now: cfg.Now()}
+	isCreator := event.CreatedByID != nil && *event.CreatedByID == user.ID
+	if v.organizer && !isCreator && !cfg.IsAdmin(user.Email) {
+		v.hideEmails = event.HideSpeakerEmailsFromReviewers
+	}
+	if v.organizer {
+		// Questions that fail to parse are reported where they're validated
+		v.questions, _ = event.GetCFPQuestions()
+	}
+	return v
+}
+
+// shape removes what the view may not see from p, in place, and fills in
+// what only organizers see. Speakers always see their own proposals in full,
+// except for the organizer notes.
+func (v proposalView) shape(p *models.Proposal) {
+	if !v.organizer {
+		p.OrganizerNotes = ""
+		return
+	}
+	answers, _ := p.GetCustomAnswers()
+	p.MissingRequiredAnswers = models.MissingAnswers(answers, v.questions, v.now)
+	isOwner := p.CreatedByID != nil && *p.CreatedByID == v.userID
+	if !v.hideEmails || isOwner {
+		return
+	}
+	speakers := parseSpeakers(p.Speakers)
+	for i := range speakers {
+		speakers[i].Email = maskEmail(speakers[i].Email)
+	}
+	if data, err := json.Marshal(speakers); err == nil {
+		p.Speakers = data
+	}
+}
+
+// shapeAll shapes each of proposals
+func (v proposalView) shapeAll(proposals []models.Proposal) {
+	for i := range proposals {
+		v.shape(&proposals[i])
+	}
+}
+
+// maskEmail hides the local part of an email, keeping the domain so
+// reviewers can still tell affiliations apart: jane@example.com becomes
+// ***@example.com
+func maskEmail(email string) string {
+	if email == "" {
+		return ""
+	}
+	if at := strings.LastIndex(email, "@"); at >= 0 {
+		return "***" + email[at:]
+	}
+	return "***"
+}
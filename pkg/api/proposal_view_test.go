@@ -0,0 +1,73 @@
+package api
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/sreday/cfp.ninja/pkg/config"
+	"github.com/sreday/cfp.ninja/pkg/models"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+func TestMaskEmail(t *testing.T) {
+	for email, want := range map[string]string{
+		"jane@example.com": "***@example.com",
+		"a@b@example.com":  "***@example.com",
+		"not-an-email":     "***",
+		"":                 "",
+	} {
+		if got := maskEmail(email); got != want {
+			t.Errorf("maskEmail(%q) = %q, want %q", email, got, want)
+		}
+	}
+}
+
+func TestProposalViewShape(t *testing.T) {
+	cfg := &config.Config{AdminEmails: []string{"admin@example.com"}}
+	creatorID, reviewerID, speakerID := uint(1), uint(2), uint(3)
+	creator := &models.User{Model: gorm.Model{ID: creatorID}, Email: "creator@example.com"}
+	reviewer := &models.User{Model: gorm.Model{ID: reviewerID}, Email: "reviewer@example.com"}
+	speaker := &models.User{Model: gorm.Model{ID: speakerID}, Email: "jane@example.com"}
+	admin := &models.User{Model: gorm.Model{ID: 4}, Email: "admin@example.com"}
+	event := &models.Event{
+		CreatedByID:                    &creatorID,
+		Organizers:                     []models.User{*creator, *reviewer, *admin},
+		HideSpeakerEmailsFromReviewers: true,
+	}
+
+	newProposal := func(ownerID uint) models.Proposal {
+		speakers, _ := json.Marshal([]models.Speaker{{Name: "Jane", Email: "jane@example.com"}})
+		return models.Proposal{CreatedByID: &ownerID, Speakers: datatypes.JSON(speakers), OrganizerNotes: "strong"}
+	}
+	emailOf := func(p models.Proposal) string { return parseSpeakers(p.Speakers)[0].Email }
+
+	tests := []struct {
+		name      string
+		user      *models.User
+		owner     uint
+		hide      bool
+		wantEmail string
+		wantNotes string
+	}{
+		{"creator", creator, speakerID, true, "jane@example.com", "strong"},
+		{"platform admin", admin, speakerID, true, "jane@example.com", "strong"},
+		{"reviewer", reviewer, speakerID, true, "***@example.com", "strong"},
+		{"reviewer with emails shown", reviewer, speakerID, false, "jane@example.com", "strong"},
+		{"reviewer owning the proposal", reviewer, reviewerID, true, "jane@example.com", "strong"},
+		{"speaker", speaker, speakerID, true, "jane@example.com", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event.HideSpeakerEmailsFromReviewers = tt.hide
+			p := newProposal(tt.owner)
+			newProposalView(cfg, event, tt.user).shape(&p)
+			if got := emailOf(p); got != tt.wantEmail {
+				t.Errorf("speaker email = %q, want %q", got, tt.wantEmail)
+			}
+			if p.OrganizerNotes != tt.wantNotes {
+				t.Errorf("organizer notes = %q, want %q", p.OrganizerNotes, tt.wantNotes)
+			}
+		})
+	}
+}
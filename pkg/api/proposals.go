@@ -4,12 +4,17 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
 	"net/http"
 	"net/mail"
+	"reflect"
 	"regexp"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
+	"unicode"
 
 	"github.com/sreday/cfp.ninja/pkg/config"
 	"github.com/sreday/cfp.ninja/pkg/email"
@@ -22,6 +27,10 @@ import (
 // errMaxAcceptedReached is returned when the accepted proposal limit is hit.
 var errMaxAcceptedReached = errors.New("maximum accepted proposals reached")
 
+// errNotCancellable is returned when a proposal stopped being accepted and
+// confirmed between the emergency-cancel checks and the locked re-read.
+var errNotCancellable = errors.New("Proposal can no longer be emergency-cancelled")
+
 // Rating constants for proposal reviews
 const (
 	MinRating = 0 // Minimum rating value (not rated/lowest)
@@ -44,43 +53,253 @@ const (
 // MaxCustomAnswerLen is the maximum length for a custom question answer value.
 const MaxCustomAnswerLen = 5000
 
+// AnswersRequestPeriod is how long speakers asked for missing answers by an
+// organizer may edit them, whatever their proposal's status
+const AnswersRequestPeriod = 7 * 24 * time.Hour
+
+// MaxCustomAnswersSize is the most bytes a proposal's custom answers may take
+// together, as stored
+const MaxCustomAnswersSize = 50000
+
+// errCustomAnswersNotObject is the validation message for custom answers
+// that aren't an object keyed by question ID
+const errCustomAnswersNotObject = "Custom answers must be an object keyed by question ID"
+
 // validateCustomAnswers checks that custom answer values match expected types
-// from the event's question definitions. Returns an error message or empty string.
-func validateCustomAnswers(answers map[string]interface{}, questions []models.CustomQuestion) string {
+// from the event's question definitions, adding a field error per invalid answer,
+// and that the answers fit within MaxCustomAnswersSize together.
+func validateCustomAnswers(answers map[string]interface{}, questions []models.CustomQuestion, errs *validationErrors) {
+	// Answers that pass on their own can still add up to more than a row
+	// should hold, so report the longest one for the speaker to trim
+	if data, _ := json.Marshal(answers); len(data) > MaxCustomAnswersSize {
+		largest, largestSize := "", -1
+		for id, val := range answers {
+			v, _ := json.Marshal(val)
+			if len(v) > largestSize || (len(v) == largestSize && id < largest) {
+				largest, largestSize = id, len(v)
+			}
+		}
+		errs.add("custom_answers."+largest, fmt.Sprintf("Custom answers must be at most %d characters in total; shorten the answer for '%s'", MaxCustomAnswersSize, largest))
+		return
+	}
+
 	questionMap := make(map[string]models.CustomQuestion)
 	for _, q := range questions {
 		questionMap[q.ID] = q
 	}
 
-	for id, val := range answers {
+	// Sort for a stable error order
+	ids := make([]string, 0, len(answers))
+	for id := range answers {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		val := answers[id]
+		field := "custom_answers." + id
 		q, known := questionMap[id]
 		if !known {
-			return "Unknown question: '" + id + "'"
+			errs.add(field, "Unknown question: '"+id+"'")
+			continue
 		}
 
 		switch q.Type {
 		case "checkbox":
 			b, ok := val.(bool)
 			if !ok {
-				return "Answer for '" + id + "' must be a boolean"
-			}
-			if q.Required && !b {
-				return "Answer for '" + id + "' must be checked"
+				errs.add(field, "Answer for '"+id+"' must be a boolean")
+			} else if q.Required && !b {
+				errs.add(field, "Answer for '"+id+"' must be checked")
 			}
 		default: // text, select, multiselect, and any future string types
 			str, ok := val.(string)
 			if !ok {
-				return "Answer for '" + id + "' must be a string"
+				errs.add(field, "Answer for '"+id+"' must be a string")
+			} else if q.Required && strings.TrimSpace(str) == "" {
+				errs.add(field, "Answer for '"+id+"' is required")
+			} else if len(str) > MaxCustomAnswerLen {
+				errs.add(field, "Answer for '"+id+"' must be at most 5000 characters")
 			}
-			if q.Required && strings.TrimSpace(str) == "" {
-				return "Answer for '" + id + "' is required"
+		}
+	}
+}
+
+// validateRequiredAnswers adds a field error for each required question
+// without an answer
+func validateRequiredAnswers(answers map[string]interface{}, questions []models.CustomQuestion, errs *validationErrors) {
+	for _, q := range questions {
+		if q.Required {
+			if _, ok := answers[q.ID]; !ok {
+				errs.add("custom_answers."+q.ID, "Required question '"+q.ID+"' not answered")
 			}
-			if len(str) > MaxCustomAnswerLen {
-				return "Answer for '" + id + "' must be at most 5000 characters"
+		}
+	}
+}
+
+// activeQuestions returns the questions taking answers at now
+func activeQuestions(questions []models.CustomQuestion, now time.Time, grace time.Duration) []models.CustomQuestion {
+	var active []models.CustomQuestion
+	for _, q := range questions {
+		if q.IsOpenAt(now, grace) {
+			active = append(active, q)
+		}
+	}
+	return active
+}
+
+// validateAnswerWindows adds a field error for each answer to a question
+// outside its open_at/close_at window. Answers equal to the previously saved
+// ones are kept, so a proposal can still be edited after a window closes.
+func validateAnswerWindows(answers, previous map[string]interface{}, questions []models.CustomQuestion, now time.Time, grace time.Duration, errs *validationErrors) {
+	for _, q := range questions {
+		answer, ok := answers[q.ID]
+		if !ok || q.IsOpenAt(now, grace) {
+			continue
+		}
+		if saved, ok := previous[q.ID]; ok && reflect.DeepEqual(saved, answer) {
+			continue
+		}
+		errs.add("custom_answers."+q.ID, "Question '"+q.ID+"' is not accepting answers")
+	}
+}
+
+// countWords counts the words in s. Words are separated by any Unicode
+// whitespace or zero-width space, and runs of punctuation such as a dash
+// between words are not counted.
+func countWords(s string) int {
+	n := 0
+	for _, field := range strings.FieldsFunc(s, func(r rune) bool {
+		return unicode.IsSpace(r) || r == '\u200b'
+	}) {
+		if strings.IndexFunc(field, func(r rune) bool { return unicode.IsLetter(r) || unicode.IsNumber(r) }) >= 0 {
+			n++
+		}
+	}
+	return n
+}
+
+// validateAbstractWords checks the abstract against the event's word limits,
+// stating the current count. Organizers of the event are exempt, so the
+// event must have its Organizers loaded.
+func validateAbstractWords(event *models.Event, abstract string, userID uint, errs *validationErrors) {
+	if event.AbstractMinWords == nil && event.AbstractMaxWords == nil {
+		return
+	}
+	if abstract == "" || event.IsOrganizer(userID) {
+		return
+	}
+	words := countWords(abstract)
+	if event.AbstractMinWords != nil && words < *event.AbstractMinWords {
+		errs.add("abstract", fmt.Sprintf("Abstract must be at least %d words (currently %d)", *event.AbstractMinWords, words))
+	} else if event.AbstractMaxWords != nil && words > *event.AbstractMaxWords {
+		errs.add("abstract", fmt.Sprintf("Abstract must be at most %d words (currently %d)", *event.AbstractMaxWords, words))
+	}
+}
+
+// validateCoCAcceptance requires coc_accepted: true when the event asks
+// speakers to accept its code of conduct. It reports whether the speaker
+// accepted it.
+func validateCoCAcceptance(event *models.Event, accepted interface{}, errs *validationErrors) bool {
+	if !event.RequireCoCAcceptance {
+		return false
+	}
+	switch v := accepted.(type) {
+	case bool:
+		if v {
+			return true
+		}
+	case nil:
+	default:
+		errs.add("coc_accepted", "coc_accepted must be a boolean")
+		return false
+	}
+	errs.add("coc_accepted", "You must accept the event's code of conduct ("+event.CoCURL+")")
+	return false
+}
+
+// proposalSource tells where a submission came from: the cfp CLI identifies
+// itself with "X-Client: cfp-cli/<version>" (or that User-Agent), browsers
+// authenticate with the session cookie, and everything else sends a token
+func proposalSource(r *http.Request) string {
+	if strings.HasPrefix(r.Header.Get("X-Client"), "cfp-cli/") || strings.HasPrefix(r.UserAgent(), "cfp-cli/") {
+		return models.ProposalSourceCLI
+	}
+	if r.Header.Get("Authorization") == "" {
+		return models.ProposalSourceWeb
+	}
+	return models.ProposalSourceAPI
+}
+
+// maxSpeakers is the most speakers a proposal can have
+const maxSpeakers = 3
+
+// validateSpeakers checks a proposal's speaker list, adding a field error per problem.
+// accountEmail, when set, must match one of the speaker emails.
+func validateSpeakers(speakers []models.Speaker, accountEmail string, errs *validationErrors) {
+	if len(speakers) == 0 {
+		errs.add("speakers", "At least one speaker is required")
+		return
+	}
+	if len(speakers) > maxSpeakers {
+		errs.add("speakers", fmt.Sprintf("Maximum %d speakers allowed", maxSpeakers))
+		return
+	}
+
+	for i, speaker := range speakers {
+		speakerNum := strconv.Itoa(i + 1)
+		field := "speakers[" + strconv.Itoa(i) + "]."
+		if speaker.Name == "" {
+			errs.add(field+"name", "Speaker "+speakerNum+": name is required")
+		}
+		if speaker.Email == "" {
+			errs.add(field+"email", "Speaker "+speakerNum+": email is required")
+		}
+		if speaker.Company == "" {
+			errs.add(field+"company", "Speaker "+speakerNum+": company is required")
+		}
+		if speaker.JobTitle == "" {
+			errs.add(field+"job_title", "Speaker "+speakerNum+": job_title is required")
+		}
+		if speaker.LinkedIn == "" {
+			errs.add(field+"linkedin", "Speaker "+speakerNum+": linkedin is required")
+		} else if !linkedInURLRegex.MatchString(speaker.LinkedIn) {
+			errs.add(field+"linkedin", "Speaker "+speakerNum+": invalid LinkedIn URL. Must be a full URL like https://linkedin.com/in/username")
+		}
+		if len(speaker.Name) > MaxSpeakerNameLen {
+			errs.add(field+"name", "Speaker "+speakerNum+": name must be at most 200 characters")
+		}
+		if len(speaker.Email) > MaxSpeakerEmailLen {
+			errs.add(field+"email", "Speaker "+speakerNum+": email must be at most 320 characters")
+		} else if speaker.Email != "" {
+			if _, err := mail.ParseAddress(speaker.Email); err != nil {
+				errs.add(field+"email", "Speaker "+speakerNum+": invalid email address")
 			}
 		}
+		if len(speaker.Bio) > MaxSpeakerBioLen {
+			errs.add(field+"bio", "Speaker "+speakerNum+": bio must be at most 2000 characters")
+		}
+		if len(speaker.Company) > MaxSpeakerCompanyLen {
+			errs.add(field+"company", "Speaker "+speakerNum+": company must be at most 200 characters")
+		}
+		if len(speaker.JobTitle) > MaxSpeakerJobTitleLen {
+			errs.add(field+"job_title", "Speaker "+speakerNum+": job_title must be at most 200 characters")
+		}
+		if speaker.PhotoURL != "" && (len(speaker.PhotoURL) > MaxEventWebsiteLen || !isHTTPURL(speaker.PhotoURL)) {
+			errs.add(field+"photo_url", "Speaker "+speakerNum+": photo_url must be a valid HTTP or HTTPS URL")
+		}
+	}
+
+	// Require at least one speaker email matches the authenticated user
+	// to prevent abuse of email notifications via fake speaker addresses
+	if accountEmail != "" {
+		for _, speaker := range speakers {
+			if models.NormalizeEmail(speaker.Email) == models.NormalizeEmail(accountEmail) {
+				return
+			}
+		}
+		errs.add("speakers", "At least one speaker email must match your account email")
 	}
-	return ""
 }
 
 // linkedInURLRegex matches valid LinkedIn profile URLs (HTTPS only).
@@ -101,166 +320,112 @@ func CreateProposalHandler(cfg *config.Config) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		user := GetUserFromContext(r.Context())
 		if user == nil {
-			encodeError(w, "Unauthorized", http.StatusUnauthorized)
+			encodeAPIError(w, r, "Unauthorized", http.StatusUnauthorized)
 			return
 		}
 
 		eventID, err := strconv.ParseUint(r.PathValue("id"), 10, 32)
 		if err != nil {
-			encodeError(w, "Invalid event ID", http.StatusBadRequest)
+			encodeAPIError(w, r, "Invalid event ID", http.StatusBadRequest)
 			return
 		}
 
 		// Get event and check CFP is open
-		var event models.Event
-		if err := cfg.DB.First(&event, eventID).Error; err != nil {
-			encodeError(w, "Event not found", http.StatusNotFound)
+		event, err := getEventWithOrganizers(cfg, r, uint(eventID))
+		if err != nil {
+			encodeAPIError(w, r, "Event not found", http.StatusNotFound)
 			return
 		}
-
-		if !event.IsCFPOpen() {
-			encodeError(w, "CFP is not accepting submissions", http.StatusBadRequest)
+		if !event.IsListed() {
+			encodeAPIError(w, r, "Event not found", http.StatusNotFound)
 			return
 		}
 
-		r.Body = http.MaxBytesReader(w, r.Body, 1<<20) // 1MB
-		defer r.Body.Close()
-
-		var proposal models.Proposal
-		if err := json.NewDecoder(r.Body).Decode(&proposal); err != nil {
-			encodeError(w, "Invalid request body", http.StatusBadRequest)
+		// Speakers submitting right at the deadline get a short grace period
+		now := cfg.Now()
+		if !event.IsCFPOpenAt(now, cfg.CFPGracePeriod) {
+			encodeAPIErrorCode(w, r, ErrCodeCFPClosed, "CFP is not accepting submissions", http.StatusBadRequest)
 			return
 		}
+		late := !now.Before(event.CFPCloseAt)
 
-		// Validate required fields
-		if proposal.Title == "" {
-			encodeError(w, "Title is required", http.StatusBadRequest)
+		var req struct {
+			models.Proposal
+			CoCAccepted interface{} `json:"coc_accepted"`
+		}
+		if !decodeJSONBody(w, r, MaxBodySize, &req) {
 			return
 		}
+		proposal := req.Proposal
 
+		var errs validationErrors
+
+		// Validate required fields and field lengths
+		if proposal.Title == "" {
+			errs.add("title", "Title is required")
+		}
 		if proposal.Abstract == "" {
-			encodeError(w, "Abstract is required", http.StatusBadRequest)
-			return
+			errs.add("abstract", "Abstract is required")
 		}
-
-		// Validate field lengths
 		if len(proposal.Title) > MaxProposalTitleLen {
-			encodeError(w, "Title must be at most 300 characters", http.StatusBadRequest)
-			return
+			errs.add("title", "Title must be at most 300 characters")
 		}
 		if len(proposal.Abstract) > MaxProposalAbstractLen {
-			encodeError(w, "Abstract must be at most 10000 characters", http.StatusBadRequest)
-			return
+			errs.add("abstract", "Abstract must be at most 10000 characters")
 		}
+		validateAbstractWords(event, proposal.Abstract, user.ID, &errs)
 
 		// Validate speakers
-		speakers, err := proposal.GetSpeakers()
+		if speakers, err := proposal.GetSpeakers(); err != nil {
+			errs.add("speakers", "Invalid speakers data")
+		} else {
+			validateSpeakers(speakers, user.Email, &errs)
+		}
+		validateHTTPURL("slides_url", proposal.SlidesURL, &errs)
+		validateHTTPURL("video_url", proposal.VideoURL, &errs)
+
+		// Validate custom answers against the event's questions, if any.
+		// Only the questions within their own window are required or take
+		// answers.
+		questions, err := event.GetCFPQuestions()
 		if err != nil {
-			encodeError(w, "Invalid speakers data", http.StatusBadRequest)
-			return
-		}
-		if len(speakers) == 0 {
-			encodeError(w, "At least one speaker is required", http.StatusBadRequest)
-			return
-		}
-		if len(speakers) > 3 {
-			encodeError(w, "Maximum 3 speakers allowed", http.StatusBadRequest)
+			cfg.Logger.Error("event has invalid cfp_questions JSON", "event_id", eventID, "error", err)
+			encodeAPIError(w, r, "Event has invalid CFP questions configuration", http.StatusInternalServerError)
 			return
 		}
-		for i, speaker := range speakers {
-			speakerNum := strconv.Itoa(i + 1)
-			if speaker.Name == "" {
-				encodeError(w, "Speaker "+speakerNum+": name is required", http.StatusBadRequest)
-				return
-			}
-			if speaker.Email == "" {
-				encodeError(w, "Speaker "+speakerNum+": email is required", http.StatusBadRequest)
-				return
-			}
-			if speaker.Company == "" {
-				encodeError(w, "Speaker "+speakerNum+": company is required", http.StatusBadRequest)
-				return
-			}
-			if speaker.JobTitle == "" {
-				encodeError(w, "Speaker "+speakerNum+": job_title is required", http.StatusBadRequest)
-				return
-			}
-			if speaker.LinkedIn == "" {
-				encodeError(w, "Speaker "+speakerNum+": linkedin is required", http.StatusBadRequest)
-				return
-			}
-			if !linkedInURLRegex.MatchString(speaker.LinkedIn) {
-				encodeError(w, "Speaker "+speakerNum+": invalid LinkedIn URL. Must be a full URL like https://linkedin.com/in/username", http.StatusBadRequest)
-				return
-			}
-			if len(speaker.Name) > MaxSpeakerNameLen {
-				encodeError(w, "Speaker "+speakerNum+": name must be at most 200 characters", http.StatusBadRequest)
-				return
-			}
-			if len(speaker.Email) > MaxSpeakerEmailLen {
-				encodeError(w, "Speaker "+speakerNum+": email must be at most 320 characters", http.StatusBadRequest)
-				return
-			}
-			if _, err := mail.ParseAddress(speaker.Email); err != nil {
-				encodeError(w, "Speaker "+speakerNum+": invalid email address", http.StatusBadRequest)
-				return
-			}
-			if len(speaker.Bio) > MaxSpeakerBioLen {
-				encodeError(w, "Speaker "+speakerNum+": bio must be at most 2000 characters", http.StatusBadRequest)
-				return
-			}
-			if len(speaker.Company) > MaxSpeakerCompanyLen {
-				encodeError(w, "Speaker "+speakerNum+": company must be at most 200 characters", http.StatusBadRequest)
-				return
-			}
-			if len(speaker.JobTitle) > MaxSpeakerJobTitleLen {
-				encodeError(w, "Speaker "+speakerNum+": job_title must be at most 200 characters", http.StatusBadRequest)
-				return
-			}
+		answers, err := proposal.GetCustomAnswers()
+		if err != nil {
+			errs.add("custom_answers", errCustomAnswersNotObject)
+		} else {
+			validateRequiredAnswers(answers, activeQuestions(questions, now, cfg.CFPGracePeriod), &errs)
+			validateCustomAnswers(answers, questions, &errs)
+			validateAnswerWindows(answers, nil, questions, now, cfg.CFPGracePeriod, &errs)
 		}
 
-		// Require at least one speaker email matches the authenticated user
-		// to prevent abuse of email notifications via fake speaker addresses
-		speakerEmailMatch := false
-		for _, speaker := range speakers {
-			if strings.EqualFold(speaker.Email, user.Email) {
-				speakerEmailMatch = true
-				break
-			}
-		}
-		if !speakerEmailMatch {
-			encodeError(w, "At least one speaker email must match your account email", http.StatusBadRequest)
+		cocAccepted := validateCoCAcceptance(event, req.CoCAccepted, &errs)
+
+		if len(errs) > 0 {
+			encodeValidationErrors(w, r, errs)
 			return
 		}
 
-		// Validate custom questions if event has them
-		if len(event.CFPQuestions) > 0 {
-			var questions []models.CustomQuestion
-			if err := json.Unmarshal(event.CFPQuestions, &questions); err != nil {
-				cfg.Logger.Error("event has invalid cfp_questions JSON", "event_id", eventID, "error", err)
-				encodeError(w, "Event has invalid CFP questions configuration", http.StatusInternalServerError)
-				return
-			}
-
-			answers, err := proposal.GetCustomAnswers()
-			if err != nil {
-				encodeError(w, "Invalid custom answers data", http.StatusBadRequest)
-				return
-			}
-
-			for _, q := range questions {
-				if q.Required {
-					if _, ok := answers[q.ID]; !ok {
-						encodeError(w, "Required question '"+q.ID+"' not answered", http.StatusBadRequest)
-						return
-					}
-				}
-			}
-
-			if errMsg := validateCustomAnswers(answers, questions); errMsg != "" {
-				encodeError(w, errMsg, http.StatusBadRequest)
-				return
-			}
+		// Abuse protection: cap submissions per user, and new accounts per event
+		if reason, message, err := checkSubmissionLimits(cfg, r, user, event.ID, now); err != nil {
+			cfg.Logger.Error("failed to check submission limits", "error", err, "user_id", user.ID)
+			encodeAPIError(w, r, "Failed to create proposal", http.StatusInternalServerError)
+			return
+		} else if reason != "" {
+			cfg.Logger.Warn("proposal submission rejected by abuse limit",
+				"reason", reason,
+				"user_id", user.ID,
+				"email", user.Email,
+				"account_age", now.Sub(user.CreatedAt).Round(time.Minute).String(),
+				"event_id", event.ID,
+				"remote_addr", r.RemoteAddr,
+				"request_id", GetRequestID(r.Context()),
+			)
+			encodeAPIErrorCode(w, r, ErrCodeRateLimited, message, http.StatusTooManyRequests)
+			return
 		}
 
 		// Set fields
@@ -275,53 +440,272 @@ func CreateProposalHandler(cfg *config.Config) http.HandlerFunc {
 		proposal.AttendanceConfirmed = false
 		proposal.AttendanceConfirmedAt = nil
 		proposal.OrganizerNotes = ""
-
-		// Use a transaction with row-level locking to enforce the per-user
-		// per-event proposal limit atomically with creation.
-		tx := cfg.DB.Begin()
-		if tx.Error != nil {
-			cfg.Logger.Error("failed to begin transaction", "error", tx.Error)
-			encodeError(w, "Failed to create proposal", http.StatusInternalServerError)
+		proposal.Imported = false
+		proposal.CopiedFromID = nil
+		proposal.CoCAcceptedAt = nil
+		proposal.AnswersRequestedUntil = nil
+		if cocAccepted {
+			proposal.CoCAcceptedAt = &now
+		}
+		proposal.Source = proposalSource(r)
+
+		if err := createProposalWithinLimit(cfg, r, &proposal, user.ID); err != nil {
+			if errors.Is(err, errProposalLimitReached) {
+				encodeAPIErrorCode(w, r, ErrCodeProposalLimit, fmt.Sprintf("You have reached the maximum of %d submissions for this event", cfg.MaxProposalsPerEvent), http.StatusBadRequest)
+				return
+			}
+			cfg.Logger.Error("failed to create proposal", "error", err)
+			encodeAPIError(w, r, "Failed to create proposal", http.StatusInternalServerError)
 			return
 		}
-		defer tx.Rollback()
 
-		// Lock the event row to serialize concurrent proposal creations
+		if late {
+			cfg.Logger.Info("proposal accepted during CFP grace period",
+				"event_id", event.ID,
+				"proposal_id", proposal.ID,
+				"user_id", user.ID,
+				"late_by", now.Sub(event.CFPCloseAt).Round(time.Second).String(),
+			)
+		}
+
+		encodeCreated(w, r, fmt.Sprintf("/api/v0/proposals/%d", proposal.ID), proposal)
+	}
+}
+
+// errProposalLimitReached is returned when a user already has the maximum
+// number of proposals on an event
+var errProposalLimitReached = errors.New("maximum proposals per event reached")
+
+// createProposalWithinLimit creates a proposal unless its submitter already
+// has MaxProposalsPerEvent proposals on the event. The event row is locked so
+// that concurrent submissions cannot exceed the limit.
+func createProposalWithinLimit(cfg *config.Config, r *http.Request, proposal *models.Proposal, userID uint) error {
+	return cfg.DBCtx(r).Transaction(func(tx *gorm.DB) error {
 		var lockedEvent models.Event
-		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&lockedEvent, eventID).Error; err != nil {
-			cfg.Logger.Error("failed to lock event for proposal creation", "error", err)
-			encodeError(w, "Failed to create proposal", http.StatusInternalServerError)
-			return
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&lockedEvent, proposal.EventID).Error; err != nil {
+			return fmt.Errorf("lock event: %w", err)
 		}
 
 		var proposalCount int64
 		if err := tx.Model(&models.Proposal{}).
-			Where("event_id = ? AND created_by_id = ?", eventID, user.ID).
+			Where("event_id = ? AND created_by_id = ?", proposal.EventID, userID).
 			Count(&proposalCount).Error; err != nil {
-			cfg.Logger.Error("failed to count proposals", "error", err)
-			encodeError(w, "Failed to create proposal", http.StatusInternalServerError)
-			return
+			return fmt.Errorf("count proposals: %w", err)
 		}
 		if proposalCount >= int64(cfg.MaxProposalsPerEvent) {
-			encodeError(w, fmt.Sprintf("You have reached the maximum of %d submissions for this event", cfg.MaxProposalsPerEvent), http.StatusBadRequest)
+			return errProposalLimitReached
+		}
+
+		return tx.Create(proposal).Error
+	})
+}
+
+// checkSubmissionLimits applies the platform-wide abuse limits to a new
+// submission. It returns a short reason for the logs and a message for the
+// user when the submission must be rejected, or an empty reason when it may
+// proceed. Admins and trusted users are exempt. Withdrawn (soft-deleted)
+// proposals still count, so deleting and resubmitting does not reset a limit.
+func checkSubmissionLimits(cfg *config.Config, r *http.Request, user *models.User, eventID uint, now time.Time) (reason, message string, err error) {
+	if user.IsTrusted || cfg.IsAdmin(user.Email) {
+		return "", "", nil
+	}
+
+	if cfg.MaxProposalsPerHour > 0 {
+		var recent int64
+		if err := cfg.DBCtx(r).Unscoped().Model(&models.Proposal{}).
+			Where("created_by_id = ? AND created_at >= ?", user.ID, now.Add(-time.Hour)).
+			Count(&recent).Error; err != nil {
+			return "", "", err
+		}
+		if recent >= int64(cfg.MaxProposalsPerHour) {
+			return "hourly_limit", fmt.Sprintf("You can submit at most %d proposals per hour; please try again later", cfg.MaxProposalsPerHour), nil
+		}
+	}
+
+	if cfg.NewAccountCooldown > 0 && now.Sub(user.CreatedAt) < cfg.NewAccountCooldown {
+		var submitted int64
+		if err := cfg.DBCtx(r).Unscoped().Model(&models.Proposal{}).
+			Where("event_id = ? AND created_by_id = ?", eventID, user.ID).
+			Count(&submitted).Error; err != nil {
+			return "", "", err
+		}
+		if submitted > 0 {
+			return "new_account", "New accounts can submit one proposal per event at first; please try again later", nil
+		}
+	}
+
+	return "", "", nil
+}
+
+// CopyProposalHandler lets a speaker resubmit one of their proposals to
+// another event. The copy is a new submitted proposal on the target event,
+// subject to the same checks as a new submission; the original is left as it
+// is. Answers to the original event's custom questions are kept where the
+// target asks a question with the same ID, and can be given or overridden
+// with an optional {"custom_answers": {...}} body. Required questions still
+// unanswered are reported as validation errors, one per question. Events that
+// require their code of conduct to be accepted also need "coc_accepted": true.
+// POST /api/v0/proposals/{id}/copy?target_event_id=
+func CopyProposalHandler(cfg *config.Config) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user := GetUserFromContext(r.Context())
+		if user == nil {
+			encodeAPIError(w, r, "Unauthorized", http.StatusUnauthorized)
 			return
 		}
 
-		if err := tx.Create(&proposal).Error; err != nil {
-			cfg.Logger.Error("failed to create proposal", "error", err)
-			encodeError(w, "Failed to create proposal", http.StatusInternalServerError)
+		id, err := strconv.ParseUint(r.PathValue("id"), 10, 32)
+		if err != nil {
+			encodeAPIError(w, r, "Invalid proposal ID", http.StatusBadRequest)
+			return
+		}
+		targetID, err := strconv.ParseUint(r.URL.Query().Get("target_event_id"), 10, 32)
+		if err != nil {
+			var errs validationErrors
+			errs.add("target_event_id", "target_event_id must be an event ID")
+			encodeValidationErrors(w, r, errs)
+			return
+		}
+
+		var original models.Proposal
+		if err := cfg.DBCtx(r).First(&original, id).Error; err != nil {
+			encodeAPIError(w, r, "Proposal not found", http.StatusNotFound)
+			return
+		}
+		if original.CreatedByID == nil || *original.CreatedByID != user.ID {
+			encodeAPIError(w, r, "Only the proposal owner can copy it", http.StatusForbidden)
+			return
+		}
+		if original.EventID == uint(targetID) {
+			var errs validationErrors
+			errs.add("target_event_id", "The proposal is already submitted to this event")
+			encodeValidationErrors(w, r, errs)
+			return
+		}
+
+		var event models.Event
+		if err := cfg.DBCtx(r).Preload("Organizers").First(&event, targetID).Error; err != nil || !event.IsListed() {
+			encodeAPIError(w, r, "Event not found", http.StatusNotFound)
+			return
+		}
+		now := cfg.Now()
+		if !event.IsCFPOpenAt(now, cfg.CFPGracePeriod) {
+			encodeAPIErrorCode(w, r, ErrCodeCFPClosed, "CFP is not accepting submissions", http.StatusBadRequest)
 			return
 		}
 
-		if err := tx.Commit().Error; err != nil {
-			cfg.Logger.Error("failed to commit proposal creation", "error", err)
-			encodeError(w, "Failed to create proposal", http.StatusInternalServerError)
+		var req struct {
+			CustomAnswers map[string]interface{} `json:"custom_answers"`
+			CoCAccepted   interface{}            `json:"coc_accepted"`
+		}
+		if err := decodeJSON(w, r, MaxBodySize, &req); err != nil && !errors.Is(err, errEmptyBody) {
+			encodeBodyError(w, r, err)
 			return
 		}
 
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusCreated)
-		encodeResponse(w, r, proposal)
+		questions, err := event.GetCFPQuestions()
+		if err != nil {
+			cfg.Logger.Error("event has invalid cfp_questions JSON", "event_id", event.ID, "error", err)
+			encodeAPIError(w, r, "Event has invalid CFP questions configuration", http.StatusInternalServerError)
+			return
+		}
+		active := activeQuestions(questions, now, cfg.CFPGracePeriod)
+
+		var errs validationErrors
+
+		// Only answers to questions taking answers now are carried over
+		answers := make(map[string]interface{})
+		if previous, err := original.GetCustomAnswers(); err == nil {
+			for _, q := range active {
+				if v, ok := previous[q.ID]; ok {
+					answers[q.ID] = v
+				}
+			}
+		}
+		for qid, v := range req.CustomAnswers {
+			answers[qid] = v
+		}
+		validateRequiredAnswers(answers, active, &errs)
+		validateCustomAnswers(answers, questions, &errs)
+		validateAnswerWindows(answers, nil, questions, now, cfg.CFPGracePeriod, &errs)
+		validateAbstractWords(&event, original.Abstract, user.ID, &errs)
+
+		speakers, err := original.GetSpeakers()
+		if err != nil {
+			errs.add("speakers", "Invalid speakers data")
+		} else {
+			validateSpeakers(speakers, user.Email, &errs)
+		}
+
+		cocAccepted := validateCoCAcceptance(&event, req.CoCAccepted, &errs)
+
+		if len(errs) > 0 {
+			encodeValidationErrors(w, r, errs)
+			return
+		}
+
+		if reason, message, err := checkSubmissionLimits(cfg, r, user, event.ID, now); err != nil {
+			cfg.Logger.Error("failed to check submission limits", "error", err, "user_id", user.ID)
+			encodeAPIError(w, r, "Failed to copy proposal", http.StatusInternalServerError)
+			return
+		} else if reason != "" {
+			cfg.Logger.Warn("proposal copy rejected by abuse limit",
+				"reason", reason,
+				"user_id", user.ID,
+				"event_id", event.ID,
+				"proposal_id", original.ID,
+				"request_id", GetRequestID(r.Context()),
+			)
+			encodeAPIErrorCode(w, r, ErrCodeRateLimited, message, http.StatusTooManyRequests)
+			return
+		}
+
+		// Ratings, notes, payment and attendance belong to the original event
+		copied := models.Proposal{
+			EventID:      event.ID,
+			Title:        original.Title,
+			Abstract:     original.Abstract,
+			Format:       original.Format,
+			Duration:     original.Duration,
+			Level:        original.Level,
+			Tags:         original.Tags,
+			Status:       models.ProposalStatusSubmitted,
+			Speakers:     original.Speakers,
+			SpeakerNotes: original.SpeakerNotes,
+			CreatedByID:  &user.ID,
+			CopiedFromID: &original.ID,
+			Source:       proposalSource(r),
+		}
+		if cocAccepted {
+			copied.CoCAcceptedAt = &now
+		}
+		if len(answers) > 0 {
+			data, err := json.Marshal(answers)
+			if err != nil {
+				encodeAPIError(w, r, "Failed to copy proposal", http.StatusInternalServerError)
+				return
+			}
+			copied.CustomAnswers = data
+		}
+
+		if err := createProposalWithinLimit(cfg, r, &copied, user.ID); err != nil {
+			if errors.Is(err, errProposalLimitReached) {
+				encodeAPIErrorCode(w, r, ErrCodeProposalLimit, fmt.Sprintf("You have reached the maximum of %d submissions for this event", cfg.MaxProposalsPerEvent), http.StatusBadRequest)
+				return
+			}
+			cfg.Logger.Error("failed to copy proposal", "error", err, "proposal_id", original.ID)
+			encodeAPIError(w, r, "Failed to copy proposal", http.StatusInternalServerError)
+			return
+		}
+
+		cfg.Logger.Info("proposal copied",
+			"proposal_id", copied.ID,
+			"copied_from_id", original.ID,
+			"event_id", event.ID,
+			"user_id", user.ID,
+		)
+
+		encodeCreated(w, r, fmt.Sprintf("/api/v0/proposals/%d", copied.ID), copied)
 	}
 }
 
@@ -330,117 +714,196 @@ func GetProposalHandler(cfg *config.Config) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		user := GetUserFromContext(r.Context())
 		if user == nil {
-			encodeError(w, "Unauthorized", http.StatusUnauthorized)
+			encodeAPIError(w, r, "Unauthorized", http.StatusUnauthorized)
 			return
 		}
 
 		idStr := r.PathValue("id")
 		id, err := strconv.ParseUint(idStr, 10, 32)
 		if err != nil {
-			encodeError(w, "Invalid proposal ID", http.StatusBadRequest)
+			encodeAPIError(w, r, "Invalid proposal ID", http.StatusBadRequest)
 			return
 		}
 
-		var proposal models.Proposal
-		if err := cfg.DB.First(&proposal, id).Error; err != nil {
-			encodeError(w, "Proposal not found", http.StatusNotFound)
+		expand, ok := parseExpandParam(w, r, proposalExpansions)
+		if !ok {
 			return
 		}
 
-		// Check authorization: owner or event organizer
-		var event models.Event
-		if err := cfg.DB.Preload("Organizers").First(&event, proposal.EventID).Error; err != nil {
-			encodeError(w, "Event not found", http.StatusNotFound)
+		proposal, event, err := getProposalWithEvent(cfg, r, uint(id))
+		if proposal == nil {
+			encodeAPIError(w, r, "Proposal not found", http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			encodeAPIError(w, r, "Event not found", http.StatusNotFound)
 			return
 		}
 
+		// Check authorization: owner or event organizer
 		isOwner := proposal.CreatedByID != nil && *proposal.CreatedByID == user.ID
 		isOrganizer := event.IsOrganizer(user.ID)
 
 		if !isOwner && !isOrganizer {
-			encodeError(w, "Forbidden", http.StatusForbidden)
+			encodeAPIError(w, r, "Forbidden", http.StatusForbidden)
 			return
 		}
 
-		// Hide organizer notes from non-organizers
-		if !isOrganizer {
-			proposal.OrganizerNotes = ""
+		// Organizers also get their own private review notes
+		if isOrganizer {
+			notes, err := models.GetReviewerNotes(cfg.DBCtx(r), proposal.ID, user.ID)
+			if err != nil {
+				cfg.Logger.Error("failed to load reviewer notes", "error", err, "proposal_id", proposal.ID)
+				encodeAPIError(w, r, "Failed to load proposal", http.StatusInternalServerError)
+				return
+			}
+			proposal.ReviewerNotes = notes
+
+			if expand["speaker_history"] {
+				if err := models.LoadSpeakerHistory(cfg.DBCtx(r), user.ID, proposal); err != nil {
+					cfg.Logger.Error("failed to load speaker history", "error", err, "proposal_id", proposal.ID)
+					encodeAPIError(w, r, "Failed to load proposal", http.StatusInternalServerError)
+					return
+				}
+			}
+		}
+		lock, err := models.GetProposalLock(cfg.DBCtx(r), proposal.ID, cfg.Now())
+		if err != nil {
+			cfg.Logger.Error("failed to load proposal lock", "error", err, "proposal_id", proposal.ID)
+			encodeAPIError(w, r, "Failed to load proposal", http.StatusInternalServerError)
+			return
 		}
+		proposal.Lock = lock
+		newProposalView(cfg, event, user).shape(proposal)
 
 		encodeResponse(w, r, proposal)
 	}
 }
 
+// pendingOwnerFields are the fields speakers may update while their proposal
+// is pending review and the CFP is open. Organizers may always update them.
+var pendingOwnerFields = map[string]bool{
+	"title": true, "abstract": true, "format": true, "duration": true,
+	"level": true, "tags": true, "speakers": true, "speaker_notes": true,
+	"custom_answers": true, "slides_url": true, "video_url": true,
+}
+
+// acceptedOwnerFields are the logistics speakers may still update once their
+// proposal is accepted, CFP open or not. The talk itself was locked in by
+// the organizers, and so were the speakers but for their details; see
+// lockSpeakers.
+var acceptedOwnerFields = map[string]bool{
+	"speakers": true, "speaker_notes": true, "slides_url": true, "video_url": true,
+}
+
+// lockSpeakers returns saved with the bio, job title, company and photo of
+// each speaker taken from updated, matched by position. Names, emails,
+// LinkedIn profiles and who is primary stay as saved, and adding or removing
+// speakers is an error.
+func lockSpeakers(saved, updated []models.Speaker, errs *validationErrors) []models.Speaker {
+	if len(updated) != len(saved) {
+		errs.add("speakers", "Speakers can't be added or removed once the proposal is accepted")
+		return saved
+	}
+	locked := slices.Clone(saved)
+	for i := range locked {
+		locked[i].Bio = updated[i].Bio
+		locked[i].JobTitle = updated[i].JobTitle
+		locked[i].Company = updated[i].Company
+		locked[i].PhotoURL = updated[i].PhotoURL
+	}
+	return locked
+}
+
 // UpdateProposalHandler updates an existing proposal
 func UpdateProposalHandler(cfg *config.Config) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		user := GetUserFromContext(r.Context())
 		if user == nil {
-			encodeError(w, "Unauthorized", http.StatusUnauthorized)
+			encodeAPIError(w, r, "Unauthorized", http.StatusUnauthorized)
 			return
 		}
 
 		idStr := r.PathValue("id")
 		id, err := strconv.ParseUint(idStr, 10, 32)
 		if err != nil {
-			encodeError(w, "Invalid proposal ID", http.StatusBadRequest)
+			encodeAPIError(w, r, "Invalid proposal ID", http.StatusBadRequest)
 			return
 		}
 
-		var proposal models.Proposal
-		if err := cfg.DB.First(&proposal, id).Error; err != nil {
-			encodeError(w, "Proposal not found", http.StatusNotFound)
+		proposal, event, err := getProposalWithEvent(cfg, r, uint(id))
+		if proposal == nil {
+			encodeAPIError(w, r, "Proposal not found", http.StatusNotFound)
 			return
 		}
-
-		var event models.Event
-		if err := cfg.DB.Preload("Organizers").First(&event, proposal.EventID).Error; err != nil {
-			encodeError(w, "Event not found", http.StatusNotFound)
+		if err != nil {
+			encodeAPIError(w, r, "Event not found", http.StatusNotFound)
 			return
 		}
 
 		isOwner := proposal.CreatedByID != nil && *proposal.CreatedByID == user.ID
 		isOrganizer := event.IsOrganizer(user.ID)
-
-		// Owner can update if CFP is still open
-		// Organizer can update organizer_notes
-		if isOwner && !event.IsCFPOpen() && !isOrganizer {
-			encodeError(w, "CFP is closed", http.StatusBadRequest)
-			return
-		}
-
-		// Owner can only edit proposals still in "submitted" status
-		if isOwner && !isOrganizer && proposal.Status != models.ProposalStatusSubmitted {
-			encodeError(w, "Proposal can only be edited while in pending review status", http.StatusBadRequest)
-			return
+		now := cfg.Now()
+
+		// Owner can update if CFP is still open and the proposal still in
+		// "submitted" status, or only its answers while an organizer asked
+		// for missing ones. Organizer can update organizer_notes and their
+		// own reviewer_notes.
+		answersRequested := isOwner && !isOrganizer && proposal.AnswersRequested(now)
+		var ownerFields map[string]bool // nil for organizers
+		if isOwner && !isOrganizer {
+			switch {
+			case proposal.Status == models.ProposalStatusSubmitted && event.IsCFPOpen():
+				ownerFields = pendingOwnerFields
+			case proposal.Status == models.ProposalStatusAccepted:
+				ownerFields = maps.Clone(acceptedOwnerFields)
+				ownerFields["custom_answers"] = answersRequested
+			case answersRequested:
+				ownerFields = map[string]bool{"custom_answers": true}
+			case !event.IsCFPOpen():
+				encodeAPIErrorCode(w, r, ErrCodeCFPClosed, "CFP is closed", http.StatusBadRequest)
+				return
+			default:
+				encodeAPIError(w, r, "Proposal can only be edited while in pending review status", http.StatusBadRequest)
+				return
+			}
 		}
 
 		if !isOwner && !isOrganizer {
-			encodeError(w, "Forbidden", http.StatusForbidden)
+			encodeAPIError(w, r, "Forbidden", http.StatusForbidden)
 			return
 		}
 
-		r.Body = http.MaxBytesReader(w, r.Body, 1<<20)
-		defer r.Body.Close()
+		// Don't overwrite someone else's edits in progress unless asked to
+		if r.URL.Query().Get("force") != "true" {
+			lock, err := models.GetProposalLock(cfg.DBCtx(r), proposal.ID, now)
+			if err != nil {
+				cfg.Logger.Error("failed to load proposal lock", "error", err, "proposal_id", proposal.ID)
+				encodeAPIError(w, r, "Failed to update proposal", http.StatusInternalServerError)
+				return
+			}
+			if lock != nil && !lock.HeldBy(user.ID) {
+				encodeAPIErrorCode(w, r, ErrCodeLocked, lockedByMessage(lock), http.StatusLocked)
+				return
+			}
+		}
+		view := newProposalView(cfg, event, user)
 
 		var updates map[string]interface{}
-		if err := json.NewDecoder(r.Body).Decode(&updates); err != nil {
-			encodeError(w, "Invalid request body", http.StatusBadRequest)
+		if !decodeJSONBody(w, r, MaxBodySize, &updates) {
 			return
 		}
 
 		// Only allow known safe fields to be updated (allowlist approach)
-		allowedFields := map[string]bool{
-			"title": true, "abstract": true, "format": true, "duration": true,
-			"level": true, "tags": true, "speakers": true, "speaker_notes": true,
-			"custom_answers": true,
-		}
+		allowedFields := ownerFields
 		if isOrganizer {
 			// Note: status and rating are NOT in this allowlist.
 			// Use the dedicated UpdateProposalStatusHandler and
 			// UpdateProposalRatingHandler which enforce max_accepted
 			// limits, rating range validation, and send notifications.
+			allowedFields = maps.Clone(pendingOwnerFields)
 			allowedFields["organizer_notes"] = true
+			allowedFields["reviewer_notes"] = true
 		}
 		filtered := make(map[string]interface{})
 		for k, v := range updates {
@@ -450,125 +913,105 @@ func UpdateProposalHandler(cfg *config.Config) http.HandlerFunc {
 		}
 		updates = filtered
 
+		// Reviewers only see masked speaker emails, which would be saved back
+		if _, ok := updates["speakers"]; ok && view.hideEmails && !isOwner {
+			encodeAPIError(w, r, errSpeakerEmailsHidden, http.StatusForbidden)
+			return
+		}
+
+		var errs validationErrors
+
+		// reviewer_notes is stored on the caller's ProposalReview, not the proposal
+		var reviewerNotes *string
+		if v, ok := updates["reviewer_notes"]; ok {
+			delete(updates, "reviewer_notes")
+			if notes, ok := v.(string); !ok {
+				errs.add("reviewer_notes", "Reviewer notes must be a string")
+			} else if len(notes) > MaxProposalOrganizerNotesLen {
+				errs.add("reviewer_notes", "Reviewer notes must be at most 5000 characters")
+			} else {
+				reviewerNotes = &notes
+			}
+		}
+
 		// Validate speakers if being updated
 		if speakersData, ok := updates["speakers"]; ok {
-			speakersJSON, err := json.Marshal(speakersData)
-			if err != nil {
-				encodeError(w, "Invalid speakers data", http.StatusBadRequest)
-				return
-			}
 			var speakers []models.Speaker
-			if err := json.Unmarshal(speakersJSON, &speakers); err != nil {
-				encodeError(w, "Invalid speakers format", http.StatusBadRequest)
-				return
-			}
-			if len(speakers) == 0 {
-				encodeError(w, "At least one speaker is required", http.StatusBadRequest)
-				return
-			}
-			if len(speakers) > 3 {
-				encodeError(w, "Maximum 3 speakers allowed", http.StatusBadRequest)
-				return
-			}
-			for i, speaker := range speakers {
-				speakerNum := strconv.Itoa(i + 1)
-				if speaker.Name == "" {
-					encodeError(w, "Speaker "+speakerNum+": name is required", http.StatusBadRequest)
-					return
-				}
-				if speaker.Email == "" {
-					encodeError(w, "Speaker "+speakerNum+": email is required", http.StatusBadRequest)
-					return
-				}
-				if speaker.Company == "" {
-					encodeError(w, "Speaker "+speakerNum+": company is required", http.StatusBadRequest)
-					return
-				}
-				if speaker.JobTitle == "" {
-					encodeError(w, "Speaker "+speakerNum+": job_title is required", http.StatusBadRequest)
-					return
-				}
-				if speaker.LinkedIn == "" {
-					encodeError(w, "Speaker "+speakerNum+": linkedin is required", http.StatusBadRequest)
-					return
-				}
-				if !linkedInURLRegex.MatchString(speaker.LinkedIn) {
-					encodeError(w, "Speaker "+speakerNum+": invalid LinkedIn URL. Must be a full URL like https://linkedin.com/in/username", http.StatusBadRequest)
-					return
-				}
-				if len(speaker.Name) > MaxSpeakerNameLen {
-					encodeError(w, "Speaker "+speakerNum+": name must be at most 200 characters", http.StatusBadRequest)
-					return
-				}
-				if len(speaker.Email) > MaxSpeakerEmailLen {
-					encodeError(w, "Speaker "+speakerNum+": email must be at most 320 characters", http.StatusBadRequest)
-					return
-				}
-				if _, err := mail.ParseAddress(speaker.Email); err != nil {
-					encodeError(w, "Speaker "+speakerNum+": invalid email address", http.StatusBadRequest)
-					return
-				}
-				if len(speaker.Bio) > MaxSpeakerBioLen {
-					encodeError(w, "Speaker "+speakerNum+": bio must be at most 2000 characters", http.StatusBadRequest)
-					return
-				}
-				if len(speaker.Company) > MaxSpeakerCompanyLen {
-					encodeError(w, "Speaker "+speakerNum+": company must be at most 200 characters", http.StatusBadRequest)
-					return
-				}
-				if len(speaker.JobTitle) > MaxSpeakerJobTitleLen {
-					encodeError(w, "Speaker "+speakerNum+": job_title must be at most 200 characters", http.StatusBadRequest)
-					return
-				}
+			speakersJSON, err := json.Marshal(speakersData)
+			if err == nil {
+				err = json.Unmarshal(speakersJSON, &speakers)
 			}
-			// Non-organizer owners must keep at least one speaker email matching their account
-			if !isOrganizer {
-				speakerEmailMatch := false
-				for _, speaker := range speakers {
-					if strings.EqualFold(speaker.Email, user.Email) {
-						speakerEmailMatch = true
-						break
-					}
+			if err != nil {
+				errs.add("speakers", "Invalid speakers format")
+			} else {
+				if !isOrganizer && proposal.Status == models.ProposalStatusAccepted {
+					// Who speaks was locked in with the talk
+					speakers = lockSpeakers(parseSpeakers(proposal.Speakers), speakers, &errs)
+					updates["speakers"] = speakers
 				}
-				if !speakerEmailMatch {
-					encodeError(w, "At least one speaker email must match your account email", http.StatusBadRequest)
-					return
+				// Non-organizer owners must keep at least one speaker email matching their account
+				accountEmail := user.Email
+				if isOrganizer {
+					accountEmail = ""
 				}
+				validateSpeakers(speakers, accountEmail, &errs)
 			}
 		}
 
 		// Validate field lengths on update
 		if title, ok := updates["title"].(string); ok && len(title) > MaxProposalTitleLen {
-			encodeError(w, "Title must be at most 300 characters", http.StatusBadRequest)
-			return
+			errs.add("title", "Title must be at most 300 characters")
 		}
-		if abstract, ok := updates["abstract"].(string); ok && len(abstract) > MaxProposalAbstractLen {
-			encodeError(w, "Abstract must be at most 10000 characters", http.StatusBadRequest)
-			return
+		if abstract, ok := updates["abstract"].(string); ok {
+			if len(abstract) > MaxProposalAbstractLen {
+				errs.add("abstract", "Abstract must be at most 10000 characters")
+			} else {
+				validateAbstractWords(event, abstract, user.ID, &errs)
+			}
 		}
 		if notes, ok := updates["organizer_notes"].(string); ok && len(notes) > MaxProposalOrganizerNotesLen {
-			encodeError(w, "Organizer notes must be at most 5000 characters", http.StatusBadRequest)
-			return
+			errs.add("organizer_notes", "Organizer notes must be at most 5000 characters")
+		}
+		for _, field := range []string{"slides_url", "video_url"} {
+			if v, ok := updates[field]; ok {
+				if s, ok := v.(string); !ok {
+					errs.add(field, "Must be a string")
+				} else {
+					validateHTTPURL(field, s, &errs)
+				}
+			}
 		}
 
-		// Validate custom answer types if being updated
+		// Validate custom answers if being updated, the same way as on create
 		if answersData, ok := updates["custom_answers"]; ok && answersData != nil {
-			if answersMap, ok := answersData.(map[string]interface{}); ok {
-				if event.CFPQuestions != nil && len(event.CFPQuestions) > 0 {
-					var questions []models.CustomQuestion
-					if err := json.Unmarshal(event.CFPQuestions, &questions); err != nil {
-						cfg.Logger.Error("event has invalid cfp_questions JSON", "event_id", proposal.EventID, "error", err)
-						encodeError(w, "Event has invalid CFP questions configuration", http.StatusInternalServerError)
-						return
-					}
-					if errMsg := validateCustomAnswers(answersMap, questions); errMsg != "" {
-						encodeError(w, errMsg, http.StatusBadRequest)
-						return
-					}
+			if answersMap, ok := answersData.(map[string]interface{}); !ok {
+				errs.add("custom_answers", errCustomAnswersNotObject)
+			} else {
+				questions, err := event.GetCFPQuestions()
+				if err != nil {
+					cfg.Logger.Error("event has invalid cfp_questions JSON", "event_id", proposal.EventID, "error", err)
+					encodeAPIError(w, r, "Event has invalid CFP questions configuration", http.StatusInternalServerError)
+					return
+				}
+				validateCustomAnswers(answersMap, questions, &errs)
+				// Organizers may fill in answers outside the question windows,
+				// and so may speakers they asked for missing answers
+				if !isOrganizer && !answersRequested {
+					previous, _ := proposal.GetCustomAnswers()
+					validateAnswerWindows(answersMap, previous, questions, now, cfg.CFPGracePeriod, &errs)
+				}
+				// The request is answered once nothing is missing
+				if answersRequested && len(models.MissingAnswers(answersMap, questions, now)) == 0 {
+					updates["answers_requested_until"] = nil
 				}
 			}
 		}
 
+		if len(errs) > 0 {
+			encodeValidationErrors(w, r, errs)
+			return
+		}
+
 		// Re-marshal JSONB fields so GORM/pgx stores them correctly.
 		// Without this, the map values are raw Go types ([]interface{}, map[string]interface{})
 		// which pgx cannot properly encode for jsonb columns.
@@ -576,24 +1019,45 @@ func UpdateProposalHandler(cfg *config.Config) http.HandlerFunc {
 			if val, ok := updates[jsonbField]; ok && val != nil {
 				jsonBytes, err := json.Marshal(val)
 				if err != nil {
-					encodeError(w, "Invalid "+jsonbField+" data", http.StatusBadRequest)
+					encodeAPIError(w, r, "Invalid "+jsonbField+" data", http.StatusBadRequest)
 					return
 				}
 				updates[jsonbField] = datatypes.JSON(jsonBytes)
 			}
 		}
 
-		if err := cfg.DB.Model(&proposal).Updates(updates).Error; err != nil {
+		err = cfg.DBCtx(r).Transaction(func(tx *gorm.DB) error {
+			if len(updates) > 0 {
+				if err := tx.Model(proposal).Updates(updates).Error; err != nil {
+					return err
+				}
+			}
+			if reviewerNotes != nil {
+				return models.SaveReviewerNotes(tx, proposal.ID, user.ID, *reviewerNotes)
+			}
+			return nil
+		})
+		if err != nil {
 			cfg.Logger.Error("failed to update proposal", "error", err)
-			encodeError(w, "Failed to update proposal", http.StatusInternalServerError)
+			encodeAPIError(w, r, "Failed to update proposal", http.StatusInternalServerError)
 			return
 		}
 
-		if err := cfg.DB.First(&proposal, id).Error; err != nil {
+		if err := cfg.DBCtx(r).First(proposal, id).Error; err != nil {
 			cfg.Logger.Error("failed to reload proposal after update", "error", err)
-			encodeError(w, "Failed to reload proposal", http.StatusInternalServerError)
+			encodeAPIError(w, r, "Failed to reload proposal", http.StatusInternalServerError)
 			return
 		}
+		if isOrganizer && reviewerNotes != nil {
+			proposal.ReviewerNotes = *reviewerNotes
+		} else if isOrganizer {
+			if proposal.ReviewerNotes, err = models.GetReviewerNotes(cfg.DBCtx(r), proposal.ID, user.ID); err != nil {
+				cfg.Logger.Error("failed to load reviewer notes", "error", err, "proposal_id", proposal.ID)
+				encodeAPIError(w, r, "Failed to reload proposal", http.StatusInternalServerError)
+				return
+			}
+		}
+		view.shape(proposal)
 		encodeResponse(w, r, proposal)
 	}
 }
@@ -603,38 +1067,38 @@ func DeleteProposalHandler(cfg *config.Config) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		user := GetUserFromContext(r.Context())
 		if user == nil {
-			encodeError(w, "Unauthorized", http.StatusUnauthorized)
+			encodeAPIError(w, r, "Unauthorized", http.StatusUnauthorized)
 			return
 		}
 
 		idStr := r.PathValue("id")
 		id, err := strconv.ParseUint(idStr, 10, 32)
 		if err != nil {
-			encodeError(w, "Invalid proposal ID", http.StatusBadRequest)
+			encodeAPIError(w, r, "Invalid proposal ID", http.StatusBadRequest)
 			return
 		}
 
 		var proposal models.Proposal
-		if err := cfg.DB.First(&proposal, id).Error; err != nil {
-			encodeError(w, "Proposal not found", http.StatusNotFound)
+		if err := cfg.DBCtx(r).First(&proposal, id).Error; err != nil {
+			encodeAPIError(w, r, "Proposal not found", http.StatusNotFound)
 			return
 		}
 
 		// Only the owner can delete their proposal
 		if proposal.CreatedByID == nil || *proposal.CreatedByID != user.ID {
-			encodeError(w, "Forbidden", http.StatusForbidden)
+			encodeAPIError(w, r, "Forbidden", http.StatusForbidden)
 			return
 		}
 
 		// Prevent deletion of accepted or tentative proposals
 		if proposal.Status == models.ProposalStatusAccepted || proposal.Status == models.ProposalStatusTentative {
-			encodeError(w, "Accepted or tentative proposals cannot be deleted. Use emergency cancel instead.", http.StatusConflict)
+			encodeAPIError(w, r, "Accepted or tentative proposals cannot be deleted. Use emergency cancel instead.", http.StatusConflict)
 			return
 		}
 
-		if err := cfg.DB.Delete(&proposal).Error; err != nil {
+		if err := cfg.DBCtx(r).Delete(&proposal).Error; err != nil {
 			cfg.Logger.Error("failed to delete proposal", "error", err)
-			encodeError(w, "Failed to delete proposal", http.StatusInternalServerError)
+			encodeAPIError(w, r, "Failed to delete proposal", http.StatusInternalServerError)
 			return
 		}
 
@@ -647,42 +1111,35 @@ func UpdateProposalStatusHandler(cfg *config.Config) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		user := GetUserFromContext(r.Context())
 		if user == nil {
-			encodeError(w, "Unauthorized", http.StatusUnauthorized)
+			encodeAPIError(w, r, "Unauthorized", http.StatusUnauthorized)
 			return
 		}
 
 		id, err := strconv.ParseUint(r.PathValue("id"), 10, 32)
 		if err != nil {
-			encodeError(w, "Invalid proposal ID", http.StatusBadRequest)
+			encodeAPIError(w, r, "Invalid proposal ID", http.StatusBadRequest)
 			return
 		}
 
-		var proposal models.Proposal
-		if err := cfg.DB.First(&proposal, id).Error; err != nil {
-			encodeError(w, "Proposal not found", http.StatusNotFound)
+		proposal, event, err := getProposalWithEvent(cfg, r, uint(id))
+		if proposal == nil {
+			encodeAPIError(w, r, "Proposal not found", http.StatusNotFound)
 			return
 		}
-
-		var event models.Event
-		if err := cfg.DB.Preload("Organizers").First(&event, proposal.EventID).Error; err != nil {
-			encodeError(w, "Event not found", http.StatusNotFound)
+		if err != nil {
+			encodeAPIError(w, r, "Event not found", http.StatusNotFound)
 			return
 		}
 
 		if !event.IsOrganizer(user.ID) {
-			encodeError(w, "Forbidden", http.StatusForbidden)
+			encodeAPIError(w, r, "Forbidden", http.StatusForbidden)
 			return
 		}
 
-		r.Body = http.MaxBytesReader(w, r.Body, 1<<20) // 1MB limit
-		defer r.Body.Close()
-
 		var req struct {
 			Status models.ProposalStatus `json:"status"`
 		}
-
-		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-			encodeError(w, "Invalid request body", http.StatusBadRequest)
+		if !decodeJSONBody(w, r, MaxSmallBodySize, &req) {
 			return
 		}
 
@@ -695,7 +1152,7 @@ func UpdateProposalStatusHandler(cfg *config.Config) http.HandlerFunc {
 		}
 
 		if !validStatuses[req.Status] {
-			encodeError(w, "Invalid status", http.StatusBadRequest)
+			encodeAPIError(w, r, "Invalid status", http.StatusBadRequest)
 			return
 		}
 
@@ -703,38 +1160,63 @@ func UpdateProposalStatusHandler(cfg *config.Config) http.HandlerFunc {
 		// when checking max_accepted limits. Lock the event row to serialize
 		// concurrent acceptance decisions (FOR UPDATE cannot be used with COUNT).
 		oldStatus := proposal.Status
-		err = cfg.DB.Transaction(func(tx *gorm.DB) error {
-			if req.Status == models.ProposalStatusAccepted && event.MaxAccepted != nil {
+		limited := req.Status == models.ProposalStatusAccepted && event.MaxAccepted != nil
+		var acceptedCount int64
+		overbooked := false
+		err = cfg.DBCtx(r).Transaction(func(tx *gorm.DB) error {
+			if limited {
 				// Lock the event row to serialize concurrent acceptances
 				var lockedEvent models.Event
 				if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&lockedEvent, event.ID).Error; err != nil {
 					return fmt.Errorf("lock event: %w", err)
 				}
 
-				var acceptedCount int64
 				if err := tx.Model(&models.Proposal{}).
 					Where("event_id = ? AND status = ?", event.ID, models.ProposalStatusAccepted).
 					Count(&acceptedCount).Error; err != nil {
 					return fmt.Errorf("count accepted: %w", err)
 				}
 
-				if acceptedCount >= int64(*event.MaxAccepted) {
-					return errMaxAcceptedReached
+				// A proposal that is already accepted holds one of the slots
+				if oldStatus != models.ProposalStatusAccepted {
+					if acceptedCount >= int64(*event.MaxAccepted) {
+						if !lockedEvent.AllowOverbook {
+							return errMaxAcceptedReached
+						}
+						overbooked = true
+					}
+					acceptedCount++
 				}
 			}
 
-			return tx.Model(&proposal).Update("status", req.Status).Error
+			return tx.Model(proposal).Update("status", req.Status).Error
 		})
 		if err != nil {
 			if errors.Is(err, errMaxAcceptedReached) {
-				encodeError(w, err.Error(), http.StatusBadRequest)
+				encodeAPIError(w, r, err.Error(), http.StatusBadRequest)
 			} else {
-				encodeError(w, "Failed to update status", http.StatusInternalServerError)
+				encodeAPIError(w, r, "Failed to update status", http.StatusInternalServerError)
 			}
 			return
 		}
 		proposal.Status = req.Status
 
+		if limited {
+			setCapacityHeaders(w, event, acceptedCount)
+			if overbooked {
+				cfg.Logger.Warn("proposal accepted past max_accepted",
+					"proposal_id", proposal.ID,
+					"event_id", event.ID,
+					"accepted", acceptedCount,
+					"max_accepted", *event.MaxAccepted,
+					"actor_id", user.ID,
+				)
+				w.Header().Set(headerCapacityWarning, "overbooked")
+			} else if acceptedCount == int64(*event.MaxAccepted) {
+				w.Header().Set(headerCapacityWarning, "full")
+			}
+		}
+
 		cfg.Logger.Info("proposal status changed",
 			"proposal_id", proposal.ID,
 			"event_id", proposal.EventID,
@@ -742,11 +1224,21 @@ func UpdateProposalStatusHandler(cfg *config.Config) http.HandlerFunc {
 			"new_status", string(req.Status),
 			"actor_id", user.ID,
 		)
+		if oldStatus != req.Status {
+			recordActivity(cfg, r, models.EventActivity{
+				EventID:    event.ID,
+				ActorID:    &user.ID,
+				Action:     models.ActivityProposalStatus,
+				ProposalID: &proposal.ID,
+				Subject:    proposal.Title,
+				Detail:     string(req.Status),
+			})
+		}
 
 		// Send email notification to speakers (fire-and-forget)
 		if oldStatus != req.Status && cfg.EmailSender != nil {
-			p := proposal // copy for goroutine
-			e := event
+			p := *proposal // copy for goroutine
+			e := *event
 			status := req.Status
 			SafeGo(cfg, func() {
 				ncfg := &email.NotifyConfig{
@@ -759,6 +1251,7 @@ func UpdateProposalStatusHandler(cfg *config.Config) http.HandlerFunc {
 			})
 		}
 
+		newProposalView(cfg, event, user).shape(proposal)
 		encodeResponse(w, r, proposal)
 	}
 }
@@ -768,57 +1261,51 @@ func UpdateProposalRatingHandler(cfg *config.Config) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		user := GetUserFromContext(r.Context())
 		if user == nil {
-			encodeError(w, "Unauthorized", http.StatusUnauthorized)
+			encodeAPIError(w, r, "Unauthorized", http.StatusUnauthorized)
 			return
 		}
 
 		id, err := strconv.ParseUint(r.PathValue("id"), 10, 32)
 		if err != nil {
-			encodeError(w, "Invalid proposal ID", http.StatusBadRequest)
+			encodeAPIError(w, r, "Invalid proposal ID", http.StatusBadRequest)
 			return
 		}
 
-		var proposal models.Proposal
-		if err := cfg.DB.First(&proposal, id).Error; err != nil {
-			encodeError(w, "Proposal not found", http.StatusNotFound)
+		proposal, event, err := getProposalWithEvent(cfg, r, uint(id))
+		if proposal == nil {
+			encodeAPIError(w, r, "Proposal not found", http.StatusNotFound)
 			return
 		}
-
-		var event models.Event
-		if err := cfg.DB.Preload("Organizers").First(&event, proposal.EventID).Error; err != nil {
-			encodeError(w, "Event not found", http.StatusNotFound)
+		if err != nil {
+			encodeAPIError(w, r, "Event not found", http.StatusNotFound)
 			return
 		}
 
 		if !event.IsOrganizer(user.ID) {
-			encodeError(w, "Forbidden", http.StatusForbidden)
+			encodeAPIError(w, r, "Forbidden", http.StatusForbidden)
 			return
 		}
 
-		r.Body = http.MaxBytesReader(w, r.Body, 1<<20) // 1MB limit
-		defer r.Body.Close()
-
 		var req struct {
 			Rating int `json:"rating"`
 		}
-
-		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-			encodeError(w, "Invalid request body", http.StatusBadRequest)
+		if !decodeJSONBody(w, r, MaxSmallBodySize, &req) {
 			return
 		}
 
 		// Validate rating range
 		if req.Rating < MinRating || req.Rating > MaxRating {
-			encodeError(w, "Rating must be between 0 and 5", http.StatusBadRequest)
+			encodeAPIError(w, r, "Rating must be between 0 and 5", http.StatusBadRequest)
 			return
 		}
 
 		proposal.Rating = &req.Rating
-		if err := cfg.DB.Model(&proposal).Update("rating", req.Rating).Error; err != nil {
-			encodeError(w, "Failed to update rating", http.StatusInternalServerError)
+		if err := cfg.DBCtx(r).Model(proposal).Update("rating", req.Rating).Error; err != nil {
+			encodeAPIError(w, r, "Failed to update rating", http.StatusInternalServerError)
 			return
 		}
 
+		newProposalView(cfg, event, user).shape(proposal)
 		encodeResponse(w, r, proposal)
 	}
 }
@@ -826,36 +1313,36 @@ func UpdateProposalRatingHandler(cfg *config.Config) http.HandlerFunc {
 // ConfirmAttendanceHandler allows the proposal owner to confirm attendance after acceptance
 func ConfirmAttendanceHandler(cfg *config.Config) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		r.Body = http.MaxBytesReader(w, r.Body, 1<<20) // 1MB limit
+		r.Body = http.MaxBytesReader(w, r.Body, MaxSmallBodySize)
 		defer r.Body.Close()
 
 		user := GetUserFromContext(r.Context())
 		if user == nil {
-			encodeError(w, "Unauthorized", http.StatusUnauthorized)
+			encodeAPIError(w, r, "Unauthorized", http.StatusUnauthorized)
 			return
 		}
 
 		id, err := strconv.ParseUint(r.PathValue("id"), 10, 32)
 		if err != nil {
-			encodeError(w, "Invalid proposal ID", http.StatusBadRequest)
+			encodeAPIError(w, r, "Invalid proposal ID", http.StatusBadRequest)
 			return
 		}
 
 		var proposal models.Proposal
-		if err := cfg.DB.First(&proposal, id).Error; err != nil {
-			encodeError(w, "Proposal not found", http.StatusNotFound)
+		if err := cfg.DBCtx(r).First(&proposal, id).Error; err != nil {
+			encodeAPIError(w, r, "Proposal not found", http.StatusNotFound)
 			return
 		}
 
 		// Only the proposal owner can confirm
 		if proposal.CreatedByID == nil || *proposal.CreatedByID != user.ID {
-			encodeError(w, "Forbidden", http.StatusForbidden)
+			encodeAPIError(w, r, "Forbidden", http.StatusForbidden)
 			return
 		}
 
 		// Only accepted proposals can be confirmed
 		if proposal.Status != models.ProposalStatusAccepted {
-			encodeError(w, "Only accepted proposals can be confirmed", http.StatusBadRequest)
+			encodeAPIError(w, r, "Only accepted proposals can be confirmed", http.StatusBadRequest)
 			return
 		}
 
@@ -866,17 +1353,17 @@ func ConfirmAttendanceHandler(cfg *config.Config) http.HandlerFunc {
 		}
 
 		now := time.Now()
-		if err := cfg.DB.Model(&proposal).Updates(map[string]interface{}{
+		if err := cfg.DBCtx(r).Model(&proposal).Updates(map[string]interface{}{
 			"attendance_confirmed":    true,
 			"attendance_confirmed_at": now,
 		}).Error; err != nil {
-			encodeError(w, "Failed to confirm attendance", http.StatusInternalServerError)
+			encodeAPIError(w, r, "Failed to confirm attendance", http.StatusInternalServerError)
 			return
 		}
 
-		if err := cfg.DB.First(&proposal, id).Error; err != nil {
+		if err := cfg.DBCtx(r).First(&proposal, id).Error; err != nil {
 			cfg.Logger.Error("failed to reload proposal after confirmation", "error", err)
-			encodeError(w, "Failed to confirm attendance", http.StatusInternalServerError)
+			encodeAPIError(w, r, "Failed to confirm attendance", http.StatusInternalServerError)
 			return
 		}
 
@@ -903,59 +1390,197 @@ func ConfirmAttendanceHandler(cfg *config.Config) http.HandlerFunc {
 	}
 }
 
+// RequestProposalAnswersHandler emails a proposal's speakers asking them to
+// answer the required questions it is missing, and lets them edit its answers
+// for AnswersRequestPeriod whatever its status (organizers only)
+func RequestProposalAnswersHandler(cfg *config.Config) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		r.Body = http.MaxBytesReader(w, r.Body, MaxSmallBodySize)
+		defer r.Body.Close()
+
+		user := GetUserFromContext(r.Context())
+		if user == nil {
+			encodeAPIError(w, r, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		id, err := strconv.ParseUint(r.PathValue("id"), 10, 32)
+		if err != nil {
+			encodeAPIError(w, r, "Invalid proposal ID", http.StatusBadRequest)
+			return
+		}
+
+		proposal, event, err := getProposalWithEvent(cfg, r, uint(id))
+		if proposal == nil {
+			encodeAPIError(w, r, "Proposal not found", http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			encodeAPIError(w, r, "Event not found", http.StatusNotFound)
+			return
+		}
+
+		if !event.IsOrganizer(user.ID) {
+			encodeAPIError(w, r, "Forbidden", http.StatusForbidden)
+			return
+		}
+
+		// Imported proposals have no speaker account to edit them with
+		if proposal.CreatedByID == nil {
+			encodeAPIError(w, r, "Imported proposals can't be updated by their speakers", http.StatusBadRequest)
+			return
+		}
+
+		questions, err := event.GetCFPQuestions()
+		if err != nil {
+			cfg.Logger.Error("event has invalid cfp_questions JSON", "event_id", event.ID, "error", err)
+			encodeAPIError(w, r, "Event has invalid CFP questions configuration", http.StatusInternalServerError)
+			return
+		}
+		answers, _ := proposal.GetCustomAnswers()
+		now := cfg.Now()
+		missing := models.MissingAnswers(answers, questions, now)
+		if len(missing) == 0 {
+			encodeAPIError(w, r, "Proposal has no missing required answers", http.StatusBadRequest)
+			return
+		}
+
+		until := now.Add(AnswersRequestPeriod)
+		if err := cfg.DBCtx(r).Model(proposal).Update("answers_requested_until", until).Error; err != nil {
+			cfg.Logger.Error("failed to request proposal answers", "error", err, "proposal_id", proposal.ID)
+			encodeAPIError(w, r, "Failed to request answers", http.StatusInternalServerError)
+			return
+		}
+		proposal.AnswersRequestedUntil = &until
+
+		cfg.Logger.Info("proposal answers requested",
+			"proposal_id", proposal.ID,
+			"event_id", event.ID,
+			"questions", missing,
+			"actor_id", user.ID,
+		)
+		recordActivity(cfg, r, models.EventActivity{
+			EventID:    event.ID,
+			ActorID:    &user.ID,
+			Action:     models.ActivityAnswersRequested,
+			ProposalID: &proposal.ID,
+			Subject:    proposal.Title,
+			Detail:     strings.Join(missing, ","),
+		})
+
+		// Email the speakers (fire-and-forget)
+		if cfg.EmailSender != nil {
+			var asked []models.CustomQuestion
+			for _, q := range questions {
+				if slices.Contains(missing, q.ID) {
+					asked = append(asked, q)
+				}
+			}
+			p, e := *proposal, *event // copy for goroutine
+			SafeGo(cfg, func() {
+				ncfg := &email.NotifyConfig{
+					Sender:  cfg.EmailSender,
+					From:    cfg.EmailFrom,
+					BaseURL: cfg.BaseURL,
+					Logger:  cfg.Logger,
+				}
+				email.SendAnswersRequestedNotification(ncfg, &p, &e, asked)
+			})
+		}
+
+		newProposalView(cfg, event, user).shape(proposal)
+		encodeResponse(w, r, proposal)
+	}
+}
+
 // EmergencyCancelHandler allows the proposal owner to emergency-cancel a confirmed proposal
 func EmergencyCancelHandler(cfg *config.Config) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		r.Body = http.MaxBytesReader(w, r.Body, 1<<20) // 1MB limit
+		r.Body = http.MaxBytesReader(w, r.Body, MaxSmallBodySize)
 		defer r.Body.Close()
 
 		user := GetUserFromContext(r.Context())
 		if user == nil {
-			encodeError(w, "Unauthorized", http.StatusUnauthorized)
+			encodeAPIError(w, r, "Unauthorized", http.StatusUnauthorized)
 			return
 		}
 
 		id, err := strconv.ParseUint(r.PathValue("id"), 10, 32)
 		if err != nil {
-			encodeError(w, "Invalid proposal ID", http.StatusBadRequest)
+			encodeAPIError(w, r, "Invalid proposal ID", http.StatusBadRequest)
 			return
 		}
 
 		var proposal models.Proposal
-		if err := cfg.DB.First(&proposal, id).Error; err != nil {
-			encodeError(w, "Proposal not found", http.StatusNotFound)
+		if err := cfg.DBCtx(r).First(&proposal, id).Error; err != nil {
+			encodeAPIError(w, r, "Proposal not found", http.StatusNotFound)
 			return
 		}
 
 		// Only the proposal owner can emergency-cancel
 		if proposal.CreatedByID == nil || *proposal.CreatedByID != user.ID {
-			encodeError(w, "Forbidden", http.StatusForbidden)
+			encodeAPIError(w, r, "Forbidden", http.StatusForbidden)
 			return
 		}
 
 		// Only accepted proposals can be emergency-cancelled
 		if proposal.Status != models.ProposalStatusAccepted {
-			encodeError(w, "Only accepted proposals can be emergency-cancelled", http.StatusBadRequest)
+			encodeAPIError(w, r, "Only accepted proposals can be emergency-cancelled", http.StatusBadRequest)
 			return
 		}
 
 		// Attendance must be confirmed
 		if !proposal.AttendanceConfirmed {
-			encodeError(w, "Only confirmed proposals can be emergency-cancelled", http.StatusBadRequest)
+			encodeAPIError(w, r, "Only confirmed proposals can be emergency-cancelled", http.StatusBadRequest)
 			return
 		}
 
-		if err := cfg.DB.Model(&proposal).Updates(map[string]interface{}{
-			"status":               models.ProposalStatusRejected,
-			"attendance_confirmed": false,
-		}).Error; err != nil {
-			encodeError(w, "Failed to cancel proposal", http.StatusInternalServerError)
+		// Lock the event row first, as status changes do, so accepted-slot
+		// accounting is serialized, then re-check the proposal under its own
+		// lock in case it changed since it was read
+		var promoted *models.Proposal
+		err = cfg.DBCtx(r).Transaction(func(tx *gorm.DB) error {
+			var event models.Event
+			if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&event, proposal.EventID).Error; err != nil {
+				return fmt.Errorf("lock event: %w", err)
+			}
+			if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&proposal, id).Error; err != nil {
+				return fmt.Errorf("lock proposal: %w", err)
+			}
+			if proposal.Status != models.ProposalStatusAccepted || !proposal.AttendanceConfirmed {
+				return errNotCancellable
+			}
+
+			if err := tx.Model(&proposal).Updates(map[string]interface{}{
+				"status":               models.ProposalStatusCancelled,
+				"attendance_confirmed": false,
+			}).Error; err != nil {
+				return fmt.Errorf("cancel proposal: %w", err)
+			}
+
+			if !event.WaitlistAutoPromote {
+				return nil
+			}
+			next, err := promoteFromWaitlist(tx, &event)
+			if err != nil {
+				return err
+			}
+			promoted = next
+			return nil
+		})
+		if err != nil {
+			if errors.Is(err, errNotCancellable) {
+				encodeAPIError(w, r, err.Error(), http.StatusConflict)
+				return
+			}
+			cfg.Logger.Error("failed to emergency cancel proposal", "proposal_id", proposal.ID, "error", err)
+			encodeAPIError(w, r, "Failed to cancel proposal", http.StatusInternalServerError)
 			return
 		}
 
-		if err := cfg.DB.First(&proposal, id).Error; err != nil {
+		if err := cfg.DBCtx(r).First(&proposal, id).Error; err != nil {
 			cfg.Logger.Error("failed to reload proposal after emergency cancel", "error", err)
-			encodeError(w, "Failed to cancel proposal", http.StatusInternalServerError)
+			encodeAPIError(w, r, "Failed to cancel proposal", http.StatusInternalServerError)
 			return
 		}
 
@@ -964,8 +1589,16 @@ func EmergencyCancelHandler(cfg *config.Config) http.HandlerFunc {
 			"event_id", proposal.EventID,
 			"actor_id", user.ID,
 		)
+		if promoted != nil {
+			cfg.Logger.Info("proposal promoted from waitlist",
+				"proposal_id", promoted.ID,
+				"event_id", promoted.EventID,
+				"cancelled_proposal_id", proposal.ID,
+			)
+		}
 
-		// Notify event organisers (fire-and-forget)
+		// Notify event organisers, and the speakers of a promoted proposal,
+		// once the transaction has committed (fire-and-forget)
 		if cfg.EmailSender != nil {
 			p := proposal // copy for goroutine
 			SafeGo(cfg, func() {
@@ -981,6 +1614,9 @@ func EmergencyCancelHandler(cfg *config.Config) http.HandlerFunc {
 					Logger:  cfg.Logger,
 				}
 				email.SendEmergencyCancelNotification(ncfg, &p, &ev)
+				if promoted != nil {
+					email.SendProposalStatusNotification(ncfg, promoted, &ev, models.ProposalStatusAccepted)
+				}
 			})
 		}
 
@@ -988,6 +1624,71 @@ func EmergencyCancelHandler(cfg *config.Config) http.HandlerFunc {
 	}
 }
 
+// Headers telling organizers how much of max_accepted is used. The capacity
+// warning is "full" when an acceptance takes the last slot and "overbooked"
+// when it goes past max_accepted on an event that allows it.
+const (
+	headerAcceptedCount   = "X-Accepted-Count"
+	headerMaxAccepted     = "X-Max-Accepted"
+	headerRemainingSlots  = "X-Remaining-Slots"
+	headerCapacityWarning = "X-Capacity-Warning"
+)
+
+// remainingSlots returns how many more proposals event can accept, never less
+// than zero, or nil when it has no max_accepted
+func remainingSlots(event *models.Event, accepted int64) *int64 {
+	if event.MaxAccepted == nil {
+		return nil
+	}
+	remaining := max(int64(*event.MaxAccepted)-accepted, 0)
+	return &remaining
+}
+
+// setCapacityHeaders sets the accepted count, and for events with a
+// max_accepted, the limit and the slots left
+func setCapacityHeaders(w http.ResponseWriter, event *models.Event, accepted int64) {
+	w.Header().Set(headerAcceptedCount, strconv.FormatInt(accepted, 10))
+	if remaining := remainingSlots(event, accepted); remaining != nil {
+		w.Header().Set(headerMaxAccepted, strconv.Itoa(*event.MaxAccepted))
+		w.Header().Set(headerRemainingSlots, strconv.FormatInt(*remaining, 10))
+	}
+}
+
+// promoteFromWaitlist accepts the highest-rated tentative proposal of event,
+// oldest first on ties, if max_accepted leaves room. The caller must hold the
+// event row lock. It returns nil when there is no room or nothing to promote.
+func promoteFromWaitlist(tx *gorm.DB, event *models.Event) (*models.Proposal, error) {
+	if event.MaxAccepted != nil {
+		var acceptedCount int64
+		if err := tx.Model(&models.Proposal{}).
+			Where("event_id = ? AND status = ?", event.ID, models.ProposalStatusAccepted).
+			Count(&acceptedCount).Error; err != nil {
+			return nil, fmt.Errorf("count accepted: %w", err)
+		}
+		if acceptedCount >= int64(*event.MaxAccepted) {
+			return nil, nil
+		}
+	}
+
+	var next models.Proposal
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("event_id = ? AND status = ?", event.ID, models.ProposalStatusTentative).
+		Order("rating DESC NULLS LAST, created_at ASC, id ASC").
+		First(&next).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("find tentative proposal: %w", err)
+	}
+
+	if err := tx.Model(&next).Update("status", models.ProposalStatusAccepted).Error; err != nil {
+		return nil, fmt.Errorf("promote proposal: %w", err)
+	}
+	next.Status = models.ProposalStatusAccepted
+	return &next, nil
+}
+
 // linkedInHTTPClient is a shared client for checking LinkedIn profile existence.
 var linkedInHTTPClient = &http.Client{
 	Timeout: 3 * time.Second,
@@ -1003,24 +1704,24 @@ var linkedInHTTPClient = &http.Client{
 func CheckLinkedInHandler(cfg *config.Config) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodGet {
-			encodeError(w, "Method not allowed", http.StatusMethodNotAllowed)
+			encodeAPIError(w, r, "Method not allowed", http.StatusMethodNotAllowed)
 			return
 		}
 
 		user := GetUserFromContext(r.Context())
 		if user == nil {
-			encodeError(w, "Unauthorized", http.StatusUnauthorized)
+			encodeAPIError(w, r, "Unauthorized", http.StatusUnauthorized)
 			return
 		}
 
 		profileURL := r.URL.Query().Get("url")
 		if profileURL == "" {
-			encodeError(w, "url parameter is required", http.StatusBadRequest)
+			encodeAPIError(w, r, "url parameter is required", http.StatusBadRequest)
 			return
 		}
 
 		if !linkedInURLRegex.MatchString(profileURL) {
-			encodeError(w, "Invalid LinkedIn URL", http.StatusBadRequest)
+			encodeAPIError(w, r, "Invalid LinkedIn URL", http.StatusBadRequest)
 			return
 		}
 
@@ -1042,3 +1743,87 @@ func CheckLinkedInHandler(cfg *config.Config) http.HandlerFunc {
 		encodeResponse(w, r, map[string]bool{"exists": resp.StatusCode == http.StatusOK})
 	}
 }
+
+// lockedByMessage explains who is editing a proposal, for 423 responses
+func lockedByMessage(lock *models.ProposalLock) string {
+	name := lock.Name
+	if name == "" {
+		name = "Another user"
+	}
+	return fmt.Sprintf("%s is editing this proposal until %s", name, lock.ExpiresAt.UTC().Format("15:04 MST"))
+}
+
+// LockProposalHandler marks a proposal as being edited by the user for
+// ProposalLockDuration, renewing their lock if they already hold it (owner or
+// organizer). Locks are advisory: others get a 423 when updating the proposal
+// unless they force it.
+func LockProposalHandler(cfg *config.Config) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user := GetUserFromContext(r.Context())
+		if user == nil {
+			encodeAPIError(w, r, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		id, err := strconv.ParseUint(r.PathValue("id"), 10, 32)
+		if err != nil {
+			encodeAPIError(w, r, "Invalid proposal ID", http.StatusBadRequest)
+			return
+		}
+
+		proposal, event, err := getProposalWithEvent(cfg, r, uint(id))
+		if proposal == nil {
+			encodeAPIError(w, r, "Proposal not found", http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			encodeAPIError(w, r, "Event not found", http.StatusNotFound)
+			return
+		}
+
+		isOwner := proposal.CreatedByID != nil && *proposal.CreatedByID == user.ID
+		if !isOwner && !event.IsOrganizer(user.ID) {
+			encodeAPIError(w, r, "Forbidden", http.StatusForbidden)
+			return
+		}
+
+		lock, err := models.AcquireProposalLock(cfg.DBCtx(r), proposal.ID, user.ID, cfg.Now())
+		if err != nil || lock == nil {
+			cfg.Logger.Error("failed to lock proposal", "error", err, "proposal_id", proposal.ID)
+			encodeAPIError(w, r, "Failed to lock proposal", http.StatusInternalServerError)
+			return
+		}
+		if !lock.HeldBy(user.ID) {
+			encodeAPIErrorCode(w, r, ErrCodeLocked, lockedByMessage(lock), http.StatusLocked)
+			return
+		}
+
+		encodeResponse(w, r, lock)
+	}
+}
+
+// UnlockProposalHandler releases the user's lock on a proposal once they are
+// done editing it. Other users' locks are left to expire.
+func UnlockProposalHandler(cfg *config.Config) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user := GetUserFromContext(r.Context())
+		if user == nil {
+			encodeAPIError(w, r, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		id, err := strconv.ParseUint(r.PathValue("id"), 10, 32)
+		if err != nil {
+			encodeAPIError(w, r, "Invalid proposal ID", http.StatusBadRequest)
+			return
+		}
+
+		if err := models.ReleaseProposalLock(cfg.DBCtx(r), uint(id), user.ID); err != nil {
+			cfg.Logger.Error("failed to unlock proposal", "error", err, "proposal_id", id)
+			encodeAPIError(w, r, "Failed to unlock proposal", http.StatusInternalServerError)
+			return
+		}
+
+		encodeResponse(w, r, map[string]string{"message": "Proposal unlocked"})
+	}
+}
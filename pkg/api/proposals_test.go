@@ -0,0 +1,342 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sreday/cfp.ninja/pkg/models"
+)
+
+func TestValidateSpeakers_ReportsEveryProblem(t *testing.T) {
+	speakers := []models.Speaker{
+		{Name: "Jane", Email: "jane@example.com", Company: "Acme", JobTitle: "SRE", LinkedIn: "https://linkedin.com/in/jane"},
+		{Name: "", Email: "not-an-email", Company: "", JobTitle: "Dev", LinkedIn: "linkedin.com/in/bob"},
+	}
+
+	var errs validationErrors
+	validateSpeakers(speakers, "jane@example.com", &errs)
+
+	fields := make(map[string]bool)
+	for _, e := range errs {
+		fields[e.Field] = true
+	}
+	for _, want := range []string{"speakers[1].name", "speakers[1].email", "speakers[1].company", "speakers[1].linkedin"} {
+		if !fields[want] {
+			t.Errorf("expected error for %s, got %+v", want, errs)
+		}
+	}
+	if fields["speakers[0].name"] || fields["speakers"] {
+		t.Errorf("expected no errors for the valid speaker, got %+v", errs)
+	}
+}
+
+func TestValidateSpeakers_AccountEmailMustMatch(t *testing.T) {
+	speakers := []models.Speaker{
+		{Name: "Jane", Email: "jane@example.com", Company: "Acme", JobTitle: "SRE", LinkedIn: "https://linkedin.com/in/jane"},
+	}
+
+	var errs validationErrors
+	validateSpeakers(speakers, "someone@example.com", &errs)
+	if len(errs) != 1 || errs[0].Field != "speakers" {
+		t.Errorf("expected a single speakers error, got %+v", errs)
+	}
+
+	errs = nil
+	validateSpeakers(speakers, " Jane@Example.COM ", &errs)
+	if len(errs) != 0 {
+		t.Errorf("expected the account email to match regardless of case, got %+v", errs)
+	}
+
+	errs = nil
+	validateSpeakers(speakers, "", &errs)
+	if len(errs) != 0 {
+		t.Errorf("expected no errors without an account email, got %+v", errs)
+	}
+}
+
+func TestValidateCustomAnswers(t *testing.T) {
+	questions := []models.CustomQuestion{
+		{ID: "coc", Type: "checkbox", Required: true},
+		{ID: "travel", Type: "select"},
+	}
+	answers := map[string]interface{}{
+		"coc":     "yes",
+		"travel":  strings.Repeat("x", MaxCustomAnswerLen+1),
+		"unknown": "value",
+	}
+
+	var errs validationErrors
+	validateCustomAnswers(answers, questions, &errs)
+
+	if len(errs) != 3 {
+		t.Fatalf("expected 3 errors, got %+v", errs)
+	}
+	// Errors are sorted by question ID
+	want := []string{"custom_answers.coc", "custom_answers.travel", "custom_answers.unknown"}
+	for i, e := range errs {
+		if e.Field != want[i] {
+			t.Errorf("expected field %q at %d, got %q", want[i], i, e.Field)
+		}
+	}
+}
+
+func TestValidateCustomAnswers_Size(t *testing.T) {
+	var questions []models.CustomQuestion
+	answers := make(map[string]interface{})
+	for i := 0; i < 12; i++ {
+		id := fmt.Sprintf("q%02d", i)
+		questions = append(questions, models.CustomQuestion{ID: id, Type: "text"})
+		answers[id] = strings.Repeat("x", MaxCustomAnswerLen-i)
+	}
+
+	var errs validationErrors
+	validateCustomAnswers(answers, questions, &errs)
+	if len(errs) != 1 || errs[0].Field != "custom_answers.q00" {
+		t.Fatalf("expected one error naming the longest answer, got %+v", errs)
+	}
+
+	for _, id := range []string{"q00", "q01", "q02"} {
+		delete(answers, id)
+	}
+	errs = nil
+	validateCustomAnswers(answers, questions, &errs)
+	if len(errs) != 0 {
+		t.Errorf("expected answers within the total to pass, got %+v", errs)
+	}
+}
+
+func TestValidateCustomAnswers_Nested(t *testing.T) {
+	questions := []models.CustomQuestion{
+		{ID: "bio_long", Type: "text"},
+		{ID: "coc", Type: "checkbox"},
+	}
+	answers := map[string]interface{}{
+		"bio_long": map[string]interface{}{"a": []interface{}{map[string]interface{}{"b": "c"}}},
+		"coc":      []interface{}{true},
+	}
+
+	var errs validationErrors
+	validateCustomAnswers(answers, questions, &errs)
+	if len(errs) != 2 || errs[0].Field != "custom_answers.bio_long" || errs[1].Field != "custom_answers.coc" {
+		t.Errorf("expected nested answers to be rejected per question, got %+v", errs)
+	}
+}
+
+func TestLockSpeakers(t *testing.T) {
+	saved := []models.Speaker{
+		{Name: "Jane Doe", Email: "jane@example.com", Bio: "Old bio", JobTitle: "SRE", Company: "Acme", LinkedIn: "https://linkedin.com/in/jane", Primary: true},
+	}
+	updated := []models.Speaker{
+		{Name: "Someone Else", Email: "else@example.com", Bio: "New bio", JobTitle: "Staff SRE", Company: "Acme Corp", LinkedIn: "https://linkedin.com/in/else", PhotoURL: "https://example.com/jane.jpg"},
+	}
+
+	var errs validationErrors
+	got := lockSpeakers(saved, updated, &errs)
+	if len(errs) != 0 {
+		t.Fatalf("unexpected errors: %+v", errs)
+	}
+	want := models.Speaker{Name: "Jane Doe", Email: "jane@example.com", Bio: "New bio", JobTitle: "Staff SRE", Company: "Acme Corp",
+		LinkedIn: "https://linkedin.com/in/jane", PhotoURL: "https://example.com/jane.jpg", Primary: true}
+	if len(got) != 1 || got[0] != want {
+		t.Errorf("lockSpeakers() = %+v, want %+v", got, want)
+	}
+	if saved[0].Bio != "Old bio" {
+		t.Errorf("expected the saved speakers to be left alone, got %+v", saved)
+	}
+
+	got = lockSpeakers(saved, append(updated, updated[0]), &errs)
+	if len(errs) != 1 || errs[0].Field != "speakers" || len(got) != 1 {
+		t.Errorf("expected adding a speaker to fail, got %+v and %+v", got, errs)
+	}
+}
+
+func TestValidateRequiredAnswers(t *testing.T) {
+	questions := []models.CustomQuestion{
+		{ID: "coc", Type: "checkbox", Required: true},
+		{ID: "bio_long", Type: "text", Required: true},
+		{ID: "travel", Type: "select"},
+	}
+
+	var errs validationErrors
+	validateRequiredAnswers(map[string]interface{}{"coc": true}, questions, &errs)
+
+	if len(errs) != 1 || errs[0].Field != "custom_answers.bio_long" {
+		t.Fatalf("expected only bio_long to be missing, got %+v", errs)
+	}
+}
+
+func TestValidateAnswerWindows(t *testing.T) {
+	now := time.Date(2026, 5, 10, 0, 0, 0, 0, time.UTC)
+	closed := now.Add(-24 * time.Hour)
+	opens := now.Add(24 * time.Hour)
+	questions := []models.CustomQuestion{
+		{ID: "main", Type: "text", Required: true, CloseAt: &closed},
+		{ID: "future", Type: "text", OpenAt: &opens},
+		{ID: "late", Type: "text", Required: true},
+	}
+
+	if active := activeQuestions(questions, now, 0); len(active) != 1 || active[0].ID != "late" {
+		t.Errorf("expected only late to be active, got %+v", active)
+	}
+
+	var errs validationErrors
+	validateAnswerWindows(map[string]interface{}{"main": "a", "future": "b", "late": "c"}, nil, questions, now, 0, &errs)
+	if len(errs) != 2 || errs[0].Field != "custom_answers.main" || errs[1].Field != "custom_answers.future" {
+		t.Fatalf("expected main and future to be rejected, got %+v", errs)
+	}
+
+	// Saved answers to closed questions are kept on edits, but can't change
+	previous := map[string]interface{}{"main": "a"}
+	errs = nil
+	validateAnswerWindows(map[string]interface{}{"main": "a", "late": "c2"}, previous, questions, now, 0, &errs)
+	if len(errs) != 0 {
+		t.Errorf("expected the unchanged answer to be kept, got %+v", errs)
+	}
+	errs = nil
+	validateAnswerWindows(map[string]interface{}{"main": "changed"}, previous, questions, now, 0, &errs)
+	if len(errs) != 1 || errs[0].Field != "custom_answers.main" {
+		t.Errorf("expected the changed answer to be rejected, got %+v", errs)
+	}
+}
+
+func TestValidateCoCAcceptance(t *testing.T) {
+	event := &models.Event{CoCURL: "https://example.com/coc", RequireCoCAcceptance: true}
+
+	var errs validationErrors
+	if !validateCoCAcceptance(event, true, &errs) || len(errs) != 0 {
+		t.Errorf("expected acceptance, got %+v", errs)
+	}
+
+	for _, v := range []interface{}{nil, false, "yes"} {
+		errs = nil
+		if validateCoCAcceptance(event, v, &errs) || len(errs) != 1 || errs[0].Field != "coc_accepted" {
+			t.Errorf("%v: expected a coc_accepted error, got %+v", v, errs)
+		}
+	}
+
+	errs = nil
+	if validateCoCAcceptance(&models.Event{}, nil, &errs) || len(errs) != 0 {
+		t.Errorf("expected no requirement without require_coc_acceptance, got %+v", errs)
+	}
+}
+
+func TestCountWords(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want int
+	}{
+		{"empty", "", 0},
+		{"only whitespace", " \n\t ", 0},
+		{"plain", "Scaling Postgres at the edge", 5},
+		{"repeated whitespace and newlines", "one  two\n\nthree\tfour", 4},
+		{"non-breaking space", "one\u00a0two", 2},
+		{"ideographic space", "一\u3000二", 2},
+		{"zero-width space", "one\u200btwo", 2},
+		{"punctuation only tokens", "before - after — end ...", 3},
+		{"hyphenated and contractions", "it's a well-known fact", 4},
+		{"accented and non-latin", "Café Zürich Ελληνικά", 3},
+		{"numbers", "in 2026 we ran 3 clusters", 6},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := countWords(tt.in); got != tt.want {
+				t.Errorf("countWords(%q) = %d, want %d", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateAbstractWords(t *testing.T) {
+	minWords, maxWords := 3, 5
+	organizerID := uint(7)
+	event := &models.Event{AbstractMinWords: &minWords, AbstractMaxWords: &maxWords, CreatedByID: &organizerID}
+
+	var errs validationErrors
+	validateAbstractWords(event, "too short", 1, &errs)
+	if len(errs) != 1 || errs[0].Field != "abstract" || errs[0].Message != "Abstract must be at least 3 words (currently 2)" {
+		t.Errorf("expected a minimum words error, got %+v", errs)
+	}
+
+	errs = nil
+	validateAbstractWords(event, "one two three four five six", 1, &errs)
+	if len(errs) != 1 || errs[0].Message != "Abstract must be at most 5 words (currently 6)" {
+		t.Errorf("expected a maximum words error, got %+v", errs)
+	}
+
+	errs = nil
+	validateAbstractWords(event, "one two three", 1, &errs)
+	if len(errs) != 0 {
+		t.Errorf("expected no errors within the limits, got %+v", errs)
+	}
+
+	errs = nil
+	validateAbstractWords(event, "short", organizerID, &errs)
+	if len(errs) != 0 {
+		t.Errorf("expected organizers to be exempt, got %+v", errs)
+	}
+
+	errs = nil
+	validateAbstractWords(&models.Event{}, "short", 1, &errs)
+	if len(errs) != 0 {
+		t.Errorf("expected no limits by default, got %+v", errs)
+	}
+}
+
+func TestProposalSource(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers map[string]string
+		want    string
+	}{
+		{"cookie session", nil, models.ProposalSourceWeb},
+		{"bearer token", map[string]string{"Authorization": "Bearer x"}, models.ProposalSourceAPI},
+		{"cli header", map[string]string{"Authorization": "Bearer x", "X-Client": "cfp-cli/1.4.0"}, models.ProposalSourceCLI},
+		{"cli user agent", map[string]string{"Authorization": "Bearer x", "User-Agent": "cfp-cli/dev"}, models.ProposalSourceCLI},
+		{"other client", map[string]string{"Authorization": "Bearer x", "X-Client": "my-script/1"}, models.ProposalSourceAPI},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest(http.MethodPost, "/api/v0/events/1/proposals", nil)
+		for k, v := range tt.headers {
+			r.Header.Set(k, v)
+		}
+		if got := proposalSource(r); got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestSetCapacityHeaders(t *testing.T) {
+	limit := 3
+	tests := []struct {
+		name      string
+		max       *int
+		accepted  int64
+		remaining string
+	}{
+		{"unlimited", nil, 5, ""},
+		{"slots left", &limit, 1, "2"},
+		{"full", &limit, 3, "0"},
+		{"overbooked", &limit, 4, "0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			setCapacityHeaders(rr, &models.Event{MaxAccepted: tt.max}, tt.accepted)
+			if got := rr.Header().Get(headerAcceptedCount); got != strconv.FormatInt(tt.accepted, 10) {
+				t.Errorf("expected accepted count %d, got %q", tt.accepted, got)
+			}
+			if got := rr.Header().Get(headerRemainingSlots); got != tt.remaining {
+				t.Errorf("expected remaining slots %q, got %q", tt.remaining, got)
+			}
+			if hasMax := rr.Header().Get(headerMaxAccepted) != ""; hasMax != (tt.max != nil) {
+				t.Errorf("expected max accepted header only with a limit, got %q", rr.Header().Get(headerMaxAccepted))
+			}
+		})
+	}
+}
@@ -0,0 +1,241 @@
+package api
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sreday/cfp.ninja/pkg/config"
+	"github.com/sreday/cfp.ninja/pkg/models"
+)
+
+// Pagination of the public API
+const (
+	DefaultPublicPageSize = 50
+	MaxPublicPageSize     = 100
+)
+
+// publicEventListedSQL matches events shown publicly: not deleted, not a
+// draft, not unlisted or archived, and approved by moderation. Bind it with
+// publicEventListedVars.
+const publicEventListedSQL = "deleted_at IS NULL AND cfp_status != @draft AND moderation_status = @approved AND NOT unlisted AND NOT archived"
+
+func publicEventListedVars() map[string]interface{} {
+	return map[string]interface{}{"draft": models.CFPStatusDraft, "approved": models.ModerationApproved}
+}
+
+// publicEventChangedSQL is when an event last changed, counting deletion
+const publicEventChangedSQL = "GREATEST(updated_at, COALESCE(deleted_at, updated_at))"
+
+// PublicEvent is an event as served by the public API. Its field names are
+// part of the API contract and do not follow changes to models.Event: add
+// fields, never rename or remove them.
+type PublicEvent struct {
+	ID                 uint                  `json:"id"`
+	Removed            bool                  `json:"removed"` // Always false; see removedPublicEvent
+	UpdatedAt          time.Time             `json:"updated_at"`
+	Slug               string                `json:"slug"`
+	Name               string                `json:"name"`
+	Description        string                `json:"description"`
+	DescriptionFormat  string                `json:"description_format"`         // "plaintext" or "markdown"
+	DescriptionHTML    string                `json:"description_html,omitempty"` // Sanitized HTML for markdown descriptions
+	Summary            string                `json:"summary"`
+	URL                string                `json:"url"`
+	Website            string                `json:"website"`
+	LogoURL            string                `json:"logo_url"`
+	Location           string                `json:"location"`
+	CountryCode        string                `json:"country_code"`
+	AttendanceMode     models.AttendanceMode `json:"attendance_mode"`
+	StartDate          *time.Time            `json:"start_date"` // null when unset
+	EndDate            *time.Time            `json:"end_date"`
+	Tags               []string              `json:"tags"`
+	Sections           []models.EventSection `json:"sections"`
+	CFPOpen            bool                  `json:"cfp_open"`
+	CFPOpenAt          *time.Time            `json:"cfp_open_at"`
+	CFPCloseAt         *time.Time            `json:"cfp_close_at"`
+	CFPURL             string                `json:"cfp_url"`
+	AbstractMinWords   *int                  `json:"abstract_min_words"` // null = no limit
+	AbstractMaxWords   *int                  `json:"abstract_max_words"`
+	TravelCovered      bool                  `json:"travel_covered"`
+	HotelCovered       bool                  `json:"hotel_covered"`
+	HonorariumProvided bool                  `json:"honorarium_provided"`
+	CreatedAt          time.Time             `json:"created_at"`
+}
+
+// removedPublicEvent tells an incremental sync that an event it may have
+// copied was deleted, unpublished, unlisted or rejected by moderation
+type removedPublicEvent struct {
+	ID        uint      `json:"id"`
+	Removed   bool      `json:"removed"` // Always true
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// optionalTime returns nil for unset times
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	t = t.UTC()
+	return &t
+}
+
+// newPublicEvent converts a listed event for the public API
+func newPublicEvent(cfg *config.Config, e *models.Event) PublicEvent {
+	tags := []string{}
+	for _, t := range strings.Split(e.Tags, ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			tags = append(tags, t)
+		}
+	}
+	sections, err := e.GetSections()
+	if err != nil || sections == nil {
+		sections = []models.EventSection{}
+	}
+	page := cfg.BaseURL + "/e/" + url.PathEscape(e.Slug)
+	return PublicEvent{
+		ID:                 e.ID,
+		UpdatedAt:          e.UpdatedAt.UTC(),
+		CreatedAt:          e.CreatedAt.UTC(),
+		Slug:               e.Slug,
+		Name:               e.Name,
+		Description:        e.Description,
+		DescriptionFormat:  string(e.DescriptionFormat),
+		DescriptionHTML:    e.DescriptionHTML,
+		Summary:            e.Summary,
+		URL:                page,
+		Website:            e.Website,
+		LogoURL:            e.LogoURL,
+		Location:           e.Location,
+		CountryCode:        e.CountryCode,
+		AttendanceMode:     e.AttendanceMode,
+		StartDate:          optionalTime(e.StartDate),
+		EndDate:            optionalTime(e.EndDate),
+		Tags:               tags,
+		Sections:           sections,
+		CFPOpen:            e.IsCFPOpenAt(cfg.Now(), 0),
+		CFPOpenAt:          optionalTime(e.CFPOpenAt),
+		CFPCloseAt:         optionalTime(e.CFPCloseAt),
+		CFPURL:             page + "/submit",
+		AbstractMinWords:   e.AbstractMinWords,
+		AbstractMaxWords:   e.AbstractMaxWords,
+		TravelCovered:      e.TravelCovered,
+		HotelCovered:       e.HotelCovered,
+		HonorariumProvided: e.HonorariumProvided,
+	}
+}
+
+// ListPublicEventsHandler lists events for API key holders, oldest change
+// first. Results are always paginated. With ?updated_since= only events
+// changed after that time are returned, including events that were deleted,
+// unpublished or rejected since, which come back with only id, updated_at and
+// removed: true so that a synced copy can drop them.
+// GET /api/v0/public/events
+func ListPublicEventsHandler(cfg *config.Config) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		var errs validationErrors
+
+		var since time.Time
+		if s := q.Get("updated_since"); s != "" {
+			t, err := time.Parse(time.RFC3339, s)
+			if err != nil {
+				errs.add("updated_since", "updated_since must be an RFC 3339 timestamp, e.g. 2026-01-02T15:04:05Z")
+			}
+			since = t
+		}
+
+		status := q.Get("status")
+		if status != "" && status != "open" && status != "closed" {
+			errs.add("status", "status must be open or closed")
+		}
+
+		page, perPage := 1, DefaultPublicPageSize
+		if s := q.Get("page"); s != "" {
+			n, err := strconv.Atoi(s)
+			if err != nil || n < 1 {
+				errs.add("page", "page must be a positive integer")
+			}
+			page = n
+		}
+		if s := q.Get("per_page"); s != "" {
+			n, err := strconv.Atoi(s)
+			if err != nil || n < 1 || n > MaxPublicPageSize {
+				errs.add("per_page", "per_page must be between 1 and 100")
+			}
+			perPage = n
+		}
+
+		if len(errs) > 0 {
+			encodeValidationErrors(w, r, errs)
+			return
+		}
+
+		query := cfg.DBCtx(r).Unscoped().Model(&models.Event{})
+		if since.IsZero() {
+			query = query.Where(publicEventListedSQL, publicEventListedVars())
+		} else {
+			query = query.Where(publicEventChangedSQL+" > ?", since)
+		}
+		if status != "" {
+			cond := cfpOpenSQL
+			if status == "closed" {
+				cond = "NOT (" + cfpOpenSQL + ")"
+			}
+			// Removed events are always returned to incremental syncs
+			query = query.Where("(NOT ("+publicEventListedSQL+") OR ("+cond+"))", mergeVars(publicEventListedVars(), cfpOpenVars(cfg.Now())))
+		}
+
+		var total int64
+		if err := query.Count(&total).Error; err != nil {
+			cfg.Logger.Error("failed to count public events", "error", err)
+			encodeAPIError(w, r, "Failed to load events", http.StatusInternalServerError)
+			return
+		}
+
+		var events []models.Event
+		if err := query.Order(publicEventChangedSQL + " ASC, id ASC").
+			Offset((page - 1) * perPage).Limit(perPage).
+			Find(&events).Error; err != nil {
+			cfg.Logger.Error("failed to query public events", "error", err)
+			encodeAPIError(w, r, "Failed to load events", http.StatusInternalServerError)
+			return
+		}
+
+		data := make([]interface{}, len(events))
+		for i := range events {
+			e := &events[i]
+			if e.DeletedAt.Valid || e.CFPStatus == models.CFPStatusDraft || e.Unlisted || e.Archived || !e.IsListed() {
+				changed := e.UpdatedAt
+				if e.DeletedAt.Valid && e.DeletedAt.Time.After(changed) {
+					changed = e.DeletedAt.Time
+				}
+				data[i] = removedPublicEvent{ID: e.ID, Removed: true, UpdatedAt: changed.UTC()}
+				continue
+			}
+			data[i] = newPublicEvent(cfg, e)
+		}
+
+		encodeResponse(w, r, map[string]interface{}{
+			"data": data,
+			"pagination": map[string]interface{}{
+				"page":        page,
+				"per_page":    perPage,
+				"total":       total,
+				"total_pages": int((total + int64(perPage) - 1) / int64(perPage)),
+			},
+		})
+	}
+}
+
+// mergeVars combines named SQL variables from several helpers
+func mergeVars(maps ...map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{})
+	for _, m := range maps {
+		for k, v := range m {
+			merged[k] = v
+		}
+	}
+	return merged
+}
@@ -0,0 +1,67 @@
+package api
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/sreday/cfp.ninja/pkg/config"
+)
+
+// publicCache holds the platform stats and country listings shown on the
+// landing page, which aggregate every event, so page loads don't each scan
+// the events table. Event writes empty it with invalidatePublicCache.
+var publicCache = struct {
+	sync.Mutex
+	entries    map[string]cachedResponse
+	generation uint64
+}{entries: make(map[string]cachedResponse)}
+
+const publicCacheTTL = 5 * time.Minute
+
+type cachedResponse struct {
+	data      interface{}
+	expiresAt time.Time
+}
+
+// getPublicCache returns the cached response for key, if fresh, and the
+// cache generation to pass to setPublicCache
+func getPublicCache(key string, now time.Time) (interface{}, uint64, bool) {
+	publicCache.Lock()
+	defer publicCache.Unlock()
+	entry, ok := publicCache.entries[key]
+	if !ok || now.After(entry.expiresAt) {
+		return nil, publicCache.generation, false
+	}
+	return entry.data, publicCache.generation, true
+}
+
+// setPublicCache caches a response computed during the given generation. It
+// is dropped if an event was written since, as it may already be stale.
+func setPublicCache(key string, data interface{}, generation uint64, now time.Time) {
+	publicCache.Lock()
+	defer publicCache.Unlock()
+	if generation != publicCache.generation {
+		return
+	}
+	publicCache.entries[key] = cachedResponse{data: data, expiresAt: now.Add(publicCacheTTL)}
+}
+
+// invalidatePublicCache empties the cache after an event is created, changed
+// or deleted
+func invalidatePublicCache() {
+	publicCache.Lock()
+	defer publicCache.Unlock()
+	publicCache.generation++
+	clear(publicCache.entries)
+}
+
+// wantsFreshResponse reports whether an admin asked with ?fresh=true to skip
+// the cache
+func wantsFreshResponse(cfg *config.Config, r *http.Request) bool {
+	if r.URL.Query().Get("fresh") != "true" {
+		return false
+	}
+	user := GetUserFromContext(r.Context())
+	return user != nil && cfg.IsAdmin(user.Email)
+}
@@ -0,0 +1,36 @@
+package api
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPublicCache(t *testing.T) {
+	invalidatePublicCache()
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	_, generation, ok := getPublicCache("stats", now)
+	if ok {
+		t.Fatal("expected an empty cache")
+	}
+	setPublicCache("stats", "cached", generation, now)
+	if data, _, ok := getPublicCache("stats", now.Add(publicCacheTTL-time.Second)); !ok || data != "cached" {
+		t.Errorf("expected the cached response, got %v %v", data, ok)
+	}
+	if _, _, ok := getPublicCache("stats", now.Add(publicCacheTTL+time.Second)); ok {
+		t.Error("expected the response to expire")
+	}
+
+	invalidatePublicCache()
+	if _, _, ok := getPublicCache("stats", now); ok {
+		t.Error("expected an event write to empty the cache")
+	}
+
+	// A response computed before an event write must not be cached after it
+	_, generation, _ = getPublicCache("countries", now)
+	invalidatePublicCache()
+	setPublicCache("countries", "stale", generation, now)
+	if _, _, ok := getPublicCache("countries", now); ok {
+		t.Error("expected the stale response to be dropped")
+	}
+}
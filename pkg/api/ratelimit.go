@@ -99,15 +99,20 @@ func (rl *RateLimiter) cleanupLoop(ctx context.Context) {
 	}
 }
 
-// clientIP extracts the real client IP, only trusting X-Forwarded-For when
-// the direct connection comes from a trusted proxy.
+// clientIP returns the client IP of r, trusting the limiter's proxies
 func (rl *RateLimiter) clientIP(r *http.Request) string {
+	return clientIP(r, rl.trustedProxies)
+}
+
+// clientIP extracts the real client IP, only trusting X-Forwarded-For when
+// the direct connection comes from one of trustedProxies.
+func clientIP(r *http.Request, trustedProxies map[string]bool) string {
 	remoteIP, _, err := net.SplitHostPort(r.RemoteAddr)
 	if err != nil {
 		remoteIP = r.RemoteAddr
 	}
 
-	if len(rl.trustedProxies) == 0 || !rl.trustedProxies[remoteIP] {
+	if len(trustedProxies) == 0 || !trustedProxies[remoteIP] {
 		return remoteIP
 	}
 
@@ -121,7 +126,7 @@ func (rl *RateLimiter) clientIP(r *http.Request) string {
 	parts := strings.Split(xff, ",")
 	for i := len(parts) - 1; i >= 0; i-- {
 		ip := strings.TrimSpace(parts[i])
-		if ip != "" && !rl.trustedProxies[ip] {
+		if ip != "" && !trustedProxies[ip] {
 			return ip
 		}
 	}
@@ -129,6 +134,12 @@ func (rl *RateLimiter) clientIP(r *http.Request) string {
 	return remoteIP
 }
 
+// Allow reports whether a request identified by key, such as an API key ID
+// rather than an IP, is within the rate limit.
+func (rl *RateLimiter) Allow(key string) bool {
+	return rl.getLimiter(key).Allow()
+}
+
 // Middleware returns an HTTP middleware that enforces rate limits per IP.
 func (rl *RateLimiter) Middleware(next http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
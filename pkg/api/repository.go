@@ -0,0 +1,94 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/sreday/cfp.ninja/pkg/config"
+	"github.com/sreday/cfp.ninja/pkg/models"
+)
+
+type requestCacheKey struct{}
+
+// requestCache holds the events and proposals loaded while serving one
+// request, so a handler and the helpers it calls share one copy instead of
+// querying again. It is only used from the request's goroutine.
+type requestCache struct {
+	events    map[uint]*models.Event
+	proposals map[uint]*models.Proposal
+}
+
+// RequestCache wraps a handler to give each request its own cache for
+// getEventWithOrganizers and getProposalWithEvent. Without it the helpers
+// still work but load from the database on every call.
+func RequestCache(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cache := &requestCache{
+			events:    make(map[uint]*models.Event),
+			proposals: make(map[uint]*models.Proposal),
+		}
+		ctx := context.WithValue(r.Context(), requestCacheKey{}, cache)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+func getRequestCache(r *http.Request) *requestCache {
+	cache, _ := r.Context().Value(requestCacheKey{}).(*requestCache)
+	return cache
+}
+
+// getEventWithOrganizers loads an event with its organizers in two queries,
+// where Preload("Organizers") takes three (event, join table, users). The
+// result is memoized for the rest of the request, and callers share it.
+func getEventWithOrganizers(cfg *config.Config, r *http.Request, id uint) (*models.Event, error) {
+	cache := getRequestCache(r)
+	if cache != nil {
+		if event, ok := cache.events[id]; ok {
+			return event, nil
+		}
+	}
+
+	var event models.Event
+	if err := cfg.DBCtx(r).First(&event, id).Error; err != nil {
+		return nil, err
+	}
+	if err := cfg.DBCtx(r).
+		Joins("JOIN event_organizers ON event_organizers.user_id = users.id").
+		Where("event_organizers.event_id = ?", event.ID).
+		Order("users.id").
+		Find(&event.Organizers).Error; err != nil {
+		return nil, err
+	}
+
+	if cache != nil {
+		cache.events[id] = &event
+	}
+	return &event, nil
+}
+
+// getProposalWithEvent loads a proposal and, through getEventWithOrganizers,
+// its event with organizers. Both are memoized for the rest of the request.
+// When only the event fails to load, the proposal is returned with the error,
+// so callers can tell a missing proposal from a missing event.
+func getProposalWithEvent(cfg *config.Config, r *http.Request, id uint) (*models.Proposal, *models.Event, error) {
+	cache := getRequestCache(r)
+	var proposal *models.Proposal
+	if cache != nil {
+		proposal = cache.proposals[id]
+	}
+	if proposal == nil {
+		proposal = &models.Proposal{}
+		if err := cfg.DBCtx(r).First(proposal, id).Error; err != nil {
+			return nil, nil, err
+		}
+		if cache != nil {
+			cache.proposals[id] = proposal
+		}
+	}
+
+	event, err := getEventWithOrganizers(cfg, r, proposal.EventID)
+	if err != nil {
+		return proposal, nil, err
+	}
+	return proposal, event, nil
+}
@@ -0,0 +1,48 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sreday/cfp.ninja/pkg/config"
+	"github.com/sreday/cfp.ninja/pkg/models"
+)
+
+func TestRequestCache_MemoizesPerRequest(t *testing.T) {
+	// No database: a lookup that isn't served from the cache would panic
+	cfg := &config.Config{}
+
+	var requests int
+	inner := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		cache := getRequestCache(r)
+		if cache == nil {
+			t.Fatal("expected a request cache in context")
+		}
+		if len(cache.events) != 0 || len(cache.proposals) != 0 {
+			t.Fatal("expected every request to start with an empty cache")
+		}
+		cache.events[7] = &models.Event{Name: "GopherCon"}
+		cache.proposals[3] = &models.Proposal{Title: "Go Performance", EventID: 7}
+
+		proposal, event, err := getProposalWithEvent(cfg, r, 3)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if proposal != cache.proposals[3] || event != cache.events[7] {
+			t.Error("expected the cached proposal and event")
+		}
+		if again, err := getEventWithOrganizers(cfg, r, 7); err != nil || again != event {
+			t.Error("expected the same event on a second lookup")
+		}
+	})
+
+	handler := RequestCache(inner)
+	for range 2 {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	}
+	if requests != 2 {
+		t.Errorf("expected 2 requests, got %d", requests)
+	}
+}
@@ -0,0 +1,91 @@
+package api
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/sreday/cfp.ninja/pkg/config"
+	"github.com/sreday/cfp.ninja/pkg/models"
+)
+
+// responseShapes has a sample of each response type built in this package.
+// Their JSON is compared against testdata/response_shapes.golden.json so
+// fields aren't added, renamed or dropped by accident. Run
+// `go test ./pkg/api -run TestResponseShapes -update` after an intended change.
+func responseShapes() map[string]interface{} {
+	created := time.Date(2026, 1, 5, 9, 30, 0, 0, time.UTC)
+	updated := time.Date(2026, 2, 1, 18, 0, 0, 0, time.UTC)
+	start := time.Date(2026, 6, 10, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 1)
+	closeAt := time.Date(2026, 5, 1, 23, 59, 0, 0, time.UTC)
+	rating := 4
+	proposalID := uint(7)
+
+	user := models.User{Model: gorm.Model{ID: 3, CreatedAt: created, UpdatedAt: updated}, Email: "ann@example.com", Name: "Ann Lee"}
+	event := &models.Event{
+		Model: gorm.Model{ID: 1, CreatedAt: created, UpdatedAt: updated},
+		Name:  "SREday London 2026", Slug: "sreday-london-2026", Summary: "A day of talks about reliability.",
+		Description: "A day of talks about reliability.", DescriptionFormat: models.DescriptionPlaintext,
+		Location: "London", CountryCode: "GB", AttendanceMode: models.AttendanceInPerson,
+		StartDate: start, EndDate: end, CFPStatus: models.CFPStatusOpen, CFPCloseAt: closeAt,
+		ModerationStatus: models.ModerationApproved,
+	}
+
+	return map[string]interface{}{
+		"organizer": newOrganizerResponse(user, true),
+		"managing_event": managingEvent{
+			ID: 1, Name: event.Name, Slug: event.Slug, StartDate: start, EndDate: end, CFPStatus: "open",
+			ProposalCount: 12, IsPaid: true, CreatedAt: created, UpdatedAt: updated,
+		},
+		"submitted_event": submittedEvent{
+			ID: 1, Name: event.Name, Slug: event.Slug, CFPStatus: "open", CreatedAt: created, UpdatedAt: updated,
+			MyProposals: []myProposal{{
+				ID: 7, Title: "Building Reliable Systems", Status: "accepted", Rating: &rating,
+				AttendanceConfirmed: true, AttendanceConfirmedAt: &updated, CreatedAt: created, UpdatedAt: updated,
+			}},
+		},
+		"dashboard_event": dashboardEvent{
+			ID: 1, Name: event.Name, Slug: event.Slug, StartDate: start, EndDate: end,
+			CFPStatus: models.CFPStatusOpen, CFPOpen: true, CFPCloseAt: &closeAt, IsCreator: true,
+			PaymentStatus: PaymentStatusPaid, ProposalCounts: map[string]int64{"submitted": 2, "accepted": 1},
+			ProposalTotal: 3, UnratedCount: 1, CreatedAt: created, UpdatedAt: updated,
+			Organizers: []dashboardOrganizer{{ID: 3, Name: "Ann Lee", Email: "ann@example.com", CreatedAt: created, UpdatedAt: updated}},
+		},
+		"dashboard_deadline":    dashboardDeadline{EventID: 1, Name: event.Name, CFPCloseAt: closeAt},
+		"moderation_event":      newModerationEvent(*event),
+		"full_export_organizer": newFullExportOrganizer(user, "creator"),
+		"activity": activityItem{
+			ID: 9, Action: "proposal_status", Summary: "Ann Lee accepted a proposal",
+			Actor: &activityActor{ID: 3, Name: "Ann Lee"}, ProposalID: &proposalID, CreatedAt: created,
+		},
+		"public_event":         newPublicEvent(&config.Config{BaseURL: "https://cfp.ninja", Clock: func() time.Time { return updated }}, event),
+		"removed_public_event": removedPublicEvent{ID: 2, Removed: true, UpdatedAt: updated},
+	}
+}
+
+func TestResponseShapes(t *testing.T) {
+	got, err := json.MarshalIndent(responseShapes(), "", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join("testdata", "response_shapes.golden.json")
+	if *updateGolden {
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read golden file (run with -update to create it): %v", err)
+	}
+	if string(got) != string(want) {
+		t.Errorf("%s differs from the responses (run with -update if the change is intended):\n%s", path, got)
+	}
+}
@@ -0,0 +1,383 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"regexp"
+	"strings"
+	"syscall"
+	"time"
+	"unicode/utf8"
+
+	"github.com/sreday/cfp.ninja/pkg/config"
+	"github.com/sreday/cfp.ninja/pkg/countries"
+)
+
+// MaxScrapePageSize is how much of an event website a scrape preview reads
+const MaxScrapePageSize = 2 << 20
+
+// errScrapeAddrNotPublic is returned when a scraped URL resolves to an
+// address that isn't on the public internet
+var errScrapeAddrNotPublic = errors.New("address is not public")
+
+// nonPublicPrefixes are ranges netip does not already classify as private or
+// local: carrier-grade NAT, IETF protocol assignments, benchmarking and the
+// NAT64 well-known prefix, which can wrap any IPv4 address
+var nonPublicPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("64:ff9b::/96"),
+}
+
+// isPublicAddr reports whether addr is a unicast address on the public internet
+func isPublicAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !addr.IsGlobalUnicast() || addr.IsPrivate() {
+		return false
+	}
+	for _, p := range nonPublicPrefixes {
+		if p.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+// dialPublicOnly refuses connections to non-public addresses. It runs after
+// DNS resolution, so a hostname pointing at an internal service is refused
+// too, including when it changes between lookups.
+func dialPublicOnly(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil || !isPublicAddr(addr) {
+		return errScrapeAddrNotPublic
+	}
+	return nil
+}
+
+// scrapeHTTPClient fetches event websites for scrape previews. It only
+// connects to public addresses, never uses a proxy and only follows redirects
+// to other HTTPS URLs.
+var scrapeHTTPClient = &http.Client{
+	Timeout: 10 * time.Second,
+	Transport: &http.Transport{
+		DialContext: (&net.Dialer{
+			Timeout: 5 * time.Second,
+			Control: dialPublicOnly,
+		}).DialContext,
+		TLSHandshakeTimeout:   5 * time.Second,
+		ResponseHeaderTimeout: 5 * time.Second,
+		MaxIdleConns:          10,
+		IdleConnTimeout:       30 * time.Second,
+	},
+	CheckRedirect: func(req *http.Request, via []*http.Request) error {
+		if len(via) >= 5 {
+			return errors.New("too many redirects")
+		}
+		if req.URL.Scheme != "https" {
+			return errors.New("redirected to a non-HTTPS URL")
+		}
+		return nil
+	},
+}
+
+// scrapeDraft is an event prefilled from its website. It has the shape of
+// the create-event request, so it can be edited and submitted as is; nothing
+// is saved until then.
+type scrapeDraft struct {
+	Name           string `json:"name"`
+	Slug           string `json:"slug"`
+	Description    string `json:"description,omitempty"`
+	Location       string `json:"location,omitempty"`
+	Country        string `json:"country,omitempty"`
+	AttendanceMode string `json:"attendance_mode,omitempty"`
+	StartDate      string `json:"start_date,omitempty"`
+	EndDate        string `json:"end_date,omitempty"`
+	Website        string `json:"website"`
+	LogoURL        string `json:"logo_url,omitempty"`
+}
+
+var (
+	scrapeMetaRegex   = regexp.MustCompile(`(?is)<meta\s[^>]*>`)
+	scrapeAttrRegex   = regexp.MustCompile(`(?is)([a-z:-]+)\s*=\s*(?:"([^"]*)"|'([^']*)')`)
+	scrapeTitleRegex  = regexp.MustCompile(`(?is)<title[^>]*>(.*?)</title>`)
+	scrapeLDRegex     = regexp.MustCompile(`(?is)<script[^>]+type\s*=\s*["']application/ld\+json["'][^>]*>(.*?)</script>`)
+	scrapeDateRegex   = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}`)
+	scrapeNonSlugChar = regexp.MustCompile(`[^a-z0-9]+`)
+)
+
+// extractEventDraft reads an event from a web page's schema.org Event JSON-LD,
+// falling back to its Open Graph tags and title. pageURL resolves relative
+// image links.
+func extractEventDraft(page string, pageURL *url.URL) scrapeDraft {
+	draft := scrapeDraft{Website: pageURL.String()}
+
+	meta := make(map[string]string)
+	for _, tag := range scrapeMetaRegex.FindAllString(page, -1) {
+		attrs := make(map[string]string)
+		for _, m := range scrapeAttrRegex.FindAllStringSubmatch(tag, -1) {
+			attrs[strings.ToLower(m[1])] = m[2] + m[3]
+		}
+		key := attrs["property"]
+		if key == "" {
+			key = attrs["name"]
+		}
+		if key = strings.ToLower(key); key != "" && meta[key] == "" {
+			meta[key] = cleanScrapedText(attrs["content"])
+		}
+	}
+
+	if event := findLDEvent(page); event != nil {
+		draft.Name = ldString(event["name"])
+		draft.Description = ldString(event["description"])
+		draft.StartDate = ldDate(event["startDate"])
+		draft.EndDate = ldDate(event["endDate"])
+		draft.Location, draft.Country = ldLocation(event["location"])
+		draft.LogoURL = ldImage(event["image"])
+		switch mode := ldString(event["eventAttendanceMode"]); {
+		case strings.HasSuffix(mode, "OnlineEventAttendanceMode"):
+			draft.AttendanceMode = "online"
+		case strings.HasSuffix(mode, "MixedEventAttendanceMode"):
+			draft.AttendanceMode = "hybrid"
+		case strings.HasSuffix(mode, "OfflineEventAttendanceMode"):
+			draft.AttendanceMode = "in_person"
+		}
+	}
+
+	if draft.Name == "" {
+		draft.Name = meta["og:title"]
+	}
+	if draft.Name == "" {
+		if m := scrapeTitleRegex.FindStringSubmatch(page); m != nil {
+			draft.Name = cleanScrapedText(m[1])
+		}
+	}
+	if draft.Description == "" {
+		draft.Description = meta["og:description"]
+	}
+	if draft.Description == "" {
+		draft.Description = meta["description"]
+	}
+	if draft.LogoURL == "" {
+		draft.LogoURL = meta["og:image"]
+	}
+	if draft.LogoURL != "" {
+		if u, err := pageURL.Parse(draft.LogoURL); err == nil && (u.Scheme == "https" || u.Scheme == "http") {
+			draft.LogoURL = u.String()
+		} else {
+			draft.LogoURL = ""
+		}
+	}
+
+	draft.Name = truncateRunes(draft.Name, MaxEventNameLen)
+	draft.Description = truncateRunes(draft.Description, MaxEventDescriptionLen)
+	draft.Location = truncateRunes(draft.Location, MaxEventLocationLen)
+	draft.Slug = strings.Trim(scrapeNonSlugChar.ReplaceAllString(strings.ToLower(draft.Name), "-"), "-")
+	draft.Slug = strings.TrimRight(truncateRunes(draft.Slug, MaxEventSlugLen), "-")
+	return draft
+}
+
+// findLDEvent returns the first schema.org Event in the page's JSON-LD,
+// looking inside arrays and @graph lists
+func findLDEvent(page string) map[string]interface{} {
+	var find func(v interface{}) map[string]interface{}
+	find = func(v interface{}) map[string]interface{} {
+		switch node := v.(type) {
+		case []interface{}:
+			for _, item := range node {
+				if event := find(item); event != nil {
+					return event
+				}
+			}
+		case map[string]interface{}:
+			types, _ := node["@type"].([]interface{})
+			if t, ok := node["@type"].(string); ok {
+				types = []interface{}{t}
+			}
+			for _, t := range types {
+				if t, ok := t.(string); ok && strings.HasSuffix(t, "Event") {
+					return node
+				}
+			}
+			return find(node["@graph"])
+		}
+		return nil
+	}
+
+	for _, m := range scrapeLDRegex.FindAllStringSubmatch(page, -1) {
+		var data interface{}
+		if json.Unmarshal([]byte(strings.TrimSpace(m[1])), &data) != nil {
+			continue
+		}
+		if event := find(data); event != nil {
+			return event
+		}
+	}
+	return nil
+}
+
+// ldString returns a JSON-LD text value, or "" if it isn't text
+func ldString(v interface{}) string {
+	s, _ := v.(string)
+	return cleanScrapedText(s)
+}
+
+// ldDate returns the YYYY-MM-DD date of a JSON-LD date or date-time
+func ldDate(v interface{}) string {
+	return scrapeDateRegex.FindString(ldString(v))
+}
+
+// ldImage returns the URL of a JSON-LD image, which may be a URL, an
+// ImageObject or a list of either
+func ldImage(v interface{}) string {
+	switch img := v.(type) {
+	case []interface{}:
+		if len(img) > 0 {
+			return ldImage(img[0])
+		}
+	case map[string]interface{}:
+		return ldString(img["url"])
+	}
+	return ldString(v)
+}
+
+// ldLocation returns the city (or venue) and country code of a JSON-LD
+// location, which may be text, a Place with a PostalAddress, or a list of
+// places of which the first physical one is used
+func ldLocation(v interface{}) (location, country string) {
+	switch loc := v.(type) {
+	case string:
+		location = cleanScrapedText(loc)
+		if i := strings.LastIndex(location, ","); i >= 0 {
+			if code, ok := countries.Lookup(strings.TrimSpace(location[i+1:])); ok {
+				country = code
+			}
+		}
+	case []interface{}:
+		for _, item := range loc {
+			if location, country = ldLocation(item); location != "" || country != "" {
+				return location, country
+			}
+		}
+	case map[string]interface{}:
+		if t, _ := loc["@type"].(string); t == "VirtualLocation" {
+			return "", ""
+		}
+		address, ok := loc["address"].(map[string]interface{})
+		if !ok {
+			location, country = ldLocation(loc["address"])
+			if location == "" {
+				location = ldString(loc["name"])
+			}
+			return location, country
+		}
+		location = ldString(address["addressLocality"])
+		if location == "" {
+			location = ldString(loc["name"])
+		}
+		countryName := ldString(address["addressCountry"])
+		if c, ok := address["addressCountry"].(map[string]interface{}); ok {
+			countryName = ldString(c["name"])
+		}
+		if code, ok := countries.Lookup(countryName); ok {
+			country = code
+		}
+	}
+	return location, country
+}
+
+// cleanScrapedText decodes HTML entities and collapses whitespace
+func cleanScrapedText(s string) string {
+	return strings.Join(strings.Fields(html.UnescapeString(s)), " ")
+}
+
+// truncateRunes shortens s to at most n bytes without splitting a character
+func truncateRunes(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return strings.TrimSpace(s[:n])
+}
+
+// ScrapeEventPreviewHandler fetches an event's website and returns an event
+// draft prefilled from its schema.org Event data and Open Graph tags, for the
+// organizer to review before creating the event. Nothing is saved.
+// POST /api/v0/events/scrape-preview
+func ScrapeEventPreviewHandler(cfg *config.Config) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user := GetUserFromContext(r.Context())
+		if user == nil {
+			encodeAPIError(w, r, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		var req struct {
+			URL string `json:"url"`
+		}
+		if !decodeJSONBody(w, r, MaxSmallBodySize, &req) {
+			return
+		}
+
+		var errs validationErrors
+		pageURL, err := url.Parse(strings.TrimSpace(req.URL))
+		switch {
+		case req.URL == "":
+			errs.add("url", "URL is required")
+		case err != nil || pageURL.Scheme != "https" || pageURL.Host == "":
+			errs.add("url", "Must be an https:// URL")
+		case len(req.URL) > MaxEventWebsiteLen:
+			errs.add("url", fmt.Sprintf("URL must be at most %d characters", MaxEventWebsiteLen))
+		}
+		if len(errs) > 0 {
+			encodeValidationErrors(w, r, errs)
+			return
+		}
+
+		fetchReq, err := http.NewRequestWithContext(r.Context(), http.MethodGet, pageURL.String(), nil)
+		if err != nil {
+			encodeAPIError(w, r, "Invalid URL", http.StatusBadRequest)
+			return
+		}
+		fetchReq.Header.Set("Accept", "text/html")
+		fetchReq.Header.Set("User-Agent", "cfp.ninja/"+cfg.Version+" (+"+cfg.BaseURL+")")
+
+		resp, err := scrapeHTTPClient.Do(fetchReq)
+		if err != nil {
+			cfg.Logger.Info("scrape preview fetch failed", "error", err, "url", pageURL.String(), "user_id", user.ID)
+			msg := "Could not fetch the page"
+			if errors.Is(err, errScrapeAddrNotPublic) {
+				msg = "The URL must point to a public website"
+			}
+			encodeAPIError(w, r, msg, http.StatusUnprocessableEntity)
+			return
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			encodeAPIError(w, r, fmt.Sprintf("The page returned HTTP %d", resp.StatusCode), http.StatusUnprocessableEntity)
+			return
+		}
+		body, err := io.ReadAll(io.LimitReader(resp.Body, MaxScrapePageSize))
+		if err != nil {
+			encodeAPIError(w, r, "Could not read the page", http.StatusUnprocessableEntity)
+			return
+		}
+
+		// Relative links resolve against where redirects ended up
+		encodeResponse(w, r, extractEventDraft(string(body), resp.Request.URL))
+	}
+}
@@ -0,0 +1,130 @@
+package api
+
+import (
+	"net/netip"
+	"net/url"
+	"testing"
+)
+
+func TestIsPublicAddr(t *testing.T) {
+	for addr, want := range map[string]bool{
+		"93.184.216.34":          true,
+		"2606:2800:220:1::248":   true,
+		"127.0.0.1":              false,
+		"10.1.2.3":               false,
+		"172.16.0.1":             false,
+		"192.168.1.1":            false,
+		"169.254.169.254":        false,
+		"100.64.0.1":             false,
+		"0.0.0.0":                false,
+		"::1":                    false,
+		"fd00::1":                false,
+		"fe80::1":                false,
+		"::ffff:127.0.0.1":       false,
+		"64:ff9b::a9fe:a9fe":     false,
+		"224.0.0.1":              false,
+		"::ffff:93.184.216.34":   true,
+		"2001:db8::1":            true,
+		"198.18.0.1":             false,
+		"192.0.0.8":              false,
+		"255.255.255.255":        false,
+		"ff02::1":                false,
+		"100.128.0.1":            true,
+		"::":                     false,
+		"::ffff:169.254.169.254": false,
+	} {
+		if got := isPublicAddr(netip.MustParseAddr(addr)); got != want {
+			t.Errorf("isPublicAddr(%s) = %v, want %v", addr, got, want)
+		}
+	}
+}
+
+func TestExtractEventDraft_JSONLD(t *testing.T) {
+	page := `<html><head>
+<title>Ignored title</title>
+<meta property="og:title" content="Ignored OG title">
+<meta property="og:image" content="/img/og.png">
+<script type="application/ld+json">{"@context": "https://schema.org", "@graph": [
+  {"@type": "Organization", "name": "Gophers Inc"},
+  {"@type": ["Event", "EducationEvent"],
+   "name": "GopherCon &amp; Friends 2026",
+   "description": "Three days\n  of Go.",
+   "startDate": "2026-06-01T09:00:00+02:00",
+   "endDate": "2026-06-03",
+   "eventAttendanceMode": "https://schema.org/MixedEventAttendanceMode",
+   "location": [
+     {"@type": "VirtualLocation", "url": "https://stream.example.com"},
+     {"@type": "Place", "name": "Congress Center", "address": {"@type": "PostalAddress", "addressLocality": "Berlin", "addressCountry": {"@type": "Country", "name": "Germany"}}}
+   ],
+   "image": [{"@type": "ImageObject", "url": "banner.png"}]}
+]}</script>
+</head></html>`
+	pageURL, _ := url.Parse("https://gophercon.example.com/2026/")
+
+	got := extractEventDraft(page, pageURL)
+	want := scrapeDraft{
+		Name:           "GopherCon & Friends 2026",
+		Slug:           "gophercon-friends-2026",
+		Description:    "Three days of Go.",
+		Location:       "Berlin",
+		Country:        "DE",
+		AttendanceMode: "hybrid",
+		StartDate:      "2026-06-01",
+		EndDate:        "2026-06-03",
+		Website:        "https://gophercon.example.com/2026/",
+		LogoURL:        "https://gophercon.example.com/2026/banner.png",
+	}
+	if got != want {
+		t.Errorf("got  %+v\nwant %+v", got, want)
+	}
+}
+
+func TestExtractEventDraft_OpenGraphFallback(t *testing.T) {
+	page := `<html><head>
+<meta name="description" content="Plain description">
+<meta content='SREday London' property='og:title' />
+<meta property="og:image" content="javascript:alert(1)">
+<script type="application/ld+json">{"@type": "Event", "location": "Online"</script>
+<title>  SREday
+  2026 </title>
+</head></html>`
+	pageURL, _ := url.Parse("https://sreday.example.com")
+
+	got := extractEventDraft(page, pageURL)
+	want := scrapeDraft{
+		Name:        "SREday London",
+		Slug:        "sreday-london",
+		Description: "Plain description",
+		Website:     "https://sreday.example.com",
+	}
+	if got != want {
+		t.Errorf("got  %+v\nwant %+v", got, want)
+	}
+
+	// Without Open Graph tags the page title names the event
+	got = extractEventDraft(`<title>  SREday
+  2026 </title>`, pageURL)
+	if got.Name != "SREday 2026" || got.Slug != "sreday-2026" {
+		t.Errorf("expected the title as the name, got %+v", got)
+	}
+}
+
+func TestLDLocation(t *testing.T) {
+	tests := []struct {
+		in                     interface{}
+		wantLocation, wantCode string
+	}{
+		{"Lisbon, Portugal", "Lisbon, Portugal", "PT"},
+		{"Somewhere", "Somewhere", ""},
+		{map[string]interface{}{"@type": "Place", "name": "ExCeL", "address": "London, United Kingdom"}, "London, United Kingdom", "GB"},
+		{map[string]interface{}{"@type": "Place", "name": "ExCeL"}, "ExCeL", ""},
+		{map[string]interface{}{"@type": "Place", "address": map[string]interface{}{"addressLocality": "Austin", "addressCountry": "US"}}, "Austin", "US"},
+		{map[string]interface{}{"@type": "VirtualLocation", "url": "https://example.com"}, "", ""},
+	}
+	for _, tt := range tests {
+		location, code := ldLocation(tt.in)
+		if location != tt.wantLocation || code != tt.wantCode {
+			t.Errorf("ldLocation(%v) = %q, %q, want %q, %q", tt.in, location, code, tt.wantLocation, tt.wantCode)
+		}
+	}
+}
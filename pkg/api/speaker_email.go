@@ -0,0 +1,189 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sreday/cfp.ninja/pkg/config"
+	"github.com/sreday/cfp.ninja/pkg/email"
+	"github.com/sreday/cfp.ninja/pkg/models"
+)
+
+// Field length limits for speaker emails
+const (
+	MaxSpeakerEmailSubjectLen = 200
+	MaxSpeakerEmailBodyLen    = 10000
+)
+
+// speakerEmailConfirmThreshold is the recipient count above which the
+// organizer must send again with confirm: true
+const speakerEmailConfirmThreshold = 50
+
+// speakerEmailRequest is the body of POST /api/v0/events/{id}/speakers/email
+type speakerEmailRequest struct {
+	Status  models.ProposalStatus `json:"status"`
+	Subject string                `json:"subject"`
+	Body    string                `json:"body"`
+	Confirm bool                  `json:"confirm"`
+}
+
+// speakerRecipients returns one recipient per speaker on each of the event's
+// proposals with the given status. A speaker listed twice on a proposal is
+// emailed once; a speaker with several matching proposals gets one email per
+// proposal so {{talk_title}} is filled in correctly.
+func speakerRecipients(proposals []models.Proposal) []email.SpeakerRecipient {
+	var recipients []email.SpeakerRecipient
+	for i := range proposals {
+		speakers, err := proposals[i].GetSpeakers()
+		if err != nil {
+			continue
+		}
+		seen := make(map[string]bool, len(speakers))
+		for _, s := range speakers {
+			addr := strings.ToLower(strings.TrimSpace(s.Email))
+			if addr == "" || seen[addr] {
+				continue
+			}
+			seen[addr] = true
+			recipients = append(recipients, email.SpeakerRecipient{
+				Name:       s.Name,
+				Email:      strings.TrimSpace(s.Email),
+				TalkTitle:  proposals[i].Title,
+				ProposalID: proposals[i].ID,
+			})
+		}
+	}
+	return recipients
+}
+
+// EmailSpeakersHandler emails every speaker whose proposal has the given
+// status, filling in {{speaker_name}}, {{talk_title}} and {{event_name}} for
+// each (creator only). Sends are capped per event per day, recorded, and
+// delivered in the background.
+// POST /api/v0/events/{id}/speakers/email
+func EmailSpeakersHandler(cfg *config.Config) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		event, ok := loadEventForCreator(cfg, w, r, "email speakers")
+		if !ok {
+			return
+		}
+		user := GetUserFromContext(r.Context())
+
+		var req speakerEmailRequest
+		if !decodeJSONBody(w, r, MaxEmailBodySize, &req) {
+			return
+		}
+
+		var errs validationErrors
+		switch req.Status {
+		case models.ProposalStatusSubmitted, models.ProposalStatusAccepted,
+			models.ProposalStatusRejected, models.ProposalStatusTentative:
+		default:
+			errs.add("status", "Status must be one of: submitted, accepted, rejected, tentative")
+		}
+		allowed := "use only {{" + strings.Join(email.SpeakerMessageVariables, "}}, {{") + "}}"
+		if strings.TrimSpace(req.Subject) == "" {
+			errs.add("subject", "Subject is required")
+		} else if len(req.Subject) > MaxSpeakerEmailSubjectLen {
+			errs.add("subject", fmt.Sprintf("Subject must be at most %d characters", MaxSpeakerEmailSubjectLen))
+		} else if unknown := email.UnknownSpeakerVariables(req.Subject); len(unknown) > 0 {
+			errs.add("subject", fmt.Sprintf("Unknown variable {{%s}}; %s", unknown[0], allowed))
+		}
+		if strings.TrimSpace(req.Body) == "" {
+			errs.add("body", "Body is required")
+		} else if len(req.Body) > MaxSpeakerEmailBodyLen {
+			errs.add("body", fmt.Sprintf("Body must be at most %d characters", MaxSpeakerEmailBodyLen))
+		} else if unknown := email.UnknownSpeakerVariables(req.Body); len(unknown) > 0 {
+			errs.add("body", fmt.Sprintf("Unknown variable {{%s}}; %s", unknown[0], allowed))
+		}
+		if len(errs) > 0 {
+			encodeValidationErrors(w, r, errs)
+			return
+		}
+
+		if cfg.MaxSpeakerEmailsPerDay > 0 {
+			var count int64
+			if err := cfg.DBCtx(r).Model(&models.SpeakerEmailSend{}).
+				Where("event_id = ? AND created_at > ?", event.ID, cfg.Now().Add(-24*time.Hour)).
+				Count(&count).Error; err != nil {
+				cfg.Logger.Error("failed to count speaker emails", "error", err, "event_id", event.ID)
+				encodeAPIError(w, r, "Failed to send email", http.StatusInternalServerError)
+				return
+			}
+			if count >= int64(cfg.MaxSpeakerEmailsPerDay) {
+				encodeAPIErrorCode(w, r, ErrCodeRateLimited,
+					fmt.Sprintf("An event can email its speakers at most %d times a day", cfg.MaxSpeakerEmailsPerDay),
+					http.StatusTooManyRequests)
+				return
+			}
+		}
+
+		var proposals []models.Proposal
+		if err := cfg.DBCtx(r).Where("event_id = ? AND status = ?", event.ID, req.Status).Order("id").Find(&proposals).Error; err != nil {
+			cfg.Logger.Error("failed to load proposals for speaker email", "error", err, "event_id", event.ID)
+			encodeAPIError(w, r, "Failed to send email", http.StatusInternalServerError)
+			return
+		}
+		recipients := speakerRecipients(proposals)
+		if len(recipients) == 0 {
+			errs.add("status", fmt.Sprintf("No speakers have %s proposals", req.Status))
+			encodeValidationErrors(w, r, errs)
+			return
+		}
+		if len(recipients) > speakerEmailConfirmThreshold && !req.Confirm {
+			encodeAPIErrorCode(w, r, ErrCodeConfirmationRequired,
+				fmt.Sprintf("This email would go to %d speakers; send again with confirm: true", len(recipients)),
+				http.StatusBadRequest)
+			return
+		}
+
+		send := models.SpeakerEmailSend{
+			EventID:    event.ID,
+			SenderID:   &user.ID,
+			Status:     req.Status,
+			Subject:    req.Subject,
+			Body:       req.Body,
+			Recipients: len(recipients),
+			CreatedAt:  cfg.Now(),
+		}
+		if err := cfg.DBCtx(r).Create(&send).Error; err != nil {
+			cfg.Logger.Error("failed to record speaker email", "error", err, "event_id", event.ID)
+			encodeAPIError(w, r, "Failed to send email", http.StatusInternalServerError)
+			return
+		}
+
+		cfg.Logger.Info("speaker email queued",
+			"event_id", event.ID,
+			"send_id", send.ID,
+			"status", req.Status,
+			"recipients", len(recipients),
+			"actor_id", user.ID,
+		)
+		recordActivity(cfg, r, models.EventActivity{
+			EventID: event.ID,
+			ActorID: &user.ID,
+			Action:  models.ActivitySpeakersEmailed,
+			Subject: req.Subject,
+			Detail:  strconv.Itoa(len(recipients)),
+		})
+
+		if cfg.EmailSender != nil {
+			replyTo := event.ContactEmail
+			if replyTo == "" {
+				replyTo = user.Email
+			}
+			ev := *event
+			SafeGo(cfg, func() {
+				ncfg := &email.NotifyConfig{Sender: cfg.EmailSender, From: cfg.EmailFrom, BaseURL: cfg.BaseURL, Logger: cfg.Logger}
+				email.SendSpeakerMessage(ncfg, &ev, replyTo, req.Subject, req.Body, recipients)
+			})
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		encodeResponse(w, r, send)
+	}
+}
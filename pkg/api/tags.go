@@ -0,0 +1,50 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/sreday/cfp.ninja/pkg/config"
+	"github.com/sreday/cfp.ninja/pkg/models"
+)
+
+// Number of tag suggestions returned by default and at most
+const (
+	DefaultTagSuggestions = 10
+	MaxTagSuggestions     = 50
+)
+
+// SuggestTagsHandler returns the tags of listed events starting with ?q=,
+// most used first, for typeahead in tag inputs. q is normalized like event
+// tags; without it the most used tags are returned.
+// GET /api/v0/tags/suggest
+func SuggestTagsHandler(cfg *config.Config) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		var errs validationErrors
+		limit := DefaultTagSuggestions
+		if v := query.Get("limit"); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil || n < 1 || n > MaxTagSuggestions {
+				errs.add("limit", "Must be a number from 1 to "+strconv.Itoa(MaxTagSuggestions))
+			}
+			limit = n
+		}
+		q := models.NormalizeTags(query.Get("q"))
+		if len(q) > MaxEventTagsLen {
+			errs.add("q", "Must be at most 1000 characters")
+		}
+		if len(errs) > 0 {
+			encodeValidationErrors(w, r, errs)
+			return
+		}
+
+		suggestions, err := models.SuggestTags(cfg.DBCtx(r), q, limit)
+		if err != nil {
+			cfg.Logger.Error("failed to suggest tags", "error", err)
+			encodeAPIError(w, r, "Failed to load tags", http.StatusInternalServerError)
+			return
+		}
+		encodeResponse(w, r, suggestions)
+	}
+}
@@ -0,0 +1,29 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/sreday/cfp.ninja/pkg/config"
+)
+
+// VersionInfo describes the server version and the CLI versions it supports
+type VersionInfo struct {
+	Version       string `json:"version"`
+	MinCLIVersion string `json:"min_cli_version,omitempty"`
+}
+
+// VersionHandler returns the server version and the minimum supported CLI version.
+// GET /api/v0/version
+func VersionHandler(cfg *config.Config) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		version := cfg.Version
+		if version == "" {
+			version = "dev"
+		}
+
+		encodeResponse(w, r, VersionInfo{
+			Version:       version,
+			MinCLIVersion: cfg.MinCLIVersion,
+		})
+	}
+}
@@ -0,0 +1,304 @@
+// Package assets serves the web frontend's static files. Every file except
+// HTML is also served under a content-hashed name, which index.html is
+// rewritten to reference, so browsers can cache assets for a year and still
+// pick up a new release as soon as index.html, which is never cached, changes.
+package assets
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// PrecompressedManifest lists, in sha256sum format, the files whose .br and
+// .gz siblings were generated by `make static-compress`. Siblings are only
+// served while the file still has the listed checksum, so a stale sibling
+// left behind by an edit is never sent instead of the current file.
+const PrecompressedManifest = "precompressed.sha256"
+
+const (
+	indexFile = "index.html"
+
+	// hashLen is how many hex digits of the SHA-256 go into hashed names
+	hashLen = 10
+
+	cacheImmutable = "public, max-age=31536000, immutable"
+	cacheNone      = "no-cache, no-store, must-revalidate"
+)
+
+// encodings are the precompressed siblings served to clients that accept
+// them, in order of preference
+var encodings = []struct {
+	name string
+	ext  string
+}{
+	{"br", ".br"},
+	{"gzip", ".gz"},
+}
+
+// Handler serves static files with SPA routing: paths that aren't files get
+// index.html so the frontend router can handle them.
+type Handler struct {
+	fsys fs.FS
+	// hashed maps a hashed path to the file it names
+	hashed map[string]string
+	// encoded lists the precompressed siblings available for a file
+	encoded map[string][]string
+	// names maps each file to its hashed name
+	names map[string]string
+	// source is index.html as shipped, index as served
+	source []byte
+	index  []byte
+	// importMapCSP is the CSP source allowing the inline import map
+	importMapCSP string
+}
+
+// New indexes the files in fsys and builds the rewritten index.html
+func New(fsys fs.FS) (*Handler, error) {
+	h := &Handler{
+		fsys:    fsys,
+		hashed:  make(map[string]string),
+		encoded: make(map[string][]string),
+	}
+
+	checksums, err := readChecksums(fsys)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[string]string)
+	err = fs.WalkDir(fsys, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		if p == PrecompressedManifest || isSibling(p) {
+			return nil
+		}
+		data, err := fs.ReadFile(fsys, p)
+		if err != nil {
+			return err
+		}
+		sum := sha256.Sum256(data)
+		digest := hex.EncodeToString(sum[:])
+
+		if checksums[p] == digest {
+			for _, enc := range encodings {
+				if _, err := fs.Stat(fsys, p+enc.ext); err == nil {
+					h.encoded[p] = append(h.encoded[p], enc.name)
+				}
+			}
+		}
+
+		if strings.EqualFold(path.Ext(p), ".html") {
+			return nil
+		}
+		ext := path.Ext(p)
+		hashedName := strings.TrimSuffix(p, ext) + "." + digest[:hashLen] + ext
+		names[p] = hashedName
+		h.hashed[hashedName] = p
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to index static files: %w", err)
+	}
+
+	h.names = names
+	h.source, err = fs.ReadFile(fsys, indexFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", indexFile, err)
+	}
+	if err := h.SetBasePath(""); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+// SetBasePath rewrites index.html for the app being served under basePath,
+// such as "/cfp" (BASE_PATH), with the prefix stripped from request paths
+// before they reach the handler. The frontend reads it from a meta tag.
+func (h *Handler) SetBasePath(basePath string) error {
+	index, importMapCSP, err := rewriteIndex(h.source, h.names, basePath)
+	if err != nil {
+		return err
+	}
+	h.index, h.importMapCSP = index, importMapCSP
+	return nil
+}
+
+// localRef matches the start of an attribute referencing a local absolute
+// path, but not a protocol-relative URL
+var localRef = regexp.MustCompile(`((?:href|src)=["'])/([^/])`)
+
+// rewriteIndex points index.html's references to local files at their hashed
+// names under basePath, and adds an import map so modules importing each
+// other by their plain names load the hashed files too. It returns the CSP
+// source that allows the import map, which is an inline script.
+func rewriteIndex(index []byte, names map[string]string, basePath string) ([]byte, string, error) {
+	var meta []byte
+	if basePath != "" {
+		index = localRef.ReplaceAll(index, []byte("${1}"+basePath+"/${2}"))
+		meta = fmt.Appendf(nil, "<meta name=\"base-path\" content=\"%s\">\n", basePath)
+	}
+	imports := make(map[string]string)
+	for name, hashedName := range names {
+		for _, quote := range []string{`"`, `'`} {
+			index = bytes.ReplaceAll(index, []byte(quote+basePath+"/"+name+quote), []byte(quote+basePath+"/"+hashedName+quote))
+		}
+		if path.Ext(name) == ".js" {
+			imports[basePath+"/"+name] = basePath + "/" + hashedName
+		}
+	}
+	if len(imports) == 0 {
+		return insertHead(index, meta)
+	}
+
+	importMap, err := json.Marshal(map[string]any{"imports": imports})
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to build import map: %w", err)
+	}
+	script := append(meta, `<script type="importmap">`...)
+	script = append(script, importMap...)
+	script = append(script, "</script>\n"...)
+	index, _, err = insertHead(index, script)
+	if err != nil {
+		return nil, "", err
+	}
+
+	sum := sha256.Sum256(importMap)
+	return index, "'sha256-" + base64.StdEncoding.EncodeToString(sum[:]) + "'", nil
+}
+
+// insertHead adds tags at the end of index.html's head
+func insertHead(index, tags []byte) ([]byte, string, error) {
+	if len(tags) == 0 {
+		return index, "", nil
+	}
+	head := bytes.Index(index, []byte("</head>"))
+	if head < 0 {
+		return nil, "", fmt.Errorf("%s has no </head>", indexFile)
+	}
+	return append(index[:head:head], append(tags, index[head:]...)...), "", nil
+}
+
+// readChecksums parses the precompressed manifest, if there is one
+func readChecksums(fsys fs.FS) (map[string]string, error) {
+	checksums := make(map[string]string)
+	f, err := fsys.Open(PrecompressedManifest)
+	if err != nil {
+		return checksums, nil
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		digest, name, ok := strings.Cut(scanner.Text(), "  ")
+		if !ok {
+			continue
+		}
+		checksums[path.Clean(strings.TrimPrefix(name, "./"))] = digest
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", PrecompressedManifest, err)
+	}
+	return checksums, nil
+}
+
+func isSibling(p string) bool {
+	for _, enc := range encodings {
+		if strings.HasSuffix(p, enc.ext) {
+			return true
+		}
+	}
+	return false
+}
+
+// ServeHTTP serves hashed files as immutable, index.html as uncacheable, and
+// other files with the same short cache lifetimes as before fingerprinting,
+// for references that can't be rewritten such as images linked from scripts
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	p := strings.TrimPrefix(path.Clean("/"+r.URL.Path), "/")
+
+	if name, ok := h.hashed[p]; ok {
+		w.Header().Set("Cache-Control", cacheImmutable)
+		h.serveFile(w, r, name)
+		return
+	}
+
+	if info, err := fs.Stat(h.fsys, p); p == "" || p == indexFile || err != nil || info.IsDir() || isSibling(p) {
+		h.serveIndex(w, r)
+		return
+	}
+
+	ext := strings.ToLower(path.Ext(p))
+	switch ext {
+	case ".html":
+		w.Header().Set("Cache-Control", cacheNone)
+	case ".js", ".css":
+		w.Header().Set("Cache-Control", "public, max-age=0, must-revalidate")
+	case ".png", ".jpg", ".jpeg", ".gif", ".svg", ".ico", ".woff", ".woff2", ".ttf":
+		w.Header().Set("Cache-Control", "public, max-age=3600")
+	}
+	h.serveFile(w, r, p)
+}
+
+func (h *Handler) serveIndex(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Cache-Control", cacheNone)
+	if csp := w.Header().Get("Content-Security-Policy"); csp != "" && h.importMapCSP != "" {
+		w.Header().Set("Content-Security-Policy", strings.Replace(csp, "script-src ", "script-src "+h.importMapCSP+" ", 1))
+	}
+	http.ServeContent(w, r, indexFile, time.Time{}, bytes.NewReader(h.index))
+}
+
+// serveFile serves name, or its best precompressed sibling the client accepts
+func (h *Handler) serveFile(w http.ResponseWriter, r *http.Request, name string) {
+	file := name
+	if encoded := h.encoded[name]; len(encoded) > 0 {
+		w.Header().Add("Vary", "Accept-Encoding")
+		for _, enc := range encodings {
+			if slices.Contains(encoded, enc.name) && accepts(r, enc.name) {
+				w.Header().Set("Content-Encoding", enc.name)
+				file = name + enc.ext
+				break
+			}
+		}
+	}
+
+	data, err := fs.ReadFile(h.fsys, file)
+	if err != nil {
+		w.Header().Del("Content-Encoding")
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	// The content type comes from the original name, not the sibling's.
+	// Embedded files have no modification time, so there's no Last-Modified.
+	http.ServeContent(w, r, name, time.Time{}, bytes.NewReader(data))
+}
+
+// accepts reports whether the request's Accept-Encoding allows encoding
+func accepts(r *http.Request, encoding string) bool {
+	for _, part := range strings.Split(r.Header.Get("Accept-Encoding"), ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if !strings.EqualFold(strings.TrimSpace(name), encoding) {
+			continue
+		}
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if v, err := strconv.ParseFloat(q, 64); err == nil && v == 0 {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
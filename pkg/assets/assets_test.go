@@ -0,0 +1,258 @@
+package assets
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+const testIndex = `<!DOCTYPE html>
+<html>
+<head>
+    <link rel="stylesheet" href="/css/style.css">
+    <link rel="icon" href="/img/favicon.svg" />
+</head>
+<body>
+    <script type="module" src="/js/app.js"></script>
+</body>
+</html>
+`
+
+func checksum(data string) string {
+	sum := sha256.Sum256([]byte(data))
+	return hex.EncodeToString(sum[:])
+}
+
+func testFS() fstest.MapFS {
+	return fstest.MapFS{
+		"index.html":       {Data: []byte(testIndex)},
+		"css/style.css":    {Data: []byte("body { color: red; }")},
+		"css/style.css.gz": {Data: []byte("gzipped css")},
+		"css/style.css.br": {Data: []byte("brotli css")},
+		"js/app.js":        {Data: []byte("import { route } from './router.js';")},
+		"js/app.js.gz":     {Data: []byte("stale gzipped js")},
+		"js/router.js":     {Data: []byte("export function route() {}")},
+		"img/favicon.svg":  {Data: []byte("<svg></svg>")},
+		PrecompressedManifest: {Data: []byte(
+			checksum("body { color: red; }") + "  ./css/style.css\n" +
+				checksum("an older app.js") + "  ./js/app.js\n",
+		)},
+	}
+}
+
+func newTestHandler(t *testing.T) *Handler {
+	t.Helper()
+	h, err := New(testFS())
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	return h
+}
+
+func get(h http.Handler, path, acceptEncoding string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	if acceptEncoding != "" {
+		req.Header.Set("Accept-Encoding", acceptEncoding)
+	}
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+	return rr
+}
+
+// hashedPath returns the hashed name index.html references for a file
+func hashedPath(t *testing.T, index, prefix, ext string) string {
+	t.Helper()
+	m := regexp.MustCompile(`"(` + regexp.QuoteMeta(prefix) + `\.[0-9a-f]{10}` + regexp.QuoteMeta(ext) + `)"`).FindStringSubmatch(index)
+	if m == nil {
+		t.Fatalf("index.html doesn't reference a hashed %s%s:\n%s", prefix, ext, index)
+	}
+	return m[1]
+}
+
+func TestIndex_RewrittenAndUncacheable(t *testing.T) {
+	h := newTestHandler(t)
+
+	for _, path := range []string{"/", "/index.html", "/events/gophercon", "/js", "/css/style.css.gz"} {
+		rr := get(h, path, "")
+		if rr.Code != http.StatusOK {
+			t.Fatalf("%s: expected 200, got %d", path, rr.Code)
+		}
+		if cc := rr.Header().Get("Cache-Control"); cc != cacheNone {
+			t.Errorf("%s: expected Cache-Control %q, got %q", path, cacheNone, cc)
+		}
+		if !strings.HasPrefix(rr.Header().Get("Content-Type"), "text/html") {
+			t.Errorf("%s: expected HTML, got %q", path, rr.Header().Get("Content-Type"))
+		}
+	}
+
+	index := get(h, "/", "").Body.String()
+	css := hashedPath(t, index, "/css/style", ".css")
+	app := hashedPath(t, index, "/js/app", ".js")
+	hashedPath(t, index, "/img/favicon", ".svg")
+	if strings.Contains(index, `"/css/style.css"`) {
+		t.Error("expected the plain stylesheet reference to be rewritten")
+	}
+	if !strings.HasSuffix(css, "."+checksum("body { color: red; }")[:hashLen]+".css") {
+		t.Errorf("expected the hash to come from the file's content, got %s", css)
+	}
+
+	// Modules import each other by plain name, so the import map must cover them all
+	for _, mapping := range []string{`"/js/app.js":"` + app + `"`, `"/js/router.js":"/js/router.`} {
+		if !strings.Contains(index, mapping) {
+			t.Errorf("expected the import map to contain %s:\n%s", mapping, index)
+		}
+	}
+	if strings.Index(index, `type="importmap"`) > strings.Index(index, `type="module"`) {
+		t.Error("expected the import map before the first module script")
+	}
+}
+
+func TestIndex_AllowsImportMapInCSP(t *testing.T) {
+	h := newTestHandler(t)
+	rr := httptest.NewRecorder()
+	rr.Header().Set("Content-Security-Policy", "default-src 'self'; script-src 'self' https://cdn.jsdelivr.net")
+	h.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	csp := rr.Header().Get("Content-Security-Policy")
+	if !strings.Contains(csp, "script-src "+h.importMapCSP+" 'self' https://cdn.jsdelivr.net") {
+		t.Errorf("expected the import map's hash in script-src, got %q", csp)
+	}
+
+	importMap := regexp.MustCompile(`<script type="importmap">(.*)</script>`).FindStringSubmatch(rr.Body.String())
+	if importMap == nil {
+		t.Fatal("expected an import map")
+	}
+	sum := sha256.Sum256([]byte(importMap[1]))
+	if want := "'sha256-" + base64.StdEncoding.EncodeToString(sum[:]) + "'"; h.importMapCSP != want {
+		t.Errorf("expected %s, got %s", want, h.importMapCSP)
+	}
+}
+
+func TestIndex_BasePath(t *testing.T) {
+	h := newTestHandler(t)
+	if err := h.SetBasePath("/cfp"); err != nil {
+		t.Fatalf("SetBasePath: %v", err)
+	}
+	index := get(h, "/", "").Body.String()
+
+	css := hashedPath(t, index, "/cfp/css/style", ".css")
+	app := hashedPath(t, index, "/cfp/js/app", ".js")
+	if !strings.Contains(index, `<meta name="base-path" content="/cfp">`) {
+		t.Errorf("expected the base path in a meta tag:\n%s", index)
+	}
+	if !strings.Contains(index, `"/cfp/js/app.js":"`+app+`"`) {
+		t.Errorf("expected the import map under the base path:\n%s", index)
+	}
+
+	// The prefix is stripped before requests reach the handler
+	if rr := get(h, strings.TrimPrefix(css, "/cfp"), ""); rr.Header().Get("Cache-Control") != cacheImmutable {
+		t.Errorf("expected %s to be served, got Cache-Control %q", css, rr.Header().Get("Cache-Control"))
+	}
+	if err := h.SetBasePath(""); err != nil {
+		t.Fatalf("SetBasePath: %v", err)
+	}
+	if index := get(h, "/", "").Body.String(); strings.Contains(index, "/cfp") {
+		t.Errorf("expected the base path to be removed:\n%s", index)
+	}
+}
+
+func TestHashedAssets_Immutable(t *testing.T) {
+	h := newTestHandler(t)
+	index := get(h, "/", "").Body.String()
+
+	tests := []struct {
+		path        string
+		contentType string
+		body        string
+	}{
+		{hashedPath(t, index, "/css/style", ".css"), "text/css", "body { color: red; }"},
+		{hashedPath(t, index, "/js/app", ".js"), "text/javascript", "import { route } from './router.js';"},
+		{hashedPath(t, index, "/img/favicon", ".svg"), "image/svg+xml", "<svg></svg>"},
+	}
+	for _, tt := range tests {
+		rr := get(h, tt.path, "")
+		if rr.Code != http.StatusOK {
+			t.Fatalf("%s: expected 200, got %d", tt.path, rr.Code)
+		}
+		if cc := rr.Header().Get("Cache-Control"); cc != cacheImmutable {
+			t.Errorf("%s: expected Cache-Control %q, got %q", tt.path, cacheImmutable, cc)
+		}
+		if ct := rr.Header().Get("Content-Type"); !strings.HasPrefix(ct, tt.contentType) {
+			t.Errorf("%s: expected Content-Type %s, got %q", tt.path, tt.contentType, ct)
+		}
+		if rr.Body.String() != tt.body {
+			t.Errorf("%s: unexpected body %q", tt.path, rr.Body.String())
+		}
+	}
+
+	// A hash that doesn't match the current content is an unknown path
+	rr := get(h, "/css/style.0123456789.css", "")
+	if cc := rr.Header().Get("Cache-Control"); cc != cacheNone {
+		t.Errorf("expected an outdated hash to get index.html, got Cache-Control %q", cc)
+	}
+}
+
+func TestPlainAssets_KeepShortCacheLifetimes(t *testing.T) {
+	h := newTestHandler(t)
+	tests := map[string]string{
+		"/css/style.css":   "public, max-age=0, must-revalidate",
+		"/js/router.js":    "public, max-age=0, must-revalidate",
+		"/img/favicon.svg": "public, max-age=3600",
+	}
+	for path, want := range tests {
+		rr := get(h, path, "")
+		if rr.Code != http.StatusOK {
+			t.Fatalf("%s: expected 200, got %d", path, rr.Code)
+		}
+		if cc := rr.Header().Get("Cache-Control"); cc != want {
+			t.Errorf("%s: expected Cache-Control %q, got %q", path, want, cc)
+		}
+	}
+}
+
+func TestPrecompressedSiblings(t *testing.T) {
+	h := newTestHandler(t)
+	css := hashedPath(t, get(h, "/", "").Body.String(), "/css/style", ".css")
+
+	tests := []struct {
+		name           string
+		path           string
+		acceptEncoding string
+		encoding       string
+		body           string
+	}{
+		{"prefers brotli", css, "gzip, deflate, br", "br", "brotli css"},
+		{"falls back to gzip", css, "gzip", "gzip", "gzipped css"},
+		{"honors q=0", css, "br;q=0, gzip", "gzip", "gzipped css"},
+		{"identity without Accept-Encoding", css, "", "", "body { color: red; }"},
+		{"unhashed path", "/css/style.css", "br", "br", "brotli css"},
+		{"ignores siblings of a changed file", "/js/app.js", "gzip", "", "import { route } from './router.js';"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := get(h, tt.path, tt.acceptEncoding)
+			if rr.Code != http.StatusOK {
+				t.Fatalf("expected 200, got %d", rr.Code)
+			}
+			if enc := rr.Header().Get("Content-Encoding"); enc != tt.encoding {
+				t.Errorf("expected Content-Encoding %q, got %q", tt.encoding, enc)
+			}
+			if ct := rr.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/") {
+				t.Errorf("expected the original file's content type, got %q", ct)
+			}
+			if rr.Body.String() != tt.body {
+				t.Errorf("expected body %q, got %q", tt.body, rr.Body.String())
+			}
+		})
+	}
+
+	if vary := get(h, css, "br").Header().Get("Vary"); vary != "Accept-Encoding" {
+		t.Errorf("expected Vary: Accept-Encoding, got %q", vary)
+	}
+}
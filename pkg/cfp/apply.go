@@ -0,0 +1,155 @@
+package cfp
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// EventChange is a field that applying an event file would change
+type EventChange struct {
+	Field string
+	Old   string
+	New   string
+}
+
+// EventFileFields returns the event fields an event file sets, sorted. Fields
+// left out of the file are not changed when it is applied.
+func EventFileFields(content string) ([]string, error) {
+	var raw map[string]interface{}
+	if err := yaml.Unmarshal([]byte(content), &raw); err != nil {
+		return nil, fmt.Errorf("invalid YAML: %w", err)
+	}
+	known := EventSchema().Properties
+	fields := make([]string, 0, len(raw))
+	for field := range raw {
+		if _, ok := known[field]; ok {
+			fields = append(fields, field)
+		}
+	}
+	sort.Strings(fields)
+	return fields, nil
+}
+
+// EventFieldValues returns the request body setting the given fields of e.
+// Fields the file sets to an empty value are sent empty, so applying clears
+// them, and cfp_questions is always sent whole. Event dates are sent as
+// midnight UTC, which is how the API reads them.
+func EventFieldValues(e *EventSubmission, fields []string) map[string]interface{} {
+	var all map[string]interface{}
+	data, _ := json.Marshal(e)
+	_ = json.Unmarshal(data, &all)
+
+	values := make(map[string]interface{}, len(fields))
+	for _, field := range fields {
+		if v, ok := all[field]; ok {
+			if s, ok := v.(string); ok && (field == "start_date" || field == "end_date") {
+				if t, err := time.Parse("2006-01-02", s); err == nil {
+					v = t.Format(time.RFC3339)
+				}
+			}
+			values[field] = v
+			continue
+		}
+		switch field {
+		case "cfp_questions":
+			values[field] = []interface{}{}
+		case "max_accepted":
+			values[field] = nil
+		case "waitlist_auto_promote", "allow_overbook", "flag_series_duplicates":
+			values[field] = false
+		default:
+			values[field] = ""
+		}
+	}
+	return values
+}
+
+// DiffEvent lists the fields of desired whose value differs from the current
+// event, as returned by the API, sorted by field. The slug identifies the
+// event, so it is never a change.
+func DiffEvent(current, desired map[string]interface{}) []EventChange {
+	var changes []EventChange
+	for field, v := range desired {
+		if field == "slug" {
+			continue
+		}
+		old, cur := normalizeEventValue(field, current[field]), normalizeEventValue(field, v)
+		if old != cur {
+			changes = append(changes, EventChange{Field: field, Old: old, New: cur})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Field < changes[j].Field })
+	return changes
+}
+
+// normalizeEventValue renders a field value so values the API would store the
+// same way compare equal: dates and times are parsed, and questions are
+// compared by content
+func normalizeEventValue(field string, v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		if field == "cfp_questions" {
+			return "[]"
+		}
+		return ""
+	case string:
+		switch field {
+		case "start_date", "end_date", "cfp_open_at", "cfp_close_at":
+			t, ok := parseEventTime(v)
+			switch {
+			case !ok:
+				return v
+			case t.IsZero():
+				// The API returns unset times as the zero time
+				return ""
+			case field == "start_date" || field == "end_date":
+				return t.Format("2006-01-02")
+			}
+			return t.UTC().Format(time.RFC3339)
+		}
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	}
+
+	data, _ := json.Marshal(v)
+	if field == "cfp_questions" {
+		var questions []CustomQuestion
+		if json.Unmarshal(data, &questions) == nil {
+			if questions == nil {
+				questions = []CustomQuestion{}
+			}
+			data, _ = json.Marshal(questions)
+		}
+	}
+	return string(data)
+}
+
+// parseEventTime parses an RFC 3339 time or a YYYY-MM-DD date
+func parseEventTime(s string) (time.Time, bool) {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		t, err = time.Parse("2006-01-02", s)
+	}
+	return t, err == nil
+}
+
+// FormatEventChange describes a change for the apply diff, shortening long
+// values
+func FormatEventChange(c EventChange) string {
+	show := func(s string) string {
+		if s == "" {
+			return "(empty)"
+		}
+		return strconv.Quote(truncateString(strings.ReplaceAll(s, "\n", " "), 60))
+	}
+	return fmt.Sprintf("%s: %s -> %s", c.Field, show(c.Old), show(c.New))
+}
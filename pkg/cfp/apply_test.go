@@ -0,0 +1,84 @@
+package cfp
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEventFileFields(t *testing.T) {
+	fields, err := EventFileFields("name: Conf\nslug: conf\nlocation: \"\"\ncfp_questions: []\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"cfp_questions", "location", "name", "slug"}; !reflect.DeepEqual(fields, want) {
+		t.Errorf("got %v, want %v", fields, want)
+	}
+
+	// JSON is YAML too
+	fields, err = EventFileFields(`{"name": "Conf", "slug": "conf", "max_accepted": 10}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"max_accepted", "name", "slug"}; !reflect.DeepEqual(fields, want) {
+		t.Errorf("got %v, want %v", fields, want)
+	}
+}
+
+func TestEventFieldValues(t *testing.T) {
+	e := &EventSubmission{Name: "Conf", Slug: "conf", StartDate: "2026-06-01"}
+	got := EventFieldValues(e, []string{"name", "start_date", "location", "cfp_questions", "max_accepted"})
+	want := map[string]interface{}{
+		"name":          "Conf",
+		"start_date":    "2026-06-01T00:00:00Z",
+		"location":      "",
+		"cfp_questions": []interface{}{},
+		"max_accepted":  nil,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %#v, want %#v", got, want)
+	}
+}
+
+func TestDiffEvent(t *testing.T) {
+	current := map[string]interface{}{
+		"name":         "Conf",
+		"slug":         "conf",
+		"location":     "Berlin",
+		"start_date":   "2026-06-01T00:00:00Z",
+		"end_date":     "0001-01-01T00:00:00Z",
+		"cfp_close_at": "2026-04-01T10:00:00+02:00",
+		"max_accepted": float64(20),
+		"cfp_questions": []interface{}{
+			map[string]interface{}{"id": "travel", "text": "Travel?", "type": "checkbox", "required": false},
+			map[string]interface{}{"id": "diet", "text": "Diet?", "type": "text"},
+		},
+	}
+	desired := map[string]interface{}{
+		"name":          "Conf",
+		"slug":          "other-slug",
+		"location":      "Lisbon",
+		"start_date":    "2026-06-01",
+		"end_date":      "",
+		"cfp_close_at":  "2026-04-01T08:00:00Z",
+		"max_accepted":  float64(20),
+		"cfp_questions": []interface{}{map[string]interface{}{"id": "diet", "text": "Diet?", "type": "text"}},
+	}
+
+	changes := DiffEvent(current, desired)
+	if len(changes) != 2 || changes[0].Field != "cfp_questions" || changes[1].Field != "location" {
+		t.Fatalf("expected cfp_questions and location to change, got %+v", changes)
+	}
+	if changes[1].Old != "Berlin" || changes[1].New != "Lisbon" {
+		t.Errorf("unexpected location change %+v", changes[1])
+	}
+	if got := FormatEventChange(changes[1]); got != `location: "Berlin" -> "Lisbon"` {
+		t.Errorf("unexpected formatted change %q", got)
+	}
+
+	// Questions are replaced whole, so dropping one is a change
+	desired["cfp_questions"] = current["cfp_questions"]
+	delete(desired, "location")
+	if changes := DiffEvent(current, desired); len(changes) != 0 {
+		t.Errorf("expected no changes, got %+v", changes)
+	}
+}
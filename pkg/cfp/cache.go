@@ -0,0 +1,161 @@
+package cfp
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	CacheDirName  = "cache"
+	CacheFilePerm = 0600
+)
+
+// EventsCache is a cached events listing, stored as JSON under the config directory
+type EventsCache struct {
+	Server     string     `json:"server"`
+	FetchedAt  time.Time  `json:"fetched_at"`
+	Events     []Event    `json:"events"`
+	Pagination Pagination `json:"pagination"`
+}
+
+// Age returns how long ago the cached data was fetched
+func (c *EventsCache) Age() time.Duration {
+	return time.Since(c.FetchedAt)
+}
+
+// cacheDir returns the path to the cache directory
+func cacheDir() (string, error) {
+	dir, err := configDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, CacheDirName), nil
+}
+
+// EventsCacheKey returns a stable key for an events listing on a server with the given filters
+func EventsCacheKey(server string, opts ListEventsOptions, all bool) string {
+	filters, _ := json.Marshal(struct {
+		Server string
+		Opts   ListEventsOptions
+		All    bool
+	}{server, opts, all})
+	sum := sha256.Sum256(filters)
+	return hex.EncodeToString(sum[:16])
+}
+
+// eventsCachePath returns the cache file path for a cache key
+func eventsCachePath(key string) (string, error) {
+	dir, err := cacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "events-"+key+".json"), nil
+}
+
+// SaveEventsCache writes an events listing to the cache with restricted permissions
+func SaveEventsCache(key string, cache *EventsCache) error {
+	dir, err := cacheDir()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(dir, ConfigDirPerm); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	path, err := eventsCachePath(key)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(cache)
+	if err != nil {
+		return fmt.Errorf("failed to marshal cache: %w", err)
+	}
+
+	// Cached listings may contain organizer-only data, so keep them owner-only
+	if err := os.WriteFile(path, data, CacheFilePerm); err != nil {
+		return fmt.Errorf("failed to write cache file: %w", err)
+	}
+
+	return nil
+}
+
+// LoadEventsCache reads a cached events listing, returning nil if none exists
+func LoadEventsCache(key string) (*EventsCache, error) {
+	path, err := eventsCachePath(key)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read cache file: %w", err)
+	}
+
+	var cache EventsCache
+	if err := json.Unmarshal(data, &cache); err != nil {
+		return nil, fmt.Errorf("failed to parse cache file: %w", err)
+	}
+
+	return &cache, nil
+}
+
+// ClearCache removes all cached data
+func ClearCache() error {
+	dir, err := cacheDir()
+	if err != nil {
+		return err
+	}
+
+	if err := os.RemoveAll(dir); err != nil {
+		return fmt.Errorf("failed to remove cache directory: %w", err)
+	}
+
+	return nil
+}
+
+// IsUnreachable reports whether err means the server could not be reached at all,
+// as opposed to the server answering with an error or the user cancelling
+func IsUnreachable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+	var apiErr *APIError
+	return !errors.As(err, &apiErr)
+}
+
+// FormatAge formats a duration as a short human-readable age (e.g. "14h ago")
+func FormatAge(d time.Duration) string {
+	if d < time.Minute {
+		return "just now"
+	}
+	return FormatDuration(d) + " ago"
+}
+
+// FormatDuration formats a duration as a short human-readable span (e.g. "14h", "2 days")
+func FormatDuration(d time.Duration) string {
+	if d < 0 {
+		d = -d
+	}
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 48*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%d days", int(d.Hours()/24))
+	}
+}
@@ -0,0 +1,71 @@
+package cfp
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestEventsCache_RoundTrip(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	key := EventsCacheKey("https://cfp.ninja", ListEventsOptions{Tag: "go"}, false)
+	if err := SaveEventsCache(key, &EventsCache{
+		Server:    "https://cfp.ninja",
+		FetchedAt: time.Now().Add(-14 * time.Hour),
+		Events:    []Event{{Slug: "gophercon"}},
+	}); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	path, _ := eventsCachePath(key)
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("expected cache file, got: %v", err)
+	}
+	if perm := info.Mode().Perm(); perm != CacheFilePerm {
+		t.Errorf("expected permissions %o, got %o", CacheFilePerm, perm)
+	}
+
+	cached, err := LoadEventsCache(key)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if cached == nil || len(cached.Events) != 1 || cached.Events[0].Slug != "gophercon" {
+		t.Fatalf("unexpected cached data: %+v", cached)
+	}
+	if got := FormatAge(cached.Age()); got != "14h ago" {
+		t.Errorf("expected age '14h ago', got %q", got)
+	}
+
+	if err := ClearCache(); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	cached, err = LoadEventsCache(key)
+	if err != nil || cached != nil {
+		t.Errorf("expected no cache after clear, got %+v, %v", cached, err)
+	}
+}
+
+func TestEventsCacheKey_DependsOnFilters(t *testing.T) {
+	a := EventsCacheKey("https://cfp.ninja", ListEventsOptions{Tag: "go"}, false)
+	b := EventsCacheKey("https://cfp.ninja", ListEventsOptions{Tag: "rust"}, false)
+	c := EventsCacheKey("https://other.example", ListEventsOptions{Tag: "go"}, false)
+	if a == b || a == c {
+		t.Error("expected different keys for different filters or servers")
+	}
+	if a != EventsCacheKey("https://cfp.ninja", ListEventsOptions{Tag: "go"}, false) {
+		t.Error("expected stable key for identical filters")
+	}
+}
+
+func TestIsUnreachable(t *testing.T) {
+	if IsUnreachable(&APIError{StatusCode: 500}) {
+		t.Error("expected API errors to not count as unreachable")
+	}
+	if !IsUnreachable(fmt.Errorf("request failed: %w", errors.New("dial tcp: connection refused"))) {
+		t.Error("expected network errors to count as unreachable")
+	}
+}
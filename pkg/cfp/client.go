@@ -2,20 +2,57 @@ package cfp
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"math/rand/v2"
 	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
 	"time"
 )
 
+const (
+	// DefaultTimeout is the per-request timeout used when none is configured
+	DefaultTimeout = 30 * time.Second
+	// DefaultMaxRetries is the number of retries attempted for transient failures
+	DefaultMaxRetries = 2
+
+	retryBaseDelay = 500 * time.Millisecond
+	retryMaxDelay  = 30 * time.Second
+)
+
 // Client is an HTTP client for the CFP.ninja API
 type Client struct {
 	BaseURL    string
 	Token      string
 	HTTPClient *http.Client
+	// MaxRetries is the number of times a transient failure is retried (0 disables retries)
+	MaxRetries int
+	// Version is the CLI version, sent as "X-Client: cfp-cli/<version>" so
+	// the server can tell CLI submissions apart
+	Version string
+
+	ctx context.Context
+}
+
+// WithContext returns a shallow copy of the client whose requests are bound to ctx,
+// so cancelling ctx (e.g. on Ctrl-C) aborts in-flight requests and pending retries.
+func (c *Client) WithContext(ctx context.Context) *Client {
+	c2 := *c
+	c2.ctx = ctx
+	return &c2
+}
+
+// Context returns the client's context, defaulting to context.Background
+func (c *Client) Context() context.Context {
+	if c.ctx != nil {
+		return c.ctx
+	}
+	return context.Background()
 }
 
 // NewClient creates a new API client from the stored config (requires login)
@@ -30,11 +67,12 @@ func NewClient() (*Client, error) {
 	}
 
 	return &Client{
-		BaseURL: cfg.Server,
+		BaseURL: NormalizeServer(cfg.Server),
 		Token:   cfg.Token,
 		HTTPClient: &http.Client{
-			Timeout: 30 * time.Second,
+			Timeout: DefaultTimeout,
 		},
+		MaxRetries: DefaultMaxRetries,
 	}, nil
 }
 
@@ -46,79 +84,164 @@ func NewPublicClient() (*Client, error) {
 	}
 
 	return &Client{
-		BaseURL: cfg.Server,
+		BaseURL: NormalizeServer(cfg.Server),
 		Token:   "", // No auth token
 		HTTPClient: &http.Client{
-			Timeout: 30 * time.Second,
+			Timeout: DefaultTimeout,
 		},
+		MaxRetries: DefaultMaxRetries,
 	}, nil
 }
 
 // NewClientWithConfig creates a client with explicit config
 func NewClientWithConfig(cfg *Config) *Client {
 	return &Client{
-		BaseURL: cfg.Server,
+		BaseURL: NormalizeServer(cfg.Server),
 		Token:   cfg.Token,
 		HTTPClient: &http.Client{
-			Timeout: 30 * time.Second,
+			Timeout: DefaultTimeout,
 		},
+		MaxRetries: DefaultMaxRetries,
 	}
 }
 
-// APIError represents an error response from the API
-type APIError struct {
-	Message    string
-	StatusCode int
-}
-
-func (e *APIError) Error() string {
-	return fmt.Sprintf("API error (%d): %s", e.StatusCode, e.Message)
+// doRequest performs an authenticated HTTP request, retrying transient failures.
+// Idempotent requests are retried on network errors and on 429/502/503/504.
+// Other requests are only retried when the server says it did not process them (429/503).
+func (c *Client) doRequest(method, path string, body interface{}) ([]byte, error) {
+	respBody, _, err := c.doRequestWithHeader(method, path, body)
+	return respBody, err
 }
 
-// doRequest performs an authenticated HTTP request
-func (c *Client) doRequest(method, path string, body interface{}) ([]byte, error) {
-	var reqBody io.Reader
+// doRequestWithHeader is doRequest, additionally returning the response headers
+func (c *Client) doRequestWithHeader(method, path string, body interface{}) ([]byte, http.Header, error) {
+	var jsonData []byte
 	if body != nil {
-		jsonData, err := json.Marshal(body)
+		var err error
+		jsonData, err = json.Marshal(body)
 		if err != nil {
-			return nil, fmt.Errorf("failed to marshal request body: %w", err)
+			return nil, nil, fmt.Errorf("failed to marshal request body: %w", err)
+		}
+	}
+
+	ctx := c.Context()
+	idempotent := method == http.MethodGet || method == http.MethodHead
+
+	for attempt := 0; ; attempt++ {
+		respBody, header, err := c.doRequestOnce(ctx, method, path, jsonData)
+		if err == nil {
+			return respBody, header, nil
+		}
+		if attempt >= c.MaxRetries || !shouldRetry(ctx, err, idempotent) {
+			return nil, header, err
+		}
+
+		delay := backoffDelay(attempt)
+		if retryAfter := parseRetryAfter(header.Get("Retry-After")); retryAfter > 0 {
+			delay = min(retryAfter, retryMaxDelay)
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, nil, fmt.Errorf("request failed: %w", ctx.Err())
+		case <-timer.C:
 		}
+	}
+}
+
+// doRequestOnce performs a single HTTP request attempt, returning the response
+// headers (if a response was received) alongside the body or error
+func (c *Client) doRequestOnce(ctx context.Context, method, path string, jsonData []byte) ([]byte, http.Header, error) {
+	var reqBody io.Reader
+	if jsonData != nil {
 		reqBody = bytes.NewReader(jsonData)
 	}
 
-	req, err := http.NewRequest(method, c.BaseURL+path, reqBody)
+	req, err := http.NewRequestWithContext(ctx, method, c.BaseURL+path, reqBody)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	if c.Token != "" {
 		req.Header.Set("Authorization", "Bearer "+c.Token)
 	}
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Accept", "application/json")
+	// version=2 asks for structured errors; older servers ignore it
+	req.Header.Set("Accept", "application/json; version=2")
+	if c.Version != "" {
+		req.Header.Set("X-Client", "cfp-cli/"+c.Version)
+	}
 
 	resp, err := c.HTTPClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
+		return nil, nil, fmt.Errorf("request failed: %w", err)
 	}
 	defer resp.Body.Close()
 
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
+		return nil, resp.Header, fmt.Errorf("failed to read response: %w", err)
 	}
 
 	if resp.StatusCode >= 400 {
-		var errResp struct {
-			Error string `json:"error"`
-		}
-		if err := json.Unmarshal(respBody, &errResp); err == nil && errResp.Error != "" {
-			return nil, &APIError{Message: errResp.Error, StatusCode: resp.StatusCode}
+		apiErr := parseAPIError(resp.StatusCode, respBody)
+		apiErr.RetryAfter = parseRetryAfter(resp.Header.Get("Retry-After"))
+		return nil, resp.Header, apiErr
+	}
+
+	return respBody, resp.Header, nil
+}
+
+// shouldRetry reports whether a failed attempt may safely be retried
+func shouldRetry(ctx context.Context, err error, idempotent bool) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.StatusCode {
+		case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+			// The server explicitly refused the request, so it was not processed
+			return true
+		case http.StatusBadGateway, http.StatusGatewayTimeout:
+			// A gateway error may hide a request the backend did process
+			return idempotent
 		}
-		return nil, &APIError{Message: string(respBody), StatusCode: resp.StatusCode}
+		return false
 	}
 
-	return respBody, nil
+	// Network-level failure: only safe to repeat if the request is idempotent
+	return idempotent
+}
+
+// backoffDelay returns the jittered exponential backoff for the given attempt
+func backoffDelay(attempt int) time.Duration {
+	delay := retryBaseDelay << attempt
+	if delay <= 0 || delay > retryMaxDelay {
+		delay = retryMaxDelay
+	}
+	// Jitter the delay into [delay/2, delay) to avoid synchronized retries
+	half := delay / 2
+	return half + rand.N(half)
+}
+
+// parseRetryAfter parses a Retry-After header in either delay-seconds or HTTP-date form
+func parseRetryAfter(v string) time.Duration {
+	if v == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(v); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d
+		}
+	}
+	return 0
 }
 
 // UserInfo represents the current user's information
@@ -144,24 +267,117 @@ func (c *Client) GetMe() (*UserInfo, error) {
 	return &user, nil
 }
 
+// ServerConfig represents the server's public configuration
+type ServerConfig struct {
+	AuthProviders        []string `json:"auth_providers"`
+	PaymentsEnabled      bool     `json:"payments_enabled"`
+	MaxProposalsPerEvent int      `json:"max_proposals_per_event"`
+
+	// Features is nil for servers that predate it
+	Features *ServerFeatures `json:"features"`
+
+	// ServerTime is the server clock reading taken from the response Date header
+	ServerTime time.Time `json:"-"`
+}
+
+// ServerFeatures says which optional parts of the platform a server runs
+type ServerFeatures struct {
+	PaymentsEnabled              bool     `json:"payments_enabled"`
+	Providers                    []string `json:"providers"`
+	EventListingFee              int      `json:"event_listing_fee"`
+	EventListingFeeCurrency      string   `json:"event_listing_fee_currency"`
+	SubmissionListingFee         int      `json:"submission_listing_fee"`
+	SubmissionListingFeeCurrency string   `json:"submission_listing_fee_currency"`
+	MaxProposalsPerEvent         int      `json:"max_proposals_per_event"`
+	MaxOrganizersPerEvent        int      `json:"max_organizers_per_event"`
+	MaxSpeakers                  int      `json:"max_speakers"`
+	EventSyncEnabled             bool     `json:"event_sync_enabled"`
+	DigestEnabled                bool     `json:"digest_enabled"`
+}
+
+// HasProvider reports whether the server offers login with the provider
+func (f *ServerFeatures) HasProvider(provider string) bool {
+	for _, p := range f.Providers {
+		if p == provider {
+			return true
+		}
+	}
+	return false
+}
+
+// GetServerConfig returns the server's public configuration
+func (c *Client) GetServerConfig() (*ServerConfig, error) {
+	data, header, err := c.doRequestWithHeader("GET", "/api/v0/config", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var sc ServerConfig
+	if err := json.Unmarshal(data, &sc); err != nil {
+		return nil, fmt.Errorf("failed to parse server config: %w", err)
+	}
+	if t, err := http.ParseTime(header.Get("Date")); err == nil {
+		sc.ServerTime = t
+	}
+
+	return &sc, nil
+}
+
+// ServerVersion represents the server version and the CLI versions it supports
+type ServerVersion struct {
+	Version       string `json:"version"`
+	MinCLIVersion string `json:"min_cli_version,omitempty"`
+}
+
+// GetServerVersion returns the server version information
+func (c *Client) GetServerVersion() (*ServerVersion, error) {
+	data, err := c.doRequest("GET", "/api/v0/version", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var v ServerVersion
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, fmt.Errorf("failed to parse server version: %w", err)
+	}
+
+	return &v, nil
+}
+
 // Event represents a conference event
 type Event struct {
 	ID             uint           `json:"id"`
 	Name           string         `json:"name"`
 	Slug           string         `json:"slug"`
 	Description    string         `json:"description"`
+	DescriptionFormat string      `json:"description_format"`
+	Summary        string         `json:"summary"`
 	Location       string         `json:"location"`
 	Country        string         `json:"country"`
+	AttendanceMode string         `json:"attendance_mode"`
 	StartDate      time.Time      `json:"start_date"`
 	EndDate        time.Time      `json:"end_date"`
 	Website        string         `json:"website"`
 	TermsURL       string         `json:"terms_url"`
+	CoCURL         string         `json:"coc_url"`
+	RequireCoCAcceptance bool      `json:"require_coc_acceptance"`
 	Tags           string         `json:"tags"`
 	CFPDescription string         `json:"cfp_description"`
 	CFPOpenAt      time.Time      `json:"cfp_open_at"`
 	CFPCloseAt     time.Time      `json:"cfp_close_at"`
 	CFPStatus      string         `json:"cfp_status"`
 	CFPQuestions   CustomQuestions `json:"cfp_questions"`
+	AbstractMinWords *int         `json:"abstract_min_words"`
+	AbstractMaxWords *int         `json:"abstract_max_words"`
+	Sections       []EventSection  `json:"sections,omitempty"`
+	CFPRequiresPayment bool       `json:"cfp_requires_payment"`
+}
+
+// EventSection is a titled block of event information, such as travel info
+// or the code of conduct
+type EventSection struct {
+	Title string `json:"title"`
+	Body  string `json:"body"`
 }
 
 // CustomQuestions is a slice that can unmarshal from both JSON arrays and objects/null
@@ -209,8 +425,12 @@ type ListEventsOptions struct {
 	Order     string // asc, desc
 	Page      int
 	PerPage   int
+	Fields    []string // Only return these event fields (server default: all)
 }
 
+// EventTableFields are the event fields shown by the table view of PrintEvents
+var EventTableFields = []string{"id", "slug", "name", "location", "country", "attendance_mode", "cfp_status", "cfp_close_at"}
+
 // EventsResponse is the response from listing events
 type EventsResponse struct {
 	Events     []Event    `json:"events"`
@@ -272,6 +492,9 @@ func (c *Client) ListEvents(opts ListEventsOptions) (*EventsResponse, error) {
 	if opts.PerPage > 0 {
 		params.Set("per_page", strconv.Itoa(opts.PerPage))
 	}
+	if len(opts.Fields) > 0 {
+		params.Set("fields", strings.Join(opts.Fields, ","))
+	}
 
 	path := "/api/v0/events"
 	if len(params) > 0 {
@@ -291,6 +514,45 @@ func (c *Client) ListEvents(opts ListEventsOptions) (*EventsResponse, error) {
 	return &resp, nil
 }
 
+// MaxListPages is the safety cap on the number of pages ListAllEvents will fetch
+const MaxListPages = 100
+
+// ErrStopPagination can be returned from a ListAllEvents callback to stop paging without error
+var ErrStopPagination = errors.New("stop pagination")
+
+// ListAllEvents iterates over every page of events matching opts, calling fn once per page.
+// Paging starts at opts.Page (default 1) and stops after the last page, after MaxListPages
+// pages, or when fn returns an error. Returning ErrStopPagination stops early without error.
+func (c *Client) ListAllEvents(opts ListEventsOptions, fn func(events []Event, p Pagination) error) error {
+	if opts.Page < 1 {
+		opts.Page = 1
+	}
+	if opts.PerPage <= 0 {
+		opts.PerPage = 100
+	}
+
+	for fetched := 0; fetched < MaxListPages; fetched++ {
+		resp, err := c.ListEvents(opts)
+		if err != nil {
+			return err
+		}
+
+		if err := fn(resp.GetEvents(), resp.Pagination); err != nil {
+			if errors.Is(err, ErrStopPagination) {
+				return nil
+			}
+			return err
+		}
+
+		if opts.Page >= resp.Pagination.TotalPages {
+			return nil
+		}
+		opts.Page++
+	}
+
+	return nil
+}
+
 // GetEvent retrieves a single event by slug
 func (c *Client) GetEvent(slug string) (*Event, error) {
 	data, err := c.doRequest("GET", "/api/v0/e/"+slug, nil)
@@ -314,6 +576,7 @@ type Speaker struct {
 	JobTitle string `json:"job_title" yaml:"job_title"`
 	LinkedIn string `json:"linkedin" yaml:"linkedin"`
 	Company  string `json:"company" yaml:"company"`
+	PhotoURL string `json:"photo_url,omitempty" yaml:"photo_url,omitempty"`
 	Primary  bool   `json:"primary" yaml:"primary"`
 }
 
@@ -328,6 +591,7 @@ type ProposalSubmission struct {
 	SpeakerNotes  string                 `json:"speaker_notes,omitempty" yaml:"speaker_notes,omitempty"`
 	Speakers      []Speaker              `json:"speakers" yaml:"speakers"`
 	CustomAnswers map[string]interface{} `json:"custom_answers,omitempty" yaml:"custom_answers,omitempty"`
+	CoCAccepted   bool                   `json:"coc_accepted,omitempty" yaml:"coc_accepted,omitempty"`
 }
 
 // Proposal represents a submitted proposal
@@ -345,10 +609,28 @@ type Proposal struct {
 	Speakers      []Speaker              `json:"speakers"`
 	SpeakerNotes  string                 `json:"speaker_notes,omitempty"`
 	CustomAnswers map[string]interface{} `json:"custom_answers,omitempty"`
-	CreatedAt     time.Time              `json:"created_at"`
-	UpdatedAt     time.Time              `json:"updated_at"`
+	SlidesURL     string                 `json:"slides_url,omitempty"`
+	VideoURL      string                 `json:"video_url,omitempty"`
+
+	AttendanceConfirmed   bool       `json:"attendance_confirmed"`
+	AttendanceConfirmedAt *time.Time `json:"attendance_confirmed_at,omitempty"`
+	OrganizerNotes        string     `json:"organizer_notes,omitempty"` // shared decision summary, only visible to organizers
+	ReviewerNotes         string     `json:"reviewer_notes,omitempty"`  // the caller's private review notes
+	IsPaid                bool       `json:"is_paid"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
 }
 
+// Proposal statuses. UpdateProposalStatus accepts all but cancelled.
+const (
+	ProposalStatusSubmitted = "submitted"
+	ProposalStatusAccepted  = "accepted"
+	ProposalStatusRejected  = "rejected"
+	ProposalStatusTentative = "tentative"
+	ProposalStatusCancelled = "cancelled" // set when a speaker emergency-cancels
+)
+
 // SubmitProposal submits a new proposal to an event
 func (c *Client) SubmitProposal(eventID uint, p *ProposalSubmission) (*Proposal, error) {
 	path := fmt.Sprintf("/api/v0/events/%d/proposals", eventID)
@@ -381,18 +663,242 @@ func (c *Client) GetProposal(id uint) (*Proposal, error) {
 	return &proposal, nil
 }
 
+// ProposalUpdate represents a partial proposal update. Only non-nil fields are sent,
+// so unset fields keep their current value.
+type ProposalUpdate struct {
+	Title          *string                `json:"title,omitempty"`
+	Abstract       *string                `json:"abstract,omitempty"`
+	Format         *string                `json:"format,omitempty"`
+	Duration       *int                   `json:"duration,omitempty"`
+	Level          *string                `json:"level,omitempty"`
+	Tags           *string                `json:"tags,omitempty"`
+	SpeakerNotes   *string                `json:"speaker_notes,omitempty"`
+	Speakers       []Speaker              `json:"speakers,omitempty"`
+	CustomAnswers  map[string]interface{} `json:"custom_answers,omitempty"`
+	SlidesURL      *string                `json:"slides_url,omitempty"`
+	VideoURL       *string                `json:"video_url,omitempty"`
+	OrganizerNotes *string                `json:"organizer_notes,omitempty"` // organizers only
+	ReviewerNotes  *string                `json:"reviewer_notes,omitempty"`  // organizers only, private to the caller
+}
+
+// UpdateProposal applies a partial update to a proposal. Speakers can edit
+// proposals still under review while the CFP is open, and once accepted only
+// their speaker details, slides and video URLs and notes; organizers can
+// always edit.
+func (c *Client) UpdateProposal(id uint, u *ProposalUpdate) (*Proposal, error) {
+	return c.proposalRequest("PUT", fmt.Sprintf("/api/v0/proposals/%d", id), u)
+}
+
+// DeleteProposal withdraws (deletes) a proposal
+func (c *Client) DeleteProposal(id uint) error {
+	_, err := c.doRequest("DELETE", fmt.Sprintf("/api/v0/proposals/%d", id), nil)
+	return err
+}
+
+// ConfirmAttendance confirms the speaker will attend for an accepted proposal
+func (c *Client) ConfirmAttendance(id uint) (*Proposal, error) {
+	return c.proposalRequest("PUT", fmt.Sprintf("/api/v0/proposals/%d/confirm", id), nil)
+}
+
+// EmergencyCancel cancels a confirmed proposal, e.g. when the speaker can no longer attend
+func (c *Client) EmergencyCancel(id uint) (*Proposal, error) {
+	return c.proposalRequest("PUT", fmt.Sprintf("/api/v0/proposals/%d/emergency-cancel", id), nil)
+}
+
+// ProposalStatusUpdate is the request body for UpdateProposalStatus
+type ProposalStatusUpdate struct {
+	Status string `json:"status"`
+}
+
+// UpdateProposalStatus sets the status of a proposal (organizers only)
+func (c *Client) UpdateProposalStatus(id uint, status string) (*Proposal, error) {
+	return c.proposalRequest("PUT", fmt.Sprintf("/api/v0/proposals/%d/status", id), &ProposalStatusUpdate{Status: status})
+}
+
+// ProposalRatingUpdate is the request body for UpdateProposalRating
+type ProposalRatingUpdate struct {
+	Rating int `json:"rating"`
+}
+
+// UpdateProposalRating sets the 0-5 rating of a proposal (organizers only)
+func (c *Client) UpdateProposalRating(id uint, rating int) (*Proposal, error) {
+	return c.proposalRequest("PUT", fmt.Sprintf("/api/v0/proposals/%d/rating", id), &ProposalRatingUpdate{Rating: rating})
+}
+
+// proposalRequest performs a request whose response is a single proposal
+func (c *Client) proposalRequest(method, path string, body interface{}) (*Proposal, error) {
+	data, err := c.doRequest(method, path, body)
+	if err != nil {
+		return nil, err
+	}
+
+	var proposal Proposal
+	if err := json.Unmarshal(data, &proposal); err != nil {
+		return nil, fmt.Errorf("failed to parse proposal: %w", err)
+	}
+
+	return &proposal, nil
+}
+
+// ListEventProposals returns all proposals submitted to an event (organizers only)
+func (c *Client) ListEventProposals(eventID uint) ([]Proposal, error) {
+	path := fmt.Sprintf("/api/v0/events/%d/proposals", eventID)
+	data, err := c.doRequest("GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var proposals []Proposal
+	if err := json.Unmarshal(data, &proposals); err != nil {
+		return nil, fmt.Errorf("failed to parse proposals: %w", err)
+	}
+
+	return proposals, nil
+}
+
+// Export formats accepted by ExportProposals
+const (
+	ExportFormatInPerson = "in-person"
+	ExportFormatOnline   = "online"
+	ExportFormatSpeakers = "speakers" // one row per confirmed speaker, for badges
+	ExportFormatPretalx  = "pretalx"  // JSON for importing into pretalx
+)
+
+// ExportProposals writes an event's proposals to w (organizers only): CSV for
+// ExportFormatInPerson, ExportFormatOnline and ExportFormatSpeakers, or JSON
+// for ExportFormatPretalx.
+func (c *Client) ExportProposals(eventID uint, format string, w io.Writer) error {
+	path := fmt.Sprintf("/api/v0/events/%d/proposals/export?format=%s", eventID, url.QueryEscape(format))
+	data, err := c.doRequest("GET", path, nil)
+	if err != nil {
+		return err
+	}
+
+	if _, err := w.Write(data); err != nil {
+		return fmt.Errorf("failed to write export: %w", err)
+	}
+	return nil
+}
+
+// Organizer represents an event organizer
+type Organizer struct {
+	ID        uint      `json:"id"`
+	Email     string    `json:"email"`
+	Name      string    `json:"name"`
+	IsCreator bool      `json:"is_creator"`
+	CreatedAt time.Time `json:"created_at"` // Of the organizer's account
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// OrganizerAddition is the request body for AddOrganizer
+type OrganizerAddition struct {
+	Email string `json:"email"`
+}
+
+// ListOrganizers returns the organizers of an event (organizers only)
+func (c *Client) ListOrganizers(eventID uint) ([]Organizer, error) {
+	path := fmt.Sprintf("/api/v0/events/%d/organizers", eventID)
+	data, err := c.doRequest("GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var organizers []Organizer
+	if err := json.Unmarshal(data, &organizers); err != nil {
+		return nil, fmt.Errorf("failed to parse organizers: %w", err)
+	}
+
+	return organizers, nil
+}
+
+// AddOrganizer adds a registered user, identified by email, as an event organizer
+func (c *Client) AddOrganizer(eventID uint, email string) error {
+	path := fmt.Sprintf("/api/v0/events/%d/organizers", eventID)
+	_, err := c.doRequest("POST", path, &OrganizerAddition{Email: email})
+	return err
+}
+
+// OrganizerResult is what became of one email passed to AddOrganizers:
+// "added", "already_organizer", "not_found" (no account with that email) or
+// "limit_reached" (the event has as many organizers as allowed)
+type OrganizerResult struct {
+	Email  string `json:"email"`
+	Result string `json:"result"`
+	UserID uint   `json:"user_id,omitempty"`
+}
+
+// AddOrganizers adds registered users, identified by email, as event
+// organizers in one request, and returns what became of each email
+func (c *Client) AddOrganizers(eventID uint, emails []string) ([]OrganizerResult, error) {
+	path := fmt.Sprintf("/api/v0/events/%d/organizers/bulk", eventID)
+	data, err := c.doRequest("POST", path, map[string][]string{"emails": emails})
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Results []OrganizerResult `json:"results"`
+	}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse organizer results: %w", err)
+	}
+
+	return resp.Results, nil
+}
+
+// RemoveOrganizer removes an organizer from an event (event creator only)
+func (c *Client) RemoveOrganizer(eventID, userID uint) error {
+	path := fmt.Sprintf("/api/v0/events/%d/organizers/%d", eventID, userID)
+	_, err := c.doRequest("DELETE", path, nil)
+	return err
+}
+
+// MyEventsOptions narrows and pages the events the user manages; Query also
+// narrows the events they submitted to. Without Page or PerPage the server
+// returns every event.
+type MyEventsOptions struct {
+	Query     string // Search query for name/slug
+	CFPStatus string // draft, open, closed, reviewing, complete
+	Page      int
+	PerPage   int
+}
+
+// path returns the endpoint with the options as query parameters
+func (o MyEventsOptions) path(endpoint string) string {
+	params := url.Values{}
+	if o.Query != "" {
+		params.Set("q", o.Query)
+	}
+	if o.CFPStatus != "" {
+		params.Set("cfp_status", o.CFPStatus)
+	}
+	if o.Page > 0 {
+		params.Set("page", strconv.Itoa(o.Page))
+	}
+	if o.PerPage > 0 {
+		params.Set("per_page", strconv.Itoa(o.PerPage))
+	}
+	if len(params) > 0 {
+		endpoint += "?" + params.Encode()
+	}
+	return endpoint
+}
+
 // MyEventsResponse represents the response from /api/v0/me/events
 type MyEventsResponse struct {
-	Managing  []ManagingEvent  `json:"managing"`
-	Submitted []SubmittedEvent `json:"submitted"`
+	Managing   []ManagingEvent  `json:"managing"`
+	Submitted  []SubmittedEvent `json:"submitted"`
+	Pagination *Pagination      `json:"pagination,omitempty"` // Of Managing, when paginated
 }
 
 // ManagingEvent represents an event the user manages
 type ManagingEvent struct {
-	ID            uint   `json:"id"`
-	Name          string `json:"name"`
-	CFPStatus     string `json:"cfp_status"`
-	ProposalCount int64  `json:"proposal_count"`
+	ID            uint      `json:"id"`
+	Name          string    `json:"name"`
+	CFPStatus     string    `json:"cfp_status"`
+	ProposalCount int64     `json:"proposal_count"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
 }
 
 // SubmittedEvent represents an event the user submitted to
@@ -401,19 +907,23 @@ type SubmittedEvent struct {
 	Name        string       `json:"name"`
 	CFPStatus   string       `json:"cfp_status"`
 	MyProposals []MyProposal `json:"my_proposals"`
+	CreatedAt   time.Time    `json:"created_at"`
+	UpdatedAt   time.Time    `json:"updated_at"`
 }
 
 // MyProposal represents a user's proposal summary
 type MyProposal struct {
-	ID     uint   `json:"id"`
-	Title  string `json:"title"`
-	Status string `json:"status"`
-	Rating *int   `json:"rating,omitempty"`
+	ID        uint      `json:"id"`
+	Title     string    `json:"title"`
+	Status    string    `json:"status"`
+	Rating    *int      `json:"rating,omitempty"`
+	CreatedAt time.Time `json:"created_at"` // When it was submitted
+	UpdatedAt time.Time `json:"updated_at"`
 }
 
 // GetMyEvents returns events the user manages or has submitted to
-func (c *Client) GetMyEvents() (*MyEventsResponse, error) {
-	data, err := c.doRequest("GET", "/api/v0/me/events", nil)
+func (c *Client) GetMyEvents(opts MyEventsOptions) (*MyEventsResponse, error) {
+	data, err := c.doRequest("GET", opts.path("/api/v0/me/events"), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -426,16 +936,78 @@ func (c *Client) GetMyEvents() (*MyEventsResponse, error) {
 	return &resp, nil
 }
 
+// DashboardResponse represents the response from /api/v0/me/dashboard
+type DashboardResponse struct {
+	Events          []DashboardEvent   `json:"events"`
+	NextCFPDeadline *DashboardDeadline `json:"next_cfp_deadline"`
+	Pagination      *Pagination        `json:"pagination,omitempty"` // When paginated
+}
+
+// DashboardEvent represents an event the user manages, with proposal counts
+type DashboardEvent struct {
+	ID             uint                 `json:"id"`
+	Name           string               `json:"name"`
+	Slug           string               `json:"slug"`
+	StartDate      time.Time            `json:"start_date"`
+	EndDate        time.Time            `json:"end_date"`
+	CFPStatus      string               `json:"cfp_status"`
+	CFPOpen        bool                 `json:"cfp_open"`
+	CFPCloseAt     *time.Time           `json:"cfp_close_at"`
+	IsCreator      bool                 `json:"is_creator"`
+	PaymentStatus  string               `json:"payment_status"`
+	ProposalCounts map[string]int64     `json:"proposal_counts"`
+	ProposalTotal  int64                `json:"proposal_total"`
+	UnratedCount   int64                `json:"unrated_count"`
+	Organizers     []DashboardOrganizer `json:"organizers"`
+	CreatedAt      time.Time            `json:"created_at"`
+	UpdatedAt      time.Time            `json:"updated_at"`
+}
+
+// DashboardOrganizer represents an organizer of a dashboard event
+type DashboardOrganizer struct {
+	ID        uint      `json:"id"`
+	Name      string    `json:"name"`
+	Email     string    `json:"email"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// DashboardDeadline is the soonest open CFP deadline among the user's events
+type DashboardDeadline struct {
+	EventID    uint      `json:"event_id"`
+	Name       string    `json:"name"`
+	CFPCloseAt time.Time `json:"cfp_close_at"`
+}
+// GetDashboard returns the events the user manages, with proposal counts,
+// payment status and organizers
+func (c *Client) GetDashboard(opts MyEventsOptions) (*DashboardResponse, error) {
+	data, err := c.doRequest("GET", opts.path("/api/v0/me/dashboard"), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp DashboardResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse dashboard: %w", err)
+	}
+
+	return &resp, nil
+}
+
 // EventSubmission represents an event to create
 type EventSubmission struct {
 	Name           string           `json:"name" yaml:"name"`
 	Slug           string           `json:"slug" yaml:"slug"`
 	Description    string           `json:"description,omitempty" yaml:"description,omitempty"`
+	DescriptionFormat string        `json:"description_format,omitempty" yaml:"description_format,omitempty"` // plaintext, markdown
+	Summary        string           `json:"summary,omitempty" yaml:"summary,omitempty"` // Defaults to the first paragraph of description
 	Location       string           `json:"location,omitempty" yaml:"location,omitempty"`
 	Country        string           `json:"country,omitempty" yaml:"country,omitempty"`
+	AttendanceMode string           `json:"attendance_mode,omitempty" yaml:"attendance_mode,omitempty"` // in_person, online, hybrid
 	StartDate      string           `json:"start_date,omitempty" yaml:"start_date,omitempty"` // YYYY-MM-DD
 	EndDate        string           `json:"end_date,omitempty" yaml:"end_date,omitempty"`     // YYYY-MM-DD
 	Website        string           `json:"website,omitempty" yaml:"website,omitempty"`
+	LogoURL        string           `json:"logo_url,omitempty" yaml:"logo_url,omitempty"`
 	TermsURL       string           `json:"terms_url,omitempty" yaml:"terms_url,omitempty"`
 	Tags           string           `json:"tags,omitempty" yaml:"tags,omitempty"`
 	CFPDescription string           `json:"cfp_description,omitempty" yaml:"cfp_description,omitempty"`
@@ -443,9 +1015,62 @@ type EventSubmission struct {
 	CFPCloseAt     string           `json:"cfp_close_at,omitempty" yaml:"cfp_close_at,omitempty"` // RFC3339
 	CFPStatus      string           `json:"cfp_status,omitempty" yaml:"cfp_status,omitempty"`     // draft, open, closed
 	MaxAccepted    *int             `json:"max_accepted,omitempty" yaml:"max_accepted,omitempty"`
+	WaitlistAutoPromote bool        `json:"waitlist_auto_promote,omitempty" yaml:"waitlist_auto_promote,omitempty"`
+	AllowOverbook  bool             `json:"allow_overbook,omitempty" yaml:"allow_overbook,omitempty"`
+	Series         string           `json:"series,omitempty" yaml:"series,omitempty"`
+	FlagSeriesDuplicates bool       `json:"flag_series_duplicates,omitempty" yaml:"flag_series_duplicates,omitempty"`
 	CFPQuestions   []CustomQuestion `json:"cfp_questions,omitempty" yaml:"cfp_questions,omitempty"`
 }
 
+// GetEventFields returns every field of an event the user organizes, as the
+// API encodes them, for comparing with an event file
+func (c *Client) GetEventFields(id uint) (map[string]interface{}, error) {
+	data, err := c.doRequest("GET", fmt.Sprintf("/api/v0/me/events/%d", id), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, fmt.Errorf("failed to parse event: %w", err)
+	}
+
+	return fields, nil
+}
+
+// ApplyEvent creates the event with the given slug, or updates the given
+// fields if it exists and the user organizes it
+func (c *Client) ApplyEvent(slug string, fields map[string]interface{}) (*Event, error) {
+	data, err := c.doRequest("PUT", "/api/v0/e/"+url.PathEscape(slug), fields)
+	if err != nil {
+		return nil, err
+	}
+
+	var event Event
+	if err := json.Unmarshal(data, &event); err != nil {
+		return nil, fmt.Errorf("failed to parse event: %w", err)
+	}
+
+	return &event, nil
+}
+
+// ScrapeEventPreview reads an event from its website's schema.org and Open
+// Graph data. Nothing is saved; the draft is meant to be edited and passed to
+// CreateEvent.
+func (c *Client) ScrapeEventPreview(pageURL string) (*EventSubmission, error) {
+	data, err := c.doRequest("POST", "/api/v0/events/scrape-preview", map[string]string{"url": pageURL})
+	if err != nil {
+		return nil, err
+	}
+
+	var draft EventSubmission
+	if err := json.Unmarshal(data, &draft); err != nil {
+		return nil, fmt.Errorf("failed to parse event draft: %w", err)
+	}
+
+	return &draft, nil
+}
+
 // CreateEvent creates a new event
 func (c *Client) CreateEvent(e *EventSubmission) (*Event, error) {
 	data, err := c.doRequest("POST", "/api/v0/events", e)
@@ -0,0 +1,532 @@
+package cfp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newTestClient(url string) *Client {
+	return &Client{
+		BaseURL:    url,
+		HTTPClient: &http.Client{Timeout: 5 * time.Second},
+		MaxRetries: DefaultMaxRetries,
+	}
+}
+
+func TestDoRequest_RetriesGETOnServiceUnavailable(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"id":1,"email":"a@example.com"}`))
+	}))
+	defer srv.Close()
+
+	user, err := newTestClient(srv.URL).GetMe()
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if user.ID != 1 {
+		t.Errorf("expected user ID 1, got %d", user.ID)
+	}
+	if got := calls.Load(); got != 2 {
+		t.Errorf("expected 2 calls, got %d", got)
+	}
+}
+
+func TestDoRequest_GivesUpAfterMaxRetries(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Retry-After", "0")
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	client := newTestClient(srv.URL)
+	client.MaxRetries = 1
+	_, err := client.GetMe()
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadGateway {
+		t.Fatalf("expected 502 APIError, got: %v", err)
+	}
+	if got := calls.Load(); got != 2 {
+		t.Errorf("expected 2 calls, got %d", got)
+	}
+}
+
+func TestDoRequest_DoesNotRetryPOSTOnBadGateway(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	_, err := newTestClient(srv.URL).SubmitProposal(1, &ProposalSubmission{Title: "Talk"})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("expected 1 call, got %d", got)
+	}
+}
+
+func TestDoRequest_RetriesPOSTOnTooManyRequests(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id":7,"title":"Talk"}`))
+	}))
+	defer srv.Close()
+
+	p, err := newTestClient(srv.URL).SubmitProposal(1, &ProposalSubmission{Title: "Talk"})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if p.ID != 7 {
+		t.Errorf("expected proposal ID 7, got %d", p.ID)
+	}
+	if got := calls.Load(); got != 2 {
+		t.Errorf("expected 2 calls, got %d", got)
+	}
+}
+
+func TestDoRequest_DoesNotRetryClientErrors(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error":"not found"}`))
+	}))
+	defer srv.Close()
+
+	_, err := newTestClient(srv.URL).GetEvent("missing")
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("expected 1 call, got %d", got)
+	}
+}
+
+func TestDoRequest_CancelledContextAbortsRetries(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "30")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	start := time.Now()
+	_, err := newTestClient(srv.URL).WithContext(ctx).GetMe()
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("expected cancellation to abort quickly, took %s", elapsed)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	if got := parseRetryAfter("5"); got != 5*time.Second {
+		t.Errorf("expected 5s, got %s", got)
+	}
+	if got := parseRetryAfter(""); got != 0 {
+		t.Errorf("expected 0, got %s", got)
+	}
+	if got := parseRetryAfter("garbage"); got != 0 {
+		t.Errorf("expected 0, got %s", got)
+	}
+	future := time.Now().Add(10 * time.Second).UTC().Format(http.TimeFormat)
+	if got := parseRetryAfter(future); got <= 0 || got > 10*time.Second {
+		t.Errorf("expected delay in (0, 10s], got %s", got)
+	}
+}
+
+func TestListAllEvents_FollowsPages(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page")
+		fmt.Fprintf(w, `{"data":[{"slug":"event-%s"}],"pagination":{"page":%s,"per_page":1,"total":3,"total_pages":3}}`, page, page)
+	}))
+	defer srv.Close()
+
+	var slugs []string
+	err := newTestClient(srv.URL).ListAllEvents(ListEventsOptions{PerPage: 1}, func(events []Event, p Pagination) error {
+		for _, e := range events {
+			slugs = append(slugs, e.Slug)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if strings.Join(slugs, ",") != "event-1,event-2,event-3" {
+		t.Errorf("unexpected slugs: %v", slugs)
+	}
+}
+
+func TestListEvents_SendsFields(t *testing.T) {
+	var got string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.URL.Query().Get("fields")
+		w.Write([]byte(`{"data":[{"ID":7,"slug":"gophercon"}],"pagination":{"page":1,"per_page":20,"total":1,"total_pages":1}}`))
+	}))
+	defer srv.Close()
+
+	resp, err := newTestClient(srv.URL).ListEvents(ListEventsOptions{Fields: []string{"id", "slug"}})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if got != "id,slug" {
+		t.Errorf("expected fields=id,slug, got %q", got)
+	}
+	events := resp.GetEvents()
+	if len(events) != 1 || events[0].ID != 7 || events[0].Slug != "gophercon" {
+		t.Errorf("unexpected events: %+v", events)
+	}
+}
+
+func TestListAllEvents_StopPagination(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Write([]byte(`{"data":[{"slug":"e"}],"pagination":{"page":1,"per_page":1,"total":10,"total_pages":10}}`))
+	}))
+	defer srv.Close()
+
+	err := newTestClient(srv.URL).ListAllEvents(ListEventsOptions{}, func(events []Event, p Pagination) error {
+		return ErrStopPagination
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("expected 1 call, got %d", got)
+	}
+}
+
+func TestUpdateProposal_SendsOnlySetFields(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" || r.URL.Path != "/api/v0/proposals/7" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode body: %v", err)
+		}
+		if len(body) != 2 || body["title"] != "New Title" || body["duration"] != float64(45) {
+			t.Errorf("expected only title and duration, got %v", body)
+		}
+		w.Write([]byte(`{"id":7,"title":"New Title","duration":45,"status":"submitted"}`))
+	}))
+	defer srv.Close()
+
+	title, duration := "New Title", 45
+	p, err := newTestClient(srv.URL).UpdateProposal(7, &ProposalUpdate{Title: &title, Duration: &duration})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if p.Title != "New Title" || p.Duration != 45 {
+		t.Errorf("unexpected proposal: %+v", p)
+	}
+}
+
+func TestProposalActions(t *testing.T) {
+	tests := []struct {
+		name     string
+		call     func(c *Client) (*Proposal, error)
+		wantPath string
+		wantBody string
+	}{
+		{
+			name:     "confirm attendance",
+			call:     func(c *Client) (*Proposal, error) { return c.ConfirmAttendance(3) },
+			wantPath: "/api/v0/proposals/3/confirm",
+		},
+		{
+			name:     "emergency cancel",
+			call:     func(c *Client) (*Proposal, error) { return c.EmergencyCancel(3) },
+			wantPath: "/api/v0/proposals/3/emergency-cancel",
+		},
+		{
+			name:     "update status",
+			call:     func(c *Client) (*Proposal, error) { return c.UpdateProposalStatus(3, ProposalStatusAccepted) },
+			wantPath: "/api/v0/proposals/3/status",
+			wantBody: `{"status":"accepted"}`,
+		},
+		{
+			name:     "update rating",
+			call:     func(c *Client) (*Proposal, error) { return c.UpdateProposalRating(3, 4) },
+			wantPath: "/api/v0/proposals/3/rating",
+			wantBody: `{"rating":4}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != "PUT" || r.URL.Path != tt.wantPath {
+					t.Errorf("expected PUT %s, got %s %s", tt.wantPath, r.Method, r.URL.Path)
+				}
+				body, _ := io.ReadAll(r.Body)
+				if string(body) != tt.wantBody {
+					t.Errorf("expected body %q, got %q", tt.wantBody, body)
+				}
+				w.Write([]byte(`{"id":3,"status":"accepted","attendance_confirmed":true}`))
+			}))
+			defer srv.Close()
+
+			p, err := tt.call(newTestClient(srv.URL))
+			if err != nil {
+				t.Fatalf("expected no error, got: %v", err)
+			}
+			if p.ID != 3 || !p.AttendanceConfirmed {
+				t.Errorf("unexpected proposal: %+v", p)
+			}
+		})
+	}
+}
+
+func TestDeleteProposal_ReturnsAPIError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "DELETE" || r.URL.Path != "/api/v0/proposals/9" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"error":"Forbidden"}`))
+	}))
+	defer srv.Close()
+
+	err := newTestClient(srv.URL).DeleteProposal(9)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected APIError, got: %v", err)
+	}
+	if apiErr.StatusCode != http.StatusForbidden || apiErr.Message != "Forbidden" {
+		t.Errorf("unexpected error: %+v", apiErr)
+	}
+}
+
+func TestListEventProposals(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v0/events/5/proposals" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		w.Write([]byte(`[{"id":1,"title":"A","organizer_notes":"strong"},{"id":2,"title":"B"}]`))
+	}))
+	defer srv.Close()
+
+	proposals, err := newTestClient(srv.URL).ListEventProposals(5)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if len(proposals) != 2 {
+		t.Fatalf("expected 2 proposals, got %d", len(proposals))
+	}
+	if proposals[0].OrganizerNotes != "strong" {
+		t.Errorf("expected organizer notes 'strong', got %q", proposals[0].OrganizerNotes)
+	}
+}
+
+func TestOrganizers(t *testing.T) {
+	var added, removed bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/api/v0/events/5/organizers":
+			w.Write([]byte(`[{"id":1,"email":"owner@example.com","name":"Owner","is_creator":true}]`))
+		case r.Method == "POST" && r.URL.Path == "/api/v0/events/5/organizers":
+			var req OrganizerAddition
+			json.NewDecoder(r.Body).Decode(&req)
+			added = req.Email == "new@example.com"
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"message":"Organizer added"}`))
+		case r.Method == "POST" && r.URL.Path == "/api/v0/events/5/organizers/bulk":
+			var req struct {
+				Emails []string `json:"emails"`
+			}
+			json.NewDecoder(r.Body).Decode(&req)
+			if len(req.Emails) != 2 {
+				t.Errorf("expected two emails, got %v", req.Emails)
+			}
+			w.Write([]byte(`{"results":[{"email":"a@example.com","result":"added","user_id":3},{"email":"b@example.com","result":"not_found"}]}`))
+		case r.Method == "DELETE" && r.URL.Path == "/api/v0/events/5/organizers/2":
+			removed = true
+			w.Write([]byte(`{"message":"Organizer removed"}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	client := newTestClient(srv.URL)
+
+	organizers, err := client.ListOrganizers(5)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if len(organizers) != 1 || !organizers[0].IsCreator {
+		t.Errorf("unexpected organizers: %+v", organizers)
+	}
+
+	if err := client.AddOrganizer(5, "new@example.com"); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if !added {
+		t.Error("expected organizer to be added")
+	}
+
+	results, err := client.AddOrganizers(5, []string{"a@example.com", "b@example.com"})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if len(results) != 2 || results[0].Result != "added" || results[0].UserID != 3 || results[1].Result != "not_found" {
+		t.Errorf("unexpected results: %+v", results)
+	}
+
+	if err := client.RemoveOrganizer(5, 2); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if !removed {
+		t.Error("expected organizer to be removed")
+	}
+}
+
+func TestExportProposals(t *testing.T) {
+	csv := "Title,Speaker\nMy Talk,Jane\n"
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v0/events/5/proposals/export" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("format"); got != ExportFormatOnline {
+			t.Errorf("expected format %q, got %q", ExportFormatOnline, got)
+		}
+		w.Header().Set("Content-Type", "text/csv")
+		w.Write([]byte(csv))
+	}))
+	defer srv.Close()
+
+	var buf strings.Builder
+	if err := newTestClient(srv.URL).ExportProposals(5, ExportFormatOnline, &buf); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if buf.String() != csv {
+		t.Errorf("expected %q, got %q", csv, buf.String())
+	}
+}
+
+func TestGetDashboard(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v0/me/dashboard" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		w.Write([]byte(`{"events":[{"id":5,"name":"Conf","slug":"conf","cfp_status":"open","payment_status":"unpaid","created_at":"2026-02-26T00:00:00Z",
+			"proposal_counts":{"submitted":3,"accepted":1},"proposal_total":4,"unrated_count":2,
+			"organizers":[{"id":9,"name":"Ann","email":"ann@example.com"}]}],
+			"next_cfp_deadline":{"event_id":5,"name":"Conf","cfp_close_at":"2026-03-01T00:00:00Z"}}`))
+	}))
+	defer srv.Close()
+
+	dashboard, err := newTestClient(srv.URL).GetDashboard(MyEventsOptions{})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if len(dashboard.Events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(dashboard.Events))
+	}
+	e := dashboard.Events[0]
+	if e.ProposalCounts["submitted"] != 3 || e.UnratedCount != 2 || e.PaymentStatus != "unpaid" || len(e.Organizers) != 1 {
+		t.Errorf("unexpected event: %+v", e)
+	}
+	if dashboard.NextCFPDeadline == nil || dashboard.NextCFPDeadline.EventID != 5 {
+		t.Errorf("expected the next deadline, got %+v", dashboard.NextCFPDeadline)
+	}
+
+	var out strings.Builder
+	f := &Formatter{Format: FormatTable, Writer: &out, Now: func() time.Time { return time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC) }}
+	if err := f.PrintDashboard(dashboard); err != nil {
+		t.Fatalf("PrintDashboard: %v", err)
+	}
+	if !strings.Contains(out.String(), "conf") || !strings.Contains(out.String(), "3d ago") || !strings.Contains(out.String(), "Next CFP deadline: Conf") {
+		t.Errorf("unexpected table:\n%s", out.String())
+	}
+}
+
+func TestGetMyEvents_Options(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.RawQuery; got != "cfp_status=open&page=2&q=sreday" {
+			t.Errorf("unexpected query: %s", got)
+		}
+		w.Write([]byte(`{"managing":[{"id":1,"name":"SREday"}],"submitted":[],"pagination":{"page":2,"per_page":20,"total":21,"total_pages":2}}`))
+	}))
+	defer srv.Close()
+
+	resp, err := newTestClient(srv.URL).GetMyEvents(MyEventsOptions{Query: "sreday", CFPStatus: "open", Page: 2})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if len(resp.Managing) != 1 || resp.Pagination == nil || resp.Pagination.Total != 21 {
+		t.Errorf("unexpected response: %+v", resp)
+	}
+}
+
+func TestDoRequest_ErrorCarriesRetryAfter(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "90")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+
+	client := newTestClient(srv.URL)
+	client.MaxRetries = 0
+	_, err := client.GetMe()
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected an APIError, got: %v", err)
+	}
+	if apiErr.RetryAfter != 90*time.Second {
+		t.Errorf("expected RetryAfter 90s, got %s", apiErr.RetryAfter)
+	}
+}
+
+func TestNewClientWithConfig_ServerUnderPath(t *testing.T) {
+	var path string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		w.Write([]byte(`{"id":1,"email":"a@example.com"}`))
+	}))
+	defer srv.Close()
+
+	client := NewClientWithConfig(&Config{Server: srv.URL + "/cfp/", Token: "abc"})
+	if _, err := client.GetMe(); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if path != "/cfp/api/v0/auth/me" {
+		t.Errorf("expected the request under the server's path, got %s", path)
+	}
+
+	if got := BuildAuthURL("https://example.com/cfp/", 8085, "github"); got != "https://example.com/cfp/api/v0/auth/github?cli=true&redirect_port=8085" {
+		t.Errorf("unexpected auth URL %s", got)
+	}
+}
@@ -4,6 +4,9 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
@@ -15,13 +18,35 @@ const (
 	ConfigFileName      = "config.yaml"
 	ConfigDirPerm       = 0700
 	ConfigFilePerm      = 0600
+	DefaultProfile      = "default"
 )
 
-// Config holds the CLI configuration
+// Config holds the CLI configuration of a single profile
 type Config struct {
 	Server       string `yaml:"server,omitempty"`
 	Token        string `yaml:"token,omitempty"`
 	AuthProvider string `yaml:"auth_provider,omitempty"`
+	Output       string `yaml:"output,omitempty"`
+
+	// Profile is the name of the profile this config was loaded from
+	Profile string `yaml:"-"`
+}
+
+// configFile is the on-disk layout: the top-level fields are the default
+// profile, additional named profiles live under "profiles"
+type configFile struct {
+	Config          `yaml:",inline"`
+	CurrentProfile  string             `yaml:"current_profile,omitempty"`
+	Profiles        map[string]*Config `yaml:"profiles,omitempty"`
+	LastUpdateCheck time.Time          `yaml:"last_update_check,omitempty"`
+}
+
+// selectedProfile overrides the config file's current profile (set via --profile)
+var selectedProfile string
+
+// SelectProfile makes LoadConfig use the named profile instead of the current one
+func SelectProfile(name string) {
+	selectedProfile = name
 }
 
 // ConfigKey represents a valid configuration key
@@ -30,11 +55,12 @@ type ConfigKey string
 const (
 	ConfigKeyServer       ConfigKey = "server"
 	ConfigKeyAuthProvider ConfigKey = "auth_provider"
+	ConfigKeyOutput       ConfigKey = "output"
 )
 
 // ValidConfigKeys returns all valid configuration keys
 func ValidConfigKeys() []ConfigKey {
-	return []ConfigKey{ConfigKeyServer, ConfigKeyAuthProvider}
+	return []ConfigKey{ConfigKeyServer, ConfigKeyAuthProvider, ConfigKeyOutput}
 }
 
 // IsValidConfigKey checks if a key is a valid configuration key
@@ -60,6 +86,11 @@ func (c *Config) GetConfigValue(key string) (string, error) {
 			return DefaultAuthProvider, nil
 		}
 		return c.AuthProvider, nil
+	case ConfigKeyOutput:
+		if c.Output == "" {
+			return string(FormatTable), nil
+		}
+		return c.Output, nil
 	default:
 		return "", fmt.Errorf("unknown config key: %s", key)
 	}
@@ -69,12 +100,17 @@ func (c *Config) GetConfigValue(key string) (string, error) {
 func (c *Config) SetConfigValue(key, value string) error {
 	switch ConfigKey(key) {
 	case ConfigKeyServer:
-		c.Server = value
+		c.Server = NormalizeServer(value)
 	case ConfigKeyAuthProvider:
 		if value != "github" && value != "google" {
 			return fmt.Errorf("invalid auth_provider: %s (must be 'github' or 'google')", value)
 		}
 		c.AuthProvider = value
+	case ConfigKeyOutput:
+		if _, err := ParseOutputFormat(value); err != nil {
+			return err
+		}
+		c.Output = value
 	default:
 		return fmt.Errorf("unknown config key: %s", key)
 	}
@@ -89,6 +125,12 @@ func (c *Config) GetAuthProvider() string {
 	return c.AuthProvider
 }
 
+// NormalizeServer trims the trailing slash from a server URL, so API paths
+// can be appended to servers under a path such as https://example.com/cfp/
+func NormalizeServer(server string) string {
+	return strings.TrimRight(strings.TrimSpace(server), "/")
+}
+
 // GetServer returns the configured server or default
 func (c *Config) GetServer() string {
 	if c.Server == "" {
@@ -122,8 +164,8 @@ func configPath() (string, error) {
 	return filepath.Join(dir, ConfigFileName), nil
 }
 
-// LoadConfig loads the configuration from disk
-func LoadConfig() (*Config, error) {
+// loadConfigFile reads the whole config file, returning an empty one if it doesn't exist
+func loadConfigFile() (*configFile, error) {
 	path, err := configPath()
 	if err != nil {
 		return nil, err
@@ -132,27 +174,21 @@ func LoadConfig() (*Config, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
 		if os.IsNotExist(err) {
-			// Return default config if file doesn't exist
-			return &Config{Server: DefaultServer}, nil
+			return &configFile{}, nil
 		}
 		return nil, fmt.Errorf("failed to read config file: %w", err)
 	}
 
-	var cfg Config
-	if err := yaml.Unmarshal(data, &cfg); err != nil {
+	var f configFile
+	if err := yaml.Unmarshal(data, &f); err != nil {
 		return nil, fmt.Errorf("failed to parse config file: %w", err)
 	}
 
-	// Set default server if not specified
-	if cfg.Server == "" {
-		cfg.Server = DefaultServer
-	}
-
-	return &cfg, nil
+	return &f, nil
 }
 
-// SaveConfig saves the configuration to disk
-func SaveConfig(cfg *Config) error {
+// saveConfigFile writes the whole config file with restricted permissions
+func saveConfigFile(f *configFile) error {
 	dir, err := configDir()
 	if err != nil {
 		return err
@@ -168,7 +204,7 @@ func SaveConfig(cfg *Config) error {
 		return err
 	}
 
-	data, err := yaml.Marshal(cfg)
+	data, err := yaml.Marshal(f)
 	if err != nil {
 		return fmt.Errorf("failed to marshal config: %w", err)
 	}
@@ -181,8 +217,94 @@ func SaveConfig(cfg *Config) error {
 	return nil
 }
 
-// ClearConfig removes the stored credentials
+// activeProfile returns the profile name in effect: --profile, then current_profile, then default
+func (f *configFile) activeProfile() string {
+	if selectedProfile != "" {
+		return selectedProfile
+	}
+	if f.CurrentProfile != "" {
+		return f.CurrentProfile
+	}
+	return DefaultProfile
+}
+
+// profile returns the config stored for a profile name, or nil if it doesn't exist
+func (f *configFile) profile(name string) *Config {
+	if name == DefaultProfile {
+		return &f.Config
+	}
+	return f.Profiles[name]
+}
+
+// setProfile stores cfg as the named profile
+func (f *configFile) setProfile(name string, cfg *Config) {
+	if name == DefaultProfile {
+		f.Config = *cfg
+		return
+	}
+	if f.Profiles == nil {
+		f.Profiles = make(map[string]*Config)
+	}
+	f.Profiles[name] = cfg
+}
+
+// LoadConfig loads the active profile's configuration from disk.
+// A profile that doesn't exist yet loads as an empty (logged out) config.
+func LoadConfig() (*Config, error) {
+	f, err := loadConfigFile()
+	if err != nil {
+		return nil, err
+	}
+
+	name := f.activeProfile()
+	var cfg Config
+	if p := f.profile(name); p != nil {
+		cfg = *p
+	}
+	cfg.Profile = name
+
+	// Set default server if not specified
+	cfg.Server = NormalizeServer(cfg.Server)
+	if cfg.Server == "" {
+		cfg.Server = DefaultServer
+	}
+
+	return &cfg, nil
+}
+
+// SaveConfig saves the configuration to disk under the profile it was loaded from
+func SaveConfig(cfg *Config) error {
+	f, err := loadConfigFile()
+	if err != nil {
+		return err
+	}
+
+	name := cfg.Profile
+	if name == "" {
+		name = f.activeProfile()
+	}
+	f.setProfile(name, cfg)
+
+	return saveConfigFile(f)
+}
+
+// ClearConfig removes the stored credentials of the active profile,
+// deleting the config file once nothing else is left in it
 func ClearConfig() error {
+	f, err := loadConfigFile()
+	if err != nil {
+		return err
+	}
+
+	name := f.activeProfile()
+	if p := f.profile(name); p != nil {
+		p.Token = ""
+	}
+
+	if name != DefaultProfile || len(f.Profiles) > 0 {
+		return saveConfigFile(f)
+	}
+
 	path, err := configPath()
 	if err != nil {
 		return err
@@ -195,6 +317,60 @@ func ClearConfig() error {
 	return nil
 }
 
+// ListProfiles returns the names of all profiles and the active one
+func ListProfiles() ([]string, string, error) {
+	f, err := loadConfigFile()
+	if err != nil {
+		return nil, "", err
+	}
+
+	names := []string{DefaultProfile}
+	for name := range f.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names[1:])
+
+	return names, f.activeProfile(), nil
+}
+
+// UseProfile makes the named profile the default for future commands
+func UseProfile(name string) error {
+	f, err := loadConfigFile()
+	if err != nil {
+		return err
+	}
+
+	if f.profile(name) == nil {
+		return fmt.Errorf("unknown profile: %s", name)
+	}
+
+	f.CurrentProfile = name
+	if name == DefaultProfile {
+		f.CurrentProfile = ""
+	}
+
+	return saveConfigFile(f)
+}
+
+// LastUpdateCheck returns when the CLI last checked the server for a newer version
+func LastUpdateCheck() (time.Time, error) {
+	f, err := loadConfigFile()
+	if err != nil {
+		return time.Time{}, err
+	}
+	return f.LastUpdateCheck, nil
+}
+
+// RecordUpdateCheck stores the time of the latest CLI version check
+func RecordUpdateCheck(t time.Time) error {
+	f, err := loadConfigFile()
+	if err != nil {
+		return err
+	}
+	f.LastUpdateCheck = t
+	return saveConfigFile(f)
+}
+
 // IsLoggedIn returns true if the user has a stored token
 func (c *Config) IsLoggedIn() bool {
 	return c.Token != ""
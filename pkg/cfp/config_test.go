@@ -0,0 +1,84 @@
+package cfp
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestConfig_LegacyFileLoadsAsDefaultProfile(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	SelectProfile("")
+
+	path, _ := configPath()
+	os.MkdirAll(filepath.Dir(path), ConfigDirPerm)
+	if err := os.WriteFile(path, []byte("server: https://example.com\ntoken: abc\n"), ConfigFilePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if cfg.Profile != DefaultProfile || cfg.Server != "https://example.com" || cfg.Token != "abc" {
+		t.Errorf("unexpected config: %+v", cfg)
+	}
+}
+
+func TestConfig_NamedProfiles(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	defer SelectProfile("")
+
+	SelectProfile("")
+	if err := SaveConfig(&Config{Server: DefaultServer, Token: "prod-token"}); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	SelectProfile("staging")
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if cfg.IsLoggedIn() {
+		t.Error("expected new profile to be logged out")
+	}
+	cfg.Server = "https://staging.example.com"
+	cfg.Token = "staging-token"
+	if err := SaveConfig(cfg); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	SelectProfile("")
+	cfg, _ = LoadConfig()
+	if cfg.Token != "prod-token" {
+		t.Errorf("expected default profile token to be untouched, got %q", cfg.Token)
+	}
+
+	if err := UseProfile("staging"); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	cfg, _ = LoadConfig()
+	if cfg.Profile != "staging" || cfg.Server != "https://staging.example.com" {
+		t.Errorf("expected staging profile to be active, got %+v", cfg)
+	}
+
+	names, active, err := ListProfiles()
+	if err != nil || active != "staging" || strings.Join(names, ",") != "default,staging" {
+		t.Errorf("unexpected profiles: %v active=%q err=%v", names, active, err)
+	}
+
+	if err := UseProfile("missing"); err == nil {
+		t.Error("expected error for unknown profile")
+	}
+
+	// Logging out of staging keeps the default profile's credentials
+	if err := ClearConfig(); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	SelectProfile(DefaultProfile)
+	cfg, _ = LoadConfig()
+	if cfg.Token != "prod-token" {
+		t.Errorf("expected default profile token to survive logout of staging, got %q", cfg.Token)
+	}
+}
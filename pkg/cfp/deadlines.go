@@ -0,0 +1,67 @@
+package cfp
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Deadline is an open CFP's closing time, as listed by `cfp events --deadlines`
+type Deadline struct {
+	// Date is the closing day in the user's time zone, YYYY-MM-DD
+	Date       string    `json:"date" yaml:"date"`
+	CFPCloseAt time.Time `json:"cfp_close_at" yaml:"cfp_close_at"`
+	Slug       string    `json:"slug" yaml:"slug"`
+	Name       string    `json:"name" yaml:"name"`
+}
+
+// DeadlineFields are the event fields Deadlines needs
+var DeadlineFields = []string{"slug", "name", "cfp_close_at"}
+
+// Deadlines returns the deadlines of the events whose CFP closes after now,
+// soonest first. With within > 0, only CFPs closing in the next within days
+// are kept. Dates and the cutoff are computed in loc, so a CFP closing at
+// 02:00 UTC on the 1st is dated the 31st in New York.
+func Deadlines(events []Event, now time.Time, within int, loc *time.Location) []Deadline {
+	now = now.In(loc)
+	var cutoff time.Time
+	if within > 0 {
+		cutoff = now.AddDate(0, 0, within)
+	}
+
+	var deadlines []Deadline
+	for _, e := range events {
+		if e.CFPCloseAt.IsZero() || !e.CFPCloseAt.After(now) {
+			continue
+		}
+		if !cutoff.IsZero() && e.CFPCloseAt.After(cutoff) {
+			continue
+		}
+		closeAt := e.CFPCloseAt.In(loc)
+		deadlines = append(deadlines, Deadline{
+			Date:       closeAt.Format("2006-01-02"),
+			CFPCloseAt: closeAt,
+			Slug:       e.Slug,
+			Name:       e.Name,
+		})
+	}
+	sort.SliceStable(deadlines, func(i, j int) bool {
+		return deadlines[i].CFPCloseAt.Before(deadlines[j].CFPCloseAt)
+	})
+	return deadlines
+}
+
+// WriteDeadlines writes one "YYYY-MM-DD<TAB>slug<TAB>name" line per deadline.
+// Tabs and line breaks in names are replaced with spaces so every deadline
+// stays on one line with three columns.
+func WriteDeadlines(w io.Writer, deadlines []Deadline) error {
+	clean := strings.NewReplacer("\t", " ", "\r", " ", "\n", " ")
+	for _, d := range deadlines {
+		if _, err := fmt.Fprintf(w, "%s\t%s\t%s\n", d.Date, d.Slug, clean.Replace(d.Name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package cfp
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestDeadlines_SortsAndFilters(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	events := []Event{
+		{Slug: "later", Name: "Later", CFPCloseAt: now.AddDate(0, 0, 20)},
+		{Slug: "closed", Name: "Closed", CFPCloseAt: now.Add(-time.Hour)},
+		{Slug: "no-deadline", Name: "No deadline"},
+		{Slug: "soon", Name: "Soon", CFPCloseAt: now.AddDate(0, 0, 2)},
+		{Slug: "far", Name: "Far", CFPCloseAt: now.AddDate(0, 2, 0)},
+	}
+
+	got := Deadlines(events, now, 0, time.UTC)
+	if len(got) != 3 || got[0].Slug != "soon" || got[1].Slug != "later" || got[2].Slug != "far" {
+		t.Fatalf("expected soon, later, far, got %+v", got)
+	}
+
+	got = Deadlines(events, now, 30, time.UTC)
+	if len(got) != 2 || got[1].Slug != "later" {
+		t.Fatalf("expected only the next 30 days, got %+v", got)
+	}
+}
+
+func TestDeadlines_TimeZones(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+
+	// 02:00 UTC on April 1st is still March 31st in New York and already
+	// 11:00 on April 1st in Tokyo
+	closeAt := time.Date(2026, 4, 1, 2, 0, 0, 0, time.UTC)
+	events := []Event{{Slug: "conf", Name: "Conf", CFPCloseAt: closeAt}}
+	now := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	for loc, want := range map[*time.Location]string{
+		time.UTC: "2026-04-01",
+		newYork:  "2026-03-31",
+		tokyo:    "2026-04-01",
+	} {
+		got := Deadlines(events, now, 0, loc)
+		if len(got) != 1 || got[0].Date != want {
+			t.Errorf("%s: expected %s, got %+v", loc, want, got)
+			continue
+		}
+		if !got[0].CFPCloseAt.Equal(closeAt) {
+			t.Errorf("%s: expected the same instant, got %s", loc, got[0].CFPCloseAt)
+		}
+	}
+
+	// A CFP with an offset timestamp is compared as an instant: 23:00-05:00
+	// is 04:00 UTC the next day, inside a 1-day window from 05:00 UTC
+	offset := time.FixedZone("", -5*3600)
+	events = []Event{{Slug: "conf", Name: "Conf", CFPCloseAt: time.Date(2026, 3, 1, 23, 0, 0, 0, offset)}}
+	now = time.Date(2026, 3, 1, 5, 0, 0, 0, time.UTC)
+	if got := Deadlines(events, now, 1, time.UTC); len(got) != 1 || got[0].Date != "2026-03-02" {
+		t.Errorf("expected the deadline dated 2026-03-02 UTC, got %+v", got)
+	}
+}
+
+func TestWriteDeadlines(t *testing.T) {
+	var buf bytes.Buffer
+	deadlines := []Deadline{
+		{Date: "2026-03-31", Slug: "conf", Name: "Conf\t2026\nEdition"},
+		{Date: "2026-04-02", Slug: "other", Name: "Other"},
+	}
+	if err := WriteDeadlines(&buf, deadlines); err != nil {
+		t.Fatal(err)
+	}
+	want := "2026-03-31\tconf\tConf 2026 Edition\n2026-04-02\tother\tOther\n"
+	if buf.String() != want {
+		t.Errorf("got %q, want %q", buf.String(), want)
+	}
+}
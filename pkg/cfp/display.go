@@ -0,0 +1,153 @@
+package cfp
+
+import (
+	"fmt"
+	"os"
+	"time"
+	"unicode/utf8"
+
+	"golang.org/x/term"
+)
+
+// ClosingSoon is how close a CFP deadline has to be for an open CFP to be
+// shown as closing soon
+const ClosingSoon = 7 * 24 * time.Hour
+
+// ANSI SGR codes for CFP statuses. They all have the same length, so a
+// column where every cell is wrapped in one stays aligned by tabwriter,
+// which counts the escape bytes as text.
+const (
+	colorReset = "\x1b[0m"
+	colorGreen = "\x1b[32m"
+	colorAmber = "\x1b[33m"
+	colorDim   = "\x1b[02m"
+	colorNone  = "\x1b[00m"
+)
+
+// TerminalWidth returns the width of the terminal f writes to, or 0 when f
+// is not a terminal
+func TerminalWidth(f *os.File) int {
+	if !term.IsTerminal(int(f.Fd())) {
+		return 0
+	}
+	width, _, err := term.GetSize(int(f.Fd()))
+	if err != nil {
+		return 0
+	}
+	return width
+}
+
+// ColorEnabled reports whether to colorize output written to f: only for
+// terminals, and never when NO_COLOR is set (https://no-color.org) or TERM
+// is dumb
+func ColorEnabled(f *os.File) bool {
+	if os.Getenv("NO_COLOR") != "" || os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	return term.IsTerminal(int(f.Fd()))
+}
+
+// formatDate renders a date for tables: relative to now, e.g. "in 12d" or
+// "3d ago", unless the formatter shows absolute dates
+func (f *Formatter) formatDate(t time.Time) string {
+	if f.AbsoluteDates {
+		return t.Format("Jan 2, 2006")
+	}
+	return relativeTime(t, f.now())
+}
+
+// formatOptionalDate is formatDate, or "-" for times older servers don't send
+func (f *Formatter) formatOptionalDate(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return f.formatDate(t)
+}
+
+// formatTime renders a time for detail views: absolute, followed by the
+// relative time unless the formatter shows absolute dates only
+func (f *Formatter) formatTime(t time.Time) string {
+	s := t.Format("Jan 2, 2006 15:04 MST")
+	if f.AbsoluteDates {
+		return s
+	}
+	return s + " (" + relativeTime(t, f.now()) + ")"
+}
+
+func (f *Formatter) now() time.Time {
+	if f.Now != nil {
+		return f.Now()
+	}
+	return time.Now()
+}
+
+// relativeTime describes t relative to now in the largest whole unit, e.g.
+// "in 12d", "3h ago" or "now"
+func relativeTime(t, now time.Time) string {
+	d := t.Sub(now)
+	past := d < 0
+	if past {
+		d = -d
+	}
+
+	var s string
+	switch {
+	case d < time.Minute:
+		return "now"
+	case d < time.Hour:
+		s = fmt.Sprintf("%dm", int(d/time.Minute))
+	case d < 24*time.Hour:
+		s = fmt.Sprintf("%dh", int(d/time.Hour))
+	default:
+		s = fmt.Sprintf("%dd", int(d/(24*time.Hour)))
+	}
+	if past {
+		return s + " ago"
+	}
+	return "in " + s
+}
+
+// cfpStatus renders a CFP status, colored when the formatter uses color:
+// open green, open but closing within ClosingSoon amber, closed dim
+func (f *Formatter) cfpStatus(status string, closeAt time.Time) string {
+	if !f.Color {
+		return status
+	}
+	color := colorNone
+	switch status {
+	case "open":
+		color = colorGreen
+		if !closeAt.IsZero() && closeAt.Sub(f.now()) < ClosingSoon {
+			color = colorAmber
+		}
+	case "closed":
+		color = colorDim
+	}
+	return color + status + colorReset
+}
+
+// header renders the header of a column colored by cfpStatus, wrapped in an
+// escape sequence of the same length as the cells so the column stays aligned
+func (f *Formatter) header(title string) string {
+	if !f.Color {
+		return title
+	}
+	return colorNone + title + colorReset
+}
+
+// truncate shortens s to at most max characters, ending it with an ellipsis
+// when anything was cut. It counts and cuts runes, never bytes, so multi-byte
+// characters are kept whole.
+func truncate(s string, max int) string {
+	if max <= 0 {
+		return ""
+	}
+	if utf8.RuneCountInString(s) <= max {
+		return s
+	}
+	runes := []rune(s)
+	if max == 1 {
+		return string(runes[:1])
+	}
+	return string(runes[:max-1]) + "…"
+}
@@ -0,0 +1,111 @@
+package cfp
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		in   string
+		max  int
+		want string
+	}{
+		{"GopherCon", 20, "GopherCon"},
+		{"GopherCon", 9, "GopherCon"},
+		{"GopherCon Europe", 10, "GopherCon…"},
+		{"Zürich Ünconference", 8, "Zürich …"},
+		{"東京Goカンファレンス2026", 6, "東京Goカ…"},
+		{"🦫🦫🦫🦫", 3, "🦫🦫…"},
+		{"Café", 1, "C"},
+		{"Café", 0, ""},
+	}
+	for _, tt := range tests {
+		got := truncate(tt.in, tt.max)
+		if got != tt.want {
+			t.Errorf("truncate(%q, %d) = %q, want %q", tt.in, tt.max, got, tt.want)
+		}
+		if !utf8.ValidString(got) {
+			t.Errorf("truncate(%q, %d) split a character: %q", tt.in, tt.max, got)
+		}
+	}
+}
+
+func TestRelativeTime(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		t    time.Time
+		want string
+	}{
+		{now.Add(30 * time.Second), "now"},
+		{now.Add(45 * time.Minute), "in 45m"},
+		{now.Add(-5 * time.Hour), "5h ago"},
+		{now.AddDate(0, 0, 12).Add(3 * time.Hour), "in 12d"},
+		{now.AddDate(0, 0, -3), "3d ago"},
+	}
+	for _, tt := range tests {
+		if got := relativeTime(tt.t, now); got != tt.want {
+			t.Errorf("relativeTime(%s) = %q, want %q", tt.t, got, tt.want)
+		}
+	}
+}
+
+func TestPrintEvents_DatesColorAndWidth(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	events := []Event{
+		{Slug: "soon", Name: "Closing Soon Conference", Location: "Zürich", Country: "CH", CFPStatus: "open", CFPCloseAt: now.AddDate(0, 0, 2)},
+		{Slug: "later", Name: "Later Conf", CFPStatus: "open", CFPCloseAt: now.AddDate(0, 0, 40)},
+		{Slug: "done", Name: "Done Conf", CFPStatus: "closed", CFPCloseAt: now.AddDate(0, 0, -3)},
+	}
+
+	var out strings.Builder
+	f := &Formatter{Format: FormatTable, Writer: &out, Now: func() time.Time { return now }}
+	if err := f.PrintEvents(events); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"in 2d", "in 40d", "3d ago"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("expected %q in:\n%s", want, out.String())
+		}
+	}
+	if strings.Contains(out.String(), "\x1b[") {
+		t.Errorf("expected no color codes without Color:\n%s", out.String())
+	}
+
+	out.Reset()
+	f.AbsoluteDates, f.Color = true, true
+	if err := f.PrintEvents(events); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"Mar 3, 2026", colorAmber + "open", colorGreen + "open", colorDim + "closed"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("expected %q in:\n%q", want, out.String())
+		}
+	}
+
+	// Colored cells are padded alike, so the status column stays aligned
+	column := func(line string) int { return utf8.RuneCountInString(line[:strings.Index(line, "\x1b[")]) }
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	for _, line := range lines[1:] {
+		if column(line) != column(lines[0]) {
+			t.Errorf("status column misaligned:\n%q", out.String())
+			break
+		}
+	}
+
+	out.Reset()
+	f.AbsoluteDates, f.Color, f.Width = false, false, 60
+	if err := f.PrintEvents(events); err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n") {
+		if n := utf8.RuneCountInString(strings.TrimRight(line, " ")); n > 60 {
+			t.Errorf("line is %d characters, wider than the terminal: %q", n, line)
+		}
+	}
+	if !strings.Contains(out.String(), "…") {
+		t.Errorf("expected long names cut with an ellipsis:\n%s", out.String())
+	}
+}
@@ -0,0 +1,154 @@
+package cfp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Error codes returned by the server alongside error messages
+const (
+	ErrorCodeValidation      = "validation"
+	ErrorCodeUnauthorized    = "unauthorized"
+	ErrorCodePaymentRequired = "payment_required"
+	ErrorCodeForbidden       = "forbidden"
+	ErrorCodeNotFound        = "not_found"
+	ErrorCodeConflict        = "conflict"
+	ErrorCodeRateLimited     = "rate_limited"
+
+	// More specific codes, each a refinement of one of the above
+	ErrorCodeInvalidBody   = "invalid_body"
+	ErrorCodeSlugConflict  = "slug_conflict"
+	ErrorCodeCFPClosed     = "cfp_closed"
+	ErrorCodeProposalLimit = "proposal_limit"
+)
+
+// Sentinel errors matched by APIError via errors.Is
+var (
+	ErrValidation      = errors.New("validation failed")
+	ErrUnauthorized    = errors.New("unauthorized")
+	ErrPaymentRequired = errors.New("payment required")
+	ErrForbidden       = errors.New("forbidden")
+	ErrNotFound        = errors.New("not found")
+	ErrConflict        = errors.New("conflict")
+	ErrRateLimited     = errors.New("rate limited")
+)
+
+// APIError represents an error response from the API
+type APIError struct {
+	Message    string
+	StatusCode int
+	// Code is the machine-readable error code; derived from StatusCode for
+	// servers that do not send one
+	Code string
+	// Fields maps field names to validation messages, when the server reports them
+	Fields map[string]string
+	// RetryAfter is how long the server asked the client to wait, from the
+	// Retry-After header; 0 when it didn't say
+	RetryAfter time.Duration
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("API error (%d): %s", e.StatusCode, e.Message)
+}
+
+// Is matches the sentinel error corresponding to the error code,
+// so errors.Is(err, ErrNotFound) works on wrapped API errors
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrValidation:
+		return e.Code == ErrorCodeValidation || e.Code == ErrorCodeInvalidBody
+	case ErrUnauthorized:
+		return e.Code == ErrorCodeUnauthorized
+	case ErrPaymentRequired:
+		return e.Code == ErrorCodePaymentRequired
+	case ErrForbidden:
+		return e.Code == ErrorCodeForbidden
+	case ErrNotFound:
+		return e.Code == ErrorCodeNotFound
+	case ErrConflict:
+		return e.Code == ErrorCodeConflict || e.Code == ErrorCodeSlugConflict
+	case ErrRateLimited:
+		return e.Code == ErrorCodeRateLimited
+	}
+	return false
+}
+
+// IsValidation reports whether err is an API validation error
+func IsValidation(err error) bool { return errors.Is(err, ErrValidation) }
+
+// IsUnauthorized reports whether err is an API authentication error
+func IsUnauthorized(err error) bool { return errors.Is(err, ErrUnauthorized) }
+
+// IsPaymentRequired reports whether err is an API payment required error
+func IsPaymentRequired(err error) bool { return errors.Is(err, ErrPaymentRequired) }
+
+// IsForbidden reports whether err is an API permission error
+func IsForbidden(err error) bool { return errors.Is(err, ErrForbidden) }
+
+// IsNotFound reports whether err is an API not found error
+func IsNotFound(err error) bool { return errors.Is(err, ErrNotFound) }
+
+// IsConflict reports whether err is an API conflict error
+func IsConflict(err error) bool { return errors.Is(err, ErrConflict) }
+
+// IsRateLimited reports whether err is an API rate limit error
+func IsRateLimited(err error) bool { return errors.Is(err, ErrRateLimited) }
+
+// parseAPIError builds an APIError from an error response body. It accepts the
+// flat {"error": "...", "code": "..."} shape, the structured
+// {"error": {"code": "...", "message": "...", "fields": {...}}} shape, and
+// non-JSON bodies.
+func parseAPIError(statusCode int, body []byte) *APIError {
+	apiErr := &APIError{Message: string(body), StatusCode: statusCode}
+
+	var payload struct {
+		Error json.RawMessage `json:"error"`
+		Code  string          `json:"code"`
+	}
+	if err := json.Unmarshal(body, &payload); err == nil && len(payload.Error) > 0 {
+		var message string
+		var structured struct {
+			Code    string            `json:"code"`
+			Message string            `json:"message"`
+			Fields  map[string]string `json:"fields"`
+		}
+		if err := json.Unmarshal(payload.Error, &message); err == nil && message != "" {
+			apiErr.Message = message
+			apiErr.Code = payload.Code
+		} else if err := json.Unmarshal(payload.Error, &structured); err == nil && structured.Message != "" {
+			apiErr.Message = structured.Message
+			apiErr.Code = structured.Code
+			apiErr.Fields = structured.Fields
+		}
+	}
+
+	if apiErr.Code == "" {
+		apiErr.Code = errorCodeForStatus(statusCode)
+	}
+	return apiErr
+}
+
+// errorCodeForStatus derives an error code from the HTTP status, for servers
+// that only return an error message
+func errorCodeForStatus(statusCode int) string {
+	switch statusCode {
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return ErrorCodeValidation
+	case http.StatusUnauthorized:
+		return ErrorCodeUnauthorized
+	case http.StatusPaymentRequired:
+		return ErrorCodePaymentRequired
+	case http.StatusForbidden:
+		return ErrorCodeForbidden
+	case http.StatusNotFound:
+		return ErrorCodeNotFound
+	case http.StatusConflict:
+		return ErrorCodeConflict
+	case http.StatusTooManyRequests:
+		return ErrorCodeRateLimited
+	}
+	return ""
+}
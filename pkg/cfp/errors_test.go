@@ -0,0 +1,95 @@
+package cfp
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestParseAPIError(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		body        string
+		wantMessage string
+		wantCode    string
+		wantFields  int
+	}{
+		{
+			name:        "legacy message only",
+			status:      http.StatusPaymentRequired,
+			body:        `{"error":"Event listing must be paid before opening CFP"}`,
+			wantMessage: "Event listing must be paid before opening CFP",
+			wantCode:    ErrorCodePaymentRequired,
+		},
+		{
+			name:        "message with code",
+			status:      http.StatusBadRequest,
+			body:        `{"error":"Slug already exists","code":"conflict"}`,
+			wantMessage: "Slug already exists",
+			wantCode:    ErrorCodeConflict,
+		},
+		{
+			name:        "structured envelope",
+			status:      http.StatusBadRequest,
+			body:        `{"error":{"code":"validation","message":"Invalid event","fields":{"name":"is required","slug":"is too long"}}}`,
+			wantMessage: "Invalid event",
+			wantCode:    ErrorCodeValidation,
+			wantFields:  2,
+		},
+		{
+			name:        "non-JSON body",
+			status:      http.StatusNotFound,
+			body:        "404 page not found",
+			wantMessage: "404 page not found",
+			wantCode:    ErrorCodeNotFound,
+		},
+		{
+			name:        "unknown status",
+			status:      http.StatusInternalServerError,
+			body:        `{"error":"boom"}`,
+			wantMessage: "boom",
+			wantCode:    "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := parseAPIError(tt.status, []byte(tt.body))
+			if err.StatusCode != tt.status {
+				t.Errorf("expected status %d, got %d", tt.status, err.StatusCode)
+			}
+			if err.Message != tt.wantMessage {
+				t.Errorf("expected message %q, got %q", tt.wantMessage, err.Message)
+			}
+			if err.Code != tt.wantCode {
+				t.Errorf("expected code %q, got %q", tt.wantCode, err.Code)
+			}
+			if len(err.Fields) != tt.wantFields {
+				t.Errorf("expected %d field errors, got %d", tt.wantFields, len(err.Fields))
+			}
+		})
+	}
+}
+
+func TestAPIError_Helpers(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusPaymentRequired)
+		w.Write([]byte(`{"error":"Event listing must be paid before opening CFP","code":"payment_required"}`))
+	}))
+	defer srv.Close()
+
+	_, err := newTestClient(srv.URL).CreateEvent(&EventSubmission{Name: "Conf", Slug: "conf"})
+	wrapped := fmt.Errorf("failed to create event: %w", err)
+
+	if !IsPaymentRequired(wrapped) {
+		t.Errorf("expected IsPaymentRequired to match, got: %v", wrapped)
+	}
+	if IsValidation(wrapped) || IsNotFound(wrapped) || IsConflict(wrapped) {
+		t.Errorf("expected only IsPaymentRequired to match, got: %v", wrapped)
+	}
+	if IsNotFound(nil) {
+		t.Error("expected IsNotFound(nil) to be false")
+	}
+}
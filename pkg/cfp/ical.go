@@ -0,0 +1,108 @@
+package cfp
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const icsTimeFormat = "20060102T150405Z"
+
+// EventURL returns the public page URL of an event on a server
+func EventURL(server, slug string) string {
+	return strings.TrimRight(server, "/") + "/e/" + url.PathEscape(slug)
+}
+
+// WriteICS writes an iCalendar (RFC 5545) feed with one entry per event CFP deadline.
+// Events without a CFP close date are skipped. server is used for event links and UIDs.
+func WriteICS(w io.Writer, events []Event, server string) error {
+	host := server
+	if u, err := url.Parse(server); err == nil && u.Host != "" {
+		host = u.Host
+	}
+	now := time.Now().UTC().Format(icsTimeFormat)
+
+	var b strings.Builder
+	writeICSLine(&b, "BEGIN:VCALENDAR")
+	writeICSLine(&b, "VERSION:2.0")
+	writeICSLine(&b, "PRODID:-//CFP.ninja//cfp CLI//EN")
+	writeICSLine(&b, "CALSCALE:GREGORIAN")
+	writeICSLine(&b, "METHOD:PUBLISH")
+	writeICSLine(&b, "X-WR-CALNAME:CFP deadlines")
+
+	for _, e := range events {
+		if e.CFPCloseAt.IsZero() {
+			continue
+		}
+		closeAt := e.CFPCloseAt.UTC()
+		link := EventURL(server, e.Slug)
+
+		description := "CFP for " + e.Name + " closes."
+		if e.Location != "" {
+			description += "\nLocation: " + e.Location
+		}
+		if !e.StartDate.IsZero() {
+			description += "\nEvent starts: " + e.StartDate.Format("Jan 2, 2006")
+		}
+		description += "\n" + link
+
+		writeICSLine(&b, "BEGIN:VEVENT")
+		writeICSLine(&b, "UID:cfp-close-"+e.Slug+"@"+host)
+		writeICSLine(&b, "DTSTAMP:"+now)
+		writeICSLine(&b, "DTSTART:"+closeAt.Format(icsTimeFormat))
+		writeICSLine(&b, "DTEND:"+closeAt.Format(icsTimeFormat))
+		writeICSLine(&b, "SUMMARY:"+escapeICSText("CFP closes: "+e.Name))
+		writeICSLine(&b, "DESCRIPTION:"+escapeICSText(description))
+		writeICSLine(&b, "URL:"+link)
+		writeICSLine(&b, "BEGIN:VALARM")
+		writeICSLine(&b, "ACTION:DISPLAY")
+		writeICSLine(&b, "DESCRIPTION:"+escapeICSText("CFP for "+e.Name+" closes tomorrow"))
+		writeICSLine(&b, "TRIGGER:-P1D")
+		writeICSLine(&b, "END:VALARM")
+		writeICSLine(&b, "END:VEVENT")
+	}
+
+	writeICSLine(&b, "END:VCALENDAR")
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Errorf("failed to write calendar: %w", err)
+	}
+	return nil
+}
+
+// escapeICSText escapes a TEXT value per RFC 5545 section 3.3.11
+func escapeICSText(s string) string {
+	r := strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	)
+	return r.Replace(s)
+}
+
+// writeICSLine writes a content line, folding it at 75 octets as RFC 5545 requires.
+// Folds never split a multi-byte UTF-8 character.
+func writeICSLine(b *strings.Builder, line string) {
+	// Continuation lines start with a space, which counts towards the limit
+	maxLen := 75
+	for len(line) > maxLen {
+		cut := maxLen
+		for cut > 0 && !isUTF8Start(line[cut]) {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		maxLen = 74
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+}
+
+func isUTF8Start(c byte) bool {
+	return c&0xC0 != 0x80
+}
@@ -0,0 +1,57 @@
+package cfp
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWriteICS(t *testing.T) {
+	events := []Event{
+		{Name: "GopherCon, EU; 2026", Slug: "gophercon-eu", Location: "Berlin", CFPCloseAt: time.Date(2026, 3, 1, 23, 59, 0, 0, time.UTC)},
+		{Name: "No Deadline", Slug: "no-deadline"},
+	}
+
+	var b strings.Builder
+	if err := WriteICS(&b, events, "https://cfp.ninja"); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	out := b.String()
+
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"UID:cfp-close-gophercon-eu@cfp.ninja\r\n",
+		"DTSTART:20260301T235900Z\r\n",
+		`SUMMARY:CFP closes: GopherCon\, EU\; 2026` + "\r\n",
+		"URL:https://cfp.ninja/e/gophercon-eu\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "no-deadline") {
+		t.Error("expected events without a CFP close date to be skipped")
+	}
+	if n := strings.Count(out, "BEGIN:VEVENT"); n != 1 {
+		t.Errorf("expected 1 VEVENT, got %d", n)
+	}
+}
+
+func TestWriteICSLine_Folds(t *testing.T) {
+	var b strings.Builder
+	writeICSLine(&b, "DESCRIPTION:"+strings.Repeat("é", 100))
+
+	for _, line := range strings.Split(strings.TrimSuffix(b.String(), "\r\n"), "\r\n") {
+		if len(line) > 75 {
+			t.Errorf("expected folded lines of at most 75 octets, got %d", len(line))
+		}
+		if !strings.HasPrefix(line, "DESCRIPTION:") && !strings.HasPrefix(line, " ") {
+			t.Errorf("expected continuation line to start with a space: %q", line)
+		}
+	}
+	unfolded := strings.ReplaceAll(b.String(), "\r\n ", "")
+	if unfolded != "DESCRIPTION:"+strings.Repeat("é", 100)+"\r\n" {
+		t.Error("expected unfolding to restore the original line")
+	}
+}
@@ -90,7 +90,7 @@ func StartOAuthServer() (*OAuthServer, error) {
 
 // BuildAuthURL constructs the OAuth initiation URL
 func BuildAuthURL(server string, port int, provider string) string {
-	return fmt.Sprintf("%s/api/v0/auth/%s?cli=true&redirect_port=%d", server, provider, port)
+	return fmt.Sprintf("%s/api/v0/auth/%s?cli=true&redirect_port=%d", NormalizeServer(server), provider, port)
 }
 
 // WaitForToken waits for the OAuth callback or timeout
@@ -8,6 +8,7 @@ import (
 	"strings"
 	"text/tabwriter"
 	"time"
+	"unicode/utf8"
 
 	"gopkg.in/yaml.v3"
 )
@@ -39,6 +40,16 @@ func ParseOutputFormat(s string) (OutputFormat, error) {
 type Formatter struct {
 	Format OutputFormat
 	Writer io.Writer
+
+	// AbsoluteDates shows dates in tables as "Jan 2, 2006" instead of
+	// relative to now, e.g. "in 12d"
+	AbsoluteDates bool
+	// Color colorizes CFP statuses in tables
+	Color bool
+	// Width is the terminal width tables are narrowed to fit, 0 for no limit
+	Width int
+	// Now returns the time relative dates are computed from (default time.Now)
+	Now func() time.Time
 }
 
 // NewFormatter creates a new formatter with the given format
@@ -89,12 +100,14 @@ func (f *Formatter) PrintEvents(events []Event) error {
 			return nil
 		}
 
-		w := tabwriter.NewWriter(f.Writer, 0, 0, 2, ' ', 0)
-		fmt.Fprintln(w, "SLUG\tNAME\tLOCATION\tCFP STATUS\tCFP CLOSES")
-		for _, e := range events {
+		type row struct{ slug, name, location, mode, status, closes string }
+		rows := make([]row, len(events))
+		// Widths of the fixed columns, headers included
+		used := []int{len("SLUG"), len("MODE"), len("CFP STATUS"), len("CFP CLOSES")}
+		for i, e := range events {
 			cfpClose := "-"
 			if !e.CFPCloseAt.IsZero() {
-				cfpClose = e.CFPCloseAt.Format("Jan 2, 2006")
+				cfpClose = f.formatDate(e.CFPCloseAt)
 			}
 			location := e.Location
 			if e.Country != "" && e.Location != "" {
@@ -102,18 +115,50 @@ func (f *Formatter) PrintEvents(events []Event) error {
 			} else if e.Country != "" {
 				location = e.Country
 			}
-			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
-				e.Slug,
-				truncate(e.Name, 40),
-				truncate(location, 25),
-				e.CFPStatus,
-				cfpClose,
+			rows[i] = row{e.Slug, e.Name, location, attendanceModeLabel(e.AttendanceMode), e.CFPStatus, cfpClose}
+			for j, v := range []string{rows[i].slug, rows[i].mode, rows[i].status, rows[i].closes} {
+				used[j] = max(used[j], utf8.RuneCountInString(v))
+			}
+		}
+
+		// Narrow the name and location columns to fit the terminal, giving
+		// the name three fifths of what is left after the other columns
+		nameWidth, locationWidth := 40, 25
+		if f.Width > 0 {
+			rest := f.Width - (used[0] + used[1] + used[2] + used[3] + 5*2)
+			if rest < nameWidth+locationWidth {
+				nameWidth = max(rest*3/5, 10)
+				locationWidth = max(rest-nameWidth, 8)
+			}
+		}
+
+		w := tabwriter.NewWriter(f.Writer, 0, 0, 2, ' ', 0)
+		fmt.Fprintf(w, "SLUG\tNAME\tLOCATION\tMODE\t%s\tCFP CLOSES\n", f.header("CFP STATUS"))
+		for i, r := range rows {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+				r.slug,
+				truncate(r.name, nameWidth),
+				truncate(r.location, locationWidth),
+				r.mode,
+				f.cfpStatus(r.status, events[i].CFPCloseAt),
+				r.closes,
 			)
 		}
 		return w.Flush()
 	}
 }
 
+// PrintPaginationSummary prints a hint about the remaining pages (table format only)
+func (f *Formatter) PrintPaginationSummary(p Pagination, shown int) {
+	if f.Format != FormatTable || p.TotalPages <= 1 || shown == 0 {
+		return
+	}
+	first := (p.Page-1)*p.PerPage + 1
+	last := first + shown - 1
+	fmt.Fprintf(f.Writer, "\nShowing %d–%d of %d (page %d of %d). Use --page N or --all to see more.\n",
+		first, last, p.Total, p.Page, p.TotalPages)
+}
+
 // PrintEvent outputs a single event with details
 func (f *Formatter) PrintEvent(event *Event) error {
 	switch f.Format {
@@ -125,7 +170,12 @@ func (f *Formatter) PrintEvent(event *Event) error {
 		fmt.Fprintf(f.Writer, "Name:        %s\n", event.Name)
 		fmt.Fprintf(f.Writer, "Slug:        %s\n", event.Slug)
 		if event.Description != "" {
-			fmt.Fprintf(f.Writer, "Description: %s\n", event.Description)
+			description := event.Description
+			if f.Width > 0 {
+				// At most three lines; the full text is in the JSON output
+				description = truncate(description, 3*f.Width-len("Description: "))
+			}
+			fmt.Fprintf(f.Writer, "Description: %s\n", description)
 		}
 		if event.Location != "" || event.Country != "" {
 			loc := event.Location
@@ -137,6 +187,9 @@ func (f *Formatter) PrintEvent(event *Event) error {
 			}
 			fmt.Fprintf(f.Writer, "Location:    %s\n", loc)
 		}
+		if event.AttendanceMode != "" {
+			fmt.Fprintf(f.Writer, "Attendance:  %s\n", attendanceModeLabel(event.AttendanceMode))
+		}
 		if !event.StartDate.IsZero() {
 			dateRange := event.StartDate.Format("Jan 2, 2006")
 			if !event.EndDate.IsZero() && !event.EndDate.Equal(event.StartDate) {
@@ -150,18 +203,33 @@ func (f *Formatter) PrintEvent(event *Event) error {
 		if event.TermsURL != "" {
 			fmt.Fprintf(f.Writer, "Terms:       %s\n", event.TermsURL)
 		}
+		if event.CoCURL != "" {
+			conduct := event.CoCURL
+			if event.RequireCoCAcceptance {
+				conduct += " (speakers must accept)"
+			}
+			fmt.Fprintf(f.Writer, "Conduct:     %s\n", conduct)
+		}
 		if event.Tags != "" {
 			fmt.Fprintf(f.Writer, "Tags:        %s\n", event.Tags)
 		}
 
+		for _, section := range event.Sections {
+			fmt.Fprintln(f.Writer)
+			fmt.Fprintf(f.Writer, "%s:\n", section.Title)
+			for _, line := range strings.Split(section.Body, "\n") {
+				fmt.Fprintf(f.Writer, "  %s\n", line)
+			}
+		}
+
 		fmt.Fprintln(f.Writer)
 		fmt.Fprintln(f.Writer, "CFP Information:")
-		fmt.Fprintf(f.Writer, "  Status:    %s\n", event.CFPStatus)
+		fmt.Fprintf(f.Writer, "  Status:    %s\n", f.cfpStatus(event.CFPStatus, event.CFPCloseAt))
 		if !event.CFPOpenAt.IsZero() {
-			fmt.Fprintf(f.Writer, "  Opens:     %s\n", event.CFPOpenAt.Format("Jan 2, 2006 15:04 MST"))
+			fmt.Fprintf(f.Writer, "  Opens:     %s\n", f.formatTime(event.CFPOpenAt))
 		}
 		if !event.CFPCloseAt.IsZero() {
-			fmt.Fprintf(f.Writer, "  Closes:    %s\n", event.CFPCloseAt.Format("Jan 2, 2006 15:04 MST"))
+			fmt.Fprintf(f.Writer, "  Closes:    %s\n", f.formatTime(event.CFPCloseAt))
 		}
 		if event.CFPDescription != "" {
 			fmt.Fprintf(f.Writer, "  Details:   %s\n", event.CFPDescription)
@@ -229,25 +297,137 @@ func (f *Formatter) PrintSubmittedEvents(events []SubmittedEvent) error {
 			return nil
 		}
 
-		for i, e := range events {
-			if i > 0 {
-				fmt.Fprintln(f.Writer)
+		f.printSubmittedEvents(events, nil)
+		return nil
+	}
+}
+
+// PrintWatchedProposals prints the proposals like PrintSubmittedEvents,
+// marking the ones in changes with * and the status they had before. It is
+// only used with table output.
+func (f *Formatter) PrintWatchedProposals(events []SubmittedEvent, changes []ProposalStatusChange) {
+	if len(events) == 0 {
+		fmt.Fprintln(f.Writer, "You haven't submitted any proposals yet.")
+		return
+	}
+	previous := make(map[uint]string, len(changes))
+	for _, c := range changes {
+		previous[c.ProposalID] = c.Old
+	}
+	f.printSubmittedEvents(events, previous)
+}
+
+func (f *Formatter) printSubmittedEvents(events []SubmittedEvent, previous map[uint]string) {
+	for i, e := range events {
+		if i > 0 {
+			fmt.Fprintln(f.Writer)
+		}
+		fmt.Fprintf(f.Writer, "%s (CFP: %s)\n", e.Name, e.CFPStatus)
+		w := tabwriter.NewWriter(f.Writer, 0, 0, 2, ' ', 0)
+		for _, p := range e.MyProposals {
+			marker, status := " ", p.Status
+			if old, ok := previous[p.ID]; ok {
+				marker = "*"
+				if old == "" {
+					status += " (new)"
+				} else {
+					status += " (was " + old + ")"
+				}
 			}
-			fmt.Fprintf(f.Writer, "%s (CFP: %s)\n", e.Name, e.CFPStatus)
-			w := tabwriter.NewWriter(f.Writer, 0, 0, 2, ' ', 0)
-			for _, p := range e.MyProposals {
-				fmt.Fprintf(w, "  #%d\t%s\t%s\n",
-					p.ID,
-					truncate(p.Title, 45),
-					p.Status,
-				)
+			fmt.Fprintf(w, " %s#%d\t%s\t%s\t%s\n",
+				marker,
+				p.ID,
+				truncate(p.Title, 45),
+				status,
+				f.formatOptionalDate(p.CreatedAt),
+			)
+		}
+		w.Flush()
+	}
+}
+
+// PrintDashboard outputs the events the user manages with their proposal counts
+func (f *Formatter) PrintDashboard(dashboard *DashboardResponse) error {
+	switch f.Format {
+	case FormatJSON:
+		return f.PrintJSON(dashboard)
+	case FormatYAML:
+		return f.PrintYAML(dashboard)
+	default:
+		if len(dashboard.Events) == 0 {
+			fmt.Fprintln(f.Writer, "You don't manage any events.")
+			return nil
+		}
+
+		w := tabwriter.NewWriter(f.Writer, 0, 0, 2, ' ', 0)
+		fmt.Fprintf(w, "SLUG\tNAME\t%s\tCFP CLOSES\tPROPOSALS\tUNRATED\tACCEPTED\tLISTING\tCREATED\n", f.header("CFP STATUS"))
+		for _, e := range dashboard.Events {
+			cfpClose := "-"
+			var closeAt time.Time
+			if e.CFPCloseAt != nil {
+				closeAt = *e.CFPCloseAt
+				cfpClose = f.formatDate(closeAt)
 			}
-			w.Flush()
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\t%d\t%d\t%s\t%s\n",
+				e.Slug,
+				truncate(e.Name, 40),
+				f.cfpStatus(e.CFPStatus, closeAt),
+				cfpClose,
+				e.ProposalTotal,
+				e.UnratedCount,
+				e.ProposalCounts["accepted"],
+				strings.ReplaceAll(e.PaymentStatus, "_", " "),
+				f.formatOptionalDate(e.CreatedAt),
+			)
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+		if d := dashboard.NextCFPDeadline; d != nil {
+			fmt.Fprintf(f.Writer, "\nNext CFP deadline: %s, %s\n", d.Name, f.formatTime(d.CFPCloseAt))
 		}
 		return nil
 	}
 }
 
+// PrintOrganizers outputs the organizers of an event
+func (f *Formatter) PrintOrganizers(organizers []Organizer) error {
+	switch f.Format {
+	case FormatJSON:
+		return f.PrintJSON(organizers)
+	case FormatYAML:
+		return f.PrintYAML(organizers)
+	default:
+		w := tabwriter.NewWriter(f.Writer, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tNAME\tEMAIL\tROLE")
+		for _, o := range organizers {
+			role := "organizer"
+			if o.IsCreator {
+				role = "creator"
+			}
+			fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", o.ID, truncate(o.Name, 40), o.Email, role)
+		}
+		return w.Flush()
+	}
+}
+
+// PrintOrganizerResults outputs what became of each email passed to AddOrganizers
+func (f *Formatter) PrintOrganizerResults(results []OrganizerResult) error {
+	switch f.Format {
+	case FormatJSON:
+		return f.PrintJSON(results)
+	case FormatYAML:
+		return f.PrintYAML(results)
+	default:
+		w := tabwriter.NewWriter(f.Writer, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "EMAIL\tRESULT")
+		for _, r := range results {
+			fmt.Fprintf(w, "%s\t%s\n", r.Email, strings.ReplaceAll(r.Result, "_", " "))
+		}
+		return w.Flush()
+	}
+}
+
 // PrintProposal outputs a single proposal with details
 func (f *Formatter) PrintProposal(proposal *Proposal) error {
 	switch f.Format {
@@ -298,13 +478,17 @@ func (f *Formatter) PrintProposal(proposal *Proposal) error {
 	}
 }
 
-// truncate truncates a string to max length with ellipsis
-func truncate(s string, max int) string {
-	if len(s) <= max {
-		return s
-	}
-	if max <= 3 {
-		return s[:max]
+// attendanceModeLabel returns the display name of an attendance mode
+func attendanceModeLabel(mode string) string {
+	switch mode {
+	case "in_person":
+		return "In-person"
+	case "online":
+		return "Online"
+	case "hybrid":
+		return "Hybrid"
+	case "":
+		return "-"
 	}
-	return s[:max-3] + "..."
+	return mode
 }
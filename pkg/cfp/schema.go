@@ -0,0 +1,315 @@
+package cfp
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Schema is a minimal JSON Schema (draft 2020-12 subset) describing a YAML template.
+// It marshals to a standard JSON Schema document.
+type Schema struct {
+	Type                 string             `json:"type,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	AdditionalProperties *bool              `json:"additionalProperties,omitempty"`
+}
+
+// Schema types
+const (
+	SchemaString  = "string"
+	SchemaInteger = "integer"
+	SchemaBoolean = "boolean"
+	SchemaArray   = "array"
+	SchemaObject  = "object"
+)
+
+var noAdditional = false
+
+// speakerSchema describes a proposal speaker entry
+var speakerSchema = &Schema{
+	Type: SchemaObject,
+	Properties: map[string]*Schema{
+		"name":      {Type: SchemaString},
+		"email":     {Type: SchemaString},
+		"bio":       {Type: SchemaString},
+		"job_title": {Type: SchemaString},
+		"company":   {Type: SchemaString},
+		"linkedin":  {Type: SchemaString},
+		"photo_url": {Type: SchemaString},
+		"primary":   {Type: SchemaBoolean},
+	},
+	Required:             []string{"name", "email", "job_title", "company", "linkedin"},
+	AdditionalProperties: &noAdditional,
+}
+
+// ProposalSchema returns the schema of the proposal template. Keys of custom_answers
+// are restricted to the given event questions.
+func ProposalSchema(questions []CustomQuestion) *Schema {
+	answers := &Schema{
+		Type:                 SchemaObject,
+		Description:          "Answers to the event's custom questions, keyed by question ID",
+		Properties:           map[string]*Schema{},
+		AdditionalProperties: &noAdditional,
+	}
+	for _, q := range questions {
+		// The server expects booleans for checkboxes and strings for everything else
+		answer := &Schema{Type: SchemaString, Description: q.Text}
+		if q.Type == "checkbox" {
+			answer.Type = SchemaBoolean
+		}
+		answers.Properties[q.ID] = answer
+	}
+
+	return &Schema{
+		Type: SchemaObject,
+		Properties: map[string]*Schema{
+			"title":          {Type: SchemaString},
+			"abstract":       {Type: SchemaString},
+			"format":         {Type: SchemaString, Enum: []string{"talk", "workshop", "lightning"}},
+			"duration":       {Type: SchemaInteger},
+			"level":          {Type: SchemaString, Enum: []string{"beginner", "intermediate", "advanced"}},
+			"tags":           {Type: SchemaString},
+			"speaker_notes":  {Type: SchemaString},
+			"speakers":       {Type: SchemaArray, Items: speakerSchema},
+			"custom_answers": answers,
+			"coc_accepted":   {Type: SchemaBoolean, Description: "Accept the event's code of conduct"},
+		},
+		Required:             []string{"title", "abstract", "speakers"},
+		AdditionalProperties: &noAdditional,
+	}
+}
+
+// EventSchema returns the schema of the event template
+func EventSchema() *Schema {
+	question := &Schema{
+		Type: SchemaObject,
+		Properties: map[string]*Schema{
+			"id":       {Type: SchemaString},
+			"text":     {Type: SchemaString},
+			"type":     {Type: SchemaString, Enum: []string{"text", "select", "multiselect", "checkbox"}},
+			"options":  {Type: SchemaArray, Items: &Schema{Type: SchemaString}},
+			"required": {Type: SchemaBoolean},
+		},
+		Required:             []string{"id", "text"},
+		AdditionalProperties: &noAdditional,
+	}
+
+	return &Schema{
+		Type: SchemaObject,
+		Properties: map[string]*Schema{
+			"name":                   {Type: SchemaString},
+			"slug":                   {Type: SchemaString},
+			"description":            {Type: SchemaString},
+			"description_format":     {Type: SchemaString, Enum: []string{"plaintext", "markdown"}},
+			"summary":                {Type: SchemaString},
+			"location":               {Type: SchemaString},
+			"country":                {Type: SchemaString},
+			"attendance_mode":        {Type: SchemaString, Enum: []string{"in_person", "online", "hybrid"}},
+			"start_date":             {Type: SchemaString},
+			"end_date":               {Type: SchemaString},
+			"website":                {Type: SchemaString},
+			"logo_url":               {Type: SchemaString},
+			"terms_url":              {Type: SchemaString},
+			"tags":                   {Type: SchemaString},
+			"cfp_description":        {Type: SchemaString},
+			"cfp_open_at":            {Type: SchemaString},
+			"cfp_close_at":           {Type: SchemaString},
+			"cfp_status":             {Type: SchemaString, Enum: []string{"draft", "open", "closed", "reviewing", "complete"}},
+			"max_accepted":           {Type: SchemaInteger},
+			"waitlist_auto_promote":  {Type: SchemaBoolean},
+			"allow_overbook":         {Type: SchemaBoolean},
+			"series":                 {Type: SchemaString},
+			"flag_series_duplicates": {Type: SchemaBoolean},
+			"cfp_questions":          {Type: SchemaArray, Items: question},
+		},
+		Required:             []string{"name", "slug"},
+		AdditionalProperties: &noAdditional,
+	}
+}
+
+// SchemaError is a single schema violation, located by YAML line where possible
+type SchemaError struct {
+	Line    int
+	Path    string
+	Message string
+}
+
+func (e SchemaError) Error() string {
+	loc := e.Path
+	if e.Line > 0 {
+		loc = fmt.Sprintf("line %d: %s", e.Line, e.Path)
+	}
+	return fmt.Sprintf("%s: %s", loc, e.Message)
+}
+
+// SchemaErrors is the list of violations found by ValidateSchema
+type SchemaErrors []SchemaError
+
+func (e SchemaErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// ValidateSchema validates YAML content against a schema, reporting unknown keys,
+// type mismatches and invalid enum values. Missing required fields are left to the
+// template parsers, which report them with friendlier messages.
+func ValidateSchema(content string, schema *Schema) error {
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(content), &doc); err != nil {
+		return fmt.Errorf("invalid YAML: %w", err)
+	}
+	if len(doc.Content) == 0 {
+		return nil
+	}
+
+	var errs SchemaErrors
+	validateNode(doc.Content[0], schema, "", &errs)
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func validateNode(node *yaml.Node, schema *Schema, path string, errs *SchemaErrors) {
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	// Empty values are treated as unset
+	if node.Kind == yaml.ScalarNode && node.Tag == "!!null" {
+		return
+	}
+	if schema.Type == "" {
+		return
+	}
+
+	if got := nodeType(node); got != schema.Type {
+		msg := fmt.Sprintf("expected %s, got %s", schema.Type, got)
+		if schema.Type == SchemaString && node.Kind == yaml.ScalarNode {
+			msg += " (wrap the value in quotes)"
+		}
+		*errs = append(*errs, SchemaError{Line: node.Line, Path: displayPath(path), Message: msg})
+		return
+	}
+
+	switch schema.Type {
+	case SchemaString:
+		// Empty values fall back to defaults in the template parsers
+		if len(schema.Enum) > 0 && node.Value != "" && !containsString(schema.Enum, node.Value) {
+			*errs = append(*errs, SchemaError{Line: node.Line, Path: displayPath(path), Message: fmt.Sprintf("invalid value %q (must be one of: %s)", node.Value, strings.Join(schema.Enum, ", "))})
+		}
+	case SchemaArray:
+		if schema.Items == nil {
+			return
+		}
+		for i, item := range node.Content {
+			validateNode(item, schema.Items, fmt.Sprintf("%s[%d]", path, i), errs)
+		}
+	case SchemaObject:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			childPath := key.Value
+			if path != "" {
+				childPath = path + "." + key.Value
+			}
+
+			prop, ok := schema.Properties[key.Value]
+			if !ok {
+				if schema.AdditionalProperties != nil && !*schema.AdditionalProperties {
+					*errs = append(*errs, SchemaError{Line: key.Line, Path: childPath, Message: unknownFieldMessage(key.Value, schema)})
+				}
+				continue
+			}
+			validateNode(value, prop, childPath, errs)
+		}
+	}
+}
+
+// nodeType maps a YAML node to its JSON Schema type
+func nodeType(node *yaml.Node) string {
+	switch node.Kind {
+	case yaml.MappingNode:
+		return SchemaObject
+	case yaml.SequenceNode:
+		return SchemaArray
+	}
+	switch node.Tag {
+	case "!!int":
+		return SchemaInteger
+	case "!!bool":
+		return SchemaBoolean
+	case "!!float":
+		return "number"
+	case "!!timestamp":
+		return "date"
+	}
+	return SchemaString
+}
+
+// unknownFieldMessage reports an unknown key, suggesting the closest known one
+func unknownFieldMessage(key string, schema *Schema) string {
+	known := make([]string, 0, len(schema.Properties))
+	for k := range schema.Properties {
+		known = append(known, k)
+	}
+	sort.Strings(known)
+
+	best, bestDist := "", 3
+	for _, k := range known {
+		if d := editDistance(key, k); d < bestDist {
+			best, bestDist = k, d
+		}
+	}
+	if best != "" {
+		return fmt.Sprintf("unknown field (did you mean %q?)", best)
+	}
+	if len(known) == 0 {
+		return "unknown field"
+	}
+	return fmt.Sprintf("unknown field (expected one of: %s)", strings.Join(known, ", "))
+}
+
+// editDistance returns the Levenshtein distance between two strings
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
+
+func displayPath(path string) string {
+	if path == "" {
+		return "(document)"
+	}
+	return path
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,131 @@
+package cfp
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestValidateProposalTemplate_GeneratedTemplateIsValid(t *testing.T) {
+	event := &Event{
+		Name: "Test Conf",
+		CFPQuestions: []CustomQuestion{
+			{ID: "travel", Text: "Need travel?", Type: "select", Options: []string{"Yes", "No"}},
+			{ID: "coc", Text: "Accept the code of conduct?", Type: "checkbox", Required: true},
+		},
+	}
+
+	if err := ValidateProposalTemplate(GenerateTemplate(event), event.CFPQuestions); err != nil {
+		t.Errorf("expected generated template to be valid, got: %v", err)
+	}
+}
+
+func TestValidateProposalTemplate_UnknownKeys(t *testing.T) {
+	content := `title: "My Talk"
+abstract: "About things"
+durration: 30
+speaker:
+  - name: "Jane"
+custom_answers:
+  travel: "Yes"
+  dietry: "none"
+`
+	err := ValidateProposalTemplate(content, []CustomQuestion{{ID: "travel", Text: "Need travel?"}, {ID: "dietary", Text: "Diet?"}})
+
+	var errs SchemaErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("expected SchemaErrors, got: %v", err)
+	}
+	if len(errs) != 3 {
+		t.Fatalf("expected 3 errors, got %d: %v", len(errs), errs)
+	}
+
+	if errs[0].Line != 3 || errs[0].Path != "durration" || !strings.Contains(errs[0].Message, `did you mean "duration"`) {
+		t.Errorf("unexpected first error: %+v", errs[0])
+	}
+	if errs[1].Line != 4 || !strings.Contains(errs[1].Message, `did you mean "speakers"`) {
+		t.Errorf("unexpected second error: %+v", errs[1])
+	}
+	if errs[2].Path != "custom_answers.dietry" || !strings.Contains(errs[2].Message, `did you mean "dietary"`) {
+		t.Errorf("unexpected third error: %+v", errs[2])
+	}
+}
+
+func TestValidateProposalTemplate_TypeMismatch(t *testing.T) {
+	content := `title: 2024
+abstract: "About things"
+duration: "thirty"
+format: keynote
+speakers:
+  - name: "Jane"
+    primary: "yes please"
+`
+	err := ValidateProposalTemplate(content, nil)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+
+	msg := err.Error()
+	for _, want := range []string{
+		"line 1: title: expected string, got integer",
+		"line 3: duration: expected integer, got string",
+		`line 4: format: invalid value "keynote"`,
+		"line 7: speakers[0].primary: expected boolean, got string",
+	} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("expected error to contain %q, got: %s", want, msg)
+		}
+	}
+}
+
+func TestValidateEventTemplate(t *testing.T) {
+	if err := ValidateEventTemplate(GenerateEventTemplate(nil)); err != nil {
+		t.Errorf("expected generated template to be valid, got: %v", err)
+	}
+
+	content := `name: "Conf"
+slug: "conf"
+start_date: 2026-05-01
+cfp_questions:
+  - id: q1
+    text: "Question"
+    kind: text
+`
+	err := ValidateEventTemplate(content)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	msg := err.Error()
+	if !strings.Contains(msg, "line 3: start_date: expected string, got date (wrap the value in quotes)") {
+		t.Errorf("expected unquoted date to be reported, got: %s", msg)
+	}
+	if !strings.Contains(msg, "line 7: cfp_questions[0].kind: unknown field") {
+		t.Errorf("expected unknown question field to be reported, got: %s", msg)
+	}
+}
+
+func TestValidateEventTemplate_AttendanceMode(t *testing.T) {
+	if err := ValidateEventTemplate("name: Conf\nslug: conf\nattendance_mode: hybrid\n"); err != nil {
+		t.Errorf("expected hybrid to be valid, got: %v", err)
+	}
+
+	err := ValidateEventTemplate("name: Conf\nslug: conf\nattendance_mode: remote\n")
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if !strings.Contains(err.Error(), `line 3: attendance_mode: invalid value "remote"`) {
+		t.Errorf("expected invalid attendance mode to be reported, got: %s", err)
+	}
+}
+
+func TestValidateEventTemplate_DescriptionFormat(t *testing.T) {
+	if err := ValidateEventTemplate(GenerateEventTemplate(nil)); err != nil {
+		t.Errorf("generated template should validate: %v", err)
+	}
+	if err := ValidateEventTemplate("name: Conf\nslug: conf\ndescription_format: markdown\n"); err != nil {
+		t.Errorf("expected markdown to validate, got %v", err)
+	}
+	if err := ValidateEventTemplate("name: Conf\nslug: conf\ndescription_format: html\n"); err == nil {
+		t.Error("expected error for unknown description format")
+	}
+}
@@ -1,6 +1,7 @@
 package cfp
 
 import (
+	"encoding/json"
 	"fmt"
 	"regexp"
 	"strings"
@@ -35,6 +36,20 @@ type SpeakerTemplate struct {
 	Primary  bool   `yaml:"primary"`
 }
 
+// abstractWordLimit describes the event's abstract word limits, or returns
+// "" when it has none
+func abstractWordLimit(event *Event) string {
+	switch {
+	case event.AbstractMinWords != nil && event.AbstractMaxWords != nil:
+		return fmt.Sprintf("%d-%d words", *event.AbstractMinWords, *event.AbstractMaxWords)
+	case event.AbstractMinWords != nil:
+		return fmt.Sprintf("at least %d words", *event.AbstractMinWords)
+	case event.AbstractMaxWords != nil:
+		return fmt.Sprintf("at most %d words", *event.AbstractMaxWords)
+	}
+	return ""
+}
+
 // GenerateTemplate creates a YAML template for proposal submission
 func GenerateTemplate(event *Event) string {
 	var sb strings.Builder
@@ -44,6 +59,9 @@ func GenerateTemplate(event *Event) string {
 	if !event.CFPCloseAt.IsZero() {
 		sb.WriteString(fmt.Sprintf("# CFP closes: %s\n", event.CFPCloseAt.Format("2006-01-02 15:04 MST")))
 	}
+	if limit := abstractWordLimit(event); limit != "" {
+		sb.WriteString(fmt.Sprintf("# Abstract length: %s\n", limit))
+	}
 	sb.WriteString("#\n")
 	sb.WriteString("# Fill in the fields below and save the file.\n")
 	sb.WriteString("# Lines starting with # are comments and will be ignored.\n")
@@ -96,6 +114,13 @@ func GenerateTemplate(event *Event) string {
 	sb.WriteString("    linkedin: \"\"      # Required (full URL: https://linkedin.com/in/username)\n")
 	sb.WriteString("    primary: true\n\n")
 
+	// Code of conduct
+	if event.RequireCoCAcceptance {
+		sb.WriteString(fmt.Sprintf("# Code of conduct (required): %s\n", event.CoCURL))
+		sb.WriteString("# Set to true once you have read and accept it\n")
+		sb.WriteString("coc_accepted: false\n\n")
+	}
+
 	// Custom questions
 	if len(event.CFPQuestions) > 0 {
 		sb.WriteString("# Event-specific questions\n")
@@ -109,13 +134,28 @@ func GenerateTemplate(event *Event) string {
 			if len(q.Options) > 0 {
 				sb.WriteString(fmt.Sprintf("  # Options: %s\n", strings.Join(q.Options, ", ")))
 			}
-			sb.WriteString(fmt.Sprintf("  %s: \"\"\n", q.ID))
+			if q.Type == "checkbox" {
+				sb.WriteString(fmt.Sprintf("  %s: false\n", q.ID))
+			} else {
+				sb.WriteString(fmt.Sprintf("  %s: \"\"\n", q.ID))
+			}
 		}
 	}
 
 	return sb.String()
 }
 
+// ValidateProposalTemplate checks a proposal template against ProposalSchema,
+// restricting custom_answers to the event's question IDs
+func ValidateProposalTemplate(content string, questions []CustomQuestion) error {
+	return ValidateSchema(content, ProposalSchema(questions))
+}
+
+// ValidateEventTemplate checks an event template against EventSchema
+func ValidateEventTemplate(content string) error {
+	return ValidateSchema(content, EventSchema())
+}
+
 // ParseTemplate parses the YAML template back into a ProposalSubmission
 func ParseTemplate(content string) (*ProposalSubmission, error) {
 	// First, parse the main structure
@@ -246,6 +286,11 @@ func ParseTemplate(content string) (*ProposalSubmission, error) {
 		return nil, fmt.Errorf("at least one speaker is required")
 	}
 
+	// Code of conduct
+	if v, ok := raw["coc_accepted"].(bool); ok {
+		proposal.CoCAccepted = v
+	}
+
 	// Custom answers
 	if answers, ok := raw["custom_answers"].(map[string]interface{}); ok {
 		proposal.CustomAnswers = make(map[string]interface{})
@@ -281,41 +326,70 @@ func ValidateCustomAnswers(proposal *ProposalSubmission, questions []CustomQuest
 	return nil
 }
 
-// GenerateEventTemplate creates a YAML template for event creation
-func GenerateEventTemplate() string {
+// GenerateEventTemplate creates a YAML template for event creation, prefilled
+// from draft when it isn't nil, such as an event read from its website
+func GenerateEventTemplate(draft *EventSubmission) string {
+	if draft == nil {
+		draft = &EventSubmission{}
+	}
 	var sb strings.Builder
 
 	sb.WriteString("# CFP.ninja Event Creation\n")
 	sb.WriteString("#\n")
+	if draft.Website != "" {
+		sb.WriteString(fmt.Sprintf("# Prefilled from %s; check every field.\n", draft.Website))
+	}
 	sb.WriteString("# Fill in the fields below and save the file.\n")
 	sb.WriteString("# Lines starting with # are comments and will be ignored.\n")
 	sb.WriteString("\n")
 
 	// Required fields
 	sb.WriteString("# Required: Event name\n")
-	sb.WriteString("name: \"\"\n\n")
+	sb.WriteString(fmt.Sprintf("name: %s\n\n", yamlString(draft.Name)))
 
 	sb.WriteString("# Required: URL slug (lowercase, alphanumeric with hyphens)\n")
 	sb.WriteString("# Example: gophercon-2026, sreday-london-2026-q1\n")
-	sb.WriteString("slug: \"\"\n\n")
+	sb.WriteString(fmt.Sprintf("slug: %s\n\n", yamlString(draft.Slug)))
 
 	// Event details
 	sb.WriteString("# Event description\n")
 	sb.WriteString("description: |\n")
-	sb.WriteString("  Describe your event here.\n\n")
+	writeYAMLBlock(&sb, draft.Description, "Describe your event here.")
+	sb.WriteString("\n")
+
+	sb.WriteString("# Format of description and cfp_description: plaintext or markdown\n")
+	sb.WriteString("# Markdown supports **bold**, *italics*, lists, headings and [links](https://example.com)\n")
+	sb.WriteString("description_format: plaintext\n\n")
+
+	sb.WriteString("# Short summary for listings and link previews, at most 300 characters\n")
+	sb.WriteString("# (optional, defaults to the first paragraph of the description)\n")
+	sb.WriteString("# summary: A one day conference about reliability\n\n")
 
 	sb.WriteString("# Location (city/venue)\n")
-	sb.WriteString("location: \"\"\n\n")
+	sb.WriteString(fmt.Sprintf("location: %s\n\n", yamlString(draft.Location)))
 
 	sb.WriteString("# Country (ISO 3166-1 alpha-2 code, e.g., US, GB, DE)\n")
-	sb.WriteString("country: \"\"\n\n")
+	sb.WriteString(fmt.Sprintf("country: %s\n\n", yamlString(draft.Country)))
+
+	attendanceMode := draft.AttendanceMode
+	if attendanceMode == "" {
+		attendanceMode = "in_person"
+	}
+	sb.WriteString("# Attendance mode: in_person, online or hybrid\n")
+	sb.WriteString("# Online events need no location or country\n")
+	sb.WriteString(fmt.Sprintf("attendance_mode: %s\n\n", attendanceMode))
 
 	sb.WriteString("# Event dates (YYYY-MM-DD)\n")
-	sb.WriteString("start_date: \"\"\n")
-	sb.WriteString("end_date: \"\"\n\n")
+	sb.WriteString(fmt.Sprintf("start_date: %s\n", yamlString(draft.StartDate)))
+	sb.WriteString(fmt.Sprintf("end_date: %s\n\n", yamlString(draft.EndDate)))
 
 	sb.WriteString("# Event website URL\n")
-	sb.WriteString("website: \"\"\n\n")
+	sb.WriteString(fmt.Sprintf("website: %s\n\n", yamlString(draft.Website)))
+
+	if draft.LogoURL != "" {
+		sb.WriteString("# Event logo or banner image URL\n")
+		sb.WriteString(fmt.Sprintf("logo_url: %s\n\n", yamlString(draft.LogoURL)))
+	}
 
 	sb.WriteString("# Terms and conditions URL (optional)\n")
 	sb.WriteString("terms_url: \"\"\n\n")
@@ -341,6 +415,17 @@ func GenerateEventTemplate() string {
 	sb.WriteString("# Maximum accepted proposals (optional, leave empty for unlimited)\n")
 	sb.WriteString("# max_accepted: 20\n\n")
 
+	sb.WriteString("# Accept the highest-rated tentative proposal when a confirmed speaker cancels\n")
+	sb.WriteString("# waitlist_auto_promote: true\n\n")
+
+	sb.WriteString("# Let acceptances go past max_accepted, expecting drop-outs\n")
+	sb.WriteString("# allow_overbook: true\n\n")
+
+	sb.WriteString("# Your events with the same series are editions of one conference. Flag talks\n")
+	sb.WriteString("# a speaker also submitted to another edition (optional)\n")
+	sb.WriteString("# series: sreday-london\n")
+	sb.WriteString("# flag_series_duplicates: true\n\n")
+
 	// Custom questions
 	sb.WriteString("# Custom CFP questions (optional)\n")
 	sb.WriteString("# cfp_questions:\n")
@@ -359,6 +444,24 @@ func GenerateEventTemplate() string {
 	return sb.String()
 }
 
+// yamlString quotes s as a YAML double-quoted string. JSON strings are valid
+// YAML, so encoding/json does the escaping.
+func yamlString(s string) string {
+	data, _ := json.Marshal(s)
+	return string(data)
+}
+
+// writeYAMLBlock writes text as the indented lines of a YAML block scalar, or
+// placeholder when text is empty
+func writeYAMLBlock(sb *strings.Builder, text, placeholder string) {
+	if strings.TrimSpace(text) == "" {
+		text = placeholder
+	}
+	for _, line := range strings.Split(text, "\n") {
+		sb.WriteString("  " + strings.TrimRight(line, " \t") + "\n")
+	}
+}
+
 // ParseEventTemplate parses the YAML template into an EventSubmission
 func ParseEventTemplate(content string) (*EventSubmission, error) {
 	var raw map[string]interface{}
@@ -388,12 +491,21 @@ func ParseEventTemplate(content string) (*EventSubmission, error) {
 	if v, ok := raw["description"].(string); ok {
 		event.Description = strings.TrimSpace(v)
 	}
+	if v, ok := raw["description_format"].(string); ok {
+		event.DescriptionFormat = strings.TrimSpace(v)
+	}
+	if v, ok := raw["summary"].(string); ok {
+		event.Summary = strings.TrimSpace(v)
+	}
 	if v, ok := raw["location"].(string); ok {
 		event.Location = strings.TrimSpace(v)
 	}
 	if v, ok := raw["country"].(string); ok {
 		event.Country = strings.TrimSpace(v)
 	}
+	if v, ok := raw["attendance_mode"].(string); ok {
+		event.AttendanceMode = strings.TrimSpace(v)
+	}
 	if v, ok := raw["start_date"].(string); ok {
 		event.StartDate = strings.TrimSpace(v)
 	}
@@ -403,6 +515,9 @@ func ParseEventTemplate(content string) (*EventSubmission, error) {
 	if v, ok := raw["website"].(string); ok {
 		event.Website = strings.TrimSpace(v)
 	}
+	if v, ok := raw["logo_url"].(string); ok {
+		event.LogoURL = strings.TrimSpace(v)
+	}
 	if v, ok := raw["terms_url"].(string); ok {
 		event.TermsURL = strings.TrimSpace(v)
 	}
@@ -431,6 +546,18 @@ func ParseEventTemplate(content string) (*EventSubmission, error) {
 		i := int(v)
 		event.MaxAccepted = &i
 	}
+	if v, ok := raw["waitlist_auto_promote"].(bool); ok {
+		event.WaitlistAutoPromote = v
+	}
+	if v, ok := raw["allow_overbook"].(bool); ok {
+		event.AllowOverbook = v
+	}
+	if v, ok := raw["series"].(string); ok {
+		event.Series = v
+	}
+	if v, ok := raw["flag_series_duplicates"].(bool); ok {
+		event.FlagSeriesDuplicates = v
+	}
 
 	// CFP questions
 	if questions, ok := raw["cfp_questions"].([]interface{}); ok {
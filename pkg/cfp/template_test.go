@@ -1,6 +1,7 @@
 package cfp
 
 import (
+	"reflect"
 	"strings"
 	"testing"
 )
@@ -367,3 +368,87 @@ speakers:
 		})
 	}
 }
+
+func TestGenerateTemplate_CodeOfConduct(t *testing.T) {
+	event := &Event{Name: "Conf", CoCURL: "https://conf.example.com/coc", RequireCoCAcceptance: true}
+	content := GenerateTemplate(event)
+	if !strings.Contains(content, "# Code of conduct (required): https://conf.example.com/coc\n") || !strings.Contains(content, "coc_accepted: false\n") {
+		t.Fatalf("expected the code of conduct checkbox, got:\n%s", content)
+	}
+	if err := ValidateProposalTemplate(content, nil); err != nil {
+		t.Errorf("expected generated template to be valid, got: %v", err)
+	}
+
+	content = strings.Replace(content, "coc_accepted: false", "coc_accepted: true", 1)
+	content = strings.Replace(content, `title: ""`, `title: "My Talk"`, 1)
+	content = strings.Replace(content, `name: ""`, `name: "Jane"`, 1)
+	content = strings.Replace(content, `email: ""`, `email: "jane@example.com"`, 1)
+	content = strings.Replace(content, `job_title: ""`, `job_title: "SRE"`, 1)
+	content = strings.Replace(content, `company: ""`, `company: "Acme"`, 1)
+	content = strings.Replace(content, `linkedin: ""`, `linkedin: "https://linkedin.com/in/jane"`, 1)
+	proposal, err := ParseTemplate(content)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if !proposal.CoCAccepted {
+		t.Error("expected coc_accepted to be parsed")
+	}
+
+	if strings.Contains(GenerateTemplate(&Event{Name: "Conf"}), "coc_accepted") {
+		t.Error("expected no checkbox when the event does not require it")
+	}
+}
+
+func TestGenerateTemplate_AbstractWordLimits(t *testing.T) {
+	minWords, maxWords := 100, 300
+	tests := []struct {
+		name  string
+		event *Event
+		want  string
+	}{
+		{"both", &Event{Name: "Conf", AbstractMinWords: &minWords, AbstractMaxWords: &maxWords}, "# Abstract length: 100-300 words\n"},
+		{"min only", &Event{Name: "Conf", AbstractMinWords: &minWords}, "# Abstract length: at least 100 words\n"},
+		{"max only", &Event{Name: "Conf", AbstractMaxWords: &maxWords}, "# Abstract length: at most 300 words\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if content := GenerateTemplate(tt.event); !strings.Contains(content, tt.want) {
+				t.Errorf("expected %q in template, got:\n%s", tt.want, content)
+			}
+		})
+	}
+
+	if strings.Contains(GenerateTemplate(&Event{Name: "Conf"}), "Abstract length") {
+		t.Error("expected no word limit line when the event has none")
+	}
+}
+
+func TestGenerateEventTemplate_Draft(t *testing.T) {
+	draft := &EventSubmission{
+		Name:           `GopherCon "EU"`,
+		Slug:           "gophercon-eu",
+		Description:    "Three days of Go.\n\nIn Berlin.",
+		Location:       "Berlin",
+		Country:        "DE",
+		AttendanceMode: "hybrid",
+		StartDate:      "2026-06-01",
+		EndDate:        "2026-06-03",
+		Website:        "https://gophercon.example.com",
+		LogoURL:        "https://gophercon.example.com/banner.png",
+	}
+	content := GenerateEventTemplate(draft)
+	if err := ValidateEventTemplate(content); err != nil {
+		t.Fatalf("expected the prefilled template to validate, got: %v", err)
+	}
+	got, err := ParseEventTemplate(content)
+	if err != nil {
+		t.Fatalf("failed to parse prefilled template: %v", err)
+	}
+	got.CFPDescription, got.CFPStatus, got.DescriptionFormat = "", "", ""
+	if !reflect.DeepEqual(got, draft) {
+		t.Errorf("expected the draft back, got %+v", got)
+	}
+	if !strings.Contains(content, "# Prefilled from https://gophercon.example.com; check every field.\n") {
+		t.Errorf("expected a note on where the values came from, got:\n%s", content)
+	}
+}
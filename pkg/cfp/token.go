@@ -0,0 +1,111 @@
+package cfp
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TokenInfo holds the claims of a session token, decoded locally
+type TokenInfo struct {
+	UserID    uint      `json:"user_id"`
+	Email     string    `json:"email"`
+	Name      string    `json:"name"`
+	IssuedAt  time.Time `json:"issued_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+	// Set on tokens an admin issued to impersonate the user
+	ImpersonatorID     uint   `json:"impersonator_id,omitempty"`
+	ImpersonatorEmail  string `json:"impersonator_email,omitempty"`
+	ImpersonationWrite bool   `json:"impersonation_write,omitempty"`
+}
+
+// DecodeToken decodes the claims of a JWT without verifying its signature.
+// It is only meant for displaying token details; the server remains the authority.
+func DecodeToken(token string) (*TokenInfo, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("token is not a JWT")
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode token payload: %w", err)
+	}
+
+	var claims struct {
+		UserID float64 `json:"user_id"`
+		Email  string  `json:"email"`
+		Name   string  `json:"name"`
+		Iat    int64   `json:"iat"`
+		Exp    int64   `json:"exp"`
+
+		ImpersonatorID     float64 `json:"impersonator_id"`
+		ImpersonatorEmail  string  `json:"impersonator_email"`
+		ImpersonationWrite bool    `json:"impersonation_write"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, fmt.Errorf("failed to parse token claims: %w", err)
+	}
+
+	info := &TokenInfo{
+		UserID: uint(claims.UserID),
+		Email:  claims.Email,
+		Name:   claims.Name,
+
+		ImpersonatorID:     uint(claims.ImpersonatorID),
+		ImpersonatorEmail:  claims.ImpersonatorEmail,
+		ImpersonationWrite: claims.ImpersonationWrite,
+	}
+	if claims.Iat > 0 {
+		info.IssuedAt = time.Unix(claims.Iat, 0)
+	}
+	if claims.Exp > 0 {
+		info.ExpiresAt = time.Unix(claims.Exp, 0)
+	}
+
+	return info, nil
+}
+
+// IsImpersonation reports whether an admin issued the token to act as the user
+func (t *TokenInfo) IsImpersonation() bool {
+	return t.ImpersonatorID != 0
+}
+
+// ImpersonationWarning returns the banner shown while using an impersonation
+// token, or "" for the user's own token
+func (t *TokenInfo) ImpersonationWarning() string {
+	if !t.IsImpersonation() {
+		return ""
+	}
+	mode := "read-only"
+	if t.ImpersonationWrite {
+		mode = "changes allowed"
+	}
+	return fmt.Sprintf("Warning: %s is impersonating %s (%s); every request is audited.", t.ImpersonatorEmail, t.Email, mode)
+}
+
+// ExpiresIn returns the time left until the token expires (negative once expired)
+func (t *TokenInfo) ExpiresIn() time.Duration {
+	return time.Until(t.ExpiresAt)
+}
+
+// IsExpired reports whether the token has an expiry in the past
+func (t *TokenInfo) IsExpired() bool {
+	return !t.ExpiresAt.IsZero() && t.ExpiresIn() <= 0
+}
+
+// Credential types reported by CredentialType
+const (
+	CredentialJWT    = "jwt"
+	CredentialAPIKey = "api_key"
+)
+
+// CredentialType reports whether a stored token is a session JWT or an opaque API key
+func CredentialType(token string) string {
+	if _, err := DecodeToken(token); err == nil {
+		return CredentialJWT
+	}
+	return CredentialAPIKey
+}
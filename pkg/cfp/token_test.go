@@ -0,0 +1,66 @@
+package cfp
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+)
+
+func makeTestToken(payload string) string {
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+	body := base64.RawURLEncoding.EncodeToString([]byte(payload))
+	return header + "." + body + ".signature"
+}
+
+func TestDecodeToken(t *testing.T) {
+	token := makeTestToken(`{"user_id":42,"email":"jane@example.com","name":"Jane","iat":1700000000,"exp":1700604800}`)
+
+	info, err := DecodeToken(token)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if info.UserID != 42 || info.Email != "jane@example.com" {
+		t.Errorf("unexpected claims: %+v", info)
+	}
+	if !info.IssuedAt.Equal(time.Unix(1700000000, 0)) {
+		t.Errorf("unexpected issued at: %v", info.IssuedAt)
+	}
+	if !info.IsExpired() {
+		t.Error("expected token from 2023 to be expired")
+	}
+}
+
+func TestDecodeToken_Impersonation(t *testing.T) {
+	info, err := DecodeToken(makeTestToken(`{"user_id":42,"email":"jane@example.com","impersonator_id":7,"impersonator_email":"support@example.com"}`))
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if !info.IsImpersonation() || info.ImpersonatorEmail != "support@example.com" {
+		t.Errorf("expected an impersonation by support, got %+v", info)
+	}
+	if got := info.ImpersonationWarning(); got != "Warning: support@example.com is impersonating jane@example.com (read-only); every request is audited." {
+		t.Errorf("unexpected warning: %q", got)
+	}
+
+	own, _ := DecodeToken(makeTestToken(`{"user_id":42,"email":"jane@example.com"}`))
+	if own.IsImpersonation() || own.ImpersonationWarning() != "" {
+		t.Errorf("expected no impersonation on the user's own token, got %+v", own)
+	}
+}
+
+func TestDecodeToken_Malformed(t *testing.T) {
+	for _, token := range []string{"", "not-a-jwt", "a.!!!.c", makeTestToken("not json")} {
+		if _, err := DecodeToken(token); err == nil {
+			t.Errorf("expected error for %q", token)
+		}
+	}
+}
+
+func TestCredentialType(t *testing.T) {
+	if got := CredentialType(makeTestToken(`{"user_id":1}`)); got != CredentialJWT {
+		t.Errorf("expected %q, got %q", CredentialJWT, got)
+	}
+	if got := CredentialType("cfp_abcdef123456"); got != CredentialAPIKey {
+		t.Errorf("expected %q, got %q", CredentialAPIKey, got)
+	}
+}
@@ -0,0 +1,53 @@
+package cfp
+
+import (
+	"strconv"
+	"strings"
+)
+
+// CompareVersions compares two semantic versions such as "v1.2.3" or "1.2".
+// It returns -1, 0 or 1, and ok=false if either version can't be parsed
+// (e.g. "dev" builds), in which case no comparison should be made.
+func CompareVersions(a, b string) (cmp int, ok bool) {
+	pa, okA := parseVersion(a)
+	pb, okB := parseVersion(b)
+	if !okA || !okB {
+		return 0, false
+	}
+
+	for i := range pa {
+		switch {
+		case pa[i] < pb[i]:
+			return -1, true
+		case pa[i] > pb[i]:
+			return 1, true
+		}
+	}
+	return 0, true
+}
+
+// parseVersion parses "v1.2.3" into its numeric parts, ignoring pre-release/build suffixes
+func parseVersion(v string) ([3]int, bool) {
+	var parts [3]int
+
+	v = strings.TrimPrefix(strings.TrimSpace(v), "v")
+	if i := strings.IndexAny(v, "-+"); i >= 0 {
+		v = v[:i]
+	}
+	if v == "" {
+		return parts, false
+	}
+
+	fields := strings.Split(v, ".")
+	if len(fields) > 3 {
+		return parts, false
+	}
+	for i, f := range fields {
+		n, err := strconv.Atoi(f)
+		if err != nil || n < 0 {
+			return parts, false
+		}
+		parts[i] = n
+	}
+	return parts, true
+}
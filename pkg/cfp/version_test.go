@@ -0,0 +1,26 @@
+package cfp
+
+import "testing"
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		cmp  int
+		ok   bool
+	}{
+		{"v1.2.3", "v1.2.3", 0, true},
+		{"1.2.3", "v1.2.4", -1, true},
+		{"v1.10.0", "v1.9.9", 1, true},
+		{"v2", "v1.9", 1, true},
+		{"v1.2.0-rc1", "v1.2.0", 0, true},
+		{"dev", "v1.0.0", 0, false},
+		{"v1.0.0", "", 0, false},
+	}
+
+	for _, tt := range tests {
+		cmp, ok := CompareVersions(tt.a, tt.b)
+		if cmp != tt.cmp || ok != tt.ok {
+			t.Errorf("CompareVersions(%q, %q) = %d, %v; want %d, %v", tt.a, tt.b, cmp, ok, tt.cmp, tt.ok)
+		}
+	}
+}
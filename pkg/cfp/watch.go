@@ -0,0 +1,105 @@
+package cfp
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// MinWatchInterval is the shortest polling interval `cfp proposals --watch`
+// accepts, so watching stays well inside the server's rate limits
+const MinWatchInterval = 30 * time.Second
+
+// ProposalStatusChange is a proposal whose status changed between two polls.
+// Old is empty for proposals that weren't there before.
+type ProposalStatusChange struct {
+	EventName  string
+	ProposalID uint
+	Title      string
+	Old        string
+	New        string
+}
+
+func (c ProposalStatusChange) String() string {
+	if c.Old == "" {
+		return fmt.Sprintf("#%d %s (%s): %s", c.ProposalID, c.Title, c.EventName, c.New)
+	}
+	return fmt.Sprintf("#%d %s (%s): %s -> %s", c.ProposalID, c.Title, c.EventName, c.Old, c.New)
+}
+
+// DiffProposalStatuses lists the proposals in cur that are new or have a
+// different status than in prev, in the order of cur
+func DiffProposalStatuses(prev, cur []SubmittedEvent) []ProposalStatusChange {
+	before := make(map[uint]string)
+	for _, e := range prev {
+		for _, p := range e.MyProposals {
+			before[p.ID] = p.Status
+		}
+	}
+
+	var changes []ProposalStatusChange
+	for _, e := range cur {
+		for _, p := range e.MyProposals {
+			if old, ok := before[p.ID]; !ok || old != p.Status {
+				changes = append(changes, ProposalStatusChange{
+					EventName:  e.Name,
+					ProposalID: p.ID,
+					Title:      p.Title,
+					Old:        old,
+					New:        p.Status,
+				})
+			}
+		}
+	}
+	return changes
+}
+
+// WatchDelay returns how long to wait before the next poll: the interval
+// (at least MinWatchInterval) plus up to 10% jitter, or retryAfter when the
+// server asked for longer
+func WatchDelay(interval, retryAfter time.Duration) time.Duration {
+	interval = max(interval, MinWatchInterval)
+	delay := interval + rand.N(interval/10)
+	return max(delay, retryAfter)
+}
+
+// Notify shows a desktop notification, best effort: it uses osascript on
+// macOS, a PowerShell toast on Windows and notify-send elsewhere, and returns
+// an error when the helper is missing or fails
+func Notify(title, message string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("osascript", "-e",
+			fmt.Sprintf("display notification %s with title %s", appleScriptString(message), appleScriptString(title)))
+	case "windows":
+		script := `[Windows.UI.Notifications.ToastNotificationManager, Windows.UI.Notifications, ContentType = WindowsRuntime] > $null
+$xml = [Windows.UI.Notifications.ToastNotificationManager]::GetTemplateContent([Windows.UI.Notifications.ToastTemplateType]::ToastText02)
+$text = $xml.GetElementsByTagName('text')
+$text.Item(0).AppendChild($xml.CreateTextNode($env:CFP_NOTIFY_TITLE)) > $null
+$text.Item(1).AppendChild($xml.CreateTextNode($env:CFP_NOTIFY_MESSAGE)) > $null
+[Windows.UI.Notifications.ToastNotificationManager]::CreateToastNotifier('cfp').Show([Windows.UI.Notifications.ToastNotification]::new($xml))`
+		cmd = exec.Command("powershell", "-NoProfile", "-NonInteractive", "-Command", script)
+		// Passed through the environment so the text is never parsed as script
+		cmd.Env = append(cmd.Environ(), "CFP_NOTIFY_TITLE="+title, "CFP_NOTIFY_MESSAGE="+message)
+	default:
+		cmd = exec.Command("notify-send", "--app-name=cfp", title, message)
+	}
+	if cmd.Err != nil {
+		// The helper isn't installed
+		return fmt.Errorf("desktop notifications are not available: %w", cmd.Err)
+	}
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("desktop notification failed: %w: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// appleScriptString quotes s as an AppleScript string literal
+func appleScriptString(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	return `"` + strings.ReplaceAll(s, `"`, `\"`) + `"`
+}
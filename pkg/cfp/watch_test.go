@@ -0,0 +1,51 @@
+package cfp
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDiffProposalStatuses(t *testing.T) {
+	prev := []SubmittedEvent{{Name: "GopherCon", MyProposals: []MyProposal{
+		{ID: 1, Title: "Generics", Status: "submitted"},
+		{ID: 2, Title: "Fuzzing", Status: "submitted"},
+	}}}
+	cur := []SubmittedEvent{
+		{Name: "GopherCon", MyProposals: []MyProposal{
+			{ID: 1, Title: "Generics", Status: "accepted"},
+			{ID: 2, Title: "Fuzzing", Status: "submitted"},
+		}},
+		{Name: "SREday", MyProposals: []MyProposal{{ID: 3, Title: "On-call", Status: "submitted"}}},
+	}
+
+	got := DiffProposalStatuses(prev, cur)
+	want := []ProposalStatusChange{
+		{EventName: "GopherCon", ProposalID: 1, Title: "Generics", Old: "submitted", New: "accepted"},
+		{EventName: "SREday", ProposalID: 3, Title: "On-call", New: "submitted"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+	if s := got[0].String(); s != "#1 Generics (GopherCon): submitted -> accepted" {
+		t.Errorf("unexpected description %q", s)
+	}
+
+	if changes := DiffProposalStatuses(cur, cur); len(changes) != 0 {
+		t.Errorf("expected no changes, got %+v", changes)
+	}
+}
+
+func TestWatchDelay(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		if d := WatchDelay(time.Minute, 0); d < time.Minute || d >= 66*time.Second {
+			t.Fatalf("expected a delay in [60s, 66s), got %s", d)
+		}
+	}
+	if d := WatchDelay(time.Second, 0); d < MinWatchInterval {
+		t.Errorf("expected at least %s, got %s", MinWatchInterval, d)
+	}
+	if d := WatchDelay(time.Minute, 5*time.Minute); d != 5*time.Minute {
+		t.Errorf("expected Retry-After to win, got %s", d)
+	}
+}
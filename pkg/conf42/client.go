@@ -19,6 +19,7 @@ type Client struct {
 type Metadata struct {
 	Events              []EventEntry `yaml:"events"`
 	DescriptionTemplate string       `yaml:"description_template"`
+	SummaryTemplate     string       `yaml:"summary_template"`
 }
 
 type EventEntry struct {
@@ -1,21 +1,32 @@
 package config
 
 import (
+	"context"
 	"crypto/rand"
 	"encoding/hex"
 	"flag"
 	"fmt"
+	"io"
 	"log/slog"
+	"net/http"
+	"net/url"
 	"os"
+	"path"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/sreday/cfp.ninja/pkg/email"
+	"github.com/sreday/cfp.ninja/pkg/geocode"
+	"github.com/sreday/cfp.ninja/pkg/geoip"
+	"github.com/sreday/cfp.ninja/pkg/logging"
 	"gorm.io/gorm"
 )
 
 type Config struct {
+	Version           string // Server version, set from main at build time
+	MinCLIVersion     string // Oldest cfp CLI version still supported, empty = no minimum
 	Port              string
 	DatabaseURL       string
 	AutoMigrate       bool
@@ -24,7 +35,18 @@ type Config struct {
 	AllowedOrigins    []string
 	TrustedProxies    []string
 	SyncInterval      time.Duration
+	SyncLogSample     int // Per-event lines of a sync run logged before sampling 1 in SyncLogSample, 0 = all
 	AutoOrganiserIDs  []uint
+	AdminEmails       []string // Platform administrators, matched case-insensitively
+
+	// CORS. AllowedOrigins may use a wildcard subdomain, e.g.
+	// "https://*.example.com". Anonymous GET requests from other origins are
+	// also allowed when they match CORSPublicOrigins ("*" by default).
+	// Credentials are only ever allowed for AllowedOrigins.
+	CORSPublicOrigins    []string
+	CORSAllowedMethods   []string
+	CORSAllowedHeaders   []string
+	CORSAllowCredentials bool
 
 	// Google OAuth
 	GoogleClientID     string
@@ -36,13 +58,42 @@ type Config struct {
 	GitHubClientSecret string
 	GitHubRedirectURL  string
 
-	// JWT
-	JWTSecret string
+	// OAuthRedirectHosts are the hosts (host or host:port, lowercase) logins
+	// may be served from, including those of the redirect URLs above. When
+	// set, the OAuth redirect URLs use the requesting host, so one binary can
+	// serve several domains, and logins from other hosts are rejected. Empty
+	// means the redirect URLs are used as configured.
+	OAuthRedirectHosts []string
+
+	// JWT. Browser sessions and CLI tokens expire after different lifetimes;
+	// zero means the default.
+	JWTSecret         string
+	BrowserSessionTTL time.Duration
+	CLITokenTTL       time.Duration
 
 	// Proposal limits
 	MaxProposalsPerEvent int
 	MaxOrganizersPerEvent int
 
+	// Abuse limits on proposal submissions. Admins and users flagged trusted
+	// are exempt. MaxProposalsPerHour caps a user's submissions across all
+	// events (0 = no cap); accounts younger than NewAccountCooldown may submit
+	// only one proposal per event (0 = no cool-down).
+	MaxProposalsPerHour int
+	NewAccountCooldown  time.Duration
+
+	// Events whose spam score at creation reaches SpamScoreThreshold are held
+	// in pending_review until an admin approves them (0 = never hold)
+	SpamScoreThreshold int
+
+	// MaxSpeakerEmailsPerDay caps how many bulk emails an event's organizers
+	// can send to its speakers in 24 hours (0 = no cap)
+	MaxSpeakerEmailsPerDay int
+
+	// CFPGracePeriod is how long after cfp_close_at proposal submissions are
+	// still accepted. Listings keep using the exact close time.
+	CFPGracePeriod time.Duration
+
 	// Stripe
 	StripeSecretKey              string
 	StripeWebhookSecret          string
@@ -51,12 +102,47 @@ type Config struct {
 	EventListingFeeCurrency      string // e.g. "usd"
 	SubmissionListingFee         int    // cents, default 100
 	SubmissionListingFeeCurrency string // e.g. "usd"
+	// ListingTermsVersion is the version of the platform terms event
+	// creators must accept before paying the listing fee. Bumping it
+	// requires a new acceptance for future checkouts only.
+	ListingTermsVersion string
 
 	// Email (Resend)
 	ResendAPIKey string
 	EmailFrom    string
 	BaseURL      string
 	EmailSender  email.Sender
+	// BasePath is the path prefix the app is served under, such as "/cfp",
+	// or empty at the root. BaseURL ends with it.
+	BasePath string
+	// EmailTemplatesDir holds optional overrides of the built-in email
+	// templates, with the same file names
+	EmailTemplatesDir string
+
+	// Weekly digest: sent on DigestDay at DigestHour:00 UTC, at most
+	// DigestSendsPerSecond emails per second
+	DigestDay            time.Weekday
+	DigestHour           int
+	DigestSendsPerSecond int
+
+	// Geocoding (event coordinates for near-me searches)
+	GeocoderProvider string // "nominatim" or "none"
+	NominatimURL     string
+	Geocoder         geocode.Geocoder
+
+	// Login history. Logins are kept for LoginRetention; GeoIPURL, when
+	// set, resolves login IPs to countries for new-country alerts.
+	LoginRetention time.Duration
+	GeoIPURL       string
+	GeoIP          geoip.Locator
+
+	// Event archival. Events are archived ArchiveAfter their end date and
+	// their proposals' speaker details scrubbed ScrubAfter that, with the
+	// creator emailed ScrubNotice beforehand. ArchiveDryRun only logs.
+	ArchiveAfter  time.Duration
+	ScrubAfter    time.Duration
+	ScrubNotice   time.Duration
+	ArchiveDryRun bool
 
 	// Legal entity (for Terms & Conditions page)
 	LegalName    string
@@ -64,6 +150,10 @@ type Config struct {
 	LegalEmail   string
 	LegalCompanyNo string
 
+	// Clock returns the current time. Nil in production (time.Now);
+	// tests can set this to freeze time around deadlines.
+	Clock func() time.Time
+
 	// OnBackgroundDone is called when a SafeGo goroutine finishes.
 	// Nil in production; tests can set this to observe background work.
 	OnBackgroundDone func()
@@ -72,10 +162,30 @@ type Config struct {
 	// (e.g. rate limiter cleanup loops). Set by RegisterRoutes.
 	Cleanup func()
 
-	DB     *gorm.DB
+	// DBStatementTimeout bounds each query a request runs through DBCtx
+	// (0 for no bound)
+	DBStatementTimeout time.Duration
+
+	DB *gorm.DB
+	// Logger is the api module's logger; other packages log through their
+	// own module's, see logging.Module
 	Logger *slog.Logger
 }
 
+// Default token lifetimes, used when BROWSER_SESSION_TTL and CLI_TOKEN_TTL
+// are unset
+const (
+	DefaultBrowserSessionTTL = 24 * time.Hour
+	DefaultCLITokenTTL       = 30 * 24 * time.Hour
+)
+
+// Default CORS methods and request headers, used when CORS_ALLOWED_METHODS
+// and CORS_ALLOWED_HEADERS are unset
+var (
+	DefaultCORSAllowedMethods = []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"}
+	DefaultCORSAllowedHeaders = []string{"Accept", "Content-Type", "Content-Length", "Accept-Encoding", "X-CSRF-Token", "Authorization"}
+)
+
 func InitConfig() (*Config, error) {
 	port := flag.String("port", "", "port to listen on")
 	autoMigrate := flag.Bool("auto-migrate", false, "enable auto-migration")
@@ -143,6 +253,24 @@ func InitConfig() (*Config, error) {
 	}
 
 	// Trusted proxies
+	corsPublicOrigins := []string{"*"}
+	if v, ok := os.LookupEnv("CORS_PUBLIC_ORIGINS"); ok {
+		corsPublicOrigins = splitList(v)
+	}
+	corsAllowedMethods := DefaultCORSAllowedMethods
+	if v := splitList(os.Getenv("CORS_ALLOWED_METHODS")); len(v) > 0 {
+		corsAllowedMethods = v
+	}
+	corsAllowedHeaders := DefaultCORSAllowedHeaders
+	if v := splitList(os.Getenv("CORS_ALLOWED_HEADERS")); len(v) > 0 {
+		corsAllowedHeaders = v
+	}
+	for _, origin := range append(append([]string{}, allowedOrigins...), corsPublicOrigins...) {
+		if !validOriginPattern(origin) {
+			return nil, fmt.Errorf("invalid CORS origin %q: use \"*\", an origin such as https://example.com or a wildcard subdomain such as https://*.example.com", origin)
+		}
+	}
+
 	trustedProxiesStr := os.Getenv("TRUSTED_PROXIES")
 	var trustedProxies []string
 	if trustedProxiesStr != "" {
@@ -154,7 +282,19 @@ func InitConfig() (*Config, error) {
 		}
 	}
 
-	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	root := newLogger(os.Stdout)
+	// Package-level slog calls, as in pkg/models, go through it too
+	slog.SetDefault(root)
+	logger := logging.Module(root, logging.Server)
+
+	syncLogSample := 20
+	if v := os.Getenv("LOG_SYNC_SAMPLE"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n >= 0 {
+			syncLogSample = n
+		} else {
+			logger.Warn("LOG_SYNC_SAMPLE is set but not a valid non-negative integer, using default", "value", v)
+		}
+	}
 
 	// Google OAuth
 	googleClientID := os.Getenv("GOOGLE_CLIENT_ID")
@@ -166,6 +306,12 @@ func InitConfig() (*Config, error) {
 	gitHubClientSecret := os.Getenv("GITHUB_CLIENT_SECRET")
 	gitHubRedirectURL := os.Getenv("GITHUB_REDIRECT_URL")
 
+	// Hosts logins may be served from, for multi-domain deployments
+	oauthRedirectHosts, err := parseOAuthRedirectHosts(os.Getenv("OAUTH_REDIRECT_HOSTS"), googleRedirectURL, gitHubRedirectURL)
+	if err != nil {
+		return nil, err
+	}
+
 	// JWT
 	jwtSecret := os.Getenv("JWT_SECRET")
 
@@ -200,6 +346,24 @@ func InitConfig() (*Config, error) {
 	if insecureMode {
 		logger.Warn("WARNING: Running in INSECURE mode - all authentication is bypassed")
 	}
+
+	browserSessionTTL := DefaultBrowserSessionTTL
+	if v := os.Getenv("BROWSER_SESSION_TTL"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d > 0 {
+			browserSessionTTL = d
+		} else {
+			logger.Warn("BROWSER_SESSION_TTL is set but not a valid positive duration, using default", "value", v)
+		}
+	}
+
+	cliTokenTTL := DefaultCLITokenTTL
+	if v := os.Getenv("CLI_TOKEN_TTL"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d > 0 {
+			cliTokenTTL = d
+		} else {
+			logger.Warn("CLI_TOKEN_TTL is set but not a valid positive duration, using default", "value", v)
+		}
+	}
 	// AUTO_ORGANISERS_IDS
 	var autoOrganiserIDs []uint
 	if idsStr := os.Getenv("AUTO_ORGANISERS_IDS"); idsStr != "" {
@@ -219,6 +383,14 @@ func InitConfig() (*Config, error) {
 		logger.Warn("AUTO_ORGANISERS_IDS not set - event sync disabled")
 	}
 
+	// ADMIN_EMAILS
+	var adminEmails []string
+	for _, e := range strings.Split(os.Getenv("ADMIN_EMAILS"), ",") {
+		if e = strings.ToLower(strings.TrimSpace(e)); e != "" {
+			adminEmails = append(adminEmails, e)
+		}
+	}
+
 	// Proposal limits
 	maxProposalsPerEvent := 3
 	if v := os.Getenv("MAX_PROPOSALS_PER_EVENT"); v != "" {
@@ -238,6 +410,60 @@ func InitConfig() (*Config, error) {
 		}
 	}
 
+	maxProposalsPerHour := 10
+	if v := os.Getenv("MAX_PROPOSALS_PER_HOUR"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n >= 0 {
+			maxProposalsPerHour = n
+		} else {
+			logger.Warn("MAX_PROPOSALS_PER_HOUR is set but not a valid non-negative integer, using default", "value", v)
+		}
+	}
+
+	newAccountCooldown := 24 * time.Hour
+	if v := os.Getenv("NEW_ACCOUNT_COOLDOWN"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d >= 0 {
+			newAccountCooldown = d
+		} else {
+			logger.Warn("NEW_ACCOUNT_COOLDOWN is set but not a valid non-negative duration, using default", "value", v)
+		}
+	}
+
+	spamScoreThreshold := 50
+	if v := os.Getenv("SPAM_SCORE_THRESHOLD"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n >= 0 {
+			spamScoreThreshold = n
+		} else {
+			logger.Warn("SPAM_SCORE_THRESHOLD is set but not a valid non-negative integer, using default", "value", v)
+		}
+	}
+
+	maxSpeakerEmailsPerDay := 3
+	if v := os.Getenv("MAX_SPEAKER_EMAILS_PER_DAY"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n >= 0 {
+			maxSpeakerEmailsPerDay = n
+		} else {
+			logger.Warn("MAX_SPEAKER_EMAILS_PER_DAY is set but not a valid non-negative integer, using default", "value", v)
+		}
+	}
+
+	cfpGracePeriod := 15 * time.Minute
+	if v := os.Getenv("CFP_GRACE_PERIOD"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d >= 0 {
+			cfpGracePeriod = d
+		} else {
+			logger.Warn("CFP_GRACE_PERIOD is set but not a valid non-negative duration, using default", "value", v)
+		}
+	}
+
+	dbStatementTimeout := 30 * time.Second
+	if v := os.Getenv("DB_STATEMENT_TIMEOUT"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d >= 0 {
+			dbStatementTimeout = d
+		} else {
+			logger.Warn("DB_STATEMENT_TIMEOUT is set but not a valid non-negative duration, using default", "value", v)
+		}
+	}
+
 	// Stripe
 	stripeSecretKey := os.Getenv("STRIPE_SECRET_KEY")
 	stripeWebhookSecret := os.Getenv("STRIPE_WEBHOOK_SECRET")
@@ -269,6 +495,11 @@ func InitConfig() (*Config, error) {
 		submissionListingFeeCurrency = "usd"
 	}
 
+	listingTermsVersion := strings.TrimSpace(os.Getenv("LISTING_TERMS_VERSION"))
+	if listingTermsVersion == "" {
+		listingTermsVersion = "1"
+	}
+
 	// Stripe validation
 	hasStripeKey := stripeSecretKey != ""
 	hasStripePubKey := stripePublishableKey != ""
@@ -295,6 +526,7 @@ func InitConfig() (*Config, error) {
 			"event_listing_fee_currency", eventListingFeeCurrency,
 			"submission_listing_fee", submissionListingFee,
 			"submission_listing_fee_currency", submissionListingFeeCurrency,
+			"listing_terms_version", listingTermsVersion,
 			"webhook_secret_set", stripeWebhookSecret != "",
 		)
 	} else {
@@ -307,6 +539,15 @@ func InitConfig() (*Config, error) {
 	if baseURL == "" {
 		baseURL = "https://cfp.ninja"
 	}
+	basePath, baseURL, err := parseBasePath(os.Getenv("BASE_PATH"), baseURL)
+	if err != nil {
+		return nil, err
+	}
+	for _, redirectURL := range []string{googleRedirectURL, gitHubRedirectURL} {
+		if u, err := url.Parse(redirectURL); basePath != "" && err == nil && redirectURL != "" && !strings.HasPrefix(u.Path, basePath+"/") {
+			logger.Warn("OAuth redirect URL is outside BASE_PATH", "url", redirectURL, "base_path", basePath)
+		}
+	}
 	emailSubdomain := os.Getenv("EMAIL_SUBDOMAIN")
 	if emailSubdomain == "" {
 		emailSubdomain = "updates"
@@ -327,6 +568,90 @@ func InitConfig() (*Config, error) {
 		logger.Warn("RESEND_API_KEY not set - email notifications disabled")
 	}
 
+	// Weekly digest schedule (UTC) and send rate. Resend allows 2 requests
+	// per second by default.
+	digestDay := time.Monday
+	if v := os.Getenv("DIGEST_DAY"); v != "" {
+		if d, ok := parseWeekday(v); ok {
+			digestDay = d
+		} else {
+			logger.Warn("DIGEST_DAY is set but not a valid weekday, using default", "value", v)
+		}
+	}
+	digestHour := 9
+	if v := os.Getenv("DIGEST_HOUR"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n >= 0 && n <= 23 {
+			digestHour = n
+		} else {
+			logger.Warn("DIGEST_HOUR is set but not an hour between 0 and 23, using default", "value", v)
+		}
+	}
+	digestSendsPerSecond := 2
+	if v := os.Getenv("DIGEST_SENDS_PER_SECOND"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			digestSendsPerSecond = n
+		} else {
+			logger.Warn("DIGEST_SENDS_PER_SECOND is set but not a valid positive integer, using default", "value", v)
+		}
+	}
+
+	// Geocoding
+	geocoderProvider := strings.ToLower(strings.TrimSpace(os.Getenv("GEOCODER")))
+	if geocoderProvider == "" {
+		geocoderProvider = "nominatim"
+	}
+	if geocoderProvider != "nominatim" && geocoderProvider != "none" {
+		logger.Warn("GEOCODER is not a known provider, geocoding disabled", "value", geocoderProvider)
+		geocoderProvider = "none"
+	}
+
+	// Login history
+	loginRetention := 180 * 24 * time.Hour
+	if v := os.Getenv("LOGIN_RETENTION"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d > 0 {
+			loginRetention = d
+		} else {
+			logger.Warn("LOGIN_RETENTION is set but not a valid positive duration, using default", "value", v)
+		}
+	}
+	geoIPURL := strings.TrimSpace(os.Getenv("GEOIP_URL"))
+	if geoIPURL != "" && !strings.Contains(geoIPURL, "{ip}") {
+		logger.Warn("GEOIP_URL has no {ip} placeholder, login country lookups disabled", "value", geoIPURL)
+		geoIPURL = ""
+	}
+
+	// Event archival thresholds
+	archiveAfter := 2 * 365 * 24 * time.Hour
+	if v := os.Getenv("ARCHIVE_AFTER"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d > 0 {
+			archiveAfter = d
+		} else {
+			logger.Warn("ARCHIVE_AFTER is set but not a valid positive duration, using default", "value", v)
+		}
+	}
+	scrubAfter := 365 * 24 * time.Hour
+	if v := os.Getenv("SCRUB_AFTER"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d > 0 {
+			scrubAfter = d
+		} else {
+			logger.Warn("SCRUB_AFTER is set but not a valid positive duration, using default", "value", v)
+		}
+	}
+	scrubNotice := 30 * 24 * time.Hour
+	if v := os.Getenv("SCRUB_NOTICE"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d > 0 {
+			scrubNotice = d
+		} else {
+			logger.Warn("SCRUB_NOTICE is set but not a valid positive duration, using default", "value", v)
+		}
+	}
+	if scrubNotice > scrubAfter {
+		logger.Warn("SCRUB_NOTICE is longer than SCRUB_AFTER, notices will go out at archival", "scrub_notice", scrubNotice, "scrub_after", scrubAfter)
+	}
+
+	// Oldest CLI release that still works against this server
+	minCLIVersion := strings.TrimSpace(os.Getenv("MIN_CLI_VERSION"))
+
 	// Legal entity (for Terms & Conditions page)
 	legalName := os.Getenv("LEGAL_NAME")
 	if legalName == "" {
@@ -354,24 +679,40 @@ func InitConfig() (*Config, error) {
 	}
 
 	return &Config{
+		Version:           "dev",
+		MinCLIVersion:     minCLIVersion,
 		Port:              portVal,
 		DatabaseURL:       dsn,
 		AutoMigrate:       *autoMigrate || isTruthy(os.Getenv("DATABASE_AUTO_MIGRATE")),
 		Insecure:          insecureMode,
 		InsecureUserEmail: os.Getenv("INSECURE_USER_EMAIL"),
 		AllowedOrigins:    allowedOrigins,
+		CORSPublicOrigins:    corsPublicOrigins,
+		CORSAllowedMethods:   corsAllowedMethods,
+		CORSAllowedHeaders:   corsAllowedHeaders,
+		CORSAllowCredentials: isTruthy(os.Getenv("CORS_ALLOW_CREDENTIALS")),
 		TrustedProxies:    trustedProxies,
 		SyncInterval:      syncIntervalVal,
 		AutoOrganiserIDs:  autoOrganiserIDs,
+		AdminEmails:       adminEmails,
 		GoogleClientID:     googleClientID,
 		GoogleClientSecret: googleClientSecret,
 		GoogleRedirectURL:  googleRedirectURL,
 		GitHubClientID:     gitHubClientID,
 		GitHubClientSecret: gitHubClientSecret,
 		GitHubRedirectURL:  gitHubRedirectURL,
+		OAuthRedirectHosts: oauthRedirectHosts,
 		JWTSecret:          jwtSecret,
+		BrowserSessionTTL:  browserSessionTTL,
+		CLITokenTTL:        cliTokenTTL,
 		MaxProposalsPerEvent:         maxProposalsPerEvent,
 		MaxOrganizersPerEvent:        maxOrganizersPerEvent,
+		MaxProposalsPerHour:          maxProposalsPerHour,
+		NewAccountCooldown:           newAccountCooldown,
+		SpamScoreThreshold:           spamScoreThreshold,
+		MaxSpeakerEmailsPerDay:       maxSpeakerEmailsPerDay,
+		CFPGracePeriod:               cfpGracePeriod,
+		DBStatementTimeout:           dbStatementTimeout,
 		StripeSecretKey:              stripeSecretKey,
 		StripeWebhookSecret:          stripeWebhookSecret,
 		StripePublishableKey:         stripePublishableKey,
@@ -379,18 +720,133 @@ func InitConfig() (*Config, error) {
 		EventListingFeeCurrency:      eventListingFeeCurrency,
 		SubmissionListingFee:         submissionListingFee,
 		SubmissionListingFeeCurrency: submissionListingFeeCurrency,
+		ListingTermsVersion:          listingTermsVersion,
 		ResendAPIKey:                 resendAPIKey,
 		EmailFrom:                    emailFrom,
 		BaseURL:                      baseURL,
+		BasePath:                     basePath,
+		EmailTemplatesDir:            strings.TrimSpace(os.Getenv("EMAIL_TEMPLATES_DIR")),
+		DigestDay:                    digestDay,
+		DigestHour:                   digestHour,
+		DigestSendsPerSecond:         digestSendsPerSecond,
+		GeocoderProvider:             geocoderProvider,
+		NominatimURL:                 os.Getenv("NOMINATIM_URL"),
+		LoginRetention:               loginRetention,
+		GeoIPURL:                     geoIPURL,
+		ArchiveAfter:                 archiveAfter,
+		ScrubAfter:                   scrubAfter,
+		ScrubNotice:                  scrubNotice,
+		ArchiveDryRun:                isTruthy(os.Getenv("ARCHIVE_DRY_RUN")),
 		LegalName:                    legalName,
 		LegalAddress:                 legalAddress,
 		LegalEmail:                   legalEmail,
 		LegalCompanyNo:               legalCompanyNo,
-		Logger:                       logger,
+		SyncLogSample:                syncLogSample,
+		Logger:                       logging.Module(root, logging.API),
 	}, nil
 }
 
+// Now returns the current time in UTC from Clock, or time.Now when Clock is
+// unset
+func (c *Config) Now() time.Time {
+	if c.Clock != nil {
+		return c.Clock().UTC()
+	}
+	return time.Now().UTC()
+}
+
+// DBCtx returns DB bound to the request's context, so queries stop when the
+// client disconnects or the server gives up on the request, and each query
+// chain started from it runs for at most DBStatementTimeout. Background work
+// must use DB with its own context instead: the request's context is
+// cancelled as soon as the response is sent.
+func (c *Config) DBCtx(r *http.Request) *gorm.DB {
+	ctx := r.Context()
+	if c.DBStatementTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.DBStatementTimeout)
+		// Release the timer with the request rather than at the deadline
+		context.AfterFunc(r.Context(), cancel)
+	}
+	return c.DB.WithContext(ctx)
+}
+
+// IsAdmin reports whether email belongs to a platform administrator
+func (c *Config) IsAdmin(email string) bool {
+	email = strings.ToLower(strings.TrimSpace(email))
+	if email == "" {
+		return false
+	}
+	for _, e := range c.AdminEmails {
+		if e == email {
+			return true
+		}
+	}
+	return false
+}
+
+// parseOAuthRedirectHosts parses OAUTH_REDIRECT_HOSTS, a comma-separated list
+// of hosts such as "cfp.ninja,staging.cfp.ninja:8443", adding the hosts of
+// the configured redirect URLs. It returns nil when the list is empty.
+func parseOAuthRedirectHosts(list string, redirectURLs ...string) ([]string, error) {
+	var hosts []string
+	for _, h := range strings.Split(list, ",") {
+		h = strings.ToLower(strings.TrimSpace(h))
+		if h == "" {
+			continue
+		}
+		if strings.ContainsAny(h, "/?#@") {
+			return nil, fmt.Errorf("invalid OAUTH_REDIRECT_HOSTS entry %q: must be a host, optionally with a port", h)
+		}
+		hosts = append(hosts, h)
+	}
+	if len(hosts) == 0 {
+		return nil, nil
+	}
+	for _, raw := range redirectURLs {
+		if raw == "" {
+			continue
+		}
+		u, err := url.Parse(raw)
+		if err != nil || u.Host == "" {
+			return nil, fmt.Errorf("invalid OAuth redirect URL %q", raw)
+		}
+		if h := strings.ToLower(u.Host); !slices.Contains(hosts, h) {
+			hosts = append(hosts, h)
+		}
+	}
+	return hosts, nil
+}
+
 // isTruthy returns true for common truthy environment variable values.
+// splitList splits a comma-separated list, dropping blank entries
+func splitList(s string) []string {
+	var list []string
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			list = append(list, v)
+		}
+	}
+	return list
+}
+
+// validOriginPattern reports whether an allowed CORS origin is "*", an
+// origin (scheme://host[:port]) or one with a wildcard as its leftmost
+// host label, e.g. "https://*.example.com"
+func validOriginPattern(origin string) bool {
+	if origin == "*" {
+		return true
+	}
+	scheme, host, ok := strings.Cut(origin, "://")
+	if !ok || scheme == "" || host == "" || strings.ContainsAny(host, "/?#") {
+		return false
+	}
+	if rest, wildcard := strings.CutPrefix(host, "*."); wildcard {
+		host = rest
+	}
+	return host != "" && !strings.Contains(host, "*")
+}
+
 func isTruthy(s string) bool {
 	switch strings.ToLower(s) {
 	case "true", "1", "yes":
@@ -399,6 +855,87 @@ func isTruthy(s string) bool {
 	return false
 }
 
+// newLogger builds the root logger from LOG_FORMAT, LOG_LEVEL and the
+// per-module LOG_LEVEL_<MODULE> overrides, such as LOG_LEVEL_PAYMENTS=debug.
+// Invalid values fall back to the defaults, JSON at info.
+func newLogger(w io.Writer) *slog.Logger {
+	opts := logging.Options{
+		Format:       strings.ToLower(strings.TrimSpace(os.Getenv("LOG_FORMAT"))),
+		ModuleLevels: make(map[string]slog.Level),
+	}
+	var invalid []string
+	if v := os.Getenv("LOG_LEVEL"); v != "" {
+		if level, err := logging.ParseLevel(v); err == nil {
+			opts.Level = level
+		} else {
+			invalid = append(invalid, "LOG_LEVEL")
+		}
+	}
+	for _, module := range logging.Modules {
+		key := "LOG_LEVEL_" + strings.ToUpper(module)
+		if v := os.Getenv(key); v != "" {
+			if level, err := logging.ParseLevel(v); err == nil {
+				opts.ModuleLevels[module] = level
+			} else {
+				invalid = append(invalid, key)
+			}
+		}
+	}
+
+	logger, err := logging.New(w, opts)
+	if err != nil {
+		invalid = append(invalid, "LOG_FORMAT")
+		opts.Format = logging.FormatJSON
+		logger, _ = logging.New(w, opts)
+	}
+	for _, key := range invalid {
+		logging.Module(logger, logging.Server).Warn(key+" is set but not valid, using default", "value", os.Getenv(key))
+	}
+	return logger
+}
+
+// parseWeekday parses a weekday name such as "monday" or "Mon".
+func parseWeekday(s string) (time.Weekday, bool) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if len(s) < 3 {
+		return 0, false
+	}
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		if strings.HasPrefix(strings.ToLower(d.String()), s) {
+			return d, true
+		}
+	}
+	return 0, false
+}
+
+// parseBasePath validates BASE_PATH and reconciles it with BASE_URL, returning
+// both. Without BASE_PATH, the path of BASE_URL is used; with it, BASE_URL
+// gets it appended unless it already ends with it.
+func parseBasePath(basePath, baseURL string) (string, string, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil || u.Host == "" {
+		return "", "", fmt.Errorf("invalid BASE_URL %q", baseURL)
+	}
+	urlPath := strings.TrimRight(u.Path, "/")
+
+	basePath = strings.TrimRight(strings.TrimSpace(basePath), "/")
+	if basePath == "" {
+		basePath = urlPath
+	}
+	if basePath != "" && (!strings.HasPrefix(basePath, "/") || path.Clean(basePath) != basePath || strings.ContainsAny(basePath, "?#%")) {
+		return "", "", fmt.Errorf("invalid BASE_PATH %q: must be an absolute path such as /cfp", basePath)
+	}
+	switch urlPath {
+	case basePath:
+	case "":
+		u.Path = basePath
+	default:
+		return "", "", fmt.Errorf("BASE_URL %q doesn't end with BASE_PATH %q", baseURL, basePath)
+	}
+	u.RawPath = ""
+	return basePath, strings.TrimRight(u.String(), "/"), nil
+}
+
 // extractHost returns the hostname from a URL, falling back to the raw string.
 func extractHost(rawURL string) string {
 	// Simple approach: strip scheme and path
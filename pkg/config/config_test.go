@@ -1,6 +1,13 @@
 package config
 
-import "testing"
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sreday/cfp.ninja/pkg/logging"
+)
 
 func TestExtractHost(t *testing.T) {
 	tests := []struct {
@@ -24,3 +31,132 @@ func TestExtractHost(t *testing.T) {
 		})
 	}
 }
+
+func TestIsAdmin(t *testing.T) {
+	cfg := &Config{AdminEmails: []string{"admin@example.com"}}
+	for email, want := range map[string]bool{
+		"admin@example.com":   true,
+		" Admin@Example.com ": true,
+		"other@example.com":   false,
+		"":                    false,
+	} {
+		if got := cfg.IsAdmin(email); got != want {
+			t.Errorf("IsAdmin(%q) = %v, want %v", email, got, want)
+		}
+	}
+}
+
+func TestParseWeekday(t *testing.T) {
+	for input, want := range map[string]time.Weekday{
+		"monday":   time.Monday,
+		" Friday ": time.Friday,
+		"SUN":      time.Sunday,
+		"wed":      time.Wednesday,
+	} {
+		if got, ok := parseWeekday(input); !ok || got != want {
+			t.Errorf("parseWeekday(%q) = %v, %v, want %v", input, got, ok, want)
+		}
+	}
+	for _, input := range []string{"", "mo", "funday", "8"} {
+		if _, ok := parseWeekday(input); ok {
+			t.Errorf("parseWeekday(%q) succeeded, want failure", input)
+		}
+	}
+}
+
+func TestParseOAuthRedirectHosts(t *testing.T) {
+	hosts, err := parseOAuthRedirectHosts(" Staging.cfp.ninja , localhost:8080,", "https://cfp.ninja/api/v0/auth/google/callback", "https://cfp.ninja/api/v0/auth/github/callback")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"staging.cfp.ninja", "localhost:8080", "cfp.ninja"}
+	if strings.Join(hosts, ",") != strings.Join(want, ",") {
+		t.Errorf("hosts = %q, want %q", hosts, want)
+	}
+
+	// Unset keeps the configured redirect URLs as they are
+	if hosts, err := parseOAuthRedirectHosts(" ", "https://cfp.ninja/cb"); err != nil || hosts != nil {
+		t.Errorf("empty list: got %q, %v", hosts, err)
+	}
+
+	for _, bad := range []string{"https://cfp.ninja", "cfp.ninja/path", "user@cfp.ninja"} {
+		if _, err := parseOAuthRedirectHosts(bad); err == nil {
+			t.Errorf("expected an error for %q", bad)
+		}
+	}
+}
+
+func TestParseBasePath(t *testing.T) {
+	tests := []struct {
+		basePath, baseURL         string
+		wantBasePath, wantBaseURL string
+	}{
+		{"", "https://cfp.ninja", "", "https://cfp.ninja"},
+		{"", "https://cfp.ninja/", "", "https://cfp.ninja"},
+		{"/cfp", "https://community.example.com", "/cfp", "https://community.example.com/cfp"},
+		{"/cfp/", "https://community.example.com/cfp/", "/cfp", "https://community.example.com/cfp"},
+		{"", "https://community.example.com/cfp", "/cfp", "https://community.example.com/cfp"},
+		{"/", "https://cfp.ninja", "", "https://cfp.ninja"},
+		{"/community/cfp", "http://localhost:8080", "/community/cfp", "http://localhost:8080/community/cfp"},
+	}
+	for _, tt := range tests {
+		basePath, baseURL, err := parseBasePath(tt.basePath, tt.baseURL)
+		if err != nil || basePath != tt.wantBasePath || baseURL != tt.wantBaseURL {
+			t.Errorf("parseBasePath(%q, %q) = %q, %q, %v; want %q, %q", tt.basePath, tt.baseURL, basePath, baseURL, err, tt.wantBasePath, tt.wantBaseURL)
+		}
+	}
+
+	for _, bad := range [][2]string{
+		{"cfp", "https://cfp.ninja"},
+		{"/cfp/../admin", "https://cfp.ninja"},
+		{"/cfp?x=1", "https://cfp.ninja"},
+		{"/cfp", "https://community.example.com/other"},
+		{"", "cfp.ninja"},
+	} {
+		if _, _, err := parseBasePath(bad[0], bad[1]); err == nil {
+			t.Errorf("expected an error for BASE_PATH %q with BASE_URL %q", bad[0], bad[1])
+		}
+	}
+}
+
+func TestNewLogger(t *testing.T) {
+	t.Setenv("LOG_FORMAT", "text")
+	t.Setenv("LOG_LEVEL", "warn")
+	t.Setenv("LOG_LEVEL_PAYMENTS", "debug")
+	t.Setenv("LOG_LEVEL_TASKS", "verbose")
+
+	var buf bytes.Buffer
+	root := newLogger(&buf)
+	if !strings.Contains(buf.String(), "LOG_LEVEL_TASKS is set but not valid") {
+		t.Errorf("expected a warning about LOG_LEVEL_TASKS, got %q", buf.String())
+	}
+	buf.Reset()
+
+	logging.Module(root, logging.API).Info("api info")
+	logging.Module(root, logging.Tasks).Info("tasks info")
+	logging.Module(root, logging.Payments).Debug("payments debug")
+	if got := buf.String(); strings.Contains(got, "api info") || strings.Contains(got, "tasks info") ||
+		!strings.Contains(got, "msg=\"payments debug\" module=payments") {
+		t.Errorf("unexpected output:\n%s", got)
+	}
+}
+
+func TestValidOriginPattern(t *testing.T) {
+	for origin, want := range map[string]bool{
+		"*":                          true,
+		"https://example.com":        true,
+		"http://localhost:5173":      true,
+		"https://*.example.com":      true,
+		"https://*.example.com:8443": true,
+		"example.com":                false,
+		"https://":                   false,
+		"https://*":                  false,
+		"https://*example.com":       false,
+		"https://cfp.*.example.com":  false,
+		"https://example.com/widget": false,
+	} {
+		if got := validOriginPattern(origin); got != want {
+			t.Errorf("validOriginPattern(%q) = %v, want %v", origin, got, want)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+// Package countries maps free-form country input to ISO 3166-1 alpha-2 codes.
+package countries
+
+import (
+	"sort"
+	"strings"
+)
+
+// Country is an ISO 3166-1 country
+type Country struct {
+	Code   string   // ISO 3166-1 alpha-2, e.g. "GB"
+	Alpha3 string   // ISO 3166-1 alpha-3, e.g. "GBR"
+	Name   string   // Display name, e.g. "United Kingdom"
+	Other  []string // Other accepted names
+}
+
+// aliases maps lowercase common names and abbreviations that are not ISO names
+// to codes. Extends the event sync's country normalization map.
+var aliases = map[string]string{
+	// United States, including states that sometimes appear instead of the country
+	"america": "US", "u.s": "US", "u.s.a": "US",
+	"ny": "US", "new york": "US", "texas": "US", "california": "US",
+	// United Kingdom
+	"uk": "GB", "u.k": "GB", "england": "GB", "scotland": "GB", "wales": "GB",
+	"northern ireland": "GB", "great britain": "GB", "britain": "GB",
+	// Other common variations
+	"the netherlands": "NL", "holland": "NL",
+	"deutschland":    "DE",
+	"brasil":         "BR",
+	"czech republic": "CZ",
+	"españa":         "ES",
+	"italia":         "IT",
+	"schweiz":        "CH", "suisse": "CH",
+	"österreich": "AT",
+	"polska":     "PL",
+	"korea":      "KR", "republic of korea": "KR",
+	"russia": "RU",
+	"turkey": "TR", "türkiye": "TR",
+	"uae": "AE",
+}
+
+var (
+	byCode = make(map[string]*Country, len(iso3166))
+	byName = make(map[string]string, len(iso3166)*3)
+)
+
+func init() {
+	for i := range iso3166 {
+		c := &iso3166[i]
+		byCode[c.Code] = c
+		byName[strings.ToLower(c.Alpha3)] = c.Code
+		byName[strings.ToLower(c.Name)] = c.Code
+		for _, n := range c.Other {
+			byName[strings.ToLower(n)] = c.Code
+		}
+	}
+	for alias, code := range aliases {
+		byName[alias] = code
+	}
+}
+
+// Lookup resolves a country code (alpha-2 or alpha-3), ISO name or common name to
+// its alpha-2 code. Matching is case-insensitive and ignores surrounding spaces
+// and trailing periods.
+func Lookup(raw string) (string, bool) {
+	s := strings.TrimSpace(strings.TrimRight(strings.TrimSpace(raw), "."))
+	if s == "" {
+		return "", false
+	}
+	if c, ok := byCode[strings.ToUpper(s)]; ok && len(s) == 2 {
+		return c.Code, true
+	}
+	if code, ok := byName[strings.ToLower(s)]; ok {
+		return code, true
+	}
+	return "", false
+}
+
+// Name returns the display name of an alpha-2 code, or "" if the code is unknown
+func Name(code string) string {
+	if c, ok := byCode[strings.ToUpper(code)]; ok {
+		return c.Name
+	}
+	return ""
+}
+
+// All returns every ISO 3166-1 country, sorted by display name
+func All() []Country {
+	all := make([]Country, len(iso3166))
+	copy(all, iso3166)
+	sort.Slice(all, func(i, j int) bool { return all[i].Name < all[j].Name })
+	return all
+}
@@ -0,0 +1,74 @@
+package countries
+
+import "testing"
+
+func TestLookup(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want string
+		ok   bool
+	}{
+		{"US", "US", true},
+		{"us", "US", true},
+		{"USA", "US", true},
+		{"United States", "US", true},
+		{"united states of america", "US", true},
+		{"U.S.A.", "US", true},
+		{"Texas", "US", true},
+		{"UK", "GB", true},
+		{"GB", "GB", true},
+		{"England", "GB", true},
+		{"United Kingdom", "GB", true},
+		{"France.", "FR", true},
+		{"  Germany ", "DE", true},
+		{"Deutschland", "DE", true},
+		{"The Netherlands", "NL", true},
+		{"Czech Republic", "CZ", true},
+		{"Czechia", "CZ", true},
+		{"South Korea", "KR", true},
+		{"Viet Nam", "VN", true},
+		{"PRT", "PT", true},
+		{"", "", false},
+		{"Atlantis", "", false},
+		{"XX", "", false},
+		{"Online", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			got, ok := Lookup(tt.raw)
+			if ok != tt.ok || got != tt.want {
+				t.Errorf("expected (%q, %v), got (%q, %v)", tt.want, tt.ok, got, ok)
+			}
+		})
+	}
+}
+
+func TestName(t *testing.T) {
+	if got := Name("GB"); got != "United Kingdom" {
+		t.Errorf("expected United Kingdom, got %q", got)
+	}
+	if got := Name("us"); got != "United States" {
+		t.Errorf("expected United States, got %q", got)
+	}
+	if got := Name("XX"); got != "" {
+		t.Errorf("expected empty name for unknown code, got %q", got)
+	}
+}
+
+func TestAll(t *testing.T) {
+	all := All()
+	if len(all) != 249 {
+		t.Errorf("expected 249 countries, got %d", len(all))
+	}
+	for i := 1; i < len(all); i++ {
+		if all[i-1].Name > all[i].Name {
+			t.Fatalf("expected countries sorted by name, got %q before %q", all[i-1].Name, all[i].Name)
+		}
+	}
+	// All returns a copy
+	all[0].Name = "changed"
+	if All()[0].Name == "changed" {
+		t.Error("expected All to return a copy")
+	}
+}
@@ -1,10 +1,14 @@
 package database
 
 import (
+	"context"
 	"os"
 	"strconv"
 	"time"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/stdlib"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
@@ -24,15 +28,20 @@ func InitDB(dsn string) (*gorm.DB, error) {
 		logLevel = logger.Silent
 	}
 
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
-		Logger: logger.Default.LogMode(logLevel),
-	})
+	pgxConfig, err := pgx.ParseConfig(dsn)
 	if err != nil {
 		return nil, err
 	}
+	sqlDB := stdlib.OpenDB(*pgxConfig, stdlib.OptionAfterConnect(scanTimesInUTC))
 
-	sqlDB, err := db.DB()
+	// Timestamps are written and read in UTC, so API responses serialize
+	// them as RFC 3339 UTC whatever the server's time zone
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{
+		Logger:  logger.Default.LogMode(logLevel),
+		NowFunc: func() time.Time { return time.Now().UTC() },
+	})
 	if err != nil {
+		sqlDB.Close()
 		return nil, err
 	}
 
@@ -47,6 +56,17 @@ func InitDB(dsn string) (*gorm.DB, error) {
 	return db, nil
 }
 
+// scanTimesInUTC makes a connection return timestamptz values in UTC rather
+// than the server's local time zone
+func scanTimesInUTC(ctx context.Context, conn *pgx.Conn) error {
+	conn.TypeMap().RegisterType(&pgtype.Type{
+		Name:  "timestamptz",
+		OID:   pgtype.TimestamptzOID,
+		Codec: &pgtype.TimestamptzCodec{ScanLocation: time.UTC},
+	})
+	return nil
+}
+
 // getEnvInt returns an environment variable as int, or the default if not set or invalid.
 func getEnvInt(key string, defaultVal int) int {
 	if val := os.Getenv(key); val != "" {
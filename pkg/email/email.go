@@ -4,8 +4,11 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"strings"
 
 	"github.com/resend/resend-go/v2"
+	"github.com/sreday/cfp.ninja/pkg/models"
+	"gorm.io/gorm"
 )
 
 // Message represents an email to be sent.
@@ -18,6 +21,12 @@ type Message struct {
 	HTML    string
 	Text    string
 	Headers map[string]string
+
+	// Template, EventID and ProposalID describe the email for the send log
+	// and are not sent. Zero IDs mean the email is not about one.
+	Template   string
+	EventID    uint
+	ProposalID uint
 }
 
 // Sender sends email messages.
@@ -36,6 +45,12 @@ func NewResendSender(apiKey string) *ResendSender {
 }
 
 func (s *ResendSender) Send(ctx context.Context, msg *Message) error {
+	_, err := s.SendWithID(ctx, msg)
+	return err
+}
+
+// SendWithID sends the message and returns the ID Resend assigned to it.
+func (s *ResendSender) SendWithID(ctx context.Context, msg *Message) (string, error) {
 	params := &resend.SendEmailRequest{
 		From:    msg.From,
 		To:      msg.To,
@@ -53,11 +68,11 @@ func (s *ResendSender) Send(ctx context.Context, msg *Message) error {
 		params.Headers = msg.Headers
 	}
 
-	_, err := s.client.Emails.SendWithContext(ctx, params)
+	sent, err := s.client.Emails.SendWithContext(ctx, params)
 	if err != nil {
-		return fmt.Errorf("resend: %w", err)
+		return "", fmt.Errorf("resend: %w", err)
 	}
-	return nil
+	return sent.Id, nil
 }
 
 // NoopSender logs emails instead of sending them. Used when RESEND_API_KEY is not set.
@@ -73,3 +88,53 @@ func (s *NoopSender) Send(_ context.Context, msg *Message) error {
 	)
 	return nil
 }
+
+// idSender is implemented by senders that report the provider's message ID.
+type idSender interface {
+	SendWithID(ctx context.Context, msg *Message) (string, error)
+}
+
+// LoggingSender records every message sent through Next in the email send
+// log. A failure to record is logged and does not fail the send.
+type LoggingSender struct {
+	Next   Sender
+	DB     *gorm.DB
+	Logger *slog.Logger
+}
+
+func (s *LoggingSender) Send(ctx context.Context, msg *Message) error {
+	var providerID string
+	var err error
+	if next, ok := s.Next.(idSender); ok {
+		providerID, err = next.SendWithID(ctx, msg)
+	} else {
+		err = s.Next.Send(ctx, msg)
+	}
+
+	entry := models.EmailLog{
+		Template:          msg.Template,
+		To:                strings.Join(msg.To, ", "),
+		Cc:                strings.Join(msg.Cc, ", "),
+		Subject:           msg.Subject,
+		ProviderMessageID: providerID,
+		Status:            models.EmailStatusSent,
+	}
+	if msg.EventID != 0 {
+		entry.EventID = &msg.EventID
+	}
+	if msg.ProposalID != 0 {
+		entry.ProposalID = &msg.ProposalID
+	}
+	if _, ok := s.Next.(*NoopSender); ok {
+		entry.Status = models.EmailStatusDisabled
+	}
+	if err != nil {
+		entry.Status = models.EmailStatusFailed
+		entry.Error = err.Error()
+	}
+	if dbErr := s.DB.Create(&entry).Error; dbErr != nil {
+		s.Logger.Error("failed to record email in send log", "template", msg.Template, "error", dbErr)
+	}
+
+	return err
+}
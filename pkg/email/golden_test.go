@@ -0,0 +1,135 @@
+package email
+
+import (
+	"flag"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+// goldenCases holds sample data for every email template, rendered and
+// compared against testdata/<name>.golden.html and .golden.txt. Run
+// `go test ./pkg/email -update` after changing a template.
+var goldenCases = map[string]interface{}{
+	"proposal_accepted": proposalStatusData{
+		SpeakerName: "Jane Doe", ProposalTitle: "Building Reliable Systems", EventName: "SREday London 2026",
+		DashboardURL: "https://cfp.ninja/dashboard/proposals", NeedsConfirmation: true,
+	},
+	"proposal_rejected": proposalStatusData{
+		SpeakerName: "Jane Doe", ProposalTitle: "Building Reliable Systems", EventName: "SREday London 2026",
+		DashboardURL: "https://cfp.ninja/dashboard/proposals",
+	},
+	"proposal_tentative": proposalStatusData{
+		SpeakerName: "Jane Doe", ProposalTitle: "Building Reliable Systems", EventName: "SREday London 2026",
+		DashboardURL: "https://cfp.ninja/dashboard/proposals",
+	},
+	"proposal_status_custom": customStatusData{
+		Body:      "Hi Jane Doe,\n\nWe loved \"Building Reliable Systems\" and would like you to speak at SREday London 2026.",
+		EventName: "SREday London 2026", EventURL: "https://cfp.ninja/e/sreday-london-2026",
+		DashboardURL: "https://cfp.ninja/dashboard/proposals", NeedsConfirmation: true,
+	},
+	"attendance_confirmed": attendanceConfirmedData{
+		OrganizerName: "Olivia", SpeakerName: "Jane Doe", SpeakerEmail: "jane@example.com", SpeakerCompany: "Acme",
+		SpeakerLinkedIn: "https://linkedin.com/in/janedoe", SpeakerBio: "SRE at Acme.",
+		ProposalTitle: "Building Reliable Systems", ProposalAbstract: "How we keep things up.",
+		EventName: "SREday London 2026", DashboardURL: "https://cfp.ninja/dashboard/events/1",
+	},
+	"emergency_cancel": attendanceConfirmedData{
+		OrganizerName: "Olivia", SpeakerName: "Jane Doe", ProposalTitle: "Building Reliable Systems",
+		EventName: "SREday London 2026", DashboardURL: "https://cfp.ninja/dashboard/events/1",
+	},
+	"weekly_digest": weeklyDigestData{
+		RecipientName: "Olivia",
+		Events: []EventActivity{
+			{EventName: "SREday London 2026", NewProposals: 5, Accepted: 2, Rejected: 1, Confirmed: 1,
+				Recent: []string{"Alice accepted 2 proposals", "Bob changed the CFP deadline"}},
+		},
+		OpenCFPs: []DigestCFP{
+			{EventName: "LLMday Paris", Location: "Paris", CloseAt: "March 1, 2026", EventURL: "https://cfp.ninja/e/llmday-paris"},
+		},
+		Matched:      true,
+		DashboardURL: "https://cfp.ninja/dashboard",
+	},
+	"cfp_extended": cfpExtendedData{
+		RecipientName: "Jane Doe", EventName: "SREday London 2026",
+		CloseAt: "March 1, 2026 at 23:59 UTC", EventURL: "https://cfp.ninja/e/sreday-london-2026",
+	},
+	"cfp_opened": cfpOpenedData{
+		RecipientName: "Jane Doe", EventName: "SREday London 2026", CloseAt: "March 1, 2026 at 23:59 UTC",
+		EventURL:       "https://cfp.ninja/e/sreday-london-2026",
+		UnsubscribeURL: "https://cfp.ninja/notify-me/unsubscribe?token=abc",
+	},
+	"event_held": eventHeldData{
+		EventName: "Crypto Giveaway", EventSlug: "crypto-giveaway", CreatorName: "Mallory",
+		CreatorEmail: "mallory@example.com", Score: 70, Reasons: []string{"new account", "suspicious link"},
+		ReviewURL: "https://cfp.ninja/admin/events",
+	},
+	"new_login_country": newLoginCountryData{
+		UserName: "Jane Doe", Country: "France", Provider: "github", IP: "203.0.113.7",
+		UserAgent: "Mozilla/5.0", LoggedInAt: "February 9, 2026 at 09:00 UTC", LoginsURL: "https://cfp.ninja/dashboard/logins",
+	},
+	"answers_requested": answersRequestedData{
+		SpeakerName: "Jane Doe", ProposalTitle: "Building Reliable Systems", EventName: "SREday London 2026",
+		Questions: []string{"Do you need travel support?", "Can we record your talk?"},
+		Until:     "March 1, 2026 at 23:59 UTC", EditURL: "https://cfp.ninja/proposals/42/edit",
+	},
+	"organizer_left": organizerLeftData{
+		CreatorName: "Jane Doe", OrganizerName: "John Smith", OrganizerEmail: "john@example.com",
+		EventName: "SREday London 2026", ManageURL: "https://cfp.ninja/dashboard/events/7",
+	},
+	"scrub_notice": scrubNoticeData{
+		CreatorName: "Jane Doe", EventName: "SREday London 2023", ProposalCount: 42,
+		ScrubAt: "March 1, 2026", ExportURL: "https://cfp.ninja/dashboard/events/7",
+	},
+	"speaker_message": speakerMessageData{
+		EventName: "SREday London 2026", Body: "Hi Jane,\n\nSlides are due next week.",
+		EventURL: "https://cfp.ninja/e/sreday-london-2026",
+	},
+}
+
+func TestTemplatesGolden(t *testing.T) {
+	for name, data := range goldenCases {
+		t.Run(name, func(t *testing.T) {
+			html, text, err := Render(name, data)
+			if err != nil {
+				t.Fatalf("Render failed: %v", err)
+			}
+			checkGolden(t, filepath.Join("testdata", name+".golden.html"), html)
+			checkGolden(t, filepath.Join("testdata", name+".golden.txt"), text)
+		})
+	}
+}
+
+func TestTemplatesGolden_CoverEveryTemplate(t *testing.T) {
+	files, err := fs.Glob(templateFS, "templates/*.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range files {
+		name := strings.TrimSuffix(filepath.Base(f), ".html")
+		if _, ok := goldenCases[name]; !ok {
+			t.Errorf("template %s has no golden case", name)
+		}
+	}
+}
+
+func checkGolden(t *testing.T, path, got string) {
+	t.Helper()
+	if *updateGolden {
+		if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read golden file (run with -update to create it): %v", err)
+	}
+	if got != string(want) {
+		t.Errorf("%s differs from the rendered output (run with -update if the change is intended):\n%s", path, got)
+	}
+}
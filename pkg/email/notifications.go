@@ -4,8 +4,13 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"net/url"
+	"regexp"
+	"slices"
 	"strings"
+	"time"
 
+	"github.com/sreday/cfp.ninja/pkg/logging"
 	"github.com/sreday/cfp.ninja/pkg/models"
 )
 
@@ -24,6 +29,11 @@ type NotifyConfig struct {
 	Logger  *slog.Logger
 }
 
+// logger returns the email module's child of Logger
+func (ncfg *NotifyConfig) logger() *slog.Logger {
+	return logging.Module(ncfg.Logger, logging.Email)
+}
+
 // proposalStatusData is the template data for proposal status emails.
 type proposalStatusData struct {
 	SpeakerName       string
@@ -47,22 +57,242 @@ type attendanceConfirmedData struct {
 	DashboardURL     string
 }
 
-// EventActivity holds weekly digest counts for a single event.
+// EventActivity holds weekly digest counts for a single event, and the
+// latest of what its organizers did, newest first.
 type EventActivity struct {
 	EventName    string
 	NewProposals int
 	Accepted     int
 	Rejected     int
 	Confirmed    int
+	Recent       []string
+}
+
+// DigestCFP is an open CFP suggested in the weekly digest.
+type DigestCFP struct {
+	EventName string
+	Location  string
+	CloseAt   string // empty when the CFP has no deadline
+	EventURL  string
+}
+
+// WeeklyDigest is the content of one user's weekly digest: activity on the
+// events they organise and open CFPs they may want to submit to. Matched is
+// true when the CFPs were picked from the user's interests rather than being
+// the most popular ones overall.
+type WeeklyDigest struct {
+	Events   []EventActivity
+	OpenCFPs []DigestCFP
+	Matched  bool
 }
 
 // weeklyDigestData is the template data for the weekly digest email.
 type weeklyDigestData struct {
-	OrganizerName string
+	RecipientName string
 	Events        []EventActivity
+	OpenCFPs      []DigestCFP
+	Matched       bool
 	DashboardURL  string
 }
 
+// cfpExtendedData is the template data for CFP extension emails.
+type cfpExtendedData struct {
+	RecipientName string
+	EventName     string
+	CloseAt       string
+	EventURL      string
+}
+
+// cfpOpenedData is the template data for notify-me emails sent when a CFP opens.
+type cfpOpenedData struct {
+	RecipientName  string
+	EventName      string
+	CloseAt        string // empty when the CFP has no deadline
+	EventURL       string
+	UnsubscribeURL string
+}
+
+// eventHeldData is the template data for the event moderation email to admins.
+type eventHeldData struct {
+	EventName    string
+	EventSlug    string
+	CreatorName  string
+	CreatorEmail string
+	Score        int
+	Reasons      []string
+	ReviewURL    string
+}
+
+// newLoginCountryData is the template data for the new login country email.
+type newLoginCountryData struct {
+	UserName   string
+	Country    string
+	Provider   string
+	IP         string
+	UserAgent  string
+	LoggedInAt string
+	LoginsURL  string
+}
+
+// speakerMessageData is the template data for organizer emails to speakers.
+type speakerMessageData struct {
+	EventName string
+	Body      string
+	EventURL  string
+}
+
+// answersRequestedData is the template data for emails asking speakers to
+// answer required questions their proposal is missing.
+type answersRequestedData struct {
+	SpeakerName   string
+	ProposalTitle string
+	EventName     string
+	Questions     []string
+	Until         string
+	EditURL       string
+}
+
+// organizerLeftData is the template data for the email telling an event's
+// creator that a co-organizer left.
+type organizerLeftData struct {
+	CreatorName    string
+	OrganizerName  string
+	OrganizerEmail string
+	EventName      string
+	ManageURL      string
+}
+
+// scrubNoticeData is the template data for the email warning an archived
+// event's creator that its proposals' personal data will be scrubbed.
+type scrubNoticeData struct {
+	CreatorName   string
+	EventName     string
+	ProposalCount int64
+	ScrubAt       string
+	ExportURL     string
+}
+
+// customStatusData is the template data for proposal status emails written
+// by the event's organizers. Body is their copy with variables filled in; the
+// confirmation link and platform footer are always added after it.
+type customStatusData struct {
+	Body              string
+	EventName         string
+	EventURL          string
+	DashboardURL      string
+	NeedsConfirmation bool
+}
+
+// SpeakerRecipient is one speaker receiving an organizer's email about one
+// of their proposals.
+type SpeakerRecipient struct {
+	Name       string
+	Email      string
+	TalkTitle  string
+	ProposalID uint
+}
+
+// speakerVariableRegex matches {{variable}} placeholders in organizer emails
+var speakerVariableRegex = regexp.MustCompile(`\{\{\s*([a-zA-Z0-9_]*)\s*\}\}`)
+
+// SpeakerMessageVariables are the placeholders organizers can use in the
+// subject and body of an email to speakers.
+var SpeakerMessageVariables = []string{"speaker_name", "talk_title", "event_name"}
+
+// UnknownSpeakerVariables returns the placeholders in s that are not
+// SpeakerMessageVariables, so typos can be rejected before anything is sent.
+func UnknownSpeakerVariables(s string) []string {
+	return unknownVariables(s, SpeakerMessageVariables)
+}
+
+// unknownVariables returns the placeholders in s that are not in allowed
+func unknownVariables(s string, allowed []string) []string {
+	var unknown []string
+	for _, m := range speakerVariableRegex.FindAllStringSubmatch(s, -1) {
+		if !slices.Contains(allowed, m[1]) && !slices.Contains(unknown, m[1]) {
+			unknown = append(unknown, m[1])
+		}
+	}
+	return unknown
+}
+
+// ExpandSpeakerVariables fills in the placeholders in s for one recipient.
+func ExpandSpeakerVariables(s string, r SpeakerRecipient, eventName string) string {
+	return speakerVariableRegex.ReplaceAllStringFunc(s, func(m string) string {
+		switch speakerVariableRegex.FindStringSubmatch(m)[1] {
+		case "speaker_name":
+			return r.Name
+		case "talk_title":
+			return r.TalkTitle
+		case "event_name":
+			return eventName
+		}
+		return m
+	})
+}
+
+// StatusEmailVariables are the placeholders organizers can use in their own
+// subject and body for proposal status emails.
+var StatusEmailVariables = []string{"speaker_name", "talk_title", "event_name", "event_url", "dashboard_url"}
+
+// UnknownStatusEmailVariables returns the placeholders in s that are not
+// StatusEmailVariables.
+func UnknownStatusEmailVariables(s string) []string {
+	return unknownVariables(s, StatusEmailVariables)
+}
+
+// sampleStatusEmailData stands in for a real speaker and proposal when
+// checking an organizer's status email copy.
+var sampleStatusEmailData = customStatusData{
+	EventName:         "Sample Event",
+	EventURL:          "https://cfp.ninja/e/sample-event",
+	DashboardURL:      "https://cfp.ninja/dashboard/proposals",
+	NeedsConfirmation: true,
+}
+
+// CheckStatusEmail renders an organizer's status email copy with sample data,
+// so copy that cannot be sent is rejected when the event is saved rather
+// than when a proposal is decided.
+func CheckStatusEmail(se models.StatusEmail) error {
+	_, _, _, err := renderStatusEmail(se, "Sample Speaker", "Sample Talk", sampleStatusEmailData)
+	return err
+}
+
+// renderStatusEmail fills in the variables in an organizer's status email
+// copy and renders it with the confirmation link and platform footer
+func renderStatusEmail(se models.StatusEmail, speakerName, talkTitle string, data customStatusData) (subject, html, text string, err error) {
+	expand := func(s string) string {
+		return speakerVariableRegex.ReplaceAllStringFunc(s, func(m string) string {
+			switch speakerVariableRegex.FindStringSubmatch(m)[1] {
+			case "speaker_name":
+				return speakerName
+			case "talk_title":
+				return talkTitle
+			case "event_name":
+				return data.EventName
+			case "event_url":
+				return data.EventURL
+			case "dashboard_url":
+				return data.DashboardURL
+			}
+			return m
+		})
+	}
+	if unknown := UnknownStatusEmailVariables(se.Subject + se.Body); len(unknown) > 0 {
+		return "", "", "", fmt.Errorf("unknown variable {{%s}}", unknown[0])
+	}
+	subject = sanitizeSubject(strings.TrimSpace(expand(se.Subject)))
+	if subject == "" {
+		return "", "", "", fmt.Errorf("subject is empty")
+	}
+	data.Body = expand(se.Body)
+	html, text, err = Render("proposal_status_custom", data)
+	if err != nil {
+		return "", "", "", err
+	}
+	return subject, html, text, nil
+}
+
 // templateForStatus returns the template name and subject line for a proposal status.
 func templateForStatus(status models.ProposalStatus) (tmpl, subject string, ok bool) {
 	switch status {
@@ -102,17 +332,32 @@ func SendProposalStatusNotification(ncfg *NotifyConfig, proposal *models.Proposa
 		}
 	}
 
-	data := proposalStatusData{
-		SpeakerName:       primary.Name,
-		ProposalTitle:     proposal.Title,
-		EventName:         event.Name,
-		DashboardURL:      ncfg.BaseURL + "/dashboard/proposals",
-		NeedsConfirmation: newStatus == models.ProposalStatusAccepted,
-	}
-
-	html, text, err := Render(tmplName, data)
-	if err != nil {
-		return fmt.Errorf("render %s: %w", tmplName, err)
+	var html, text string
+	if se, ok := event.GetStatusEmail(newStatus); ok {
+		// The organizers' own copy, under the same template name so the
+		// email log reads the same
+		data := customStatusData{
+			EventName:         event.Name,
+			EventURL:          ncfg.BaseURL + "/e/" + event.Slug,
+			DashboardURL:      ncfg.BaseURL + "/dashboard/proposals",
+			NeedsConfirmation: newStatus == models.ProposalStatusAccepted,
+		}
+		subject, html, text, err = renderStatusEmail(se, primary.Name, proposal.Title, data)
+		if err != nil {
+			return fmt.Errorf("render custom %s: %w", tmplName, err)
+		}
+	} else {
+		data := proposalStatusData{
+			SpeakerName:       primary.Name,
+			ProposalTitle:     proposal.Title,
+			EventName:         event.Name,
+			DashboardURL:      ncfg.BaseURL + "/dashboard/proposals",
+			NeedsConfirmation: newStatus == models.ProposalStatusAccepted,
+		}
+		html, text, err = Render(tmplName, data)
+		if err != nil {
+			return fmt.Errorf("render %s: %w", tmplName, err)
+		}
 	}
 
 	// Build recipient lists
@@ -125,17 +370,20 @@ func SendProposalStatusNotification(ncfg *NotifyConfig, proposal *models.Proposa
 	}
 
 	msg := &Message{
-		To:      to,
-		Cc:      cc,
-		From:    ncfg.From,
-		ReplyTo: event.ContactEmail,
-		Subject: subject,
-		HTML:    html,
-		Text:    text,
+		Template:   tmplName,
+		EventID:    event.ID,
+		ProposalID: proposal.ID,
+		To:         to,
+		Cc:         cc,
+		From:       ncfg.From,
+		ReplyTo:    event.ContactEmail,
+		Subject:    subject,
+		HTML:       html,
+		Text:       text,
 	}
 
 	if err := ncfg.Sender.Send(context.Background(), msg); err != nil {
-		ncfg.Logger.Error("failed to send proposal status email",
+		ncfg.logger().Error("failed to send proposal status email",
 			"proposal_id", proposal.ID,
 			"status", string(newStatus),
 			"error", err,
@@ -143,7 +391,7 @@ func SendProposalStatusNotification(ncfg *NotifyConfig, proposal *models.Proposa
 		return err
 	}
 
-	ncfg.Logger.Info("sent proposal status email",
+	ncfg.logger().Info("sent proposal status email",
 		"proposal_id", proposal.ID,
 		"status", string(newStatus),
 		"to", to,
@@ -158,7 +406,7 @@ func SendProposalStatusNotification(ncfg *NotifyConfig, proposal *models.Proposa
 func SendAttendanceConfirmedNotification(ncfg *NotifyConfig, proposal *models.Proposal, event *models.Event) error {
 	speakers, err := proposal.GetSpeakers()
 	if err != nil {
-		ncfg.Logger.Error("failed to parse speakers for attendance notification", "proposal_id", proposal.ID, "error", err)
+		ncfg.logger().Error("failed to parse speakers for attendance notification", "proposal_id", proposal.ID, "error", err)
 	}
 	speakerName := "A speaker"
 	var speakerEmail, speakerCompany, speakerLinkedIn, speakerBio string
@@ -214,23 +462,26 @@ func SendAttendanceConfirmedNotification(ncfg *NotifyConfig, proposal *models.Pr
 	}
 
 	msg := &Message{
-		To:      to,
-		Cc:      cc,
-		From:    ncfg.From,
-		ReplyTo: event.ContactEmail,
-		Subject: sanitizeSubject(fmt.Sprintf("Speaker confirmed: %s", proposal.Title)),
-		HTML:    html,
-		Text:    text,
+		Template:   "attendance_confirmed",
+		EventID:    event.ID,
+		ProposalID: proposal.ID,
+		To:         to,
+		Cc:         cc,
+		From:       ncfg.From,
+		ReplyTo:    event.ContactEmail,
+		Subject:    sanitizeSubject(fmt.Sprintf("Speaker confirmed: %s", proposal.Title)),
+		HTML:       html,
+		Text:       text,
 	}
 
 	if err := ncfg.Sender.Send(context.Background(), msg); err != nil {
-		ncfg.Logger.Error("failed to send attendance confirmation email",
+		ncfg.logger().Error("failed to send attendance confirmation email",
 			"error", err,
 		)
 		return err
 	}
 
-	ncfg.Logger.Info("sent attendance confirmation email",
+	ncfg.logger().Info("sent attendance confirmation email",
 		"to", to,
 		"cc", cc,
 		"proposal_id", proposal.ID,
@@ -244,7 +495,7 @@ func SendAttendanceConfirmedNotification(ncfg *NotifyConfig, proposal *models.Pr
 func SendEmergencyCancelNotification(ncfg *NotifyConfig, proposal *models.Proposal, event *models.Event) error {
 	speakers, err := proposal.GetSpeakers()
 	if err != nil {
-		ncfg.Logger.Error("failed to parse speakers for emergency cancel notification", "proposal_id", proposal.ID, "error", err)
+		ncfg.logger().Error("failed to parse speakers for emergency cancel notification", "proposal_id", proposal.ID, "error", err)
 	}
 	var speakerName string
 	if len(speakers) > 0 {
@@ -291,23 +542,26 @@ func SendEmergencyCancelNotification(ncfg *NotifyConfig, proposal *models.Propos
 	}
 
 	msg := &Message{
-		To:      to,
-		Cc:      cc,
-		From:    ncfg.From,
-		ReplyTo: event.ContactEmail,
-		Subject: sanitizeSubject(fmt.Sprintf("Emergency cancellation: %s", proposal.Title)),
-		HTML:    html,
-		Text:    text,
+		Template:   "emergency_cancel",
+		EventID:    event.ID,
+		ProposalID: proposal.ID,
+		To:         to,
+		Cc:         cc,
+		From:       ncfg.From,
+		ReplyTo:    event.ContactEmail,
+		Subject:    sanitizeSubject(fmt.Sprintf("Emergency cancellation: %s", proposal.Title)),
+		HTML:       html,
+		Text:       text,
 	}
 
 	if err := ncfg.Sender.Send(context.Background(), msg); err != nil {
-		ncfg.Logger.Error("failed to send emergency cancel email",
+		ncfg.logger().Error("failed to send emergency cancel email",
 			"error", err,
 		)
 		return err
 	}
 
-	ncfg.Logger.Info("sent emergency cancel email",
+	ncfg.logger().Info("sent emergency cancel email",
 		"to", to,
 		"cc", cc,
 		"proposal_id", proposal.ID,
@@ -315,25 +569,497 @@ func SendEmergencyCancelNotification(ncfg *NotifyConfig, proposal *models.Propos
 	return nil
 }
 
-// SendWeeklyDigest emails a single organiser their weekly activity summary.
-func SendWeeklyDigest(ncfg *NotifyConfig, organizer *models.User, activities []EventActivity) error {
+// SendAnswersRequestedNotification emails all speakers on a proposal that an
+// organizer asked them to answer the required questions it is missing, and
+// until when they can. The primary speaker goes in To, other speakers in Cc.
+func SendAnswersRequestedNotification(ncfg *NotifyConfig, proposal *models.Proposal, event *models.Event, questions []models.CustomQuestion) error {
+	speakers, err := proposal.GetSpeakers()
+	if err != nil {
+		return fmt.Errorf("get speakers: %w", err)
+	}
+	if len(speakers) == 0 {
+		return fmt.Errorf("no speakers found for proposal %d", proposal.ID)
+	}
+	primary := speakers[0]
+	for _, s := range speakers {
+		if s.Primary {
+			primary = s
+			break
+		}
+	}
+
+	data := answersRequestedData{
+		SpeakerName:   primary.Name,
+		ProposalTitle: proposal.Title,
+		EventName:     event.Name,
+		EditURL:       fmt.Sprintf("%s/proposals/%d/edit", ncfg.BaseURL, proposal.ID),
+	}
+	for _, q := range questions {
+		data.Questions = append(data.Questions, q.Text)
+	}
+	if proposal.AnswersRequestedUntil != nil {
+		data.Until = proposal.AnswersRequestedUntil.UTC().Format("January 2, 2006 at 15:04 UTC")
+	}
+
+	html, text, err := Render("answers_requested", data)
+	if err != nil {
+		return fmt.Errorf("render answers_requested: %w", err)
+	}
+
+	to := []string{primary.Email}
+	var cc []string
+	for _, s := range speakers {
+		if s.Email != primary.Email {
+			cc = append(cc, s.Email)
+		}
+	}
+
+	msg := &Message{
+		Template:   "answers_requested",
+		EventID:    event.ID,
+		ProposalID: proposal.ID,
+		To:         to,
+		Cc:         cc,
+		From:       ncfg.From,
+		ReplyTo:    event.ContactEmail,
+		Subject:    sanitizeSubject(fmt.Sprintf("Answers missing from your proposal: %s", proposal.Title)),
+		HTML:       html,
+		Text:       text,
+	}
+
+	if err := ncfg.Sender.Send(context.Background(), msg); err != nil {
+		ncfg.logger().Error("failed to send answers requested email",
+			"proposal_id", proposal.ID,
+			"error", err,
+		)
+		return err
+	}
+
+	ncfg.logger().Info("sent answers requested email",
+		"proposal_id", proposal.ID,
+		"to", to,
+		"cc", cc,
+	)
+	return nil
+}
+
+// SendCFPExtendedNotification emails each recipient, separately, that the
+// event's CFP was reopened with a new deadline. It returns the number of
+// emails sent; failures are logged and do not stop the remaining sends.
+func SendCFPExtendedNotification(ncfg *NotifyConfig, event *models.Event, recipients []models.User) int {
+	sent := 0
+	for _, u := range recipients {
+		if u.Email == "" {
+			continue
+		}
+		name := u.Name
+		if name == "" {
+			name = "there"
+		}
+		data := cfpExtendedData{
+			RecipientName: name,
+			EventName:     event.Name,
+			CloseAt:       event.CFPCloseAt.UTC().Format("January 2, 2006 at 15:04 UTC"),
+			EventURL:      ncfg.BaseURL + "/e/" + event.Slug,
+		}
+
+		html, text, err := Render("cfp_extended", data)
+		if err != nil {
+			ncfg.logger().Error("failed to render cfp extended email", "event_id", event.ID, "error", err)
+			return sent
+		}
+
+		msg := &Message{
+			Template: "cfp_extended",
+			EventID:  event.ID,
+			To:       []string{u.Email},
+			From:     ncfg.From,
+			ReplyTo:  event.ContactEmail,
+			Subject:  sanitizeSubject(fmt.Sprintf("The CFP for %s has been extended", event.Name)),
+			HTML:     html,
+			Text:     text,
+		}
+		if err := ncfg.Sender.Send(context.Background(), msg); err != nil {
+			ncfg.logger().Error("failed to send cfp extended email",
+				"event_id", event.ID,
+				"user_id", u.ID,
+				"error", err,
+			)
+			continue
+		}
+		sent++
+	}
+
+	ncfg.logger().Info("sent cfp extended emails",
+		"event_id", event.ID,
+		"sent", sent,
+		"recipients", len(recipients),
+	)
+	return sent
+}
+
+// CFPOpenedUnsubscribeURL is the one-click unsubscribe endpoint for a
+// notify-me registration, for the List-Unsubscribe header.
+func CFPOpenedUnsubscribeURL(baseURL, token string) string {
+	return baseURL + "/api/v0/notify-me/unsubscribe?token=" + url.QueryEscape(token)
+}
+
+// SendCFPOpenedNotification emails each registration, separately, that the
+// event's CFP is open. Registrations without a user or email are skipped. It
+// returns the number of emails sent; failures are logged and do not stop the
+// remaining sends.
+func SendCFPOpenedNotification(ncfg *NotifyConfig, event *models.Event, interests []models.CFPInterest) int {
+	sent := 0
+	for _, in := range interests {
+		if in.User == nil || in.User.Email == "" {
+			continue
+		}
+		name := in.User.Name
+		if name == "" {
+			name = "there"
+		}
+		data := cfpOpenedData{
+			RecipientName:  name,
+			EventName:      event.Name,
+			EventURL:       ncfg.BaseURL + "/e/" + event.Slug,
+			UnsubscribeURL: ncfg.BaseURL + "/notify-me/unsubscribe?token=" + url.QueryEscape(in.UnsubscribeToken),
+		}
+		if !event.CFPCloseAt.IsZero() {
+			data.CloseAt = event.CFPCloseAt.UTC().Format("January 2, 2006 at 15:04 UTC")
+		}
+
+		html, text, err := Render("cfp_opened", data)
+		if err != nil {
+			ncfg.logger().Error("failed to render cfp opened email", "event_id", event.ID, "error", err)
+			return sent
+		}
+
+		msg := &Message{
+			Template: "cfp_opened",
+			EventID:  event.ID,
+			To:       []string{in.User.Email},
+			From:     ncfg.From,
+			ReplyTo:  event.ContactEmail,
+			Subject:  sanitizeSubject(fmt.Sprintf("The CFP for %s is open", event.Name)),
+			HTML:     html,
+			Text:     text,
+			Headers: map[string]string{
+				"List-Unsubscribe":      "<" + CFPOpenedUnsubscribeURL(ncfg.BaseURL, in.UnsubscribeToken) + ">",
+				"List-Unsubscribe-Post": "List-Unsubscribe=One-Click",
+			},
+		}
+		if err := ncfg.Sender.Send(context.Background(), msg); err != nil {
+			ncfg.logger().Error("failed to send cfp opened email",
+				"event_id", event.ID,
+				"user_id", in.UserID,
+				"error", err,
+			)
+			continue
+		}
+		sent++
+	}
+
+	ncfg.logger().Info("sent cfp opened emails",
+		"event_id", event.ID,
+		"sent", sent,
+		"recipients", len(interests),
+	)
+	return sent
+}
+
+// SendSpeakerMessage emails an organizer-written message to each recipient,
+// separately, with the template variables filled in for them. Replies go to
+// replyTo. It returns the number of emails sent; failures are logged and do
+// not stop the remaining sends.
+func SendSpeakerMessage(ncfg *NotifyConfig, event *models.Event, replyTo, subject, body string, recipients []SpeakerRecipient) int {
+	sent := 0
+	for _, rcpt := range recipients {
+		if rcpt.Email == "" {
+			continue
+		}
+		data := speakerMessageData{
+			EventName: event.Name,
+			Body:      ExpandSpeakerVariables(body, rcpt, event.Name),
+			EventURL:  ncfg.BaseURL + "/e/" + event.Slug,
+		}
+
+		html, text, err := Render("speaker_message", data)
+		if err != nil {
+			ncfg.logger().Error("failed to render speaker message email", "event_id", event.ID, "error", err)
+			return sent
+		}
+
+		msg := &Message{
+			Template:   "speaker_message",
+			EventID:    event.ID,
+			ProposalID: rcpt.ProposalID,
+			To:         []string{rcpt.Email},
+			From:       ncfg.From,
+			ReplyTo:    replyTo,
+			Subject:    sanitizeSubject(ExpandSpeakerVariables(subject, rcpt, event.Name)),
+			HTML:       html,
+			Text:       text,
+		}
+		if err := ncfg.Sender.Send(context.Background(), msg); err != nil {
+			ncfg.logger().Error("failed to send speaker message email",
+				"event_id", event.ID,
+				"proposal_id", rcpt.ProposalID,
+				"error", err,
+			)
+			continue
+		}
+		sent++
+	}
+
+	ncfg.logger().Info("sent speaker message emails",
+		"event_id", event.ID,
+		"sent", sent,
+		"recipients", len(recipients),
+	)
+	return sent
+}
+
+// SendEventHeldNotification tells the platform admins that a new event scored
+// as likely spam and is waiting in pending_review. reasons describe the signals
+// that contributed to the score.
+func SendEventHeldNotification(ncfg *NotifyConfig, event *models.Event, creator *models.User, admins []string, score int, reasons []string) error {
+	if len(admins) == 0 {
+		return nil
+	}
+
+	data := eventHeldData{
+		EventName:    event.Name,
+		EventSlug:    event.Slug,
+		CreatorName:  creator.Name,
+		CreatorEmail: creator.Email,
+		Score:        score,
+		Reasons:      reasons,
+		ReviewURL:    ncfg.BaseURL + "/api/v0/admin/events/moderation",
+	}
+
+	html, text, err := Render("event_held", data)
+	if err != nil {
+		return fmt.Errorf("render event_held: %w", err)
+	}
+
+	msg := &Message{
+		Template: "event_held",
+		EventID:  event.ID,
+		To:       admins,
+		From:     ncfg.From,
+		Subject:  sanitizeSubject(fmt.Sprintf("Event held for review: %s", event.Name)),
+		HTML:     html,
+		Text:     text,
+	}
+
+	if err := ncfg.Sender.Send(context.Background(), msg); err != nil {
+		ncfg.logger().Error("failed to send event held email",
+			"event_id", event.ID,
+			"error", err,
+		)
+		return err
+	}
+
+	ncfg.logger().Info("sent event held email",
+		"to", admins,
+		"event_id", event.ID,
+	)
+	return nil
+}
+
+// SendOrganizerLeftNotification tells the event's creator that a co-organizer
+// removed themselves from the event
+func SendOrganizerLeftNotification(ncfg *NotifyConfig, event *models.Event, creator, organizer *models.User) error {
+	if creator.Email == "" {
+		return nil
+	}
+	creatorName := creator.Name
+	if creatorName == "" {
+		creatorName = "there"
+	}
+	organizerName := organizer.Name
+	if organizerName == "" {
+		organizerName = organizer.Email
+	}
+
+	data := organizerLeftData{
+		CreatorName:    creatorName,
+		OrganizerName:  organizerName,
+		OrganizerEmail: organizer.Email,
+		EventName:      event.Name,
+		ManageURL:      fmt.Sprintf("%s/dashboard/events/%d", ncfg.BaseURL, event.ID),
+	}
+
+	html, text, err := Render("organizer_left", data)
+	if err != nil {
+		return fmt.Errorf("render organizer_left: %w", err)
+	}
+
+	msg := &Message{
+		Template: "organizer_left",
+		EventID:  event.ID,
+		To:       []string{creator.Email},
+		From:     ncfg.From,
+		Subject:  sanitizeSubject(fmt.Sprintf("%s left the organisers of %s", organizerName, event.Name)),
+		HTML:     html,
+		Text:     text,
+	}
+
+	if err := ncfg.Sender.Send(context.Background(), msg); err != nil {
+		ncfg.logger().Error("failed to send organizer left email",
+			"event_id", event.ID,
+			"error", err,
+		)
+		return err
+	}
+
+	ncfg.logger().Info("sent organizer left email",
+		"event_id", event.ID,
+		"organizer_id", organizer.ID,
+	)
+	return nil
+}
+
+// SendScrubNoticeNotification warns an archived event's creator that the
+// speaker emails and notes of its proposals will be scrubbed at scrubAt, so
+// they can export anything they still need
+func SendScrubNoticeNotification(ncfg *NotifyConfig, event *models.Event, creator *models.User, proposalCount int64, scrubAt time.Time) error {
+	if creator.Email == "" {
+		return nil
+	}
+	creatorName := creator.Name
+	if creatorName == "" {
+		creatorName = "there"
+	}
+
+	data := scrubNoticeData{
+		CreatorName:   creatorName,
+		EventName:     event.Name,
+		ProposalCount: proposalCount,
+		ScrubAt:       scrubAt.UTC().Format("January 2, 2006"),
+		ExportURL:     fmt.Sprintf("%s/dashboard/events/%d", ncfg.BaseURL, event.ID),
+	}
+
+	html, text, err := Render("scrub_notice", data)
+	if err != nil {
+		return fmt.Errorf("render scrub_notice: %w", err)
+	}
+
+	msg := &Message{
+		Template: "scrub_notice",
+		EventID:  event.ID,
+		To:       []string{creator.Email},
+		From:     ncfg.From,
+		Subject:  sanitizeSubject(fmt.Sprintf("Speaker details for %s will be removed on %s", event.Name, data.ScrubAt)),
+		HTML:     html,
+		Text:     text,
+	}
+
+	if err := ncfg.Sender.Send(context.Background(), msg); err != nil {
+		ncfg.logger().Error("failed to send scrub notice email",
+			"event_id", event.ID,
+			"error", err,
+		)
+		return err
+	}
+
+	ncfg.logger().Info("sent scrub notice email",
+		"event_id", event.ID,
+		"scrub_at", scrubAt,
+	)
+	return nil
+}
+
+// SendNewLoginCountryNotification warns a user that their account was signed
+// in to from a country it has not been used from recently. countryName and
+// providerName are display names, e.g. "Germany" and "GitHub".
+func SendNewLoginCountryNotification(ncfg *NotifyConfig, user *models.User, login *models.LoginEvent, countryName, providerName string) error {
+	if user.Email == "" {
+		return nil
+	}
+	name := user.Name
+	if name == "" {
+		name = "there"
+	}
+	userAgent := login.UserAgent
+	if userAgent == "" {
+		userAgent = "unknown"
+	}
+
+	data := newLoginCountryData{
+		UserName:   name,
+		Country:    countryName,
+		Provider:   providerName,
+		IP:         login.IP,
+		UserAgent:  userAgent,
+		LoggedInAt: login.CreatedAt.UTC().Format("January 2, 2006 at 15:04 UTC"),
+		LoginsURL:  ncfg.BaseURL + "/api/v0/me/logins",
+	}
+
+	html, text, err := Render("new_login_country", data)
+	if err != nil {
+		return fmt.Errorf("render new_login_country: %w", err)
+	}
+
+	msg := &Message{
+		Template: "new_login_country",
+		To:       []string{user.Email},
+		From:     ncfg.From,
+		Subject:  sanitizeSubject(fmt.Sprintf("New sign-in to your CFP.ninja account from %s", countryName)),
+		HTML:     html,
+		Text:     text,
+	}
+
+	if err := ncfg.Sender.Send(context.Background(), msg); err != nil {
+		ncfg.logger().Error("failed to send new login country email",
+			"user_id", user.ID,
+			"error", err,
+		)
+		return err
+	}
+
+	ncfg.logger().Info("sent new login country email",
+		"user_id", user.ID,
+		"country", login.Country,
+	)
+	return nil
+}
+
+// RenderWeeklyDigest renders a user's weekly digest without sending it, as
+// used both for the email and for previews.
+func RenderWeeklyDigest(ncfg *NotifyConfig, user *models.User, digest *WeeklyDigest) (html, text string, err error) {
 	data := weeklyDigestData{
-		OrganizerName: organizer.Name,
-		Events:        activities,
+		RecipientName: user.Name,
+		Events:        digest.Events,
+		OpenCFPs:      digest.OpenCFPs,
+		Matched:       digest.Matched,
 		DashboardURL:  ncfg.BaseURL + "/dashboard",
 	}
+	if data.RecipientName == "" {
+		data.RecipientName = "there"
+	}
 
-	html, text, err := Render("weekly_digest", data)
+	html, text, err = Render("weekly_digest", data)
 	if err != nil {
-		return fmt.Errorf("render weekly_digest: %w", err)
+		return "", "", fmt.Errorf("render weekly_digest: %w", err)
+	}
+	return html, text, nil
+}
+
+// SendWeeklyDigest emails a single user their weekly digest.
+func SendWeeklyDigest(ncfg *NotifyConfig, user *models.User, digest *WeeklyDigest) error {
+	html, text, err := RenderWeeklyDigest(ncfg, user, digest)
+	if err != nil {
+		return err
 	}
 
 	msg := &Message{
-		To:      []string{organizer.Email},
-		From:    ncfg.From,
-		Subject: "Your weekly CFP digest",
-		HTML:    html,
-		Text:    text,
+		Template: "weekly_digest",
+		To:       []string{user.Email},
+		From:     ncfg.From,
+		Subject:  "Your weekly CFP digest",
+		HTML:     html,
+		Text:     text,
 		Headers: map[string]string{
 			"List-Unsubscribe": "<" + ncfg.BaseURL + "/dashboard/settings>",
 		},
@@ -5,8 +5,10 @@ import (
 	"encoding/json"
 	"log/slog"
 	"os"
+	"strings"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/sreday/cfp.ninja/pkg/models"
 )
@@ -86,6 +88,60 @@ func TestSendProposalStatusNotification_Accepted(t *testing.T) {
 	}
 }
 
+func TestSendProposalStatusNotification_CustomCopy(t *testing.T) {
+	mock := &mockSender{}
+	ncfg := newTestNotifyConfig(mock)
+
+	proposal := &models.Proposal{
+		Title: "My Talk",
+		Speakers: makeSpeakersJSON([]models.Speaker{
+			{Name: "Alice", Email: "alice@example.com", Primary: true},
+		}),
+	}
+	event := &models.Event{
+		Name:         "SREday London",
+		Slug:         "sreday-london",
+		StatusEmails: []byte(`{"accepted":{"subject":"{{talk_title}} is in!","body":"Hi {{speaker_name}},\nSee you at {{event_name}}."}}`),
+	}
+
+	if err := SendProposalStatusNotification(ncfg, proposal, event, models.ProposalStatusAccepted); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	msgs := mock.Messages()
+	if len(msgs) != 1 {
+		t.Fatalf("expected 1 message, got %d", len(msgs))
+	}
+	msg := msgs[0]
+	if msg.Subject != "My Talk is in!" || msg.Template != "proposal_accepted" {
+		t.Errorf("Subject = %q, Template = %q", msg.Subject, msg.Template)
+	}
+	for _, want := range []string{"Hi Alice,\nSee you at SREday London.", "Please confirm your attendance", "via CFP.ninja", "/dashboard/proposals"} {
+		if !strings.Contains(msg.Text, want) {
+			t.Errorf("text body missing %q:\n%s", want, msg.Text)
+		}
+	}
+
+	// Statuses without custom copy keep the platform wording
+	if err := SendProposalStatusNotification(ncfg, proposal, event, models.ProposalStatusRejected); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if msgs := mock.Messages(); msgs[1].Subject != "Update on your proposal" {
+		t.Errorf("Subject = %q, want the platform subject", msgs[1].Subject)
+	}
+}
+
+func TestCheckStatusEmail(t *testing.T) {
+	if err := CheckStatusEmail(models.StatusEmail{Subject: "Welcome to {{event_name}}", Body: "Details: {{dashboard_url}}"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := CheckStatusEmail(models.StatusEmail{Subject: "Hi", Body: "Room {{room}}"}); err == nil {
+		t.Error("expected an error for an unknown variable")
+	}
+	if err := CheckStatusEmail(models.StatusEmail{Subject: "{{ speaker_name }}", Body: "Hi"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
 func TestSendProposalStatusNotification_Rejected(t *testing.T) {
 	mock := &mockSender{}
 	ncfg := newTestNotifyConfig(mock)
@@ -330,16 +386,61 @@ func TestSendEmergencyCancelNotification_NoContactEmail(t *testing.T) {
 	}
 }
 
+func TestSendAnswersRequestedNotification(t *testing.T) {
+	mock := &mockSender{}
+	ncfg := newTestNotifyConfig(mock)
+
+	until := time.Date(2026, 3, 1, 23, 59, 0, 0, time.UTC)
+	proposal := &models.Proposal{
+		Title: "My Talk",
+		Speakers: makeSpeakersJSON([]models.Speaker{
+			{Name: "Bob", Email: "bob@example.com"},
+			{Name: "Alice", Email: "alice@example.com", Primary: true},
+		}),
+		AnswersRequestedUntil: &until,
+	}
+	proposal.ID = 42
+	event := &models.Event{Name: "SREday London", ContactEmail: "organisers@sreday.com"}
+	questions := []models.CustomQuestion{{ID: "travel", Text: "Do you need travel support?", Type: "text", Required: true}}
+
+	if err := SendAnswersRequestedNotification(ncfg, proposal, event, questions); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	msgs := mock.Messages()
+	if len(msgs) != 1 {
+		t.Fatalf("expected 1 message, got %d", len(msgs))
+	}
+	msg := msgs[0]
+	if msg.To[0] != "alice@example.com" || len(msg.Cc) != 1 || msg.Cc[0] != "bob@example.com" {
+		t.Errorf("To = %v, Cc = %v, want the primary speaker in To", msg.To, msg.Cc)
+	}
+	if msg.ReplyTo != "organisers@sreday.com" {
+		t.Errorf("ReplyTo = %q, want organisers@sreday.com", msg.ReplyTo)
+	}
+	for _, want := range []string{"Do you need travel support?", "March 1, 2026 at 23:59 UTC", "https://cfp.ninja/proposals/42/edit"} {
+		if !strings.Contains(msg.Text, want) {
+			t.Errorf("expected the text to contain %q, got:\n%s", want, msg.Text)
+		}
+	}
+}
+
 func TestSendWeeklyDigest(t *testing.T) {
 	mock := &mockSender{}
 	ncfg := newTestNotifyConfig(mock)
 
 	org := &models.User{Email: "org@example.com", Name: "Org"}
-	activities := []EventActivity{
-		{EventName: "SREday", NewProposals: 3, Accepted: 1},
+	digest := &WeeklyDigest{
+		Events: []EventActivity{
+			{EventName: "SREday", NewProposals: 3, Accepted: 1},
+		},
+		OpenCFPs: []DigestCFP{
+			{EventName: "LLMday", EventURL: "https://cfp.ninja/e/llmday"},
+		},
+		Matched: true,
 	}
 
-	err := SendWeeklyDigest(ncfg, org, activities)
+	err := SendWeeklyDigest(ncfg, org, digest)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -354,4 +455,276 @@ func TestSendWeeklyDigest(t *testing.T) {
 	if msgs[0].Headers["List-Unsubscribe"] == "" {
 		t.Error("missing List-Unsubscribe header")
 	}
+	if !strings.Contains(msgs[0].HTML, "Open CFPs matching your interests") {
+		t.Error("HTML missing matched CFPs heading")
+	}
+}
+
+func TestSendCFPExtendedNotification(t *testing.T) {
+	mock := &mockSender{}
+	ncfg := newTestNotifyConfig(mock)
+
+	event := &models.Event{
+		Name:         "SREday London",
+		Slug:         "sreday-london",
+		ContactEmail: "hello@sreday.com",
+		CFPCloseAt:   time.Date(2026, 5, 1, 23, 59, 0, 0, time.UTC),
+	}
+	recipients := []models.User{
+		{Email: "alice@example.com", Name: "Alice"},
+		{Email: "bob@example.com", Name: "Bob"},
+		{Name: "No Email"},
+	}
+
+	if sent := SendCFPExtendedNotification(ncfg, event, recipients); sent != 2 {
+		t.Fatalf("expected 2 emails sent, got %d", sent)
+	}
+
+	msgs := mock.Messages()
+	if len(msgs) != 2 {
+		t.Fatalf("expected 2 messages, got %d", len(msgs))
+	}
+	// Each submitter gets their own email so addresses are not shared
+	for i, want := range []string{"alice@example.com", "bob@example.com"} {
+		if len(msgs[i].To) != 1 || msgs[i].To[0] != want || len(msgs[i].Cc) != 0 {
+			t.Errorf("message %d: To = %v, Cc = %v, want only %s", i, msgs[i].To, msgs[i].Cc, want)
+		}
+	}
+	msg := msgs[0]
+	if msg.Subject != "The CFP for SREday London has been extended" {
+		t.Errorf("Subject = %q", msg.Subject)
+	}
+	if msg.ReplyTo != "hello@sreday.com" {
+		t.Errorf("ReplyTo = %q", msg.ReplyTo)
+	}
+	for _, want := range []string{"Hi Alice", "May 1, 2026 at 23:59 UTC", "https://cfp.ninja/e/sreday-london"} {
+		if !strings.Contains(msg.Text, want) {
+			t.Errorf("text body missing %q", want)
+		}
+	}
+}
+
+func TestSendCFPOpenedNotification(t *testing.T) {
+	mock := &mockSender{}
+	ncfg := newTestNotifyConfig(mock)
+
+	event := &models.Event{
+		Name:         "SREday London",
+		Slug:         "sreday-london",
+		ContactEmail: "hello@sreday.com",
+	}
+	interests := []models.CFPInterest{
+		{UserID: 1, UnsubscribeToken: "tok1", User: &models.User{Email: "alice@example.com", Name: "Alice"}},
+		{UserID: 2, UnsubscribeToken: "tok2"},
+	}
+
+	if sent := SendCFPOpenedNotification(ncfg, event, interests); sent != 1 {
+		t.Fatalf("expected 1 email sent, got %d", sent)
+	}
+
+	msgs := mock.Messages()
+	if len(msgs) != 1 {
+		t.Fatalf("expected 1 message, got %d", len(msgs))
+	}
+	msg := msgs[0]
+	if msg.Subject != "The CFP for SREday London is open" {
+		t.Errorf("Subject = %q", msg.Subject)
+	}
+	if got := msg.Headers["List-Unsubscribe"]; got != "<https://cfp.ninja/api/v0/notify-me/unsubscribe?token=tok1>" {
+		t.Errorf("List-Unsubscribe = %q", got)
+	}
+	if msg.Headers["List-Unsubscribe-Post"] != "List-Unsubscribe=One-Click" {
+		t.Errorf("List-Unsubscribe-Post = %q", msg.Headers["List-Unsubscribe-Post"])
+	}
+	for _, want := range []string{"Hi Alice", "https://cfp.ninja/e/sreday-london", "https://cfp.ninja/notify-me/unsubscribe?token=tok1"} {
+		if !strings.Contains(msg.Text, want) {
+			t.Errorf("text body missing %q", want)
+		}
+	}
+	// No deadline, so no "closes on"
+	if strings.Contains(msg.Text, "closes on") {
+		t.Errorf("text body mentions a deadline: %q", msg.Text)
+	}
+}
+
+func TestSendEventHeldNotification(t *testing.T) {
+	mock := &mockSender{}
+	ncfg := newTestNotifyConfig(mock)
+
+	event := &models.Event{Name: "Cheap Pills Summit", Slug: "cheap-pills"}
+	creator := &models.User{Name: "Spammer", Email: "bot@mailinator.com"}
+	admins := []string{"admin@cfp.ninja", "ops@cfp.ninja"}
+	reasons := []string{"disposable_email (30): mailinator.com", "link_density (40): 12 links in 30 words"}
+
+	if err := SendEventHeldNotification(ncfg, event, creator, admins, 70, reasons); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	msgs := mock.Messages()
+	if len(msgs) != 1 {
+		t.Fatalf("expected 1 message, got %d", len(msgs))
+	}
+	msg := msgs[0]
+	if len(msg.To) != 2 || msg.To[0] != "admin@cfp.ninja" {
+		t.Errorf("To = %v", msg.To)
+	}
+	if msg.Subject != "Event held for review: Cheap Pills Summit" {
+		t.Errorf("Subject = %q", msg.Subject)
+	}
+	for _, want := range append([]string{"scored 70", "bot@mailinator.com", "https://cfp.ninja/api/v0/admin/events/moderation"}, reasons...) {
+		if !strings.Contains(msg.Text, want) {
+			t.Errorf("text body missing %q", want)
+		}
+	}
+
+	// Nobody to tell without admins
+	if err := SendEventHeldNotification(ncfg, event, creator, nil, 70, reasons); err != nil || len(mock.Messages()) != 1 {
+		t.Errorf("expected no email without admins, err %v", err)
+	}
+}
+
+func TestSendOrganizerLeftNotification(t *testing.T) {
+	mock := &mockSender{}
+	ncfg := newTestNotifyConfig(mock)
+
+	event := &models.Event{Name: "GopherCon"}
+	event.ID = 7
+	creator := &models.User{Name: "Alice", Email: "alice@example.com"}
+	organizer := &models.User{Email: "bob@example.com"}
+
+	if err := SendOrganizerLeftNotification(ncfg, event, creator, organizer); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	msgs := mock.Messages()
+	if len(msgs) != 1 {
+		t.Fatalf("expected 1 message, got %d", len(msgs))
+	}
+	msg := msgs[0]
+	if len(msg.To) != 1 || msg.To[0] != "alice@example.com" {
+		t.Errorf("To = %v", msg.To)
+	}
+	// Organizers without a name are named by their email
+	if msg.Subject != "bob@example.com left the organisers of GopherCon" {
+		t.Errorf("Subject = %q", msg.Subject)
+	}
+	if !strings.Contains(msg.Text, "/dashboard/events/7") {
+		t.Errorf("text body missing the event link: %s", msg.Text)
+	}
+}
+
+func TestSendScrubNoticeNotification(t *testing.T) {
+	mock := &mockSender{}
+	ncfg := newTestNotifyConfig(mock)
+
+	event := &models.Event{Name: "GopherCon 2023"}
+	event.ID = 7
+	creator := &models.User{Name: "Alice", Email: "alice@example.com"}
+	scrubAt := time.Date(2026, 3, 1, 3, 0, 0, 0, time.UTC)
+
+	if err := SendScrubNoticeNotification(ncfg, event, creator, 12, scrubAt); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	msgs := mock.Messages()
+	if len(msgs) != 1 {
+		t.Fatalf("expected 1 message, got %d", len(msgs))
+	}
+	msg := msgs[0]
+	if len(msg.To) != 1 || msg.To[0] != "alice@example.com" {
+		t.Errorf("To = %v", msg.To)
+	}
+	if msg.Subject != "Speaker details for GopherCon 2023 will be removed on March 1, 2026" {
+		t.Errorf("Subject = %q", msg.Subject)
+	}
+	if !strings.Contains(msg.Text, "12 proposals") || !strings.Contains(msg.Text, "/dashboard/events/7") {
+		t.Errorf("text body missing the proposal count or event link: %s", msg.Text)
+	}
+}
+
+func TestSendNewLoginCountryNotification(t *testing.T) {
+	mock := &mockSender{}
+	ncfg := newTestNotifyConfig(mock)
+
+	user := &models.User{Name: "Alice", Email: "alice@example.com"}
+	login := &models.LoginEvent{
+		Provider:  "github",
+		IP:        "81.2.69.160",
+		UserAgent: "Mozilla/5.0",
+		Country:   "GB",
+		CreatedAt: time.Date(2026, 3, 1, 12, 30, 0, 0, time.UTC),
+	}
+
+	if err := SendNewLoginCountryNotification(ncfg, user, login, "United Kingdom", "GitHub"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	msgs := mock.Messages()
+	if len(msgs) != 1 {
+		t.Fatalf("expected 1 message, got %d", len(msgs))
+	}
+	msg := msgs[0]
+	if len(msg.To) != 1 || msg.To[0] != "alice@example.com" {
+		t.Errorf("To = %v", msg.To)
+	}
+	if msg.Subject != "New sign-in to your CFP.ninja account from United Kingdom" {
+		t.Errorf("Subject = %q", msg.Subject)
+	}
+	for _, want := range []string{"Hi Alice", "81.2.69.160", "Mozilla/5.0", "March 1, 2026 at 12:30 UTC", "GitHub", "https://cfp.ninja/api/v0/me/logins"} {
+		if !strings.Contains(msg.Text, want) {
+			t.Errorf("text body missing %q", want)
+		}
+	}
+}
+
+func TestSendSpeakerMessage(t *testing.T) {
+	mock := &mockSender{}
+	ncfg := newTestNotifyConfig(mock)
+
+	event := &models.Event{Name: "SREday London", Slug: "sreday-london"}
+	recipients := []SpeakerRecipient{
+		{Name: "Alice", Email: "alice@example.com", TalkTitle: "Chaos <Engineering>", ProposalID: 1},
+		{Name: "Bob", Email: "bob@example.com", TalkTitle: "On-call Without Tears", ProposalID: 2},
+		{Name: "No Email", TalkTitle: "Ghost Talk", ProposalID: 3},
+	}
+	body := "Hi {{speaker_name}},\n\nAV check for \"{{ talk_title }}\" at {{event_name}} is at 9am."
+
+	if sent := SendSpeakerMessage(ncfg, event, "hello@sreday.com", "{{event_name}}: AV for {{talk_title}}", body, recipients); sent != 2 {
+		t.Fatalf("expected 2 emails sent, got %d", sent)
+	}
+
+	msgs := mock.Messages()
+	if len(msgs) != 2 {
+		t.Fatalf("expected 2 messages, got %d", len(msgs))
+	}
+	msg := msgs[0]
+	if len(msg.To) != 1 || msg.To[0] != "alice@example.com" {
+		t.Errorf("To = %v", msg.To)
+	}
+	if msg.ReplyTo != "hello@sreday.com" {
+		t.Errorf("ReplyTo = %q", msg.ReplyTo)
+	}
+	if msg.Subject != "SREday London: AV for Chaos <Engineering>" {
+		t.Errorf("Subject = %q", msg.Subject)
+	}
+	if want := "Hi Alice,\n\nAV check for \"Chaos <Engineering>\" at SREday London is at 9am."; !strings.Contains(msg.Text, want) {
+		t.Errorf("text body missing %q:\n%s", want, msg.Text)
+	}
+	if !strings.Contains(msg.HTML, "Chaos &lt;Engineering&gt;") {
+		t.Error("expected variables to be HTML-escaped in the HTML body")
+	}
+	if !strings.Contains(msgs[1].Text, "Hi Bob") {
+		t.Errorf("second message not personalised:\n%s", msgs[1].Text)
+	}
+}
+
+func TestUnknownSpeakerVariables(t *testing.T) {
+	got := UnknownSpeakerVariables("Hi {{speaker_name}}, {{ talk_titel }} at {{venue}} {{venue}} {{}}")
+	want := []string{"talk_titel", "venue", ""}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("UnknownSpeakerVariables = %q, want %q", got, want)
+	}
+	if got := UnknownSpeakerVariables("{{speaker_name}} {{talk_title}} {{event_name}}"); len(got) != 0 {
+		t.Errorf("expected no unknown variables, got %q", got)
+	}
 }
@@ -5,6 +5,11 @@ import (
 	"embed"
 	"fmt"
 	htmltemplate "html/template"
+	"io/fs"
+	"os"
+	"path"
+	"strings"
+	"sync"
 	texttemplate "text/template"
 )
 
@@ -12,6 +17,7 @@ import (
 var templateFS embed.FS
 
 var (
+	templatesMu   sync.RWMutex
 	htmlTemplates *htmltemplate.Template
 	textTemplates *texttemplate.Template
 )
@@ -21,14 +27,82 @@ func init() {
 	textTemplates = texttemplate.Must(texttemplate.ParseFS(templateFS, "templates/*.txt"))
 }
 
+// LoadTemplates replaces the built-in templates with any of the same name
+// found in dir, so email copy can change without a redeploy. Overrides must
+// use the built-in file names (e.g. proposal_accepted.html); unknown names and
+// templates that fail to parse are errors, and the templates in use are left
+// unchanged. An empty dir restores the built-in templates.
+func LoadTemplates(dir string) error {
+	html, err := htmltemplate.ParseFS(templateFS, "templates/*.html")
+	if err != nil {
+		return err
+	}
+	text, err := texttemplate.ParseFS(templateFS, "templates/*.txt")
+	if err != nil {
+		return err
+	}
+
+	if dir != "" {
+		if err := loadOverrides(os.DirFS(dir), html, text); err != nil {
+			return fmt.Errorf("email templates in %s: %w", dir, err)
+		}
+	}
+
+	templatesMu.Lock()
+	htmlTemplates, textTemplates = html, text
+	templatesMu.Unlock()
+	return nil
+}
+
+// loadOverrides parses each template file in fsys into html or text,
+// replacing the built-in template of the same name
+func loadOverrides(fsys fs.FS, html *htmltemplate.Template, text *texttemplate.Template) error {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		ext := path.Ext(name)
+		if entry.IsDir() || (ext != ".html" && ext != ".txt") {
+			continue
+		}
+		content, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return err
+		}
+
+		if ext == ".html" {
+			if html.Lookup(name) == nil {
+				return fmt.Errorf("%s: no built-in template %s", name, strings.TrimSuffix(name, ext))
+			}
+			if _, err := html.New(name).Parse(string(content)); err != nil {
+				return err
+			}
+		} else {
+			if text.Lookup(name) == nil {
+				return fmt.Errorf("%s: no built-in template %s", name, strings.TrimSuffix(name, ext))
+			}
+			if _, err := text.New(name).Parse(string(content)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 // Render executes the named template pair and returns HTML and plain text bodies.
 func Render(name string, data interface{}) (html string, text string, err error) {
 	var htmlBuf, textBuf bytes.Buffer
 
-	if err := htmlTemplates.ExecuteTemplate(&htmlBuf, name+".html", data); err != nil {
+	templatesMu.RLock()
+	htmlTmpl, textTmpl := htmlTemplates, textTemplates
+	templatesMu.RUnlock()
+
+	if err := htmlTmpl.ExecuteTemplate(&htmlBuf, name+".html", data); err != nil {
 		return "", "", fmt.Errorf("render html %s: %w", name, err)
 	}
-	if err := textTemplates.ExecuteTemplate(&textBuf, name+".txt", data); err != nil {
+	if err := textTmpl.ExecuteTemplate(&textBuf, name+".txt", data); err != nil {
 		return "", "", fmt.Errorf("render text %s: %w", name, err)
 	}
 
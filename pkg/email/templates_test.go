@@ -1,6 +1,8 @@
 package email
 
 import (
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 )
@@ -151,27 +153,34 @@ func TestRenderAttendanceConfirmed(t *testing.T) {
 
 func TestRenderWeeklyDigest(t *testing.T) {
 	data := struct {
-		OrganizerName string
+		RecipientName string
 		Events        []struct {
 			EventName    string
 			NewProposals int
 			Accepted     int
 			Rejected     int
 			Confirmed    int
+			Recent       []string
 		}
+		OpenCFPs     []DigestCFP
+		Matched      bool
 		DashboardURL string
 	}{
-		OrganizerName: "Eve",
+		RecipientName: "Eve",
 		Events: []struct {
 			EventName    string
 			NewProposals int
 			Accepted     int
 			Rejected     int
 			Confirmed    int
+			Recent       []string
 		}{
-			{EventName: "SREday London", NewProposals: 5, Accepted: 2, Rejected: 1, Confirmed: 1},
+			{EventName: "SREday London", NewProposals: 5, Accepted: 2, Rejected: 1, Confirmed: 1, Recent: []string{"Alice accepted 2 proposals"}},
 			{EventName: "LLMday Paris", NewProposals: 3, Accepted: 0, Rejected: 0, Confirmed: 0},
 		},
+		OpenCFPs: []DigestCFP{
+			{EventName: "DevOpsDays Berlin", Location: "Berlin", EventURL: "https://cfp.ninja/e/devopsdays-berlin"},
+		},
 		DashboardURL: "https://cfp.ninja/dashboard",
 	}
 
@@ -189,6 +198,12 @@ func TestRenderWeeklyDigest(t *testing.T) {
 	if !strings.Contains(text, "Eve") {
 		t.Error("text missing organizer name")
 	}
+	if !strings.Contains(html, "<li>Alice accepted 2 proposals</li>") || !strings.Contains(text, "- Alice accepted 2 proposals") {
+		t.Error("digest missing recent activity")
+	}
+	if !strings.Contains(html, "Popular open CFPs") || !strings.Contains(text, "https://cfp.ninja/e/devopsdays-berlin") {
+		t.Error("digest missing open CFPs")
+	}
 }
 
 func TestRenderInvalidTemplate(t *testing.T) {
@@ -197,3 +212,54 @@ func TestRenderInvalidTemplate(t *testing.T) {
 		t.Error("expected error for nonexistent template")
 	}
 }
+
+func TestLoadTemplates_Override(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "proposal_rejected.txt"), []byte("Sorry {{.SpeakerName}}, not this time."), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := LoadTemplates(dir); err != nil {
+		t.Fatalf("LoadTemplates failed: %v", err)
+	}
+	t.Cleanup(func() { LoadTemplates("") })
+
+	data := proposalStatusData{SpeakerName: "Jane", ProposalTitle: "Talk", EventName: "SREday"}
+	html, text, err := Render("proposal_rejected", data)
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	if text != "Sorry Jane, not this time." {
+		t.Errorf("text = %q, want the override", text)
+	}
+	if !strings.Contains(html, "SREday") {
+		t.Error("HTML should still use the built-in template")
+	}
+
+	if err := LoadTemplates(""); err != nil {
+		t.Fatalf("LoadTemplates failed: %v", err)
+	}
+	if _, text, _ := Render("proposal_rejected", data); text == "Sorry Jane, not this time." {
+		t.Error("expected the built-in template after resetting")
+	}
+}
+
+func TestLoadTemplates_Invalid(t *testing.T) {
+	for name, content := range map[string]string{
+		"proposal_rejected.html": "{{if .SpeakerName}}unclosed",
+		"no_such_email.txt":      "Hello",
+	} {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			if err := LoadTemplates(dir); err == nil {
+				t.Error("expected an error")
+			}
+			// The templates in use are unchanged
+			if _, _, err := Render("proposal_rejected", proposalStatusData{}); err != nil {
+				t.Errorf("Render failed after a rejected override: %v", err)
+			}
+		})
+	}
+}
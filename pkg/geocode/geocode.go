@@ -0,0 +1,192 @@
+// Package geocode resolves event locations to coordinates and provides the
+// distance helpers used by near-me event searches.
+package geocode
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrNotFound is returned when a location cannot be resolved to coordinates
+var ErrNotFound = errors.New("geocode: location not found")
+
+// EarthRadiusKm is the mean Earth radius used for distance calculations
+const EarthRadiusKm = 6371.0
+
+// Point is a WGS 84 coordinate
+type Point struct {
+	Lat float64
+	Lon float64
+}
+
+// Geocoder resolves a free-form location, optionally restricted to an
+// ISO 3166-1 alpha-2 country code, to coordinates.
+type Geocoder interface {
+	Geocode(ctx context.Context, location, countryCode string) (Point, error)
+}
+
+// NoopGeocoder resolves nothing. Used when geocoding is disabled.
+type NoopGeocoder struct{}
+
+func (NoopGeocoder) Geocode(context.Context, string, string) (Point, error) {
+	return Point{}, ErrNotFound
+}
+
+// cacheEntry is a cached lookup; negative results expire sooner so fixed
+// typos or newly mapped places are picked up again
+type cacheEntry struct {
+	point   Point
+	found   bool
+	expires time.Time
+}
+
+// Cache memoizes lookups of another Geocoder in memory. Only definitive results
+// are cached: transient errors (timeouts, 5xx) are retried on the next call.
+type Cache struct {
+	next        Geocoder
+	ttl         time.Duration
+	negativeTTL time.Duration
+
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+}
+
+// NewCache wraps next with an in-memory cache
+func NewCache(next Geocoder) *Cache {
+	return &Cache{
+		next:        next,
+		ttl:         30 * 24 * time.Hour,
+		negativeTTL: 24 * time.Hour,
+		entries:     make(map[string]cacheEntry),
+	}
+}
+
+func (c *Cache) Geocode(ctx context.Context, location, countryCode string) (Point, error) {
+	key := strings.ToLower(strings.TrimSpace(location)) + "|" + strings.ToLower(countryCode)
+
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	c.mu.Unlock()
+	if ok && time.Now().Before(entry.expires) {
+		if !entry.found {
+			return Point{}, ErrNotFound
+		}
+		return entry.point, nil
+	}
+
+	p, err := c.next.Geocode(ctx, location, countryCode)
+	switch {
+	case err == nil:
+		entry = cacheEntry{point: p, found: true, expires: time.Now().Add(c.ttl)}
+	case errors.Is(err, ErrNotFound):
+		entry = cacheEntry{expires: time.Now().Add(c.negativeTTL)}
+	default:
+		return Point{}, err
+	}
+
+	c.mu.Lock()
+	c.entries[key] = entry
+	c.mu.Unlock()
+	return p, err
+}
+
+// RateLimit spaces calls to another Geocoder at least interval apart, as
+// required by public geocoding services such as Nominatim (1 request/second).
+type RateLimit struct {
+	next     Geocoder
+	interval time.Duration
+
+	mu       sync.Mutex
+	nextSlot time.Time
+}
+
+// NewRateLimit wraps next so it is called at most once per interval
+func NewRateLimit(next Geocoder, interval time.Duration) *RateLimit {
+	return &RateLimit{next: next, interval: interval}
+}
+
+func (l *RateLimit) Geocode(ctx context.Context, location, countryCode string) (Point, error) {
+	// Reserve the next slot, then wait for it outside the lock
+	l.mu.Lock()
+	now := time.Now()
+	slot := l.nextSlot
+	if slot.Before(now) {
+		slot = now
+	}
+	l.nextSlot = slot.Add(l.interval)
+	l.mu.Unlock()
+
+	if wait := time.Until(slot); wait > 0 {
+		timer := time.NewTimer(wait)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			return Point{}, ctx.Err()
+		case <-timer.C:
+		}
+	}
+	return l.next.Geocode(ctx, location, countryCode)
+}
+
+// DistanceKm returns the great-circle distance between two points using the
+// haversine formula
+func DistanceKm(a, b Point) float64 {
+	lat1, lat2 := radians(a.Lat), radians(b.Lat)
+	dLat := lat2 - lat1
+	dLon := radians(b.Lon - a.Lon)
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * EarthRadiusKm * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// BoundingBox is a latitude/longitude rectangle. When it crosses the
+// antimeridian, MinLon is greater than MaxLon.
+type BoundingBox struct {
+	MinLat, MaxLat float64
+	MinLon, MaxLon float64
+}
+
+// CrossesAntimeridian reports whether the box wraps around longitude ±180
+func (b BoundingBox) CrossesAntimeridian() bool {
+	return b.MinLon > b.MaxLon
+}
+
+// Around returns a box containing every point within radiusKm of center. It is
+// a cheap, index-friendly pre-filter; callers still check the exact distance.
+func Around(center Point, radiusKm float64) BoundingBox {
+	dLat := degrees(radiusKm / EarthRadiusKm)
+	box := BoundingBox{
+		MinLat: math.Max(center.Lat-dLat, -90),
+		MaxLat: math.Min(center.Lat+dLat, 90),
+		MinLon: -180,
+		MaxLon: 180,
+	}
+
+	// Near the poles, or for huge radii, every longitude is in range
+	if box.MinLat == -90 || box.MaxLat == 90 {
+		return box
+	}
+	dLon := degrees(math.Asin(math.Sin(radiusKm/EarthRadiusKm) / math.Cos(radians(center.Lat))))
+	if math.IsNaN(dLon) || dLon >= 180 {
+		return box
+	}
+	box.MinLon = wrapLon(center.Lon - dLon)
+	box.MaxLon = wrapLon(center.Lon + dLon)
+	return box
+}
+
+func wrapLon(lon float64) float64 {
+	if lon < -180 {
+		return lon + 360
+	}
+	if lon > 180 {
+		return lon - 360
+	}
+	return lon
+}
+
+func radians(deg float64) float64 { return deg * math.Pi / 180 }
+func degrees(rad float64) float64 { return rad * 180 / math.Pi }
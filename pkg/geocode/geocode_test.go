@@ -0,0 +1,185 @@
+package geocode
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+var (
+	berlin = Point{Lat: 52.52, Lon: 13.405}
+	london = Point{Lat: 51.5074, Lon: -0.1278}
+)
+
+func TestDistanceKm(t *testing.T) {
+	if d := DistanceKm(berlin, london); math.Abs(d-932) > 5 {
+		t.Errorf("expected Berlin-London ~932 km, got %.1f", d)
+	}
+	if d := DistanceKm(berlin, berlin); d != 0 {
+		t.Errorf("expected 0 km to itself, got %.1f", d)
+	}
+}
+
+func TestAround(t *testing.T) {
+	box := Around(berlin, 500)
+	if box.CrossesAntimeridian() {
+		t.Fatal("expected box around Berlin not to cross the antimeridian")
+	}
+	// Every point on the circle must be inside the box
+	for bearing := 0.0; bearing < 360; bearing += 15 {
+		p := destination(berlin, bearing, 499)
+		if p.Lat < box.MinLat || p.Lat > box.MaxLat || p.Lon < box.MinLon || p.Lon > box.MaxLon {
+			t.Errorf("point at bearing %.0f (%v) outside box %+v", bearing, p, box)
+		}
+	}
+	// London is ~930 km away, well outside
+	if london.Lon >= box.MinLon {
+		t.Errorf("expected London west of the box, box %+v", box)
+	}
+}
+
+func TestAround_Antimeridian(t *testing.T) {
+	fiji := Point{Lat: -17.7, Lon: 178.0}
+	box := Around(fiji, 500)
+	if !box.CrossesAntimeridian() {
+		t.Fatalf("expected box around Fiji to cross the antimeridian, got %+v", box)
+	}
+	if box.MinLon < 170 || box.MaxLon > -170 {
+		t.Errorf("unexpected longitude range %+v", box)
+	}
+}
+
+func TestAround_Pole(t *testing.T) {
+	box := Around(Point{Lat: 89.5, Lon: 0}, 200)
+	if box.MinLon != -180 || box.MaxLon != 180 {
+		t.Errorf("expected all longitudes near the pole, got %+v", box)
+	}
+}
+
+// destination returns the point distanceKm from p along bearing (degrees)
+func destination(p Point, bearing, distanceKm float64) Point {
+	d := distanceKm / EarthRadiusKm
+	b := radians(bearing)
+	lat1, lon1 := radians(p.Lat), radians(p.Lon)
+	lat2 := math.Asin(math.Sin(lat1)*math.Cos(d) + math.Cos(lat1)*math.Sin(d)*math.Cos(b))
+	lon2 := lon1 + math.Atan2(math.Sin(b)*math.Sin(d)*math.Cos(lat1), math.Cos(d)-math.Sin(lat1)*math.Sin(lat2))
+	return Point{Lat: degrees(lat2), Lon: degrees(lon2)}
+}
+
+type countingGeocoder struct {
+	calls int
+	point Point
+	err   error
+}
+
+func (g *countingGeocoder) Geocode(context.Context, string, string) (Point, error) {
+	g.calls++
+	return g.point, g.err
+}
+
+func TestCache(t *testing.T) {
+	next := &countingGeocoder{point: berlin}
+	c := NewCache(next)
+
+	for i := 0; i < 3; i++ {
+		p, err := c.Geocode(context.Background(), " Berlin ", "DE")
+		if err != nil || p != berlin {
+			t.Fatalf("expected %v, got %v (err %v)", berlin, p, err)
+		}
+	}
+	c.Geocode(context.Background(), "berlin", "de")
+	if next.calls != 1 {
+		t.Errorf("expected 1 upstream call, got %d", next.calls)
+	}
+}
+
+func TestCache_NotFoundCachedTransientNot(t *testing.T) {
+	notFound := &countingGeocoder{err: ErrNotFound}
+	c := NewCache(notFound)
+	c.Geocode(context.Background(), "Atlantis", "")
+	if _, err := c.Geocode(context.Background(), "Atlantis", ""); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+	if notFound.calls != 1 {
+		t.Errorf("expected not-found result cached, got %d calls", notFound.calls)
+	}
+
+	failing := &countingGeocoder{err: errors.New("timeout")}
+	c = NewCache(failing)
+	c.Geocode(context.Background(), "Berlin", "")
+	c.Geocode(context.Background(), "Berlin", "")
+	if failing.calls != 2 {
+		t.Errorf("expected transient errors not cached, got %d calls", failing.calls)
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	next := &countingGeocoder{point: berlin}
+	l := NewRateLimit(next, 50*time.Millisecond)
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if _, err := l.Geocode(context.Background(), "Berlin", ""); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 100*time.Millisecond {
+		t.Errorf("expected calls spaced 50ms apart, 3 calls took %v", elapsed)
+	}
+
+	// A cancelled context gives up waiting for its slot
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := l.Geocode(ctx, "Berlin", ""); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestNominatim(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/search" {
+			t.Errorf("expected /search, got %s", r.URL.Path)
+		}
+		if ua := r.Header.Get("User-Agent"); ua != "cfp.ninja-test" {
+			t.Errorf("expected User-Agent cfp.ninja-test, got %q", ua)
+		}
+		if cc := r.URL.Query().Get("countrycodes"); cc != "de" {
+			t.Errorf("expected countrycodes de, got %q", cc)
+		}
+		if r.URL.Query().Get("q") == "Nowhere" {
+			w.Write([]byte(`[]`))
+			return
+		}
+		w.Write([]byte(`[{"lat":"52.5170365","lon":"13.3888599","display_name":"Berlin, Deutschland"}]`))
+	}))
+	defer srv.Close()
+
+	n := NewNominatim(srv.URL, "cfp.ninja-test")
+	p, err := n.Geocode(context.Background(), "Berlin", "DE")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p.Lat != 52.5170365 || p.Lon != 13.3888599 {
+		t.Errorf("unexpected point %v", p)
+	}
+
+	if _, err := n.Geocode(context.Background(), "Nowhere", "DE"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestNominatim_HTTPError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+
+	_, err := NewNominatim(srv.URL, "test").Geocode(context.Background(), "Berlin", "")
+	if err == nil || errors.Is(err, ErrNotFound) {
+		t.Errorf("expected a transient error, got %v", err)
+	}
+}
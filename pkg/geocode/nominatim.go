@@ -0,0 +1,91 @@
+package geocode
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const DefaultNominatimURL = "https://nominatim.openstreetmap.org"
+
+// Nominatim geocodes with the OpenStreetMap Nominatim search API. The public
+// instance requires an identifying User-Agent and at most one request per
+// second, so wrap it with NewRateLimit and NewCache.
+type Nominatim struct {
+	BaseURL    string
+	UserAgent  string
+	HTTPClient *http.Client
+}
+
+// NewNominatim creates a Nominatim geocoder. An empty baseURL uses the public instance.
+func NewNominatim(baseURL, userAgent string) *Nominatim {
+	if baseURL == "" {
+		baseURL = DefaultNominatimURL
+	}
+	return &Nominatim{
+		BaseURL:   strings.TrimRight(baseURL, "/"),
+		UserAgent: userAgent,
+		HTTPClient: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+	}
+}
+
+type nominatimResult struct {
+	Lat string `json:"lat"`
+	Lon string `json:"lon"`
+}
+
+func (n *Nominatim) Geocode(ctx context.Context, location, countryCode string) (Point, error) {
+	if strings.TrimSpace(location) == "" {
+		return Point{}, ErrNotFound
+	}
+
+	params := url.Values{}
+	params.Set("q", location)
+	params.Set("format", "jsonv2")
+	params.Set("limit", "1")
+	if countryCode != "" {
+		params.Set("countrycodes", strings.ToLower(countryCode))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, n.BaseURL+"/search?"+params.Encode(), nil)
+	if err != nil {
+		return Point{}, err
+	}
+	req.Header.Set("User-Agent", n.UserAgent)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := n.HTTPClient.Do(req)
+	if err != nil {
+		return Point{}, fmt.Errorf("nominatim: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return Point{}, fmt.Errorf("nominatim: HTTP %d", resp.StatusCode)
+	}
+
+	var results []nominatimResult
+	if err := json.NewDecoder(resp.Body).Decode(&results); err != nil {
+		return Point{}, fmt.Errorf("nominatim: decoding response: %w", err)
+	}
+	if len(results) == 0 {
+		return Point{}, ErrNotFound
+	}
+
+	lat, err := strconv.ParseFloat(results[0].Lat, 64)
+	if err != nil {
+		return Point{}, fmt.Errorf("nominatim: invalid latitude %q", results[0].Lat)
+	}
+	lon, err := strconv.ParseFloat(results[0].Lon, 64)
+	if err != nil {
+		return Point{}, fmt.Errorf("nominatim: invalid longitude %q", results[0].Lon)
+	}
+	return Point{Lat: lat, Lon: lon}, nil
+}
@@ -0,0 +1,77 @@
+// Package geoip resolves client IP addresses to countries, used to warn users
+// about logins from countries their account has not been used from.
+package geoip
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Locator resolves an IP address to an ISO 3166-1 alpha-2 country code. An
+// empty code with a nil error means the country is unknown.
+type Locator interface {
+	Country(ctx context.Context, ip string) (string, error)
+}
+
+// NoopLocator resolves nothing. Used when no lookup service is configured.
+type NoopLocator struct{}
+
+func (NoopLocator) Country(context.Context, string) (string, error) {
+	return "", nil
+}
+
+// HTTPLocator looks countries up with a web service that answers with the
+// bare country code, such as https://ipinfo.io/{ip}/country. The {ip}
+// placeholder in URL is replaced with the address being looked up.
+type HTTPLocator struct {
+	URL        string
+	HTTPClient *http.Client
+}
+
+// NewHTTPLocator creates an HTTPLocator for the given URL template
+func NewHTTPLocator(urlTemplate string) *HTTPLocator {
+	return &HTTPLocator{
+		URL: urlTemplate,
+		HTTPClient: &http.Client{
+			Timeout: 5 * time.Second,
+		},
+	}
+}
+
+func (l *HTTPLocator) Country(ctx context.Context, ip string) (string, error) {
+	if ip == "" {
+		return "", nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.ReplaceAll(l.URL, "{ip}", url.PathEscape(ip)), nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Accept", "text/plain")
+
+	resp, err := l.HTTPClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("geoip: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("geoip: HTTP %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 64))
+	if err != nil {
+		return "", fmt.Errorf("geoip: reading response: %w", err)
+	}
+	code := strings.ToUpper(strings.TrimSpace(string(body)))
+	if len(code) != 2 {
+		// Private and reserved addresses have no country
+		return "", nil
+	}
+	return code, nil
+}
@@ -0,0 +1,43 @@
+package geoip
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHTTPLocator(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/81.2.69.160/country":
+			w.Write([]byte("gb\n"))
+		case "/10.0.0.1/country":
+			w.Write([]byte("undefined"))
+		default:
+			http.Error(w, "bad request", http.StatusBadRequest)
+		}
+	}))
+	defer srv.Close()
+
+	l := NewHTTPLocator(srv.URL + "/{ip}/country")
+	tests := []struct {
+		ip      string
+		want    string
+		wantErr bool
+	}{
+		{"81.2.69.160", "GB", false},
+		{"10.0.0.1", "", false},
+		{"", "", false},
+		{"not-an-ip", "", true},
+	}
+	for _, tt := range tests {
+		got, err := l.Country(context.Background(), tt.ip)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("Country(%q) error = %v, wantErr %v", tt.ip, err, tt.wantErr)
+		}
+		if got != tt.want {
+			t.Errorf("Country(%q) = %q, want %q", tt.ip, got, tt.want)
+		}
+	}
+}
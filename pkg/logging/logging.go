@@ -0,0 +1,188 @@
+// Package logging builds the server's loggers. Every package logs through a
+// child of the root logger tagged with its module, and each module can log
+// at its own level, so debug logs can be turned on for one part of the
+// server without drowning the rest in them.
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"sync/atomic"
+)
+
+// ModuleKey is the attribute naming the module a line was logged by
+const ModuleKey = "module"
+
+// Module names, each with its own LOG_LEVEL_<MODULE> override
+const (
+	API      = "api"
+	Payments = "payments"
+	Tasks    = "tasks"
+	Email    = "email"
+	Server   = "server"
+)
+
+// Modules lists the modules whose level can be set
+var Modules = []string{API, Payments, Tasks, Email, Server}
+
+// Formats are the supported LOG_FORMAT values
+const (
+	FormatJSON = "json"
+	FormatText = "text"
+)
+
+// Options configures the root logger
+type Options struct {
+	Format string
+	Level  slog.Level
+	// ModuleLevels overrides Level for some modules
+	ModuleLevels map[string]slog.Level
+}
+
+// New returns the root logger writing to w. Use Module for the logger of a
+// package.
+func New(w io.Writer, opts Options) (*slog.Logger, error) {
+	minLevel := opts.Level
+	for _, level := range opts.ModuleLevels {
+		minLevel = min(minLevel, level)
+	}
+	handlerOpts := &slog.HandlerOptions{Level: minLevel}
+
+	var handler slog.Handler
+	switch opts.Format {
+	case "", FormatJSON:
+		handler = slog.NewJSONHandler(w, handlerOpts)
+	case FormatText:
+		handler = slog.NewTextHandler(w, handlerOpts)
+	default:
+		return nil, fmt.Errorf("invalid log format %q: must be %s or %s", opts.Format, FormatJSON, FormatText)
+	}
+	levels := &levels{level: opts.Level, modules: opts.ModuleLevels}
+	return slog.New(&moduleHandler{root: handler, next: handler, levels: levels, level: opts.Level}), nil
+}
+
+// ParseLevel parses a level name such as debug, info, warn or error
+func ParseLevel(s string) (slog.Level, error) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(strings.TrimSpace(s))); err != nil {
+		return 0, fmt.Errorf("invalid log level %q: must be debug, info, warn or error", s)
+	}
+	return level, nil
+}
+
+// Module returns the child of logger for a module, logging at that module's
+// level. The module replaces the one logger was for, if any. Loggers not
+// made by New get the module attribute only, and nil stands for the default
+// logger.
+func Module(logger *slog.Logger, module string) *slog.Logger {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	h, ok := logger.Handler().(*moduleHandler)
+	if !ok {
+		return logger.With(ModuleKey, module)
+	}
+	return slog.New(&moduleHandler{
+		root:   h.root,
+		next:   h.root.WithAttrs([]slog.Attr{slog.String(ModuleKey, module)}),
+		levels: h.levels,
+		level:  h.levels.of(module),
+	})
+}
+
+type levels struct {
+	level   slog.Level
+	modules map[string]slog.Level
+}
+
+func (l *levels) of(module string) slog.Level {
+	if level, ok := l.modules[module]; ok {
+		return level
+	}
+	return l.level
+}
+
+// moduleHandler filters records at its module's level. root is the handler
+// without the module attribute, for switching modules.
+type moduleHandler struct {
+	root   slog.Handler
+	next   slog.Handler
+	levels *levels
+	level  slog.Level
+}
+
+func (h *moduleHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return level >= h.level && h.next.Enabled(ctx, level)
+}
+
+func (h *moduleHandler) Handle(ctx context.Context, r slog.Record) error {
+	return h.next.Handle(ctx, r)
+}
+
+func (h *moduleHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &moduleHandler{root: h.root.WithAttrs(attrs), next: h.next.WithAttrs(attrs), levels: h.levels, level: h.level}
+}
+
+func (h *moduleHandler) WithGroup(name string) slog.Handler {
+	return &moduleHandler{root: h.root.WithGroup(name), next: h.next.WithGroup(name), levels: h.levels, level: h.level}
+}
+
+// Sampler thins out high-volume lines, such as one per synced event: the
+// first First info and debug lines are logged, then one in every Every.
+// Warnings and errors are always logged. Every below 2 logs every line.
+type Sampler struct {
+	First int
+	Every int
+
+	seen    atomic.Int64
+	dropped atomic.Int64
+}
+
+// Logger returns logger logging through the sampler
+func (s *Sampler) Logger(logger *slog.Logger) *slog.Logger {
+	if s.Every < 2 {
+		return logger
+	}
+	return slog.New(&sampledHandler{next: logger.Handler(), sampler: s})
+}
+
+// Dropped returns how many lines the sampler left out
+func (s *Sampler) Dropped() int64 {
+	return s.dropped.Load()
+}
+
+func (s *Sampler) keep() bool {
+	n := s.seen.Add(1)
+	if n <= int64(s.First) || (n-int64(s.First))%int64(s.Every) == 0 {
+		return true
+	}
+	s.dropped.Add(1)
+	return false
+}
+
+type sampledHandler struct {
+	next    slog.Handler
+	sampler *Sampler
+}
+
+func (h *sampledHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+func (h *sampledHandler) Handle(ctx context.Context, r slog.Record) error {
+	if r.Level < slog.LevelWarn && !h.sampler.keep() {
+		return nil
+	}
+	return h.next.Handle(ctx, r)
+}
+
+func (h *sampledHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &sampledHandler{next: h.next.WithAttrs(attrs), sampler: h.sampler}
+}
+
+func (h *sampledHandler) WithGroup(name string) slog.Handler {
+	return &sampledHandler{next: h.next.WithGroup(name), sampler: h.sampler}
+}
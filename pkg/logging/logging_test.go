@@ -0,0 +1,137 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func lines(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+	var out []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var m map[string]any
+		if err := json.Unmarshal([]byte(line), &m); err != nil {
+			t.Fatalf("invalid JSON line %q: %v", line, err)
+		}
+		out = append(out, m)
+	}
+	return out
+}
+
+func TestModule_Levels(t *testing.T) {
+	var buf bytes.Buffer
+	root, err := New(&buf, Options{Level: slog.LevelInfo, ModuleLevels: map[string]slog.Level{
+		Payments: slog.LevelDebug,
+		Tasks:    slog.LevelWarn,
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	api := Module(root, API)
+	payments := Module(api.With("request_id", "abc"), Payments)
+	tasks := Module(root, Tasks)
+
+	root.Debug("root debug")
+	api.Debug("api debug")
+	api.Info("api info")
+	payments.Debug("payments debug")
+	tasks.Info("tasks info")
+	tasks.Warn("tasks warn")
+
+	got := lines(t, &buf)
+	want := []struct{ msg, module string }{
+		{"api info", API},
+		{"payments debug", Payments},
+		{"tasks warn", Tasks},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d lines, got %v", len(want), got)
+	}
+	for i, w := range want {
+		if got[i]["msg"] != w.msg || got[i][ModuleKey] != w.module {
+			t.Errorf("line %d: expected %q from %s, got %v", i, w.msg, w.module, got[i])
+		}
+	}
+	// Switching modules keeps the other attributes and doesn't repeat the module
+	if got[1]["request_id"] != "abc" || strings.Count(buf.String(), `"module"`) != 3 {
+		t.Errorf("unexpected attributes:\n%s", buf.String())
+	}
+}
+
+func TestNew_Format(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := New(&buf, Options{Format: FormatText})
+	if err != nil {
+		t.Fatal(err)
+	}
+	Module(logger, Server).Info("started")
+	if !strings.Contains(buf.String(), "msg=started module=server") {
+		t.Errorf("expected a text line, got %q", buf.String())
+	}
+
+	if _, err := New(&buf, Options{Format: "xml"}); err == nil {
+		t.Error("expected an unknown format to be rejected")
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	for s, want := range map[string]slog.Level{"debug": slog.LevelDebug, "INFO": slog.LevelInfo, " warn ": slog.LevelWarn, "error": slog.LevelError} {
+		if got, err := ParseLevel(s); err != nil || got != want {
+			t.Errorf("%q: expected %v, got %v (%v)", s, want, got, err)
+		}
+	}
+	if _, err := ParseLevel("verbose"); err == nil {
+		t.Error("expected an unknown level to be rejected")
+	}
+}
+
+func TestModule_ForeignLogger(t *testing.T) {
+	var buf bytes.Buffer
+	Module(slog.New(slog.NewJSONHandler(&buf, nil)), Email).Info("sent")
+	if got := lines(t, &buf); len(got) != 1 || got[0][ModuleKey] != Email {
+		t.Errorf("expected the module attribute, got %v", got)
+	}
+}
+
+func TestSampler(t *testing.T) {
+	var buf bytes.Buffer
+	sampler := &Sampler{First: 2, Every: 3}
+	logger := sampler.Logger(slog.New(slog.NewJSONHandler(&buf, nil)))
+	for i := range 8 {
+		logger.Info("synced", "n", i)
+	}
+	logger.Error("failed")
+
+	var kept []float64
+	for _, line := range lines(t, &buf) {
+		if line["msg"] == "synced" {
+			kept = append(kept, line["n"].(float64))
+		}
+	}
+	// The first two, then every third
+	if len(kept) != 4 || kept[0] != 0 || kept[1] != 1 || kept[2] != 4 || kept[3] != 7 {
+		t.Errorf("unexpected lines kept: %v", kept)
+	}
+	if !strings.Contains(buf.String(), `"msg":"failed"`) {
+		t.Error("expected errors to always be logged")
+	}
+	if sampler.Dropped() != 4 {
+		t.Errorf("expected 4 dropped, got %d", sampler.Dropped())
+	}
+
+	// Sampling disabled
+	buf.Reset()
+	logger = (&Sampler{}).Logger(slog.New(slog.NewJSONHandler(&buf, nil)))
+	for range 5 {
+		logger.Info("synced")
+	}
+	if n := len(lines(t, &buf)); n != 5 {
+		t.Errorf("expected every line, got %d", n)
+	}
+}
@@ -0,0 +1,331 @@
+// Package markdown renders the markdown organizers write in event and CFP
+// descriptions to HTML. It covers the common subset: paragraphs, headings,
+// emphasis, code, lists, quotes, rules and links. Raw HTML in the source is
+// shown as text, and the output always goes through pkg/sanitize.
+package markdown
+
+import (
+	"html"
+	"regexp"
+	"strings"
+
+	"github.com/sreday/cfp.ninja/pkg/sanitize"
+)
+
+// ToHTML renders markdown source to sanitized HTML
+func ToHTML(src string) string {
+	src = strings.ReplaceAll(src, "\r\n", "\n")
+	var b strings.Builder
+	renderBlocks(&b, strings.Split(src, "\n"))
+	return sanitize.HTML(b.String())
+}
+
+var (
+	headingRe  = regexp.MustCompile(`^ {0,3}(#{1,6})(?:[ \t]+(.*?))?(?:[ \t]+#+)?[ \t]*$`)
+	ruleRe     = regexp.MustCompile(`^ {0,3}(?:(?:\*[ \t]*){3,}|(?:-[ \t]*){3,}|(?:_[ \t]*){3,})$`)
+	fenceRe    = regexp.MustCompile("^ {0,3}(`{3,}|~{3,})")
+	listItemRe = regexp.MustCompile(`^( {0,3})([-*+]|\d{1,9}[.)])([ \t]+|$)`)
+	quoteRe    = regexp.MustCompile(`^ {0,3}> ?`)
+)
+
+// renderBlocks renders lines as a sequence of block elements
+func renderBlocks(b *strings.Builder, lines []string) {
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		switch {
+		case strings.TrimSpace(line) == "":
+			i++
+
+		case fenceRe.MatchString(line):
+			fence := fenceRe.FindStringSubmatch(line)[1]
+			i++
+			b.WriteString("<pre><code>")
+			for ; i < len(lines); i++ {
+				if strings.HasPrefix(strings.TrimSpace(lines[i]), fence[:3]) {
+					i++
+					break
+				}
+				b.WriteString(html.EscapeString(lines[i]) + "\n")
+			}
+			b.WriteString("</code></pre>\n")
+
+		case headingRe.MatchString(line):
+			m := headingRe.FindStringSubmatch(line)
+			level := string(rune('0' + len(m[1])))
+			b.WriteString("<h" + level + ">" + renderInline(m[2]) + "</h" + level + ">\n")
+			i++
+
+		case ruleRe.MatchString(line):
+			b.WriteString("<hr>\n")
+			i++
+
+		case quoteRe.MatchString(line):
+			var inner []string
+			for ; i < len(lines) && quoteRe.MatchString(lines[i]); i++ {
+				inner = append(inner, quoteRe.ReplaceAllString(lines[i], ""))
+			}
+			b.WriteString("<blockquote>\n")
+			renderBlocks(b, inner)
+			b.WriteString("</blockquote>\n")
+
+		case listItemRe.MatchString(line):
+			i = renderList(b, lines, i)
+
+		default:
+			var para []string
+			for ; i < len(lines) && strings.TrimSpace(lines[i]) != "" && (len(para) == 0 || !startsBlock(lines[i])); i++ {
+				para = append(para, lines[i])
+			}
+			b.WriteString("<p>" + renderParagraph(para) + "</p>\n")
+		}
+	}
+}
+
+// startsBlock reports whether line interrupts a paragraph
+func startsBlock(line string) bool {
+	return fenceRe.MatchString(line) || headingRe.MatchString(line) || ruleRe.MatchString(line) ||
+		quoteRe.MatchString(line) || listItemRe.MatchString(line)
+}
+
+// renderList renders the list starting at lines[i] and returns the index of
+// the first line after it. Lines indented past the marker belong to the item,
+// so nested lists work.
+func renderList(b *strings.Builder, lines []string, i int) int {
+	m := listItemRe.FindStringSubmatch(lines[i])
+	indent := len(m[1])
+	ordered := m[2][0] >= '0' && m[2][0] <= '9'
+	tagName := "ul"
+	if ordered {
+		tagName = "ol"
+	}
+	b.WriteString("<" + tagName + ">\n")
+
+	for i < len(lines) {
+		m := listItemRe.FindStringSubmatch(lines[i])
+		if m == nil || len(m[1]) != indent || (m[2][0] >= '0' && m[2][0] <= '9') != ordered {
+			break
+		}
+		contentIndent := len(m[0])
+		item := []string{lines[i][len(m[0]):]}
+		i++
+
+		// The item continues with indented lines, and with unindented ones
+		// until a blank line or the next item
+		loose := false
+		for i < len(lines) {
+			line := lines[i]
+			if strings.TrimSpace(line) == "" {
+				if i+1 < len(lines) && leadingSpaces(lines[i+1]) >= contentIndent && strings.TrimSpace(lines[i+1]) != "" {
+					item = append(item, "")
+					loose = true
+					i++
+					continue
+				}
+				break
+			}
+			if leadingSpaces(line) >= contentIndent {
+				item = append(item, line[contentIndent:])
+			} else if !startsBlock(line) && strings.TrimSpace(item[len(item)-1]) != "" {
+				item = append(item, line)
+			} else {
+				break
+			}
+			i++
+		}
+
+		b.WriteString("<li>")
+		if !loose && !containsBlock(item[1:]) {
+			b.WriteString(renderParagraph(item))
+		} else {
+			var inner strings.Builder
+			renderBlocks(&inner, item)
+			b.WriteString("\n" + inner.String())
+		}
+		b.WriteString("</li>\n")
+
+		// A blank line between items
+		if i+1 < len(lines) && strings.TrimSpace(lines[i]) == "" && listItemRe.MatchString(lines[i+1]) {
+			i++
+		}
+	}
+
+	b.WriteString("</" + tagName + ">\n")
+	return i
+}
+
+func containsBlock(lines []string) bool {
+	for _, line := range lines {
+		if startsBlock(line) {
+			return true
+		}
+	}
+	return false
+}
+
+func leadingSpaces(s string) int {
+	return len(s) - len(strings.TrimLeft(s, " "))
+}
+
+// renderParagraph renders the lines of a paragraph. A line ending in two
+// spaces or a backslash is a hard break; other line breaks are kept as is.
+func renderParagraph(lines []string) string {
+	var b strings.Builder
+	for i, line := range lines {
+		line = strings.TrimLeft(line, " \t")
+		hardBreak := false
+		if i < len(lines)-1 {
+			if strings.HasSuffix(line, "  ") {
+				hardBreak = true
+			} else if strings.HasSuffix(line, `\`) {
+				hardBreak = true
+				line = line[:len(line)-1]
+			}
+		}
+		b.WriteString(renderInline(strings.TrimRight(line, " \t")))
+		if hardBreak {
+			b.WriteString("<br>")
+		}
+		if i < len(lines)-1 {
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
+
+var (
+	linkRe     = regexp.MustCompile(`^!?\[([^\]]*)\]\(\s*<?([^\s()<>]*)>?(?:\s+"([^"]*)")?\s*\)`)
+	autolinkRe = regexp.MustCompile(`^<((?:https?://|mailto:)[^\s<>]+)>`)
+	bareURLRe  = regexp.MustCompile(`^https?://[^\s<>]*[^\s<>.,:;"')\]!?]`)
+)
+
+// renderInline renders emphasis, code spans, links and escapes in a line.
+// Everything else is escaped text.
+func renderInline(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); {
+		c := s[i]
+		rest := s[i:]
+		switch {
+		case c == '\\' && i+1 < len(s) && strings.IndexByte("\\`*_{}[]()#+-.!<>~|", s[i+1]) >= 0:
+			b.WriteString(html.EscapeString(s[i+1 : i+2]))
+			i += 2
+			continue
+
+		case c == '`':
+			n := countRun(rest, '`')
+			fence := rest[:n]
+			if end := strings.Index(rest[n:], fence); end >= 0 {
+				code := rest[n : n+end]
+				if len(code) > 1 && strings.HasPrefix(code, " ") && strings.HasSuffix(code, " ") {
+					code = code[1 : len(code)-1]
+				}
+				b.WriteString("<code>" + html.EscapeString(code) + "</code>")
+				i += n + end + n
+				continue
+			}
+			b.WriteString(fence)
+			i += n
+			continue
+
+		case c == '[' || c == '!' && strings.HasPrefix(rest, "!["):
+			if m := linkRe.FindStringSubmatch(rest); m != nil {
+				text := renderInline(m[1])
+				if c == '!' {
+					// Images would load in readers' emails; link to them instead
+					text = html.EscapeString(m[1])
+				}
+				b.WriteString(`<a href="` + html.EscapeString(m[2]) + `"`)
+				if m[3] != "" {
+					b.WriteString(` title="` + html.EscapeString(m[3]) + `"`)
+				}
+				b.WriteString(">" + text + "</a>")
+				i += len(m[0])
+				continue
+			}
+
+		case c == '<':
+			if m := autolinkRe.FindStringSubmatch(rest); m != nil {
+				writeLink(&b, m[1])
+				i += len(m[0])
+				continue
+			}
+
+		case c == 'h' && (i == 0 || !isWordByte(s[i-1])):
+			if m := bareURLRe.FindString(rest); m != "" {
+				writeLink(&b, m)
+				i += len(m)
+				continue
+			}
+
+		case c == '*' || c == '_' || c == '~':
+			if n, inner, ok := emphasis(s, i); ok {
+				tagName := "em"
+				switch {
+				case c == '~':
+					tagName = "del"
+				case n == 2:
+					tagName = "strong"
+				}
+				b.WriteString("<" + tagName + ">" + renderInline(inner) + "</" + tagName + ">")
+				i += n + len(inner) + n
+				continue
+			}
+			n := countRun(rest, c)
+			b.WriteString(rest[:n])
+			i += n
+			continue
+		}
+
+		b.WriteString(html.EscapeString(s[i : i+1]))
+		i++
+	}
+	return b.String()
+}
+
+// emphasis finds the span opened by the delimiter run at s[i]: one or two
+// * or _, or two ~. It returns the run length and the text inside.
+func emphasis(s string, i int) (int, string, bool) {
+	c := s[i]
+	n := countRun(s[i:], c)
+	if n > 2 || c == '~' && n != 2 {
+		return 0, "", false
+	}
+	open := i + n
+	// The opener must be followed by text, and _ must not be inside a word
+	if open >= len(s) || s[open] == ' ' || c == '_' && i > 0 && isWordByte(s[i-1]) {
+		return 0, "", false
+	}
+	delim := s[i:open]
+	for from := open + 1; from < len(s); {
+		j := strings.Index(s[from:], delim)
+		if j < 0 {
+			return 0, "", false
+		}
+		end := from + j
+		// The closer must follow text, be exactly n long, and _ must not be
+		// followed by a word character
+		after := end + n
+		if s[end-1] != ' ' && (after >= len(s) || s[after] != c) && !(c == '_' && after < len(s) && isWordByte(s[after])) {
+			return n, s[open:end], true
+		}
+		from = end + countRun(s[end:], c)
+	}
+	return 0, "", false
+}
+
+func writeLink(b *strings.Builder, url string) {
+	escaped := html.EscapeString(url)
+	b.WriteString(`<a href="` + escaped + `">` + html.EscapeString(strings.TrimPrefix(url, "mailto:")) + "</a>")
+}
+
+func countRun(s string, c byte) int {
+	n := 0
+	for n < len(s) && s[n] == c {
+		n++
+	}
+	return n
+}
+
+func isWordByte(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' || c >= 0x80
+}
@@ -0,0 +1,63 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestToHTML(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"paragraphs", "First line\nsame paragraph\n\nSecond", "<p>First line\nsame paragraph</p>\n<p>Second</p>\n"},
+		{"emphasis", "Talks on **Go**, *cloud* and __SRE__", "<p>Talks on <strong>Go</strong>, <em>cloud</em> and <strong>SRE</strong></p>\n"},
+		{"strikethrough", "~~closed~~ open", "<p><del>closed</del> open</p>\n"},
+		{"literal asterisks", "5 * 3 = 15 and a*b", "<p>5 * 3 = 15 and a*b</p>\n"},
+		{"snake_case kept", "use my_var_name here", "<p>use my_var_name here</p>\n"},
+		{"escaped asterisk", `\*not emphasis\*`, "<p>*not emphasis*</p>\n"},
+		{"headings", "# Title\n### Details ###", "<h1>Title</h1>\n<h3>Details</h3>\n"},
+		{"rule", "a\n\n---\n\nb", "<p>a</p>\n<hr>\n<p>b</p>\n"},
+		{"code span", "Run `go test ./...` <now>", "<p>Run <code>go test ./...</code> &lt;now&gt;</p>\n"},
+		{"fenced code", "```go\nfmt.Println(\"<hi>\")\n```", "<pre><code>fmt.Println(&#34;&lt;hi&gt;&#34;)\n</code></pre>\n"},
+		{"bullet list", "- one\n- **two**\n* three", "<ul>\n<li>one</li>\n<li><strong>two</strong></li>\n<li>three</li>\n</ul>\n"},
+		{"ordered list", "1. first\n2. second", "<ol>\n<li>first</li>\n<li>second</li>\n</ol>\n"},
+		{"nested list", "- a\n  - b\n- c", "<ul>\n<li>\n<p>a</p>\n<ul>\n<li>b</li>\n</ul>\n</li>\n<li>c</li>\n</ul>\n"},
+		{"list after paragraph", "Topics:\n- Go\n- Rust", "<p>Topics:</p>\n<ul>\n<li>Go</li>\n<li>Rust</li>\n</ul>\n"},
+		{"blockquote", "> quoted\n> text", "<blockquote>\n<p>quoted\ntext</p>\n</blockquote>\n"},
+		{"hard break", "line one  \nline two", "<p>line one<br>\nline two</p>\n"},
+		{"link", `[CFP](https://example.com/cfp "Apply")`, `<p><a href="https://example.com/cfp" title="Apply" rel="nofollow noopener">CFP</a></p>` + "\n"},
+		{"autolink", "<https://example.com>", `<p><a href="https://example.com" rel="nofollow noopener">https://example.com</a></p>` + "\n"},
+		{"bare url", "See https://example.com/cfp.", `<p>See <a href="https://example.com/cfp" rel="nofollow noopener">https://example.com/cfp</a>.</p>` + "\n"},
+		{"image becomes link", "![logo](https://example.com/logo.png)", `<p><a href="https://example.com/logo.png" rel="nofollow noopener">logo</a></p>` + "\n"},
+		{"crlf", "a\r\n\r\nb", "<p>a</p>\n<p>b</p>\n"},
+
+		// Raw HTML is text, and unsafe links are dropped by the sanitizer
+		{"raw html escaped", "<script>alert(1)</script>", "<p>&lt;script&gt;alert(1)&lt;/script&gt;</p>\n"},
+		{"javascript link", "[click](javascript:alert%281%29)", "<p><a>click</a></p>\n"},
+		{"html in link text", `[<img src=x onerror=alert(1)>](https://example.com)`, `<p><a href="https://example.com" rel="nofollow noopener">&lt;img src=x onerror=alert(1)&gt;</a></p>` + "\n"},
+		{"quote in url", `[x](https://example.com/"onclick="alert)`, `<p><a href="https://example.com/&#34;onclick=&#34;alert" rel="nofollow noopener">x</a></p>` + "\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ToHTML(tt.in); got != tt.want {
+				t.Errorf("ToHTML(%q)\n got: %q\nwant: %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func FuzzToHTML(f *testing.F) {
+	f.Add("# Title\n\n- **a**\n  - b\n\n> q\n\n```\ncode\n```")
+	f.Add("[x](javascript:alert(1)) <script>")
+	f.Add("*a **b** c* ~~d~~ _e_")
+
+	f.Fuzz(func(t *testing.T, in string) {
+		out := strings.ToLower(ToHTML(in))
+		if strings.Contains(out, "<script") || strings.Contains(out, "javascript:") && strings.Contains(out, "href") {
+			t.Fatalf("unsafe output %q", out)
+		}
+	})
+}
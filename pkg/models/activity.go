@@ -0,0 +1,235 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Actions recorded in an event's activity log
+const (
+	ActivityProposalStatus   = "proposal_status"   // Detail is the new status, Subject the proposal title
+	ActivityCFPStatus        = "cfp_status"        // Detail is the new CFP status
+	ActivityEventUpdated     = "event_updated"     // Detail is the comma-separated changed fields
+	ActivityOrganizerAdded   = "organizer_added"   // Subject is the organizer's name
+	ActivityOrganizerRemoved = "organizer_removed" // Subject is the organizer's name
+	ActivitySpeakersEmailed  = "speakers_emailed"  // Detail is the recipient count, Subject the email subject
+	ActivityAnswersRequested = "answers_requested" // Detail is the comma-separated question IDs, Subject the proposal title
+	ActivityOrganizerLeft    = "organizer_left"    // The actor removed themselves as an organizer
+	ActivityCreatorChanged   = "creator_changed"   // Subject is the new creator's name, after the last one's account was deleted
+)
+
+// ActivityActions lists every activity action, for validating filters
+var ActivityActions = []string{
+	ActivityProposalStatus,
+	ActivityCFPStatus,
+	ActivityEventUpdated,
+	ActivityOrganizerAdded,
+	ActivityOrganizerRemoved,
+	ActivitySpeakersEmailed,
+	ActivityAnswersRequested,
+	ActivityOrganizerLeft,
+	ActivityCreatorChanged,
+}
+
+// EventActivity records something an organizer did to an event, so
+// co-organizers can see what changed while they were away. Subject keeps the
+// proposal title or organizer name as it was at the time.
+type EventActivity struct {
+	ID         uint      `gorm:"primarykey" json:"id"`
+	EventID    uint      `gorm:"not null;index:idx_event_activities_event_created" json:"event_id"`
+	ActorID    *uint     `gorm:"index" json:"actor_id"` // nil once the actor's account is deleted
+	Action     string    `gorm:"size:32;not null" json:"action"`
+	ProposalID *uint     `json:"proposal_id,omitempty"`
+	Subject    string    `json:"subject,omitempty"`
+	Detail     string    `json:"detail,omitempty"`
+	CreatedAt  time.Time `gorm:"index:idx_event_activities_event_created" json:"created_at"`
+
+	Event *Event `gorm:"constraint:OnDelete:CASCADE" json:"-"`
+	Actor *User  `gorm:"constraint:OnDelete:SET NULL" json:"-"`
+}
+
+// ActivityFilter narrows an event's activity log. Zero values match everything.
+type ActivityFilter struct {
+	Actions []string
+	Since   time.Time
+	Until   time.Time
+}
+
+func (f ActivityFilter) apply(db *gorm.DB) *gorm.DB {
+	if len(f.Actions) > 0 {
+		db = db.Where("action IN ?", f.Actions)
+	}
+	if !f.Since.IsZero() {
+		db = db.Where("created_at >= ?", f.Since)
+	}
+	if !f.Until.IsZero() {
+		db = db.Where("created_at < ?", f.Until)
+	}
+	return db
+}
+
+// ListEventActivity returns a page of the event's activity, newest first,
+// with the actors loaded, and the number of entries matching the filter
+func ListEventActivity(db *gorm.DB, eventID uint, filter ActivityFilter, offset, limit int) ([]EventActivity, int64, error) {
+	var total int64
+	if err := filter.apply(db.Model(&EventActivity{}).Where("event_id = ?", eventID)).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var activity []EventActivity
+	err := filter.apply(db.Preload("Actor").Where("event_id = ?", eventID)).
+		Order("created_at DESC, id DESC").Offset(offset).Limit(limit).Find(&activity).Error
+	return activity, total, err
+}
+
+// ActorName is the name shown for the activity's actor
+func (a *EventActivity) ActorName() string {
+	if a.Actor == nil {
+		return "A former organizer"
+	}
+	if a.Actor.Name != "" {
+		return a.Actor.Name
+	}
+	return a.Actor.Email
+}
+
+// Summary describes the activity as a sentence, such as
+// `Alice accepted "Scaling Go"`
+func (a *EventActivity) Summary() string {
+	return a.ActorName() + " " + a.describe()
+}
+
+// describe is the summary without the actor
+func (a *EventActivity) describe() string {
+	switch a.Action {
+	case ActivityProposalStatus:
+		switch ProposalStatus(a.Detail) {
+		case ProposalStatusAccepted, ProposalStatusRejected:
+			return fmt.Sprintf("%s %q", a.Detail, a.Subject)
+		case ProposalStatusTentative:
+			return fmt.Sprintf("marked %q as tentative", a.Subject)
+		default:
+			return fmt.Sprintf("moved %q back to %s", a.Subject, a.Detail)
+		}
+	case ActivityCFPStatus:
+		switch CFPStatus(a.Detail) {
+		case CFPStatusOpen:
+			return "opened the CFP"
+		case CFPStatusClosed:
+			return "closed the CFP"
+		case CFPStatusReviewing:
+			return "started reviewing the CFP"
+		case CFPStatusComplete:
+			return "marked the CFP complete"
+		default:
+			return "moved the CFP back to " + a.Detail
+		}
+	case ActivityEventUpdated:
+		return "changed " + describeFields(strings.Split(a.Detail, ","))
+	case ActivityOrganizerAdded:
+		return fmt.Sprintf("added %s as an organizer", a.Subject)
+	case ActivityOrganizerRemoved:
+		return fmt.Sprintf("removed %s as an organizer", a.Subject)
+	case ActivitySpeakersEmailed:
+		return fmt.Sprintf("emailed %s speakers: %q", a.Detail, a.Subject)
+	case ActivityAnswersRequested:
+		return fmt.Sprintf("asked the speaker of %q for missing answers", a.Subject)
+	case ActivityOrganizerLeft:
+		return "left the event's organizers"
+	case ActivityCreatorChanged:
+		return fmt.Sprintf("made %s the event creator", a.Subject)
+	}
+	return a.Action
+}
+
+// activityFieldNames are the readable names of event fields whose column name
+// does not read well
+var activityFieldNames = map[string]string{
+	"cfp_close_at":           "the CFP deadline",
+	"cfp_open_at":            "the CFP opening date",
+	"cfp_description":        "the CFP description",
+	"cfp_questions":          "the CFP questions",
+	"cfp_status":             "the CFP status",
+	"cfp_requires_payment":   "the submission fee",
+	"coc_url":                "the code of conduct",
+	"require_coc_acceptance": "the code of conduct requirement",
+	"status_emails":          "the status email copy",
+	"terms_url":              "the terms",
+	"max_accepted":           "the maximum accepted talks",
+	"start_date":             "the start date",
+	"end_date":               "the end date",
+}
+
+// describeFields lists changed fields as "the CFP deadline, name and
+// location", summarizing all but the first two when there are more than three
+func describeFields(fields []string) string {
+	names := make([]string, len(fields))
+	for i, f := range fields {
+		if name, ok := activityFieldNames[f]; ok {
+			names[i] = name
+		} else {
+			names[i] = strings.ReplaceAll(f, "_", " ")
+		}
+	}
+	switch {
+	case len(names) == 1:
+		return names[0]
+	case len(names) > 3:
+		return fmt.Sprintf("%s, %s and %d other fields", names[0], names[1], len(names)-2)
+	default:
+		return strings.Join(names[:len(names)-1], ", ") + " and " + names[len(names)-1]
+	}
+}
+
+// SummarizeActivity describes activity newest first in at most limit
+// sentences, folding proposal decisions by the same organizer into one, such
+// as "Alice accepted 5 proposals"
+func SummarizeActivity(activity []EventActivity, limit int) []string {
+	type group struct {
+		first *EventActivity
+		count int
+	}
+	var groups []*group
+	decisions := make(map[string]*group)
+	for i := range activity {
+		a := &activity[i]
+		if a.Action == ActivityProposalStatus {
+			key := "-/" + a.Detail
+			if a.ActorID != nil {
+				key = fmt.Sprintf("%d/%s", *a.ActorID, a.Detail)
+			}
+			if g, ok := decisions[key]; ok {
+				g.count++
+				continue
+			}
+			decisions[key] = &group{first: a, count: 1}
+			groups = append(groups, decisions[key])
+			continue
+		}
+		groups = append(groups, &group{first: a, count: 1})
+	}
+
+	var out []string
+	for _, g := range groups {
+		if len(out) == limit {
+			break
+		}
+		if g.count == 1 {
+			out = append(out, g.first.Summary())
+			continue
+		}
+		var what string
+		switch ProposalStatus(g.first.Detail) {
+		case ProposalStatusAccepted, ProposalStatusRejected:
+			what = fmt.Sprintf("%s %d proposals", g.first.Detail, g.count)
+		case ProposalStatusTentative:
+			what = fmt.Sprintf("marked %d proposals as tentative", g.count)
+		default:
+			what = fmt.Sprintf("moved %d proposals back to %s", g.count, g.first.Detail)
+		}
+		out = append(out, g.first.ActorName()+" "+what)
+	}
+	return out
+}
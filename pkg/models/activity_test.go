@@ -0,0 +1,74 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEventActivity_Summary(t *testing.T) {
+	alice := &User{Name: "Alice"}
+	tests := []struct {
+		name     string
+		activity EventActivity
+		want     string
+	}{
+		{"accepted", EventActivity{Actor: alice, Action: ActivityProposalStatus, Detail: "accepted", Subject: "Scaling Go"}, `Alice accepted "Scaling Go"`},
+		{"tentative", EventActivity{Actor: alice, Action: ActivityProposalStatus, Detail: "tentative", Subject: "Scaling Go"}, `Alice marked "Scaling Go" as tentative`},
+		{"back to submitted", EventActivity{Actor: alice, Action: ActivityProposalStatus, Detail: "submitted", Subject: "Scaling Go"}, `Alice moved "Scaling Go" back to submitted`},
+		{"cfp opened", EventActivity{Actor: alice, Action: ActivityCFPStatus, Detail: "open"}, "Alice opened the CFP"},
+		{"deadline", EventActivity{Actor: alice, Action: ActivityEventUpdated, Detail: "cfp_close_at"}, "Alice changed the CFP deadline"},
+		{"two fields", EventActivity{Actor: alice, Action: ActivityEventUpdated, Detail: "cfp_close_at,location"}, "Alice changed the CFP deadline and location"},
+		{"three fields", EventActivity{Actor: alice, Action: ActivityEventUpdated, Detail: "cfp_close_at,location,name"}, "Alice changed the CFP deadline, location and name"},
+		{"many fields", EventActivity{Actor: alice, Action: ActivityEventUpdated, Detail: "cfp_close_at,location,name,tags,website"}, "Alice changed the CFP deadline, location and 3 other fields"},
+		{"organizer added", EventActivity{Actor: alice, Action: ActivityOrganizerAdded, Subject: "Bob"}, "Alice added Bob as an organizer"},
+		{"speakers emailed", EventActivity{Actor: alice, Action: ActivitySpeakersEmailed, Detail: "12", Subject: "Slides due"}, `Alice emailed 12 speakers: "Slides due"`},
+		{"answers requested", EventActivity{Actor: alice, Action: ActivityAnswersRequested, Detail: "travel", Subject: "Scaling Go"}, `Alice asked the speaker of "Scaling Go" for missing answers`},
+		{"organizer left", EventActivity{Actor: alice, Action: ActivityOrganizerLeft}, "Alice left the event's organizers"},
+		{"creator changed", EventActivity{Actor: alice, Action: ActivityCreatorChanged, Subject: "Bob"}, "Alice made Bob the event creator"},
+		{"actor without name", EventActivity{Actor: &User{Email: "alice@example.com"}, Action: ActivityCFPStatus, Detail: "closed"}, "alice@example.com closed the CFP"},
+		{"deleted actor", EventActivity{Action: ActivityCFPStatus, Detail: "closed"}, "A former organizer closed the CFP"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.activity.Summary(); got != tt.want {
+				t.Errorf("Summary() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSummarizeActivity(t *testing.T) {
+	aliceID, bobID := uint(1), uint(2)
+	alice := &User{Name: "Alice"}
+	bob := &User{Name: "Bob"}
+	accept := func(title string) EventActivity {
+		return EventActivity{ActorID: &aliceID, Actor: alice, Action: ActivityProposalStatus, Detail: "accepted", Subject: title}
+	}
+
+	// Newest first: Alice's acceptances fold into the first of them
+	activity := []EventActivity{
+		accept("One"),
+		{ActorID: &bobID, Actor: bob, Action: ActivityEventUpdated, Detail: "cfp_close_at"},
+		accept("Two"),
+		{ActorID: &bobID, Actor: bob, Action: ActivityProposalStatus, Detail: "accepted", Subject: "Three"},
+		accept("Four"),
+		{ActorID: &aliceID, Actor: alice, Action: ActivityProposalStatus, Detail: "rejected", Subject: "Five"},
+	}
+	got := SummarizeActivity(activity, 10)
+	want := []string{
+		"Alice accepted 3 proposals",
+		"Bob changed the CFP deadline",
+		`Bob accepted "Three"`,
+		`Alice rejected "Five"`,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SummarizeActivity() = %q, want %q", got, want)
+	}
+
+	if got := SummarizeActivity(activity, 2); !reflect.DeepEqual(got, want[:2]) {
+		t.Errorf("SummarizeActivity(limit 2) = %q, want %q", got, want[:2])
+	}
+	if got := SummarizeActivity(nil, 3); len(got) != 0 {
+		t.Errorf("SummarizeActivity(nil) = %q, want none", got)
+	}
+}
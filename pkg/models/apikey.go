@@ -0,0 +1,55 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// APIKey lets a third party, such as a newsletter listing open CFPs, use the
+// public API. Keys are issued by admins; only a SHA-256 hash of the key is
+// stored, with its first characters kept so admins can tell keys apart.
+type APIKey struct {
+	ID           uint       `gorm:"primarykey" json:"id"`
+	Name         string     `gorm:"not null" json:"name"`
+	ContactEmail string     `json:"contact_email"`
+	Prefix       string     `gorm:"size:16" json:"prefix"`
+	KeyHash      string     `gorm:"uniqueIndex;not null" json:"-"`
+	RequestCount int64      `gorm:"default:0" json:"request_count"`
+	LastUsedAt   *time.Time `json:"last_used_at"`
+	RevokedAt    *time.Time `json:"revoked_at"`
+	CreatedByID  *uint      `gorm:"index" json:"created_by_id"` // nil once the admin's account is deleted
+	CreatedAt    time.Time  `json:"created_at"`
+
+	CreatedBy *User `gorm:"constraint:OnDelete:SET NULL" json:"-"`
+}
+
+// GetActiveAPIKeyByHash looks up an unrevoked API key by the hash of its key
+func GetActiveAPIKeyByHash(db *gorm.DB, hash string) (*APIKey, error) {
+	var key APIKey
+	if err := db.Where("key_hash = ? AND revoked_at IS NULL", hash).First(&key).Error; err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+// RecordAPIKeyUse counts a request made with the key
+func RecordAPIKeyUse(db *gorm.DB, id uint, at time.Time) error {
+	return db.Model(&APIKey{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"request_count": gorm.Expr("request_count + 1"),
+		"last_used_at":  at,
+	}).Error
+}
+
+// RevokeAPIKey revokes a key; requests made with it are refused from then on.
+// Revoking a revoked key keeps the original revocation time.
+func RevokeAPIKey(db *gorm.DB, id uint, at time.Time) error {
+	var key APIKey
+	if err := db.First(&key, id).Error; err != nil {
+		return err
+	}
+	if key.RevokedAt != nil {
+		return nil
+	}
+	return db.Model(&key).Update("revoked_at", at).Error
+}
@@ -0,0 +1,98 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// FindEventsToArchive returns the events not yet archived that ended before
+// the given time
+func FindEventsToArchive(db *gorm.DB, endedBefore time.Time) ([]Event, error) {
+	var events []Event
+	err := db.Where("NOT archived AND end_date < ?", endedBefore).Order("id").Find(&events).Error
+	return events, err
+}
+
+// ArchiveEvents marks the events as archived at now. Events archived in the
+// meantime keep their original archival time.
+func ArchiveEvents(db *gorm.DB, ids []uint, now time.Time) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	result := db.Model(&Event{}).Where("id IN ? AND NOT archived", ids).
+		Updates(map[string]interface{}{"archived": true, "archived_at": now})
+	return result.RowsAffected, result.Error
+}
+
+// FindEventsDueScrubNotice returns the archived events archived before the
+// given time whose creator has not been told their proposals will be scrubbed
+func FindEventsDueScrubNotice(db *gorm.DB, archivedBefore time.Time) ([]Event, error) {
+	var events []Event
+	err := db.Where("archived AND scrubbed_at IS NULL AND scrub_notice_sent_at IS NULL AND archived_at < ?", archivedBefore).
+		Order("id").Find(&events).Error
+	return events, err
+}
+
+// ClaimScrubNotice records that the event's scrub notice was sent at now. It
+// reports false when it had already been claimed, so each creator is emailed
+// at most once even when the task runs on several instances.
+func ClaimScrubNotice(db *gorm.DB, eventID uint, now time.Time) (bool, error) {
+	result := db.Model(&Event{}).Where("id = ? AND scrub_notice_sent_at IS NULL", eventID).
+		Update("scrub_notice_sent_at", now)
+	return result.RowsAffected > 0, result.Error
+}
+
+// FindEventsToScrub returns the archived events archived before the given
+// time whose scrub notice went out before noticedBefore and that have not
+// been scrubbed yet
+func FindEventsToScrub(db *gorm.DB, archivedBefore, noticedBefore time.Time) ([]Event, error) {
+	var events []Event
+	err := db.Where("archived AND scrubbed_at IS NULL AND archived_at < ? AND scrub_notice_sent_at < ?", archivedBefore, noticedBefore).
+		Order("id").Find(&events).Error
+	return events, err
+}
+
+// CountEventProposals returns the number of proposals of the event,
+// including withdrawn and deleted ones, whose personal data a scrub removes
+func CountEventProposals(db *gorm.DB, eventID uint) (int64, error) {
+	var count int64
+	err := db.Unscoped().Model(&Proposal{}).Where("event_id = ?", eventID).Count(&count).Error
+	return count, err
+}
+
+// ScrubEventProposals removes the speaker emails and speaker notes from all
+// of the event's proposals, deleted ones included, and marks the event as
+// scrubbed at now. It returns the number of proposals scrubbed.
+func ScrubEventProposals(db *gorm.DB, eventID uint, now time.Time) (int, error) {
+	scrubbed := 0
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var proposals []Proposal
+		if err := tx.Unscoped().Where("event_id = ?", eventID).Find(&proposals).Error; err != nil {
+			return err
+		}
+		for i := range proposals {
+			p := &proposals[i]
+			speakers, err := p.GetSpeakers()
+			if err != nil {
+				return err
+			}
+			updates := map[string]interface{}{"speaker_notes": ""}
+			if len(speakers) > 0 {
+				for j := range speakers {
+					speakers[j].Email = ""
+				}
+				if err := p.SetSpeakers(speakers); err != nil {
+					return err
+				}
+				updates["speakers"] = p.Speakers
+			}
+			if err := tx.Unscoped().Model(p).UpdateColumns(updates).Error; err != nil {
+				return err
+			}
+		}
+		scrubbed = len(proposals)
+		return tx.Model(&Event{}).Where("id = ?", eventID).Update("scrubbed_at", now).Error
+	})
+	return scrubbed, err
+}
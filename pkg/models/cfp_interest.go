@@ -0,0 +1,63 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// CFPInterest is a user's request to be emailed when an event's CFP opens.
+// NotifiedAt is set when the email is claimed for sending, so each
+// registration is emailed at most once however many paths open the CFP.
+type CFPInterest struct {
+	ID               uint       `gorm:"primarykey" json:"id"`
+	EventID          uint       `gorm:"uniqueIndex:idx_cfp_interests_event_user;not null" json:"event_id"`
+	UserID           uint       `gorm:"uniqueIndex:idx_cfp_interests_event_user;not null" json:"-"`
+	UnsubscribeToken string     `gorm:"uniqueIndex;size:64;not null" json:"-"`
+	NotifiedAt       *time.Time `gorm:"index" json:"notified_at,omitempty"`
+	CreatedAt        time.Time  `json:"created_at"`
+
+	Event *Event `gorm:"constraint:OnDelete:CASCADE" json:"-"`
+	User  *User  `gorm:"constraint:OnDelete:CASCADE" json:"-"`
+}
+
+// ClaimCFPInterests marks the event's pending registrations as notified at
+// now and returns them with their users. Concurrent callers never claim the
+// same row, so only the caller that claimed a registration emails it.
+func ClaimCFPInterests(db *gorm.DB, eventID uint, now time.Time) ([]CFPInterest, error) {
+	var claimed []CFPInterest
+	if err := db.Model(&claimed).Clauses(clause.Returning{}).
+		Where("event_id = ? AND notified_at IS NULL", eventID).
+		Update("notified_at", now).Error; err != nil {
+		return nil, err
+	}
+	if len(claimed) == 0 {
+		return nil, nil
+	}
+
+	userIDs := make([]uint, len(claimed))
+	for i, c := range claimed {
+		userIDs[i] = c.UserID
+	}
+	var users []User
+	if err := db.Where("id IN ?", userIDs).Find(&users).Error; err != nil {
+		return nil, err
+	}
+	byID := make(map[uint]*User, len(users))
+	for i := range users {
+		byID[users[i].ID] = &users[i]
+	}
+	for i := range claimed {
+		claimed[i].User = byID[claimed[i].UserID]
+	}
+	return claimed, nil
+}
+
+// CountCFPInterests returns how many users asked to hear when the event's CFP
+// opens, including those already emailed
+func CountCFPInterests(db *gorm.DB, eventID uint) (int64, error) {
+	var n int64
+	err := db.Model(&CFPInterest{}).Where("event_id = ?", eventID).Count(&n).Error
+	return n, err
+}
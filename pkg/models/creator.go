@@ -0,0 +1,140 @@
+package models
+
+import (
+	"errors"
+	"log/slog"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// EventCreatorForeignKey is the constraint clearing events.created_by_id when
+// the creator's user row is deleted
+const EventCreatorForeignKey = "fk_events_created_by"
+
+// CreatorTransfer records an event handed to a co-organizer because its
+// creator's account was deleted
+type CreatorTransfer struct {
+	EventID    uint
+	NewCreator User
+}
+
+// ReassignCreatedEvents hands each event created by userID to its co-organizer
+// with the oldest account, who stops being listed as a co-organizer. Events
+// with no other organizer are orphaned: their creator is cleared and only
+// admins can take the creator's actions on them. It returns the transfers and
+// the IDs of the orphaned events. Call it in a transaction.
+func ReassignCreatedEvents(tx *gorm.DB, userID uint) ([]CreatorTransfer, []uint, error) {
+	var eventIDs []uint
+	if err := tx.Model(&Event{}).Where("created_by_id = ?", userID).
+		Order("id").Pluck("id", &eventIDs).Error; err != nil {
+		return nil, nil, err
+	}
+
+	var transfers []CreatorTransfer
+	var orphaned []uint
+	for _, eventID := range eventIDs {
+		var heir User
+		err := tx.Joins("JOIN event_organizers ON event_organizers.user_id = users.id").
+			Where("event_organizers.event_id = ? AND users.id <> ?", eventID, userID).
+			Order("users.created_at, users.id").First(&heir).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			if err := tx.Model(&Event{}).Where("id = ?", eventID).
+				Update("created_by_id", nil).Error; err != nil {
+				return nil, nil, err
+			}
+			orphaned = append(orphaned, eventID)
+			continue
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if err := tx.Model(&Event{}).Where("id = ?", eventID).
+			Update("created_by_id", heir.ID).Error; err != nil {
+			return nil, nil, err
+		}
+		// The creator is not listed among the co-organizers
+		if err := tx.Exec("DELETE FROM event_organizers WHERE event_id = ? AND user_id = ?", eventID, heir.ID).Error; err != nil {
+			return nil, nil, err
+		}
+		transfers = append(transfers, CreatorTransfer{EventID: eventID, NewCreator: heir})
+	}
+	return transfers, orphaned, nil
+}
+
+// DeleteUser deletes a user account for good. Their events go to a
+// co-organizer or are orphaned (see ReassignCreatedEvents), they are removed
+// from the events they co-organize, and their proposals are kept without an
+// owner. Their sessions, logins and reviewer notes go with the account.
+func DeleteUser(db *gorm.DB, userID uint) ([]CreatorTransfer, []uint, error) {
+	var transfers []CreatorTransfer
+	var orphaned []uint
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var user User
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&user, userID).Error; err != nil {
+			return err
+		}
+
+		var err error
+		if transfers, orphaned, err = ReassignCreatedEvents(tx, userID); err != nil {
+			return err
+		}
+		// Deleted events keep their creator until the foreign key clears it
+		if err := tx.Exec("DELETE FROM event_organizers WHERE user_id = ?", userID).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Model(&Proposal{}).Where("created_by_id = ?", userID).
+			Update("created_by_id", nil).Error; err != nil {
+			return err
+		}
+		return tx.Unscoped().Delete(&user).Error
+	})
+	return transfers, orphaned, err
+}
+
+// EnsureEventCreatorForeignKey makes deleting a user clear the creator of their
+// events. Events whose creator was deleted before the constraint existed are
+// reassigned or orphaned first, as DeleteUser would have done. This must be
+// called after AutoMigrate.
+func EnsureEventCreatorForeignKey(db *gorm.DB) error {
+	var exists bool
+	if err := db.Raw("SELECT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = ?)",
+		EventCreatorForeignKey).Scan(&exists).Error; err != nil {
+		return err
+	}
+	if exists {
+		return nil
+	}
+
+	var missing []uint
+	if err := db.Model(&Event{}).Distinct("created_by_id").
+		Where("created_by_id NOT IN (SELECT id FROM users WHERE deleted_at IS NULL)").
+		Pluck("created_by_id", &missing).Error; err != nil {
+		return err
+	}
+	for _, userID := range missing {
+		err := db.Transaction(func(tx *gorm.DB) error {
+			transfers, orphaned, err := ReassignCreatedEvents(tx, userID)
+			if err == nil && len(transfers)+len(orphaned) > 0 {
+				slog.Warn("reassigned events of a deleted creator",
+					"user_id", userID, "transferred", len(transfers), "orphaned", len(orphaned))
+			}
+			return err
+		})
+		if err != nil {
+			return err
+		}
+	}
+	// Deleted events may still point at users that no longer exist
+	if err := db.Exec("UPDATE events SET created_by_id = NULL WHERE created_by_id NOT IN (SELECT id FROM users)").Error; err != nil {
+		return err
+	}
+
+	if err := db.Exec("ALTER TABLE events ADD CONSTRAINT " + EventCreatorForeignKey +
+		" FOREIGN KEY (created_by_id) REFERENCES users(id) ON DELETE SET NULL").Error; err != nil {
+		return err
+	}
+	slog.Info("created foreign key on events.created_by_id")
+	return nil
+}
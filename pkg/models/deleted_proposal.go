@@ -0,0 +1,75 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// DeletedProposalRetention is how long the proposals of a deleted event are
+// kept for recovery before they are purged
+const DeletedProposalRetention = 30 * 24 * time.Hour
+
+// DeletedProposal is a copy of a proposal taken when its event was deleted,
+// so an admin can recover speakers' submissions after a mistaken deletion.
+// Copies are purged by a background task once PurgeAfter has passed.
+type DeletedProposal struct {
+	ID          uint           `gorm:"primarykey" json:"id"`
+	ProposalID  uint           `gorm:"index" json:"proposal_id"`
+	EventID     uint           `gorm:"index" json:"event_id"`
+	EventSlug   string         `json:"event_slug"`
+	Data        datatypes.JSON `gorm:"type:jsonb" json:"data"` // the proposal as returned by the API
+	DeletedByID *uint          `json:"deleted_by_id"`
+	CreatedAt   time.Time      `json:"created_at"`
+	PurgeAfter  time.Time      `gorm:"index" json:"purge_after"`
+}
+
+// RetainDeletedProposals copies the event's proposals into the retention
+// table, to be kept for DeletedProposalRetention from now. It returns the
+// number of proposals copied.
+func RetainDeletedProposals(db *gorm.DB, event *Event, deletedByID uint, now time.Time) (int, error) {
+	var proposals []Proposal
+	if err := db.Where("event_id = ?", event.ID).Order("id").Find(&proposals).Error; err != nil {
+		return 0, err
+	}
+	if len(proposals) == 0 {
+		return 0, nil
+	}
+
+	copies := make([]DeletedProposal, len(proposals))
+	for i := range proposals {
+		data, err := json.Marshal(&proposals[i])
+		if err != nil {
+			return 0, err
+		}
+		copies[i] = DeletedProposal{
+			ProposalID:  proposals[i].ID,
+			EventID:     event.ID,
+			EventSlug:   event.Slug,
+			Data:        data,
+			DeletedByID: &deletedByID,
+			PurgeAfter:  now.Add(DeletedProposalRetention),
+		}
+	}
+	if err := db.CreateInBatches(&copies, 100).Error; err != nil {
+		return 0, err
+	}
+	return len(copies), nil
+}
+
+// GetDeletedProposals returns the retained copies of a deleted event's
+// proposals, oldest proposal first
+func GetDeletedProposals(db *gorm.DB, eventID uint) ([]DeletedProposal, error) {
+	var copies []DeletedProposal
+	err := db.Where("event_id = ?", eventID).Order("proposal_id, id").Find(&copies).Error
+	return copies, err
+}
+
+// PurgeDeletedProposals permanently deletes copies whose retention ended
+// before now
+func PurgeDeletedProposals(db *gorm.DB, now time.Time) (int64, error) {
+	result := db.Where("purge_after < ?", now).Delete(&DeletedProposal{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,77 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// DigestRun records one scheduled send of the weekly digest. The run is
+// created before the first email goes out and finished once every recipient
+// was handled, so a restart resumes an unfinished run instead of sending the
+// week's digest again.
+type DigestRun struct {
+	ID           uint       `gorm:"primarykey"`
+	ScheduledFor time.Time  `gorm:"uniqueIndex;not null"`
+	FinishedAt   *time.Time `gorm:"index"`
+	Sent         int        `gorm:"not null;default:0"`
+	CreatedAt    time.Time
+}
+
+// DigestDelivery records that a user's digest for a run was claimed for
+// sending. The unique index means each user gets at most one digest per run,
+// even across restarts or several server instances.
+type DigestDelivery struct {
+	ID        uint `gorm:"primarykey"`
+	RunID     uint `gorm:"uniqueIndex:idx_digest_deliveries_run_user;not null"`
+	UserID    uint `gorm:"uniqueIndex:idx_digest_deliveries_run_user;not null"`
+	CreatedAt time.Time
+
+	Run  *DigestRun `gorm:"constraint:OnDelete:CASCADE"`
+	User *User      `gorm:"constraint:OnDelete:CASCADE"`
+}
+
+// StartDigestRun returns the run for the scheduled time, creating it if this
+// is the first attempt. A run with FinishedAt set has already been sent.
+func StartDigestRun(db *gorm.DB, scheduledFor time.Time) (*DigestRun, error) {
+	run := DigestRun{ScheduledFor: scheduledFor}
+	if err := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&run).Error; err != nil {
+		return nil, err
+	}
+	if err := db.Where("scheduled_for = ?", scheduledFor).First(&run).Error; err != nil {
+		return nil, err
+	}
+	return &run, nil
+}
+
+// UnfinishedDigestRun returns the most recent run scheduled after since that
+// never finished, or nil when there is none
+func UnfinishedDigestRun(db *gorm.DB, since time.Time) (*DigestRun, error) {
+	var runs []DigestRun
+	if err := db.Where("finished_at IS NULL AND scheduled_for > ?", since).
+		Order("scheduled_for DESC").Limit(1).Find(&runs).Error; err != nil {
+		return nil, err
+	}
+	if len(runs) == 0 {
+		return nil, nil
+	}
+	return &runs[0], nil
+}
+
+// ClaimDigestDelivery claims the user's digest for the run, returning false
+// when it was already claimed
+func ClaimDigestDelivery(db *gorm.DB, runID, userID uint) (bool, error) {
+	result := db.Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&DigestDelivery{RunID: runID, UserID: userID})
+	return result.RowsAffected == 1, result.Error
+}
+
+// FinishDigestRun marks the run as finished at now, adding sent to the
+// number of digests sent for it
+func FinishDigestRun(db *gorm.DB, runID uint, sent int, now time.Time) error {
+	return db.Model(&DigestRun{}).Where("id = ?", runID).Updates(map[string]interface{}{
+		"finished_at": now,
+		"sent":        gorm.Expr("sent + ?", sent),
+	}).Error
+}
@@ -0,0 +1,40 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Email delivery statuses recorded in the send log
+const (
+	EmailStatusSent     = "sent"     // accepted by the email provider
+	EmailStatusFailed   = "failed"   // the provider returned an error
+	EmailStatusDisabled = "disabled" // email is not configured, so it was only logged
+)
+
+// EmailLog records an outgoing email so organizers can check whether a
+// notification went out. Only metadata is kept, never the body.
+type EmailLog struct {
+	ID                uint      `gorm:"primarykey" json:"id"`
+	EventID           *uint     `gorm:"index" json:"event_id,omitempty"`
+	ProposalID        *uint     `gorm:"index" json:"proposal_id,omitempty"`
+	Template          string    `gorm:"size:64;not null" json:"template"`
+	To                string    `json:"to"` // comma-separated
+	Cc                string    `json:"cc,omitempty"`
+	Subject           string    `json:"subject"`
+	ProviderMessageID string    `gorm:"size:128" json:"provider_message_id,omitempty"`
+	Status            string    `gorm:"size:16;not null" json:"status"`
+	Error             string    `json:"error,omitempty"`
+	CreatedAt         time.Time `gorm:"index" json:"created_at"`
+
+	Event    *Event    `gorm:"constraint:OnDelete:CASCADE" json:"-"`
+	Proposal *Proposal `gorm:"constraint:OnDelete:CASCADE" json:"-"`
+}
+
+// GetProposalEmailLogs returns the emails sent about a proposal, newest first
+func GetProposalEmailLogs(db *gorm.DB, proposalID uint) ([]EmailLog, error) {
+	var logs []EmailLog
+	err := db.Where("proposal_id = ?", proposalID).Order("created_at DESC, id DESC").Find(&logs).Error
+	return logs, err
+}
@@ -1,10 +1,19 @@
 package models
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
 	"time"
 
 	"gorm.io/datatypes"
 	"gorm.io/gorm"
+
+	"github.com/sreday/cfp.ninja/pkg/countries"
+	"github.com/sreday/cfp.ninja/pkg/geocode"
+	"github.com/sreday/cfp.ninja/pkg/markdown"
 )
 
 type CFPStatus string
@@ -17,6 +26,62 @@ const (
 	CFPStatusComplete  CFPStatus = "complete"
 )
 
+// AttendanceMode is how speakers and attendees take part in an event
+type AttendanceMode string
+
+const (
+	AttendanceInPerson AttendanceMode = "in_person"
+	AttendanceOnline   AttendanceMode = "online"
+	AttendanceHybrid   AttendanceMode = "hybrid"
+)
+
+// Valid reports whether m is a known attendance mode
+func (m AttendanceMode) Valid() bool {
+	return m == AttendanceInPerson || m == AttendanceOnline || m == AttendanceHybrid
+}
+
+// IsOnline reports whether the event can be attended online. It is the value
+// of the legacy is_online flag.
+func (m AttendanceMode) IsOnline() bool {
+	return m == AttendanceOnline || m == AttendanceHybrid
+}
+
+// HasVenue reports whether the event takes place at a physical location
+func (m AttendanceMode) HasVenue() bool {
+	return m != AttendanceOnline
+}
+
+// DescriptionFormat is how the event and CFP descriptions are written
+type DescriptionFormat string
+
+const (
+	DescriptionPlaintext DescriptionFormat = "plaintext"
+	DescriptionMarkdown  DescriptionFormat = "markdown"
+)
+
+// Valid reports whether f is a known description format
+func (f DescriptionFormat) Valid() bool {
+	return f == DescriptionPlaintext || f == DescriptionMarkdown
+}
+
+// ModerationStatus is whether an event may be listed publicly. Events that
+// look like spam when created wait in pending_review until an admin decides.
+type ModerationStatus string
+
+const (
+	ModerationApproved      ModerationStatus = "approved"
+	ModerationPendingReview ModerationStatus = "pending_review"
+	ModerationRejected      ModerationStatus = "rejected"
+)
+
+// AttendanceModeFromOnline maps the legacy is_online flag to an attendance mode
+func AttendanceModeFromOnline(isOnline bool) AttendanceMode {
+	if isOnline {
+		return AttendanceOnline
+	}
+	return AttendanceInPerson
+}
+
 // CustomQuestion defines a question for CFP submissions.
 // These are stored as JSONB in Event.CFPQuestions.
 //
@@ -35,14 +100,96 @@ const (
 //	    "text": "Any dietary restrictions?",
 //	    "type": "text",
 //	    "required": false
+//	  },
+//	  {
+//	    "id": "late_breaking_results",
+//	    "text": "Which new results will you present?",
+//	    "type": "text",
+//	    "required": true,
+//	    "open_at": "2026-05-01T00:00:00Z",
+//	    "close_at": "2026-05-15T00:00:00Z"
 //	  }
 //	]
+//
+// A question with open_at or close_at only takes answers within that window,
+// inside the CFP's own. Giving the main questions a close_at and the others a
+// later open_at runs a second, late-breaking phase of the same CFP.
 type CustomQuestion struct {
-	ID       string   `json:"id"`                // Unique ID (e.g., "q1", "travel_needs")
-	Text     string   `json:"text"`              // Question text displayed to submitter
-	Type     string   `json:"type"`              // "text", "select", "multiselect", "checkbox"
-	Options  []string `json:"options,omitempty"` // For select/multiselect types
-	Required bool     `json:"required"`          // Whether answer is required for submission
+	ID       string     `json:"id"`                 // Unique ID (e.g., "q1", "travel_needs")
+	Text     string     `json:"text"`               // Question text displayed to submitter
+	Type     string     `json:"type"`               // "text", "select", "multiselect", "checkbox"
+	Options  []string   `json:"options,omitempty"`  // For select/multiselect types
+	Required bool       `json:"required"`           // Whether answer is required for submission
+	OpenAt   *time.Time `json:"open_at,omitempty"`  // Answers accepted from (nil = when the CFP opens)
+	CloseAt  *time.Time `json:"close_at,omitempty"` // Answers accepted until (nil = when the CFP closes)
+}
+
+// IsOpenAt reports whether the question takes answers at now, with the same
+// grace period after close_at as the CFP deadline
+func (q CustomQuestion) IsOpenAt(now time.Time, grace time.Duration) bool {
+	if q.OpenAt != nil && !now.After(*q.OpenAt) {
+		return false
+	}
+	if q.CloseAt != nil && !now.Before(q.CloseAt.Add(grace)) {
+		return false
+	}
+	return true
+}
+
+// GetCFPQuestions unmarshals the CFP questions JSON
+func (e *Event) GetCFPQuestions() ([]CustomQuestion, error) {
+	var questions []CustomQuestion
+	if len(e.CFPQuestions) == 0 {
+		return questions, nil
+	}
+	err := json.Unmarshal(e.CFPQuestions, &questions)
+	return questions, err
+}
+
+// FlagActiveQuestions adds "active" to each of the CFP questions, telling
+// speakers which ones take answers at now. It only changes the loaded event,
+// for the public event page; the flag is never stored.
+func (e *Event) FlagActiveQuestions(now time.Time, grace time.Duration) error {
+	if len(e.CFPQuestions) == 0 {
+		return nil
+	}
+	questions, err := e.GetCFPQuestions()
+	if err != nil {
+		return err
+	}
+	var raw []map[string]interface{}
+	if err := json.Unmarshal(e.CFPQuestions, &raw); err != nil {
+		return err
+	}
+	for i := range raw {
+		if raw[i] == nil {
+			continue
+		}
+		raw[i]["active"] = e.IsCFPOpenAt(now, grace) && questions[i].IsOpenAt(now, grace)
+	}
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return err
+	}
+	e.CFPQuestions = data
+	return nil
+}
+
+// EventSection is a titled block of event information shown on the event
+// page, such as travel info, the code of conduct or the recording policy.
+// These are stored in order as JSONB in Event.Sections.
+type EventSection struct {
+	Title string `json:"title"`
+	Body  string `json:"body"`
+}
+
+// StatusEmail is an organizer's own subject and body for the email sent to
+// speakers when a proposal gets a status. Both may use the placeholders in
+// email.StatusEmailVariables. These are stored as JSONB in Event.StatusEmails,
+// keyed by status.
+type StatusEmail struct {
+	Subject string `json:"subject"`
+	Body    string `json:"body"`
 }
 
 type Event struct {
@@ -50,17 +197,33 @@ type Event struct {
 	// Event details
 	Name        string    `gorm:"index;not null" json:"name"`
 	Slug        string    `gorm:"uniqueIndex;not null" json:"slug"` // Custom URL slug (e.g., "sreday-london-2026-q1")
-	Description string    `json:"description"`
+	Description string    `json:"description,omitempty"` // Left out of listings, which carry Summary
+	// Short description for listings and link previews, at most
+	// MaxSummaryLen characters. Derived from the description when empty.
+	Summary string `gorm:"size:300" json:"summary"`
+	// Applies to Description and CFPDescription, which are stored as written
+	DescriptionFormat DescriptionFormat `gorm:"size:16;default:'plaintext'" json:"description_format"`
 	Location    string    `gorm:"index" json:"location"` // City/venue (e.g., "London", "San Francisco")
-	Country     string    `gorm:"index" json:"country"`  // ISO 3166-1 alpha-2 (e.g., "GB", "US")
+	Country     string    `gorm:"index" json:"country"`  // As entered; organizers usually send ISO 3166-1 alpha-2 (e.g., "GB", "US")
+	CountryCode string    `gorm:"index;size:2" json:"country_code"` // ISO 3166-1 alpha-2 resolved from Country, empty if unrecognized
+	Latitude    *float64  `gorm:"index:idx_events_lat_lon" json:"latitude"`  // Geocoded from Location; nil if not (yet) geocoded
+	Longitude   *float64  `gorm:"index:idx_events_lat_lon" json:"longitude"`
 	StartDate   time.Time `gorm:"index" json:"start_date"`
 	EndDate     time.Time `json:"end_date"`
 	Website     string    `json:"website"`
 	LogoURL     string    `json:"logo_url"`
 	TermsURL    string    `json:"terms_url"` // Link to terms and conditions
+	CoCURL      string    `gorm:"column:coc_url" json:"coc_url"` // Link to the code of conduct
+	// Speakers must accept the code of conduct at CoCURL to submit
+	RequireCoCAcceptance bool `gorm:"column:require_coc_acceptance;default:false" json:"require_coc_acceptance"`
 	Tags        string    `gorm:"index" json:"tags"` // Comma-separated (e.g., "sre,devops,cloud")
-	IsOnline     bool   `gorm:"default:false" json:"is_online"`
+	AttendanceMode AttendanceMode `gorm:"index;size:16;default:'in_person'" json:"attendance_mode"`
+	IsOnline     bool   `gorm:"default:false" json:"is_online"` // Legacy: true for online and hybrid events; kept in sync with AttendanceMode
 	ContactEmail string `json:"contact_email,omitempty"`
+	Sections     datatypes.JSON `gorm:"type:jsonb" json:"sections"` // []EventSection - see EventSection type for schema
+	// Organizer copy for the accepted, rejected and tentative emails, replacing
+	// the platform wording. Only shown to organizers.
+	StatusEmails datatypes.JSON `gorm:"type:jsonb" json:"status_emails,omitempty"` // map[ProposalStatus]StatusEmail
 
 	// Speaker benefits
 	TravelCovered      bool `gorm:"default:false" json:"travel_covered"`
@@ -73,7 +236,21 @@ type Event struct {
 	CFPCloseAt     time.Time      `gorm:"index" json:"cfp_close_at"`
 	CFPStatus      CFPStatus      `gorm:"index;default:'draft'" json:"cfp_status"`
 	MaxAccepted  *int           `json:"max_accepted"`                    // Maximum proposals accepted (nil = unlimited)
+	// When a confirmed speaker cancels, accept the highest-rated tentative
+	// proposal in their place (within max_accepted)
+	WaitlistAutoPromote bool `gorm:"default:false" json:"waitlist_auto_promote"`
+	// Let acceptances go past max_accepted, for events that overbook
+	// expecting drop-outs. Going over is logged rather than refused.
+	AllowOverbook bool `gorm:"default:false" json:"allow_overbook"`
 	CFPQuestions datatypes.JSON `gorm:"type:jsonb" json:"cfp_questions"` // []CustomQuestion - see CustomQuestion type for schema
+	// Abstract length guidance for speakers, enforced on submission (nil = no limit)
+	AbstractMinWords *int `json:"abstract_min_words"`
+	AbstractMaxWords *int `json:"abstract_max_words"`
+	// Show the number of submissions so far in listings with ?include=stats
+	ShowSubmissionCount bool `gorm:"default:false" json:"show_submission_count"`
+	// Mask speaker emails (keeping the domain) in proposals shown to
+	// co-organizers other than the creator
+	HideSpeakerEmailsFromReviewers bool `gorm:"default:false" json:"hide_speaker_emails_from_reviewers"`
 
 	// Payment (for future Stripe integration)
 	IsPaid                   bool   `gorm:"default:false" json:"is_paid"`
@@ -82,10 +259,134 @@ type Event struct {
 	CFPSubmissionFee         int    `json:"cfp_submission_fee,omitempty"`          // Fee in cents (e.g., 2500 = $25.00)
 	CFPSubmissionFeeCurrency string `gorm:"default:'usd'" json:"cfp_submission_fee_currency,omitempty"`
 
+	// Platform terms the creator accepted before paying the listing fee.
+	// Checkout requires the version to match the current LISTING_TERMS_VERSION.
+	ListingTermsVersion    string     `json:"listing_terms_version,omitempty"`
+	ListingTermsAcceptedAt *time.Time `json:"listing_terms_accepted_at,omitempty"`
+
+	// Unlisted events are reachable by slug and take submissions as usual,
+	// but are left out of listings, the public API, stats and digests, and
+	// ask search engines not to index them
+	Unlisted bool `gorm:"index;default:false" json:"unlisted"`
+
+	// Archived events ended long ago (ARCHIVE_AFTER). Like unlisted events
+	// they stay reachable by slug but are left out of listings, the public
+	// API, stats and digests. Their proposals' speaker emails and notes are
+	// scrubbed later, after the creator was emailed a notice.
+	Archived          bool       `gorm:"index;default:false" json:"archived"`
+	ArchivedAt        *time.Time `json:"archived_at,omitempty"`
+	ScrubNoticeSentAt *time.Time `json:"-"`
+	ScrubbedAt        *time.Time `json:"scrubbed_at,omitempty"`
+
+	// Events by the same creator with the same series form a series, such as
+	// the editions of a conference. Empty for standalone events.
+	Series string `gorm:"index;size:200" json:"series"`
+	// Flag proposals closely matching one the same speaker submitted to
+	// another event of the series. Matches are shown, never blocked.
+	FlagSeriesDuplicates bool `gorm:"default:false" json:"flag_series_duplicates"`
+
+	// Moderation (spam scoring at creation). The score and its signals are
+	// only shown to admins.
+	ModerationStatus ModerationStatus `gorm:"index;size:16;default:'approved'" json:"moderation_status"`
+	SpamScore        int              `gorm:"default:0" json:"-"`
+	SpamSignals      datatypes.JSON   `gorm:"type:jsonb" json:"-"` // []spam.Signal
+
 	CreatedByID *uint `gorm:"index;constraint:OnDelete:SET NULL" json:"created_by_id"` // Pointer to allow NULL when creator is deleted
 
 	// Co-organizers (many-to-many)
 	Organizers []User `gorm:"many2many:event_organizers;" json:"organizers,omitempty"`
+
+	// Draft is set when an organizer previews their draft event through the
+	// public endpoints; never stored
+	Draft bool `gorm:"-" json:"draft,omitempty"`
+
+	// Sanitized HTML rendered from the descriptions when they are markdown;
+	// never stored. See RenderDescriptions.
+	DescriptionHTML    string `gorm:"-" json:"description_html,omitempty"`
+	CFPDescriptionHTML string `gorm:"-" json:"cfp_description_html,omitempty"`
+
+	// NotifyMeCount is how many users asked to be emailed when the CFP opens.
+	// Only set for organizers; never stored.
+	NotifyMeCount *int64 `gorm:"-" json:"notify_me_count,omitempty"`
+
+	// Listing aggregates, only set with ?include=stats; never stored.
+	// DaysUntilCFPClose is set for open CFPs with a deadline, SubmissionCount
+	// for events with ShowSubmissionCount.
+	DaysUntilCFPClose *int   `gorm:"-" json:"days_until_cfp_close,omitempty"`
+	SubmissionCount   *int64 `gorm:"-" json:"submission_count,omitempty"`
+
+	// derivedSummary is the Summary FillSummary derived, if any, so it can
+	// tell a derived summary from one the organizers wrote
+	derivedSummary string
+}
+
+// RenderDescriptions sets DescriptionHTML and CFPDescriptionHTML from the
+// markdown source, or clears them for plaintext. It runs whenever an event is
+// loaded or saved.
+func (e *Event) RenderDescriptions() {
+	e.DescriptionHTML, e.CFPDescriptionHTML = "", ""
+	if e.DescriptionFormat != DescriptionMarkdown {
+		return
+	}
+	if e.Description != "" {
+		e.DescriptionHTML = markdown.ToHTML(e.Description)
+	}
+	if e.CFPDescription != "" {
+		e.CFPDescriptionHTML = markdown.ToHTML(e.CFPDescription)
+	}
+}
+
+// BeforeSave clears a derived summary so only summaries the organizers wrote
+// are stored; AfterSave derives it again
+func (e *Event) BeforeSave(tx *gorm.DB) error {
+	if e.IsSummaryDerived() {
+		e.Summary = ""
+	}
+	return nil
+}
+
+// AfterFind renders the descriptions of loaded events and fills in their summary
+func (e *Event) AfterFind(tx *gorm.DB) error {
+	e.RenderDescriptions()
+	e.FillSummary()
+	return nil
+}
+
+// AfterSave renders the descriptions of created and updated events and fills
+// in their summary
+func (e *Event) AfterSave(tx *gorm.DB) error {
+	e.RenderDescriptions()
+	e.FillSummary()
+	return nil
+}
+
+// GetSections unmarshals the sections JSON
+func (e *Event) GetSections() ([]EventSection, error) {
+	var sections []EventSection
+	if len(e.Sections) == 0 {
+		return sections, nil
+	}
+	err := json.Unmarshal(e.Sections, &sections)
+	return sections, err
+}
+
+// GetStatusEmail returns the organizer's copy for the email sent when a
+// proposal gets the status, if they set one
+func (e *Event) GetStatusEmail(status ProposalStatus) (StatusEmail, bool) {
+	if len(e.StatusEmails) == 0 {
+		return StatusEmail{}, false
+	}
+	var emails map[ProposalStatus]StatusEmail
+	if err := json.Unmarshal(e.StatusEmails, &emails); err != nil {
+		return StatusEmail{}, false
+	}
+	se, ok := emails[status]
+	return se, ok
+}
+
+// IsListed reports whether the event passed moderation and may be shown publicly
+func (e *Event) IsListed() bool {
+	return e.ModerationStatus == "" || e.ModerationStatus == ModerationApproved
 }
 
 // IsOrganizer checks if a user is an organizer of the event (creator or co-organizer)
@@ -103,9 +404,185 @@ func (e *Event) IsOrganizer(userID uint) bool {
 
 // IsCFPOpen checks if the CFP is currently accepting submissions
 func (e *Event) IsCFPOpen() bool {
+	return e.IsCFPOpenAt(time.Now(), 0)
+}
+
+// IsCFPOpenAt checks if the CFP accepts submissions at now, allowing grace after
+// the close time. CFPCloseAt is an absolute instant, so the comparison holds
+// whatever timezone the organizer entered the deadline in.
+//
+// Unset (zero) dates leave that side of the window unbounded: an open CFP with
+// no dates is open until its status changes. The listing filters in the api
+// package apply the same rule.
+func (e *Event) IsCFPOpenAt(now time.Time, grace time.Duration) bool {
 	if e.CFPStatus != CFPStatusOpen {
 		return false
 	}
-	now := time.Now()
-	return now.After(e.CFPOpenAt) && now.Before(e.CFPCloseAt)
+	if !e.CFPOpenAt.IsZero() && !now.After(e.CFPOpenAt) {
+		return false
+	}
+	if !e.CFPCloseAt.IsZero() && !now.Before(e.CFPCloseAt.Add(grace)) {
+		return false
+	}
+	return true
+}
+
+// BackfillCountryCodes resolves country_code for events saved before the column
+// existed, using the free-form country text. Unrecognized values stay empty.
+// This must be called after AutoMigrate.
+func BackfillCountryCodes(db *gorm.DB) error {
+	missing := "country != '' AND (country_code IS NULL OR country_code = '')"
+
+	var values []string
+	if err := db.Unscoped().Model(&Event{}).Distinct("country").Where(missing).Pluck("country", &values).Error; err != nil {
+		return err
+	}
+
+	for _, v := range values {
+		code, ok := countries.Lookup(v)
+		if !ok {
+			slog.Warn("cannot resolve event country to an ISO code", "country", v)
+			continue
+		}
+		if err := db.Unscoped().Model(&Event{}).Where("country = ?", v).Where(missing).Update("country_code", code).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GeocodeEvent resolves the event's location to coordinates and stores them.
+// Online-only events and events without a location are skipped. The update only
+// applies while the location is unchanged, so a slow lookup cannot overwrite
+// the coordinates of a newer location.
+func GeocodeEvent(ctx context.Context, db *gorm.DB, g geocode.Geocoder, event *Event) error {
+	if !event.AttendanceMode.HasVenue() || strings.TrimSpace(event.Location) == "" {
+		return nil
+	}
+
+	p, err := g.Geocode(ctx, event.Location, event.CountryCode)
+	if err != nil {
+		return err
+	}
+
+	event.Latitude, event.Longitude = &p.Lat, &p.Lon
+	return db.WithContext(ctx).Model(&Event{}).
+		Where("id = ? AND location = ? AND country_code = ?", event.ID, event.Location, event.CountryCode).
+		Updates(map[string]interface{}{"latitude": p.Lat, "longitude": p.Lon}).Error
+}
+
+// BackfillAttendanceModes derives attendance_mode for events saved before the
+// column existed, which all default to in_person, from the legacy is_online flag.
+// This must be called after AutoMigrate.
+func BackfillAttendanceModes(db *gorm.DB) error {
+	return db.Unscoped().Model(&Event{}).
+		Where("is_online = ? AND (attendance_mode = ? OR attendance_mode IS NULL OR attendance_mode = '')", true, AttendanceInPerson).
+		Update("attendance_mode", AttendanceOnline).Error
+}
+
+// EventSlugIndex is the unique index on events.slug. Slug conflicts on create
+// and update are detected from violations of it rather than by looking the
+// slug up first, which two concurrent requests could both pass.
+const EventSlugIndex = "idx_events_slug"
+
+// EnsureEventSlugIndex makes sure events.slug has a unique index. AutoMigrate
+// only checks that an index of that name exists, so databases where it was
+// created without UNIQUE (or dropped) would accept duplicate slugs; the index
+// is recreated as unique. This fails while duplicate slugs exist, which must
+// be resolved by hand. This must be called after AutoMigrate.
+func EnsureEventSlugIndex(db *gorm.DB) error {
+	var unique bool
+	if err := db.Raw(`SELECT EXISTS (
+		SELECT 1 FROM pg_index i JOIN pg_class c ON c.oid = i.indexrelid
+		WHERE c.relname = ? AND i.indisunique)`, EventSlugIndex).Scan(&unique).Error; err != nil {
+		return err
+	}
+	if unique {
+		return nil
+	}
+	if err := db.Exec("DROP INDEX IF EXISTS " + EventSlugIndex).Error; err != nil {
+		return err
+	}
+	if err := db.Exec("CREATE UNIQUE INDEX " + EventSlugIndex + " ON events (slug)").Error; err != nil {
+		return fmt.Errorf("create unique index on events.slug (duplicate slugs must be renamed first): %w", err)
+	}
+	slog.Info("created unique index on events.slug")
+	return nil
+}
+
+// OpenCFPPredicate is the condition of the partial index on events with an
+// open CFP. Queries meant to use the index must repeat it literally: a bound
+// parameter can't prove the index applies under a generic plan.
+const OpenCFPPredicate = "cfp_status = 'open'"
+
+// EnsureEventListingIndexes creates the indexes behind the public events
+// listing that AutoMigrate can't declare: CFP status with the closing date,
+// for the open and closed filters, and a partial index on open CFPs in the
+// default listing order, so the first pages don't sort the whole table.
+// This must be called after AutoMigrate.
+func EnsureEventListingIndexes(db *gorm.DB) error {
+	for _, stmt := range []string{
+		"CREATE INDEX IF NOT EXISTS idx_events_cfp_status_close ON events (cfp_status, cfp_close_at)",
+		"CREATE INDEX IF NOT EXISTS idx_events_open_cfp ON events (start_date DESC, id DESC) WHERE " + OpenCFPPredicate + " AND deleted_at IS NULL",
+	} {
+		if err := db.Exec(stmt).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// EventPreviewLink lets someone without an account view a draft event page.
+// The link's token is signed and carries its expiry; deleting the row revokes
+// the token.
+type EventPreviewLink struct {
+	ID          uint      `gorm:"primarykey" json:"id"`
+	EventID     uint      `gorm:"not null;index" json:"event_id"`
+	CreatedByID uint      `gorm:"not null" json:"created_by_id"`
+	ExpiresAt   time.Time `gorm:"not null" json:"expires_at"`
+	CreatedAt   time.Time `json:"created_at"`
+
+	Event     *Event `gorm:"constraint:OnDelete:CASCADE" json:"-"`
+	CreatedBy *User  `gorm:"constraint:OnDelete:CASCADE" json:"-"`
+}
+
+// SpeakerEmailSend records a bulk email an organizer sent to an event's
+// speakers. It is kept as an audit trail and to enforce the daily cap.
+type SpeakerEmailSend struct {
+	ID         uint           `gorm:"primarykey" json:"id"`
+	EventID    uint           `gorm:"not null;index:idx_speaker_email_sends_event_created" json:"event_id"`
+	SenderID   *uint          `gorm:"index" json:"sender_id"` // nil once the sender's account is deleted
+	Status     ProposalStatus `gorm:"size:16" json:"status"`
+	Subject    string         `json:"subject"`
+	Body       string         `json:"body"`
+	Recipients int            `json:"recipients"`
+	CreatedAt  time.Time      `gorm:"index:idx_speaker_email_sends_event_created" json:"created_at"`
+
+	Event  *Event `gorm:"constraint:OnDelete:CASCADE" json:"-"`
+	Sender *User  `gorm:"constraint:OnDelete:SET NULL" json:"-"`
+}
+
+// EventSlugHistory keeps a slug that no longer belongs to a live event, such
+// as the slug of an event merged into another, so that links to it can be
+// redirected to the event that replaced it.
+type EventSlugHistory struct {
+	ID        uint      `gorm:"primarykey" json:"id"`
+	Slug      string    `gorm:"uniqueIndex;not null" json:"slug"`
+	EventID   uint      `gorm:"not null;index" json:"event_id"`
+	CreatedAt time.Time `json:"created_at"`
+
+	Event *Event `gorm:"constraint:OnDelete:CASCADE" json:"-"`
+}
+
+// GetEventSlugRedirect returns the live event a retired slug now points to
+func GetEventSlugRedirect(db *gorm.DB, slug string) (*Event, error) {
+	var history EventSlugHistory
+	if err := db.Where("slug = ?", slug).First(&history).Error; err != nil {
+		return nil, err
+	}
+	var event Event
+	if err := db.First(&event, history.EventID).Error; err != nil {
+		return nil, err
+	}
+	return &event, nil
 }
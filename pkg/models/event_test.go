@@ -1,6 +1,7 @@
 package models
 
 import (
+	"encoding/json"
 	"testing"
 	"time"
 )
@@ -69,6 +70,44 @@ func TestEvent_IsCFPOpen(t *testing.T) {
 			},
 			expected: false,
 		},
+		{
+			name: "CFP open - no dates",
+			event: Event{
+				CFPStatus: CFPStatusOpen,
+			},
+			expected: true,
+		},
+		{
+			name: "CFP open - no open date",
+			event: Event{
+				CFPStatus:  CFPStatusOpen,
+				CFPCloseAt: now.Add(time.Hour),
+			},
+			expected: true,
+		},
+		{
+			name: "CFP open - no close date",
+			event: Event{
+				CFPStatus: CFPStatusOpen,
+				CFPOpenAt: now.Add(-time.Hour),
+			},
+			expected: true,
+		},
+		{
+			name: "CFP closed - no open date, past close time",
+			event: Event{
+				CFPStatus:  CFPStatusOpen,
+				CFPCloseAt: now.Add(-time.Hour),
+			},
+			expected: false,
+		},
+		{
+			name: "CFP closed - no dates, status closed",
+			event: Event{
+				CFPStatus: CFPStatusClosed,
+			},
+			expected: false,
+		},
 		{
 			name: "CFP at exact open time (boundary)",
 			event: Event{
@@ -90,6 +129,110 @@ func TestEvent_IsCFPOpen(t *testing.T) {
 	}
 }
 
+func TestEvent_IsCFPOpenAt_Grace(t *testing.T) {
+	closeAt := time.Date(2026, 3, 31, 23, 59, 59, 0, time.UTC)
+	event := Event{
+		CFPStatus:  CFPStatusOpen,
+		CFPOpenAt:  closeAt.AddDate(0, -1, 0),
+		CFPCloseAt: closeAt,
+	}
+	grace := 15 * time.Minute
+
+	testCases := []struct {
+		name     string
+		now      time.Time
+		grace    time.Duration
+		expected bool
+	}{
+		{"just before close", closeAt.Add(-30 * time.Second), 0, true},
+		{"exactly at close without grace", closeAt, 0, false},
+		{"exactly at close with grace", closeAt, grace, true},
+		{"within grace", closeAt.Add(14 * time.Minute), grace, true},
+		{"at end of grace", closeAt.Add(grace), grace, false},
+		{"after grace", closeAt.Add(time.Hour), grace, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := event.IsCFPOpenAt(tc.now, tc.grace); got != tc.expected {
+				t.Errorf("expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+
+	closed := event
+	closed.CFPStatus = CFPStatusClosed
+	if closed.IsCFPOpenAt(closeAt.Add(-time.Minute), grace) {
+		t.Error("expected grace period not to reopen a closed CFP")
+	}
+}
+
+func TestCustomQuestion_IsOpenAt(t *testing.T) {
+	openAt := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+	closeAt := time.Date(2026, 5, 15, 0, 0, 0, 0, time.UTC)
+	grace := 15 * time.Minute
+
+	testCases := []struct {
+		name     string
+		question CustomQuestion
+		now      time.Time
+		expected bool
+	}{
+		{"no window", CustomQuestion{}, openAt.AddDate(-1, 0, 0), true},
+		{"before open", CustomQuestion{OpenAt: &openAt}, openAt.Add(-time.Minute), false},
+		{"exactly at open", CustomQuestion{OpenAt: &openAt}, openAt, false},
+		{"after open", CustomQuestion{OpenAt: &openAt, CloseAt: &closeAt}, openAt.Add(time.Minute), true},
+		{"within grace", CustomQuestion{CloseAt: &closeAt}, closeAt.Add(14 * time.Minute), true},
+		{"after grace", CustomQuestion{CloseAt: &closeAt}, closeAt.Add(grace), false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.question.IsOpenAt(tc.now, grace); got != tc.expected {
+				t.Errorf("expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestEvent_FlagActiveQuestions(t *testing.T) {
+	now := time.Date(2026, 5, 10, 0, 0, 0, 0, time.UTC)
+	event := Event{
+		CFPStatus:  CFPStatusOpen,
+		CFPCloseAt: now.AddDate(0, 1, 0),
+		CFPQuestions: []byte(`[
+			{"id": "main", "text": "Main?", "type": "text", "close_at": "2026-05-01T00:00:00Z"},
+			{"id": "late", "text": "Late?", "type": "text", "open_at": "2026-05-01T00:00:00Z", "required": true}
+		]`),
+	}
+
+	if err := event.FlagActiveQuestions(now, 0); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var flagged []map[string]interface{}
+	if err := json.Unmarshal(event.CFPQuestions, &flagged); err != nil {
+		t.Fatalf("invalid questions JSON: %v", err)
+	}
+	if flagged[0]["active"] != false || flagged[1]["active"] != true {
+		t.Errorf("expected only the late question to be active, got %v", flagged)
+	}
+	if flagged[1]["required"] != true || flagged[1]["open_at"] != "2026-05-01T00:00:00Z" {
+		t.Errorf("expected the other fields to be kept, got %v", flagged[1])
+	}
+
+	// Nothing takes answers while the CFP itself is closed
+	event.CFPStatus = CFPStatusClosed
+	if err := event.FlagActiveQuestions(now, 0); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := json.Unmarshal(event.CFPQuestions, &flagged); err != nil {
+		t.Fatalf("invalid questions JSON: %v", err)
+	}
+	if flagged[1]["active"] != false {
+		t.Errorf("expected no active questions for a closed CFP, got %v", flagged)
+	}
+}
+
 func TestEvent_IsOrganizer(t *testing.T) {
 	event := Event{
 		CreatedByID: uintPtr(100),
@@ -195,3 +338,25 @@ func TestCFPStatus_Constants(t *testing.T) {
 		t.Errorf("expected 'complete', got %s", CFPStatusComplete)
 	}
 }
+
+func TestEvent_RenderDescriptions(t *testing.T) {
+	e := Event{
+		Description:       "Talks on **Go**",
+		CFPDescription:    "- 30 minute talks",
+		DescriptionFormat: DescriptionMarkdown,
+	}
+	e.RenderDescriptions()
+	if e.DescriptionHTML != "<p>Talks on <strong>Go</strong></p>\n" {
+		t.Errorf("unexpected description HTML %q", e.DescriptionHTML)
+	}
+	if e.CFPDescriptionHTML != "<ul>\n<li>30 minute talks</li>\n</ul>\n" {
+		t.Errorf("unexpected CFP description HTML %q", e.CFPDescriptionHTML)
+	}
+
+	// Switching back to plaintext clears the rendered HTML
+	e.DescriptionFormat = DescriptionPlaintext
+	e.RenderDescriptions()
+	if e.DescriptionHTML != "" || e.CFPDescriptionHTML != "" {
+		t.Errorf("expected no HTML for plaintext, got %q and %q", e.DescriptionHTML, e.CFPDescriptionHTML)
+	}
+}
@@ -0,0 +1,42 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ImpersonationLog is the audit trail of admin impersonation: one row when an
+// admin starts impersonating a user and one per request made as that user,
+// with both identities.
+type ImpersonationLog struct {
+	ID        uint      `gorm:"primarykey" json:"id"`
+	AdminID   uint      `gorm:"index;not null" json:"admin_id"`
+	UserID    uint      `gorm:"index;not null" json:"user_id"`
+	Method    string    `gorm:"size:10" json:"method"`
+	Path      string    `json:"path"`
+	Status    int       `json:"status"`
+	Write     bool      `json:"write"` // whether the session allowed changes
+	CreatedAt time.Time `gorm:"index" json:"created_at"`
+}
+
+// ImpersonationLogFilter narrows GetImpersonationLogs; zero fields match all
+type ImpersonationLogFilter struct {
+	AdminID uint
+	UserID  uint
+}
+
+// GetImpersonationLogs returns the most recent impersonation audit entries,
+// newest first
+func GetImpersonationLogs(db *gorm.DB, filter ImpersonationLogFilter, limit int) ([]ImpersonationLog, error) {
+	q := db.Model(&ImpersonationLog{})
+	if filter.AdminID != 0 {
+		q = q.Where("admin_id = ?", filter.AdminID)
+	}
+	if filter.UserID != 0 {
+		q = q.Where("user_id = ?", filter.UserID)
+	}
+	logs := []ImpersonationLog{}
+	err := q.Order("created_at DESC, id DESC").Limit(limit).Find(&logs).Error
+	return logs, err
+}
@@ -0,0 +1,59 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// LoginEvent is a successful sign-in, recorded when a token is issued so users
+// can see when and from where their account was used. Rows older than the
+// retention period are deleted by a background task.
+type LoginEvent struct {
+	ID        uint      `gorm:"primarykey" json:"id"`
+	UserID    uint      `gorm:"index:idx_login_events_user_created;not null" json:"-"`
+	Provider  string    `gorm:"size:32" json:"provider"` // "github" or "google"
+	IP        string    `gorm:"size:64" json:"ip"`
+	UserAgent string    `json:"user_agent"`
+	Country   string    `gorm:"size:2" json:"country"` // ISO 3166-1 alpha-2, empty when unknown
+	CreatedAt time.Time `gorm:"index:idx_login_events_user_created;index" json:"created_at"`
+
+	User *User `gorm:"constraint:OnDelete:CASCADE" json:"-"`
+}
+
+// GetLoginEvents returns a user's most recent logins, newest first
+func GetLoginEvents(db *gorm.DB, userID uint, limit int) ([]LoginEvent, error) {
+	var logins []LoginEvent
+	err := db.Where("user_id = ?", userID).Order("created_at DESC, id DESC").Limit(limit).Find(&logins).Error
+	return logins, err
+}
+
+// LoginCountrySeen reports whether the user logged in from country since the
+// given time, not counting the login with ID except. known is false when the
+// user has never logged in from a known country before, as on their first
+// login, so there is nothing to compare against.
+func LoginCountrySeen(db *gorm.DB, userID uint, country string, since time.Time, except uint) (seen, known bool, err error) {
+	var earlier int64
+	if err := db.Model(&LoginEvent{}).
+		Where("user_id = ? AND id <> ? AND country <> ''", userID, except).
+		Count(&earlier).Error; err != nil {
+		return false, false, err
+	}
+	if earlier == 0 {
+		return false, false, nil
+	}
+
+	var matching int64
+	if err := db.Model(&LoginEvent{}).
+		Where("user_id = ? AND id <> ? AND country = ? AND created_at >= ?", userID, except, country, since).
+		Count(&matching).Error; err != nil {
+		return false, true, err
+	}
+	return matching > 0, true, nil
+}
+
+// DeleteLoginEventsBefore deletes logins recorded before the given time
+func DeleteLoginEventsBefore(db *gorm.DB, before time.Time) (int64, error) {
+	result := db.Where("created_at < ?", before).Delete(&LoginEvent{})
+	return result.RowsAffected, result.Error
+}
@@ -6,6 +6,7 @@ import (
 
 	"gorm.io/datatypes"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type ProposalFormat string
@@ -23,6 +24,7 @@ const (
 	ProposalStatusAccepted  ProposalStatus = "accepted"
 	ProposalStatusRejected  ProposalStatus = "rejected"
 	ProposalStatusTentative ProposalStatus = "tentative"
+	ProposalStatusCancelled ProposalStatus = "cancelled" // withdrawn by the speaker after confirming
 )
 
 // Speaker is embedded in Proposal.Speakers JSONB field (not a GORM model).
@@ -56,6 +58,7 @@ type Speaker struct {
 	JobTitle string `json:"job_title,omitempty"` // Required: current job title
 	LinkedIn string `json:"linkedin,omitempty"` // Required: full LinkedIn profile URL
 	Company  string `json:"company,omitempty"`  // Required: current employer
+	PhotoURL string `json:"photo_url,omitempty"` // Headshot for the program
 	Primary  bool   `json:"primary"`            // Is this the primary/submitting speaker?
 }
 
@@ -77,12 +80,46 @@ type Proposal struct {
 	AttendanceConfirmed   bool       `gorm:"default:false" json:"attendance_confirmed"`
 	AttendanceConfirmedAt *time.Time `json:"attendance_confirmed_at,omitempty"`
 
+	// When the speaker accepted the event's code of conduct, for events
+	// that require it
+	CoCAcceptedAt *time.Time `gorm:"column:coc_accepted_at" json:"coc_accepted_at,omitempty"`
+
 	// Multiple speakers stored as JSONB - see Speaker type for schema
 	Speakers datatypes.JSON `gorm:"type:jsonb" json:"speakers"`
 
+	// Logistics speakers can still update once accepted
+	SlidesURL string `gorm:"size:2000" json:"slides_url,omitempty"`
+	VideoURL  string `gorm:"size:2000" json:"video_url,omitempty"`
+
 	// Notes
 	SpeakerNotes   string `json:"speaker_notes,omitempty"`   // Private notes from speaker to organizers
-	OrganizerNotes string `json:"organizer_notes,omitempty"` // Internal notes from organizers
+	OrganizerNotes string `json:"organizer_notes,omitempty"` // Shared decision summary, hidden from speakers
+
+	// The requesting organizer's private notes from their ProposalReview.
+	// Not stored on the proposal; filled in per request by LoadReviewerNotes.
+	ReviewerNotes string `gorm:"-" json:"reviewer_notes,omitempty"`
+
+	// Other events of the series the speaker submitted the same talk to.
+	// Not stored; filled in for organizers by LoadSeriesSubmissions.
+	AlsoSubmittedTo []SeriesSubmission `gorm:"-" json:"also_submitted_to,omitempty"`
+
+	// The speaker's proposals to the requesting organizer's other events.
+	// Not stored; only set when an organizer expands speaker_history, see
+	// LoadSpeakerHistory.
+	SpeakerHistory *[]SpeakerHistoryEntry `gorm:"-" json:"speaker_history,omitempty"`
+
+	// IDs of the event's required questions the proposal has no answer to,
+	// such as ones added after it was submitted. Not stored; filled in for
+	// organizers by MissingAnswers.
+	MissingRequiredAnswers []string `gorm:"-" json:"missing_required_answers,omitempty"`
+
+	// Who is editing the proposal right now, if anyone. Not stored on the
+	// proposal; filled in by GetProposalLock.
+	Lock *ProposalLock `gorm:"-" json:"lock,omitempty"`
+
+	// Until when the speaker may fill in missing answers after an organizer
+	// asked them to, even if the proposal could no longer be edited
+	AnswersRequestedUntil *time.Time `json:"answers_requested_until,omitempty"`
 
 	// Answers to custom questions (stored as JSONB).
 	// Keys are question IDs from Event.CFPQuestions, values are the answers.
@@ -94,8 +131,28 @@ type Proposal struct {
 	StripePaymentID string `json:"stripe_payment_id,omitempty"`
 
 	CreatedByID *uint `gorm:"index;constraint:OnDelete:SET NULL" json:"created_by_id,omitempty"` // User who submitted
+
+	// Imported proposals came from another CFP tool's CSV export and have no
+	// submitting user
+	Imported bool `gorm:"default:false" json:"imported"`
+
+	// Set when the speaker copied this proposal from one of their proposals
+	// to another event
+	CopiedFromID *uint `gorm:"index" json:"copied_from_id,omitempty"`
+
+	// Where the proposal was submitted from (ProposalSource*), recorded by
+	// the server for statistics. Empty for proposals from before it was.
+	Source string `gorm:"size:16;index" json:"source,omitempty"`
 }
 
+// Proposal sources
+const (
+	ProposalSourceWeb    = "web"    // browser session
+	ProposalSourceCLI    = "cli"    // the cfp CLI
+	ProposalSourceAPI    = "api"    // any other bearer token client
+	ProposalSourceImport = "import" // CSV import by organizers
+)
+
 // GetSpeakers unmarshals the speakers JSON
 func (p *Proposal) GetSpeakers() ([]Speaker, error) {
 	var speakers []Speaker
@@ -126,6 +183,27 @@ func (p *Proposal) GetCustomAnswers() (map[string]interface{}, error) {
 	return answers, err
 }
 
+// MissingAnswers returns the IDs of the required questions, among those
+// already open for answers at now, that answers has no answer to
+func MissingAnswers(answers map[string]interface{}, questions []CustomQuestion, now time.Time) []string {
+	var missing []string
+	for _, q := range questions {
+		if !q.Required || (q.OpenAt != nil && !now.After(*q.OpenAt)) {
+			continue
+		}
+		if answers[q.ID] == nil {
+			missing = append(missing, q.ID)
+		}
+	}
+	return missing
+}
+
+// AnswersRequested reports whether the speaker may still fill in the answers
+// an organizer asked them for at now
+func (p *Proposal) AnswersRequested(now time.Time) bool {
+	return p.AnswersRequestedUntil != nil && now.Before(*p.AnswersRequestedUntil)
+}
+
 // SetCustomAnswers marshals custom answers to JSON
 func (p *Proposal) SetCustomAnswers(answers map[string]interface{}) error {
 	data, err := json.Marshal(answers)
@@ -135,3 +213,65 @@ func (p *Proposal) SetCustomAnswers(answers map[string]interface{}) error {
 	p.CustomAnswers = data
 	return nil
 }
+
+// ProposalReview holds one organizer's private notes on a proposal. Each
+// reviewer has at most one review per proposal, and its notes are only ever
+// returned to that reviewer.
+type ProposalReview struct {
+	ID         uint      `gorm:"primarykey" json:"id"`
+	ProposalID uint      `gorm:"not null;uniqueIndex:idx_proposal_reviews_proposal_reviewer" json:"proposal_id"`
+	ReviewerID uint      `gorm:"not null;uniqueIndex:idx_proposal_reviews_proposal_reviewer;index" json:"reviewer_id"`
+	Notes      string    `json:"notes"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+
+	Proposal *Proposal `gorm:"constraint:OnDelete:CASCADE" json:"-"`
+	Reviewer *User     `gorm:"constraint:OnDelete:CASCADE" json:"-"`
+}
+
+// SaveReviewerNotes creates or replaces the reviewer's private notes on a proposal
+func SaveReviewerNotes(db *gorm.DB, proposalID, reviewerID uint, notes string) error {
+	review := ProposalReview{ProposalID: proposalID, ReviewerID: reviewerID, Notes: notes}
+	return db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "proposal_id"}, {Name: "reviewer_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"notes", "updated_at"}),
+	}).Create(&review).Error
+}
+
+// GetReviewerNotes returns the reviewer's private notes on a proposal, or an
+// empty string if they have not written any
+func GetReviewerNotes(db *gorm.DB, proposalID, reviewerID uint) (string, error) {
+	var reviews []ProposalReview
+	if err := db.Where("proposal_id = ? AND reviewer_id = ?", proposalID, reviewerID).Limit(1).Find(&reviews).Error; err != nil {
+		return "", err
+	}
+	if len(reviews) == 0 {
+		return "", nil
+	}
+	return reviews[0].Notes, nil
+}
+
+// LoadReviewerNotes fills in ReviewerNotes on each proposal with the reviewer's
+// own notes. Other organizers' notes are never loaded.
+func LoadReviewerNotes(db *gorm.DB, reviewerID uint, proposals []Proposal) error {
+	if len(proposals) == 0 {
+		return nil
+	}
+	ids := make([]uint, len(proposals))
+	for i, p := range proposals {
+		ids[i] = p.ID
+	}
+
+	var reviews []ProposalReview
+	if err := db.Where("reviewer_id = ? AND proposal_id IN ?", reviewerID, ids).Find(&reviews).Error; err != nil {
+		return err
+	}
+	notes := make(map[uint]string, len(reviews))
+	for _, r := range reviews {
+		notes[r.ProposalID] = r.Notes
+	}
+	for i := range proposals {
+		proposals[i].ReviewerNotes = notes[proposals[i].ID]
+	}
+	return nil
+}
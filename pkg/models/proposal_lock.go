@@ -0,0 +1,73 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ProposalLockDuration is how long an edit lock lasts unless its holder renews it
+const ProposalLockDuration = 5 * time.Minute
+
+// ProposalLock marks a proposal as being edited by one user, so others are
+// warned before overwriting their changes. Locks are advisory and expire on
+// their own: an expired lock is ignored when read and taken over by the next
+// user locking the proposal, so nothing needs to clean them up.
+type ProposalLock struct {
+	ProposalID uint      `gorm:"primaryKey;autoIncrement:false" json:"-"`
+	UserID     uint      `gorm:"not null" json:"user_id"`
+	ExpiresAt  time.Time `gorm:"not null" json:"expires_at"`
+
+	// The holder's display name. Not stored; filled in from User when read.
+	Name string `gorm:"-" json:"name"`
+
+	Proposal *Proposal `gorm:"constraint:OnDelete:CASCADE" json:"-"`
+	User     *User     `gorm:"constraint:OnDelete:CASCADE" json:"-"`
+}
+
+// HeldBy reports whether the lock belongs to the user
+func (l *ProposalLock) HeldBy(userID uint) bool {
+	return l != nil && l.UserID == userID
+}
+
+// AcquireProposalLock locks the proposal for the user until now plus
+// ProposalLockDuration, renewing the lock if they already hold it. If another
+// user holds an unexpired lock it is left alone. Either way the current lock
+// is returned, so the caller can tell who holds it.
+func AcquireProposalLock(db *gorm.DB, proposalID, userID uint, now time.Time) (*ProposalLock, error) {
+	lock := ProposalLock{ProposalID: proposalID, UserID: userID, ExpiresAt: now.Add(ProposalLockDuration)}
+	err := db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "proposal_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"user_id", "expires_at"}),
+		Where: clause.Where{Exprs: []clause.Expression{
+			clause.Expr{SQL: "proposal_locks.user_id = excluded.user_id OR proposal_locks.expires_at <= ?", Vars: []interface{}{now}},
+		}},
+	}).Create(&lock).Error
+	if err != nil {
+		return nil, err
+	}
+	return GetProposalLock(db, proposalID, now)
+}
+
+// GetProposalLock returns the proposal's unexpired lock with its holder's
+// name, or nil if nobody is editing it
+func GetProposalLock(db *gorm.DB, proposalID uint, now time.Time) (*ProposalLock, error) {
+	var locks []ProposalLock
+	if err := db.Preload("User").Where("proposal_id = ? AND expires_at > ?", proposalID, now).Limit(1).Find(&locks).Error; err != nil {
+		return nil, err
+	}
+	if len(locks) == 0 {
+		return nil, nil
+	}
+	lock := &locks[0]
+	if lock.User != nil {
+		lock.Name = lock.User.Name
+	}
+	return lock, nil
+}
+
+// ReleaseProposalLock removes the user's lock on the proposal, if they hold it
+func ReleaseProposalLock(db *gorm.DB, proposalID, userID uint) error {
+	return db.Where("proposal_id = ? AND user_id = ?", proposalID, userID).Delete(&ProposalLock{}).Error
+}
@@ -3,6 +3,7 @@ package models
 import (
 	"encoding/json"
 	"testing"
+	"time"
 )
 
 func TestProposal_GetSpeakers(t *testing.T) {
@@ -246,3 +247,22 @@ func TestProposalFormat_Constants(t *testing.T) {
 		t.Errorf("expected 'lightning', got %s", FormatLightning)
 	}
 }
+
+func TestMissingAnswers(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	later := now.Add(time.Hour)
+	questions := []CustomQuestion{
+		{ID: "coc", Type: "checkbox", Required: true},
+		{ID: "travel", Type: "text", Required: true},
+		{ID: "dietary", Type: "text"},
+		{ID: "late", Type: "text", Required: true, OpenAt: &later},
+	}
+
+	got := MissingAnswers(map[string]interface{}{"coc": false, "travel": nil}, questions, now)
+	if len(got) != 1 || got[0] != "travel" {
+		t.Errorf("expected only travel to be missing, got %v", got)
+	}
+	if got := MissingAnswers(nil, questions, later.Add(time.Second)); len(got) != 3 {
+		t.Errorf("expected every required question once open, got %v", got)
+	}
+}
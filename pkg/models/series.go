@@ -0,0 +1,124 @@
+package models
+
+import (
+	"strings"
+	"unicode"
+
+	"gorm.io/gorm"
+)
+
+// SeriesDuplicateSimilarity is the trigram similarity of title and abstract
+// from which a proposal counts as the same talk as one its speaker submitted
+// to another event of the series
+const SeriesDuplicateSimilarity = 0.6
+
+// SeriesSubmission is another event of a series that a proposal's speaker
+// submitted a closely matching proposal to. Organizers only learn the event
+// and what became of the other proposal.
+type SeriesSubmission struct {
+	EventSlug string         `json:"event_slug"`
+	EventName string         `json:"event_name"`
+	Status    ProposalStatus `json:"status"`
+}
+
+// trigrams returns the set of trigrams of text's words, the way PostgreSQL's
+// pg_trgm extracts them: lowercased, split on anything but letters and
+// digits, and padded with two spaces in front and one behind
+func trigrams(text string) map[string]bool {
+	set := make(map[string]bool)
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for _, word := range words {
+		padded := []rune("  " + word + " ")
+		for i := 0; i+3 <= len(padded); i++ {
+			set[string(padded[i:i+3])] = true
+		}
+	}
+	return set
+}
+
+// TrigramSimilarity is the share of trigrams two texts have in common, from
+// 0 (none) to 1 (the same words), as pg_trgm's similarity() computes it
+func TrigramSimilarity(a, b string) float64 {
+	return setSimilarity(trigrams(a), trigrams(b))
+}
+
+func setSimilarity(a, b map[string]bool) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	common := 0
+	for t := range a {
+		if b[t] {
+			common++
+		}
+	}
+	return float64(common) / float64(len(a)+len(b)-common)
+}
+
+// LoadSeriesSubmissions fills in AlsoSubmittedTo on each proposal of event,
+// when the event flags series duplicates. Events form a series when they
+// share a series name and creator, so nobody can join another creator's
+// series to look at its submissions. Drafts, events held for review,
+// unlisted and archived events are left out, as the event's co-organizers
+// may not know of them.
+func LoadSeriesSubmissions(db *gorm.DB, event *Event, proposals []Proposal) error {
+	if !event.FlagSeriesDuplicates || event.Series == "" || event.CreatedByID == nil {
+		return nil
+	}
+	var speakers []uint
+	for _, p := range proposals {
+		if p.CreatedByID != nil {
+			speakers = append(speakers, *p.CreatedByID)
+		}
+	}
+	if len(speakers) == 0 {
+		return nil
+	}
+
+	var others []struct {
+		CreatedByID uint
+		Title       string
+		Abstract    string
+		Status      ProposalStatus
+		Slug        string
+		Name        string
+	}
+	if err := db.Table("proposals").
+		Select("proposals.created_by_id, proposals.title, proposals.abstract, proposals.status, events.slug, events.name").
+		Joins("JOIN events ON events.id = proposals.event_id AND events.deleted_at IS NULL").
+		Where("events.series = ? AND events.created_by_id = ? AND events.id <> ?", event.Series, *event.CreatedByID, event.ID).
+		Where("events.cfp_status <> ? AND events.moderation_status = ? AND NOT events.unlisted AND NOT events.archived", CFPStatusDraft, ModerationApproved).
+		Where("proposals.deleted_at IS NULL AND proposals.created_by_id IN ?", speakers).
+		Order("events.start_date, events.id").
+		Scan(&others).Error; err != nil {
+		return err
+	}
+	if len(others) == 0 {
+		return nil
+	}
+
+	otherTrigrams := make([]map[string]bool, len(others))
+	for i, o := range others {
+		otherTrigrams[i] = trigrams(o.Title + " " + o.Abstract)
+	}
+	for i := range proposals {
+		p := &proposals[i]
+		if p.CreatedByID == nil {
+			continue
+		}
+		own := trigrams(p.Title + " " + p.Abstract)
+		seen := make(map[string]bool)
+		for j, o := range others {
+			if o.CreatedByID != *p.CreatedByID || seen[o.Slug] {
+				continue
+			}
+			if setSimilarity(own, otherTrigrams[j]) >= SeriesDuplicateSimilarity {
+				seen[o.Slug] = true
+				p.AlsoSubmittedTo = append(p.AlsoSubmittedTo, SeriesSubmission{EventSlug: o.Slug, EventName: o.Name, Status: o.Status})
+			}
+		}
+	}
+	return nil
+}
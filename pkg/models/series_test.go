@@ -0,0 +1,42 @@
+package models
+
+import "testing"
+
+func TestTrigramSimilarity(t *testing.T) {
+	talk := "Scaling Postgres at Acme: lessons from ten years of on-call"
+	tests := []struct {
+		name     string
+		a, b     string
+		min, max float64
+	}{
+		{"identical", talk, talk, 1, 1},
+		{"case and punctuation", talk, "scaling postgres at acme -- lessons from ten years of on call", 1, 1},
+		{"light edit", talk, "Scaling Postgres at Acme: lessons from eleven years of on-call", SeriesDuplicateSimilarity, 0.99},
+		{"different talk", talk, "Building a design system for internal tools", 0, 0.2},
+		{"empty", talk, "", 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := TrigramSimilarity(tt.a, tt.b)
+			if got < tt.min || got > tt.max {
+				t.Errorf("TrigramSimilarity(%q, %q) = %.2f, want between %.2f and %.2f", tt.a, tt.b, got, tt.min, tt.max)
+			}
+			if rev := TrigramSimilarity(tt.b, tt.a); rev != got {
+				t.Errorf("expected a symmetric similarity, got %.2f and %.2f", got, rev)
+			}
+		})
+	}
+}
+
+func TestTrigrams_MatchPgTrgm(t *testing.T) {
+	// SELECT show_trgm('Cat') gives {"  c"," ca","at ",cat}
+	got := trigrams("Cat")
+	for _, want := range []string{"  c", " ca", "cat", "at "} {
+		if !got[want] {
+			t.Errorf("expected trigram %q in %v", want, got)
+		}
+	}
+	if len(got) != 4 {
+		t.Errorf("expected 4 trigrams, got %v", got)
+	}
+}
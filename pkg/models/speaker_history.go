@@ -0,0 +1,61 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// SpeakerHistoryEntry is another proposal by a proposal's speaker, on an event
+// the requesting organizer also organizes
+type SpeakerHistoryEntry struct {
+	ProposalID     uint           `json:"proposal_id"`
+	Title          string         `json:"title"`
+	Status         ProposalStatus `json:"status"`
+	Rating         *int           `json:"rating"`
+	EventID        uint           `json:"event_id"`
+	EventSlug      string         `json:"event_slug"`
+	EventName      string         `json:"event_name"`
+	EventStartDate time.Time      `json:"event_start_date"`
+}
+
+// speakerEmails returns the proposal's normalized speaker emails
+func speakerEmails(p *Proposal) []string {
+	speakers, _ := p.GetSpeakers()
+	var emails []string
+	for _, s := range speakers {
+		if email := NormalizeEmail(s.Email); email != "" {
+			emails = append(emails, email)
+		}
+	}
+	return emails
+}
+
+// LoadSpeakerHistory sets SpeakerHistory on the proposal to the other
+// proposals its speakers submitted to other events, newest event first. A
+// proposal counts as theirs when it was submitted from the same account or
+// lists one of the same speaker emails. Only events the organizer created or
+// co-organizes are searched, so nobody learns about events they don't run.
+func LoadSpeakerHistory(db *gorm.DB, organizerID uint, p *Proposal) error {
+	history := []SpeakerHistoryEntry{}
+	p.SpeakerHistory = &history
+	emails := speakerEmails(p)
+	if p.CreatedByID == nil && len(emails) == 0 {
+		return nil
+	}
+
+	q := db.Table("proposals").
+		Select("proposals.id AS proposal_id, proposals.title, proposals.status, proposals.rating, events.id AS event_id, events.slug AS event_slug, events.name AS event_name, events.start_date AS event_start_date").
+		Joins("JOIN events ON events.id = proposals.event_id AND events.deleted_at IS NULL").
+		Where("(events.created_by_id = @user OR events.id IN (SELECT event_id FROM event_organizers WHERE user_id = @user))", map[string]interface{}{"user": organizerID}).
+		Where("proposals.deleted_at IS NULL AND proposals.event_id <> ?", p.EventID)
+
+	bySpeaker := db.Where("EXISTS (SELECT 1 FROM jsonb_array_elements(CASE WHEN jsonb_typeof(proposals.speakers) = 'array' THEN proposals.speakers ELSE '[]'::jsonb END) AS s WHERE lower(trim(s->>'email')) IN ?)", emails)
+	if len(emails) == 0 {
+		bySpeaker = db.Where("proposals.created_by_id = ?", *p.CreatedByID)
+	} else if p.CreatedByID != nil {
+		bySpeaker = bySpeaker.Or("proposals.created_by_id = ?", *p.CreatedByID)
+	}
+
+	return q.Where(bySpeaker).Order("events.start_date DESC, proposals.id").Scan(&history).Error
+}
@@ -0,0 +1,18 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+
+	"gorm.io/datatypes"
+)
+
+func TestSpeakerEmails(t *testing.T) {
+	p := &Proposal{Speakers: datatypes.JSON(`[{"name": "Jane", "email": " Jane@Example.com "}, {"name": "No Email"}, {"name": "John", "email": "john@example.com"}]`)}
+	if got, want := speakerEmails(p), []string{"jane@example.com", "john@example.com"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("speakerEmails() = %v, want %v", got, want)
+	}
+	if got := speakerEmails(&Proposal{}); len(got) != 0 {
+		t.Errorf("expected no emails without speakers, got %v", got)
+	}
+}
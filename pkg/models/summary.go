@@ -0,0 +1,70 @@
+package models
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/sreday/cfp.ninja/pkg/markdown"
+	"github.com/sreday/cfp.ninja/pkg/sanitize"
+)
+
+// MaxSummaryLen is the most characters an event summary may have
+const MaxSummaryLen = 300
+
+// SummarizeDescription derives a summary from the first paragraph of a
+// description, skipping markdown headings and markup, and cut at a sentence
+// or word boundary to fit MaxSummaryLen
+func SummarizeDescription(description string, format DescriptionFormat) string {
+	description = strings.ReplaceAll(description, "\r\n", "\n")
+	var summary string
+	for _, p := range strings.Split(description, "\n\n") {
+		p = strings.TrimSpace(p)
+		if p == "" || (format == DescriptionMarkdown && strings.HasPrefix(p, "#")) {
+			continue
+		}
+		if format == DescriptionMarkdown {
+			summary = sanitize.Text(markdown.ToHTML(p))
+		} else {
+			summary = strings.Join(strings.Fields(p), " ")
+		}
+		if summary != "" {
+			break
+		}
+	}
+	return truncateSummary(summary)
+}
+
+// truncateSummary cuts s to MaxSummaryLen characters: after its last full
+// sentence if that keeps at least half, otherwise after its last full word
+// with an ellipsis
+func truncateSummary(s string) string {
+	if utf8.RuneCountInString(s) <= MaxSummaryLen {
+		return s
+	}
+	cut := string([]rune(s)[:MaxSummaryLen])
+	if i := strings.LastIndexAny(cut, ".!?"); i >= len(cut)/2 && (i == len(cut)-1 || cut[i+1] == ' ') {
+		return cut[:i+1]
+	}
+	// Leave room for the ellipsis
+	cut = string([]rune(cut)[:MaxSummaryLen-1])
+	if i := strings.LastIndex(cut, " "); i > 0 {
+		cut = cut[:i]
+	}
+	return strings.TrimRight(cut, " ,;:-") + "…"
+}
+
+// FillSummary sets Summary from the description when the organizers left it
+// empty. It runs whenever an event is loaded or saved, so a derived summary
+// follows changes to the description.
+func (e *Event) FillSummary() {
+	if strings.TrimSpace(e.Summary) == "" || e.Summary == e.derivedSummary {
+		e.Summary = SummarizeDescription(e.Description, e.DescriptionFormat)
+		e.derivedSummary = e.Summary
+	}
+}
+
+// IsSummaryDerived reports whether Summary was derived from the description
+// rather than written by the organizers
+func (e *Event) IsSummaryDerived() bool {
+	return e.Summary != "" && e.Summary == e.derivedSummary
+}
@@ -0,0 +1,65 @@
+package models
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestSummarizeDescription(t *testing.T) {
+	testCases := []struct {
+		name        string
+		description string
+		format      DescriptionFormat
+		want        string
+	}{
+		{"empty", "", DescriptionPlaintext, ""},
+		{"first paragraph", "A conference about SRE.\n\nTickets on sale soon.", DescriptionPlaintext, "A conference about SRE."},
+		{"collapses line breaks", "A conference\r\nabout SRE.\r\n\r\nMore.", DescriptionPlaintext, "A conference about SRE."},
+		{"plaintext keeps hashes", "# not a heading\n\nSecond.", DescriptionPlaintext, "# not a heading"},
+		{"skips markdown headings", "# SREday\n\nA **one day** conference about [SRE](https://sreday.com).", DescriptionMarkdown, "A one day conference about SRE."},
+		{"skips leading blank paragraphs", "\n\n\n\nHello.", DescriptionPlaintext, "Hello."},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := SummarizeDescription(tc.description, tc.format); got != tc.want {
+				t.Errorf("SummarizeDescription(%q) = %q, want %q", tc.description, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSummarizeDescription_Truncates(t *testing.T) {
+	sentences := strings.Repeat("This sentence is exactly forty chars!! ", 10)
+	got := SummarizeDescription(sentences, DescriptionPlaintext)
+	if !strings.HasSuffix(got, "!") || utf8.RuneCountInString(got) > MaxSummaryLen {
+		t.Errorf("expected a cut after the last full sentence, got %q", got)
+	}
+
+	words := strings.Repeat("żółw ", 100)
+	got = SummarizeDescription(words, DescriptionPlaintext)
+	if !strings.HasSuffix(got, "żółw…") || utf8.RuneCountInString(got) > MaxSummaryLen {
+		t.Errorf("expected a cut after the last full word, got %q", got)
+	}
+}
+
+func TestFillSummary(t *testing.T) {
+	e := Event{Description: "First.\n\nSecond."}
+	e.FillSummary()
+	if e.Summary != "First." {
+		t.Fatalf("expected a derived summary, got %q", e.Summary)
+	}
+
+	e.Description = "Changed.\n\nSecond."
+	e.FillSummary()
+	if e.Summary != "Changed." {
+		t.Errorf("expected the derived summary to follow the description, got %q", e.Summary)
+	}
+
+	e.Summary = "Written by the organizers."
+	e.FillSummary()
+	if e.Summary != "Written by the organizers." {
+		t.Errorf("expected the written summary to be kept, got %q", e.Summary)
+	}
+}
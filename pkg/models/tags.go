@@ -0,0 +1,78 @@
+package models
+
+import (
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// MaxEventTags is the most tags an event keeps
+const MaxEventTags = 20
+
+// SplitTags splits a comma-separated tag list into normalized tags: trimmed,
+// lowercased and with runs of whitespace collapsed to one space, leaving out
+// empty and repeated tags, in their original order
+func SplitTags(tags string) []string {
+	var out []string
+	seen := make(map[string]bool)
+	for _, t := range strings.Split(tags, ",") {
+		t = strings.ToLower(strings.Join(strings.Fields(t), " "))
+		if t == "" || seen[t] {
+			continue
+		}
+		seen[t] = true
+		out = append(out, t)
+	}
+	return out
+}
+
+// NormalizeTags normalizes a comma-separated tag list as SplitTags does,
+// keeping the first MaxEventTags. Event tags are stored normalized, so
+// filters can match them exactly.
+func NormalizeTags(tags string) string {
+	split := SplitTags(tags)
+	if len(split) > MaxEventTags {
+		split = split[:MaxEventTags]
+	}
+	return strings.Join(split, ",")
+}
+
+// NormalizeEventTags normalizes the tags of events saved before tags were
+// normalized. This must be called after AutoMigrate.
+func NormalizeEventTags(db *gorm.DB) error {
+	var values []string
+	if err := db.Unscoped().Model(&Event{}).Distinct("tags").Where("tags <> ''").Pluck("tags", &values).Error; err != nil {
+		return err
+	}
+	for _, v := range values {
+		normalized := NormalizeTags(v)
+		if normalized == v {
+			continue
+		}
+		if err := db.Unscoped().Model(&Event{}).Where("tags = ?", v).Update("tags", normalized).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// TagSuggestion is a known tag and the number of listed events using it
+type TagSuggestion struct {
+	Tag    string `json:"tag"`
+	Events int64  `json:"events"`
+}
+
+// SuggestTags returns the tags of listed events starting with prefix, most
+// used first. Only listed events count, so tags of drafts, unlisted and
+// archived events are never suggested.
+func SuggestTags(db *gorm.DB, prefix string, limit int) ([]TagSuggestion, error) {
+	suggestions := []TagSuggestion{}
+	err := db.Raw(`SELECT tag, COUNT(*) AS events
+		FROM events, unnest(string_to_array(events.tags, ',')) AS tag
+		WHERE events.deleted_at IS NULL AND events.cfp_status <> ? AND events.moderation_status = ?
+			AND NOT events.unlisted AND NOT events.archived AND starts_with(tag, ?)
+		GROUP BY tag
+		ORDER BY events DESC, tag
+		LIMIT ?`, CFPStatusDraft, ModerationApproved, prefix, limit).Scan(&suggestions).Error
+	return suggestions, err
+}
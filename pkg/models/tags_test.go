@@ -0,0 +1,46 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeTags(t *testing.T) {
+	var many []string
+	for i := 0; i < MaxEventTags+5; i++ {
+		many = append(many, fmt.Sprintf("tag%d", i))
+	}
+
+	testCases := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"empty", "", ""},
+		{"already normalized", "sre,devops,cloud", "sre,devops,cloud"},
+		{"trims and lowercases", " Kubernetes , SRE ", "kubernetes,sre"},
+		{"collapses whitespace", "platform   engineering,\tcloud\nnative", "platform engineering,cloud native"},
+		{"dedupes keeping the first", "k8s,Kubernetes ,K8S,kubernetes", "k8s,kubernetes"},
+		{"drops empty tags", ",,go,, ,", "go"},
+		{"caps the number of tags", strings.Join(many, ","), strings.Join(many[:MaxEventTags], ",")},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := NormalizeTags(tc.in); got != tc.want {
+				t.Errorf("NormalizeTags(%q) = %q, want %q", tc.in, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSplitTags_KeepsAll(t *testing.T) {
+	var many []string
+	for i := 0; i < MaxEventTags+1; i++ {
+		many = append(many, fmt.Sprintf("tag%d", i))
+	}
+	if got := SplitTags(strings.Join(many, ",")); len(got) != MaxEventTags+1 {
+		t.Errorf("expected SplitTags to keep all %d tags, got %d", MaxEventTags+1, len(got))
+	}
+}
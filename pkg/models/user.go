@@ -3,6 +3,7 @@ package models
 import (
 	"fmt"
 	"log/slog"
+	"strings"
 	"time"
 
 	"gorm.io/gorm"
@@ -22,6 +23,47 @@ type User struct {
 
 	IsActive        bool       `gorm:"default:true"`
 	TermsAcceptedAt *time.Time `gorm:"index"`
+
+	// Set by an admin to exempt the user from proposal submission abuse limits
+	IsTrusted bool `gorm:"default:false"`
+
+	// Incremented to sign the user out everywhere: tokens issued for an
+	// earlier generation are rejected
+	TokenGeneration int `gorm:"default:0;not null" json:"-"`
+}
+
+// NormalizeEmail trims and lowercases an email address. User emails are
+// stored normalized and every lookup normalizes its input, so addresses match
+// whatever case the OAuth provider or the person typing reports.
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// NormalizeUserEmails normalizes the emails of users saved before emails were
+// normalized, and indexes lower(email) for case-insensitive lookups. A user
+// whose normalized email belongs to another user is left as is and logged,
+// to be merged by hand. This must be called after AutoMigrate.
+func NormalizeUserEmails(db *gorm.DB) error {
+	var users []User
+	if err := db.Unscoped().Select("id", "email").
+		Where("email <> lower(trim(email))").Find(&users).Error; err != nil {
+		return err
+	}
+	for _, u := range users {
+		normalized := NormalizeEmail(u.Email)
+		var taken int64
+		if err := db.Unscoped().Model(&User{}).Where("email = ? AND id <> ?", normalized, u.ID).Count(&taken).Error; err != nil {
+			return err
+		}
+		if taken > 0 {
+			slog.Warn("cannot normalize user email, another user has it", "user_id", u.ID)
+			continue
+		}
+		if err := db.Unscoped().Model(&User{}).Where("id = ?", u.ID).Update("email", normalized).Error; err != nil {
+			return err
+		}
+	}
+	return db.Exec("CREATE INDEX IF NOT EXISTS idx_users_email_lower ON users (lower(email))").Error
 }
 
 // CreatePartialUniqueIndexes creates partial unique indexes for fields that can be empty.
@@ -79,14 +121,16 @@ func GetUserByGitHubID(db *gorm.DB, gitHubID string) (*User, error) {
 	return &user, nil
 }
 
-// GetUserByEmail looks up a user by email.
+// GetUserByEmail looks up a user by email, ignoring case and surrounding
+// whitespace.
 // Returns gorm.ErrRecordNotFound if email is empty or user doesn't exist.
 func GetUserByEmail(db *gorm.DB, email string) (*User, error) {
+	email = NormalizeEmail(email)
 	if email == "" {
 		return nil, gorm.ErrRecordNotFound
 	}
 	var user User
-	if err := db.Where("email = ?", email).First(&user).Error; err != nil {
+	if err := db.Where("lower(email) = ?", email).First(&user).Error; err != nil {
 		return nil, err
 	}
 	return &user, nil
@@ -95,7 +139,7 @@ func GetUserByEmail(db *gorm.DB, email string) (*User, error) {
 // CreateUser creates a new user
 func CreateUser(db *gorm.DB, email, name, googleID, pictureURL string) (*User, error) {
 	user := &User{
-		Email:      email,
+		Email:      NormalizeEmail(email),
 		Name:       name,
 		GoogleID:   googleID,
 		PictureURL: pictureURL,
@@ -124,7 +168,7 @@ func CreateOrUpdateUserFromGoogle(db *gorm.DB, googleID, email, name, pictureURL
 		// Using targeted Updates instead of Save to avoid overwriting
 		// other fields like is_active (which would reactivate deactivated users).
 		if err := db.Model(&user).Updates(map[string]interface{}{
-			"email":       email,
+			"email":       NormalizeEmail(email),
 			"name":        name,
 			"picture_url": pictureURL,
 		}).Error; err != nil {
@@ -137,7 +181,7 @@ func CreateOrUpdateUserFromGoogle(db *gorm.DB, googleID, email, name, pictureURL
 
 	// Create new user
 	user = User{
-		Email:      email,
+		Email:      NormalizeEmail(email),
 		Name:       name,
 		GoogleID:   googleID,
 		PictureURL: pictureURL,
@@ -156,6 +200,24 @@ func AcceptTerms(db *gorm.DB, userID uint) error {
 		Update("terms_accepted_at", now).Error
 }
 
+// RevokeUserTokens invalidates every token issued to the user so far
+func RevokeUserTokens(db *gorm.DB, userID uint) error {
+	return db.Model(&User{}).Where("id = ?", userID).
+		Update("token_generation", gorm.Expr("token_generation + 1")).Error
+}
+
+// SetUserTrusted flags or unflags a user as trusted
+func SetUserTrusted(db *gorm.DB, userID uint, trusted bool) error {
+	result := db.Model(&User{}).Where("id = ?", userID).Update("is_trusted", trusted)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
 // CreateOrUpdateUserFromGitHub creates or updates a user from GitHub OAuth data.
 // Only matches by provider ID to prevent account takeover via email matching.
 func CreateOrUpdateUserFromGitHub(db *gorm.DB, gitHubID, email, name, pictureURL string) (*User, error) {
@@ -171,7 +233,7 @@ func CreateOrUpdateUserFromGitHub(db *gorm.DB, gitHubID, email, name, pictureURL
 		// Using targeted Updates instead of Save to avoid overwriting
 		// other fields like is_active (which would reactivate deactivated users).
 		if err := db.Model(&user).Updates(map[string]interface{}{
-			"email":       email,
+			"email":       NormalizeEmail(email),
 			"name":        name,
 			"picture_url": pictureURL,
 		}).Error; err != nil {
@@ -184,7 +246,7 @@ func CreateOrUpdateUserFromGitHub(db *gorm.DB, gitHubID, email, name, pictureURL
 
 	// Create new user
 	user = User{
-		Email:      email,
+		Email:      NormalizeEmail(email),
 		Name:       name,
 		GitHubID:   gitHubID,
 		PictureURL: pictureURL,
@@ -14,3 +14,19 @@ func TestUser_IsActive_Default(t *testing.T) {
 		t.Error("user should be active by default")
 	}
 }
+
+func TestNormalizeEmail(t *testing.T) {
+	tests := map[string]string{
+		"jane@example.com":          "jane@example.com",
+		"Jane.Doe@Example.com":      "jane.doe@example.com",
+		"  jane@example.com\n":      "jane@example.com",
+		"":                          "",
+		"   ":                       "",
+		"JANE.DOE+cfp@EXAMPLE.COM ": "jane.doe+cfp@example.com",
+	}
+	for in, want := range tests {
+		if got := NormalizeEmail(in); got != want {
+			t.Errorf("NormalizeEmail(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,333 @@
+// Package sanitize cleans user-written HTML, such as event descriptions, for
+// rendering outside the SPA: in emails, feeds, widgets and meta tags.
+// Content is stored as written and sanitized when it is rendered.
+package sanitize
+
+import (
+	"html"
+	"net/url"
+	"strings"
+)
+
+// Policy is an allowlist of elements and attributes. Anything not allowed is
+// dropped, keeping its text content, except for elements like script and
+// style whose content is dropped with them.
+type Policy struct {
+	elements        map[string]map[string]bool // element -> allowed attributes
+	urlSchemes      map[string]bool
+	requireNoFollow bool
+}
+
+// urlAttrs are attributes whose value is a URL and must use an allowed scheme
+var urlAttrs = map[string]bool{"href": true, "src": true, "cite": true, "action": true}
+
+// skipContentElements are dropped together with everything inside them
+var skipContentElements = map[string]bool{
+	"script": true, "style": true, "iframe": true, "noscript": true, "object": true,
+	"template": true, "textarea": true, "title": true, "xmp": true, "noembed": true,
+}
+
+// voidElements have no end tag
+var voidElements = map[string]bool{"br": true, "hr": true, "img": true, "wbr": true}
+
+// NewPolicy returns a policy that allows no elements, so Sanitize keeps only
+// the escaped text
+func NewPolicy() *Policy {
+	return &Policy{
+		elements:   make(map[string]map[string]bool),
+		urlSchemes: make(map[string]bool),
+	}
+}
+
+// AllowElements allows the given elements without attributes
+func (p *Policy) AllowElements(names ...string) *Policy {
+	for _, name := range names {
+		name = strings.ToLower(name)
+		if p.elements[name] == nil {
+			p.elements[name] = make(map[string]bool)
+		}
+	}
+	return p
+}
+
+// AllowAttrs allows the given attributes on element, allowing the element too
+func (p *Policy) AllowAttrs(element string, attrs ...string) *Policy {
+	p.AllowElements(element)
+	for _, attr := range attrs {
+		p.elements[strings.ToLower(element)][strings.ToLower(attr)] = true
+	}
+	return p
+}
+
+// AllowURLSchemes sets which schemes URL attributes may use. Relative URLs
+// are always allowed.
+func (p *Policy) AllowURLSchemes(schemes ...string) *Policy {
+	for _, s := range schemes {
+		p.urlSchemes[strings.ToLower(s)] = true
+	}
+	return p
+}
+
+// RequireNoFollowOnLinks adds rel="nofollow noopener" to links with an href
+func (p *Policy) RequireNoFollowOnLinks() *Policy {
+	p.requireNoFollow = true
+	return p
+}
+
+// DescriptionPolicy allows the formatting organizers use in descriptions:
+// paragraphs, emphasis, lists, headings, quotes, code and http(s)/mailto
+// links. Images are not allowed, as they would load in readers' emails.
+func DescriptionPolicy() *Policy {
+	return NewPolicy().
+		AllowElements("p", "br", "hr", "strong", "b", "em", "i", "u", "s", "del",
+			"code", "pre", "blockquote", "ul", "ol", "li", "h1", "h2", "h3", "h4", "h5", "h6").
+		AllowAttrs("a", "href", "title").
+		AllowURLSchemes("http", "https", "mailto").
+		RequireNoFollowOnLinks()
+}
+
+var descriptionPolicy = DescriptionPolicy()
+
+// HTML sanitizes s with DescriptionPolicy
+func HTML(s string) string {
+	return descriptionPolicy.Sanitize(s)
+}
+
+// Text strips all markup from s and returns the unescaped text, for plain
+// text contexts like meta tags and email subjects that escape on their own
+func Text(s string) string {
+	var b strings.Builder
+	walk(s, func(text string) {
+		b.WriteString(html.UnescapeString(text))
+	}, nil)
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+// Sanitize returns s with everything the policy does not allow removed.
+// Text is re-escaped, so the result is safe to insert into an HTML document.
+func (p *Policy) Sanitize(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+	walk(s, func(text string) {
+		b.WriteString(html.EscapeString(html.UnescapeString(text)))
+	}, func(t tag) {
+		allowed, ok := p.elements[t.name]
+		if !ok {
+			return
+		}
+		if t.end {
+			if !voidElements[t.name] {
+				b.WriteString("</" + t.name + ">")
+			}
+			return
+		}
+		b.WriteString("<" + t.name)
+		hasHref := false
+		for _, a := range t.attrs {
+			if !allowed[a.name] || a.name == "rel" && p.requireNoFollow {
+				continue
+			}
+			if urlAttrs[a.name] && !p.allowedURL(a.value) {
+				continue
+			}
+			if a.name == "href" {
+				hasHref = true
+			}
+			b.WriteString(" " + a.name + `="` + html.EscapeString(a.value) + `"`)
+		}
+		if p.requireNoFollow && t.name == "a" && hasHref {
+			b.WriteString(` rel="nofollow noopener"`)
+		}
+		b.WriteString(">")
+	})
+	return b.String()
+}
+
+// allowedURL reports whether a URL attribute value is relative or uses an
+// allowed scheme. Browsers ignore whitespace and control characters inside
+// schemes ("java\tscript:"), so those are removed before parsing.
+func (p *Policy) allowedURL(value string) bool {
+	cleaned := strings.Map(func(r rune) rune {
+		if r <= ' ' || r == 0x7f {
+			return -1
+		}
+		return r
+	}, value)
+	u, err := url.Parse(cleaned)
+	if err != nil {
+		return false
+	}
+	if u.Scheme == "" {
+		// A colon before any slash would make browsers read a scheme
+		if i := strings.IndexByte(cleaned, ':'); i >= 0 && !strings.ContainsAny(cleaned[:i], "/?#") {
+			return false
+		}
+		return true
+	}
+	return p.urlSchemes[strings.ToLower(u.Scheme)]
+}
+
+type attr struct {
+	name, value string
+}
+
+type tag struct {
+	name  string
+	end   bool
+	attrs []attr
+}
+
+// walk splits s into text and tags. Comments, doctypes and processing
+// instructions are dropped, as are skipContentElements with their content.
+// Attribute values are passed unescaped. onTag may be nil.
+func walk(s string, onText func(string), onTag func(tag)) {
+	for len(s) > 0 {
+		i := strings.IndexByte(s, '<')
+		if i < 0 {
+			onText(s)
+			return
+		}
+		if i > 0 {
+			onText(s[:i])
+			s = s[i:]
+		}
+
+		switch {
+		case strings.HasPrefix(s, "<!--"):
+			end := strings.Index(s[4:], "-->")
+			if end < 0 {
+				return
+			}
+			s = s[4+end+3:]
+			continue
+		case len(s) > 1 && (s[1] == '!' || s[1] == '?'):
+			end := strings.IndexByte(s, '>')
+			if end < 0 {
+				return
+			}
+			s = s[end+1:]
+			continue
+		}
+
+		t, rest, ok := parseTag(s)
+		if !ok {
+			// A lone "<" is text
+			onText("<")
+			s = s[1:]
+			continue
+		}
+		s = rest
+		if skipContentElements[t.name] {
+			if !t.end {
+				s = skipPastEndTag(s, t.name)
+			}
+			continue
+		}
+		if onTag != nil {
+			onTag(t)
+		}
+	}
+}
+
+// parseTag parses the tag at the start of s, which begins with "<"
+func parseTag(s string) (tag, string, bool) {
+	var t tag
+	i := 1
+	if i < len(s) && s[i] == '/' {
+		t.end = true
+		i++
+	}
+	start := i
+	for i < len(s) && isNameByte(s[i], i == start) {
+		i++
+	}
+	if i == start {
+		return t, s, false
+	}
+	t.name = strings.ToLower(s[start:i])
+
+	for {
+		for i < len(s) && (isSpace(s[i]) || s[i] == '/') {
+			i++
+		}
+		if i >= len(s) {
+			// Unterminated tag: drop it and the rest
+			return tag{}, "", true
+		}
+		if s[i] == '>' {
+			return t, s[i+1:], true
+		}
+
+		nameStart := i
+		for i < len(s) && !isSpace(s[i]) && s[i] != '/' && s[i] != '>' && s[i] != '=' {
+			i++
+		}
+		a := attr{name: strings.ToLower(s[nameStart:i])}
+		for i < len(s) && isSpace(s[i]) {
+			i++
+		}
+		if i < len(s) && s[i] == '=' {
+			i++
+			for i < len(s) && isSpace(s[i]) {
+				i++
+			}
+			if i < len(s) && (s[i] == '"' || s[i] == '\'') {
+				q := s[i]
+				end := strings.IndexByte(s[i+1:], q)
+				if end < 0 {
+					return tag{}, "", true
+				}
+				a.value = s[i+1 : i+1+end]
+				i += end + 2
+			} else {
+				valStart := i
+				for i < len(s) && !isSpace(s[i]) && s[i] != '>' {
+					i++
+				}
+				a.value = s[valStart:i]
+			}
+			a.value = html.UnescapeString(a.value)
+		}
+		if !t.end && a.name != "" {
+			t.attrs = append(t.attrs, a)
+		}
+	}
+}
+
+// skipPastEndTag returns s after the end tag of name, or "" when it is missing
+func skipPastEndTag(s, name string) string {
+	// Only ASCII is lowercased, so offsets into lower match s
+	b := []byte(s)
+	for i, c := range b {
+		if 'A' <= c && c <= 'Z' {
+			b[i] = c + 'a' - 'A'
+		}
+	}
+	lower := string(b)
+	for from := 0; ; {
+		i := strings.Index(lower[from:], "</"+name)
+		if i < 0 {
+			return ""
+		}
+		i += from + 2 + len(name)
+		if i == len(s) || isSpace(s[i]) || s[i] == '>' || s[i] == '/' {
+			end := strings.IndexByte(s[i:], '>')
+			if end < 0 {
+				return ""
+			}
+			return s[i+end+1:]
+		}
+		from = i
+	}
+}
+
+func isNameByte(c byte, first bool) bool {
+	if 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' {
+		return true
+	}
+	return !first && ('0' <= c && c <= '9' || c == '-')
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f'
+}
@@ -0,0 +1,122 @@
+package sanitize
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHTML(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"plain text", "Go conference in Berlin", "Go conference in Berlin"},
+		{"formatting kept", "<p>Talks on <strong>Go</strong> &amp; <em>cloud</em></p>", "<p>Talks on <strong>Go</strong> &amp; <em>cloud</em></p>"},
+		{"lists kept", "<ul><li>one</li><li>two</li></ul>", "<ul><li>one</li><li>two</li></ul>"},
+		{"tag names lowercased", "<P>hi</P><BR/>", "<p>hi</p><br>"},
+		{"lone angle brackets escaped", "1 < 2 > 0", "1 &lt; 2 &gt; 0"},
+
+		// Script and style tags go with their content
+		{"script tag", "<script>alert('XSS')</script>hello", "hello"},
+		{"script mixed case", "<ScRiPt>alert(1)</sCrIpT>ok", "ok"},
+		{"script with attributes", `<script src="https://evil.example/x.js"></script>`, ""},
+		{"script end tag in string", `<script>var s = "</scriptx>"; alert(1)</script>after`, "after"},
+		{"unterminated script", "before<script>alert(1)", "before"},
+		{"style tag", "<style>body{display:none}</style>text", "text"},
+		{"iframe", `<iframe src="https://evil.example"></iframe>x`, "x"},
+		{"comment", "a<!-- <script>alert(1)</script> -->b", "ab"},
+		{"doctype", "<!DOCTYPE html>text", "text"},
+
+		// Disallowed elements are dropped, keeping their text
+		{"img onerror", `<img src=x onerror=alert('XSS')>`, ""},
+		{"svg onload", `<svg onload=alert('XSS')>`, ""},
+		{"div kept text", "<div>inside</div>", "inside"},
+		{"nested unknown", "<span><font>t</font></span>", "t"},
+
+		// Event handlers and other attributes are stripped
+		{"onclick", `<p onclick="alert(1)">x</p>`, "<p>x</p>"},
+		{"style attribute", `<p style="background:url(javascript:alert(1))">x</p>`, "<p>x</p>"},
+		{"onmouseover on link", `<a href="https://example.com" onmouseover="alert(1)">x</a>`, `<a href="https://example.com" rel="nofollow noopener">x</a>`},
+
+		// javascript: and other schemes are removed from links
+		{"https link", `<a href="https://example.com/cfp?a=1&amp;b=2">CFP</a>`, `<a href="https://example.com/cfp?a=1&amp;b=2" rel="nofollow noopener">CFP</a>`},
+		{"mailto link", `<a href="mailto:cfp@example.com">mail</a>`, `<a href="mailto:cfp@example.com" rel="nofollow noopener">mail</a>`},
+		{"relative link", `<a href="/events/gophercon">x</a>`, `<a href="/events/gophercon" rel="nofollow noopener">x</a>`},
+		{"javascript url", `<a href="javascript:alert('XSS')">Click me</a>`, "<a>Click me</a>"},
+		{"javascript url uppercase", `<a href="JaVaScRiPt:alert(1)">x</a>`, "<a>x</a>"},
+		{"javascript url with whitespace", "<a href=\" java\tscript:alert(1)\">x</a>", "<a>x</a>"},
+		{"javascript url entity encoded", `<a href="&#106;avascript:alert(1)">x</a>`, "<a>x</a>"},
+		{"javascript url hex entities", `<a href="&#x6A;&#x61;&#x76;&#x61;script&#x3A;alert(1)">x</a>`, "<a>x</a>"},
+		{"data url", `<a href="data:text/html;base64,PHNjcmlwdD5hbGVydCgxKTwvc2NyaXB0Pg==">x</a>`, "<a>x</a>"},
+		{"vbscript url", `<a href='vbscript:msgbox(1)'>x</a>`, "<a>x</a>"},
+		{"rel cannot be overridden", `<a href="https://example.com" rel="opener">x</a>`, `<a href="https://example.com" rel="nofollow noopener">x</a>`},
+
+		// Attribute quoting cannot break out of the tag
+		{"quote in attribute", `<a href="https://example.com/&quot;onclick=&quot;alert(1)" title='x"y'>x</a>`, `<a href="https://example.com/&#34;onclick=&#34;alert(1)" title="x&#34;y" rel="nofollow noopener">x</a>`},
+		{"unterminated attribute", `<a href="https://example.com>x`, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := HTML(tt.in); got != tt.want {
+				t.Errorf("HTML(%q)\n got: %q\nwant: %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewPolicy_TextOnly(t *testing.T) {
+	got := NewPolicy().Sanitize(`<p>Hello <b>world</b></p><script>alert(1)</script>`)
+	if got != "Hello world" {
+		t.Errorf("expected tags stripped, got %q", got)
+	}
+}
+
+func TestText(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"<p>Talks on <strong>Go</strong> &amp; cloud</p>", "Talks on Go & cloud"},
+		{"<script>alert(1)</script>Hello\n\n  world", "Hello world"},
+		{`<img src=x onerror=alert(1)>`, ""},
+		{"1 < 2", "1 < 2"},
+	}
+	for _, tt := range tests {
+		if got := Text(tt.in); got != tt.want {
+			t.Errorf("Text(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+// FuzzHTML checks that sanitized output, parsed again, only has allowed
+// elements and attributes and never a javascript: URL
+func FuzzHTML(f *testing.F) {
+	f.Add(`<script>alert(1)</script>`)
+	f.Add(`<a href="javascript:alert(1)">x</a>`)
+	f.Add(`<p onclick="x">y</p>`)
+	f.Add(`<<script>script>alert(1)<</script>/script>`)
+
+	policy := DescriptionPolicy()
+	f.Fuzz(func(t *testing.T, in string) {
+		out := HTML(in)
+		walk(out, func(string) {}, func(tg tag) {
+			allowed, ok := policy.elements[tg.name]
+			if !ok {
+				t.Fatalf("element %q in output %q", tg.name, out)
+			}
+			for _, a := range tg.attrs {
+				if !allowed[a.name] && a.name != "rel" {
+					t.Fatalf("attribute %q in output %q", a.name, out)
+				}
+				if a.name == "href" && !policy.allowedURL(a.value) {
+					t.Fatalf("URL %q in output %q", a.value, out)
+				}
+			}
+		})
+		if strings.Contains(strings.ToLower(out), "<script") {
+			t.Fatalf("script tag in output %q", out)
+		}
+	})
+}
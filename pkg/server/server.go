@@ -1,13 +1,18 @@
 package server
 
 import (
+	"errors"
 	"net/http"
 	"os"
+	"time"
 
 	"github.com/sreday/cfp.ninja/pkg/api"
 	"github.com/sreday/cfp.ninja/pkg/config"
 	"github.com/sreday/cfp.ninja/pkg/database"
 	"github.com/sreday/cfp.ninja/pkg/email"
+	"github.com/sreday/cfp.ninja/pkg/geocode"
+	"github.com/sreday/cfp.ninja/pkg/geoip"
+	"github.com/sreday/cfp.ninja/pkg/logging"
 	"github.com/sreday/cfp.ninja/pkg/models"
 	"github.com/stripe/stripe-go/v82"
 )
@@ -20,6 +25,7 @@ func SetupServer(staticHandler http.Handler) (*config.Config, http.Handler, erro
 	if err != nil {
 		return nil, nil, err
 	}
+	logger := logging.Module(cfg.Logger, logging.Server)
 
 	// Initialize database
 	db, err := database.InitDB(cfg.DatabaseURL)
@@ -30,11 +36,25 @@ func SetupServer(staticHandler http.Handler) (*config.Config, http.Handler, erro
 
 	// Auto-migrate if enabled
 	if cfg.AutoMigrate {
-		cfg.Logger.Info("running database migrations")
+		logger.Info("running database migrations")
 		if err := db.AutoMigrate(
 			&models.User{},
 			&models.Event{},
 			&models.Proposal{},
+			&models.ProposalReview{},
+			&models.ProposalLock{},
+			&models.DeletedProposal{},
+			&models.ImpersonationLog{},
+			&models.EventPreviewLink{},
+			&models.SpeakerEmailSend{},
+			&models.APIKey{},
+			&models.EventSlugHistory{},
+			&models.LoginEvent{},
+			&models.CFPInterest{},
+			&models.DigestRun{},
+			&models.DigestDelivery{},
+			&models.EmailLog{},
+			&models.EventActivity{},
 		); err != nil {
 			return nil, nil, err
 		}
@@ -42,6 +62,34 @@ func SetupServer(staticHandler http.Handler) (*config.Config, http.Handler, erro
 		if err := models.CreatePartialUniqueIndexes(db); err != nil {
 			return nil, nil, err
 		}
+		// Emails are matched normalized, so stored ones must be too
+		if err := models.NormalizeUserEmails(db); err != nil {
+			return nil, nil, err
+		}
+		// Tags are filtered by exact match, so stored ones must be normalized
+		if err := models.NormalizeEventTags(db); err != nil {
+			return nil, nil, err
+		}
+		// Slug conflicts are detected by the unique index, so it must exist
+		if err := models.EnsureEventSlugIndex(db); err != nil {
+			return nil, nil, err
+		}
+		// Deleting a user must not leave events pointing at them
+		if err := models.EnsureEventCreatorForeignKey(db); err != nil {
+			return nil, nil, err
+		}
+		// Indexes for the public events listing
+		if err := models.EnsureEventListingIndexes(db); err != nil {
+			return nil, nil, err
+		}
+		// Resolve ISO country codes for events created before country_code existed
+		if err := models.BackfillCountryCodes(db); err != nil {
+			return nil, nil, err
+		}
+		// Derive attendance_mode from is_online for events created before hybrid events existed
+		if err := models.BackfillAttendanceModes(db); err != nil {
+			return nil, nil, err
+		}
 	}
 
 	// Set Stripe API key once at startup (not per-request) to avoid data races
@@ -49,20 +97,67 @@ func SetupServer(staticHandler http.Handler) (*config.Config, http.Handler, erro
 		stripe.Key = cfg.StripeSecretKey
 	}
 
-	// Initialise email sender
+	// Initialise email sender, recording every email in the send log
+	emailLogger := logging.Module(cfg.Logger, logging.Email)
+	var sender email.Sender
 	if cfg.ResendAPIKey != "" {
-		cfg.EmailSender = email.NewResendSender(cfg.ResendAPIKey)
-		cfg.Logger.Info("email notifications enabled (Resend)")
+		sender = email.NewResendSender(cfg.ResendAPIKey)
+		logger.Info("email notifications enabled (Resend)")
+	} else {
+		sender = &email.NoopSender{Logger: emailLogger}
+	}
+	cfg.EmailSender = &email.LoggingSender{Next: sender, DB: cfg.DB, Logger: emailLogger}
+	// Fail at startup rather than at the first send if an override is broken
+	if err := email.LoadTemplates(cfg.EmailTemplatesDir); err != nil {
+		return nil, nil, err
+	}
+	if cfg.EmailTemplatesDir != "" {
+		logger.Info("email template overrides loaded", "dir", cfg.EmailTemplatesDir)
+	}
+
+	// Initialise geocoder
+	if cfg.GeocoderProvider == "nominatim" {
+		nominatim := geocode.NewNominatim(cfg.NominatimURL, "cfp.ninja (+"+cfg.BaseURL+")")
+		cfg.Geocoder = geocode.NewCache(geocode.NewRateLimit(nominatim, time.Second))
+		logger.Info("geocoding enabled (Nominatim)")
+	} else {
+		cfg.Geocoder = geocode.NoopGeocoder{}
+	}
+
+	// Initialise login country lookups
+	if cfg.GeoIPURL != "" {
+		cfg.GeoIP = geoip.NewHTTPLocator(cfg.GeoIPURL)
+		logger.Info("login country lookups enabled")
 	} else {
-		cfg.EmailSender = &email.NoopSender{Logger: cfg.Logger}
+		cfg.GeoIP = geoip.NoopLocator{}
+	}
+
+	handler, err := NewHandler(cfg, staticHandler)
+	if err != nil {
+		return nil, nil, err
 	}
+	return cfg, handler, nil
+}
 
+// NewHandler registers the routes and the SPA fallback on a new mux and wraps
+// it with the middleware, serving it under cfg.BasePath.
+// The staticHandler parameter is optional, as for SetupServer.
+func NewHandler(cfg *config.Config, staticHandler http.Handler) (http.Handler, error) {
 	// Create mux and register routes
 	mux := http.NewServeMux()
 	RegisterRoutes(cfg, mux)
 
 	// Fallback handler for SPA routing (only if staticHandler provided)
 	if staticHandler != nil {
+		if cfg.BasePath != "" {
+			setter, ok := staticHandler.(interface{ SetBasePath(string) error })
+			if !ok {
+				return nil, errors.New("static handler can't be served under BASE_PATH")
+			}
+			if err := setter.SetBasePath(cfg.BasePath); err != nil {
+				return nil, err
+			}
+		}
 		mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
 			// If it's an API route, return 404 (API routes are already registered)
 			if len(r.URL.Path) >= 5 && r.URL.Path[:5] == "/api/" {
@@ -73,15 +168,19 @@ func SetupServer(staticHandler http.Handler) (*config.Config, http.Handler, erro
 		})
 	}
 
-	// Wrap with security headers, request ID, compression, and request logging.
-	// Order (outermost first): RequestID → RequestLogging → SecurityHeaders → Gzip → mux
+	// Wrap with security headers, request ID, CORS preflights, compression,
+	// request logging and the per-request cache.
+	// Order (outermost first): RequestID → RequestLogging → SecurityHeaders → BasePath → CorsPreflight → Gzip → RequestCache → mux
 	var handler http.Handler = mux
+	handler = api.RequestCache(handler)
 	handler = api.GzipHandler(handler)
+	handler = api.CorsPreflight(cfg, handler)
+	handler = api.BasePath(cfg.BasePath, handler)
 	handler = api.SecurityHeaders(handler)
 	handler = api.RequestLogging(cfg.Logger, handler)
 	handler = api.RequestID(handler)
 
-	return cfg, handler, nil
+	return handler, nil
 }
 
 // RegisterRoutes registers all API routes on the given mux.
@@ -89,15 +188,21 @@ func SetupServer(staticHandler http.Handler) (*config.Config, http.Handler, erro
 func RegisterRoutes(cfg *config.Config, mux *http.ServeMux) {
 	// Rate limiters for different endpoint groups.
 	// In test mode (GO_TEST=1), use permissive limits to avoid flaky tests.
-	var authLimiter, writeLimiter, readLimiter *api.RateLimiter
+	var authLimiter, writeLimiter, readLimiter, apiKeyLimiter, scrapeLimiter, exportLimiter *api.RateLimiter
 	if os.Getenv("GO_TEST") == "1" {
 		authLimiter = api.NewRateLimiter(1000, 10000, cfg.TrustedProxies)
 		writeLimiter = api.NewRateLimiter(1000, 10000, cfg.TrustedProxies)
 		readLimiter = api.NewRateLimiter(1000, 10000, cfg.TrustedProxies)
+		apiKeyLimiter = api.NewRateLimiter(1000, 10000, cfg.TrustedProxies)
+		scrapeLimiter = api.NewRateLimiter(1000, 10000, cfg.TrustedProxies)
+		exportLimiter = api.NewRateLimiter(1000, 10000, cfg.TrustedProxies)
 	} else {
 		authLimiter = api.NewRateLimiter(5, 10, cfg.TrustedProxies)     // 5 req/s, burst 10 (OAuth)
 		writeLimiter = api.NewRateLimiter(10, 20, cfg.TrustedProxies)   // 10 req/s, burst 20 (create/update)
 		readLimiter = api.NewRateLimiter(30, 60, cfg.TrustedProxies)    // 30 req/s, burst 60 (public reads)
+		apiKeyLimiter = api.NewRateLimiter(100, 200, cfg.TrustedProxies) // 100 req/s, burst 200 per API key (public API)
+		scrapeLimiter = api.NewRateLimiter(0.2, 5, cfg.TrustedProxies) // 1 req per 5s, burst 5 (fetches other websites)
+		exportLimiter = api.NewRateLimiter(1.0/60, 3, cfg.TrustedProxies) // 1 req per minute, burst 3 (full event exports)
 	}
 
 	// Health check (no auth, no CORS, no rate limiting)
@@ -105,14 +210,29 @@ func RegisterRoutes(cfg *config.Config, mux *http.ServeMux) {
 
 	// Public endpoints (no auth, with CORS, rate limited)
 	mux.HandleFunc("/api/v0/config", api.CorsHandler(cfg, api.ConfigHandler(cfg)))
-	mux.HandleFunc("GET /api/v0/stats", api.CorsHandler(cfg, readLimiter.Middleware(api.GetStatsHandler(cfg))))
+	mux.HandleFunc("GET /api/v0/version", api.CorsHandler(cfg, readLimiter.Middleware(api.VersionHandler(cfg))))
+	mux.HandleFunc("GET /api/v0/stats", api.CorsHandler(cfg, readLimiter.Middleware(api.OptionalAuthHandler(cfg, api.GetStatsHandler(cfg)))))
 	mux.HandleFunc("GET /api/v0/stats/proposals", api.AuthCorsHandler(cfg, readLimiter.Middleware(api.GetProposalStatsHandler(cfg))))
-	mux.HandleFunc("OPTIONS /api/v0/stats/proposals", api.CorsHandler(cfg, func(w http.ResponseWriter, r *http.Request) {}))
-	mux.HandleFunc("GET /api/v0/countries", api.CorsHandler(cfg, readLimiter.Middleware(api.GetCountriesHandler(cfg))))
+	mux.HandleFunc("GET /api/v0/countries", api.CorsHandler(cfg, readLimiter.Middleware(api.OptionalAuthHandler(cfg, api.GetCountriesHandler(cfg)))))
+	mux.HandleFunc("GET /api/v0/tags/suggest", api.CorsHandler(cfg, readLimiter.Middleware(api.SuggestTagsHandler(cfg))))
 	mux.HandleFunc("GET /api/v0/events", api.CorsHandler(cfg, readLimiter.Middleware(api.ListEventsHandler(cfg))))
 	mux.HandleFunc("POST /api/v0/events", api.CorsHandler(cfg, writeLimiter.Middleware(api.AuthHandler(cfg, api.CreateEventHandler(cfg)))))
-	mux.HandleFunc("OPTIONS /api/v0/events", api.CorsHandler(cfg, func(w http.ResponseWriter, r *http.Request) {}))
-	mux.HandleFunc("GET /api/v0/e/{slug}", api.CorsHandler(cfg, readLimiter.Middleware(api.GetEventBySlugHandler(cfg))))
+	mux.HandleFunc("POST /api/v0/events/scrape-preview", api.CorsHandler(cfg, scrapeLimiter.Middleware(api.AuthHandler(cfg, api.ScrapeEventPreviewHandler(cfg)))))
+	// Public API for third parties (API key, rate limited per key)
+	mux.HandleFunc("GET /api/v0/public/events", api.CorsHandler(cfg, api.APIKeyHandler(cfg, apiKeyLimiter, api.ListPublicEventsHandler(cfg))))
+
+	mux.HandleFunc("GET /api/v0/e/{slug}", api.CorsHandler(cfg, readLimiter.Middleware(api.OptionalAuthHandler(cfg, api.GetEventBySlugHandler(cfg)))))
+	// /api/v0/events/by-slug/{slug} would conflict with /api/v0/events/{id}/cfp-status
+	mux.HandleFunc("PUT /api/v0/e/{slug}", api.CorsHandler(cfg, writeLimiter.Middleware(api.AuthHandler(cfg, api.ApplyEventHandler(cfg)))))
+	mux.HandleFunc("POST /api/v0/e/{slug}/notify-me", api.AuthCorsHandler(cfg, writeLimiter.Middleware(api.NotifyMeHandler(cfg))))
+	mux.HandleFunc("DELETE /api/v0/e/{slug}/notify-me", api.AuthCorsHandler(cfg, writeLimiter.Middleware(api.RemoveNotifyMeHandler(cfg))))
+	// One-click unsubscribe from notify-me emails; the token is the credential
+	mux.HandleFunc("POST /api/v0/notify-me/unsubscribe", api.CorsHandler(cfg, writeLimiter.Middleware(api.UnsubscribeNotifyMeHandler(cfg))))
+
+	// API documentation (OpenAPI document and Swagger UI)
+	mux.HandleFunc("GET /api/v0/openapi.json", api.CorsHandler(cfg, readLimiter.Middleware(api.OpenAPIHandler(cfg))))
+	mux.HandleFunc("GET /api/v0/docs", readLimiter.Middleware(api.APIDocsHandler(cfg)))
+	mux.HandleFunc("GET /api/v0/docs/docs.js", readLimiter.Middleware(api.APIDocsScriptHandler(cfg)))
 
 	// Auth endpoints - Google OAuth (rate limited)
 	mux.HandleFunc("/api/v0/auth/google", api.CorsHandler(cfg, authLimiter.Middleware(api.GoogleAuthHandler(cfg))))
@@ -124,11 +244,12 @@ func RegisterRoutes(cfg *config.Config, mux *http.ServeMux) {
 	mux.HandleFunc("/api/v0/auth/logout", api.CorsHandler(cfg, authLimiter.Middleware(api.LogoutHandler(cfg))))
 	mux.HandleFunc("/api/v0/auth/me", api.AuthCorsHandler(cfg, api.GetMeHandler(cfg)))
 	mux.HandleFunc("POST /api/v0/auth/accept-terms", api.AuthCorsHandler(cfg, writeLimiter.Middleware(api.AcceptTermsHandler(cfg))))
-	mux.HandleFunc("OPTIONS /api/v0/auth/accept-terms", api.CorsHandler(cfg, func(w http.ResponseWriter, r *http.Request) {}))
 	mux.HandleFunc("GET /api/v0/me/events", api.AuthCorsHandler(cfg, api.GetMyEventsHandler(cfg)))
-	mux.HandleFunc("OPTIONS /api/v0/me/events", api.CorsHandler(cfg, func(w http.ResponseWriter, r *http.Request) {}))
+	mux.HandleFunc("POST /api/v0/me/logout-all", api.AuthCorsHandler(cfg, writeLimiter.Middleware(api.LogoutAllHandler(cfg))))
+	mux.HandleFunc("GET /api/v0/me/dashboard", api.AuthCorsHandler(cfg, api.GetMyDashboardHandler(cfg)))
+	mux.HandleFunc("GET /api/v0/me/logins", api.AuthCorsHandler(cfg, api.GetMyLoginsHandler(cfg)))
+	mux.HandleFunc("GET /api/v0/me/digest/preview", api.AuthCorsHandler(cfg, api.GetMyDigestPreviewHandler(cfg)))
 	mux.HandleFunc("GET /api/v0/me/events/{id}", api.AuthCorsHandler(cfg, api.GetEventForOrganizerHandler(cfg)))
-	mux.HandleFunc("OPTIONS /api/v0/me/events/{id}", api.CorsHandler(cfg, func(w http.ResponseWriter, r *http.Request) {}))
 
 	// LinkedIn profile check (auth required, rate limited)
 	mux.HandleFunc("/api/v0/check-linkedin", api.AuthCorsHandler(cfg, readLimiter.Middleware(api.CheckLinkedInHandler(cfg))))
@@ -136,54 +257,83 @@ func RegisterRoutes(cfg *config.Config, mux *http.ServeMux) {
 	// Stripe webhook endpoint (no auth, no CORS - server-to-server from Stripe)
 	mux.HandleFunc("POST /api/v0/webhooks/stripe", writeLimiter.Middleware(api.StripeWebhookHandler(cfg)))
 
-	// cors is a shorthand for the common CORS preflight handler
-	cors := func(w http.ResponseWriter, r *http.Request) {}
-
 	// Event endpoints (with path parameters)
-	mux.HandleFunc("GET /api/v0/events/{id}", api.CorsHandler(cfg, api.GetEventByIDHandler(cfg)))
+	mux.HandleFunc("GET /api/v0/events/{id}", api.CorsHandler(cfg, api.OptionalAuthHandler(cfg, api.GetEventByIDHandler(cfg))))
 	mux.HandleFunc("PUT /api/v0/events/{id}", api.CorsHandler(cfg, writeLimiter.Middleware(api.AuthHandler(cfg, api.UpdateEventHandler(cfg)))))
 	mux.HandleFunc("DELETE /api/v0/events/{id}", api.CorsHandler(cfg, writeLimiter.Middleware(api.AuthHandler(cfg, api.DeleteEventHandler(cfg)))))
-	mux.HandleFunc("OPTIONS /api/v0/events/{id}", api.CorsHandler(cfg, cors))
 
 	mux.HandleFunc("PUT /api/v0/events/{id}/cfp-status", api.CorsHandler(cfg, writeLimiter.Middleware(api.AuthHandler(cfg, api.UpdateCFPStatusHandler(cfg)))))
-	mux.HandleFunc("OPTIONS /api/v0/events/{id}/cfp-status", api.CorsHandler(cfg, cors))
 
 	mux.HandleFunc("POST /api/v0/events/{id}/checkout", api.CorsHandler(cfg, writeLimiter.Middleware(api.AuthHandler(cfg, api.CreateEventCheckoutHandler(cfg)))))
-	mux.HandleFunc("OPTIONS /api/v0/events/{id}/checkout", api.CorsHandler(cfg, cors))
+	mux.HandleFunc("POST /api/v0/events/{id}/accept-terms", api.CorsHandler(cfg, writeLimiter.Middleware(api.AuthHandler(cfg, api.AcceptListingTermsHandler(cfg)))))
 
 	mux.HandleFunc("GET /api/v0/events/{id}/proposals", api.CorsHandler(cfg, api.AuthHandler(cfg, api.GetEventProposalsHandler(cfg))))
 	mux.HandleFunc("POST /api/v0/events/{id}/proposals", api.CorsHandler(cfg, writeLimiter.Middleware(api.AuthHandler(cfg, api.CreateProposalHandler(cfg)))))
-	mux.HandleFunc("OPTIONS /api/v0/events/{id}/proposals", api.CorsHandler(cfg, cors))
+
+	mux.HandleFunc("GET /api/v0/events/{id}/proposals/summary", api.CorsHandler(cfg, api.AuthHandler(cfg, api.GetEventProposalsSummaryHandler(cfg))))
 
 	mux.HandleFunc("GET /api/v0/events/{id}/proposals/export", api.CorsHandler(cfg, writeLimiter.Middleware(api.AuthHandler(cfg, api.ExportProposalsHandler(cfg)))))
-	mux.HandleFunc("OPTIONS /api/v0/events/{id}/proposals/export", api.CorsHandler(cfg, cors))
+	mux.HandleFunc("GET /api/v0/events/{id}/export/full", api.CorsHandler(cfg, exportLimiter.Middleware(api.AuthHandler(cfg, api.ExportFullEventHandler(cfg)))))
+
+	mux.HandleFunc("POST /api/v0/events/{id}/proposals/import", api.CorsHandler(cfg, writeLimiter.Middleware(api.AuthHandler(cfg, api.ImportProposalsHandler(cfg)))))
 
 	mux.HandleFunc("POST /api/v0/events/{id}/proposals/{proposalId}/checkout", api.CorsHandler(cfg, writeLimiter.Middleware(api.AuthHandler(cfg, api.CreateProposalCheckoutHandler(cfg)))))
-	mux.HandleFunc("OPTIONS /api/v0/events/{id}/proposals/{proposalId}/checkout", api.CorsHandler(cfg, cors))
+
+	mux.HandleFunc("GET /api/v0/events/{id}/activity", api.CorsHandler(cfg, api.AuthHandler(cfg, api.GetEventActivityHandler(cfg))))
 
 	mux.HandleFunc("GET /api/v0/events/{id}/organizers", api.CorsHandler(cfg, api.AuthHandler(cfg, api.GetEventOrganizersHandler(cfg))))
 	mux.HandleFunc("POST /api/v0/events/{id}/organizers", api.CorsHandler(cfg, writeLimiter.Middleware(api.AuthHandler(cfg, api.AddOrganizerHandler(cfg)))))
-	mux.HandleFunc("OPTIONS /api/v0/events/{id}/organizers", api.CorsHandler(cfg, cors))
+	mux.HandleFunc("POST /api/v0/events/{id}/organizers/bulk", api.CorsHandler(cfg, writeLimiter.Middleware(api.AuthHandler(cfg, api.BulkAddOrganizersHandler(cfg)))))
+	mux.HandleFunc("POST /api/v0/events/{id}/speakers/email", api.CorsHandler(cfg, writeLimiter.Middleware(api.AuthHandler(cfg, api.EmailSpeakersHandler(cfg)))))
+	mux.HandleFunc("GET /api/v0/events/{id}/preview-links", api.AuthCorsHandler(cfg, api.ListPreviewLinksHandler(cfg)))
+	mux.HandleFunc("POST /api/v0/events/{id}/preview-links", api.CorsHandler(cfg, writeLimiter.Middleware(api.AuthHandler(cfg, api.CreatePreviewLinkHandler(cfg)))))
+	mux.HandleFunc("DELETE /api/v0/events/{id}/preview-links/{linkId}", api.CorsHandler(cfg, writeLimiter.Middleware(api.AuthHandler(cfg, api.RevokePreviewLinkHandler(cfg)))))
+
+	mux.HandleFunc("DELETE /api/v0/events/{id}/organizers/me", api.CorsHandler(cfg, writeLimiter.Middleware(api.AuthHandler(cfg, api.LeaveEventHandler(cfg)))))
 	mux.HandleFunc("DELETE /api/v0/events/{id}/organizers/{userId}", api.CorsHandler(cfg, writeLimiter.Middleware(api.AuthHandler(cfg, api.RemoveOrganizerHandler(cfg)))))
-	mux.HandleFunc("OPTIONS /api/v0/events/{id}/organizers/{userId}", api.CorsHandler(cfg, cors))
 
 	// Proposal endpoints (with path parameters)
 	mux.HandleFunc("GET /api/v0/proposals/{id}", api.AuthCorsHandler(cfg, api.GetProposalHandler(cfg)))
 	mux.HandleFunc("PUT /api/v0/proposals/{id}", api.AuthCorsHandler(cfg, writeLimiter.Middleware(api.UpdateProposalHandler(cfg))))
 	mux.HandleFunc("DELETE /api/v0/proposals/{id}", api.AuthCorsHandler(cfg, writeLimiter.Middleware(api.DeleteProposalHandler(cfg))))
-	mux.HandleFunc("OPTIONS /api/v0/proposals/{id}", api.CorsHandler(cfg, cors))
+
+	mux.HandleFunc("POST /api/v0/proposals/{id}/copy", api.AuthCorsHandler(cfg, writeLimiter.Middleware(api.CopyProposalHandler(cfg))))
 
 	mux.HandleFunc("PUT /api/v0/proposals/{id}/status", api.AuthCorsHandler(cfg, writeLimiter.Middleware(api.UpdateProposalStatusHandler(cfg))))
-	mux.HandleFunc("OPTIONS /api/v0/proposals/{id}/status", api.CorsHandler(cfg, cors))
 
 	mux.HandleFunc("PUT /api/v0/proposals/{id}/rating", api.AuthCorsHandler(cfg, writeLimiter.Middleware(api.UpdateProposalRatingHandler(cfg))))
-	mux.HandleFunc("OPTIONS /api/v0/proposals/{id}/rating", api.CorsHandler(cfg, cors))
+
+	mux.HandleFunc("GET /api/v0/proposals/{id}/notifications", api.AuthCorsHandler(cfg, api.GetProposalNotificationsHandler(cfg)))
+
+	mux.HandleFunc("POST /api/v0/proposals/{id}/request-answers", api.AuthCorsHandler(cfg, writeLimiter.Middleware(api.RequestProposalAnswersHandler(cfg))))
+
+	// Advisory edit lock, renewed while editing and released when done
+	mux.HandleFunc("POST /api/v0/proposals/{id}/lock", api.AuthCorsHandler(cfg, writeLimiter.Middleware(api.LockProposalHandler(cfg))))
+	mux.HandleFunc("DELETE /api/v0/proposals/{id}/lock", api.AuthCorsHandler(cfg, writeLimiter.Middleware(api.UnlockProposalHandler(cfg))))
 
 	mux.HandleFunc("PUT /api/v0/proposals/{id}/emergency-cancel", api.AuthCorsHandler(cfg, writeLimiter.Middleware(api.EmergencyCancelHandler(cfg))))
-	mux.HandleFunc("OPTIONS /api/v0/proposals/{id}/emergency-cancel", api.CorsHandler(cfg, cors))
 
 	mux.HandleFunc("PUT /api/v0/proposals/{id}/confirm", api.AuthCorsHandler(cfg, writeLimiter.Middleware(api.ConfirmAttendanceHandler(cfg))))
-	mux.HandleFunc("OPTIONS /api/v0/proposals/{id}/confirm", api.CorsHandler(cfg, cors))
+
+	// Admin endpoints (ADMIN_EMAILS only)
+	mux.HandleFunc("PUT /api/v0/admin/users/{id}/trusted", api.CorsHandler(cfg, writeLimiter.Middleware(api.AdminHandler(cfg, api.SetUserTrustedHandler(cfg)))))
+	mux.HandleFunc("DELETE /api/v0/admin/users/{id}", api.CorsHandler(cfg, writeLimiter.Middleware(api.AdminHandler(cfg, api.DeleteUserHandler(cfg)))))
+
+	mux.HandleFunc("POST /api/v0/admin/impersonate/{userID}", api.CorsHandler(cfg, writeLimiter.Middleware(api.AdminHandler(cfg, api.ImpersonateUserHandler(cfg)))))
+	mux.HandleFunc("GET /api/v0/admin/impersonations", api.CorsHandler(cfg, api.AdminHandler(cfg, api.ListImpersonationLogsHandler(cfg))))
+
+	mux.HandleFunc("GET /api/v0/admin/api-keys", api.CorsHandler(cfg, api.AdminHandler(cfg, api.ListAPIKeysHandler(cfg))))
+	mux.HandleFunc("POST /api/v0/admin/api-keys", api.CorsHandler(cfg, writeLimiter.Middleware(api.AdminHandler(cfg, api.CreateAPIKeyHandler(cfg)))))
+
+	mux.HandleFunc("DELETE /api/v0/admin/api-keys/{id}", api.CorsHandler(cfg, writeLimiter.Middleware(api.AdminHandler(cfg, api.RevokeAPIKeyHandler(cfg)))))
+
+	mux.HandleFunc("GET /api/v0/admin/events/moderation", api.CorsHandler(cfg, api.AdminHandler(cfg, api.ListModerationEventsHandler(cfg))))
+
+	mux.HandleFunc("POST /api/v0/admin/events/merge", api.CorsHandler(cfg, writeLimiter.Middleware(api.AdminHandler(cfg, api.MergeEventsHandler(cfg)))))
+
+	mux.HandleFunc("GET /api/v0/admin/events/{id}/deleted-proposals", api.CorsHandler(cfg, api.AdminHandler(cfg, api.ListDeletedProposalsHandler(cfg))))
+
+	mux.HandleFunc("PUT /api/v0/admin/events/{id}/moderation", api.CorsHandler(cfg, writeLimiter.Middleware(api.AdminHandler(cfg, api.UpdateEventModerationHandler(cfg)))))
 
 	// Store cleanup function for graceful shutdown
 	cfg.Cleanup = func() {
@@ -0,0 +1,45 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/sreday/cfp.ninja/pkg/api"
+	"github.com/sreday/cfp.ninja/pkg/config"
+)
+
+var pathParam = regexp.MustCompile(`\{[^}]+\}`)
+
+func TestOpenAPISpec_DocumentedRoutesRegistered(t *testing.T) {
+	cfg := &config.Config{}
+	mux := http.NewServeMux()
+	RegisterRoutes(cfg, mux)
+	defer cfg.Cleanup()
+
+	var spec struct {
+		OpenAPI string                                `json:"openapi"`
+		Paths   map[string]map[string]json.RawMessage `json:"paths"`
+	}
+	if err := json.Unmarshal(api.OpenAPISpec(), &spec); err != nil {
+		t.Fatalf("embedded OpenAPI spec is not valid JSON: %v", err)
+	}
+	if spec.OpenAPI == "" {
+		t.Fatal("expected openapi version to be set")
+	}
+	if len(spec.Paths) == 0 {
+		t.Fatal("expected documented paths")
+	}
+
+	for path, ops := range spec.Paths {
+		for method := range ops {
+			req := httptest.NewRequest(strings.ToUpper(method), pathParam.ReplaceAllString(path, "1"), nil)
+			if _, pattern := mux.Handler(req); pattern == "" {
+				t.Errorf("%s %s is documented but not registered", strings.ToUpper(method), path)
+			}
+		}
+	}
+}
@@ -0,0 +1,189 @@
+// Package spam scores newly created events for signs of SEO spam, so that
+// suspicious events can be held for review before they are listed.
+package spam
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Points awarded per signal. An event's score is the sum of its signals.
+const (
+	HoneypotPoints       = 100 // the hidden form field was filled in
+	MaxLinkPoints        = 40  // cap for link density
+	LinkPointsPer100     = 4   // points per extra link per 100 words of description
+	DisposableEmailPoint = 30  // creator uses a throwaway email domain
+	NewAccountPoints     = 15  // creator account is younger than NewAccountAge
+	DuplicateNamePoints  = 25  // name is nearly identical to another creator's event
+)
+
+// NewAccountAge is how old an account must be to no longer count as brand-new
+const NewAccountAge = 24 * time.Hour
+
+// DuplicateNameSimilarity is the token similarity above which two event names
+// are considered duplicates
+const DuplicateNameSimilarity = 0.8
+
+// Input describes the event being created and its creator
+type Input struct {
+	Name           string
+	Description    string
+	CreatorEmail   string
+	AccountAge     time.Duration
+	HoneypotFilled bool
+	// Names of existing events by other creators to compare against
+	ExistingNames []string
+}
+
+// Signal is one component of a spam score
+type Signal struct {
+	Name   string `json:"name"`
+	Points int    `json:"points"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// Result is an event's spam score with the signals that contributed to it
+type Result struct {
+	Score   int      `json:"score"`
+	Signals []Signal `json:"signals"`
+}
+
+func (r *Result) add(name string, points int, detail string) {
+	r.Score += points
+	r.Signals = append(r.Signals, Signal{Name: name, Points: points, Detail: detail})
+}
+
+// Score computes the spam score for a new event
+func Score(in Input) Result {
+	r := Result{Signals: []Signal{}}
+
+	if in.HoneypotFilled {
+		r.add("honeypot", HoneypotPoints, "hidden form field was filled in")
+	}
+
+	// The first link is free: most descriptions point at the event website
+	if links, words := countLinks(in.Description); links > 1 {
+		points := min((links-1)*LinkPointsPer100*100/words, MaxLinkPoints)
+		if points > 0 {
+			r.add("link_density", points, fmt.Sprintf("%d links in %d words", links, words))
+		}
+	}
+
+	if domain, ok := DisposableDomain(in.CreatorEmail); ok {
+		r.add("disposable_email", DisposableEmailPoint, domain)
+	}
+
+	if in.AccountAge < NewAccountAge {
+		r.add("new_account", NewAccountPoints, "account created "+in.AccountAge.Round(time.Minute).String()+" ago")
+	}
+
+	best, bestName := 0.0, ""
+	for _, name := range in.ExistingNames {
+		if s := NameSimilarity(in.Name, name); s > best {
+			best, bestName = s, name
+		}
+	}
+	if best >= DuplicateNameSimilarity {
+		r.add("duplicate_name", DuplicateNamePoints, fmt.Sprintf("similar to %q", bestName))
+	}
+
+	return r
+}
+
+var linkRegex = regexp.MustCompile(`(?i)\b(?:https?://|www\.)\S+`)
+
+// countLinks returns the number of links and words in text. Links count as words.
+func countLinks(text string) (links, words int) {
+	return len(linkRegex.FindAllStringIndex(text, -1)), len(strings.Fields(text))
+}
+
+// disposableDomains are common throwaway email providers
+var disposableDomains = map[string]bool{
+	"10minutemail.com":  true,
+	"burnermail.io":     true,
+	"dispostable.com":   true,
+	"emailondeck.com":   true,
+	"fakeinbox.com":     true,
+	"getnada.com":       true,
+	"guerrillamail.com": true,
+	"guerrillamail.net": true,
+	"mailinator.com":    true,
+	"maildrop.cc":       true,
+	"mailnesia.com":     true,
+	"mintemail.com":     true,
+	"moakt.com":         true,
+	"sharklasers.com":   true,
+	"temp-mail.org":     true,
+	"tempail.com":       true,
+	"tempmail.com":      true,
+	"throwawaymail.com": true,
+	"trashmail.com":     true,
+	"yopmail.com":       true,
+}
+
+// DisposableDomain reports whether email uses a disposable email provider,
+// including its subdomains, and returns the matched domain
+func DisposableDomain(email string) (string, bool) {
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return "", false
+	}
+	domain := strings.ToLower(strings.TrimSpace(email[at+1:]))
+	for d := domain; d != ""; {
+		if disposableDomains[d] {
+			return d, true
+		}
+		dot := strings.Index(d, ".")
+		if dot < 0 {
+			break
+		}
+		d = d[dot+1:]
+	}
+	return "", false
+}
+
+// NameTokens splits an event name into lowercase words, dropping numbers such
+// as years so that "GopherCon 2026" and "Gophercon '25" compare equal
+func NameTokens(name string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	tokens := fields[:0]
+	for _, f := range fields {
+		if strings.IndexFunc(f, unicode.IsLetter) >= 0 {
+			tokens = append(tokens, f)
+		}
+	}
+	return tokens
+}
+
+// NameSimilarity is the Jaccard similarity of two event names' tokens, from
+// 0 (nothing in common) to 1 (same words)
+func NameSimilarity(a, b string) float64 {
+	ta, tb := NameTokens(a), NameTokens(b)
+	if len(ta) == 0 || len(tb) == 0 {
+		return 0
+	}
+	set := make(map[string]bool, len(ta))
+	for _, t := range ta {
+		set[t] = true
+	}
+	union := len(set)
+	common := 0
+	seen := make(map[string]bool, len(tb))
+	for _, t := range tb {
+		if seen[t] {
+			continue
+		}
+		seen[t] = true
+		if set[t] {
+			common++
+		} else {
+			union++
+		}
+	}
+	return float64(common) / float64(union)
+}
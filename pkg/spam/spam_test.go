@@ -0,0 +1,125 @@
+package spam
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// signals indexes a result's signals by name
+func signals(r Result) map[string]Signal {
+	m := make(map[string]Signal, len(r.Signals))
+	for _, s := range r.Signals {
+		m[s.Name] = s
+	}
+	return m
+}
+
+func TestScore_CleanEvent(t *testing.T) {
+	r := Score(Input{
+		Name:          "GopherCon EU",
+		Description:   "The European Go conference. Two days of talks and workshops for Gophers of all levels. More at https://gophercon.eu",
+		CreatorEmail:  "organizer@gophercon.eu",
+		AccountAge:    90 * 24 * time.Hour,
+		ExistingNames: []string{"KubeCon Europe", "PyCon DE"},
+	})
+	if r.Score != 0 {
+		t.Errorf("expected score 0, got %d (%+v)", r.Score, r.Signals)
+	}
+	if r.Signals == nil {
+		t.Error("expected non-nil signals")
+	}
+}
+
+func TestScore_Signals(t *testing.T) {
+	spammy := strings.Repeat("cheap pills https://spam.example ", 5)
+	r := Score(Input{
+		Name:           "GopherCon 2026",
+		Description:    spammy,
+		CreatorEmail:   "bot@mailinator.com",
+		AccountAge:     10 * time.Minute,
+		HoneypotFilled: true,
+		ExistingNames:  []string{"PyCon", "GopherCon 2025"},
+	})
+
+	got := signals(r)
+	want := map[string]int{
+		"honeypot":         HoneypotPoints,
+		"link_density":     MaxLinkPoints,
+		"disposable_email": DisposableEmailPoint,
+		"new_account":      NewAccountPoints,
+		"duplicate_name":   DuplicateNamePoints,
+	}
+	total := 0
+	for name, points := range want {
+		if got[name].Points != points {
+			t.Errorf("signal %s: expected %d points, got %+v", name, points, got[name])
+		}
+		total += points
+	}
+	if r.Score != total {
+		t.Errorf("expected score %d, got %d", total, r.Score)
+	}
+	if !strings.Contains(got["duplicate_name"].Detail, "GopherCon 2025") {
+		t.Errorf("expected duplicate detail to name the match, got %q", got["duplicate_name"].Detail)
+	}
+}
+
+func TestScore_LinkDensity(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		expected    int
+	}{
+		{"no links", "A conference about Go.", 0},
+		{"one link", "See https://example.com", 0},
+		{"two links in long text", strings.Repeat("word ", 98) + "https://example.com www.example.org", 4},
+		{"dense links", "https://a.example https://b.example www.c.example buy now", 40},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := Score(Input{Description: tc.description, AccountAge: NewAccountAge})
+			if got := signals(r)["link_density"].Points; got != tc.expected {
+				t.Errorf("expected %d points, got %d", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestDisposableDomain(t *testing.T) {
+	tests := map[string]bool{
+		"a@mailinator.com":         true,
+		"a@MAILINATOR.com":         true,
+		"a@eu.mailinator.com":      true,
+		"a@notmailinator.com":      false,
+		"a@example.com":            false,
+		"no-at-sign":               false,
+		"a@yopmail.com":            true,
+		"weird@name@trashmail.com": true,
+	}
+	for email, expected := range tests {
+		if _, got := DisposableDomain(email); got != expected {
+			t.Errorf("DisposableDomain(%q) = %v, want %v", email, got, expected)
+		}
+	}
+}
+
+func TestNameSimilarity(t *testing.T) {
+	tests := []struct {
+		a, b string
+		min  float64
+		max  float64
+	}{
+		{"GopherCon 2026", "Gophercon '25", 1, 1},
+		{"SREday London", "SREday London 2026", 1, 1},
+		{"SREday London", "SREday Paris", 0.3, 0.4},
+		{"DevOpsDays Berlin", "PyCon DE", 0, 0},
+		{"2026", "2026", 0, 0},
+	}
+	for _, tc := range tests {
+		got := NameSimilarity(tc.a, tc.b)
+		if got < tc.min || got > tc.max {
+			t.Errorf("NameSimilarity(%q, %q) = %.2f, want between %.2f and %.2f", tc.a, tc.b, got, tc.min, tc.max)
+		}
+	}
+}
@@ -21,6 +21,7 @@ type HomeMetadata struct {
 	Events              []EventRef `yaml:"events"`
 	EventsPast          []EventRef `yaml:"events_past"`
 	DescriptionTemplate string     `yaml:"description_template"`
+	SummaryTemplate     string     `yaml:"summary_template"`
 	Mailto              string     `yaml:"mailto"`
 }
 
@@ -35,6 +36,8 @@ type EventMetadata struct {
 	StartTime time.Time `yaml:"start_time"`
 	Days      int       `yaml:"days"`
 	LumaEvt   string    `yaml:"luma_evt"`
+	// AttendanceMode is in_person (default), online or hybrid
+	AttendanceMode string `yaml:"attendance_mode"`
 }
 
 type SpeakerRecord struct {
@@ -0,0 +1,160 @@
+package tasks
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/sreday/cfp.ninja/pkg/email"
+	"github.com/sreday/cfp.ninja/pkg/models"
+	"gorm.io/gorm"
+)
+
+// ArchiveConfig holds the thresholds of the event archival task
+type ArchiveConfig struct {
+	// Events are archived this long after their end date
+	ArchiveAfter time.Duration
+	// Their proposals are scrubbed this long after archival
+	ScrubAfter time.Duration
+	// The creator is emailed this long before the scrub
+	ScrubNotice time.Duration
+	// DryRun logs what would change without changing or sending anything
+	DryRun bool
+}
+
+// ArchiveResult counts what one archival run did, or would do in a dry run
+type ArchiveResult struct {
+	Archived int
+	Notified int
+	Scrubbed int
+}
+
+// StartEventArchival archives long-past events and scrubs the personal data
+// of their proposals, at startup and then daily. Intended to be launched as a
+// goroutine from main.
+func StartEventArchival(ctx context.Context, db *gorm.DB, logger *slog.Logger, sender email.Sender, emailFrom, baseURL string, cfg ArchiveConfig) {
+	logger.Info("event archival starting", "archive_after", cfg.ArchiveAfter, "scrub_after", cfg.ScrubAfter, "scrub_notice", cfg.ScrubNotice, "dry_run", cfg.DryRun)
+	ncfg := &email.NotifyConfig{
+		Sender:  sender,
+		From:    emailFrom,
+		BaseURL: baseURL,
+		Logger:  logger,
+	}
+
+	ticker := time.NewTicker(24 * time.Hour)
+	defer ticker.Stop()
+	for {
+		result := RunEventArchival(db, ncfg, cfg, time.Now())
+		if result.Archived+result.Notified+result.Scrubbed > 0 {
+			logger.Info("event archival finished", "archived", result.Archived, "notified", result.Notified, "scrubbed", result.Scrubbed, "dry_run", cfg.DryRun)
+		}
+
+		select {
+		case <-ctx.Done():
+			logger.Info("event archival stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunEventArchival runs the three steps of archival as of now: archive events
+// that ended more than ArchiveAfter ago, email the creators of events due to
+// be scrubbed within ScrubNotice, and scrub the events whose notice has run
+// out. Each step only picks up what the previous runs left, so running it
+// again changes nothing. A creator is always emailed a full ScrubNotice before
+// the scrub, even if the task was down when it was due.
+func RunEventArchival(db *gorm.DB, ncfg *email.NotifyConfig, cfg ArchiveConfig, now time.Time) ArchiveResult {
+	var result ArchiveResult
+	logger := ncfg.Logger
+
+	toArchive, err := models.FindEventsToArchive(db, now.Add(-cfg.ArchiveAfter))
+	if err != nil {
+		logger.Error("failed to find events to archive", "error", err)
+		return result
+	}
+	ids := make([]uint, len(toArchive))
+	for i, e := range toArchive {
+		ids[i] = e.ID
+		if cfg.DryRun {
+			logger.Info("dry run: would archive event", "event_id", e.ID, "slug", e.Slug, "end_date", e.EndDate)
+		}
+	}
+	if cfg.DryRun {
+		result.Archived = len(ids)
+	} else if archived, err := models.ArchiveEvents(db, ids, now); err != nil {
+		logger.Error("failed to archive events", "error", err)
+	} else {
+		result.Archived = int(archived)
+	}
+
+	toNotify, err := models.FindEventsDueScrubNotice(db, now.Add(cfg.ScrubNotice-cfg.ScrubAfter))
+	if err != nil {
+		logger.Error("failed to find events due a scrub notice", "error", err)
+		return result
+	}
+	for i := range toNotify {
+		if sendScrubNotice(db, ncfg, cfg, &toNotify[i], now) {
+			result.Notified++
+		}
+	}
+
+	toScrub, err := models.FindEventsToScrub(db, now.Add(-cfg.ScrubAfter), now.Add(-cfg.ScrubNotice))
+	if err != nil {
+		logger.Error("failed to find events to scrub", "error", err)
+		return result
+	}
+	for _, e := range toScrub {
+		if cfg.DryRun {
+			logger.Info("dry run: would scrub event proposals", "event_id", e.ID, "slug", e.Slug)
+			result.Scrubbed++
+			continue
+		}
+		scrubbed, err := models.ScrubEventProposals(db, e.ID, now)
+		if err != nil {
+			logger.Error("failed to scrub event proposals", "event_id", e.ID, "error", err)
+			continue
+		}
+		logger.Info("scrubbed event proposals", "event_id", e.ID, "proposals", scrubbed)
+		result.Scrubbed++
+	}
+	return result
+}
+
+// sendScrubNotice claims the event's scrub notice and emails its creator.
+// Events whose creator deleted their account are claimed without an email,
+// so the scrub still follows a full notice period later.
+func sendScrubNotice(db *gorm.DB, ncfg *email.NotifyConfig, cfg ArchiveConfig, event *models.Event, now time.Time) bool {
+	logger := ncfg.Logger
+	count, err := models.CountEventProposals(db, event.ID)
+	if err != nil {
+		logger.Error("failed to count proposals for scrub notice", "event_id", event.ID, "error", err)
+		return false
+	}
+	scrubAt := now.Add(cfg.ScrubNotice)
+	if cfg.DryRun {
+		logger.Info("dry run: would send scrub notice", "event_id", event.ID, "slug", event.Slug, "proposals", count, "scrub_at", scrubAt)
+		return true
+	}
+
+	claimed, err := models.ClaimScrubNotice(db, event.ID, now)
+	if err != nil {
+		logger.Error("failed to claim scrub notice", "event_id", event.ID, "error", err)
+		return false
+	}
+	if !claimed {
+		return false
+	}
+
+	if event.CreatedByID == nil || count == 0 {
+		return true
+	}
+	var creator models.User
+	if err := db.First(&creator, *event.CreatedByID).Error; err != nil {
+		logger.Warn("no creator to send scrub notice to", "event_id", event.ID, "error", err)
+		return true
+	}
+	// Logged by the sender on failure; the scrub still waits out the notice
+	_ = email.SendScrubNoticeNotification(ncfg, event, &creator, count, scrubAt)
+	return true
+}
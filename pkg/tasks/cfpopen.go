@@ -0,0 +1,87 @@
+package tasks
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/sreday/cfp.ninja/pkg/email"
+	"github.com/sreday/cfp.ninja/pkg/models"
+	"gorm.io/gorm"
+)
+
+// cfpOpenCheckInterval is how often StartCFPOpenNotifier looks for CFPs that
+// opened on their own when cfp_open_at passed
+const cfpOpenCheckInterval = 5 * time.Minute
+
+// StartCFPOpenNotifier emails users who asked to hear when a CFP opens. Status
+// changes made through the API notify straight away; this catches CFPs that
+// open at cfp_open_at or through the payment webhook. Intended to be launched
+// as a goroutine from main.
+func StartCFPOpenNotifier(ctx context.Context, db *gorm.DB, logger *slog.Logger, sender email.Sender, emailFrom, baseURL string) {
+	logger.Info("cfp open notifier starting", "interval", cfpOpenCheckInterval)
+	ncfg := &email.NotifyConfig{
+		Sender:  sender,
+		From:    emailFrom,
+		BaseURL: baseURL,
+		Logger:  logger,
+	}
+
+	ticker := time.NewTicker(cfpOpenCheckInterval)
+	defer ticker.Stop()
+	for {
+		notifyOpenedCFPs(db, ncfg, time.Now())
+
+		select {
+		case <-ctx.Done():
+			logger.Info("cfp open notifier stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// notifyOpenedCFPs notifies pending registrations for every event whose CFP
+// accepts submissions at now
+func notifyOpenedCFPs(db *gorm.DB, ncfg *email.NotifyConfig, now time.Time) {
+	var eventIDs []uint
+	if err := db.Model(&models.CFPInterest{}).
+		Where("notified_at IS NULL").
+		Distinct("event_id").
+		Pluck("event_id", &eventIDs).Error; err != nil {
+		ncfg.Logger.Error("failed to query pending cfp interests", "error", err)
+		return
+	}
+	if len(eventIDs) == 0 {
+		return
+	}
+
+	var events []models.Event
+	if err := db.Where("id IN ?", eventIDs).Find(&events).Error; err != nil {
+		ncfg.Logger.Error("failed to load events for cfp open notifications", "error", err)
+		return
+	}
+	for i := range events {
+		NotifyCFPOpened(db, ncfg, &events[i], now)
+	}
+}
+
+// NotifyCFPOpened emails the event's pending notify-me registrations if its
+// CFP accepts submissions at now, and returns the number of emails sent.
+// Registrations are claimed before sending, so each is emailed at most once
+// even when called concurrently for the same event.
+func NotifyCFPOpened(db *gorm.DB, ncfg *email.NotifyConfig, event *models.Event, now time.Time) int {
+	if !event.IsListed() || !event.IsCFPOpenAt(now, 0) {
+		return 0
+	}
+
+	claimed, err := models.ClaimCFPInterests(db, event.ID, now)
+	if err != nil {
+		ncfg.Logger.Error("failed to claim cfp interests", "event_id", event.ID, "error", err)
+		return 0
+	}
+	if len(claimed) == 0 {
+		return 0
+	}
+	return email.SendCFPOpenedNotification(ncfg, event, claimed)
+}
@@ -0,0 +1,35 @@
+package tasks
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/sreday/cfp.ninja/pkg/models"
+	"gorm.io/gorm"
+)
+
+// StartDeletedProposalPurge permanently deletes the retained proposals of
+// deleted events once their retention ends, at startup and then daily.
+// Intended to be launched as a goroutine from main.
+func StartDeletedProposalPurge(ctx context.Context, db *gorm.DB, logger *slog.Logger) {
+	logger.Info("deleted proposal purge starting", "retention", models.DeletedProposalRetention)
+
+	ticker := time.NewTicker(24 * time.Hour)
+	defer ticker.Stop()
+	for {
+		purged, err := models.PurgeDeletedProposals(db, time.Now())
+		if err != nil {
+			logger.Error("failed to purge deleted proposals", "error", err)
+		} else if purged > 0 {
+			logger.Info("purged deleted proposals", "count", purged)
+		}
+
+		select {
+		case <-ctx.Done():
+			logger.Info("deleted proposal purge stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
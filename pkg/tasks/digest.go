@@ -8,16 +8,50 @@ import (
 	"github.com/sreday/cfp.ninja/pkg/email"
 	"github.com/sreday/cfp.ninja/pkg/models"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
-// StartWeeklyDigest sends a weekly summary to every organiser.
-// It fires on the next Monday at 09:00 UTC, then repeats weekly.
+// DigestSchedule is when the weekly digest goes out and how fast it is sent
+type DigestSchedule struct {
+	Day            time.Weekday
+	Hour           int // UTC
+	SendsPerSecond int // 0 = no limit
+}
+
+// digestOpenCFPs is how many open CFPs each digest suggests
+const digestOpenCFPs = 5
+
+// digestActivityItems is how many activity summaries each event in a digest
+// shows
+const digestActivityItems = 3
+
+// digestCandidates is how many of the most popular open CFPs are considered
+// when matching a user's interests
+const digestCandidates = 100
+
+// StartWeeklyDigest sends a weekly summary to every organiser at the
+// scheduled day and hour (UTC). A run interrupted by a restart is resumed
+// on startup, skipping the organisers it already emailed.
 // Intended to be launched as a goroutine from main.
-func StartWeeklyDigest(ctx context.Context, db *gorm.DB, logger *slog.Logger, sender email.Sender, emailFrom, baseURL string) {
-	logger.Info("weekly digest scheduler starting")
+func StartWeeklyDigest(ctx context.Context, db *gorm.DB, logger *slog.Logger, sender email.Sender, emailFrom, baseURL string, schedule DigestSchedule) {
+	logger.Info("weekly digest scheduler starting", "day", schedule.Day, "hour", schedule.Hour)
+	ncfg := &email.NotifyConfig{
+		Sender:  sender,
+		From:    emailFrom,
+		BaseURL: baseURL,
+		Logger:  logger,
+	}
+
+	run, err := models.UnfinishedDigestRun(db, time.Now().AddDate(0, 0, -7))
+	if err != nil {
+		logger.Error("failed to query unfinished digest runs", "error", err)
+	} else if run != nil {
+		logger.Info("resuming weekly digest", "scheduled_for", run.ScheduledFor)
+		sendAllDigests(ctx, db, ncfg, schedule, run.ScheduledFor)
+	}
 
 	for {
-		next := nextMonday0900(time.Now())
+		next := nextDigestRun(time.Now(), schedule.Day, schedule.Hour)
 		wait := time.Until(next)
 		logger.Info("weekly digest next run", "at", next, "in", wait)
 
@@ -26,18 +60,18 @@ func StartWeeklyDigest(ctx context.Context, db *gorm.DB, logger *slog.Logger, se
 			logger.Info("weekly digest stopped")
 			return
 		case <-time.After(wait):
-			sendAllDigests(ctx, db, logger, sender, emailFrom, baseURL)
+			sendAllDigests(ctx, db, ncfg, schedule, next)
 		}
 	}
 }
 
-// nextMonday0900 returns the next Monday at 09:00 UTC strictly after now.
-func nextMonday0900(now time.Time) time.Time {
+// nextDigestRun returns the next given weekday at hour:00 UTC strictly after now.
+func nextDigestRun(now time.Time, day time.Weekday, hour int) time.Time {
 	now = now.UTC()
-	// Find days until next Monday
-	daysUntilMonday := (time.Monday - now.Weekday() + 7) % 7
-	candidate := time.Date(now.Year(), now.Month(), now.Day(), 9, 0, 0, 0, time.UTC).AddDate(0, 0, int(daysUntilMonday))
-	// If it's already Monday but past 09:00, go to next Monday
+	// Find days until the next send day
+	daysUntil := (day - now.Weekday() + 7) % 7
+	candidate := time.Date(now.Year(), now.Month(), now.Day(), hour, 0, 0, 0, time.UTC).AddDate(0, 0, int(daysUntil))
+	// If it's already the send day but past the hour, go to next week
 	if !candidate.After(now) {
 		candidate = candidate.AddDate(0, 0, 7)
 	}
@@ -53,152 +87,341 @@ type eventCounts struct {
 	Confirmed int
 }
 
-func sendAllDigests(ctx context.Context, db *gorm.DB, logger *slog.Logger, sender email.Sender, emailFrom, baseURL string) {
-	ncfg := &email.NotifyConfig{
-		Sender:  sender,
-		From:    emailFrom,
-		BaseURL: baseURL,
-		Logger:  logger,
-	}
+// orgEvent is an event organised by a user, through the join table or as creator
+type orgEvent struct {
+	UserID  uint
+	EventID uint
+	Name    string
+}
 
-	since := time.Now().AddDate(0, 0, -7)
+// organiserEventsSQL lists (user_id, event_id, name) for every organiser of
+// every live event, from both the event_organizers join table and
+// created_by_id ownership
+const organiserEventsSQL = `
+	SELECT eo.user_id, e.id AS event_id, e.name
+	FROM event_organizers eo
+	JOIN events e ON e.id = eo.event_id
+	WHERE e.deleted_at IS NULL
+	UNION
+	SELECT e.created_by_id AS user_id, e.id AS event_id, e.name
+	FROM events e
+	WHERE e.created_by_id IS NOT NULL AND e.deleted_at IS NULL
+`
 
-	// Find all organisers who have at least one event (via join table OR as creator)
-	var organisers []models.User
-	if err := db.Distinct().
-		Where("users.id IN (?) OR users.id IN (?)",
-			db.Table("event_organizers").Select("user_id"),
-			db.Table("events").Select("created_by_id").Where("created_by_id IS NOT NULL AND deleted_at IS NULL"),
-		).
-		Find(&organisers).Error; err != nil {
-		logger.Error("failed to query organisers for digest", "error", err)
-		return
-	}
+// digestInterests is what a user's suggested CFPs are matched against: the
+// tags of their proposals and the countries of the events they submitted to.
+// Events they already submitted to or organise are never suggested.
+type digestInterests struct {
+	Tags      map[string]bool
+	Countries map[string]bool
+	Skip      map[uint]bool
+}
 
-	if len(organisers) == 0 {
-		return
-	}
+// digestBuilder holds the data shared by every digest of a run
+type digestBuilder struct {
+	baseURL    string
+	counts     map[uint]*eventCounts
+	activity   map[uint][]models.EventActivity // event_id -> newest first
+	eventNames map[uint]string
+	orgEvents  map[uint][]uint // user_id -> []event_id
+	interests  map[uint]*digestInterests
+	candidates []models.Event // open CFPs, most proposals first
+}
 
-	// Collect all event IDs across all organisers in one query
-	// Include both event_organizers join table and created_by_id ownership
-	type orgEvent struct {
-		UserID  uint
-		EventID uint
-		Name    string
+// loadDigestBuilder loads the activity between since and until on the events
+// of the given users, their interests, and the CFPs open at now
+func loadDigestBuilder(db *gorm.DB, baseURL string, userIDs []uint, since, until, now time.Time) (*digestBuilder, error) {
+	b := &digestBuilder{
+		baseURL:    baseURL,
+		counts:     make(map[uint]*eventCounts),
+		activity:   make(map[uint][]models.EventActivity),
+		eventNames: make(map[uint]string),
+		orgEvents:  make(map[uint][]uint),
+		interests:  make(map[uint]*digestInterests),
 	}
+
 	var orgEvents []orgEvent
-	if err := db.Raw(`
-		SELECT eo.user_id, e.id AS event_id, e.name
-		FROM event_organizers eo
-		JOIN events e ON e.id = eo.event_id
-		WHERE e.deleted_at IS NULL
-		UNION
-		SELECT e.created_by_id AS user_id, e.id AS event_id, e.name
-		FROM events e
-		WHERE e.created_by_id IS NOT NULL AND e.deleted_at IS NULL
-	`).Scan(&orgEvents).Error; err != nil {
-		logger.Error("failed to query organiser events for digest", "error", err)
-		return
+	if err := db.Raw("SELECT * FROM ("+organiserEventsSQL+") oe WHERE oe.user_id IN ?", userIDs).
+		Scan(&orgEvents).Error; err != nil {
+		return nil, err
+	}
+	for _, oe := range orgEvents {
+		b.eventNames[oe.EventID] = oe.Name
+		b.orgEvents[oe.UserID] = append(b.orgEvents[oe.UserID], oe.EventID)
+		b.interestsFor(oe.UserID).Skip[oe.EventID] = true
 	}
 
-	if len(orgEvents) == 0 {
-		return
+	if len(b.eventNames) > 0 {
+		eventIDs := make([]uint, 0, len(b.eventNames))
+		for id := range b.eventNames {
+			eventIDs = append(eventIDs, id)
+		}
+		if err := b.loadCounts(db, eventIDs, since, until); err != nil {
+			return nil, err
+		}
+		var activity []models.EventActivity
+		if err := db.Preload("Actor").
+			Where("event_id IN ? AND created_at >= ? AND created_at < ?", eventIDs, since, until).
+			Order("created_at DESC, id DESC").
+			Find(&activity).Error; err != nil {
+			return nil, err
+		}
+		for _, a := range activity {
+			b.activity[a.EventID] = append(b.activity[a.EventID], a)
+		}
 	}
 
-	// Collect unique event IDs
-	eventIDSet := make(map[uint]bool)
-	for _, oe := range orgEvents {
-		eventIDSet[oe.EventID] = true
+	// Interests from the users' own proposals
+	type submission struct {
+		UserID      uint
+		EventID     uint
+		Tags        string
+		CountryCode string
+	}
+	var submitted []submission
+	if err := db.Raw(`
+		SELECT p.created_by_id AS user_id, p.event_id, p.tags, e.country_code
+		FROM proposals p
+		JOIN events e ON e.id = p.event_id
+		WHERE p.created_by_id IN ? AND p.deleted_at IS NULL
+	`, userIDs).Scan(&submitted).Error; err != nil {
+		return nil, err
+	}
+	for _, s := range submitted {
+		in := b.interestsFor(s.UserID)
+		in.Skip[s.EventID] = true
+		for _, tag := range models.SplitTags(s.Tags) {
+			in.Tags[tag] = true
+		}
+		if s.CountryCode != "" {
+			in.Countries[s.CountryCode] = true
+		}
 	}
-	eventIDs := make([]uint, 0, len(eventIDSet))
-	for id := range eventIDSet {
-		eventIDs = append(eventIDs, id)
+
+	// Most popular open CFPs. The open condition is the same rule as
+	// models.Event.IsCFPOpenAt.
+	if err := db.Where("moderation_status = ? AND cfp_status = ? AND NOT unlisted AND NOT archived", models.ModerationApproved, models.CFPStatusOpen).
+		Where("cfp_open_at IS NULL OR cfp_open_at <= ?", now).
+		Where("cfp_close_at IS NULL OR cfp_close_at = ? OR cfp_close_at >= ?", time.Time{}, now).
+		Order(clause.Expr{SQL: "(SELECT COUNT(*) FROM proposals p WHERE p.event_id = events.id AND p.deleted_at IS NULL) DESC, cfp_close_at, id"}).
+		Limit(digestCandidates).
+		Find(&b.candidates).Error; err != nil {
+		return nil, err
 	}
 
-	// Batch query: new proposals per event (created in last 7 days)
+	return b, nil
+}
+
+// loadCounts counts the proposal activity between since and until on the events
+func (b *digestBuilder) loadCounts(db *gorm.DB, eventIDs []uint, since, until time.Time) error {
 	type countRow struct {
 		EventID uint `gorm:"column:event_id"`
 		Count   int  `gorm:"column:cnt"`
 	}
 
+	// New proposals per event
 	var newCounts []countRow
 	if err := db.Raw(`
 		SELECT event_id, COUNT(*) AS cnt
 		FROM proposals
-		WHERE event_id IN ? AND deleted_at IS NULL AND created_at >= ?
+		WHERE event_id IN ? AND deleted_at IS NULL AND created_at >= ? AND created_at < ?
 		GROUP BY event_id
-	`, eventIDs, since).Scan(&newCounts).Error; err != nil {
-		logger.Error("failed to query new proposal counts", "error", err)
-		return
+	`, eventIDs, since, until).Scan(&newCounts).Error; err != nil {
+		return err
 	}
 
-	// Batch query: accepted proposals updated in last 7 days
+	// Accepted proposals updated in the period
 	var acceptedCounts []countRow
 	if err := db.Raw(`
 		SELECT event_id, COUNT(*) AS cnt
 		FROM proposals
-		WHERE event_id IN ? AND deleted_at IS NULL AND status = ? AND updated_at >= ?
+		WHERE event_id IN ? AND deleted_at IS NULL AND status = ? AND updated_at >= ? AND updated_at < ?
 		GROUP BY event_id
-	`, eventIDs, models.ProposalStatusAccepted, since).Scan(&acceptedCounts).Error; err != nil {
-		logger.Error("failed to query accepted proposal counts", "error", err)
-		return
+	`, eventIDs, models.ProposalStatusAccepted, since, until).Scan(&acceptedCounts).Error; err != nil {
+		return err
 	}
 
-	// Batch query: rejected proposals updated in last 7 days
+	// Rejected proposals updated in the period
 	var rejectedCounts []countRow
 	if err := db.Raw(`
 		SELECT event_id, COUNT(*) AS cnt
 		FROM proposals
-		WHERE event_id IN ? AND deleted_at IS NULL AND status = ? AND updated_at >= ?
+		WHERE event_id IN ? AND deleted_at IS NULL AND status = ? AND updated_at >= ? AND updated_at < ?
 		GROUP BY event_id
-	`, eventIDs, models.ProposalStatusRejected, since).Scan(&rejectedCounts).Error; err != nil {
-		logger.Error("failed to query rejected proposal counts", "error", err)
-		return
+	`, eventIDs, models.ProposalStatusRejected, since, until).Scan(&rejectedCounts).Error; err != nil {
+		return err
 	}
 
-	// Batch query: confirmed attendance in last 7 days
+	// Confirmed attendance in the period
 	var confirmedCounts []countRow
 	if err := db.Raw(`
 		SELECT event_id, COUNT(*) AS cnt
 		FROM proposals
-		WHERE event_id IN ? AND deleted_at IS NULL AND attendance_confirmed = true AND attendance_confirmed_at >= ?
+		WHERE event_id IN ? AND deleted_at IS NULL AND attendance_confirmed = true AND attendance_confirmed_at >= ? AND attendance_confirmed_at < ?
 		GROUP BY event_id
-	`, eventIDs, since).Scan(&confirmedCounts).Error; err != nil {
-		logger.Error("failed to query confirmed attendance counts", "error", err)
-		return
+	`, eventIDs, since, until).Scan(&confirmedCounts).Error; err != nil {
+		return err
 	}
 
-	// Build lookup maps
-	countsByEvent := make(map[uint]*eventCounts)
 	for _, c := range newCounts {
-		ec := getOrCreate(countsByEvent, c.EventID)
-		ec.New = c.Count
+		getOrCreate(b.counts, c.EventID).New = c.Count
 	}
 	for _, c := range acceptedCounts {
-		ec := getOrCreate(countsByEvent, c.EventID)
-		ec.Accepted = c.Count
+		getOrCreate(b.counts, c.EventID).Accepted = c.Count
 	}
 	for _, c := range rejectedCounts {
-		ec := getOrCreate(countsByEvent, c.EventID)
-		ec.Rejected = c.Count
+		getOrCreate(b.counts, c.EventID).Rejected = c.Count
 	}
 	for _, c := range confirmedCounts {
-		ec := getOrCreate(countsByEvent, c.EventID)
-		ec.Confirmed = c.Count
+		getOrCreate(b.counts, c.EventID).Confirmed = c.Count
 	}
+	return nil
+}
 
-	// Build event name lookup
-	eventNames := make(map[uint]string)
-	for _, oe := range orgEvents {
-		eventNames[oe.EventID] = oe.Name
+func (b *digestBuilder) interestsFor(userID uint) *digestInterests {
+	if in, ok := b.interests[userID]; ok {
+		return in
 	}
+	in := &digestInterests{
+		Tags:      make(map[string]bool),
+		Countries: make(map[string]bool),
+		Skip:      make(map[uint]bool),
+	}
+	b.interests[userID] = in
+	return in
+}
 
-	// Build per-organiser activity lists
-	orgEventsMap := make(map[uint][]uint) // user_id -> []event_id
-	for _, oe := range orgEvents {
-		orgEventsMap[oe.UserID] = append(orgEventsMap[oe.UserID], oe.EventID)
+// build returns the user's digest
+func (b *digestBuilder) build(userID uint) *email.WeeklyDigest {
+	digest := &email.WeeklyDigest{}
+	for _, evID := range b.orgEvents[userID] {
+		recent := models.SummarizeActivity(b.activity[evID], digestActivityItems)
+		ec := b.counts[evID]
+		if ec == nil {
+			ec = &eventCounts{EventID: evID}
+		}
+		if ec.New == 0 && ec.Accepted == 0 && ec.Rejected == 0 && ec.Confirmed == 0 && len(recent) == 0 {
+			continue
+		}
+		digest.Events = append(digest.Events, email.EventActivity{
+			EventName:    b.eventNames[evID],
+			NewProposals: ec.New,
+			Accepted:     ec.Accepted,
+			Rejected:     ec.Rejected,
+			Confirmed:    ec.Confirmed,
+			Recent:       recent,
+		})
+	}
+
+	// Suggest the open CFPs matching the user's interests, falling back to
+	// the most popular ones when nothing matches
+	in := b.interestsFor(userID)
+	var matched, popular []models.Event
+	for _, ev := range b.candidates {
+		if in.Skip[ev.ID] {
+			continue
+		}
+		if len(popular) < digestOpenCFPs {
+			popular = append(popular, ev)
+		}
+		if len(matched) < digestOpenCFPs && in.matches(&ev) {
+			matched = append(matched, ev)
+		}
+	}
+	picked := popular
+	if len(matched) > 0 {
+		picked = matched
+		digest.Matched = true
+	}
+	for _, ev := range picked {
+		cfp := email.DigestCFP{
+			EventName: ev.Name,
+			Location:  ev.Location,
+			EventURL:  b.baseURL + "/e/" + ev.Slug,
+		}
+		if !ev.CFPCloseAt.IsZero() {
+			cfp.CloseAt = ev.CFPCloseAt.UTC().Format("January 2, 2006")
+		}
+		digest.OpenCFPs = append(digest.OpenCFPs, cfp)
+	}
+	return digest
+}
+
+// matches reports whether the event shares a tag or country with the interests
+func (in *digestInterests) matches(ev *models.Event) bool {
+	if ev.CountryCode != "" && in.Countries[ev.CountryCode] {
+		return true
 	}
+	for _, tag := range models.SplitTags(ev.Tags) {
+		if in.Tags[tag] {
+			return true
+		}
+	}
+	return false
+}
 
+// BuildDigest returns the digest the user would get for the 7 days before
+// now, for previews. Unlike the scheduled send it is built for any user,
+// whether or not they organise an event.
+func BuildDigest(db *gorm.DB, baseURL string, user *models.User, now time.Time) (*email.WeeklyDigest, error) {
+	b, err := loadDigestBuilder(db, baseURL, []uint{user.ID}, now.AddDate(0, 0, -7), now, now)
+	if err != nil {
+		return nil, err
+	}
+	return b.build(user.ID), nil
+}
+
+// sendAllDigests sends the digest for the run scheduled at scheduledFor to
+// every organiser with activity in the 7 days before it, at most
+// schedule.SendsPerSecond emails per second. Organisers already emailed for
+// the run are skipped, and a finished run is not sent again.
+func sendAllDigests(ctx context.Context, db *gorm.DB, ncfg *email.NotifyConfig, schedule DigestSchedule, scheduledFor time.Time) {
+	logger := ncfg.Logger
+
+	run, err := models.StartDigestRun(db, scheduledFor)
+	if err != nil {
+		logger.Error("failed to record digest run", "error", err)
+		return
+	}
+	if run.FinishedAt != nil {
+		logger.Info("weekly digest already sent", "scheduled_for", scheduledFor)
+		return
+	}
+
+	// Find all organisers who have at least one event (via join table OR as creator)
+	var organisers []models.User
+	if err := db.Distinct().
+		Where("users.id IN (?) OR users.id IN (?)",
+			db.Table("event_organizers").Select("user_id"),
+			db.Table("events").Select("created_by_id").Where("created_by_id IS NOT NULL AND deleted_at IS NULL"),
+		).
+		Find(&organisers).Error; err != nil {
+		logger.Error("failed to query organisers for digest", "error", err)
+		return
+	}
+
+	userIDs := make([]uint, len(organisers))
+	for i, org := range organisers {
+		userIDs[i] = org.ID
+	}
+	var b *digestBuilder
+	if len(organisers) > 0 {
+		b, err = loadDigestBuilder(db, ncfg.BaseURL, userIDs, scheduledFor.AddDate(0, 0, -7), scheduledFor, time.Now())
+		if err != nil {
+			logger.Error("failed to load digest activity", "error", err)
+			return
+		}
+	}
+
+	// Pace sends to stay under the email provider's rate limit
+	var pace <-chan time.Time
+	if schedule.SendsPerSecond > 0 {
+		ticker := time.NewTicker(time.Second / time.Duration(schedule.SendsPerSecond))
+		defer ticker.Stop()
+		pace = ticker.C
+	}
+
+	sent := 0
 	for _, org := range organisers {
 		select {
 		case <-ctx.Done():
@@ -206,39 +429,45 @@ func sendAllDigests(ctx context.Context, db *gorm.DB, logger *slog.Logger, sende
 		default:
 		}
 
-		evIDs := orgEventsMap[org.ID]
-		if len(evIDs) == 0 {
+		digest := b.build(org.ID)
+		// Only organisers with activity that week get a digest
+		if len(digest.Events) == 0 {
 			continue
 		}
 
-		var activities []email.EventActivity
-		for _, evID := range evIDs {
-			ec := countsByEvent[evID]
-			if ec == nil || (ec.New == 0 && ec.Accepted == 0 && ec.Rejected == 0 && ec.Confirmed == 0) {
-				continue
-			}
-			activities = append(activities, email.EventActivity{
-				EventName:    eventNames[evID],
-				NewProposals: ec.New,
-				Accepted:     ec.Accepted,
-				Rejected:     ec.Rejected,
-				Confirmed:    ec.Confirmed,
-			})
+		claimed, err := models.ClaimDigestDelivery(db, run.ID, org.ID)
+		if err != nil {
+			logger.Error("failed to claim weekly digest", "user_id", org.ID, "error", err)
+			continue
 		}
-
-		if len(activities) == 0 {
+		if !claimed {
 			continue
 		}
 
-		if err := email.SendWeeklyDigest(ncfg, &org, activities); err != nil {
+		if pace != nil {
+			select {
+			case <-ctx.Done():
+				return
+			case <-pace:
+			}
+		}
+
+		if err := email.SendWeeklyDigest(ncfg, &org, digest); err != nil {
 			logger.Error("failed to send weekly digest",
 				"organizer", org.Email,
 				"error", err,
 			)
 		} else {
-			logger.Info("sent weekly digest", "organizer", org.Email, "events", len(activities))
+			sent++
+			logger.Info("sent weekly digest", "organizer", org.Email, "events", len(digest.Events))
 		}
 	}
+
+	if err := models.FinishDigestRun(db, run.ID, sent, time.Now()); err != nil {
+		logger.Error("failed to finish digest run", "error", err)
+		return
+	}
+	logger.Info("weekly digest run finished", "scheduled_for", scheduledFor, "sent", sent)
 }
 
 func getOrCreate(m map[uint]*eventCounts, eventID uint) *eventCounts {
@@ -3,55 +3,58 @@ package tasks
 import (
 	"testing"
 	"time"
+
+	"github.com/sreday/cfp.ninja/pkg/models"
+	"gorm.io/gorm"
 )
 
 func TestNextMonday0900_OnSunday(t *testing.T) {
 	// Sunday 2026-02-08 14:00 UTC -> next Monday is 2026-02-09 09:00 UTC
 	now := time.Date(2026, 2, 8, 14, 0, 0, 0, time.UTC)
-	got := nextMonday0900(now)
+	got := nextDigestRun(now, time.Monday, 9)
 	want := time.Date(2026, 2, 9, 9, 0, 0, 0, time.UTC)
 	if !got.Equal(want) {
-		t.Errorf("nextMonday0900(%v) = %v, want %v", now, got, want)
+		t.Errorf("nextDigestRun(%v, Monday, 9) = %v, want %v", now, got, want)
 	}
 }
 
 func TestNextMonday0900_OnMondayBefore0900(t *testing.T) {
 	// Monday 2026-02-09 08:00 UTC -> same day 09:00
 	now := time.Date(2026, 2, 9, 8, 0, 0, 0, time.UTC)
-	got := nextMonday0900(now)
+	got := nextDigestRun(now, time.Monday, 9)
 	want := time.Date(2026, 2, 9, 9, 0, 0, 0, time.UTC)
 	if !got.Equal(want) {
-		t.Errorf("nextMonday0900(%v) = %v, want %v", now, got, want)
+		t.Errorf("nextDigestRun(%v, Monday, 9) = %v, want %v", now, got, want)
 	}
 }
 
 func TestNextMonday0900_OnMondayAfter0900(t *testing.T) {
 	// Monday 2026-02-09 10:00 UTC -> next Monday 2026-02-16 09:00
 	now := time.Date(2026, 2, 9, 10, 0, 0, 0, time.UTC)
-	got := nextMonday0900(now)
+	got := nextDigestRun(now, time.Monday, 9)
 	want := time.Date(2026, 2, 16, 9, 0, 0, 0, time.UTC)
 	if !got.Equal(want) {
-		t.Errorf("nextMonday0900(%v) = %v, want %v", now, got, want)
+		t.Errorf("nextDigestRun(%v, Monday, 9) = %v, want %v", now, got, want)
 	}
 }
 
 func TestNextMonday0900_OnMondayAt0900(t *testing.T) {
 	// Monday 2026-02-09 09:00:00 UTC -> exactly at 09:00, should go to next week
 	now := time.Date(2026, 2, 9, 9, 0, 0, 0, time.UTC)
-	got := nextMonday0900(now)
+	got := nextDigestRun(now, time.Monday, 9)
 	want := time.Date(2026, 2, 16, 9, 0, 0, 0, time.UTC)
 	if !got.Equal(want) {
-		t.Errorf("nextMonday0900(%v) = %v, want %v", now, got, want)
+		t.Errorf("nextDigestRun(%v, Monday, 9) = %v, want %v", now, got, want)
 	}
 }
 
 func TestNextMonday0900_OnWednesday(t *testing.T) {
 	// Wednesday 2026-02-11 12:00 UTC -> Monday 2026-02-16 09:00
 	now := time.Date(2026, 2, 11, 12, 0, 0, 0, time.UTC)
-	got := nextMonday0900(now)
+	got := nextDigestRun(now, time.Monday, 9)
 	want := time.Date(2026, 2, 16, 9, 0, 0, 0, time.UTC)
 	if !got.Equal(want) {
-		t.Errorf("nextMonday0900(%v) = %v, want %v", now, got, want)
+		t.Errorf("nextDigestRun(%v, Monday, 9) = %v, want %v", now, got, want)
 	}
 }
 
@@ -60,9 +63,9 @@ func TestNextMonday0900_AlwaysMonday(t *testing.T) {
 	base := time.Date(2026, 2, 2, 15, 30, 0, 0, time.UTC) // Monday 15:30
 	for i := 0; i < 7; i++ {
 		now := base.AddDate(0, 0, i)
-		got := nextMonday0900(now)
+		got := nextDigestRun(now, time.Monday, 9)
 		if got.Weekday() != time.Monday {
-			t.Errorf("day %d: nextMonday0900 returned %v (weekday %v), want Monday", i, got, got.Weekday())
+			t.Errorf("day %d: nextDigestRun returned %v (weekday %v), want Monday", i, got, got.Weekday())
 		}
 		if got.Hour() != 9 || got.Minute() != 0 {
 			t.Errorf("day %d: time = %02d:%02d, want 09:00", i, got.Hour(), got.Minute())
@@ -72,3 +75,86 @@ func TestNextMonday0900_AlwaysMonday(t *testing.T) {
 		}
 	}
 }
+
+func TestNextDigestRun_ConfiguredDayAndHour(t *testing.T) {
+	// Wednesday 2026-02-11 12:00 UTC -> Friday 2026-02-13 16:00
+	now := time.Date(2026, 2, 11, 12, 0, 0, 0, time.UTC)
+	got := nextDigestRun(now, time.Friday, 16)
+	want := time.Date(2026, 2, 13, 16, 0, 0, 0, time.UTC)
+	if !got.Equal(want) {
+		t.Errorf("nextDigestRun(%v, Friday, 16) = %v, want %v", now, got, want)
+	}
+
+	// Same day, before the hour -> today
+	now = time.Date(2026, 2, 11, 6, 0, 0, 0, time.UTC)
+	got = nextDigestRun(now, time.Wednesday, 7)
+	want = time.Date(2026, 2, 11, 7, 0, 0, 0, time.UTC)
+	if !got.Equal(want) {
+		t.Errorf("nextDigestRun(%v, Wednesday, 7) = %v, want %v", now, got, want)
+	}
+}
+
+func TestDigestBuilder_OpenCFPs(t *testing.T) {
+	b := &digestBuilder{
+		baseURL:    "https://cfp.ninja",
+		counts:     map[uint]*eventCounts{},
+		eventNames: map[uint]string{},
+		orgEvents:  map[uint][]uint{},
+		interests:  map[uint]*digestInterests{},
+		candidates: []models.Event{
+			{Model: gorm.Model{ID: 1}, Name: "Popular", Slug: "popular", CountryCode: "US", Tags: "ai"},
+			{Model: gorm.Model{ID: 2}, Name: "Submitted", Slug: "submitted", CountryCode: "GB", Tags: "sre"},
+			{Model: gorm.Model{ID: 3}, Name: "Matching", Slug: "matching", CountryCode: "DE", Tags: "DevOps, cloud"},
+		},
+	}
+
+	in := b.interestsFor(7)
+	in.Tags["devops"] = true
+	in.Skip[2] = true
+
+	digest := b.build(7)
+	if !digest.Matched {
+		t.Error("expected matched CFPs")
+	}
+	if len(digest.OpenCFPs) != 1 || digest.OpenCFPs[0].EventName != "Matching" {
+		t.Fatalf("OpenCFPs = %+v, want only Matching", digest.OpenCFPs)
+	}
+	if digest.OpenCFPs[0].EventURL != "https://cfp.ninja/e/matching" {
+		t.Errorf("EventURL = %q", digest.OpenCFPs[0].EventURL)
+	}
+
+	// A user without interests gets the most popular CFPs
+	digest = b.build(8)
+	if digest.Matched {
+		t.Error("expected popular CFPs")
+	}
+	if len(digest.OpenCFPs) != 3 || digest.OpenCFPs[0].EventName != "Popular" {
+		t.Errorf("OpenCFPs = %+v, want all three, Popular first", digest.OpenCFPs)
+	}
+}
+
+func TestDigestBuilder_RecentActivity(t *testing.T) {
+	aliceID := uint(1)
+	alice := &models.User{Name: "Alice"}
+	b := &digestBuilder{
+		counts: map[uint]*eventCounts{1: {EventID: 1, New: 2}},
+		activity: map[uint][]models.EventActivity{
+			2: {{ActorID: &aliceID, Actor: alice, Action: models.ActivityEventUpdated, Detail: "cfp_close_at"}},
+		},
+		eventNames: map[uint]string{1: "Counted", 2: "Changed", 3: "Quiet"},
+		orgEvents:  map[uint][]uint{7: {1, 2, 3}},
+		interests:  map[uint]*digestInterests{},
+	}
+
+	// An event with only organizer activity is included, a quiet one is not
+	digest := b.build(7)
+	if len(digest.Events) != 2 {
+		t.Fatalf("Events = %+v, want Counted and Changed", digest.Events)
+	}
+	if digest.Events[0].EventName != "Counted" || len(digest.Events[0].Recent) != 0 {
+		t.Errorf("Events[0] = %+v", digest.Events[0])
+	}
+	if got := digest.Events[1].Recent; len(got) != 1 || got[0] != "Alice changed the CFP deadline" {
+		t.Errorf("Events[1].Recent = %q", got)
+	}
+}
@@ -3,6 +3,7 @@ package tasks
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net/url"
@@ -12,6 +13,9 @@ import (
 	"time"
 
 	"github.com/sreday/cfp.ninja/pkg/conf42"
+	"github.com/sreday/cfp.ninja/pkg/countries"
+	"github.com/sreday/cfp.ninja/pkg/geocode"
+	"github.com/sreday/cfp.ninja/pkg/logging"
 	"github.com/sreday/cfp.ninja/pkg/models"
 	"github.com/sreday/cfp.ninja/pkg/sreday"
 	"gorm.io/gorm"
@@ -24,10 +28,14 @@ var sources = []string{
 }
 
 // StartEventSync runs an immediate sync then repeats at the given interval until ctx is cancelled.
+// Each run also geocodes events that have no coordinates yet. The lines for
+// each created or updated event are sampled after the first logSample of a
+// run (see logging.Sampler); 0 logs them all.
 // Intended to be launched as a goroutine from main.
-func StartEventSync(ctx context.Context, db *gorm.DB, logger *slog.Logger, interval time.Duration, organiserIDs []uint) {
-	logger.Info("event sync starting", "interval", interval)
-	syncAllSources(ctx, db, logger, organiserIDs)
+func StartEventSync(ctx context.Context, db *gorm.DB, logger *slog.Logger, interval time.Duration, logSample int, organiserIDs []uint, geocoder geocode.Geocoder) {
+	logger.Info("event sync starting", "interval", interval, "log_sample", logSample)
+	syncAllSources(ctx, db, logger, logSample, organiserIDs)
+	geocodeMissingEvents(ctx, db, logger, geocoder)
 
 	ticker := time.NewTicker(interval)
 	defer ticker.Stop()
@@ -38,7 +46,8 @@ func StartEventSync(ctx context.Context, db *gorm.DB, logger *slog.Logger, inter
 			logger.Info("event sync stopped")
 			return
 		case <-ticker.C:
-			syncAllSources(ctx, db, logger, organiserIDs)
+			syncAllSources(ctx, db, logger, logSample, organiserIDs)
+			geocodeMissingEvents(ctx, db, logger, geocoder)
 		}
 	}
 }
@@ -66,7 +75,8 @@ func logoForSource(sourceURL string) string {
 
 // changedFields compares an existing event against proposed updates and returns
 // a comma-separated list of field names that differ. Returns empty string if nothing changed.
-func changedFields(existing models.Event, name, description, logoURL, contactEmail string, startDate, endDate time.Time, isPaid bool) string {
+// An empty summary means the source has no summary template and is not compared.
+func changedFields(existing models.Event, name, description, summary, logoURL, contactEmail string, startDate, endDate time.Time, isPaid bool, mode models.AttendanceMode) string {
 	var changed []string
 	if existing.Name != name {
 		changed = append(changed, "name")
@@ -80,6 +90,9 @@ func changedFields(existing models.Event, name, description, logoURL, contactEma
 	if existing.Description != description {
 		changed = append(changed, "description")
 	}
+	if summary != "" && existing.Summary != summary {
+		changed = append(changed, "summary")
+	}
 	if existing.IsPaid != isPaid {
 		changed = append(changed, "is_paid")
 	}
@@ -89,9 +102,53 @@ func changedFields(existing models.Event, name, description, logoURL, contactEma
 	if existing.ContactEmail != contactEmail {
 		changed = append(changed, "contact_email")
 	}
+	if existing.AttendanceMode != mode {
+		changed = append(changed, "attendance_mode")
+	}
 	return strings.Join(changed, ",")
 }
 
+// defaultCFPDates returns the CFP window given to synced events: open from
+// today until 23:59 UTC two weeks before the event. Upcoming events that start
+// sooner than that close at the end of today instead.
+func defaultCFPDates(now, startDate time.Time, isPast bool) (openAt, closeAt time.Time) {
+	openAt = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	closeAt = time.Date(startDate.Year(), startDate.Month(), startDate.Day()-14, 23, 59, 0, 0, time.UTC)
+	if !isPast && closeAt.Before(now) {
+		closeAt = time.Date(now.Year(), now.Month(), now.Day(), 23, 59, 0, 0, time.UTC)
+	}
+	return openAt, closeAt
+}
+
+// missingCFPDates returns the default CFP dates for whichever of the existing
+// event's dates are unset. Dates organizers have set are never overwritten.
+func missingCFPDates(existing models.Event, openAt, closeAt time.Time) map[string]interface{} {
+	missing := make(map[string]interface{})
+	if existing.CFPOpenAt.IsZero() {
+		missing["cfp_open_at"] = openAt
+	}
+	if existing.CFPCloseAt.IsZero() {
+		missing["cfp_close_at"] = closeAt
+	}
+	return missing
+}
+
+// mergeMissingCFPDates adds backfilled CFP dates to updates and to the diff summary
+func mergeMissingCFPDates(diff string, updates, missing map[string]interface{}) string {
+	for _, field := range []string{"cfp_open_at", "cfp_close_at"} {
+		v, ok := missing[field]
+		if !ok {
+			continue
+		}
+		updates[field] = v
+		if diff != "" {
+			diff += ","
+		}
+		diff += field
+	}
+	return diff
+}
+
 // renderDescription renders a Go text/template with event data.
 // The template can use lowercase keys: {{ name }}, {{ location }}, {{ country }},
 // {{ start_date }}, {{ end_date }}, {{ website }}, {{ slug }}.
@@ -124,7 +181,15 @@ func renderDescription(logger *slog.Logger, tmplStr string, event models.Event)
 	return buf.String()
 }
 
-func syncAllSources(ctx context.Context, db *gorm.DB, logger *slog.Logger, organiserIDs []uint) {
+// renderSummary renders a summary template like renderDescription, trimmed
+// to the first paragraph and cut to fit models.MaxSummaryLen
+func renderSummary(logger *slog.Logger, tmplStr string, event models.Event) string {
+	return models.SummarizeDescription(renderDescription(logger, tmplStr, event), models.DescriptionPlaintext)
+}
+
+func syncAllSources(ctx context.Context, db *gorm.DB, logger *slog.Logger, logSample int, organiserIDs []uint) {
+	sampler := &logging.Sampler{First: logSample, Every: logSample}
+	eventLogger := sampler.Logger(logger)
 	totalCreated := 0
 	totalUpdated := 0
 	totalSkipped := 0
@@ -136,7 +201,7 @@ func syncAllSources(ctx context.Context, db *gorm.DB, logger *slog.Logger, organ
 		default:
 		}
 
-		created, updated, skipped, err := syncSource(db, logger, baseURL, organiserIDs)
+		created, updated, skipped, err := syncSource(db, eventLogger, baseURL, organiserIDs)
 		if err != nil {
 			logger.Error("failed to sync source", "url", baseURL, "error", err)
 			continue
@@ -153,7 +218,7 @@ func syncAllSources(ctx context.Context, db *gorm.DB, logger *slog.Logger, organ
 	default:
 	}
 
-	created, updated, skipped, err := syncConf42(db, logger, organiserIDs)
+	created, updated, skipped, err := syncConf42(db, eventLogger, organiserIDs)
 	if err != nil {
 		logger.Error("failed to sync conf42", "error", err)
 	} else {
@@ -162,7 +227,43 @@ func syncAllSources(ctx context.Context, db *gorm.DB, logger *slog.Logger, organ
 		totalSkipped += skipped
 	}
 
-	logger.Info("event sync completed", "created", totalCreated, "updated", totalUpdated, "skipped", totalSkipped)
+	logger.Info("event sync completed", "created", totalCreated, "updated", totalUpdated, "skipped", totalSkipped, "unlogged", sampler.Dropped())
+}
+
+// geocodeMissingEvents resolves coordinates for in-person events that have none,
+// both synced and organizer-created. Locations the geocoder cannot resolve stay
+// without coordinates; the geocoder's cache keeps them from being looked up on
+// every run.
+func geocodeMissingEvents(ctx context.Context, db *gorm.DB, logger *slog.Logger, geocoder geocode.Geocoder) {
+	if _, disabled := geocoder.(geocode.NoopGeocoder); disabled || geocoder == nil {
+		return
+	}
+
+	var events []models.Event
+	if err := db.Where("latitude IS NULL AND location != '' AND attendance_mode != ?", models.AttendanceOnline).
+		Order("id ASC").Find(&events).Error; err != nil {
+		logger.Error("failed to load events to geocode", "error", err)
+		return
+	}
+
+	geocoded, notFound := 0, 0
+	for i := range events {
+		if ctx.Err() != nil {
+			return
+		}
+		err := models.GeocodeEvent(ctx, db, geocoder, &events[i])
+		switch {
+		case err == nil:
+			geocoded++
+		case errors.Is(err, geocode.ErrNotFound):
+			notFound++
+		default:
+			logger.Warn("failed to geocode event", "slug", events[i].Slug, "error", err)
+		}
+	}
+	if len(events) > 0 {
+		logger.Info("event geocoding completed", "geocoded", geocoded, "not_found", notFound, "candidates", len(events))
+	}
 }
 
 func syncSource(db *gorm.DB, logger *slog.Logger, baseURL string, organiserIDs []uint) (created, updated, skipped int, err error) {
@@ -181,7 +282,7 @@ func syncSource(db *gorm.DB, logger *slog.Logger, baseURL string, organiserIDs [
 
 	// Upcoming events (CFP open)
 	for _, ref := range home.Events {
-		wasCreated, wasUpdated, syncErr := syncEvent(db, logger, client, ref, sitePrefix, baseURL, false, organiserIDs, home.DescriptionTemplate, contactEmail)
+		wasCreated, wasUpdated, syncErr := syncEvent(db, logger, client, ref, sitePrefix, baseURL, false, organiserIDs, home.DescriptionTemplate, home.SummaryTemplate, contactEmail)
 		if syncErr != nil {
 			logger.Error("failed to sync event", "url", ref.URL, "error", syncErr)
 			continue
@@ -197,7 +298,7 @@ func syncSource(db *gorm.DB, logger *slog.Logger, baseURL string, organiserIDs [
 
 	// Past events (CFP closed)
 	for _, ref := range home.EventsPast {
-		wasCreated, wasUpdated, syncErr := syncEvent(db, logger, client, ref, sitePrefix, baseURL, true, organiserIDs, home.DescriptionTemplate, contactEmail)
+		wasCreated, wasUpdated, syncErr := syncEvent(db, logger, client, ref, sitePrefix, baseURL, true, organiserIDs, home.DescriptionTemplate, home.SummaryTemplate, contactEmail)
 		if syncErr != nil {
 			logger.Error("failed to sync event", "url", ref.URL, "error", syncErr)
 			continue
@@ -216,7 +317,7 @@ func syncSource(db *gorm.DB, logger *slog.Logger, baseURL string, organiserIDs [
 
 // syncEvent processes a single event reference.
 // Returns (true, false, nil) if created, (false, true, nil) if updated, (false, false, nil) if skipped.
-func syncEvent(db *gorm.DB, logger *slog.Logger, client *sreday.Client, ref sreday.EventRef, sitePrefix, baseURL string, isPast bool, organiserIDs []uint, descriptionTemplate, contactEmail string) (created bool, updated bool, err error) {
+func syncEvent(db *gorm.DB, logger *slog.Logger, client *sreday.Client, ref sreday.EventRef, sitePrefix, baseURL string, isPast bool, organiserIDs []uint, descriptionTemplate, summaryTemplate, contactEmail string) (created bool, updated bool, err error) {
 	slug := slugFromCFPLink(ref.CFPLink)
 	if slug == "" {
 		slug = makeSlug(sitePrefix, ref.URL)
@@ -237,6 +338,12 @@ func syncEvent(db *gorm.DB, logger *slog.Logger, client *sreday.Client, ref sred
 
 	endDate := startDate.AddDate(0, 0, days-1)
 
+	// SREday-style events are in person unless their metadata says otherwise
+	mode := models.AttendanceInPerson
+	if meta != nil {
+		mode = parseAttendanceMode(meta.AttendanceMode)
+	}
+
 	// Build a temporary event for template rendering
 	eventForTemplate := models.Event{
 		Name:      ref.Name,
@@ -248,25 +355,36 @@ func syncEvent(db *gorm.DB, logger *slog.Logger, client *sreday.Client, ref sred
 		Website:   resolveURL(baseURL, ref.URL),
 	}
 	description := renderDescription(logger, descriptionTemplate, eventForTemplate)
+	summary := renderSummary(logger, summaryTemplate, eventForTemplate)
 
 	logoURL := logoForSource(baseURL)
 
 	// Check if already exists
 	var existing models.Event
 	if db.Where("slug = ?", slug).First(&existing).Error == nil {
-		// Update existing event — preserve existing is_paid value
-		diff := changedFields(existing, ref.Name, description, logoURL, contactEmail, startDate, endDate, existing.IsPaid)
-		if diff == "" {
+		// Update existing event — preserve existing is_paid value. Only the
+		// columns below are written, so organizer-edited fields such as
+		// sections are left alone.
+		diff := changedFields(existing, ref.Name, description, summary, logoURL, contactEmail, startDate, endDate, existing.IsPaid, mode)
+		cfpOpenAt, cfpCloseAt := defaultCFPDates(time.Now(), startDate, isPast)
+		missing := missingCFPDates(existing, cfpOpenAt, cfpCloseAt)
+		if diff == "" && len(missing) == 0 {
 			return false, false, nil // nothing changed, skip
 		}
 		updates := map[string]interface{}{
-			"name":          ref.Name,
-			"start_date":    startDate,
-			"end_date":      endDate,
-			"description":   description,
-			"logo_url":      logoURL,
-			"contact_email": contactEmail,
+			"name":            ref.Name,
+			"start_date":      startDate,
+			"end_date":        endDate,
+			"description":     description,
+			"logo_url":        logoURL,
+			"contact_email":   contactEmail,
+			"attendance_mode": mode,
+			"is_online":       mode.IsOnline(),
 		}
+		if summary != "" {
+			updates["summary"] = summary
+		}
+		diff = mergeMissingCFPDates(diff, updates, missing)
 		if err := db.Model(&existing).Updates(updates).Error; err != nil {
 			return false, false, fmt.Errorf("updating event %s: %w", slug, err)
 		}
@@ -274,15 +392,7 @@ func syncEvent(db *gorm.DB, logger *slog.Logger, client *sreday.Client, ref sred
 		return false, true, nil
 	}
 
-	// Compute CFP dates
-	now := time.Now()
-	cfpOpenAt := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
-	cfpCloseAt := time.Date(startDate.Year(), startDate.Month(), startDate.Day()-14, 23, 59, 0, 0, time.UTC)
-
-	// For upcoming events close to start, ensure cfpCloseAt is not in the past
-	if !isPast && cfpCloseAt.Before(now) {
-		cfpCloseAt = time.Date(now.Year(), now.Month(), now.Day(), 23, 59, 0, 0, time.UTC)
-	}
+	cfpOpenAt, cfpCloseAt := defaultCFPDates(time.Now(), startDate, isPast)
 
 	cfpStatus := models.CFPStatusOpen
 	if isPast {
@@ -290,21 +400,25 @@ func syncEvent(db *gorm.DB, logger *slog.Logger, client *sreday.Client, ref sred
 	}
 
 	newEvent := models.Event{
-		Name:         ref.Name,
-		Slug:         slug,
-		Description:  description,
-		Location:     extractLocationWithoutCountry(ref.Location),
-		Country:      extractCountry(ref.Location),
-		StartDate:    startDate,
-		EndDate:      endDate,
-		Website:      resolveURL(baseURL, ref.URL),
-		LogoURL:      logoURL,
-		TermsURL:     termsURLForSource(baseURL),
-		ContactEmail: contactEmail,
-		CFPStatus:    cfpStatus,
-		CFPOpenAt:    cfpOpenAt,
-		CFPCloseAt:   cfpCloseAt,
-		IsPaid:       true,
+		Name:           ref.Name,
+		Slug:           slug,
+		Description:    description,
+		Summary:        summary,
+		Location:       extractLocationWithoutCountry(ref.Location),
+		Country:        extractCountry(ref.Location),
+		CountryCode:    countryCode(ref.Location),
+		AttendanceMode: mode,
+		IsOnline:       mode.IsOnline(),
+		StartDate:      startDate,
+		EndDate:        endDate,
+		Website:        resolveURL(baseURL, ref.URL),
+		LogoURL:        logoURL,
+		TermsURL:       termsURLForSource(baseURL),
+		ContactEmail:   contactEmail,
+		CFPStatus:      cfpStatus,
+		CFPOpenAt:      cfpOpenAt,
+		CFPCloseAt:     cfpCloseAt,
+		IsPaid:         true,
 	}
 
 	if len(organiserIDs) > 0 {
@@ -387,26 +501,35 @@ func syncConf42(db *gorm.DB, logger *slog.Logger, organiserIDs []uint) (created,
 		if description == "" {
 			description = entry.Description
 		}
+		summary := renderSummary(logger, meta.SummaryTemplate, eventForTemplate)
 
 		conf42Logo := "/img/stickers/conf42.png"
 
 		// Check if already exists
 		var existing models.Event
 		if db.Where("slug = ?", slug).First(&existing).Error == nil {
-			diff := changedFields(existing, eventName, description, conf42Logo, conf42ContactEmail, eventDate, eventDate, true)
-			if diff == "" {
+			diff := changedFields(existing, eventName, description, summary, conf42Logo, conf42ContactEmail, eventDate, eventDate, true, models.AttendanceOnline)
+			cfpOpenAt, cfpCloseAt := defaultCFPDates(now, eventDate, false)
+			missing := missingCFPDates(existing, cfpOpenAt, cfpCloseAt)
+			if diff == "" && len(missing) == 0 {
 				skipped++
 				continue // nothing changed
 			}
 			updates := map[string]interface{}{
-				"name":          eventName,
-				"start_date":    eventDate,
-				"end_date":      eventDate,
-				"description":   description,
-				"logo_url":      conf42Logo,
-				"contact_email": conf42ContactEmail,
-				"is_paid":       true,
+				"name":            eventName,
+				"start_date":      eventDate,
+				"end_date":        eventDate,
+				"description":     description,
+				"logo_url":        conf42Logo,
+				"contact_email":   conf42ContactEmail,
+				"is_paid":         true,
+				"attendance_mode": models.AttendanceOnline,
+				"is_online":       true,
+			}
+			if summary != "" {
+				updates["summary"] = summary
 			}
+			diff = mergeMissingCFPDates(diff, updates, missing)
 			if err := db.Model(&existing).Updates(updates).Error; err != nil {
 				logger.Error("failed to update conf42 event", "slug", slug, "error", err)
 				continue
@@ -416,30 +539,28 @@ func syncConf42(db *gorm.DB, logger *slog.Logger, organiserIDs []uint) (created,
 			continue
 		}
 
-		cfpOpenAt := today
-		cfpCloseAt := time.Date(eventDate.Year(), eventDate.Month(), eventDate.Day()-14, 23, 59, 0, 0, time.UTC)
-		if cfpCloseAt.Before(now) {
-			cfpCloseAt = time.Date(now.Year(), now.Month(), now.Day(), 23, 59, 0, 0, time.UTC)
-		}
+		cfpOpenAt, cfpCloseAt := defaultCFPDates(now, eventDate, false)
 
 		newEvent := models.Event{
-			Name:         eventName,
-			Slug:         slug,
-			Description:  description,
-			Location:     "Online",
-			Country:      "",
-			IsOnline:     true,
-			StartDate:    eventDate,
-			EndDate:      eventDate,
-			Website:      fmt.Sprintf("https://www.conf42.com/%s", entry.ShortURL),
-			LogoURL:      conf42Logo,
-			ContactEmail: conf42ContactEmail,
-			Tags:         conf42Tags(entry.Name),
-			CFPStatus:    models.CFPStatusOpen,
-			CFPOpenAt:    cfpOpenAt,
-			CFPCloseAt:   cfpCloseAt,
-			TermsURL:     "https://www.conf42.com/terms-and-conditions.pdf",
-			IsPaid:       true,
+			Name:           eventName,
+			Slug:           slug,
+			Description:    description,
+			Summary:        summary,
+			Location:       "Online",
+			Country:        "",
+			AttendanceMode: models.AttendanceOnline,
+			IsOnline:       true,
+			StartDate:      eventDate,
+			EndDate:        eventDate,
+			Website:        fmt.Sprintf("https://www.conf42.com/%s", entry.ShortURL),
+			LogoURL:        conf42Logo,
+			ContactEmail:   conf42ContactEmail,
+			Tags:           conf42Tags(entry.Name),
+			CFPStatus:      models.CFPStatusOpen,
+			CFPOpenAt:      cfpOpenAt,
+			CFPCloseAt:     cfpCloseAt,
+			TermsURL:       "https://www.conf42.com/terms-and-conditions.pdf",
+			IsPaid:         true,
 		}
 
 		if len(organiserIDs) > 0 {
@@ -499,7 +620,8 @@ func conf42Slug(shortURL string) string {
 	return fmt.Sprintf("conf42-%s-%s", topic, year)
 }
 
-// conf42Tags maps a Conf42 event name to comma-separated tags.
+// conf42Tags maps a Conf42 event name to comma-separated tags, normalized
+// like the tags of events saved through the API.
 func conf42Tags(name string) string {
 	lower := strings.ToLower(name)
 
@@ -529,7 +651,7 @@ func conf42Tags(name string) string {
 
 	for key, tags := range tagMap {
 		if strings.Contains(lower, key) {
-			return tags
+			return models.NormalizeTags(tags)
 		}
 	}
 
@@ -558,6 +680,16 @@ func termsURLForSource(sourceURL string) string {
 }
 
 // getSitePrefix extracts the site name from a URL hostname (e.g., "https://sreday.com" -> "sreday").
+// parseAttendanceMode reads the attendance_mode of source metadata, accepting
+// "in-person" as well as "in_person". Empty or unknown values mean in person.
+func parseAttendanceMode(raw string) models.AttendanceMode {
+	mode := models.AttendanceMode(strings.ReplaceAll(strings.ToLower(strings.TrimSpace(raw)), "-", "_"))
+	if !mode.Valid() {
+		return models.AttendanceInPerson
+	}
+	return mode
+}
+
 func getSitePrefix(sourceURL string) string {
 	u, err := url.Parse(sourceURL)
 	if err != nil {
@@ -666,6 +798,13 @@ func normalizeCountry(raw string) string {
 	return cleaned
 }
 
+// countryCode resolves the country of a location to its ISO 3166-1 alpha-2 code,
+// or "" when it is not recognized
+func countryCode(location string) string {
+	code, _ := countries.Lookup(extractCountry(location))
+	return code
+}
+
 // extractLocationWithoutCountry strips only the last comma-segment (country) but keeps the rest.
 // "Harness, New York, US" -> "Harness, New York"
 // "London, UK" -> "London"
@@ -3,8 +3,10 @@ package tasks
 import (
 	"log/slog"
 	"os"
+	"strings"
 	"testing"
 	"time"
+	"unicode/utf8"
 
 	"github.com/sreday/cfp.ninja/pkg/models"
 )
@@ -101,6 +103,75 @@ func TestGetSitePrefix(t *testing.T) {
 	}
 }
 
+func TestParseAttendanceMode(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want models.AttendanceMode
+	}{
+		{"", models.AttendanceInPerson},
+		{"hybrid", models.AttendanceHybrid},
+		{" Online ", models.AttendanceOnline},
+		{"in-person", models.AttendanceInPerson},
+		{"virtual", models.AttendanceInPerson},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			if got := parseAttendanceMode(tt.raw); got != tt.want {
+				t.Errorf("parseAttendanceMode(%q) = %q, want %q", tt.raw, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDefaultCFPDates(t *testing.T) {
+	now := time.Date(2026, 3, 10, 15, 30, 0, 0, time.UTC)
+
+	openAt, closeAt := defaultCFPDates(now, time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC), false)
+	if want := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC); !openAt.Equal(want) {
+		t.Errorf("openAt = %v, want %v", openAt, want)
+	}
+	if want := time.Date(2026, 5, 18, 23, 59, 0, 0, time.UTC); !closeAt.Equal(want) {
+		t.Errorf("closeAt = %v, want %v", closeAt, want)
+	}
+
+	// Upcoming events starting within two weeks close at the end of today
+	_, closeAt = defaultCFPDates(now, time.Date(2026, 3, 20, 0, 0, 0, 0, time.UTC), false)
+	if want := time.Date(2026, 3, 10, 23, 59, 0, 0, time.UTC); !closeAt.Equal(want) {
+		t.Errorf("closeAt = %v, want %v", closeAt, want)
+	}
+
+	// Past events keep their past close date
+	_, closeAt = defaultCFPDates(now, time.Date(2026, 1, 20, 0, 0, 0, 0, time.UTC), true)
+	if want := time.Date(2026, 1, 6, 23, 59, 0, 0, time.UTC); !closeAt.Equal(want) {
+		t.Errorf("closeAt = %v, want %v", closeAt, want)
+	}
+}
+
+func TestMissingCFPDates(t *testing.T) {
+	openAt := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+	closeAt := time.Date(2026, 5, 18, 23, 59, 0, 0, time.UTC)
+
+	updates := map[string]interface{}{"name": "Event"}
+	diff := mergeMissingCFPDates("name", updates, missingCFPDates(models.Event{}, openAt, closeAt))
+	if diff != "name,cfp_open_at,cfp_close_at" {
+		t.Errorf("diff = %q", diff)
+	}
+	if updates["cfp_open_at"] != openAt || updates["cfp_close_at"] != closeAt {
+		t.Errorf("expected both dates backfilled, got %v", updates)
+	}
+
+	// Dates set by organizers are kept
+	organizerClose := time.Date(2026, 4, 1, 12, 0, 0, 0, time.UTC)
+	missing := missingCFPDates(models.Event{CFPCloseAt: organizerClose}, openAt, closeAt)
+	if _, ok := missing["cfp_close_at"]; ok || len(missing) != 1 {
+		t.Errorf("expected only cfp_open_at backfilled, got %v", missing)
+	}
+	if diff := mergeMissingCFPDates("", map[string]interface{}{}, nil); diff != "" {
+		t.Errorf("expected empty diff, got %q", diff)
+	}
+}
+
 func TestSlugFromCFPLink(t *testing.T) {
 	tests := []struct {
 		cfpLink string
@@ -182,3 +253,20 @@ func TestRenderDescription(t *testing.T) {
 		})
 	}
 }
+
+func TestRenderSummary(t *testing.T) {
+	event := models.Event{Name: "SREday London 2026", Location: "London"}
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+
+	if got := renderSummary(logger, "", event); got != "" {
+		t.Errorf("expected no summary without a template, got %q", got)
+	}
+	got := renderSummary(logger, "{{ name }} comes to\n{{ location }}.\n\nMore details.", event)
+	if want := "SREday London 2026 comes to London."; got != want {
+		t.Errorf("renderSummary() = %q, want %q", got, want)
+	}
+	long := renderSummary(logger, strings.Repeat("word ", 100), event)
+	if n := utf8.RuneCountInString(long); n > models.MaxSummaryLen {
+		t.Errorf("expected at most %d characters, got %d", models.MaxSummaryLen, n)
+	}
+}
@@ -0,0 +1,34 @@
+package tasks
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/sreday/cfp.ninja/pkg/models"
+	"gorm.io/gorm"
+)
+
+// StartLoginCleanup deletes login history older than retention, once at
+// startup and then daily. Intended to be launched as a goroutine from main.
+func StartLoginCleanup(ctx context.Context, db *gorm.DB, logger *slog.Logger, retention time.Duration) {
+	logger.Info("login history cleanup starting", "retention", retention)
+
+	ticker := time.NewTicker(24 * time.Hour)
+	defer ticker.Stop()
+	for {
+		deleted, err := models.DeleteLoginEventsBefore(db, time.Now().Add(-retention))
+		if err != nil {
+			logger.Error("failed to delete old logins", "error", err)
+		} else if deleted > 0 {
+			logger.Info("deleted old logins", "count", deleted)
+		}
+
+		select {
+		case <-ctx.Done():
+			logger.Info("login history cleanup stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
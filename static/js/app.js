@@ -3,7 +3,7 @@ import { router } from './router.js';
 import { renderNav } from './components/nav.js';
 import { toast } from './components/toast.js';
 import { initTheme } from './theme.js';
-import { escapeHtml } from './utils.js';
+import { escapeHtml, BASE_PATH } from './utils.js';
 
 // Views
 import { EventsView } from './views/events.js';
@@ -20,6 +20,7 @@ import { SubmissionSuccessView } from './views/submission-success.js';
 import { StatsView } from './views/stats.js';
 import { TermsView } from './views/terms.js';
 import { LoginView } from './views/login.js';
+import { NotifyMeUnsubscribeView } from './views/notify-me-unsubscribe.js';
 
 // App configuration (populated on init)
 let appConfig = { auth_providers: ['github', 'google'] }; // defaults until fetched
@@ -30,7 +31,7 @@ export function getAppConfig() {
 
 // API helper functions
 export const API = {
-    baseUrl: '/api/v0',
+    baseUrl: `${BASE_PATH}/api/v0`,
 
     async getConfig() {
         const res = await fetch(`${this.baseUrl}/config`);
@@ -77,7 +78,11 @@ export const API = {
         const json = await response.json();
 
         if (!response.ok) {
-            throw new Error(json.error || 'Request failed');
+            const error = new Error(json.error || 'Request failed');
+            error.status = response.status;
+            error.code = json.code;
+            error.details = json.details;
+            throw error;
         }
 
         return json;
@@ -102,8 +107,21 @@ export const API = {
         return this.request('GET', `/me/events/${id}`);
     },
 
-    getEventBySlug(slug) {
-        return this.request('GET', `/e/${slug}`);
+    getEventBySlug(slug, previewToken) {
+        const query = previewToken ? `?preview_token=${encodeURIComponent(previewToken)}` : '';
+        return this.request('GET', `/e/${slug}${query}`);
+    },
+
+    getPreviewLinks(eventId) {
+        return this.request('GET', `/events/${eventId}/preview-links`);
+    },
+
+    createPreviewLink(eventId, expiresInDays) {
+        return this.request('POST', `/events/${eventId}/preview-links`, { expires_in_days: expiresInDays });
+    },
+
+    revokePreviewLink(eventId, linkId) {
+        return this.request('DELETE', `/events/${eventId}/preview-links/${linkId}`);
     },
 
     createEvent(data) {
@@ -114,8 +132,21 @@ export const API = {
         return this.request('PUT', `/events/${id}`, data);
     },
 
-    deleteEvent(id) {
-        return this.request('DELETE', `/events/${id}`);
+    deleteEvent(id, confirm = null) {
+        return this.request('DELETE', `/events/${id}`, confirm ? { confirm } : null);
+    },
+
+    // Notify me when a CFP opens
+    notifyMe(slug) {
+        return this.request('POST', `/e/${slug}/notify-me`);
+    },
+
+    removeNotifyMe(slug) {
+        return this.request('DELETE', `/e/${slug}/notify-me`);
+    },
+
+    unsubscribeNotifyMe(token) {
+        return this.request('POST', '/notify-me/unsubscribe', { token });
     },
 
     // My Events & Proposals (for dashboard)
@@ -132,12 +163,21 @@ export const API = {
         return this.request('POST', `/events/${eventId}/proposals`, data);
     },
 
-    getProposal(id) {
-        return this.request('GET', `/proposals/${id}`);
+    getProposal(id, { expand } = {}) {
+        const query = expand ? `?expand=${encodeURIComponent(expand)}` : '';
+        return this.request('GET', `/proposals/${id}${query}`);
+    },
+
+    updateProposal(id, data, { force = false } = {}) {
+        return this.request('PUT', `/proposals/${id}${force ? '?force=true' : ''}`, data);
+    },
+
+    lockProposal(id) {
+        return this.request('POST', `/proposals/${id}/lock`, {});
     },
 
-    updateProposal(id, data) {
-        return this.request('PUT', `/proposals/${id}`, data);
+    unlockProposal(id) {
+        return this.request('DELETE', `/proposals/${id}/lock`);
     },
 
     deleteProposal(id) {
@@ -160,6 +200,10 @@ export const API = {
         return this.request('PUT', `/proposals/${id}/emergency-cancel`, {});
     },
 
+    requestProposalAnswers(id) {
+        return this.request('POST', `/proposals/${id}/request-answers`, {});
+    },
+
     // Event proposals (for organizers)
     getEventProposals(eventId, params = {}) {
         const query = new URLSearchParams(params).toString();
@@ -167,8 +211,9 @@ export const API = {
     },
 
     // Countries
-    getCountries() {
-        return this.request('GET', '/countries');
+    // With all=true, every ISO country is listed (for country pickers)
+    getCountries({ all = false } = {}) {
+        return this.request('GET', `/countries${all ? '?all=true' : ''}`);
     },
 
     // Organizers
@@ -184,6 +229,10 @@ export const API = {
         return this.request('DELETE', `/events/${eventId}/organizers/${userId}`);
     },
 
+    leaveEvent(eventId) {
+        return this.request('DELETE', `/events/${eventId}/organizers/me`);
+    },
+
     // Payments
     createEventCheckout(eventId) {
         return this.request('POST', `/events/${eventId}/checkout`);
@@ -223,7 +272,7 @@ export const Auth = {
     async logout() {
         // Clear server-side session cookie (best-effort)
         try {
-            await fetch('/api/v0/auth/logout', { method: 'POST' });
+            await fetch(`${API.baseUrl}/auth/logout`, { method: 'POST' });
         } catch (_) { /* ignore */ }
         localStorage.removeItem(this.USER_KEY);
         renderNav();
@@ -243,7 +292,7 @@ export const Auth = {
         const top = (window.innerHeight - height) / 2;
 
         const popup = window.open(
-            `/api/v0/auth/${provider}`,
+            `${API.baseUrl}/auth/${provider}`,
             'CFP.ninja Login',
             `width=${width},height=${height},left=${left},top=${top}`
         );
@@ -325,8 +374,11 @@ export const Auth = {
             const user = await API.getMe();
             this.setUser(user);
 
-            // Existing user who hasn't accepted terms — prompt them
-            if (!user.terms_accepted_at) {
+            // An admin is seeing the app as this user; terms are theirs to accept
+            if (user.impersonated_by) {
+                this._showImpersonationBanner(user);
+            } else if (!user.terms_accepted_at) {
+                // Existing user who hasn't accepted terms — prompt them
                 this._showTermsAcceptanceModal();
             }
         } catch (error) {
@@ -335,6 +387,27 @@ export const Auth = {
         }
     },
 
+    // Show a banner while an admin impersonates the user, with a way out
+    _showImpersonationBanner(user) {
+        document.getElementById('impersonation-banner')?.remove();
+
+        const mode = user.impersonation_write ? 'changes are allowed' : 'read-only';
+        const banner = document.createElement('div');
+        banner.id = 'impersonation-banner';
+        banner.className = 'alert alert-warning rounded-0 mb-0 text-center';
+        banner.setAttribute('role', 'alert');
+        banner.innerHTML = `
+            ${escapeHtml(user.impersonated_by.email)} is viewing CFP.ninja as
+            <strong>${escapeHtml(user.name)}</strong> (${mode}). Every request is audited.
+            <button type="button" class="btn btn-sm btn-outline-dark ms-2" id="impersonation-stop">Stop</button>
+        `;
+        document.getElementById('app').prepend(banner);
+        banner.querySelector('#impersonation-stop').addEventListener('click', () => {
+            banner.remove();
+            this.logout();
+        });
+    },
+
     // Show modal requiring terms acceptance for existing users
     _showTermsAcceptanceModal() {
         // Remove any existing modal
@@ -472,6 +545,7 @@ async function init() {
         .add('/pricing', PricingView)
         .add('/terms', TermsView)
         .add('/login', LoginView)
+        .add('/notify-me/unsubscribe', NotifyMeUnsubscribeView)
         .add('/e/:slug', EventDetailView)
         .add('/e/:slug/submit', requireAuth(SubmitProposalView))
         .add('/e/:slug/submitted', requireAuth(SubmissionSuccessView))
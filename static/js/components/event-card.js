@@ -1,13 +1,19 @@
 // Event card component
-import { escapeHtml, escapeAttr, truncate, formatDateRange, getCfpStatus } from '../utils.js';
+import { escapeHtml, escapeAttr, truncate, formatDateRange, getCfpStatus, getAttendanceMode } from '../utils.js';
 import { router } from '../router.js';
 
 export function renderEventCard(event, managingMap) {
     const cfpStatus = getCfpStatus(event);
 
-    const countryPill = event.is_online
-        ? '<span class="badge bg-secondary">Online</span>'
-        : event.country ? `<span class="badge bg-secondary">${escapeHtml(event.country)}</span>` : '';
+    const mode = getAttendanceMode(event);
+    let countryPill = '';
+    if (mode === 'online') {
+        countryPill = '<span class="badge bg-secondary">Online</span>';
+    } else if (event.country) {
+        countryPill = `<span class="badge bg-secondary">${escapeHtml(event.country)}${mode === 'hybrid' ? ' + Online' : ''}</span>`;
+    } else if (mode === 'hybrid') {
+        countryPill = '<span class="badge bg-secondary">Hybrid</span>';
+    }
 
     return `
         <div class="col-md-6">
@@ -20,7 +20,7 @@ export function renderFilters(currentFilters, countries = []) {
                     <select class="form-select" id="country-filter">
                         <option value="">All Countries</option>
                         ${countries.map(c => `
-                            <option value="${escapeAttr(c)}" ${country === c ? 'selected' : ''}>${escapeHtml(c)}</option>
+                            <option value="${escapeAttr(c.code)}" ${country === c.code ? 'selected' : ''}>${escapeHtml(c.name)}</option>
                         `).join('')}
                     </select>
                 </div>
@@ -29,6 +29,7 @@ export function renderFilters(currentFilters, countries = []) {
                         <option value="" ${!type ? 'selected' : ''}>All Types</option>
                         <option value="in-person" ${type === 'in-person' ? 'selected' : ''}>In-Person</option>
                         <option value="online" ${type === 'online' ? 'selected' : ''}>Online</option>
+                        <option value="hybrid" ${type === 'hybrid' ? 'selected' : ''}>Hybrid</option>
                     </select>
                 </div>
                 <div class="col-md-2">
@@ -1,6 +1,6 @@
 // Navigation component
 import { Auth, getAppConfig } from '../app.js';
-import { escapeHtml } from '../utils.js';
+import { escapeHtml, BASE_PATH } from '../utils.js';
 import { toggleTheme, getTheme } from '../theme.js';
 
 // Track cleanup function for dropdown handlers
@@ -19,7 +19,7 @@ export function renderNav() {
     container.innerHTML = `
         <nav class="navbar navbar-expand-lg">
             <div class="container">
-                <a class="navbar-brand d-flex align-items-center gap-2" href="/"><img src="/img/cfpninja-logo.png" alt="CFP.ninja" height="32" class="logo-spin">CFP.ninja</a>
+                <a class="navbar-brand d-flex align-items-center gap-2" href="/"><img src="${BASE_PATH}/img/cfpninja-logo.png" alt="CFP.ninja" height="32" class="logo-spin">CFP.ninja</a>
                 <button class="navbar-toggler" type="button" data-bs-toggle="collapse" data-bs-target="#navbarNav">
                     <span class="navbar-toggler-icon"></span>
                 </button>
@@ -1,4 +1,5 @@
 // SPA Router using History API
+import { BASE_PATH } from './utils.js';
 
 class Router {
     constructor() {
@@ -50,20 +51,35 @@ class Router {
         }
 
         e.preventDefault();
-        this.navigate(href);
+        // Links in index.html are already under the base path
+        this.navigate(this.stripBasePath(href));
     }
 
+    // navigate takes app paths such as /dashboard, without the base path
     navigate(path, replace = false) {
+        const url = path.startsWith('/') ? BASE_PATH + path : path;
         if (replace) {
-            history.replaceState(null, '', path);
+            history.replaceState(null, '', url);
         } else {
-            history.pushState(null, '', path);
+            history.pushState(null, '', url);
         }
         this.handleRoute();
     }
 
+    stripBasePath(path) {
+        if (BASE_PATH && (path === BASE_PATH || path.startsWith(BASE_PATH + '/'))) {
+            return path.slice(BASE_PATH.length) || '/';
+        }
+        return path;
+    }
+
+    // currentPath is the app path of the page, without the base path
+    currentPath() {
+        return this.stripBasePath(window.location.pathname);
+    }
+
     async handleRoute() {
-        const path = window.location.pathname;
+        const path = this.currentPath();
         const query = Object.fromEntries(new URLSearchParams(window.location.search));
 
         for (const route of this.routes) {
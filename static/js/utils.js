@@ -1,5 +1,8 @@
 // Utility functions
 
+// Path prefix the app is served under (BASE_PATH), set by the server in index.html
+export const BASE_PATH = document.querySelector('meta[name="base-path"]')?.content || '';
+
 const htmlEscapeMap = { '&': '&amp;', '<': '&lt;', '>': '&gt;', '"': '&quot;', "'": '&#39;' };
 const htmlEscapeRe = /[&<>"']/g;
 
@@ -129,6 +132,11 @@ export function timeUntil(dateString) {
     return 'Ending soon';
 }
 
+// Go serializes unset time.Time values as 0001-01-01T00:00:00Z
+function isSetDate(dateString) {
+    return !!dateString && !dateString.startsWith('0001-01-01');
+}
+
 export function getCfpStatus(event) {
     // Support both API field names (cfp_open_at/cfp_close_at and cfp_start/cfp_end)
     const cfpStart = event.cfp_open_at || event.cfp_start;
@@ -145,25 +153,29 @@ export function getCfpStatus(event) {
         return { status: 'closed', label: 'CFP Closed', class: 'cfp-closed' };
     }
 
-    if (!cfpStart || !cfpEnd) {
+    // Unset dates leave that side of the window open, matching the backend:
+    // an open CFP without dates is open until its status changes
+    const hasStart = isSetDate(cfpStart);
+    const hasEnd = isSetDate(cfpEnd);
+    if (cfpStatus !== 'open' && (!hasStart || !hasEnd)) {
         return { status: 'none', label: 'No CFP', class: '' };
     }
 
     const now = new Date();
-    const start = new Date(cfpStart);
-    const end = new Date(cfpEnd);
+    const start = hasStart ? new Date(cfpStart) : null;
+    const end = hasEnd ? new Date(cfpEnd) : null;
 
     // Only show as open if status is 'open' AND within date range
     // Use strict < for end to match backend's now.Before(cfpCloseAt)
-    if (cfpStatus === 'open' && now >= start && now < end) {
-        return { status: 'open', label: `CFP Open - ${timeUntil(cfpEnd)}`, class: 'cfp-open' };
+    if (cfpStatus === 'open' && (!start || now >= start) && (!end || now < end)) {
+        return { status: 'open', label: end ? `CFP Open - ${timeUntil(cfpEnd)}` : 'CFP Open', class: 'cfp-open' };
     }
 
     // Status is 'open' but dates don't match
-    if (now < start) {
+    if (start && now < start) {
         return { status: 'upcoming', label: `Opens ${formatDate(cfpStart)}`, class: 'cfp-soon' };
     }
-    if (now > end) {
+    if (end && now > end) {
         return { status: 'closed', label: 'CFP Closed', class: 'cfp-closed' };
     }
 
@@ -251,12 +263,26 @@ export const EXPERIENCE_LEVELS = [
     { value: 'all', label: 'All Levels' }
 ];
 
-// Proposal statuses (must match backend: submitted, accepted, rejected, tentative)
+// Event attendance modes (must match backend: in_person, online, hybrid)
+export const ATTENDANCE_MODES = [
+    { value: 'in_person', label: 'In-Person' },
+    { value: 'online', label: 'Online' },
+    { value: 'hybrid', label: 'Hybrid' }
+];
+
+// Attendance mode of an event, falling back to the legacy is_online flag
+export function getAttendanceMode(event) {
+    return event.attendance_mode || (event.is_online ? 'online' : 'in_person');
+}
+
+// Proposal statuses (must match backend: submitted, accepted, rejected, tentative, cancelled).
+// cancelled is set by the speaker's emergency cancel; organizers cannot choose it.
 export const PROPOSAL_STATUSES = [
     { value: 'submitted', label: 'Pending Review', class: 'bg-warning' },
     { value: 'accepted', label: 'Accepted', class: 'bg-success' },
     { value: 'rejected', label: 'Rejected', class: 'bg-danger' },
-    { value: 'tentative', label: 'Tentative', class: 'bg-secondary' }
+    { value: 'tentative', label: 'Tentative', class: 'bg-secondary' },
+    { value: 'cancelled', label: 'Cancelled', class: 'bg-dark', speakerOnly: true }
 ];
 
 // Calendar helpers
@@ -385,38 +411,3 @@ export function generateGoogleCalendarURL(event) {
 
     return `https://calendar.google.com/calendar/render?${params.toString()}`;
 }
-
-// Full list of countries (ISO 3166-1)
-export const COUNTRIES = [
-    "Afghanistan", "Albania", "Algeria", "Andorra", "Angola", "Antigua and Barbuda",
-    "Argentina", "Armenia", "Australia", "Austria", "Azerbaijan", "Bahamas", "Bahrain",
-    "Bangladesh", "Barbados", "Belarus", "Belgium", "Belize", "Benin", "Bhutan",
-    "Bolivia", "Bosnia and Herzegovina", "Botswana", "Brazil", "Brunei", "Bulgaria",
-    "Burkina Faso", "Burundi", "Cabo Verde", "Cambodia", "Cameroon", "Canada",
-    "Central African Republic", "Chad", "Chile", "China", "Colombia", "Comoros",
-    "Congo (Democratic Republic)", "Congo (Republic)", "Costa Rica", "Croatia", "Cuba",
-    "Cyprus", "Czech Republic", "Denmark", "Djibouti", "Dominica", "Dominican Republic",
-    "Ecuador", "Egypt", "El Salvador", "Equatorial Guinea", "Eritrea", "Estonia",
-    "Eswatini", "Ethiopia", "Fiji", "Finland", "France", "Gabon", "Gambia", "Georgia",
-    "Germany", "Ghana", "Greece", "Grenada", "Guatemala", "Guinea", "Guinea-Bissau",
-    "Guyana", "Haiti", "Honduras", "Hungary", "Iceland", "India", "Indonesia", "Iran",
-    "Iraq", "Ireland", "Israel", "Italy", "Ivory Coast", "Jamaica", "Japan", "Jordan",
-    "Kazakhstan", "Kenya", "Kiribati", "Kosovo", "Kuwait", "Kyrgyzstan", "Laos", "Latvia",
-    "Lebanon", "Lesotho", "Liberia", "Libya", "Liechtenstein", "Lithuania", "Luxembourg",
-    "Madagascar", "Malawi", "Malaysia", "Maldives", "Mali", "Malta", "Marshall Islands",
-    "Mauritania", "Mauritius", "Mexico", "Micronesia", "Moldova", "Monaco", "Mongolia",
-    "Montenegro", "Morocco", "Mozambique", "Myanmar", "Namibia", "Nauru", "Nepal",
-    "Netherlands", "New Zealand", "Nicaragua", "Niger", "Nigeria", "North Korea",
-    "North Macedonia", "Norway", "Oman", "Pakistan", "Palau", "Palestine", "Panama",
-    "Papua New Guinea", "Paraguay", "Peru", "Philippines", "Poland", "Portugal", "Qatar",
-    "Romania", "Russia", "Rwanda", "Saint Kitts and Nevis", "Saint Lucia",
-    "Saint Vincent and the Grenadines", "Samoa", "San Marino", "Sao Tome and Principe",
-    "Saudi Arabia", "Senegal", "Serbia", "Seychelles", "Sierra Leone", "Singapore",
-    "Slovakia", "Slovenia", "Solomon Islands", "Somalia", "South Africa", "South Korea",
-    "South Sudan", "Spain", "Sri Lanka", "Sudan", "Suriname", "Sweden", "Switzerland",
-    "Syria", "Taiwan", "Tajikistan", "Tanzania", "Thailand", "Timor-Leste", "Togo",
-    "Tonga", "Trinidad and Tobago", "Tunisia", "Turkey", "Turkmenistan", "Tuvalu",
-    "Uganda", "Ukraine", "United Arab Emirates", "United Kingdom", "United States",
-    "Uruguay", "Uzbekistan", "Vanuatu", "Vatican City", "Venezuela", "Vietnam", "Yemen",
-    "Zambia", "Zimbabwe"
-];
@@ -1,5 +1,5 @@
 // CLI installation page
-import { showLoading } from '../utils.js';
+import { showLoading, BASE_PATH } from '../utils.js';
 
 export async function CliView() {
     const main = document.getElementById('main-content');
@@ -8,7 +8,7 @@ export async function CliView() {
     main.innerHTML = `
         <div class="cli-page">
             <div class="mb-4 text-center">
-                <img src="/img/cfpninja-logo.png" alt="CFP.ninja" class="mb-3" style="max-height: 80px;">
+                <img src="${BASE_PATH}/img/cfpninja-logo.png" alt="CFP.ninja" class="mb-3" style="max-height: 80px;">
                 <h1>cfp</h1>
                 <p class="text-muted">Browse events, submit proposals, and manage your CFPs from the terminal.</p>
             </div>
@@ -2,12 +2,18 @@
 import { API, getAppConfig } from '../app.js';
 import { router } from '../router.js';
 import { toast } from '../components/toast.js';
-import { escapeHtml, slugify, showLoading, validateCheckoutUrl, COUNTRIES } from '../utils.js';
+import { escapeHtml, slugify, showLoading, validateCheckoutUrl, ATTENDANCE_MODES } from '../utils.js';
 import { renderCliCommand, attachCliCommandHandlers, buildCreateYamlCommand, updateCliCommand } from '../components/cli-command.js';
 
 export async function CreateEventView() {
     const main = document.getElementById('main-content');
-    renderCreateEventForm(main, COUNTRIES);
+    let countries = [];
+    try {
+        countries = await API.getCountries({ all: true });
+    } catch (error) {
+        console.error('Failed to load countries:', error);
+    }
+    renderCreateEventForm(main, countries);
 }
 
 function renderCreateEventForm(container, countries = []) {
@@ -59,6 +65,12 @@ function renderCreateEventForm(container, countries = []) {
                                 <div class="form-text">Markdown supported.</div>
                             </div>
 
+                            <div class="mb-3">
+                                <label for="summary" class="form-label">Summary</label>
+                                <textarea class="form-control" id="summary" name="summary" rows="2" maxlength="300"></textarea>
+                                <div class="form-text">Shown in event listings and link previews. Leave empty to use the first paragraph of the description.</div>
+                            </div>
+
                             <div class="row">
                                 <div class="col-md-6 mb-3">
                                     <label for="start_date" class="form-label">Start Date <span class="text-danger">*</span></label>
@@ -71,12 +83,11 @@ function renderCreateEventForm(container, countries = []) {
                             </div>
 
                             <div class="mb-3">
-                                <div class="form-check">
-                                    <input class="form-check-input" type="checkbox" id="is_online" name="is_online">
-                                    <label class="form-check-label" for="is_online">
-                                        Online Event
-                                    </label>
-                                </div>
+                                <label for="attendance_mode" class="form-label">Attendance</label>
+                                <select class="form-select" id="attendance_mode" name="attendance_mode">
+                                    ${ATTENDANCE_MODES.map(m => `<option value="${m.value}">${m.label}</option>`).join('')}
+                                </select>
+                                <div class="form-text">Hybrid events have a venue and can also be attended online.</div>
                             </div>
 
                             <div class="row" id="location-row">
@@ -88,7 +99,7 @@ function renderCreateEventForm(container, countries = []) {
                                     <label for="country" class="form-label">Country <span class="text-danger">*</span></label>
                                     <select class="form-select" id="country" name="country" required>
                                         <option value="">Select a country</option>
-                                        ${countries.map(c => `<option value="${escapeHtml(c)}">${escapeHtml(c)}</option>`).join('')}
+                                        ${countries.map(c => `<option value="${escapeHtml(c.code)}">${escapeHtml(c.name)}</option>`).join('')}
                                     </select>
                                 </div>
                             </div>
@@ -98,11 +109,25 @@ function renderCreateEventForm(container, countries = []) {
                                 <input type="url" class="form-control" id="website" name="website" placeholder="https://" required>
                             </div>
 
+                            <!-- Honeypot: hidden from people, filled in by bots -->
+                            <div aria-hidden="true" style="position:absolute;left:-10000px;top:auto;width:1px;height:1px;overflow:hidden;">
+                                <label for="homepage">Homepage</label>
+                                <input type="text" id="homepage" name="homepage" tabindex="-1" autocomplete="off">
+                            </div>
+
                             <div class="mb-3">
                                 <label for="terms_url" class="form-label">Terms &amp; Conditions URL (Optional)</label>
                                 <input type="url" class="form-control" id="terms_url" name="terms_url" placeholder="https://example.com/terms.pdf">
                                 <div class="form-text">Link to your event's terms and conditions document.</div>
                             </div>
+
+                            <div class="mb-3">
+                                <div class="form-check">
+                                    <input class="form-check-input" type="checkbox" id="unlisted" name="unlisted">
+                                    <label class="form-check-label" for="unlisted">Unlisted</label>
+                                </div>
+                                <div class="form-text">Leave the event out of listings and search engines. People with the link can still see it and submit proposals.</div>
+                            </div>
                         </div>
                     </div>
 
@@ -275,13 +300,14 @@ function attachFormHandlers() {
             name: formData.get('name') || undefined,
             slug: formData.get('slug') || undefined,
             description: formData.get('description') || undefined,
+            summary: formData.get('summary') || undefined,
             start_date: startDate ? new Date(startDate).toISOString() : undefined,
             end_date: endDate ? new Date(endDate).toISOString() : undefined,
             location: formData.get('location') || undefined,
             country: formData.get('country') || undefined,
             website: formData.get('website') || undefined,
             terms_url: formData.get('terms_url') || undefined,
-            is_online: formData.get('is_online') ? true : undefined,
+            attendance_mode: formData.get('attendance_mode') || undefined,
             travel_covered: formData.get('travel_covered') ? true : undefined,
             hotel_covered: formData.get('hotel_covered') ? true : undefined,
             honorarium_provided: formData.get('honorarium_provided') ? true : undefined,
@@ -299,14 +325,15 @@ function attachFormHandlers() {
     form?.addEventListener('input', updateCliPreview);
     form?.addEventListener('change', updateCliPreview);
 
-    // Toggle location/country visibility based on online checkbox
-    const isOnlineCheckbox = document.getElementById('is_online');
+    // Toggle location/country visibility based on attendance mode: only
+    // online events have no venue
+    const attendanceSelect = document.getElementById('attendance_mode');
     const locationRow = document.getElementById('location-row');
     const locationInput = document.getElementById('location');
     const countrySelect = document.getElementById('country');
 
-    isOnlineCheckbox?.addEventListener('change', () => {
-        const online = isOnlineCheckbox.checked;
+    attendanceSelect?.addEventListener('change', () => {
+        const online = attendanceSelect.value === 'online';
         locationRow.classList.toggle('d-none', online);
         locationInput.required = !online;
         countrySelect.required = !online;
@@ -394,13 +421,16 @@ function attachFormHandlers() {
             name: formData.get('name'),
             slug: formData.get('slug'),
             description: formData.get('description') || '',
+            summary: formData.get('summary') || '',
             start_date: startDate ? new Date(startDate).toISOString() : null,
             end_date: endDate ? new Date(endDate).toISOString() : null,
             location: formData.get('location') || '',
             country: formData.get('country') || '',
             website: formData.get('website') || '',
+            homepage: formData.get('homepage') || undefined,
             terms_url: formData.get('terms_url') || '',
-            is_online: !!formData.get('is_online'),
+            unlisted: !!formData.get('unlisted'),
+            attendance_mode: formData.get('attendance_mode') || 'in_person',
             travel_covered: !!formData.get('travel_covered'),
             hotel_covered: !!formData.get('hotel_covered'),
             honorarium_provided: !!formData.get('honorarium_provided'),
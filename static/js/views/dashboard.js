@@ -74,7 +74,7 @@ function renderDashboard(container, managing, submitted) {
     const exampleSlug = eventWithSlug?.slug || eventWithSlug?.Slug || '<event-slug>';
 
     const hasOpenEvents = managing.some(e => e.cfp_status === 'open');
-    const path = window.location.pathname;
+    const path = router.currentPath();
     const defaultTab = path === '/dashboard/proposals' ? 'proposals' :
                        path === '/dashboard/events' ? 'events' :
                        (hasOpenEvents ? 'events' : 'proposals');
@@ -688,7 +688,7 @@ function renderProposalsList(proposals) {
                         </div>
                         <div class="mt-2">
                             <button class="btn btn-sm btn-outline-primary me-1 view-proposal-btn" data-proposal-id="${proposalId}">View</button>
-                            ${proposal.status === 'submitted'
+                            ${proposal.status === 'submitted' || proposal.status === 'accepted' || (proposal.answers_requested_until && new Date(proposal.answers_requested_until) > new Date())
                                 ? `<a href="/proposals/${proposalId}/edit" class="btn btn-sm btn-outline-secondary me-1">Edit</a>`
                                 : `<button class="btn btn-sm btn-outline-secondary me-1" disabled title="Proposals can only be edited while in pending review">Edit</button>`}
                             ${needsPayment ? `<button class="btn btn-sm btn-warning me-1 pay-proposal-btn" data-proposal-id="${proposalId}" data-event-id="${proposal.event_id}">Complete Payment</button>` : ''}
@@ -18,7 +18,9 @@ export async function EditProposalView({ id }) {
     try {
         const proposal = await API.getProposal(id);
 
-        if (proposal.status !== 'submitted') {
+        // Accepted talks keep their logistics editable, and organizers can
+        // ask for missing answers whatever the status
+        if (proposal.status !== 'submitted' && proposal.status !== 'accepted' && !answersRequested(proposal)) {
             showError(main, 'Proposals can only be edited while in pending review status.');
             return;
         }
@@ -31,8 +33,14 @@ export async function EditProposalView({ id }) {
     }
 }
 
+function answersRequested(proposal) {
+    return !!proposal.answers_requested_until && new Date(proposal.answers_requested_until) > new Date();
+}
+
 function renderEditForm(container, proposal, event) {
     const proposalId = proposal.ID || proposal.id;
+    // Once accepted, the talk and who gives it are locked in
+    const locked = proposal.status === 'accepted' ? 'disabled' : '';
 
     // Parse custom questions from the event
     let customQuestions = [];
@@ -84,6 +92,12 @@ function renderEditForm(container, proposal, event) {
 
                 <h1 class="mb-4">Edit Proposal</h1>
                 <p class="text-muted mb-4">Editing <strong>${escapeHtml(proposal.title)}</strong></p>
+                ${proposal.status === 'accepted' ? `
+                    <div class="alert alert-info">Your talk has been accepted. You can still update your speaker details, slides and video links, and notes for the organizers.</div>
+                ` : proposal.status !== 'submitted' ? `
+                    <div class="alert alert-info">The organizers asked you to answer their new questions. Only your answers will be saved.</div>
+                ` : ''}
+                <div id="edit-lock-alert"></div>
 
                 <form id="edit-proposal-form">
                     <div class="card mb-4">
@@ -93,20 +107,20 @@ function renderEditForm(container, proposal, event) {
                         <div class="card-body">
                             <div class="mb-3">
                                 <label for="title" class="form-label">Title <span class="text-danger">*</span></label>
-                                <input type="text" class="form-control" id="title" name="title" required maxlength="200" value="${escapeHtml(proposal.title || '')}">
+                                <input type="text" class="form-control" id="title" name="title" required maxlength="200" value="${escapeHtml(proposal.title || '')}" ${locked}>
                                 <div class="form-text">A concise, descriptive title for your talk.</div>
                             </div>
 
                             <div class="mb-3">
                                 <label for="abstract" class="form-label">Abstract <span class="text-danger">*</span></label>
-                                <textarea class="form-control" id="abstract" name="abstract" rows="6" required>${escapeHtml(proposal.abstract || '')}</textarea>
+                                <textarea class="form-control" id="abstract" name="abstract" rows="6" required ${locked}>${escapeHtml(proposal.abstract || '')}</textarea>
                                 <div class="form-text">Describe your talk. Markdown supported. This will be shown to attendees if accepted.</div>
                             </div>
 
                             <div class="row">
                                 <div class="col-md-4 mb-3">
                                     <label for="format" class="form-label">Format <span class="text-danger">*</span></label>
-                                    <select class="form-select" id="format" name="format" required>
+                                    <select class="form-select" id="format" name="format" required ${locked}>
                                         ${TALK_FORMATS.map(f =>
                                             `<option value="${escapeHtml(f.value)}" ${proposal.format === f.value ? 'selected' : ''}>${escapeHtml(f.label)}</option>`
                                         ).join('')}
@@ -115,14 +129,14 @@ function renderEditForm(container, proposal, event) {
 
                                 <div class="col-md-4 mb-3">
                                     <label for="duration" class="form-label">Duration <span class="text-danger">*</span></label>
-                                    <select class="form-select" id="duration" name="duration" required>
+                                    <select class="form-select" id="duration" name="duration" required ${locked}>
                                         ${[15, 30, 45, 60, 90].map(d => `<option value="${d}" ${proposal.duration === d ? 'selected' : ''}>${d} minutes</option>`).join('')}
                                     </select>
                                 </div>
 
                                 <div class="col-md-4 mb-3">
                                     <label for="level" class="form-label">Level <span class="text-danger">*</span></label>
-                                    <select class="form-select" id="level" name="level" required>
+                                    <select class="form-select" id="level" name="level" required ${locked}>
                                         ${EXPERIENCE_LEVELS.map(l =>
                                             `<option value="${escapeHtml(l.value)}" ${proposal.level === l.value ? 'selected' : ''}>${escapeHtml(l.label)}</option>`
                                         ).join('')}
@@ -130,6 +144,17 @@ function renderEditForm(container, proposal, event) {
                                 </div>
                             </div>
 
+                            <div class="row">
+                                <div class="col-md-6 mb-3">
+                                    <label for="slides_url" class="form-label">Slides URL (Optional)</label>
+                                    <input type="url" class="form-control" id="slides_url" name="slides_url" maxlength="2000" value="${escapeHtml(proposal.slides_url || '')}">
+                                </div>
+                                <div class="col-md-6 mb-3">
+                                    <label for="video_url" class="form-label">Video URL (Optional)</label>
+                                    <input type="url" class="form-control" id="video_url" name="video_url" maxlength="2000" value="${escapeHtml(proposal.video_url || '')}">
+                                </div>
+                            </div>
+
                             <div class="mb-3">
                                 <label for="notes" class="form-label">Speaker Notes (Optional)</label>
                                 <textarea class="form-control" id="notes" name="notes" rows="4">${escapeHtml(proposal.speaker_notes || '')}</textarea>
@@ -141,7 +166,7 @@ function renderEditForm(container, proposal, event) {
                     <div class="card mb-4">
                         <div class="card-header d-flex justify-content-between align-items-center">
                             <h5 class="mb-0">Speakers</h5>
-                            <button type="button" class="btn btn-sm btn-success" id="add-speaker">+ Add Speaker</button>
+                            ${locked ? '' : '<button type="button" class="btn btn-sm btn-success" id="add-speaker">+ Add Speaker</button>'}
                         </div>
                         <div class="card-body">
                             <div id="speakers-container">
@@ -187,6 +212,13 @@ function renderEditForm(container, proposal, event) {
         setVal(`speaker_job_title_${i}`, speaker.job_title);
         setVal(`speaker_company_${i}`, speaker.company);
         setVal(`speaker_linkedin_${i}`, speaker.linkedin);
+        if (locked) {
+            ['name', 'email', 'linkedin'].forEach(field => {
+                const el = form.querySelector(`[name="speaker_${field}_${i}"]`);
+                if (el) el.readOnly = true;
+            });
+            form.querySelector('.remove-speaker')?.remove();
+        }
     });
 
     // Pre-populate custom answers
@@ -240,6 +272,29 @@ function attachEditFormHandlers(proposalId, initialSpeakerCount, customQuestions
         }
     });
 
+    // Hold the edit lock while the form is open so others editing the
+    // proposal are warned, renewing it before it lapses. If someone else
+    // holds it, say who; saving then overrides their lock.
+    let force = false;
+    const lockAlert = document.getElementById('edit-lock-alert');
+    const holdLock = async () => {
+        if (!document.body.contains(form)) {
+            clearInterval(lockTimer);
+            return;
+        }
+        try {
+            await API.lockProposal(proposalId);
+            force = false;
+            lockAlert.innerHTML = '';
+        } catch (error) {
+            if (error.status !== 423) return;
+            force = true;
+            lockAlert.innerHTML = `<div class="alert alert-warning">${escapeHtml(error.message)}. Saving will overwrite their changes.</div>`;
+        }
+    };
+    const lockTimer = setInterval(holdLock, 4 * 60 * 1000);
+    holdLock();
+
     // Form submission
     form?.addEventListener('submit', async (e) => {
         e.preventDefault();
@@ -284,6 +339,8 @@ function attachEditFormHandlers(proposalId, initialSpeakerCount, customQuestions
             duration: parseInt(formData.get('duration')),
             level: formData.get('level'),
             speaker_notes: formData.get('notes') || '',
+            slides_url: formData.get('slides_url') || '',
+            video_url: formData.get('video_url') || '',
             speakers,
             custom_answers: customAnswers
         };
@@ -292,7 +349,8 @@ function attachEditFormHandlers(proposalId, initialSpeakerCount, customQuestions
             submitBtn.disabled = true;
             submitBtn.textContent = 'Saving...';
 
-            await API.updateProposal(proposalId, data);
+            await API.updateProposal(proposalId, data, { force });
+            API.unlockProposal(proposalId).catch(() => {});
             toast.success('Proposal updated successfully!');
             router.navigate('/dashboard/proposals');
         } catch (error) {
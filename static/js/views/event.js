@@ -1,6 +1,7 @@
 // Event detail view
 import { API, Auth, getAppConfig } from '../app.js';
 import { router } from '../router.js';
+import { toast } from '../components/toast.js';
 import {
     escapeHtml,
     escapeAttr,
@@ -20,7 +21,8 @@ export async function EventDetailView({ slug }) {
     showLoading(main);
 
     try {
-        const event = await API.getEventBySlug(slug);
+        const previewToken = new URLSearchParams(window.location.search).get('preview_token');
+        const event = await API.getEventBySlug(slug, previewToken);
         renderEventDetail(main, event);
     } catch (error) {
         console.error('Error loading event:', error);
@@ -38,6 +40,16 @@ function renderEventDetail(container, event) {
     updateMetaTags(event);
 
     container.innerHTML = `
+        ${event.draft ? `
+            <div class="alert alert-warning" role="alert">
+                <strong>Draft preview.</strong> Only organizers and people with a preview link can see this page until the CFP leaves draft.
+            </div>
+        ` : ''}
+        ${event.archived ? `
+            <div class="alert alert-secondary" role="alert">
+                <strong>Archived event.</strong> This event ended some time ago and no longer appears in listings.
+            </div>
+        ` : ''}
         <div class="event-header">
             <div class="d-flex justify-content-between align-items-start flex-wrap gap-3">
                 <div>
@@ -145,6 +157,26 @@ function renderEventDetail(container, event) {
         });
     }
 
+    // Attach notify-me button handler
+    const notifyBtn = container.querySelector('#notify-me-btn');
+    if (notifyBtn) {
+        notifyBtn.addEventListener('click', async () => {
+            if (!isLoggedIn) {
+                Auth.login();
+                return;
+            }
+            notifyBtn.disabled = true;
+            try {
+                await API.notifyMe(event.slug);
+                notifyBtn.textContent = "We'll email you when it opens";
+                toast.success("We'll email you when the CFP opens.");
+            } catch (error) {
+                notifyBtn.disabled = false;
+                toast.error(error.message || 'Failed to register.');
+            }
+        });
+    }
+
     // Attach ICS download handler
     const icsBtn = container.querySelector('#download-ics-btn');
     if (icsBtn) {
@@ -245,16 +277,26 @@ function renderCfpInfo(event, cfpStatus, isLoggedIn, cfpStart, cfpEnd) {
                 <button class="btn btn-secondary w-100" disabled>
                     Opens ${escapeHtml(formatDate(cfpStart))}
                 </button>
+                ${renderNotifyMeButton(isLoggedIn)}
             ` : `
                 <button class="btn btn-secondary w-100" disabled>
                     CFP Closed
                 </button>
+                ${renderNotifyMeButton(isLoggedIn)}
                 ${event.contact_email ? `<a href="mailto:${escapeAttr(event.contact_email)}" class="d-block text-center mt-2 small text-muted text-decoration-none">Reach out to the organisers</a>` : ''}
             `}
         </div>
     `;
 }
 
+function renderNotifyMeButton(isLoggedIn) {
+    return `
+        <button class="btn btn-outline-primary w-100 mt-2" id="notify-me-btn">
+            ${isLoggedIn ? 'Notify me when the CFP opens' : 'Login to get notified when the CFP opens'}
+        </button>
+    `;
+}
+
 function formatDescription(text) {
     if (!text) return '';
     // Use marked.js for Markdown rendering if available, but ONLY if DOMPurify is also loaded
@@ -283,9 +325,7 @@ function isOrganizer(event) {
 
 function updateMetaTags(event) {
     const title = `${event.name} - Submit to CFP | CFP.ninja`;
-    const description = event.description
-        ? event.description.substring(0, 200) + (event.description.length > 200 ? '...' : '')
-        : `Submit your talk proposal to ${event.name}`;
+    const description = event.summary || `Submit your talk proposal to ${event.name}`;
     const url = window.location.href;
 
     // Update document title
@@ -322,4 +362,12 @@ function updateMetaTags(event) {
     if (event.location && event.country) {
         setMeta('og:locale', 'en_US');
     }
+
+    // Unlisted events are shared by link only and archived events are stale,
+    // so keep them out of search engines
+    if (event.unlisted || event.archived) {
+        setMeta('robots', 'noindex', true);
+    } else {
+        document.querySelector('meta[name="robots"]')?.remove();
+    }
 }
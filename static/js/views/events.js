@@ -3,7 +3,7 @@ import { API, Auth } from '../app.js';
 import { renderEventCards } from '../components/event-card.js';
 import { renderFilters, attachFilterHandlers } from '../components/filters.js';
 import { renderPagination } from '../components/pagination.js';
-import { showLoading, buildQueryString } from '../utils.js';
+import { showLoading, buildQueryString, BASE_PATH } from '../utils.js';
 import { renderCliCommand, attachCliCommandHandlers, updateCliCommand, buildEventsCommand } from '../components/cli-command.js';
 
 const PAGE_SIZE = 12;
@@ -97,7 +97,7 @@ function renderEventsPage(container, events, filters, currentPage, totalPages, c
         <div id="pagination-container" class="mt-4"></div>
 
         <div class="text-center mt-1 mb-1">
-            <img src="/img/cfpninja-logo.png" alt="CFP.ninja" class="opacity-75 logo-spin logo-hero">
+            <img src="${BASE_PATH}/img/cfpninja-logo.png" alt="CFP.ninja" class="opacity-75 logo-spin logo-hero">
         </div>
     `;
 
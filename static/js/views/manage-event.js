@@ -10,7 +10,9 @@ import {
     showError,
     formatDateForInput,
     formatDateTimeForInput,
-    validateCheckoutUrl
+    validateCheckoutUrl,
+    getAttendanceMode,
+    ATTENDANCE_MODES
 } from '../utils.js';
 import { renderCliCommand, attachCliCommandHandlers, buildEventYamlExport, updateCliCommand } from '../components/cli-command.js';
 
@@ -21,7 +23,7 @@ export async function ManageEventView({ id }, query) {
     try {
         const [event, countries] = await Promise.all([
             API.getEventForOrganizer(id),
-            API.getCountries()
+            API.getCountries({ all: true })
         ]);
         renderManageEventForm(main, event, countries);
 
@@ -36,7 +38,7 @@ export async function ManageEventView({ id }, query) {
                 try {
                     const [freshEvent, freshCountries] = await Promise.all([
                         API.getEventForOrganizer(id),
-                        API.getCountries()
+                        API.getCountries({ all: true })
                     ]);
                     renderManageEventForm(main, freshEvent, freshCountries);
                     if (freshEvent.is_paid) {
@@ -99,6 +101,7 @@ function renderManageEventForm(container, event, countries = []) {
                     <div>
                         <h1 class="mb-2">Manage Event</h1>
                         <span class="cfp-status ${cfpStatus.class}">${escapeHtml(cfpStatus.label)}</span>
+                        ${event.notify_me_count ? `<span class="text-muted small ms-2">${event.notify_me_count} waiting for the CFP to open</span>` : ''}
                     </div>
                     <button class="btn btn-outline-danger btn-sm" id="delete-event-btn">Delete Event</button>
                 </div>
@@ -158,6 +161,12 @@ function renderManageEventForm(container, event, countries = []) {
                                 <textarea class="form-control" id="description" name="description" rows="4">${escapeHtml(event.description || '')}</textarea>
                             </div>
 
+                            <div class="mb-3">
+                                <label for="summary" class="form-label">Summary</label>
+                                <textarea class="form-control" id="summary" name="summary" rows="2" maxlength="300">${escapeHtml(event.summary || '')}</textarea>
+                                <div class="form-text">Shown in event listings and link previews. Leave empty to use the first paragraph of the description.</div>
+                            </div>
+
                             <div class="row">
                                 <div class="col-md-6 mb-3">
                                     <label for="start_date" class="form-label">Start Date <span class="text-danger">*</span></label>
@@ -170,15 +179,14 @@ function renderManageEventForm(container, event, countries = []) {
                             </div>
 
                             <div class="mb-3">
-                                <div class="form-check">
-                                    <input class="form-check-input" type="checkbox" id="is_online" name="is_online" ${event.is_online ? 'checked' : ''}>
-                                    <label class="form-check-label" for="is_online">
-                                        Online Event
-                                    </label>
-                                </div>
+                                <label for="attendance_mode" class="form-label">Attendance</label>
+                                <select class="form-select" id="attendance_mode" name="attendance_mode">
+                                    ${ATTENDANCE_MODES.map(m => `<option value="${m.value}" ${getAttendanceMode(event) === m.value ? 'selected' : ''}>${m.label}</option>`).join('')}
+                                </select>
+                                <div class="form-text">Hybrid events have a venue and can also be attended online.</div>
                             </div>
 
-                            <div class="row ${event.is_online ? 'd-none' : ''}" id="location-row">
+                            <div class="row ${getAttendanceMode(event) === 'online' ? 'd-none' : ''}" id="location-row">
                                 <div class="col-md-6 mb-3">
                                     <label for="location" class="form-label">Location (City/Venue)</label>
                                     <input type="text" class="form-control" id="location" name="location" value="${escapeHtml(event.location || '')}">
@@ -187,7 +195,7 @@ function renderManageEventForm(container, event, countries = []) {
                                     <label for="country" class="form-label">Country</label>
                                     <select class="form-select" id="country" name="country">
                                         <option value="">Select a country</option>
-                                        ${countries.map(c => `<option value="${escapeHtml(c)}" ${event.country === c ? 'selected' : ''}>${escapeHtml(c)}</option>`).join('')}
+                                        ${countries.map(c => `<option value="${escapeHtml(c.code)}" ${event.country_code === c.code ? 'selected' : ''}>${escapeHtml(c.name)}</option>`).join('')}
                                     </select>
                                 </div>
                             </div>
@@ -203,6 +211,24 @@ function renderManageEventForm(container, event, countries = []) {
                                 <div class="form-text">Link to your event's terms and conditions document.</div>
                             </div>
 
+                            <div class="mb-3">
+                                <div class="form-check">
+                                    <input class="form-check-input" type="checkbox" id="unlisted" name="unlisted" ${event.unlisted ? 'checked' : ''}>
+                                    <label class="form-check-label" for="unlisted">Unlisted</label>
+                                </div>
+                                <div class="form-text">Leave the event out of listings and search engines. People with the link can still see it and submit proposals.</div>
+                            </div>
+
+                            <div class="mb-3">
+                                <label for="series" class="form-label">Series (Optional)</label>
+                                <input type="text" class="form-control" id="series" name="series" value="${escapeHtml(event.series || '')}" placeholder="sreday-london">
+                                <div class="form-text">Your events with the same series are editions of one conference.</div>
+                                <div class="form-check mt-2">
+                                    <input class="form-check-input" type="checkbox" id="flag_series_duplicates" name="flag_series_duplicates" ${event.flag_series_duplicates ? 'checked' : ''}>
+                                    <label class="form-check-label" for="flag_series_duplicates">Flag talks also submitted to other editions</label>
+                                </div>
+                            </div>
+
                             <div class="mb-3">
                                 <label for="contact_email" class="form-label">Contact Email (Optional)</label>
                                 <input type="email" class="form-control" id="contact_email" name="contact_email" value="${escapeHtml(event.contact_email || '')}" placeholder="organizer@example.com">
@@ -327,6 +353,19 @@ function renderManageEventForm(container, event, countries = []) {
                         </div>
                     </div>
 
+                    ${event.cfp_status === 'draft' && isEventCreator(event) ? `
+                        <div class="card mb-4">
+                            <div class="card-header d-flex justify-content-between align-items-center">
+                                <h5 class="mb-0">Preview Links</h5>
+                                <button type="button" class="btn btn-sm btn-outline-primary" id="create-preview-link-btn">+ New Link</button>
+                            </div>
+                            <div class="card-body">
+                                <p class="form-text mt-0">Share the draft event page with people who don't have an account. Links expire after 7 days and never show proposals or organisers.</p>
+                                <div id="preview-links-list"></div>
+                            </div>
+                        </div>
+                    ` : ''}
+
                     <div class="d-flex gap-3 mb-4">
                         <button type="submit" class="btn btn-primary">Save Changes</button>
                         <a href="/dashboard" class="btn btn-outline-secondary">Cancel</a>
@@ -388,6 +427,72 @@ function renderQuestionForm(index, question = null) {
     `;
 }
 
+function isEventCreator(event) {
+    const currentUser = Auth.getUser();
+    return !!currentUser && event.created_by_id === currentUser.id;
+}
+
+async function loadPreviewLinks(eventId) {
+    const container = document.getElementById('preview-links-list');
+    if (!container) return;
+
+    try {
+        const links = await API.getPreviewLinks(eventId);
+        if (links.length === 0) {
+            container.innerHTML = '<p class="text-muted mb-0">No preview links yet.</p>';
+            return;
+        }
+
+        let html = '<ul class="list-group">';
+        for (const link of links) {
+            html += `
+                <li class="list-group-item d-flex justify-content-between align-items-center gap-2">
+                    <div class="text-truncate">
+                        <code class="small">${escapeHtml(link.url)}</code>
+                        <div class="text-muted small">
+                            Created ${escapeHtml(new Date(link.created_at).toLocaleDateString())}
+                            &middot; ${link.expired ? '<span class="text-danger">Expired</span>' : `Expires ${escapeHtml(new Date(link.expires_at).toLocaleDateString())}`}
+                        </div>
+                    </div>
+                    <div class="btn-group flex-shrink-0">
+                        ${link.expired ? '' : `<button type="button" class="btn btn-sm btn-outline-secondary btn-copy-preview-link" data-url="${escapeAttr(link.url)}">Copy</button>`}
+                        <button type="button" class="btn btn-sm btn-outline-danger btn-revoke-preview-link" data-link-id="${link.id}">Revoke</button>
+                    </div>
+                </li>`;
+        }
+        html += '</ul>';
+        container.innerHTML = html;
+
+        container.querySelectorAll('.btn-copy-preview-link').forEach(btn => {
+            btn.addEventListener('click', async () => {
+                try {
+                    await navigator.clipboard.writeText(btn.dataset.url);
+                    toast.success('Preview link copied.');
+                } catch (err) {
+                    toast.error('Could not copy the link.');
+                }
+            });
+        });
+
+        container.querySelectorAll('.btn-revoke-preview-link').forEach(btn => {
+            btn.addEventListener('click', async () => {
+                try {
+                    btn.disabled = true;
+                    await API.revokePreviewLink(eventId, btn.dataset.linkId);
+                    toast.success('Preview link revoked.');
+                    loadPreviewLinks(eventId);
+                } catch (err) {
+                    toast.error(err.message || 'Failed to revoke preview link.');
+                    btn.disabled = false;
+                }
+            });
+        });
+    } catch (err) {
+        container.innerHTML = '<p class="text-muted">Failed to load preview links.</p>';
+        console.error('Error loading preview links:', err);
+    }
+}
+
 async function loadOrganisers(eventId) {
     const container = document.getElementById('organisers-list');
     if (!container) return;
@@ -407,6 +512,7 @@ async function loadOrganisers(eventId) {
                         ${org.is_creator ? '<span class="badge bg-secondary ms-2">Creator</span>' : ''}
                     </div>
                     ${isCreator && !org.is_creator ? `<button type="button" class="btn btn-sm btn-outline-danger btn-remove-organiser" data-user-id="${org.id}">Remove</button>` : ''}
+                    ${!org.is_creator && currentUser && org.id === currentUser.id ? '<button type="button" class="btn btn-sm btn-outline-danger" id="leave-event-btn">Leave</button>' : ''}
                 </li>`;
         }
         html += '</ul>';
@@ -441,6 +547,21 @@ async function loadOrganisers(eventId) {
             });
         });
 
+        // Co-organizers can remove themselves
+        const leaveBtn = document.getElementById('leave-event-btn');
+        leaveBtn?.addEventListener('click', async () => {
+            if (!confirm('Leave this event? You will lose access to it and its proposals.')) return;
+            try {
+                leaveBtn.disabled = true;
+                await API.leaveEvent(eventId);
+                toast.success('You left the event.');
+                router.navigate('/dashboard/events');
+            } catch (err) {
+                toast.error(err.message || 'Failed to leave the event.');
+                leaveBtn.disabled = false;
+            }
+        });
+
         // Attach add handler
         const addBtn = document.getElementById('add-organiser-btn');
         const emailInput = document.getElementById('organiser-email');
@@ -511,12 +632,13 @@ function attachFormHandlers(event, initialQuestionCount) {
             name: formData.get('name') || undefined,
             slug: formData.get('slug') || undefined,
             description: formData.get('description') || undefined,
+            summary: formData.get('summary') || undefined,
             start_date: startDate ? new Date(startDate).toISOString() : undefined,
             end_date: endDate ? new Date(endDate).toISOString() : undefined,
             location: formData.get('location') || undefined,
             country: formData.get('country') || undefined,
             website: formData.get('website') || undefined,
-            is_online: formData.get('is_online') ? true : undefined,
+            attendance_mode: formData.get('attendance_mode') || undefined,
             contact_email: formData.get('contact_email') || undefined,
             travel_covered: formData.get('travel_covered') ? true : undefined,
             hotel_covered: formData.get('hotel_covered') ? true : undefined,
@@ -550,12 +672,12 @@ function attachFormHandlers(event, initialQuestionCount) {
         }
     });
 
-    // Toggle location/country visibility based on online checkbox
-    const isOnlineCheckbox = document.getElementById('is_online');
+    // Toggle location/country visibility based on attendance mode
+    const attendanceSelect = document.getElementById('attendance_mode');
     const locationRow = document.getElementById('location-row');
 
-    isOnlineCheckbox?.addEventListener('change', () => {
-        locationRow.classList.toggle('d-none', isOnlineCheckbox.checked);
+    attendanceSelect?.addEventListener('change', () => {
+        locationRow.classList.toggle('d-none', attendanceSelect.value === 'online');
     });
 
     // Update CLI preview on any form change
@@ -597,7 +719,20 @@ function attachFormHandlers(event, initialQuestionCount) {
         }
 
         try {
-            await API.deleteEvent(eventId);
+            try {
+                await API.deleteEvent(eventId);
+            } catch (error) {
+                if (error.code !== 'confirmation_required') {
+                    throw error;
+                }
+                // Events with proposals are only deleted when confirmed with the slug
+                const { proposal_total: total, slug } = error.details;
+                const typed = prompt(`"${event.name}" has ${total} proposal${total === 1 ? '' : 's'} that will be deleted with it. Export them first if you need them.\n\nType the event slug (${slug}) to confirm:`);
+                if (typed === null) {
+                    return;
+                }
+                await API.deleteEvent(eventId, typed.trim());
+            }
             toast.success('Event deleted.');
             router.navigate('/dashboard');
         } catch (error) {
@@ -646,13 +781,17 @@ function attachFormHandlers(event, initialQuestionCount) {
             name: formData.get('name'),
             slug: formData.get('slug'),
             description: formData.get('description') || '',
+            summary: formData.get('summary') || '',
             start_date: startDate ? new Date(startDate).toISOString() : null,
             end_date: endDate ? new Date(endDate).toISOString() : null,
             location: formData.get('location') || '',
             country: formData.get('country') || '',
             website: formData.get('website') || '',
             terms_url: formData.get('terms_url') || '',
-            is_online: !!formData.get('is_online'),
+            unlisted: !!formData.get('unlisted'),
+            series: formData.get('series') || '',
+            flag_series_duplicates: !!formData.get('flag_series_duplicates'),
+            attendance_mode: formData.get('attendance_mode') || 'in_person',
             contact_email: formData.get('contact_email') || '',
             travel_covered: !!formData.get('travel_covered'),
             hotel_covered: !!formData.get('hotel_covered'),
@@ -684,4 +823,19 @@ function attachFormHandlers(event, initialQuestionCount) {
 
     // Load organisers asynchronously
     loadOrganisers(eventId);
+
+    const createPreviewBtn = document.getElementById('create-preview-link-btn');
+    createPreviewBtn?.addEventListener('click', async () => {
+        try {
+            createPreviewBtn.disabled = true;
+            await API.createPreviewLink(eventId, 7);
+            toast.success('Preview link created.');
+            loadPreviewLinks(eventId);
+        } catch (err) {
+            toast.error(err.message || 'Failed to create preview link.');
+        } finally {
+            createPreviewBtn.disabled = false;
+        }
+    });
+    loadPreviewLinks(eventId);
 }
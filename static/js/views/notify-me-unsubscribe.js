@@ -0,0 +1,37 @@
+// Unsubscribe from a "notify me when the CFP opens" email
+import { API } from '../app.js';
+import { escapeHtml, showLoading } from '../utils.js';
+
+export async function NotifyMeUnsubscribeView() {
+    const main = document.getElementById('main-content');
+    const token = new URLSearchParams(window.location.search).get('token');
+
+    const render = (title, message) => {
+        main.innerHTML = `
+            <div class="row justify-content-center py-5">
+                <div class="col-sm-8 col-md-6 col-lg-5">
+                    <div class="card">
+                        <div class="card-body p-4 text-center">
+                            <h1 class="h4 mb-3">${escapeHtml(title)}</h1>
+                            <p class="text-muted">${escapeHtml(message)}</p>
+                            <a href="/" class="btn btn-primary">Browse events</a>
+                        </div>
+                    </div>
+                </div>
+            </div>
+        `;
+    };
+
+    if (!token) {
+        render('Invalid link', 'This unsubscribe link is missing its token.');
+        return;
+    }
+
+    showLoading(main);
+    try {
+        await API.unsubscribeNotifyMe(token);
+        render('Unsubscribed', "You won't be emailed when this CFP opens.");
+    } catch (error) {
+        render('Could not unsubscribe', error.message || 'Please try again later.');
+    }
+}
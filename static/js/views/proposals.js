@@ -6,6 +6,7 @@ import {
     escapeHtml,
     sanitizeUrl,
     formatDate,
+    formatDateTime,
     showLoading,
     showError,
     truncate,
@@ -230,6 +231,7 @@ function renderProposalItem(proposal) {
                     <p class="text-muted small mb-2">${escapeHtml(truncate((proposal.abstract || '').split('\n')[0], 150))}</p>
                     <div class="d-flex flex-wrap gap-2 align-items-center">
                         <span class="badge ${statusInfo.class}">${escapeHtml(statusInfo.label)}</span>
+                        ${(proposal.missing_required_answers || []).length > 0 ? '<span class="badge bg-warning text-dark">Missing answers</span>' : ''}
                         ${status === 'accepted' ? (proposal.attendance_confirmed
                             ? '<span class="badge bg-success">&#10003; Attendance Confirmed</span>'
                             : '<span class="badge bg-warning text-dark">&#9203; Awaiting Confirmation</span>'
@@ -250,6 +252,11 @@ function renderProposalItem(proposal) {
                             return display;
                         }).join('; ')}</div>
                     ` : ''}
+                    ${(proposal.also_submitted_to || []).length > 0 ? `
+                        <div class="text-muted small mt-1">Also submitted to ${proposal.also_submitted_to.map(s =>
+                            `<a href="/e/${encodeURIComponent(s.event_slug)}">${escapeHtml(s.event_name)}</a> (${escapeHtml(s.status)})`
+                        ).join(', ')}</div>
+                    ` : ''}
                 </div>
                 <div class="d-flex flex-column align-items-end gap-2">
                     ${renderRating(proposal.rating)}
@@ -342,6 +349,20 @@ function renderProposalModal(proposal) {
                 `).join('')}
             </div>
 
+            ${anonymousMode ? '' : `
+                <div id="speaker-history" class="mb-4"></div>
+            `}
+
+            ${(proposal.missing_required_answers || []).length > 0 ? `
+                <div class="alert alert-warning">
+                    No answer to the required ${proposal.missing_required_answers.map(escapeHtml).join(', ')}.
+                    ${proposal.answers_requested_until
+                        ? `<span class="d-block small">Requested from the speaker until ${escapeHtml(formatDateTime(proposal.answers_requested_until))}.</span>`
+                        : ''}
+                    <button type="button" class="btn btn-sm btn-outline-dark mt-2 request-answers-btn">Request update</button>
+                </div>
+            ` : ''}
+
             ${Object.keys(customAnswers).length > 0 ? `
                 <h6>Additional Answers</h6>
                 <dl class="mb-4">
@@ -355,7 +376,8 @@ function renderProposalModal(proposal) {
             ${status === 'accepted' ? `
                 <h6>Attendance</h6>
                 <p class="mb-4">${proposal.attendance_confirmed
-                    ? '<span class="badge bg-success">&#10003; Attendance Confirmed</span>'
+                    ? `<span class="badge bg-success">&#10003; Attendance Confirmed</span>${proposal.attendance_confirmed_at
+                        ? ` <span class="text-muted small">${escapeHtml(formatDateTime(proposal.attendance_confirmed_at))}</span>` : ''}`
                     : '<span class="badge bg-warning text-dark">&#9203; Awaiting Confirmation</span>'
                 }</p>
             ` : ''}
@@ -369,7 +391,7 @@ function renderProposalModal(proposal) {
 
             <h6>Update Status</h6>
             <div class="btn-group" role="group">
-                ${PROPOSAL_STATUSES.map(s => `
+                ${PROPOSAL_STATUSES.filter(s => !s.speakerOnly).map(s => `
                     <button type="button" class="btn btn-sm ${status === s.value ? s.class : 'btn-outline-secondary'} status-btn" data-status="${s.value}">
                         ${escapeHtml(s.label)}
                     </button>
@@ -382,11 +404,32 @@ function renderProposalModal(proposal) {
     `;
 }
 
+// renderSpeakerHistory lists the speaker's proposals to the organizer's other events
+function renderSpeakerHistory(history) {
+    if (history.length === 0) return '';
+    return `
+        <h6>Speaker History</h6>
+        <ul class="list-unstyled small mb-0">
+            ${history.map(h => {
+                const statusInfo = PROPOSAL_STATUSES.find(s => s.value === h.status) || PROPOSAL_STATUSES[0];
+                return `
+                    <li class="mb-1">
+                        <span class="badge ${statusInfo.class} me-1">${escapeHtml(statusInfo.label)}</span>
+                        <strong>${escapeHtml(h.title)}</strong>
+                        <span class="text-muted">at ${escapeHtml(h.event_name)}</span>
+                        ${h.rating ? `<span class="text-warning ms-1">${'★'.repeat(h.rating)}</span>` : ''}
+                    </li>
+                `;
+            }).join('')}
+        </ul>
+    `;
+}
+
 function downloadCSV(eventId, format) {
     const controller = new AbortController();
     const timeoutId = setTimeout(() => controller.abort(), 30000); // 30s timeout
 
-    fetch(`/api/v0/events/${eventId}/proposals/export?format=${format}`, {
+    fetch(`${API.baseUrl}/events/${eventId}/proposals/export?format=${format}`, {
         signal: controller.signal
     })
     .then(resp => {
@@ -481,6 +524,16 @@ function attachHandlers(event, allProposals) {
         currentProposal = proposal;
         modalContent.innerHTML = renderProposalModal(proposal);
 
+        // Fetched on open, as it searches the organizer's other events
+        if (!anonymousMode) {
+            API.getProposal(proposalId, { expand: 'speaker_history' }).then(detail => {
+                const historyEl = document.getElementById('speaker-history');
+                if (historyEl && currentProposal === proposal) {
+                    historyEl.innerHTML = renderSpeakerHistory(detail.speaker_history || []);
+                }
+            }).catch(error => console.error('Error loading speaker history:', error));
+        }
+
         // Simple modal show (without Bootstrap JS)
         modal.style.display = 'block';
         modal.classList.add('show');
@@ -563,6 +616,22 @@ function attachHandlers(event, allProposals) {
             return;
         }
 
+        // Ask the speaker for missing answers
+        const requestBtn = e.target.closest('.request-answers-btn');
+        if (requestBtn && currentProposal) {
+            const proposalId = currentProposal.ID || currentProposal.id;
+            requestBtn.disabled = true;
+            try {
+                const updated = await API.requestProposalAnswers(proposalId);
+                currentProposal.answers_requested_until = updated.answers_requested_until;
+                toast.success('The speaker was asked to fill in their answers.');
+            } catch (error) {
+                toast.error(error.message || 'Failed to request an update.');
+                requestBtn.disabled = false;
+            }
+            return;
+        }
+
         // Status update
         const statusBtn = e.target.closest('.status-btn');
         if (statusBtn && currentProposal) {
@@ -7,6 +7,7 @@ import {
     showLoading,
     showError,
     validateCheckoutUrl,
+    getAttendanceMode,
     TALK_FORMATS,
     EXPERIENCE_LEVELS
 } from '../utils.js';
@@ -58,6 +59,16 @@ export async function SubmitProposalView({ slug }) {
     }
 }
 
+// abstractWordGuidance describes the event's abstract word limits, if any
+function abstractWordGuidance(event) {
+    const min = event.abstract_min_words;
+    const max = event.abstract_max_words;
+    if (min && max) return ` This event asks for ${min}-${max} words.`;
+    if (min) return ` This event asks for at least ${min} words.`;
+    if (max) return ` This event asks for at most ${max} words.`;
+    return '';
+}
+
 function renderSubmitForm(container, event, myProposalCount, maxProposals) {
     // Parse CFP questions from the event (JSONB field)
     let customQuestions = [];
@@ -77,6 +88,8 @@ function renderSubmitForm(container, event, myProposalCount, maxProposals) {
             console.error('Error parsing cfp_questions:', e);
         }
     }
+    // Questions outside their own submission window don't take answers
+    customQuestions = customQuestions.filter(q => q.active !== false);
 
     const user = Auth.getUser();
     const eventId = event.ID || event.id;
@@ -123,7 +136,7 @@ function renderSubmitForm(container, event, myProposalCount, maxProposals) {
                             <div class="mb-3">
                                 <label for="abstract" class="form-label">Abstract <span class="text-danger">*</span></label>
                                 <textarea class="form-control" id="abstract" name="abstract" rows="6" required></textarea>
-                                <div class="form-text">Describe your talk. Markdown supported. This will be shown to attendees if accepted.</div>
+                                <div class="form-text">Describe your talk. Markdown supported. This will be shown to attendees if accepted.${abstractWordGuidance(event)}</div>
                             </div>
 
                             <div class="row">
@@ -369,7 +382,7 @@ export function renderAcknowledgments(event) {
     if (!event.honorarium_provided) {
         checks.push({ id: 'ack_honorarium', label: 'I acknowledge that no speaker honorarium is provided' });
     }
-    if (event.is_online) {
+    if (getAttendanceMode(event) === 'online') {
         checks.push({ id: 'ack_online', label: 'I acknowledge that this is a 100% remote, online event' });
     }
     checks.push({ id: 'ack_email', label: 'I acknowledge that if my talk is selected, the organizer will reach out to the email provided in my application' });
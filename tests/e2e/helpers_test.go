@@ -331,6 +331,7 @@ func createTestEvent(name, slug, country string, cfpOpen bool) *models.Event {
 		Description: "Test event description",
 		Location:    "Test Location",
 		Country:     country,
+		CountryCode: country,
 		StartDate:   now.AddDate(0, 1, 0),
 		EndDate:     now.AddDate(0, 1, 3),
 		Website:     "https://example.com",
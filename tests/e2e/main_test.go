@@ -4,16 +4,15 @@ import (
 	"context"
 	"io/fs"
 	"log/slog"
-	"net/http"
 	"net/http/httptest"
 	"os"
 	"path/filepath"
-	"strings"
 	"testing"
 
 	"github.com/go-rod/rod"
 	"github.com/go-rod/rod/lib/launcher"
 	"github.com/sreday/cfp.ninja/pkg/api"
+	"github.com/sreday/cfp.ninja/pkg/assets"
 	"github.com/sreday/cfp.ninja/pkg/config"
 	"github.com/sreday/cfp.ninja/pkg/models"
 	"github.com/sreday/cfp.ninja/pkg/server"
@@ -57,17 +56,11 @@ func TestMain(m *testing.M) {
 	}
 
 	// Create a static file handler
-	fileServer := http.FileServer(http.FS(staticFS))
-	staticHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		path := strings.TrimPrefix(r.URL.Path, "/")
-		if path == "" {
-			path = "index.html"
-		}
-		if _, err := fs.Stat(staticFS, path); err != nil {
-			r.URL.Path = "/"
-		}
-		fileServer.ServeHTTP(w, r)
-	})
+	staticHandler, err := assets.New(staticFS)
+	if err != nil {
+		slog.Error("failed to index static files", "error", err)
+		os.Exit(1)
+	}
 
 	// Setup server with static files
 	cfg, handler, err := server.SetupServer(staticHandler)
@@ -0,0 +1,91 @@
+package e2e
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// fetchIndex returns the index.html the server sends the browser
+func fetchIndex(t *testing.T) string {
+	t.Helper()
+	resp, err := http.Get(baseURL + "/")
+	if err != nil {
+		t.Fatalf("failed to fetch index.html: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200 for index.html, got %d", resp.StatusCode)
+	}
+	if cc := resp.Header.Get("Cache-Control"); !strings.Contains(cc, "no-store") {
+		t.Errorf("expected index.html to be uncacheable, got Cache-Control %q", cc)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	return string(body)
+}
+
+func TestStatic_SPABootsWithHashedAssets(t *testing.T) {
+	page := newPage(t)
+	defer closePage(t, page)
+
+	// The navigation is rendered by the app's modules, so it only appears
+	// when every hashed module resolved through the import map
+	assertVisible(t, page, "#nav-container .navbar-brand")
+
+	src := page.MustEval(`() => document.querySelector('script[type="module"]').getAttribute('src')`).String()
+	if !regexp.MustCompile(`^/js/app\.[0-9a-f]{10}\.js$`).MatchString(src) {
+		t.Errorf("expected the app to load from a hashed name, got %q", src)
+	}
+}
+
+func TestStatic_HashedAssetsServed(t *testing.T) {
+	index := fetchIndex(t)
+
+	paths := regexp.MustCompile(`"(/[^"]+\.[0-9a-f]{10}\.[a-z]+)"`).FindAllStringSubmatch(index, -1)
+	if len(paths) == 0 {
+		t.Fatalf("expected index.html to reference hashed assets:\n%s", index)
+	}
+	seen := make(map[string]bool)
+	for _, m := range paths {
+		seen[m[1]] = true
+	}
+
+	// Modules imported by other modules are only named in the import map
+	importMap := regexp.MustCompile(`<script type="importmap">(.*?)</script>`).FindStringSubmatch(index)
+	if importMap == nil {
+		t.Fatal("expected index.html to have an import map")
+	}
+	var parsed struct {
+		Imports map[string]string `json:"imports"`
+	}
+	if err := json.Unmarshal([]byte(importMap[1]), &parsed); err != nil {
+		t.Fatalf("invalid import map: %v", err)
+	}
+	if parsed.Imports["/js/router.js"] == "" {
+		t.Errorf("expected the import map to cover /js/router.js, got %v", parsed.Imports)
+	}
+	for _, p := range parsed.Imports {
+		seen[p] = true
+	}
+
+	for p := range seen {
+		resp, err := http.Get(baseURL + p)
+		if err != nil {
+			t.Fatalf("failed to fetch %s: %v", p, err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("%s: expected 200, got %d", p, resp.StatusCode)
+			continue
+		}
+		if strings.HasPrefix(resp.Header.Get("Content-Type"), "text/html") {
+			t.Errorf("%s: got index.html instead of the asset", p)
+		}
+		if cc := resp.Header.Get("Cache-Control"); cc != "public, max-age=31536000, immutable" {
+			t.Errorf("%s: expected an immutable Cache-Control, got %q", p, cc)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+type activityResponse struct {
+	Data []struct {
+		Action  string `json:"action"`
+		Summary string `json:"summary"`
+		Actor   *struct {
+			Name string `json:"name"`
+		} `json:"actor"`
+		ProposalID *uint `json:"proposal_id"`
+	} `json:"data"`
+	Pagination struct {
+		Total int64 `json:"total"`
+	} `json:"pagination"`
+}
+
+func getActivity(t *testing.T, eventID uint, query, token string) activityResponse {
+	t.Helper()
+	resp := doAuthGet(fmt.Sprintf("/api/v0/events/%d/activity%s", eventID, query), token)
+	assertStatus(t, resp, http.StatusOK)
+	var activity activityResponse
+	if err := parseJSON(resp, &activity); err != nil {
+		t.Fatalf("failed to parse activity: %v", err)
+	}
+	return activity
+}
+
+func TestEventActivity(t *testing.T) {
+	t.Parallel()
+	eventID, proposal := createAcceptedProposal(t, "activity")
+
+	resp := doPut(fmt.Sprintf("/api/v0/events/%d", eventID), map[string]interface{}{
+		"cfp_close_at": time.Now().AddDate(0, 0, 14).Format(time.RFC3339),
+		"location":     "Lisbon",
+	}, adminToken)
+	assertStatus(t, resp, http.StatusOK)
+	resp.Body.Close()
+
+	resp = doPost(fmt.Sprintf("/api/v0/events/%d/organizers", eventID), OrganizerInput{Email: "other@test.com"}, adminToken)
+	assertStatus(t, resp, http.StatusCreated)
+	resp.Body.Close()
+
+	// Co-organizers see the full log, newest first
+	activity := getActivity(t, eventID, "", otherToken)
+	want := []string{
+		"Admin User added Other User as an organizer",
+		"Admin User changed the CFP deadline and location",
+		fmt.Sprintf("Admin User accepted %q", proposal.Title),
+		"Admin User opened the CFP",
+	}
+	if len(activity.Data) != len(want) || activity.Pagination.Total != int64(len(want)) {
+		t.Fatalf("expected %d entries, got %+v", len(want), activity)
+	}
+	for i, w := range want {
+		if activity.Data[i].Summary != w {
+			t.Errorf("entry %d: expected %q, got %q", i, w, activity.Data[i].Summary)
+		}
+		if activity.Data[i].Actor == nil || activity.Data[i].Actor.Name != "Admin User" {
+			t.Errorf("entry %d: expected Admin User as actor, got %+v", i, activity.Data[i].Actor)
+		}
+	}
+	if p := activity.Data[2].ProposalID; p == nil || *p != proposal.ID {
+		t.Errorf("expected proposal %d on the decision, got %v", proposal.ID, p)
+	}
+
+	// Saving unchanged values is not activity
+	resp = doPut(fmt.Sprintf("/api/v0/events/%d", eventID), map[string]interface{}{"location": "Lisbon"}, adminToken)
+	assertStatus(t, resp, http.StatusOK)
+	resp.Body.Close()
+	if got := getActivity(t, eventID, "", adminToken).Pagination.Total; got != int64(len(want)) {
+		t.Errorf("expected %d entries after a no-op update, got %d", len(want), got)
+	}
+
+	filtered := getActivity(t, eventID, "?action=proposal_status,cfp_status&per_page=1", adminToken)
+	if filtered.Pagination.Total != 2 || len(filtered.Data) != 1 || filtered.Data[0].Action != "proposal_status" {
+		t.Errorf("unexpected filtered activity: %+v", filtered)
+	}
+	future := time.Now().AddDate(0, 0, 1).Format("2006-01-02")
+	if got := getActivity(t, eventID, "?since="+future, adminToken); len(got.Data) != 0 {
+		t.Errorf("expected no activity since %s, got %+v", future, got.Data)
+	}
+	if got := getActivity(t, eventID, "?until="+future, adminToken); got.Pagination.Total != int64(len(want)) {
+		t.Errorf("expected all activity until %s, got %d", future, got.Pagination.Total)
+	}
+}
+
+func TestEventActivity_Errors(t *testing.T) {
+	t.Parallel()
+	eventID, _ := createAcceptedProposal(t, "activity-errors")
+	path := fmt.Sprintf("/api/v0/events/%d/activity", eventID)
+
+	resp := doAuthGet(path, speakerToken)
+	assertStatus(t, resp, http.StatusForbidden)
+	resp.Body.Close()
+
+	resp = doAuthGet(path, "")
+	assertStatus(t, resp, http.StatusUnauthorized)
+	resp.Body.Close()
+
+	resp = doAuthGet(path+"?action=deleted_everything", adminToken)
+	assertErrorCode(t, resp, "validation")
+
+	resp = doAuthGet(path+"?since=yesterday", adminToken)
+	assertErrorCode(t, resp, "validation")
+}
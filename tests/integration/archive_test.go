@@ -0,0 +1,163 @@
+package integration
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sreday/cfp.ninja/pkg/email"
+	"github.com/sreday/cfp.ninja/pkg/models"
+	"github.com/sreday/cfp.ninja/pkg/tasks"
+)
+
+// recordingSender keeps the emails sent by a task for assertions
+type recordingSender struct {
+	mu       sync.Mutex
+	messages []*email.Message
+}
+
+func (s *recordingSender) Send(_ context.Context, msg *email.Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.messages = append(s.messages, msg)
+	return nil
+}
+
+func (s *recordingSender) count() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.messages)
+}
+
+func TestEventArchival(t *testing.T) {
+	now := time.Now()
+	name := fmt.Sprintf("Archival Conf %d", now.UnixNano())
+	event := createTestEvent(adminToken, EventInput{
+		Name:      name,
+		Slug:      fmt.Sprintf("archival-%d", now.UnixNano()),
+		StartDate: now.AddDate(0, 1, 0).Format(time.RFC3339),
+		EndDate:   now.AddDate(0, 1, 1).Format(time.RFC3339),
+	})
+	updateCFPStatus(adminToken, event.ID, "open")
+	proposal := createTestProposal(speakerToken, event.ID, ProposalInput{
+		Title:        "Old Talk",
+		Abstract:     "A talk from long ago.",
+		Format:       "talk",
+		Duration:     30,
+		Level:        "beginner",
+		SpeakerNotes: "Call me on my private number",
+		Speakers:     []Speaker{{Name: "Speaker User", Email: "speaker@test.com", Primary: true}},
+	})
+
+	// The event ended three years ago
+	ended := now.AddDate(-3, 0, 0)
+	if err := testConfig.DB.Model(&models.Event{}).Where("id = ?", event.ID).
+		Updates(map[string]interface{}{"start_date": ended.AddDate(0, 0, -1), "end_date": ended}).Error; err != nil {
+		t.Fatalf("failed to backdate event: %v", err)
+	}
+
+	sender := &recordingSender{}
+	ncfg := &email.NotifyConfig{Sender: sender, From: "test@cfp.ninja", BaseURL: "http://localhost", Logger: slog.Default()}
+	cfg := tasks.ArchiveConfig{ArchiveAfter: 2 * 365 * 24 * time.Hour, ScrubAfter: time.Hour, ScrubNotice: 30 * time.Minute}
+	loadEvent := func(t *testing.T) models.Event {
+		t.Helper()
+		var e models.Event
+		if err := testConfig.DB.First(&e, event.ID).Error; err != nil {
+			t.Fatalf("failed to load event: %v", err)
+		}
+		return e
+	}
+	loadProposal := func(t *testing.T) models.Proposal {
+		t.Helper()
+		var p models.Proposal
+		if err := testConfig.DB.First(&p, proposal.ID).Error; err != nil {
+			t.Fatalf("failed to load proposal: %v", err)
+		}
+		return p
+	}
+
+	t.Run("dry run changes nothing", func(t *testing.T) {
+		dry := cfg
+		dry.DryRun = true
+		tasks.RunEventArchival(testConfig.DB, ncfg, dry, now)
+		if e := loadEvent(t); e.Archived {
+			t.Error("expected a dry run to leave the event unarchived")
+		}
+	})
+
+	t.Run("archives ended events", func(t *testing.T) {
+		tasks.RunEventArchival(testConfig.DB, ncfg, cfg, now)
+		e := loadEvent(t)
+		if !e.Archived || e.ArchivedAt == nil {
+			t.Fatalf("expected the event archived, got archived=%v at %v", e.Archived, e.ArchivedAt)
+		}
+		if sender.count() != 0 {
+			t.Errorf("expected no notice yet, got %d emails", sender.count())
+		}
+	})
+
+	t.Run("archived events leave the listings", func(t *testing.T) {
+		resp := doGet("/api/v0/events?q=" + strings.ReplaceAll(name, " ", "+"))
+		assertStatus(t, resp, http.StatusOK)
+		var list EventListResponse
+		if err := parseJSON(resp, &list); err != nil {
+			t.Fatalf("failed to parse response: %v", err)
+		}
+		if len(list.Data) != 0 {
+			t.Errorf("expected no listed events, got %+v", list.Data)
+		}
+	})
+
+	t.Run("archived events stay reachable by slug", func(t *testing.T) {
+		resp := doGet("/api/v0/e/" + event.Slug)
+		assertStatus(t, resp, http.StatusOK)
+		if resp.Header.Get("X-Robots-Tag") != "noindex" {
+			t.Error("expected X-Robots-Tag: noindex")
+		}
+		if body := readBody(resp); !strings.Contains(body, `"archived":true`) {
+			t.Errorf("expected the event marked archived, got %s", body)
+		}
+	})
+
+	t.Run("creator is emailed once before the scrub", func(t *testing.T) {
+		later := now.Add(45 * time.Minute)
+		tasks.RunEventArchival(testConfig.DB, ncfg, cfg, later)
+		tasks.RunEventArchival(testConfig.DB, ncfg, cfg, later)
+		if sender.count() != 1 {
+			t.Fatalf("expected one scrub notice, got %d", sender.count())
+		}
+		if msg := sender.messages[0]; msg.To[0] != "admin@test.com" || !strings.Contains(msg.Subject, name) {
+			t.Errorf("expected the notice to the creator about the event, got %v %q", msg.To, msg.Subject)
+		}
+		if p := loadProposal(t); p.SpeakerNotes == "" {
+			t.Error("expected the proposal untouched during the notice period")
+		}
+	})
+
+	t.Run("scrubs speaker details after the notice", func(t *testing.T) {
+		later := now.Add(2 * time.Hour)
+		tasks.RunEventArchival(testConfig.DB, ncfg, cfg, later)
+		p := loadProposal(t)
+		speakers, err := p.GetSpeakers()
+		if err != nil {
+			t.Fatalf("failed to parse speakers: %v", err)
+		}
+		if p.SpeakerNotes != "" || len(speakers) != 1 || speakers[0].Email != "" || speakers[0].Name != "Speaker User" {
+			t.Errorf("expected emails and notes removed and names kept, got %q %+v", p.SpeakerNotes, speakers)
+		}
+		if e := loadEvent(t); e.ScrubbedAt == nil {
+			t.Error("expected scrubbed_at set")
+		}
+
+		// Nothing left to do on the next run
+		result := tasks.RunEventArchival(testConfig.DB, ncfg, cfg, later.Add(time.Hour))
+		if result.Scrubbed != 0 || result.Notified != 0 || sender.count() != 1 {
+			t.Errorf("expected a rerun to change nothing, got %+v and %d emails", result, sender.count())
+		}
+	})
+}
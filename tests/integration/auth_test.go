@@ -1,11 +1,16 @@
 package integration
 
 import (
+	"fmt"
 	"net/http"
 	"testing"
+	"time"
+
+	"github.com/sreday/cfp.ninja/pkg/api"
 )
 
 func TestGetCurrentUser(t *testing.T) {
+	t.Parallel()
 	tests := []struct {
 		name          string
 		token         string
@@ -55,6 +60,7 @@ func TestGetCurrentUser(t *testing.T) {
 }
 
 func TestAuthRequired(t *testing.T) {
+	t.Parallel()
 	// List of endpoints that require authentication
 	protectedEndpoints := []struct {
 		method string
@@ -89,6 +95,7 @@ func TestAuthRequired(t *testing.T) {
 }
 
 func TestPublicEndpoints(t *testing.T) {
+	t.Parallel()
 	// List of endpoints that should be accessible without authentication
 	publicEndpoints := []struct {
 		method string
@@ -119,6 +126,7 @@ func TestPublicEndpoints(t *testing.T) {
 }
 
 func TestLogout(t *testing.T) {
+	t.Parallel()
 	t.Run("returns 200 and clears session cookie", func(t *testing.T) {
 		resp := doPost("/api/v0/auth/logout", nil, "")
 		defer resp.Body.Close()
@@ -157,3 +165,70 @@ func TestLogout(t *testing.T) {
 		}
 	})
 }
+
+func TestTokenTypes(t *testing.T) {
+	t.Parallel()
+	browserToken, _ := api.GenerateJWT(testConfig, userSpeaker, api.TokenTypeBrowser)
+	cliToken, _ := api.GenerateJWT(testConfig, userSpeaker, api.TokenTypeCLI)
+
+	withCookie := func(token string) *http.Response {
+		req, _ := http.NewRequest(http.MethodGet, testServer.URL+"/api/v0/auth/me", nil)
+		req.AddCookie(&http.Cookie{Name: "cfpninja_session", Value: token})
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+		return resp
+	}
+
+	tests := []struct {
+		name         string
+		resp         func() *http.Response
+		expectedCode int
+	}{
+		{"browser session cookie", func() *http.Response { return withCookie(browserToken) }, http.StatusOK},
+		{"CLI bearer token", func() *http.Response { return doAuthGet("/api/v0/auth/me", cliToken) }, http.StatusOK},
+		{"CLI token as cookie", func() *http.Response { return withCookie(cliToken) }, http.StatusUnauthorized},
+		{"browser session as bearer token", func() *http.Response { return doAuthGet("/api/v0/auth/me", browserToken) }, http.StatusUnauthorized},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := tc.resp()
+			defer resp.Body.Close()
+			assertStatus(t, resp, tc.expectedCode)
+		})
+	}
+}
+
+func TestLogoutAll(t *testing.T) {
+	t.Parallel()
+	user, token := createTestUserWithJWT(fmt.Sprintf("logout-all-%d@test.com", time.Now().UnixNano()), "Logout All User")
+	other, _ := api.GenerateJWT(testConfig, user, api.TokenTypeBrowser)
+
+	resp := doPost("/api/v0/me/logout-all", nil, token)
+	assertStatus(t, resp, http.StatusOK)
+	resp.Body.Close()
+
+	// Every token issued before, not only the one used, stops working
+	resp = doAuthGet("/api/v0/auth/me", token)
+	assertStatus(t, resp, http.StatusUnauthorized)
+	resp.Body.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, testServer.URL+"/api/v0/auth/me", nil)
+	req.AddCookie(&http.Cookie{Name: "cfpninja_session", Value: other})
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	assertStatus(t, resp, http.StatusUnauthorized)
+	resp.Body.Close()
+
+	// Signing in again issues a working token
+	if err := testConfig.DB.First(user, user.ID).Error; err != nil {
+		t.Fatalf("failed to reload user: %v", err)
+	}
+	fresh, _ := api.GenerateJWT(testConfig, user, api.TokenTypeCLI)
+	resp = doAuthGet("/api/v0/auth/me", fresh)
+	defer resp.Body.Close()
+	assertStatus(t, resp, http.StatusOK)
+}
@@ -0,0 +1,141 @@
+package integration
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/sreday/cfp.ninja/pkg/assets"
+	"github.com/sreday/cfp.ninja/pkg/server"
+)
+
+func TestBasePath(t *testing.T) {
+	basePath := testConfig.BasePath
+	testConfig.BasePath = "/cfp"
+	t.Cleanup(func() { testConfig.BasePath = basePath })
+
+	static, err := assets.New(fstest.MapFS{
+		"index.html":    {Data: []byte(`<html><head><link rel="stylesheet" href="/css/style.css"></head><body><a href="/terms">Terms</a></body></html>`)},
+		"css/style.css": {Data: []byte("body {}")},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	handler, err := server.NewHandler(testConfig, static)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewServer(handler)
+	defer srv.Close()
+
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}}
+	do := func(t *testing.T, method, path string, body any, token string) *http.Response {
+		t.Helper()
+		var data []byte
+		if body != nil {
+			data, _ = json.Marshal(body)
+		}
+		req, _ := http.NewRequest(method, srv.URL+path, bytes.NewReader(data))
+		req.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp
+	}
+
+	t.Run("API is served under the prefix", func(t *testing.T) {
+		resp := do(t, http.MethodGet, "/cfp/api/v0/health", nil, "")
+		assertStatus(t, resp, http.StatusOK)
+		resp.Body.Close()
+	})
+
+	t.Run("paths outside the prefix are not found", func(t *testing.T) {
+		for _, path := range []string{"/api/v0/health", "/", "/terms"} {
+			resp := do(t, http.MethodGet, path, nil, "")
+			assertStatus(t, resp, http.StatusNotFound)
+			resp.Body.Close()
+		}
+	})
+
+	t.Run("prefix redirects to the app", func(t *testing.T) {
+		resp := do(t, http.MethodGet, "/cfp", nil, "")
+		assertStatus(t, resp, http.StatusMovedPermanently)
+		resp.Body.Close()
+		if got := resp.Header.Get("Location"); got != "/cfp/" {
+			t.Errorf("expected a redirect to /cfp/, got %q", got)
+		}
+	})
+
+	t.Run("SPA routes get index.html under the prefix", func(t *testing.T) {
+		resp := do(t, http.MethodGet, "/cfp/events/some-conf", nil, "")
+		assertStatus(t, resp, http.StatusOK)
+		body := readBody(resp)
+		for _, want := range []string{`<meta name="base-path" content="/cfp">`, `href="/cfp/css/style.`, `href="/cfp/terms"`} {
+			if !strings.Contains(body, want) {
+				t.Errorf("expected index.html to contain %s:\n%s", want, body)
+			}
+		}
+
+		resp = do(t, http.MethodGet, "/cfp/api/v0/nope", nil, "")
+		assertStatus(t, resp, http.StatusNotFound)
+		resp.Body.Close()
+	})
+
+	t.Run("Location headers carry the prefix", func(t *testing.T) {
+		now := time.Now().UTC()
+		resp := do(t, http.MethodPost, "/cfp/api/v0/events", EventInput{
+			Name:       "Base Path Conf",
+			Slug:       fmt.Sprintf("base-path-%d", now.UnixNano()),
+			StartDate:  now.AddDate(0, 2, 0).Format(time.RFC3339),
+			EndDate:    now.AddDate(0, 2, 1).Format(time.RFC3339),
+			CFPOpenAt:  now.AddDate(0, 0, -1).Format(time.RFC3339),
+			CFPCloseAt: now.AddDate(0, 1, 0).Format(time.RFC3339),
+		}, adminToken)
+		assertStatus(t, resp, http.StatusCreated)
+		var event EventResponse
+		if err := parseJSON(resp, &event); err != nil {
+			t.Fatalf("failed to parse event: %v", err)
+		}
+		if want := fmt.Sprintf("/cfp/api/v0/events/%d", event.ID); resp.Header.Get("Location") != want {
+			t.Errorf("expected Location %s, got %q", want, resp.Header.Get("Location"))
+		}
+	})
+
+	t.Run("session cookie is scoped to the prefix", func(t *testing.T) {
+		resp := do(t, http.MethodPost, "/cfp/api/v0/auth/logout", nil, "")
+		assertStatus(t, resp, http.StatusOK)
+		resp.Body.Close()
+		cookies := resp.Cookies()
+		if len(cookies) != 1 || cookies[0].Path != "/cfp/" {
+			t.Errorf("expected the session cookie cleared on /cfp/, got %+v", cookies)
+		}
+	})
+
+	t.Run("OpenAPI document points at the prefix", func(t *testing.T) {
+		resp := do(t, http.MethodGet, "/cfp/api/v0/openapi.json", nil, "")
+		assertStatus(t, resp, http.StatusOK)
+		var doc struct {
+			Servers []struct {
+				URL string `json:"url"`
+			} `json:"servers"`
+		}
+		if err := parseJSON(resp, &doc); err != nil {
+			t.Fatalf("failed to parse document: %v", err)
+		}
+		if len(doc.Servers) != 1 || doc.Servers[0].URL != "/cfp" {
+			t.Errorf("expected the server URL /cfp, got %+v", doc.Servers)
+		}
+	})
+}
@@ -0,0 +1,105 @@
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestBulkAddOrganizers(t *testing.T) {
+	t.Parallel()
+	now := time.Now().UTC()
+	event := createTestEvent(adminToken, EventInput{
+		Name:       "Committee Conf",
+		Slug:       fmt.Sprintf("committee-%d", now.UnixNano()),
+		StartDate:  now.AddDate(0, 2, 0).Format(time.RFC3339),
+		EndDate:    now.AddDate(0, 2, 1).Format(time.RFC3339),
+		CFPOpenAt:  now.AddDate(0, 0, -1).Format(time.RFC3339),
+		CFPCloseAt: now.AddDate(0, 1, 0).Format(time.RFC3339),
+	})
+	path := fmt.Sprintf("/api/v0/events/%d/organizers/bulk", event.ID)
+
+	// Enough committee members to go one past the limit, with the creator
+	// and the other user already taking two places
+	var committee []string
+	for i := 0; i < testConfig.MaxOrganizersPerEvent-1; i++ {
+		email := fmt.Sprintf("committee-%d-%d@test.com", i, now.UnixNano())
+		createTestUserWithJWT(email, fmt.Sprintf("Committee Member %d", i))
+		committee = append(committee, email)
+	}
+
+	type result struct {
+		Email  string `json:"email"`
+		Result string `json:"result"`
+		UserID uint   `json:"user_id"`
+	}
+	add := func(t *testing.T, emails []string) []result {
+		t.Helper()
+		resp := doPost(path, map[string]interface{}{"emails": emails}, adminToken)
+		assertStatus(t, resp, http.StatusOK)
+		var got struct {
+			Results []result `json:"results"`
+		}
+		if err := parseJSON(resp, &got); err != nil {
+			t.Fatalf("failed to parse response: %v", err)
+		}
+		if len(got.Results) != len(emails) {
+			t.Fatalf("expected a result per email, got %+v", got.Results)
+		}
+		return got.Results
+	}
+
+	t.Run("reports each email", func(t *testing.T) {
+		results := add(t, []string{"Other@Test.com", "nobody-registered@test.com", "admin@test.com", "other@test.com"})
+		want := []string{"added", "not_found", "already_organizer", "already_organizer"}
+		for i, r := range results {
+			if r.Result != want[i] {
+				t.Errorf("%s: expected %s, got %s", r.Email, want[i], r.Result)
+			}
+		}
+		if results[0].UserID != userOther.ID {
+			t.Errorf("expected the other user's ID, got %d", results[0].UserID)
+		}
+	})
+
+	t.Run("stops at the organizer limit", func(t *testing.T) {
+		results := add(t, committee)
+		last := len(results) - 1
+		for _, r := range results[:last] {
+			if r.Result != "added" {
+				t.Errorf("%s: expected added, got %s", r.Email, r.Result)
+			}
+		}
+		if results[last].Result != "limit_reached" {
+			t.Errorf("%s: expected limit_reached, got %s", results[last].Email, results[last].Result)
+		}
+
+		resp := doAuthGet(fmt.Sprintf("/api/v0/events/%d/organizers", event.ID), adminToken)
+		assertStatus(t, resp, http.StatusOK)
+		var organizers []map[string]interface{}
+		if err := parseJSON(resp, &organizers); err != nil {
+			t.Fatalf("failed to parse response: %v", err)
+		}
+		if len(organizers) != testConfig.MaxOrganizersPerEvent {
+			t.Errorf("expected %d organizers, got %d", testConfig.MaxOrganizersPerEvent, len(organizers))
+		}
+	})
+
+	t.Run("validates the list", func(t *testing.T) {
+		fields := validationFields(t, doPost(path, map[string]interface{}{"emails": []string{}}, adminToken))
+		if fields["emails"] == "" {
+			t.Errorf("expected an emails error, got %v", fields)
+		}
+		fields = validationFields(t, doPost(path, map[string]interface{}{"emails": []string{"a@test.com", " "}}, adminToken))
+		if fields["emails.1"] == "" {
+			t.Errorf("expected an emails.1 error, got %v", fields)
+		}
+	})
+
+	t.Run("only organizers can add", func(t *testing.T) {
+		resp := doPost(path, map[string]interface{}{"emails": []string{"speaker@test.com"}}, speakerToken)
+		assertStatus(t, resp, http.StatusForbidden)
+		resp.Body.Close()
+	})
+}
@@ -0,0 +1,255 @@
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// freezeClock pins the server clock to at for the rest of the test
+func freezeClock(t *testing.T, at time.Time) {
+	t.Helper()
+	testConfig.Clock = func() time.Time { return at }
+	t.Cleanup(func() { testConfig.Clock = nil })
+}
+
+func TestCreateProposal_DeadlineGracePeriod(t *testing.T) {
+	grace := testConfig.CFPGracePeriod
+	testConfig.CFPGracePeriod = 15 * time.Minute
+	t.Cleanup(func() { testConfig.CFPGracePeriod = grace })
+
+	tests := []struct {
+		name         string
+		offset       time.Duration // submission time relative to cfp_close_at
+		expectedCode int
+	}{
+		{"30 seconds before close", -30 * time.Second, http.StatusCreated},
+		{"exactly at close", 0, http.StatusCreated},
+		{"within grace period", 14*time.Minute + 59*time.Second, http.StatusCreated},
+		{"at end of grace period", 15 * time.Minute, http.StatusBadRequest},
+		{"an hour late", time.Hour, http.StatusBadRequest},
+	}
+
+	for i, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			now := time.Now().UTC()
+			closeAt := now.Add(time.Hour).Truncate(time.Second)
+			event := createTestEvent(adminToken, EventInput{
+				Name:       "Deadline Event",
+				Slug:       fmt.Sprintf("deadline-%d-%d", i, now.UnixNano()),
+				StartDate:  now.AddDate(0, 2, 0).Format(time.RFC3339),
+				EndDate:    now.AddDate(0, 2, 1).Format(time.RFC3339),
+				CFPOpenAt:  now.AddDate(0, 0, -7).Format(time.RFC3339),
+				CFPCloseAt: closeAt.Format(time.RFC3339),
+			})
+			updateCFPStatus(adminToken, event.ID, "open")
+
+			freezeClock(t, closeAt.Add(tc.offset))
+			resp := doPost(fmt.Sprintf("/api/v0/events/%d/proposals", event.ID), ProposalInput{
+				Title:    "Deadline Talk",
+				Abstract: "Submitted right at the deadline.",
+				Format:   "talk",
+				Duration: 30,
+				Level:    "intermediate",
+				Speakers: []Speaker{
+					{Name: "Speaker User", Email: "speaker@test.com", Bio: "Bio", Company: "Acme", JobTitle: "Dev", LinkedIn: "https://linkedin.com/in/speaker"},
+				},
+			}, speakerToken)
+			assertStatus(t, resp, tc.expectedCode)
+			resp.Body.Close()
+		})
+	}
+}
+
+func TestListEvents_NoGracePeriod(t *testing.T) {
+	now := time.Now().UTC()
+	closeAt := now.Add(time.Hour).Truncate(time.Second)
+	event := createTestEvent(adminToken, EventInput{
+		Name:       "Deadline Listing Event",
+		Slug:       fmt.Sprintf("deadline-listing-%d", now.UnixNano()),
+		StartDate:  now.AddDate(0, 2, 0).Format(time.RFC3339),
+		EndDate:    now.AddDate(0, 2, 1).Format(time.RFC3339),
+		Tags:       "deadlinetest",
+		CFPOpenAt:  now.AddDate(0, 0, -7).Format(time.RFC3339),
+		CFPCloseAt: closeAt.Format(time.RFC3339),
+	})
+	updateCFPStatus(adminToken, event.ID, "open")
+
+	listed := func(t *testing.T, status string) bool {
+		t.Helper()
+		resp := doGet("/api/v0/events?tag=deadlinetest&status=" + status)
+		assertStatus(t, resp, http.StatusOK)
+		var result EventListResponse
+		if err := parseJSON(resp, &result); err != nil {
+			t.Fatalf("failed to parse response: %v", err)
+		}
+		for _, e := range result.Data {
+			if e.ID == event.ID {
+				return true
+			}
+		}
+		return false
+	}
+
+	freezeClock(t, closeAt.Add(-time.Minute))
+	if !listed(t, "open") {
+		t.Error("expected event listed as open before the deadline")
+	}
+
+	// The grace period only applies to submissions: listings close on time
+	freezeClock(t, closeAt.Add(time.Minute))
+	if listed(t, "open") {
+		t.Error("expected event not listed as open after the deadline")
+	}
+	if !listed(t, "closed") {
+		t.Error("expected event listed as closed after the deadline")
+	}
+}
+
+func TestOpenCFPWithoutDates(t *testing.T) {
+	now := time.Now().UTC()
+	noDates := createTestEvent(adminToken, EventInput{
+		Name:      "No Dates Event",
+		Slug:      fmt.Sprintf("no-dates-%d", now.UnixNano()),
+		StartDate: now.AddDate(0, 2, 0).Format(time.RFC3339),
+		EndDate:   now.AddDate(0, 2, 1).Format(time.RFC3339),
+		Tags:      "nodatestest",
+	})
+	updateCFPStatus(adminToken, noDates.ID, "open")
+
+	closeOnly := createTestEvent(adminToken, EventInput{
+		Name:       "Close Only Event",
+		Slug:       fmt.Sprintf("close-only-%d", now.UnixNano()),
+		StartDate:  now.AddDate(0, 2, 0).Format(time.RFC3339),
+		EndDate:    now.AddDate(0, 2, 1).Format(time.RFC3339),
+		Tags:       "nodatestest",
+		CFPCloseAt: now.Add(time.Hour).Format(time.RFC3339),
+	})
+	updateCFPStatus(adminToken, closeOnly.ID, "open")
+
+	listIDs := func(t *testing.T, status string) map[uint]bool {
+		t.Helper()
+		resp := doGet("/api/v0/events?tag=nodatestest&status=" + status)
+		assertStatus(t, resp, http.StatusOK)
+		var result EventListResponse
+		if err := parseJSON(resp, &result); err != nil {
+			t.Fatalf("failed to parse response: %v", err)
+		}
+		ids := make(map[uint]bool)
+		for _, e := range result.Data {
+			ids[e.ID] = true
+		}
+		return ids
+	}
+
+	t.Run("listed as open", func(t *testing.T) {
+		open := listIDs(t, "open")
+		if !open[noDates.ID] || !open[closeOnly.ID] {
+			t.Errorf("expected both events in status=open, got %v", open)
+		}
+		if closed := listIDs(t, "closed"); closed[noDates.ID] || closed[closeOnly.ID] {
+			t.Errorf("expected neither event in status=closed, got %v", closed)
+		}
+	})
+
+	t.Run("accepts submissions", func(t *testing.T) {
+		createTestProposal(speakerToken, noDates.ID, ProposalInput{
+			Title:    "Undated Talk",
+			Abstract: "Submitted to a CFP without dates.",
+			Format:   "talk",
+			Duration: 30,
+			Level:    "intermediate",
+			Speakers: []Speaker{
+				{Name: "Speaker User", Email: "speaker@test.com", Bio: "Bio", Company: "Acme", JobTitle: "Dev", LinkedIn: "https://linkedin.com/in/speaker"},
+			},
+		})
+	})
+
+	t.Run("close date still applies", func(t *testing.T) {
+		freezeClock(t, now.Add(2*time.Hour))
+		if open := listIDs(t, "open"); open[closeOnly.ID] || !open[noDates.ID] {
+			t.Errorf("expected only the undated event open after the close date, got %v", open)
+		}
+		if closed := listIDs(t, "closed"); !closed[closeOnly.ID] {
+			t.Errorf("expected close-only event in status=closed, got %v", closed)
+		}
+	})
+
+	t.Run("closed status wins", func(t *testing.T) {
+		updateCFPStatus(adminToken, noDates.ID, "closed")
+		if closed := listIDs(t, "closed"); !closed[noDates.ID] {
+			t.Errorf("expected closed undated event in status=closed, got %v", closed)
+		}
+	})
+}
+
+func TestUpdateCFPStatus_ReopenRequiresNewDeadline(t *testing.T) {
+	t.Parallel()
+	now := time.Now().UTC()
+	event := createTestEvent(adminToken, EventInput{
+		Name:       "Reopen Event",
+		Slug:       fmt.Sprintf("reopen-%d", now.UnixNano()),
+		StartDate:  now.AddDate(0, 2, 0).Format(time.RFC3339),
+		EndDate:    now.AddDate(0, 2, 1).Format(time.RFC3339),
+		CFPOpenAt:  now.AddDate(0, 0, -30).Format(time.RFC3339),
+		CFPCloseAt: now.AddDate(0, 0, -1).Format(time.RFC3339),
+	})
+	updateCFPStatus(adminToken, event.ID, "closed")
+	path := fmt.Sprintf("/api/v0/events/%d/cfp-status", event.ID)
+
+	assertCloseAtRejected := func(t *testing.T, input CFPStatusInput) {
+		t.Helper()
+		resp := doPut(path, input, adminToken)
+		assertStatus(t, resp, http.StatusBadRequest)
+		var result struct {
+			Fields map[string]string `json:"fields"`
+		}
+		if err := parseJSON(resp, &result); err != nil {
+			t.Fatalf("failed to parse JSON: %v", err)
+		}
+		if _, ok := result.Fields["cfp_close_at"]; !ok {
+			t.Errorf("expected error for field cfp_close_at, got %v", result.Fields)
+		}
+	}
+
+	t.Run("past deadline without new date", func(t *testing.T) {
+		assertCloseAtRejected(t, CFPStatusInput{Status: "open"})
+	})
+
+	t.Run("new date in the past", func(t *testing.T) {
+		assertCloseAtRejected(t, CFPStatusInput{Status: "open", CFPCloseAt: now.Add(-time.Hour).Format(time.RFC3339)})
+	})
+
+	t.Run("new date without opening", func(t *testing.T) {
+		assertCloseAtRejected(t, CFPStatusInput{Status: "closed", CFPCloseAt: now.AddDate(0, 0, 7).Format(time.RFC3339)})
+	})
+
+	t.Run("new date in the future", func(t *testing.T) {
+		closeAt := now.AddDate(0, 0, 7).Truncate(time.Second)
+		resp := doPut(path, CFPStatusInput{Status: "open", CFPCloseAt: closeAt.Format(time.RFC3339)}, adminToken)
+		assertStatus(t, resp, http.StatusOK)
+		var updated EventResponse
+		if err := parseJSON(resp, &updated); err != nil {
+			t.Fatalf("failed to parse response: %v", err)
+		}
+		if updated.CFPStatus != "open" {
+			t.Errorf("expected status open, got %q", updated.CFPStatus)
+		}
+		got, err := time.Parse(time.RFC3339, updated.CFPCloseAt)
+		if err != nil || !got.Equal(closeAt) {
+			t.Errorf("expected cfp_close_at %v, got %q", closeAt, updated.CFPCloseAt)
+		}
+
+		createTestProposal(speakerToken, event.ID, ProposalInput{
+			Title:    "Reopened Talk",
+			Abstract: "Submitted after the CFP was extended.",
+			Format:   "talk",
+			Duration: 30,
+			Level:    "intermediate",
+			Speakers: []Speaker{
+				{Name: "Speaker User", Email: "speaker@test.com", Bio: "Bio", Company: "Acme", JobTitle: "Dev", LinkedIn: "https://linkedin.com/in/speaker"},
+			},
+		})
+	})
+}
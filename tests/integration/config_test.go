@@ -6,6 +6,7 @@ import (
 )
 
 func TestGetConfig_ReturnsExpectedShape(t *testing.T) {
+	t.Parallel()
 	resp := doGet("/api/v0/config")
 	assertStatus(t, resp, http.StatusOK)
 
@@ -24,6 +25,14 @@ func TestGetConfig_ReturnsExpectedShape(t *testing.T) {
 		t.Errorf("expected max_organizers_per_event > 0, got %d", cfg.MaxOrganizersPerEvent)
 	}
 
+	// The feature map repeats the limits for clients that only read it
+	if cfg.Features.Providers == nil {
+		t.Error("expected features.providers to be a list")
+	}
+	if cfg.Features.MaxSpeakers != 3 {
+		t.Errorf("expected features.max_speakers 3, got %d", cfg.Features.MaxSpeakers)
+	}
+
 	// NotificationEmail should be derived and non-empty
 	if cfg.NotificationEmail == "" {
 		t.Error("expected notification_email to be set")
@@ -31,6 +40,7 @@ func TestGetConfig_ReturnsExpectedShape(t *testing.T) {
 }
 
 func TestGetConfig_NoAuth(t *testing.T) {
+	t.Parallel()
 	// Config should be accessible without authentication
 	resp := doGet("/api/v0/config")
 	if resp.StatusCode == http.StatusUnauthorized {
@@ -40,6 +50,7 @@ func TestGetConfig_NoAuth(t *testing.T) {
 }
 
 func TestGetConfig_MethodNotAllowed(t *testing.T) {
+	t.Parallel()
 	resp := doPost("/api/v0/config", nil, "")
 	if resp.StatusCode != http.StatusMethodNotAllowed {
 		t.Errorf("expected 405 for POST, got %d", resp.StatusCode)
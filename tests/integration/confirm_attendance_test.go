@@ -1,10 +1,14 @@
 package integration
 
 import (
+	"encoding/csv"
 	"fmt"
 	"net/http"
+	"strings"
 	"testing"
 	"time"
+
+	"github.com/sreday/cfp.ninja/pkg/models"
 )
 
 // createAcceptedProposal creates an event with open CFP, creates a proposal, and accepts it.
@@ -38,6 +42,7 @@ func createAcceptedProposal(t *testing.T, suffix string) (uint, *ProposalRespons
 }
 
 func TestConfirmAttendance_OwnerConfirmsAccepted(t *testing.T) {
+	t.Parallel()
 	_, proposal := createAcceptedProposal(t, "owner-confirm")
 
 	resp := doPut(
@@ -60,6 +65,7 @@ func TestConfirmAttendance_OwnerConfirmsAccepted(t *testing.T) {
 }
 
 func TestConfirmAttendance_NonOwnerForbidden(t *testing.T) {
+	t.Parallel()
 	_, proposal := createAcceptedProposal(t, "nonowner-forbid")
 
 	// Other user (not the proposal owner) tries to confirm
@@ -73,6 +79,7 @@ func TestConfirmAttendance_NonOwnerForbidden(t *testing.T) {
 }
 
 func TestConfirmAttendance_OrganizerCannotConfirm(t *testing.T) {
+	t.Parallel()
 	_, proposal := createAcceptedProposal(t, "org-forbid")
 
 	// Admin is the organizer but not the proposal owner
@@ -86,6 +93,7 @@ func TestConfirmAttendance_OrganizerCannotConfirm(t *testing.T) {
 }
 
 func TestConfirmAttendance_NonAcceptedProposalRejected(t *testing.T) {
+	t.Parallel()
 	now := time.Now()
 	event := createTestEvent(adminToken, EventInput{
 		Name:       "Non-Accepted Confirm Test",
@@ -126,6 +134,7 @@ func TestConfirmAttendance_NonAcceptedProposalRejected(t *testing.T) {
 }
 
 func TestConfirmAttendance_RejectedProposal(t *testing.T) {
+	t.Parallel()
 	now := time.Now()
 	event := createTestEvent(adminToken, EventInput{
 		Name:       "Rejected Confirm Test",
@@ -159,6 +168,7 @@ func TestConfirmAttendance_RejectedProposal(t *testing.T) {
 }
 
 func TestConfirmAttendance_DoubleConfirm(t *testing.T) {
+	t.Parallel()
 	_, proposal := createAcceptedProposal(t, "double-confirm")
 
 	// First confirm succeeds
@@ -182,6 +192,7 @@ func TestConfirmAttendance_DoubleConfirm(t *testing.T) {
 }
 
 func TestConfirmAttendance_Unauthenticated(t *testing.T) {
+	t.Parallel()
 	_, proposal := createAcceptedProposal(t, "unauth-confirm")
 
 	resp := doPut(
@@ -194,6 +205,7 @@ func TestConfirmAttendance_Unauthenticated(t *testing.T) {
 }
 
 func TestConfirmAttendance_NotFound(t *testing.T) {
+	t.Parallel()
 	resp := doPut(
 		"/api/v0/proposals/99999/confirm",
 		map[string]interface{}{},
@@ -202,3 +214,105 @@ func TestConfirmAttendance_NotFound(t *testing.T) {
 	assertStatus(t, resp, http.StatusNotFound)
 	resp.Body.Close()
 }
+
+func TestConfirmAttendance_Timestamps(t *testing.T) {
+	t.Parallel()
+	eventID, early := createAcceptedProposal(t, "timestamps")
+	late := createTestProposal(speakerToken, eventID, ProposalInput{
+		Title:    "Late Confirmer",
+		Abstract: "Confirmed at the last minute.",
+		Format:   "talk",
+		Duration: 30,
+		Level:    "intermediate",
+		Speakers: []Speaker{
+			{Name: "Speaker User", Email: "speaker@test.com", Bio: "Bio", Company: "Acme", JobTitle: "Dev", LinkedIn: "https://linkedin.com/in/speaker"},
+		},
+	})
+	updateProposalStatus(adminToken, late.ID, "accepted")
+	for _, id := range []uint{early.ID, late.ID} {
+		resp := doPut(fmt.Sprintf("/api/v0/proposals/%d/confirm", id), map[string]interface{}{}, speakerToken)
+		assertStatus(t, resp, http.StatusOK)
+		resp.Body.Close()
+	}
+	earlyAt := time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)
+	lateAt := time.Date(2026, 4, 20, 18, 0, 0, 0, time.UTC)
+	testConfig.DB.Model(&models.Proposal{}).Where("id = ?", early.ID).Update("attendance_confirmed_at", earlyAt)
+	testConfig.DB.Model(&models.Proposal{}).Where("id = ?", late.ID).Update("attendance_confirmed_at", lateAt)
+
+	listPath := fmt.Sprintf("/api/v0/events/%d/proposals", eventID)
+	listed := func(t *testing.T, query string) []uint {
+		t.Helper()
+		resp := doAuthGet(listPath+query, adminToken)
+		assertStatus(t, resp, http.StatusOK)
+		var proposals []ProposalResponse
+		if err := parseJSON(resp, &proposals); err != nil {
+			t.Fatalf("failed to parse response: %v", err)
+		}
+		ids := make([]uint, len(proposals))
+		for i, p := range proposals {
+			ids[i] = p.ID
+		}
+		return ids
+	}
+
+	t.Run("filters the organizer listing", func(t *testing.T) {
+		if ids := listed(t, "?confirmed_before=2026-04-01"); len(ids) != 1 || ids[0] != early.ID {
+			t.Errorf("expected only the early confirmation, got %v", ids)
+		}
+		if ids := listed(t, "?confirmed_after=2026-03-01T10:00:00Z"); len(ids) != 1 || ids[0] != late.ID {
+			t.Errorf("expected only the late confirmation, got %v", ids)
+		}
+		if ids := listed(t, "?confirmed_after=2026-01-01&confirmed_before=2026-12-31"); len(ids) != 2 {
+			t.Errorf("expected both confirmations, got %v", ids)
+		}
+
+		fields := validationFields(t, doAuthGet(listPath+"?confirmed_before=last-week", adminToken))
+		if fields["confirmed_before"] == "" {
+			t.Errorf("expected a confirmed_before error, got %v", fields)
+		}
+	})
+
+	t.Run("exported as an ISO time", func(t *testing.T) {
+		resp := doAuthGet(fmt.Sprintf("/api/v0/events/%d/proposals/export?format=in-person", eventID), adminToken)
+		assertStatus(t, resp, http.StatusOK)
+		records, err := csv.NewReader(strings.NewReader(readBody(resp))).ReadAll()
+		if err != nil {
+			t.Fatalf("failed to parse CSV: %v", err)
+		}
+		confirmedAt := map[string]string{}
+		for _, row := range records[1:] {
+			confirmedAt[row[12]] = row[len(row)-1]
+		}
+		if got := confirmedAt["Late Confirmer"]; got != lateAt.Format(time.RFC3339) {
+			t.Errorf("expected confirmed_at %s, got %q", lateAt.Format(time.RFC3339), got)
+		}
+	})
+
+	t.Run("speakers see their own confirmation time", func(t *testing.T) {
+		resp := doAuthGet("/api/v0/me/events", speakerToken)
+		assertStatus(t, resp, http.StatusOK)
+		var me struct {
+			Submitted []struct {
+				ID          uint `json:"id"`
+				MyProposals []struct {
+					ID                    uint       `json:"id"`
+					AttendanceConfirmedAt *time.Time `json:"attendance_confirmed_at"`
+				} `json:"my_proposals"`
+			} `json:"submitted"`
+		}
+		if err := parseJSON(resp, &me); err != nil {
+			t.Fatalf("failed to parse response: %v", err)
+		}
+		for _, e := range me.Submitted {
+			for _, p := range e.MyProposals {
+				if p.ID == early.ID {
+					if p.AttendanceConfirmedAt == nil || !p.AttendanceConfirmedAt.Equal(earlyAt) {
+						t.Errorf("expected attendance_confirmed_at %s, got %v", earlyAt, p.AttendanceConfirmedAt)
+					}
+					return
+				}
+			}
+		}
+		t.Errorf("proposal %d not found in /me/events", early.ID)
+	})
+}
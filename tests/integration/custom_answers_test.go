@@ -0,0 +1,114 @@
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sreday/cfp.ninja/pkg/models"
+)
+
+func TestCustomAnswers_SizeLimits(t *testing.T) {
+	t.Parallel()
+	now := time.Now().UTC()
+	event := createTestEvent(adminToken, EventInput{
+		Name:       "Long Answers Conf",
+		Slug:       fmt.Sprintf("long-answers-%d", now.UnixNano()),
+		StartDate:  now.AddDate(0, 2, 0).Format(time.RFC3339),
+		EndDate:    now.AddDate(0, 2, 1).Format(time.RFC3339),
+		CFPOpenAt:  now.AddDate(0, 0, -1).Format(time.RFC3339),
+		CFPCloseAt: now.AddDate(0, 1, 0).Format(time.RFC3339),
+	})
+	updateCFPStatus(adminToken, event.ID, "open")
+
+	var questions []models.CustomQuestion
+	for i := 0; i < 12; i++ {
+		questions = append(questions, models.CustomQuestion{ID: fmt.Sprintf("q%02d", i), Text: "Tell us more", Type: "text"})
+	}
+	resp := doPut(fmt.Sprintf("/api/v0/events/%d", event.ID), map[string]interface{}{"cfp_questions": questions}, adminToken)
+	resp.Body.Close()
+	assertStatus(t, resp, http.StatusOK)
+
+	input := func(answers interface{}) map[string]interface{} {
+		return map[string]interface{}{
+			"title":    "Long Answers",
+			"abstract": "Many words.",
+			"format":   "talk",
+			"duration": 30,
+			"speakers": []Speaker{
+				{Name: "Speaker User", Email: "speaker@test.com", Company: "Acme Inc", JobTitle: "Engineer", LinkedIn: "https://linkedin.com/in/speaker", Primary: true},
+			},
+			"custom_answers": answers,
+		}
+	}
+	proposalsPath := fmt.Sprintf("/api/v0/events/%d/proposals", event.ID)
+
+	t.Run("rejects an answer over the per-answer limit", func(t *testing.T) {
+		fields := validationFields(t, doPost(proposalsPath, input(map[string]string{"q03": strings.Repeat("x", 5001)}), speakerToken))
+		if fields["custom_answers.q03"] == "" {
+			t.Errorf("expected an error for q03, got %v", fields)
+		}
+	})
+
+	t.Run("rejects answers over the total limit, naming the longest", func(t *testing.T) {
+		answers := make(map[string]string)
+		for i, q := range questions {
+			answers[q.ID] = strings.Repeat("x", 5000-i)
+		}
+		fields := validationFields(t, doPost(proposalsPath, input(answers), speakerToken))
+		if len(fields) != 1 || fields["custom_answers.q00"] == "" {
+			t.Errorf("expected a single error for q00, got %v", fields)
+		}
+	})
+
+	t.Run("rejects nested answers", func(t *testing.T) {
+		nested := map[string]interface{}{"q00": map[string]interface{}{"a": []interface{}{map[string]interface{}{"b": "c"}}}}
+		fields := validationFields(t, doPost(proposalsPath, input(nested), speakerToken))
+		if fields["custom_answers.q00"] == "" {
+			t.Errorf("expected an error for q00, got %v", fields)
+		}
+
+		fields = validationFields(t, doPost(proposalsPath, input([]string{"x"}), speakerToken))
+		if fields["custom_answers"] == "" {
+			t.Errorf("expected a custom_answers error, got %v", fields)
+		}
+	})
+
+	t.Run("applies the same limits on update", func(t *testing.T) {
+		resp := doPost(proposalsPath, input(map[string]string{"q00": "Short"}), speakerToken)
+		assertStatus(t, resp, http.StatusCreated)
+		var proposal ProposalResponse
+		if err := parseJSON(resp, &proposal); err != nil {
+			t.Fatalf("failed to parse response: %v", err)
+		}
+		proposalPath := fmt.Sprintf("/api/v0/proposals/%d", proposal.ID)
+
+		fields := validationFields(t, doPut(proposalPath, map[string]interface{}{"custom_answers": map[string]string{"q05": strings.Repeat("x", 5001)}}, speakerToken))
+		if fields["custom_answers.q05"] == "" {
+			t.Errorf("expected an error for q05, got %v", fields)
+		}
+		fields = validationFields(t, doPut(proposalPath, map[string]interface{}{"custom_answers": "x"}, speakerToken))
+		if fields["custom_answers"] == "" {
+			t.Errorf("expected a custom_answers error, got %v", fields)
+		}
+	})
+
+	t.Run("rejects answers to events without questions", func(t *testing.T) {
+		plain := createTestEvent(adminToken, EventInput{
+			Name:       "No Questions Conf",
+			Slug:       fmt.Sprintf("no-questions-%d", now.UnixNano()),
+			StartDate:  now.AddDate(0, 2, 0).Format(time.RFC3339),
+			EndDate:    now.AddDate(0, 2, 1).Format(time.RFC3339),
+			CFPOpenAt:  now.AddDate(0, 0, -1).Format(time.RFC3339),
+			CFPCloseAt: now.AddDate(0, 1, 0).Format(time.RFC3339),
+		})
+		updateCFPStatus(adminToken, plain.ID, "open")
+		path := fmt.Sprintf("/api/v0/events/%d/proposals", plain.ID)
+		fields := validationFields(t, doPost(path, input(map[string]string{"extra": strings.Repeat("x", 100000)}), speakerToken))
+		if fields["custom_answers.extra"] == "" {
+			t.Errorf("expected an error for extra, got %v", fields)
+		}
+	})
+}
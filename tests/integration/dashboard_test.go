@@ -0,0 +1,108 @@
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+type dashboardResponse struct {
+	Events []struct {
+		ID             uint             `json:"id"`
+		CFPOpen        bool             `json:"cfp_open"`
+		IsCreator      bool             `json:"is_creator"`
+		PaymentStatus  string           `json:"payment_status"`
+		ProposalCounts map[string]int64 `json:"proposal_counts"`
+		ProposalTotal  int64            `json:"proposal_total"`
+		UnratedCount   int64            `json:"unrated_count"`
+		Organizers     []struct {
+			Email string `json:"email"`
+		} `json:"organizers"`
+	} `json:"events"`
+	NextCFPDeadline *struct {
+		EventID uint `json:"event_id"`
+	} `json:"next_cfp_deadline"`
+}
+
+func getDashboard(t *testing.T, token string) dashboardResponse {
+	t.Helper()
+	resp := doAuthGet("/api/v0/me/dashboard", token)
+	assertStatus(t, resp, http.StatusOK)
+	var dashboard dashboardResponse
+	if err := parseJSON(resp, &dashboard); err != nil {
+		t.Fatalf("failed to parse dashboard: %v", err)
+	}
+	return dashboard
+}
+
+func TestMyDashboard(t *testing.T) {
+	eventID, accepted := createAcceptedProposal(t, "dashboard")
+	for _, title := range []string{"Unrated Talk", "Rated Talk"} {
+		p := createTestProposal(speakerToken, eventID, ProposalInput{
+			Title:    title,
+			Abstract: "A talk for dashboard testing.",
+			Format:   "talk",
+			Duration: 30,
+			Speakers: []Speaker{
+				{Name: "Speaker User", Email: "speaker@test.com", Bio: "Bio", Company: "Acme", JobTitle: "Dev", LinkedIn: "https://linkedin.com/in/speaker"},
+			},
+		})
+		if title == "Rated Talk" {
+			resp := doPut(fmt.Sprintf("/api/v0/proposals/%d/rating", p.ID), ProposalRatingInput{Rating: 4}, adminToken)
+			assertStatus(t, resp, http.StatusOK)
+			resp.Body.Close()
+		}
+	}
+	resp := doPost(fmt.Sprintf("/api/v0/events/%d/organizers", eventID), OrganizerInput{Email: "other@test.com"}, adminToken)
+	assertStatus(t, resp, http.StatusCreated)
+	resp.Body.Close()
+
+	dashboard := getDashboard(t, adminToken)
+	var found bool
+	for _, e := range dashboard.Events {
+		if e.ID != eventID {
+			continue
+		}
+		found = true
+		if !e.IsCreator || !e.CFPOpen || e.PaymentStatus == "" {
+			t.Errorf("unexpected event flags: %+v", e)
+		}
+		if e.ProposalTotal != 3 || e.ProposalCounts["accepted"] != 1 || e.ProposalCounts["submitted"] != 2 || e.ProposalCounts["rejected"] != 0 {
+			t.Errorf("unexpected proposal counts: total %d, %v", e.ProposalTotal, e.ProposalCounts)
+		}
+		// The accepted proposal is decided, so only one submitted proposal awaits a rating
+		if e.UnratedCount != 1 {
+			t.Errorf("expected 1 unrated proposal, got %d", e.UnratedCount)
+		}
+		if len(e.Organizers) != 1 || e.Organizers[0].Email != "other@test.com" {
+			t.Errorf("expected other@test.com as organizer, got %+v", e.Organizers)
+		}
+	}
+	if !found {
+		t.Fatalf("expected event %d on the dashboard (accepted proposal %d)", eventID, accepted.ID)
+	}
+	if dashboard.NextCFPDeadline == nil {
+		t.Error("expected a next CFP deadline")
+	}
+
+	// Co-organizers see the event too, but not as its creator
+	for _, e := range getDashboard(t, otherToken).Events {
+		if e.ID == eventID && e.IsCreator {
+			t.Error("expected the co-organizer not to be the creator")
+		}
+	}
+
+	resp = doAuthGet("/api/v0/me/dashboard", "")
+	assertStatus(t, resp, http.StatusUnauthorized)
+	resp.Body.Close()
+}
+
+func TestMyDashboard_NoEvents(t *testing.T) {
+	t.Parallel()
+	_, token := createTestUserWithJWT(fmt.Sprintf("dashboard-%d@test.com", time.Now().UnixNano()), "No Events")
+	dashboard := getDashboard(t, token)
+	if dashboard.Events == nil || len(dashboard.Events) != 0 || dashboard.NextCFPDeadline != nil {
+		t.Errorf("expected an empty dashboard, got %+v", dashboard)
+	}
+}
@@ -3,11 +3,15 @@ package integration
 import (
 	"fmt"
 	"net/http"
+	"strings"
 	"testing"
 	"time"
+
+	"github.com/sreday/cfp.ninja/pkg/models"
 )
 
 func TestDeleteEvent_CreatorCanDelete(t *testing.T) {
+	t.Parallel()
 	now := time.Now()
 	event := createTestEvent(adminToken, EventInput{
 		Name:      "Delete Me Event",
@@ -34,6 +38,7 @@ func TestDeleteEvent_CreatorCanDelete(t *testing.T) {
 }
 
 func TestDeleteEvent_NonCreatorForbidden(t *testing.T) {
+	t.Parallel()
 	now := time.Now()
 	event := createTestEvent(adminToken, EventInput{
 		Name:      "Cannot Delete Event",
@@ -54,6 +59,7 @@ func TestDeleteEvent_NonCreatorForbidden(t *testing.T) {
 }
 
 func TestDeleteEvent_OrganizerButNotCreatorForbidden(t *testing.T) {
+	t.Parallel()
 	now := time.Now()
 	event := createTestEvent(adminToken, EventInput{
 		Name:      "Organizer Delete Test",
@@ -78,6 +84,7 @@ func TestDeleteEvent_OrganizerButNotCreatorForbidden(t *testing.T) {
 }
 
 func TestDeleteEvent_Unauthenticated(t *testing.T) {
+	t.Parallel()
 	now := time.Now()
 	event := createTestEvent(adminToken, EventInput{
 		Name:      "Unauth Delete Test",
@@ -93,18 +100,21 @@ func TestDeleteEvent_Unauthenticated(t *testing.T) {
 }
 
 func TestDeleteEvent_NotFound(t *testing.T) {
+	t.Parallel()
 	resp := doDelete("/api/v0/events/99999", adminToken)
 	assertStatus(t, resp, http.StatusNotFound)
 	resp.Body.Close()
 }
 
 func TestDeleteEvent_InvalidID(t *testing.T) {
+	t.Parallel()
 	resp := doDelete("/api/v0/events/invalid", adminToken)
 	assertStatus(t, resp, http.StatusBadRequest)
 	resp.Body.Close()
 }
 
 func TestDeleteEvent_CascadesProposals(t *testing.T) {
+	t.Parallel()
 	now := time.Now()
 
 	// Create event with an open CFP
@@ -135,8 +145,8 @@ func TestDeleteEvent_CascadesProposals(t *testing.T) {
 	assertStatus(t, resp, http.StatusOK)
 	resp.Body.Close()
 
-	// Delete the event
-	resp = doDelete(fmt.Sprintf("/api/v0/events/%d", event.ID), adminToken)
+	// Delete the event, confirming with its slug
+	resp = doRequest(http.MethodDelete, fmt.Sprintf("/api/v0/events/%d", event.ID), map[string]string{"confirm": event.Slug}, adminToken)
 	assertStatus(t, resp, http.StatusOK)
 	resp.Body.Close()
 
@@ -147,6 +157,7 @@ func TestDeleteEvent_CascadesProposals(t *testing.T) {
 }
 
 func TestDeleteEvent_CascadesOrganizers(t *testing.T) {
+	t.Parallel()
 	now := time.Now()
 	event := createTestEvent(adminToken, EventInput{
 		Name:      "Cascade Org Delete",
@@ -179,3 +190,117 @@ func TestDeleteEvent_CascadesOrganizers(t *testing.T) {
 	assertStatus(t, resp, http.StatusNotFound)
 	resp.Body.Close()
 }
+
+func TestDeleteEvent_ProposalsRequireConfirmation(t *testing.T) {
+	t.Parallel()
+	now := time.Now()
+	event := createTestEvent(adminToken, EventInput{
+		Name:       "Confirm Delete Test",
+		Slug:       "confirm-delete-" + fmt.Sprintf("%d", now.UnixNano()),
+		StartDate:  now.AddDate(0, 1, 0).Format(time.RFC3339),
+		EndDate:    now.AddDate(0, 1, 1).Format(time.RFC3339),
+		CFPOpenAt:  now.AddDate(0, 0, -1).Format(time.RFC3339),
+		CFPCloseAt: now.AddDate(0, 0, 7).Format(time.RFC3339),
+	})
+	updateCFPStatus(adminToken, event.ID, "open")
+	var proposals []*ProposalResponse
+	for _, title := range []string{"Keep Me Talk", "Keep Me Too Talk"} {
+		proposals = append(proposals, createTestProposal(speakerToken, event.ID, ProposalInput{
+			Title:    title,
+			Abstract: "A talk worth recovering.",
+			Format:   "talk",
+			Duration: 30,
+			Level:    "beginner",
+			Speakers: []Speaker{
+				{Name: "Speaker User", Email: "speaker@test.com", Bio: "Test bio", Company: "Acme", JobTitle: "Dev", LinkedIn: "https://linkedin.com/in/speaker"},
+			},
+		}))
+	}
+	updateProposalStatus(adminToken, proposals[1].ID, "rejected")
+	path := fmt.Sprintf("/api/v0/events/%d", event.ID)
+
+	type refusal struct {
+		Code    string `json:"code"`
+		Details struct {
+			Slug           string           `json:"slug"`
+			ProposalTotal  int64            `json:"proposal_total"`
+			ProposalCounts map[string]int64 `json:"proposal_counts"`
+			ExportURL      string           `json:"export_url"`
+		} `json:"details"`
+	}
+	refuse := func(t *testing.T, body interface{}) refusal {
+		t.Helper()
+		resp := doRequest(http.MethodDelete, path, body, adminToken)
+		assertStatus(t, resp, http.StatusBadRequest)
+		var got refusal
+		if err := parseJSON(resp, &got); err != nil {
+			t.Fatalf("failed to parse response: %v", err)
+		}
+		if got.Code != "confirmation_required" {
+			t.Errorf("expected confirmation_required, got %q", got.Code)
+		}
+		return got
+	}
+
+	t.Run("refused without confirmation", func(t *testing.T) {
+		got := refuse(t, nil)
+		if got.Details.Slug != event.Slug || got.Details.ProposalTotal != 2 {
+			t.Errorf("expected the slug and two proposals, got %+v", got.Details)
+		}
+		if got.Details.ProposalCounts["submitted"] != 1 || got.Details.ProposalCounts["rejected"] != 1 {
+			t.Errorf("expected counts by status, got %v", got.Details.ProposalCounts)
+		}
+		if got.Details.ExportURL != fmt.Sprintf("/api/v0/events/%d/proposals/export", event.ID) {
+			t.Errorf("expected a pointer to the export, got %q", got.Details.ExportURL)
+		}
+	})
+
+	t.Run("refused on a slug mismatch", func(t *testing.T) {
+		refuse(t, map[string]string{"confirm": "not-" + event.Slug})
+		refuse(t, map[string]string{"confirm": strings.ToUpper(event.Slug)})
+
+		resp := doAuthGet(fmt.Sprintf("/api/v0/proposals/%d", proposals[0].ID), speakerToken)
+		assertStatus(t, resp, http.StatusOK)
+		resp.Body.Close()
+	})
+
+	t.Run("confirmed deletion keeps a copy for recovery", func(t *testing.T) {
+		resp := doRequest(http.MethodDelete, path, map[string]string{"confirm": event.Slug}, adminToken)
+		assertStatus(t, resp, http.StatusOK)
+		resp.Body.Close()
+
+		copies, err := models.GetDeletedProposals(testConfig.DB, event.ID)
+		if err != nil {
+			t.Fatalf("failed to load deleted proposals: %v", err)
+		}
+		if len(copies) != 2 || copies[0].ProposalID != proposals[0].ID || copies[0].EventSlug != event.Slug {
+			t.Fatalf("expected copies of both proposals, got %+v", copies)
+		}
+		if d := time.Until(copies[0].PurgeAfter); d < models.DeletedProposalRetention-time.Hour || d > models.DeletedProposalRetention {
+			t.Errorf("expected the copy to be purged in 30 days, got %v", d)
+		}
+		if !strings.Contains(string(copies[0].Data), "Keep Me Talk") {
+			t.Errorf("expected the copy to hold the proposal, got %s", copies[0].Data)
+		}
+	})
+
+	t.Run("copies are purged after retention", func(t *testing.T) {
+		if _, err := models.PurgeDeletedProposals(testConfig.DB, time.Now().Add(models.DeletedProposalRetention+time.Hour)); err != nil {
+			t.Fatalf("failed to purge: %v", err)
+		}
+		copies, err := models.GetDeletedProposals(testConfig.DB, event.ID)
+		if err != nil {
+			t.Fatalf("failed to load deleted proposals: %v", err)
+		}
+		if len(copies) != 0 {
+			t.Errorf("expected the copies to be purged, got %d", len(copies))
+		}
+		var remaining int64
+		if err := testConfig.DB.Unscoped().Model(&models.Proposal{}).Where("event_id = ?", event.ID).Count(&remaining).Error; err != nil {
+			t.Fatalf("failed to count proposals: %v", err)
+		}
+		if remaining != 0 {
+			t.Errorf("expected no proposals left for the event, got %d", remaining)
+		}
+	})
+}
@@ -0,0 +1,165 @@
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestDeleteUser_CreatedEvents(t *testing.T) {
+	t.Parallel()
+	now := time.Now().UTC()
+	newEvent := func(token, name string) *EventResponse {
+		return createTestEvent(token, EventInput{
+			Name:       name,
+			Slug:       fmt.Sprintf("deleted-creator-%d", time.Now().UnixNano()),
+			StartDate:  now.AddDate(0, 2, 0).Format(time.RFC3339),
+			EndDate:    now.AddDate(0, 2, 1).Format(time.RFC3339),
+			CFPOpenAt:  now.AddDate(0, 0, -1).Format(time.RFC3339),
+			CFPCloseAt: now.AddDate(0, 1, 0).Format(time.RFC3339),
+		})
+	}
+	addOrganizer := func(t *testing.T, eventID uint, email, token string) {
+		t.Helper()
+		resp := doPost(fmt.Sprintf("/api/v0/events/%d/organizers", eventID), OrganizerInput{Email: email}, token)
+		assertStatus(t, resp, http.StatusCreated)
+		resp.Body.Close()
+	}
+	type organizer struct {
+		ID        uint `json:"id"`
+		IsCreator bool `json:"is_creator"`
+	}
+	organizers := func(t *testing.T, eventID uint, token string) []organizer {
+		t.Helper()
+		resp := doAuthGet(fmt.Sprintf("/api/v0/events/%d/organizers", eventID), token)
+		assertStatus(t, resp, http.StatusOK)
+		var orgs []organizer
+		if err := parseJSON(resp, &orgs); err != nil {
+			t.Fatalf("failed to parse organizers: %v", err)
+		}
+		return orgs
+	}
+	type deleteResult struct {
+		Transferred []struct {
+			EventID      uint `json:"event_id"`
+			NewCreatorID uint `json:"new_creator_id"`
+		} `json:"transferred_events"`
+		Orphaned []uint `json:"orphaned_events"`
+	}
+	deleteUser := func(t *testing.T, userID uint) deleteResult {
+		t.Helper()
+		resp := doDelete(fmt.Sprintf("/api/v0/admin/users/%d", userID), adminToken)
+		assertStatus(t, resp, http.StatusOK)
+		var result deleteResult
+		if err := parseJSON(resp, &result); err != nil {
+			t.Fatalf("failed to parse response: %v", err)
+		}
+		return result
+	}
+
+	stamp := time.Now().UnixNano()
+	creator, creatorToken := createTestUserWithJWT(fmt.Sprintf("creator-%d@test.com", stamp), "Deleted Creator")
+	// The heir is the co-organizer with the oldest account, whatever the order they were added in
+	heir, heirToken := createTestUserWithJWT(fmt.Sprintf("heir-%d@test.com", stamp), "Heir Organizer")
+	younger, youngerToken := createTestUserWithJWT(fmt.Sprintf("younger-%d@test.com", stamp), "Younger Organizer")
+
+	shared := newEvent(creatorToken, "Shared Conf")
+	addOrganizer(t, shared.ID, younger.Email, creatorToken)
+	addOrganizer(t, shared.ID, heir.Email, creatorToken)
+	solo := newEvent(creatorToken, "Solo Conf")
+
+	t.Run("non-admins can't delete users", func(t *testing.T) {
+		resp := doDelete(fmt.Sprintf("/api/v0/admin/users/%d", creator.ID), speakerToken)
+		assertStatus(t, resp, http.StatusForbidden)
+		resp.Body.Close()
+	})
+
+	t.Run("admins can't delete themselves", func(t *testing.T) {
+		resp := doDelete(fmt.Sprintf("/api/v0/admin/users/%d", userAdmin.ID), adminToken)
+		assertStatus(t, resp, http.StatusBadRequest)
+		resp.Body.Close()
+	})
+
+	result := deleteUser(t, creator.ID)
+
+	t.Run("reports what became of the events", func(t *testing.T) {
+		if len(result.Transferred) != 1 || result.Transferred[0].EventID != shared.ID || result.Transferred[0].NewCreatorID != heir.ID {
+			t.Errorf("expected %d to go to user %d, got %+v", shared.ID, heir.ID, result.Transferred)
+		}
+		if len(result.Orphaned) != 1 || result.Orphaned[0] != solo.ID {
+			t.Errorf("expected %d to be orphaned, got %v", solo.ID, result.Orphaned)
+		}
+	})
+
+	t.Run("deleted users can't sign in", func(t *testing.T) {
+		resp := doAuthGet("/api/v0/auth/me", creatorToken)
+		assertStatus(t, resp, http.StatusUnauthorized)
+		resp.Body.Close()
+	})
+
+	t.Run("heir becomes the creator", func(t *testing.T) {
+		orgs := organizers(t, shared.ID, youngerToken)
+		if len(orgs) != 2 {
+			t.Fatalf("expected 2 organizers, got %+v", orgs)
+		}
+		for _, o := range orgs {
+			if o.IsCreator != (o.ID == heir.ID) {
+				t.Errorf("organizer %d: is_creator = %v", o.ID, o.IsCreator)
+			}
+		}
+
+		activity := getActivity(t, shared.ID, "?action=creator_changed", heirToken)
+		if len(activity.Data) != 1 || activity.Data[0].Summary != "Admin User made Heir Organizer the event creator" {
+			t.Errorf("expected a creator_changed entry, got %+v", activity.Data)
+		}
+	})
+
+	t.Run("heir can remove organizers", func(t *testing.T) {
+		resp := doDelete(fmt.Sprintf("/api/v0/events/%d/organizers/%d", shared.ID, younger.ID), heirToken)
+		assertStatus(t, resp, http.StatusOK)
+		resp.Body.Close()
+	})
+
+	t.Run("orphaned event has no creator", func(t *testing.T) {
+		resp := doAuthGet(fmt.Sprintf("/api/v0/me/events/%d", solo.ID), adminToken)
+		assertStatus(t, resp, http.StatusOK)
+		var event EventResponse
+		if err := parseJSON(resp, &event); err != nil {
+			t.Fatalf("failed to parse event: %v", err)
+		}
+		if event.CreatedByID != nil {
+			t.Errorf("expected no creator, got %d", *event.CreatedByID)
+		}
+	})
+
+	t.Run("only admins manage orphaned events", func(t *testing.T) {
+		resp := doPost(fmt.Sprintf("/api/v0/events/%d/organizers", solo.ID), OrganizerInput{Email: younger.Email}, otherToken)
+		assertStatus(t, resp, http.StatusForbidden)
+		resp.Body.Close()
+
+		if orgs := organizers(t, solo.ID, adminToken); len(orgs) != 0 {
+			t.Errorf("expected no organizers, got %+v", orgs)
+		}
+		addOrganizer(t, solo.ID, younger.Email, adminToken)
+
+		// Co-organizers still can't take the creator's actions
+		resp = doDelete(fmt.Sprintf("/api/v0/events/%d", solo.ID), youngerToken)
+		assertStatus(t, resp, http.StatusForbidden)
+		resp.Body.Close()
+
+		resp = doDelete(fmt.Sprintf("/api/v0/events/%d/organizers/%d", solo.ID, younger.ID), adminToken)
+		assertStatus(t, resp, http.StatusOK)
+		resp.Body.Close()
+
+		resp = doDelete(fmt.Sprintf("/api/v0/events/%d", solo.ID), adminToken)
+		assertStatus(t, resp, http.StatusOK)
+		resp.Body.Close()
+	})
+
+	t.Run("unknown user", func(t *testing.T) {
+		resp := doDelete(fmt.Sprintf("/api/v0/admin/users/%d", creator.ID), adminToken)
+		assertStatus(t, resp, http.StatusNotFound)
+		resp.Body.Close()
+	})
+}
@@ -0,0 +1,33 @@
+package integration
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestDigestPreview(t *testing.T) {
+	t.Parallel()
+	t.Run("renders for a non-organizer", func(t *testing.T) {
+		resp := doAuthGet("/api/v0/me/digest/preview", otherToken)
+		assertStatus(t, resp, http.StatusOK)
+		defer resp.Body.Close()
+		if ct := resp.Header.Get("Content-Type"); !strings.HasPrefix(ct, "text/html") {
+			t.Errorf("expected text/html, got %q", ct)
+		}
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatalf("failed to read body: %v", err)
+		}
+		if !strings.Contains(string(body), "Weekly CFP Digest") {
+			t.Error("expected the digest heading in the preview")
+		}
+	})
+
+	t.Run("requires auth", func(t *testing.T) {
+		resp := doAuthGet("/api/v0/me/digest/preview", "")
+		assertStatus(t, resp, http.StatusUnauthorized)
+		resp.Body.Close()
+	})
+}
@@ -0,0 +1,54 @@
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestProposalNotifications(t *testing.T) {
+	t.Parallel()
+	_, proposal := createAcceptedProposal(t, "email-log")
+	path := fmt.Sprintf("/api/v0/proposals/%d/notifications", proposal.ID)
+
+	type emailLog struct {
+		Template   string `json:"template"`
+		To         string `json:"to"`
+		Status     string `json:"status"`
+		ProposalID uint   `json:"proposal_id"`
+	}
+
+	// The acceptance email is sent in the background
+	deadline := time.Now().Add(3 * time.Second)
+	var logs []emailLog
+	for {
+		resp := doAuthGet(path, adminToken)
+		assertStatus(t, resp, http.StatusOK)
+		if err := parseJSON(resp, &logs); err != nil {
+			t.Fatalf("failed to parse notifications: %v", err)
+		}
+		if len(logs) > 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("expected the acceptance email in the send log")
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	got := logs[0]
+	if got.Template != "proposal_accepted" || got.To != "speaker@test.com" || got.ProposalID != proposal.ID {
+		t.Errorf("unexpected log entry %+v", got)
+	}
+	// Tests run without RESEND_API_KEY, so emails are only logged
+	if got.Status != "disabled" {
+		t.Errorf("status = %q, want disabled", got.Status)
+	}
+
+	t.Run("speakers cannot see the log", func(t *testing.T) {
+		resp := doAuthGet(path, speakerToken)
+		assertStatus(t, resp, http.StatusForbidden)
+		resp.Body.Close()
+	})
+}
@@ -28,6 +28,7 @@ func createConfirmedProposal(t *testing.T, suffix string) (uint, *ProposalRespon
 }
 
 func TestEmergencyCancel_OwnerCancelsConfirmed(t *testing.T) {
+	t.Parallel()
 	_, proposal := createConfirmedProposal(t, "ec-owner-cancel")
 
 	resp := doPut(
@@ -41,8 +42,8 @@ func TestEmergencyCancel_OwnerCancelsConfirmed(t *testing.T) {
 	if err := parseJSON(resp, &result); err != nil {
 		t.Fatalf("failed to parse response: %v", err)
 	}
-	if result.Status != "rejected" {
-		t.Errorf("expected status 'rejected', got %q", result.Status)
+	if result.Status != "cancelled" {
+		t.Errorf("expected status 'cancelled', got %q", result.Status)
 	}
 	if result.AttendanceConfirmed {
 		t.Error("expected attendance_confirmed to be false")
@@ -50,6 +51,7 @@ func TestEmergencyCancel_OwnerCancelsConfirmed(t *testing.T) {
 }
 
 func TestEmergencyCancel_NonOwnerForbidden(t *testing.T) {
+	t.Parallel()
 	_, proposal := createConfirmedProposal(t, "ec-nonowner")
 
 	resp := doPut(
@@ -62,6 +64,7 @@ func TestEmergencyCancel_NonOwnerForbidden(t *testing.T) {
 }
 
 func TestEmergencyCancel_SubmittedProposal(t *testing.T) {
+	t.Parallel()
 	now := time.Now()
 	event := createTestEvent(adminToken, EventInput{
 		Name:       "EC Submitted Test",
@@ -94,6 +97,7 @@ func TestEmergencyCancel_SubmittedProposal(t *testing.T) {
 }
 
 func TestEmergencyCancel_AcceptedButNotConfirmed(t *testing.T) {
+	t.Parallel()
 	_, proposal := createAcceptedProposal(t, "ec-not-confirmed")
 
 	resp := doPut(
@@ -106,6 +110,7 @@ func TestEmergencyCancel_AcceptedButNotConfirmed(t *testing.T) {
 }
 
 func TestEmergencyCancel_AlreadyRejected(t *testing.T) {
+	t.Parallel()
 	now := time.Now()
 	event := createTestEvent(adminToken, EventInput{
 		Name:       "EC Rejected Test",
@@ -139,6 +144,7 @@ func TestEmergencyCancel_AlreadyRejected(t *testing.T) {
 }
 
 func TestEmergencyCancel_Unauthenticated(t *testing.T) {
+	t.Parallel()
 	_, proposal := createConfirmedProposal(t, "ec-unauth")
 
 	resp := doPut(
@@ -151,6 +157,7 @@ func TestEmergencyCancel_Unauthenticated(t *testing.T) {
 }
 
 func TestEmergencyCancel_NotFound(t *testing.T) {
+	t.Parallel()
 	resp := doPut(
 		"/api/v0/proposals/99999/emergency-cancel",
 		map[string]interface{}{},
@@ -161,6 +168,7 @@ func TestEmergencyCancel_NotFound(t *testing.T) {
 }
 
 func TestEmergencyCancel_DoubleCancel(t *testing.T) {
+	t.Parallel()
 	_, proposal := createConfirmedProposal(t, "ec-double")
 
 	// First cancel succeeds
@@ -172,7 +180,7 @@ func TestEmergencyCancel_DoubleCancel(t *testing.T) {
 	assertStatus(t, resp, http.StatusOK)
 	resp.Body.Close()
 
-	// Second cancel fails (status is now rejected, not accepted)
+	// Second cancel fails (status is now cancelled, not accepted)
 	resp = doPut(
 		fmt.Sprintf("/api/v0/proposals/%d/emergency-cancel", proposal.ID),
 		map[string]interface{}{},
@@ -181,3 +189,86 @@ func TestEmergencyCancel_DoubleCancel(t *testing.T) {
 	assertStatus(t, resp, http.StatusBadRequest)
 	resp.Body.Close()
 }
+
+// createTentativeProposal submits another proposal to eventID and marks it tentative
+func createTentativeProposal(t *testing.T, eventID uint, title string) *ProposalResponse {
+	t.Helper()
+	proposal := createTestProposal(speakerToken, eventID, ProposalInput{
+		Title:    title,
+		Abstract: "A talk on the waitlist.",
+		Format:   "talk",
+		Duration: 30,
+		Level:    "beginner",
+		Speakers: []Speaker{
+			{Name: "Speaker User", Email: "speaker@test.com", Bio: "Bio", Company: "Acme", JobTitle: "Dev", LinkedIn: "https://linkedin.com/in/speaker"},
+		},
+	})
+	updateProposalStatus(adminToken, proposal.ID, "tentative")
+	return proposal
+}
+
+func getProposalStatus(t *testing.T, id uint) string {
+	t.Helper()
+	resp := doAuthGet(fmt.Sprintf("/api/v0/proposals/%d", id), adminToken)
+	assertStatus(t, resp, http.StatusOK)
+	var p ProposalResponse
+	if err := parseJSON(resp, &p); err != nil {
+		t.Fatalf("failed to parse proposal: %v", err)
+	}
+	return p.Status
+}
+
+func TestEmergencyCancel_WaitlistPromotion(t *testing.T) {
+	t.Parallel()
+	t.Run("promotes the highest-rated tentative proposal", func(t *testing.T) {
+		eventID, proposal := createConfirmedProposal(t, "ec-waitlist")
+		resp := doPut(fmt.Sprintf("/api/v0/events/%d", eventID), map[string]interface{}{
+			"waitlist_auto_promote": true,
+			"max_accepted":          1,
+		}, adminToken)
+		assertStatus(t, resp, http.StatusOK)
+		resp.Body.Close()
+
+		older := createTentativeProposal(t, eventID, "Waitlist Unrated")
+		rated := createTentativeProposal(t, eventID, "Waitlist Rated")
+		resp = doPut(fmt.Sprintf("/api/v0/proposals/%d/rating", rated.ID), map[string]interface{}{"rating": 4}, adminToken)
+		assertStatus(t, resp, http.StatusOK)
+		resp.Body.Close()
+
+		resp = doPut(fmt.Sprintf("/api/v0/proposals/%d/emergency-cancel", proposal.ID), map[string]interface{}{}, speakerToken)
+		assertStatus(t, resp, http.StatusOK)
+		resp.Body.Close()
+
+		if got := getProposalStatus(t, rated.ID); got != "accepted" {
+			t.Errorf("expected rated tentative proposal to be accepted, got %q", got)
+		}
+		if got := getProposalStatus(t, older.ID); got != "tentative" {
+			t.Errorf("expected max_accepted to leave the other proposal tentative, got %q", got)
+		}
+	})
+
+	t.Run("no promotion without opt-in", func(t *testing.T) {
+		eventID, proposal := createConfirmedProposal(t, "ec-no-waitlist")
+		tentative := createTentativeProposal(t, eventID, "Waitlist Not Promoted")
+
+		resp := doPut(fmt.Sprintf("/api/v0/proposals/%d/emergency-cancel", proposal.ID), map[string]interface{}{}, speakerToken)
+		assertStatus(t, resp, http.StatusOK)
+		resp.Body.Close()
+
+		if got := getProposalStatus(t, tentative.ID); got != "tentative" {
+			t.Errorf("expected proposal to stay tentative, got %q", got)
+		}
+
+		resp = doAuthGet(fmt.Sprintf("/api/v0/events/%d/proposals/summary", eventID), adminToken)
+		assertStatus(t, resp, http.StatusOK)
+		var summary struct {
+			ByStatus map[string]int64 `json:"by_status"`
+		}
+		if err := parseJSON(resp, &summary); err != nil {
+			t.Fatalf("failed to parse summary: %v", err)
+		}
+		if summary.ByStatus["cancelled"] != 1 || summary.ByStatus["rejected"] != 0 {
+			t.Errorf("expected the cancellation counted apart from rejections, got %v", summary.ByStatus)
+		}
+	})
+}
@@ -0,0 +1,183 @@
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// errorEnvelope is the v2 error response shape
+type errorEnvelope struct {
+	Error struct {
+		Code    string            `json:"code"`
+		Message string            `json:"message"`
+		Fields  map[string]string `json:"fields"`
+	} `json:"error"`
+}
+
+// assertErrorCode checks the code of a v2 error response
+func assertErrorCode(t *testing.T, resp *http.Response, expectedCode string) {
+	t.Helper()
+	var result errorEnvelope
+	if err := parseJSON(resp, &result); err != nil {
+		t.Fatalf("failed to parse JSON: %v", err)
+	}
+	if result.Error.Code != expectedCode {
+		t.Errorf("expected code %q, got %q (message %q)", expectedCode, result.Error.Code, result.Error.Message)
+	}
+}
+
+func TestErrors_LegacyShapeIncludesCode(t *testing.T) {
+	t.Parallel()
+	resp := doGet("/api/v0/e/does-not-exist")
+	assertStatus(t, resp, http.StatusNotFound)
+
+	var result map[string]string
+	if err := parseJSON(resp, &result); err != nil {
+		t.Fatalf("failed to parse JSON: %v", err)
+	}
+	if result["error"] != "Event not found" {
+		t.Errorf("expected error 'Event not found', got %q", result["error"])
+	}
+	if result["code"] != "not_found" {
+		t.Errorf("expected code 'not_found', got %q", result["code"])
+	}
+}
+
+func TestErrors_NotFound(t *testing.T) {
+	t.Parallel()
+	resp := doGet("/api/v0/e/does-not-exist?v=2")
+	assertStatus(t, resp, http.StatusNotFound)
+	assertErrorCode(t, resp, "not_found")
+}
+
+func TestErrors_SlugConflict(t *testing.T) {
+	resp := doPost("/api/v0/events?v=2", map[string]interface{}{
+		"name": "Duplicate",
+		"slug": eventGopherCon.Slug,
+	}, adminToken)
+	assertStatus(t, resp, http.StatusConflict)
+	assertErrorCode(t, resp, "slug_conflict")
+}
+
+func TestErrors_SlugConflictOnUpdate(t *testing.T) {
+	now := time.Now()
+	event := createTestEvent(adminToken, EventInput{
+		Name:      "Slug Update Conflict",
+		Slug:      fmt.Sprintf("slug-update-conflict-%d", now.UnixNano()),
+		StartDate: now.AddDate(0, 1, 0).Format(time.RFC3339),
+		EndDate:   now.AddDate(0, 1, 1).Format(time.RFC3339),
+	})
+	resp := doPut(fmt.Sprintf("/api/v0/events/%d?v=2", event.ID), map[string]interface{}{
+		"slug": eventGopherCon.Slug,
+	}, adminToken)
+	assertStatus(t, resp, http.StatusConflict)
+	assertErrorCode(t, resp, "slug_conflict")
+}
+
+func TestErrors_Validation(t *testing.T) {
+	t.Parallel()
+	resp := doPost("/api/v0/events?v=2", map[string]interface{}{
+		"slug": fmt.Sprintf("no-name-%d", time.Now().UnixNano()),
+	}, adminToken)
+	assertStatus(t, resp, http.StatusBadRequest)
+	assertErrorCode(t, resp, "validation")
+}
+
+func TestErrors_Forbidden(t *testing.T) {
+	resp := doDelete(fmt.Sprintf("/api/v0/events/%d?v=2", eventGopherCon.ID), speakerToken)
+	assertStatus(t, resp, http.StatusForbidden)
+	assertErrorCode(t, resp, "forbidden")
+}
+
+func TestErrors_CFPClosed(t *testing.T) {
+	resp := doPost(fmt.Sprintf("/api/v0/events/%d/proposals?v=2", eventClosedEvent.ID), map[string]interface{}{
+		"title":    "Too late",
+		"abstract": "Submitted after the deadline",
+	}, speakerToken)
+	assertStatus(t, resp, http.StatusBadRequest)
+	assertErrorCode(t, resp, "cfp_closed")
+}
+
+func TestErrors_AcceptHeaderSelectsEnvelope(t *testing.T) {
+	t.Parallel()
+	req, err := http.NewRequest(http.MethodGet, testServer.URL+"/api/v0/e/does-not-exist", nil)
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+	req.Header.Set("Accept", "application/json; version=2")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	assertStatus(t, resp, http.StatusNotFound)
+	assertErrorCode(t, resp, "not_found")
+}
+
+func TestErrors_CreateEventReportsAllFields(t *testing.T) {
+	t.Parallel()
+	resp := doPost("/api/v0/events?v=2", map[string]interface{}{
+		"slug":       "Not A Valid Slug!",
+		"website":    "ftp://example.com",
+		"cfp_status": "bogus",
+	}, adminToken)
+	assertStatus(t, resp, http.StatusBadRequest)
+
+	var result errorEnvelope
+	if err := parseJSON(resp, &result); err != nil {
+		t.Fatalf("failed to parse JSON: %v", err)
+	}
+	if result.Error.Code != "validation" {
+		t.Errorf("expected code 'validation', got %q", result.Error.Code)
+	}
+	for _, field := range []string{"slug", "name", "website", "cfp_status"} {
+		if _, ok := result.Error.Fields[field]; !ok {
+			t.Errorf("expected error for field %q, got %v", field, result.Error.Fields)
+		}
+	}
+}
+
+func TestErrors_CreateProposalReportsAllFields(t *testing.T) {
+	resp := doPost(fmt.Sprintf("/api/v0/events/%d/proposals", eventGopherCon.ID), map[string]interface{}{
+		"title": "",
+		"speakers": []map[string]interface{}{
+			{"name": "Speaker", "email": "speaker@test.com"},
+		},
+	}, speakerToken)
+	assertStatus(t, resp, http.StatusBadRequest)
+
+	// Legacy clients get the first violation as the message, plus all fields
+	var result struct {
+		Error  string            `json:"error"`
+		Code   string            `json:"code"`
+		Fields map[string]string `json:"fields"`
+	}
+	if err := parseJSON(resp, &result); err != nil {
+		t.Fatalf("failed to parse JSON: %v", err)
+	}
+	if result.Error != "Title is required" {
+		t.Errorf("expected error 'Title is required', got %q", result.Error)
+	}
+	for _, field := range []string{"title", "abstract", "speakers[0].company", "speakers[0].job_title", "speakers[0].linkedin"} {
+		if _, ok := result.Fields[field]; !ok {
+			t.Errorf("expected error for field %q, got %v", field, result.Fields)
+		}
+	}
+}
+
+func TestErrors_UpdateEventReportsAllFields(t *testing.T) {
+	resp := doPut(fmt.Sprintf("/api/v0/events/%d?v=2", eventGopherCon.ID), map[string]interface{}{
+		"terms_url":     "not a url",
+		"contact_email": "not-an-email",
+	}, adminToken)
+	assertStatus(t, resp, http.StatusBadRequest)
+
+	var result errorEnvelope
+	if err := parseJSON(resp, &result); err != nil {
+		t.Fatalf("failed to parse JSON: %v", err)
+	}
+	if len(result.Error.Fields) != 2 {
+		t.Errorf("expected 2 field errors, got %v", result.Error.Fields)
+	}
+}
@@ -1,14 +1,32 @@
 package integration
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
 	"testing"
 	"time"
+
+	"github.com/sreday/cfp.ninja/pkg/api"
+	"github.com/sreday/cfp.ninja/pkg/database"
+	"github.com/sreday/cfp.ninja/pkg/models"
+	"gorm.io/gorm"
 )
 
 func TestListEvents(t *testing.T) {
-	resp := doGet("/api/v0/events")
+	t.Parallel()
+	f := newFixtures(t)
+	first := f.event(adminToken, EventInput{})
+	second := f.event(adminToken, EventInput{})
+	f.draftEvent(adminToken, EventInput{}) // drafts are never listed
+
+	resp := doGet("/api/v0/events?tag=" + f.tag)
 	assertStatus(t, resp, http.StatusOK)
 
 	var result EventListResponse
@@ -16,24 +34,56 @@ func TestListEvents(t *testing.T) {
 		t.Fatalf("failed to parse response: %v", err)
 	}
 
-	// Should have at least the events we created
-	if result.Pagination.Total < 3 {
-		t.Errorf("expected at least 3 events, got %d", result.Pagination.Total)
+	if result.Pagination.Total != 2 || len(result.Data) != 2 {
+		t.Fatalf("expected 2 events, got %d: %+v", result.Pagination.Total, result.Data)
+	}
+	for _, e := range result.Data {
+		if e.ID != first.ID && e.ID != second.ID {
+			t.Errorf("unexpected event %s", e.Slug)
+		}
 	}
 }
 
 func TestListEventsFilters(t *testing.T) {
+	t.Parallel()
+	f := newFixtures(t)
+	now := time.Now()
+	// Every event is named after the fixtures' tag, so filters that can't
+	// be combined with ?tag= narrow the listing to them with ?q=
+	gopherCon := f.event(adminToken, EventInput{
+		Name:     "GopherCon " + f.tag,
+		Location: "Denver, CO",
+		Country:  "US",
+		Tags:     "go,conference",
+	})
+	f.event(adminToken, EventInput{
+		Name:     "DevOpsCon " + f.tag,
+		Location: "Berlin",
+		Country:  "DE",
+		Tags:     "devops,cloud",
+	})
+	past := f.draftEvent(adminToken, EventInput{
+		Name:       "Past Conference " + f.tag,
+		Location:   "New York",
+		Country:    "US",
+		StartDate:  now.AddDate(0, 0, -30).Format(time.RFC3339),
+		EndDate:    now.AddDate(0, 0, -28).Format(time.RFC3339),
+		CFPOpenAt:  now.AddDate(0, 0, -90).Format(time.RFC3339),
+		CFPCloseAt: now.AddDate(0, 0, -60).Format(time.RFC3339),
+	})
+	f.cfpStatus(adminToken, past.ID, "closed")
+	f.draftEvent(adminToken, EventInput{Name: "Draft Conference " + f.tag, Country: "US", Tags: "go"})
+
 	tests := []struct {
-		name          string
-		query         string
-		expectAtLeast int
-		expectAtMost  int
-		validate      func(t *testing.T, events []EventResponse)
+		name     string
+		query    string
+		expected int
+		validate func(t *testing.T, events []EventResponse)
 	}{
 		{
-			name:          "filter by country US",
-			query:         "?country=US",
-			expectAtLeast: 2, // GopherCon, Draft, Past
+			name:     "filter by country US",
+			query:    "?tag=" + f.tag + "&country=US",
+			expected: 2, // GopherCon, Past; drafts are never listed
 			validate: func(t *testing.T, events []EventResponse) {
 				for _, e := range events {
 					if e.Country != "US" {
@@ -43,9 +93,9 @@ func TestListEventsFilters(t *testing.T) {
 			},
 		},
 		{
-			name:          "filter by country Germany",
-			query:         "?country=DE",
-			expectAtLeast: 1,
+			name:     "filter by country Germany",
+			query:    "?tag=" + f.tag + "&country=DE",
+			expected: 1,
 			validate: func(t *testing.T, events []EventResponse) {
 				for _, e := range events {
 					if e.Country != "DE" {
@@ -55,9 +105,9 @@ func TestListEventsFilters(t *testing.T) {
 			},
 		},
 		{
-			name:          "filter by tag go",
-			query:         "?tag=go",
-			expectAtLeast: 1,
+			name:     "filter by tag go",
+			query:    "?q=" + f.tag + "&tag=go",
+			expected: 1,
 			validate: func(t *testing.T, events []EventResponse) {
 				for _, e := range events {
 					if e.Tags == "" || !containsTag(e.Tags, "go") {
@@ -67,14 +117,14 @@ func TestListEventsFilters(t *testing.T) {
 			},
 		},
 		{
-			name:          "filter by tag devops",
-			query:         "?tag=devops",
-			expectAtLeast: 1,
+			name:     "filter by tag devops",
+			query:    "?q=" + f.tag + "&tag=devops",
+			expected: 1,
 		},
 		{
-			name:          "filter by status open",
-			query:         "?status=open",
-			expectAtLeast: 3, // GopherCon, DevOpsCon, PyCon
+			name:     "filter by status open",
+			query:    "?tag=" + f.tag + "&status=open",
+			expected: 2, // GopherCon, DevOpsCon
 			validate: func(t *testing.T, events []EventResponse) {
 				for _, e := range events {
 					if e.CFPStatus != "open" {
@@ -84,36 +134,29 @@ func TestListEventsFilters(t *testing.T) {
 			},
 		},
 		{
-			name:          "filter by status closed",
-			query:         "?status=closed",
-			expectAtLeast: 1, // Closed event, Draft event
+			name:     "filter by status closed",
+			query:    "?tag=" + f.tag + "&status=closed",
+			expected: 1, // Past
 		},
 		{
-			name:          "search by name",
-			query:         "?q=GopherCon",
-			expectAtLeast: 1,
+			name:     "search by name",
+			query:    "?q=" + url.QueryEscape("GopherCon "+f.tag),
+			expected: 1,
 			validate: func(t *testing.T, events []EventResponse) {
-				found := false
-				for _, e := range events {
-					if e.Slug == "gophercon-2025" {
-						found = true
-						break
-					}
-				}
-				if !found {
-					t.Error("expected to find GopherCon event in search results")
+				if len(events) != 1 || events[0].ID != gopherCon.ID {
+					t.Errorf("expected to find the GopherCon event in search results, got %+v", events)
 				}
 			},
 		},
 		{
-			name:          "search by location",
-			query:         "?q=Berlin",
-			expectAtLeast: 1,
+			name:     "filter by location",
+			query:    "?tag=" + f.tag + "&location=Berlin",
+			expected: 1,
 		},
 		{
-			name:         "combined filters",
-			query:        "?country=US&status=open",
-			expectAtMost: 5,
+			name:     "combined filters",
+			query:    "?tag=" + f.tag + "&country=US&status=open",
+			expected: 1, // GopherCon
 		},
 	}
 
@@ -127,11 +170,8 @@ func TestListEventsFilters(t *testing.T) {
 				t.Fatalf("failed to parse response: %v", err)
 			}
 
-			if tc.expectAtLeast > 0 && result.Pagination.Total < tc.expectAtLeast {
-				t.Errorf("expected at least %d events, got %d", tc.expectAtLeast, result.Pagination.Total)
-			}
-			if tc.expectAtMost > 0 && result.Pagination.Total > tc.expectAtMost {
-				t.Errorf("expected at most %d events, got %d", tc.expectAtMost, result.Pagination.Total)
+			if result.Pagination.Total != tc.expected {
+				t.Errorf("expected %d events, got %d", tc.expected, result.Pagination.Total)
 			}
 			if tc.validate != nil {
 				tc.validate(t, result.Data)
@@ -141,6 +181,7 @@ func TestListEventsFilters(t *testing.T) {
 }
 
 func TestListEventsPagination(t *testing.T) {
+	t.Parallel()
 	tests := []struct {
 		name            string
 		query           string
@@ -188,6 +229,7 @@ func TestListEventsPagination(t *testing.T) {
 }
 
 func TestGetEventBySlug(t *testing.T) {
+	t.Parallel()
 	tests := []struct {
 		name         string
 		slug         string
@@ -269,6 +311,7 @@ func TestGetEventByID(t *testing.T) {
 }
 
 func TestCreateEvent(t *testing.T) {
+	t.Parallel()
 	now := time.Now()
 	tests := []struct {
 		name         string
@@ -345,6 +388,7 @@ func TestCreateEvent(t *testing.T) {
 }
 
 func TestUpdateEvent(t *testing.T) {
+	t.Parallel()
 	// Create a test event first
 	now := time.Now()
 	event := createTestEvent(adminToken, EventInput{
@@ -410,6 +454,7 @@ func TestUpdateEvent(t *testing.T) {
 }
 
 func TestUpdateCFPStatus(t *testing.T) {
+	t.Parallel()
 	// Create a test event first
 	now := time.Now()
 	event := createTestEvent(adminToken, EventInput{
@@ -467,28 +512,38 @@ func TestUpdateCFPStatus(t *testing.T) {
 }
 
 func TestGetMyEvents(t *testing.T) {
+	t.Parallel()
+	f := newFixtures(t)
+	_, organizerToken := f.user("Organizer")
+	_, submitterToken := f.user("Speaker")
+	event := f.event(organizerToken, EventInput{})
+	f.event(organizerToken, EventInput{})
+	f.proposal(submitterToken, event.ID, ProposalInput{})
+
 	tests := []struct {
-		name                   string
-		token                 string
-		expectedCode           int
-		expectManagingAtLeast  int
-		expectSubmittedAtLeast int
+		name              string
+		token             string
+		expectedCode      int
+		expectManaging    int
+		expectSubmitted   int
+		expectSubmittedTo uint
 	}{
 		{
-			name:                  "admin's events",
-			token:                adminToken,
-			expectedCode:          http.StatusOK,
-			expectManagingAtLeast: 5, // Created 5 events in fixtures
+			name:           "organizer's events",
+			token:          organizerToken,
+			expectedCode:   http.StatusOK,
+			expectManaging: 2,
 		},
 		{
-			name:                   "speaker's events",
-			token:                 speakerToken,
-			expectedCode:           http.StatusOK,
-			expectSubmittedAtLeast: 1, // Speaker submitted to GopherCon
+			name:              "speaker's events",
+			token:             submitterToken,
+			expectedCode:      http.StatusOK,
+			expectSubmitted:   1,
+			expectSubmittedTo: event.ID,
 		},
 		{
 			name:         "unauthorized",
-			token:       "",
+			token:        "",
 			expectedCode: http.StatusUnauthorized,
 		},
 	}
@@ -500,26 +555,55 @@ func TestGetMyEvents(t *testing.T) {
 
 			if tc.expectedCode == http.StatusOK {
 				var result struct {
-					Managing  []interface{} `json:"managing"`
-					Submitted []interface{} `json:"submitted"`
+					Managing  []EventResponse `json:"managing"`
+					Submitted []EventResponse `json:"submitted"`
 				}
 				if err := parseJSON(resp, &result); err != nil {
 					t.Fatalf("failed to parse response: %v", err)
 				}
-				if tc.expectManagingAtLeast > 0 && len(result.Managing) < tc.expectManagingAtLeast {
-					t.Errorf("expected at least %d managing events, got %d", tc.expectManagingAtLeast, len(result.Managing))
+				if len(result.Managing) != tc.expectManaging {
+					t.Errorf("expected %d managing events, got %d", tc.expectManaging, len(result.Managing))
 				}
-				if tc.expectSubmittedAtLeast > 0 && len(result.Submitted) < tc.expectSubmittedAtLeast {
-					t.Errorf("expected at least %d submitted events, got %d", tc.expectSubmittedAtLeast, len(result.Submitted))
+				if len(result.Submitted) != tc.expectSubmitted {
+					t.Errorf("expected %d submitted events, got %d", tc.expectSubmitted, len(result.Submitted))
+				} else if tc.expectSubmittedTo != 0 && result.Submitted[0].ID != tc.expectSubmittedTo {
+					t.Errorf("expected a submission to event %d, got %d", tc.expectSubmittedTo, result.Submitted[0].ID)
 				}
 			}
 		})
 	}
 }
 
+func TestGetMyEvents_DatabaseError(t *testing.T) {
+	// A handler whose database connection is closed must fail loudly rather
+	// than render an empty dashboard
+	db, err := database.InitDB(os.Getenv("DATABASE_URL"))
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB.Close()
+
+	cfg := *testConfig
+	cfg.DB = db
+	for _, user := range []*models.User{userAdmin, userSpeaker} {
+		req := httptest.NewRequest(http.MethodGet, "/api/v0/me/events", nil)
+		req = req.WithContext(context.WithValue(req.Context(), api.UserContextKey, user))
+		rec := httptest.NewRecorder()
+		api.GetMyEventsHandler(&cfg)(rec, req)
+		if rec.Code != http.StatusInternalServerError {
+			t.Errorf("expected 500 for %s, got %d: %s", user.Email, rec.Code, rec.Body.String())
+		}
+	}
+}
+
 // Organizer Management Tests
 
 func TestAddOrganizer_Success(t *testing.T) {
+	t.Parallel()
 	// Create a test event
 	now := time.Now()
 	event := createTestEvent(adminToken, EventInput{
@@ -543,6 +627,7 @@ func TestAddOrganizer_Success(t *testing.T) {
 }
 
 func TestAddOrganizer_AlreadyOrganizer(t *testing.T) {
+	t.Parallel()
 	now := time.Now()
 	event := createTestEvent(adminToken, EventInput{
 		Name:      "Already Organizer Test",
@@ -568,7 +653,63 @@ func TestAddOrganizer_AlreadyOrganizer(t *testing.T) {
 	assertStatus(t, resp, http.StatusConflict)
 }
 
+func TestAddOrganizer_EmailIgnoresCase(t *testing.T) {
+	t.Parallel()
+	now := time.Now()
+	event := createTestEvent(adminToken, EventInput{
+		Name:      "Organizer Email Case Test",
+		Slug:      "org-email-case-" + fmt.Sprintf("%d", now.UnixNano()),
+		StartDate: now.AddDate(0, 1, 0).Format(time.RFC3339),
+		EndDate:   now.AddDate(0, 1, 1).Format(time.RFC3339),
+	})
+
+	resp := doPost(
+		fmt.Sprintf("/api/v0/events/%d/organizers", event.ID),
+		OrganizerInput{Email: "  Speaker@Test.COM "},
+		adminToken,
+	)
+	assertStatus(t, resp, http.StatusCreated)
+	resp.Body.Close()
+}
+
+func TestNormalizeUserEmails(t *testing.T) {
+	suffix := fmt.Sprintf("%d", time.Now().UnixNano())
+	mixed := &models.User{Email: " Jane.Doe-" + suffix + "@Example.com", IsActive: true}
+	if err := testConfig.DB.Create(mixed).Error; err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+	// A user whose normalized email is already taken is left alone
+	taken := &models.User{Email: "dup-" + suffix + "@example.com", IsActive: true}
+	clash := &models.User{Email: "DUP-" + suffix + "@example.com", IsActive: true}
+	if err := testConfig.DB.Create(taken).Error; err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+	if err := testConfig.DB.Create(clash).Error; err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+
+	if err := models.NormalizeUserEmails(testConfig.DB); err != nil {
+		t.Fatalf("NormalizeUserEmails: %v", err)
+	}
+
+	var got models.User
+	testConfig.DB.First(&got, mixed.ID)
+	if want := "jane.doe-" + suffix + "@example.com"; got.Email != want {
+		t.Errorf("expected %q, got %q", want, got.Email)
+	}
+	testConfig.DB.First(&got, clash.ID)
+	if got.Email != clash.Email {
+		t.Errorf("expected the clashing email to stay %q, got %q", clash.Email, got.Email)
+	}
+
+	found, err := models.GetUserByEmail(testConfig.DB, "JANE.DOE-"+suffix+"@EXAMPLE.COM")
+	if err != nil || found.ID != mixed.ID {
+		t.Errorf("expected lookup to ignore case, got %v %v", found, err)
+	}
+}
+
 func TestAddOrganizer_NotFound(t *testing.T) {
+	t.Parallel()
 	now := time.Now()
 	event := createTestEvent(adminToken, EventInput{
 		Name:      "Organizer Not Found Test",
@@ -587,6 +728,7 @@ func TestAddOrganizer_NotFound(t *testing.T) {
 }
 
 func TestAddOrganizer_Forbidden(t *testing.T) {
+	t.Parallel()
 	now := time.Now()
 	event := createTestEvent(adminToken, EventInput{
 		Name:      "Forbidden Add Test",
@@ -605,6 +747,7 @@ func TestAddOrganizer_Forbidden(t *testing.T) {
 }
 
 func TestRemoveOrganizer_Success(t *testing.T) {
+	t.Parallel()
 	now := time.Now()
 	event := createTestEvent(adminToken, EventInput{
 		Name:      "Remove Organizer Test",
@@ -634,6 +777,7 @@ func TestRemoveOrganizer_Success(t *testing.T) {
 }
 
 func TestRemoveOrganizer_CannotRemoveCreator(t *testing.T) {
+	t.Parallel()
 	now := time.Now()
 	event := createTestEvent(adminToken, EventInput{
 		Name:      "Cannot Remove Creator Test",
@@ -651,6 +795,7 @@ func TestRemoveOrganizer_CannotRemoveCreator(t *testing.T) {
 }
 
 func TestRemoveOrganizer_OnlyCreatorCanRemove(t *testing.T) {
+	t.Parallel()
 	now := time.Now()
 	event := createTestEvent(adminToken, EventInput{
 		Name:      "Only Creator Can Remove Test",
@@ -684,6 +829,7 @@ func TestRemoveOrganizer_OnlyCreatorCanRemove(t *testing.T) {
 }
 
 func TestGetEventOrganizers(t *testing.T) {
+	t.Parallel()
 	now := time.Now()
 	event := createTestEvent(adminToken, EventInput{
 		Name:      "Get Organizers Test",
@@ -757,6 +903,83 @@ func splitTags(tags string) []string {
 	return result
 }
 
+func TestListEvents_IncludeStats(t *testing.T) {
+	t.Parallel()
+	now := time.Now()
+	name := fmt.Sprintf("Stats Conf %d", now.UnixNano())
+	event := createTestEvent(adminToken, EventInput{
+		Name:       name,
+		Slug:       fmt.Sprintf("stats-conf-%d", now.UnixNano()),
+		StartDate:  now.AddDate(0, 2, 0).Format(time.RFC3339),
+		EndDate:    now.AddDate(0, 2, 1).Format(time.RFC3339),
+		CFPOpenAt:  now.AddDate(0, 0, -1).Format(time.RFC3339),
+		CFPCloseAt: now.AddDate(0, 0, 10).Add(time.Hour).Format(time.RFC3339),
+	})
+	updateCFPStatus(adminToken, event.ID, "open")
+	createTestProposal(speakerToken, event.ID, ProposalInput{
+		Title:    "Counted Proposal",
+		Abstract: "Test abstract",
+		Format:   "talk",
+		Duration: 30,
+		Speakers: []Speaker{
+			{Name: "Speaker User", Email: "speaker@test.com", Company: "Acme Inc", JobTitle: "Engineer", LinkedIn: "https://linkedin.com/in/speaker", Primary: true},
+		},
+	})
+
+	list := func(query string) map[string]interface{} {
+		t.Helper()
+		resp := doGet("/api/v0/events?q=" + url.QueryEscape(name) + query)
+		assertStatus(t, resp, http.StatusOK)
+		var result struct {
+			Data []map[string]interface{} `json:"data"`
+		}
+		if err := parseJSON(resp, &result); err != nil {
+			t.Fatalf("failed to parse response: %v", err)
+		}
+		if len(result.Data) != 1 {
+			t.Fatalf("expected 1 event, got %d", len(result.Data))
+		}
+		return result.Data[0]
+	}
+
+	// Absent by default
+	got := list("")
+	if _, ok := got["days_until_cfp_close"]; ok {
+		t.Errorf("expected no stats by default, got %v", got["days_until_cfp_close"])
+	}
+
+	// The submission count is only shown when the organizer enables it
+	got = list("&include=stats")
+	if got["days_until_cfp_close"] != float64(10) {
+		t.Errorf("expected days_until_cfp_close 10, got %v", got["days_until_cfp_close"])
+	}
+	if _, ok := got["submission_count"]; ok {
+		t.Errorf("expected no submission_count, got %v", got["submission_count"])
+	}
+
+	resp := doPut(fmt.Sprintf("/api/v0/events/%d", event.ID), map[string]interface{}{"show_submission_count": true}, adminToken)
+	assertStatus(t, resp, http.StatusOK)
+	resp.Body.Close()
+
+	got = list("&include=stats")
+	if got["submission_count"] != float64(1) {
+		t.Errorf("expected submission_count 1, got %v", got["submission_count"])
+	}
+
+	// Stats are added to a field selection too
+	got = list("&include=stats&fields=name")
+	if got["name"] != name || got["submission_count"] != float64(1) || got["days_until_cfp_close"] != float64(10) {
+		t.Errorf("expected name and stats, got %v", got)
+	}
+	if _, ok := got["slug"]; ok {
+		t.Errorf("expected only the selected fields and stats, got %v", got)
+	}
+
+	resp = doGet("/api/v0/events?include=everything")
+	assertStatus(t, resp, http.StatusBadRequest)
+	resp.Body.Close()
+}
+
 func TestCreateEvent_ConcurrentDuplicateSlug(t *testing.T) {
 	now := time.Now()
 	slug := "concurrent-slug-" + fmt.Sprintf("%d", now.UnixNano())
@@ -799,7 +1022,36 @@ func TestCreateEvent_ConcurrentDuplicateSlug(t *testing.T) {
 	}
 }
 
+func TestEnsureEventSlugIndex(t *testing.T) {
+	// Replace the unique index with a plain one, as on databases that were
+	// migrated without it
+	if err := testConfig.DB.Exec("DROP INDEX IF EXISTS " + models.EventSlugIndex).Error; err != nil {
+		t.Fatalf("failed to drop index: %v", err)
+	}
+	if err := testConfig.DB.Exec("CREATE INDEX " + models.EventSlugIndex + " ON events (slug)").Error; err != nil {
+		t.Fatalf("failed to create plain index: %v", err)
+	}
+
+	if err := models.EnsureEventSlugIndex(testConfig.DB); err != nil {
+		t.Fatalf("EnsureEventSlugIndex: %v", err)
+	}
+	// Running it again is a no-op
+	if err := models.EnsureEventSlugIndex(testConfig.DB); err != nil {
+		t.Fatalf("EnsureEventSlugIndex again: %v", err)
+	}
+
+	resp := doPost("/api/v0/events", EventInput{
+		Name:      "Duplicate After Migration",
+		Slug:      eventGopherCon.Slug,
+		StartDate: time.Now().AddDate(0, 1, 0).Format(time.RFC3339),
+		EndDate:   time.Now().AddDate(0, 1, 1).Format(time.RFC3339),
+	}, adminToken)
+	assertStatus(t, resp, http.StatusConflict)
+	resp.Body.Close()
+}
+
 func TestCreateEvent_DateValidation(t *testing.T) {
+	t.Parallel()
 	now := time.Now()
 
 	tests := []struct {
@@ -863,6 +1115,7 @@ func TestCreateEvent_DateValidation(t *testing.T) {
 }
 
 func TestUpdateEvent_DateValidation(t *testing.T) {
+	t.Parallel()
 	now := time.Now()
 	event := createTestEvent(adminToken, EventInput{
 		Name:      "Date Update Test",
@@ -912,6 +1165,7 @@ func TestUpdateEvent_DateValidation(t *testing.T) {
 
 // TestAddOrganizer_MaxLimit verifies that the MAX_ORGANIZERS_PER_EVENT limit is enforced.
 func TestAddOrganizer_MaxLimit(t *testing.T) {
+	t.Parallel()
 	now := time.Now()
 	event := createTestEvent(adminToken, EventInput{
 		Name:      "Max Organizers Test",
@@ -990,8 +1244,90 @@ func TestDraftEventHiddenFromPublic(t *testing.T) {
 	assertStatus(t, resp, http.StatusNotFound)
 }
 
+// TestDraftEventPreview verifies that organizers can preview their draft
+// event through the public endpoints while everyone else gets a 404.
+func TestDraftEventPreview(t *testing.T) {
+	t.Parallel()
+	now := time.Now()
+	event := createTestEvent(adminToken, EventInput{
+		Name:      "Draft Preview Event",
+		Slug:      fmt.Sprintf("draft-preview-%d", now.UnixNano()),
+		StartDate: now.AddDate(0, 2, 0).Format(time.RFC3339),
+		EndDate:   now.AddDate(0, 2, 1).Format(time.RFC3339),
+		Tags:      "draftpreviewtest",
+	})
+	resp := doPost(fmt.Sprintf("/api/v0/events/%d/organizers", event.ID), OrganizerInput{Email: "speaker@test.com"}, adminToken)
+	assertStatus(t, resp, http.StatusCreated)
+	resp.Body.Close()
+
+	paths := []string{
+		"/api/v0/e/" + event.Slug,
+		fmt.Sprintf("/api/v0/events/%d", event.ID),
+	}
+	tests := []struct {
+		name         string
+		token        string
+		expectedCode int
+	}{
+		{"creator", adminToken, http.StatusOK},
+		{"co-organizer", speakerToken, http.StatusOK},
+		{"other user", otherToken, http.StatusNotFound},
+		{"anonymous", "", http.StatusNotFound},
+		{"invalid token", "not-a-jwt", http.StatusNotFound},
+	}
+
+	for _, tc := range tests {
+		for _, path := range paths {
+			t.Run(tc.name+" "+path, func(t *testing.T) {
+				resp := doAuthGet(path, tc.token)
+				assertStatus(t, resp, tc.expectedCode)
+				if tc.expectedCode != http.StatusOK {
+					resp.Body.Close()
+					return
+				}
+				if cc := resp.Header.Get("Cache-Control"); cc != "private, no-store" {
+					t.Errorf("expected Cache-Control private, no-store, got %q", cc)
+				}
+				var got EventResponse
+				if err := parseJSON(resp, &got); err != nil {
+					t.Fatalf("failed to parse response: %v", err)
+				}
+				if !got.Draft || got.ID != event.ID {
+					t.Errorf("expected draft event %d with draft: true, got id %d draft %v", event.ID, got.ID, got.Draft)
+				}
+			})
+		}
+	}
+
+	t.Run("hidden from listings", func(t *testing.T) {
+		resp := doAuthGet("/api/v0/events?tag=draftpreviewtest", adminToken)
+		assertStatus(t, resp, http.StatusOK)
+		var result EventListResponse
+		if err := parseJSON(resp, &result); err != nil {
+			t.Fatalf("failed to parse response: %v", err)
+		}
+		if len(result.Data) != 0 {
+			t.Errorf("expected draft event hidden from listing, got %d events", len(result.Data))
+		}
+	})
+
+	t.Run("no draft marker once open", func(t *testing.T) {
+		updateCFPStatus(adminToken, event.ID, "open")
+		resp := doGet("/api/v0/e/" + event.Slug)
+		assertStatus(t, resp, http.StatusOK)
+		var got EventResponse
+		if err := parseJSON(resp, &got); err != nil {
+			t.Fatalf("failed to parse response: %v", err)
+		}
+		if got.Draft {
+			t.Error("expected no draft marker on an open event")
+		}
+	})
+}
+
 // TestListEvents_PaginationEdgeCases tests pagination boundary conditions.
 func TestListEvents_PaginationEdgeCases(t *testing.T) {
+	t.Parallel()
 	t.Run("page=0 defaults to page 1", func(t *testing.T) {
 		resp := doGet("/api/v0/events?page=0")
 		assertStatus(t, resp, http.StatusOK)
@@ -1057,3 +1393,847 @@ func TestListEvents_PaginationEdgeCases(t *testing.T) {
 		}
 	})
 }
+
+func TestListEvents_FieldSelection(t *testing.T) {
+	t.Parallel()
+	t.Run("returns only requested fields", func(t *testing.T) {
+		resp := doGet("/api/v0/events?fields=id,name,slug,cfp_close_at")
+		assertStatus(t, resp, http.StatusOK)
+
+		var result struct {
+			Data       []map[string]interface{} `json:"data"`
+			Pagination PaginationInfo           `json:"pagination"`
+		}
+		if err := parseJSON(resp, &result); err != nil {
+			t.Fatalf("failed to parse response: %v", err)
+		}
+		if len(result.Data) == 0 {
+			t.Fatal("expected events")
+		}
+		if result.Pagination.Total == 0 {
+			t.Error("expected pagination to be unaffected by field selection")
+		}
+		for _, e := range result.Data {
+			if len(e) != 4 {
+				t.Errorf("expected 4 fields, got %v", e)
+			}
+			for _, key := range []string{"ID", "name", "slug", "cfp_close_at"} {
+				if _, ok := e[key]; !ok {
+					t.Errorf("expected field %q in %v", key, e)
+				}
+			}
+		}
+	})
+
+	t.Run("unknown field is rejected", func(t *testing.T) {
+		resp := doGet("/api/v0/events?fields=name,stripe_payment_id")
+		assertStatus(t, resp, http.StatusBadRequest)
+		assertErrorCode(t, resp, "validation")
+	})
+}
+
+func TestGetEvent_ExpandOrganizersPublic(t *testing.T) {
+	paths := []string{
+		"/api/v0/e/" + eventGopherCon.Slug + "?expand=organizers_public",
+		fmt.Sprintf("/api/v0/events/%d?expand=organizers_public", eventGopherCon.ID),
+	}
+
+	for _, path := range paths {
+		t.Run(path, func(t *testing.T) {
+			resp := doGet(path)
+			assertStatus(t, resp, http.StatusOK)
+
+			body := readBody(resp)
+			if strings.Contains(body, userAdmin.Email) {
+				t.Error("expected organizer emails to stay private")
+			}
+
+			var result struct {
+				Slug             string `json:"slug"`
+				OrganizersPublic []struct {
+					Name string `json:"name"`
+				} `json:"organizers_public"`
+			}
+			if err := json.Unmarshal([]byte(body), &result); err != nil {
+				t.Fatalf("failed to parse response: %v", err)
+			}
+			if result.Slug != eventGopherCon.Slug {
+				t.Errorf("expected slug %s, got %s", eventGopherCon.Slug, result.Slug)
+			}
+			found := false
+			for _, o := range result.OrganizersPublic {
+				if o.Name == userAdmin.Name {
+					found = true
+				}
+			}
+			if !found {
+				t.Errorf("expected %q among public organizers, got %+v", userAdmin.Name, result.OrganizersPublic)
+			}
+		})
+	}
+
+	t.Run("not expanded by default", func(t *testing.T) {
+		resp := doGet("/api/v0/e/" + eventGopherCon.Slug)
+		assertStatus(t, resp, http.StatusOK)
+		if strings.Contains(readBody(resp), "organizers_public") {
+			t.Error("expected organizers_public only when requested")
+		}
+	})
+
+	t.Run("unknown expansion is rejected", func(t *testing.T) {
+		resp := doGet("/api/v0/e/" + eventGopherCon.Slug + "?expand=organizers")
+		assertStatus(t, resp, http.StatusBadRequest)
+		resp.Body.Close()
+	})
+}
+
+func TestListEvents_StableOrderingWithTies(t *testing.T) {
+	t.Parallel()
+	// Events sharing every sort key must still paginate without overlaps or gaps
+	now := time.Now().UTC().Truncate(time.Second)
+	start := now.AddDate(0, 3, 0)
+	ids := make(map[uint]bool)
+	for i := 0; i < 5; i++ {
+		event := createTestEvent(adminToken, EventInput{
+			Name:       "Tie Conference",
+			Slug:       fmt.Sprintf("tie-conf-%d", i),
+			Location:   "Lisbon",
+			Country:    "PT",
+			StartDate:  start.Format(time.RFC3339),
+			EndDate:    start.AddDate(0, 0, 1).Format(time.RFC3339),
+			Tags:       "tiebreak",
+			CFPOpenAt:  now.AddDate(0, 0, -1).Format(time.RFC3339),
+			CFPCloseAt: now.AddDate(0, 1, 0).Format(time.RFC3339),
+		})
+		updateCFPStatus(adminToken, event.ID, "open")
+		ids[event.ID] = true
+	}
+
+	queries := []string{
+		"tag=tiebreak",
+		"tag=tiebreak&status=open",
+		"tag=tiebreak&sort=start_date",
+		"tag=tiebreak&sort=name&order=desc",
+		"tag=tiebreak&sort=cfp_close_at",
+	}
+
+	for _, q := range queries {
+		t.Run(q, func(t *testing.T) {
+			seen := make(map[uint]bool)
+			for page := 1; page <= 3; page++ {
+				resp := doGet(fmt.Sprintf("/api/v0/events?%s&per_page=2&page=%d", q, page))
+				assertStatus(t, resp, http.StatusOK)
+
+				var result EventListResponse
+				if err := parseJSON(resp, &result); err != nil {
+					t.Fatalf("failed to parse response: %v", err)
+				}
+				for _, e := range result.Data {
+					if seen[e.ID] {
+						t.Errorf("event %d appears on more than one page", e.ID)
+					}
+					seen[e.ID] = true
+				}
+			}
+			for id := range ids {
+				if !seen[id] {
+					t.Errorf("event %d missing from paginated results", id)
+				}
+			}
+		})
+	}
+}
+
+func TestListEvents_DefaultOrderOpenFirst(t *testing.T) {
+	t.Parallel()
+	// Open CFPs come first and the rest after, each latest start first, with
+	// a page boundary falling between the two
+	now := time.Now().UTC().Truncate(time.Second)
+	tag := fmt.Sprintf("openfirst%d", now.UnixNano())
+	var open, closed []uint
+	for i := 0; i < 5; i++ {
+		start := now.AddDate(0, 2, i)
+		event := createTestEvent(adminToken, EventInput{
+			Name:       "Open First Conference",
+			Slug:       fmt.Sprintf("%s-%d", tag, i),
+			StartDate:  start.Format(time.RFC3339),
+			EndDate:    start.AddDate(0, 0, 1).Format(time.RFC3339),
+			Tags:       tag,
+			CFPOpenAt:  now.AddDate(0, 0, -1).Format(time.RFC3339),
+			CFPCloseAt: now.AddDate(0, 1, 0).Format(time.RFC3339),
+		})
+		// Alternate so neither group is contiguous by start date
+		if i%2 == 0 {
+			updateCFPStatus(adminToken, event.ID, "open")
+			open = append([]uint{event.ID}, open...)
+		} else {
+			updateCFPStatus(adminToken, event.ID, "closed")
+			closed = append([]uint{event.ID}, closed...)
+		}
+	}
+	want := append(open, closed...)
+
+	var got []uint
+	for page := 1; page <= 3; page++ {
+		resp := doGet(fmt.Sprintf("/api/v0/events?tag=%s&per_page=2&page=%d", tag, page))
+		assertStatus(t, resp, http.StatusOK)
+
+		var result EventListResponse
+		if err := parseJSON(resp, &result); err != nil {
+			t.Fatalf("failed to parse response: %v", err)
+		}
+		if result.Pagination.Total != 5 {
+			t.Errorf("expected total 5, got %d", result.Pagination.Total)
+		}
+		for _, e := range result.Data {
+			got = append(got, e.ID)
+		}
+	}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("expected order %v, got %v", want, got)
+	}
+}
+
+func TestListEvents_OpenCFPIndexUsable(t *testing.T) {
+	// The test table is too small for the planner to prefer an index, so
+	// scans are disabled: this checks that the first page of open CFPs can
+	// be read from the partial index in order, without sorting
+	err := testConfig.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SET LOCAL enable_seqscan = off").Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("SET LOCAL enable_bitmapscan = off").Error; err != nil {
+			return err
+		}
+
+		var plan []string
+		if err := tx.Raw(`EXPLAIN SELECT id FROM events
+			WHERE cfp_status != ? AND moderation_status = ? AND `+models.OpenCFPPredicate+`
+			AND (cfp_open_at IS NULL OR cfp_open_at <= ?)
+			AND deleted_at IS NULL
+			ORDER BY start_date DESC, id DESC LIMIT 20`,
+			models.CFPStatusDraft, models.ModerationApproved, time.Now()).Scan(&plan).Error; err != nil {
+			return err
+		}
+		explained := strings.Join(plan, "\n")
+		if !strings.Contains(explained, "idx_events_open_cfp") {
+			t.Errorf("expected the open CFP index in the plan:\n%s", explained)
+		}
+		if strings.Contains(explained, "Sort") {
+			t.Errorf("expected no sort in the plan:\n%s", explained)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("failed to explain the query: %v", err)
+	}
+}
+
+func TestCreateEvent_CountryResolution(t *testing.T) {
+	t.Parallel()
+	now := time.Now()
+	start := now.AddDate(0, 2, 0)
+
+	tests := []struct {
+		name         string
+		country      string
+		expectedCode int
+		countryCode  string
+	}{
+		{"alpha-2 code", "NL", http.StatusCreated, "NL"},
+		{"lowercase code", "fr", http.StatusCreated, "FR"},
+		{"country name", "Germany", http.StatusCreated, "DE"},
+		{"common name", "USA", http.StatusCreated, "US"},
+		{"unknown country", "Atlantis", http.StatusBadRequest, ""},
+	}
+
+	for i, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := doPost("/api/v0/events", EventInput{
+				Name:      "Country Event",
+				Slug:      fmt.Sprintf("country-event-%d-%d", i, now.UnixNano()),
+				Location:  "Somewhere",
+				Country:   tc.country,
+				StartDate: start.Format(time.RFC3339),
+				EndDate:   start.AddDate(0, 0, 1).Format(time.RFC3339),
+			}, adminToken)
+			if tc.expectedCode != http.StatusCreated {
+				assertCountryRejected(t, resp)
+				return
+			}
+			assertStatus(t, resp, http.StatusCreated)
+
+			var event EventResponse
+			if err := parseJSON(resp, &event); err != nil {
+				t.Fatalf("failed to parse response: %v", err)
+			}
+			if event.Country != tc.country {
+				t.Errorf("expected country kept as %q, got %q", tc.country, event.Country)
+			}
+			if event.CountryCode != tc.countryCode {
+				t.Errorf("expected country_code %q, got %q", tc.countryCode, event.CountryCode)
+			}
+		})
+	}
+}
+
+func TestUpdateEvent_CountryResolution(t *testing.T) {
+	t.Parallel()
+	now := time.Now()
+	start := now.AddDate(0, 2, 0)
+	event := createTestEvent(adminToken, EventInput{
+		Name:      "Country Update Event",
+		Slug:      fmt.Sprintf("country-update-%d", now.UnixNano()),
+		Location:  "Berlin",
+		Country:   "DE",
+		StartDate: start.Format(time.RFC3339),
+		EndDate:   start.AddDate(0, 0, 1).Format(time.RFC3339),
+	})
+	path := fmt.Sprintf("/api/v0/events/%d", event.ID)
+
+	resp := doPut(path, map[string]interface{}{"country": "Austria"}, adminToken)
+	assertStatus(t, resp, http.StatusOK)
+	var updated EventResponse
+	if err := parseJSON(resp, &updated); err != nil {
+		t.Fatalf("failed to parse response: %v", err)
+	}
+	if updated.CountryCode != "AT" {
+		t.Errorf("expected country_code AT, got %q", updated.CountryCode)
+	}
+
+	resp = doPut(path, map[string]interface{}{"country": "Narnia"}, adminToken)
+	assertCountryRejected(t, resp)
+}
+
+// assertCountryRejected checks for a validation error on the country field
+func assertCountryRejected(t *testing.T, resp *http.Response) {
+	t.Helper()
+	assertStatus(t, resp, http.StatusBadRequest)
+	var result struct {
+		Fields map[string]string `json:"fields"`
+	}
+	if err := parseJSON(resp, &result); err != nil {
+		t.Fatalf("failed to parse JSON: %v", err)
+	}
+	if _, ok := result.Fields["country"]; !ok {
+		t.Errorf("expected error for field country, got %v", result.Fields)
+	}
+}
+
+func TestListEvents_CountryFilterMatchesCodeOrName(t *testing.T) {
+	// GopherCon was created with the code "US"; the name finds it too
+	for _, country := range []string{"US", "us", "United States", "USA"} {
+		t.Run(country, func(t *testing.T) {
+			resp := doGet("/api/v0/events?country=" + url.QueryEscape(country))
+			assertStatus(t, resp, http.StatusOK)
+
+			var result EventListResponse
+			if err := parseJSON(resp, &result); err != nil {
+				t.Fatalf("failed to parse response: %v", err)
+			}
+			found := false
+			for _, e := range result.Data {
+				if e.ID == eventGopherCon.ID {
+					found = true
+				}
+				if e.CountryCode != "US" {
+					t.Errorf("expected only US events, got %q (%s)", e.CountryCode, e.Country)
+				}
+			}
+			if !found {
+				t.Errorf("expected GopherCon in results for country=%q", country)
+			}
+		})
+	}
+}
+
+// createModeEvent creates an open event tagged modetest with the given attendance
+func createModeEvent(t *testing.T, input EventInput) *EventResponse {
+	t.Helper()
+	now := time.Now().UTC()
+	start := now.AddDate(0, 2, 0)
+	input.Name = "Mode " + input.Slug
+	input.Slug = fmt.Sprintf("%s-%d", input.Slug, now.UnixNano())
+	input.Location = "Berlin"
+	input.Country = "DE"
+	input.StartDate = start.Format(time.RFC3339)
+	input.EndDate = start.AddDate(0, 0, 1).Format(time.RFC3339)
+	input.Tags = "modetest"
+	input.CFPOpenAt = now.AddDate(0, 0, -1).Format(time.RFC3339)
+	input.CFPCloseAt = now.AddDate(0, 1, 0).Format(time.RFC3339)
+	event := createTestEvent(adminToken, input)
+	updateCFPStatus(adminToken, event.ID, "open")
+	return event
+}
+
+func TestCreateEvent_AttendanceMode(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		input    EventInput
+		mode     string
+		isOnline bool
+	}{
+		{"default", EventInput{Slug: "mode-default"}, "in_person", false},
+		{"legacy is_online", EventInput{Slug: "mode-legacy", IsOnline: true}, "online", true},
+		{"hybrid", EventInput{Slug: "mode-hybrid", AttendanceMode: "hybrid"}, "hybrid", true},
+		{"mode wins over is_online", EventInput{Slug: "mode-wins", AttendanceMode: "in_person", IsOnline: true}, "in_person", false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			event := createModeEvent(t, tc.input)
+			if event.AttendanceMode != tc.mode {
+				t.Errorf("expected attendance_mode %q, got %q", tc.mode, event.AttendanceMode)
+			}
+			if event.IsOnline != tc.isOnline {
+				t.Errorf("expected is_online %v, got %v", tc.isOnline, event.IsOnline)
+			}
+		})
+	}
+
+	resp := doPost("/api/v0/events", EventInput{
+		Name:           "Bad Mode",
+		Slug:           fmt.Sprintf("mode-bad-%d", time.Now().UnixNano()),
+		StartDate:      time.Now().AddDate(0, 2, 0).Format(time.RFC3339),
+		EndDate:        time.Now().AddDate(0, 2, 1).Format(time.RFC3339),
+		AttendanceMode: "remote",
+	}, adminToken)
+	assertStatus(t, resp, http.StatusBadRequest)
+	var result struct {
+		Fields map[string]string `json:"fields"`
+	}
+	if err := parseJSON(resp, &result); err != nil {
+		t.Fatalf("failed to parse JSON: %v", err)
+	}
+	if _, ok := result.Fields["attendance_mode"]; !ok {
+		t.Errorf("expected error for field attendance_mode, got %v", result.Fields)
+	}
+}
+
+func TestListEvents_TypeFilterIncludesHybrid(t *testing.T) {
+	t.Parallel()
+	inPerson := createModeEvent(t, EventInput{Slug: "type-in-person", AttendanceMode: "in_person"})
+	online := createModeEvent(t, EventInput{Slug: "type-online", AttendanceMode: "online"})
+	hybrid := createModeEvent(t, EventInput{Slug: "type-hybrid", AttendanceMode: "hybrid"})
+
+	tests := []struct {
+		filter   string
+		included []uint
+		excluded []uint
+	}{
+		{"online", []uint{online.ID, hybrid.ID}, []uint{inPerson.ID}},
+		{"in-person", []uint{inPerson.ID, hybrid.ID}, []uint{online.ID}},
+		{"in_person", []uint{inPerson.ID, hybrid.ID}, []uint{online.ID}},
+		{"hybrid", []uint{hybrid.ID}, []uint{inPerson.ID, online.ID}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.filter, func(t *testing.T) {
+			resp := doGet("/api/v0/events?tag=modetest&per_page=100&type=" + tc.filter)
+			assertStatus(t, resp, http.StatusOK)
+			var result EventListResponse
+			if err := parseJSON(resp, &result); err != nil {
+				t.Fatalf("failed to parse response: %v", err)
+			}
+			ids := make(map[uint]bool)
+			for _, e := range result.Data {
+				ids[e.ID] = true
+			}
+			for _, id := range tc.included {
+				if !ids[id] {
+					t.Errorf("expected event %d in type=%s results", id, tc.filter)
+				}
+			}
+			for _, id := range tc.excluded {
+				if ids[id] {
+					t.Errorf("expected event %d not in type=%s results", id, tc.filter)
+				}
+			}
+		})
+	}
+}
+
+func TestUpdateEvent_AttendanceMode(t *testing.T) {
+	t.Parallel()
+	event := createModeEvent(t, EventInput{Slug: "mode-update"})
+	path := fmt.Sprintf("/api/v0/events/%d", event.ID)
+
+	update := func(t *testing.T, body map[string]interface{}) EventResponse {
+		t.Helper()
+		resp := doPut(path, body, adminToken)
+		assertStatus(t, resp, http.StatusOK)
+		var updated EventResponse
+		if err := parseJSON(resp, &updated); err != nil {
+			t.Fatalf("failed to parse response: %v", err)
+		}
+		return updated
+	}
+
+	updated := update(t, map[string]interface{}{"attendance_mode": "hybrid"})
+	if updated.AttendanceMode != "hybrid" || !updated.IsOnline {
+		t.Errorf("expected hybrid with is_online, got %q/%v", updated.AttendanceMode, updated.IsOnline)
+	}
+
+	// Older clients re-send is_online unchanged; that must not downgrade hybrid
+	updated = update(t, map[string]interface{}{"is_online": true, "name": "Mode Renamed"})
+	if updated.AttendanceMode != "hybrid" {
+		t.Errorf("expected hybrid kept, got %q", updated.AttendanceMode)
+	}
+
+	updated = update(t, map[string]interface{}{"is_online": false})
+	if updated.AttendanceMode != "in_person" || updated.IsOnline {
+		t.Errorf("expected in_person after is_online=false, got %q/%v", updated.AttendanceMode, updated.IsOnline)
+	}
+
+	resp := doPut(path, map[string]interface{}{"attendance_mode": "remote"}, adminToken)
+	assertStatus(t, resp, http.StatusBadRequest)
+}
+
+func TestUpdateEvent_Sections(t *testing.T) {
+	t.Parallel()
+	event := createModeEvent(t, EventInput{Slug: "sections"})
+	path := fmt.Sprintf("/api/v0/events/%d", event.ID)
+
+	resp := doPut(path, map[string]interface{}{
+		"sections": []map[string]string{{"title": "", "body": "Missing a title"}},
+	}, adminToken)
+	assertStatus(t, resp, http.StatusBadRequest)
+	resp.Body.Close()
+
+	resp = doPut(path, map[string]interface{}{
+		"sections": []map[string]string{
+			{"title": "Travel", "body": "The venue is a short walk from the station."},
+			{"title": "Recording policy", "body": "All talks are recorded."},
+		},
+	}, adminToken)
+	assertStatus(t, resp, http.StatusOK)
+	resp.Body.Close()
+
+	resp = doGet("/api/v0/e/" + event.Slug)
+	assertStatus(t, resp, http.StatusOK)
+	var got struct {
+		Sections []struct {
+			Title string `json:"title"`
+			Body  string `json:"body"`
+		} `json:"sections"`
+	}
+	if err := parseJSON(resp, &got); err != nil {
+		t.Fatalf("failed to parse response: %v", err)
+	}
+	if len(got.Sections) != 2 || got.Sections[0].Title != "Travel" || got.Sections[1].Title != "Recording policy" {
+		t.Errorf("expected both sections in order, got %+v", got.Sections)
+	}
+
+	resp = doPut(path, map[string]interface{}{"sections": nil}, adminToken)
+	assertStatus(t, resp, http.StatusOK)
+	if body := readBody(resp); !strings.Contains(body, `"sections":null`) {
+		t.Errorf("expected sections to be cleared, got %s", body)
+	}
+}
+
+func TestUpdateEvent_StatusEmails(t *testing.T) {
+	t.Parallel()
+	event := createModeEvent(t, EventInput{Slug: "status-emails"})
+	path := fmt.Sprintf("/api/v0/events/%d", event.ID)
+
+	resp := doPut(path, map[string]interface{}{
+		"status_emails": map[string]interface{}{
+			"accepted": map[string]string{"subject": "You're in", "body": "Your room is {{room}}."},
+		},
+	}, adminToken)
+	assertStatus(t, resp, http.StatusBadRequest)
+	assertErrorCode(t, resp, "validation")
+
+	resp = doPut(path, map[string]interface{}{
+		"status_emails": map[string]interface{}{
+			"accepted": map[string]string{"subject": "{{talk_title}} is in!", "body": "Hi {{speaker_name}}, see you at {{event_name}}."},
+		},
+	}, adminToken)
+	assertStatus(t, resp, http.StatusOK)
+	if body := readBody(resp); !strings.Contains(body, `"status_emails":{"accepted"`) {
+		t.Errorf("expected the status email copy in the response, got %s", body)
+	}
+
+	// The copy is organizer-only
+	resp = doGet("/api/v0/e/" + event.Slug)
+	assertStatus(t, resp, http.StatusOK)
+	if body := readBody(resp); strings.Contains(body, "status_emails") {
+		t.Errorf("expected no status email copy on the public event, got %s", body)
+	}
+
+	resp = doPut(path, map[string]interface{}{"status_emails": nil}, adminToken)
+	assertStatus(t, resp, http.StatusOK)
+	if body := readBody(resp); strings.Contains(body, "status_emails") {
+		t.Errorf("expected the status email copy to be cleared, got %s", body)
+	}
+}
+
+func TestEvent_DescriptionFormat(t *testing.T) {
+	t.Parallel()
+	plain := createModeEvent(t, EventInput{Slug: "format-plain", Description: "Talks on **Go**"})
+	if plain.DescriptionFormat != "plaintext" || plain.DescriptionHTML != "" {
+		t.Errorf("expected plaintext without HTML, got %q/%q", plain.DescriptionFormat, plain.DescriptionHTML)
+	}
+
+	md := createModeEvent(t, EventInput{
+		Slug:              "format-markdown",
+		Description:       "Talks on **Go**\n\n<script>alert(1)</script>",
+		DescriptionFormat: "markdown",
+		CFPDescription:    "[Apply](javascript:alert%281%29)",
+	})
+	if md.Description != "Talks on **Go**\n\n<script>alert(1)</script>" {
+		t.Errorf("expected the markdown source kept, got %q", md.Description)
+	}
+	if md.DescriptionHTML != "<p>Talks on <strong>Go</strong></p>\n<p>&lt;script&gt;alert(1)&lt;/script&gt;</p>\n" {
+		t.Errorf("unexpected description_html %q", md.DescriptionHTML)
+	}
+	if md.CFPDescriptionHTML != "<p><a>Apply</a></p>\n" {
+		t.Errorf("unexpected cfp_description_html %q", md.CFPDescriptionHTML)
+	}
+
+	// Fetching renders it again, and switching to plaintext drops the HTML
+	resp := doGet(fmt.Sprintf("/api/v0/e/%s", md.Slug))
+	assertStatus(t, resp, http.StatusOK)
+	var fetched EventResponse
+	if err := parseJSON(resp, &fetched); err != nil {
+		t.Fatalf("failed to parse response: %v", err)
+	}
+	if fetched.DescriptionHTML != md.DescriptionHTML {
+		t.Errorf("expected the same HTML on fetch, got %q", fetched.DescriptionHTML)
+	}
+
+	path := fmt.Sprintf("/api/v0/events/%d", md.ID)
+	resp = doPut(path, map[string]interface{}{"description_format": "plaintext"}, adminToken)
+	assertStatus(t, resp, http.StatusOK)
+	var updated EventResponse
+	if err := parseJSON(resp, &updated); err != nil {
+		t.Fatalf("failed to parse response: %v", err)
+	}
+	if updated.DescriptionFormat != "plaintext" || updated.DescriptionHTML != "" {
+		t.Errorf("expected plaintext without HTML, got %q/%q", updated.DescriptionFormat, updated.DescriptionHTML)
+	}
+
+	resp = doPut(path, map[string]interface{}{"description_format": "html"}, adminToken)
+	assertStatus(t, resp, http.StatusBadRequest)
+	resp.Body.Close()
+}
+
+func TestCreate_LocationHeader(t *testing.T) {
+	now := time.Now()
+	resp := doPost("/api/v0/events", EventInput{
+		Name:      "Location Header Event",
+		Slug:      fmt.Sprintf("location-header-%d", now.UnixNano()),
+		StartDate: now.AddDate(0, 2, 0).Format(time.RFC3339),
+		EndDate:   now.AddDate(0, 2, 1).Format(time.RFC3339),
+	}, adminToken)
+	assertStatus(t, resp, http.StatusCreated)
+	var event EventResponse
+	if err := parseJSON(resp, &event); err != nil {
+		t.Fatalf("failed to parse response: %v", err)
+	}
+	if got, want := resp.Header.Get("Location"), fmt.Sprintf("/api/v0/events/%d", event.ID); got != want {
+		t.Errorf("expected Location %q, got %q", want, got)
+	}
+	if got := resp.Header.Get("Content-Type"); got != "application/json" {
+		t.Errorf("expected Content-Type application/json, got %q", got)
+	}
+
+	resp = doPost(fmt.Sprintf("/api/v0/events/%d/proposals", eventGopherCon.ID), ProposalInput{
+		Title:    "Location Header Proposal",
+		Abstract: "Test abstract",
+		Format:   "talk",
+		Duration: 30,
+		Speakers: []Speaker{
+			{Name: "Speaker User", Email: "speaker@test.com", Company: "Acme Inc", JobTitle: "Engineer", LinkedIn: "https://linkedin.com/in/speaker", Primary: true},
+		},
+	}, speakerToken)
+	assertStatus(t, resp, http.StatusCreated)
+	var proposal ProposalResponse
+	if err := parseJSON(resp, &proposal); err != nil {
+		t.Fatalf("failed to parse response: %v", err)
+	}
+	if got, want := resp.Header.Get("Location"), fmt.Sprintf("/api/v0/proposals/%d", proposal.ID); got != want {
+		t.Errorf("expected Location %q, got %q", want, got)
+	}
+}
+
+func TestScrapeEventPreview_RefusesUnsafeURLs(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name, url string
+		want      int
+	}{
+		{"missing URL", "", http.StatusBadRequest},
+		{"plain HTTP", "http://example.com", http.StatusBadRequest},
+		{"loopback", "https://127.0.0.1/", http.StatusUnprocessableEntity},
+		{"cloud metadata", "https://169.254.169.254/latest/meta-data/", http.StatusUnprocessableEntity},
+		{"localhost name", "https://localhost:8443/", http.StatusUnprocessableEntity},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := doPost("/api/v0/events/scrape-preview", map[string]string{"url": tt.url}, adminToken)
+			assertStatus(t, resp, tt.want)
+			assertErrorCode(t, resp, "validation")
+		})
+	}
+
+	resp := doPost("/api/v0/events/scrape-preview", map[string]string{"url": "https://example.com"}, "")
+	assertStatus(t, resp, http.StatusUnauthorized)
+	resp.Body.Close()
+}
+
+func TestApplyEventBySlug(t *testing.T) {
+	t.Parallel()
+	slug := fmt.Sprintf("apply-test-%d", time.Now().UnixNano())
+	path := "/api/v0/e/" + slug
+	now := time.Now()
+	questions := []map[string]interface{}{
+		{"id": "travel", "text": "Need travel support?", "type": "checkbox"},
+		{"id": "diet", "text": "Dietary needs?", "type": "text"},
+	}
+
+	// Unknown slugs are created, taking the slug from the URL
+	resp := doPut(path, map[string]interface{}{
+		"name":          "Apply Test",
+		"start_date":    now.AddDate(0, 2, 0).Format(time.RFC3339),
+		"end_date":      now.AddDate(0, 2, 1).Format(time.RFC3339),
+		"location":      "Berlin",
+		"cfp_questions": questions,
+	}, adminToken)
+	assertStatus(t, resp, http.StatusCreated)
+	var created EventResponse
+	if err := parseJSON(resp, &created); err != nil {
+		t.Fatalf("failed to parse event: %v", err)
+	}
+	if created.Slug != slug {
+		t.Errorf("expected slug %q, got %q", slug, created.Slug)
+	}
+
+	// Existing events are updated field by field, with questions replaced whole
+	resp = doPut(path, map[string]interface{}{
+		"slug":          slug,
+		"name":          "Apply Test 2026",
+		"cfp_questions": questions[1:],
+	}, adminToken)
+	assertStatus(t, resp, http.StatusOK)
+	body := readBody(resp)
+	if !strings.Contains(body, `"name":"Apply Test 2026"`) || !strings.Contains(body, `"location":"Berlin"`) {
+		t.Errorf("expected the name updated and the location kept, got %s", body)
+	}
+	if strings.Contains(body, "travel") || !strings.Contains(body, "diet") {
+		t.Errorf("expected the questions replaced, got %s", body)
+	}
+
+	// Only organizers can update it
+	resp = doPut(path, map[string]interface{}{"name": "Hijacked"}, otherToken)
+	assertStatus(t, resp, http.StatusForbidden)
+	resp.Body.Close()
+
+	resp = doPut(path, map[string]interface{}{"slug": "something-else", "name": "Renamed"}, adminToken)
+	assertStatus(t, resp, http.StatusBadRequest)
+	assertErrorCode(t, resp, "validation")
+
+	resp = doPut(path, map[string]interface{}{"end_date": now.Format(time.RFC3339)}, adminToken)
+	assertStatus(t, resp, http.StatusBadRequest)
+	assertErrorCode(t, resp, "validation")
+}
+
+func TestUnlistedEvent(t *testing.T) {
+	now := time.Now()
+	event := createTestEvent(adminToken, EventInput{
+		Name:       "Unlisted Community Meetup",
+		Slug:       fmt.Sprintf("unlisted-meetup-%d", now.UnixNano()),
+		Country:    "NL",
+		StartDate:  now.AddDate(0, 2, 0).Format(time.RFC3339),
+		EndDate:    now.AddDate(0, 2, 1).Format(time.RFC3339),
+		Tags:       "zz-unlisted-only",
+		CFPOpenAt:  now.Add(-time.Hour).Format(time.RFC3339),
+		CFPCloseAt: now.AddDate(0, 1, 0).Format(time.RFC3339),
+	})
+	updateCFPStatus(adminToken, event.ID, "open")
+	before := getStats(t, "/api/v0/stats?fresh=true", adminToken)
+
+	// Only organizers can unlist an event
+	resp := doPut(fmt.Sprintf("/api/v0/events/%d", event.ID), map[string]interface{}{"unlisted": true}, otherToken)
+	resp.Body.Close()
+	assertStatus(t, resp, http.StatusForbidden)
+
+	resp = doPut(fmt.Sprintf("/api/v0/events/%d", event.ID), map[string]interface{}{"unlisted": true}, adminToken)
+	assertStatus(t, resp, http.StatusOK)
+	var updated EventResponse
+	if err := parseJSON(resp, &updated); err != nil {
+		t.Fatalf("failed to parse response: %v", err)
+	}
+	if !updated.Unlisted {
+		t.Fatal("expected the event to be unlisted")
+	}
+
+	// Left out of the listing
+	resp = doGet("/api/v0/events?q=" + url.QueryEscape("Unlisted Community Meetup"))
+	assertStatus(t, resp, http.StatusOK)
+	var result EventListResponse
+	if err := parseJSON(resp, &result); err != nil {
+		t.Fatalf("failed to parse response: %v", err)
+	}
+	if len(result.Data) != 0 {
+		t.Errorf("expected the unlisted event to be left out of the listing, got %d events", len(result.Data))
+	}
+
+	// Left out of the stats
+	after := getStats(t, "/api/v0/stats?fresh=true", adminToken)
+	if after.TotalEvents != before.TotalEvents-1 || after.CFPOpen != before.CFPOpen-1 {
+		t.Errorf("expected the unlisted event to be left out of the stats, got %+v then %+v", before, after)
+	}
+	if slices.Contains(after.UniqueTags, "zz-unlisted-only") {
+		t.Errorf("expected the unlisted event's tags to be left out, got %v", after.UniqueTags)
+	}
+
+	// Incremental public API syncs see it as removed
+	key := createTestAPIKey(t, "Unlisted event sync")
+	sync := listPublicEvents(t, key, url.Values{"updated_since": {now.Add(-time.Minute).UTC().Format(time.RFC3339)}, "per_page": {"100"}})
+	removed := false
+	for _, e := range sync.Data {
+		if e.ID == event.ID {
+			removed = e.Removed
+		}
+	}
+	if !removed {
+		t.Error("expected the public API to report the unlisted event as removed")
+	}
+
+	// Still reachable by slug, but not indexed
+	resp = doGet("/api/v0/e/" + event.Slug)
+	assertStatus(t, resp, http.StatusOK)
+	if got := resp.Header.Get("X-Robots-Tag"); got != "noindex" {
+		t.Errorf("expected X-Robots-Tag: noindex, got %q", got)
+	}
+	var page EventResponse
+	if err := parseJSON(resp, &page); err != nil {
+		t.Fatalf("failed to parse response: %v", err)
+	}
+	if !page.Unlisted {
+		t.Error("expected the event page to say the event is unlisted")
+	}
+
+	// Listed events are indexed as usual
+	resp = doGet("/api/v0/e/" + eventGopherCon.Slug)
+	resp.Body.Close()
+	if got := resp.Header.Get("X-Robots-Tag"); got != "" {
+		t.Errorf("expected no X-Robots-Tag for a listed event, got %q", got)
+	}
+
+	// Speakers with the link submit as usual
+	proposal := createTestProposal(speakerToken, event.ID, ProposalInput{
+		Title:    "Talk at an Unlisted Meetup",
+		Abstract: "Test abstract",
+		Format:   "talk",
+		Duration: 30,
+		Speakers: []Speaker{
+			{Name: "Speaker User", Email: "speaker@test.com", Company: "Acme Inc", JobTitle: "Engineer", LinkedIn: "https://linkedin.com/in/speaker", Primary: true},
+		},
+	})
+	if proposal == nil {
+		t.Fatal("expected speakers to be able to submit to an unlisted event")
+	}
+}
@@ -0,0 +1,119 @@
+package integration
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExportFullEvent(t *testing.T) {
+	t.Parallel()
+	now := time.Now()
+	event := createTestEvent(adminToken, EventInput{
+		Name:      "Leaving Conf",
+		Slug:      fmt.Sprintf("leaving-%d", now.UnixNano()),
+		StartDate: now.AddDate(0, 1, 0).Format(time.RFC3339),
+		EndDate:   now.AddDate(0, 1, 1).Format(time.RFC3339),
+	})
+	updateCFPStatus(adminToken, event.ID, "open")
+	createTestProposal(speakerToken, event.ID, ProposalInput{
+		Title:    "Moving Platforms",
+		Abstract: "Everything must go.",
+		Format:   "talk",
+		Duration: 30,
+		Level:    "beginner",
+		Speakers: []Speaker{{Name: "Speaker User", Email: "speaker@test.com", Primary: true}},
+	})
+	resp := doPost(fmt.Sprintf("/api/v0/events/%d/organizers", event.ID), OrganizerInput{Email: "other@test.com"}, adminToken)
+	assertStatus(t, resp, http.StatusCreated)
+	resp.Body.Close()
+	path := fmt.Sprintf("/api/v0/events/%d/export/full", event.ID)
+
+	t.Run("creator gets a zip of everything", func(t *testing.T) {
+		resp := doAuthGet(path, adminToken)
+		assertStatus(t, resp, http.StatusOK)
+		if ct := resp.Header.Get("Content-Type"); ct != "application/zip" {
+			t.Errorf("expected application/zip, got %q", ct)
+		}
+		if cd := resp.Header.Get("Content-Disposition"); !strings.Contains(cd, event.Slug) || !strings.Contains(cd, ".zip") {
+			t.Errorf("expected a zip attachment named after the event, got %q", cd)
+		}
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			t.Fatalf("failed to read body: %v", err)
+		}
+		zr, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+		if err != nil {
+			t.Fatalf("invalid zip: %v", err)
+		}
+
+		files := make(map[string][]byte)
+		for _, f := range zr.File {
+			rc, err := f.Open()
+			if err != nil {
+				t.Fatalf("failed to open %s: %v", f.Name, err)
+			}
+			files[f.Name], _ = io.ReadAll(rc)
+			rc.Close()
+		}
+		for _, name := range []string{"event.json", "proposals.json", "organizers.json", "activity.json"} {
+			if !json.Valid(files[name]) {
+				t.Errorf("expected valid JSON in %s, got %q", name, files[name])
+			}
+		}
+
+		var exported struct {
+			Slug string `json:"slug"`
+		}
+		json.Unmarshal(files["event.json"], &exported)
+		if exported.Slug != event.Slug {
+			t.Errorf("expected the event in event.json, got %q", exported.Slug)
+		}
+		var proposals []struct {
+			Title    string    `json:"title"`
+			Speakers []Speaker `json:"speakers"`
+		}
+		json.Unmarshal(files["proposals.json"], &proposals)
+		if len(proposals) != 1 || proposals[0].Title != "Moving Platforms" || proposals[0].Speakers[0].Email != "speaker@test.com" {
+			t.Errorf("expected the proposal with its speakers, got %+v", proposals)
+		}
+		var organizers []struct {
+			Email string `json:"email"`
+			Role  string `json:"role"`
+		}
+		json.Unmarshal(files["organizers.json"], &organizers)
+		roles := make(map[string]string)
+		for _, o := range organizers {
+			roles[o.Email] = o.Role
+		}
+		if roles["admin@test.com"] != "creator" || roles["other@test.com"] != "organizer" {
+			t.Errorf("expected the creator and the co-organizer, got %+v", organizers)
+		}
+		var activity []struct {
+			Action string `json:"action"`
+		}
+		json.Unmarshal(files["activity.json"], &activity)
+		if len(activity) == 0 {
+			t.Error("expected the audit log to record the CFP opening and the added organizer")
+		}
+	})
+
+	t.Run("reviewers are refused", func(t *testing.T) {
+		resp := doAuthGet(path, otherToken)
+		assertStatus(t, resp, http.StatusForbidden)
+		resp.Body.Close()
+	})
+
+	t.Run("requires auth", func(t *testing.T) {
+		resp := doAuthGet(path, "")
+		assertStatus(t, resp, http.StatusUnauthorized)
+		resp.Body.Close()
+	})
+}
@@ -10,9 +10,43 @@ import (
 	"time"
 )
 
+// exportFixture creates an event whose accepted proposals are the seeded
+// GopherCon talks, submitted by a user of its own
+func exportFixture(t *testing.T) *EventResponse {
+	t.Helper()
+	f := newFixtures(t)
+	_, speaker := f.user("Speaker User")
+	event := f.event(adminToken, EventInput{})
+	for _, input := range []ProposalInput{
+		{
+			Title:    "Go Performance Tips",
+			Abstract: "Learn how to optimize your Go code for maximum performance.",
+			Duration: 45,
+			Level:    "intermediate",
+			Tags:     "performance,optimization",
+		},
+		{
+			Title:    "Mastering Go Channels",
+			Abstract: "Deep dive into Go channels and concurrency patterns.",
+			Duration: 30,
+			Level:    "advanced",
+			Tags:     "concurrency,channels",
+		},
+	} {
+		input.Speakers = []Speaker{
+			{Name: "Speaker User", Email: "speaker@test.com", Bio: "A Go developer", Company: "Acme Inc", JobTitle: "Engineer", LinkedIn: "https://linkedin.com/in/speaker", Primary: true},
+		}
+		proposal := f.proposal(speaker, event.ID, input)
+		f.proposalStatus(adminToken, proposal.ID, "accepted")
+	}
+	return event
+}
+
 func TestExportProposals_InPersonFormat(t *testing.T) {
+	t.Parallel()
+	event := exportFixture(t)
 	resp := doAuthGet(
-		fmt.Sprintf("/api/v0/events/%d/proposals/export?format=in-person", eventGopherCon.ID),
+		fmt.Sprintf("/api/v0/events/%d/proposals/export?format=in-person", event.ID),
 		adminToken,
 	)
 	assertStatus(t, resp, http.StatusOK)
@@ -41,13 +75,13 @@ func TestExportProposals_InPersonFormat(t *testing.T) {
 		t.Fatalf("failed to parse CSV: %v", err)
 	}
 
-	// Must have header + at least 2 data rows (proposalGoPerf and proposalGoChannels)
-	if len(records) < 3 {
-		t.Fatalf("expected at least 3 rows (header + 2 data), got %d", len(records))
+	// Must have header + 2 data rows, one per proposal
+	if len(records) != 3 {
+		t.Fatalf("expected 3 rows (header + 2 data), got %d", len(records))
 	}
 
 	// Verify header columns
-	expectedHeader := []string{"status", "confirmed", "name", "track", "email", "day", "organization", "photo", "linkedin", "linkedin2", "twitter", "twitter2", "title", "abstract", "description", "bio"}
+	expectedHeader := []string{"status", "confirmed", "name", "track", "email", "day", "organization", "photo", "linkedin", "linkedin2", "twitter", "twitter2", "title", "abstract", "description", "bio", "coc_accepted_at", "confirmed_at"}
 	header := records[0]
 	if len(header) != len(expectedHeader) {
 		t.Fatalf("expected %d columns, got %d: %v", len(expectedHeader), len(header), header)
@@ -92,8 +126,10 @@ func TestExportProposals_InPersonFormat(t *testing.T) {
 }
 
 func TestExportProposals_OnlineFormat(t *testing.T) {
+	t.Parallel()
+	event := exportFixture(t)
 	resp := doAuthGet(
-		fmt.Sprintf("/api/v0/events/%d/proposals/export?format=online", eventGopherCon.ID),
+		fmt.Sprintf("/api/v0/events/%d/proposals/export?format=online", event.ID),
 		adminToken,
 	)
 	assertStatus(t, resp, http.StatusOK)
@@ -107,12 +143,12 @@ func TestExportProposals_OnlineFormat(t *testing.T) {
 		t.Fatalf("failed to parse CSV: %v", err)
 	}
 
-	if len(records) < 3 {
-		t.Fatalf("expected at least 3 rows (header + 2 data), got %d", len(records))
+	if len(records) != 3 {
+		t.Fatalf("expected 3 rows (header + 2 data), got %d", len(records))
 	}
 
 	// Verify header columns
-	expectedHeader := []string{"Featured", "Track", "Name1", "Email1", "JobTitle1", "Company1", "Name2", "Email2", "JobTitle2", "Company2", "Title", "Abstract", "LinkedIn1", "Twitter1", "LinkedIn2", "Twitter2", "Slides", "Picture", "YouTube", "Keywords", "Duration", "Status", "Confirmed"}
+	expectedHeader := []string{"Featured", "Track", "Name1", "Email1", "JobTitle1", "Company1", "Name2", "Email2", "JobTitle2", "Company2", "Title", "Abstract", "LinkedIn1", "Twitter1", "LinkedIn2", "Twitter2", "Slides", "Picture", "YouTube", "Keywords", "Duration", "Status", "Confirmed", "CoCAcceptedAt", "ConfirmedAt"}
 	header := records[0]
 	if len(header) != len(expectedHeader) {
 		t.Fatalf("expected %d columns, got %d: %v", len(expectedHeader), len(header), header)
@@ -169,8 +205,11 @@ func TestExportProposals_OnlineFormat(t *testing.T) {
 }
 
 func TestExportProposals_TwoSpeakers_InPerson(t *testing.T) {
+	t.Parallel()
+	f := newFixtures(t)
+	event := f.event(adminToken, EventInput{})
 	// Create a proposal with two speakers and accept it
-	twoSpeakerProposal := createTestProposal(speakerToken, eventGopherCon.ID, ProposalInput{
+	twoSpeakerProposal := f.proposal(speakerToken, event.ID, ProposalInput{
 		Title:    "Two Speaker Talk InPerson",
 		Abstract: "A talk by two speakers",
 		Format:   "talk",
@@ -181,10 +220,10 @@ func TestExportProposals_TwoSpeakers_InPerson(t *testing.T) {
 			{Name: "Bob Jones", Email: "bob@test.com", Bio: "Speaker two bio", Company: "BobCo", JobTitle: "VP Eng", LinkedIn: "https://linkedin.com/in/bob"},
 		},
 	})
-	updateProposalStatus(adminToken, twoSpeakerProposal.ID, "accepted")
+	f.proposalStatus(adminToken, twoSpeakerProposal.ID, "accepted")
 
 	resp := doAuthGet(
-		fmt.Sprintf("/api/v0/events/%d/proposals/export?format=in-person", eventGopherCon.ID),
+		fmt.Sprintf("/api/v0/events/%d/proposals/export?format=in-person", event.ID),
 		adminToken,
 	)
 	assertStatus(t, resp, http.StatusOK)
@@ -194,8 +233,8 @@ func TestExportProposals_TwoSpeakers_InPerson(t *testing.T) {
 	reader := csv.NewReader(strings.NewReader(string(body)))
 	records, _ := reader.ReadAll()
 
-	if len(records) < 2 {
-		t.Fatalf("expected at least 2 rows (header + data), got %d", len(records))
+	if len(records) != 2 {
+		t.Fatalf("expected 2 rows (header + data), got %d", len(records))
 	}
 
 	found := false
@@ -227,8 +266,11 @@ func TestExportProposals_TwoSpeakers_InPerson(t *testing.T) {
 }
 
 func TestExportProposals_TwoSpeakers_Online(t *testing.T) {
+	t.Parallel()
+	f := newFixtures(t)
+	event := f.event(adminToken, EventInput{})
 	// Create a proposal with two speakers (for online format) and accept it
-	twoSpeakerProposal := createTestProposal(speakerToken, eventGopherCon.ID, ProposalInput{
+	twoSpeakerProposal := f.proposal(speakerToken, event.ID, ProposalInput{
 		Title:    "Two Speaker Talk Online",
 		Abstract: "A talk by two speakers for online",
 		Format:   "talk",
@@ -239,10 +281,10 @@ func TestExportProposals_TwoSpeakers_Online(t *testing.T) {
 			{Name: "Dave Brown", Email: "dave@test.com", Bio: "Dave bio", Company: "DaveCo", JobTitle: "Lead", LinkedIn: "https://linkedin.com/in/dave"},
 		},
 	})
-	updateProposalStatus(adminToken, twoSpeakerProposal.ID, "accepted")
+	f.proposalStatus(adminToken, twoSpeakerProposal.ID, "accepted")
 
 	resp := doAuthGet(
-		fmt.Sprintf("/api/v0/events/%d/proposals/export?format=online", eventGopherCon.ID),
+		fmt.Sprintf("/api/v0/events/%d/proposals/export?format=online", event.ID),
 		adminToken,
 	)
 	assertStatus(t, resp, http.StatusOK)
@@ -252,8 +294,8 @@ func TestExportProposals_TwoSpeakers_Online(t *testing.T) {
 	reader := csv.NewReader(strings.NewReader(string(body)))
 	records, _ := reader.ReadAll()
 
-	if len(records) < 2 {
-		t.Fatalf("expected at least 2 rows (header + data), got %d", len(records))
+	if len(records) != 2 {
+		t.Fatalf("expected 2 rows (header + data), got %d", len(records))
 	}
 
 	found := false
@@ -313,6 +355,7 @@ func TestExportProposals_TwoSpeakers_Online(t *testing.T) {
 }
 
 func TestExportProposals_IncludesAllStatuses(t *testing.T) {
+	t.Parallel()
 	now := time.Now()
 	event := createTestEvent(adminToken, EventInput{
 		Name:       "Export Filter Test",
@@ -445,6 +488,7 @@ func TestExportProposals_NonOrganizer(t *testing.T) {
 }
 
 func TestExportProposals_NonExistentEvent(t *testing.T) {
+	t.Parallel()
 	resp := doAuthGet(
 		"/api/v0/events/99999/proposals/export?format=in-person",
 		adminToken,
@@ -454,9 +498,10 @@ func TestExportProposals_NonExistentEvent(t *testing.T) {
 }
 
 func TestExportProposals_EmptyEvent(t *testing.T) {
-	// DevOpsCon has no proposals
+	t.Parallel()
+	event := newFixtures(t).event(adminToken, EventInput{})
 	resp := doAuthGet(
-		fmt.Sprintf("/api/v0/events/%d/proposals/export?format=online", eventDevOpsCon.ID),
+		fmt.Sprintf("/api/v0/events/%d/proposals/export?format=online", event.ID),
 		adminToken,
 	)
 	assertStatus(t, resp, http.StatusOK)
@@ -474,3 +519,86 @@ func TestExportProposals_EmptyEvent(t *testing.T) {
 		t.Errorf("expected 1 row (header only), got %d", len(records))
 	}
 }
+
+func exportSpeakers(t *testing.T, eventID uint, status string) [][]string {
+	t.Helper()
+	resp := doAuthGet(fmt.Sprintf("/api/v0/events/%d/proposals/export?format=speakers&status=%s", eventID, status), adminToken)
+	assertStatus(t, resp, http.StatusOK)
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	records, err := csv.NewReader(strings.NewReader(string(body))).ReadAll()
+	if err != nil {
+		t.Fatalf("failed to parse CSV: %v", err)
+	}
+	return records
+}
+
+func TestExportProposals_SpeakersFormat(t *testing.T) {
+	t.Parallel()
+	eventID, proposal := createAcceptedProposal(t, "export-speakers")
+	second := createTestProposal(speakerToken, eventID, ProposalInput{
+		Title:    "Second Export Talk",
+		Abstract: "Another talk by the same speaker.",
+		Format:   "talk",
+		Duration: 30,
+		Speakers: []Speaker{
+			{Name: "Speaker User", Email: "SPEAKER@test.com", Bio: "Bio", Company: "Acme", JobTitle: "Dev", LinkedIn: "https://linkedin.com/in/speaker"},
+		},
+	})
+	updateProposalStatus(adminToken, second.ID, "accepted")
+
+	// Nobody has confirmed yet
+	if records := exportSpeakers(t, eventID, ""); len(records) != 1 {
+		t.Errorf("expected only the header before confirmation, got %v", records)
+	}
+	records := exportSpeakers(t, eventID, "unconfirmed")
+	if len(records) != 2 || records[1][1] != "speaker@test.com" || records[1][5] != proposal.Title+"; Second Export Talk" {
+		t.Errorf("expected one deduplicated speaker with both talks, got %v", records)
+	}
+
+	for _, id := range []uint{proposal.ID, second.ID} {
+		resp := doPut(fmt.Sprintf("/api/v0/proposals/%d/confirm", id), map[string]interface{}{}, speakerToken)
+		assertStatus(t, resp, http.StatusOK)
+		resp.Body.Close()
+	}
+	records = exportSpeakers(t, eventID, "confirmed")
+	if len(records) != 2 || records[1][0] != "Speaker User" || records[1][2] != "Acme" || records[1][3] != "Dev" {
+		t.Errorf("expected the confirmed speaker, got %v", records)
+	}
+
+	resp := doAuthGet(fmt.Sprintf("/api/v0/events/%d/proposals/export?format=speakers&status=rejected", eventID), adminToken)
+	defer resp.Body.Close()
+	assertStatus(t, resp, http.StatusBadRequest)
+}
+
+func TestExportProposals_PretalxFormat(t *testing.T) {
+	t.Parallel()
+	eventID, proposal := createAcceptedProposal(t, "export-pretalx")
+
+	resp := doAuthGet(fmt.Sprintf("/api/v0/events/%d/proposals/export?format=pretalx", eventID), adminToken)
+	assertStatus(t, resp, http.StatusOK)
+	if ct := resp.Header.Get("Content-Type"); !strings.HasPrefix(ct, "application/json") {
+		t.Errorf("expected Content-Type application/json, got %q", ct)
+	}
+	var export struct {
+		Count   int `json:"count"`
+		Results []struct {
+			Code     string `json:"code"`
+			Title    string `json:"title"`
+			State    string `json:"state"`
+			Speakers []struct {
+				Email string `json:"email"`
+			} `json:"speakers"`
+		} `json:"results"`
+	}
+	if err := parseJSON(resp, &export); err != nil {
+		t.Fatalf("failed to parse export: %v", err)
+	}
+	if export.Count != 1 || len(export.Results) != 1 {
+		t.Fatalf("expected one submission, got %+v", export)
+	}
+	s := export.Results[0]
+	if s.Title != proposal.Title || s.State != "accepted" || len(s.Code) != 6 || len(s.Speakers) != 1 || s.Speakers[0].Email != "speaker@test.com" {
+		t.Errorf("unexpected submission: %+v", s)
+	}
+}
@@ -0,0 +1,154 @@
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sreday/cfp.ninja/pkg/api"
+	"github.com/sreday/cfp.ninja/pkg/models"
+)
+
+// fixtureSeq numbers fixtures across the run, so tests running in parallel
+// never pick the same email, slug or tag
+var fixtureSeq atomic.Int64
+
+// fixtures creates users, events and proposals owned by a single test. Tests
+// that only look at their own fixtures can run in parallel with the rest of
+// the suite, see cleanDatabase. Its methods fail the test the fixtures were
+// made for, so call them from that test rather than from its subtests.
+type fixtures struct {
+	t *testing.T
+	// tag is on every event the fixtures create, so listings filtered by
+	// ?tag= only hold this test's events. It has a fixed width, so it is
+	// never part of another fixtures' tag and also works in ?q= searches
+	// on names that include it.
+	tag string
+}
+
+func newFixtures(t *testing.T) *fixtures {
+	t.Helper()
+	return &fixtures{t: t, tag: fmt.Sprintf("fixture-%06d", fixtureSeq.Add(1))}
+}
+
+// unique returns prefix followed by a suffix no other fixture has, usable
+// in slugs and emails
+func (f *fixtures) unique(prefix string) string {
+	return fmt.Sprintf("%s-%s-%d", prefix, f.tag, fixtureSeq.Add(1))
+}
+
+// user creates a user with a unique email and returns it with a CLI token
+func (f *fixtures) user(name string) (*models.User, string) {
+	f.t.Helper()
+	email := f.unique("user") + "@test.com"
+	user := &models.User{
+		Email:    email,
+		Name:     name,
+		GoogleID: "google-" + email,
+		GitHubID: "github-" + email,
+		IsActive: true,
+	}
+	if err := testConfig.DB.Create(user).Error; err != nil {
+		f.t.Fatalf("failed to create user %s: %v", email, err)
+	}
+	token, err := api.GenerateJWT(testConfig, user, api.TokenTypeCLI)
+	if err != nil {
+		f.t.Fatalf("failed to generate JWT for %s: %v", email, err)
+	}
+	return user, token
+}
+
+// draftEvent creates an event with a draft CFP. Empty name, slug and dates
+// are filled in, with the CFP open from yesterday for a month and the event
+// two months out. The fixtures' tag is added to the tags.
+func (f *fixtures) draftEvent(token string, input EventInput) *EventResponse {
+	f.t.Helper()
+	now := time.Now()
+	if input.Slug == "" {
+		input.Slug = f.unique("event")
+	}
+	if input.Name == "" {
+		input.Name = "Fixture Conf " + input.Slug
+	}
+	if input.StartDate == "" {
+		input.StartDate = now.AddDate(0, 2, 0).Format(time.RFC3339)
+	}
+	if input.EndDate == "" {
+		input.EndDate = now.AddDate(0, 2, 1).Format(time.RFC3339)
+	}
+	if input.CFPOpenAt == "" {
+		input.CFPOpenAt = now.AddDate(0, 0, -1).Format(time.RFC3339)
+	}
+	if input.CFPCloseAt == "" {
+		input.CFPCloseAt = now.AddDate(0, 1, 0).Format(time.RFC3339)
+	}
+	input.Tags = strings.Trim(input.Tags+","+f.tag, ",")
+
+	resp := doPost("/api/v0/events", input, token)
+	assertStatus(f.t, resp, http.StatusCreated)
+	var event EventResponse
+	if err := parseJSON(resp, &event); err != nil {
+		f.t.Fatalf("failed to parse event %s: %v", input.Slug, err)
+	}
+	return &event
+}
+
+// event creates an event as draftEvent does and opens its CFP
+func (f *fixtures) event(token string, input EventInput) *EventResponse {
+	f.t.Helper()
+	event := f.draftEvent(token, input)
+	f.cfpStatus(token, event.ID, "open")
+	event.CFPStatus = "open"
+	return event
+}
+
+// cfpStatus sets the CFP status of an event
+func (f *fixtures) cfpStatus(token string, eventID uint, status string) {
+	f.t.Helper()
+	resp := doPut(fmt.Sprintf("/api/v0/events/%d/cfp-status", eventID), CFPStatusInput{Status: status}, token)
+	assertStatus(f.t, resp, http.StatusOK)
+	resp.Body.Close()
+}
+
+// proposal submits a proposal to an event. An empty title, abstract,
+// format, duration, level and speaker list are filled in.
+func (f *fixtures) proposal(token string, eventID uint, input ProposalInput) *ProposalResponse {
+	f.t.Helper()
+	if input.Title == "" {
+		input.Title = "Fixture Talk " + f.unique("talk")
+	}
+	if input.Abstract == "" {
+		input.Abstract = "A talk submitted by the test fixtures."
+	}
+	if input.Format == "" {
+		input.Format = "talk"
+	}
+	if input.Duration == 0 {
+		input.Duration = 30
+	}
+	if input.Level == "" {
+		input.Level = "beginner"
+	}
+	if len(input.Speakers) == 0 {
+		input.Speakers = []Speaker{{Name: "Fixture Speaker", Email: f.unique("speaker") + "@test.com", Primary: true}}
+	}
+
+	resp := doPost(fmt.Sprintf("/api/v0/events/%d/proposals", eventID), input, token)
+	assertStatus(f.t, resp, http.StatusCreated)
+	var proposal ProposalResponse
+	if err := parseJSON(resp, &proposal); err != nil {
+		f.t.Fatalf("failed to parse proposal %q: %v", input.Title, err)
+	}
+	return &proposal
+}
+
+// proposalStatus sets the status of a proposal, as an organizer of its event
+func (f *fixtures) proposalStatus(token string, proposalID uint, status string) {
+	f.t.Helper()
+	resp := doPut(fmt.Sprintf("/api/v0/proposals/%d/status", proposalID), ProposalStatusInput{Status: status}, token)
+	assertStatus(f.t, resp, http.StatusOK)
+	resp.Body.Close()
+}
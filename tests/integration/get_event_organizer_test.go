@@ -8,6 +8,7 @@ import (
 )
 
 func TestGetEventForOrganizer_CreatorCanAccess(t *testing.T) {
+	t.Parallel()
 	now := time.Now()
 	event := createTestEvent(adminToken, EventInput{
 		Name:      "Org View Test",
@@ -32,6 +33,7 @@ func TestGetEventForOrganizer_CreatorCanAccess(t *testing.T) {
 }
 
 func TestGetEventForOrganizer_CoOrganizerCanAccess(t *testing.T) {
+	t.Parallel()
 	now := time.Now()
 	event := createTestEvent(adminToken, EventInput{
 		Name:      "Co-Org View Test",
@@ -63,6 +65,7 @@ func TestGetEventForOrganizer_CoOrganizerCanAccess(t *testing.T) {
 }
 
 func TestGetEventForOrganizer_NonOrganizerForbidden(t *testing.T) {
+	t.Parallel()
 	now := time.Now()
 	event := createTestEvent(adminToken, EventInput{
 		Name:      "Forbidden Org View",
@@ -84,12 +87,14 @@ func TestGetEventForOrganizer_Unauthenticated(t *testing.T) {
 }
 
 func TestGetEventForOrganizer_NotFound(t *testing.T) {
+	t.Parallel()
 	resp := doAuthGet("/api/v0/me/events/99999", adminToken)
 	assertStatus(t, resp, http.StatusNotFound)
 	resp.Body.Close()
 }
 
 func TestGetEventForOrganizer_InvalidID(t *testing.T) {
+	t.Parallel()
 	resp := doAuthGet("/api/v0/me/events/invalid", adminToken)
 	assertStatus(t, resp, http.StatusBadRequest)
 	resp.Body.Close()
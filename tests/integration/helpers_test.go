@@ -108,15 +108,24 @@ type EventResponse struct {
 	Name                     string `json:"name"`
 	Slug                     string `json:"slug"`
 	Description              string `json:"description"`
+	DescriptionFormat        string `json:"description_format"`
+	DescriptionHTML          string `json:"description_html"`
+	Summary                  string `json:"summary"`
+	CFPDescriptionHTML       string `json:"cfp_description_html"`
 	Location                 string `json:"location"`
 	Country                  string `json:"country"`
+	CountryCode              string `json:"country_code"`
 	StartDate                string `json:"start_date"`
 	EndDate                  string `json:"end_date"`
 	Website                  string `json:"website"`
 	Tags                     string `json:"tags"`
+	AttendanceMode           string `json:"attendance_mode"`
 	IsOnline                 bool   `json:"is_online"`
 	ContactEmail             string `json:"contact_email"`
 	CFPStatus                string `json:"cfp_status"`
+	ModerationStatus         string `json:"moderation_status"`
+	Unlisted                 bool   `json:"unlisted"`
+	Draft                    bool   `json:"draft"`
 	CFPOpenAt                string `json:"cfp_open_at"`
 	CFPCloseAt               string `json:"cfp_close_at"`
 	CreatedByID              *uint  `json:"created_by_id"`
@@ -143,21 +152,24 @@ type PaginationInfo struct {
 
 // ProposalResponse represents a proposal in API responses
 type ProposalResponse struct {
-	ID                    uint   `json:"id"`
-	EventID               uint   `json:"event_id"`
-	Title                 string `json:"title"`
-	Abstract              string `json:"abstract"`
-	Format                string `json:"format"`
-	Duration              int    `json:"duration"`
-	Level                 string `json:"level"`
-	Tags                  string `json:"tags"`
-	Status                string `json:"status"`
-	Rating                *int   `json:"rating,omitempty"`
-	AttendanceConfirmed   bool   `json:"attendance_confirmed"`
-	AttendanceConfirmedAt string `json:"attendance_confirmed_at,omitempty"`
-	CreatedByID           *uint  `json:"created_by_id,omitempty"`
-	IsPaid                bool   `json:"is_paid"`
-	StripePaymentID       string `json:"stripe_payment_id,omitempty"`
+	ID                    uint      `json:"id"`
+	EventID               uint      `json:"event_id"`
+	Title                 string    `json:"title"`
+	Abstract              string    `json:"abstract"`
+	Format                string    `json:"format"`
+	Duration              int       `json:"duration"`
+	Level                 string    `json:"level"`
+	Tags                  string    `json:"tags"`
+	Speakers              []Speaker `json:"speakers"`
+	Status                string    `json:"status"`
+	Rating                *int      `json:"rating,omitempty"`
+	AttendanceConfirmed   bool      `json:"attendance_confirmed"`
+	AttendanceConfirmedAt string    `json:"attendance_confirmed_at,omitempty"`
+	CreatedByID           *uint     `json:"created_by_id,omitempty"`
+	IsPaid                bool      `json:"is_paid"`
+	StripePaymentID       string    `json:"stripe_payment_id,omitempty"`
+	OrganizerNotes        string    `json:"organizer_notes,omitempty"`
+	ReviewerNotes         string    `json:"reviewer_notes,omitempty"`
 }
 
 // ConfigResponse represents the /api/v0/config endpoint response
@@ -172,6 +184,10 @@ type ConfigResponse struct {
 	MaxProposalsPerEvent         int      `json:"max_proposals_per_event"`
 	MaxOrganizersPerEvent        int      `json:"max_organizers_per_event"`
 	NotificationEmail            string   `json:"notification_email,omitempty"`
+	Features                     struct {
+		Providers   []string `json:"providers"`
+		MaxSpeakers int      `json:"max_speakers"`
+	} `json:"features"`
 }
 
 // ProposalListResponse is just an alias since the API returns an array directly
@@ -195,25 +211,35 @@ type StatsResponse struct {
 	UniqueTags      []string `json:"unique_tags"`
 }
 
-// CountriesResponse is just an alias for []string since the API returns an array directly
-type CountriesResponse = []string
+// CountryCount is an entry of the countries listing
+type CountryCount struct {
+	Code  string `json:"code"`
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+// CountriesResponse is an alias since the API returns an array directly
+type CountriesResponse = []CountryCount
 
 // EventInput represents the input for creating/updating an event
 type EventInput struct {
-	Name           string `json:"name"`
-	Slug           string `json:"slug"`
-	Description    string `json:"description,omitempty"`
-	Location       string `json:"location,omitempty"`
-	Country        string `json:"country,omitempty"`
-	StartDate      string `json:"start_date"`
-	EndDate        string `json:"end_date"`
-	Website        string `json:"website,omitempty"`
-	Tags           string `json:"tags,omitempty"`
-	IsOnline       bool   `json:"is_online,omitempty"`
-	ContactEmail   string `json:"contact_email,omitempty"`
-	CFPDescription string `json:"cfp_description,omitempty"`
-	CFPOpenAt      string `json:"cfp_open_at,omitempty"`
-	CFPCloseAt     string `json:"cfp_close_at,omitempty"`
+	Name              string `json:"name"`
+	Slug              string `json:"slug"`
+	Description       string `json:"description,omitempty"`
+	DescriptionFormat string `json:"description_format,omitempty"`
+	Summary           string `json:"summary,omitempty"`
+	Location          string `json:"location,omitempty"`
+	Country           string `json:"country,omitempty"`
+	StartDate         string `json:"start_date"`
+	EndDate           string `json:"end_date"`
+	Website           string `json:"website,omitempty"`
+	Tags              string `json:"tags,omitempty"`
+	AttendanceMode    string `json:"attendance_mode,omitempty"`
+	IsOnline          bool   `json:"is_online,omitempty"`
+	ContactEmail      string `json:"contact_email,omitempty"`
+	CFPDescription    string `json:"cfp_description,omitempty"`
+	CFPOpenAt         string `json:"cfp_open_at,omitempty"`
+	CFPCloseAt        string `json:"cfp_close_at,omitempty"`
 }
 
 // ProposalInput represents the input for creating/updating a proposal
@@ -246,7 +272,8 @@ type OrganizerInput struct {
 
 // CFPStatusInput represents the input for updating CFP status
 type CFPStatusInput struct {
-	Status string `json:"status"`
+	Status     string `json:"status"`
+	CFPCloseAt string `json:"cfp_close_at,omitempty"`
 }
 
 // ProposalStatusInput represents the input for updating proposal status
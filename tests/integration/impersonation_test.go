@@ -0,0 +1,149 @@
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestImpersonation(t *testing.T) {
+	admins := testConfig.AdminEmails
+	testConfig.AdminEmails = []string{"admin@test.com"}
+	t.Cleanup(func() { testConfig.AdminEmails = admins })
+
+	email := fmt.Sprintf("impersonated-%d@test.com", time.Now().UnixNano())
+	user, _ := createTestUserWithJWT(email, "Confused Organizer")
+	path := fmt.Sprintf("/api/v0/admin/impersonate/%d", user.ID)
+
+	type session struct {
+		Token     string    `json:"token"`
+		Write     bool      `json:"write"`
+		ExpiresAt time.Time `json:"expires_at"`
+		User      struct {
+			ID uint `json:"id"`
+		} `json:"user"`
+	}
+	impersonate := func(t *testing.T, body interface{}) session {
+		t.Helper()
+		resp := doPost(path, body, adminToken)
+		assertStatus(t, resp, http.StatusOK)
+		var got session
+		if err := parseJSON(resp, &got); err != nil {
+			t.Fatalf("failed to parse response: %v", err)
+		}
+		if got.Token == "" || got.User.ID != user.ID {
+			t.Fatalf("expected a token for the user, got %+v", got)
+		}
+		return got
+	}
+	createEvent := func(token string) *http.Response {
+		now := time.Now().UTC()
+		return doPost("/api/v0/events", EventInput{
+			Name:      "Impersonated Conf",
+			Slug:      fmt.Sprintf("impersonated-%d", now.UnixNano()),
+			StartDate: now.AddDate(0, 2, 0).Format(time.RFC3339),
+			EndDate:   now.AddDate(0, 2, 1).Format(time.RFC3339),
+		}, token)
+	}
+
+	readOnly := impersonate(t, nil)
+
+	t.Run("short-lived and read-only by default", func(t *testing.T) {
+		if readOnly.Write {
+			t.Error("expected a read-only session")
+		}
+		if d := time.Until(readOnly.ExpiresAt); d <= 14*time.Minute || d > 15*time.Minute {
+			t.Errorf("expected the token to expire in 15 minutes, got %v", d)
+		}
+	})
+
+	t.Run("sees the app as the user", func(t *testing.T) {
+		resp := doAuthGet("/api/v0/auth/me", readOnly.Token)
+		assertStatus(t, resp, http.StatusOK)
+		var me struct {
+			ID             uint `json:"id"`
+			ImpersonatedBy *struct {
+				ID uint `json:"id"`
+			} `json:"impersonated_by"`
+		}
+		if err := parseJSON(resp, &me); err != nil {
+			t.Fatalf("failed to parse response: %v", err)
+		}
+		if me.ID != user.ID || me.ImpersonatedBy == nil || me.ImpersonatedBy.ID != userAdmin.ID {
+			t.Errorf("expected the user impersonated by the admin, got %+v", me)
+		}
+
+		resp = doAuthGet("/api/v0/auth/me", speakerToken)
+		if body := readBody(resp); strings.Contains(body, "impersonated_by") {
+			t.Errorf("expected no impersonation for a regular session, got %s", body)
+		}
+	})
+
+	t.Run("changes are refused", func(t *testing.T) {
+		resp := createEvent(readOnly.Token)
+		assertStatus(t, resp, http.StatusForbidden)
+		if body := readBody(resp); !strings.Contains(body, `"impersonation_read_only"`) {
+			t.Errorf("expected an impersonation_read_only error, got %s", body)
+		}
+	})
+
+	t.Run("write sessions may change things", func(t *testing.T) {
+		write := impersonate(t, map[string]bool{"write": true})
+		resp := createEvent(write.Token)
+		assertStatus(t, resp, http.StatusCreated)
+		resp.Body.Close()
+	})
+
+	t.Run("no admin routes while impersonating", func(t *testing.T) {
+		resp := doAuthGet("/api/v0/admin/impersonations", readOnly.Token)
+		assertStatus(t, resp, http.StatusForbidden)
+		resp.Body.Close()
+	})
+
+	t.Run("only admins impersonate", func(t *testing.T) {
+		resp := doPost(path, nil, speakerToken)
+		assertStatus(t, resp, http.StatusForbidden)
+		resp.Body.Close()
+	})
+
+	t.Run("every request is audited with both identities", func(t *testing.T) {
+		resp := doAuthGet(fmt.Sprintf("/api/v0/admin/impersonations?user_id=%d", user.ID), adminToken)
+		assertStatus(t, resp, http.StatusOK)
+		var logs []struct {
+			AdminID uint   `json:"admin_id"`
+			UserID  uint   `json:"user_id"`
+			Method  string `json:"method"`
+			Path    string `json:"path"`
+			Status  int    `json:"status"`
+			Write   bool   `json:"write"`
+		}
+		if err := parseJSON(resp, &logs); err != nil {
+			t.Fatalf("failed to parse response: %v", err)
+		}
+		// Two sessions started, /auth/me, the refused and the allowed event,
+		// and the refused admin route
+		if len(logs) != 6 {
+			t.Fatalf("expected 6 audit entries, got %+v", logs)
+		}
+		for _, l := range logs {
+			if l.AdminID != userAdmin.ID || l.UserID != user.ID {
+				t.Errorf("expected both identities, got %+v", l)
+			}
+		}
+		// Newest first
+		if logs[0].Path != "/api/v0/admin/impersonations" || logs[0].Status != http.StatusForbidden {
+			t.Errorf("expected the refused admin route last, got %+v", logs[0])
+		}
+		if logs[1].Method != http.MethodPost || logs[1].Path != "/api/v0/events" || logs[1].Status != http.StatusCreated || !logs[1].Write {
+			t.Errorf("expected the write session's event, got %+v", logs[1])
+		}
+		if logs[3].Path != "/api/v0/events" || logs[3].Status != http.StatusForbidden || logs[3].Write {
+			t.Errorf("expected the refused event, got %+v", logs[3])
+		}
+		if logs[5].Path != path || logs[5].Status != http.StatusOK {
+			t.Errorf("expected the first session to start the log, got %+v", logs[5])
+		}
+	})
+}
@@ -0,0 +1,177 @@
+package integration
+
+import (
+	"bytes"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sreday/cfp.ninja/pkg/models"
+)
+
+// ImportRow is one row of a proposal import report
+type ImportRow struct {
+	Line       int               `json:"line"`
+	Title      string            `json:"title"`
+	Result     string            `json:"result"`
+	Errors     map[string]string `json:"errors"`
+	ProposalID uint              `json:"proposal_id"`
+}
+
+// ImportReport is the response of POST /api/v0/events/{id}/proposals/import
+type ImportReport struct {
+	DryRun         bool        `json:"dry_run"`
+	Imported       int         `json:"imported"`
+	Duplicates     int         `json:"duplicates"`
+	Invalid        int         `json:"invalid"`
+	IgnoredColumns []string    `json:"ignored_columns"`
+	Rows           []ImportRow `json:"rows"`
+}
+
+// doImport uploads csv as the "file" field of a multipart form
+func doImport(eventID uint, csv, query, token string) *http.Response {
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	part, err := mw.CreateFormFile("file", "proposals.csv")
+	if err != nil {
+		panic(err)
+	}
+	part.Write([]byte(csv))
+	mw.Close()
+
+	req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/api/v0/events/%d/proposals/import%s", testServer.URL, eventID, query), &body)
+	if err != nil {
+		panic(err)
+	}
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		panic(err)
+	}
+	return resp
+}
+
+func TestImportProposals(t *testing.T) {
+	t.Parallel()
+	now := time.Now()
+	event := createTestEvent(adminToken, EventInput{
+		Name:       "Import Event",
+		Slug:       fmt.Sprintf("import-event-%d", now.UnixNano()),
+		StartDate:  now.AddDate(0, 2, 0).Format(time.RFC3339),
+		EndDate:    now.AddDate(0, 2, 1).Format(time.RFC3339),
+		CFPOpenAt:  now.AddDate(0, 0, -1).Format(time.RFC3339),
+		CFPCloseAt: now.AddDate(0, 1, 0).Format(time.RFC3339),
+	})
+	updateCFPStatus(adminToken, event.ID, "open")
+	createTestProposal(speakerToken, event.ID, ProposalInput{
+		Title:    "Already Submitted",
+		Abstract: "Submitted on cfp.ninja.",
+		Format:   "talk",
+		Duration: 30,
+		Level:    "beginner",
+		Speakers: []Speaker{{Name: "Speaker User", Email: "speaker@test.com", Bio: "Bio", Company: "Acme", JobTitle: "Dev", LinkedIn: "https://linkedin.com/in/speaker", Primary: true}},
+	})
+
+	header := "Title,Description,Format,Duration,Owner,Owner Email,Company,Tagline,LinkedIn,Track\n"
+	good := "Imported Talk,From Sessionize.,Session,45,Jane Doe,jane@example.com,Acme,SRE,https://linkedin.com/in/jane,Main\n" +
+		"already submitted,Same title as an existing proposal.,talk,30,Jane Doe,jane@example.com,Acme,SRE,https://linkedin.com/in/jane,Main\n" +
+		"Imported Talk,Same title as an earlier row.,talk,30,Jane Doe,jane@example.com,Acme,SRE,https://linkedin.com/in/jane,Main\n"
+	bad := "Broken,,talk,30,Jane Doe,not-an-email,Acme,SRE,https://linkedin.com/in/jane,Main\n"
+
+	t.Run("organizers only", func(t *testing.T) {
+		resp := doImport(event.ID, header+good, "", otherToken)
+		defer resp.Body.Close()
+		assertStatus(t, resp, http.StatusForbidden)
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		resp := doPost(fmt.Sprintf("/api/v0/events/%d/proposals/import", event.ID), map[string]string{}, adminToken)
+		defer resp.Body.Close()
+		assertStatus(t, resp, http.StatusBadRequest)
+	})
+
+	t.Run("unusable file", func(t *testing.T) {
+		resp := doImport(event.ID, "Abstract\nNo title column\n", "", adminToken)
+		defer resp.Body.Close()
+		assertStatus(t, resp, http.StatusBadRequest)
+	})
+
+	t.Run("dry run reports row errors", func(t *testing.T) {
+		resp := doImport(event.ID, header+good+bad, "?dry_run=true", adminToken)
+		defer resp.Body.Close()
+		assertStatus(t, resp, http.StatusOK)
+
+		var report ImportReport
+		if err := parseJSON(resp, &report); err != nil {
+			t.Fatalf("failed to parse response: %v", err)
+		}
+		if !report.DryRun || report.Imported != 0 || report.Duplicates != 2 || report.Invalid != 1 || len(report.Rows) != 4 {
+			t.Fatalf("unexpected report: %+v", report)
+		}
+		want := []string{"ok", "duplicate", "duplicate", "invalid"}
+		for i, row := range report.Rows {
+			if row.Result != want[i] || row.Line != i+2 {
+				t.Errorf("row %d: expected %s on line %d, got %+v", i, want[i], i+2, row)
+			}
+		}
+		if report.Rows[3].Errors["abstract"] == "" || report.Rows[3].Errors["speakers[0].email"] == "" {
+			t.Errorf("expected abstract and email errors, got %v", report.Rows[3].Errors)
+		}
+		if len(report.IgnoredColumns) != 1 || report.IgnoredColumns[0] != "Track" {
+			t.Errorf("expected Track to be ignored, got %v", report.IgnoredColumns)
+		}
+	})
+
+	t.Run("invalid rows block the import", func(t *testing.T) {
+		resp := doImport(event.ID, header+good+bad, "", adminToken)
+		defer resp.Body.Close()
+		assertStatus(t, resp, http.StatusBadRequest)
+
+		var count int64
+		testConfig.DB.Model(&models.Proposal{}).Where("event_id = ? AND imported = ?", event.ID, true).Count(&count)
+		if count != 0 {
+			t.Errorf("expected nothing imported, got %d proposals", count)
+		}
+	})
+
+	t.Run("imports valid rows and skips duplicates", func(t *testing.T) {
+		resp := doImport(event.ID, header+good, "", adminToken)
+		defer resp.Body.Close()
+		assertStatus(t, resp, http.StatusOK)
+
+		var report ImportReport
+		if err := parseJSON(resp, &report); err != nil {
+			t.Fatalf("failed to parse response: %v", err)
+		}
+		if report.Imported != 1 || report.Duplicates != 2 || report.Rows[0].ProposalID == 0 {
+			t.Fatalf("unexpected report: %+v", report)
+		}
+
+		var p models.Proposal
+		if err := testConfig.DB.First(&p, report.Rows[0].ProposalID).Error; err != nil {
+			t.Fatalf("failed to load imported proposal: %v", err)
+		}
+		if !p.Imported || p.CreatedByID != nil || p.Status != models.ProposalStatusSubmitted || p.Duration != 45 {
+			t.Errorf("unexpected imported proposal: %+v", p)
+		}
+
+		// Importing the same file again only finds duplicates
+		resp2 := doImport(event.ID, header+good, "", adminToken)
+		defer resp2.Body.Close()
+		assertStatus(t, resp2, http.StatusOK)
+		var again ImportReport
+		if err := parseJSON(resp2, &again); err != nil {
+			t.Fatalf("failed to parse response: %v", err)
+		}
+		if again.Imported != 0 || again.Duplicates != 3 {
+			t.Errorf("expected only duplicates on re-import, got %+v", again)
+		}
+		if !strings.Contains(readBody(doAuthGet(fmt.Sprintf("/api/v0/events/%d/proposals", event.ID), adminToken)), `"imported":true`) {
+			t.Error("expected imported proposals to be marked in the proposal list")
+		}
+	})
+}
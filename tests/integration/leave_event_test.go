@@ -0,0 +1,80 @@
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestLeaveEvent(t *testing.T) {
+	t.Parallel()
+	now := time.Now().UTC()
+	newEvent := func(token, name string) *EventResponse {
+		return createTestEvent(token, EventInput{
+			Name:       name,
+			Slug:       fmt.Sprintf("leave-%d", time.Now().UnixNano()),
+			StartDate:  now.AddDate(0, 2, 0).Format(time.RFC3339),
+			EndDate:    now.AddDate(0, 2, 1).Format(time.RFC3339),
+			CFPOpenAt:  now.AddDate(0, 0, -1).Format(time.RFC3339),
+			CFPCloseAt: now.AddDate(0, 1, 0).Format(time.RFC3339),
+		})
+	}
+	addOrganizer := func(t *testing.T, eventID uint, email, token string) {
+		t.Helper()
+		resp := doPost(fmt.Sprintf("/api/v0/events/%d/organizers", eventID), OrganizerInput{Email: email}, token)
+		assertStatus(t, resp, http.StatusCreated)
+		resp.Body.Close()
+	}
+	leave := func(eventID uint, token string) *http.Response {
+		return doDelete(fmt.Sprintf("/api/v0/events/%d/organizers/me", eventID), token)
+	}
+
+	event := newEvent(adminToken, "Leave Conf")
+	addOrganizer(t, event.ID, "other@test.com", adminToken)
+
+	t.Run("creator can't leave", func(t *testing.T) {
+		resp := leave(event.ID, adminToken)
+		assertStatus(t, resp, http.StatusBadRequest)
+		resp.Body.Close()
+	})
+
+	t.Run("non-organizers can't leave", func(t *testing.T) {
+		resp := leave(event.ID, speakerToken)
+		assertStatus(t, resp, http.StatusForbidden)
+		resp.Body.Close()
+	})
+
+	t.Run("co-organizer leaves", func(t *testing.T) {
+		resp := leave(event.ID, otherToken)
+		assertStatus(t, resp, http.StatusOK)
+		resp.Body.Close()
+
+		resp = doAuthGet(fmt.Sprintf("/api/v0/events/%d/organizers", event.ID), otherToken)
+		assertStatus(t, resp, http.StatusForbidden)
+		resp.Body.Close()
+
+		activity := getActivity(t, event.ID, "?action=organizer_left", adminToken)
+		if len(activity.Data) != 1 || activity.Data[0].Summary != "Other User left the event's organizers" {
+			t.Errorf("expected an organizer_left entry, got %+v", activity.Data)
+		}
+	})
+
+	t.Run("last organizer of an event without creator can't leave", func(t *testing.T) {
+		creator, creatorToken := createTestUserWithJWT(fmt.Sprintf("leave-creator-%d@test.com", now.UnixNano()), "Departed Creator")
+		orphaned := newEvent(creatorToken, "Orphaned Conf")
+		addOrganizer(t, orphaned.ID, "other@test.com", creatorToken)
+		addOrganizer(t, orphaned.ID, "speaker@test.com", creatorToken)
+		if err := testConfig.DB.Delete(creator).Error; err != nil {
+			t.Fatalf("failed to delete creator: %v", err)
+		}
+
+		resp := leave(orphaned.ID, speakerToken)
+		assertStatus(t, resp, http.StatusOK)
+		resp.Body.Close()
+
+		resp = leave(orphaned.ID, otherToken)
+		assertStatus(t, resp, http.StatusBadRequest)
+		resp.Body.Close()
+	})
+}
@@ -0,0 +1,61 @@
+package integration
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/sreday/cfp.ninja/pkg/models"
+)
+
+func TestMyLogins(t *testing.T) {
+	t.Parallel()
+	f := newFixtures(t)
+	speaker, token := f.user("Speaker")
+	other, _ := f.user("Other")
+	now := time.Now()
+	logins := []models.LoginEvent{
+		{UserID: speaker.ID, Provider: "github", IP: "81.2.69.160", Country: "GB", CreatedAt: now.AddDate(0, 0, -30)},
+		{UserID: speaker.ID, Provider: "google", IP: "203.0.113.7", Country: "DE", CreatedAt: now.AddDate(0, 0, -120)},
+		{UserID: other.ID, Provider: "github", IP: "198.51.100.1", Country: "US", CreatedAt: now},
+	}
+	if err := testConfig.DB.Create(&logins).Error; err != nil {
+		t.Fatalf("failed to create logins: %v", err)
+	}
+	t.Cleanup(func() {
+		testConfig.DB.Where("user_id IN ?", []uint{speaker.ID, other.ID}).Delete(&models.LoginEvent{})
+	})
+
+	t.Run("lists only the user's own logins, newest first", func(t *testing.T) {
+		resp := doAuthGet("/api/v0/me/logins", token)
+		assertStatus(t, resp, http.StatusOK)
+		var got []models.LoginEvent
+		if err := parseJSON(resp, &got); err != nil {
+			t.Fatalf("failed to parse response: %v", err)
+		}
+		if len(got) != 2 || got[0].ID != logins[0].ID || got[1].ID != logins[1].ID {
+			t.Fatalf("unexpected logins: %+v", got)
+		}
+	})
+
+	t.Run("requires auth", func(t *testing.T) {
+		resp := doGet("/api/v0/me/logins")
+		defer resp.Body.Close()
+		assertStatus(t, resp, http.StatusUnauthorized)
+	})
+
+	t.Run("countries seen within the window", func(t *testing.T) {
+		since := now.AddDate(0, 0, -90)
+		if seen, known, _ := models.LoginCountrySeen(testConfig.DB, speaker.ID, "GB", since, 0); !seen || !known {
+			t.Errorf("expected GB to be seen, got seen=%v known=%v", seen, known)
+		}
+		// Only seen before the window
+		if seen, known, _ := models.LoginCountrySeen(testConfig.DB, speaker.ID, "DE", since, 0); seen || !known {
+			t.Errorf("expected DE not to be seen recently, got seen=%v known=%v", seen, known)
+		}
+		// The only login is the one being checked
+		if _, known, _ := models.LoginCountrySeen(testConfig.DB, other.ID, "US", since, logins[2].ID); known {
+			t.Error("expected no earlier logins to compare against")
+		}
+	})
+}
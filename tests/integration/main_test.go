@@ -5,6 +5,7 @@ import (
 	"log/slog"
 	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/sreday/cfp.ninja/pkg/api"
@@ -30,6 +31,13 @@ func TestMain(m *testing.M) {
 	if os.Getenv("JWT_SECRET") == "" {
 		os.Setenv("JWT_SECRET", "test-secret")
 	}
+	// Abuse limits are enabled per test; fixtures submit many proposals from new accounts
+	os.Setenv("MAX_PROPOSALS_PER_HOUR", "0")
+	os.Setenv("NEW_ACCOUNT_COOLDOWN", "0")
+	// admin@test.com is the platform admin, so admin endpoints are reachable
+	os.Setenv("ADMIN_EMAILS", "admin@test.com")
+	// Never reach the public geocoder from tests; a stub is installed below
+	os.Setenv("GEOCODER", "none")
 
 	// Reuse actual server setup from pkg/server (no static files for tests)
 	cfg, handler, err := server.SetupServer(nil)
@@ -39,6 +47,7 @@ func TestMain(m *testing.M) {
 	}
 
 	testConfig = cfg
+	testConfig.Geocoder = stubGeocoder
 	testServer = httptest.NewServer(handler)
 
 	// Start user cache cleanup with a cancellable context
@@ -46,7 +55,8 @@ func TestMain(m *testing.M) {
 
 	api.StartUserCacheCleanup(cacheCtx)
 
-	// Clean database before tests
+	// Clean database before tests; see cleanDatabase for how tests keep
+	// out of each other's way afterwards
 	cleanDatabase()
 
 	// Seed test data
@@ -62,21 +72,31 @@ func TestMain(m *testing.M) {
 	os.Exit(code)
 }
 
-// cleanDatabase truncates all tables to ensure a clean state
+// cleanDatabase truncates every table, once per run before the shared
+// fixtures are seeded. Tests never truncate: most run in parallel, so
+// instead of starting from an empty database each test creates the data it
+// asserts on with newFixtures. Tests that change testConfig, write the
+// seeded fixtures or count rows across the whole database must not call
+// t.Parallel; Go runs them before releasing the parallel ones, so they see
+// only the seeded fixtures and what earlier serial tests left behind.
 func cleanDatabase() {
 	db := testConfig.DB
 
-	// Disable foreign key checks temporarily
-	db.Exec("SET session_replication_role = 'replica'")
-
-	// Truncate tables in order to avoid foreign key issues
-	db.Exec("TRUNCATE TABLE proposals CASCADE")
-	db.Exec("TRUNCATE TABLE event_organizers CASCADE")
-	db.Exec("TRUNCATE TABLE events CASCADE")
-	db.Exec("TRUNCATE TABLE users CASCADE")
-
-	// Re-enable foreign key checks
-	db.Exec("SET session_replication_role = 'origin'")
+	var tables []string
+	if err := db.Raw("SELECT tablename FROM pg_tables WHERE schemaname = current_schema()").Scan(&tables).Error; err != nil {
+		slog.Error("failed to list tables", "error", err)
+		os.Exit(1)
+	}
+	if len(tables) == 0 {
+		return
+	}
+	for i, table := range tables {
+		tables[i] = `"` + table + `"`
+	}
+	if err := db.Exec("TRUNCATE TABLE " + strings.Join(tables, ", ") + " CASCADE").Error; err != nil {
+		slog.Error("failed to truncate tables", "error", err)
+		os.Exit(1)
+	}
 }
 
 // createTestUserWithJWT creates a user directly in the database and generates a JWT token.
@@ -98,7 +118,7 @@ func createTestUserWithJWT(email, name string) (*models.User, string) {
 	}
 
 	// Generate JWT token
-	token, err := api.GenerateJWT(testConfig, user)
+	token, err := api.GenerateJWT(testConfig, user, api.TokenTypeCLI)
 	if err != nil {
 		slog.Error("failed to generate JWT", "email", email, "error", err)
 		os.Exit(1)
@@ -0,0 +1,121 @@
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/sreday/cfp.ninja/pkg/models"
+)
+
+// EventMergeResult is the response of POST /api/v0/admin/events/merge
+type EventMergeResult struct {
+	SourceID           uint   `json:"source_id"`
+	SourceSlug         string `json:"source_slug"`
+	TargetID           uint   `json:"target_id"`
+	ProposalsMoved     int64  `json:"proposals_moved"`
+	OrganizersAdded    []uint `json:"organizers_added"`
+	PaymentTransferred bool   `json:"payment_transferred"`
+}
+
+func createMergeTestEvent(t *testing.T, token, name string) *EventResponse {
+	t.Helper()
+	now := time.Now()
+	event := createTestEvent(token, EventInput{
+		Name:       name,
+		Slug:       fmt.Sprintf("merge-%d", now.UnixNano()),
+		StartDate:  now.AddDate(0, 2, 0).Format(time.RFC3339),
+		EndDate:    now.AddDate(0, 2, 1).Format(time.RFC3339),
+		CFPOpenAt:  now.AddDate(0, 0, -1).Format(time.RFC3339),
+		CFPCloseAt: now.AddDate(0, 1, 0).Format(time.RFC3339),
+	})
+	updateCFPStatus(token, event.ID, "open")
+	return event
+}
+
+func TestMergeEvents(t *testing.T) {
+	t.Run("admins only", func(t *testing.T) {
+		resp := doPost("/api/v0/admin/events/merge", map[string]uint{"source_id": 1, "target_id": 2}, speakerToken)
+		defer resp.Body.Close()
+		assertStatus(t, resp, http.StatusForbidden)
+	})
+
+	t.Run("validates ids", func(t *testing.T) {
+		resp := doPost("/api/v0/admin/events/merge", map[string]uint{"source_id": 5, "target_id": 5}, adminToken)
+		defer resp.Body.Close()
+		assertStatus(t, resp, http.StatusBadRequest)
+
+		resp2 := doPost("/api/v0/admin/events/merge", map[string]uint{"source_id": 999999, "target_id": eventGopherCon.ID}, adminToken)
+		defer resp2.Body.Close()
+		assertStatus(t, resp2, http.StatusNotFound)
+	})
+
+	t.Run("refuses conflicting payments", func(t *testing.T) {
+		source := createMergeTestEvent(t, adminToken, "Paid Duplicate")
+		target := createMergeTestEvent(t, adminToken, "Paid Original")
+		testConfig.DB.Model(&models.Event{}).Where("id IN ?", []uint{source.ID, target.ID}).Update("is_paid", true)
+
+		resp := doPost("/api/v0/admin/events/merge", map[string]uint{"source_id": source.ID, "target_id": target.ID}, adminToken)
+		defer resp.Body.Close()
+		assertStatus(t, resp, http.StatusConflict)
+
+		var count int64
+		testConfig.DB.Model(&models.Event{}).Where("id = ?", source.ID).Count(&count)
+		if count != 1 {
+			t.Error("expected the source event to be kept")
+		}
+	})
+
+	t.Run("moves proposals and organizers and redirects the slug", func(t *testing.T) {
+		source := createMergeTestEvent(t, speakerToken, "Duplicate Conf")
+		target := createMergeTestEvent(t, adminToken, "Original Conf")
+		proposal := createTestProposal(otherToken, source.ID, ProposalInput{
+			Title:    "Merged Talk",
+			Abstract: "Submitted to the duplicate.",
+			Format:   "talk",
+			Duration: 30,
+			Level:    "beginner",
+			Speakers: []Speaker{{Name: "Other User", Email: "other@test.com", Bio: "Bio", Company: "Acme", JobTitle: "Dev", LinkedIn: "https://linkedin.com/in/other", Primary: true}},
+		})
+		testConfig.DB.Model(&models.Event{}).Where("id = ?", source.ID).Updates(map[string]interface{}{"is_paid": true, "stripe_payment_id": "pi_merge"})
+
+		resp := doPost("/api/v0/admin/events/merge", map[string]uint{"source_id": source.ID, "target_id": target.ID}, adminToken)
+		assertStatus(t, resp, http.StatusOK)
+		var result EventMergeResult
+		if err := parseJSON(resp, &result); err != nil {
+			t.Fatalf("failed to parse response: %v", err)
+		}
+		if result.ProposalsMoved != 1 || len(result.OrganizersAdded) != 1 || !result.PaymentTransferred || result.SourceSlug != source.Slug {
+			t.Fatalf("unexpected merge result: %+v", result)
+		}
+
+		var moved models.Proposal
+		testConfig.DB.First(&moved, proposal.ID)
+		if moved.EventID != target.ID {
+			t.Errorf("expected the proposal on event %d, got %d", target.ID, moved.EventID)
+		}
+
+		var merged models.Event
+		testConfig.DB.First(&merged, target.ID)
+		if !merged.IsPaid || merged.StripePaymentID != "pi_merge" {
+			t.Errorf("expected the listing payment on the target, got %+v", merged)
+		}
+
+		// The source's creator now organizes the target
+		resp = doAuthGet(fmt.Sprintf("/api/v0/events/%d/organizers", target.ID), speakerToken)
+		defer resp.Body.Close()
+		assertStatus(t, resp, http.StatusOK)
+
+		resp = doGet(fmt.Sprintf("/api/v0/events/%d", source.ID))
+		defer resp.Body.Close()
+		assertStatus(t, resp, http.StatusNotFound)
+
+		resp = doGet("/api/v0/e/" + source.Slug)
+		defer resp.Body.Close()
+		assertStatus(t, resp, http.StatusOK)
+		if resp.Request.URL.Path != "/api/v0/e/"+target.Slug {
+			t.Errorf("expected a redirect to the target, ended at %s", resp.Request.URL.Path)
+		}
+	})
+}
@@ -0,0 +1,183 @@
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/sreday/cfp.ninja/pkg/models"
+)
+
+func TestMissingRequiredAnswers(t *testing.T) {
+	t.Parallel()
+	now := time.Now().UTC()
+	event := createTestEvent(adminToken, EventInput{
+		Name:       "Missing Answers Conf",
+		Slug:       fmt.Sprintf("missing-answers-%d", now.UnixNano()),
+		StartDate:  now.AddDate(0, 2, 0).Format(time.RFC3339),
+		EndDate:    now.AddDate(0, 2, 1).Format(time.RFC3339),
+		CFPOpenAt:  now.AddDate(0, 0, -1).Format(time.RFC3339),
+		CFPCloseAt: now.AddDate(0, 1, 0).Format(time.RFC3339),
+	})
+	updateCFPStatus(adminToken, event.ID, "open")
+	eventPath := fmt.Sprintf("/api/v0/events/%d", event.ID)
+
+	submit := func(title string) *ProposalResponse {
+		return createTestProposal(speakerToken, event.ID, ProposalInput{
+			Title:    title,
+			Abstract: "Submitted before the travel question.",
+			Format:   "talk",
+			Duration: 30,
+			Level:    "intermediate",
+			Speakers: []Speaker{
+				{Name: "Speaker User", Email: "speaker@test.com", Company: "Acme Inc", JobTitle: "Engineer", LinkedIn: "https://linkedin.com/in/speaker", Primary: true},
+			},
+		})
+	}
+	accepted := submit("Accepted Before The Question")
+	updateProposalStatus(adminToken, accepted.ID, "accepted")
+	pending := submit("Pending Before The Question")
+
+	// A required question added mid-CFP
+	questions := []models.CustomQuestion{{ID: "travel", Text: "Do you need travel support?", Type: "text", Required: true}}
+	resp := doPut(eventPath, map[string]interface{}{"cfp_questions": questions}, adminToken)
+	resp.Body.Close()
+	assertStatus(t, resp, http.StatusOK)
+
+	type listed struct {
+		ID                     uint     `json:"ID"`
+		MissingRequiredAnswers []string `json:"missing_required_answers"`
+		AnswersRequestedUntil  *string  `json:"answers_requested_until"`
+	}
+	list := func(t *testing.T, query string) map[uint]listed {
+		t.Helper()
+		resp := doAuthGet(eventPath+"/proposals"+query, adminToken)
+		assertStatus(t, resp, http.StatusOK)
+		var proposals []listed
+		if err := parseJSON(resp, &proposals); err != nil {
+			t.Fatalf("failed to parse response: %v", err)
+		}
+		byID := make(map[uint]listed)
+		for _, p := range proposals {
+			byID[p.ID] = p
+		}
+		return byID
+	}
+
+	t.Run("organizers see the missing answers", func(t *testing.T) {
+		got := list(t, "")[accepted.ID].MissingRequiredAnswers
+		if len(got) != 1 || got[0] != "travel" {
+			t.Errorf("expected travel to be missing, got %v", got)
+		}
+
+		resp := doAuthGet(fmt.Sprintf("/api/v0/proposals/%d", accepted.ID), speakerToken)
+		var own listed
+		if err := parseJSON(resp, &own); err != nil {
+			t.Fatalf("failed to parse response: %v", err)
+		}
+		if len(own.MissingRequiredAnswers) != 0 {
+			t.Errorf("expected speakers not to get missing answers, got %v", own.MissingRequiredAnswers)
+		}
+	})
+
+	requestPath := fmt.Sprintf("/api/v0/proposals/%d/request-answers", accepted.ID)
+	proposalPath := fmt.Sprintf("/api/v0/proposals/%d", accepted.ID)
+
+	t.Run("speakers can't edit accepted proposals unasked", func(t *testing.T) {
+		resp := doPut(proposalPath, map[string]interface{}{"custom_answers": map[string]string{"travel": "No"}}, speakerToken)
+		defer resp.Body.Close()
+		assertStatus(t, resp, http.StatusBadRequest)
+	})
+
+	t.Run("only organizers request answers", func(t *testing.T) {
+		resp := doPost(requestPath, nil, speakerToken)
+		defer resp.Body.Close()
+		assertStatus(t, resp, http.StatusForbidden)
+	})
+
+	t.Run("request lets the speaker fill in answers only", func(t *testing.T) {
+		resp := doPost(requestPath, nil, adminToken)
+		assertStatus(t, resp, http.StatusOK)
+		var requested listed
+		if err := parseJSON(resp, &requested); err != nil {
+			t.Fatalf("failed to parse response: %v", err)
+		}
+		if requested.AnswersRequestedUntil == nil {
+			t.Fatalf("expected answers_requested_until to be set, got %+v", requested)
+		}
+
+		resp = doPut(proposalPath, map[string]interface{}{
+			"title":          "Renamed While Accepted",
+			"custom_answers": map[string]string{"travel": "No, thanks"},
+		}, speakerToken)
+		assertStatus(t, resp, http.StatusOK)
+		resp.Body.Close()
+
+		var stored models.Proposal
+		testConfig.DB.First(&stored, accepted.ID)
+		if stored.Title != accepted.Title || stored.Status != models.ProposalStatusAccepted {
+			t.Errorf("expected only the answers to change, got title %q and status %q", stored.Title, stored.Status)
+		}
+		if stored.AnswersRequestedUntil != nil {
+			t.Errorf("expected the request to be answered, got %v", stored.AnswersRequestedUntil)
+		}
+
+		var activity models.EventActivity
+		if err := testConfig.DB.Where("event_id = ? AND action = ?", event.ID, models.ActivityAnswersRequested).First(&activity).Error; err != nil || activity.Detail != "travel" {
+			t.Errorf("expected the request in the activity log, got %+v (%v)", activity, err)
+		}
+	})
+
+	t.Run("nothing to request once answered", func(t *testing.T) {
+		resp := doPost(requestPath, nil, adminToken)
+		defer resp.Body.Close()
+		assertStatus(t, resp, http.StatusBadRequest)
+	})
+
+	t.Run("listing filters on missing answers", func(t *testing.T) {
+		got := list(t, "?missing_answers=true")
+		if _, ok := got[pending.ID]; !ok || len(got) != 1 {
+			t.Errorf("expected only the pending proposal, got %+v", got)
+		}
+	})
+}
+
+func TestCreateProposal_IgnoresAnswersRequestedUntil(t *testing.T) {
+	now := time.Now().UTC()
+	event := createTestEvent(adminToken, EventInput{
+		Name:       "Requested Answers Conf",
+		Slug:       fmt.Sprintf("requested-answers-%d", now.UnixNano()),
+		StartDate:  now.AddDate(0, 2, 0).Format(time.RFC3339),
+		EndDate:    now.AddDate(0, 2, 1).Format(time.RFC3339),
+		CFPOpenAt:  now.AddDate(0, 0, -1).Format(time.RFC3339),
+		CFPCloseAt: now.AddDate(0, 1, 0).Format(time.RFC3339),
+	})
+	updateCFPStatus(adminToken, event.ID, "open")
+
+	// Only organizers let speakers edit a submitted proposal
+	resp := doPost(fmt.Sprintf("/api/v0/events/%d/proposals", event.ID), map[string]interface{}{
+		"title":    "Self Granted Edits",
+		"abstract": "Tries to keep the proposal editable after the decision.",
+		"format":   "talk",
+		"duration": 30,
+		"level":    "intermediate",
+		"speakers": []Speaker{
+			{Name: "Speaker User", Email: "speaker@test.com", Company: "Acme Inc", JobTitle: "Engineer", LinkedIn: "https://linkedin.com/in/speaker", Primary: true},
+		},
+		"answers_requested_until": now.AddDate(0, 1, 0).Format(time.RFC3339),
+	}, speakerToken)
+	assertStatus(t, resp, http.StatusCreated)
+	var created ProposalResponse
+	if err := parseJSON(resp, &created); err != nil {
+		t.Fatalf("failed to parse response: %v", err)
+	}
+
+	var stored models.Proposal
+	if err := testConfig.DB.First(&stored, created.ID).Error; err != nil {
+		t.Fatalf("failed to load proposal: %v", err)
+	}
+	if stored.AnswersRequestedUntil != nil {
+		t.Errorf("expected answers_requested_until to be ignored, got %v", stored.AnswersRequestedUntil)
+	}
+}
@@ -0,0 +1,162 @@
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestCreateEvent_SpamHeldForModeration(t *testing.T) {
+	email := fmt.Sprintf("spammer-%d@mailinator.com", time.Now().UnixNano())
+	_, token := createTestUserWithJWT(email, "Spam User")
+
+	now := time.Now().UTC()
+	slug := fmt.Sprintf("spam-%d", now.UnixNano())
+	resp := doPost("/api/v0/events", map[string]interface{}{
+		"name":        "Cheap Pills Summit",
+		"slug":        slug,
+		"description": "Buy now https://spam.example https://spam.example/2 https://spam.example/3",
+		"start_date":  now.AddDate(0, 2, 0).Format(time.RFC3339),
+		"end_date":    now.AddDate(0, 2, 1).Format(time.RFC3339),
+		"cfp_status":  "open",
+	}, token)
+	assertStatus(t, resp, http.StatusCreated)
+	var event EventResponse
+	if err := parseJSON(resp, &event); err != nil {
+		t.Fatalf("failed to parse response: %v", err)
+	}
+	if event.ModerationStatus != "pending_review" {
+		t.Fatalf("expected moderation_status pending_review, got %q", event.ModerationStatus)
+	}
+
+	t.Run("hidden from public", func(t *testing.T) {
+		resp := doGet("/api/v0/e/" + slug)
+		assertStatus(t, resp, http.StatusNotFound)
+		resp.Body.Close()
+
+		resp = doGet(fmt.Sprintf("/api/v0/events/%d", event.ID))
+		assertStatus(t, resp, http.StatusNotFound)
+		resp.Body.Close()
+
+		resp = doPost(fmt.Sprintf("/api/v0/events/%d/proposals", event.ID), ProposalInput{
+			Title:    "Held Talk",
+			Abstract: "Submitted to an event held for review.",
+			Format:   "talk",
+			Duration: 30,
+			Level:    "intermediate",
+			Speakers: []Speaker{
+				{Name: "Speaker User", Email: "speaker@test.com", Bio: "Bio", Company: "Acme", JobTitle: "Dev", LinkedIn: "https://linkedin.com/in/speaker"},
+			},
+		}, speakerToken)
+		assertStatus(t, resp, http.StatusNotFound)
+		resp.Body.Close()
+	})
+
+	t.Run("listed for admins with signals", func(t *testing.T) {
+		resp := doAuthGet("/api/v0/admin/events/moderation", adminToken)
+		assertStatus(t, resp, http.StatusOK)
+		var held []struct {
+			ID          uint `json:"id"`
+			SpamScore   int  `json:"spam_score"`
+			SpamSignals []struct {
+				Name string `json:"name"`
+			} `json:"spam_signals"`
+		}
+		if err := parseJSON(resp, &held); err != nil {
+			t.Fatalf("failed to parse response: %v", err)
+		}
+		for _, e := range held {
+			if e.ID != event.ID {
+				continue
+			}
+			names := map[string]bool{}
+			for _, s := range e.SpamSignals {
+				names[s.Name] = true
+			}
+			if !names["disposable_email"] || !names["new_account"] || !names["link_density"] {
+				t.Errorf("expected disposable_email, new_account and link_density signals, got %v", names)
+			}
+			if e.SpamScore < testConfig.SpamScoreThreshold {
+				t.Errorf("expected score of at least %d, got %d", testConfig.SpamScoreThreshold, e.SpamScore)
+			}
+			return
+		}
+		t.Errorf("expected event %d in moderation queue", event.ID)
+	})
+
+	t.Run("non-admin cannot moderate", func(t *testing.T) {
+		resp := doAuthGet("/api/v0/admin/events/moderation", speakerToken)
+		assertStatus(t, resp, http.StatusForbidden)
+		resp.Body.Close()
+
+		resp = doPut(fmt.Sprintf("/api/v0/admin/events/%d/moderation", event.ID), map[string]string{"status": "approved"}, token)
+		assertStatus(t, resp, http.StatusForbidden)
+		resp.Body.Close()
+	})
+
+	t.Run("invalid status", func(t *testing.T) {
+		resp := doPut(fmt.Sprintf("/api/v0/admin/events/%d/moderation", event.ID), map[string]string{"status": "spam"}, adminToken)
+		assertStatus(t, resp, http.StatusBadRequest)
+		resp.Body.Close()
+	})
+
+	t.Run("approved event is listed", func(t *testing.T) {
+		resp := doPut(fmt.Sprintf("/api/v0/admin/events/%d/moderation", event.ID), map[string]string{"status": "approved"}, adminToken)
+		assertStatus(t, resp, http.StatusOK)
+		resp.Body.Close()
+
+		resp = doGet("/api/v0/e/" + slug)
+		assertStatus(t, resp, http.StatusOK)
+		resp.Body.Close()
+	})
+}
+
+func TestCreateEvent_Honeypot(t *testing.T) {
+	now := time.Now().UTC()
+	input := func(slug string, homepage string) map[string]interface{} {
+		return map[string]interface{}{
+			"name":       "Honeypot Conf",
+			"slug":       slug,
+			"start_date": now.AddDate(0, 2, 0).Format(time.RFC3339),
+			"end_date":   now.AddDate(0, 2, 1).Format(time.RFC3339),
+			"homepage":   homepage,
+		}
+	}
+
+	t.Run("filled honeypot is held", func(t *testing.T) {
+		resp := doPost("/api/v0/events", input(fmt.Sprintf("honeypot-%d", now.UnixNano()), "https://bot.example"), otherToken)
+		assertStatus(t, resp, http.StatusCreated)
+		var event EventResponse
+		if err := parseJSON(resp, &event); err != nil {
+			t.Fatalf("failed to parse response: %v", err)
+		}
+		if event.ModerationStatus != "pending_review" {
+			t.Errorf("expected moderation_status pending_review, got %q", event.ModerationStatus)
+		}
+	})
+
+	t.Run("empty honeypot is approved", func(t *testing.T) {
+		resp := doPost("/api/v0/events", input(fmt.Sprintf("honeypot-ok-%d", now.UnixNano()), ""), otherToken)
+		assertStatus(t, resp, http.StatusCreated)
+		var event EventResponse
+		if err := parseJSON(resp, &event); err != nil {
+			t.Fatalf("failed to parse response: %v", err)
+		}
+		if event.ModerationStatus != "approved" {
+			t.Errorf("expected moderation_status approved, got %q", event.ModerationStatus)
+		}
+	})
+
+	t.Run("admins are never held", func(t *testing.T) {
+		resp := doPost("/api/v0/events", input(fmt.Sprintf("honeypot-admin-%d", now.UnixNano()), "https://bot.example"), adminToken)
+		assertStatus(t, resp, http.StatusCreated)
+		var event EventResponse
+		if err := parseJSON(resp, &event); err != nil {
+			t.Fatalf("failed to parse response: %v", err)
+		}
+		if event.ModerationStatus != "approved" {
+			t.Errorf("expected moderation_status approved, got %q", event.ModerationStatus)
+		}
+	})
+}
@@ -0,0 +1,130 @@
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+type myEventsSearchResponse struct {
+	Managing []struct {
+		ID uint `json:"id"`
+	} `json:"managing"`
+	Submitted []struct {
+		ID uint `json:"id"`
+	} `json:"submitted"`
+	Pagination *struct {
+		Page       int   `json:"page"`
+		Total      int64 `json:"total"`
+		TotalPages int   `json:"total_pages"`
+	} `json:"pagination"`
+}
+
+func TestMyEventsSearch(t *testing.T) {
+	t.Parallel()
+	now := time.Now()
+	_, token := createTestUserWithJWT(fmt.Sprintf("my-events-%d@test.com", now.UnixNano()), "Many Events")
+	var ids []uint
+	for i, name := range []string{"Alpha Search Conf", "Beta Search Conf", "Gamma Meetup"} {
+		event := createTestEvent(token, EventInput{
+			Name:      name,
+			Slug:      fmt.Sprintf("my-events-%d-%d", i, now.UnixNano()),
+			StartDate: now.AddDate(0, i+1, 0).Format(time.RFC3339),
+			EndDate:   now.AddDate(0, i+1, 1).Format(time.RFC3339),
+		})
+		ids = append(ids, event.ID)
+	}
+	updateCFPStatus(token, ids[2], "closed")
+
+	getMine := func(t *testing.T, path string) myEventsSearchResponse {
+		t.Helper()
+		resp := doAuthGet(path, token)
+		assertStatus(t, resp, http.StatusOK)
+		var mine myEventsSearchResponse
+		if err := parseJSON(resp, &mine); err != nil {
+			t.Fatalf("failed to parse response: %v", err)
+		}
+		return mine
+	}
+	managedIDs := func(mine myEventsSearchResponse) []uint {
+		var got []uint
+		for _, e := range mine.Managing {
+			got = append(got, e.ID)
+		}
+		return got
+	}
+
+	t.Run("q matches name and slug", func(t *testing.T) {
+		mine := getMine(t, "/api/v0/me/events?q=SEARCH+conf")
+		if got := managedIDs(mine); len(got) != 2 || got[0] != ids[1] || got[1] != ids[0] {
+			t.Errorf("expected events %v, latest first, got %v", ids[:2], got)
+		}
+		if mine.Pagination != nil {
+			t.Errorf("expected no pagination without page parameters, got %+v", mine.Pagination)
+		}
+		if got := managedIDs(getMine(t, "/api/v0/me/events?q=my-events-2-")); len(got) != 1 || got[0] != ids[2] {
+			t.Errorf("expected the slug to match event %d, got %v", ids[2], got)
+		}
+	})
+
+	t.Run("cfp_status", func(t *testing.T) {
+		if got := managedIDs(getMine(t, "/api/v0/me/events?cfp_status=closed")); len(got) != 1 || got[0] != ids[2] {
+			t.Errorf("expected only the closed event, got %v", got)
+		}
+	})
+
+	t.Run("pagination", func(t *testing.T) {
+		mine := getMine(t, "/api/v0/me/events?per_page=2&page=2")
+		if got := managedIDs(mine); len(got) != 1 || got[0] != ids[0] {
+			t.Errorf("expected the earliest event on page 2, got %v", got)
+		}
+		if mine.Pagination == nil || mine.Pagination.Page != 2 || mine.Pagination.Total != 3 || mine.Pagination.TotalPages != 2 {
+			t.Errorf("unexpected pagination %+v", mine.Pagination)
+		}
+	})
+
+	t.Run("dashboard", func(t *testing.T) {
+		resp := doAuthGet("/api/v0/me/dashboard?q=gamma&per_page=10", token)
+		assertStatus(t, resp, http.StatusOK)
+		var dashboard struct {
+			dashboardResponse
+			Pagination *struct {
+				Total int64 `json:"total"`
+			} `json:"pagination"`
+		}
+		if err := parseJSON(resp, &dashboard); err != nil {
+			t.Fatalf("failed to parse dashboard: %v", err)
+		}
+		if len(dashboard.Events) != 1 || dashboard.Events[0].ID != ids[2] || dashboard.Pagination == nil || dashboard.Pagination.Total != 1 {
+			t.Errorf("expected only event %d, got %+v", ids[2], dashboard)
+		}
+	})
+
+	t.Run("q narrows submitted events", func(t *testing.T) {
+		resp := doAuthGet("/api/v0/me/events?q=zz-no-such-event", speakerToken)
+		assertStatus(t, resp, http.StatusOK)
+		var mine myEventsSearchResponse
+		if err := parseJSON(resp, &mine); err != nil {
+			t.Fatalf("failed to parse response: %v", err)
+		}
+		if len(mine.Managing) != 0 || len(mine.Submitted) != 0 {
+			t.Errorf("expected nothing to match, got %+v", mine)
+		}
+	})
+
+	t.Run("invalid parameters", func(t *testing.T) {
+		for query, field := range map[string]string{
+			"cfp_status=bogus": "cfp_status",
+			"per_page=0":       "per_page",
+			"per_page=101":     "per_page",
+			"page=0":           "page",
+		} {
+			for _, path := range []string{"/api/v0/me/events?", "/api/v0/me/dashboard?"} {
+				if fields := validationFields(t, doAuthGet(path+query, token)); fields[field] == "" {
+					t.Errorf("%s%s: expected a %s error, got %v", path, query, field, fields)
+				}
+			}
+		}
+	})
+}
@@ -0,0 +1,167 @@
+package integration
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sreday/cfp.ninja/pkg/geocode"
+)
+
+// fakeGeocoder resolves a fixed set of city names
+type fakeGeocoder map[string]geocode.Point
+
+func (g fakeGeocoder) Geocode(_ context.Context, location, _ string) (geocode.Point, error) {
+	if p, ok := g[strings.ToLower(location)]; ok {
+		return p, nil
+	}
+	return geocode.Point{}, geocode.ErrNotFound
+}
+
+var stubGeocoder = fakeGeocoder{
+	"berlin":  {Lat: 52.52, Lon: 13.405},
+	"potsdam": {Lat: 52.3906, Lon: 13.0645},
+	"prague":  {Lat: 50.0755, Lon: 14.4378},
+	"lisbon":  {Lat: 38.7223, Lon: -9.1393},
+}
+
+// waitForCoordinates polls until background geocoding has stored the event's
+// coordinates (or, with want false, until the deadline confirms there are none)
+func waitForCoordinates(t *testing.T, id uint, want bool) {
+	t.Helper()
+	deadline := time.Now().Add(3 * time.Second)
+	for {
+		resp := doAuthGet(fmt.Sprintf("/api/v0/me/events/%d", id), adminToken)
+		var event struct {
+			Latitude  *float64 `json:"latitude"`
+			Longitude *float64 `json:"longitude"`
+		}
+		if err := parseJSON(resp, &event); err != nil {
+			t.Fatalf("failed to parse response: %v", err)
+		}
+		has := event.Latitude != nil && event.Longitude != nil
+		if has == want && (has || time.Now().After(deadline)) {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("event %d: expected coordinates present=%v", id, want)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+func createGeoEvent(t *testing.T, slug, location string, online bool) *EventResponse {
+	t.Helper()
+	now := time.Now().UTC()
+	start := now.AddDate(0, 2, 0)
+	event := createTestEvent(adminToken, EventInput{
+		Name:       "Geo " + location,
+		Slug:       fmt.Sprintf("%s-%d", slug, now.UnixNano()),
+		Location:   location,
+		Country:    "DE",
+		IsOnline:   online,
+		StartDate:  start.Format(time.RFC3339),
+		EndDate:    start.AddDate(0, 0, 1).Format(time.RFC3339),
+		Tags:       "geotest",
+		CFPOpenAt:  now.AddDate(0, 0, -1).Format(time.RFC3339),
+		CFPCloseAt: now.AddDate(0, 1, 0).Format(time.RFC3339),
+	})
+	updateCFPStatus(adminToken, event.ID, "open")
+	return event
+}
+
+func TestListEvents_Near(t *testing.T) {
+	potsdam := createGeoEvent(t, "geo-potsdam", "Potsdam", false)
+	berlin := createGeoEvent(t, "geo-berlin", "Berlin", false)
+	prague := createGeoEvent(t, "geo-prague", "Prague", false)
+	lisbon := createGeoEvent(t, "geo-lisbon", "Lisbon", false)
+	unknown := createGeoEvent(t, "geo-unknown", "Atlantis", false)
+	online := createGeoEvent(t, "geo-online", "Berlin", true)
+
+	for _, e := range []*EventResponse{potsdam, berlin, prague, lisbon} {
+		waitForCoordinates(t, e.ID, true)
+	}
+	waitForCoordinates(t, unknown.ID, false)
+	waitForCoordinates(t, online.ID, false)
+
+	listIDs := func(t *testing.T, query string) []uint {
+		t.Helper()
+		resp := doGet("/api/v0/events?tag=geotest&" + query)
+		assertStatus(t, resp, http.StatusOK)
+		var result EventListResponse
+		if err := parseJSON(resp, &result); err != nil {
+			t.Fatalf("failed to parse response: %v", err)
+		}
+		ids := make([]uint, len(result.Data))
+		for i, e := range result.Data {
+			ids[i] = e.ID
+		}
+		return ids
+	}
+
+	t.Run("within 500 km, nearest first", func(t *testing.T) {
+		ids := listIDs(t, "near=52.52,13.405&radius_km=500")
+		want := []uint{berlin.ID, potsdam.ID, prague.ID}
+		if fmt.Sprint(ids) != fmt.Sprint(want) {
+			t.Errorf("expected %v, got %v", want, ids)
+		}
+	})
+
+	t.Run("default radius", func(t *testing.T) {
+		ids := listIDs(t, "near=52.52,13.405")
+		want := []uint{berlin.ID, potsdam.ID}
+		if fmt.Sprint(ids) != fmt.Sprint(want) {
+			t.Errorf("expected %v, got %v", want, ids)
+		}
+	})
+
+	t.Run("explicit sort wins over distance", func(t *testing.T) {
+		ids := listIDs(t, "near=52.52,13.405&radius_km=500&sort=created_at&order=desc")
+		want := []uint{prague.ID, berlin.ID, potsdam.ID}
+		if fmt.Sprint(ids) != fmt.Sprint(want) {
+			t.Errorf("expected %v, got %v", want, ids)
+		}
+	})
+
+	t.Run("invalid near", func(t *testing.T) {
+		resp := doGet("/api/v0/events?near=berlin")
+		assertStatus(t, resp, http.StatusBadRequest)
+		resp.Body.Close()
+	})
+}
+
+func TestUpdateEvent_RegeocodesOnLocationChange(t *testing.T) {
+	event := createGeoEvent(t, "geo-moving", "Berlin", false)
+	waitForCoordinates(t, event.ID, true)
+
+	resp := doPut(fmt.Sprintf("/api/v0/events/%d", event.ID), map[string]interface{}{"location": "Lisbon"}, adminToken)
+	assertStatus(t, resp, http.StatusOK)
+	resp.Body.Close()
+	waitForCoordinates(t, event.ID, true)
+
+	// Now only found near Lisbon
+	resp = doGet("/api/v0/events?tag=geotest&near=38.72,-9.14&radius_km=50")
+	assertStatus(t, resp, http.StatusOK)
+	var result EventListResponse
+	if err := parseJSON(resp, &result); err != nil {
+		t.Fatalf("failed to parse response: %v", err)
+	}
+	found := false
+	for _, e := range result.Data {
+		if e.ID == event.ID {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected moved event %d near Lisbon", event.ID)
+	}
+
+	// An unresolvable location clears the coordinates
+	resp = doPut(fmt.Sprintf("/api/v0/events/%d", event.ID), map[string]interface{}{"location": "Atlantis"}, adminToken)
+	assertStatus(t, resp, http.StatusOK)
+	resp.Body.Close()
+	waitForCoordinates(t, event.ID, false)
+}
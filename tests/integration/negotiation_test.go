@@ -0,0 +1,136 @@
+package integration
+
+import (
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// doAcceptGet makes a GET request asking for a specific representation
+func doAcceptGet(t *testing.T, path, token, accept string) *http.Response {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodGet, testServer.URL+path, nil)
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+	req.Header.Set("Accept", accept)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	return resp
+}
+
+func TestNegotiation_YAML(t *testing.T) {
+	tests := []struct {
+		name  string
+		path  string
+		token string
+		key   string
+	}{
+		{"events list", "/api/v0/events", "", "pagination"},
+		{"event by slug", "/api/v0/e/" + eventGopherCon.Slug, "", "slug"},
+		{"event by ID", fmt.Sprintf("/api/v0/events/%d", eventGopherCon.ID), "", "slug"},
+		{"proposal", fmt.Sprintf("/api/v0/proposals/%d", proposalGoPerf.ID), speakerToken, "title"},
+		{"my events", "/api/v0/me/events", adminToken, "managing"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := doAcceptGet(t, tt.path, tt.token, "application/yaml")
+			assertStatus(t, resp, http.StatusOK)
+			if ct := resp.Header.Get("Content-Type"); ct != "application/yaml" {
+				t.Errorf("expected Content-Type application/yaml, got %q", ct)
+			}
+
+			var body map[string]interface{}
+			if err := yaml.Unmarshal([]byte(readBody(resp)), &body); err != nil {
+				t.Fatalf("response is not valid YAML: %v", err)
+			}
+			if _, ok := body[tt.key]; !ok {
+				t.Errorf("expected key %q in YAML response", tt.key)
+			}
+		})
+	}
+}
+
+func TestNegotiation_YAMLList(t *testing.T) {
+	resp := doAcceptGet(t, fmt.Sprintf("/api/v0/events/%d/proposals", eventGopherCon.ID), adminToken, "application/yaml")
+	assertStatus(t, resp, http.StatusOK)
+
+	var proposals []map[string]interface{}
+	if err := yaml.Unmarshal([]byte(readBody(resp)), &proposals); err != nil {
+		t.Fatalf("response is not valid YAML: %v", err)
+	}
+	if len(proposals) == 0 {
+		t.Fatal("expected proposals in YAML response")
+	}
+	if _, ok := proposals[0]["speakers"]; !ok {
+		t.Error("expected speakers in YAML proposal")
+	}
+}
+
+func TestNegotiation_CSV(t *testing.T) {
+	tests := []struct {
+		name   string
+		path   string
+		token  string
+		header string
+	}{
+		{"events list", "/api/v0/events", "", "cfp_close_at"},
+		{"event proposals", fmt.Sprintf("/api/v0/events/%d/proposals", eventGopherCon.ID), adminToken, "speakers"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := doAcceptGet(t, tt.path, tt.token, "text/csv")
+			assertStatus(t, resp, http.StatusOK)
+			if ct := resp.Header.Get("Content-Type"); !strings.HasPrefix(ct, "text/csv") {
+				t.Errorf("expected Content-Type text/csv, got %q", ct)
+			}
+
+			records, err := csv.NewReader(strings.NewReader(readBody(resp))).ReadAll()
+			if err != nil {
+				t.Fatalf("response is not valid CSV: %v", err)
+			}
+			if len(records) < 2 {
+				t.Fatalf("expected header and rows, got %d records", len(records))
+			}
+			found := false
+			for _, h := range records[0] {
+				if h == tt.header {
+					found = true
+				}
+			}
+			if !found {
+				t.Errorf("expected column %q in header %v", tt.header, records[0])
+			}
+		})
+	}
+}
+
+func TestNegotiation_CSVNotSupportedFallsBackToJSON(t *testing.T) {
+	resp := doAcceptGet(t, "/api/v0/e/"+eventGopherCon.Slug, "", "text/csv")
+	assertStatus(t, resp, http.StatusOK)
+	if ct := resp.Header.Get("Content-Type"); ct != "application/json" {
+		t.Errorf("expected Content-Type application/json, got %q", ct)
+	}
+	resp.Body.Close()
+}
+
+func TestNegotiation_UnknownTypeFallsBackToJSON(t *testing.T) {
+	t.Parallel()
+	resp := doAcceptGet(t, "/api/v0/events", "", "application/xml")
+	assertStatus(t, resp, http.StatusOK)
+	if ct := resp.Header.Get("Content-Type"); ct != "application/json" {
+		t.Errorf("expected Content-Type application/json, got %q", ct)
+	}
+	resp.Body.Close()
+}
@@ -0,0 +1,157 @@
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/sreday/cfp.ninja/pkg/models"
+)
+
+// createClosedEvent creates a listed event whose CFP is closed
+func createClosedEvent(t *testing.T, suffix string) *EventResponse {
+	t.Helper()
+	now := time.Now()
+	event := createTestEvent(adminToken, EventInput{
+		Name:       "Notify Me " + suffix,
+		Slug:       "notify-me-" + suffix + "-" + fmt.Sprintf("%d", now.UnixNano()),
+		StartDate:  now.AddDate(0, 2, 0).Format(time.RFC3339),
+		EndDate:    now.AddDate(0, 2, 1).Format(time.RFC3339),
+		CFPOpenAt:  now.AddDate(0, 0, -1).Format(time.RFC3339),
+		CFPCloseAt: now.AddDate(0, 1, 0).Format(time.RFC3339),
+	})
+	updateCFPStatus(adminToken, event.ID, "closed")
+	return event
+}
+
+// getCFPInterest returns the user's notify-me registration, or nil
+func getCFPInterest(eventID uint, user *models.User) *models.CFPInterest {
+	var interest models.CFPInterest
+	if err := testConfig.DB.Where("event_id = ? AND user_id = ?", eventID, user.ID).First(&interest).Error; err != nil {
+		return nil
+	}
+	return &interest
+}
+
+func TestNotifyMe(t *testing.T) {
+	t.Run("register, repeat and organizer count", func(t *testing.T) {
+		event := createClosedEvent(t, "register")
+		path := "/api/v0/e/" + event.Slug + "/notify-me"
+
+		resp := doPost(path, nil, speakerToken)
+		assertStatus(t, resp, http.StatusCreated)
+		if got := resp.Header.Get("Location"); got != path {
+			t.Errorf("Location = %q, want %q", got, path)
+		}
+		resp.Body.Close()
+
+		resp = doPost(path, nil, speakerToken)
+		assertStatus(t, resp, http.StatusOK)
+		resp.Body.Close()
+
+		resp = doAuthGet(fmt.Sprintf("/api/v0/me/events/%d", event.ID), adminToken)
+		assertStatus(t, resp, http.StatusOK)
+		var organizerView struct {
+			NotifyMeCount *int64 `json:"notify_me_count"`
+		}
+		if err := parseJSON(resp, &organizerView); err != nil {
+			t.Fatalf("failed to parse event: %v", err)
+		}
+		if organizerView.NotifyMeCount == nil || *organizerView.NotifyMeCount != 1 {
+			t.Errorf("expected notify_me_count 1, got %v", organizerView.NotifyMeCount)
+		}
+	})
+
+	t.Run("requires auth", func(t *testing.T) {
+		event := createClosedEvent(t, "unauth")
+		resp := doPost("/api/v0/e/"+event.Slug+"/notify-me", nil, "")
+		assertStatus(t, resp, http.StatusUnauthorized)
+		resp.Body.Close()
+	})
+
+	t.Run("open CFP is a conflict", func(t *testing.T) {
+		resp := doPost("/api/v0/e/"+eventGopherCon.Slug+"/notify-me", nil, speakerToken)
+		assertStatus(t, resp, http.StatusConflict)
+		resp.Body.Close()
+	})
+
+	t.Run("draft event is not found", func(t *testing.T) {
+		now := time.Now()
+		draft := createTestEvent(adminToken, EventInput{
+			Name:      "Notify Me Draft",
+			Slug:      "notify-me-draft-" + fmt.Sprintf("%d", now.UnixNano()),
+			StartDate: now.AddDate(0, 2, 0).Format(time.RFC3339),
+			EndDate:   now.AddDate(0, 2, 1).Format(time.RFC3339),
+		})
+		resp := doPost("/api/v0/e/"+draft.Slug+"/notify-me", nil, speakerToken)
+		assertStatus(t, resp, http.StatusNotFound)
+		resp.Body.Close()
+	})
+
+	t.Run("opening the CFP claims registrations once", func(t *testing.T) {
+		event := createClosedEvent(t, "open")
+		resp := doPost("/api/v0/e/"+event.Slug+"/notify-me", nil, speakerToken)
+		assertStatus(t, resp, http.StatusCreated)
+		resp.Body.Close()
+
+		updateCFPStatus(adminToken, event.ID, "open")
+
+		deadline := time.Now().Add(3 * time.Second)
+		var interest *models.CFPInterest
+		for {
+			interest = getCFPInterest(event.ID, userSpeaker)
+			if interest != nil && interest.NotifiedAt != nil {
+				break
+			}
+			if time.Now().After(deadline) {
+				t.Fatal("expected the registration to be notified after the CFP opened")
+			}
+			time.Sleep(50 * time.Millisecond)
+		}
+
+		// Closing and reopening does not notify again
+		notifiedAt := *interest.NotifiedAt
+		updateCFPStatus(adminToken, event.ID, "closed")
+		updateCFPStatus(adminToken, event.ID, "open")
+		time.Sleep(200 * time.Millisecond)
+		if again := getCFPInterest(event.ID, userSpeaker); again == nil || !again.NotifiedAt.Equal(notifiedAt) {
+			t.Errorf("expected notified_at to stay %v", notifiedAt)
+		}
+	})
+
+	t.Run("unsubscribe and unregister", func(t *testing.T) {
+		event := createClosedEvent(t, "unsubscribe")
+		path := "/api/v0/e/" + event.Slug + "/notify-me"
+
+		resp := doPost(path, nil, speakerToken)
+		assertStatus(t, resp, http.StatusCreated)
+		resp.Body.Close()
+		interest := getCFPInterest(event.ID, userSpeaker)
+		if interest == nil {
+			t.Fatal("expected a registration")
+		}
+
+		resp = doPost("/api/v0/notify-me/unsubscribe?token="+interest.UnsubscribeToken, nil, "")
+		assertStatus(t, resp, http.StatusOK)
+		resp.Body.Close()
+		if getCFPInterest(event.ID, userSpeaker) != nil {
+			t.Error("expected the registration to be removed")
+		}
+
+		// Unknown tokens succeed, so repeated clicks are harmless
+		resp = doPost("/api/v0/notify-me/unsubscribe", map[string]string{"token": interest.UnsubscribeToken}, "")
+		assertStatus(t, resp, http.StatusOK)
+		resp.Body.Close()
+
+		resp = doPost(path, nil, otherToken)
+		assertStatus(t, resp, http.StatusCreated)
+		resp.Body.Close()
+		resp = doDelete(path, otherToken)
+		assertStatus(t, resp, http.StatusOK)
+		resp.Body.Close()
+		resp = doDelete(path, otherToken)
+		assertStatus(t, resp, http.StatusNotFound)
+		resp.Body.Close()
+	})
+}
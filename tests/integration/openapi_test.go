@@ -0,0 +1,198 @@
+package integration
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// openAPISchema is the subset of an OpenAPI schema object the contract test needs
+type openAPISchema struct {
+	Ref        string                    `json:"$ref"`
+	Type       string                    `json:"type"`
+	Required   []string                  `json:"required"`
+	Properties map[string]*openAPISchema `json:"properties"`
+	Items      *openAPISchema            `json:"items"`
+}
+
+type openAPIParameter struct {
+	Name     string `json:"name"`
+	In       string `json:"in"`
+	Required bool   `json:"required"`
+}
+
+type openAPIOperation struct {
+	Parameters []openAPIParameter    `json:"parameters"`
+	Security   []map[string][]string `json:"security"`
+	Responses  map[string]struct {
+		Content map[string]struct {
+			Schema *openAPISchema `json:"schema"`
+		} `json:"content"`
+	} `json:"responses"`
+}
+
+type openAPIDocument struct {
+	Paths      map[string]map[string]*openAPIOperation `json:"paths"`
+	Components struct {
+		Schemas map[string]*openAPISchema `json:"schemas"`
+	} `json:"components"`
+}
+
+func fetchOpenAPIDocument(t *testing.T) *openAPIDocument {
+	t.Helper()
+	resp := doGet("/api/v0/openapi.json")
+	assertStatus(t, resp, http.StatusOK)
+
+	var doc openAPIDocument
+	if err := json.NewDecoder(resp.Body).Decode(&doc); err != nil {
+		t.Fatalf("failed to decode OpenAPI document: %v", err)
+	}
+	resp.Body.Close()
+	return &doc
+}
+
+// resolve follows a local $ref to its component schema
+func (d *openAPIDocument) resolve(t *testing.T, s *openAPISchema) *openAPISchema {
+	t.Helper()
+	if s == nil || s.Ref == "" {
+		return s
+	}
+	name := strings.TrimPrefix(s.Ref, "#/components/schemas/")
+	resolved, ok := d.Components.Schemas[name]
+	if !ok {
+		t.Fatalf("unresolved $ref %q", s.Ref)
+	}
+	return resolved
+}
+
+// checkDocumented reports every required property of the schema missing from value
+func (d *openAPIDocument) checkDocumented(t *testing.T, where string, s *openAPISchema, value interface{}) {
+	t.Helper()
+	s = d.resolve(t, s)
+	if s == nil || value == nil {
+		return
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for _, name := range s.Required {
+			if _, ok := v[name]; !ok {
+				t.Errorf("%s: documented field %q missing from response", where, name)
+			}
+		}
+		for name, prop := range s.Properties {
+			if child, ok := v[name]; ok {
+				d.checkDocumented(t, where+"."+name, prop, child)
+			}
+		}
+	case []interface{}:
+		for i, item := range v {
+			d.checkDocumented(t, fmt.Sprintf("%s[%d]", where, i), s.Items, item)
+		}
+	}
+}
+
+// openAPIPathParams substitutes path parameters with seeded fixtures
+func openAPIPathParams(path string) string {
+	id := eventGopherCon.ID
+	if strings.HasPrefix(path, "/api/v0/proposals/") {
+		id = proposalGoPerf.ID
+	}
+	return strings.NewReplacer(
+		"{slug}", eventGopherCon.Slug,
+		"{id}", fmt.Sprint(id),
+	).Replace(path)
+}
+
+// TestOpenAPI_DocumentedFieldsPresent calls every documented GET endpoint that returns
+// JSON and fails when a required field of the documented schema is missing from the
+// real response, so the spec cannot silently drift from the handlers.
+func TestOpenAPI_DocumentedFieldsPresent(t *testing.T) {
+	t.Parallel()
+	doc := fetchOpenAPIDocument(t)
+
+	paths := make([]string, 0, len(doc.Paths))
+	for path := range doc.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	var apiKey string
+	checked := 0
+	for _, path := range paths {
+		op, ok := doc.Paths[path]["get"]
+		if !ok {
+			continue
+		}
+		ok200, ok := op.Responses["200"]
+		if !ok {
+			continue
+		}
+		media, ok := ok200.Content["application/json"]
+		if !ok || media.Schema == nil {
+			continue
+		}
+		skip := false
+		for _, p := range op.Parameters {
+			// Endpoints with required query parameters reach external services
+			if p.In == "query" && p.Required {
+				skip = true
+			}
+		}
+		if skip {
+			continue
+		}
+
+		// The public API takes an API key instead of a login token
+		token := adminToken
+		for _, s := range op.Security {
+			if _, ok := s["apiKeyAuth"]; ok {
+				if apiKey == "" {
+					apiKey = createTestAPIKey(t, "OpenAPI contract test")
+				}
+				token = apiKey
+			}
+		}
+
+		t.Run(path, func(t *testing.T) {
+			resp := doAuthGet(openAPIPathParams(path), token)
+			assertStatus(t, resp, http.StatusOK)
+
+			var body interface{}
+			if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			resp.Body.Close()
+
+			doc.checkDocumented(t, "response", media.Schema, body)
+		})
+		checked++
+	}
+
+	if checked == 0 {
+		t.Fatal("expected at least one documented GET endpoint to be checked")
+	}
+}
+
+func TestOpenAPI_DocsPage(t *testing.T) {
+	t.Parallel()
+	resp := doGet("/api/v0/docs")
+	assertStatus(t, resp, http.StatusOK)
+	if ct := resp.Header.Get("Content-Type"); !strings.HasPrefix(ct, "text/html") {
+		t.Errorf("expected text/html, got %q", ct)
+	}
+	body := readBody(resp)
+	// Relative references keep working under BASE_PATH
+	if !strings.Contains(body, `src="docs/docs.js"`) {
+		t.Error("expected docs page to load the Swagger UI init script")
+	}
+
+	resp = doGet("/api/v0/docs/docs.js")
+	assertStatus(t, resp, http.StatusOK)
+	if body := readBody(resp); !strings.Contains(body, `url: "openapi.json"`) {
+		t.Error("expected init script to reference the OpenAPI document")
+	}
+}
@@ -17,6 +17,7 @@ func futureDate(days int) string {
 // TestPaymentGateCFPStatus tests that the CFP status cannot be set to "open"
 // when an event listing fee is configured and the event is unpaid.
 func TestPaymentGateCFPStatus(t *testing.T) {
+	t.Parallel()
 	if testConfig.EventListingFee == 0 {
 		t.Skip("EVENT_LISTING_FEE not configured - skipping payment gate tests")
 	}
@@ -57,6 +58,7 @@ func TestPaymentGateCFPStatus(t *testing.T) {
 // TestPaymentGateCreateEventOpen tests that creating an event with status "open"
 // is blocked when listing fee is configured.
 func TestPaymentGateCreateEventOpen(t *testing.T) {
+	t.Parallel()
 	if testConfig.EventListingFee == 0 {
 		t.Skip("EVENT_LISTING_FEE not configured - skipping payment gate tests")
 	}
@@ -75,6 +77,7 @@ func TestPaymentGateCreateEventOpen(t *testing.T) {
 // TestEventCheckoutEndpoint tests the event checkout endpoint.
 // Without real Stripe keys, we expect the checkout to fail gracefully.
 func TestEventCheckoutEndpoint(t *testing.T) {
+	t.Parallel()
 	// Create a draft event
 	event := createTestEvent(adminToken, EventInput{
 		Name:      "Checkout Test Event",
@@ -125,6 +128,7 @@ func TestEventCheckoutEndpoint(t *testing.T) {
 
 // TestProposalCheckoutEndpoint tests the proposal checkout endpoint.
 func TestProposalCheckoutEndpoint(t *testing.T) {
+	t.Parallel()
 	// Create event with CFP requiring payment
 	event := createTestEvent(adminToken, EventInput{
 		Name:       "Proposal Checkout Test",
@@ -213,6 +217,7 @@ func TestProposalCheckoutEndpoint(t *testing.T) {
 // TestCFPRequiresPaymentToggle tests that toggling cfp_requires_payment
 // auto-populates fee fields from server config.
 func TestCFPRequiresPaymentToggle(t *testing.T) {
+	t.Parallel()
 	event := createTestEvent(adminToken, EventInput{
 		Name:      "Payment Toggle Test",
 		Slug:      "payment-toggle-test",
@@ -258,6 +263,7 @@ func TestCFPRequiresPaymentToggle(t *testing.T) {
 // TestPublicEventSanitization tests that public endpoints show cfp_requires_payment
 // but hide internal payment fields.
 func TestPublicEventSanitization(t *testing.T) {
+	t.Parallel()
 	// Create event and mark as paid with payment requirement
 	event := createTestEvent(adminToken, EventInput{
 		Name:       "Sanitization Test",
@@ -309,6 +315,7 @@ func TestPublicEventSanitization(t *testing.T) {
 
 // TestConfigEndpointPaymentFields tests that the config endpoint includes payment fields.
 func TestConfigEndpointPaymentFields(t *testing.T) {
+	t.Parallel()
 	resp := doGet("/api/v0/config")
 	assertStatus(t, resp, http.StatusOK)
 
@@ -333,6 +340,7 @@ func TestConfigEndpointPaymentFields(t *testing.T) {
 
 // TestWebhookEndpoint tests the Stripe webhook endpoint.
 func TestWebhookEndpoint(t *testing.T) {
+	t.Parallel()
 	t.Run("rejects when webhook secret not configured", func(t *testing.T) {
 		if testConfig.StripeWebhookSecret != "" {
 			t.Skip("webhook secret is configured")
@@ -357,3 +365,71 @@ func TestWebhookEndpoint(t *testing.T) {
 		assertStatus(t, resp, http.StatusMethodNotAllowed)
 	})
 }
+
+// TestAcceptListingTerms tests that the creator records acceptance of the
+// current listing terms, which the listing checkout requires.
+func TestAcceptListingTerms(t *testing.T) {
+	event := createTestEvent(adminToken, EventInput{
+		Name:      "Listing Terms Test",
+		Slug:      fmt.Sprintf("listing-terms-%d", time.Now().UnixNano()),
+		StartDate: futureDate(30),
+		EndDate:   futureDate(31),
+	})
+	path := fmt.Sprintf("/api/v0/events/%d/accept-terms", event.ID)
+	current := map[string]string{"version": testConfig.ListingTermsVersion}
+
+	if testConfig.EventListingFee > 0 {
+		t.Run("checkout requires acceptance", func(t *testing.T) {
+			resp := doPost(fmt.Sprintf("/api/v0/events/%d/checkout", event.ID), nil, adminToken)
+			defer resp.Body.Close()
+			assertStatus(t, resp, http.StatusConflict)
+		})
+	}
+
+	t.Run("creator only", func(t *testing.T) {
+		resp := doPost(path, current, otherToken)
+		defer resp.Body.Close()
+		assertStatus(t, resp, http.StatusForbidden)
+	})
+
+	t.Run("outdated version", func(t *testing.T) {
+		resp := doPost(path, map[string]string{"version": "outdated-" + testConfig.ListingTermsVersion}, adminToken)
+		defer resp.Body.Close()
+		assertStatus(t, resp, http.StatusConflict)
+	})
+
+	t.Run("records the accepted version", func(t *testing.T) {
+		resp := doPost(path, current, adminToken)
+		assertStatus(t, resp, http.StatusOK)
+		resp.Body.Close()
+
+		resp = doAuthGet(fmt.Sprintf("/api/v0/me/events/%d", event.ID), adminToken)
+		assertStatus(t, resp, http.StatusOK)
+		var organizerView struct {
+			ListingTermsVersion    string     `json:"listing_terms_version"`
+			ListingTermsAcceptedAt *time.Time `json:"listing_terms_accepted_at"`
+		}
+		if err := parseJSON(resp, &organizerView); err != nil {
+			t.Fatalf("failed to parse response: %v", err)
+		}
+		if organizerView.ListingTermsVersion != testConfig.ListingTermsVersion || organizerView.ListingTermsAcceptedAt == nil {
+			t.Errorf("expected version %q to be accepted, got %+v", testConfig.ListingTermsVersion, organizerView)
+		}
+	})
+
+	t.Run("a new version needs a new acceptance", func(t *testing.T) {
+		version := testConfig.ListingTermsVersion
+		testConfig.ListingTermsVersion = version + "-next"
+		t.Cleanup(func() { testConfig.ListingTermsVersion = version })
+
+		resp := doPost(path, map[string]string{"version": version}, adminToken)
+		defer resp.Body.Close()
+		assertStatus(t, resp, http.StatusConflict)
+
+		var stored models.Event
+		testConfig.DB.First(&stored, event.ID)
+		if stored.ListingTermsVersion != version {
+			t.Errorf("expected the earlier acceptance to be kept, got %q", stored.ListingTermsVersion)
+		}
+	})
+}
@@ -0,0 +1,159 @@
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+// PreviewLinkResponse represents a draft event preview link
+type PreviewLinkResponse struct {
+	ID        uint   `json:"id"`
+	Token     string `json:"token"`
+	URL       string `json:"url"`
+	ExpiresAt string `json:"expires_at"`
+	CreatedAt string `json:"created_at"`
+	Expired   bool   `json:"expired"`
+}
+
+func createPreviewLink(t *testing.T, eventID uint, body interface{}) PreviewLinkResponse {
+	t.Helper()
+	resp := doPost(fmt.Sprintf("/api/v0/events/%d/preview-links", eventID), body, adminToken)
+	assertStatus(t, resp, http.StatusCreated)
+	var link PreviewLinkResponse
+	if err := parseJSON(resp, &link); err != nil {
+		t.Fatalf("failed to parse response: %v", err)
+	}
+	return link
+}
+
+func TestPreviewLinks(t *testing.T) {
+	now := time.Now()
+	event := createTestEvent(adminToken, EventInput{
+		Name:      "Preview Link Event",
+		Slug:      fmt.Sprintf("preview-link-%d", now.UnixNano()),
+		StartDate: now.AddDate(0, 2, 0).Format(time.RFC3339),
+		EndDate:   now.AddDate(0, 2, 1).Format(time.RFC3339),
+	})
+	other := createTestEvent(adminToken, EventInput{
+		Name:      "Other Preview Event",
+		Slug:      fmt.Sprintf("preview-other-%d", now.UnixNano()),
+		StartDate: now.AddDate(0, 2, 0).Format(time.RFC3339),
+		EndDate:   now.AddDate(0, 2, 1).Format(time.RFC3339),
+	})
+	resp := doPost(fmt.Sprintf("/api/v0/events/%d/organizers", event.ID), OrganizerInput{Email: "speaker@test.com"}, adminToken)
+	assertStatus(t, resp, http.StatusCreated)
+	resp.Body.Close()
+
+	linksPath := fmt.Sprintf("/api/v0/events/%d/preview-links", event.ID)
+	preview := func(slug, token string) *http.Response {
+		return doGet("/api/v0/e/" + slug + "?preview_token=" + url.QueryEscape(token))
+	}
+
+	link := createPreviewLink(t, event.ID, nil)
+	if link.Token == "" || !strings.Contains(link.URL, "preview_token=") {
+		t.Fatalf("expected token and preview URL, got %+v", link)
+	}
+
+	t.Run("creator only", func(t *testing.T) {
+		resp := doPost(linksPath, nil, speakerToken)
+		assertStatus(t, resp, http.StatusForbidden)
+		resp.Body.Close()
+
+		resp = doAuthGet(linksPath, speakerToken)
+		assertStatus(t, resp, http.StatusForbidden)
+		resp.Body.Close()
+
+		resp = doPost(linksPath, nil, "")
+		assertStatus(t, resp, http.StatusUnauthorized)
+		resp.Body.Close()
+	})
+
+	t.Run("invalid expiry", func(t *testing.T) {
+		resp := doPost(linksPath, map[string]int{"expires_in_days": 0}, adminToken)
+		assertStatus(t, resp, http.StatusBadRequest)
+		resp.Body.Close()
+	})
+
+	t.Run("token shows the draft", func(t *testing.T) {
+		resp := preview(event.Slug, link.Token)
+		assertStatus(t, resp, http.StatusOK)
+		var got EventResponse
+		if err := parseJSON(resp, &got); err != nil {
+			t.Fatalf("failed to parse response: %v", err)
+		}
+		if !got.Draft || got.ID != event.ID {
+			t.Errorf("expected draft event %d, got id %d draft %v", event.ID, got.ID, got.Draft)
+		}
+	})
+
+	t.Run("no organizer data", func(t *testing.T) {
+		resp := doGet("/api/v0/e/" + event.Slug + "?expand=organizers_public&preview_token=" + url.QueryEscape(link.Token))
+		assertStatus(t, resp, http.StatusOK)
+		body := readBody(resp)
+		if strings.Contains(body, "organizers_public") || strings.Contains(body, "speaker@test.com") {
+			t.Errorf("expected no organizer data in preview, got %s", body)
+		}
+
+		resp = doGet(fmt.Sprintf("/api/v0/events/%d/proposals?preview_token=%s", event.ID, url.QueryEscape(link.Token)))
+		assertStatus(t, resp, http.StatusUnauthorized)
+		resp.Body.Close()
+	})
+
+	t.Run("rejected tokens", func(t *testing.T) {
+		for name, tc := range map[string]struct{ slug, token string }{
+			"other event": {other.Slug, link.Token},
+			"tampered":    {event.Slug, link.Token + "0"},
+			"garbage":     {event.Slug, "not-a-token"},
+			"missing":     {event.Slug, ""},
+		} {
+			resp := preview(tc.slug, tc.token)
+			if resp.StatusCode != http.StatusNotFound {
+				t.Errorf("%s: expected 404, got %d", name, resp.StatusCode)
+			}
+			resp.Body.Close()
+		}
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		short := createPreviewLink(t, event.ID, map[string]int{"expires_in_days": 1})
+		freezeClock(t, now.Add(48*time.Hour))
+		resp := preview(event.Slug, short.Token)
+		assertStatus(t, resp, http.StatusNotFound)
+		resp.Body.Close()
+	})
+
+	t.Run("listed with creation date", func(t *testing.T) {
+		resp := doAuthGet(linksPath, adminToken)
+		assertStatus(t, resp, http.StatusOK)
+		var links []PreviewLinkResponse
+		if err := parseJSON(resp, &links); err != nil {
+			t.Fatalf("failed to parse response: %v", err)
+		}
+		if len(links) != 2 {
+			t.Fatalf("expected 2 links, got %d", len(links))
+		}
+		for _, l := range links {
+			if l.CreatedAt == "" || l.ExpiresAt == "" {
+				t.Errorf("expected created_at and expires_at, got %+v", l)
+			}
+		}
+	})
+
+	t.Run("revoked", func(t *testing.T) {
+		resp := doDelete(fmt.Sprintf("%s/%d", linksPath, link.ID), adminToken)
+		assertStatus(t, resp, http.StatusOK)
+		resp.Body.Close()
+
+		resp = preview(event.Slug, link.Token)
+		assertStatus(t, resp, http.StatusNotFound)
+		resp.Body.Close()
+
+		resp = doDelete(fmt.Sprintf("%s/%d", linksPath, link.ID), adminToken)
+		assertStatus(t, resp, http.StatusNotFound)
+		resp.Body.Close()
+	})
+}
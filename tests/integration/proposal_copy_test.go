@@ -0,0 +1,106 @@
+package integration
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"gorm.io/datatypes"
+
+	"github.com/sreday/cfp.ninja/pkg/models"
+)
+
+func copyProposalPath(proposalID, targetID uint) string {
+	return fmt.Sprintf("/api/v0/proposals/%d/copy?target_event_id=%d", proposalID, targetID)
+}
+
+func TestCopyProposal(t *testing.T) {
+	t.Parallel()
+	now := time.Now()
+	newEvent := func(name string) *EventResponse {
+		event := createTestEvent(adminToken, EventInput{
+			Name:       name,
+			Slug:       fmt.Sprintf("copy-%d", time.Now().UnixNano()),
+			StartDate:  now.AddDate(0, 2, 0).Format(time.RFC3339),
+			EndDate:    now.AddDate(0, 2, 1).Format(time.RFC3339),
+			CFPOpenAt:  now.AddDate(0, 0, -1).Format(time.RFC3339),
+			CFPCloseAt: now.AddDate(0, 1, 0).Format(time.RFC3339),
+		})
+		updateCFPStatus(adminToken, event.ID, "open")
+		return event
+	}
+	source := newEvent("Copy Source Conf")
+	target := newEvent("Copy Target Conf")
+	closed := createTestEvent(adminToken, EventInput{
+		Name:      "Copy Closed Conf",
+		Slug:      fmt.Sprintf("copy-closed-%d", now.UnixNano()),
+		StartDate: now.AddDate(0, 2, 0).Format(time.RFC3339),
+		EndDate:   now.AddDate(0, 2, 1).Format(time.RFC3339),
+	})
+
+	original := createTestProposal(speakerToken, source.ID, ProposalInput{
+		Title:    "Rejected Then Resubmitted",
+		Abstract: "A talk worth a second chance.",
+		Format:   "talk",
+		Duration: 30,
+		Level:    "intermediate",
+		Speakers: []Speaker{{Name: "Speaker User", Email: "speaker@test.com", Bio: "Bio", Company: "Acme", JobTitle: "Dev", LinkedIn: "https://linkedin.com/in/speaker", Primary: true}},
+	})
+	updateProposalStatus(adminToken, original.ID, "rejected")
+
+	questions, _ := json.Marshal([]models.CustomQuestion{{ID: "travel", Type: "text", Required: true}})
+	testConfig.DB.Model(&models.Event{}).Where("id = ?", target.ID).Update("cfp_questions", datatypes.JSON(questions))
+
+	t.Run("owner only", func(t *testing.T) {
+		resp := doPost(copyProposalPath(original.ID, target.ID), nil, otherToken)
+		defer resp.Body.Close()
+		assertStatus(t, resp, http.StatusForbidden)
+	})
+
+	t.Run("target CFP must be open", func(t *testing.T) {
+		resp := doPost(copyProposalPath(original.ID, closed.ID), nil, speakerToken)
+		defer resp.Body.Close()
+		assertStatus(t, resp, http.StatusBadRequest)
+	})
+
+	t.Run("lists unanswered required questions", func(t *testing.T) {
+		resp := doPost(copyProposalPath(original.ID, target.ID), nil, speakerToken)
+		assertStatus(t, resp, http.StatusBadRequest)
+		var body struct {
+			Code   string            `json:"code"`
+			Fields map[string]string `json:"fields"`
+		}
+		if err := parseJSON(resp, &body); err != nil {
+			t.Fatalf("failed to parse response: %v", err)
+		}
+		if body.Code != "validation" || body.Fields["custom_answers.travel"] == "" {
+			t.Errorf("expected a validation error for the travel question, got %+v", body)
+		}
+	})
+
+	t.Run("creates a fresh submitted copy", func(t *testing.T) {
+		resp := doPost(copyProposalPath(original.ID, target.ID), map[string]interface{}{
+			"custom_answers": map[string]string{"travel": "Flying from Lisbon"},
+		}, speakerToken)
+		assertStatus(t, resp, http.StatusCreated)
+		var copied struct {
+			ProposalResponse
+			CopiedFromID *uint `json:"copied_from_id"`
+		}
+		if err := parseJSON(resp, &copied); err != nil {
+			t.Fatalf("failed to parse response: %v", err)
+		}
+		if copied.EventID != target.ID || copied.Status != "submitted" || copied.Title != original.Title ||
+			copied.CopiedFromID == nil || *copied.CopiedFromID != original.ID {
+			t.Fatalf("unexpected copy: %+v", copied)
+		}
+
+		var stored models.Proposal
+		testConfig.DB.First(&stored, original.ID)
+		if stored.EventID != source.ID || stored.Status != models.ProposalStatusRejected {
+			t.Errorf("expected the original to be untouched, got %+v", stored)
+		}
+	})
+}
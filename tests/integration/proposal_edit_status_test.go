@@ -0,0 +1,144 @@
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/sreday/cfp.ninja/pkg/models"
+)
+
+func TestUpdateProposal_FieldsByStatus(t *testing.T) {
+	t.Parallel()
+	speaker := Speaker{Name: "Speaker User", Email: "speaker@test.com", Bio: "Bio", Company: "Acme Inc", JobTitle: "Engineer", LinkedIn: "https://linkedin.com/in/speaker", Primary: true}
+	edit := func(change func(s *map[string]interface{})) []map[string]interface{} {
+		s := map[string]interface{}{
+			"name": speaker.Name, "email": speaker.Email, "bio": speaker.Bio, "company": speaker.Company,
+			"job_title": speaker.JobTitle, "linkedin": speaker.LinkedIn, "primary": true,
+		}
+		change(&s)
+		return []map[string]interface{}{s}
+	}
+	speakerField := func(field, value string) (map[string]interface{}, func(p models.Proposal) string) {
+		return map[string]interface{}{"speakers": edit(func(s *map[string]interface{}) { (*s)[field] = value })},
+			func(p models.Proposal) string {
+				speakers, _ := p.GetSpeakers()
+				switch field {
+				case "name":
+					return speakers[0].Name
+				case "bio":
+					return speakers[0].Bio
+				case "job_title":
+					return speakers[0].JobTitle
+				case "company":
+					return speakers[0].Company
+				default:
+					return speakers[0].PhotoURL
+				}
+			}
+	}
+
+	type field struct {
+		name      string
+		update    map[string]interface{}
+		stored    func(p models.Proposal) string
+		want      string
+		logistics bool // still editable by the speaker once accepted
+	}
+	fields := []field{
+		{name: "title", update: map[string]interface{}{"title": "Edited Title"}, stored: func(p models.Proposal) string { return p.Title }, want: "Edited Title"},
+		{name: "abstract", update: map[string]interface{}{"abstract": "Edited abstract."}, stored: func(p models.Proposal) string { return p.Abstract }, want: "Edited abstract."},
+		{name: "format", update: map[string]interface{}{"format": "workshop"}, stored: func(p models.Proposal) string { return string(p.Format) }, want: "workshop"},
+		{name: "duration", update: map[string]interface{}{"duration": 45}, stored: func(p models.Proposal) string { return fmt.Sprint(p.Duration) }, want: "45"},
+		{name: "speaker_notes", update: map[string]interface{}{"speaker_notes": "Arriving late"}, stored: func(p models.Proposal) string { return p.SpeakerNotes }, want: "Arriving late", logistics: true},
+		{name: "slides_url", update: map[string]interface{}{"slides_url": "https://example.com/slides"}, stored: func(p models.Proposal) string { return p.SlidesURL }, want: "https://example.com/slides", logistics: true},
+		{name: "video_url", update: map[string]interface{}{"video_url": "https://example.com/video"}, stored: func(p models.Proposal) string { return p.VideoURL }, want: "https://example.com/video", logistics: true},
+	}
+	for _, f := range []struct{ name, value string }{
+		{"name", "Someone Else"},
+		{"bio", "Typo fixed"},
+		{"job_title", "Staff Engineer"},
+		{"company", "Acme Corp"},
+		{"photo_url", "https://example.com/me.jpg"},
+	} {
+		update, stored := speakerField(f.name, f.value)
+		fields = append(fields, field{name: "speaker " + f.name, update: update, stored: stored, want: f.value, logistics: f.name != "name"})
+	}
+
+	now := time.Now()
+	proposalIn := func(t *testing.T, status string) uint {
+		t.Helper()
+		event := createTestEvent(adminToken, EventInput{
+			Name:       "Edit By Status Conf",
+			Slug:       fmt.Sprintf("edit-by-status-%d", time.Now().UnixNano()),
+			StartDate:  now.AddDate(0, 2, 0).Format(time.RFC3339),
+			EndDate:    now.AddDate(0, 2, 1).Format(time.RFC3339),
+			CFPOpenAt:  now.AddDate(0, 0, -1).Format(time.RFC3339),
+			CFPCloseAt: now.AddDate(0, 1, 0).Format(time.RFC3339),
+		})
+		updateCFPStatus(adminToken, event.ID, "open")
+		proposal := createTestProposal(speakerToken, event.ID, ProposalInput{
+			Title: "Original Title", Abstract: "Original abstract.", Format: "talk", Duration: 30, Level: "intermediate",
+			Speakers: []Speaker{speaker},
+		})
+		if status != "submitted" {
+			updateProposalStatus(adminToken, proposal.ID, status)
+		}
+		return proposal.ID
+	}
+
+	for _, status := range []string{"submitted", "accepted", "tentative", "rejected"} {
+		id := proposalIn(t, status)
+		for _, f := range fields {
+			t.Run(status+"/"+f.name, func(t *testing.T) {
+				var before models.Proposal
+				testConfig.DB.First(&before, id)
+
+				resp := doPut(fmt.Sprintf("/api/v0/proposals/%d", id), f.update, speakerToken)
+				defer resp.Body.Close()
+				var after models.Proposal
+				testConfig.DB.First(&after, id)
+
+				switch {
+				case status == "submitted" || (status == "accepted" && f.logistics):
+					assertStatus(t, resp, http.StatusOK)
+					if got := f.stored(after); got != f.want {
+						t.Errorf("expected %s to become %q, got %q", f.name, f.want, got)
+					}
+				case status == "accepted":
+					assertStatus(t, resp, http.StatusOK)
+					if got := f.stored(after); got != f.stored(before) {
+						t.Errorf("expected %s to stay locked, got %q", f.name, got)
+					}
+				default:
+					assertStatus(t, resp, http.StatusBadRequest)
+				}
+			})
+		}
+	}
+
+	t.Run("organizers edit accepted proposals in full", func(t *testing.T) {
+		id := proposalIn(t, "accepted")
+		for _, f := range fields {
+			resp := doPut(fmt.Sprintf("/api/v0/proposals/%d", id), f.update, adminToken)
+			resp.Body.Close()
+			assertStatus(t, resp, http.StatusOK)
+			var after models.Proposal
+			testConfig.DB.First(&after, id)
+			if got := f.stored(after); got != f.want {
+				t.Errorf("expected %s to become %q, got %q", f.name, f.want, got)
+			}
+		}
+	})
+
+	t.Run("accepted speakers can't add speakers", func(t *testing.T) {
+		id := proposalIn(t, "accepted")
+		cospeaker := map[string]interface{}{"name": "Co Speaker", "email": "co@example.com", "company": "Acme", "job_title": "SRE", "linkedin": "https://linkedin.com/in/co"}
+		speakers := append(edit(func(*map[string]interface{}) {}), cospeaker)
+		fields := validationFields(t, doPut(fmt.Sprintf("/api/v0/proposals/%d", id), map[string]interface{}{"speakers": speakers}, speakerToken))
+		if fields["speakers"] == "" {
+			t.Errorf("expected a speakers error, got %v", fields)
+		}
+	})
+}
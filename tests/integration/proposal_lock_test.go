@@ -0,0 +1,160 @@
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sreday/cfp.ninja/pkg/models"
+)
+
+func TestProposalLock(t *testing.T) {
+	t.Parallel()
+	now := time.Now().UTC()
+	event := createTestEvent(adminToken, EventInput{
+		Name:       "Proposal Lock Conf",
+		Slug:       fmt.Sprintf("proposal-lock-%d", now.UnixNano()),
+		StartDate:  now.AddDate(0, 2, 0).Format(time.RFC3339),
+		EndDate:    now.AddDate(0, 2, 1).Format(time.RFC3339),
+		CFPOpenAt:  now.AddDate(0, 0, -1).Format(time.RFC3339),
+		CFPCloseAt: now.AddDate(0, 1, 0).Format(time.RFC3339),
+	})
+	updateCFPStatus(adminToken, event.ID, "open")
+	resp := doPost(fmt.Sprintf("/api/v0/events/%d/organizers", event.ID), OrganizerInput{Email: "other@test.com"}, adminToken)
+	resp.Body.Close()
+
+	proposal := createTestProposal(speakerToken, event.ID, ProposalInput{
+		Title:    "Locking Without Tears",
+		Abstract: "Advisory locks for people, not databases.",
+		Format:   "talk",
+		Duration: 30,
+		Level:    "intermediate",
+		Speakers: []Speaker{
+			{Name: "Speaker User", Email: "speaker@test.com", Company: "Acme Inc", JobTitle: "Engineer", LinkedIn: "https://linkedin.com/in/speaker", Primary: true},
+		},
+	})
+	proposalPath := fmt.Sprintf("/api/v0/proposals/%d", proposal.ID)
+
+	type lockResponse struct {
+		UserID    uint      `json:"user_id"`
+		Name      string    `json:"name"`
+		ExpiresAt time.Time `json:"expires_at"`
+	}
+	lock := func(t *testing.T, token string, status int) lockResponse {
+		t.Helper()
+		resp := doPost(proposalPath+"/lock", nil, token)
+		assertStatus(t, resp, status)
+		var got lockResponse
+		if status != http.StatusOK {
+			resp.Body.Close()
+			return got
+		}
+		if err := parseJSON(resp, &got); err != nil {
+			t.Fatalf("failed to parse response: %v", err)
+		}
+		return got
+	}
+	holder := func(t *testing.T, token string) *lockResponse {
+		t.Helper()
+		resp := doAuthGet(proposalPath, token)
+		assertStatus(t, resp, http.StatusOK)
+		var got struct {
+			Lock *lockResponse `json:"lock"`
+		}
+		if err := parseJSON(resp, &got); err != nil {
+			t.Fatalf("failed to parse response: %v", err)
+		}
+		return got.Lock
+	}
+
+	t.Run("unlocked proposals have no holder", func(t *testing.T) {
+		if got := holder(t, adminToken); got != nil {
+			t.Errorf("expected no lock, got %+v", got)
+		}
+	})
+
+	t.Run("organizer locks the proposal", func(t *testing.T) {
+		got := lock(t, adminToken, http.StatusOK)
+		if got.UserID != userAdmin.ID || got.Name != "Admin User" {
+			t.Errorf("expected the admin to hold the lock, got %+v", got)
+		}
+		if d := time.Until(got.ExpiresAt); d <= 4*time.Minute || d > models.ProposalLockDuration {
+			t.Errorf("expected the lock to expire in about five minutes, got %v", d)
+		}
+		if h := holder(t, speakerToken); h == nil || h.Name != "Admin User" {
+			t.Errorf("expected the speaker to see the admin's lock, got %+v", h)
+		}
+	})
+
+	t.Run("holder renews the lock", func(t *testing.T) {
+		testConfig.DB.Model(&models.ProposalLock{}).Where("proposal_id = ?", proposal.ID).
+			Update("expires_at", time.Now().Add(time.Minute))
+		if got := lock(t, adminToken, http.StatusOK); time.Until(got.ExpiresAt) <= 4*time.Minute {
+			t.Errorf("expected the lock to be renewed, expires at %v", got.ExpiresAt)
+		}
+	})
+
+	t.Run("others can't take the lock", func(t *testing.T) {
+		lock(t, otherToken, http.StatusLocked)
+		lock(t, speakerToken, http.StatusLocked)
+	})
+
+	t.Run("others' updates are refused with the holder's name", func(t *testing.T) {
+		resp := doPut(proposalPath, map[string]interface{}{"title": "Speaker Edit"}, speakerToken)
+		assertStatus(t, resp, http.StatusLocked)
+		body := readBody(resp)
+		if !strings.Contains(body, "Admin User") || !strings.Contains(body, `"locked"`) {
+			t.Errorf("expected a locked error naming the holder, got %s", body)
+		}
+
+		resp = doPut(proposalPath, map[string]interface{}{"organizer_notes": "Mine"}, otherToken)
+		assertStatus(t, resp, http.StatusLocked)
+		resp.Body.Close()
+	})
+
+	t.Run("holder updates as usual", func(t *testing.T) {
+		resp := doPut(proposalPath, map[string]interface{}{"title": "Locking Without Fears"}, adminToken)
+		assertStatus(t, resp, http.StatusOK)
+		resp.Body.Close()
+	})
+
+	t.Run("force overrides the lock", func(t *testing.T) {
+		resp := doPut(proposalPath+"?force=true", map[string]interface{}{"title": "Speaker Edit"}, speakerToken)
+		assertStatus(t, resp, http.StatusOK)
+		resp.Body.Close()
+	})
+
+	t.Run("expired locks are ignored and taken over", func(t *testing.T) {
+		testConfig.DB.Model(&models.ProposalLock{}).Where("proposal_id = ?", proposal.ID).
+			Update("expires_at", time.Now().Add(-time.Second))
+		if got := holder(t, adminToken); got != nil {
+			t.Errorf("expected the expired lock to be ignored, got %+v", got)
+		}
+		if got := lock(t, otherToken, http.StatusOK); got.UserID != userOther.ID {
+			t.Errorf("expected the other organizer to take the lock, got %+v", got)
+		}
+	})
+
+	t.Run("only the holder releases the lock", func(t *testing.T) {
+		resp := doDelete(proposalPath+"/lock", adminToken)
+		assertStatus(t, resp, http.StatusOK)
+		resp.Body.Close()
+		if got := holder(t, adminToken); got == nil || got.UserID != userOther.ID {
+			t.Errorf("expected the other organizer to keep the lock, got %+v", got)
+		}
+
+		resp = doDelete(proposalPath+"/lock", otherToken)
+		assertStatus(t, resp, http.StatusOK)
+		resp.Body.Close()
+		if got := holder(t, adminToken); got != nil {
+			t.Errorf("expected the lock to be released, got %+v", got)
+		}
+	})
+
+	t.Run("strangers can't lock", func(t *testing.T) {
+		_, token := createTestUserWithJWT(fmt.Sprintf("lock-stranger-%d@test.com", now.UnixNano()), "Stranger")
+		lock(t, token, http.StatusForbidden)
+	})
+}
@@ -1,53 +1,59 @@
 package integration
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
 	"testing"
 	"time"
 )
 
 func TestGetEventProposals(t *testing.T) {
+	t.Parallel()
+	f := newFixtures(t)
+	_, organizerToken := f.user("Organizer")
+	_, submitterToken := f.user("Speaker")
+	_, coSubmitterToken := f.user("Another Speaker")
+	event := f.event(organizerToken, EventInput{})
+	f.proposal(submitterToken, event.ID, ProposalInput{})
+	f.proposal(submitterToken, event.ID, ProposalInput{})
+	f.proposal(coSubmitterToken, event.ID, ProposalInput{})
+
 	tests := []struct {
-		name          string
-		eventID       uint
+		name         string
 		token        string
-		expectedCode  int
-		expectAtLeast int
+		expectedCode int
+		expected     int
 	}{
 		{
-			name:          "organizer sees all proposals",
-			eventID:       eventGopherCon.ID,
-			token:        adminToken,
-			expectedCode:  http.StatusOK,
-			expectAtLeast: 2, // Created 2 proposals in fixtures
+			name:         "organizer sees all proposals",
+			token:        organizerToken,
+			expectedCode: http.StatusOK,
+			expected:     3,
 		},
 		{
 			name:         "speaker sees own proposals",
-			eventID:      eventGopherCon.ID,
-			token:       speakerToken,
+			token:        submitterToken,
 			expectedCode: http.StatusOK,
-			// Speaker created 2 proposals
-			expectAtLeast: 2,
+			expected:     2,
 		},
 		{
 			name:         "other user sees no proposals (not organizer, no submissions)",
-			eventID:      eventGopherCon.ID,
-			token:       otherToken,
+			token:        otherToken,
 			expectedCode: http.StatusOK,
-			// Other user has no proposals and is not organizer
 		},
 		{
 			name:         "unauthorized",
-			eventID:      eventGopherCon.ID,
-			token:       "",
+			token:        "",
 			expectedCode: http.StatusUnauthorized,
 		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			resp := doAuthGet(fmt.Sprintf("/api/v0/events/%d/proposals", tc.eventID), tc.token)
+			resp := doAuthGet(fmt.Sprintf("/api/v0/events/%d/proposals", event.ID), tc.token)
 			assertStatus(t, resp, tc.expectedCode)
 
 			if tc.expectedCode == http.StatusOK {
@@ -55,8 +61,8 @@ func TestGetEventProposals(t *testing.T) {
 				if err := parseJSON(resp, &proposals); err != nil {
 					t.Fatalf("failed to parse response: %v", err)
 				}
-				if tc.expectAtLeast > 0 && len(proposals) < tc.expectAtLeast {
-					t.Errorf("expected at least %d proposals, got %d", tc.expectAtLeast, len(proposals))
+				if len(proposals) != tc.expected {
+					t.Errorf("expected %d proposals, got %d", tc.expected, len(proposals))
 				}
 			}
 		})
@@ -307,8 +313,8 @@ func TestUpdateProposalStatusRestriction(t *testing.T) {
 		assertStatus(t, resp, http.StatusOK)
 	})
 
-	// Test: owner cannot update an accepted proposal
-	t.Run("owner cannot update accepted proposal", func(t *testing.T) {
+	// Test: owner cannot change the talk once accepted, only its logistics
+	t.Run("owner cannot retitle accepted proposal", func(t *testing.T) {
 		p := createTestProposal(speakerToken, eventGopherCon.ID, ProposalInput{
 			Title:    "Status Restriction Accepted",
 			Abstract: "Test abstract",
@@ -323,10 +329,16 @@ func TestUpdateProposalStatusRestriction(t *testing.T) {
 		assertStatus(t, resp, http.StatusOK)
 		resp.Body.Close()
 
-		// Owner tries to update — should be blocked
+		// Owner tries to update — the title and abstract are kept
 		resp = doPut(fmt.Sprintf("/api/v0/proposals/%d", p.ID), updateInput, speakerToken)
-		defer resp.Body.Close()
-		assertStatus(t, resp, http.StatusBadRequest)
+		assertStatus(t, resp, http.StatusOK)
+		var updated ProposalResponse
+		if err := parseJSON(resp, &updated); err != nil {
+			t.Fatalf("failed to parse response: %v", err)
+		}
+		if updated.Title != p.Title || updated.Abstract != p.Abstract {
+			t.Errorf("expected the talk to stay locked, got %q: %q", updated.Title, updated.Abstract)
+		}
 	})
 
 	// Test: owner cannot update a rejected proposal
@@ -663,6 +675,7 @@ func TestProposalStatusTransitions_InvalidStatus(t *testing.T) {
 // TestCreateProposal_MaxPerEventLimit verifies that the per-user per-event
 // proposal limit (MAX_PROPOSALS_PER_EVENT, default 3) is enforced.
 func TestCreateProposal_MaxPerEventLimit(t *testing.T) {
+	t.Parallel()
 	now := time.Now()
 
 	// Create a fresh event with open CFP so existing proposals don't interfere
@@ -714,3 +727,598 @@ func TestCreateProposal_MaxPerEventLimit(t *testing.T) {
 	defer resp.Body.Close()
 	assertStatus(t, resp, http.StatusBadRequest)
 }
+
+func TestReviewerNotes_PrivatePerOrganizer(t *testing.T) {
+	t.Parallel()
+	now := time.Now()
+	event := createTestEvent(adminToken, EventInput{
+		Name:       "Reviewer Notes Event",
+		Slug:       fmt.Sprintf("reviewer-notes-%d", now.UnixNano()),
+		StartDate:  now.AddDate(0, 2, 0).Format(time.RFC3339),
+		EndDate:    now.AddDate(0, 2, 1).Format(time.RFC3339),
+		CFPOpenAt:  now.AddDate(0, 0, -1).Format(time.RFC3339),
+		CFPCloseAt: now.AddDate(0, 1, 0).Format(time.RFC3339),
+	})
+	updateCFPStatus(adminToken, event.ID, "open")
+
+	resp := doPost(fmt.Sprintf("/api/v0/events/%d/organizers", event.ID), OrganizerInput{Email: "other@test.com"}, adminToken)
+	assertStatus(t, resp, http.StatusCreated)
+	resp.Body.Close()
+
+	proposal := createTestProposal(speakerToken, event.ID, ProposalInput{
+		Title:    "Reviewed Talk",
+		Abstract: "A talk with several reviewers.",
+		Format:   "talk",
+		Duration: 30,
+		Level:    "intermediate",
+		Speakers: []Speaker{
+			{Name: "Speaker User", Email: "speaker@test.com", Bio: "Bio", Company: "Acme", JobTitle: "Dev", LinkedIn: "https://linkedin.com/in/speaker"},
+		},
+	})
+	path := fmt.Sprintf("/api/v0/proposals/%d", proposal.ID)
+
+	update := func(t *testing.T, token string, body map[string]interface{}) ProposalResponse {
+		t.Helper()
+		resp := doPut(path, body, token)
+		assertStatus(t, resp, http.StatusOK)
+		var p ProposalResponse
+		if err := parseJSON(resp, &p); err != nil {
+			t.Fatalf("failed to parse response: %v", err)
+		}
+		return p
+	}
+	get := func(t *testing.T, token string) ProposalResponse {
+		t.Helper()
+		resp := doAuthGet(path, token)
+		assertStatus(t, resp, http.StatusOK)
+		var p ProposalResponse
+		if err := parseJSON(resp, &p); err != nil {
+			t.Fatalf("failed to parse response: %v", err)
+		}
+		return p
+	}
+
+	p := update(t, adminToken, map[string]interface{}{"organizer_notes": "Accept, strong fit", "reviewer_notes": "Admin: great demo"})
+	if p.ReviewerNotes != "Admin: great demo" || p.OrganizerNotes != "Accept, strong fit" {
+		t.Errorf("unexpected notes in update response: %+v", p)
+	}
+	update(t, otherToken, map[string]interface{}{"reviewer_notes": "Other: too long"})
+
+	t.Run("each organizer sees only their own notes", func(t *testing.T) {
+		if p := get(t, adminToken); p.ReviewerNotes != "Admin: great demo" || p.OrganizerNotes != "Accept, strong fit" {
+			t.Errorf("admin: unexpected notes %q / %q", p.ReviewerNotes, p.OrganizerNotes)
+		}
+		if p := get(t, otherToken); p.ReviewerNotes != "Other: too long" || p.OrganizerNotes != "Accept, strong fit" {
+			t.Errorf("other: unexpected notes %q / %q", p.ReviewerNotes, p.OrganizerNotes)
+		}
+
+		resp := doAuthGet(fmt.Sprintf("/api/v0/events/%d/proposals", event.ID), otherToken)
+		assertStatus(t, resp, http.StatusOK)
+		var list []ProposalResponse
+		if err := parseJSON(resp, &list); err != nil {
+			t.Fatalf("failed to parse response: %v", err)
+		}
+		if len(list) != 1 || list[0].ReviewerNotes != "Other: too long" {
+			t.Errorf("expected other's notes in listing, got %+v", list)
+		}
+	})
+
+	t.Run("speaker sees neither", func(t *testing.T) {
+		if p := get(t, speakerToken); p.ReviewerNotes != "" || p.OrganizerNotes != "" {
+			t.Errorf("speaker saw notes %q / %q", p.ReviewerNotes, p.OrganizerNotes)
+		}
+		p := update(t, speakerToken, map[string]interface{}{"title": "Reviewed Talk v2", "reviewer_notes": "sneaky"})
+		if p.ReviewerNotes != "" || p.OrganizerNotes != "" {
+			t.Errorf("speaker update response leaked notes %q / %q", p.ReviewerNotes, p.OrganizerNotes)
+		}
+		if p := get(t, adminToken); p.ReviewerNotes != "Admin: great demo" {
+			t.Errorf("speaker update changed reviewer notes to %q", p.ReviewerNotes)
+		}
+	})
+}
+
+func TestGetEventProposalsSummary(t *testing.T) {
+	t.Parallel()
+	now := time.Now()
+	event := createTestEvent(adminToken, EventInput{
+		Name:       "Summary Event",
+		Slug:       fmt.Sprintf("summary-%d", now.UnixNano()),
+		StartDate:  now.AddDate(0, 2, 0).Format(time.RFC3339),
+		EndDate:    now.AddDate(0, 2, 1).Format(time.RFC3339),
+		CFPOpenAt:  now.AddDate(0, 0, -1).Format(time.RFC3339),
+		CFPCloseAt: now.AddDate(0, 1, 0).Format(time.RFC3339),
+	})
+	updateCFPStatus(adminToken, event.ID, "open")
+
+	resp := doPut(fmt.Sprintf("/api/v0/events/%d", event.ID), map[string]interface{}{"max_accepted": 3}, adminToken)
+	assertStatus(t, resp, http.StatusOK)
+	resp.Body.Close()
+
+	var ids []uint
+	for i, format := range []string{"talk", "talk", "workshop"} {
+		p := createTestProposal(speakerToken, event.ID, ProposalInput{
+			Title:    fmt.Sprintf("Summary Talk %d", i),
+			Abstract: "Counted in the summary.",
+			Format:   format,
+			Duration: 30,
+			Level:    "intermediate",
+			Speakers: []Speaker{
+				{Name: "Speaker User", Email: "speaker@test.com", Bio: "Bio", Company: "Acme", JobTitle: "Dev", LinkedIn: "https://linkedin.com/in/speaker"},
+			},
+		})
+		ids = append(ids, p.ID)
+	}
+	updateProposalStatus(adminToken, ids[0], "accepted")
+	updateProposalStatus(adminToken, ids[1], "rejected")
+	for i, rating := range []int{4, 1} {
+		resp := doPut(fmt.Sprintf("/api/v0/proposals/%d/rating", ids[i]), map[string]int{"rating": rating}, adminToken)
+		assertStatus(t, resp, http.StatusOK)
+		resp.Body.Close()
+	}
+
+	path := fmt.Sprintf("/api/v0/events/%d/proposals/summary", event.ID)
+
+	t.Run("organizer", func(t *testing.T) {
+		resp := doAuthGet(path, adminToken)
+		assertStatus(t, resp, http.StatusOK)
+		var summary struct {
+			Total          int64            `json:"total"`
+			ByStatus       map[string]int64 `json:"by_status"`
+			ByFormat       map[string]int64 `json:"by_format"`
+			Unrated        int64            `json:"unrated"`
+			Last24h        int64            `json:"last_24h"`
+			Last7d         int64            `json:"last_7d"`
+			AverageRating  *float64         `json:"average_rating"`
+			AcceptedCount  int64            `json:"accepted_count"`
+			RemainingSlots *int64           `json:"remaining_slots"`
+		}
+		if err := parseJSON(resp, &summary); err != nil {
+			t.Fatalf("failed to parse response: %v", err)
+		}
+		if summary.Total != 3 || summary.Last24h != 3 || summary.Last7d != 3 {
+			t.Errorf("expected 3 proposals, all recent, got %+v", summary)
+		}
+		if summary.ByStatus["accepted"] != 1 || summary.ByStatus["rejected"] != 1 || summary.ByStatus["submitted"] != 1 {
+			t.Errorf("unexpected status counts %v", summary.ByStatus)
+		}
+		if summary.ByFormat["talk"] != 2 || summary.ByFormat["workshop"] != 1 || summary.ByFormat["lightning"] != 0 {
+			t.Errorf("unexpected format counts %v", summary.ByFormat)
+		}
+		if summary.Unrated != 1 {
+			t.Errorf("expected 1 unrated, got %d", summary.Unrated)
+		}
+		if summary.AverageRating == nil || *summary.AverageRating != 2.5 {
+			t.Errorf("expected average rating 2.5, got %v", summary.AverageRating)
+		}
+		if summary.AcceptedCount != 1 || summary.RemainingSlots == nil || *summary.RemainingSlots != 2 {
+			t.Errorf("expected 1 accepted and 2 remaining slots, got %d and %v", summary.AcceptedCount, summary.RemainingSlots)
+		}
+	})
+
+	t.Run("speaker forbidden", func(t *testing.T) {
+		resp := doAuthGet(path, speakerToken)
+		assertStatus(t, resp, http.StatusForbidden)
+		resp.Body.Close()
+	})
+}
+
+func TestUpdateProposalStatus_Capacity(t *testing.T) {
+	t.Parallel()
+	now := time.Now()
+	event := createTestEvent(adminToken, EventInput{
+		Name:       "Capacity Conf",
+		Slug:       fmt.Sprintf("capacity-%d", now.UnixNano()),
+		StartDate:  now.AddDate(0, 2, 0).Format(time.RFC3339),
+		EndDate:    now.AddDate(0, 2, 1).Format(time.RFC3339),
+		CFPOpenAt:  now.AddDate(0, 0, -1).Format(time.RFC3339),
+		CFPCloseAt: now.AddDate(0, 1, 0).Format(time.RFC3339),
+	})
+	updateCFPStatus(adminToken, event.ID, "open")
+	eventPath := fmt.Sprintf("/api/v0/events/%d", event.ID)
+
+	resp := doPut(eventPath, map[string]interface{}{"max_accepted": 2}, adminToken)
+	assertStatus(t, resp, http.StatusOK)
+	resp.Body.Close()
+
+	var ids []uint
+	for i := 0; i < 4; i++ {
+		p := createTestProposal(speakerToken, event.ID, ProposalInput{
+			Title:    fmt.Sprintf("Capacity Talk %d", i),
+			Abstract: "Competing for a slot.",
+			Format:   "talk",
+			Duration: 30,
+			Level:    "intermediate",
+			Speakers: []Speaker{
+				{Name: "Speaker User", Email: "speaker@test.com", Bio: "Bio", Company: "Acme", JobTitle: "Dev", LinkedIn: "https://linkedin.com/in/speaker"},
+			},
+		})
+		ids = append(ids, p.ID)
+	}
+
+	accept := func(id uint) *http.Response {
+		return doPut(fmt.Sprintf("/api/v0/proposals/%d/status", id), ProposalStatusInput{Status: "accepted"}, adminToken)
+	}
+	capacity := func(resp *http.Response) string {
+		return fmt.Sprintf("accepted=%s max=%s remaining=%s warning=%s",
+			resp.Header.Get("X-Accepted-Count"), resp.Header.Get("X-Max-Accepted"),
+			resp.Header.Get("X-Remaining-Slots"), resp.Header.Get("X-Capacity-Warning"))
+	}
+
+	t.Run("acceptances report the slots left", func(t *testing.T) {
+		resp := accept(ids[0])
+		resp.Body.Close()
+		assertStatus(t, resp, http.StatusOK)
+		if got := capacity(resp); got != "accepted=1 max=2 remaining=1 warning=" {
+			t.Errorf("unexpected capacity headers: %s", got)
+		}
+
+		resp = accept(ids[1])
+		resp.Body.Close()
+		assertStatus(t, resp, http.StatusOK)
+		if got := capacity(resp); got != "accepted=2 max=2 remaining=0 warning=full" {
+			t.Errorf("expected the last slot to be flagged, got %s", got)
+		}
+
+		// Accepting an accepted proposal again takes no new slot
+		resp = accept(ids[1])
+		resp.Body.Close()
+		assertStatus(t, resp, http.StatusOK)
+	})
+
+	t.Run("organizer listing carries the capacity", func(t *testing.T) {
+		resp := doAuthGet(eventPath+"/proposals", adminToken)
+		resp.Body.Close()
+		assertStatus(t, resp, http.StatusOK)
+		if got := capacity(resp); got != "accepted=2 max=2 remaining=0 warning=" {
+			t.Errorf("unexpected capacity headers: %s", got)
+		}
+
+		resp = doAuthGet(eventPath+"/proposals", speakerToken)
+		resp.Body.Close()
+		if resp.Header.Get("X-Accepted-Count") != "" {
+			t.Error("expected speakers not to get the capacity headers")
+		}
+	})
+
+	t.Run("full events refuse acceptances", func(t *testing.T) {
+		resp := accept(ids[2])
+		body := readBody(resp)
+		assertStatus(t, resp, http.StatusBadRequest)
+		if !strings.Contains(body, "maximum accepted proposals reached") {
+			t.Errorf("expected the max_accepted error, got %s", body)
+		}
+	})
+
+	t.Run("overbooking events accept with a warning", func(t *testing.T) {
+		resp := doPut(eventPath, map[string]interface{}{"allow_overbook": true}, adminToken)
+		assertStatus(t, resp, http.StatusOK)
+		resp.Body.Close()
+
+		resp = accept(ids[2])
+		resp.Body.Close()
+		assertStatus(t, resp, http.StatusOK)
+		if got := capacity(resp); got != "accepted=3 max=2 remaining=0 warning=overbooked" {
+			t.Errorf("expected the overbooking to be flagged, got %s", got)
+		}
+
+		resp = doAuthGet(eventPath+"/proposals/summary", adminToken)
+		assertStatus(t, resp, http.StatusOK)
+		var summary struct {
+			AcceptedCount  int64  `json:"accepted_count"`
+			RemainingSlots *int64 `json:"remaining_slots"`
+		}
+		if err := parseJSON(resp, &summary); err != nil {
+			t.Fatalf("failed to parse response: %v", err)
+		}
+		if summary.AcceptedCount != 3 || summary.RemainingSlots == nil || *summary.RemainingSlots != 0 {
+			t.Errorf("expected 3 accepted and no slots left, got %d and %v", summary.AcceptedCount, summary.RemainingSlots)
+		}
+	})
+}
+
+func TestCreateProposal_CodeOfConduct(t *testing.T) {
+	t.Parallel()
+	now := time.Now()
+	event := createTestEvent(adminToken, EventInput{
+		Name:       "CoC Conf",
+		Slug:       fmt.Sprintf("coc-conf-%d", now.UnixNano()),
+		StartDate:  now.AddDate(0, 2, 0).Format(time.RFC3339),
+		EndDate:    now.AddDate(0, 2, 1).Format(time.RFC3339),
+		CFPOpenAt:  now.AddDate(0, 0, -1).Format(time.RFC3339),
+		CFPCloseAt: now.AddDate(0, 1, 0).Format(time.RFC3339),
+	})
+	updateCFPStatus(adminToken, event.ID, "open")
+	eventPath := fmt.Sprintf("/api/v0/events/%d", event.ID)
+
+	// Requiring acceptance needs a code of conduct to accept
+	resp := doPut(eventPath, map[string]interface{}{"require_coc_acceptance": true}, adminToken)
+	assertStatus(t, resp, http.StatusBadRequest)
+	resp.Body.Close()
+
+	resp = doPut(eventPath, map[string]interface{}{"coc_url": "https://coc.example.com", "require_coc_acceptance": true}, adminToken)
+	assertStatus(t, resp, http.StatusOK)
+	resp.Body.Close()
+
+	submission := map[string]interface{}{
+		"title":    "Conduct Matters",
+		"abstract": "Why every event needs a code of conduct.",
+		"format":   "talk",
+		"duration": 30,
+		"level":    "beginner",
+		"speakers": []Speaker{{Name: "Speaker User", Email: "speaker@test.com", Bio: "Bio", Company: "Acme", JobTitle: "Dev", LinkedIn: "https://linkedin.com/in/speaker", Primary: true}},
+	}
+	path := fmt.Sprintf("/api/v0/events/%d/proposals", event.ID)
+
+	resp = doPost(path, submission, speakerToken)
+	assertStatus(t, resp, http.StatusBadRequest)
+	var body struct {
+		Fields map[string]string `json:"fields"`
+	}
+	if err := parseJSON(resp, &body); err != nil {
+		t.Fatalf("failed to parse response: %v", err)
+	}
+	if body.Fields["coc_accepted"] == "" {
+		t.Errorf("expected a coc_accepted error, got %+v", body.Fields)
+	}
+
+	submission["coc_accepted"] = true
+	resp = doPost(path, submission, speakerToken)
+	assertStatus(t, resp, http.StatusCreated)
+	var created struct {
+		ID            uint       `json:"id"`
+		CoCAcceptedAt *time.Time `json:"coc_accepted_at"`
+	}
+	if err := parseJSON(resp, &created); err != nil {
+		t.Fatalf("failed to parse response: %v", err)
+	}
+	if created.CoCAcceptedAt == nil {
+		t.Error("expected the acceptance time to be recorded")
+	}
+}
+
+func TestCreateProposal_AbstractWordLimits(t *testing.T) {
+	t.Parallel()
+	now := time.Now()
+	event := createTestEvent(adminToken, EventInput{
+		Name:       "Word Limit Conf",
+		Slug:       fmt.Sprintf("word-limit-conf-%d", now.UnixNano()),
+		StartDate:  now.AddDate(0, 2, 0).Format(time.RFC3339),
+		EndDate:    now.AddDate(0, 2, 1).Format(time.RFC3339),
+		CFPOpenAt:  now.AddDate(0, 0, -1).Format(time.RFC3339),
+		CFPCloseAt: now.AddDate(0, 1, 0).Format(time.RFC3339),
+	})
+	updateCFPStatus(adminToken, event.ID, "open")
+	eventPath := fmt.Sprintf("/api/v0/events/%d", event.ID)
+
+	resp := doPut(eventPath, map[string]interface{}{"abstract_min_words": 10, "abstract_max_words": 5}, adminToken)
+	assertStatus(t, resp, http.StatusBadRequest)
+	resp.Body.Close()
+
+	resp = doPut(eventPath, map[string]interface{}{"abstract_min_words": 5, "abstract_max_words": 10}, adminToken)
+	assertStatus(t, resp, http.StatusOK)
+	resp.Body.Close()
+
+	submission := map[string]interface{}{
+		"title":    "Brevity",
+		"abstract": "Too short.",
+		"format":   "talk",
+		"duration": 30,
+		"level":    "beginner",
+		"speakers": []Speaker{{Name: "Speaker User", Email: "speaker@test.com", Bio: "Bio", Company: "Acme", JobTitle: "Dev", LinkedIn: "https://linkedin.com/in/speaker", Primary: true}},
+	}
+	path := fmt.Sprintf("/api/v0/events/%d/proposals", event.ID)
+
+	resp = doPost(path, submission, speakerToken)
+	assertStatus(t, resp, http.StatusBadRequest)
+	var body struct {
+		Fields map[string]string `json:"fields"`
+	}
+	if err := parseJSON(resp, &body); err != nil {
+		t.Fatalf("failed to parse response: %v", err)
+	}
+	if want := "Abstract must be at least 5 words (currently 2)"; body.Fields["abstract"] != want {
+		t.Errorf("expected %q, got %+v", want, body.Fields)
+	}
+
+	// Organizers are exempt
+	organizerSubmission := map[string]interface{}{}
+	for k, v := range submission {
+		organizerSubmission[k] = v
+	}
+	organizerSubmission["speakers"] = []Speaker{{Name: "Admin User", Email: "admin@test.com", Bio: "Bio", Company: "Acme", JobTitle: "Organizer", LinkedIn: "https://linkedin.com/in/admin", Primary: true}}
+	resp = doPost(path, organizerSubmission, adminToken)
+	assertStatus(t, resp, http.StatusCreated)
+	resp.Body.Close()
+
+	submission["abstract"] = "A talk about keeping abstracts short."
+	resp = doPost(path, submission, speakerToken)
+	assertStatus(t, resp, http.StatusCreated)
+	resp.Body.Close()
+}
+
+// TestProposalRoutes_PathIDs guards the pattern routes of the proposal
+// handlers: malformed IDs are a 400 from the handler, and paths that do not
+// match a route (trailing slashes, extra segments) are a 404.
+func TestProposalRoutes_PathIDs(t *testing.T) {
+	p := createTestProposal(speakerToken, eventGopherCon.ID, ProposalInput{
+		Title:    "Path Routing Proposal",
+		Abstract: "Test abstract",
+		Format:   "talk",
+		Duration: 30,
+		Speakers: []Speaker{
+			{Name: "Speaker User", Email: "speaker@test.com", Company: "Acme Inc", JobTitle: "Engineer", LinkedIn: "https://linkedin.com/in/speaker", Primary: true},
+		},
+	})
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		token  string
+		body   interface{}
+		want   int
+	}{
+		{"get non-numeric", http.MethodGet, "/api/v0/proposals/abc", speakerToken, nil, http.StatusBadRequest},
+		{"get negative", http.MethodGet, "/api/v0/proposals/-1", speakerToken, nil, http.StatusBadRequest},
+		{"get overflow", http.MethodGet, "/api/v0/proposals/99999999999", speakerToken, nil, http.StatusBadRequest},
+		{"get decimal", http.MethodGet, "/api/v0/proposals/1.5", speakerToken, nil, http.StatusBadRequest},
+		{"status non-numeric", http.MethodPut, "/api/v0/proposals/abc/status", adminToken, ProposalStatusInput{Status: "accepted"}, http.StatusBadRequest},
+		{"rating non-numeric", http.MethodPut, "/api/v0/proposals/abc/rating", adminToken, map[string]int{"rating": 3}, http.StatusBadRequest},
+		{"confirm non-numeric", http.MethodPut, "/api/v0/proposals/abc/confirm", speakerToken, nil, http.StatusBadRequest},
+		{"emergency cancel non-numeric", http.MethodPut, "/api/v0/proposals/abc/emergency-cancel", speakerToken, nil, http.StatusBadRequest},
+		{"copy non-numeric", http.MethodPost, "/api/v0/proposals/abc/copy?target_event_id=1", speakerToken, nil, http.StatusBadRequest},
+		{"create non-numeric event", http.MethodPost, "/api/v0/events/abc/proposals", speakerToken, ProposalInput{Title: "x", Abstract: "y"}, http.StatusBadRequest},
+
+		{"get trailing slash", http.MethodGet, fmt.Sprintf("/api/v0/proposals/%d/", p.ID), speakerToken, nil, http.StatusNotFound},
+		{"status trailing slash", http.MethodPut, fmt.Sprintf("/api/v0/proposals/%d/status/", p.ID), adminToken, ProposalStatusInput{Status: "accepted"}, http.StatusNotFound},
+		{"status extra segment", http.MethodPut, fmt.Sprintf("/api/v0/proposals/%d/status/extra", p.ID), adminToken, ProposalStatusInput{Status: "accepted"}, http.StatusNotFound},
+		{"confirm extra segment", http.MethodPut, fmt.Sprintf("/api/v0/proposals/%d/confirm/extra", p.ID), speakerToken, nil, http.StatusNotFound},
+		{"unknown action", http.MethodPut, fmt.Sprintf("/api/v0/proposals/%d/unknown", p.ID), speakerToken, nil, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := doRequest(tt.method, tt.path, tt.body, tt.token)
+			defer resp.Body.Close()
+			assertStatus(t, resp, tt.want)
+		})
+	}
+
+	// The proposal itself was never touched
+	resp := doAuthGet(fmt.Sprintf("/api/v0/proposals/%d", p.ID), speakerToken)
+	defer resp.Body.Close()
+	assertStatus(t, resp, http.StatusOK)
+	var got ProposalResponse
+	if err := parseJSON(resp, &got); err != nil {
+		t.Fatalf("failed to parse response: %v", err)
+	}
+	if got.Status != "submitted" {
+		t.Errorf("expected status submitted, got %q", got.Status)
+	}
+}
+
+func TestHideSpeakerEmailsFromReviewers(t *testing.T) {
+	t.Parallel()
+	eventID, proposal := createAcceptedProposal(t, "hide-emails")
+	eventPath := fmt.Sprintf("/api/v0/events/%d", eventID)
+
+	resp := doPost(eventPath+"/organizers", OrganizerInput{Email: "other@test.com"}, adminToken)
+	assertStatus(t, resp, http.StatusCreated)
+	resp.Body.Close()
+
+	// Only the creator decides what reviewers see
+	resp = doPut(eventPath, map[string]interface{}{"hide_speaker_emails_from_reviewers": true}, otherToken)
+	assertStatus(t, resp, http.StatusForbidden)
+	resp.Body.Close()
+	resp = doPut(eventPath, map[string]interface{}{"hide_speaker_emails_from_reviewers": true}, adminToken)
+	assertStatus(t, resp, http.StatusOK)
+	resp.Body.Close()
+
+	listPath := eventPath + "/proposals"
+	detailPath := fmt.Sprintf("/api/v0/proposals/%d", proposal.ID)
+	for _, tt := range []struct {
+		name, path, token, want string
+	}{
+		{"creator list", listPath, adminToken, "speaker@test.com"},
+		{"creator detail", detailPath, adminToken, "speaker@test.com"},
+		{"reviewer list", listPath, otherToken, "***@test.com"},
+		{"reviewer detail", detailPath, otherToken, "***@test.com"},
+		{"speaker detail", detailPath, speakerToken, "speaker@test.com"},
+	} {
+		resp := doAuthGet(tt.path, tt.token)
+		assertStatus(t, resp, http.StatusOK)
+		var got ProposalResponse
+		if tt.path == listPath {
+			var list ProposalListResponse
+			if err := parseJSON(resp, &list); err != nil || len(list) != 1 {
+				t.Fatalf("%s: expected one proposal, got %+v (%v)", tt.name, list, err)
+			}
+			got = list[0]
+		} else if err := parseJSON(resp, &got); err != nil {
+			t.Fatalf("%s: failed to parse proposal: %v", tt.name, err)
+		}
+		if len(got.Speakers) != 1 || got.Speakers[0].Email != tt.want {
+			t.Errorf("%s: expected speaker email %q, got %+v", tt.name, tt.want, got.Speakers)
+		}
+	}
+
+	// Exports keyed on email are refused; the others are masked
+	resp = doAuthGet(eventPath+"/proposals/export?format=speakers", otherToken)
+	assertStatus(t, resp, http.StatusForbidden)
+	resp.Body.Close()
+	resp = doAuthGet(eventPath+"/proposals/export?format=in-person", otherToken)
+	assertStatus(t, resp, http.StatusOK)
+	if body := readBody(resp); strings.Contains(body, "speaker@test.com") || !strings.Contains(body, "***@test.com") {
+		t.Errorf("expected masked emails in the export, got %s", body)
+	}
+
+	// Masked speakers can't be saved back
+	resp = doPut(detailPath, map[string]interface{}{
+		"speakers": []Speaker{{Name: "Speaker User", Email: "***@test.com"}},
+	}, otherToken)
+	assertStatus(t, resp, http.StatusForbidden)
+	resp.Body.Close()
+}
+
+func TestProposalSource_RecordedFromRequest(t *testing.T) {
+	now := time.Now()
+	event := createTestEvent(adminToken, EventInput{
+		Name:       "Source Test",
+		Slug:       fmt.Sprintf("source-test-%d", now.UnixNano()),
+		StartDate:  now.AddDate(0, 1, 0).Format(time.RFC3339),
+		EndDate:    now.AddDate(0, 1, 1).Format(time.RFC3339),
+		CFPOpenAt:  now.AddDate(0, 0, -1).Format(time.RFC3339),
+		CFPCloseAt: now.AddDate(0, 0, 7).Format(time.RFC3339),
+	})
+	updateCFPStatus(adminToken, event.ID, "open")
+	path := "/api/v0/events/" + uintToStr(event.ID) + "/proposals"
+	proposal := func(title string) map[string]interface{} {
+		return map[string]interface{}{
+			"title":    title,
+			"abstract": "Where did this come from?",
+			"format":   "talk",
+			"duration": 30,
+			"level":    "beginner",
+			"speakers": []Speaker{{Name: "Speaker User", Email: "speaker@test.com", Company: "Acme", JobTitle: "Dev", LinkedIn: "https://linkedin.com/in/speaker"}},
+			// Clients can't choose their source
+			"source": "import",
+		}
+	}
+
+	resp := doPost(path, proposal("From a script"), speakerToken)
+	assertStatus(t, resp, http.StatusCreated)
+	body := readBody(resp)
+	if !strings.Contains(body, `"source":"api"`) {
+		t.Errorf("expected a bearer token submission to be recorded as api, got %s", body)
+	}
+
+	data, _ := json.Marshal(proposal("From the CLI"))
+	req, _ := http.NewRequest(http.MethodPost, testServer.URL+path, bytes.NewReader(data))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+speakerToken)
+	req.Header.Set("X-Client", "cfp-cli/1.2.3")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertStatus(t, resp, http.StatusCreated)
+	body = readBody(resp)
+	if !strings.Contains(body, `"source":"cli"`) {
+		t.Errorf("expected the CLI submission to be recorded as cli, got %s", body)
+	}
+
+	resp = doAuthGet("/api/v0/stats/proposals", speakerToken)
+	assertStatus(t, resp, http.StatusOK)
+	var stats struct {
+		BySource map[string]int64 `json:"by_source"`
+	}
+	if err := parseJSON(resp, &stats); err != nil {
+		t.Fatal(err)
+	}
+	if stats.BySource["api"] < 1 || stats.BySource["cli"] < 1 {
+		t.Errorf("expected api and cli submissions counted, got %v", stats.BySource)
+	}
+	if _, ok := stats.BySource["web"]; !ok {
+		t.Errorf("expected every source listed, got %v", stats.BySource)
+	}
+}
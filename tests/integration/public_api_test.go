@@ -0,0 +1,208 @@
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/sreday/cfp.ninja/pkg/models"
+)
+
+// APIKeyResponse is an API key as listed to admins
+type APIKeyResponse struct {
+	ID           uint       `json:"id"`
+	Name         string     `json:"name"`
+	Prefix       string     `json:"prefix"`
+	Key          string     `json:"key"` // Only set when the key is created
+	RequestCount int64      `json:"request_count"`
+	LastUsedAt   *time.Time `json:"last_used_at"`
+	RevokedAt    *time.Time `json:"revoked_at"`
+}
+
+// PublicEventResponse is an event from GET /api/v0/public/events
+type PublicEventResponse struct {
+	ID        uint      `json:"id"`
+	Removed   bool      `json:"removed"`
+	UpdatedAt time.Time `json:"updated_at"`
+	Slug      string    `json:"slug"`
+	Name      string    `json:"name"`
+	URL       string    `json:"url"`
+	Tags      []string  `json:"tags"`
+	CFPOpen   bool      `json:"cfp_open"`
+}
+
+type publicEventsPage struct {
+	Data       []PublicEventResponse `json:"data"`
+	Pagination struct {
+		Page       int   `json:"page"`
+		PerPage    int   `json:"per_page"`
+		Total      int64 `json:"total"`
+		TotalPages int   `json:"total_pages"`
+	} `json:"pagination"`
+}
+
+// createTestAPIKey issues an API key as the admin and returns the key
+func createTestAPIKey(t *testing.T, name string) string {
+	t.Helper()
+	resp := doPost("/api/v0/admin/api-keys", map[string]string{"name": name, "contact_email": "feeds@example.com"}, adminToken)
+	assertStatus(t, resp, http.StatusCreated)
+	var key APIKeyResponse
+	if err := parseJSON(resp, &key); err != nil {
+		t.Fatalf("failed to parse API key: %v", err)
+	}
+	if key.Key == "" || key.Prefix == "" || key.Key[:len(key.Prefix)] != key.Prefix {
+		t.Fatalf("unexpected API key response: %+v", key)
+	}
+	return key.Key
+}
+
+func listPublicEvents(t *testing.T, key string, query url.Values) publicEventsPage {
+	t.Helper()
+	resp := doAuthGet("/api/v0/public/events?"+query.Encode(), key)
+	assertStatus(t, resp, http.StatusOK)
+	var page publicEventsPage
+	if err := parseJSON(resp, &page); err != nil {
+		t.Fatalf("failed to parse public events: %v", err)
+	}
+	return page
+}
+
+func TestAPIKeys_AdminOnly(t *testing.T) {
+	t.Parallel()
+	resp := doPost("/api/v0/admin/api-keys", map[string]string{"name": "Newsletter"}, speakerToken)
+	defer resp.Body.Close()
+	assertStatus(t, resp, http.StatusForbidden)
+
+	resp2 := doPost("/api/v0/admin/api-keys", map[string]string{"name": ""}, adminToken)
+	defer resp2.Body.Close()
+	assertStatus(t, resp2, http.StatusBadRequest)
+}
+
+func TestPublicEvents(t *testing.T) {
+	key := createTestAPIKey(t, "Weekly CFP newsletter")
+
+	t.Run("requires a valid key", func(t *testing.T) {
+		resp := doGet("/api/v0/public/events")
+		defer resp.Body.Close()
+		assertStatus(t, resp, http.StatusUnauthorized)
+
+		// A login token is not an API key
+		resp2 := doAuthGet("/api/v0/public/events", speakerToken)
+		defer resp2.Body.Close()
+		assertStatus(t, resp2, http.StatusUnauthorized)
+
+		resp3 := doAuthGet("/api/v0/public/events", "cfpk_not-a-real-key")
+		defer resp3.Body.Close()
+		assertStatus(t, resp3, http.StatusUnauthorized)
+	})
+
+	t.Run("validates parameters", func(t *testing.T) {
+		for _, q := range []string{"per_page=500", "page=0", "updated_since=yesterday", "status=all"} {
+			resp := doAuthGet("/api/v0/public/events?"+q, key)
+			assertStatus(t, resp, http.StatusBadRequest)
+			resp.Body.Close()
+		}
+	})
+
+	t.Run("lists published events with stable fields", func(t *testing.T) {
+		page := listPublicEvents(t, key, url.Values{"per_page": {"100"}})
+		if page.Pagination.PerPage != 100 || page.Pagination.Total == 0 {
+			t.Fatalf("unexpected pagination: %+v", page.Pagination)
+		}
+		var found *PublicEventResponse
+		for i, e := range page.Data {
+			if e.Removed {
+				t.Errorf("full listing should not contain removed events, got %+v", e)
+			}
+			if e.ID == eventGopherCon.ID {
+				found = &page.Data[i]
+			}
+		}
+		if found == nil {
+			t.Fatal("expected gophercon in the public listing")
+		}
+		if found.Slug != eventGopherCon.Slug || found.URL == "" || found.Tags == nil {
+			t.Errorf("unexpected event: %+v", found)
+		}
+
+		paged := listPublicEvents(t, key, url.Values{"per_page": {"1"}})
+		if len(paged.Data) != 1 || paged.Pagination.TotalPages != int(paged.Pagination.Total) {
+			t.Errorf("expected one event per page, got %+v", paged.Pagination)
+		}
+	})
+
+	t.Run("incremental sync returns changes and removals", func(t *testing.T) {
+		since := time.Now().Add(-time.Second).UTC()
+		now := time.Now()
+		event := createTestEvent(adminToken, EventInput{
+			Name:       "Public API Event",
+			Slug:       fmt.Sprintf("public-api-%d", now.UnixNano()),
+			StartDate:  now.AddDate(0, 2, 0).Format(time.RFC3339),
+			EndDate:    now.AddDate(0, 2, 1).Format(time.RFC3339),
+			CFPOpenAt:  now.AddDate(0, 0, -1).Format(time.RFC3339),
+			CFPCloseAt: now.AddDate(0, 1, 0).Format(time.RFC3339),
+			Tags:       "go, cloud",
+		})
+		updateCFPStatus(adminToken, event.ID, "open")
+
+		page := listPublicEvents(t, key, url.Values{"updated_since": {since.Format(time.RFC3339)}})
+		var got *PublicEventResponse
+		for i := range page.Data {
+			if page.Data[i].ID == event.ID {
+				got = &page.Data[i]
+			}
+		}
+		if got == nil || got.Removed || !got.CFPOpen || len(got.Tags) != 2 {
+			t.Fatalf("expected the new open event, got %+v", got)
+		}
+
+		resp := doDelete(fmt.Sprintf("/api/v0/events/%d", event.ID), adminToken)
+		assertStatus(t, resp, http.StatusOK)
+		resp.Body.Close()
+
+		page = listPublicEvents(t, key, url.Values{"updated_since": {since.Format(time.RFC3339)}, "status": {"open"}})
+		got = nil
+		for i := range page.Data {
+			if page.Data[i].ID == event.ID {
+				got = &page.Data[i]
+			}
+		}
+		if got == nil || !got.Removed || got.Slug != "" {
+			t.Errorf("expected a removal for the deleted event, got %+v", got)
+		}
+	})
+
+	t.Run("usage is counted and revoked keys stop working", func(t *testing.T) {
+		var keys []APIKeyResponse
+		resp := doAuthGet("/api/v0/admin/api-keys", adminToken)
+		assertStatus(t, resp, http.StatusOK)
+		if err := parseJSON(resp, &keys); err != nil {
+			t.Fatalf("failed to parse API keys: %v", err)
+		}
+		var listed *APIKeyResponse
+		for i := range keys {
+			if keys[i].Name == "Weekly CFP newsletter" {
+				listed = &keys[i]
+			}
+		}
+		if listed == nil || listed.Key != "" || listed.RequestCount < 5 || listed.LastUsedAt == nil {
+			t.Fatalf("expected the key listed with its usage and without the key, got %+v", listed)
+		}
+
+		resp = doDelete(fmt.Sprintf("/api/v0/admin/api-keys/%d", listed.ID), adminToken)
+		assertStatus(t, resp, http.StatusOK)
+		resp.Body.Close()
+
+		resp = doAuthGet("/api/v0/public/events", key)
+		defer resp.Body.Close()
+		assertStatus(t, resp, http.StatusUnauthorized)
+
+		var stored models.APIKey
+		testConfig.DB.First(&stored, listed.ID)
+		if stored.RevokedAt == nil {
+			t.Error("expected revoked_at to be set")
+		}
+	})
+}
@@ -0,0 +1,139 @@
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+// queryCounter records the SQL statements GORM runs while a test is counting,
+// so tests can pin how many round trips an endpoint takes
+var queryCounter struct {
+	once       sync.Once
+	mu         sync.Mutex
+	recording  bool
+	statements []string
+}
+
+// countQueries returns the SQL statements run while serving fn's requests.
+// Each endpoint should be called once before counting, so the short-lived
+// user cache behind authentication is warm and doesn't add a lookup.
+func countQueries(t *testing.T, fn func()) []string {
+	t.Helper()
+	queryCounter.once.Do(func() {
+		record := func(db *gorm.DB) {
+			queryCounter.mu.Lock()
+			defer queryCounter.mu.Unlock()
+			if queryCounter.recording {
+				queryCounter.statements = append(queryCounter.statements, db.Statement.SQL.String())
+			}
+		}
+		cb := testConfig.DB.Callback()
+		for _, err := range []error{
+			cb.Query().After("gorm:query").Register("test:count_query", record),
+			cb.Create().After("gorm:create").Register("test:count_create", record),
+			cb.Update().After("gorm:update").Register("test:count_update", record),
+			cb.Delete().After("gorm:delete").Register("test:count_delete", record),
+			cb.Row().After("gorm:row").Register("test:count_row", record),
+			cb.Raw().After("gorm:raw").Register("test:count_raw", record),
+		} {
+			if err != nil {
+				t.Fatalf("failed to register query counter: %v", err)
+			}
+		}
+	})
+
+	queryCounter.mu.Lock()
+	queryCounter.recording, queryCounter.statements = true, nil
+	queryCounter.mu.Unlock()
+
+	fn()
+
+	queryCounter.mu.Lock()
+	defer queryCounter.mu.Unlock()
+	queryCounter.recording = false
+	return queryCounter.statements
+}
+
+// assertMaxQueries fails when serving fn's requests took more than max
+// statements, listing them so a regression is easy to track down
+func assertMaxQueries(t *testing.T, max int, fn func()) {
+	t.Helper()
+	statements := countQueries(t, fn)
+	if len(statements) > max {
+		t.Errorf("expected at most %d SQL statements, got %d:\n%s", max, len(statements), strings.Join(statements, "\n"))
+	}
+}
+
+func TestQueryCounts(t *testing.T) {
+	proposal := createTestProposal(speakerToken, eventGopherCon.ID, ProposalInput{
+		Title:    "Query Count Proposal",
+		Abstract: "Test abstract",
+		Format:   "talk",
+		Duration: 30,
+		Speakers: []Speaker{
+			{Name: "Speaker User", Email: "speaker@test.com", Company: "Acme Inc", JobTitle: "Engineer", LinkedIn: "https://linkedin.com/in/speaker", Primary: true},
+		},
+	})
+	proposalPath := fmt.Sprintf("/api/v0/proposals/%d", proposal.ID)
+
+	tests := []struct {
+		name string
+		// max is the number of statements the endpoint takes, after the
+		// user cache is warm
+		max     int
+		request func() *http.Response
+		status  int
+	}{
+		{
+			// proposal, event, organizers
+			name:    "get proposal as speaker",
+			max:     3,
+			request: func() *http.Response { return doAuthGet(proposalPath, speakerToken) },
+			status:  http.StatusOK,
+		},
+		{
+			// proposal, event, organizers, reviewer notes
+			name:    "get proposal as organizer",
+			max:     4,
+			request: func() *http.Response { return doAuthGet(proposalPath, adminToken) },
+			status:  http.StatusOK,
+		},
+		{
+			// proposal, event, organizers, update
+			name: "rate proposal",
+			max:  4,
+			request: func() *http.Response {
+				return doPut(proposalPath+"/rating", map[string]int{"rating": 4}, adminToken)
+			},
+			status: http.StatusOK,
+		},
+		{
+			// event, organizers, notify-me count
+			name: "get event as organizer",
+			max:  3,
+			request: func() *http.Response {
+				return doAuthGet(fmt.Sprintf("/api/v0/me/events/%d", eventGopherCon.ID), adminToken)
+			},
+			status: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := tt.request()
+			assertStatus(t, resp, tt.status)
+			resp.Body.Close()
+
+			assertMaxQueries(t, tt.max, func() {
+				resp := tt.request()
+				assertStatus(t, resp, tt.status)
+				resp.Body.Close()
+			})
+		})
+	}
+}
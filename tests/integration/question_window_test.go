@@ -0,0 +1,172 @@
+package integration
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/sreday/cfp.ninja/pkg/models"
+	"gorm.io/datatypes"
+)
+
+// validationFields returns the field errors of a validation response
+func validationFields(t *testing.T, resp *http.Response) map[string]string {
+	t.Helper()
+	assertStatus(t, resp, http.StatusBadRequest)
+	var body struct {
+		Code   string            `json:"code"`
+		Fields map[string]string `json:"fields"`
+	}
+	if err := parseJSON(resp, &body); err != nil {
+		t.Fatalf("failed to parse response: %v", err)
+	}
+	if body.Code != "validation" {
+		t.Fatalf("expected a validation error, got %+v", body)
+	}
+	return body.Fields
+}
+
+func TestQuestionWindows_LateBreakingPhase(t *testing.T) {
+	t.Parallel()
+	now := time.Now().UTC()
+	event := createTestEvent(adminToken, EventInput{
+		Name:       "Late Breaking Conf",
+		Slug:       fmt.Sprintf("late-breaking-%d", now.UnixNano()),
+		StartDate:  now.AddDate(0, 2, 0).Format(time.RFC3339),
+		EndDate:    now.AddDate(0, 2, 1).Format(time.RFC3339),
+		CFPOpenAt:  now.AddDate(0, 0, -30).Format(time.RFC3339),
+		CFPCloseAt: now.AddDate(0, 1, 0).Format(time.RFC3339),
+	})
+	updateCFPStatus(adminToken, event.ID, "open")
+	eventPath := fmt.Sprintf("/api/v0/events/%d", event.ID)
+
+	// The main phase closed yesterday; the late-breaking one is open
+	mainClose := now.AddDate(0, 0, -1)
+	lateOpen := now.AddDate(0, 0, -1)
+	questions := []models.CustomQuestion{
+		{ID: "main_track", Text: "Which track?", Type: "text", Required: true, CloseAt: &mainClose},
+		{ID: "late_results", Text: "Which new results?", Type: "text", Required: true, OpenAt: &lateOpen},
+	}
+
+	t.Run("rejects windows that close before they open", func(t *testing.T) {
+		before := lateOpen.Add(-time.Hour)
+		invalid := []models.CustomQuestion{{ID: "late_results", Text: "?", Type: "text", OpenAt: &lateOpen, CloseAt: &before}}
+		fields := validationFields(t, doPut(eventPath, map[string]interface{}{"cfp_questions": invalid}, adminToken))
+		if fields["cfp_questions.late_results.close_at"] == "" {
+			t.Errorf("expected a close_at error, got %v", fields)
+		}
+
+		fields = validationFields(t, doPut(eventPath, map[string]interface{}{
+			"cfp_questions": []map[string]interface{}{{"id": "q", "text": "?", "type": "text", "open_at": "next week"}},
+		}, adminToken))
+		if fields["cfp_questions"] == "" {
+			t.Errorf("expected a cfp_questions error, got %v", fields)
+		}
+	})
+
+	resp := doPut(eventPath, map[string]interface{}{"cfp_questions": questions}, adminToken)
+	resp.Body.Close()
+	assertStatus(t, resp, http.StatusOK)
+
+	t.Run("event page flags the active questions", func(t *testing.T) {
+		resp := doGet("/api/v0/e/" + event.Slug)
+		assertStatus(t, resp, http.StatusOK)
+		var page struct {
+			CFPQuestions []struct {
+				ID     string `json:"id"`
+				Active *bool  `json:"active"`
+			} `json:"cfp_questions"`
+		}
+		if err := parseJSON(resp, &page); err != nil {
+			t.Fatalf("failed to parse response: %v", err)
+		}
+		active := map[string]bool{}
+		for _, q := range page.CFPQuestions {
+			if q.Active == nil {
+				t.Fatalf("expected every question to be flagged, got %+v", page.CFPQuestions)
+			}
+			active[q.ID] = *q.Active
+		}
+		if active["main_track"] || !active["late_results"] {
+			t.Errorf("expected only late_results to be active, got %v", active)
+		}
+
+		// The flag is never stored
+		var stored models.Event
+		testConfig.DB.First(&stored, event.ID)
+		var raw []map[string]interface{}
+		json.Unmarshal(stored.CFPQuestions, &raw)
+		for _, q := range raw {
+			if _, ok := q["active"]; ok {
+				t.Errorf("expected the active flag not to be stored, got %v", raw)
+			}
+		}
+	})
+
+	input := func(answers map[string]string) map[string]interface{} {
+		return map[string]interface{}{
+			"title":    "Late Breaking Results",
+			"abstract": "Fresh results.",
+			"format":   "talk",
+			"duration": 30,
+			"speakers": []Speaker{
+				{Name: "Speaker User", Email: "speaker@test.com", Company: "Acme Inc", JobTitle: "Engineer", LinkedIn: "https://linkedin.com/in/speaker", Primary: true},
+			},
+			"custom_answers": answers,
+		}
+	}
+	proposalsPath := fmt.Sprintf("/api/v0/events/%d/proposals", event.ID)
+
+	t.Run("only active questions are required and take answers", func(t *testing.T) {
+		fields := validationFields(t, doPost(proposalsPath, input(map[string]string{}), speakerToken))
+		if fields["custom_answers.late_results"] == "" || fields["custom_answers.main_track"] != "" {
+			t.Errorf("expected only late_results to be required, got %v", fields)
+		}
+
+		fields = validationFields(t, doPost(proposalsPath, input(map[string]string{"main_track": "SRE", "late_results": "New"}), speakerToken))
+		if fields["custom_answers.main_track"] == "" {
+			t.Errorf("expected the closed question to refuse answers, got %v", fields)
+		}
+	})
+
+	var proposal ProposalResponse
+	t.Run("submits answering the active questions", func(t *testing.T) {
+		resp := doPost(proposalsPath, input(map[string]string{"late_results": "New"}), speakerToken)
+		assertStatus(t, resp, http.StatusCreated)
+		if err := parseJSON(resp, &proposal); err != nil {
+			t.Fatalf("failed to parse response: %v", err)
+		}
+	})
+
+	t.Run("edits keep answers given while a question was open", func(t *testing.T) {
+		if proposal.ID == 0 {
+			t.Skip("no proposal was submitted")
+		}
+		// As if the proposal was submitted during the main phase
+		answers, _ := json.Marshal(map[string]string{"main_track": "SRE", "late_results": "New"})
+		testConfig.DB.Model(&models.Proposal{}).Where("id = ?", proposal.ID).Update("custom_answers", datatypes.JSON(answers))
+		proposalPath := fmt.Sprintf("/api/v0/proposals/%d", proposal.ID)
+
+		resp := doPut(proposalPath, map[string]interface{}{
+			"custom_answers": map[string]string{"main_track": "SRE", "late_results": "Newer"},
+		}, speakerToken)
+		resp.Body.Close()
+		assertStatus(t, resp, http.StatusOK)
+
+		fields := validationFields(t, doPut(proposalPath, map[string]interface{}{
+			"custom_answers": map[string]string{"main_track": "Platform", "late_results": "Newer"},
+		}, speakerToken))
+		if fields["custom_answers.main_track"] == "" {
+			t.Errorf("expected changing a closed question's answer to be refused, got %v", fields)
+		}
+
+		// Organizers may still correct it
+		resp = doPut(proposalPath, map[string]interface{}{
+			"custom_answers": map[string]string{"main_track": "Platform", "late_results": "Newer"},
+		}, adminToken)
+		resp.Body.Close()
+		assertStatus(t, resp, http.StatusOK)
+	})
+}
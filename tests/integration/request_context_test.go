@@ -0,0 +1,55 @@
+package integration
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestDBCtx(t *testing.T) {
+	slowQuery := func(t *testing.T, ctx context.Context) (time.Duration, error) {
+		t.Helper()
+		r := httptest.NewRequest(http.MethodGet, "/api/v0/events", nil).WithContext(ctx)
+		start := time.Now()
+		err := testConfig.DBCtx(r).Exec("SELECT pg_sleep(5)").Error
+		return time.Since(start), err
+	}
+
+	t.Run("cancelling the request aborts its query", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(100*time.Millisecond, cancel)
+
+		elapsed, err := slowQuery(t, ctx)
+		if err == nil {
+			t.Fatal("expected the query to be cancelled")
+		}
+		if elapsed > 2*time.Second {
+			t.Errorf("expected the query to stop soon after the cancellation, took %v", elapsed)
+		}
+	})
+
+	t.Run("statement timeout", func(t *testing.T) {
+		timeout := testConfig.DBStatementTimeout
+		testConfig.DBStatementTimeout = 100 * time.Millisecond
+		t.Cleanup(func() { testConfig.DBStatementTimeout = timeout })
+
+		elapsed, err := slowQuery(t, context.Background())
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("expected the query to time out, got %v", err)
+		}
+		if elapsed > 2*time.Second {
+			t.Errorf("expected the query to stop at the timeout, took %v", elapsed)
+		}
+	})
+
+	t.Run("connection is usable after a cancellation", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/api/v0/events", nil)
+		var one int
+		if err := testConfig.DBCtx(r).Raw("SELECT 1").Scan(&one).Error; err != nil || one != 1 {
+			t.Errorf("expected 1, got %d (%v)", one, err)
+		}
+	})
+}
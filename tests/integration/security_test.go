@@ -82,7 +82,8 @@ func TestSortFieldSQLInjection(t *testing.T) {
 
 // TestXSSPayloadsInEventFields verifies that HTML/script payloads in event fields
 // are stored and returned verbatim without server-side modification or stripping.
-// Client-side escaping (escapeHtml, DOMPurify) handles sanitization for rendering.
+// Client-side escaping (escapeHtml, DOMPurify) handles rendering in the SPA, and
+// pkg/sanitize handles descriptions rendered server-side.
 func TestXSSPayloadsInEventFields(t *testing.T) {
 	now := time.Now()
 	payloads := []struct {
@@ -311,8 +312,8 @@ func TestCSVFormulaInjection(t *testing.T) {
 	})
 }
 
-// TestOversizedRequestBodyRejected verifies that request bodies exceeding the
-// 1MB MaxBytesReader limit are rejected for both event and proposal creation.
+// TestOversizedRequestBodyRejected verifies that request bodies over their
+// route's limit are rejected with 413, and hostile JSON shapes with 400.
 func TestOversizedRequestBodyRejected(t *testing.T) {
 	oversized := strings.Repeat("x", 2*1024*1024) // 2MB string
 	now := time.Now()
@@ -326,7 +327,7 @@ func TestOversizedRequestBodyRejected(t *testing.T) {
 			EndDate:     now.AddDate(0, 1, 1).Format(time.RFC3339),
 		}, adminToken)
 		defer resp.Body.Close()
-		assertStatus(t, resp, http.StatusBadRequest)
+		assertStatus(t, resp, http.StatusRequestEntityTooLarge)
 	})
 
 	t.Run("proposal creation", func(t *testing.T) {
@@ -349,6 +350,57 @@ func TestOversizedRequestBodyRejected(t *testing.T) {
 			speakerToken,
 		)
 		defer resp.Body.Close()
+		assertStatus(t, resp, http.StatusRequestEntityTooLarge)
+	})
+
+	p := createTestProposal(speakerToken, eventGopherCon.ID, ProposalInput{
+		Title:    "Body Limits Proposal",
+		Abstract: "Test abstract",
+		Format:   "talk",
+		Duration: 30,
+		Speakers: []Speaker{
+			{Name: "Speaker User", Email: "speaker@test.com", Company: "Acme Inc", JobTitle: "Engineer", LinkedIn: "https://linkedin.com/in/speaker", Primary: true},
+		},
+	})
+
+	t.Run("status change over the small limit", func(t *testing.T) {
+		resp := doPut(fmt.Sprintf("/api/v0/proposals/%d/status", p.ID), map[string]string{
+			"status":  "accepted",
+			"padding": strings.Repeat("x", 8<<10),
+		}, adminToken)
+		defer resp.Body.Close()
+		assertStatus(t, resp, http.StatusRequestEntityTooLarge)
+
+		var result map[string]interface{}
+		parseJSON(resp, &result)
+		if result["code"] != "too_large" {
+			t.Errorf("expected code too_large, got %v", result["code"])
+		}
+	})
+
+	t.Run("deeply nested custom answers", func(t *testing.T) {
+		var nested interface{} = "x"
+		for i := 0; i < 64; i++ {
+			nested = map[string]interface{}{"a": nested}
+		}
+		resp := doPut(fmt.Sprintf("/api/v0/proposals/%d", p.ID), map[string]interface{}{
+			"custom_answers": map[string]interface{}{"q": nested},
+		}, speakerToken)
+		defer resp.Body.Close()
+		assertStatus(t, resp, http.StatusBadRequest)
+
+		var result map[string]interface{}
+		parseJSON(resp, &result)
+		if result["code"] != "invalid_body" {
+			t.Errorf("expected code invalid_body, got %v", result["code"])
+		}
+	})
+
+	t.Run("huge array in proposal update", func(t *testing.T) {
+		resp := doPut(fmt.Sprintf("/api/v0/proposals/%d", p.ID), map[string]interface{}{
+			"tags": make([]int, 5000),
+		}, speakerToken)
+		defer resp.Body.Close()
 		assertStatus(t, resp, http.StatusBadRequest)
 	})
 }
@@ -0,0 +1,110 @@
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestSeriesDuplicates(t *testing.T) {
+	t.Parallel()
+	now := time.Now()
+	series := fmt.Sprintf("series-conf-%d", now.UnixNano())
+	newEdition := func(token, name string, months int) *EventResponse {
+		event := createTestEvent(token, EventInput{
+			Name:       name,
+			Slug:       fmt.Sprintf("%s-%d-%d", series, months, now.UnixNano()),
+			StartDate:  now.AddDate(0, months, 0).Format(time.RFC3339),
+			EndDate:    now.AddDate(0, months, 1).Format(time.RFC3339),
+			CFPOpenAt:  now.AddDate(0, 0, -1).Format(time.RFC3339),
+			CFPCloseAt: now.AddDate(0, 1, 0).Format(time.RFC3339),
+		})
+		updateCFPStatus(token, event.ID, "open")
+		resp := doPut(fmt.Sprintf("/api/v0/events/%d", event.ID), map[string]interface{}{"series": series}, token)
+		assertStatus(t, resp, http.StatusOK)
+		resp.Body.Close()
+		return event
+	}
+	spring := newEdition(adminToken, "Series Conf Spring", 2)
+	autumn := newEdition(adminToken, "Series Conf Autumn", 8)
+	// Another creator naming the same series isn't part of it
+	outsider := newEdition(otherToken, "Lookalike Summit", 3)
+
+	submit := func(token, email, title, abstract string, eventID uint) *ProposalResponse {
+		return createTestProposal(token, eventID, ProposalInput{
+			Title:    title,
+			Abstract: abstract,
+			Format:   "talk",
+			Duration: 30,
+			Level:    "intermediate",
+			Speakers: []Speaker{
+				{Name: "Series Speaker", Email: email, Bio: "Bio", Company: "Acme", JobTitle: "SRE", LinkedIn: "https://linkedin.com/in/series", Primary: true},
+			},
+		})
+	}
+	const title = "Ten Years of Postgres On-Call"
+	const abstract = "What a decade of pages taught us about running Postgres at scale."
+	submit(speakerToken, "speaker@test.com", title, abstract, spring.ID)
+	submit(speakerToken, "speaker@test.com", title+" (updated)", abstract, outsider.ID)
+	submit(otherToken, "other@test.com", title, abstract, spring.ID)
+	duplicate := submit(speakerToken, "speaker@test.com", title, abstract+" Now with more war stories.", autumn.ID)
+	fresh := submit(speakerToken, "speaker@test.com", "Designing Internal Tools", "A design system for the tools nobody sees.", autumn.ID)
+
+	type listed struct {
+		ID              uint `json:"ID"`
+		AlsoSubmittedTo []struct {
+			EventSlug string `json:"event_slug"`
+			EventName string `json:"event_name"`
+			Status    string `json:"status"`
+		} `json:"also_submitted_to"`
+	}
+	list := func(t *testing.T, token string) map[uint]listed {
+		t.Helper()
+		resp := doAuthGet(fmt.Sprintf("/api/v0/events/%d/proposals", autumn.ID), token)
+		assertStatus(t, resp, http.StatusOK)
+		var proposals []listed
+		if err := parseJSON(resp, &proposals); err != nil {
+			t.Fatalf("failed to parse response: %v", err)
+		}
+		byID := make(map[uint]listed)
+		for _, p := range proposals {
+			byID[p.ID] = p
+		}
+		return byID
+	}
+
+	t.Run("off until the event flags duplicates", func(t *testing.T) {
+		if got := list(t, adminToken)[duplicate.ID].AlsoSubmittedTo; len(got) != 0 {
+			t.Errorf("expected no flags, got %+v", got)
+		}
+	})
+
+	resp := doPut(fmt.Sprintf("/api/v0/events/%d", autumn.ID), map[string]interface{}{"flag_series_duplicates": true}, adminToken)
+	assertStatus(t, resp, http.StatusOK)
+	resp.Body.Close()
+
+	t.Run("flags the speaker's talk from another edition", func(t *testing.T) {
+		proposals := list(t, adminToken)
+		got := proposals[duplicate.ID].AlsoSubmittedTo
+		if len(got) != 1 || got[0].EventSlug != spring.Slug || got[0].EventName != spring.Name || got[0].Status != "submitted" {
+			t.Errorf("expected only the spring edition, got %+v", got)
+		}
+		if other := proposals[fresh.ID].AlsoSubmittedTo; len(other) != 0 {
+			t.Errorf("expected a different talk not to be flagged, got %+v", other)
+		}
+	})
+
+	t.Run("speakers don't see the flags", func(t *testing.T) {
+		if got := list(t, speakerToken)[duplicate.ID].AlsoSubmittedTo; len(got) != 0 {
+			t.Errorf("expected no flags for the speaker, got %+v", got)
+		}
+	})
+
+	t.Run("series names follow the slug rules", func(t *testing.T) {
+		fields := validationFields(t, doPut(fmt.Sprintf("/api/v0/events/%d", autumn.ID), map[string]interface{}{"series": "Series Conf!"}, adminToken))
+		if fields["series"] == "" {
+			t.Errorf("expected a series error, got %v", fields)
+		}
+	})
+}
@@ -0,0 +1,165 @@
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/sreday/cfp.ninja/pkg/models"
+)
+
+// SpeakerEmailInput is the body of POST /api/v0/events/{id}/speakers/email
+type SpeakerEmailInput struct {
+	Status  string `json:"status"`
+	Subject string `json:"subject"`
+	Body    string `json:"body"`
+	Confirm bool   `json:"confirm,omitempty"`
+}
+
+func TestEmailSpeakers(t *testing.T) {
+	perDay := testConfig.MaxSpeakerEmailsPerDay
+	testConfig.MaxSpeakerEmailsPerDay = 2
+	t.Cleanup(func() { testConfig.MaxSpeakerEmailsPerDay = perDay })
+
+	now := time.Now()
+	event := createTestEvent(adminToken, EventInput{
+		Name:       "Speaker Email Event",
+		Slug:       fmt.Sprintf("speaker-email-%d", now.UnixNano()),
+		StartDate:  now.AddDate(0, 2, 0).Format(time.RFC3339),
+		EndDate:    now.AddDate(0, 2, 1).Format(time.RFC3339),
+		CFPOpenAt:  now.AddDate(0, 0, -1).Format(time.RFC3339),
+		CFPCloseAt: now.AddDate(0, 1, 0).Format(time.RFC3339),
+	})
+	updateCFPStatus(adminToken, event.ID, "open")
+	resp := doPost(fmt.Sprintf("/api/v0/events/%d/organizers", event.ID), OrganizerInput{Email: "other@test.com"}, adminToken)
+	assertStatus(t, resp, http.StatusCreated)
+	resp.Body.Close()
+
+	proposal := createTestProposal(speakerToken, event.ID, ProposalInput{
+		Title:    "AV Check Talk",
+		Abstract: "A talk that needs a projector.",
+		Format:   "talk",
+		Duration: 30,
+		Level:    "intermediate",
+		Speakers: []Speaker{
+			{Name: "Speaker User", Email: "speaker@test.com", Bio: "Bio", Company: "Acme", JobTitle: "Dev", LinkedIn: "https://linkedin.com/in/speaker", Primary: true},
+			{Name: "Co Speaker", Email: "co@test.com", Bio: "Bio", Company: "Acme", JobTitle: "Dev", LinkedIn: "https://linkedin.com/in/co"},
+		},
+	})
+	updateProposalStatus(adminToken, proposal.ID, "accepted")
+
+	path := fmt.Sprintf("/api/v0/events/%d/speakers/email", event.ID)
+	valid := SpeakerEmailInput{
+		Status:  "accepted",
+		Subject: "{{event_name}}: AV instructions",
+		Body:    "Hi {{speaker_name}}, please bring slides for {{talk_title}}.",
+	}
+
+	t.Run("creator only", func(t *testing.T) {
+		resp := doPost(path, valid, otherToken)
+		assertStatus(t, resp, http.StatusForbidden)
+		resp.Body.Close()
+
+		resp = doPost(path, valid, "")
+		assertStatus(t, resp, http.StatusUnauthorized)
+		resp.Body.Close()
+	})
+
+	t.Run("validation", func(t *testing.T) {
+		for name, input := range map[string]SpeakerEmailInput{
+			"unknown variable": {Status: "accepted", Subject: "Hi", Body: "See you at {{venue}}"},
+			"bad status":       {Status: "withdrawn", Subject: "Hi", Body: "Hello"},
+			"missing subject":  {Status: "accepted", Body: "Hello"},
+			"no recipients":    {Status: "tentative", Subject: "Hi", Body: "Hello"},
+		} {
+			resp := doPost(path, input, adminToken)
+			if resp.StatusCode != http.StatusBadRequest {
+				t.Errorf("%s: expected 400, got %d", name, resp.StatusCode)
+			}
+			resp.Body.Close()
+		}
+	})
+
+	t.Run("sends and records", func(t *testing.T) {
+		resp := doPost(path, valid, adminToken)
+		assertStatus(t, resp, http.StatusAccepted)
+		var send struct {
+			ID         uint `json:"id"`
+			Recipients int  `json:"recipients"`
+		}
+		if err := parseJSON(resp, &send); err != nil {
+			t.Fatalf("failed to parse response: %v", err)
+		}
+		if send.Recipients != 2 {
+			t.Errorf("expected 2 recipients, got %d", send.Recipients)
+		}
+
+		var record models.SpeakerEmailSend
+		if err := testConfig.DB.First(&record, send.ID).Error; err != nil {
+			t.Fatalf("expected send to be recorded: %v", err)
+		}
+		if record.EventID != event.ID || record.Subject != valid.Subject || record.SenderID == nil {
+			t.Errorf("unexpected record %+v", record)
+		}
+	})
+
+	t.Run("daily cap", func(t *testing.T) {
+		resp := doPost(path, valid, adminToken)
+		assertStatus(t, resp, http.StatusAccepted)
+		resp.Body.Close()
+
+		resp = doPost(path, valid, adminToken)
+		assertStatus(t, resp, http.StatusTooManyRequests)
+		resp.Body.Close()
+
+		// The cap is a rolling 24 hours
+		freezeClock(t, now.Add(25*time.Hour))
+		resp = doPost(path, valid, adminToken)
+		assertStatus(t, resp, http.StatusAccepted)
+		resp.Body.Close()
+	})
+}
+
+func TestEmailSpeakers_ConfirmLargeSend(t *testing.T) {
+	t.Parallel()
+	now := time.Now()
+	event := createTestEvent(adminToken, EventInput{
+		Name:      "Large Speaker Email Event",
+		Slug:      fmt.Sprintf("speaker-email-large-%d", now.UnixNano()),
+		StartDate: now.AddDate(0, 2, 0).Format(time.RFC3339),
+		EndDate:   now.AddDate(0, 2, 1).Format(time.RFC3339),
+	})
+
+	// Seed directly: the API would hit the per-speaker proposal limit
+	proposals := make([]models.Proposal, 51)
+	for i := range proposals {
+		proposals[i] = models.Proposal{EventID: event.ID, Title: fmt.Sprintf("Talk %d", i), Status: models.ProposalStatusAccepted}
+		if err := proposals[i].SetSpeakers([]models.Speaker{{Name: "Speaker", Email: fmt.Sprintf("bulk-%d@test.com", i), Primary: true}}); err != nil {
+			t.Fatalf("failed to set speakers: %v", err)
+		}
+	}
+	if err := testConfig.DB.Create(&proposals).Error; err != nil {
+		t.Fatalf("failed to seed proposals: %v", err)
+	}
+
+	path := fmt.Sprintf("/api/v0/events/%d/speakers/email", event.ID)
+	input := SpeakerEmailInput{Status: "accepted", Subject: "Schedule", Body: "The schedule is out."}
+
+	resp := doPost(path, input, adminToken)
+	assertStatus(t, resp, http.StatusBadRequest)
+	var result struct {
+		Code string `json:"code"`
+	}
+	if err := parseJSON(resp, &result); err != nil {
+		t.Fatalf("failed to parse response: %v", err)
+	}
+	if result.Code != "confirmation_required" {
+		t.Errorf("expected code confirmation_required, got %q", result.Code)
+	}
+
+	input.Confirm = true
+	resp = doPost(path, input, adminToken)
+	assertStatus(t, resp, http.StatusAccepted)
+	resp.Body.Close()
+}
@@ -0,0 +1,134 @@
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestSpeakerHistory(t *testing.T) {
+	t.Parallel()
+	now := time.Now().UTC()
+	newEvent := func(token, name string, months int) *EventResponse {
+		event := createTestEvent(token, EventInput{
+			Name:       name,
+			Slug:       fmt.Sprintf("history-%d-%d", months, now.UnixNano()),
+			StartDate:  now.AddDate(0, months, 0).Format(time.RFC3339),
+			EndDate:    now.AddDate(0, months, 1).Format(time.RFC3339),
+			CFPOpenAt:  now.AddDate(0, 0, -1).Format(time.RFC3339),
+			CFPCloseAt: now.AddDate(0, 1, 0).Format(time.RFC3339),
+		})
+		updateCFPStatus(token, event.ID, "open")
+		return event
+	}
+	current := newEvent(adminToken, "History Conf 2026", 6)
+	earlier := newEvent(adminToken, "History Conf 2025", 2)
+	// Run by someone else, with the admin as co-organizer
+	coOrganized := newEvent(otherToken, "History Meetup", 3)
+	resp := doPost(fmt.Sprintf("/api/v0/events/%d/organizers", coOrganized.ID), OrganizerInput{Email: "admin@test.com"}, otherToken)
+	assertStatus(t, resp, http.StatusCreated)
+	resp.Body.Close()
+	// Run by someone else without the admin
+	elsewhere := newEvent(otherToken, "Unrelated Summit", 4)
+
+	speaker := Speaker{Name: "Speaker User", Email: "speaker@test.com", Company: "Acme Inc", JobTitle: "Engineer", LinkedIn: "https://linkedin.com/in/speaker", Primary: true}
+	submit := func(token string, eventID uint, title string, speakers ...Speaker) *ProposalResponse {
+		return createTestProposal(token, eventID, ProposalInput{
+			Title:    title,
+			Abstract: "A talk worth remembering.",
+			Format:   "talk",
+			Duration: 30,
+			Level:    "intermediate",
+			Speakers: speakers,
+		})
+	}
+	reviewed := submit(speakerToken, current.ID, "This Year's Talk", speaker)
+	great := submit(speakerToken, earlier.ID, "Last Year's Talk", speaker)
+	updateProposalStatus(adminToken, great.ID, "accepted")
+	resp = doPut(fmt.Sprintf("/api/v0/proposals/%d/rating", great.ID), ProposalRatingInput{Rating: 5}, adminToken)
+	assertStatus(t, resp, http.StatusOK)
+	resp.Body.Close()
+	// Submitted from another account, found by the speaker's email
+	coSpeaking := submit(otherToken, coOrganized.ID, "Meetup Panel",
+		Speaker{Name: "Other User", Email: "other@test.com", Company: "Acme Inc", JobTitle: "Engineer", LinkedIn: "https://linkedin.com/in/other", Primary: true},
+		Speaker{Name: "Speaker User", Email: "Speaker@Test.com", Company: "Acme Inc", JobTitle: "Engineer", LinkedIn: "https://linkedin.com/in/speaker"})
+	hidden := submit(speakerToken, elsewhere.ID, "Talk The Admin Doesn't Run", speaker)
+	newcomerEmail := fmt.Sprintf("history-newcomer-%d@test.com", now.UnixNano())
+	_, newcomerToken := createTestUserWithJWT(newcomerEmail, "Newcomer")
+	unrelated := submit(newcomerToken, earlier.ID, "Someone Else's Talk",
+		Speaker{Name: "Newcomer", Email: newcomerEmail, Company: "Acme Inc", JobTitle: "Engineer", LinkedIn: "https://linkedin.com/in/newcomer", Primary: true})
+
+	type entry struct {
+		ProposalID uint   `json:"proposal_id"`
+		Title      string `json:"title"`
+		Status     string `json:"status"`
+		Rating     *int   `json:"rating"`
+		EventID    uint   `json:"event_id"`
+		EventName  string `json:"event_name"`
+	}
+	history := func(t *testing.T, token string, id uint) *[]entry {
+		t.Helper()
+		resp := doAuthGet(fmt.Sprintf("/api/v0/proposals/%d?expand=speaker_history", id), token)
+		assertStatus(t, resp, http.StatusOK)
+		var got struct {
+			SpeakerHistory *[]entry `json:"speaker_history"`
+		}
+		if err := parseJSON(resp, &got); err != nil {
+			t.Fatalf("failed to parse response: %v", err)
+		}
+		return got.SpeakerHistory
+	}
+
+	t.Run("lists the speaker's proposals on the organizer's events", func(t *testing.T) {
+		got := history(t, adminToken, reviewed.ID)
+		if got == nil || len(*got) != 2 {
+			t.Fatalf("expected two earlier proposals, got %+v", got)
+		}
+		// Newest event first
+		meetup, last := (*got)[0], (*got)[1]
+		if meetup.ProposalID != coSpeaking.ID || meetup.EventName != coOrganized.Name {
+			t.Errorf("expected the co-organized meetup first, got %+v", meetup)
+		}
+		if last.ProposalID != great.ID || last.Status != "accepted" || last.Rating == nil || *last.Rating != 5 || last.EventID != earlier.ID {
+			t.Errorf("expected last year's accepted talk with its rating, got %+v", last)
+		}
+	})
+
+	t.Run("never shows events the organizer doesn't run", func(t *testing.T) {
+		for _, e := range *history(t, adminToken, reviewed.ID) {
+			if e.ProposalID == hidden.ID || e.EventID == elsewhere.ID {
+				t.Errorf("expected %q to stay hidden, got %+v", elsewhere.Name, e)
+			}
+			if e.ProposalID == unrelated.ID {
+				t.Errorf("expected other speakers' proposals to be left out, got %+v", e)
+			}
+		}
+
+		// The other organizer runs the meetup and the summit, but not the conference
+		got := history(t, otherToken, coSpeaking.ID)
+		if got == nil || len(*got) != 1 || (*got)[0].ProposalID != hidden.ID {
+			t.Errorf("expected only the summit talk, got %+v", got)
+		}
+	})
+
+	t.Run("empty when there is no history", func(t *testing.T) {
+		got := history(t, adminToken, unrelated.ID)
+		if got == nil || len(*got) != 0 {
+			t.Errorf("expected an empty history, got %+v", got)
+		}
+	})
+
+	t.Run("speakers don't get the expansion", func(t *testing.T) {
+		if got := history(t, speakerToken, reviewed.ID); got != nil {
+			t.Errorf("expected no history for the speaker, got %+v", got)
+		}
+	})
+
+	t.Run("unknown expansions are refused", func(t *testing.T) {
+		fields := validationFields(t, doAuthGet(fmt.Sprintf("/api/v0/proposals/%d?expand=organizers_public", reviewed.ID), adminToken))
+		if fields["expand"] == "" {
+			t.Errorf("expected an expand error, got %v", fields)
+		}
+	})
+}
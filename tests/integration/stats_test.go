@@ -1,51 +1,49 @@
 package integration
 
 import (
+	"fmt"
 	"net/http"
+	"slices"
 	"testing"
+	"time"
 )
 
+// TestGetStats counts every listed event, so it runs serially and compares
+// the stats before and after creating events of its own
 func TestGetStats(t *testing.T) {
-	resp := doGet("/api/v0/stats")
-	assertStatus(t, resp, http.StatusOK)
+	before := getStats(t, "/api/v0/stats?fresh=true", adminToken)
 
-	var stats StatsResponse
-	if err := parseJSON(resp, &stats); err != nil {
-		t.Fatalf("failed to parse response: %v", err)
-	}
+	f := newFixtures(t)
+	// No other test lists events in Tuvalu
+	f.event(adminToken, EventInput{Location: f.unique("open"), Country: "TV"})
+	closed := f.draftEvent(adminToken, EventInput{Location: f.unique("closed"), Country: "TV"})
+	f.cfpStatus(adminToken, closed.ID, "closed")
+	// Draft events are counted, but neither as open nor closed
+	f.draftEvent(adminToken, EventInput{Location: f.unique("draft")})
 
-	// We created 5 events in fixtures (draft events are counted)
-	if stats.TotalEvents < 5 {
-		t.Errorf("expected at least 5 events, got %d", stats.TotalEvents)
+	stats := getStats(t, "/api/v0/stats?fresh=true", adminToken)
+	if stats.TotalEvents != before.TotalEvents+3 {
+		t.Errorf("expected %d events, got %d", before.TotalEvents+3, stats.TotalEvents)
 	}
-
-	// We created 3 events with open CFP (GopherCon, DevOpsCon, PyCon)
-	if stats.CFPOpen < 3 {
-		t.Errorf("expected at least 3 open CFPs, got %d", stats.CFPOpen)
+	if stats.CFPOpen != before.CFPOpen+1 {
+		t.Errorf("expected %d open CFPs, got %d", before.CFPOpen+1, stats.CFPOpen)
 	}
-
-	// At least 1 closed CFP (Past Conference)
-	if stats.CFPClosed < 1 {
-		t.Errorf("expected at least 1 closed CFP, got %d", stats.CFPClosed)
+	if stats.CFPClosed != before.CFPClosed+1 {
+		t.Errorf("expected %d closed CFPs, got %d", before.CFPClosed+1, stats.CFPClosed)
 	}
-
-	// Fixtures create events in US, DE, GB
-	if stats.UniqueCountries < 3 {
-		t.Errorf("expected at least 3 unique countries, got %d", stats.UniqueCountries)
+	if stats.UniqueCountries != before.UniqueCountries+1 {
+		t.Errorf("expected %d unique countries, got %d", before.UniqueCountries+1, stats.UniqueCountries)
 	}
-
-	// Fixtures create events in Denver, Berlin, Cardiff, San Francisco, New York
-	if stats.UniqueLocations < 5 {
-		t.Errorf("expected at least 5 unique locations, got %d", stats.UniqueLocations)
+	if stats.UniqueLocations != before.UniqueLocations+3 {
+		t.Errorf("expected %d unique locations, got %d", before.UniqueLocations+3, stats.UniqueLocations)
 	}
-
-	// Tags should be a non-nil slice
-	if stats.UniqueTags == nil {
-		t.Error("expected unique_tags to be non-nil")
+	if !slices.Contains(stats.UniqueTags, f.tag) {
+		t.Errorf("expected the fixtures' tag %s, got %v", f.tag, stats.UniqueTags)
 	}
 }
 
 func TestGetStats_ResponseShape(t *testing.T) {
+	t.Parallel()
 	resp := doGet("/api/v0/stats")
 	assertStatus(t, resp, http.StatusOK)
 
@@ -63,8 +61,58 @@ func TestGetStats_ResponseShape(t *testing.T) {
 	}
 }
 
+func getStats(t *testing.T, path, token string) StatsResponse {
+	t.Helper()
+	resp := doAuthGet(path, token)
+	assertStatus(t, resp, http.StatusOK)
+	var stats StatsResponse
+	if err := parseJSON(resp, &stats); err != nil {
+		t.Fatalf("failed to parse response: %v", err)
+	}
+	return stats
+}
+
+// TestGetStats_CacheInvalidatedByEventWrites counts every listed event, so
+// it runs serially
+func TestGetStats_CacheInvalidatedByEventWrites(t *testing.T) {
+	before := getStats(t, "/api/v0/stats", "")
+
+	now := time.Now()
+	createTestEvent(adminToken, EventInput{
+		Name:      "Stats Cache Event",
+		Slug:      fmt.Sprintf("stats-cache-%d", now.UnixNano()),
+		StartDate: now.AddDate(0, 2, 0).Format(time.RFC3339),
+		EndDate:   now.AddDate(0, 2, 1).Format(time.RFC3339),
+		Tags:      " zz-stats-cache , sre",
+	})
+
+	after := getStats(t, "/api/v0/stats", "")
+	if after.TotalEvents != before.TotalEvents+1 {
+		t.Errorf("expected the new event to be counted, got %d then %d", before.TotalEvents, after.TotalEvents)
+	}
+	if !slices.Contains(after.UniqueTags, "zz-stats-cache") {
+		t.Errorf("expected the trimmed new tag, got %v", after.UniqueTags)
+	}
+	if !slices.IsSorted(after.UniqueTags) {
+		t.Errorf("expected sorted tags, got %v", after.UniqueTags)
+	}
+
+	// Anyone may ask for fresh stats, but only admins skip the cache
+	for _, token := range []string{"", otherToken, adminToken} {
+		if got := getStats(t, "/api/v0/stats?fresh=true", token); got.TotalEvents != after.TotalEvents {
+			t.Errorf("expected %d events, got %d", after.TotalEvents, got.TotalEvents)
+		}
+	}
+}
+
 func TestGetCountries(t *testing.T) {
-	resp := doGet("/api/v0/countries")
+	t.Parallel()
+	f := newFixtures(t)
+	// No other test lists events in Nauru
+	f.event(adminToken, EventInput{Country: "NR"})
+	f.draftEvent(adminToken, EventInput{Country: "NR"})
+
+	resp := doAuthGet("/api/v0/countries?fresh=true", adminToken)
 	assertStatus(t, resp, http.StatusOK)
 
 	var countries CountriesResponse
@@ -72,22 +120,62 @@ func TestGetCountries(t *testing.T) {
 		t.Fatalf("failed to parse response: %v", err)
 	}
 
-	// We created events in US, DE, GB
-	if len(countries) < 3 {
-		t.Errorf("expected at least 3 countries, got %d", len(countries))
-	}
-
-	// Check that expected countries are present
-	expectedCountries := map[string]bool{"US": true, "DE": true, "GB": true}
+	// Check that the seeded countries and ours are present and named; drafts
+	// are counted too
+	expectedCountries := map[string]string{"US": "United States", "DE": "Germany", "GB": "United Kingdom", "NR": "Nauru"}
 	for _, country := range countries {
-		delete(expectedCountries, country)
+		if name, ok := expectedCountries[country.Code]; ok {
+			if country.Name != name {
+				t.Errorf("expected %s to be named %q, got %q", country.Code, name, country.Name)
+			}
+			if country.Code == "NR" && country.Count != 2 {
+				t.Errorf("expected NR to have 2 events, got %d", country.Count)
+			}
+			delete(expectedCountries, country.Code)
+		}
 	}
 	if len(expectedCountries) > 0 {
 		t.Errorf("missing expected countries: %v", expectedCountries)
 	}
+
+	// Sorted by name
+	for i := 1; i < len(countries); i++ {
+		if countries[i-1].Name > countries[i].Name {
+			t.Errorf("expected countries sorted by name, got %q before %q", countries[i-1].Name, countries[i].Name)
+		}
+	}
+}
+
+func TestGetCountries_All(t *testing.T) {
+	t.Parallel()
+	// No other test lists events in Palau
+	newFixtures(t).event(adminToken, EventInput{Country: "PW"})
+
+	resp := doAuthGet("/api/v0/countries?all=true&fresh=true", adminToken)
+	assertStatus(t, resp, http.StatusOK)
+
+	var countries CountriesResponse
+	if err := parseJSON(resp, &countries); err != nil {
+		t.Fatalf("failed to parse response: %v", err)
+	}
+
+	if len(countries) != 249 {
+		t.Errorf("expected all 249 ISO 3166-1 countries, got %d", len(countries))
+	}
+	counts := make(map[string]int)
+	for _, c := range countries {
+		counts[c.Code] = c.Count
+	}
+	if counts["PW"] != 1 {
+		t.Errorf("expected PW to have 1 event, got %d", counts["PW"])
+	}
+	if count, ok := counts["AQ"]; !ok || count != 0 {
+		t.Errorf("expected AQ listed with 0 events, got %d (listed: %v)", count, ok)
+	}
 }
 
 func TestStatsNoAuth(t *testing.T) {
+	t.Parallel()
 	// Stats should be accessible without authentication
 	resp := doGet("/api/v0/stats")
 	if resp.StatusCode == http.StatusUnauthorized {
@@ -97,6 +185,7 @@ func TestStatsNoAuth(t *testing.T) {
 }
 
 func TestCountriesNoAuth(t *testing.T) {
+	t.Parallel()
 	// Countries should be accessible without authentication
 	resp := doGet("/api/v0/countries")
 	if resp.StatusCode == http.StatusUnauthorized {
@@ -106,6 +195,7 @@ func TestCountriesNoAuth(t *testing.T) {
 }
 
 func TestHealthCheck(t *testing.T) {
+	t.Parallel()
 	resp := doGet("/api/v0/health")
 	assertStatus(t, resp, http.StatusOK)
 
@@ -120,6 +210,7 @@ func TestHealthCheck(t *testing.T) {
 }
 
 func TestHealthCheck_NoAuth(t *testing.T) {
+	t.Parallel()
 	resp := doGet("/api/v0/health")
 	if resp.StatusCode == http.StatusUnauthorized {
 		t.Error("health endpoint should be public")
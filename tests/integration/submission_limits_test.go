@@ -0,0 +1,114 @@
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// limitsTestEvent creates an event with an open CFP for abuse limit tests
+func limitsTestEvent(t *testing.T, name string) *EventResponse {
+	t.Helper()
+	now := time.Now()
+	event := createTestEvent(adminToken, EventInput{
+		Name:       name,
+		Slug:       fmt.Sprintf("limits-%d", now.UnixNano()),
+		StartDate:  now.AddDate(0, 2, 0).Format(time.RFC3339),
+		EndDate:    now.AddDate(0, 2, 1).Format(time.RFC3339),
+		CFPOpenAt:  now.AddDate(0, 0, -1).Format(time.RFC3339),
+		CFPCloseAt: now.AddDate(0, 1, 0).Format(time.RFC3339),
+	})
+	updateCFPStatus(adminToken, event.ID, "open")
+	return event
+}
+
+func submitLimitsProposal(eventID uint, email, token string) *http.Response {
+	return doPost(fmt.Sprintf("/api/v0/events/%d/proposals", eventID), ProposalInput{
+		Title:    "Limits Talk",
+		Abstract: "Checking the abuse limits.",
+		Format:   "talk",
+		Duration: 30,
+		Level:    "intermediate",
+		Speakers: []Speaker{
+			{Name: "Limits User", Email: email, Bio: "Bio", Company: "Acme", JobTitle: "Dev", LinkedIn: "https://linkedin.com/in/limits"},
+		},
+	}, token)
+}
+
+func TestCreateProposal_HourlyLimit(t *testing.T) {
+	perHour, admins := testConfig.MaxProposalsPerHour, testConfig.AdminEmails
+	testConfig.MaxProposalsPerHour = 2
+	testConfig.AdminEmails = []string{"admin@test.com"}
+	t.Cleanup(func() {
+		testConfig.MaxProposalsPerHour = perHour
+		testConfig.AdminEmails = admins
+	})
+
+	email := fmt.Sprintf("hourly-%d@test.com", time.Now().UnixNano())
+	user, token := createTestUserWithJWT(email, "Hourly User")
+
+	for i := 0; i < 2; i++ {
+		resp := submitLimitsProposal(limitsTestEvent(t, "Hourly Event").ID, email, token)
+		assertStatus(t, resp, http.StatusCreated)
+		resp.Body.Close()
+	}
+
+	blocked := limitsTestEvent(t, "Hourly Blocked Event")
+	resp := submitLimitsProposal(blocked.ID, email, token)
+	assertStatus(t, resp, http.StatusTooManyRequests)
+	resp.Body.Close()
+
+	t.Run("non-admin cannot trust", func(t *testing.T) {
+		resp := doPut(fmt.Sprintf("/api/v0/admin/users/%d/trusted", user.ID), map[string]bool{"trusted": true}, speakerToken)
+		assertStatus(t, resp, http.StatusForbidden)
+		resp.Body.Close()
+	})
+
+	t.Run("trusted user bypasses limit", func(t *testing.T) {
+		resp := doPut(fmt.Sprintf("/api/v0/admin/users/%d/trusted", user.ID), map[string]bool{"trusted": true}, adminToken)
+		assertStatus(t, resp, http.StatusOK)
+		var result struct {
+			IsTrusted bool `json:"is_trusted"`
+		}
+		if err := parseJSON(resp, &result); err != nil {
+			t.Fatalf("failed to parse response: %v", err)
+		}
+		if !result.IsTrusted {
+			t.Error("expected is_trusted true")
+		}
+
+		resp = submitLimitsProposal(blocked.ID, email, token)
+		assertStatus(t, resp, http.StatusCreated)
+		resp.Body.Close()
+	})
+}
+
+func TestCreateProposal_NewAccountCooldown(t *testing.T) {
+	cooldown := testConfig.NewAccountCooldown
+	testConfig.NewAccountCooldown = 24 * time.Hour
+	t.Cleanup(func() { testConfig.NewAccountCooldown = cooldown })
+
+	email := fmt.Sprintf("newbie-%d@test.com", time.Now().UnixNano())
+	_, token := createTestUserWithJWT(email, "New User")
+	event := limitsTestEvent(t, "Cooldown Event")
+
+	resp := submitLimitsProposal(event.ID, email, token)
+	assertStatus(t, resp, http.StatusCreated)
+	resp.Body.Close()
+
+	resp = submitLimitsProposal(event.ID, email, token)
+	assertStatus(t, resp, http.StatusTooManyRequests)
+	resp.Body.Close()
+
+	// Other events still accept the account's first submission
+	resp = submitLimitsProposal(limitsTestEvent(t, "Cooldown Other Event").ID, email, token)
+	assertStatus(t, resp, http.StatusCreated)
+	resp.Body.Close()
+
+	// Once the account is old enough the per-event limit applies as usual
+	freezeClock(t, time.Now().Add(25*time.Hour))
+	resp = submitLimitsProposal(event.ID, email, token)
+	assertStatus(t, resp, http.StatusCreated)
+	resp.Body.Close()
+}
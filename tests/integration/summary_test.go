@@ -0,0 +1,93 @@
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEventSummary(t *testing.T) {
+	t.Parallel()
+	now := time.Now()
+	event := createTestEvent(adminToken, EventInput{
+		Name:              "Summary Conf",
+		Slug:              fmt.Sprintf("summary-%d", now.UnixNano()),
+		Description:       "# Summary Conf\n\nA **one day** conference.\n\nTickets on sale soon.",
+		DescriptionFormat: "markdown",
+		StartDate:         now.AddDate(0, 2, 0).Format(time.RFC3339),
+		EndDate:           now.AddDate(0, 2, 1).Format(time.RFC3339),
+	})
+	updateCFPStatus(adminToken, event.ID, "open")
+
+	findListed := func(t *testing.T) (EventResponse, bool) {
+		t.Helper()
+		resp := doGet("/api/v0/events?q=" + event.Slug)
+		assertStatus(t, resp, http.StatusOK)
+		var list EventListResponse
+		if err := parseJSON(resp, &list); err != nil {
+			t.Fatalf("failed to parse response: %v", err)
+		}
+		for _, e := range list.Data {
+			if e.ID == event.ID {
+				return e, true
+			}
+		}
+		return EventResponse{}, false
+	}
+
+	t.Run("derived from the description", func(t *testing.T) {
+		if event.Summary != "A one day conference." {
+			t.Errorf("expected a derived summary, got %q", event.Summary)
+		}
+	})
+
+	t.Run("listings carry only the summary", func(t *testing.T) {
+		e, ok := findListed(t)
+		if !ok {
+			t.Fatal("expected the event in the listing")
+		}
+		if e.Summary != "A one day conference." || e.Description != "" || e.DescriptionHTML != "" {
+			t.Errorf("expected only the summary, got summary %q, description %q", e.Summary, e.Description)
+		}
+
+		resp := doGet(fmt.Sprintf("/api/v0/events/%d", event.ID))
+		assertStatus(t, resp, http.StatusOK)
+		var detail EventResponse
+		if err := parseJSON(resp, &detail); err != nil {
+			t.Fatalf("failed to parse response: %v", err)
+		}
+		if detail.Description == "" || detail.Summary == "" {
+			t.Errorf("expected the description and summary, got %+v", detail)
+		}
+	})
+
+	t.Run("written by organizers", func(t *testing.T) {
+		resp := doPut(fmt.Sprintf("/api/v0/events/%d", event.ID), map[string]string{"summary": "  Our own words.  "}, adminToken)
+		assertStatus(t, resp, http.StatusOK)
+		resp.Body.Close()
+
+		resp = doPut(fmt.Sprintf("/api/v0/events/%d", event.ID), map[string]string{"description": "Rewritten."}, adminToken)
+		assertStatus(t, resp, http.StatusOK)
+		resp.Body.Close()
+
+		if e, _ := findListed(t); e.Summary != "Our own words." {
+			t.Errorf("expected the written summary to be kept, got %q", e.Summary)
+		}
+
+		resp = doPut(fmt.Sprintf("/api/v0/events/%d", event.ID), map[string]string{"summary": ""}, adminToken)
+		assertStatus(t, resp, http.StatusOK)
+		resp.Body.Close()
+		if e, _ := findListed(t); e.Summary != "Rewritten." {
+			t.Errorf("expected the summary derived again, got %q", e.Summary)
+		}
+	})
+
+	t.Run("at most 300 characters", func(t *testing.T) {
+		resp := doPut(fmt.Sprintf("/api/v0/events/%d", event.ID), map[string]string{"summary": strings.Repeat("a", 301)}, adminToken)
+		if fields := validationFields(t, resp); fields["summary"] == "" {
+			t.Errorf("expected a summary error, got %v", fields)
+		}
+	})
+}
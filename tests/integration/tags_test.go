@@ -0,0 +1,97 @@
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEventTags(t *testing.T) {
+	t.Parallel()
+	now := time.Now()
+	tag := fmt.Sprintf("zz-tags-%d", now.UnixNano())
+	event := createTestEvent(adminToken, EventInput{
+		Name:      "Tagged Conf",
+		Slug:      fmt.Sprintf("tagged-%d", now.UnixNano()),
+		StartDate: now.AddDate(0, 2, 0).Format(time.RFC3339),
+		EndDate:   now.AddDate(0, 2, 1).Format(time.RFC3339),
+		Tags:      fmt.Sprintf(" %s Native , k8s,%s  NATIVE,", strings.ToUpper(tag), tag),
+	})
+	updateCFPStatus(adminToken, event.ID, "open")
+	normalized := tag + " native"
+
+	t.Run("normalized on save", func(t *testing.T) {
+		if want := normalized + ",k8s"; event.Tags != want {
+			t.Errorf("expected tags %q, got %q", want, event.Tags)
+		}
+
+		resp := doPut(fmt.Sprintf("/api/v0/events/%d", event.ID), map[string]string{"tags": "K8S, " + strings.ToUpper(normalized)}, adminToken)
+		assertStatus(t, resp, http.StatusOK)
+		var updated EventResponse
+		if err := parseJSON(resp, &updated); err != nil {
+			t.Fatalf("failed to parse response: %v", err)
+		}
+		if want := "k8s," + normalized; updated.Tags != want {
+			t.Errorf("expected tags %q, got %q", want, updated.Tags)
+		}
+	})
+
+	t.Run("at most 20 tags", func(t *testing.T) {
+		tags := make([]string, 21)
+		for i := range tags {
+			tags[i] = fmt.Sprintf("tag%d", i)
+		}
+		resp := doPut(fmt.Sprintf("/api/v0/events/%d", event.ID), map[string]string{"tags": strings.Join(tags, ",")}, adminToken)
+		if fields := validationFields(t, resp); fields["tags"] == "" {
+			t.Errorf("expected a tags error, got %v", fields)
+		}
+	})
+
+	t.Run("filter matches whole tags", func(t *testing.T) {
+		for query, want := range map[string]int{
+			strings.ToUpper(normalized): 1,
+			normalized:                  1,
+			tag:                         0,
+		} {
+			resp := doGet("/api/v0/events?tag=" + strings.ReplaceAll(query, " ", "+"))
+			assertStatus(t, resp, http.StatusOK)
+			var list EventListResponse
+			if err := parseJSON(resp, &list); err != nil {
+				t.Fatalf("failed to parse response: %v", err)
+			}
+			if len(list.Data) != want {
+				t.Errorf("expected %d events for tag %q, got %d", want, query, len(list.Data))
+			}
+		}
+	})
+
+	t.Run("suggestions", func(t *testing.T) {
+		resp := doGet("/api/v0/tags/suggest?q=" + strings.ToUpper(tag[:len(tag)-3]))
+		assertStatus(t, resp, http.StatusOK)
+		var suggestions []struct {
+			Tag    string `json:"tag"`
+			Events int    `json:"events"`
+		}
+		if err := parseJSON(resp, &suggestions); err != nil {
+			t.Fatalf("failed to parse response: %v", err)
+		}
+		found := false
+		for _, s := range suggestions {
+			if !strings.HasPrefix(s.Tag, "zz-tags-") {
+				t.Errorf("expected only matching tags, got %q", s.Tag)
+			}
+			if s.Tag == normalized && s.Events == 1 {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("expected %q used by one event, got %+v", normalized, suggestions)
+		}
+
+		resp = doGet("/api/v0/tags/suggest?limit=0")
+		assertStatus(t, resp, http.StatusBadRequest)
+		resp.Body.Close()
+	})
+}
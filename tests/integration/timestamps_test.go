@@ -0,0 +1,49 @@
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// TestTimestampsUTC checks timestamps read back from the database are
+// serialized in UTC
+func TestTimestampsUTC(t *testing.T) {
+	t.Parallel()
+	now := time.Now()
+	event := createTestEvent(adminToken, EventInput{
+		Name:      "Timestamp Conf",
+		Slug:      fmt.Sprintf("timestamps-%d", now.UnixNano()),
+		StartDate: now.AddDate(0, 2, 0).Format(time.RFC3339),
+		EndDate:   now.AddDate(0, 2, 1).Format(time.RFC3339),
+	})
+
+	resp := doAuthGet("/api/v0/me/events?q="+event.Slug, adminToken)
+	assertStatus(t, resp, http.StatusOK)
+	var mine struct {
+		Managing []struct {
+			CreatedAt string `json:"created_at"`
+			UpdatedAt string `json:"updated_at"`
+		} `json:"managing"`
+	}
+	if err := parseJSON(resp, &mine); err != nil {
+		t.Fatalf("failed to parse response: %v", err)
+	}
+	if len(mine.Managing) != 1 {
+		t.Fatalf("expected the event, got %+v", mine)
+	}
+	for _, ts := range []string{mine.Managing[0].CreatedAt, mine.Managing[0].UpdatedAt} {
+		parsed, err := time.Parse(time.RFC3339, ts)
+		if err != nil {
+			t.Errorf("expected an RFC 3339 time, got %q", ts)
+			continue
+		}
+		if parsed.Location() != time.UTC {
+			t.Errorf("expected a UTC time, got %q", ts)
+		}
+		if d := time.Since(parsed); d < 0 || d > time.Minute {
+			t.Errorf("expected a time just now, got %q", ts)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package integration
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestGetVersion_ReturnsServerVersion(t *testing.T) {
+	t.Parallel()
+	resp := doGet("/api/v0/version")
+	assertStatus(t, resp, http.StatusOK)
+
+	var v struct {
+		Version       string `json:"version"`
+		MinCLIVersion string `json:"min_cli_version"`
+	}
+	if err := parseJSON(resp, &v); err != nil {
+		t.Fatalf("failed to parse version response: %v", err)
+	}
+
+	if v.Version == "" {
+		t.Error("expected version to be set")
+	}
+}
+
+func TestGetVersion_MethodNotAllowed(t *testing.T) {
+	t.Parallel()
+	resp := doPost("/api/v0/version", nil, "")
+	if resp.StatusCode != http.StatusMethodNotAllowed {
+		t.Errorf("expected 405 for POST, got %d", resp.StatusCode)
+	}
+	resp.Body.Close()
+}